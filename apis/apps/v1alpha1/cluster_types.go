@@ -23,6 +23,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
@@ -96,8 +97,13 @@ type ClusterSpec struct {
 	//   removing all persistent data.
 	// - `WipeOut`: An aggressive policy that deletes all Cluster resources, including volume snapshots and
 	//   backups in external storage.
+	//   The removal of the backup artifacts is verified and retried, and the final result is reported
+	//   in an event of the Cluster.
 	//   This results in complete data removal and should be used cautiously, primarily in non-production environments
 	//   to avoid irreversible data loss.
+	// - `Retain`: Extends the `Delete` policy but retains the backups, and archives the Cluster spec along with
+	//   the pointer to the final backup in a ConfigMap named "<cluster>-archive-<uid>", which can be used to recreate
+	//   the Cluster later.
 	//
 	// Warning: Choosing an inappropriate termination policy can result in data loss.
 	// The `WipeOut` policy is particularly risky in production environments due to its irreversible nature.
@@ -174,11 +180,63 @@ type ClusterSpec struct {
 	// +optional
 	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
 
+	// Specifies the IP families of the Services of the Cluster, to support the IPv6 and dual-stack networks.
+	//
+	// It's applied to the Services of the Cluster and its Components, including the headless Services, which do not
+	// specify the IP families themselves. The address that the engines bind to is also derived from it,
+	// see the built-in function `getBindAddress` of the config templates and the env `KB_BIND_ADDRESS`.
+	//
+	// +optional
+	IPStack *IPStack `json:"ipStack,omitempty"`
+
+	// Configures the DNS records of the exposed Services of the Cluster.
+	//
+	// +optional
+	DNS *ClusterDNS `json:"dns,omitempty"`
+
 	// Specifies the backup configuration of the Cluster.
 	//
 	// +optional
 	Backup *ClusterBackup `json:"backup,omitempty"`
 
+	// Specifies the tags to be propagated to the cloud resources created indirectly for the Cluster,
+	// such as the volumes provisioned for the PVCs and the load balancers provisioned for the Services.
+	//
+	// The volumes are provisioned by the StorageClasses generated by KubeBlocks, which copy the StorageClasses
+	// of the claims with the tags added to the parameters of the CSI drivers, i.e. the AWS EBS, Azure Disk,
+	// Alibaba Cloud Disk and GCE PD drivers. The load balancers are tagged by the annotations of the cloud provider
+	// configured for KubeBlocks.
+	// Changes to the tags only apply to the resources created afterward, the StorageClass of the existing volumes
+	// can't be changed.
+	//
+	// +optional
+	CloudTags map[string]string `json:"cloudTags,omitempty"`
+
+	// Specifies the policy for automatically upgrading the service versions of the components.
+	//
+	// +optional
+	UpgradePolicy *ClusterUpgradePolicy `json:"upgradePolicy,omitempty"`
+
+	// Specifies the time windows in which the non-urgent disruptive actions are allowed to execute,
+	// such as the rolling updates of the instances and the OpsRequests created by KubeBlocks automatically.
+	// If not specified, these actions can be executed at any time.
+	// The OpsRequests created by users are not deferred, the instances are updated at once while they are running.
+	//
+	// The windows can be ignored temporarily for emergencies by the annotation
+	// `apps.kubeblocks.io/ignore-disruption-windows: "true"` on the Cluster.
+	//
+	// +optional
+	DisruptionWindows []MaintenanceWindow `json:"disruptionWindows,omitempty"`
+
+	// Specifies the maximum number of the finished OpsRequests, i.e. those in "Succeed", "Failed", "Cancelled"
+	// or "Aborted" phase, to retain for the Cluster.
+	// When exceeded, the earliest finished OpsRequests are deleted automatically.
+	// If not specified, the finished OpsRequests are retained until their TTLs expire.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxOpsRequestHistory *int32 `json:"maxOpsRequestHistory,omitempty"`
+
 	// !!!!! The following fields may be deprecated in subsequent versions, please DO NOT rely on them for new requirements.
 
 	// Describes how Pods are distributed across node.
@@ -245,6 +303,63 @@ type ClusterSpec struct {
 	Network *ClusterNetwork `json:"network,omitempty"`
 }
 
+// ClusterUpgradePolicy defines how the service versions of the components are upgraded automatically.
+type ClusterUpgradePolicy struct {
+	// Specifies whether to upgrade the components automatically when a new patch release of their serviceVersion
+	// is published in the ComponentVersion catalog.
+	//
+	// Only releases that share the major and minor version with the current serviceVersion are considered.
+	// Each upgrade is carried out by an Upgrade OpsRequest created by KubeBlocks.
+	//
+	// A failed upgrade is not retried automatically, it can be retried by deleting the failed OpsRequest.
+	// The next upgrades are backed off exponentially, from 10 minutes up to 24 hours, after consecutive failures.
+	//
+	// Automatic upgrades can be suspended for a single Cluster by the annotation
+	// `apps.kubeblocks.io/skip-auto-patch: "true"`.
+	//
+	// +kubebuilder:default=false
+	// +optional
+	AutoPatch bool `json:"autoPatch,omitempty"`
+
+	// Specifies the time window in which the automatic upgrades are allowed to start.
+	// If not specified, the upgrades can be started at any time.
+	//
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+
+	// Specifies the order in which the components and shardings are upgraded, one at a time.
+	// Components and shardings not listed are upgraded after the listed ones, in alphabetical order.
+	//
+	// +optional
+	ComponentOrder []string `json:"componentOrder,omitempty"`
+}
+
+// MaintenanceWindow defines a recurring weekly time window.
+type MaintenanceWindow struct {
+	// Specifies the days of the week on which the window opens.
+	// If not specified, the window opens every day.
+	//
+	// +optional
+	DaysOfWeek []Weekday `json:"daysOfWeek,omitempty"`
+
+	// Specifies the start time of the window in UTC, in the format of "HH:MM".
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	StartTime string `json:"startTime"`
+
+	// Specifies the duration of the window.
+	//
+	// +kubebuilder:validation:Required
+	Duration metav1.Duration `json:"duration"`
+}
+
+// Weekday defines a day of the week.
+//
+// +enum
+// +kubebuilder:validation:Enum={Sunday,Monday,Tuesday,Wednesday,Thursday,Friday,Saturday}
+type Weekday string
+
 type ClusterBackup struct {
 	// Specifies whether automated backup is enabled for the Cluster.
 	//
@@ -299,6 +414,19 @@ type ClusterBackup struct {
 	// +kubebuilder:default=false
 	// +optional
 	PITREnabled *bool `json:"pitrEnabled,omitempty"`
+
+	// Specifies whether to take a final full backup before the Cluster is deleted.
+	//
+	// If enabled, deleting the Cluster with the `Delete`, `WipeOut` or `Retain` termination policy creates a full backup
+	// using the specified backup method and waits for it to complete before the workloads and PVCs are removed.
+	// The final backup is retained until it is manually deleted, even if the Cluster is wiped out.
+	// The progress of the final backup is reported in the `FinalBackup` condition of the Cluster.
+	// If the final backup fails, the deletion is blocked until the failed backup is deleted to retry,
+	// or the Cluster is annotated with `apps.kubeblocks.io/skip-final-backup=true` to delete it without the final backup.
+	//
+	// +kubebuilder:default=false
+	// +optional
+	FinalBackupOnDelete *bool `json:"finalBackupOnDelete,omitempty"`
 }
 
 // ClusterResources is deprecated since v0.9.
@@ -502,6 +630,68 @@ type ClusterStatus struct {
 	//
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Records the changes of the child objects that the change requested by the annotation
+	// `apps.kubeblocks.io/simulate` would cause, the change is simulated without being applied.
+	// It's removed once the annotation is removed.
+	//
+	// +optional
+	Simulation *ClusterSimulation `json:"simulation,omitempty"`
+}
+
+// ClusterSimulation records the result of simulating a change of the Cluster.
+type ClusterSimulation struct {
+	// The hash of the simulated change, the simulation is recomputed when the change is modified.
+	ObservedChange string `json:"observedChange"`
+
+	// The time when the simulation was computed.
+	SimulationTime metav1.Time `json:"simulationTime"`
+
+	// The error message if the change can't be simulated, e.g. the change is invalid.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// The changes of the child objects that the change would cause.
+	//
+	// +optional
+	Changes []SimulatedChange `json:"changes,omitempty"`
+}
+
+// SimulatedAction defines the action that would be taken on an object.
+//
+// +enum
+// +kubebuilder:validation:Enum={Create,Update,Delete,Recreate,Resize}
+type SimulatedAction string
+
+const (
+	SimulatedCreate   SimulatedAction = "Create"
+	SimulatedUpdate   SimulatedAction = "Update"
+	SimulatedDelete   SimulatedAction = "Delete"
+	SimulatedRecreate SimulatedAction = "Recreate"
+	SimulatedResize   SimulatedAction = "Resize"
+)
+
+// SimulatedChange describes a change of an object that a simulated change would cause.
+type SimulatedChange struct {
+	// The kind of the object, e.g. Component, Service, Pod, PersistentVolumeClaim.
+	Kind string `json:"kind"`
+
+	// The name of the object.
+	Name string `json:"name"`
+
+	// The action that would be taken on the object.
+	Action SimulatedAction `json:"action"`
+
+	// The name of the Component that the object belongs to.
+	//
+	// +optional
+	Component string `json:"component,omitempty"`
+
+	// Describes the change in details, e.g. the fields changed.
+	//
+	// +optional
+	Details string `json:"details,omitempty"`
 }
 
 // ShardingSpec defines how KubeBlocks manage dynamic provisioned shards.
@@ -705,6 +895,11 @@ type ClusterComponentSpec struct {
 	// +optional
 	SchedulingPolicy *SchedulingPolicy `json:"schedulingPolicy,omitempty"`
 
+	// Specifies the hints for scheduling the pods of the Component, which are evaluated when the pods are created.
+	//
+	// +optional
+	SchedulingHints *SchedulingHints `json:"schedulingHints,omitempty"`
+
 	// Specifies the resources required by the Component.
 	// It allows defining the CPU, memory requirements and limits for the Component's containers.
 	//
@@ -871,6 +1066,66 @@ type ClusterComponentSpec struct {
 	// +optional
 	OfflineInstances []string `json:"offlineInstances,omitempty"`
 
+	// Specifies the policy to provide stable IPs for the instances of the Component,
+	// for the engines or clients that cannot tolerate the IP changes across the recreation of pods.
+	// The IP of each instance is advertised in the `workloads.kubeblocks.io/instance-ip` annotation of its pod
+	// and injected into the containers as the env `KB_INSTANCE_IP`, the instance is recreated once its IP is changed.
+	//
+	// +optional
+	InstanceIP *InstanceIPPolicy `json:"instanceIP,omitempty"`
+
+	// Specifies the persistence related settings of the Component, such as expanding the volumes automatically.
+	//
+	// +optional
+	Persistence *ClusterComponentPersistence `json:"persistence,omitempty"`
+
+	// Specifies an overlay which is strategic-merged into the pod template of the generated workload,
+	// e.g. to add extra volumes, sysctls, hostAliases or dnsConfig, without forking the ComponentDefinition.
+	//
+	// Only `metadata.labels`, `metadata.annotations` and `spec` are allowed, and the fields which would break
+	// the security or the management of the pods are rejected, including `spec.hostNetwork`, `spec.hostPID`,
+	// `spec.hostIPC`, `spec.serviceAccountName`, `spec.nodeName`, `spec.restartPolicy`, the `securityContext`
+	// of the pod except for `sysctls`, and the `image`, `command`, `args` and `securityContext` of the containers.
+	// The containers and init containers in the overlay are merged by name, and should exist in the pod template.
+	//
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Type=object
+	// +optional
+	PodTemplateOverlay *runtime.RawExtension `json:"podTemplateOverlay,omitempty"`
+
+	// Specifies the kernel parameters and the huge pages required by the database engine of the Component,
+	// instead of tuning them with privileged init containers.
+	//
+	// +optional
+	KernelTuning *KernelTuning `json:"kernelTuning,omitempty"`
+
+	// Specifies the host-network mode of the Component, which is supported only if the ComponentDefinition declares
+	// the host-network capability in `spec.hostNetwork`.
+	//
+	// +optional
+	HostNetwork *ComponentHostNetwork `json:"hostNetwork,omitempty"`
+
+	// Specifies the weights of the replicas in the read traffic of the Component, by instance template or by instance,
+	// so that the larger replicas receive proportionally more read traffic.
+	//
+	// The weights are rendered into the annotation `apps.kubeblocks.io/replica-weights` of the Services which select
+	// a non-writable role, as a JSON object mapping the pod names to their weights, to be consumed by the proxies
+	// and load balancers supporting weighted routing.
+	// The replicas not matched by any item have a weight of 1.
+	//
+	// +optional
+	ReplicaWeights []ReplicaWeight `json:"replicaWeights,omitempty"`
+
+	// Specifies the resources of the sidecar containers injected into the pods of the Component,
+	// e.g. "lorry", "kb-agent", "config-manager" or the exporter, which can be referred to as "exporter".
+	// They take precedence over the defaults of the operator, and are rolled out without changing the resources
+	// of the main containers.
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	SidecarResources []SidecarResources `json:"sidecarResources,omitempty"`
+
 	// Determines whether metrics exporter information is annotated on the Component's headless Service.
 	//
 	// If set to true, the following annotations will not be patched into the Service:
@@ -884,6 +1139,16 @@ type ClusterComponentSpec struct {
 	// +optional
 	DisableExporter *bool `json:"disableExporter,omitempty"`
 
+	// Specifies whether to designate a follower replica of the Component as the dedicated backup replica.
+	//
+	// If set to true, an available follower is labeled with "apps.kubeblocks.io/backup-replica" and preferred
+	// as the target of all backups whose pod selection strategy is "Any". While a backup runs on it, the replica is
+	// excluded from the read Services of the Component, which select non-writable roles.
+	// If the designated replica fails, another available follower is designated automatically.
+	//
+	// +optional
+	BackupReplica *bool `json:"backupReplica,omitempty"`
+
 	// Deprecated since v0.9
 	// Determines whether metrics exporter information is annotated on the Component's headless Service.
 	//
@@ -934,6 +1199,12 @@ type ClusterComponentStatus struct {
 	//
 	// +optional
 	MembersStatus []workloads.MemberStatus `json:"membersStatus,omitempty"`
+
+	// Records the generation of the Cluster which has been fully rolled out to the Component,
+	// i.e. the Component and all the objects generated for it match the spec of that generation.
+	//
+	// +optional
+	LastAppliedGeneration int64 `json:"lastAppliedGeneration,omitempty"`
 }
 
 // ClusterSwitchPolicy defines the switch policy for a Cluster.
@@ -948,6 +1219,76 @@ type ClusterSwitchPolicy struct {
 	Type SwitchPolicyType `json:"type"`
 }
 
+// SidecarResources specifies the resources of a sidecar container.
+type SidecarResources struct {
+	// Specifies the name of the sidecar container.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies the resources of the sidecar container.
+	//
+	// +kubebuilder:validation:Required
+	Resources corev1.ResourceRequirements `json:"resources"`
+}
+
+// ClusterComponentPersistence defines the persistence related settings of a Component.
+type ClusterComponentPersistence struct {
+	// Specifies the policy to expand the volumes of the Component automatically before they are full.
+	//
+	// +optional
+	AutoExpansion *VolumeAutoExpansion `json:"autoExpansion,omitempty"`
+
+	// Specifies the usage percentage of a volume at which the instance is switched into the read-only protective mode
+	// by the agent, to prevent the data from being corrupted by a full disk.
+	// A DiskPressure condition is raised on the Component meanwhile, both are cleared once the usage drops below
+	// the threshold, e.g. after the volume is expanded.
+	// The protective mode is disabled if not set or set to 0.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	CriticalThresholdPercent int32 `json:"criticalThresholdPercent,omitempty"`
+}
+
+// VolumeAutoExpansion defines the policy to expand the volumes of a Component automatically, by creating
+// VolumeExpansion OpsRequests when the usage of the volumes crosses the threshold.
+type VolumeAutoExpansion struct {
+	// Specifies the names of the volumeClaimTemplates to expand automatically.
+	// All the volumeClaimTemplates of the Component are taken into account if not specified.
+	//
+	// +optional
+	VolumeNames []string `json:"volumeNames,omitempty"`
+
+	// Specifies the usage percentage of a volume that triggers the expansion.
+	// The highest usage among the instances is taken as the usage of a volumeClaimTemplate.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=99
+	// +kubebuilder:default=80
+	// +optional
+	ThresholdPercent int32 `json:"thresholdPercent,omitempty"`
+
+	// Specifies the size added to the volume on each expansion.
+	//
+	// +kubebuilder:validation:Required
+	Increment resource.Quantity `json:"increment"`
+
+	// Specifies the maximum size that the volume can be expanded to.
+	// The volume is not expanded any more once it reaches this size.
+	//
+	// +kubebuilder:validation:Required
+	MaxSize resource.Quantity `json:"maxSize"`
+
+	// Specifies the minimum interval in seconds between two automatic expansions of the Component,
+	// to limit the rate of the expansions.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=3600
+	// +optional
+	MinIntervalSeconds int32 `json:"minIntervalSeconds,omitempty"`
+}
+
 type ClusterComponentVolumeClaimTemplate struct {
 	// Refers to the name of a volumeMount defined in either:
 	//
@@ -1005,15 +1346,62 @@ type PersistentVolumeClaimSpec struct {
 	//
 	// +optional
 	VolumeMode *corev1.PersistentVolumeMode `json:"volumeMode,omitempty" protobuf:"bytes,6,opt,name=volumeMode,casttype=PersistentVolumeMode"`
+
+	// Specifies the performance of the volume, such as the provisioned IOPS and throughput of the cloud disks,
+	// e.g. AWS EBS gp3/io2 and Azure Ultra Disk.
+	//
+	// The performance is applied by a VolumeAttributesClass generated by KubeBlocks for the StorageClass of the claim,
+	// which requires the VolumeAttributesClass feature of Kubernetes and the support of the CSI driver.
+	// It can be changed by the VolumeTuning OpsRequest to modify the volumes online, without re-provisioning them.
+	//
+	// +optional
+	Performance *VolumePerformance `json:"performance,omitempty"`
+}
+
+// VolumePerformance defines the performance parameters of a volume.
+type VolumePerformance struct {
+	// Specifies the provisioned IOPS of the volume.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	IOPS *int64 `json:"iops,omitempty"`
+
+	// Specifies the provisioned throughput of the volume, in MiB/s.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Throughput *int64 `json:"throughput,omitempty"`
+}
+
+// VolumeAttributesClassName returns the name of the VolumeAttributesClass generated for the StorageClass
+// with the performance, or nil if neither the IOPS nor the throughput is specified.
+// The default StorageClass is used if the StorageClass is not specified.
+func (r *VolumePerformance) VolumeAttributesClassName(storageClassName *string) *string {
+	if r == nil || (r.IOPS == nil && r.Throughput == nil) {
+		return nil
+	}
+	name := "kb-default"
+	if storageClassName != nil && *storageClassName != "" {
+		name = fmt.Sprintf("kb-%s", *storageClassName)
+	}
+	if r.IOPS != nil {
+		name = fmt.Sprintf("%s-iops-%d", name, *r.IOPS)
+	}
+	if r.Throughput != nil {
+		name = fmt.Sprintf("%s-throughput-%d", name, *r.Throughput)
+	}
+	return &name
 }
 
 // ToV1PersistentVolumeClaimSpec converts to corev1.PersistentVolumeClaimSpec.
 func (r *PersistentVolumeClaimSpec) ToV1PersistentVolumeClaimSpec() corev1.PersistentVolumeClaimSpec {
+	storageClassName := r.getStorageClassName(viper.GetString(constant.CfgKeyDefaultStorageClass))
 	return corev1.PersistentVolumeClaimSpec{
-		AccessModes:      r.AccessModes,
-		Resources:        r.Resources,
-		StorageClassName: r.getStorageClassName(viper.GetString(constant.CfgKeyDefaultStorageClass)),
-		VolumeMode:       r.VolumeMode,
+		AccessModes:               r.AccessModes,
+		Resources:                 r.Resources,
+		StorageClassName:          storageClassName,
+		VolumeMode:                r.VolumeMode,
+		VolumeAttributesClassName: r.Performance.VolumeAttributesClassName(storageClassName),
 	}
 }
 
@@ -1149,6 +1537,46 @@ type SchedulingPolicy struct {
 	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
 }
 
+// SchedulingHints provides the hints for scheduling the pods, which are evaluated when the pods are created.
+type SchedulingHints struct {
+	// Specifies the consumer applications that the pods prefer to be co-located with, for data locality,
+	// e.g. a cache that serves the application pods on the same node.
+	//
+	// The terms are translated into the preferred pod affinity of the pods when they are created, e.g. during the scale-out,
+	// so that the placements of the consumers at that time are taken into account.
+	// Changes to the terms don't cause the existing pods to be recreated.
+	//
+	// +optional
+	ConsumerAffinity []ConsumerAffinityTerm `json:"consumerAffinity,omitempty"`
+}
+
+// ConsumerAffinityTerm defines the preference to be co-located with the pods of a consumer application.
+type ConsumerAffinityTerm struct {
+	// Specifies the label selector of the consumer application pods.
+	//
+	// +kubebuilder:validation:Required
+	LabelSelector metav1.LabelSelector `json:"labelSelector"`
+
+	// Specifies the namespaces of the consumer application pods.
+	// If not specified, the namespace of the Component is used.
+	//
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Specifies the topology domain to be co-located in, defaults to "kubernetes.io/hostname", i.e. the same node.
+	//
+	// +kubebuilder:default="kubernetes.io/hostname"
+	// +optional
+	TopologyKey string `json:"topologyKey,omitempty"`
+
+	// Specifies the weight of the preference, in the range 1-100.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:validation:Required
+	Weight int32 `json:"weight"`
+}
+
 type TLSConfig struct {
 	// A boolean flag that indicates whether the Component should use Transport Layer Security (TLS)
 	// for secure communication.
@@ -1313,6 +1741,54 @@ type ClusterNetwork struct {
 	PubliclyAccessible bool `json:"publiclyAccessible,omitempty"`
 }
 
+// DNSProviderType defines how the DNS records are managed.
+//
+// +enum
+// +kubebuilder:validation:Enum={ExternalDNS}
+type DNSProviderType string
+
+const (
+	// ExternalDNSProvider annotates the Services with the hostnames, and the records are managed by ExternalDNS.
+	ExternalDNSProvider DNSProviderType = "ExternalDNS"
+)
+
+// ClusterDNS configures stable DNS names for the Services of the Cluster exposed outside of the Kubernetes cluster,
+// i.e. the Services of type LoadBalancer or NodePort.
+// The records follow the changes of the addresses, such as the re-provisioned load balancers.
+type ClusterDNS struct {
+	// Specifies the DNS zone which the hostnames belong to, e.g. "db.example.com".
+	//
+	// +kubebuilder:validation:Required
+	Domain string `json:"domain"`
+
+	// Specifies how the DNS records are managed. Defaults to "ExternalDNS".
+	//
+	// +kubebuilder:default=ExternalDNS
+	// +optional
+	Provider DNSProviderType `json:"provider,omitempty"`
+
+	// Specifies the template of the hostnames, relative to the domain.
+	// The following variables are supported: $(SERVICE_NAME), $(CLUSTER_NAME), $(COMPONENT_NAME) and $(NAMESPACE),
+	// where $(SERVICE_NAME) is the name of the Kubernetes Service.
+	// Defaults to "$(SERVICE_NAME).$(NAMESPACE)".
+	//
+	// +optional
+	HostnameTemplate string `json:"hostnameTemplate,omitempty"`
+
+	// Specifies the hostname templates for specific Services, overriding the `hostnameTemplate`.
+	// The key is the name of the Kubernetes Service without the prefix of the cluster name,
+	// e.g. "vpc" for the cluster Service "mycluster-vpc", "mysql-vpc" for the component Service "mycluster-mysql-vpc".
+	//
+	// +optional
+	ServiceHostnameTemplates map[string]string `json:"serviceHostnameTemplates,omitempty"`
+
+	// Specifies the TTL of the DNS records in seconds.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TTL *int32 `json:"ttl,omitempty"`
+}
+
 type ServiceRef struct {
 	// Specifies the identifier of the service reference declaration.
 	// It corresponds to the serviceRefDeclaration name defined in either:
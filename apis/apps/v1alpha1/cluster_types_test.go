@@ -277,3 +277,27 @@ func TestGetComponentOrName(t *testing.T) {
 		t.Error("function GetComponentByName should return nil")
 	}
 }
+
+func TestVolumeAttributesClassName(t *testing.T) {
+	var (
+		iops       int64 = 3000
+		throughput int64 = 125
+		sc               = "ebs-gp3"
+	)
+	var performance *VolumePerformance
+	if name := performance.VolumeAttributesClassName(&sc); name != nil {
+		t.Errorf("expect no volume attributes class, but got %s", *name)
+	}
+	performance = &VolumePerformance{IOPS: &iops}
+	if name := performance.VolumeAttributesClassName(&sc); name == nil || *name != "kb-ebs-gp3-iops-3000" {
+		t.Errorf("unexpected volume attributes class: %v", name)
+	}
+	performance.Throughput = &throughput
+	if name := performance.VolumeAttributesClassName(nil); name == nil || *name != "kb-default-iops-3000-throughput-125" {
+		t.Errorf("unexpected volume attributes class: %v", name)
+	}
+	pvcSpec := PersistentVolumeClaimSpec{StorageClassName: &sc, Performance: performance}
+	if name := pvcSpec.ToV1PersistentVolumeClaimSpec().VolumeAttributesClassName; name == nil || *name != "kb-ebs-gp3-iops-3000-throughput-125" {
+		t.Errorf("unexpected volume attributes class of the pvc: %v", name)
+	}
+}
@@ -0,0 +1,219 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterPeeringSpec defines the desired state of ClusterPeering.
+//
+// +kubebuilder:validation:XValidation:rule="!has(oldSelf.promote) || !oldSelf.promote || (has(self.promote) && self.promote)",message="forbidden to cancel the promotion of the read-replica cluster"
+type ClusterPeeringSpec struct {
+	// Specifies the name of the source Cluster which the read-replica cluster replicates from.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.clusterName"
+	ClusterName string `json:"clusterName"`
+
+	// Specifies the name of the source Component which the read-replica cluster replicates from.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.componentName"
+	ComponentName string `json:"componentName"`
+
+	// Specifies the Service which exposes the replication endpoint of the source Component
+	// to the remote Kubernetes cluster, e.g. the LoadBalancer Service created by an Expose OpsRequest.
+	//
+	// +kubebuilder:validation:Required
+	ReplicationService ClusterPeeringReplicationService `json:"replicationService"`
+
+	// Specifies the name of the system account of the source Component used for replication.
+	// The credential of the account is copied to the remote Kubernetes cluster for the read-replica cluster.
+	//
+	// +kubebuilder:validation:Required
+	ReplicationAccount string `json:"replicationAccount"`
+
+	// Specifies the remote Kubernetes cluster where the read-replica cluster is created.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.remote"
+	Remote ClusterPeeringRemote `json:"remote"`
+
+	// Specifies the number of the instances of the read-replica cluster.
+	//
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// Promotes the read-replica cluster to a standalone cluster.
+	// The replication from the source Cluster is stopped and the read-replica cluster is detached from the peering,
+	// it is kept in the remote Kubernetes cluster after the ClusterPeering is deleted.
+	// The promotion can not be cancelled.
+	//
+	// +optional
+	Promote bool `json:"promote,omitempty"`
+}
+
+// ClusterPeeringReplicationService defines the Service which exposes the replication endpoint of the source Component.
+type ClusterPeeringReplicationService struct {
+	// Specifies the name of the Service in the namespace of the ClusterPeering.
+	// The Service must be reachable from the remote Kubernetes cluster, i.e. its load balancer ingress is used as the host.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies the port of the Service.
+	// Defaults to the first port of the Service if not set.
+	//
+	// +optional
+	Port int32 `json:"port,omitempty"`
+}
+
+// ClusterPeeringRemote defines the remote Kubernetes cluster where the read-replica cluster is created.
+type ClusterPeeringRemote struct {
+	// Specifies the name of the Secret in the namespace of the ClusterPeering which holds the kubeconfig
+	// of the remote Kubernetes cluster under the key `kubeconfig`.
+	// KubeBlocks with the same addons must be installed in the remote Kubernetes cluster.
+	//
+	// +kubebuilder:validation:Required
+	KubeConfigSecretName string `json:"kubeConfigSecretName"`
+
+	// Specifies the namespace of the read-replica cluster in the remote Kubernetes cluster.
+	// Defaults to the namespace of the ClusterPeering if not set.
+	//
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Specifies the name of the read-replica cluster in the remote Kubernetes cluster.
+	// Defaults to the name of the ClusterPeering if not set.
+	//
+	// +optional
+	ClusterName string `json:"clusterName,omitempty"`
+}
+
+// ClusterPeeringPhase defines the phase of a ClusterPeering.
+//
+// +enum
+// +kubebuilder:validation:Enum={Pending,Replicating,Promoted,Failed}
+type ClusterPeeringPhase string
+
+const (
+	// ClusterPeeringPendingPhase indicates the read-replica cluster is waiting to be created, e.g. the source is not ready.
+	ClusterPeeringPendingPhase ClusterPeeringPhase = "Pending"
+
+	// ClusterPeeringReplicatingPhase indicates the read-replica cluster is created and replicates from the source.
+	ClusterPeeringReplicatingPhase ClusterPeeringPhase = "Replicating"
+
+	// ClusterPeeringPromotedPhase indicates the read-replica cluster is promoted to a standalone cluster.
+	ClusterPeeringPromotedPhase ClusterPeeringPhase = "Promoted"
+
+	// ClusterPeeringFailedPhase indicates the read-replica cluster failed to be created or updated in the remote.
+	ClusterPeeringFailedPhase ClusterPeeringPhase = "Failed"
+)
+
+// ClusterPeeringStatus defines the observed state of ClusterPeering.
+type ClusterPeeringStatus struct {
+	// Represents the most recent generation observed for this ClusterPeering.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Represents the phase of the ClusterPeering.
+	//
+	// +optional
+	Phase ClusterPeeringPhase `json:"phase,omitempty"`
+
+	// Provides the details of the phase, e.g. the error of the last reconciliation.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Records the replication endpoint of the source Component in the form of `host:port`.
+	//
+	// +optional
+	SourceEndpoint string `json:"sourceEndpoint,omitempty"`
+
+	// Records the replication lag of the read-replica cluster, as reported by the agent of its instance.
+	//
+	// +optional
+	Lag *int64 `json:"lag,omitempty"`
+
+	// Records the last time the replication lag is probed.
+	//
+	// +optional
+	LastLagProbeTime *metav1.Time `json:"lastLagProbeTime,omitempty"`
+}
+
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={kubeblocks},shortName=cpeer
+// +kubebuilder:printcolumn:name="CLUSTER",type="string",JSONPath=".spec.clusterName",description="source cluster name"
+// +kubebuilder:printcolumn:name="COMPONENT",type="string",JSONPath=".spec.componentName",description="source component name"
+// +kubebuilder:printcolumn:name="LAG",type="integer",JSONPath=".status.lag",description="replication lag"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.phase",description="status phase"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ClusterPeering creates a read-replica cluster of a Cluster Component in a remote Kubernetes cluster.
+//
+// The read-replica cluster replicates from the source Component over the exposed replication Service,
+// with the credential of the replication account copied to the remote Kubernetes cluster.
+// The replication source is passed to the read-replica cluster by the environment variables
+// `KB_REPLICATION_SOURCE_HOST`, `KB_REPLICATION_SOURCE_PORT`, `KB_REPLICATION_SOURCE_USER` and
+// `KB_REPLICATION_SOURCE_PASSWORD`, and the addons supporting the peering start as a replica of it.
+type ClusterPeering struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterPeeringSpec   `json:"spec,omitempty"`
+	Status ClusterPeeringStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterPeeringList contains a list of ClusterPeering.
+type ClusterPeeringList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterPeering `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterPeering{}, &ClusterPeeringList{})
+}
+
+// GetRemoteNamespace returns the namespace of the read-replica cluster in the remote Kubernetes cluster.
+func (r *ClusterPeering) GetRemoteNamespace() string {
+	if r.Spec.Remote.Namespace != "" {
+		return r.Spec.Remote.Namespace
+	}
+	return r.Namespace
+}
+
+// GetRemoteClusterName returns the name of the read-replica cluster in the remote Kubernetes cluster.
+func (r *ClusterPeering) GetRemoteClusterName() string {
+	if r.Spec.Remote.ClusterName != "" {
+		return r.Spec.Remote.ClusterName
+	}
+	return r.Name
+}
@@ -0,0 +1,203 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ClusterSetSpec defines the desired state of ClusterSet.
+type ClusterSetSpec struct {
+	// Specifies the desired number of member Clusters.
+	//
+	// The member Clusters are named as "<clusterSetName>-<index>", with the index ranging from 0 to replicas-1.
+	// When scaling down, the member Clusters with the highest indexes are removed according to the `scaleDownPolicy`.
+	//
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Specifies the policy to remove the member Clusters out of the desired replicas when scaling down.
+	//
+	// - `Retain`: the member Clusters are released from the ClusterSet and kept running, they have to be deleted manually.
+	// - `Delete`: the member Clusters are deleted, their data is handled according to their `terminationPolicy`.
+	//
+	// +kubebuilder:default=Retain
+	// +optional
+	ScaleDownPolicy ClusterSetScaleDownPolicy `json:"scaleDownPolicy,omitempty"`
+
+	// Specifies the template used to stamp out the member Clusters.
+	//
+	// The following placeholders in the string values of the template are substituted for each member Cluster:
+	//
+	// - $(KB_CLUSTER_SET_NAME): the name of the ClusterSet.
+	// - $(KB_CLUSTER_SET_INDEX): the index of the member Cluster.
+	// - $(KB_CLUSTER_NAME): the name of the member Cluster.
+	//
+	// When the template is changed, only the fields changed in the template are updated to the member Clusters,
+	// the changes made to the member Clusters by others, e.g. the OpsRequests, are kept.
+	//
+	// +kubebuilder:validation:Required
+	Template ClusterTemplate `json:"template"`
+
+	// Specifies how the changes of the template are rolled out to the member Clusters.
+	//
+	// +optional
+	UpdateStrategy *ClusterSetUpdateStrategy `json:"updateStrategy,omitempty"`
+}
+
+// ClusterSetScaleDownPolicy defines how the member Clusters are removed when the ClusterSet scales down.
+//
+// +enum
+// +kubebuilder:validation:Enum={Retain,Delete}
+type ClusterSetScaleDownPolicy string
+
+const (
+	// ClusterSetScaleDownRetain releases the member Clusters from the ClusterSet.
+	ClusterSetScaleDownRetain ClusterSetScaleDownPolicy = "Retain"
+
+	// ClusterSetScaleDownDelete deletes the member Clusters.
+	ClusterSetScaleDownDelete ClusterSetScaleDownPolicy = "Delete"
+)
+
+// ClusterTemplate describes the member Clusters that will be created from a ClusterSet.
+type ClusterTemplate struct {
+	// Specifies the labels to be added to the member Clusters.
+	//
+	// +kubebuilder:validation:XValidation:rule="!('apps.kubeblocks.io/cluster-set' in self) && !('apps.kubeblocks.io/cluster-set-index' in self)",message="the labels apps.kubeblocks.io/cluster-set and apps.kubeblocks.io/cluster-set-index are reserved"
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Specifies the annotations to be added to the member Clusters.
+	//
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Specifies the spec of the member Clusters.
+	//
+	// +kubebuilder:validation:XValidation:rule="has(self.clusterDefinitionRef) || (has(self.componentSpecs) && size(self.componentSpecs) > 0) || (has(self.shardingSpecs) && size(self.shardingSpecs) > 0)",message="either clusterDefinitionRef, componentSpecs or shardingSpecs should be specified"
+	// +kubebuilder:validation:Required
+	Spec ClusterSpec `json:"spec"`
+}
+
+// ClusterSetUpdateStrategy defines how the changes of the template are rolled out to the member Clusters.
+type ClusterSetUpdateStrategy struct {
+	// Specifies the maximum number of member Clusters that can be unavailable during the update.
+	// A member Cluster is considered unavailable if it is not in the Running phase,
+	// or its latest spec has not been observed yet.
+	//
+	// The value can be an absolute number (e.g., 5) or a percentage of the desired replicas (e.g., 10%).
+	// The absolute number is calculated from the percentage by rounding down, and at least one member Cluster
+	// will be updated at a time.
+	//
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:XIntOrString
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// ClusterSetStatus defines the observed state of ClusterSet.
+type ClusterSetStatus struct {
+	// Represents the most recent generation observed for this ClusterSet.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Represents the number of member Clusters created.
+	//
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// Represents the number of member Clusters in the Running phase.
+	//
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
+	// Represents the number of member Clusters that have been updated to the latest template.
+	//
+	// +optional
+	UpdatedReplicas int32 `json:"updatedReplicas,omitempty"`
+
+	// Represents the revision of the latest template.
+	//
+	// +optional
+	UpdateRevision string `json:"updateRevision,omitempty"`
+
+	// Records the status of each member Cluster.
+	//
+	// +optional
+	Clusters []ClusterSetMemberStatus `json:"clusters,omitempty"`
+}
+
+// ClusterSetMemberStatus records the status of a member Cluster.
+type ClusterSetMemberStatus struct {
+	// The name of the member Cluster.
+	Name string `json:"name"`
+
+	// The index of the member Cluster.
+	Index int32 `json:"index"`
+
+	// The phase of the member Cluster.
+	//
+	// +optional
+	Phase ClusterPhase `json:"phase,omitempty"`
+
+	// The revision of the template that the member Cluster is updated to.
+	//
+	// +optional
+	Revision string `json:"revision,omitempty"`
+}
+
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={kubeblocks,all},shortName=clset
+// +kubebuilder:printcolumn:name="REPLICAS",type="integer",JSONPath=".spec.replicas",description="desired replicas."
+// +kubebuilder:printcolumn:name="READY",type="integer",JSONPath=".status.readyReplicas",description="ready replicas."
+// +kubebuilder:printcolumn:name="UPDATED",type="integer",JSONPath=".status.updatedReplicas",description="updated replicas."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ClusterSet stamps out a number of Clusters from a template, like a ReplicaSet does for Pods.
+//
+// It is aimed at platforms that provision a database per tenant. The member Clusters are named by index,
+// and the changes of the template are rolled out to the member Clusters gradually.
+type ClusterSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterSetSpec   `json:"spec,omitempty"`
+	Status ClusterSetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterSetList contains a list of ClusterSet.
+type ClusterSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterSet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterSet{}, &ClusterSetList{})
+}
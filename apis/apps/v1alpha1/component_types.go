@@ -18,7 +18,9 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
@@ -210,6 +212,11 @@ type ComponentSpec struct {
 	// +optional
 	SchedulingPolicy *SchedulingPolicy `json:"schedulingPolicy,omitempty"`
 
+	// Specifies the hints for scheduling the pods of the Component, which are evaluated when the pods are created.
+	//
+	// +optional
+	SchedulingHints *SchedulingHints `json:"schedulingHints,omitempty"`
+
 	// Specifies the TLS configuration for the Component, including:
 	//
 	// - A boolean flag that indicates whether the Component should use Transport Layer Security (TLS) for secure communication.
@@ -260,10 +267,23 @@ type ComponentSpec struct {
 	// +optional
 	OfflineInstances []string `json:"offlineInstances,omitempty"`
 
+	// Specifies the policy to provide stable IPs for the instances of the Component,
+	// for the engines or clients that cannot tolerate the IP changes across the recreation of pods.
+	// The IP of each instance is advertised in the `workloads.kubeblocks.io/instance-ip` annotation of its pod
+	// and injected into the containers as the env `KB_INSTANCE_IP`, the instance is recreated once its IP is changed.
+	//
+	// +optional
+	InstanceIP *InstanceIPPolicy `json:"instanceIP,omitempty"`
+
 	// Defines runtimeClassName for all Pods managed by this Component.
 	// +optional
 	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
 
+	// Specifies the IP families of the Services of the Component, which is inherited from the Cluster.
+	//
+	// +optional
+	IPStack *IPStack `json:"ipStack,omitempty"`
+
 	// Determines whether metrics exporter information is annotated on the Component's headless Service.
 	//
 	// If set to true, the following annotations will not be patched into the Service:
@@ -277,6 +297,63 @@ type ComponentSpec struct {
 	// +optional
 	DisableExporter *bool `json:"disableExporter,omitempty"`
 
+	// Specifies an overlay which is strategic-merged into the pod template of the generated workload,
+	// e.g. to add extra volumes, sysctls, hostAliases or dnsConfig, without forking the ComponentDefinition.
+	//
+	// Only `metadata.labels`, `metadata.annotations` and `spec` are allowed, and the fields which would break
+	// the security or the management of the pods are rejected, including `spec.hostNetwork`, `spec.hostPID`,
+	// `spec.hostIPC`, `spec.serviceAccountName`, `spec.nodeName`, `spec.restartPolicy`, the `securityContext`
+	// of the pod except for `sysctls`, and the `image`, `command`, `args` and `securityContext` of the containers.
+	// The containers and init containers in the overlay are merged by name, and should exist in the pod template.
+	//
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Type=object
+	// +optional
+	PodTemplateOverlay *runtime.RawExtension `json:"podTemplateOverlay,omitempty"`
+
+	// Specifies the kernel parameters and the huge pages required by the database engine of the Component,
+	// instead of tuning them with privileged init containers.
+	//
+	// +optional
+	KernelTuning *KernelTuning `json:"kernelTuning,omitempty"`
+
+	// Specifies the host-network mode of the Component, which is supported only if the ComponentDefinition declares
+	// the host-network capability in `spec.hostNetwork`.
+	//
+	// +optional
+	HostNetwork *ComponentHostNetwork `json:"hostNetwork,omitempty"`
+
+	// Specifies the weights of the replicas in the read traffic of the Component, by instance template or by instance,
+	// so that the larger replicas receive proportionally more read traffic.
+	//
+	// The weights are rendered into the annotation `apps.kubeblocks.io/replica-weights` of the Services which select
+	// a non-writable role, as a JSON object mapping the pod names to their weights, to be consumed by the proxies
+	// and load balancers supporting weighted routing.
+	// The replicas not matched by any item have a weight of 1.
+	//
+	// +optional
+	ReplicaWeights []ReplicaWeight `json:"replicaWeights,omitempty"`
+
+	// Specifies the resources of the sidecar containers injected into the pods of the Component,
+	// e.g. "lorry", "kb-agent", "config-manager" or the exporter, which can be referred to as "exporter".
+	// They take precedence over the defaults of the operator, and are rolled out without changing the resources
+	// of the main containers.
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	SidecarResources []SidecarResources `json:"sidecarResources,omitempty"`
+
+	// Specifies whether to designate a follower replica of the Component as the dedicated backup replica.
+	//
+	// If set to true, an available follower is labeled with "apps.kubeblocks.io/backup-replica" and preferred
+	// as the target of all backups whose pod selection strategy is "Any". While a backup runs on it, the replica is
+	// excluded from the read Services of the Component, which select non-writable roles.
+	// If the designated replica fails, another available follower is designated automatically.
+	//
+	// +optional
+	BackupReplica *bool `json:"backupReplica,omitempty"`
+
 	// Stop the Component.
 	// If set, all the computing resources will be released.
 	//
@@ -323,6 +400,81 @@ type ComponentStatus struct {
 	//
 	// +optional
 	Message ComponentMessageMap `json:"message,omitempty"`
+
+	// Lists the offline instances of the Component and the PVCs retained for them.
+	// The retained PVCs can be deleted by the OpsRequest of type `PurgeOfflineInstances`.
+	//
+	// +optional
+	OfflineInstances []OfflineInstanceStatus `json:"offlineInstances,omitempty"`
+
+	// Records the most recent executions of the lifecycle actions reported by the agents of the Component,
+	// ordered from the oldest to the newest.
+	// Only a bounded number of entries is kept, the oldest ones are dropped first.
+	//
+	// +optional
+	ActionHistory []ActionExecutionRecord `json:"actionHistory,omitempty"`
+}
+
+// ActionExecutionRecord describes an execution of a lifecycle action.
+type ActionExecutionRecord struct {
+	// The name of the lifecycle action, such as `memberJoin`, `memberLeave`, `postProvision` and `dataDump`.
+	Action string `json:"action"`
+
+	// The name of the Pod where the action was executed.
+	//
+	// +optional
+	PodName string `json:"podName,omitempty"`
+
+	// The time when the action was started.
+	StartTime metav1.Time `json:"startTime"`
+
+	// How long the action took to finish.
+	//
+	// +optional
+	Duration metav1.Duration `json:"duration,omitempty"`
+
+	// Indicates whether the action finished successfully.
+	Succeeded bool `json:"succeeded"`
+
+	// The output of the action, truncated if it is too long.
+	//
+	// +optional
+	Output string `json:"output,omitempty"`
+
+	// The error message of the action if it failed, truncated if it is too long.
+	//
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// OfflineInstanceStatus describes an offline instance and its retained PVCs.
+type OfflineInstanceStatus struct {
+	// The name of the instance.
+	Name string `json:"name"`
+
+	// The time when the instance is observed offline for the first time.
+	OfflineSince metav1.Time `json:"offlineSince"`
+
+	// The PVCs retained for the instance.
+	//
+	// +optional
+	RetainedPVCs []RetainedPVC `json:"retainedPVCs,omitempty"`
+}
+
+// RetainedPVC describes a PVC retained for an offline instance.
+type RetainedPVC struct {
+	// The name of the PVC.
+	Name string `json:"name"`
+
+	// The storage size requested by the PVC.
+	//
+	// +optional
+	Size resource.Quantity `json:"size,omitempty"`
+
+	// The creation time of the PVC.
+	//
+	// +optional
+	CreationTimestamp metav1.Time `json:"creationTimestamp,omitempty"`
 }
 
 // +genclient
@@ -22,6 +22,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -893,6 +894,42 @@ type Action struct {
 	//
 	// +optional
 	PreCondition *PreConditionType `json:"preCondition,omitempty"`
+
+	// Defines the restrictions enforced on the process of the Action, such as the user to run as and
+	// the resource limits. It's only applicable to the Actions executed by the kb-agent.
+	//
+	// The process of the Action runs as a non-root user once the sandbox is specified.
+	//
+	// +optional
+	Sandbox *ActionSandbox `json:"sandbox,omitempty"`
+}
+
+// ActionSandbox defines the restrictions enforced on the process of an Action executed by the kb-agent.
+type ActionSandbox struct {
+	// Specifies the user ID to run the process of the Action.
+	// Running as root is not allowed, the process runs as the user `nobody` (65534) if not specified.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RunAsUser *int64 `json:"runAsUser,omitempty"`
+
+	// Specifies the group ID to run the process of the Action.
+	// Running as the root group is not allowed, the process runs as the group `nogroup` (65534) if not specified.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RunAsGroup *int64 `json:"runAsGroup,omitempty"`
+
+	// Specifies the maximum CPU time of the process in seconds, the process is killed once it exceeds the limit.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	CPUSeconds int64 `json:"cpuSeconds,omitempty"`
+
+	// Specifies the maximum resident memory of the process, the process is killed once it exceeds the limit.
+	//
+	// +optional
+	Memory *resource.Quantity `json:"memory,omitempty"`
 }
 
 type Probe struct {
@@ -1333,6 +1370,30 @@ type ComponentLifecycleActions struct {
 	//
 	// +optional
 	AccountProvision *LifecycleActionHandler `json:"accountProvision,omitempty"`
+
+	// Defines the procedure to rebalance the data among the shards of a sharding, e.g. after the shards are scaled out.
+	//
+	// The action is invoked by the OpsRequest of type "Rebalance" on one ready replica of each shard, and it is
+	// invoked repeatedly until the migration of the shard completes, so it must be idempotent: the first invocation
+	// starts the migration, and the later ones report its progress.
+	// The output is expected to be a JSON object like `{"phase": "Running", "message": "migrated 3/10 slots"}`,
+	// where the phase is one of "Running", "Completed" and "Failed". An empty output is treated as "Completed".
+	//
+	// Note: This field is immutable once it has been set.
+	//
+	// +optional
+	Rebalance *LifecycleActionHandler `json:"rebalance,omitempty"`
+
+	// Defines the procedure to report the hash slots served by a shard of a sharding.
+	//
+	// The action is invoked on one ready replica of each running shard to publish the routing metadata of the sharding,
+	// which is refreshed after the data is rebalanced among the shards.
+	// The output is expected to be the hash slots served by the shard in the format of "0-5460,10923-10999".
+	//
+	// Note: This field is immutable once it has been set.
+	//
+	// +optional
+	ShardRouting *LifecycleActionHandler `json:"shardRouting,omitempty"`
 }
 
 type ComponentSwitchover struct {
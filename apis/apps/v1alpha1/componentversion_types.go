@@ -92,6 +92,27 @@ type ComponentVersionRelease struct {
 	// +kubebuilder:validation:XValidation:rule="self.all(key, size(key) <= 32)",message="Container name may not exceed maximum length of 32 characters"
 	// +kubebuilder:validation:XValidation:rule="self.all(key, size(self[key]) <= 256)",message="Image name may not exceed maximum length of 256 characters"
 	Images map[string]string `json:"images"`
+
+	// ReleaseDate is the date when the service version was released, in the format of "YYYY-MM-DD".
+	//
+	// +kubebuilder:validation:Pattern=`^\d{4}-\d{2}-\d{2}$`
+	// +optional
+	ReleaseDate string `json:"releaseDate,omitempty"`
+
+	// EndOfLifeDate is the date when the service version reaches the end of life and is no longer maintained upstream,
+	// in the format of "YYYY-MM-DD".
+	// Components running the service version after the date are reported with the `ServiceVersionRisk` condition.
+	//
+	// +kubebuilder:validation:Pattern=`^\d{4}-\d{2}-\d{2}$`
+	// +optional
+	EndOfLifeDate string `json:"endOfLifeDate,omitempty"`
+
+	// CVEs lists the references of the known vulnerabilities of the service version, e.g. "CVE-2024-21096".
+	// Components running the service version are reported with the `ServiceVersionRisk` condition.
+	//
+	// +kubebuilder:validation:MaxItems=128
+	// +optional
+	CVEs []string `json:"cves,omitempty"`
 }
 
 // ComponentVersionStatus defines the observed state of ComponentVersion
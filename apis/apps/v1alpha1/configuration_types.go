@@ -83,6 +83,29 @@ type ConfigurationItemDetail struct {
 	//
 	// +optional
 	ConfigFileParams map[string]ConfigParams `json:"configFileParams,omitempty"`
+
+	// Specifies the user-defined configuration parameters applied only to the pods of a role.
+	//
+	// The parameters are applied online to the pods of the role, and the configuration files of the role are
+	// rendered into the ConfigMap as `<file>.role-<role>`, which the engine may include when it starts with the role.
+	//
+	// +listType=map
+	// +listMapKey=role
+	// +optional
+	RoleOverlays []RoleConfigOverlay `json:"roleOverlays,omitempty"`
+}
+
+// RoleConfigOverlay defines the configuration parameters overlaid on the pods of a role.
+type RoleConfigOverlay struct {
+	// Specifies the role of the pods.
+	//
+	// +kubebuilder:validation:Required
+	Role string `json:"role"`
+
+	// Specifies the parameters of the configuration files overlaid on the pods of the role.
+	//
+	// +optional
+	ConfigFileParams map[string]ConfigParams `json:"configFileParams,omitempty"`
 }
 
 // ConfigurationSpec defines the desired state of a Configuration resource.
@@ -0,0 +1,148 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DatabaseSpec defines the desired state of Database.
+type DatabaseSpec struct {
+	// Specifies the name of the Cluster where the database is created.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.clusterName"
+	ClusterName string `json:"clusterName"`
+
+	// Specifies the name of the Component where the database is created.
+	// The database is created through the agent of the Pod with the writable role of the Component.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.componentName"
+	ComponentName string `json:"componentName"`
+
+	// Specifies the name of the database in the engine.
+	// Defaults to the name of the Database object if not set.
+	//
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern:=`^[a-z_][a-z0-9_]*$`
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.databaseName"
+	// +optional
+	DatabaseName string `json:"databaseName,omitempty"`
+
+	// Specifies whether the database in the engine is dropped when the object is deleted.
+	//
+	// - `Retain`: the database is kept in the engine.
+	// - `Delete`: the database is dropped from the engine.
+	//
+	// +kubebuilder:default=Retain
+	// +optional
+	DeletionPolicy DatabaseDeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// DatabaseDeletionPolicy defines whether the object in the engine is removed when the object describing it is deleted.
+//
+// +enum
+// +kubebuilder:validation:Enum={Retain,Delete}
+type DatabaseDeletionPolicy string
+
+const (
+	DatabaseDeletionPolicyRetain DatabaseDeletionPolicy = "Retain"
+	DatabaseDeletionPolicyDelete DatabaseDeletionPolicy = "Delete"
+)
+
+// DatabasePhase defines the phase of a Database or a DatabaseUser.
+//
+// +enum
+// +kubebuilder:validation:Enum={Pending,Provisioned,Failed}
+type DatabasePhase string
+
+const (
+	// DatabasePendingPhase indicates the object is waiting to be provisioned, e.g. the Component is not ready.
+	DatabasePendingPhase DatabasePhase = "Pending"
+
+	// DatabaseProvisionedPhase indicates the object is provisioned in the engine.
+	DatabaseProvisionedPhase DatabasePhase = "Provisioned"
+
+	// DatabaseFailedPhase indicates the object failed to be provisioned in the engine.
+	DatabaseFailedPhase DatabasePhase = "Failed"
+)
+
+// DatabaseStatus defines the observed state of Database.
+type DatabaseStatus struct {
+	// Represents the most recent generation observed for this Database.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Represents the phase of the Database.
+	//
+	// +optional
+	Phase DatabasePhase `json:"phase,omitempty"`
+
+	// Provides the details of the phase, e.g. the error of the last provision.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={kubeblocks}
+// +kubebuilder:printcolumn:name="CLUSTER",type="string",JSONPath=".spec.clusterName",description="cluster name"
+// +kubebuilder:printcolumn:name="COMPONENT",type="string",JSONPath=".spec.componentName",description="component name"
+// +kubebuilder:printcolumn:name="DATABASE",type="string",JSONPath=".spec.databaseName",description="database name"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.phase",description="status phase"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Database declares a database (or schema) to be created in the engine of a Cluster Component.
+//
+// It allows the application teams to request their databases declaratively, the database is created
+// through the agent of the Component and is kept in the engine unless the deletion policy is `Delete`.
+type Database struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DatabaseSpec   `json:"spec,omitempty"`
+	Status DatabaseStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DatabaseList contains a list of Database.
+type DatabaseList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Database `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Database{}, &DatabaseList{})
+}
+
+// GetDatabaseName returns the name of the database in the engine.
+func (r *Database) GetDatabaseName() string {
+	if r.Spec.DatabaseName != "" {
+		return r.Spec.DatabaseName
+	}
+	return r.Name
+}
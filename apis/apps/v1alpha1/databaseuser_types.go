@@ -0,0 +1,179 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DatabaseUserSpec defines the desired state of DatabaseUser.
+type DatabaseUserSpec struct {
+	// Specifies the name of the Cluster where the user is created.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.clusterName"
+	ClusterName string `json:"clusterName"`
+
+	// Specifies the name of the Component where the user is created.
+	// The user is created through the agent of the Pod with the writable role of the Component.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.componentName"
+	ComponentName string `json:"componentName"`
+
+	// Specifies the name of the user in the engine.
+	// Defaults to the name of the DatabaseUser object if not set.
+	//
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern:=`^[a-z_][a-z0-9_]*$`
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.userName"
+	// +optional
+	UserName string `json:"userName,omitempty"`
+
+	// Specifies the privileges granted to the user on the databases.
+	// The user has no privileges on the databases not listed here,
+	// and the privileges are revoked once the database is removed from the list.
+	//
+	// +listType=map
+	// +listMapKey=database
+	// +optional
+	Grants []DatabaseGrant `json:"grants,omitempty"`
+
+	// Specifies the policy to generate the password of the user.
+	// The password is generated once and stored in the Secret recorded in `status.secretName`.
+	//
+	// +optional
+	PasswordConfig *PasswordConfig `json:"passwordConfig,omitempty"`
+
+	// Specifies whether the user in the engine is deleted when the object is deleted.
+	//
+	// - `Retain`: the user is kept in the engine.
+	// - `Delete`: the user is deleted from the engine.
+	//
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy DatabaseDeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// DatabaseGrant defines the privileges granted to a user on a database.
+type DatabaseGrant struct {
+	// Specifies the name of the database in the engine.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern:=`^[a-z_][a-z0-9_]*$`
+	Database string `json:"database"`
+
+	// Specifies the role of the user on the database.
+	//
+	// - `ReadOnly`: reads the data of the database.
+	// - `ReadWrite`: reads and writes the data of the database.
+	// - `Owner`: owns the database, which includes the privileges to change the schema.
+	//
+	// +kubebuilder:default=ReadOnly
+	// +optional
+	Role DatabaseRole `json:"role,omitempty"`
+}
+
+// DatabaseRole defines the role of a user on a database.
+//
+// +enum
+// +kubebuilder:validation:Enum={ReadOnly,ReadWrite,Owner}
+type DatabaseRole string
+
+const (
+	DatabaseReadOnlyRole  DatabaseRole = "ReadOnly"
+	DatabaseReadWriteRole DatabaseRole = "ReadWrite"
+	DatabaseOwnerRole     DatabaseRole = "Owner"
+)
+
+// DatabaseUserStatus defines the observed state of DatabaseUser.
+type DatabaseUserStatus struct {
+	// Represents the most recent generation observed for this DatabaseUser.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Represents the phase of the DatabaseUser.
+	//
+	// +optional
+	Phase DatabasePhase `json:"phase,omitempty"`
+
+	// Provides the details of the phase, e.g. the error of the last provision.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Records the name of the Secret that holds the username and the password of the user.
+	//
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// Records the privileges granted to the user in the engine.
+	//
+	// +optional
+	Grants []DatabaseGrant `json:"grants,omitempty"`
+}
+
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={kubeblocks}
+// +kubebuilder:printcolumn:name="CLUSTER",type="string",JSONPath=".spec.clusterName",description="cluster name"
+// +kubebuilder:printcolumn:name="COMPONENT",type="string",JSONPath=".spec.componentName",description="component name"
+// +kubebuilder:printcolumn:name="USER",type="string",JSONPath=".spec.userName",description="user name"
+// +kubebuilder:printcolumn:name="SECRET",type="string",JSONPath=".status.secretName",description="secret of the user"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.phase",description="status phase"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// DatabaseUser declares a user to be created in the engine of a Cluster Component, with the least privileges
+// on the databases it is granted.
+//
+// The password of the user is generated and stored in a Secret owned by the DatabaseUser,
+// so that each application can get its own credential instead of sharing the system accounts.
+type DatabaseUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DatabaseUserSpec   `json:"spec,omitempty"`
+	Status DatabaseUserStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DatabaseUserList contains a list of DatabaseUser.
+type DatabaseUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DatabaseUser `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DatabaseUser{}, &DatabaseUserList{})
+}
+
+// GetUserName returns the name of the user in the engine.
+func (r *DatabaseUser) GetUserName() string {
+	if r.Spec.UserName != "" {
+		return r.Spec.UserName
+	}
+	return r.Name
+}
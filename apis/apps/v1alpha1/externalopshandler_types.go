@@ -0,0 +1,133 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ExternalOpsHandlerSpec defines the desired state of ExternalOpsHandler.
+type ExternalOpsHandlerSpec struct {
+	// Specifies how to connect to the webhook which handles the operation.
+	//
+	// +kubebuilder:validation:Required
+	ClientConfig ExternalOpsHandlerClientConfig `json:"clientConfig"`
+
+	// Specifies the timeout of each call to the webhook.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=30
+	// +kubebuilder:default=10
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+
+	// Indicates whether the webhook supports cancelling the running operation.
+	// The OpsRequests handled by a handler which is not cancellable can't be cancelled.
+	//
+	// +optional
+	Cancellable bool `json:"cancellable,omitempty"`
+
+	// Specifies the phases of the Cluster in which the operation is allowed to start.
+	// Defaults to the up running phases of the Cluster if not set.
+	//
+	// +optional
+	FromClusterPhases []ClusterPhase `json:"fromClusterPhases,omitempty"`
+}
+
+// ExternalOpsHandlerClientConfig defines how to connect to the webhook, either by the URL or by the Service.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.url) != has(self.service)",message="exactly one of url or service must be specified"
+type ExternalOpsHandlerClientConfig struct {
+	// Specifies the URL of the webhook in the form of `https://host:port/path`.
+	//
+	// +optional
+	URL *string `json:"url,omitempty"`
+
+	// Specifies the Service of the webhook.
+	//
+	// +optional
+	Service *ExternalOpsHandlerServiceReference `json:"service,omitempty"`
+
+	// Specifies the PEM encoded CA bundle to verify the serving certificate of the webhook.
+	// The system trust roots are used if not set.
+	//
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+}
+
+// ExternalOpsHandlerServiceReference refers to the Service of a webhook.
+type ExternalOpsHandlerServiceReference struct {
+	// Specifies the namespace of the Service.
+	//
+	// +kubebuilder:validation:Required
+	Namespace string `json:"namespace"`
+
+	// Specifies the name of the Service.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies the port of the Service.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:default=443
+	// +optional
+	Port int32 `json:"port,omitempty"`
+
+	// Specifies the URL path which the requests are sent to.
+	//
+	// +optional
+	Path *string `json:"path,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories={kubeblocks,all},scope=Cluster,shortName=eoh
+// +kubebuilder:printcolumn:name="CANCELLABLE",type="boolean",JSONPath=".spec.cancellable",description="whether the operation can be cancelled."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ExternalOpsHandler registers an out-of-tree handler of the operations, which is implemented by a webhook.
+//
+// The OpsRequests of type `External` which refer to the handler are dispatched to the webhook:
+// the Action, Reconcile and Cancel calls of the OpsRequest are sent to the webhook over HTTP
+// with the OpsRequest and the Cluster as the payload, so that the proprietary procedures
+// can be plugged into the ops framework.
+type ExternalOpsHandler struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ExternalOpsHandlerSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ExternalOpsHandlerList contains a list of ExternalOpsHandler.
+type ExternalOpsHandlerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ExternalOpsHandler `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ExternalOpsHandler{}, &ExternalOpsHandlerList{})
+}
@@ -0,0 +1,115 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NamespacePolicySpec defines the desired state of NamespacePolicy.
+type NamespacePolicySpec struct {
+	// Selects the namespaces by their labels, the policy is applied to the Clusters created in them.
+	// An empty selector matches all namespaces.
+	//
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Specifies the priority of the policy. If multiple policies match the namespace of a Cluster,
+	// the one with the highest priority is applied, and the one with the smallest name if they have the same priority.
+	//
+	// +kubebuilder:default=0
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
+	// Specifies the least protective termination policy allowed for the Clusters.
+	// The termination policy of a Cluster which is less protective than it is raised to it,
+	// in the order of `WipeOut`, `Delete`, `Retain`, `Halt` and `DoNotTerminate`.
+	//
+	// +optional
+	TerminationPolicy TerminationPolicyType `json:"terminationPolicy,omitempty"`
+
+	// Specifies the default backup configuration of the Clusters, which is applied if `spec.backup` of the Cluster is not set.
+	//
+	// +optional
+	Backup *ClusterBackup `json:"backup,omitempty"`
+
+	// Specifies whether the monitoring is enabled by default for the components of the Clusters.
+	// It is applied to the components and the shardings whose `disableExporter` is not set.
+	//
+	// +optional
+	MonitoringEnabled *bool `json:"monitoringEnabled,omitempty"`
+
+	// Specifies the floor and the ceiling of the resources of the components of the Clusters.
+	//
+	// +optional
+	Resources *NamespacePolicyResources `json:"resources,omitempty"`
+}
+
+// NamespacePolicyResources defines the floor and the ceiling of the resources of the components.
+type NamespacePolicyResources struct {
+	// Specifies the minimum resources of the components. The requests and limits less than it are raised to it,
+	// and the requests not set default to it.
+	//
+	// +optional
+	Min corev1.ResourceList `json:"min,omitempty"`
+
+	// Specifies the maximum resources of the components. The requests and limits greater than it are lowered to it,
+	// and the limits not set default to it.
+	//
+	// +optional
+	Max corev1.ResourceList `json:"max,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories={kubeblocks},scope=Cluster,shortName=nsp
+// +kubebuilder:printcolumn:name="PRIORITY",type="integer",JSONPath=".spec.priority",description="priority"
+// +kubebuilder:printcolumn:name="TERMINATION-POLICY",type="string",JSONPath=".spec.terminationPolicy",description="least protective termination policy"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// NamespacePolicy applies the default policies to the Clusters created in the matching namespaces,
+// so that the platform teams can enforce the baselines of backup, monitoring, termination policy and resources
+// without repeating them in each Cluster.
+//
+// The policy is merged into the spec of a Cluster once when the Cluster is created, and the name of the applied
+// policy is recorded in the annotation `apps.kubeblocks.io/namespace-policy` of the Cluster.
+// Later changes of the policy don't affect the existing Clusters.
+type NamespacePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NamespacePolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NamespacePolicyList contains a list of NamespacePolicy.
+type NamespacePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NamespacePolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NamespacePolicy{}, &NamespacePolicyList{})
+}
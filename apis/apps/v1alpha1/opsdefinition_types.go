@@ -20,6 +20,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 )
 
 // OpsDefinitionSpec defines the desired state of OpsDefinition.
@@ -204,6 +207,26 @@ type PodSelector struct {
 	// +kubebuilder:default=Any
 	// +kubebuilder:validation:Required
 	MultiPodSelectionPolicy PodSelectionPolicy `json:"multiPodSelectionPolicy,omitempty"`
+
+	// Specifies the ordinals of the target Pods.
+	// Only the Pods whose ordinal is within the ranges or the discrete values are selected.
+	//
+	// For example, if Ordinals is {ranges: [{start: 0, end: 1}], discrete: [3]},
+	// the Pods with ordinal 0, 1 and 3 are selected.
+	//
+	// +optional
+	Ordinals *workloads.Ordinals `json:"ordinals,omitempty"`
+
+	// Specifies the number or percentage of the matched Pods to be selected when the `multiPodSelectionPolicy` is 'All'.
+	// It accepts an absolute number (e.g., 2) or a percentage of the matched Pods (e.g., "10%").
+	// Percentages are rounded up to the nearest whole number of Pods, and at least one Pod is selected.
+	//
+	// The available Pods are preferred, and the Pods are picked in the ascending order of their ordinals.
+	// If not specified, all the matched Pods are selected.
+	//
+	// +kubebuilder:validation:XIntOrString
+	// +optional
+	Sample *intstr.IntOrString `json:"sample,omitempty"`
 }
 
 type ComponentInfo struct {
@@ -245,14 +268,19 @@ type ParametersSchema struct {
 
 // OpsAction specifies a custom action defined in OpsDefinition for execution in a "Custom" OpsRequest.
 //
-// OpsAction can be of three types:
+// OpsAction can be of the following types:
 //
 //   - workload: Creates a Job or Pod to run custom scripts, ideal for isolated or long-running tasks.
 //   - exec: Executes commands directly within an existing container using the kubectl exec interface,
 //     suitable for immediate, short-lived operations.
+//   - kbAgent: Invokes an action served by the kb-agent of the target Pods.
+//   - waitForCondition: Waits until a K8s object meets the expected condition.
 //   - resourceModifier: Modifies a K8s object using JSON patches, useful for updating the spec of some resource.
 //
-// +kubebuilder:validation:XValidation:rule="has(self.workload) || has(self.exec) || has(self.resourceModifier)", message="at least one action exists for workload, exec and resourceModifier."
+// The actions are executed in order as the steps of the operation, and the outputs of an action
+// can be passed to the subsequent actions.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.workload) || has(self.exec) || has(self.kbAgent) || has(self.waitForCondition) || has(self.resourceModifier)", message="at least one action exists for workload, exec, kbAgent, waitForCondition and resourceModifier."
 type OpsAction struct {
 	// Specifies the name of the OpsAction.
 	// +kubebuilder:validation:MaxLength=20
@@ -278,6 +306,30 @@ type OpsAction struct {
 	// +optional
 	Parameters []string `json:"parameters,omitempty"`
 
+	// Specifies the maximum duration in seconds for the action to complete since it starts.
+	// The action is marked as failed if it doesn't complete in time, and then handled by the `failurePolicy`.
+	//
+	// If not set, the action waits until it completes.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// Specifies the names of the output variables of the action.
+	//
+	// The action reports the outputs as `KEY=VALUE` lines:
+	//
+	// - For 'workload' and 'exec' actions, in the termination message of the containers (`/dev/termination-log`).
+	//   The output of the command is written into the termination message automatically for 'exec' actions.
+	// - For 'kbAgent' actions, in the output of the kb-agent action.
+	//
+	// Only the declared variables are recorded in the status of the OpsRequest. They are injected into the
+	// subsequent actions as environment variables, and can be referenced using $() in the 'waitForCondition' actions.
+	//
+	// +kubebuilder:validation:items:Pattern=`^[A-Za-z_][A-Za-z0-9_]*$`
+	// +optional
+	Outputs []string `json:"outputs,omitempty"`
+
 	// Specifies the configuration for a 'workload' action.
 	// This action leads to the creation of a K8s workload, such as a Pod or Job, to execute specified tasks.
 	//
@@ -289,6 +341,19 @@ type OpsAction struct {
 	// +optional
 	Exec *OpsExecAction `json:"exec,omitempty"`
 
+	// Specifies the configuration for a 'kbAgent' action.
+	// It invokes the action served by the kb-agent of the target Pods, with the parameters of the OpsRequest
+	// and the outputs of the previous actions.
+	//
+	// +optional
+	KBAgent *OpsKBAgentAction `json:"kbAgent,omitempty"`
+
+	// Specifies the configuration for a 'waitForCondition' action.
+	// It waits until the K8s object meets the success condition, or fails if it meets the failure condition.
+	//
+	// +optional
+	WaitForCondition *OpsWaitForConditionAction `json:"waitForCondition,omitempty"`
+
 	// Specifies the configuration for a 'resourceModifier' action.
 	// This action allows for modifications to existing K8s objects.
 	//
@@ -330,6 +395,13 @@ type OpsWorkloadAction struct {
 	// +optional
 	BackoffLimit int32 `json:"backoffLimit,omitempty"`
 
+	// Specifies the number of seconds after which the finished Job is deleted automatically,
+	// it takes effect only for the "Job" workload type.
+	// The PriorityClass of the workload can be specified by `podSpec.priorityClassName`.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+
 	// Specifies the PodSpec of the 'workload' action.
 	// +kubebuilder:validation:Required
 	PodSpec corev1.PodSpec `json:"podSpec"`
@@ -360,6 +432,49 @@ type OpsExecAction struct {
 	ContainerName string `json:"containerName"`
 }
 
+type OpsKBAgentAction struct {
+	// Specifies a PodInfoExtractor defined in the `opsDefinition.spec.podInfoExtractors`,
+	// the action is invoked on each Pod selected by it.
+	//
+	// +kubebuilder:validation:Required
+	PodInfoExtractorName string `json:"podInfoExtractorName"`
+
+	// Specifies the name of the action served by the kb-agent.
+	//
+	// +kubebuilder:validation:Required
+	ActionName string `json:"actionName"`
+
+	// Specifies the number of retries allowed before marking the action as failed.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=0
+	// +optional
+	BackoffLimit int32 `json:"backoffLimit,omitempty"`
+}
+
+type OpsWaitForConditionAction struct {
+	// Specifies the K8s object to wait for, in the namespace of the OpsRequest.
+	// The name of the object can reference the parameters and the outputs of the previous actions using $().
+	//
+	// Defaults to the Component of the OpsRequest, or the Cluster if the component is a sharding.
+	//
+	// +optional
+	Resource *TypedObjectRef `json:"resource,omitempty"`
+
+	// Specifies the conditions to wait for, the parameters and the outputs of the previous actions
+	// can be referenced using $() in the expressions.
+	//
+	// +kubebuilder:validation:Required
+	MatchExpressions MatchExpressions `json:"matchExpressions"`
+
+	// Specifies the frequency (in seconds) at which the conditions are checked.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=5
+	// +optional
+	PeriodSeconds int32 `json:"periodSeconds,omitempty"`
+}
+
 type OpsResourceModifierAction struct {
 	// Specifies the K8s object that is to be updated.
 	//
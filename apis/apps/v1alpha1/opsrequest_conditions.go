@@ -21,6 +21,8 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
 )
 
 const (
@@ -35,6 +37,7 @@ const (
 	ConditionTypeVerticalScaling    = "VerticalScaling"
 	ConditionTypeHorizontalScaling  = "HorizontalScaling"
 	ConditionTypeVolumeExpanding    = "VolumeExpanding"
+	ConditionTypeVolumeTuning       = "VolumeTuning"
 	ConditionTypeReconfigure        = "Reconfigure"
 	ConditionTypeSwitchover         = "Switchover"
 	ConditionTypeStop               = "Stopping"
@@ -44,7 +47,25 @@ const (
 	ConditionTypeDataScript         = "ExecuteDataScript"
 	ConditionTypeBackup             = "Backup"
 	ConditionTypeInstanceRebuilding = "InstancesRebuilding"
+	ConditionTypePurgeOffline       = "PurgingOfflineInstances"
+	ConditionTypeShardingConversion = "ConvertingToSharding"
+	ConditionTypeRebalance          = "Rebalancing"
+	ConditionTypeWaitingForConfirm  = "WaitingForConfirm"
 	ConditionTypeCustomOperation    = "CustomOperation"
+	ConditionTypeExternalOperation  = "ExternalOperation"
+	ConditionTypeRollingBack        = "RollingBack"
+
+	// phase gate condition types, which are set on all the OpsRequests regardless of the type.
+	// e.g. `kubectl wait --for=condition=ActionApplied opsrequest/<name>`.
+	// ConditionTypeValidated and ConditionTypeCancelled above are phase gates too.
+
+	ConditionTypeQueued            = "Queued"
+	ConditionTypeActionApplied     = "ActionApplied"
+	ConditionTypeProgressCompleted = "ProgressCompleted"
+	ConditionTypeRolledBack        = "RolledBack"
+	ConditionTypeApproved          = "Approved"
+
+	ConditionTypeProgressDeadlineExceeded = "ProgressDeadlineExceeded"
 
 	// condition and event reasons
 
@@ -59,18 +80,73 @@ const (
 	ReasonClusterPhaseMismatch     = "ClusterPhaseMismatch"
 	ReasonOpsTypeNotSupported      = "OpsTypeNotSupported"
 	ReasonValidateFailed           = "ValidateFailed"
+	ReasonDuplicateOpsRequest      = "DuplicateOpsRequest"
+	ReasonPreCheckRejected         = "PreCheckRejected"
 	ReasonClusterNotFound          = "ClusterNotFound"
 	ReasonOpsRequestFailed         = "OpsRequestFailed"
 	ReasonOpsCanceling             = "Canceling"
 	ReasonOpsCancelFailed          = "CancelFailed"
 	ReasonOpsCancelSucceed         = "CancelSucceed"
 	ReasonOpsCancelByController    = "CancelByController"
+
+	// reasons of the phase gate conditions
+
+	ReasonValidatePassed              = "ValidateOpsRequestPassed"
+	ReasonWaitingForClusterPhase      = "WaitingForClusterPhase"
+	ReasonWaitingInQueue              = "WaitingInQueue"
+	ReasonConflictWith                = "ConflictWith"
+	ReasonWaitingForDependentOps      = "WaitingForDependentOpsRequests"
+	ReasonWaitingForMaintenanceWindow = "WaitingForMaintenanceWindow"
+	ReasonWaitingForApproval          = "WaitingForApproval"
+	ReasonApproved                    = "Approved"
+	ReasonApprovalRejected            = "ApprovalRejected"
+	ReasonDequeued                    = "Dequeued"
+	ReasonActionApplied               = "ActionApplied"
+	ReasonActionApplyFailed           = "ActionApplyFailed"
+	ReasonRetryingFailedAttempt       = "RetryingFailedAttempt"
+	ReasonProgressSucceed             = "ProgressSucceed"
+	ReasonProgressFailed              = "ProgressFailed"
+	ReasonProgressCancelled           = "ProgressCancelled"
+	ReasonProgressAborted             = "ProgressAborted"
+	ReasonRolledBackToLastConfig      = "RolledBackToLastConfiguration"
+	ReasonRollbackToLastConfigFailed  = "RollbackToLastConfigurationFailed"
+	ReasonDryRunPlanned               = "DryRunPlanned"
 )
 
 func (r *OpsRequest) SetStatusCondition(condition metav1.Condition) {
 	meta.SetStatusCondition(&r.Status.Conditions, condition)
 }
 
+// IsApproved checks if the opsRequest doesn't need to be approved or has been approved.
+func (r *OpsRequest) IsApproved() bool {
+	return !r.Spec.ApprovalRequired || meta.IsStatusConditionTrue(r.Status.Conditions, ConditionTypeApproved)
+}
+
+// MaxOpsTimelineEntries is the maximum number of the entries kept in `status.timeline` of the OpsRequest.
+const MaxOpsTimelineEntries = 64
+
+// AppendTimelineEntry appends an entry to `status.timeline`, the oldest entries are dropped once the number
+// of the entries exceeds MaxOpsTimelineEntries.
+// The entry is skipped if it repeats the latest entry, so that it's safe to be called in each reconciliation.
+func (r *OpsRequest) AppendTimelineEntry(entryType OpsTimelineEntryType, reason, objectKey, message string) {
+	if l := len(r.Status.Timeline); l > 0 {
+		last := r.Status.Timeline[l-1]
+		if last.Type == entryType && last.Reason == reason && last.ObjectKey == objectKey && last.Message == message {
+			return
+		}
+	}
+	r.Status.Timeline = append(r.Status.Timeline, OpsTimelineEntry{
+		Time:      metav1.Now(),
+		Type:      entryType,
+		Reason:    reason,
+		ObjectKey: objectKey,
+		Message:   message,
+	})
+	if l := len(r.Status.Timeline); l > MaxOpsTimelineEntries {
+		r.Status.Timeline = append([]OpsTimelineEntry(nil), r.Status.Timeline[l-MaxOpsTimelineEntries:]...)
+	}
+}
+
 // NewWaitForProcessingCondition waits the controller to process the opsRequest.
 func NewWaitForProcessingCondition(ops *OpsRequest) *metav1.Condition {
 	return &metav1.Condition{
@@ -137,7 +213,7 @@ func NewValidatePassedCondition(opsRequestName string) *metav1.Condition {
 	return &metav1.Condition{
 		Type:               ConditionTypeValidated,
 		Status:             metav1.ConditionTrue,
-		Reason:             "ValidateOpsRequestPassed",
+		Reason:             ReasonValidatePassed,
 		LastTransitionTime: metav1.Now(),
 		Message:            fmt.Sprintf("OpsRequest: %s is validated", opsRequestName),
 	}
@@ -154,6 +230,87 @@ func NewValidateFailedCondition(reason, message string) *metav1.Condition {
 	}
 }
 
+// NewQueuedCondition creates a condition that the OpsRequest is waiting to be processed for the reason.
+func NewQueuedCondition(ops *OpsRequest, reason, message string) *metav1.Condition {
+	return newOpsCondition(ops, ConditionTypeQueued, reason, message)
+}
+
+// NewDequeuedCondition creates a condition that the OpsRequest leaves the queue and starts to be processed.
+func NewDequeuedCondition(ops *OpsRequest) *metav1.Condition {
+	condition := newOpsCondition(ops, ConditionTypeQueued, ReasonDequeued,
+		fmt.Sprintf("OpsRequest: %s starts to be processed", ops.Name))
+	condition.Status = metav1.ConditionFalse
+	return condition
+}
+
+// NewWaitingForApprovalCondition creates a condition that the OpsRequest is waiting to be approved.
+func NewWaitingForApprovalCondition(ops *OpsRequest) *metav1.Condition {
+	condition := newOpsCondition(ops, ConditionTypeApproved, ReasonWaitingForApproval,
+		fmt.Sprintf(`annotate the OpsRequest with "%s: <approver>" to approve it`, constant.OpsApprovedByAnnotationKey))
+	condition.Status = metav1.ConditionFalse
+	return condition
+}
+
+// NewApprovalRejectedCondition creates a condition that the approval of the OpsRequest is rejected.
+func NewApprovalRejectedCondition(ops *OpsRequest, approver, reason string) *metav1.Condition {
+	condition := newOpsCondition(ops, ConditionTypeApproved, ReasonApprovalRejected,
+		fmt.Sprintf(`the approval by "%s" is rejected: %s`, approver, reason))
+	condition.Status = metav1.ConditionFalse
+	return condition
+}
+
+// NewApprovedCondition creates a condition that the OpsRequest is approved, the time of the approval
+// is recorded in the lastTransitionTime of the condition.
+func NewApprovedCondition(ops *OpsRequest, approver string) *metav1.Condition {
+	return newOpsCondition(ops, ConditionTypeApproved, ReasonApproved,
+		fmt.Sprintf(`OpsRequest: %s is approved by "%s"`, ops.Name, approver))
+}
+
+// NewActionAppliedCondition creates a condition that the action of the OpsRequest has been applied to the Cluster.
+func NewActionAppliedCondition(ops *OpsRequest) *metav1.Condition {
+	return newOpsCondition(ops, ConditionTypeActionApplied, ReasonActionApplied,
+		fmt.Sprintf("The action of OpsRequest: %s is applied to Cluster: %s", ops.Name, ops.Spec.GetClusterName()))
+}
+
+// NewActionApplyFailedCondition creates a condition that the action of the OpsRequest failed to apply.
+func NewActionApplyFailedCondition(ops *OpsRequest, err error) *metav1.Condition {
+	condition := newOpsCondition(ops, ConditionTypeActionApplied, ReasonActionApplyFailed,
+		fmt.Sprintf("Failed to apply the action of OpsRequest: %s to Cluster: %s", ops.Name, ops.Spec.GetClusterName()))
+	condition.Status = metav1.ConditionFalse
+	if err != nil {
+		condition.Message = err.Error()
+	}
+	return condition
+}
+
+// NewProgressCompletedCondition creates a condition that the OpsRequest is completed with the phase,
+// the reason tells the result of the OpsRequest.
+func NewProgressCompletedCondition(ops *OpsRequest, phase OpsPhase) *metav1.Condition {
+	reason := ReasonProgressSucceed
+	switch phase {
+	case OpsFailedPhase:
+		reason = ReasonProgressFailed
+	case OpsCancelledPhase:
+		reason = ReasonProgressCancelled
+	case OpsAbortedPhase:
+		reason = ReasonProgressAborted
+	}
+	return newOpsCondition(ops, ConditionTypeProgressCompleted, reason,
+		fmt.Sprintf("OpsRequest: %s is completed with phase: %s", ops.Name, phase))
+}
+
+// NewRolledBackCondition creates a condition that the changes of the cancelled OpsRequest are rolled back,
+// or failed to roll back if err is not nil.
+func NewRolledBackCondition(ops *OpsRequest, err error) *metav1.Condition {
+	if err != nil {
+		condition := newOpsCondition(ops, ConditionTypeRolledBack, ReasonRollbackToLastConfigFailed, err.Error())
+		condition.Status = metav1.ConditionFalse
+		return condition
+	}
+	return newOpsCondition(ops, ConditionTypeRolledBack, ReasonRolledBackToLastConfig,
+		fmt.Sprintf("The changes of OpsRequest: %s are rolled back to the last configuration", ops.Name))
+}
+
 // NewFailedCondition creates a condition that the OpsRequest processing failed
 func NewFailedCondition(ops *OpsRequest, err error) *metav1.Condition {
 	msg := fmt.Sprintf("Failed to process OpsRequest: %s in cluster: %s, more detailed informations in status.components", ops.Name, ops.Spec.GetClusterName())
@@ -181,6 +338,30 @@ func NewSucceedCondition(ops *OpsRequest) *metav1.Condition {
 	}
 }
 
+// NewDryRunSucceedCondition creates a condition that the plan of the dry-run OpsRequest is generated.
+func NewDryRunSucceedCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeSucceed,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonDryRunPlanned,
+		LastTransitionTime: metav1.Now(),
+		Message: fmt.Sprintf("Successfully planned the dry-run OpsRequest: %s in Cluster: %s, the expected changes are recorded in status.plan",
+			ops.Name, ops.Spec.GetClusterName()),
+	}
+}
+
+// NewProgressDeadlineExceededCondition creates a condition that the Component makes no progress within the progress deadline.
+func NewProgressDeadlineExceededCondition(ops *OpsRequest, componentName string, deadlineSeconds int32) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeProgressDeadlineExceeded,
+		Status:             metav1.ConditionTrue,
+		Reason:             ConditionTypeProgressDeadlineExceeded,
+		LastTransitionTime: metav1.Now(),
+		Message: fmt.Sprintf("Component: %s of OpsRequest: %s made no progress within the progress deadline of %ds",
+			componentName, ops.Name, deadlineSeconds),
+	}
+}
+
 // NewRestartingCondition creates a condition that the operation starts to restart components
 func NewRestartingCondition(ops *OpsRequest) *metav1.Condition {
 	return &metav1.Condition{
@@ -192,6 +373,20 @@ func NewRestartingCondition(ops *OpsRequest) *metav1.Condition {
 	}
 }
 
+// NewWaitingForConfirmCondition creates a condition that the canary instances are restarted and the operation
+// waits for the approval to restart the remaining instances,
+// or the data is redistributed into the shards and the operation waits for the confirmation to cut over.
+func NewWaitingForConfirmCondition(ops *OpsRequest) *metav1.Condition {
+	if ops.Spec.Type == ShardingConversionType {
+		return newOpsCondition(ops, ConditionTypeWaitingForConfirm, "ShardingDataVerified",
+			fmt.Sprintf(`The data is redistributed into the sharding and verified, annotate the OpsRequest with "%s: true" `+
+				`to remove the source component`, constant.OpsCutoverConfirmedAnnotationKey))
+	}
+	return newOpsCondition(ops, ConditionTypeWaitingForConfirm, "CanaryInstancesRestarted",
+		fmt.Sprintf(`The canary instances have been restarted, annotate the OpsRequest with "%s: true" to restart the remaining instances`,
+			constant.OpsCanaryApprovedAnnotationKey))
+}
+
 // NewInstancesRebuildingCondition creates a condition that the operation starts to rebuild the instances.
 func NewInstancesRebuildingCondition(ops *OpsRequest) *metav1.Condition {
 	return &metav1.Condition{
@@ -203,6 +398,33 @@ func NewInstancesRebuildingCondition(ops *OpsRequest) *metav1.Condition {
 	}
 }
 
+// NewPurgeOfflineInstancesCondition creates a condition that the operation starts to purge the offline instances.
+func NewPurgeOfflineInstancesCondition(ops *OpsRequest) *metav1.Condition {
+	return newOpsCondition(ops, ConditionTypePurgeOffline, "PurgeOfflineInstancesStarted",
+		fmt.Sprintf("Start to purge the offline instances in Cluster: %s", ops.Spec.GetClusterName()))
+}
+
+// NewShardingConversionCondition creates a condition that the operation starts to convert a Component into a sharding.
+func NewShardingConversionCondition(ops *OpsRequest) *metav1.Condition {
+	conversion := ops.Spec.ShardingConversion
+	return newOpsCondition(ops, ConditionTypeShardingConversion, "ShardingConversionStarted",
+		fmt.Sprintf("Start to convert the component %s into the sharding %s in Cluster: %s",
+			conversion.ComponentName, conversion.ShardingName, ops.Spec.GetClusterName()))
+}
+
+// NewRebalanceCondition creates a condition that the operation starts to rebalance the data among the shards.
+func NewRebalanceCondition(ops *OpsRequest) *metav1.Condition {
+	return newOpsCondition(ops, ConditionTypeRebalance, "RebalanceStarted",
+		fmt.Sprintf("Start to rebalance the data among the shards in Cluster: %s", ops.Spec.GetClusterName()))
+}
+
+// NewExternalOpsCondition creates a condition that the operation is dispatched to the webhook of an ExternalOpsHandler.
+func NewExternalOpsCondition(ops *OpsRequest) *metav1.Condition {
+	return newOpsCondition(ops, ConditionTypeExternalOperation, "ExternalOperationStarted",
+		fmt.Sprintf("Start to handle the operation by the ExternalOpsHandler: %s in Cluster: %s",
+			ops.Spec.ExternalOps.HandlerName, ops.Spec.GetClusterName()))
+}
+
 // NewSwitchoveringCondition creates a condition that the operation starts to switchover components
 func NewSwitchoveringCondition(generation int64, message string) *metav1.Condition {
 	return &metav1.Condition{
@@ -248,6 +470,12 @@ func NewVolumeExpandingCondition(ops *OpsRequest) *metav1.Condition {
 	}
 }
 
+// NewVolumeTuningCondition creates a condition that the operation starts to modify the performance of the volumes.
+func NewVolumeTuningCondition(ops *OpsRequest) *metav1.Condition {
+	return newOpsCondition(ops, ConditionTypeVolumeTuning, "VolumeTuningStarted",
+		fmt.Sprintf("Start to modify the performance of the volumes in Cluster: %s", ops.Spec.GetClusterName()))
+}
+
 func NewExposingCondition(ops *OpsRequest) *metav1.Condition {
 	return &metav1.Condition{
 		Type:               ConditionTypeExpose,
@@ -269,6 +497,17 @@ func NewUpgradingCondition(ops *OpsRequest) *metav1.Condition {
 	}
 }
 
+// NewRollingBackCondition creates a condition that the OpsRequest starts to roll back the referenced OpsRequest.
+func NewRollingBackCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeRollingBack,
+		Status:             metav1.ConditionTrue,
+		Reason:             "RollbackStarted",
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf("Start to roll back the OpsRequest: %s in Cluster: %s", ops.Spec.Rollback.OpsRequestName, ops.Spec.GetClusterName()),
+	}
+}
+
 // NewStopCondition creates a condition that the OpsRequest starts to stop the cluster.
 func NewStopCondition(ops *OpsRequest) *metav1.Condition {
 	return &metav1.Condition{
@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/pkg/errors"
@@ -47,6 +48,13 @@ func TestNewAllCondition(t *testing.T) {
 	NewReconfigureFailedCondition(opsRequest, nil)
 	NewReconfigureFailedCondition(opsRequest, errors.New("reconfigure opsRequest failed"))
 	NewBackupCondition(opsRequest)
+	NewQueuedCondition(opsRequest, ReasonWaitingInQueue, "queued")
+	NewDequeuedCondition(opsRequest)
+	NewActionAppliedCondition(opsRequest)
+	NewActionApplyFailedCondition(opsRequest, nil)
+	NewActionApplyFailedCondition(opsRequest, errors.New("apply action failed"))
+	NewRolledBackCondition(opsRequest, nil)
+	NewRolledBackCondition(opsRequest, errors.New("rollback failed"))
 
 	opsRequest.Spec.Reconfigure = &Reconfigure{
 		ComponentOps: ComponentOps{
@@ -67,6 +75,27 @@ func TestSetStatusCondition(t *testing.T) {
 	}
 }
 
+func TestPhaseGateConditions(t *testing.T) {
+	opsRequest := createTestOpsRequest("mysql-test", "mysql-restart", RestartType)
+	opsRequest.SetStatusCondition(*NewQueuedCondition(opsRequest, ReasonWaitingInQueue, "queued"))
+	opsRequest.SetStatusCondition(*NewDequeuedCondition(opsRequest))
+	if !meta.IsStatusConditionFalse(opsRequest.Status.Conditions, ConditionTypeQueued) {
+		t.Errorf(`Condition: %s should be false after dequeued`, ConditionTypeQueued)
+	}
+
+	for phase, reason := range map[OpsPhase]string{
+		OpsSucceedPhase:   ReasonProgressSucceed,
+		OpsFailedPhase:    ReasonProgressFailed,
+		OpsCancelledPhase: ReasonProgressCancelled,
+		OpsAbortedPhase:   ReasonProgressAborted,
+	} {
+		condition := NewProgressCompletedCondition(opsRequest, phase)
+		if condition.Type != ConditionTypeProgressCompleted || condition.Status != metav1.ConditionTrue || condition.Reason != reason {
+			t.Errorf(`unexpected condition %v for phase: %s`, condition, phase)
+		}
+	}
+}
+
 func createTestOpsRequest(clusterName, opsRequestName string, opsType OpsType) *OpsRequest {
 	randomStr, _ := password.Generate(6, 0, 0, true, false)
 	return &OpsRequest{
@@ -84,3 +113,46 @@ func createTestOpsRequest(clusterName, opsRequestName string, opsType OpsType) *
 		},
 	}
 }
+
+func TestAppendTimelineEntry(t *testing.T) {
+	opsRequest := createTestOpsRequest("mysql-test", "mysql-restart", RestartType)
+	opsRequest.AppendTimelineEntry(PhaseChangedTimelineEntry, string(OpsCreatingPhase), "", "Pending -> Creating")
+	opsRequest.AppendTimelineEntry(PhaseChangedTimelineEntry, string(OpsCreatingPhase), "", "Pending -> Creating")
+	if len(opsRequest.Status.Timeline) != 1 {
+		t.Fatalf("expected the repeated entry to be skipped, got %d entries", len(opsRequest.Status.Timeline))
+	}
+	for i := 0; i < MaxOpsTimelineEntries+10; i++ {
+		opsRequest.AppendTimelineEntry(ProgressChangedTimelineEntry, string(ProcessingProgressStatus),
+			fmt.Sprintf("Pod/mysql-test-mysql-%d", i), "")
+	}
+	timeline := opsRequest.Status.Timeline
+	if len(timeline) != MaxOpsTimelineEntries {
+		t.Fatalf("expected %d entries, got %d", MaxOpsTimelineEntries, len(timeline))
+	}
+	if timeline[len(timeline)-1].ObjectKey != fmt.Sprintf("Pod/mysql-test-mysql-%d", MaxOpsTimelineEntries+9) {
+		t.Errorf("expected the latest entry to be kept, got %s", timeline[len(timeline)-1].ObjectKey)
+	}
+	if timeline[0].ObjectKey != "Pod/mysql-test-mysql-10" {
+		t.Errorf("expected the oldest entries to be dropped, got %s", timeline[0].ObjectKey)
+	}
+}
+
+func TestIsApproved(t *testing.T) {
+	opsRequest := createTestOpsRequest("mysql-test", "mysql-stop", StopType)
+	if !opsRequest.IsApproved() {
+		t.Errorf("expected the OpsRequest without approvalRequired to be approved")
+	}
+	opsRequest.Spec.ApprovalRequired = true
+	opsRequest.SetStatusCondition(*NewWaitingForApprovalCondition(opsRequest))
+	if opsRequest.IsApproved() {
+		t.Errorf("expected the OpsRequest waiting for the approval not to be approved")
+	}
+	opsRequest.SetStatusCondition(*NewApprovalRejectedCondition(opsRequest, "bob", "not allowed"))
+	if opsRequest.IsApproved() {
+		t.Errorf("expected the OpsRequest with the rejected approval not to be approved")
+	}
+	opsRequest.SetStatusCondition(*NewApprovedCondition(opsRequest, "alice"))
+	if !opsRequest.IsApproved() {
+		t.Errorf("expected the OpsRequest to be approved")
+	}
+}
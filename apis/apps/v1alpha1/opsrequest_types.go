@@ -21,13 +21,16 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 )
 
 // TODO: @wangyelei could refactor to ops group
 
 // OpsRequestSpec defines the desired state of OpsRequest
 //
-// +kubebuilder:validation:XValidation:rule="has(self.cancel) && self.cancel ? (self.type in ['VerticalScaling', 'HorizontalScaling']) : true",message="forbidden to cancel the opsRequest which type not in ['VerticalScaling','HorizontalScaling']"
+// +kubebuilder:validation:XValidation:rule="has(self.cancel) && self.cancel ? (self.type in ['VerticalScaling', 'HorizontalScaling', 'External']) : true",message="forbidden to cancel the opsRequest which type not in ['VerticalScaling','HorizontalScaling','External']"
+// +kubebuilder:validation:XValidation:rule="has(self.autoStartAfterSeconds) ? self.type == 'Stop' : true",message="autoStartAfterSeconds is only supported by the Stop opsRequest"
 type OpsRequestSpec struct {
 	// Specifies the name of the Cluster resource that this operation is targeting.
 	//
@@ -64,14 +67,48 @@ type OpsRequestSpec struct {
 	// +optional
 	Force bool `json:"force,omitempty"`
 
+	// Specifies whether to only preview the changes of the OpsRequest without mutating the Cluster.
+	//
+	// If set to true, the expected changes, e.g. the replicas, the pods to be created and deleted, the resources
+	// and the parameters to be patched, are computed and written to `status.plan`, and the OpsRequest succeeds
+	// once the plan is generated.
+	// It is supported by the "HorizontalScaling", "VerticalScaling" and "Reconfiguring" OpsRequests.
+	//
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.dryRun"
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// Indicates whether the OpsRequest needs to be approved before its action is applied.
+	//
+	// If set to true, the OpsRequest is held in the "PendingApproval" phase after it's validated, until the
+	// `ops.kubeblocks.io/approved-by` annotation is added with the name of the approver.
+	// The approver must be allowed to `approve` the OpsRequests of the namespace (the `approve` verb on the
+	// `opsrequests` resource of the `apps.kubeblocks.io` group), the approver and the time of the approval are
+	// recorded in the "Approved" condition.
+	//
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.approvalRequired"
+	// +optional
+	ApprovalRequired bool `json:"approvalRequired,omitempty"`
+
 	// Indicates whether opsRequest should continue to queue when 'force' is set to true.
 	// +kubebuilder:default=false
 	// +optional
 	EnqueueOnForce bool `json:"enqueueOnForce,omitempty"`
 
+	// Specifies a key to deduplicate the OpsRequests submitted by automation, e.g. when a request is retried.
+	//
+	// An OpsRequest fails validation if an earlier one with the same key targeting the same Cluster was created
+	// within the idempotency TTL of the operator (24 hours by default). The name of the earlier OpsRequest
+	// is reported in the failure message.
+	//
+	// +kubebuilder:validation:MaxLength=128
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.idempotencyKey"
+	// +optional
+	IdempotencyKey string `json:"idempotencyKey,omitempty"`
+
 	// Specifies the type of this operation. Supported types include "Start", "Stop", "Restart", "Switchover",
 	// "VerticalScaling", "HorizontalScaling", "VolumeExpansion", "Reconfiguring", "Upgrade", "Backup", "Restore",
-	// "Expose", "DataScript", "RebuildInstance", "Custom".
+	// "Expose", "DataScript", "RebuildInstance", "PurgeOfflineInstances", "ShardingConversion", "Rebalance", "Custom", "External".
 	//
 	// Note: This field is immutable once set.
 	//
@@ -91,6 +128,14 @@ type OpsRequestSpec struct {
 	// +optional
 	TTLSecondsAfterUnsuccessfulCompletion int32 `json:"ttlSecondsAfterUnsuccessfulCompletion,omitempty"`
 
+	// Specifies the duration in seconds that an OpsRequest will remain in the system after it finishes
+	// in any phase ("Succeed", "Failed", "Cancelled" or "Aborted") before automatic deletion.
+	// It applies if `ttlSecondsAfterSucceed` or `ttlSecondsAfterUnsuccessfulCompletion` for the phase is not set.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+
 	// Specifies the maximum time in seconds that the OpsRequest will wait for its pre-conditions to be met
 	// before it aborts the operation.
 	// If set to 0 (default), pre-conditions must be satisfied immediately for the OpsRequest to proceed.
@@ -99,6 +144,11 @@ type OpsRequestSpec struct {
 	// +optional
 	PreConditionDeadlineSeconds *int32 `json:"preConditionDeadlineSeconds,omitempty"`
 
+	// Specifies the scheduling policy of the OpsRequest, e.g. the maintenance window in which it is allowed to begin.
+	//
+	// +optional
+	SchedulingPolicy *OpsSchedulingPolicy `json:"schedulingPolicy,omitempty"`
+
 	// Specifies the maximum duration (in seconds) that an opsRequest is allowed to run.
 	// If the opsRequest runs longer than this duration, its phase will be marked as Aborted.
 	// If this value is not set or set to 0, the timeout will be ignored and the opsRequest will run indefinitely.
@@ -106,10 +156,111 @@ type OpsRequestSpec struct {
 	// +kubebuilder:Minimum=0
 	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
 
+	// Specifies how failed Component actions are retried before the whole OpsRequest is marked as Failed.
+	// If not set, the OpsRequest fails as soon as any Component action fails.
+	// A retry re-checks the failed instances of the Component after the backoff, without re-applying the changes.
+	// The OpsRequest is marked as Failed once the retries of all the failed Components are exhausted.
+	//
+	// +optional
+	RetryPolicy *OpsRetryPolicy `json:"retryPolicy,omitempty"`
+
+	// Specifies how the transient errors, such as API conflicts, are retried while the OpsRequest is executing its action
+	// or reconciling its progress.
+	// If not set, such errors are retried without limit.
+	//
+	// +optional
+	FailurePolicy *OpsFailurePolicy `json:"failurePolicy,omitempty"`
+
+	// Specifies the order to apply the changes to the Components of the "HorizontalScaling" and "Restart" OpsRequests.
+	// If not set, the changes are applied to all the Components at once.
+	//
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.executionOrder"
+	// +optional
+	ExecutionOrder *OpsExecutionOrder `json:"executionOrder,omitempty"`
+
 	// Exactly one of its members must be set.
 	SpecificOpsRequest `json:",inline"`
 }
 
+// OpsRetryPolicy defines the retry policy for the failed Component actions of an OpsRequest.
+type OpsRetryPolicy struct {
+	// Specifies the maximum number of retries for the failed actions of each Component.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=0
+	// +optional
+	MaxRetries int32 `json:"maxRetries,omitempty"`
+
+	// Specifies the wait time in seconds before the first retry.
+	// The wait time doubles after each retry, and does not exceed `maxBackoffSeconds`.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=5
+	// +optional
+	BackoffSeconds int32 `json:"backoffSeconds,omitempty"`
+
+	// Specifies the upper limit in seconds of the wait time between two retries.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=300
+	// +optional
+	MaxBackoffSeconds int32 `json:"maxBackoffSeconds,omitempty"`
+}
+
+// OpsFailurePolicy defines the retry policy for the transient errors of an OpsRequest.
+type OpsFailurePolicy struct {
+	// Specifies the maximum number of consecutive retries before the OpsRequest is marked as Failed.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=3
+	// +optional
+	Retries int32 `json:"retries,omitempty"`
+
+	// Specifies the wait time in seconds before the first retry.
+	// The wait time doubles after each retry.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=5
+	// +optional
+	BackoffSeconds int32 `json:"backoffSeconds,omitempty"`
+}
+
+// OpsExecutionPolicy defines how the changes are applied to the Components of an OpsRequest.
+//
+// +enum
+// +kubebuilder:validation:Enum={Parallel,Serial}
+type OpsExecutionPolicy string
+
+const (
+	// ParallelExecutionPolicy applies the changes to all the Components at once.
+	ParallelExecutionPolicy OpsExecutionPolicy = "Parallel"
+
+	// SerialExecutionPolicy applies the changes component-by-component, and waits for the progress of each Component
+	// to complete before starting the next.
+	SerialExecutionPolicy OpsExecutionPolicy = "Serial"
+)
+
+// OpsExecutionOrder defines the order to apply the changes to the Components of an OpsRequest.
+type OpsExecutionOrder struct {
+	// Specifies how the changes are applied to the Components.
+	//
+	// - Parallel: applies the changes to all the Components at once.
+	// - Serial: applies the changes component-by-component, and waits for the progress of each Component
+	//   to complete before starting the next.
+	//
+	// +kubebuilder:default=Parallel
+	// +optional
+	Policy OpsExecutionPolicy `json:"policy,omitempty"`
+
+	// Specifies the order of the Components or shardings for the "Serial" policy,
+	// e.g. to scale the proxy Components after the data Components.
+	// The Components not listed follow the listed ones, in the order they are specified in the OpsRequest.
+	// If not set, the Components are processed in the order they are specified in the OpsRequest.
+	//
+	// +optional
+	Components []string `json:"components,omitempty"`
+}
+
 type SpecificOpsRequest struct {
 	// Specifies the desired new version of the Cluster.
 	//
@@ -141,6 +292,16 @@ type SpecificOpsRequest struct {
 	// +listMapKey=componentName
 	VolumeExpansionList []VolumeExpansion `json:"volumeExpansion,omitempty"  patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
 
+	// Lists VolumeTuning objects, each specifying a Component and the desired performance of its volumeClaimTemplates.
+	// The volumes are modified online, without re-provisioning them.
+	//
+	// +optional
+	// +patchMergeKey=componentName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=componentName
+	VolumeTuningList []VolumeTuning `json:"volumeTuning,omitempty"  patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+
 	// Lists Components to be restarted.
 	//
 	// +optional
@@ -150,7 +311,38 @@ type SpecificOpsRequest struct {
 	// +patchStrategy=merge,retainKeys
 	// +listType=map
 	// +listMapKey=componentName
-	RestartList []ComponentOps `json:"restart,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+	RestartList []Restart `json:"restart,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+
+	// Lists Components to be stopped. If empty, all Components will be stopped.
+	//
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.stop"
+	// +kubebuilder:validation:MaxItems=1024
+	// +patchMergeKey=componentName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=componentName
+	StopList []ComponentOps `json:"stop,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+
+	// Specifies the seconds after which the Components stopped by the Stop OpsRequest are started automatically.
+	// A Start OpsRequest for the stopped Components is created once the period elapses, which is useful to
+	// stop the clusters for development and testing out of the working hours.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=60
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.autoStartAfterSeconds"
+	AutoStartAfterSeconds *int32 `json:"autoStartAfterSeconds,omitempty"`
+
+	// Lists Components to be started. If empty, all Components will be started.
+	//
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.start"
+	// +kubebuilder:validation:MaxItems=1024
+	// +patchMergeKey=componentName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=componentName
+	StartList []ComponentOps `json:"start,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
 
 	// Lists Switchover objects, each specifying a Component to perform the switchover operation.
 	//
@@ -240,10 +432,66 @@ type SpecificOpsRequest struct {
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.rebuildFrom"
 	RebuildFrom []RebuildInstance `json:"rebuildFrom,omitempty"  patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
 
+	// Lists the Components whose retained PVCs of the offline instances are to be deleted.
+	// Only the instances that have been offline longer than the specified threshold are purged.
+	//
+	// +optional
+	// +patchMergeKey=componentName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=componentName
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.purgeOfflineInstances"
+	PurgeOfflineInstancesList []PurgeOfflineInstances `json:"purgeOfflineInstances,omitempty"  patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+
+	// Specifies the parameters to convert a standalone Component into a sharding of the same engine.
+	// The data of the source Component is redistributed into the shards by the engine-native tooling actions,
+	// and the source Component keeps serving until the cutover.
+	//
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.shardingConversion"
+	ShardingConversion *ShardingConversion `json:"shardingConversion,omitempty"`
+
+	// Specifies the parameters to roll back the changes of a succeeded OpsRequest.
+	// The Cluster is reverted to the `status.lastConfiguration` recorded by the referenced OpsRequest.
+	//
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.rollback"
+	Rollback *Rollback `json:"rollback,omitempty"`
+
+	// Lists the shardings to rebalance the data among the shards, e.g. after the shards are scaled out.
+	// The rebalance action defined by the ComponentDefinition is invoked on each shard, and the migration progress
+	// of each shard is tracked in `status.components[*].progressDetails`.
+	//
+	// +optional
+	// +patchMergeKey=componentName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=componentName
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.rebalance"
+	RebalanceList []Rebalance `json:"rebalance,omitempty"  patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+
 	// Specifies a custom operation defined by OpsDefinition.
 	//
 	// +optional
 	CustomOps *CustomOps `json:"custom,omitempty"`
+
+	// Specifies an operation handled by the webhook registered by an ExternalOpsHandler.
+	//
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.external"
+	ExternalOps *ExternalOps `json:"external,omitempty"`
+}
+
+// OpsSchedulingPolicy defines the scheduling policy of the OpsRequest.
+type OpsSchedulingPolicy struct {
+	// Specifies the maintenance window in which the OpsRequest is allowed to begin, e.g. Saturday 02:00-04:00 UTC.
+	// The OpsRequest is held in the "Scheduled" phase until the window opens, and it is not interrupted if the window
+	// closes after it begins.
+	//
+	// It is honored by the "HorizontalScaling", "VerticalScaling" and "Restart" OpsRequests.
+	//
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
 }
 
 // ComponentOps specifies the Component to be operated on.
@@ -253,6 +501,85 @@ type ComponentOps struct {
 	ComponentName string `json:"componentName"`
 }
 
+// RestartStrategy defines how the instances of a Component are restarted.
+//
+// +enum
+// +kubebuilder:validation:Enum={Rolling,Canary}
+type RestartStrategy string
+
+const (
+	// RollingRestartStrategy restarts all the instances by rolling update the workload.
+	RollingRestartStrategy RestartStrategy = "Rolling"
+
+	// CanaryRestartStrategy restarts a subset of the instances first, and waits for the approval to restart the others.
+	CanaryRestartStrategy RestartStrategy = "Canary"
+)
+
+type Restart struct {
+	// Specifies the name of the Component.
+	ComponentOps `json:",inline"`
+
+	// Specifies how the instances of the Component are restarted.
+	//
+	// - Rolling: restarts all the instances by rolling update the workload.
+	// - Canary: restarts the canary instances one by one first, the followers before the leader, and then pauses
+	//   the OpsRequest in the "WaitingForConfirm" phase. The remaining instances are restarted one by one after
+	//   the OpsRequest is annotated with `ops.kubeblocks.io/canary-approved: "true"`.
+	//
+	// +kubebuilder:default=Rolling
+	// +optional
+	Strategy RestartStrategy `json:"strategy,omitempty"`
+
+	// Specifies the number of the canary instances to restart first, for the "Canary" strategy.
+	// Either `canaryCount` or `canaryPercent` can be specified, and it defaults to 1 if neither is specified.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	CanaryCount *int32 `json:"canaryCount,omitempty"`
+
+	// Specifies the percentage of the instances to restart first, for the "Canary" strategy.
+	// The number of the canary instances is rounded up, and it is at least 1.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	CanaryPercent *int32 `json:"canaryPercent,omitempty"`
+
+	// Specifies the names of the instances to restart, e.g. to recover a single wedged replica.
+	// If set, only the listed instances are recreated one by one, the followers before the leader,
+	// rather than rolling the entire Component. It can not be specified with the "Canary" strategy.
+	//
+	// +optional
+	InstanceNames []string `json:"instanceNames,omitempty"`
+}
+
+type Rebalance struct {
+	// Specifies the name of the sharding.
+	ComponentOps `json:",inline"`
+
+	// Specifies the parameters passed to the rebalance action.
+	//
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+type PurgeOfflineInstances struct {
+	// Specifies the name of the Component.
+	ComponentOps `json:",inline"`
+
+	// Specifies the minimum duration that an instance must have been offline before its PVCs can be deleted,
+	// as reported in `component.status.offlineInstances`.
+	//
+	// +kubebuilder:validation:Required
+	OfflineLongerThan metav1.Duration `json:"offlineLongerThan"`
+
+	// Specifies the names of the offline instances to purge.
+	// If not specified, all the offline instances that satisfy `offlineLongerThan` are purged.
+	//
+	// +optional
+	InstanceNames []string `json:"instanceNames,omitempty"`
+}
+
 type RebuildInstance struct {
 	// Specifies the name of the Component.
 	ComponentOps `json:",inline"`
@@ -296,6 +623,70 @@ type RebuildInstance struct {
 	RestoreEnv []corev1.EnvVar `json:"restoreEnv,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
 }
 
+// Rollback defines the parameters to roll back a succeeded OpsRequest.
+type Rollback struct {
+	// Specifies the name of the OpsRequest to be rolled back, which must target the same Cluster.
+	// Only the succeeded "HorizontalScaling", "VerticalScaling" and "Reconfiguring" OpsRequests can be rolled back.
+	//
+	// +kubebuilder:validation:Required
+	OpsRequestName string `json:"opsRequestName"`
+}
+
+// ShardingConversion defines the parameters to convert a standalone Component into a sharding.
+type ShardingConversion struct {
+	// Specifies the name of the source Component to be converted.
+	// The source Component must be defined in `cluster.spec.componentSpecs`.
+	// Its writes are frozen by setting the writable instances read-only before the redistribution,
+	// and it is kept until the cutover is confirmed by annotating the OpsRequest with
+	// `ops.kubeblocks.io/cutover-confirmed: "true"`.
+	// If the redistribution or the verification fails, or the OpsRequest is cancelled before the cutover,
+	// the sharding is removed and the writes of the source Component are resumed.
+	ComponentOps `json:",inline"`
+
+	// Specifies the name of the sharding to be created.
+	// The template of the sharding is copied from the specification of the source Component.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=15
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([a-z0-9\.\-]*[a-z0-9])?$`
+	ShardingName string `json:"shardingName"`
+
+	// Specifies the desired number of shards.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=2048
+	Shards int32 `json:"shards"`
+
+	// Specifies the name of the OpsDefinition that redistributes the data of the source Component into the shards.
+	// Its actions are executed against the sharding once all shards are running,
+	// and they are expected to use the engine-native tooling to migrate the data.
+	//
+	// The name of the source Component and the number of shards are passed to the actions
+	// as the environment variables `KB_CONVERSION_SOURCE_COMPONENT` and `KB_CONVERSION_SHARDS`.
+	//
+	// +kubebuilder:validation:Required
+	RedistributionOpsDefinitionName string `json:"redistributionOpsDefinitionName"`
+
+	// Specifies the read-only query to verify the redistributed data, e.g. `SELECT COUNT(*) FROM orders`.
+	// It must return a single row with a single count, and it is executed through the agent on the writable
+	// instance of the source Component and each shard after the redistribution.
+	// The conversion fails if the count of the source Component is not equal to the sum of the counts of the shards.
+	//
+	// +kubebuilder:validation:Required
+	VerificationQuery string `json:"verificationQuery"`
+
+	// Specifies the parameters passed to the redistribution actions.
+	// The parameters must be defined in the parametersSchema of the OpsDefinition.
+	//
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=name
+	Parameters []Parameter `json:"parameters,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+}
+
 type Instance struct {
 	// Pod name of the instance.
 	// +kubebuilder:validation:Required
@@ -328,6 +719,27 @@ type Switchover struct {
 	//
 	// +kubebuilder:validation:Required
 	InstanceName string `json:"instanceName"`
+
+	// Specifies the time window (in seconds) to verify that the new primary serves writes after the switchover.
+	// The verification writes a probe record on the target instance through its agent and reads it back.
+	// If the verification does not pass within this window, KubeBlocks switches back to the original primary,
+	// and the Component is marked as Failed.
+	// If this value is not set or set to 0, the verification will be skipped.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	VerifyWindowSeconds int32 `json:"verifyWindowSeconds,omitempty"`
+
+	// Specifies the maximum replication lag allowed for the secondaries after the switchover.
+	// The replication lag is reported by the agent of each replica, and its unit depends on the database engine.
+	//
+	// If set, the verification specified by `verifyWindowSeconds` also checks that every secondary of the Component,
+	// including the original primary, replicates from the new primary with a lag not greater than this value.
+	// It takes effect only when `verifyWindowSeconds` is set.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxReplicationLag *int64 `json:"maxReplicationLag,omitempty"`
 }
 
 // Upgrade defines the parameters for an upgrade operation.
@@ -351,6 +763,13 @@ type Upgrade struct {
 	// +kubebuilder:validation:MaxItems=1024
 	// +optional
 	Components []UpgradeComponent `json:"components,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+
+	// Specifies whether to pre-pull the images of the target versions on the nodes hosting the Pods of the Components
+	// before the upgrade starts, to minimize the downtime of each Pod during the rolling upgrade.
+	// The upgrade waits for the images to be pulled for up to 10 minutes, and fails if any image can't be pulled.
+	//
+	// +optional
+	PrePullImages bool `json:"prePullImages,omitempty"`
 }
 
 // +kubebuilder:validation:XValidation:rule="has(self.componentDefinitionName) || has(self.serviceVersion)",message="at least one componentDefinitionName or serviceVersion"
@@ -385,6 +804,8 @@ type VerticalScaling struct {
 	corev1.ResourceRequirements `json:",inline"`
 
 	// Specifies the desired compute resources of the instance template that need to vertical scale.
+	// The progress of the instances of each template is tracked separately,
+	// with the template name as the group of the progress details.
 	// +patchMergeKey=name
 	// +patchStrategy=merge,retainKeys
 	// +listType=map
@@ -439,6 +860,16 @@ type VolumeExpansion struct {
 	// +listMapKey=name
 	// +optional
 	Instances []InstanceVolumeClaimTemplate `json:"instances,omitempty"  patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+
+	// Specifies whether to shrink the volumes if the requested storage is less than the current capacity.
+	//
+	// Since the volumes can not be shrunk natively, the instances of the Component are replaced:
+	// the new instances with the smaller volumes are scaled out, their data is restored from a backup of the Component
+	// through the horizontal scaling backup policy, and then the original instances are taken offline.
+	// The instance templates and the sharding Components are not supported.
+	//
+	// +optional
+	Shrink bool `json:"shrink,omitempty"`
 }
 
 type OpsRequestVolumeClaimTemplate struct {
@@ -455,6 +886,35 @@ type OpsRequestVolumeClaimTemplate struct {
 	Name string `json:"name"`
 }
 
+// VolumeTuning encapsulates the parameters required for a volume tuning operation.
+type VolumeTuning struct {
+	// Specifies the name of the Component.
+	ComponentOps `json:",inline"`
+
+	// Specifies the volumeClaimTemplates to be tuned and the desired performance for each one.
+	//
+	// +kubebuilder:validation:Required
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=name
+	VolumeClaimTemplates []OpsRequestVolumeTuningTemplate `json:"volumeClaimTemplates" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+}
+
+type OpsRequestVolumeTuningTemplate struct {
+	// Specify the name of the volumeClaimTemplate in the Component.
+	// The specified name must match one of the volumeClaimTemplates defined
+	// in the `clusterComponentSpec.volumeClaimTemplates` field.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies the desired performance of the volumes.
+	//
+	// +kubebuilder:validation:Required
+	Performance VolumePerformance `json:"performance"`
+}
+
 // HorizontalScaling defines the parameters of a horizontal scaling operation.
 type HorizontalScaling struct {
 	// Specifies the name of the Component.
@@ -479,8 +939,64 @@ type HorizontalScaling struct {
 	// Note: Any configuration that creates instances is considered invalid.
 	// +optional
 	ScaleIn *ScaleIn `json:"scaleIn,omitempty"`
+
+	// Specifies the maximum duration in seconds that the scaling of the Component may make no progress,
+	// i.e. no instance is created, deleted or changes its progress status.
+	// Once the deadline is exceeded, the pending instances of the Component are marked as Failed,
+	// and the OpsRequest fails unless it is retried according to `spec.retryPolicy`.
+	//
+	// If not set, the OpsRequest waits for the progress of the Component without a deadline.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+
+	// Specifies the actions to invoke through the kb-agent on the instances to be deleted before scaling in,
+	// such as draining the connections. The actions are invoked in order on each instance.
+	//
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	PreScaleInActions []ScalingAction `json:"preScaleInActions,omitempty"`
+
+	// Specifies the actions to invoke through the kb-agent on the created instances after scaling out,
+	// such as rebalancing the shards. The actions are invoked in order on each instance once it is ready.
+	//
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	PostScaleOutActions []ScalingAction `json:"postScaleOutActions,omitempty"`
+}
+
+// ScalingAction defines an action invoked through the kb-agent on the instances during horizontal scaling.
+type ScalingAction struct {
+	// Specifies the name of the action served by the kb-agent of the instances.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies the parameters passed to the action.
+	//
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+
+	// Specifies how to handle the failure of the action.
+	//
+	// - Abort: fails the OpsRequest. The instances are not deleted if a pre-scale-in action fails.
+	// - Ignore: ignores the failure and continues the scaling.
+	//
+	// +kubebuilder:validation:Enum={Abort,Ignore}
+	// +kubebuilder:default=Abort
+	// +optional
+	FailurePolicy ScalingActionFailurePolicy `json:"failurePolicy,omitempty"`
 }
 
+// ScalingActionFailurePolicy defines how to handle the failure of the scaling action.
+type ScalingActionFailurePolicy string
+
+const (
+	ScalingActionFailurePolicyAbort  ScalingActionFailurePolicy = "Abort"
+	ScalingActionFailurePolicyIgnore ScalingActionFailurePolicy = "Ignore"
+)
+
 // ScaleOut defines the configuration for a scale-out operation.
 type ScaleOut struct {
 
@@ -499,6 +1015,50 @@ type ScaleOut struct {
 	// Specifies the instances in the offline list to bring back online.
 	// +optional
 	OfflineInstancesToOnline []string `json:"offlineInstancesToOnline,omitempty"`
+
+	// Specifies where the new instances are placed, e.g. in different availability zones.
+	// An instance template pinned to the requested placement is generated for each item,
+	// and the new instances are created from the instance templates.
+	//
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=name
+	Placements []ScaleOutPlacement `json:"placements,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+}
+
+// ScaleOutPlacement defines the placement of the new instances added during scaling.
+type ScaleOutPlacement struct {
+	// Specifies the name of the instance template generated for the new instances,
+	// which must be unique among the instance templates of the component.
+	//
+	// +kubebuilder:validation:MaxLength=54
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([a-z0-9\.\-]*[a-z0-9])?$`
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies the number of new instances placed as requested.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Required
+	Replicas int32 `json:"replicas"`
+
+	// Specifies the availability zone of the new instances,
+	// which is a shortcut to select the nodes by the label `topology.kubernetes.io/zone`.
+	//
+	// +optional
+	Zone string `json:"zone,omitempty"`
+
+	// Specifies the labels of the nodes which the new instances are scheduled onto.
+	//
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Specifies how the new instances are spread across the topology domains.
+	//
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
 }
 
 // ScaleIn defines the configuration for a scale-in operation.
@@ -510,8 +1070,52 @@ type ScaleIn struct {
 	// Specifies the instance names that need to be taken offline.
 	// +optional
 	OnlineInstancesToOffline []string `json:"onlineInstancesToOffline,omitempty"`
+
+	// Specifies the maximum replication lag allowed for the up-to-date replicas of a replication Component.
+	// The replication lag is reported by the agent of each replica, and its unit depends on the database engine.
+	//
+	// If set, before scaling in, KubeBlocks checks that the instances to be taken offline are not
+	// the only up-to-date replicas, that is, at least one of the remaining secondary replicas has a replication lag
+	// not greater than this value. Otherwise, the scale-in is deferred until the check passes.
+	//
+	// The check doesn't pass either if the replication lag of any secondary replica can't be retrieved,
+	// e.g. the database engine doesn't report it. For a sharding, the check is performed on each shard.
+	//
+	// If not set, the check will be skipped.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxReplicationLag *int64 `json:"maxReplicationLag,omitempty"`
+
+	// Specifies whether to fail the OpsRequest instead of deferring the scale-in
+	// when the replication lag check specified by `maxReplicationLag` does not pass.
+	//
+	// +optional
+	FailOnReplicationLag bool `json:"failOnReplicationLag,omitempty"`
+
+	// Specifies how to handle the PVCs of the instances taken offline.
+	//
+	// - Delete: the PVCs are deleted along with the instances.
+	// - Retain: the PVCs are kept, and they are reused if the instances with the same names are created again.
+	// - Backup: a VolumeSnapshot is taken for each PVC before the instances are taken offline,
+	//   and the PVCs are deleted once the snapshots are ready to use. The snapshots are kept after the Cluster is deleted.
+	//   The scale-in is deferred until the snapshots are ready, and the PVCs are retained if the volumes don't support snapshots.
+	//
+	// +kubebuilder:validation:Enum={Delete,Retain,Backup}
+	// +kubebuilder:default=Delete
+	// +optional
+	PVCRetentionPolicy ScaleInPVCRetentionPolicy `json:"pvcRetentionPolicy,omitempty"`
 }
 
+// ScaleInPVCRetentionPolicy defines how to handle the PVCs of the instances taken offline.
+type ScaleInPVCRetentionPolicy string
+
+const (
+	ScaleInPVCRetentionPolicyDelete ScaleInPVCRetentionPolicy = "Delete"
+	ScaleInPVCRetentionPolicyRetain ScaleInPVCRetentionPolicy = "Retain"
+	ScaleInPVCRetentionPolicyBackup ScaleInPVCRetentionPolicy = "Backup"
+)
+
 // ReplicaChanger defines the parameters for changing the number of replicas.
 type ReplicaChanger struct {
 	// Specifies the replica changes for the component.
@@ -585,6 +1189,16 @@ type ConfigurationItem struct {
 	// +optional
 	Policy *UpgradePolicy `json:"policy,omitempty"`
 
+	// Specifies the role of the pods that the parameters are applied to, the parameters are applied to all the pods
+	// if not set.
+	//
+	// The role-scoped parameters are rendered as an overlay of the configuration, and applied online to the pods
+	// of the role, so only the dynamic parameters are allowed.
+	// The overlay follows the role changes of the pods.
+	//
+	// +optional
+	Role string `json:"role,omitempty"`
+
 	// Sets the configuration files and their associated parameters that need to be updated.
 	// It should contain at least one item.
 	//
@@ -633,6 +1247,18 @@ type CustomOps struct {
 	CustomOpsComponents []CustomOpsComponent `json:"components"  patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
 }
 
+type ExternalOps struct {
+	// Specifies the name of the ExternalOpsHandler which handles the operation.
+	//
+	// +kubebuilder:validation:Required
+	HandlerName string `json:"handlerName"`
+
+	// Specifies the parameters passed to the webhook of the ExternalOpsHandler.
+	//
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
 type CustomOpsComponent struct {
 	// Specifies the name of the Component.
 	ComponentOps `json:",inline"`
@@ -930,6 +1556,50 @@ type ScriptSpec struct {
 	// +optional
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.scriptSpec.script.selector"
 	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// Specifies whether the scripts are rendered as Go templates before they are executed.
+	//
+	// The templates can reference the built-in objects `.cluster`, `.component` and `.parameters`
+	// with their JSON field names, e.g. `CREATE DATABASE {{ .parameters.dbName }};` or `{{ .cluster.metadata.name }}`.
+	//
+	// Note: this field cannot be modified once set.
+	//
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.scriptSpec.templated"
+	Templated bool `json:"templated,omitempty"`
+
+	// Specifies the parameters referenced by the templated scripts as `{{ .parameters.<name> }}`.
+	//
+	// Note: this field cannot be modified once set.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.scriptSpec.parameters"
+	Parameters []ScriptParameter `json:"parameters,omitempty"`
+}
+
+// ScriptParameter defines a parameter of the templated scripts.
+type ScriptParameter struct {
+	// Specifies the name of the parameter.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern:=`^[A-Za-z_][A-Za-z0-9_]*$`
+	Name string `json:"name"`
+
+	// Specifies the value of the parameter, which is rendered into the scripts as is.
+	//
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// Specifies the key of a Secret to source the value of the parameter from.
+	//
+	// The value is never rendered into the scripts. It is injected into the Job as an environment variable
+	// and the parameter is rendered as a reference to the variable, which is expanded by the shell of the Job.
+	// So the value doesn't appear in the OpsRequest or the Job.
+	//
+	// +optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
 }
 
 type Backup struct {
@@ -1020,6 +1690,15 @@ type Restore struct {
 	//
 	// This setting is useful for coordinating PostReady operations across the Cluster for optimal cluster conditions.
 	DeferPostReadyUntilClusterRunning bool `json:"deferPostReadyUntilClusterRunning,omitempty"`
+
+	// Specifies the rules to mask the sensitive data after the PostReady actions, by the masking actions declared
+	// in the ActionSet of the Backup. The Cluster is not marked as ready until the data is masked,
+	// and the Services of the Cluster and its Components are not created until then.
+	//
+	// This is useful for restoring a production backup into a non-production Cluster.
+	//
+	// +optional
+	MaskingRules []dpv1alpha1.MaskingRule `json:"maskingRules,omitempty"`
 }
 
 // ScriptSecret represents the secret that is used to execute the script.
@@ -1065,7 +1744,8 @@ type OpsRequestStatus struct {
 	ClusterGeneration int64 `json:"clusterGeneration,omitempty"`
 
 	// Represents the phase of the OpsRequest.
-	// Possible values include "Pending", "Creating", "Running", "Cancelling", "Cancelled", "Failed", "Succeed".
+	// Possible values include "Pending", "PendingApproval", "Scheduled", "Creating", "Running", "WaitingForConfirm",
+	// "Cancelling", "Cancelled", "Failed", "Succeed".
 	Phase OpsPhase `json:"phase,omitempty"`
 
 	// Represents the progress of the OpsRequest.
@@ -1081,6 +1761,14 @@ type OpsRequestStatus struct {
 	// +optional
 	Components map[string]OpsRequestComponentStatus `json:"components,omitempty"`
 
+	// Records the expected changes computed by the dry run of the OpsRequest, if `spec.dryRun` is true.
+	// +optional
+	Plan *OpsPlan `json:"plan,omitempty"`
+
+	// Records the Components which the changes have been applied to in order, if `spec.executionOrder.policy` is "Serial".
+	// +optional
+	ExecutedComponents []string `json:"executedComponents,omitempty"`
+
 	// A collection of additional key-value pairs that provide supplementary information for the OpsRequest.
 	Extras []map[string]string `json:"extras,omitempty"`
 
@@ -1105,6 +1793,29 @@ type OpsRequestStatus struct {
 	// +optional
 	ReconfiguringStatusAsComponent map[string]*ReconfiguringStatus `json:"reconfiguringStatusAsComponent,omitempty"`
 
+	// Records the number of consecutive attempts failed with a transient error, according to `spec.failurePolicy`.
+	// It is reset once an attempt succeeds.
+	// +optional
+	FailedAttempts int32 `json:"failedAttempts,omitempty"`
+
+	// Records the last attempt failed with a transient error.
+	// +optional
+	LastFailedAttempt *OpsFailedAttempt `json:"lastFailedAttempt,omitempty"`
+
+	// Records the period that the Components are stopped, if `opsRequest.spec.type` equals to "Stop".
+	// +optional
+	StoppedPeriod *OpsStoppedPeriod `json:"stoppedPeriod,omitempty"`
+
+	// Records a bounded timeline of what the OpsRequest did, e.g. the transitions of the phase,
+	// the changes of the progress of each Pod and the decisions to abort the OpsRequests.
+	// Only the latest 64 entries are kept.
+	// +optional
+	Timeline []OpsTimelineEntry `json:"timeline,omitempty"`
+
+	// Records the results of the scripts executed by the DataScript OpsRequest, one entry for each script on each target.
+	// +optional
+	ScriptResults []ScriptResult `json:"scriptResults,omitempty"`
+
 	// Describes the detailed status of the OpsRequest.
 	// Possible condition types include "Cancelled", "WaitForProgressing", "Validated", "Succeed", "Failed", "Restarting",
 	// "VerticalScaling", "HorizontalScaling", "VolumeExpanding", "Reconfigure", "Switchover", "Stopping", "Starting",
@@ -1117,6 +1828,87 @@ type OpsRequestStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// OpsFailedAttempt records an attempt of the OpsRequest failed with a transient error.
+type OpsFailedAttempt struct {
+	// Records the time when the attempt failed.
+	Time metav1.Time `json:"time"`
+
+	// Records the error of the attempt.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// OpsStoppedPeriod records the period that the Components are stopped by a Stop OpsRequest.
+type OpsStoppedPeriod struct {
+	// Records the time when the Components are stopped.
+	StopTimestamp metav1.Time `json:"stopTimestamp"`
+
+	// Records the time when the Components are to be started automatically, if `spec.autoStartAfterSeconds` is set.
+	// +optional
+	AutoStartTimestamp *metav1.Time `json:"autoStartTimestamp,omitempty"`
+
+	// Records the name of the Start OpsRequest created to start the Components automatically.
+	// +optional
+	StartOpsName string `json:"startOpsName,omitempty"`
+}
+
+// OpsTimelineEntryType defines the type of the entries in the timeline of the OpsRequest.
+type OpsTimelineEntryType string
+
+const (
+	// PhaseChangedTimelineEntry records a transition of `status.phase`.
+	PhaseChangedTimelineEntry OpsTimelineEntryType = "PhaseChanged"
+
+	// ProgressChangedTimelineEntry records a change of the progress of an object, e.g. a Pod or an action.
+	ProgressChangedTimelineEntry OpsTimelineEntryType = "ProgressChanged"
+
+	// AbortedTimelineEntry records a decision to abort an OpsRequest, either this one or an earlier one.
+	AbortedTimelineEntry OpsTimelineEntryType = "Aborted"
+)
+
+// OpsTimelineEntry records an event in the timeline of the OpsRequest.
+type OpsTimelineEntry struct {
+	// Records the time when the event happened.
+	Time metav1.Time `json:"time"`
+
+	// Specifies the type of the event, e.g. "PhaseChanged", "ProgressChanged" or "Aborted".
+	Type OpsTimelineEntryType `json:"type"`
+
+	// Records the reason of the event in CamelCase, e.g. the new phase or the new status of the progress.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Records the object that the event is about, e.g. "Pod/mycluster-mysql-0" or "OpsRequest/my-ops".
+	// +optional
+	ObjectKey string `json:"objectKey,omitempty"`
+
+	// Records the details of the event.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ScriptResult records the result of a script executed by the DataScript OpsRequest.
+type ScriptResult struct {
+	// Specifies the name of the Job that executed the script.
+	JobName string `json:"jobName"`
+
+	// Specifies the target that the script was executed on, e.g. "Service/mycluster-mysql" or "Pod/mycluster-mysql-0".
+	// +optional
+	Target string `json:"target,omitempty"`
+
+	// Specifies the index of the script, in the order of the scripts executed.
+	Index int32 `json:"index"`
+
+	// Records the exit code of the script.
+	// It's not set if the script was not executed because a previous script failed.
+	// +optional
+	ExitCode *int32 `json:"exitCode,omitempty"`
+
+	// Records the tail of the output of the script, including the stdout and the stderr.
+	// +optional
+	Output string `json:"output,omitempty"`
+}
+
 // +kubebuilder:validation:XValidation:rule="has(self.objectKey) || has(self.actionName)", message="at least one objectKey or actionName."
 
 type ProgressStatusDetail struct {
@@ -1175,6 +1967,10 @@ type ActionTask struct {
 	// The count of retry attempts made for this task.
 	// +optional
 	Retries int32 `json:"retries,omitempty"`
+
+	// Records the output variables reported by the task, as declared in `opsDefinition.spec.actions[*].outputs`.
+	// +optional
+	Outputs map[string]string `json:"outputs,omitempty"`
 }
 
 // LastComponentConfiguration can be used to track and compare the desired state of the Component over time.
@@ -1217,6 +2013,104 @@ type LastComponentConfiguration struct {
 	// Records the name of the ComponentDefinition prior to any changes.
 	// +optional
 	ComponentDefinitionName string `json:"componentDefinitionName,omitempty"`
+
+	// Records the parameters of the configurations prior to the reconfiguring.
+	// +optional
+	Configurations []LastConfigurationItem `json:"configurations,omitempty"`
+}
+
+// LastConfigurationItem records the parameters of a configuration item prior to the reconfiguring.
+type LastConfigurationItem struct {
+	// Specifies the name of the configuration item.
+	Name string `json:"name"`
+
+	// Records the parameters of the configuration files.
+	// +optional
+	ConfigFileParams map[string]ConfigParams `json:"configFileParams,omitempty"`
+
+	// Records the parameters overlaid on the pods of the roles.
+	// +optional
+	RoleOverlays []RoleConfigOverlay `json:"roleOverlays,omitempty"`
+}
+
+// OpsPlan records the expected changes of a dry-run OpsRequest.
+type OpsPlan struct {
+	// Records the expected changes of the Components, the key is the name of the Component or the sharding.
+	//
+	// +optional
+	Components map[string]OpsComponentPlan `json:"components,omitempty"`
+}
+
+// OpsComponentPlan records the expected changes of a Component.
+type OpsComponentPlan struct {
+	// Records the current replicas of the Component.
+	//
+	// +optional
+	CurrentReplicas *int32 `json:"currentReplicas,omitempty"`
+
+	// Records the expected replicas of the Component.
+	//
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Records the expected instance templates of the Component.
+	//
+	// +optional
+	Instances []InstanceTemplate `json:"instances,omitempty"`
+
+	// Records the expected offline instances of the Component.
+	//
+	// +optional
+	OfflineInstances []string `json:"offlineInstances,omitempty"`
+
+	// Records the names of the pods to be created.
+	//
+	// +optional
+	PodsToCreate []string `json:"podsToCreate,omitempty"`
+
+	// Records the names of the pods to be deleted.
+	//
+	// +optional
+	PodsToDelete []string `json:"podsToDelete,omitempty"`
+
+	// Records the expected resources of the Component.
+	//
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Records the expected resources of the instance templates.
+	//
+	// +optional
+	InstanceResources []InstanceResourceTemplate `json:"instanceResources,omitempty"`
+
+	// Records the parameters to be patched.
+	//
+	// +optional
+	ParameterPatches []ParameterPatch `json:"parameterPatches,omitempty"`
+}
+
+// ParameterPatch records the change of a parameter in a configuration file.
+type ParameterPatch struct {
+	// Specifies the name of the configuration item.
+	Name string `json:"name"`
+
+	// Specifies the name of the configuration file.
+	//
+	// +optional
+	FileName string `json:"fileName,omitempty"`
+
+	// Specifies the name of the parameter.
+	Key string `json:"key"`
+
+	// Records the current value of the parameter, it is empty if the parameter is not set by the user before.
+	//
+	// +optional
+	CurrentValue *string `json:"currentValue,omitempty"`
+
+	// Records the expected value of the parameter, it is empty if the parameter is to be removed.
+	//
+	// +optional
+	Value *string `json:"value,omitempty"`
 }
 
 type LastConfiguration struct {
@@ -1248,6 +2142,25 @@ type OpsRequestComponentStatus struct {
 	// +optional
 	ProgressDetails []ProgressStatusDetail `json:"progressDetails,omitempty"`
 
+	// Records the number of retries performed for the failed actions of the Component,
+	// according to `opsRequest.spec.retryPolicy`.
+	// +optional
+	Retries int32 `json:"retries,omitempty"`
+
+	// Records the time of the last retry.
+	// +optional
+	LastRetryTime metav1.Time `json:"lastRetryTime,omitempty"`
+
+	// Records the last time when the progress details of the Component changed,
+	// which is used to check the progress deadline of the Component.
+	// +optional
+	LastProgressTime metav1.Time `json:"lastProgressTime,omitempty"`
+
+	// Records the scaling actions which have been invoked on the instances of the Component,
+	// in the format of "<action>/<instance>".
+	// +optional
+	InvokedActions []string `json:"invokedActions,omitempty"`
+
 	// Provides an explanation for the Component being in its current state.
 	// +kubebuilder:validation:MaxLength=1024
 	// +optional
@@ -1409,6 +2322,46 @@ func (c ComponentOps) GetComponentName() string {
 	return c.ComponentName
 }
 
+func (h HorizontalScaling) GetProgressDeadlineSeconds() *int32 {
+	return h.ProgressDeadlineSeconds
+}
+
+// GetNewInstances returns the instance templates of the new instances, including the instance templates
+// generated for the placements.
+func (s ScaleOut) GetNewInstances() []InstanceTemplate {
+	if len(s.Placements) == 0 {
+		return s.NewInstances
+	}
+	newInstances := make([]InstanceTemplate, 0, len(s.NewInstances)+len(s.Placements))
+	newInstances = append(newInstances, s.NewInstances...)
+	for _, placement := range s.Placements {
+		newInstances = append(newInstances, placement.ToInstanceTemplate())
+	}
+	return newInstances
+}
+
+// ToInstanceTemplate builds the instance template pinned to the placement.
+func (p ScaleOutPlacement) ToInstanceTemplate() InstanceTemplate {
+	schedulingPolicy := &SchedulingPolicy{
+		TopologySpreadConstraints: p.TopologySpreadConstraints,
+	}
+	if len(p.NodeSelector) > 0 || p.Zone != "" {
+		schedulingPolicy.NodeSelector = map[string]string{}
+		for k, v := range p.NodeSelector {
+			schedulingPolicy.NodeSelector[k] = v
+		}
+		if p.Zone != "" {
+			schedulingPolicy.NodeSelector[corev1.LabelTopologyZone] = p.Zone
+		}
+	}
+	replicas := p.Replicas
+	return InstanceTemplate{
+		Name:             p.Name,
+		Replicas:         &replicas,
+		SchedulingPolicy: schedulingPolicy,
+	}
+}
+
 // ToExposeListToMap build expose map
 func (r OpsRequestSpec) ToExposeListToMap() map[string]Expose {
 	exposeMap := make(map[string]Expose)
@@ -52,3 +52,126 @@ func TestSetStatusAndMessage(t *testing.T) {
 		t.Error("set progressDetail status and message failed")
 	}
 }
+
+func TestGetHorizontalScalingExpectedReplicas(t *testing.T) {
+	replicaChanges := func(n int32) *int32 { return &n }
+	ops := &OpsRequest{}
+	for _, c := range []struct {
+		hScale   HorizontalScaling
+		expected int32
+	}{
+		{HorizontalScaling{Replicas: replicaChanges(1)}, 1},
+		{HorizontalScaling{ScaleIn: &ScaleIn{ReplicaChanger: ReplicaChanger{ReplicaChanges: replicaChanges(2)}}}, 1},
+		{HorizontalScaling{ScaleIn: &ScaleIn{OnlineInstancesToOffline: []string{"test-mysql-0"}}}, 2},
+		{HorizontalScaling{ScaleOut: &ScaleOut{ReplicaChanger: ReplicaChanger{ReplicaChanges: replicaChanges(2)}},
+			ScaleIn: &ScaleIn{ReplicaChanger: ReplicaChanger{ReplicaChanges: replicaChanges(1)}}}, 4},
+	} {
+		c.hScale.ComponentName = componentName
+		if replicas := ops.GetHorizontalScalingExpectedReplicas("test", c.hScale, 3); replicas != c.expected {
+			t.Errorf("expected replicas %d, but got %d", c.expected, replicas)
+		}
+	}
+}
+
+func TestValidateExecutionOrder(t *testing.T) {
+	ops := &OpsRequest{}
+	ops.Spec.Type = RestartType
+	ops.Spec.RestartList = []Restart{{ComponentOps: ComponentOps{ComponentName: "mysql"}}, {ComponentOps: ComponentOps{ComponentName: "proxy"}}}
+	for _, c := range []struct {
+		executionOrder *OpsExecutionOrder
+		valid          bool
+	}{
+		{nil, true},
+		{&OpsExecutionOrder{Policy: SerialExecutionPolicy}, true},
+		{&OpsExecutionOrder{Policy: SerialExecutionPolicy, Components: []string{"proxy", "mysql"}}, true},
+		{&OpsExecutionOrder{Policy: ParallelExecutionPolicy, Components: []string{"proxy"}}, false},
+		{&OpsExecutionOrder{Policy: SerialExecutionPolicy, Components: []string{"redis"}}, false},
+		{&OpsExecutionOrder{Policy: SerialExecutionPolicy, Components: []string{"proxy", "proxy"}}, false},
+	} {
+		ops.Spec.ExecutionOrder = c.executionOrder
+		if err := ops.validateExecutionOrder(); (err == nil) != c.valid {
+			t.Errorf("expected the execution order %v to be valid: %t, but got error: %v", c.executionOrder, c.valid, err)
+		}
+	}
+
+	ops.Spec.Type = VerticalScalingType
+	ops.Spec.ExecutionOrder = &OpsExecutionOrder{Policy: SerialExecutionPolicy}
+	if err := ops.validateExecutionOrder(); err == nil {
+		t.Error("expected the execution order to be rejected for the VerticalScaling OpsRequest")
+	}
+}
+
+func TestValidateRestartInstanceNames(t *testing.T) {
+	ops := &OpsRequest{}
+	ops.Spec.ClusterName = "mycluster"
+	for _, c := range []struct {
+		restart Restart
+		valid   bool
+	}{
+		{Restart{ComponentOps: ComponentOps{ComponentName: "mysql"}}, true},
+		{Restart{ComponentOps: ComponentOps{ComponentName: "mysql"}, InstanceNames: []string{"mycluster-mysql-1"}}, true},
+		{Restart{ComponentOps: ComponentOps{ComponentName: "mysql"}, InstanceNames: []string{"mycluster-proxy-0"}}, false},
+		{Restart{ComponentOps: ComponentOps{ComponentName: "mysql"}, InstanceNames: []string{"mycluster-mysql-0", "mycluster-mysql-0"}}, false},
+		{Restart{ComponentOps: ComponentOps{ComponentName: "mysql"}, Strategy: CanaryRestartStrategy, InstanceNames: []string{"mycluster-mysql-0"}}, false},
+	} {
+		if err := ops.validateRestartInstanceNames(c.restart); (err == nil) != c.valid {
+			t.Errorf("expected the restart %v to be valid: %t, but got error: %v", c.restart, c.valid, err)
+		}
+	}
+}
+
+func TestValidateVerticalScalingInstances(t *testing.T) {
+	cluster := &Cluster{}
+	cluster.Name = "mycluster"
+	cluster.Spec.ComponentSpecs = []ClusterComponentSpec{
+		{Name: "mysql", Instances: []InstanceTemplate{{Name: "large"}}},
+	}
+	ops := &OpsRequest{}
+	ops.Spec.ClusterName = cluster.Name
+	ops.Spec.Type = VerticalScalingType
+	for _, c := range []struct {
+		instances []InstanceResourceTemplate
+		valid     bool
+	}{
+		{nil, true},
+		{[]InstanceResourceTemplate{{Name: "large"}}, true},
+		{[]InstanceResourceTemplate{{Name: "larg"}}, false},
+		{[]InstanceResourceTemplate{{Name: "large"}, {Name: "small"}}, false},
+	} {
+		ops.Spec.VerticalScalingList = []VerticalScaling{
+			{ComponentOps: ComponentOps{ComponentName: "mysql"}, Instances: c.instances},
+		}
+		if err := ops.validateVerticalScaling(cluster); (err == nil) != c.valid {
+			t.Errorf("expected the instances %v to be valid: %t, but got error: %v", c.instances, c.valid, err)
+		}
+	}
+}
+
+func TestScaleOutGetNewInstances(t *testing.T) {
+	scaleOut := ScaleOut{
+		NewInstances: []InstanceTemplate{{Name: "large"}},
+		Placements: []ScaleOutPlacement{
+			{Name: "zone-a", Replicas: 2, Zone: "zone-a"},
+			{Name: "ssd", Replicas: 1, NodeSelector: map[string]string{"disk": "ssd"}},
+		},
+	}
+	newInstances := scaleOut.GetNewInstances()
+	if len(newInstances) != 3 {
+		t.Fatalf("expected 3 new instance templates, but got %d", len(newInstances))
+	}
+	zoneA := newInstances[1]
+	if zoneA.Name != "zone-a" || zoneA.GetReplicas() != 2 {
+		t.Errorf("unexpected instance template %v for the placement zone-a", zoneA)
+	}
+	if zoneA.SchedulingPolicy.NodeSelector["topology.kubernetes.io/zone"] != "zone-a" {
+		t.Errorf("expected the instance template to be pinned to zone-a, but got %v", zoneA.SchedulingPolicy.NodeSelector)
+	}
+	ssd := newInstances[2]
+	if ssd.SchedulingPolicy.NodeSelector["disk"] != "ssd" || len(ssd.SchedulingPolicy.NodeSelector) != 1 {
+		t.Errorf("unexpected node selector %v for the placement ssd", ssd.SchedulingPolicy.NodeSelector)
+	}
+	scaleOut.Placements[1].NodeSelector["disk"] = "hdd"
+	if ssd.SchedulingPolicy.NodeSelector["disk"] != "ssd" {
+		t.Error("expected the node selector of the instance template not to share the placement's")
+	}
+}
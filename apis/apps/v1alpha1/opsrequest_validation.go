@@ -33,6 +33,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 const (
@@ -119,6 +120,12 @@ func (r *OpsRequest) validateClusterPhase(cluster *Cluster) error {
 func (r *OpsRequest) validateOps(ctx context.Context,
 	k8sClient client.Client,
 	cluster *Cluster) error {
+	if r.Spec.DryRun && !slices.Contains([]OpsType{HorizontalScalingType, VerticalScalingType, ReconfiguringType}, r.Spec.Type) {
+		return fmt.Errorf(`the dry run is not supported by the OpsRequest of type "%s"`, r.Spec.Type)
+	}
+	if err := r.validateExecutionOrder(); err != nil {
+		return err
+	}
 	// Check whether the corresponding attribute is legal according to the operation type
 	switch r.Spec.Type {
 	case UpgradeType:
@@ -129,8 +136,14 @@ func (r *OpsRequest) validateOps(ctx context.Context,
 		return r.validateHorizontalScaling(ctx, k8sClient, cluster)
 	case VolumeExpansionType:
 		return r.validateVolumeExpansion(ctx, k8sClient, cluster)
+	case VolumeTuningType:
+		return r.validateVolumeTuning(cluster)
 	case RestartType:
 		return r.validateRestart(cluster)
+	case StopType:
+		return r.checkComponentExistence(cluster, r.Spec.StopList)
+	case StartType:
+		return r.checkComponentExistence(cluster, r.Spec.StartList)
 	case ReconfiguringType:
 		return r.validateReconfigure(ctx, k8sClient, cluster)
 	case SwitchoverType:
@@ -141,6 +154,16 @@ func (r *OpsRequest) validateOps(ctx context.Context,
 		return r.validateExpose(ctx, cluster)
 	case RebuildInstanceType:
 		return r.validateRebuildInstance(cluster)
+	case PurgeOfflineInstancesType:
+		return r.validatePurgeOfflineInstances(cluster)
+	case ShardingConversionType:
+		return r.validateShardingConversion(cluster)
+	case RebalanceType:
+		return r.validateRebalance(cluster)
+	case RollbackType:
+		return r.validateRollback(ctx, k8sClient)
+	case ExternalType:
+		return r.validateExternal(ctx, k8sClient, cluster)
 	}
 	return nil
 }
@@ -187,13 +210,174 @@ func (r *OpsRequest) validateRebuildInstance(cluster *Cluster) error {
 	return r.checkComponentExistence(cluster, compOpsList)
 }
 
+// validatePurgeOfflineInstances validates spec.purgeOfflineInstances
+func (r *OpsRequest) validatePurgeOfflineInstances(cluster *Cluster) error {
+	purgeList := r.Spec.PurgeOfflineInstancesList
+	if len(purgeList) == 0 {
+		return notEmptyError("spec.purgeOfflineInstances")
+	}
+	var compOpsList []ComponentOps
+	for _, v := range purgeList {
+		compOpsList = append(compOpsList, v.ComponentOps)
+		if v.OfflineLongerThan.Duration <= 0 {
+			return fmt.Errorf(`offlineLongerThan of component "%s" must be greater than 0`, v.ComponentName)
+		}
+		compSpec := cluster.Spec.GetComponentByName(v.ComponentName)
+		if compSpec == nil {
+			continue
+		}
+		for _, insName := range v.InstanceNames {
+			if !slices.Contains(compSpec.OfflineInstances, insName) {
+				return fmt.Errorf(`instance "%s" is not an offline instance of component "%s"`, insName, v.ComponentName)
+			}
+		}
+	}
+	return r.checkComponentExistence(cluster, compOpsList)
+}
+
+// validateShardingConversion validates spec.shardingConversion
+func (r *OpsRequest) validateShardingConversion(cluster *Cluster) error {
+	conversion := r.Spec.ShardingConversion
+	if conversion == nil {
+		return notEmptyError("spec.shardingConversion")
+	}
+	if len(cluster.Spec.ClusterDefRef) > 0 {
+		return fmt.Errorf("the cluster which refers to a ClusterDefinition does not support the sharding conversion")
+	}
+	compSpec := cluster.Spec.GetComponentByName(conversion.ComponentName)
+	if compSpec == nil {
+		return fmt.Errorf(`component "%s" not found in cluster.spec.componentSpecs`, conversion.ComponentName)
+	}
+	if len(compSpec.ComponentDef) == 0 {
+		return fmt.Errorf(`the componentDef of component "%s" is required for the sharding conversion`, conversion.ComponentName)
+	}
+	if conversion.ShardingName == conversion.ComponentName {
+		return fmt.Errorf(`the sharding name "%s" can not be the same as the name of the source component`, conversion.ShardingName)
+	}
+	for _, spec := range cluster.Spec.ComponentSpecs {
+		if spec.Name == conversion.ShardingName {
+			return fmt.Errorf(`the sharding name "%s" conflicts with the component in cluster.spec.componentSpecs`, conversion.ShardingName)
+		}
+	}
+	// the sharding is created by the operation, so it can only be checked before the operation starts.
+	if r.Status.Phase == "" || r.Status.Phase == OpsPendingPhase {
+		if cluster.Spec.GetShardingByName(conversion.ShardingName) != nil {
+			return fmt.Errorf(`the sharding "%s" already exists in cluster.spec.shardingSpecs`, conversion.ShardingName)
+		}
+	}
+	return nil
+}
+
+// validateRebalance validates spec.rebalance
+func (r *OpsRequest) validateRebalance(cluster *Cluster) error {
+	rebalanceList := r.Spec.RebalanceList
+	if len(rebalanceList) == 0 {
+		return notEmptyError("spec.rebalance")
+	}
+	for _, v := range rebalanceList {
+		if cluster.Spec.GetShardingByName(v.ComponentName) == nil {
+			return fmt.Errorf(`sharding "%s" not found in cluster.spec.shardingSpecs`, v.ComponentName)
+		}
+	}
+	return nil
+}
+
 // validateUpgrade validates spec.restart
 func (r *OpsRequest) validateRestart(cluster *Cluster) error {
 	restartList := r.Spec.RestartList
 	if len(restartList) == 0 {
 		return notEmptyError("spec.restart")
 	}
-	return r.checkComponentExistence(cluster, restartList)
+	var compOpsList []ComponentOps
+	for _, v := range restartList {
+		compOpsList = append(compOpsList, v.ComponentOps)
+		if err := r.validateRestartInstanceNames(v); err != nil {
+			return err
+		}
+		if v.Strategy != CanaryRestartStrategy {
+			if v.CanaryCount != nil || v.CanaryPercent != nil {
+				return fmt.Errorf(`canaryCount and canaryPercent of component "%s" can only be specified with the "%s" strategy`,
+					v.ComponentName, CanaryRestartStrategy)
+			}
+			continue
+		}
+		if v.CanaryCount != nil && v.CanaryPercent != nil {
+			return fmt.Errorf(`canaryCount and canaryPercent of component "%s" can not be specified at the same time`, v.ComponentName)
+		}
+	}
+	return r.checkComponentExistence(cluster, compOpsList)
+}
+
+// validateRestartInstanceNames validates the instances to restart belong to the component or the sharding.
+func (r *OpsRequest) validateRestartInstanceNames(restart Restart) error {
+	if len(restart.InstanceNames) == 0 {
+		return nil
+	}
+	if restart.Strategy == CanaryRestartStrategy {
+		return fmt.Errorf(`instanceNames of component "%s" can not be specified with the "%s" strategy`,
+			restart.ComponentName, CanaryRestartStrategy)
+	}
+	prefix := fmt.Sprintf("%s-%s-", r.Spec.GetClusterName(), restart.ComponentName)
+	names := map[string]struct{}{}
+	for _, name := range restart.InstanceNames {
+		if !strings.HasPrefix(name, prefix) {
+			return fmt.Errorf(`the instance "%s" does not belong to the component "%s"`, name, restart.ComponentName)
+		}
+		if _, ok := names[name]; ok {
+			return fmt.Errorf(`duplicated instance "%s" of component "%s"`, name, restart.ComponentName)
+		}
+		names[name] = struct{}{}
+	}
+	return nil
+}
+
+// validateRollback validates spec.rollback
+// validateExternal validates spec.external
+func (r *OpsRequest) validateExternal(ctx context.Context, k8sClient client.Client, cluster *Cluster) error {
+	externalOps := r.Spec.ExternalOps
+	if externalOps == nil || externalOps.HandlerName == "" {
+		return notEmptyError("spec.external.handlerName")
+	}
+	handler := &ExternalOpsHandler{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: externalOps.HandlerName}, handler); err != nil {
+		return err
+	}
+	fromPhases := handler.Spec.FromClusterPhases
+	if len(fromPhases) == 0 {
+		fromPhases = GetClusterUpRunningPhases()
+	}
+	if !r.Force() && !slices.Contains(fromPhases, cluster.Status.Phase) {
+		return fmt.Errorf(`the ExternalOpsHandler "%s" requires the cluster to be in phases %v, but it is "%s"`,
+			handler.Name, fromPhases, cluster.Status.Phase)
+	}
+	return nil
+}
+
+func (r *OpsRequest) validateRollback(ctx context.Context, k8sClient client.Client) error {
+	rollback := r.Spec.Rollback
+	if rollback == nil || rollback.OpsRequestName == "" {
+		return notEmptyError("spec.rollback.opsRequestName")
+	}
+	targetOps := &OpsRequest{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: rollback.OpsRequestName, Namespace: r.Namespace}, targetOps); err != nil {
+		return err
+	}
+	if targetOps.Spec.GetClusterName() != r.Spec.GetClusterName() {
+		return fmt.Errorf(`the OpsRequest "%s" does not belong to the cluster "%s"`, targetOps.Name, r.Spec.GetClusterName())
+	}
+	if !slices.Contains([]OpsType{HorizontalScalingType, VerticalScalingType, ReconfiguringType}, targetOps.Spec.Type) {
+		return fmt.Errorf(`the OpsRequest "%s" of type "%s" can not be rolled back`, targetOps.Name, targetOps.Spec.Type)
+	}
+	if targetOps.Spec.DryRun {
+		return fmt.Errorf(`the dry-run OpsRequest "%s" can not be rolled back`, targetOps.Name)
+	}
+	if targetOps.Status.Phase != OpsSucceedPhase {
+		return fmt.Errorf(`only the succeeded OpsRequest can be rolled back, but the phase of "%s" is "%s"`, targetOps.Name, targetOps.Status.Phase)
+	}
+	if len(targetOps.Status.LastConfiguration.Components) == 0 {
+		return fmt.Errorf(`the OpsRequest "%s" has no last configuration to roll back to`, targetOps.Name)
+	}
+	return nil
 }
 
 // validateUpgrade validates spec.clusterOps.upgrade
@@ -238,6 +422,17 @@ func (r *OpsRequest) validateVerticalScaling(cluster *Cluster) error {
 		if invalidValue, err := compareRequestsAndLimits(v.ResourceRequirements); err != nil {
 			return invalidValueError(invalidValue, err.Error())
 		}
+		for _, ins := range v.Instances {
+			if invalidValue, err := validateVerticalResourceList(ins.Requests); err != nil {
+				return invalidValueError(invalidValue, err.Error())
+			}
+			if invalidValue, err := validateVerticalResourceList(ins.Limits); err != nil {
+				return invalidValueError(invalidValue, err.Error())
+			}
+			if invalidValue, err := compareRequestsAndLimits(ins.ResourceRequirements); err != nil {
+				return invalidValueError(invalidValue, err.Error())
+			}
+		}
 	}
 	return r.checkComponentExistence(cluster, compOpsList)
 }
@@ -324,7 +519,7 @@ func compareQuantity(requestQuantity, limitQuantity *resource.Quantity) bool {
 }
 
 // validateHorizontalScaling validates api when spec.type is HorizontalScaling
-func (r *OpsRequest) validateHorizontalScaling(_ context.Context, _ client.Client, cluster *Cluster) error {
+func (r *OpsRequest) validateHorizontalScaling(ctx context.Context, cli client.Client, cluster *Cluster) error {
 	horizontalScalingList := r.Spec.HorizontalScalingList
 	if len(horizontalScalingList) == 0 {
 		return notEmptyError("spec.horizontalScaling")
@@ -343,6 +538,9 @@ func (r *OpsRequest) validateHorizontalScaling(_ context.Context, _ client.Clien
 			if err := r.validateHorizontalScalingSpec(hScale, comSpec, cluster.Name, false); err != nil {
 				return err
 			}
+			if err := r.validateHorizontalScalingMinReplicas(ctx, cli, cluster, hScale, comSpec, false); err != nil {
+				return err
+			}
 		}
 	}
 	for _, shardingSpec := range cluster.Spec.ShardingSpecs {
@@ -350,11 +548,91 @@ func (r *OpsRequest) validateHorizontalScaling(_ context.Context, _ client.Clien
 			if err := r.validateHorizontalScalingSpec(hScale, shardingSpec.Template, cluster.Name, true); err != nil {
 				return err
 			}
+			if err := r.validateHorizontalScalingMinReplicas(ctx, cli, cluster, hScale, shardingSpec.Template, true); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateHorizontalScalingMinReplicas rejects the horizontal scaling that would drop the replicas of the component
+// below the minimum declared in its ComponentDefinition, unless the OpsRequest is forced.
+func (r *OpsRequest) validateHorizontalScalingMinReplicas(ctx context.Context,
+	cli client.Client,
+	cluster *Cluster,
+	hScale HorizontalScaling,
+	compSpec ClusterComponentSpec,
+	isSharding bool) error {
+	if r.Force() || !viper.GetBool(constant.FeatureGateReplicasProtection) {
+		return nil
+	}
+	compDefName := compSpec.ComponentDef
+	if compDefName == "" && !isSharding {
+		// the component is created from the ClusterDefinition, get the resolved definition from the Component object.
+		comp := &Component{}
+		if err := cli.Get(ctx, types.NamespacedName{Namespace: cluster.Namespace, Name: constant.GenerateClusterComponentName(cluster.Name, hScale.ComponentName)}, comp); err != nil {
+			return client.IgnoreNotFound(err)
 		}
+		compDefName = comp.Spec.CompDef
+	}
+	if compDefName == "" {
+		return nil
+	}
+	compDef, err := getComponentDefByName(ctx, cli, compDefName)
+	if err != nil {
+		return err
+	}
+	if compDef.Spec.ReplicasLimit == nil {
+		return nil
+	}
+	if lastCompConfiguration, ok := r.Status.LastConfiguration.Components[hScale.ComponentName]; ok && lastCompConfiguration.Replicas != nil {
+		compSpec.Replicas = *lastCompConfiguration.Replicas
+	}
+	replicas := r.GetHorizontalScalingExpectedReplicas(cluster.Name, hScale, compSpec.Replicas)
+	minReplicas := compDef.Spec.ReplicasLimit.MinReplicas
+	if replicas < minReplicas {
+		return fmt.Errorf(`the replicas of component "%s" can't be less than %d declared in the ComponentDefinition "%s", `+
+			`but it would be %d after the horizontal scaling, set "spec.force" to true to bypass the check`,
+			hScale.ComponentName, minReplicas, compDefName, replicas)
 	}
 	return nil
 }
 
+// GetHorizontalScalingExpectedReplicas gets the expected replicas of the component after the horizontal scaling.
+func (r *OpsRequest) GetHorizontalScalingExpectedReplicas(clusterName string, hScale HorizontalScaling, compReplicas int32) int32 {
+	if hScale.Replicas != nil {
+		return *hScale.Replicas
+	}
+	getReplicaChanges := func(replicaChanger ReplicaChanger, newInstances []InstanceTemplate, offlineOrOnlineInsNames []string) int32 {
+		if replicaChanger.ReplicaChanges != nil {
+			return *replicaChanger.ReplicaChanges
+		}
+		allReplicaChanges := int32(0)
+		insTplSet := sets.New[string]()
+		for _, v := range replicaChanger.Instances {
+			insTplSet.Insert(v.Name)
+			allReplicaChanges += v.ReplicaChanges
+		}
+		for insTplName, replicaCount := range r.CountOfflineOrOnlineInstances(clusterName, hScale.ComponentName, offlineOrOnlineInsNames) {
+			if !insTplSet.Has(insTplName) {
+				allReplicaChanges += replicaCount
+			}
+		}
+		for _, v := range newInstances {
+			allReplicaChanges += v.GetReplicas()
+		}
+		return allReplicaChanges
+	}
+	if hScale.ScaleOut != nil {
+		compReplicas += getReplicaChanges(hScale.ScaleOut.ReplicaChanger, hScale.ScaleOut.GetNewInstances(), hScale.ScaleOut.OfflineInstancesToOnline)
+	}
+	if hScale.ScaleIn != nil {
+		compReplicas -= getReplicaChanges(hScale.ScaleIn.ReplicaChanger, nil, hScale.ScaleIn.OnlineInstancesToOffline)
+	}
+	return compReplicas
+}
+
 // CountOfflineOrOnlineInstances calculate the number of instances that need to be brought online and offline corresponding to the instance template name.
 func (r *OpsRequest) CountOfflineOrOnlineInstances(clusterName, componentName string, hScaleInstanceNames []string) map[string]int32 {
 	offlineOrOnlineInsCountMap := map[string]int32{}
@@ -427,10 +705,15 @@ func (r *OpsRequest) validateHorizontalScalingSpec(hScale HorizontalScaling, com
 					replicaCount, replicaCount, insTplName, hScaleInstanceFieldName)
 			}
 		}
+		newInsTplNames := sets.New[string]()
 		for _, insTpl := range newInstances {
 			if _, ok := compInsTplMap[insTpl.Name]; ok {
 				return fmt.Errorf(`new instance template "%s" already exists in component "%s"`, insTpl.Name, hScale.ComponentName)
 			}
+			if newInsTplNames.Has(insTpl.Name) {
+				return fmt.Errorf(`%s new instance template "%s" is duplicated in "newInstances" and "placements"`, msgPrefix, insTpl.Name)
+			}
+			newInsTplNames.Insert(insTpl.Name)
 			allReplicaChanges += insTpl.GetReplicas()
 		}
 		if replicaChanger.ReplicaChanges != nil && allReplicaChanges > *replicaChanger.ReplicaChanges {
@@ -447,7 +730,7 @@ func (r *OpsRequest) validateHorizontalScalingSpec(hScale HorizontalScaling, com
 		}
 	}
 	if scaleOut != nil {
-		if err := validateHScaleOperation(scaleOut.ReplicaChanger, scaleOut.NewInstances, scaleOut.OfflineInstancesToOnline, false); err != nil {
+		if err := validateHScaleOperation(scaleOut.ReplicaChanger, scaleOut.GetNewInstances(), scaleOut.OfflineInstancesToOnline, false); err != nil {
 			return err
 		}
 		if len(scaleOut.OfflineInstancesToOnline) > 0 {
@@ -479,6 +762,12 @@ func (r *OpsRequest) validateVolumeExpansion(ctx context.Context, cli client.Cli
 		if err := r.checkInstanceTemplate(cluster, v.ComponentOps, instanceNames); err != nil {
 			return err
 		}
+		if v.Shrink && len(v.Instances) > 0 {
+			return fmt.Errorf(`shrinking the volumes of the instance templates is not supported in component "%s"`, v.ComponentName)
+		}
+		if v.Shrink && cluster.Spec.GetShardingByName(v.ComponentName) != nil {
+			return fmt.Errorf(`shrinking the volumes of the sharding component "%s" is not supported`, v.ComponentName)
+		}
 	}
 	if err := r.checkComponentExistence(cluster, compOpsList); err != nil {
 		return err
@@ -486,6 +775,40 @@ func (r *OpsRequest) validateVolumeExpansion(ctx context.Context, cli client.Cli
 	return r.checkVolumesAllowExpansion(ctx, cli, cluster)
 }
 
+// validateVolumeTuning validates volumeTuning api when spec.type is VolumeTuning.
+func (r *OpsRequest) validateVolumeTuning(cluster *Cluster) error {
+	volumeTuningList := r.Spec.VolumeTuningList
+	if len(volumeTuningList) == 0 {
+		return notEmptyError("spec.volumeTuning")
+	}
+	compOpsList := make([]ComponentOps, len(volumeTuningList))
+	for i, v := range volumeTuningList {
+		compOpsList[i] = v.ComponentOps
+	}
+	if err := r.checkComponentExistence(cluster, compOpsList); err != nil {
+		return err
+	}
+	for _, v := range volumeTuningList {
+		compSpec := cluster.Spec.GetComponentByName(v.ComponentName)
+		if compSpec == nil {
+			compSpec = &cluster.Spec.GetShardingByName(v.ComponentName).Template
+		}
+		vctNames := sets.New[string]()
+		for _, vct := range compSpec.VolumeClaimTemplates {
+			vctNames.Insert(vct.Name)
+		}
+		for _, vct := range v.VolumeClaimTemplates {
+			if !vctNames.Has(vct.Name) {
+				return fmt.Errorf(`volumeClaimTemplate "%s" not found in component "%s"`, vct.Name, v.ComponentName)
+			}
+			if vct.Performance.IOPS == nil && vct.Performance.Throughput == nil {
+				return fmt.Errorf(`neither iops nor throughput is specified for volumeClaimTemplate "%s" of component "%s"`, vct.Name, v.ComponentName)
+			}
+		}
+	}
+	return nil
+}
+
 // validateSwitchover validates switchover api when spec.type is Switchover.
 func (r *OpsRequest) validateSwitchover(ctx context.Context, cli client.Client, cluster *Cluster) error {
 	switchoverList := r.Spec.SwitchoverList
@@ -566,6 +889,8 @@ func (r *OpsRequest) checkVolumesAllowExpansion(ctx context.Context, cli client.
 		allowExpansion      bool
 		requestStorage      resource.Quantity
 		isShardingComponent bool
+		allowShrink         bool
+		shrink              bool
 	}
 
 	vols := make(map[string]map[string]Entity)
@@ -577,20 +902,20 @@ func (r *OpsRequest) checkVolumesAllowExpansion(ctx context.Context, cli client.
 		}
 		return fmt.Sprintf("%s%s", componentName, templateKey)
 	}
-	setVols := func(vcts []OpsRequestVolumeClaimTemplate, componentName, templateName string) {
+	setVols := func(vcts []OpsRequestVolumeClaimTemplate, componentName, templateName string, allowShrink bool) {
 		for _, vct := range vcts {
 			key := getKey(componentName, templateName)
 			if _, ok := vols[key]; !ok {
 				vols[key] = make(map[string]Entity)
 			}
-			vols[key][vct.Name] = Entity{false, nil, false, vct.Storage, false}
+			vols[key][vct.Name] = Entity{requestStorage: vct.Storage, allowShrink: allowShrink}
 		}
 	}
 
 	for _, comp := range r.Spec.VolumeExpansionList {
-		setVols(comp.VolumeClaimTemplates, comp.ComponentOps.ComponentName, "")
+		setVols(comp.VolumeClaimTemplates, comp.ComponentOps.ComponentName, "", comp.Shrink)
 		for _, ins := range comp.Instances {
-			setVols(ins.VolumeClaimTemplates, comp.ComponentOps.ComponentName, ins.Name)
+			setVols(ins.VolumeClaimTemplates, comp.ComponentOps.ComponentName, ins.Name, false)
 		}
 	}
 	fillVol := func(vct ClusterComponentVolumeClaimTemplate, key string, isShardingComp bool) {
@@ -634,10 +959,15 @@ func (r *OpsRequest) checkVolumesAllowExpansion(ctx context.Context, cli client.
 			if !e.existInSpec {
 				continue
 			}
-			e.storageClassName, err = r.getSCNameByPvcAndCheckStorageSize(ctx, cli, key, vname, e.isShardingComponent, e.requestStorage)
+			e.storageClassName, e.shrink, err = r.getSCNameByPvcAndCheckStorageSize(ctx, cli, key, vname, e.isShardingComponent, e.requestStorage, e.allowShrink)
 			if err != nil {
 				return err
 			}
+			if e.shrink {
+				// the volumes are replaced rather than expanded
+				vols[key][vname] = e
+				continue
+			}
 			allowExpansion, err := r.checkStorageClassAllowExpansion(ctx, cli, e.storageClassName)
 			if err != nil {
 				continue // ignore the error and take it as not-supported
@@ -657,7 +987,7 @@ func (r *OpsRequest) checkVolumesAllowExpansion(ctx context.Context, cli client.
 			if !e.existInSpec {
 				notFound = append(notFound, vct)
 			}
-			if !e.allowExpansion {
+			if !e.allowExpansion && !e.shrink {
 				notSupport = append(notSupport, vct)
 				if e.storageClassName != nil {
 					notSupportSc = append(notSupportSc, *e.storageClassName)
@@ -698,13 +1028,15 @@ func (r *OpsRequest) checkStorageClassAllowExpansion(ctx context.Context,
 	return *storageClass.AllowVolumeExpansion, nil
 }
 
-// getSCNameByPvcAndCheckStorageSize gets the storageClassName by pvc and checks if the storage size is valid.
+// getSCNameByPvcAndCheckStorageSize gets the storageClassName by pvc and checks if the storage size is valid,
+// it returns whether the volumes are requested to shrink.
 func (r *OpsRequest) getSCNameByPvcAndCheckStorageSize(ctx context.Context,
 	cli client.Client,
 	componentName,
 	vctName string,
 	isShardingComponent bool,
-	requestStorage resource.Quantity) (*string, error) {
+	requestStorage resource.Quantity,
+	allowShrink bool) (*string, bool, error) {
 	matchingLabels := client.MatchingLabels{
 		constant.AppInstanceLabelKey:             r.Spec.GetClusterName(),
 		constant.VolumeClaimTemplateNameLabelKey: vctName,
@@ -716,18 +1048,21 @@ func (r *OpsRequest) getSCNameByPvcAndCheckStorageSize(ctx context.Context,
 	}
 	pvcList := &corev1.PersistentVolumeClaimList{}
 	if err := cli.List(ctx, pvcList, client.InNamespace(r.Namespace), matchingLabels); err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	if len(pvcList.Items) == 0 {
-		return nil, nil
+		return nil, false, nil
 	}
 	pvc := pvcList.Items[0]
 	previousValue := *pvc.Status.Capacity.Storage()
 	if requestStorage.Cmp(previousValue) < 0 {
-		return nil, fmt.Errorf(`requested storage size of volumeClaimTemplate "%s" can not less than status.capacity.storage "%s" `,
+		if allowShrink {
+			return pvc.Spec.StorageClassName, true, nil
+		}
+		return nil, false, fmt.Errorf(`requested storage size of volumeClaimTemplate "%s" can not less than status.capacity.storage "%s" `,
 			vctName, previousValue.String())
 	}
-	return pvc.Spec.StorageClassName, nil
+	return pvc.Spec.StorageClassName, false, nil
 }
 
 // validateDataScript validates the data script.
@@ -909,3 +1244,41 @@ func getComponentDefByName(ctx context.Context, cli client.Client, compDefName s
 	}
 	return compDef, nil
 }
+
+// validateExecutionOrder validates the execution order of the Components.
+func (r *OpsRequest) validateExecutionOrder() error {
+	executionOrder := r.Spec.ExecutionOrder
+	if executionOrder == nil {
+		return nil
+	}
+	var compNames []string
+	switch r.Spec.Type {
+	case HorizontalScalingType:
+		for _, v := range r.Spec.HorizontalScalingList {
+			compNames = append(compNames, v.ComponentName)
+		}
+	case RestartType:
+		for _, v := range r.Spec.RestartList {
+			compNames = append(compNames, v.ComponentName)
+		}
+	default:
+		return fmt.Errorf(`the execution order is not supported by the OpsRequest of type "%s"`, r.Spec.Type)
+	}
+	if len(executionOrder.Components) == 0 {
+		return nil
+	}
+	if executionOrder.Policy != SerialExecutionPolicy {
+		return fmt.Errorf(`spec.executionOrder.components can only be specified with the "%s" policy`, SerialExecutionPolicy)
+	}
+	ordered := map[string]bool{}
+	for _, compName := range executionOrder.Components {
+		if !slices.Contains(compNames, compName) {
+			return fmt.Errorf(`the component "%s" in spec.executionOrder.components is not found in the OpsRequest`, compName)
+		}
+		if ordered[compName] {
+			return fmt.Errorf(`the component "%s" in spec.executionOrder.components is duplicated`, compName)
+		}
+		ordered[compName] = true
+	}
+	return nil
+}
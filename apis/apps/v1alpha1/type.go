@@ -22,6 +22,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 const (
@@ -198,6 +199,16 @@ type ComponentConfigSpec struct {
 	// +listType=set
 	// +optional
 	ReRenderResourceTypes []RerenderResourceType `json:"reRenderResourceTypes,omitempty"`
+
+	// Specifies whether the rendered configuration files are stored in a Secret and mounted by a secret volume,
+	// instead of a ConfigMap.
+	//
+	// It is intended for the configuration files that embed credentials, e.g. the password of the replication user.
+	// The ConfigMap of the template is still created to carry the metadata of reconfiguring, without the file contents.
+	// It can't be used together with `injectEnvTo` or `asEnvFrom`.
+	//
+	// +optional
+	AsSecret *bool `json:"asSecret,omitempty"`
 }
 
 // RerenderResourceType defines the resource requirements for a component.
@@ -302,6 +313,11 @@ const (
 	ConditionTypeReplicasReady       = "ReplicasReady"       // ConditionTypeReplicasReady all pods of components are ready
 	ConditionTypeReady               = "Ready"               // ConditionTypeReady all components are running
 	ConditionTypeSwitchoverPrefix    = "Switchover-"         // ConditionTypeSwitchoverPrefix component status condition of switchover
+	ConditionTypeFinalBackup         = "FinalBackup"         // ConditionTypeFinalBackup the final backup taken before the cluster is deleted
+	ConditionTypeServiceVersionRisk  = "ServiceVersionRisk"  // ConditionTypeServiceVersionRisk the service version is end of life or has known vulnerabilities
+	ConditionTypeDiskPressure        = "DiskPressure"        // ConditionTypeDiskPressure the volumes of the component cross the critical usage threshold
+	ConditionTypeArtifactsRemoval    = "ArtifactsRemoval"    // ConditionTypeArtifactsRemoval the backup artifacts are being removed before the cluster is wiped out
+	ConditionTypeConverged           = "Converged"           // ConditionTypeConverged all the components have rolled out the current generation of the cluster
 )
 
 // Phase represents the current status of the ClusterDefinition CR.
@@ -320,11 +336,12 @@ const (
 
 // OpsPhase defines opsRequest phase.
 // +enum
-// +kubebuilder:validation:Enum={Pending,Creating,Running,Cancelling,Cancelled,Aborted,Failed,Succeed}
+// +kubebuilder:validation:Enum={Pending,PendingApproval,Scheduled,Creating,Running,WaitingForConfirm,Cancelling,Cancelled,Aborted,Failed,Succeed}
 type OpsPhase string
 
 const (
 	OpsPendingPhase    OpsPhase = "Pending"
+	OpsScheduledPhase  OpsPhase = "Scheduled"
 	OpsCreatingPhase   OpsPhase = "Creating"
 	OpsRunningPhase    OpsPhase = "Running"
 	OpsCancellingPhase OpsPhase = "Cancelling"
@@ -332,6 +349,11 @@ const (
 	OpsCancelledPhase  OpsPhase = "Cancelled"
 	OpsFailedPhase     OpsPhase = "Failed"
 	OpsAbortedPhase    OpsPhase = "Aborted"
+	// OpsWaitingForConfirmPhase indicates that the OpsRequest is paused and waiting for the approval to continue,
+	// e.g. after the canary instances are restarted.
+	OpsWaitingForConfirmPhase OpsPhase = "WaitingForConfirm"
+	// OpsPendingApprovalPhase indicates that the OpsRequest is validated and waiting for the approval to apply its action.
+	OpsPendingApprovalPhase OpsPhase = "PendingApproval"
 )
 
 // PodSelectionPolicy pod selection strategy.
@@ -367,7 +389,7 @@ const (
 
 // OpsType defines operation types.
 // +enum
-// +kubebuilder:validation:Enum={Upgrade,VerticalScaling,VolumeExpansion,HorizontalScaling,Restart,Reconfiguring,Start,Stop,Expose,Switchover,DataScript,Backup,Restore,RebuildInstance,Custom}
+// +kubebuilder:validation:Enum={Upgrade,VerticalScaling,VolumeExpansion,HorizontalScaling,Restart,Reconfiguring,Start,Stop,Expose,Switchover,DataScript,Backup,Restore,RebuildInstance,PurgeOfflineInstances,ShardingConversion,Rollback,Rebalance,VolumeTuning,Custom,External}
 type OpsType string
 
 const (
@@ -386,6 +408,18 @@ const (
 	RestoreType           OpsType = "Restore"
 	RebuildInstanceType   OpsType = "RebuildInstance" // RebuildInstance rebuilding an instance is very useful when a node is offline or an instance is unrecoverable.
 	CustomType            OpsType = "Custom"          // use opsDefinition
+	// PurgeOfflineInstancesType deletes the PVCs retained for the instances that have been offline for a long time.
+	PurgeOfflineInstancesType OpsType = "PurgeOfflineInstances"
+	// ShardingConversionType converts a standalone Component into a sharding of the same engine.
+	ShardingConversionType OpsType = "ShardingConversion"
+	// RollbackType reverts the Cluster to the last configuration recorded by a succeeded OpsRequest.
+	RollbackType OpsType = "Rollback"
+	// RebalanceType rebalances the data among the shards of the shardings by the rebalance action of the Component.
+	RebalanceType OpsType = "Rebalance"
+	// ExternalType dispatches the operation to the webhook registered by an ExternalOpsHandler.
+	ExternalType OpsType = "External"
+	// VolumeTuningType modifies the performance of the volumes online, such as the IOPS and throughput.
+	VolumeTuningType OpsType = "VolumeTuning"
 )
 
 // ComponentResourceKey defines the resource key of component, such as pod/pvc.
@@ -447,10 +481,31 @@ const (
 	BestEffortParallelStrategy UpdateStrategy = "BestEffortParallel"
 )
 
+// IPStack defines the IP families of the Services and the address family that the engines bind to.
+type IPStack struct {
+	// Specifies the IP family policy of the Services.
+	//
+	// - `SingleStack`: the Services are assigned a single IP family.
+	// - `PreferDualStack`: the Services are assigned both IPv4 and IPv6 families if the Kubernetes cluster supports.
+	// - `RequireDualStack`: the Services are assigned both IPv4 and IPv6 families, otherwise the creation fails.
+	//
+	// +kubebuilder:validation:Enum={SingleStack,PreferDualStack,RequireDualStack}
+	// +optional
+	IPFamilyPolicy *corev1.IPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
+
+	// Specifies the IP families of the Services, the first one is the primary family.
+	// The engines bind to the IPv6 wildcard address if any of the families is IPv6.
+	//
+	// +kubebuilder:validation:MaxItems=2
+	// +listType=atomic
+	// +optional
+	IPFamilies []corev1.IPFamily `json:"ipFamilies,omitempty"`
+}
+
 // TerminationPolicyType defines termination policy types.
 //
 // +enum
-// +kubebuilder:validation:Enum={DoNotTerminate,Halt,Delete,WipeOut}
+// +kubebuilder:validation:Enum={DoNotTerminate,Halt,Delete,WipeOut,Retain}
 type TerminationPolicyType string
 
 const (
@@ -465,6 +520,10 @@ const (
 
 	// WipeOut is based on Delete and wipe out all volume snapshots and snapshot data from backup storage location.
 	WipeOut TerminationPolicyType = "WipeOut"
+
+	// Retain is based on Delete, it retains the backups and archives the spec and the final backup of the cluster
+	// in a ConfigMap before the deletion.
+	Retain TerminationPolicyType = "Retain"
 )
 
 // PodAntiAffinity defines the pod anti-affinity strategy.
@@ -1167,3 +1226,143 @@ const (
 	HTTPProtocol  PrometheusScheme = "http"
 	HTTPSProtocol PrometheusScheme = "https"
 )
+
+// InstanceIPType defines the way to provide stable IPs for the instances.
+//
+// +enum
+// +kubebuilder:validation:Enum={Service,StaticIP}
+type InstanceIPType string
+
+const (
+	// InstanceIPService provides each instance a dedicated ClusterIP Service, whose cluster IP is kept across
+	// the recreation of the pod.
+	InstanceIPService InstanceIPType = "Service"
+
+	// InstanceIPStaticIP assigns each instance a static IP from a pool, through a secondary network of multus.
+	InstanceIPStaticIP InstanceIPType = "StaticIP"
+)
+
+// InstanceIPPolicy defines how the instances of a Component get stable IPs that survive the recreation of pods.
+type InstanceIPPolicy struct {
+	// Specifies the way to provide the stable IPs.
+	//
+	// +kubebuilder:validation:Required
+	Type InstanceIPType `json:"type"`
+
+	// Specifies the multus NetworkAttachmentDefinition that the static IPs belong to, in the format of "[namespace/]name".
+	// It is required if the type is StaticIP.
+	//
+	// +optional
+	NetworkName string `json:"networkName,omitempty"`
+
+	// Specifies the pool of the static IPs in CIDR notation, e.g. "10.1.1.10/24".
+	// Each instance is assigned an IP from the pool and keeps it until the instance is removed.
+	// It is required if the type is StaticIP.
+	//
+	// +optional
+	IPs []string `json:"ips,omitempty"`
+}
+
+// KernelTuning defines the kernel parameters and the huge pages required by a Component.
+type KernelTuning struct {
+	// Specifies the sysctls required by the database engine, e.g. "net.core.somaxconn" or "vm.overcommit_memory".
+	//
+	// The namespaced sysctls, e.g. "net.*", "kernel.shm*", "kernel.msg*", "kernel.sem" and "fs.mqueue.*", are set
+	// in the security context of the pods, and the unsafe ones among them must be allowed by the kubelet
+	// with "--allowed-unsafe-sysctls".
+	// The node-level sysctls, e.g. "vm.*", can not be set per pod, they are required from the nodes instead:
+	// the pods are only scheduled to the nodes labeled with "sysctl.kubeblocks.io/<name>=<value>",
+	// which are expected to be tuned by the administrator.
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Sysctls []corev1.Sysctl `json:"sysctls,omitempty"`
+
+	// Specifies the huge pages allocated to the main container of the Component.
+	//
+	// +optional
+	HugePages *HugePages `json:"hugePages,omitempty"`
+}
+
+// HugePages defines the huge pages allocated to a container.
+type HugePages struct {
+	// Specifies the size of a huge page.
+	//
+	// +kubebuilder:validation:Enum={2Mi,1Gi}
+	// +kubebuilder:validation:Required
+	PageSize string `json:"pageSize"`
+
+	// Specifies the amount of the huge pages, which should be a multiple of the page size.
+	// It is set as both the request and the limit of the "hugepages-<pageSize>" resource.
+	//
+	// +kubebuilder:validation:Required
+	Size resource.Quantity `json:"size"`
+
+	// Specifies the path to mount the huge pages in the main container.
+	//
+	// +kubebuilder:default="/dev/hugepages"
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+}
+
+// ComponentHostNetwork defines the host-network mode of a Component.
+type ComponentHostNetwork struct {
+	// Specifies whether to run the pods of the Component in the host network.
+	//
+	// The host ports of the container ports declared in the ComponentDefinition are allocated from the host port
+	// range of the operator, and they are unique across the clusters, so the instances co-scheduled on a node
+	// never conflict. The allocated ports are published in the connection contract Secret of the Cluster.
+	//
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Specifies the static host ports of the container ports, which are used instead of the allocated ones,
+	// e.g. to keep the well-known port of the engine.
+	// The static ports are rejected if they are used by the other components.
+	//
+	// +optional
+	StaticPorts []HostNetworkStaticPort `json:"staticPorts,omitempty"`
+}
+
+// HostNetworkStaticPort defines the static host port of a container port.
+type HostNetworkStaticPort struct {
+	// Specifies the name of the container.
+	//
+	// +kubebuilder:validation:Required
+	Container string `json:"container"`
+
+	// Specifies the name of the container port.
+	//
+	// +kubebuilder:validation:Required
+	Port string `json:"port"`
+
+	// Specifies the host port.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	HostPort int32 `json:"hostPort"`
+}
+
+// ReplicaWeight defines the weight of the replicas in the read traffic.
+type ReplicaWeight struct {
+	// Specifies the name of the instance template, the weight applies to all the replicas of the template.
+	// Either `template` or `instances` must be provided.
+	//
+	// +optional
+	Template string `json:"template,omitempty"`
+
+	// Specifies the names of the instances, the weight takes precedence over the one of their template.
+	// Either `template` or `instances` must be provided.
+	//
+	// +optional
+	Instances []string `json:"instances,omitempty"`
+
+	// Specifies the weight of the replicas.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	Weight int32 `json:"weight"`
+}
@@ -25,6 +25,7 @@ package v1alpha1
 
 import (
 	"github.com/apecloud/kubeblocks/apis/apps/v1beta1"
+	dataprotectionv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	workloadsv1alpha1 "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/api/core/v1"
@@ -52,6 +53,11 @@ func (in *Action) DeepCopyInto(out *Action) {
 		*out = new(PreConditionType)
 		**out = **in
 	}
+	if in.Sandbox != nil {
+		in, out := &in.Sandbox, &out.Sandbox
+		*out = new(ActionSandbox)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Action.
@@ -64,9 +70,63 @@ func (in *Action) DeepCopy() *Action {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionExecutionRecord) DeepCopyInto(out *ActionExecutionRecord) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionExecutionRecord.
+func (in *ActionExecutionRecord) DeepCopy() *ActionExecutionRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionExecutionRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionSandbox) DeepCopyInto(out *ActionSandbox) {
+	*out = *in
+	if in.RunAsUser != nil {
+		in, out := &in.RunAsUser, &out.RunAsUser
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RunAsGroup != nil {
+		in, out := &in.RunAsGroup, &out.RunAsGroup
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionSandbox.
+func (in *ActionSandbox) DeepCopy() *ActionSandbox {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionSandbox)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ActionTask) DeepCopyInto(out *ActionTask) {
 	*out = *in
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionTask.
@@ -177,6 +237,11 @@ func (in *BackupPolicy) DeepCopyInto(out *BackupPolicy) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupPolicy.
@@ -346,6 +411,11 @@ func (in *ClusterBackup) DeepCopyInto(out *ClusterBackup) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.FinalBackupOnDelete != nil {
+		in, out := &in.FinalBackupOnDelete, &out.FinalBackupOnDelete
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBackup.
@@ -399,6 +469,26 @@ func (in *ClusterComponentConfigSource) DeepCopy() *ClusterComponentConfigSource
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterComponentPersistence) DeepCopyInto(out *ClusterComponentPersistence) {
+	*out = *in
+	if in.AutoExpansion != nil {
+		in, out := &in.AutoExpansion, &out.AutoExpansion
+		*out = new(VolumeAutoExpansion)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterComponentPersistence.
+func (in *ClusterComponentPersistence) DeepCopy() *ClusterComponentPersistence {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterComponentPersistence)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterComponentService) DeepCopyInto(out *ClusterComponentService) {
 	*out = *in
@@ -479,6 +569,11 @@ func (in *ClusterComponentSpec) DeepCopyInto(out *ClusterComponentSpec) {
 		*out = new(SchedulingPolicy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SchedulingHints != nil {
+		in, out := &in.SchedulingHints, &out.SchedulingHints
+		*out = new(SchedulingHints)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Resources.DeepCopyInto(&out.Resources)
 	if in.VolumeClaimTemplates != nil {
 		in, out := &in.VolumeClaimTemplates, &out.VolumeClaimTemplates
@@ -557,11 +652,55 @@ func (in *ClusterComponentSpec) DeepCopyInto(out *ClusterComponentSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.InstanceIP != nil {
+		in, out := &in.InstanceIP, &out.InstanceIP
+		*out = new(InstanceIPPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Persistence != nil {
+		in, out := &in.Persistence, &out.Persistence
+		*out = new(ClusterComponentPersistence)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodTemplateOverlay != nil {
+		in, out := &in.PodTemplateOverlay, &out.PodTemplateOverlay
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KernelTuning != nil {
+		in, out := &in.KernelTuning, &out.KernelTuning
+		*out = new(KernelTuning)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostNetwork != nil {
+		in, out := &in.HostNetwork, &out.HostNetwork
+		*out = new(ComponentHostNetwork)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReplicaWeights != nil {
+		in, out := &in.ReplicaWeights, &out.ReplicaWeights
+		*out = make([]ReplicaWeight, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SidecarResources != nil {
+		in, out := &in.SidecarResources, &out.SidecarResources
+		*out = make([]SidecarResources, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.DisableExporter != nil {
 		in, out := &in.DisableExporter, &out.DisableExporter
 		*out = new(bool)
 		**out = **in
 	}
+	if in.BackupReplica != nil {
+		in, out := &in.BackupReplica, &out.BackupReplica
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Monitor != nil {
 		in, out := &in.Monitor, &out.Monitor
 		*out = new(bool)
@@ -638,6 +777,33 @@ func (in *ClusterComponentVolumeClaimTemplate) DeepCopy() *ClusterComponentVolum
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDNS) DeepCopyInto(out *ClusterDNS) {
+	*out = *in
+	if in.ServiceHostnameTemplates != nil {
+		in, out := &in.ServiceHostnameTemplates, &out.ServiceHostnameTemplates
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDNS.
+func (in *ClusterDNS) DeepCopy() *ClusterDNS {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDNS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterDefinition) DeepCopyInto(out *ClusterDefinition) {
 	*out = *in
@@ -807,239 +973,624 @@ func (in *ClusterObjectReference) DeepCopy() *ClusterObjectReference {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterResources) DeepCopyInto(out *ClusterResources) {
+func (in *ClusterPeering) DeepCopyInto(out *ClusterPeering) {
 	*out = *in
-	out.CPU = in.CPU.DeepCopy()
-	out.Memory = in.Memory.DeepCopy()
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterResources.
-func (in *ClusterResources) DeepCopy() *ClusterResources {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPeering.
+func (in *ClusterPeering) DeepCopy() *ClusterPeering {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterResources)
+	out := new(ClusterPeering)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterService) DeepCopyInto(out *ClusterService) {
-	*out = *in
-	in.Service.DeepCopyInto(&out.Service)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterService.
-func (in *ClusterService) DeepCopy() *ClusterService {
-	if in == nil {
-		return nil
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterPeering) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	out := new(ClusterService)
-	in.DeepCopyInto(out)
-	return out
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
+func (in *ClusterPeeringList) DeepCopyInto(out *ClusterPeeringList) {
 	*out = *in
-	if in.ShardingSpecs != nil {
-		in, out := &in.ShardingSpecs, &out.ShardingSpecs
-		*out = make([]ShardingSpec, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.ComponentSpecs != nil {
-		in, out := &in.ComponentSpecs, &out.ComponentSpecs
-		*out = make([]ClusterComponentSpec, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Services != nil {
-		in, out := &in.Services, &out.Services
-		*out = make([]ClusterService, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Affinity != nil {
-		in, out := &in.Affinity, &out.Affinity
-		*out = new(Affinity)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterPeering, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.SchedulingPolicy != nil {
-		in, out := &in.SchedulingPolicy, &out.SchedulingPolicy
-		*out = new(SchedulingPolicy)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.RuntimeClassName != nil {
-		in, out := &in.RuntimeClassName, &out.RuntimeClassName
-		*out = new(string)
-		**out = **in
-	}
-	if in.Backup != nil {
-		in, out := &in.Backup, &out.Backup
-		*out = new(ClusterBackup)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int32)
-		**out = **in
-	}
-	in.Resources.DeepCopyInto(&out.Resources)
-	in.Storage.DeepCopyInto(&out.Storage)
-	if in.Network != nil {
-		in, out := &in.Network, &out.Network
-		*out = new(ClusterNetwork)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
-func (in *ClusterSpec) DeepCopy() *ClusterSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPeeringList.
+func (in *ClusterPeeringList) DeepCopy() *ClusterPeeringList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterSpec)
+	out := new(ClusterPeeringList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterPeeringList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
+func (in *ClusterPeeringRemote) DeepCopyInto(out *ClusterPeeringRemote) {
 	*out = *in
-	if in.Components != nil {
-		in, out := &in.Components, &out.Components
-		*out = make(map[string]ClusterComponentStatus, len(*in))
-		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
-		}
-	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
-func (in *ClusterStatus) DeepCopy() *ClusterStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPeeringRemote.
+func (in *ClusterPeeringRemote) DeepCopy() *ClusterPeeringRemote {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterStatus)
+	out := new(ClusterPeeringRemote)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterStorage) DeepCopyInto(out *ClusterStorage) {
+func (in *ClusterPeeringReplicationService) DeepCopyInto(out *ClusterPeeringReplicationService) {
 	*out = *in
-	out.Size = in.Size.DeepCopy()
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStorage.
-func (in *ClusterStorage) DeepCopy() *ClusterStorage {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPeeringReplicationService.
+func (in *ClusterPeeringReplicationService) DeepCopy() *ClusterPeeringReplicationService {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterStorage)
+	out := new(ClusterPeeringReplicationService)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterSwitchPolicy) DeepCopyInto(out *ClusterSwitchPolicy) {
+func (in *ClusterPeeringSpec) DeepCopyInto(out *ClusterPeeringSpec) {
 	*out = *in
+	out.ReplicationService = in.ReplicationService
+	out.Remote = in.Remote
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSwitchPolicy.
-func (in *ClusterSwitchPolicy) DeepCopy() *ClusterSwitchPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPeeringSpec.
+func (in *ClusterPeeringSpec) DeepCopy() *ClusterPeeringSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterSwitchPolicy)
+	out := new(ClusterPeeringSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterTopology) DeepCopyInto(out *ClusterTopology) {
+func (in *ClusterPeeringStatus) DeepCopyInto(out *ClusterPeeringStatus) {
 	*out = *in
-	if in.Components != nil {
-		in, out := &in.Components, &out.Components
-		*out = make([]ClusterTopologyComponent, len(*in))
-		copy(*out, *in)
+	if in.Lag != nil {
+		in, out := &in.Lag, &out.Lag
+		*out = new(int64)
+		**out = **in
 	}
-	if in.Orders != nil {
-		in, out := &in.Orders, &out.Orders
-		*out = new(ClusterTopologyOrders)
-		(*in).DeepCopyInto(*out)
+	if in.LastLagProbeTime != nil {
+		in, out := &in.LastLagProbeTime, &out.LastLagProbeTime
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTopology.
-func (in *ClusterTopology) DeepCopy() *ClusterTopology {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPeeringStatus.
+func (in *ClusterPeeringStatus) DeepCopy() *ClusterPeeringStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterTopology)
+	out := new(ClusterPeeringStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterTopologyComponent) DeepCopyInto(out *ClusterTopologyComponent) {
+func (in *ClusterResources) DeepCopyInto(out *ClusterResources) {
 	*out = *in
+	out.CPU = in.CPU.DeepCopy()
+	out.Memory = in.Memory.DeepCopy()
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTopologyComponent.
-func (in *ClusterTopologyComponent) DeepCopy() *ClusterTopologyComponent {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterResources.
+func (in *ClusterResources) DeepCopy() *ClusterResources {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterTopologyComponent)
+	out := new(ClusterResources)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterTopologyOrders) DeepCopyInto(out *ClusterTopologyOrders) {
+func (in *ClusterService) DeepCopyInto(out *ClusterService) {
 	*out = *in
-	if in.Provision != nil {
-		in, out := &in.Provision, &out.Provision
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
+	in.Service.DeepCopyInto(&out.Service)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterService.
+func (in *ClusterService) DeepCopy() *ClusterService {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSet) DeepCopyInto(out *ClusterSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSet.
+func (in *ClusterSet) DeepCopy() *ClusterSet {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSetList) DeepCopyInto(out *ClusterSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSetList.
+func (in *ClusterSetList) DeepCopy() *ClusterSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSetMemberStatus) DeepCopyInto(out *ClusterSetMemberStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSetMemberStatus.
+func (in *ClusterSetMemberStatus) DeepCopy() *ClusterSetMemberStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSetMemberStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSetSpec) DeepCopyInto(out *ClusterSetSpec) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	in.Template.DeepCopyInto(&out.Template)
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(ClusterSetUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSetSpec.
+func (in *ClusterSetSpec) DeepCopy() *ClusterSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSetStatus) DeepCopyInto(out *ClusterSetStatus) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]ClusterSetMemberStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSetStatus.
+func (in *ClusterSetStatus) DeepCopy() *ClusterSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSetUpdateStrategy) DeepCopyInto(out *ClusterSetUpdateStrategy) {
+	*out = *in
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSetUpdateStrategy.
+func (in *ClusterSetUpdateStrategy) DeepCopy() *ClusterSetUpdateStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSetUpdateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSimulation) DeepCopyInto(out *ClusterSimulation) {
+	*out = *in
+	in.SimulationTime.DeepCopyInto(&out.SimulationTime)
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]SimulatedChange, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSimulation.
+func (in *ClusterSimulation) DeepCopy() *ClusterSimulation {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSimulation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
+	*out = *in
+	if in.ShardingSpecs != nil {
+		in, out := &in.ShardingSpecs, &out.ShardingSpecs
+		*out = make([]ShardingSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ComponentSpecs != nil {
+		in, out := &in.ComponentSpecs, &out.ComponentSpecs
+		*out = make([]ClusterComponentSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]ClusterService, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SchedulingPolicy != nil {
+		in, out := &in.SchedulingPolicy, &out.SchedulingPolicy
+		*out = new(SchedulingPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.IPStack != nil {
+		in, out := &in.IPStack, &out.IPStack
+		*out = new(IPStack)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(ClusterDNS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(ClusterBackup)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CloudTags != nil {
+		in, out := &in.CloudTags, &out.CloudTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.UpgradePolicy != nil {
+		in, out := &in.UpgradePolicy, &out.UpgradePolicy
+		*out = new(ClusterUpgradePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DisruptionWindows != nil {
+		in, out := &in.DisruptionWindows, &out.DisruptionWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaxOpsRequestHistory != nil {
+		in, out := &in.MaxOpsRequestHistory, &out.MaxOpsRequestHistory
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	in.Storage.DeepCopyInto(&out.Storage)
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = new(ClusterNetwork)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
+func (in *ClusterSpec) DeepCopy() *ClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
+	*out = *in
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make(map[string]ClusterComponentStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Simulation != nil {
+		in, out := &in.Simulation, &out.Simulation
+		*out = new(ClusterSimulation)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
+func (in *ClusterStatus) DeepCopy() *ClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStorage) DeepCopyInto(out *ClusterStorage) {
+	*out = *in
+	out.Size = in.Size.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStorage.
+func (in *ClusterStorage) DeepCopy() *ClusterStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSwitchPolicy) DeepCopyInto(out *ClusterSwitchPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSwitchPolicy.
+func (in *ClusterSwitchPolicy) DeepCopy() *ClusterSwitchPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSwitchPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTemplate) DeepCopyInto(out *ClusterTemplate) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplate.
+func (in *ClusterTemplate) DeepCopy() *ClusterTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTopology) DeepCopyInto(out *ClusterTopology) {
+	*out = *in
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]ClusterTopologyComponent, len(*in))
+		copy(*out, *in)
+	}
+	if in.Orders != nil {
+		in, out := &in.Orders, &out.Orders
+		*out = new(ClusterTopologyOrders)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTopology.
+func (in *ClusterTopology) DeepCopy() *ClusterTopology {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTopology)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTopologyComponent) DeepCopyInto(out *ClusterTopologyComponent) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTopologyComponent.
+func (in *ClusterTopologyComponent) DeepCopy() *ClusterTopologyComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTopologyComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTopologyOrders) DeepCopyInto(out *ClusterTopologyOrders) {
+	*out = *in
+	if in.Provision != nil {
+		in, out := &in.Provision, &out.Provision
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Terminate != nil {
 		in, out := &in.Terminate, &out.Terminate
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Update != nil {
-		in, out := &in.Update, &out.Update
+	if in.Update != nil {
+		in, out := &in.Update, &out.Update
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTopologyOrders.
+func (in *ClusterTopologyOrders) DeepCopy() *ClusterTopologyOrders {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTopologyOrders)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgradePolicy) DeepCopyInto(out *ClusterUpgradePolicy) {
+	*out = *in
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ComponentOrder != nil {
+		in, out := &in.ComponentOrder, &out.ComponentOrder
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTopologyOrders.
-func (in *ClusterTopologyOrders) DeepCopy() *ClusterTopologyOrders {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradePolicy.
+func (in *ClusterUpgradePolicy) DeepCopy() *ClusterUpgradePolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterTopologyOrders)
+	out := new(ClusterUpgradePolicy)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1116,6 +1667,11 @@ func (in *ComponentConfigSpec) DeepCopyInto(out *ComponentConfigSpec) {
 		*out = make([]RerenderResourceType, len(*in))
 		copy(*out, *in)
 	}
+	if in.AsSecret != nil {
+		in, out := &in.AsSecret, &out.AsSecret
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentConfigSpec.
@@ -1326,6 +1882,26 @@ func (in *ComponentDefinitionStatus) DeepCopy() *ComponentDefinitionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentHostNetwork) DeepCopyInto(out *ComponentHostNetwork) {
+	*out = *in
+	if in.StaticPorts != nil {
+		in, out := &in.StaticPorts, &out.StaticPorts
+		*out = make([]HostNetworkStaticPort, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentHostNetwork.
+func (in *ComponentHostNetwork) DeepCopy() *ComponentHostNetwork {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentHostNetwork)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentInfo) DeepCopyInto(out *ComponentInfo) {
 	*out = *in
@@ -1404,6 +1980,16 @@ func (in *ComponentLifecycleActions) DeepCopyInto(out *ComponentLifecycleActions
 		*out = new(LifecycleActionHandler)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Rebalance != nil {
+		in, out := &in.Rebalance, &out.Rebalance
+		*out = new(LifecycleActionHandler)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ShardRouting != nil {
+		in, out := &in.ShardRouting, &out.ShardRouting
+		*out = new(LifecycleActionHandler)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentLifecycleActions.
@@ -1609,6 +2195,11 @@ func (in *ComponentSpec) DeepCopyInto(out *ComponentSpec) {
 		*out = new(SchedulingPolicy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SchedulingHints != nil {
+		in, out := &in.SchedulingHints, &out.SchedulingHints
+		*out = new(SchedulingHints)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.TLSConfig != nil {
 		in, out := &in.TLSConfig, &out.TLSConfig
 		*out = new(TLSConfig)
@@ -1626,16 +2217,60 @@ func (in *ComponentSpec) DeepCopyInto(out *ComponentSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.InstanceIP != nil {
+		in, out := &in.InstanceIP, &out.InstanceIP
+		*out = new(InstanceIPPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.RuntimeClassName != nil {
 		in, out := &in.RuntimeClassName, &out.RuntimeClassName
 		*out = new(string)
 		**out = **in
 	}
+	if in.IPStack != nil {
+		in, out := &in.IPStack, &out.IPStack
+		*out = new(IPStack)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.DisableExporter != nil {
 		in, out := &in.DisableExporter, &out.DisableExporter
 		*out = new(bool)
 		**out = **in
 	}
+	if in.PodTemplateOverlay != nil {
+		in, out := &in.PodTemplateOverlay, &out.PodTemplateOverlay
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KernelTuning != nil {
+		in, out := &in.KernelTuning, &out.KernelTuning
+		*out = new(KernelTuning)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostNetwork != nil {
+		in, out := &in.HostNetwork, &out.HostNetwork
+		*out = new(ComponentHostNetwork)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReplicaWeights != nil {
+		in, out := &in.ReplicaWeights, &out.ReplicaWeights
+		*out = make([]ReplicaWeight, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SidecarResources != nil {
+		in, out := &in.SidecarResources, &out.SidecarResources
+		*out = make([]SidecarResources, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BackupReplica != nil {
+		in, out := &in.BackupReplica, &out.BackupReplica
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Stop != nil {
 		in, out := &in.Stop, &out.Stop
 		*out = new(bool)
@@ -1670,6 +2305,20 @@ func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.OfflineInstances != nil {
+		in, out := &in.OfflineInstances, &out.OfflineInstances
+		*out = make([]OfflineInstanceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ActionHistory != nil {
+		in, out := &in.ActionHistory, &out.ActionHistory
+		*out = make([]ActionExecutionRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatus.
@@ -1903,6 +2552,11 @@ func (in *ComponentVersionRelease) DeepCopyInto(out *ComponentVersionRelease) {
 			(*out)[key] = val
 		}
 	}
+	if in.CVEs != nil {
+		in, out := &in.CVEs, &out.CVEs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentVersionRelease.
@@ -2268,6 +2922,13 @@ func (in *ConfigurationItemDetail) DeepCopyInto(out *ConfigurationItemDetail) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.RoleOverlays != nil {
+		in, out := &in.RoleOverlays, &out.RoleOverlays
+		*out = make([]RoleConfigOverlay, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigurationItemDetail.
@@ -2436,6 +3097,27 @@ func (in *ConnectionCredentialAuth) DeepCopy() *ConnectionCredentialAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerAffinityTerm) DeepCopyInto(out *ConsumerAffinityTerm) {
+	*out = *in
+	in.LabelSelector.DeepCopyInto(&out.LabelSelector)
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerAffinityTerm.
+func (in *ConsumerAffinityTerm) DeepCopy() *ConsumerAffinityTerm {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerAffinityTerm)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerVars) DeepCopyInto(out *ContainerVars) {
 	*out = *in
@@ -2557,31 +3239,239 @@ func (in *CustomOpsComponent) DeepCopyInto(out *CustomOpsComponent) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomOpsComponent.
-func (in *CustomOpsComponent) DeepCopy() *CustomOpsComponent {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomOpsComponent.
+func (in *CustomOpsComponent) DeepCopy() *CustomOpsComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomOpsComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomParametersValidation) DeepCopyInto(out *CustomParametersValidation) {
+	*out = *in
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomParametersValidation.
+func (in *CustomParametersValidation) DeepCopy() *CustomParametersValidation {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomParametersValidation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Database) DeepCopyInto(out *Database) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Database.
+func (in *Database) DeepCopy() *Database {
+	if in == nil {
+		return nil
+	}
+	out := new(Database)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Database) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseGrant) DeepCopyInto(out *DatabaseGrant) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseGrant.
+func (in *DatabaseGrant) DeepCopy() *DatabaseGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseList) DeepCopyInto(out *DatabaseList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Database, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseList.
+func (in *DatabaseList) DeepCopy() *DatabaseList {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DatabaseList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseSpec) DeepCopyInto(out *DatabaseSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseSpec.
+func (in *DatabaseSpec) DeepCopy() *DatabaseSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseStatus) DeepCopyInto(out *DatabaseStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseStatus.
+func (in *DatabaseStatus) DeepCopy() *DatabaseStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseUser) DeepCopyInto(out *DatabaseUser) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseUser.
+func (in *DatabaseUser) DeepCopy() *DatabaseUser {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DatabaseUser) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseUserList) DeepCopyInto(out *DatabaseUserList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DatabaseUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseUserList.
+func (in *DatabaseUserList) DeepCopy() *DatabaseUserList {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseUserList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DatabaseUserList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseUserSpec) DeepCopyInto(out *DatabaseUserSpec) {
+	*out = *in
+	if in.Grants != nil {
+		in, out := &in.Grants, &out.Grants
+		*out = make([]DatabaseGrant, len(*in))
+		copy(*out, *in)
+	}
+	if in.PasswordConfig != nil {
+		in, out := &in.PasswordConfig, &out.PasswordConfig
+		*out = new(PasswordConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseUserSpec.
+func (in *DatabaseUserSpec) DeepCopy() *DatabaseUserSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CustomOpsComponent)
+	out := new(DatabaseUserSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CustomParametersValidation) DeepCopyInto(out *CustomParametersValidation) {
+func (in *DatabaseUserStatus) DeepCopyInto(out *DatabaseUserStatus) {
 	*out = *in
-	if in.Schema != nil {
-		in, out := &in.Schema, &out.Schema
-		*out = (*in).DeepCopy()
+	if in.Grants != nil {
+		in, out := &in.Grants, &out.Grants
+		*out = make([]DatabaseGrant, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomParametersValidation.
-func (in *CustomParametersValidation) DeepCopy() *CustomParametersValidation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseUserStatus.
+func (in *DatabaseUserStatus) DeepCopy() *DatabaseUserStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CustomParametersValidation)
+	out := new(DatabaseUserStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2711,6 +3601,157 @@ func (in *Expose) DeepCopy() *Expose {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalOps) DeepCopyInto(out *ExternalOps) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalOps.
+func (in *ExternalOps) DeepCopy() *ExternalOps {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalOps)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalOpsHandler) DeepCopyInto(out *ExternalOpsHandler) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalOpsHandler.
+func (in *ExternalOpsHandler) DeepCopy() *ExternalOpsHandler {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalOpsHandler)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ExternalOpsHandler) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalOpsHandlerClientConfig) DeepCopyInto(out *ExternalOpsHandlerClientConfig) {
+	*out = *in
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		*out = new(string)
+		**out = **in
+	}
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(ExternalOpsHandlerServiceReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalOpsHandlerClientConfig.
+func (in *ExternalOpsHandlerClientConfig) DeepCopy() *ExternalOpsHandlerClientConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalOpsHandlerClientConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalOpsHandlerList) DeepCopyInto(out *ExternalOpsHandlerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ExternalOpsHandler, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalOpsHandlerList.
+func (in *ExternalOpsHandlerList) DeepCopy() *ExternalOpsHandlerList {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalOpsHandlerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ExternalOpsHandlerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalOpsHandlerServiceReference) DeepCopyInto(out *ExternalOpsHandlerServiceReference) {
+	*out = *in
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalOpsHandlerServiceReference.
+func (in *ExternalOpsHandlerServiceReference) DeepCopy() *ExternalOpsHandlerServiceReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalOpsHandlerServiceReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalOpsHandlerSpec) DeepCopyInto(out *ExternalOpsHandlerSpec) {
+	*out = *in
+	in.ClientConfig.DeepCopyInto(&out.ClientConfig)
+	if in.FromClusterPhases != nil {
+		in, out := &in.FromClusterPhases, &out.FromClusterPhases
+		*out = make([]ClusterPhase, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalOpsHandlerSpec.
+func (in *ExternalOpsHandlerSpec) DeepCopy() *ExternalOpsHandlerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalOpsHandlerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HorizontalScaling) DeepCopyInto(out *HorizontalScaling) {
 	*out = *in
@@ -2730,6 +3771,25 @@ func (in *HorizontalScaling) DeepCopyInto(out *HorizontalScaling) {
 		*out = new(ScaleIn)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ProgressDeadlineSeconds != nil {
+		in, out := &in.ProgressDeadlineSeconds, &out.ProgressDeadlineSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PreScaleInActions != nil {
+		in, out := &in.PreScaleInActions, &out.PreScaleInActions
+		*out = make([]ScalingAction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PostScaleOutActions != nil {
+		in, out := &in.PostScaleOutActions, &out.PostScaleOutActions
+		*out = make([]ScalingAction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizontalScaling.
@@ -2784,6 +3844,21 @@ func (in *HostNetworkContainerPort) DeepCopy() *HostNetworkContainerPort {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostNetworkStaticPort) DeepCopyInto(out *HostNetworkStaticPort) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostNetworkStaticPort.
+func (in *HostNetworkStaticPort) DeepCopy() *HostNetworkStaticPort {
+	if in == nil {
+		return nil
+	}
+	out := new(HostNetworkStaticPort)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostNetworkVarSelector) DeepCopyInto(out *HostNetworkVarSelector) {
 	*out = *in
@@ -2821,6 +3896,47 @@ func (in *HostNetworkVars) DeepCopy() *HostNetworkVars {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HugePages) DeepCopyInto(out *HugePages) {
+	*out = *in
+	out.Size = in.Size.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HugePages.
+func (in *HugePages) DeepCopy() *HugePages {
+	if in == nil {
+		return nil
+	}
+	out := new(HugePages)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPStack) DeepCopyInto(out *IPStack) {
+	*out = *in
+	if in.IPFamilyPolicy != nil {
+		in, out := &in.IPFamilyPolicy, &out.IPFamilyPolicy
+		*out = new(v1.IPFamilyPolicy)
+		**out = **in
+	}
+	if in.IPFamilies != nil {
+		in, out := &in.IPFamilies, &out.IPFamilies
+		*out = make([]v1.IPFamily, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPStack.
+func (in *IPStack) DeepCopy() *IPStack {
+	if in == nil {
+		return nil
+	}
+	out := new(IPStack)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Instance) DeepCopyInto(out *Instance) {
 	*out = *in
@@ -2836,6 +3952,26 @@ func (in *Instance) DeepCopy() *Instance {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceIPPolicy) DeepCopyInto(out *InstanceIPPolicy) {
+	*out = *in
+	if in.IPs != nil {
+		in, out := &in.IPs, &out.IPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceIPPolicy.
+func (in *InstanceIPPolicy) DeepCopy() *InstanceIPPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceIPPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstanceReplicasTemplate) DeepCopyInto(out *InstanceReplicasTemplate) {
 	*out = *in
@@ -3001,6 +4137,31 @@ func (in *JSONPatchOperation) DeepCopy() *JSONPatchOperation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KernelTuning) DeepCopyInto(out *KernelTuning) {
+	*out = *in
+	if in.Sysctls != nil {
+		in, out := &in.Sysctls, &out.Sysctls
+		*out = make([]v1.Sysctl, len(*in))
+		copy(*out, *in)
+	}
+	if in.HugePages != nil {
+		in, out := &in.HugePages, &out.HugePages
+		*out = new(HugePages)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KernelTuning.
+func (in *KernelTuning) DeepCopy() *KernelTuning {
+	if in == nil {
+		return nil
+	}
+	out := new(KernelTuning)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LastComponentConfiguration) DeepCopyInto(out *LastComponentConfiguration) {
 	*out = *in
@@ -3052,6 +4213,13 @@ func (in *LastComponentConfiguration) DeepCopyInto(out *LastComponentConfigurati
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Configurations != nil {
+		in, out := &in.Configurations, &out.Configurations
+		*out = make([]LastConfigurationItem, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LastComponentConfiguration.
@@ -3086,6 +4254,35 @@ func (in *LastConfiguration) DeepCopy() *LastConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LastConfigurationItem) DeepCopyInto(out *LastConfigurationItem) {
+	*out = *in
+	if in.ConfigFileParams != nil {
+		in, out := &in.ConfigFileParams, &out.ConfigFileParams
+		*out = make(map[string]ConfigParams, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.RoleOverlays != nil {
+		in, out := &in.RoleOverlays, &out.RoleOverlays
+		*out = make([]RoleConfigOverlay, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LastConfigurationItem.
+func (in *LastConfigurationItem) DeepCopy() *LastConfigurationItem {
+	if in == nil {
+		return nil
+	}
+	out := new(LastConfigurationItem)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LegacyRenderedTemplateSpec) DeepCopyInto(out *LegacyRenderedTemplateSpec) {
 	*out = *in
@@ -3117,27 +4314,48 @@ func (in *LifecycleActionHandler) DeepCopyInto(out *LifecycleActionHandler) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LifecycleActionHandler.
-func (in *LifecycleActionHandler) DeepCopy() *LifecycleActionHandler {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LifecycleActionHandler.
+func (in *LifecycleActionHandler) DeepCopy() *LifecycleActionHandler {
+	if in == nil {
+		return nil
+	}
+	out := new(LifecycleActionHandler)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogConfig) DeepCopyInto(out *LogConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogConfig.
+func (in *LogConfig) DeepCopy() *LogConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(LifecycleActionHandler)
+	out := new(LogConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogConfig) DeepCopyInto(out *LogConfig) {
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
 	*out = *in
+	if in.DaysOfWeek != nil {
+		in, out := &in.DaysOfWeek, &out.DaysOfWeek
+		*out = make([]Weekday, len(*in))
+		copy(*out, *in)
+	}
+	out.Duration = in.Duration
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogConfig.
-func (in *LogConfig) DeepCopy() *LogConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
 	if in == nil {
 		return nil
 	}
-	out := new(LogConfig)
+	out := new(MaintenanceWindow)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -3237,6 +4455,151 @@ func (in *NamedVar) DeepCopy() *NamedVar {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacePolicy) DeepCopyInto(out *NamespacePolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacePolicy.
+func (in *NamespacePolicy) DeepCopy() *NamespacePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespacePolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacePolicyList) DeepCopyInto(out *NamespacePolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NamespacePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacePolicyList.
+func (in *NamespacePolicyList) DeepCopy() *NamespacePolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacePolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespacePolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacePolicyResources) DeepCopyInto(out *NamespacePolicyResources) {
+	*out = *in
+	if in.Min != nil {
+		in, out := &in.Min, &out.Min
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacePolicyResources.
+func (in *NamespacePolicyResources) DeepCopy() *NamespacePolicyResources {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacePolicyResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacePolicySpec) DeepCopyInto(out *NamespacePolicySpec) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(ClusterBackup)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MonitoringEnabled != nil {
+		in, out := &in.MonitoringEnabled, &out.MonitoringEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(NamespacePolicyResources)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacePolicySpec.
+func (in *NamespacePolicySpec) DeepCopy() *NamespacePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OfflineInstanceStatus) DeepCopyInto(out *OfflineInstanceStatus) {
+	*out = *in
+	in.OfflineSince.DeepCopyInto(&out.OfflineSince)
+	if in.RetainedPVCs != nil {
+		in, out := &in.RetainedPVCs, &out.RetainedPVCs
+		*out = make([]RetainedPVC, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OfflineInstanceStatus.
+func (in *OfflineInstanceStatus) DeepCopy() *OfflineInstanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OfflineInstanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpsAction) DeepCopyInto(out *OpsAction) {
 	*out = *in
@@ -3245,6 +4608,16 @@ func (in *OpsAction) DeepCopyInto(out *OpsAction) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Workload != nil {
 		in, out := &in.Workload, &out.Workload
 		*out = new(OpsWorkloadAction)
@@ -3255,6 +4628,16 @@ func (in *OpsAction) DeepCopyInto(out *OpsAction) {
 		*out = new(OpsExecAction)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.KBAgent != nil {
+		in, out := &in.KBAgent, &out.KBAgent
+		*out = new(OpsKBAgentAction)
+		**out = **in
+	}
+	if in.WaitForCondition != nil {
+		in, out := &in.WaitForCondition, &out.WaitForCondition
+		*out = new(OpsWaitForConditionAction)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ResourceModifier != nil {
 		in, out := &in.ResourceModifier, &out.ResourceModifier
 		*out = new(OpsResourceModifierAction)
@@ -3272,6 +4655,72 @@ func (in *OpsAction) DeepCopy() *OpsAction {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsComponentPlan) DeepCopyInto(out *OpsComponentPlan) {
+	*out = *in
+	if in.CurrentReplicas != nil {
+		in, out := &in.CurrentReplicas, &out.CurrentReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = make([]InstanceTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OfflineInstances != nil {
+		in, out := &in.OfflineInstances, &out.OfflineInstances
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodsToCreate != nil {
+		in, out := &in.PodsToCreate, &out.PodsToCreate
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodsToDelete != nil {
+		in, out := &in.PodsToDelete, &out.PodsToDelete
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InstanceResources != nil {
+		in, out := &in.InstanceResources, &out.InstanceResources
+		*out = make([]InstanceResourceTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ParameterPatches != nil {
+		in, out := &in.ParameterPatches, &out.ParameterPatches
+		*out = make([]ParameterPatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsComponentPlan.
+func (in *OpsComponentPlan) DeepCopy() *OpsComponentPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsComponentPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpsDefinition) DeepCopyInto(out *OpsDefinition) {
 	*out = *in
@@ -3432,6 +4881,94 @@ func (in *OpsExecAction) DeepCopy() *OpsExecAction {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsExecutionOrder) DeepCopyInto(out *OpsExecutionOrder) {
+	*out = *in
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsExecutionOrder.
+func (in *OpsExecutionOrder) DeepCopy() *OpsExecutionOrder {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsExecutionOrder)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsFailedAttempt) DeepCopyInto(out *OpsFailedAttempt) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsFailedAttempt.
+func (in *OpsFailedAttempt) DeepCopy() *OpsFailedAttempt {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsFailedAttempt)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsFailurePolicy) DeepCopyInto(out *OpsFailurePolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsFailurePolicy.
+func (in *OpsFailurePolicy) DeepCopy() *OpsFailurePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsFailurePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsKBAgentAction) DeepCopyInto(out *OpsKBAgentAction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsKBAgentAction.
+func (in *OpsKBAgentAction) DeepCopy() *OpsKBAgentAction {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsKBAgentAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsPlan) DeepCopyInto(out *OpsPlan) {
+	*out = *in
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make(map[string]OpsComponentPlan, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsPlan.
+func (in *OpsPlan) DeepCopy() *OpsPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpsRecorder) DeepCopyInto(out *OpsRecorder) {
 	*out = *in
@@ -3510,6 +5047,13 @@ func (in *OpsRequestComponentStatus) DeepCopyInto(out *OpsRequestComponentStatus
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.LastRetryTime.DeepCopyInto(&out.LastRetryTime)
+	in.LastProgressTime.DeepCopyInto(&out.LastProgressTime)
+	if in.InvokedActions != nil {
+		in, out := &in.InvokedActions, &out.InvokedActions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsRequestComponentStatus.
@@ -3557,16 +5101,41 @@ func (in *OpsRequestList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpsRequestSpec) DeepCopyInto(out *OpsRequestSpec) {
 	*out = *in
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
 	if in.PreConditionDeadlineSeconds != nil {
 		in, out := &in.PreConditionDeadlineSeconds, &out.PreConditionDeadlineSeconds
 		*out = new(int32)
 		**out = **in
 	}
+	if in.SchedulingPolicy != nil {
+		in, out := &in.SchedulingPolicy, &out.SchedulingPolicy
+		*out = new(OpsSchedulingPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.TimeoutSeconds != nil {
 		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
 		*out = new(int32)
 		**out = **in
 	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(OpsRetryPolicy)
+		**out = **in
+	}
+	if in.FailurePolicy != nil {
+		in, out := &in.FailurePolicy, &out.FailurePolicy
+		*out = new(OpsFailurePolicy)
+		**out = **in
+	}
+	if in.ExecutionOrder != nil {
+		in, out := &in.ExecutionOrder, &out.ExecutionOrder
+		*out = new(OpsExecutionOrder)
+		(*in).DeepCopyInto(*out)
+	}
 	in.SpecificOpsRequest.DeepCopyInto(&out.SpecificOpsRequest)
 }
 
@@ -3591,6 +5160,16 @@ func (in *OpsRequestStatus) DeepCopyInto(out *OpsRequestStatus) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = new(OpsPlan)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExecutedComponents != nil {
+		in, out := &in.ExecutedComponents, &out.ExecutedComponents
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Extras != nil {
 		in, out := &in.Extras, &out.Extras
 		*out = make([]map[string]string, len(*in))
@@ -3628,6 +5207,30 @@ func (in *OpsRequestStatus) DeepCopyInto(out *OpsRequestStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.LastFailedAttempt != nil {
+		in, out := &in.LastFailedAttempt, &out.LastFailedAttempt
+		*out = new(OpsFailedAttempt)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StoppedPeriod != nil {
+		in, out := &in.StoppedPeriod, &out.StoppedPeriod
+		*out = new(OpsStoppedPeriod)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Timeline != nil {
+		in, out := &in.Timeline, &out.Timeline
+		*out = make([]OpsTimelineEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ScriptResults != nil {
+		in, out := &in.ScriptResults, &out.ScriptResults
+		*out = make([]ScriptResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -3663,6 +5266,22 @@ func (in *OpsRequestVolumeClaimTemplate) DeepCopy() *OpsRequestVolumeClaimTempla
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsRequestVolumeTuningTemplate) DeepCopyInto(out *OpsRequestVolumeTuningTemplate) {
+	*out = *in
+	in.Performance.DeepCopyInto(&out.Performance)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsRequestVolumeTuningTemplate.
+func (in *OpsRequestVolumeTuningTemplate) DeepCopy() *OpsRequestVolumeTuningTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsRequestVolumeTuningTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpsResourceModifierAction) DeepCopyInto(out *OpsResourceModifierAction) {
 	*out = *in
@@ -3685,6 +5304,41 @@ func (in *OpsResourceModifierAction) DeepCopy() *OpsResourceModifierAction {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsRetryPolicy) DeepCopyInto(out *OpsRetryPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsRetryPolicy.
+func (in *OpsRetryPolicy) DeepCopy() *OpsRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsSchedulingPolicy) DeepCopyInto(out *OpsSchedulingPolicy) {
+	*out = *in
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsSchedulingPolicy.
+func (in *OpsSchedulingPolicy) DeepCopy() *OpsSchedulingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsSchedulingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpsService) DeepCopyInto(out *OpsService) {
 	*out = *in
@@ -3731,6 +5385,42 @@ func (in *OpsService) DeepCopy() *OpsService {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsStoppedPeriod) DeepCopyInto(out *OpsStoppedPeriod) {
+	*out = *in
+	in.StopTimestamp.DeepCopyInto(&out.StopTimestamp)
+	if in.AutoStartTimestamp != nil {
+		in, out := &in.AutoStartTimestamp, &out.AutoStartTimestamp
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsStoppedPeriod.
+func (in *OpsStoppedPeriod) DeepCopy() *OpsStoppedPeriod {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsStoppedPeriod)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsTimelineEntry) DeepCopyInto(out *OpsTimelineEntry) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsTimelineEntry.
+func (in *OpsTimelineEntry) DeepCopy() *OpsTimelineEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsTimelineEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpsVarSource) DeepCopyInto(out *OpsVarSource) {
 	*out = *in
@@ -3756,9 +5446,35 @@ func (in *OpsVarSource) DeepCopy() *OpsVarSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsWaitForConditionAction) DeepCopyInto(out *OpsWaitForConditionAction) {
+	*out = *in
+	if in.Resource != nil {
+		in, out := &in.Resource, &out.Resource
+		*out = new(TypedObjectRef)
+		(*in).DeepCopyInto(*out)
+	}
+	out.MatchExpressions = in.MatchExpressions
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsWaitForConditionAction.
+func (in *OpsWaitForConditionAction) DeepCopy() *OpsWaitForConditionAction {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsWaitForConditionAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpsWorkloadAction) DeepCopyInto(out *OpsWorkloadAction) {
 	*out = *in
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
 	in.PodSpec.DeepCopyInto(&out.PodSpec)
 }
 
@@ -3845,6 +5561,31 @@ func (in *ParameterPair) DeepCopy() *ParameterPair {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParameterPatch) DeepCopyInto(out *ParameterPatch) {
+	*out = *in
+	if in.CurrentValue != nil {
+		in, out := &in.CurrentValue, &out.CurrentValue
+		*out = new(string)
+		**out = **in
+	}
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParameterPatch.
+func (in *ParameterPatch) DeepCopy() *ParameterPatch {
+	if in == nil {
+		return nil
+	}
+	out := new(ParameterPatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ParametersSchema) DeepCopyInto(out *ParametersSchema) {
 	*out = *in
@@ -3908,6 +5649,11 @@ func (in *PersistentVolumeClaimSpec) DeepCopyInto(out *PersistentVolumeClaimSpec
 		*out = new(v1.PersistentVolumeMode)
 		**out = **in
 	}
+	if in.Performance != nil {
+		in, out := &in.Performance, &out.Performance
+		*out = new(VolumePerformance)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistentVolumeClaimSpec.
@@ -3930,7 +5676,7 @@ func (in *PodInfoExtractor) DeepCopyInto(out *PodInfoExtractor) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	out.PodSelector = in.PodSelector
+	in.PodSelector.DeepCopyInto(&out.PodSelector)
 	if in.VolumeMounts != nil {
 		in, out := &in.VolumeMounts, &out.VolumeMounts
 		*out = make([]v1.VolumeMount, len(*in))
@@ -3953,6 +5699,16 @@ func (in *PodInfoExtractor) DeepCopy() *PodInfoExtractor {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodSelector) DeepCopyInto(out *PodSelector) {
 	*out = *in
+	if in.Ordinals != nil {
+		in, out := &in.Ordinals, &out.Ordinals
+		*out = new(workloadsv1alpha1.Ordinals)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sample != nil {
+		in, out := &in.Sample, &out.Sample
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSelector.
@@ -4079,7 +5835,9 @@ func (in *ProgressStatusDetail) DeepCopyInto(out *ProgressStatusDetail) {
 	if in.ActionTasks != nil {
 		in, out := &in.ActionTasks, &out.ActionTasks
 		*out = make([]ActionTask, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	in.StartTime.DeepCopyInto(&out.StartTime)
 	in.EndTime.DeepCopyInto(&out.EndTime)
@@ -4130,6 +5888,51 @@ func (in *ProvisionSecretRef) DeepCopy() *ProvisionSecretRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PurgeOfflineInstances) DeepCopyInto(out *PurgeOfflineInstances) {
+	*out = *in
+	out.ComponentOps = in.ComponentOps
+	out.OfflineLongerThan = in.OfflineLongerThan
+	if in.InstanceNames != nil {
+		in, out := &in.InstanceNames, &out.InstanceNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PurgeOfflineInstances.
+func (in *PurgeOfflineInstances) DeepCopy() *PurgeOfflineInstances {
+	if in == nil {
+		return nil
+	}
+	out := new(PurgeOfflineInstances)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Rebalance) DeepCopyInto(out *Rebalance) {
+	*out = *in
+	out.ComponentOps = in.ComponentOps
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Rebalance.
+func (in *Rebalance) DeepCopy() *Rebalance {
+	if in == nil {
+		return nil
+	}
+	out := new(Rebalance)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RebuildInstance) DeepCopyInto(out *RebuildInstance) {
 	*out = *in
@@ -4315,6 +6118,26 @@ func (in *ReplicaRole) DeepCopy() *ReplicaRole {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaWeight) DeepCopyInto(out *ReplicaWeight) {
+	*out = *in
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaWeight.
+func (in *ReplicaWeight) DeepCopy() *ReplicaWeight {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaWeight)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReplicasLimit) DeepCopyInto(out *ReplicasLimit) {
 	*out = *in
@@ -4333,49 +6156,141 @@ func (in *ReplicasLimit) DeepCopy() *ReplicasLimit {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceMeta) DeepCopyInto(out *ResourceMeta) {
 	*out = *in
-	if in.AsVolumeFrom != nil {
-		in, out := &in.AsVolumeFrom, &out.AsVolumeFrom
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
+	if in.AsVolumeFrom != nil {
+		in, out := &in.AsVolumeFrom, &out.AsVolumeFrom
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceMeta.
+func (in *ResourceMeta) DeepCopy() *ResourceMeta {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceMeta)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Restart) DeepCopyInto(out *Restart) {
+	*out = *in
+	out.ComponentOps = in.ComponentOps
+	if in.CanaryCount != nil {
+		in, out := &in.CanaryCount, &out.CanaryCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CanaryPercent != nil {
+		in, out := &in.CanaryPercent, &out.CanaryPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.InstanceNames != nil {
+		in, out := &in.InstanceNames, &out.InstanceNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Restart.
+func (in *Restart) DeepCopy() *Restart {
+	if in == nil {
+		return nil
+	}
+	out := new(Restart)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Restore) DeepCopyInto(out *Restore) {
+	*out = *in
+	if in.MaskingRules != nil {
+		in, out := &in.MaskingRules, &out.MaskingRules
+		*out = make([]dataprotectionv1alpha1.MaskingRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Restore.
+func (in *Restore) DeepCopy() *Restore {
+	if in == nil {
+		return nil
+	}
+	out := new(Restore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetainedPVC) DeepCopyInto(out *RetainedPVC) {
+	*out = *in
+	out.Size = in.Size.DeepCopy()
+	in.CreationTimestamp.DeepCopyInto(&out.CreationTimestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetainedPVC.
+func (in *RetainedPVC) DeepCopy() *RetainedPVC {
+	if in == nil {
+		return nil
+	}
+	out := new(RetainedPVC)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryPolicy) DeepCopyInto(out *RetryPolicy) {
+	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceMeta.
-func (in *ResourceMeta) DeepCopy() *ResourceMeta {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicy.
+func (in *RetryPolicy) DeepCopy() *RetryPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceMeta)
+	out := new(RetryPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Restore) DeepCopyInto(out *Restore) {
+func (in *RoleConfigOverlay) DeepCopyInto(out *RoleConfigOverlay) {
 	*out = *in
+	if in.ConfigFileParams != nil {
+		in, out := &in.ConfigFileParams, &out.ConfigFileParams
+		*out = make(map[string]ConfigParams, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Restore.
-func (in *Restore) DeepCopy() *Restore {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleConfigOverlay.
+func (in *RoleConfigOverlay) DeepCopy() *RoleConfigOverlay {
 	if in == nil {
 		return nil
 	}
-	out := new(Restore)
+	out := new(RoleConfigOverlay)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RetryPolicy) DeepCopyInto(out *RetryPolicy) {
+func (in *Rollback) DeepCopyInto(out *Rollback) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicy.
-func (in *RetryPolicy) DeepCopy() *RetryPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Rollback.
+func (in *Rollback) DeepCopy() *Rollback {
 	if in == nil {
 		return nil
 	}
-	out := new(RetryPolicy)
+	out := new(Rollback)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -4404,6 +6319,11 @@ func (in *ScaleIn) DeepCopyInto(out *ScaleIn) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.MaxReplicationLag != nil {
+		in, out := &in.MaxReplicationLag, &out.MaxReplicationLag
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleIn.
@@ -4432,6 +6352,13 @@ func (in *ScaleOut) DeepCopyInto(out *ScaleOut) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Placements != nil {
+		in, out := &in.Placements, &out.Placements
+		*out = make([]ScaleOutPlacement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleOut.
@@ -4444,6 +6371,57 @@ func (in *ScaleOut) DeepCopy() *ScaleOut {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleOutPlacement) DeepCopyInto(out *ScaleOutPlacement) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleOutPlacement.
+func (in *ScaleOutPlacement) DeepCopy() *ScaleOutPlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleOutPlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingAction) DeepCopyInto(out *ScalingAction) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingAction.
+func (in *ScalingAction) DeepCopy() *ScalingAction {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SchedulePolicy) DeepCopyInto(out *SchedulePolicy) {
 	*out = *in
@@ -4464,6 +6442,28 @@ func (in *SchedulePolicy) DeepCopy() *SchedulePolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulingHints) DeepCopyInto(out *SchedulingHints) {
+	*out = *in
+	if in.ConsumerAffinity != nil {
+		in, out := &in.ConsumerAffinity, &out.ConsumerAffinity
+		*out = make([]ConsumerAffinityTerm, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingHints.
+func (in *SchedulingHints) DeepCopy() *SchedulingHints {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulingHints)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SchedulingPolicy) DeepCopyInto(out *SchedulingPolicy) {
 	*out = *in
@@ -4534,6 +6534,46 @@ func (in *ScriptFrom) DeepCopy() *ScriptFrom {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScriptParameter) DeepCopyInto(out *ScriptParameter) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScriptParameter.
+func (in *ScriptParameter) DeepCopy() *ScriptParameter {
+	if in == nil {
+		return nil
+	}
+	out := new(ScriptParameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScriptResult) DeepCopyInto(out *ScriptResult) {
+	*out = *in
+	if in.ExitCode != nil {
+		in, out := &in.ExitCode, &out.ExitCode
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScriptResult.
+func (in *ScriptResult) DeepCopy() *ScriptResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ScriptResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScriptSecret) DeepCopyInto(out *ScriptSecret) {
 	*out = *in
@@ -4573,6 +6613,13 @@ func (in *ScriptSpec) DeepCopyInto(out *ScriptSpec) {
 		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]ScriptParameter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScriptSpec.
@@ -4959,6 +7006,27 @@ func (in *ServiceVars) DeepCopy() *ServiceVars {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardingConversion) DeepCopyInto(out *ShardingConversion) {
+	*out = *in
+	out.ComponentOps = in.ComponentOps
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]Parameter, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardingConversion.
+func (in *ShardingConversion) DeepCopy() *ShardingConversion {
+	if in == nil {
+		return nil
+	}
+	out := new(ShardingConversion)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ShardingSpec) DeepCopyInto(out *ShardingSpec) {
 	*out = *in
@@ -4975,6 +7043,37 @@ func (in *ShardingSpec) DeepCopy() *ShardingSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SidecarResources) DeepCopyInto(out *SidecarResources) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarResources.
+func (in *SidecarResources) DeepCopy() *SidecarResources {
+	if in == nil {
+		return nil
+	}
+	out := new(SidecarResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SimulatedChange) DeepCopyInto(out *SimulatedChange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SimulatedChange.
+func (in *SimulatedChange) DeepCopy() *SimulatedChange {
+	if in == nil {
+		return nil
+	}
+	out := new(SimulatedChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SpecificOpsRequest) DeepCopyInto(out *SpecificOpsRequest) {
 	*out = *in
@@ -4997,15 +7096,41 @@ func (in *SpecificOpsRequest) DeepCopyInto(out *SpecificOpsRequest) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.VolumeTuningList != nil {
+		in, out := &in.VolumeTuningList, &out.VolumeTuningList
+		*out = make([]VolumeTuning, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.RestartList != nil {
 		in, out := &in.RestartList, &out.RestartList
+		*out = make([]Restart, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StopList != nil {
+		in, out := &in.StopList, &out.StopList
+		*out = make([]ComponentOps, len(*in))
+		copy(*out, *in)
+	}
+	if in.AutoStartAfterSeconds != nil {
+		in, out := &in.AutoStartAfterSeconds, &out.AutoStartAfterSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.StartList != nil {
+		in, out := &in.StartList, &out.StartList
 		*out = make([]ComponentOps, len(*in))
 		copy(*out, *in)
 	}
 	if in.SwitchoverList != nil {
 		in, out := &in.SwitchoverList, &out.SwitchoverList
 		*out = make([]Switchover, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.VerticalScalingList != nil {
 		in, out := &in.VerticalScalingList, &out.VerticalScalingList
@@ -5051,12 +7176,12 @@ func (in *SpecificOpsRequest) DeepCopyInto(out *SpecificOpsRequest) {
 	if in.Restore != nil {
 		in, out := &in.Restore, &out.Restore
 		*out = new(Restore)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.RestoreSpec != nil {
 		in, out := &in.RestoreSpec, &out.RestoreSpec
 		*out = new(Restore)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.RebuildFrom != nil {
 		in, out := &in.RebuildFrom, &out.RebuildFrom
@@ -5065,11 +7190,40 @@ func (in *SpecificOpsRequest) DeepCopyInto(out *SpecificOpsRequest) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PurgeOfflineInstancesList != nil {
+		in, out := &in.PurgeOfflineInstancesList, &out.PurgeOfflineInstancesList
+		*out = make([]PurgeOfflineInstances, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ShardingConversion != nil {
+		in, out := &in.ShardingConversion, &out.ShardingConversion
+		*out = new(ShardingConversion)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(Rollback)
+		**out = **in
+	}
+	if in.RebalanceList != nil {
+		in, out := &in.RebalanceList, &out.RebalanceList
+		*out = make([]Rebalance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.CustomOps != nil {
 		in, out := &in.CustomOps, &out.CustomOps
 		*out = new(CustomOps)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ExternalOps != nil {
+		in, out := &in.ExternalOps, &out.ExternalOps
+		*out = new(ExternalOps)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpecificOpsRequest.
@@ -5086,6 +7240,11 @@ func (in *SpecificOpsRequest) DeepCopy() *SpecificOpsRequest {
 func (in *Switchover) DeepCopyInto(out *Switchover) {
 	*out = *in
 	out.ComponentOps = in.ComponentOps
+	if in.MaxReplicationLag != nil {
+		in, out := &in.MaxReplicationLag, &out.MaxReplicationLag
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Switchover.
@@ -5430,6 +7589,28 @@ func (in *VerticalScaling) DeepCopy() *VerticalScaling {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeAutoExpansion) DeepCopyInto(out *VolumeAutoExpansion) {
+	*out = *in
+	if in.VolumeNames != nil {
+		in, out := &in.VolumeNames, &out.VolumeNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Increment = in.Increment.DeepCopy()
+	out.MaxSize = in.MaxSize.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeAutoExpansion.
+func (in *VolumeAutoExpansion) DeepCopy() *VolumeAutoExpansion {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeAutoExpansion)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeExpansion) DeepCopyInto(out *VolumeExpansion) {
 	*out = *in
@@ -5460,6 +7641,31 @@ func (in *VolumeExpansion) DeepCopy() *VolumeExpansion {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumePerformance) DeepCopyInto(out *VolumePerformance) {
+	*out = *in
+	if in.IOPS != nil {
+		in, out := &in.IOPS, &out.IOPS
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Throughput != nil {
+		in, out := &in.Throughput, &out.Throughput
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumePerformance.
+func (in *VolumePerformance) DeepCopy() *VolumePerformance {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumePerformance)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeProtectionSpec) DeepCopyInto(out *VolumeProtectionSpec) {
 	*out = *in
@@ -5481,3 +7687,26 @@ func (in *VolumeProtectionSpec) DeepCopy() *VolumeProtectionSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeTuning) DeepCopyInto(out *VolumeTuning) {
+	*out = *in
+	out.ComponentOps = in.ComponentOps
+	if in.VolumeClaimTemplates != nil {
+		in, out := &in.VolumeClaimTemplates, &out.VolumeClaimTemplates
+		*out = make([]OpsRequestVolumeTuningTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeTuning.
+func (in *VolumeTuning) DeepCopy() *VolumeTuning {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeTuning)
+	in.DeepCopyInto(out)
+	return out
+}
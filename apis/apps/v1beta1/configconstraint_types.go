@@ -116,6 +116,15 @@ type ConfigConstraintSpec struct {
 	// +optional
 	ImmutableParameters []string `json:"immutableParameters,omitempty"`
 
+	// Declares the parameters renamed or deprecated in the engine version the ConfigConstraint applies to.
+	// The parameters set by the older engine versions are translated to the new names, or dropped with a warning
+	// if deprecated, rather than failing the rendering and reconfiguring after an engine upgrade.
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	ParameterMigrations []ParameterMigration `json:"parameterMigrations,omitempty"`
+
 	// Specifies the format of the configuration file and any associated parameters that are specific to the chosen format.
 	// Supported formats include `ini`, `xml`, `yaml`, `json`, `hcl`, `dotenv`, `properties`, and `toml`.
 	//
@@ -185,6 +194,25 @@ type ParametersSchema struct {
 	SchemaInJSON *apiext.JSONSchemaProps `json:"schemaInJSON,omitempty"`
 }
 
+// ParameterMigration declares a parameter renamed or deprecated.
+type ParameterMigration struct {
+	// Specifies the name of the parameter in the older engine versions.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies the new name of the parameter.
+	// The parameter is considered deprecated and dropped if it is not set.
+	//
+	// +optional
+	RenamedTo string `json:"renamedTo,omitempty"`
+
+	// Specifies the engine version since which the parameter is renamed or deprecated, for information only.
+	//
+	// +optional
+	Since string `json:"since,omitempty"`
+}
+
 // ReloadAction defines the mechanisms available for dynamically reloading a process within K8s without requiring a restart.
 //
 // Only one of the mechanisms can be specified at a time.
@@ -148,6 +148,11 @@ func (in *ConfigConstraintSpec) DeepCopyInto(out *ConfigConstraintSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ParameterMigrations != nil {
+		in, out := &in.ParameterMigrations, &out.ParameterMigrations
+		*out = make([]ParameterMigration, len(*in))
+		copy(*out, *in)
+	}
 	if in.FileFormatConfig != nil {
 		in, out := &in.FileFormatConfig, &out.FileFormatConfig
 		*out = new(FileFormatConfig)
@@ -263,6 +268,21 @@ func (in *IniConfig) DeepCopy() *IniConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParameterMigration) DeepCopyInto(out *ParameterMigration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParameterMigration.
+func (in *ParameterMigration) DeepCopy() *ParameterMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(ParameterMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ParametersSchema) DeepCopyInto(out *ParametersSchema) {
 	*out = *in
@@ -156,6 +156,13 @@ type RestoreActionSpec struct {
 	//
 	// +optional
 	PostReady []ActionSpec `json:"postReady,omitempty"`
+
+	// Specifies the actions that scrub the sensitive data after the "postReady" actions,
+	// which are executed only if the Restore specifies `spec.maskingConfig`.
+	// The masking rules are passed to the actions in JSON format by the environment variable "DP_MASKING_RULES".
+	//
+	// +optional
+	Masking []ActionSpec `json:"masking,omitempty"`
 }
 
 // ActionSpec defines an action that should be executed. Only one of the fields may be set.
@@ -289,3 +296,10 @@ func (r *ActionSet) HasPostReadyStage() bool {
 	}
 	return len(r.Spec.Restore.PostReady) > 0
 }
+
+func (r *ActionSet) HasMaskingStage() bool {
+	if r == nil || r.Spec.Restore == nil {
+		return false
+	}
+	return len(r.Spec.Restore.Masking) > 0
+}
@@ -187,6 +187,44 @@ type BackupStatus struct {
 	//
 	// +optional
 	Extras []map[string]string `json:"extras,omitempty"`
+
+	// Records the result of the latest restore rehearsal of the backup.
+	//
+	// +optional
+	LastRestoreRehearsal *RestoreRehearsalRecord `json:"lastRestoreRehearsal,omitempty"`
+}
+
+// RestoreRehearsalRecord records the result and timing of a restore rehearsal.
+type RestoreRehearsalRecord struct {
+	// The name of the Restore which performs the rehearsal.
+	//
+	// +kubebuilder:validation:Required
+	RestoreName string `json:"restoreName"`
+
+	// Indicates whether the rehearsal succeeded.
+	//
+	// +kubebuilder:validation:Required
+	Succeeded bool `json:"succeeded"`
+
+	// Records the date/time when the rehearsal started.
+	//
+	// +optional
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+
+	// Records the date/time when the rehearsal finished.
+	//
+	// +optional
+	CompletionTimestamp *metav1.Time `json:"completionTimestamp,omitempty"`
+
+	// Records the duration of the rehearsal, from the start to the completion of the validation.
+	//
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// Records the reason if the rehearsal failed.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // BackupTimeRange records the time range of backed up data, for PITR, this is the
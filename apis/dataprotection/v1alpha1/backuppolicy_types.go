@@ -45,6 +45,20 @@ type BackupPolicySpec struct {
 	// +kubebuilder:validation:Maximum=10
 	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
 
+	// Specifies the name of the PriorityClass of the backup jobs, e.g. a lower one than the
+	// database workloads to make the backups yield to the serving pods under resource pressure.
+	//
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// Specifies the number of seconds after which the finished backup jobs are deleted automatically.
+	// The jobs of the completed backups are always deleted once the backups complete,
+	// this field mainly cleans up the jobs of the failed backups, which are kept for troubleshooting otherwise.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+
 	// Specifies the target information to back up, such as the target pod, the
 	// cluster connection credential.
 	//
@@ -64,6 +64,14 @@ type RestoreSpec struct {
 	// +optional
 	ReadyConfig *ReadyConfig `json:"readyConfig,omitempty"`
 
+	// Configuration for the "masking" phase, which scrubs the sensitive data after the "postReady" phase
+	// by the masking actions declared in `actionSet.spec.restore.masking`.
+	// The masking actions are executed on the targets of `readyConfig`.
+	//
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.maskingConfig"
+	// +optional
+	MaskingConfig *MaskingConfig `json:"maskingConfig,omitempty"`
+
 	// List of environment variables to set in the container for restore. These will be
 	// merged with the env of Backup and ActionSet.
 	//
@@ -84,6 +92,72 @@ type RestoreSpec struct {
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Maximum=10
 	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+
+	// Specifies the name of the PriorityClass of the restore jobs, e.g. a lower one than the
+	// database workloads to make the restores yield to the serving pods under resource pressure.
+	//
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// Specifies the number of seconds after which the finished restore jobs are deleted automatically.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+
+	// Specifies whether the restore is a rehearsal.
+	//
+	// If true, the backup is restored into an ephemeral Cluster, which is built from the cluster snapshot of the Backup
+	// and named with the suffix "-rehearsal-<uid>". After the Cluster is running, the `rehearsalValidation` is run
+	// against it, the result and timing are recorded in the `status.lastRestoreRehearsal` of the Backup,
+	// and the Cluster is torn down.
+	// The Backup must be in the namespace of the Restore, and the other restore configurations are ignored for a rehearsal.
+	//
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.rehearsal"
+	// +optional
+	Rehearsal bool `json:"rehearsal,omitempty"`
+
+	// Specifies the deadline of a rehearsal in seconds, counted from the start of the rehearsal.
+	// If the rehearsal is not finished in time, it fails and the ephemeral Cluster is torn down.
+	// Defaults to 7200 seconds if not specified.
+	//
+	// +kubebuilder:validation:Minimum=60
+	// +optional
+	RehearsalDeadlineSeconds *int32 `json:"rehearsalDeadlineSeconds,omitempty"`
+
+	// Specifies the validation to run against the ephemeral Cluster of a rehearsal.
+	// If not specified, the rehearsal succeeds once the Cluster is running.
+	//
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.rehearsalValidation"
+	// +optional
+	RehearsalValidation *RestoreRehearsalValidation `json:"rehearsalValidation,omitempty"`
+}
+
+// RestoreRehearsalValidation defines the validation job of a restore rehearsal.
+// The name and namespace of the ephemeral Cluster are injected as the environment variables
+// "KB_CLUSTER_NAME" and "KB_NAMESPACE".
+type RestoreRehearsalValidation struct {
+	// Specifies the image of the validation job.
+	//
+	// +kubebuilder:validation:Required
+	Image string `json:"image"`
+
+	// Specifies the commands to validate the restored data, it succeeds if the commands exit with 0.
+	//
+	// +kubebuilder:validation:Required
+	Command []string `json:"command"`
+
+	// Specifies the environment variables of the validation job.
+	//
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Specifies the timeout of the validation job in seconds.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=600
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
 }
 
 // BackupRef describes the backup info.
@@ -195,6 +269,38 @@ type ReadyConfig struct {
 	ReadinessProbe *ReadinessProbe `json:"readinessProbe,omitempty"`
 }
 
+// MaskingConfig defines the rules to mask the restored data.
+type MaskingConfig struct {
+	// Specifies the masking rules, which are passed to the masking actions
+	// in JSON format by the environment variable "DP_MASKING_RULES".
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:Required
+	Rules []MaskingRule `json:"rules"`
+}
+
+// MaskingRule defines which columns of which tables should be masked and how.
+type MaskingRule struct {
+	// Specifies the patterns of the tables to be masked, such as "app.users" or "*.customers".
+	// The pattern syntax is interpreted by the masking actions of the addon.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:Required
+	Tables []string `json:"tables"`
+
+	// Specifies the patterns of the columns to be masked in the matched tables, such as "email" or "*_phone".
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:Required
+	Columns []string `json:"columns"`
+
+	// Specifies the method to mask the matched columns.
+	//
+	// +kubebuilder:default=Redact
+	// +optional
+	Method MaskingMethod `json:"method,omitempty"`
+}
+
 type JobAction struct {
 
 	// Specifies the restore policy, which is required when the pod selection strategy for the source target is 'All'.
@@ -407,6 +513,13 @@ type RestoreStatusActions struct {
 	// +patchStrategy=merge,retainKeys
 	// +optional
 	PostReady []RestoreStatusAction `json:"postReady,omitempty"`
+
+	// Records the actions for the masking phase.
+	//
+	// +patchMergeKey=jobName
+	// +patchStrategy=merge,retainKeys
+	// +optional
+	Masking []RestoreStatusAction `json:"masking,omitempty"`
 }
 
 type RestoreStatusAction struct {
@@ -478,6 +591,11 @@ type RestoreStatus struct {
 	//
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Records the name of the ephemeral Cluster of a rehearsal.
+	//
+	// +optional
+	RehearsalClusterName string `json:"rehearsalClusterName,omitempty"`
 }
 
 // +genclient
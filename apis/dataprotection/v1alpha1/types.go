@@ -214,6 +214,7 @@ type RestoreStage string
 const (
 	PrepareData RestoreStage = "prepareData"
 	PostReady   RestoreStage = "postReady"
+	Masking     RestoreStage = "masking"
 )
 
 // VolumeClaimRestorePolicy defines restore policy for persistent volume claim.
@@ -231,6 +232,23 @@ const (
 	VolumeClaimRestorePolicySerial   VolumeClaimRestorePolicy = "Serial"
 )
 
+// MaskingMethod defines how the matched columns are masked.
+// Supported methods are as follows:
+//
+// 1. Redact: replaces the values with a fixed placeholder.
+// 2. Hash: replaces the values with their hashes, which keeps the values distinguishable.
+// 3. Nullify: sets the values to null.
+//
+// +enum
+// +kubebuilder:validation:Enum={Redact,Hash,Nullify}
+type MaskingMethod string
+
+const (
+	MaskingMethodRedact  MaskingMethod = "Redact"
+	MaskingMethodHash    MaskingMethod = "Hash"
+	MaskingMethodNullify MaskingMethod = "Nullify"
+)
+
 type DataRestorePolicy string
 
 const (
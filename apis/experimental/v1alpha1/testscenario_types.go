@@ -0,0 +1,222 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// TestScenarioSpec defines the desired state of TestScenario
+type TestScenarioSpec struct {
+	// Specifies the steps of the scenario, which are executed one by one in order,
+	// e.g. create cluster -> scale -> switchover -> backup -> restore -> verify.
+	// The scenario fails as soon as one of the steps fails.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.steps"
+	Steps []ScenarioStep `json:"steps"`
+
+	// Specifies whether to delete the objects created by the scenario after it is completed.
+	// The objects are owned by the TestScenario and are deleted along with it anyway.
+	//
+	// +kubebuilder:default=false
+	// +optional
+	CleanupOnCompletion bool `json:"cleanupOnCompletion,omitempty"`
+}
+
+// ScenarioStep defines a step of the scenario.
+// A step creates the object if specified, and then waits for the phase of the object to be one of the
+// expected phases.
+type ScenarioStep struct {
+	// Specifies the name of the step, it should be unique in the scenario.
+	//
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// Specifies the object to create in the step, e.g. a Cluster, an OpsRequest, a Backup or a Restore.
+	// The object is created in the namespace of the TestScenario.
+	//
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:EmbeddedResource
+	// +optional
+	Object *runtime.RawExtension `json:"object,omitempty"`
+
+	// Specifies the existing object to verify in the step, it is required if no object is created in the step.
+	//
+	// +optional
+	Verify *ScenarioObjectReference `json:"verify,omitempty"`
+
+	// Specifies the expected phases of the object, the step succeeds once the object reaches one of them.
+	// The phase is read from the `status.phase` of the object.
+	//
+	// +kubebuilder:validation:MinItems=1
+	ExpectedPhases []string `json:"expectedPhases"`
+
+	// Specifies the phases which fail the step immediately once the object reaches one of them.
+	//
+	// +kubebuilder:default={"Failed"}
+	// +optional
+	FailurePhases []string `json:"failurePhases,omitempty"`
+
+	// Specifies the timeout of the step in seconds.
+	//
+	// +kubebuilder:default=1800
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// ScenarioObjectReference references an object in the namespace of the TestScenario.
+type ScenarioObjectReference struct {
+	// Specifies the API version of the object.
+	APIVersion string `json:"apiVersion"`
+
+	// Specifies the kind of the object.
+	Kind string `json:"kind"`
+
+	// Specifies the name of the object.
+	Name string `json:"name"`
+}
+
+// TestScenarioPhase defines the phase of the TestScenario and its steps.
+//
+// +enum
+// +kubebuilder:validation:Enum={Pending,Running,Succeeded,Failed}
+type TestScenarioPhase string
+
+const (
+	PendingTestScenarioPhase   TestScenarioPhase = "Pending"
+	RunningTestScenarioPhase   TestScenarioPhase = "Running"
+	SucceededTestScenarioPhase TestScenarioPhase = "Succeeded"
+	FailedTestScenarioPhase    TestScenarioPhase = "Failed"
+)
+
+// TestScenarioStatus defines the observed state of TestScenario
+type TestScenarioStatus struct {
+	// Represents the phase of the scenario.
+	//
+	// +optional
+	Phase TestScenarioPhase `json:"phase,omitempty"`
+
+	// Records the index of the step in progress.
+	//
+	// +optional
+	CurrentStep int32 `json:"currentStep,omitempty"`
+
+	// Records the status of the steps, in the same order as the spec.steps.
+	//
+	// +optional
+	Steps []ScenarioStepStatus `json:"steps,omitempty"`
+
+	// Records the time when the scenario is started.
+	//
+	// +optional
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+
+	// Records the time when the scenario is completed.
+	//
+	// +optional
+	CompletionTimestamp *metav1.Time `json:"completionTimestamp,omitempty"`
+
+	// Records the total duration of the scenario, e.g. "5m20s".
+	//
+	// +optional
+	Duration string `json:"duration,omitempty"`
+
+	// Provides the message of the scenario, e.g. why it failed.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ScenarioStepStatus defines the observed state of a step.
+type ScenarioStepStatus struct {
+	// Specifies the name of the step.
+	Name string `json:"name"`
+
+	// Represents the phase of the step.
+	//
+	// +optional
+	Phase TestScenarioPhase `json:"phase,omitempty"`
+
+	// Records the last observed phase of the object of the step.
+	//
+	// +optional
+	ObjectPhase string `json:"objectPhase,omitempty"`
+
+	// Records the time when the step is started.
+	//
+	// +optional
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+
+	// Records the time when the step is completed.
+	//
+	// +optional
+	CompletionTimestamp *metav1.Time `json:"completionTimestamp,omitempty"`
+
+	// Records the duration of the step, e.g. "1m5s".
+	//
+	// +optional
+	Duration string `json:"duration,omitempty"`
+
+	// Provides the message of the step, e.g. why it failed.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={kubeblocks},shortName=ts
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.phase",description="scenario status phase."
+// +kubebuilder:printcolumn:name="CURRENT-STEP",type="integer",JSONPath=".status.currentStep",description="the index of the step in progress."
+// +kubebuilder:printcolumn:name="DURATION",type="string",JSONPath=".status.duration",description="the duration of the scenario."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// TestScenario is the Schema for the testscenarios API.
+// It runs the declarative scenarios in the cluster, which helps to validate KubeBlocks in the users' own environments,
+// e.g. after upgrading KubeBlocks.
+type TestScenario struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TestScenarioSpec   `json:"spec,omitempty"`
+	Status TestScenarioStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TestScenarioList contains a list of TestScenario
+type TestScenarioList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TestScenario `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TestScenario{}, &TestScenarioList{})
+}
+
+// IsComplete checks whether the scenario is completed.
+func (r *TestScenario) IsComplete() bool {
+	return r.Status.Phase == SucceededTestScenarioPhase || r.Status.Phase == FailedTestScenarioPhase
+}
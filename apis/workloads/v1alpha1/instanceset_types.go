@@ -356,6 +356,12 @@ type InstanceSetSpec struct {
 	// +optional
 	MemberUpdateStrategy *MemberUpdateStrategy `json:"memberUpdateStrategy,omitempty"`
 
+	// Guards the quorum of the voting members when they are deleted on update or scale-in.
+	// The deletion is blocked, with the `InstanceQuorumGuarded` condition set, if it would break the availability.
+	//
+	// +optional
+	QuorumGuard *QuorumGuard `json:"quorumGuard,omitempty"`
+
 	// Indicates that the InstanceSet is paused, meaning the reconciliation of this InstanceSet object will be paused.
 	// +optional
 	Paused bool `json:"paused,omitempty"`
@@ -548,6 +554,23 @@ const (
 	ParallelUpdateStrategy           MemberUpdateStrategy = "Parallel"
 )
 
+// QuorumGuard defines the checks before deleting a voting member.
+type QuorumGuard struct {
+	// Specifies the minimum number of available voting members to be kept after a voting member is deleted.
+	// Defaults to the majority of the voting members.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinAvailableVoters *int32 `json:"minAvailableVoters,omitempty"`
+
+	// Specifies whether to make sure that the member to be deleted is not the only synced replica.
+	// As the writes are committed on the majority of the voting members, the remaining available voting members
+	// are required to intersect any majority of them, which is stricter than `minAvailableVoters` set below the majority.
+	//
+	// +optional
+	CheckSyncedReplicas bool `json:"checkSyncedReplicas,omitempty"`
+}
+
 // RoleUpdateMechanism defines the way how pod role label being updated.
 // +enum
 type RoleUpdateMechanism string
@@ -740,6 +763,10 @@ const (
 	// InstanceUpdateRestricted represents a ConditionType that indicates updates to an InstanceSet are blocked(when the
 	// PodUpdatePolicy is set to StrictInPlace but the pods cannot be updated in-place).
 	InstanceUpdateRestricted ConditionType = "InstanceUpdateRestricted"
+
+	// InstanceQuorumGuarded represents a ConditionType that indicates the deletion of a voting member is blocked
+	// as it would break the quorum of the voting members.
+	InstanceQuorumGuarded ConditionType = "InstanceQuorumGuarded"
 )
 
 const (
@@ -760,6 +787,9 @@ const (
 
 	// ReasonInstanceUpdateRestricted is a reason for condition InstanceUpdateRestricted.
 	ReasonInstanceUpdateRestricted = "InstanceUpdateRestricted"
+
+	// ReasonInstanceQuorumGuarded is a reason for condition InstanceQuorumGuarded.
+	ReasonInstanceQuorumGuarded = "InstanceQuorumGuarded"
 )
 
 const defaultInstanceTemplateReplicas = 1
@@ -216,6 +216,11 @@ func (in *InstanceSetSpec) DeepCopyInto(out *InstanceSetSpec) {
 		*out = new(MemberUpdateStrategy)
 		**out = **in
 	}
+	if in.QuorumGuard != nil {
+		in, out := &in.QuorumGuard, &out.QuorumGuard
+		*out = new(QuorumGuard)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Credential != nil {
 		in, out := &in.Credential, &out.Credential
 		*out = new(Credential)
@@ -459,6 +464,26 @@ func (in *Ordinals) DeepCopy() *Ordinals {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuorumGuard) DeepCopyInto(out *QuorumGuard) {
+	*out = *in
+	if in.MinAvailableVoters != nil {
+		in, out := &in.MinAvailableVoters, &out.MinAvailableVoters
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuorumGuard.
+func (in *QuorumGuard) DeepCopy() *QuorumGuard {
+	if in == nil {
+		return nil
+	}
+	out := new(QuorumGuard)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Range) DeepCopyInto(out *Range) {
 	*out = *in
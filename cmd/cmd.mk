@@ -73,6 +73,42 @@ cue-helper: test-go-generate build-checks ## Build cue-helper related binaries
 clean-cue-helper: ## Clean bin/cue-helper.
 	rm -f bin/cue-helper
 
+## gateway cmd
+
+GATEWAY_LD_FLAGS = "-s -w"
+
+bin/gateway.%: ## Cross build bin/gateway.$(OS).$(ARCH) .
+	GOOS=$(word 2,$(subst ., ,$@)) GOARCH=$(word 3,$(subst ., ,$@)) $(GO) build -ldflags=${GATEWAY_LD_FLAGS} -o $@ ./cmd/gateway/main.go
+
+.PHONY: gateway
+gateway: OS=$(shell $(GO) env GOOS)
+gateway: ARCH=$(shell $(GO) env GOARCH)
+gateway: build-checks ## Build gateway related binaries
+	$(MAKE) bin/gateway.${OS}.${ARCH}
+	mv bin/gateway.${OS}.${ARCH} bin/gateway
+
+.PHONY: clean-gateway
+clean-gateway: ## Clean bin/gateway.
+	rm -f bin/gateway
+
+## metrics-adapter cmd
+
+METRICS_ADAPTER_LD_FLAGS = "-s -w"
+
+bin/metrics-adapter.%: ## Cross build bin/metrics-adapter.$(OS).$(ARCH) .
+	GOOS=$(word 2,$(subst ., ,$@)) GOARCH=$(word 3,$(subst ., ,$@)) $(GO) build -ldflags=${METRICS_ADAPTER_LD_FLAGS} -o $@ ./cmd/metricsadapter/main.go
+
+.PHONY: metrics-adapter
+metrics-adapter: OS=$(shell $(GO) env GOOS)
+metrics-adapter: ARCH=$(shell $(GO) env GOARCH)
+metrics-adapter: build-checks ## Build metrics-adapter related binaries
+	$(MAKE) bin/metrics-adapter.${OS}.${ARCH}
+	mv bin/metrics-adapter.${OS}.${ARCH} bin/metrics-adapter
+
+.PHONY: clean-metrics-adapter
+clean-metrics-adapter: ## Clean bin/metrics-adapter.
+	rm -f bin/metrics-adapter
+
 ## lorry cmd
 
 LORRY_LD_FLAGS = "-s -w"
@@ -0,0 +1,76 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	ctrl "sigs.k8s.io/controller-runtime"
+	kzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/apecloud/kubeblocks/pkg/gateway"
+)
+
+func main() {
+	var (
+		config    gateway.Config
+		tokenFile string
+	)
+	pflag.StringVar(&config.Address, "bind-address", ":8080", "The address the gateway server binds to.")
+	pflag.StringVar(&config.TLSCertFile, "tls-cert-file", "", "The file containing the x509 certificate for HTTPS, required.")
+	pflag.StringVar(&config.TLSKeyFile, "tls-private-key-file", "", "The file containing the x509 private key matching --tls-cert-file, required.")
+	pflag.StringVar(&tokenFile, "token-file", "", "The file containing the bearer tokens of the tenants, in the format of \"token,tenant,namespace\" per line.")
+
+	opts := kzap.Options{
+		Development: true,
+	}
+	opts.BindFlags(flag.CommandLine)
+	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
+	pflag.Parse()
+	ctrl.SetLogger(kzap.New(kzap.UseFlagOptions(&opts)))
+
+	if len(tokenFile) == 0 {
+		panic(errors.New("the token file is required"))
+	}
+	authenticator, err := gateway.LoadTokenAuthenticator(tokenFile)
+	if err != nil {
+		panic(errors.Wrap(err, "load tokens failed"))
+	}
+
+	server := gateway.NewServer(config, gateway.NewImpersonatingClientFactory(ctrl.GetConfigOrDie()), authenticator)
+	if err = server.StartNonBlocking(); err != nil {
+		panic(errors.Wrap(err, "HTTP server initialize failed"))
+	}
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGTERM, os.Interrupt)
+	<-stop
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_ = server.Shutdown(ctx)
+}
@@ -0,0 +1,99 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package hook
+
+import (
+	"os"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	extensionsv1alpha1 "github.com/apecloud/kubeblocks/apis/extensions/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/addon/bundle"
+)
+
+// AddonBundle installs the addons from the offline bundle, the charts are installed from the charts image
+// pulled from the private registry, and the images required should be pushed to the registry beforehand.
+type AddonBundle struct {
+	BasedHandler
+
+	// File is the path of the bundle.
+	File string
+	// Digest is the digest of the bundle manifest published along with the bundle.
+	Digest string
+	// Registry is the private registry the images of the bundle are pushed to.
+	Registry string
+}
+
+func (p *AddonBundle) IsSkip(*UpgradeContext) (bool, error) {
+	return len(p.File) == 0, nil
+}
+
+func (p *AddonBundle) Handle(ctx *UpgradeContext) error {
+	dir, err := os.MkdirTemp("", "addon-bundle-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	b, err := bundle.LoadFile(p.File, dir, p.Digest)
+	if err != nil {
+		return err
+	}
+	addons, err := b.Addons()
+	if err != nil {
+		return err
+	}
+	for image, local := range b.Images(p.Registry) {
+		Log("image[%s] of bundle %s is expected in the registry as %s", image, b.Manifest.Name, local)
+	}
+	for _, addon := range addons {
+		if err = b.Localize(addon, p.Registry); err != nil {
+			return err
+		}
+		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			return applyBundleAddon(ctx, addon)
+		})
+		if err != nil {
+			return err
+		}
+		Log("addon[%s] is applied from bundle %s", addon.Name, b.Manifest.Name)
+	}
+	return nil
+}
+
+// applyBundleAddon creates the addon or updates its definition, whether the addon is enabled is kept as it is.
+func applyBundleAddon(ctx *UpgradeContext, addon *extensionsv1alpha1.Addon) error {
+	addons := ctx.KBClient.ExtensionsV1alpha1().Addons()
+	existing, err := addons.Get(ctx, addon.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = addons.Create(ctx, addon, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	installSpec := existing.Spec.InstallSpec
+	existing.Spec = addon.Spec
+	existing.Spec.InstallSpec = installSpec
+	_, err = addons.Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,315 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hook
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apecloud/kubeblocks/pkg/addon"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+const (
+	// UpgradeReportName is the name of the ConfigMap which records the result of the upgrade pre-checks,
+	// and the rollback instructions if the upgrade fails.
+	UpgradeReportName = "kubeblocks-upgrade-report"
+
+	precheckReportKey = "precheck.json"
+	failureReportKey  = "failure.json"
+)
+
+// PreCheckReport describes the incompatibilities found before upgrading.
+type PreCheckReport struct {
+	From string `json:"from,omitempty"`
+	To   string `json:"to"`
+
+	// IncompatibleAddons lists the installed addons which do not support the target version, and need to be upgraded.
+	// They are blockers as well, since the addon controller refuses to reconcile them after upgrading.
+	IncompatibleAddons []string `json:"incompatibleAddons,omitempty"`
+	// DeprecatedClusters lists the clusters which still reference the deprecated ClusterVersion API.
+	DeprecatedClusters []string `json:"deprecatedClusters,omitempty"`
+	// DeprecatedVersions lists the stored API versions which are deprecated in the target version.
+	DeprecatedVersions []string `json:"deprecatedVersions,omitempty"`
+	// LegacyAgentComponents lists the components whose pods still run the legacy lorry containers,
+	// they are migrated to the kb-agent by a Restart OpsRequest once the cluster is annotated with
+	// apps.kubeblocks.io/migrate-to-kb-agent=true.
+	LegacyAgentComponents []string `json:"legacyAgentComponents,omitempty"`
+	// Blockers lists the findings which prevent the upgrade from succeeding.
+	Blockers []string `json:"blockers,omitempty"`
+}
+
+// FailureReport records the failed stage and how to roll back.
+type FailureReport struct {
+	From     string   `json:"from,omitempty"`
+	To       string   `json:"to"`
+	Stage    string   `json:"stage"`
+	Error    string   `json:"error"`
+	Time     string   `json:"time"`
+	Steps    []string `json:"completedStages,omitempty"`
+	Rollback []string `json:"rollback"`
+}
+
+// PreCheck scans the addons, clusters and CRDs before upgrading, and records the findings
+// into the upgrade report. If Strict is set, the upgrade is aborted when blockers are found.
+type PreCheck struct {
+	BasedHandler
+
+	Strict bool
+}
+
+func (p *PreCheck) Handle(ctx *UpgradeContext) error {
+	report := &PreCheckReport{To: ctx.Version}
+	if ctx.From != nil {
+		report.From = ctx.From.String()
+	}
+	if err := checkAddons(ctx, report); err != nil {
+		return err
+	}
+	if err := checkClusters(ctx, report); err != nil {
+		return err
+	}
+	if err := checkCRDVersions(ctx, report); err != nil {
+		return err
+	}
+	if err := checkLegacyAgents(ctx, report); err != nil {
+		return err
+	}
+
+	for _, addon := range report.IncompatibleAddons {
+		Log("precheck: %s", addon)
+	}
+	for _, cluster := range report.DeprecatedClusters {
+		Log("precheck: cluster[%s] references the deprecated ClusterVersion", cluster)
+	}
+	for _, version := range report.DeprecatedVersions {
+		Log("precheck: stored version [%s] is deprecated", version)
+	}
+	for _, comp := range report.LegacyAgentComponents {
+		Log("precheck: component[%s] still runs the legacy lorry containers", comp)
+	}
+	for _, blocker := range report.Blockers {
+		Log("precheck blocker: %s", blocker)
+	}
+	if err := writeUpgradeReport(ctx, precheckReportKey, report); err != nil {
+		return err
+	}
+	if p.Strict && len(report.Blockers) > 0 {
+		return fmt.Errorf("upgrade pre-check failed, found %d blocker(s), see ConfigMap %s/%s for details",
+			len(report.Blockers), ctx.Namespace, UpgradeReportName)
+	}
+	return nil
+}
+
+func checkAddons(ctx *UpgradeContext, report *PreCheckReport) error {
+	addons, err := ctx.KBClient.ExtensionsV1alpha1().Addons().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	for _, item := range addons.Items {
+		if item.GetDeletionTimestamp() != nil || item.Spec.InstallSpec == nil || !item.Spec.InstallSpec.Enabled {
+			continue
+		}
+		constraint := item.GetAnnotations()[addon.KubeBlocksVersionAnnotationKey]
+		if constraint == "" {
+			continue
+		}
+		ok, err := addon.ValidateKubeBlocksVersion(constraint, ctx.Version)
+		if err != nil {
+			Log("addon[%s] has invalid version constraint [%s]: %v", item.GetName(), constraint, err)
+			continue
+		}
+		if !ok {
+			finding := fmt.Sprintf("addon[%s] version %s requires KubeBlocks %s", item.GetName(), item.Spec.Version, constraint)
+			report.IncompatibleAddons = append(report.IncompatibleAddons, finding)
+			report.Blockers = append(report.Blockers, finding)
+		}
+	}
+	return nil
+}
+
+func checkClusters(ctx *UpgradeContext, report *PreCheckReport) error {
+	clusters, err := ctx.KBClient.AppsV1alpha1().Clusters(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	for _, cluster := range clusters.Items {
+		if cluster.Spec.ClusterVersionRef != "" {
+			report.DeprecatedClusters = append(report.DeprecatedClusters, cluster.Namespace+"/"+cluster.Name)
+		}
+	}
+	return nil
+}
+
+// checkLegacyAgents finds the components whose pods still run the legacy lorry containers.
+func checkLegacyAgents(ctx *UpgradeContext, report *PreCheckReport) error {
+	pods, err := ctx.K8sClient.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", constant.AppManagedByLabelKey, constant.AppName),
+	})
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	comps := sets.New[string]()
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if !hasLegacyAgentContainer(&pod.Spec) {
+			continue
+		}
+		comps.Insert(fmt.Sprintf("%s/%s/%s", pod.Namespace,
+			pod.Labels[constant.AppInstanceLabelKey], pod.Labels[constant.KBAppComponentLabelKey]))
+	}
+	report.LegacyAgentComponents = sets.List(comps)
+	return nil
+}
+
+func hasLegacyAgentContainer(podSpec *corev1.PodSpec) bool {
+	for _, c := range podSpec.Containers {
+		switch c.Name {
+		case constant.LorryContainerName, constant.RoleProbeContainerName, constant.VolumeProtectionProbeContainerName:
+			return true
+		}
+	}
+	return false
+}
+
+// checkCRDVersions compares the stored versions of the existing CRDs with the versions of the target CRDs.
+// Stored versions no longer served by the target CRDs must be migrated before upgrading.
+func checkCRDVersions(ctx *UpgradeContext, report *PreCheckReport) error {
+	crdList, err := parseCRDs(ctx.CRDPath)
+	if err != nil {
+		return err
+	}
+	for _, crd := range crdList {
+		existing, err := ctx.CRDClient.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, crd.GetName(), metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		deprecated, unserved := checkStoredVersions(existing.Status.StoredVersions, crd.Spec.Versions)
+		for _, v := range deprecated {
+			report.DeprecatedVersions = append(report.DeprecatedVersions, fmt.Sprintf("%s/%s", crd.GetName(), v))
+		}
+		for _, v := range unserved {
+			report.Blockers = append(report.Blockers,
+				fmt.Sprintf("stored version %s of %s is not served by KubeBlocks %s", v, crd.GetName(), ctx.Version))
+		}
+	}
+	return nil
+}
+
+func checkStoredVersions(storedVersions []string, versions []apiextensionsv1.CustomResourceDefinitionVersion) (deprecated, unserved []string) {
+	served := sets.New[string]()
+	deprecatedVersions := sets.New[string]()
+	for _, v := range versions {
+		if v.Served {
+			served.Insert(v.Name)
+		}
+		if v.Deprecated {
+			deprecatedVersions.Insert(v.Name)
+		}
+	}
+	for _, v := range storedVersions {
+		switch {
+		case !served.Has(v):
+			unserved = append(unserved, v)
+		case deprecatedVersions.Has(v):
+			deprecated = append(deprecated, v)
+		}
+	}
+	return
+}
+
+// RecordFailure records the failed stage and the rollback instructions into the upgrade report.
+func RecordFailure(ctx *UpgradeContext, stage string, err error) {
+	report := &FailureReport{
+		To:       ctx.Version,
+		Stage:    stage,
+		Error:    err.Error(),
+		Time:     time.Now().UTC().Format(time.RFC3339),
+		Steps:    ctx.CompletedStages,
+		Rollback: rollbackInstructions(ctx),
+	}
+	if ctx.From != nil {
+		report.From = ctx.From.String()
+	}
+	for _, step := range report.Rollback {
+		Log("rollback: %s", step)
+	}
+	if werr := writeUpgradeReport(ctx, failureReportKey, report); werr != nil {
+		Log("failed to record the upgrade failure: %v", werr)
+	}
+}
+
+func rollbackInstructions(ctx *UpgradeContext) []string {
+	completed := sets.New(ctx.CompletedStages...)
+	var steps []string
+	if completed.Has(stageName(&UpdateCRD{})) {
+		steps = append(steps, "the CRDs have been updated, re-apply the CRDs of the previous KubeBlocks version before rolling back")
+	}
+	steps = append(steps, fmt.Sprintf("helm rollback %s -n %s", ctx.Release, ctx.Namespace))
+	if completed.Has(stageName(&StopOperator{})) {
+		steps = append(steps, fmt.Sprintf("the KubeBlocks deployments have been scaled to 0, scale them up if the rollback does not restore them: "+
+			"kubectl -n %s scale deployment -l %s=%s --replicas=1", ctx.Namespace, constant.AppInstanceLabelKey, ctx.Release))
+	}
+	return steps
+}
+
+func writeUpgradeReport(ctx *UpgradeContext, key string, report any) error {
+	b, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cmClient := ctx.K8sClient.CoreV1().ConfigMaps(ctx.Namespace)
+		cm, err := cmClient.Get(ctx, UpgradeReportName, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      UpgradeReportName,
+					Namespace: ctx.Namespace,
+				},
+				Data: map[string]string{key: string(b)},
+			}
+			_, err = cmClient.Create(ctx, cm, metav1.CreateOptions{})
+			return err
+		case err != nil:
+			return err
+		}
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		if key == precheckReportKey {
+			// a new upgrade starts, drop the stale failure report
+			delete(cm.Data, failureReportKey)
+		}
+		cm.Data[key] = string(b)
+		_, err = cmClient.Update(ctx, cm, metav1.UpdateOptions{})
+		return err
+	})
+}
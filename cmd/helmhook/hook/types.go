@@ -19,6 +19,7 @@ package hook
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	"k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -36,6 +37,8 @@ type UpgradeMetaContext struct {
 	CRDPath   string
 	Version   string
 	Namespace string
+	// Release is the name of the helm release of KubeBlocks
+	Release string
 
 	CRClient
 }
@@ -55,6 +58,9 @@ type UpgradeContext struct {
 	To   Version
 
 	UpdatedObjects map[schema.GroupVersionResource][]client.Object
+
+	// CompletedStages records the stages which have been done, used to generate the rollback instructions
+	CompletedStages []string
 }
 
 type Version struct {
@@ -113,10 +119,36 @@ func (w Workflow) AddStage(stage ContextHandler) Workflow {
 		if !skip {
 			err = stage.Handle(ctx)
 		}
-		return err
+		if err != nil {
+			return &StageError{Stage: stageName(stage), Err: err}
+		}
+		ctx.CompletedStages = append(ctx.CompletedStages, stageName(stage))
+		return nil
 	})
 }
 
+// StageError indicates which stage of the workflow failed.
+type StageError struct {
+	Stage string
+	Err   error
+}
+
+func (e *StageError) Error() string {
+	return fmt.Sprintf("stage %s failed: %v", e.Stage, e.Err)
+}
+
+func (e *StageError) Unwrap() error {
+	return e.Err
+}
+
+func stageName(stage ContextHandler) string {
+	t := reflect.TypeOf(stage)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
 func (w Workflow) WrapStage(stageFn ContextHandlerFunc) Workflow {
 	return append(w, stageFn)
 }
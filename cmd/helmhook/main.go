@@ -21,6 +21,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"os"
 	"os/signal"
@@ -32,20 +33,32 @@ import (
 
 	"github.com/apecloud/kubeblocks/cmd/helmhook/hook"
 	_ "github.com/apecloud/kubeblocks/cmd/helmhook/hook/multiversion"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 )
 
 var (
 	crdPath    string
 	version    string
 	namespace  string
+	release    string
 	keepAddons bool
+	strictPre  bool
+
+	addonBundle         string
+	addonBundleDigest   string
+	addonBundleRegistry string
 )
 
 func setupFlags() {
 	pflag.StringVar(&crdPath, "crd", "/kubeblocks/crd", "CRD directory for the kubeblocks")
 	pflag.StringVar(&version, "version", "", "KubeBlocks version")
 	pflag.StringVar(&namespace, "namespace", "default", "The namespace scope for this request")
+	pflag.StringVar(&release, "release", constant.AppName, "The name of the helm release of KubeBlocks")
 	pflag.BoolVar(&keepAddons, "keep-addons", true, "Whether to allow addon updates. If set to true, the addons that KubeBlocks depends on will not be upgraded after KubeBlocks is upgrade")
+	pflag.StringVar(&addonBundle, "addon-bundle", "", "The offline bundle to install the addons from")
+	pflag.StringVar(&addonBundleDigest, "addon-bundle-digest", "", "The digest of the manifest of the offline bundle, which is published along with the bundle")
+	pflag.StringVar(&addonBundleRegistry, "addon-bundle-registry", "", "The private registry the images of the offline bundle are pushed to")
+	pflag.BoolVar(&strictPre, "strict-precheck", false, "Whether to abort the upgrade if the pre-check finds blockers. The findings are always recorded in the ConfigMap "+hook.UpgradeReportName)
 
 	opts := zap.Options{
 		Development: true,
@@ -70,12 +83,24 @@ func main() {
 	hook.CheckErr(err)
 
 	upgradeContext := hook.NewUpgradeContext(ctx, config, version, crdPath, namespace)
-	hook.CheckErr(hook.NewUpgradeWorkflow().
+	upgradeContext.Release = release
+	err = hook.NewUpgradeWorkflow().
 		WrapStage(hook.PrepareFor).
+		AddStage(&hook.PreCheck{Strict: strictPre}).
 		AddStage(&hook.StopOperator{}).
 		AddStage(&hook.Addon{KeepAddons: keepAddons}).
 		AddStage(&hook.Conversion{}).
 		AddStage(&hook.UpdateCRD{}).
 		AddStage(&hook.UpdateCR{}).
-		Do(upgradeContext))
+		AddStage(&hook.AddonBundle{File: addonBundle, Digest: addonBundleDigest, Registry: addonBundleRegistry}).
+		Do(upgradeContext)
+	if err != nil {
+		stage := "PrepareFor"
+		var stageErr *hook.StageError
+		if errors.As(err, &stageErr) {
+			stage = stageErr.Stage
+		}
+		hook.RecordFailure(upgradeContext, stage, err)
+	}
+	hook.CheckErr(err)
 }
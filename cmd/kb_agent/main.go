@@ -80,6 +80,8 @@ func main() {
 		panic(errors.Wrap(err, "Cron jobs initialize failed"))
 	}
 	jobManager.Start()
+	// restart the cron jobs of the probes once the handlers are refreshed by the controller
+	handlers.RegisterRefreshHook(jobManager.Refresh)
 
 	// start HTTP Server
 	httpServer := httpserver.NewServer()
@@ -46,6 +46,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	// +kubebuilder:scaffold:imports
 
@@ -64,6 +65,7 @@ import (
 	k8scorecontrollers "github.com/apecloud/kubeblocks/controllers/k8score"
 	workloadscontrollers "github.com/apecloud/kubeblocks/controllers/workloads"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
 	"github.com/apecloud/kubeblocks/pkg/controller/multicluster"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
@@ -144,9 +146,19 @@ func init() {
 	viper.SetDefault(instanceset.FeatureGateIgnorePodVerticalScaling, false)
 	viper.SetDefault(intctrlutil.FeatureGateEnableRuntimeMetrics, false)
 	viper.SetDefault(constant.CfgKBReconcileWorkers, 8)
+	viper.SetDefault(constant.CfgKeyClusterHistoryLimit, 10)
+	viper.SetDefault(constant.CfgKeyServiceVersionRiskPolicy, component.ServiceVersionRiskPolicyWarn)
+	viper.SetDefault(constant.CfgKeyStatusPatchCoalesceWindow, "2s")
+	viper.SetDefault(constant.CfgKeyOpsIdempotencyKeyTTL, "24h")
+	viper.SetDefault(constant.CfgKeyFleetStatusExportInterval, "1m")
+	viper.SetDefault(constant.CfgKeyFleetStatusConfigMap, "kubeblocks-fleet-status")
+	viper.SetDefault(constant.CfgKeyShutdownDrainTimeout, "30s")
+	viper.SetDefault(constant.CfgKeyShutdownHandoffConfigMap, "kubeblocks-shutdown-handoff")
 	viper.SetDefault(constant.FeatureGateIgnoreConfigTemplateDefaultMode, false)
 	viper.SetDefault(constant.FeatureGateComponentReplicasAnnotation, true)
 	viper.SetDefault(constant.FeatureGateInPlacePodVerticalScaling, false)
+	viper.SetDefault(constant.FeatureGateReplicasProtection, true)
+	viper.SetDefault(constant.FeatureGateTestScenario, false)
 }
 
 type flagName string
@@ -313,6 +325,8 @@ func main() {
 	userAgent = viper.GetString(userAgentFlagKey.viperName())
 
 	setupLog.Info("golang runtime metrics.", "featureGate", intctrlutil.EnabledRuntimeMetrics())
+	// leave the time to record the interrupted actions after the drain timeout.
+	gracefulShutdownTimeout := viper.GetDuration(constant.CfgKeyShutdownDrainTimeout) + 10*time.Second
 	mgr, err := ctrl.NewManager(intctrlutil.GeKubeRestConfig(userAgent), ctrl.Options{
 		Scheme: scheme,
 		Metrics: server.Options{
@@ -340,6 +354,9 @@ func main() {
 		// after the manager stops then its usage might be unsafe.
 		LeaderElectionReleaseOnCancel: true,
 
+		// wait for the in-flight actions and plans to be drained by the ShutdownDrainer.
+		GracefulShutdownTimeout: &gracefulShutdownTimeout,
+
 		WebhookServer: webhook.NewServer(webhook.Options{
 			Port:    9443,
 			CertDir: viper.GetString("cert_dir"),
@@ -483,6 +500,112 @@ func main() {
 			os.Exit(1)
 		}
 
+		if err = (&appscontrollers.ClusterSetReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("cluster-set-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ClusterSet")
+			os.Exit(1)
+		}
+
+		if err = (&appscontrollers.DatabaseReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("database-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Database")
+			os.Exit(1)
+		}
+
+		if err = (&appscontrollers.DatabaseUserReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("database-user-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "DatabaseUser")
+			os.Exit(1)
+		}
+
+		if err = (&appscontrollers.ClusterPeeringReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("cluster-peering-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ClusterPeering")
+			os.Exit(1)
+		}
+
+		if err = (&appscontrollers.ClusterAutoPatchReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("cluster-auto-patch-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ClusterAutoPatch")
+			os.Exit(1)
+		}
+
+		if err = (&appscontrollers.VolumeAutoExpansionReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("volume-auto-expansion-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "VolumeAutoExpansion")
+			os.Exit(1)
+		}
+
+		if annotation := viper.GetString(constant.CfgKeyNodeRebootRequiredAnnotation); annotation != "" {
+			if err = (&appscontrollers.NodeRebootReconciler{
+				Client:     mgr.GetClient(),
+				Scheme:     mgr.GetScheme(),
+				Recorder:   mgr.GetEventRecorderFor("node-reboot-controller"),
+				Annotation: annotation,
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "NodeReboot")
+				os.Exit(1)
+			}
+		}
+
+		if viper.GetBool(constant.CfgKeyEnableWebhooks) {
+			mgr.GetWebhookServer().Register(appscontrollers.ClusterWebhookPath, &webhook.Admission{
+				Handler: &appscontrollers.ClusterValidationHandler{
+					Client:  mgr.GetClient(),
+					Decoder: admission.NewDecoder(mgr.GetScheme()),
+				},
+			})
+		}
+
+		if viper.GetBool(constant.CfgKeyPodEvictionSwitchover) {
+			mgr.GetWebhookServer().Register(appscontrollers.PodEvictionWebhookPath, &webhook.Admission{
+				Handler: &appscontrollers.PodEvictionHandler{
+					Client:   mgr.GetClient(),
+					Recorder: mgr.GetEventRecorderFor("pod-eviction-webhook"),
+				},
+			})
+		}
+
+		if interval := viper.GetDuration(constant.CfgKeyFleetStatusExportInterval); interval > 0 {
+			if err = mgr.Add(&appscontrollers.FleetStatusExporter{
+				Client:    mgr.GetClient(),
+				Log:       ctrl.Log.WithName("fleet-status-exporter"),
+				Interval:  interval,
+				Namespace: viper.GetString(constant.CfgKeyCtrlrMgrNS),
+				Name:      viper.GetString(constant.CfgKeyFleetStatusConfigMap),
+			}); err != nil {
+				setupLog.Error(err, "unable to add runnable", "runnable", "FleetStatusExporter")
+				os.Exit(1)
+			}
+		}
+
+		if err = (&appscontrollers.BackupReplicaReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("backup-replica-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "BackupReplica")
+			os.Exit(1)
+		}
+
 		if err = (&appscontrollers.BackupPolicyTemplateReconciler{
 			Client:   mgr.GetClient(),
 			Scheme:   mgr.GetScheme(),
@@ -525,9 +648,24 @@ func main() {
 			setupLog.Error(err, "unable to create controller", "controller", "NodeCountScaler")
 			os.Exit(1)
 		}
+		if viper.GetBool(constant.FeatureGateTestScenario) {
+			if err = (&experimentalcontrollers.TestScenarioReconciler{
+				Client:   mgr.GetClient(),
+				Scheme:   mgr.GetScheme(),
+				Recorder: mgr.GetEventRecorderFor("test-scenario-controller"),
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "TestScenario")
+				os.Exit(1)
+			}
+		}
 	}
 	// +kubebuilder:scaffold:builder
 
+	if err := mgr.Add(&intctrlutil.ShutdownDrainer{Client: mgr.GetClient()}); err != nil {
+		setupLog.Error(err, "unable to add runnable", "runnable", "ShutdownDrainer")
+		os.Exit(1)
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -0,0 +1,121 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	kzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/apecloud/kubeblocks/pkg/metricsadapter"
+)
+
+const (
+	// the ConfigMap published by the kube-apiserver with the CA to verify the requests proxied by it.
+	authenticationConfigMapNamespace = "kube-system"
+	authenticationConfigMapName      = "extension-apiserver-authentication"
+	requestHeaderClientCAKey         = "requestheader-client-ca-file"
+)
+
+func main() {
+	var (
+		config       metricsadapter.Config
+		rulesFile    string
+		clientCAFile string
+	)
+	pflag.StringVar(&config.Address, "bind-address", ":6443", "The address the metrics adapter binds to.")
+	pflag.StringVar(&config.TLSCertFile, "tls-cert-file", "", "The file containing the x509 certificate for HTTPS.")
+	pflag.StringVar(&config.TLSKeyFile, "tls-private-key-file", "", "The file containing the x509 private key matching --tls-cert-file.")
+	pflag.StringVar(&clientCAFile, "client-ca-file", "", "The file containing the CA to verify the client certificates of the kube-apiserver. "+
+		"If not set, the requestheader client CA published by the kube-apiserver is used.")
+	pflag.StringVar(&rulesFile, "rules-file", "", "The file containing the rules of the metrics to serve.")
+
+	opts := kzap.Options{
+		Development: true,
+	}
+	opts.BindFlags(flag.CommandLine)
+	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
+	pflag.Parse()
+	ctrl.SetLogger(kzap.New(kzap.UseFlagOptions(&opts)))
+
+	if len(rulesFile) == 0 {
+		panic(errors.New("the rules file is required"))
+	}
+	rules, err := metricsadapter.LoadRules(rulesFile)
+	if err != nil {
+		panic(errors.Wrap(err, "load metric rules failed"))
+	}
+
+	scheme := runtime.NewScheme()
+	if err = clientgoscheme.AddToScheme(scheme); err != nil {
+		panic(err)
+	}
+	cli, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: scheme})
+	if err != nil {
+		panic(errors.Wrap(err, "create client failed"))
+	}
+
+	if config.ClientCA, err = loadClientCA(cli, clientCAFile); err != nil {
+		panic(errors.Wrap(err, "load client CA failed"))
+	}
+	server, err := metricsadapter.NewServer(config, metricsadapter.NewProvider(cli, metricsadapter.NewHTTPScraper(), rules))
+	if err != nil {
+		panic(errors.Wrap(err, "create metrics adapter server failed"))
+	}
+	if err = server.StartNonBlocking(); err != nil {
+		panic(errors.Wrap(err, "HTTP server initialize failed"))
+	}
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGTERM, os.Interrupt)
+	<-stop
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_ = server.Shutdown(ctx)
+}
+
+func loadClientCA(cli client.Client, clientCAFile string) ([]byte, error) {
+	if len(clientCAFile) > 0 {
+		return os.ReadFile(clientCAFile)
+	}
+	cm := &corev1.ConfigMap{}
+	key := client.ObjectKey{Namespace: authenticationConfigMapNamespace, Name: authenticationConfigMapName}
+	if err := cli.Get(context.Background(), key, cm); err != nil {
+		return nil, err
+	}
+	ca, ok := cm.Data[requestHeaderClientCAKey]
+	if !ok {
+		return nil, errors.Errorf("%s is not found in ConfigMap %s", requestHeaderClientCAKey, key)
+	}
+	return []byte(ca), nil
+}
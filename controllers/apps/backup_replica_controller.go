@@ -0,0 +1,236 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	reasonBackupReplicaDesignated = "BackupReplicaDesignated"
+)
+
+// BackupReplicaReconciler designates a follower of the components which enable the backup replica as the dedicated
+// backup replica, and excludes it from the read services of the component while a backup runs on it.
+// All the replicas are labeled as read serving through the pod template, the reconciler only flips the label of the
+// backup replica.
+type BackupReplicaReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=components,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups,verbs=get;list;watch
+
+// Reconcile keeps the backup replica designation and the read serving label of the backup replica.
+// The designated replica is kept as long as it is an available follower, otherwise another available follower
+// is designated.
+func (r *BackupReplicaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("component", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	comp := &appsv1alpha1.Component{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, comp); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	clusterName := comp.Labels[constant.AppInstanceLabelKey]
+	compName := comp.Labels[constant.KBAppComponentLabelKey]
+	if clusterName == "" || compName == "" {
+		return intctrlutil.Reconciled()
+	}
+	podList := &corev1.PodList{}
+	if err := r.Client.List(reqCtx.Ctx, podList, client.InNamespace(comp.Namespace),
+		client.MatchingLabels(constant.GetComponentWellKnownLabels(clusterName, compName))); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	pods := podList.Items
+
+	designated := ""
+	backingUpPods := map[string]bool{}
+	if comp.Spec.BackupReplica != nil && *comp.Spec.BackupReplica && comp.DeletionTimestamp == nil {
+		var err error
+		if backingUpPods, err = r.backingUpPods(reqCtx, comp.Namespace, clusterName, compName); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		designated = designateBackupReplica(pods)
+		if designated != "" && !isBackupReplica(findPod(pods, designated)) {
+			r.Recorder.Eventf(comp, corev1.EventTypeNormal, reasonBackupReplicaDesignated,
+				"designated the replica %s as the backup replica", designated)
+		}
+	}
+
+	for i := range pods {
+		isDesignated := pods[i].Name == designated
+		if err := r.syncPodLabels(reqCtx, &pods[i], isDesignated, isDesignated && backingUpPods[pods[i].Name]); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+	}
+	return intctrlutil.Reconciled()
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *BackupReplicaReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("backup-replica").
+		For(&appsv1alpha1.Component{}).
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(r.componentOf)).
+		Watches(&dpv1alpha1.Backup{}, handler.EnqueueRequestsFromMapFunc(r.componentOf)).
+		Complete(r)
+}
+
+// componentOf maps the pods and backups to their components.
+func (r *BackupReplicaReconciler) componentOf(_ context.Context, obj client.Object) []reconcile.Request {
+	labels := obj.GetLabels()
+	clusterName := labels[constant.AppInstanceLabelKey]
+	compName := labels[constant.KBAppComponentLabelKey]
+	if clusterName == "" || compName == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{
+		Namespace: obj.GetNamespace(),
+		Name:      constant.GenerateClusterComponentName(clusterName, compName),
+	}}}
+}
+
+// backingUpPods returns the pods targeted by the running backups of the component.
+func (r *BackupReplicaReconciler) backingUpPods(reqCtx intctrlutil.RequestCtx, namespace, clusterName, compName string) (map[string]bool, error) {
+	backupList := &dpv1alpha1.BackupList{}
+	if err := r.Client.List(reqCtx.Ctx, backupList, client.InNamespace(namespace),
+		client.MatchingLabels{constant.AppInstanceLabelKey: clusterName, constant.KBAppComponentLabelKey: compName}); err != nil {
+		return nil, err
+	}
+	pods := map[string]bool{}
+	for _, backup := range backupList.Items {
+		if backup.Status.Phase != dpv1alpha1.BackupPhaseRunning {
+			continue
+		}
+		targets := backup.Status.Targets
+		if backup.Status.Target != nil {
+			targets = append(targets, *backup.Status.Target)
+		}
+		for _, target := range targets {
+			for _, podName := range target.SelectedTargetPods {
+				pods[podName] = true
+			}
+		}
+	}
+	return pods, nil
+}
+
+// syncPodLabels patches the backup replica label and the read serving label of the pod.
+// The read serving label is set to true through the pod template, it is only flipped to false while a backup runs on
+// the backup replica, and flipped back after that.
+func (r *BackupReplicaReconciler) syncPodLabels(reqCtx intctrlutil.RequestCtx, pod *corev1.Pod, backupReplica, backingUp bool) error {
+	patch := client.MergeFrom(pod.DeepCopy())
+	changed := false
+	if _, exist := pod.Labels[constant.BackupReplicaLabelKey]; exist && !backupReplica {
+		delete(pod.Labels, constant.BackupReplicaLabelKey)
+		changed = true
+	}
+	setLabel := func(key, value string) {
+		if pod.Labels == nil {
+			pod.Labels = map[string]string{}
+		}
+		if pod.Labels[key] != value {
+			pod.Labels[key] = value
+			changed = true
+		}
+	}
+	if backupReplica {
+		setLabel(constant.BackupReplicaLabelKey, "true")
+	}
+	if backingUp {
+		setLabel(constant.ReadServingLabelKey, "false")
+	} else if pod.Labels[constant.ReadServingLabelKey] == "false" {
+		setLabel(constant.ReadServingLabelKey, "true")
+	}
+	if !changed {
+		return nil
+	}
+	return client.IgnoreNotFound(r.Client.Patch(reqCtx.Ctx, pod, patch))
+}
+
+// designateBackupReplica returns the name of the pod designated as the backup replica. The designated replica is kept
+// if it is still an available follower, otherwise the available follower with the smallest name is designated.
+func designateBackupReplica(pods []corev1.Pod) string {
+	var candidates []string
+	for i := range pods {
+		if !isBackupReplicaCandidate(&pods[i]) {
+			continue
+		}
+		if isBackupReplica(&pods[i]) {
+			return pods[i].Name
+		}
+		candidates = append(candidates, pods[i].Name)
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+	sort.Strings(candidates)
+	return candidates[0]
+}
+
+// isBackupReplicaCandidate checks whether the pod is an available replica which is known as a secondary, the pods
+// whose roles are not probed yet are not designated as they may turn out to be the primary.
+func isBackupReplicaCandidate(pod *corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil || !intctrlutil.IsAvailable(pod, 0) {
+		return false
+	}
+	if len(pod.Labels[constant.RoleLabelKey]) == 0 {
+		return false
+	}
+	accessMode, ok := pod.Labels[constant.AccessModeLabelKey]
+	return ok && accessMode != string(appsv1alpha1.ReadWrite)
+}
+
+func isBackupReplica(pod *corev1.Pod) bool {
+	return pod != nil && pod.Labels[constant.BackupReplicaLabelKey] == "true"
+}
+
+func findPod(pods []corev1.Pod, name string) *corev1.Pod {
+	for i := range pods {
+		if pods[i].Name == name {
+			return &pods[i]
+		}
+	}
+	return nil
+}
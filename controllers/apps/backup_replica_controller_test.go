@@ -0,0 +1,193 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+var _ = Describe("backup replica", func() {
+	const (
+		namespace   = "default"
+		clusterName = "mycluster"
+		compName    = "mysql"
+	)
+
+	var (
+		ctx        = context.Background()
+		cli        client.Client
+		reconciler *BackupReplicaReconciler
+		comp       *appsv1alpha1.Component
+	)
+
+	newPod := func(name, role string, accessMode appsv1alpha1.AccessMode) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+				Labels: map[string]string{
+					constant.AppManagedByLabelKey:   constant.AppName,
+					constant.AppInstanceLabelKey:    clusterName,
+					constant.KBAppComponentLabelKey: compName,
+					constant.RoleLabelKey:           role,
+					constant.AccessModeLabelKey:     string(accessMode),
+					constant.ReadServingLabelKey:    "true",
+				},
+			},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		}
+	}
+
+	getPod := func(name string) *corev1.Pod {
+		pod := &corev1.Pod{}
+		Expect(cli.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, pod)).Should(Succeed())
+		return pod
+	}
+
+	reconcile := func() {
+		req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(comp)}
+		_, err := reconciler.Reconcile(ctx, req)
+		Expect(err).ShouldNot(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		compFullName := constant.GenerateClusterComponentName(clusterName, compName)
+		comp = &appsv1alpha1.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      compFullName,
+				Labels:    constant.GetComponentWellKnownLabels(clusterName, compName),
+			},
+			Spec: appsv1alpha1.ComponentSpec{BackupReplica: pointer.Bool(true)},
+		}
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+		Expect(appsv1alpha1.AddToScheme(scheme)).Should(Succeed())
+		Expect(dpv1alpha1.AddToScheme(scheme)).Should(Succeed())
+		cli = fake.NewClientBuilder().WithScheme(scheme).WithObjects(comp,
+			newPod(compFullName+"-0", "leader", appsv1alpha1.ReadWrite),
+			newPod(compFullName+"-1", "follower", appsv1alpha1.Readonly),
+			newPod(compFullName+"-2", "follower", appsv1alpha1.Readonly)).Build()
+		reconciler = &BackupReplicaReconciler{
+			Client:   cli,
+			Scheme:   scheme,
+			Recorder: record.NewFakeRecorder(10),
+		}
+	})
+
+	It("designates a follower and re-designates it if it fails", func() {
+		reconcile()
+		Expect(getPod(comp.Name + "-0").Labels).ShouldNot(HaveKey(constant.BackupReplicaLabelKey))
+		Expect(getPod(comp.Name + "-1").Labels).Should(HaveKeyWithValue(constant.BackupReplicaLabelKey, "true"))
+		Expect(getPod(comp.Name + "-2").Labels).ShouldNot(HaveKey(constant.BackupReplicaLabelKey))
+		for _, suffix := range []string{"-0", "-1", "-2"} {
+			Expect(getPod(comp.Name + suffix).Labels).Should(HaveKeyWithValue(constant.ReadServingLabelKey, "true"))
+		}
+
+		By("the designated replica fails")
+		pod := getPod(comp.Name + "-1")
+		pod.Status.Phase = corev1.PodFailed
+		Expect(cli.Status().Update(ctx, pod)).Should(Succeed())
+		reconcile()
+		Expect(getPod(comp.Name + "-1").Labels).ShouldNot(HaveKey(constant.BackupReplicaLabelKey))
+		Expect(getPod(comp.Name + "-2").Labels).Should(HaveKeyWithValue(constant.BackupReplicaLabelKey, "true"))
+	})
+
+	It("designates only the replicas known as secondaries", func() {
+		for _, suffix := range []string{"-1", "-2"} {
+			pod := getPod(comp.Name + suffix)
+			delete(pod.Labels, constant.RoleLabelKey)
+			delete(pod.Labels, constant.AccessModeLabelKey)
+			Expect(cli.Update(ctx, pod)).Should(Succeed())
+		}
+		reconcile()
+		for _, suffix := range []string{"-0", "-1", "-2"} {
+			Expect(getPod(comp.Name + suffix).Labels).ShouldNot(HaveKey(constant.BackupReplicaLabelKey))
+		}
+
+		By("the role of the replica is probed")
+		pod := getPod(comp.Name + "-2")
+		pod.Labels[constant.RoleLabelKey] = "follower"
+		pod.Labels[constant.AccessModeLabelKey] = string(appsv1alpha1.Readonly)
+		Expect(cli.Update(ctx, pod)).Should(Succeed())
+		reconcile()
+		Expect(getPod(comp.Name + "-2").Labels).Should(HaveKeyWithValue(constant.BackupReplicaLabelKey, "true"))
+	})
+
+	It("excludes the backup replica from the read services during backups", func() {
+		reconcile()
+		backup := &dpv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      "backup",
+				Labels:    constant.GetComponentWellKnownLabels(clusterName, compName),
+			},
+			Status: dpv1alpha1.BackupStatus{
+				Phase:  dpv1alpha1.BackupPhaseRunning,
+				Target: &dpv1alpha1.BackupStatusTarget{SelectedTargetPods: []string{comp.Name + "-1"}},
+			},
+		}
+		Expect(cli.Create(ctx, backup)).Should(Succeed())
+		reconcile()
+		Expect(getPod(comp.Name + "-1").Labels).Should(HaveKeyWithValue(constant.ReadServingLabelKey, "false"))
+		Expect(getPod(comp.Name + "-2").Labels).Should(HaveKeyWithValue(constant.ReadServingLabelKey, "true"))
+
+		By("the backup is completed")
+		backup.Status.Phase = dpv1alpha1.BackupPhaseCompleted
+		Expect(cli.Update(ctx, backup)).Should(Succeed())
+		reconcile()
+		Expect(getPod(comp.Name + "-1").Labels).Should(HaveKeyWithValue(constant.ReadServingLabelKey, "true"))
+	})
+
+	It("removes the designation if the backup replica is disabled", func() {
+		reconcile()
+		pod := getPod(comp.Name + "-1")
+		pod.Labels[constant.ReadServingLabelKey] = "false"
+		Expect(cli.Update(ctx, pod)).Should(Succeed())
+		comp.Spec.BackupReplica = nil
+		Expect(cli.Update(ctx, comp)).Should(Succeed())
+		reconcile()
+		for _, suffix := range []string{"-0", "-1", "-2"} {
+			Expect(getPod(comp.Name + suffix).Labels).ShouldNot(HaveKey(constant.BackupReplicaLabelKey))
+			Expect(getPod(comp.Name + suffix).Labels).Should(HaveKeyWithValue(constant.ReadServingLabelKey, "true"))
+		}
+	})
+})
@@ -24,7 +24,6 @@ import (
 	"math"
 
 	corev1 "k8s.io/api/core/v1"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -43,6 +42,8 @@ import (
 // +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=clusters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=clusters/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=clusters/finalizers,verbs=update
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=namespacepolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
 
 // owned K8s core API resources controller-gen RBAC marker
 // full access on core API resources
@@ -93,6 +94,18 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 
 	reqCtx.Log.V(1).Info("reconcile", "cluster", req.NamespacedName)
 
+	// merge the NamespacePolicy into the newly created cluster, the patch triggers another reconciliation
+	if applied, err := r.reconcileNamespacePolicy(reqCtx); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	} else if applied {
+		return intctrlutil.Reconciled()
+	}
+
+	// simulate the change requested by the annotation before the plan, it doesn't change anything but the status
+	if err := r.reconcileSimulation(reqCtx); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+
 	// the cluster reconciliation loop is a 3-stage model: plan Init, plan Build and plan Execute
 	// Init stage
 	planBuilder := newClusterPlanBuilder(reqCtx, r.Client)
@@ -104,11 +117,20 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		if re, ok := err.(intctrlutil.RequeueError); ok {
 			return intctrlutil.RequeueAfter(re.RequeueAfter(), reqCtx.Log, re.Reason())
 		}
-		if apierrors.IsConflict(err) {
+		class := intctrlutil.RecordReconcileError("cluster", err)
+		if class == intctrlutil.ErrorClassConflict {
 			return intctrlutil.Requeue(reqCtx.Log, err.Error())
 		}
 		c := planBuilder.(*clusterPlanBuilder)
 		sendWarningEventWithError(r.Recorder, c.transCtx.Cluster, corev1.EventTypeWarning, err)
+		if class == intctrlutil.ErrorClassTerminal {
+			// retrying will not help, record the error in the status and wait for the spec to be changed.
+			if patchErr := patchTerminalErrorCondition(reqCtx.Ctx, r.Client, c.transCtx.Cluster, &c.transCtx.Cluster.Status.Conditions, err); patchErr != nil {
+				return intctrlutil.RequeueWithError(patchErr, reqCtx.Log, "")
+			}
+			reqCtx.Log.Info("terminal error, stop requeueing", "error", err.Error())
+			return intctrlutil.Reconciled()
+		}
 		return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
 	}
 
@@ -135,6 +157,8 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			&clusterHaltRecoveryTransformer{},
 			// update finalizer and cd&cv labels
 			&clusterAssureMetaTransformer{},
+			// generate the components to adopt the existing StatefulSets
+			&clusterAdoptionTransformer{},
 			// validate cd & cv's existence and availability
 			&clusterLoadRefResourcesTransformer{},
 			// normalize the cluster and component API
@@ -147,6 +171,10 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			&clusterRestoreTransformer{},
 			// create all cluster components objects
 			&clusterComponentTransformer{},
+			// publish the routing metadata of shardings
+			&clusterShardingRoutingTransformer{},
+			// publish the connection contract of the cluster
+			&clusterConnectionContractTransformer{},
 			// update cluster components' status
 			&clusterComponentStatusTransformer{},
 			// build backuppolicy and backupschedule from backupPolicyTemplate
@@ -157,6 +185,10 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			&clusterSecretTransformer{},
 			// update cluster status
 			&clusterStatusTransformer{},
+			// record the history of cluster generations
+			&clusterHistoryTransformer{},
+			// estimate the monthly cost of the cluster
+			&clusterCostTransformer{},
 			// always safe to put your transformer below
 		).
 		Build()
@@ -0,0 +1,177 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// terminationPolicyProtectionLevels ranks the termination policies from the least protective to the most.
+var terminationPolicyProtectionLevels = map[appsv1alpha1.TerminationPolicyType]int{
+	appsv1alpha1.WipeOut:        0,
+	appsv1alpha1.Delete:         1,
+	appsv1alpha1.Retain:         2,
+	appsv1alpha1.Halt:           3,
+	appsv1alpha1.DoNotTerminate: 4,
+}
+
+// reconcileNamespacePolicy merges the NamespacePolicy matching the namespace into the newly created Cluster.
+// The policy is applied only once, it returns true if the Cluster is patched, and the patch triggers another reconciliation.
+func (r *ClusterReconciler) reconcileNamespacePolicy(reqCtx intctrlutil.RequestCtx) (bool, error) {
+	cluster := &appsv1alpha1.Cluster{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, cluster); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	if cluster.IsDeleting() || cluster.Status.ObservedGeneration > 0 {
+		return false, nil
+	}
+	if _, ok := cluster.Annotations[constant.NamespacePolicyAnnotationKey]; ok {
+		return false, nil
+	}
+
+	policy, err := r.matchNamespacePolicy(reqCtx, cluster.Namespace)
+	if err != nil || policy == nil {
+		return false, err
+	}
+	patch := client.MergeFrom(cluster.DeepCopy())
+	applyNamespacePolicy(cluster, policy)
+	if cluster.Annotations == nil {
+		cluster.Annotations = map[string]string{}
+	}
+	cluster.Annotations[constant.NamespacePolicyAnnotationKey] = policy.Name
+	if err = r.Client.Patch(reqCtx.Ctx, cluster, patch); err != nil {
+		return false, err
+	}
+	r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "NamespacePolicyApplied",
+		"the NamespacePolicy %s is applied to the cluster", policy.Name)
+	return true, nil
+}
+
+// matchNamespacePolicy returns the NamespacePolicy with the highest priority that matches the labels of the namespace.
+func (r *ClusterReconciler) matchNamespacePolicy(reqCtx intctrlutil.RequestCtx, namespace string) (*appsv1alpha1.NamespacePolicy, error) {
+	policyList := &appsv1alpha1.NamespacePolicyList{}
+	if err := r.Client.List(reqCtx.Ctx, policyList); err != nil {
+		return nil, err
+	}
+	if len(policyList.Items) == 0 {
+		return nil, nil
+	}
+	ns := &corev1.Namespace{}
+	if err := r.Client.Get(reqCtx.Ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		return nil, err
+	}
+
+	var matched []appsv1alpha1.NamespacePolicy
+	for _, policy := range policyList.Items {
+		selector := labels.Everything()
+		if policy.Spec.NamespaceSelector != nil {
+			var err error
+			if selector, err = metav1.LabelSelectorAsSelector(policy.Spec.NamespaceSelector); err != nil {
+				reqCtx.Log.Info("invalid namespace selector of NamespacePolicy, skip it", "policy", policy.Name, "error", err.Error())
+				continue
+			}
+		}
+		if selector.Matches(labels.Set(ns.Labels)) {
+			matched = append(matched, policy)
+		}
+	}
+	if len(matched) == 0 {
+		return nil, nil
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].Spec.Priority != matched[j].Spec.Priority {
+			return matched[i].Spec.Priority > matched[j].Spec.Priority
+		}
+		return matched[i].Name < matched[j].Name
+	})
+	return &matched[0], nil
+}
+
+// applyNamespacePolicy merges the defaults of the policy into the spec of the Cluster,
+// the fields specified in the Cluster take precedence except the baselines enforced by the policy.
+func applyNamespacePolicy(cluster *appsv1alpha1.Cluster, policy *appsv1alpha1.NamespacePolicy) {
+	spec := &policy.Spec
+	if spec.TerminationPolicy != "" &&
+		terminationPolicyProtectionLevels[cluster.Spec.TerminationPolicy] < terminationPolicyProtectionLevels[spec.TerminationPolicy] {
+		cluster.Spec.TerminationPolicy = spec.TerminationPolicy
+	}
+	if spec.Backup != nil && cluster.Spec.Backup == nil {
+		cluster.Spec.Backup = spec.Backup.DeepCopy()
+	}
+
+	applyToComp := func(compSpec *appsv1alpha1.ClusterComponentSpec) {
+		if spec.MonitoringEnabled != nil && compSpec.DisableExporter == nil && compSpec.Monitor == nil {
+			disableExporter := !*spec.MonitoringEnabled
+			compSpec.DisableExporter = &disableExporter
+		}
+		if spec.Resources != nil {
+			clampResources(&compSpec.Resources, spec.Resources)
+			for i := range compSpec.Instances {
+				if compSpec.Instances[i].Resources != nil {
+					clampResources(compSpec.Instances[i].Resources, spec.Resources)
+				}
+			}
+		}
+	}
+	for i := range cluster.Spec.ComponentSpecs {
+		applyToComp(&cluster.Spec.ComponentSpecs[i])
+	}
+	for i := range cluster.Spec.ShardingSpecs {
+		applyToComp(&cluster.Spec.ShardingSpecs[i].Template)
+	}
+}
+
+// clampResources raises the resources to the floor and lowers them to the ceiling,
+// the requests not set default to the floor and the limits not set default to the ceiling.
+func clampResources(resources *corev1.ResourceRequirements, bounds *appsv1alpha1.NamespacePolicyResources) {
+	set := func(list *corev1.ResourceList, name corev1.ResourceName, quantity *resource.Quantity) {
+		if *list == nil {
+			*list = corev1.ResourceList{}
+		}
+		(*list)[name] = quantity.DeepCopy()
+	}
+	for name, floor := range bounds.Min {
+		if q, ok := resources.Requests[name]; !ok || q.Cmp(floor) < 0 {
+			set(&resources.Requests, name, &floor)
+		}
+		if q, ok := resources.Limits[name]; ok && q.Cmp(floor) < 0 {
+			set(&resources.Limits, name, &floor)
+		}
+	}
+	for name, ceiling := range bounds.Max {
+		if q, ok := resources.Limits[name]; !ok || q.Cmp(ceiling) > 0 {
+			set(&resources.Limits, name, &ceiling)
+		}
+		if q, ok := resources.Requests[name]; ok && q.Cmp(ceiling) > 0 {
+			set(&resources.Requests, name, &ceiling)
+		}
+	}
+}
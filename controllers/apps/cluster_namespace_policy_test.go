@@ -0,0 +1,117 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+var _ = Describe("namespace policy", func() {
+	newCluster := func(terminationPolicy appsv1alpha1.TerminationPolicyType, resources corev1.ResourceRequirements) *appsv1alpha1.Cluster {
+		return &appsv1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "mycluster"},
+			Spec: appsv1alpha1.ClusterSpec{
+				TerminationPolicy: terminationPolicy,
+				ComponentSpecs: []appsv1alpha1.ClusterComponentSpec{{
+					Name:      "mysql",
+					Resources: resources,
+				}},
+				ShardingSpecs: []appsv1alpha1.ShardingSpec{{
+					Name: "shard",
+					Template: appsv1alpha1.ClusterComponentSpec{
+						Name:            "shard",
+						DisableExporter: pointer.Bool(false),
+					},
+				}},
+			},
+		}
+	}
+
+	newPolicy := func() *appsv1alpha1.NamespacePolicy {
+		return &appsv1alpha1.NamespacePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "baseline"},
+			Spec: appsv1alpha1.NamespacePolicySpec{
+				TerminationPolicy: appsv1alpha1.Halt,
+				Backup: &appsv1alpha1.ClusterBackup{
+					Enabled:        pointer.Bool(true),
+					CronExpression: "0 18 * * *",
+				},
+				MonitoringEnabled: pointer.Bool(true),
+				Resources: &appsv1alpha1.NamespacePolicyResources{
+					Min: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+					Max: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("4"),
+						corev1.ResourceMemory: resource.MustParse("8Gi"),
+					},
+				},
+			},
+		}
+	}
+
+	It("merges the defaults and enforces the baselines", func() {
+		cluster := newCluster(appsv1alpha1.Delete, corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("16Gi")},
+			Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8")},
+		})
+		applyNamespacePolicy(cluster, newPolicy())
+
+		Expect(cluster.Spec.TerminationPolicy).Should(Equal(appsv1alpha1.Halt))
+		Expect(cluster.Spec.Backup).ShouldNot(BeNil())
+		Expect(cluster.Spec.Backup.CronExpression).Should(Equal("0 18 * * *"))
+
+		comp := cluster.Spec.ComponentSpecs[0]
+		Expect(comp.DisableExporter).ShouldNot(BeNil())
+		Expect(*comp.DisableExporter).Should(BeFalse())
+		Expect(comp.Resources.Requests.Cpu().String()).Should(Equal("500m"))
+		Expect(comp.Resources.Requests.Memory().String()).Should(Equal("8Gi"))
+		Expect(comp.Resources.Limits.Cpu().String()).Should(Equal("4"))
+		Expect(comp.Resources.Limits.Memory().String()).Should(Equal("8Gi"))
+
+		shard := cluster.Spec.ShardingSpecs[0].Template
+		Expect(*shard.DisableExporter).Should(BeFalse())
+		Expect(shard.Resources.Requests.Cpu().String()).Should(Equal("500m"))
+	})
+
+	It("keeps the settings of the cluster", func() {
+		cluster := newCluster(appsv1alpha1.DoNotTerminate, corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+		})
+		cluster.Spec.Backup = &appsv1alpha1.ClusterBackup{Enabled: pointer.Bool(false)}
+		cluster.Spec.ComponentSpecs[0].DisableExporter = pointer.Bool(true)
+		applyNamespacePolicy(cluster, newPolicy())
+
+		Expect(cluster.Spec.TerminationPolicy).Should(Equal(appsv1alpha1.DoNotTerminate))
+		Expect(*cluster.Spec.Backup.Enabled).Should(BeFalse())
+
+		comp := cluster.Spec.ComponentSpecs[0]
+		Expect(*comp.DisableExporter).Should(BeTrue())
+		Expect(comp.Resources.Requests.Cpu().String()).Should(Equal("1"))
+		Expect(comp.Resources.Limits.Cpu().String()).Should(Equal("2"))
+	})
+})
@@ -186,6 +186,19 @@ func (c *clusterPlanBuilder) Build() (graph.Plan, error) {
 // Plan implementation
 
 func (p *clusterPlan) Execute() error {
+	// the plan is executed to completion even if the operator is shutting down, to not leave the objects half-updated.
+	key := fmt.Sprintf("cluster-plan.%s.%s", p.transCtx.OrigCluster.Namespace, p.transCtx.OrigCluster.Name)
+	return intctrlutil.RunToCompletion(p.transCtx.Context, key, func(ctx context.Context) error {
+		reqCtx := p.transCtx.Context
+		p.transCtx.Context = ctx
+		defer func() {
+			p.transCtx.Context = reqCtx
+		}()
+		return p.execute()
+	})
+}
+
+func (p *clusterPlan) execute() error {
 	less := func(v1, v2 graph.Vertex) bool {
 		getWeight := func(v graph.Vertex) int {
 			lifecycleVertex, ok := v.(*model.ObjectVertex)
@@ -0,0 +1,347 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// nonPodSpecFields are the fields of the Component spec whose changes don't recreate the pods.
+var nonPodSpecFields = sets.New("replicas", "instances", "offlineInstances", "volumeClaimTemplates", "stop",
+	"labels", "annotations", "services", "systemAccounts", "configs", "replicaWeights", "backupReplica",
+	"parallelPodManagementConcurrency", "podUpdatePolicy")
+
+// reconcileSimulation simulates the change of the Cluster requested by the annotation apps.kubeblocks.io/simulate,
+// and records the changes of the child objects it would cause in status.simulation, nothing is applied.
+func (r *ClusterReconciler) reconcileSimulation(reqCtx intctrlutil.RequestCtx) error {
+	cluster := &appsv1alpha1.Cluster{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, cluster); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if cluster.IsDeleting() {
+		return nil
+	}
+
+	change, ok := cluster.Annotations[constant.SimulateSpecAnnotationKey]
+	if !ok {
+		if cluster.Status.Simulation == nil {
+			return nil
+		}
+		patch := client.MergeFrom(cluster.DeepCopy())
+		cluster.Status.Simulation = nil
+		return r.Client.Status().Patch(reqCtx.Ctx, cluster, patch)
+	}
+
+	// the simulation is recomputed if either the change or the current spec is modified
+	observedChange := fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprintf("%d/%s", cluster.Generation, change))))[:16]
+	if cluster.Status.Simulation != nil && cluster.Status.Simulation.ObservedChange == observedChange {
+		return nil
+	}
+	simulation := &appsv1alpha1.ClusterSimulation{
+		ObservedChange: observedChange,
+		SimulationTime: metav1.Now(),
+	}
+	changes, err := r.simulate(reqCtx, cluster, change)
+	if err != nil {
+		if intctrlutil.IsRequeueError(err) {
+			return err
+		}
+		simulation.Message = err.Error()
+	}
+	simulation.Changes = changes
+
+	patch := client.MergeFrom(cluster.DeepCopy())
+	cluster.Status.Simulation = simulation
+	return r.Client.Status().Patch(reqCtx.Ctx, cluster, patch)
+}
+
+// simulate runs the transformers generating the child objects of the Cluster against the changed Cluster,
+// and collects the changes from the DAG instead of executing it.
+func (r *ClusterReconciler) simulate(reqCtx intctrlutil.RequestCtx, cluster *appsv1alpha1.Cluster, change string) ([]appsv1alpha1.SimulatedChange, error) {
+	simulated, err := applySimulatedChange(cluster, change)
+	if err != nil {
+		return nil, err
+	}
+
+	// the events of the simulation are dropped
+	simulationCtx := reqCtx
+	simulationCtx.Recorder = &record.FakeRecorder{}
+	planBuilder := newClusterPlanBuilder(simulationCtx, r.Client)
+	plan, err := planBuilder.
+		AddTransformer(
+			&clusterInitTransformer{cluster: simulated},
+			&clusterLoadRefResourcesTransformer{},
+			&ClusterAPINormalizationTransformer{},
+			&clusterServiceTransformer{},
+			&clusterComponentTransformer{},
+			&clusterBackupPolicyTransformer{},
+		).
+		Build()
+	if err != nil && !intctrlutil.IsDelayedRequeueError(err) {
+		return nil, err
+	}
+
+	var changes []appsv1alpha1.SimulatedChange
+	for _, v := range plan.(*clusterPlan).dag.Vertices() {
+		vertex, ok := v.(*model.ObjectVertex)
+		if !ok || vertex.Action == nil {
+			continue
+		}
+		if _, ok = vertex.Obj.(*appsv1alpha1.Cluster); ok {
+			continue
+		}
+		var action appsv1alpha1.SimulatedAction
+		switch *vertex.Action {
+		case model.CREATE:
+			action = appsv1alpha1.SimulatedCreate
+		case model.UPDATE, model.PATCH:
+			if vertex.OriObj != nil && apiequality.Semantic.DeepEqual(vertex.OriObj, vertex.Obj) {
+				continue
+			}
+			action = appsv1alpha1.SimulatedUpdate
+		case model.DELETE:
+			action = appsv1alpha1.SimulatedDelete
+		default:
+			continue
+		}
+		obj := vertex.Obj
+		kind := reflect.TypeOf(obj).Elem().Name()
+		if comp, ok := obj.(*appsv1alpha1.Component); ok {
+			oldComp, _ := vertex.OriObj.(*appsv1alpha1.Component)
+			compChanges, err := simulateComponentChanges(cluster.Name, action, oldComp, comp)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, compChanges...)
+			continue
+		}
+		changes = append(changes, appsv1alpha1.SimulatedChange{Kind: kind, Name: obj.GetName(), Action: action})
+	}
+	changes = dedupSimulatedChanges(changes)
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Kind != changes[j].Kind {
+			return changes[i].Kind < changes[j].Kind
+		}
+		return changes[i].Name < changes[j].Name
+	})
+	return changes, nil
+}
+
+// dedupSimulatedChanges drops the updates of the objects which are to be deleted or created too, e.g. the Component
+// to be deleted is updated to mark the deletion first.
+func dedupSimulatedChanges(changes []appsv1alpha1.SimulatedChange) []appsv1alpha1.SimulatedChange {
+	key := func(change appsv1alpha1.SimulatedChange) string {
+		return change.Kind + "/" + change.Name
+	}
+	overridden := sets.New[string]()
+	for _, change := range changes {
+		if change.Action == appsv1alpha1.SimulatedDelete || change.Action == appsv1alpha1.SimulatedCreate {
+			overridden.Insert(key(change))
+		}
+	}
+	var result []appsv1alpha1.SimulatedChange
+	seen := sets.New[string]()
+	for _, change := range changes {
+		if overridden.Has(key(change)) && change.Action != appsv1alpha1.SimulatedDelete && change.Action != appsv1alpha1.SimulatedCreate {
+			continue
+		}
+		if seen.Has(key(change)) {
+			continue
+		}
+		seen.Insert(key(change))
+		result = append(result, change)
+	}
+	return result
+}
+
+// applySimulatedChange applies the change, which is a JSON merge patch, to a copy of the Cluster.
+func applySimulatedChange(cluster *appsv1alpha1.Cluster, change string) (*appsv1alpha1.Cluster, error) {
+	original, err := json.Marshal(cluster)
+	if err != nil {
+		return nil, err
+	}
+	patched, err := jsonpatch.MergePatch(original, []byte(change))
+	if err != nil {
+		return nil, fmt.Errorf("invalid simulated change: %s", err.Error())
+	}
+	simulated := &appsv1alpha1.Cluster{}
+	if err = json.Unmarshal(patched, simulated); err != nil {
+		return nil, fmt.Errorf("invalid simulated change: %s", err.Error())
+	}
+	// keep the identity of the Cluster and take the change as a new generation
+	simulated.ObjectMeta = *cluster.ObjectMeta.DeepCopy()
+	simulated.Generation = cluster.Generation + 1
+	simulated.Status = *cluster.Status.DeepCopy()
+	return simulated, nil
+}
+
+// simulateComponentChanges computes the changes of the Component and its pods and PVCs.
+func simulateComponentChanges(clusterName string, action appsv1alpha1.SimulatedAction,
+	oldComp, newComp *appsv1alpha1.Component) ([]appsv1alpha1.SimulatedChange, error) {
+	compName, err := component.ShortName(clusterName, newComp.Name)
+	if err != nil {
+		return nil, err
+	}
+	podsOf := func(comp *appsv1alpha1.Component) (map[string]string, error) {
+		if comp == nil || (comp.Spec.Stop != nil && *comp.Spec.Stop) {
+			return map[string]string{}, nil
+		}
+		return component.GenerateAllPodNamesToSet(comp.Spec.Replicas, comp.Spec.Instances, comp.Spec.OfflineInstances, clusterName, compName)
+	}
+	if action == appsv1alpha1.SimulatedCreate {
+		oldComp = nil
+	}
+	if action == appsv1alpha1.SimulatedDelete {
+		oldComp, newComp = newComp, nil
+	}
+	oldPods, err := podsOf(oldComp)
+	if err != nil {
+		return nil, err
+	}
+	newPods, err := podsOf(newComp)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []appsv1alpha1.SimulatedChange{{Kind: "Component", Name: constant.GenerateClusterComponentName(clusterName, compName), Action: action, Component: compName}}
+	if oldComp != nil && newComp != nil {
+		changes[0].Details = strings.Join(diffSpecFields(oldComp.Spec, newComp.Spec), ",")
+	}
+	addChange := func(kind, objName string, action appsv1alpha1.SimulatedAction, details string) {
+		changes = append(changes, appsv1alpha1.SimulatedChange{Kind: kind, Name: objName, Action: action, Component: compName, Details: details})
+	}
+
+	var recreatedFields, changedTemplates []string
+	if oldComp != nil && newComp != nil {
+		for _, field := range diffSpecFields(oldComp.Spec, newComp.Spec) {
+			if !nonPodSpecFields.Has(field) {
+				recreatedFields = append(recreatedFields, field)
+			}
+		}
+		changedTemplates = diffInstanceTemplates(oldComp.Spec.Instances, newComp.Spec.Instances)
+	}
+	for _, pod := range sets.List(sets.KeySet(oldPods).Union(sets.KeySet(newPods))) {
+		oldTpl, inOld := oldPods[pod]
+		newTpl, inNew := newPods[pod]
+		switch {
+		case !inOld:
+			addChange("Pod", pod, appsv1alpha1.SimulatedCreate, "")
+		case !inNew:
+			addChange("Pod", pod, appsv1alpha1.SimulatedDelete, "")
+		case len(recreatedFields) > 0:
+			addChange("Pod", pod, appsv1alpha1.SimulatedRecreate, strings.Join(recreatedFields, ","))
+		case oldTpl != newTpl || (newTpl != "" && sets.New(changedTemplates...).Has(newTpl)):
+			addChange("Pod", pod, appsv1alpha1.SimulatedRecreate, "instances")
+		}
+	}
+
+	var oldVCTs, newVCTs []appsv1alpha1.ClusterComponentVolumeClaimTemplate
+	if oldComp != nil {
+		oldVCTs = oldComp.Spec.VolumeClaimTemplates
+	}
+	if newComp != nil {
+		newVCTs = newComp.Spec.VolumeClaimTemplates
+	}
+	oldStorage, newStorage := storageOfVCTs(oldVCTs), storageOfVCTs(newVCTs)
+	for _, vct := range sets.List(sets.KeySet(oldStorage).Union(sets.KeySet(newStorage))) {
+		oldSize, inOld := oldStorage[vct]
+		newSize, inNew := newStorage[vct]
+		for _, pod := range sets.List(sets.KeySet(oldPods).Union(sets.KeySet(newPods))) {
+			pvc := fmt.Sprintf("%s-%s", vct, pod)
+			_, podInOld := oldPods[pod]
+			_, podInNew := newPods[pod]
+			switch {
+			case podInNew && (!podInOld || !inOld):
+				if inNew {
+					addChange("PersistentVolumeClaim", pvc, appsv1alpha1.SimulatedCreate, newSize)
+				}
+			case podInOld && (!podInNew || !inNew):
+				// the PVCs of the deleted pods are retained or deleted according to the PVC retention policy
+				addChange("PersistentVolumeClaim", pvc, appsv1alpha1.SimulatedDelete, "")
+			case oldSize != newSize:
+				addChange("PersistentVolumeClaim", pvc, appsv1alpha1.SimulatedResize, fmt.Sprintf("%s -> %s", oldSize, newSize))
+			}
+		}
+	}
+	return changes, nil
+}
+
+// diffSpecFields returns the json names of the top-level fields which are different in the two specs.
+func diffSpecFields(oldSpec, newSpec appsv1alpha1.ComponentSpec) []string {
+	var fields []string
+	oldValue, newValue := reflect.ValueOf(oldSpec), reflect.ValueOf(newSpec)
+	for i := 0; i < oldValue.NumField(); i++ {
+		if apiequality.Semantic.DeepEqual(oldValue.Field(i).Interface(), newValue.Field(i).Interface()) {
+			continue
+		}
+		name := strings.Split(oldValue.Type().Field(i).Tag.Get("json"), ",")[0]
+		fields = append(fields, name)
+	}
+	return fields
+}
+
+// diffInstanceTemplates returns the names of the instance templates which are changed.
+func diffInstanceTemplates(oldTpls, newTpls []appsv1alpha1.InstanceTemplate) []string {
+	oldTplMap := map[string]appsv1alpha1.InstanceTemplate{}
+	for _, tpl := range oldTpls {
+		oldTplMap[tpl.Name] = tpl
+	}
+	var names []string
+	for _, tpl := range newTpls {
+		oldTpl, ok := oldTplMap[tpl.Name]
+		if !ok {
+			continue
+		}
+		// the replicas of the template don't change the existing pods
+		oldTpl.Replicas = tpl.Replicas
+		if !apiequality.Semantic.DeepEqual(oldTpl, tpl) {
+			names = append(names, tpl.Name)
+		}
+	}
+	return names
+}
+
+func storageOfVCTs(vcts []appsv1alpha1.ClusterComponentVolumeClaimTemplate) map[string]string {
+	storage := map[string]string{}
+	for _, vct := range vcts {
+		size := vct.Spec.Resources.Requests[corev1.ResourceStorage]
+		storage[vct.Name] = size.String()
+	}
+	return storage
+}
@@ -0,0 +1,118 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+var _ = Describe("cluster simulation", func() {
+	const clusterName = "mycluster"
+
+	newComp := func(replicas int32, cpu, storage string) *appsv1alpha1.Component {
+		return &appsv1alpha1.Component{
+			ObjectMeta: metav1.ObjectMeta{Name: clusterName + "-mysql"},
+			Spec: appsv1alpha1.ComponentSpec{
+				Replicas: replicas,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+				},
+				VolumeClaimTemplates: []appsv1alpha1.ClusterComponentVolumeClaimTemplate{{
+					Name: "data",
+					Spec: appsv1alpha1.PersistentVolumeClaimSpec{
+						Resources: corev1.VolumeResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(storage)},
+						},
+					},
+				}},
+			},
+		}
+	}
+
+	changesOf := func(changes []appsv1alpha1.SimulatedChange, kind string) map[string]appsv1alpha1.SimulatedAction {
+		result := map[string]appsv1alpha1.SimulatedAction{}
+		for _, change := range changes {
+			if change.Kind == kind {
+				result[change.Name] = change.Action
+			}
+		}
+		return result
+	}
+
+	It("applies the change to a copy of the cluster", func() {
+		cluster := &appsv1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: clusterName, Generation: 2},
+			Spec: appsv1alpha1.ClusterSpec{
+				ComponentSpecs: []appsv1alpha1.ClusterComponentSpec{{Name: "mysql", Replicas: 1}},
+			},
+		}
+		simulated, err := applySimulatedChange(cluster, `{"spec":{"componentSpecs":[{"name":"mysql","replicas":3}]}}`)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(simulated.Spec.ComponentSpecs[0].Replicas).Should(BeEquivalentTo(3))
+		Expect(simulated.Generation).Should(BeEquivalentTo(3))
+		Expect(cluster.Spec.ComponentSpecs[0].Replicas).Should(BeEquivalentTo(1))
+
+		_, err = applySimulatedChange(cluster, `{"spec":`)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("computes the changes of the pods and PVCs of the scaled out component", func() {
+		changes, err := simulateComponentChanges(clusterName, appsv1alpha1.SimulatedUpdate, newComp(2, "1", "10Gi"), newComp(3, "1", "20Gi"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(changesOf(changes, "Component")).Should(Equal(map[string]appsv1alpha1.SimulatedAction{
+			"mycluster-mysql": appsv1alpha1.SimulatedUpdate,
+		}))
+		Expect(changesOf(changes, "Pod")).Should(Equal(map[string]appsv1alpha1.SimulatedAction{
+			"mycluster-mysql-2": appsv1alpha1.SimulatedCreate,
+		}))
+		Expect(changesOf(changes, "PersistentVolumeClaim")).Should(Equal(map[string]appsv1alpha1.SimulatedAction{
+			"data-mycluster-mysql-0": appsv1alpha1.SimulatedResize,
+			"data-mycluster-mysql-1": appsv1alpha1.SimulatedResize,
+			"data-mycluster-mysql-2": appsv1alpha1.SimulatedCreate,
+		}))
+	})
+
+	It("computes the pods recreated by the changed resources", func() {
+		changes, err := simulateComponentChanges(clusterName, appsv1alpha1.SimulatedUpdate, newComp(2, "1", "10Gi"), newComp(1, "2", "10Gi"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(changesOf(changes, "Pod")).Should(Equal(map[string]appsv1alpha1.SimulatedAction{
+			"mycluster-mysql-0": appsv1alpha1.SimulatedRecreate,
+			"mycluster-mysql-1": appsv1alpha1.SimulatedDelete,
+		}))
+		Expect(changesOf(changes, "PersistentVolumeClaim")).Should(Equal(map[string]appsv1alpha1.SimulatedAction{
+			"data-mycluster-mysql-1": appsv1alpha1.SimulatedDelete,
+		}))
+	})
+
+	It("computes the changes of the deleted component", func() {
+		changes, err := simulateComponentChanges(clusterName, appsv1alpha1.SimulatedDelete, nil, newComp(1, "1", "10Gi"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(changesOf(changes, "Component")).Should(HaveKeyWithValue("mycluster-mysql", appsv1alpha1.SimulatedDelete))
+		Expect(changesOf(changes, "Pod")).Should(HaveKeyWithValue("mycluster-mysql-0", appsv1alpha1.SimulatedDelete))
+		Expect(changesOf(changes, "PersistentVolumeClaim")).Should(HaveKeyWithValue("data-mycluster-mysql-0", appsv1alpha1.SimulatedDelete))
+	})
+})
@@ -36,10 +36,23 @@ const (
 	ReasonPreCheckFailed        = "PreCheckFailed"        // ReasonPreCheckFailed preChecks failed for provisioning started
 	ReasonApplyResourcesFailed  = "ApplyResourcesFailed"  // ReasonApplyResourcesFailed applies resources failed to create or change the cluster
 	ReasonApplyResourcesSucceed = "ApplyResourcesSucceed" // ReasonApplyResourcesSucceed applies resources succeeded to create or change the cluster
+	ReasonTerminalError         = "TerminalError"         // ReasonTerminalError applies resources failed and retrying will not help until the spec is changed
 	ReasonReplicasNotReady      = "ReplicasNotReady"      // ReasonReplicasNotReady the pods of components are not ready
 	ReasonAllReplicasReady      = "AllReplicasReady"      // ReasonAllReplicasReady the pods of components are ready
 	ReasonComponentsNotReady    = "ComponentsNotReady"    // ReasonComponentsNotReady the components of cluster are not ready
 	ReasonClusterReady          = "ClusterReady"          // ReasonClusterReady the components of cluster are ready, the component phase is running
+	ReasonFinalBackupRunning    = "FinalBackupRunning"    // ReasonFinalBackupRunning the final backup is running before the cluster is deleted
+	ReasonFinalBackupCompleted  = "FinalBackupCompleted"  // ReasonFinalBackupCompleted the final backup is completed, the cluster can be deleted
+	ReasonFinalBackupFailed     = "FinalBackupFailed"     // ReasonFinalBackupFailed the final backup failed, the deletion of the cluster is blocked
+	ReasonFinalBackupSkipped    = "FinalBackupSkipped"    // ReasonFinalBackupSkipped the final backup is skipped by the annotation, the cluster is deleted without it
+	ReasonArtifactsRemoving     = "ArtifactsRemoving"     // ReasonArtifactsRemoving the backup artifacts are being removed before the cluster is wiped out
+	ReasonWipeOutCompleted      = "WipeOutCompleted"      // ReasonWipeOutCompleted all the backup artifacts are removed, the cluster is wiped out
+	ReasonWipeOutIncomplete     = "WipeOutIncomplete"     // ReasonWipeOutIncomplete the cluster is wiped out, but some backup artifacts failed to be removed
+	ReasonClusterArchived       = "ClusterArchived"       // ReasonClusterArchived the spec and the final backup of the cluster are archived before deletion
+	ReasonServiceVersionRisk    = "ServiceVersionRisk"    // ReasonServiceVersionRisk some components run service versions which are end of life or have known vulnerabilities
+	ReasonDataMasking           = "DataMasking"           // ReasonDataMasking the components of cluster are running, but the restored data is being masked
+	ReasonConverged             = "Converged"             // ReasonConverged all the components have rolled out the current generation of the cluster
+	ReasonNotConverged          = "NotConverged"          // ReasonNotConverged some components have not rolled out the current generation of the cluster
 )
 
 func setProvisioningStartedCondition(conditions *[]metav1.Condition, clusterName string, clusterGeneration int64, err error) {
@@ -114,6 +127,17 @@ func newFailedApplyResourcesCondition(err error) metav1.Condition {
 	}
 }
 
+// newTerminalErrorCondition creates a condition when the reconciliation stops because of a terminal error.
+func newTerminalErrorCondition(generation int64, err error) metav1.Condition {
+	return metav1.Condition{
+		Type:               appsv1alpha1.ConditionTypeApplyResources,
+		ObservedGeneration: generation,
+		Status:             metav1.ConditionFalse,
+		Message:            err.Error(),
+		Reason:             ReasonTerminalError,
+	}
+}
+
 // newAllReplicasPodsReadyConditions creates a condition when all pods of components are ready
 func newAllReplicasPodsReadyConditions() metav1.Condition {
 	return metav1.Condition{
@@ -146,6 +170,16 @@ func newClusterReadyCondition(clusterName string) metav1.Condition {
 	}
 }
 
+// newDataMaskingCondition creates a condition when the restored data of cluster is being masked
+func newDataMaskingCondition(clusterName string) metav1.Condition {
+	return metav1.Condition{
+		Type:    appsv1alpha1.ConditionTypeReady,
+		Status:  metav1.ConditionFalse,
+		Message: fmt.Sprintf("Cluster: %s is running, waiting for the restored data to be masked", clusterName),
+		Reason:  ReasonDataMasking,
+	}
+}
+
 // newComponentsNotReadyCondition creates a condition when components of cluster are not ready
 func newComponentsNotReadyCondition(notReadyComponentNames map[string]struct{}) metav1.Condition {
 	cNameSlice := maps.Keys(notReadyComponentNames)
@@ -157,3 +191,70 @@ func newComponentsNotReadyCondition(notReadyComponentNames map[string]struct{})
 		Reason:  ReasonComponentsNotReady,
 	}
 }
+
+// newFinalBackupRunningCondition creates a condition when the final backup is running before the cluster is deleted
+func newFinalBackupRunningCondition(clusterName, backupName string) metav1.Condition {
+	return metav1.Condition{
+		Type:    appsv1alpha1.ConditionTypeFinalBackup,
+		Status:  metav1.ConditionFalse,
+		Message: fmt.Sprintf("Cluster: %s is waiting for the final backup %s to complete before deletion", clusterName, backupName),
+		Reason:  ReasonFinalBackupRunning,
+	}
+}
+
+// newFinalBackupCompletedCondition creates a condition when the final backup is completed
+func newFinalBackupCompletedCondition(backupName string) metav1.Condition {
+	return metav1.Condition{
+		Type:    appsv1alpha1.ConditionTypeFinalBackup,
+		Status:  metav1.ConditionTrue,
+		Message: fmt.Sprintf("the final backup %s is completed", backupName),
+		Reason:  ReasonFinalBackupCompleted,
+	}
+}
+
+// newFinalBackupFailedCondition creates a condition when the final backup failed, which blocks the deletion of the cluster
+func newFinalBackupFailedCondition(clusterName, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:    appsv1alpha1.ConditionTypeFinalBackup,
+		Status:  metav1.ConditionFalse,
+		Message: fmt.Sprintf("the deletion of Cluster: %s is blocked, %s", clusterName, message),
+		Reason:  ReasonFinalBackupFailed,
+	}
+}
+
+// newArtifactsRemovingCondition creates a condition when the backup artifacts are being removed
+func newArtifactsRemovingCondition(message string) metav1.Condition {
+	return metav1.Condition{
+		Type:    appsv1alpha1.ConditionTypeArtifactsRemoval,
+		Status:  metav1.ConditionFalse,
+		Message: message,
+		Reason:  ReasonArtifactsRemoving,
+	}
+}
+
+// newConvergedCondition creates a condition when all the components have rolled out the current generation of the cluster
+func newConvergedCondition(clusterGeneration int64) metav1.Condition {
+	return metav1.Condition{
+		Type:               appsv1alpha1.ConditionTypeConverged,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: clusterGeneration,
+		Message:            fmt.Sprintf("the generation %d of Cluster is rolled out to all the Components", clusterGeneration),
+		Reason:             ReasonConverged,
+	}
+}
+
+// newNotConvergedCondition creates a condition when some components have not rolled out the current generation of the cluster
+func newNotConvergedCondition(clusterGeneration int64, notConvergedCompNames []string) metav1.Condition {
+	message := fmt.Sprintf("waiting for the generation %d of Cluster to be applied", clusterGeneration)
+	if len(notConvergedCompNames) > 0 {
+		slices.Sort(notConvergedCompNames)
+		message = fmt.Sprintf("waiting for the generation %d of Cluster to be rolled out to Components: %v", clusterGeneration, notConvergedCompNames)
+	}
+	return metav1.Condition{
+		Type:               appsv1alpha1.ConditionTypeConverged,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: clusterGeneration,
+		Message:            message,
+		Reason:             ReasonNotConverged,
+	}
+}
@@ -0,0 +1,210 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+// ClusterWebhookPath is the path to serve the cluster validating webhook.
+const ClusterWebhookPath = "/validate-apps-kubeblocks-io-v1alpha1-cluster"
+
+// ClusterValidationHandler validates the Clusters. If the replicas protection is enabled, it rejects the replicas
+// of a running component being set to 0 by editing the spec, the Stop OpsRequest should be used instead, and the
+// replicas being dropped below the minimum declared in the ComponentDefinition. If the service version risk policy
+// is "Block", it rejects the components being added with, or changed to, the service versions which are end of life
+// or have known vulnerabilities.
+type ClusterValidationHandler struct {
+	Client  client.Client
+	Decoder *admission.Decoder
+}
+
+// +kubebuilder:webhook:path=/validate-apps-kubeblocks-io-v1alpha1-cluster,mutating=false,failurePolicy=fail,sideEffects=None,groups=apps.kubeblocks.io,resources=clusters,verbs=create;update,versions=v1alpha1,name=vcluster.kb.io,admissionReviewVersions=v1
+
+var _ admission.Handler = &ClusterValidationHandler{}
+
+// Handle validates the cluster.
+func (h *ClusterValidationHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+	cluster, oldCluster := &appsv1alpha1.Cluster{}, &appsv1alpha1.Cluster{}
+	if err := h.Decoder.Decode(req, cluster); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if req.Operation == admissionv1.Update {
+		if err := h.Decoder.DecodeRaw(req.OldObject, oldCluster); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+	}
+	if !cluster.DeletionTimestamp.IsZero() {
+		return admission.Allowed("")
+	}
+
+	if resp := h.validateServiceVersionRisk(ctx, cluster, oldCluster); !resp.Allowed {
+		return resp
+	}
+	if req.Operation != admissionv1.Update || !viper.GetBool(constant.FeatureGateReplicasProtection) {
+		return admission.Allowed("")
+	}
+	for _, compSpec := range cluster.Spec.ComponentSpecs {
+		if resp := h.validateReplicas(ctx, cluster, compSpec, oldCluster.Spec.GetComponentByName(compSpec.Name), false); !resp.Allowed {
+			return resp
+		}
+	}
+	for _, shardingSpec := range cluster.Spec.ShardingSpecs {
+		for _, oldShardingSpec := range oldCluster.Spec.ShardingSpecs {
+			if oldShardingSpec.Name != shardingSpec.Name {
+				continue
+			}
+			template := shardingSpec.Template
+			template.Name = shardingSpec.Name
+			if resp := h.validateReplicas(ctx, cluster, template, &oldShardingSpec.Template, true); !resp.Allowed {
+				return resp
+			}
+		}
+	}
+	return admission.Allowed("")
+}
+
+// validateServiceVersionRisk only checks the components which are added, or whose definitions or service versions
+// are changed, so that the running components are never blocked.
+func (h *ClusterValidationHandler) validateServiceVersionRisk(ctx context.Context, cluster, oldCluster *appsv1alpha1.Cluster) admission.Response {
+	if viper.GetString(constant.CfgKeyServiceVersionRiskPolicy) != component.ServiceVersionRiskPolicyBlock {
+		return admission.Allowed("")
+	}
+	compSpecs, err := h.compSpecs4ServiceVersion(ctx, cluster)
+	if err != nil {
+		return h.errored(err)
+	}
+	oldCompSpecs, err := h.compSpecs4ServiceVersion(ctx, oldCluster)
+	if err != nil {
+		return h.errored(err)
+	}
+	for name, compSpec := range compSpecs {
+		oldCompSpec, ok := oldCompSpecs[name]
+		if len(compSpec.ComponentDef) == 0 ||
+			ok && oldCompSpec.ComponentDef == compSpec.ComponentDef && oldCompSpec.ServiceVersion == compSpec.ServiceVersion {
+			continue
+		}
+		compDef, serviceVersion, err := resolveCompDefinitionNServiceVersion(ctx, h.Client, compSpec.ComponentDef, compSpec.ServiceVersion)
+		if err != nil {
+			// the definition can't be resolved, leave it to be reported by the controller.
+			continue
+		}
+		risk, _, err := component.ResolveServiceVersionRisk(ctx, h.Client, compDef, serviceVersion)
+		if err != nil {
+			return h.errored(err)
+		}
+		if risk != nil {
+			return admission.Denied(fmt.Sprintf(`component "%s" is rejected by the service version risk policy: %s`, name, risk.Message()))
+		}
+	}
+	return admission.Allowed("")
+}
+
+// compSpecs4ServiceVersion returns the specs of the components and the sharding templates of the cluster keyed by
+// the name, with the definitions of the components created from the cluster topology filled in.
+func (h *ClusterValidationHandler) compSpecs4ServiceVersion(ctx context.Context,
+	cluster *appsv1alpha1.Cluster) (map[string]appsv1alpha1.ClusterComponentSpec, error) {
+	compSpecs := make(map[string]appsv1alpha1.ClusterComponentSpec)
+	for _, compSpec := range cluster.Spec.ComponentSpecs {
+		compSpecs[compSpec.Name] = compSpec
+	}
+	for _, shardingSpec := range cluster.Spec.ShardingSpecs {
+		compSpecs[shardingSpec.Name] = shardingSpec.Template
+	}
+	if len(cluster.Spec.ClusterDefRef) == 0 {
+		return compSpecs, nil
+	}
+	clusterDef := &appsv1alpha1.ClusterDefinition{}
+	if err := h.Client.Get(ctx, types.NamespacedName{Name: cluster.Spec.ClusterDefRef}, clusterDef); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	topology := referredClusterTopology(clusterDef, cluster.Spec.Topology)
+	if topology == nil {
+		return compSpecs, nil
+	}
+	for _, comp := range topology.Components {
+		compSpec := compSpecs[comp.Name]
+		if len(compSpec.ComponentDef) == 0 {
+			compSpec.ComponentDef = comp.CompDef
+		}
+		compSpecs[comp.Name] = compSpec
+	}
+	return compSpecs, nil
+}
+
+// validateReplicas only checks the components whose replicas are decreased, so that the existing specs are never blocked.
+func (h *ClusterValidationHandler) validateReplicas(ctx context.Context, cluster *appsv1alpha1.Cluster,
+	compSpec appsv1alpha1.ClusterComponentSpec, oldCompSpec *appsv1alpha1.ClusterComponentSpec, isSharding bool) admission.Response {
+	if oldCompSpec == nil || compSpec.Replicas >= oldCompSpec.Replicas {
+		return admission.Allowed("")
+	}
+	if compSpec.Replicas == 0 {
+		if boolptr.IsSetToTrue(compSpec.Stop) {
+			return admission.Allowed("")
+		}
+		return admission.Denied(fmt.Sprintf(`setting the replicas of component "%s" to 0 is rejected, use the Stop OpsRequest to stop it instead`, compSpec.Name))
+	}
+
+	compDefName := compSpec.ComponentDef
+	if len(compDefName) == 0 && !isSharding {
+		// the component is created from the ClusterDefinition, get the resolved definition from the Component object.
+		comp := &appsv1alpha1.Component{}
+		compKey := types.NamespacedName{Namespace: cluster.Namespace, Name: constant.GenerateClusterComponentName(cluster.Name, compSpec.Name)}
+		if err := h.Client.Get(ctx, compKey, comp); err != nil {
+			return h.errored(err)
+		}
+		compDefName = comp.Spec.CompDef
+	}
+	if len(compDefName) == 0 {
+		return admission.Allowed("")
+	}
+	compDef := &appsv1alpha1.ComponentDefinition{}
+	if err := h.Client.Get(ctx, types.NamespacedName{Name: compDefName}, compDef); err != nil {
+		return h.errored(err)
+	}
+	if compDef.Spec.ReplicasLimit != nil && compSpec.Replicas < compDef.Spec.ReplicasLimit.MinReplicas {
+		return admission.Denied(fmt.Sprintf(`the replicas of component "%s" can't be less than %d declared in the ComponentDefinition "%s"`,
+			compSpec.Name, compDef.Spec.ReplicasLimit.MinReplicas, compDefName))
+	}
+	return admission.Allowed("")
+}
+
+func (h *ClusterValidationHandler) errored(err error) admission.Response {
+	if client.IgnoreNotFound(err) == nil {
+		return admission.Allowed("")
+	}
+	return admission.Errored(http.StatusInternalServerError, err)
+}
@@ -0,0 +1,174 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+var _ = Describe("cluster webhook", func() {
+	const (
+		namespace   = "default"
+		clusterName = "mycluster"
+		compName    = "mysql"
+		compDefName = "mysql-8.0"
+	)
+
+	newCluster := func(replicas int32, stop *bool) *appsv1alpha1.Cluster {
+		return &appsv1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: clusterName},
+			Spec: appsv1alpha1.ClusterSpec{
+				ComponentSpecs: []appsv1alpha1.ClusterComponentSpec{
+					{Name: compName, ComponentDef: compDefName, Replicas: replicas, Stop: stop},
+				},
+			},
+		}
+	}
+
+	newRequest := func(cluster, oldCluster *appsv1alpha1.Cluster) admission.Request {
+		object, err := json.Marshal(cluster)
+		Expect(err).Should(Succeed())
+		req := admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Namespace: namespace,
+				Name:      clusterName,
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: object},
+			},
+		}
+		if oldCluster != nil {
+			oldObject, err := json.Marshal(oldCluster)
+			Expect(err).Should(Succeed())
+			req.Operation = admissionv1.Update
+			req.OldObject = runtime.RawExtension{Raw: oldObject}
+		}
+		return req
+	}
+
+	var handler *ClusterValidationHandler
+
+	BeforeEach(func() {
+		compDef := &appsv1alpha1.ComponentDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: compDefName},
+			Spec: appsv1alpha1.ComponentDefinitionSpec{
+				ReplicasLimit: &appsv1alpha1.ReplicasLimit{MinReplicas: 2, MaxReplicas: 5},
+			},
+		}
+		compVersion := &appsv1alpha1.ComponentVersion{
+			ObjectMeta: metav1.ObjectMeta{Name: "mysql", Labels: map[string]string{compDefName: compDefName}},
+			Spec: appsv1alpha1.ComponentVersionSpec{
+				CompatibilityRules: []appsv1alpha1.ComponentVersionCompatibilityRule{
+					{CompDefs: []string{compDefName}, Releases: []string{"r0", "r1"}},
+				},
+				Releases: []appsv1alpha1.ComponentVersionRelease{
+					{Name: "r0", ServiceVersion: "8.0.30", CVEs: []string{"CVE-2024-20961"}},
+					{Name: "r1", ServiceVersion: "8.0.36"},
+				},
+			},
+			Status: appsv1alpha1.ComponentVersionStatus{Phase: appsv1alpha1.AvailablePhase},
+		}
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+		Expect(appsv1alpha1.AddToScheme(scheme)).Should(Succeed())
+		handler = &ClusterValidationHandler{
+			Client:  fake.NewClientBuilder().WithScheme(scheme).WithObjects(compDef, compVersion).Build(),
+			Decoder: admission.NewDecoder(scheme),
+		}
+		viper.Set(constant.FeatureGateReplicasProtection, true)
+	})
+
+	AfterEach(func() {
+		viper.Set(constant.FeatureGateReplicasProtection, false)
+		viper.Set(constant.CfgKeyServiceVersionRiskPolicy, component.ServiceVersionRiskPolicyWarn)
+	})
+
+	It("rejects setting the replicas of a running component to 0", func() {
+		resp := handler.Handle(context.Background(), newRequest(newCluster(0, nil), newCluster(3, nil)))
+		Expect(resp.Allowed).Should(BeFalse())
+		Expect(resp.Result.Message).Should(ContainSubstring("use the Stop OpsRequest"))
+
+		By("the component is allowed to be stopped")
+		Expect(handler.Handle(context.Background(), newRequest(newCluster(0, pointer.Bool(true)), newCluster(3, nil))).Allowed).Should(BeTrue())
+	})
+
+	It("rejects dropping the replicas below the minimum of the definition", func() {
+		resp := handler.Handle(context.Background(), newRequest(newCluster(1, nil), newCluster(3, nil)))
+		Expect(resp.Allowed).Should(BeFalse())
+		Expect(resp.Result.Message).Should(ContainSubstring("can't be less than 2"))
+
+		Expect(handler.Handle(context.Background(), newRequest(newCluster(2, nil), newCluster(3, nil))).Allowed).Should(BeTrue())
+	})
+
+	It("allows everything if the replicas protection is disabled", func() {
+		viper.Set(constant.FeatureGateReplicasProtection, false)
+		Expect(handler.Handle(context.Background(), newRequest(newCluster(0, nil), newCluster(3, nil))).Allowed).Should(BeTrue())
+	})
+
+	Context("service version risk", func() {
+		withServiceVersion := func(cluster *appsv1alpha1.Cluster, serviceVersion string) *appsv1alpha1.Cluster {
+			cluster.Spec.ComponentSpecs[0].ServiceVersion = serviceVersion
+			return cluster
+		}
+
+		BeforeEach(func() {
+			viper.Set(constant.CfgKeyServiceVersionRiskPolicy, component.ServiceVersionRiskPolicyBlock)
+		})
+
+		It("rejects the components with the risky service versions", func() {
+			resp := handler.Handle(context.Background(), newRequest(withServiceVersion(newCluster(3, nil), "8.0.30"), nil))
+			Expect(resp.Allowed).Should(BeFalse())
+			Expect(resp.Result.Message).Should(ContainSubstring("CVE-2024-20961"))
+
+			Expect(handler.Handle(context.Background(), newRequest(withServiceVersion(newCluster(3, nil), "8.0.36"), nil)).Allowed).Should(BeTrue())
+
+			By("changing the service version to a risky one")
+			resp = handler.Handle(context.Background(), newRequest(withServiceVersion(newCluster(3, nil), "8.0.30"),
+				withServiceVersion(newCluster(3, nil), "8.0.36")))
+			Expect(resp.Allowed).Should(BeFalse())
+		})
+
+		It("allows the running components with the risky service versions", func() {
+			Expect(handler.Handle(context.Background(), newRequest(withServiceVersion(newCluster(4, nil), "8.0.30"),
+				withServiceVersion(newCluster(3, nil), "8.0.30"))).Allowed).Should(BeTrue())
+		})
+
+		It("allows everything if the policy is Warn", func() {
+			viper.Set(constant.CfgKeyServiceVersionRiskPolicy, component.ServiceVersionRiskPolicyWarn)
+			Expect(handler.Handle(context.Background(), newRequest(withServiceVersion(newCluster(3, nil), "8.0.30"), nil)).Allowed).Should(BeTrue())
+		})
+	})
+})
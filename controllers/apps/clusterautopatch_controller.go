@@ -0,0 +1,408 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	// autoPatchCheckInterval is the interval to check the new patch releases for the clusters.
+	autoPatchCheckInterval = time.Hour
+	// autoPatchBackoffBase and autoPatchBackoffMax bound the exponential backoff after failed automatic upgrades.
+	autoPatchBackoffBase = 10 * time.Minute
+	autoPatchBackoffMax  = 24 * time.Hour
+	// autoPatchOpsTTL is the time to keep the succeeded OpsRequests of automatic upgrades,
+	// the failed ones are kept to skip their targets.
+	autoPatchOpsTTL = 7 * 24 * time.Hour
+
+	reasonAutoPatchCreated = "AutoPatchCreated"
+)
+
+// ClusterAutoPatchReconciler upgrades the components of a Cluster to the latest patch releases of their
+// service versions automatically, by creating Upgrade OpsRequests.
+type ClusterAutoPatchReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=clusters,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=components,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=componentdefinitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=componentversions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsrequests,verbs=get;list;watch;create
+
+// Reconcile creates an Upgrade OpsRequest for the first component, in the order of the upgrade policy, that has
+// a newer patch release in the ComponentVersion catalog, if the Cluster is running and within its maintenance window
+// and disruption windows.
+// Only one OpsRequest is in progress for a Cluster at a time, so the components are rolled out one by one.
+func (r *ClusterAutoPatchReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("cluster", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	cluster := &appsv1alpha1.Cluster{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, cluster); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if !autoPatchEnabled(cluster) {
+		return intctrlutil.Reconciled()
+	}
+	if cluster.Status.Phase != appsv1alpha1.RunningClusterPhase {
+		return intctrlutil.RequeueAfter(autoPatchCheckInterval, reqCtx.Log, "cluster is not running")
+	}
+
+	running, err := hasRunningOpsRequest(reqCtx.Ctx, r.Client, cluster)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if running {
+		return intctrlutil.RequeueAfter(autoPatchCheckInterval, reqCtx.Log, "cluster has running OpsRequests")
+	}
+
+	if open, wait := inMaintenanceWindow(cluster.Spec.UpgradePolicy.MaintenanceWindow, time.Now()); !open {
+		return intctrlutil.RequeueAfter(wait, reqCtx.Log, "wait for the maintenance window")
+	}
+	if open, wait := intctrlutil.InDisruptionWindows(intctrlutil.GetClusterDisruptionWindows(cluster), time.Now()); !open {
+		if wait == 0 {
+			wait = autoPatchCheckInterval
+		}
+		return intctrlutil.RequeueAfter(wait, reqCtx.Log, "wait for the disruption windows")
+	}
+
+	history, err := r.autoPatchHistory(reqCtx, cluster, time.Now())
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if history.backoff > 0 {
+		return intctrlutil.RequeueAfter(history.backoff, reqCtx.Log, "back off after the failed automatic upgrades")
+	}
+
+	for _, compName := range autoPatchComponentOrder(cluster) {
+		target, err := r.latestPatchVersion(reqCtx, cluster, compName)
+		if err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		if target == "" {
+			continue
+		}
+		if history.failedTargets.Has(autoPatchTarget(compName, target)) {
+			// never retry a failed upgrade automatically, it can be retried by deleting the failed OpsRequest.
+			continue
+		}
+		if err = r.createUpgradeOpsRequest(reqCtx, cluster, compName, target); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		break
+	}
+	return intctrlutil.RequeueAfter(autoPatchCheckInterval, reqCtx.Log, "")
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterAutoPatchReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("cluster-auto-patch").
+		For(&appsv1alpha1.Cluster{}).
+		Watches(&appsv1alpha1.ComponentVersion{}, handler.EnqueueRequestsFromMapFunc(r.autoPatchClusters)).
+		Complete(r)
+}
+
+// autoPatchClusters enqueues all the clusters with auto patch enabled when a ComponentVersion changes,
+// the compatibility with the ComponentVersion is resolved in the reconciliation.
+func (r *ClusterAutoPatchReconciler) autoPatchClusters(ctx context.Context, obj client.Object) []reconcile.Request {
+	clusters := &appsv1alpha1.ClusterList{}
+	if err := r.Client.List(ctx, clusters); err != nil {
+		return nil
+	}
+	requests := make([]reconcile.Request, 0)
+	for i := range clusters.Items {
+		if autoPatchEnabled(&clusters.Items[i]) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(&clusters.Items[i]),
+			})
+		}
+	}
+	return requests
+}
+
+// hasRunningOpsRequest checks whether the cluster has any OpsRequest not completed yet.
+func hasRunningOpsRequest(ctx context.Context, cli client.Reader, cluster *appsv1alpha1.Cluster) (bool, error) {
+	opsList := &appsv1alpha1.OpsRequestList{}
+	if err := cli.List(ctx, opsList, client.InNamespace(cluster.Namespace),
+		client.MatchingLabels{constant.AppInstanceLabelKey: cluster.Name}); err != nil {
+		return false, err
+	}
+	for i := range opsList.Items {
+		if !opsList.Items[i].IsComplete() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type autoPatchHistoryResult struct {
+	// failedTargets are the targets of the failed automatic upgrades, in the format of "<component>/<serviceVersion>".
+	failedTargets sets.Set[string]
+	// backoff is the duration to wait before the next automatic upgrade.
+	backoff time.Duration
+}
+
+// autoPatchHistory collects the failed automatic upgrades of the cluster, and computes the backoff by the number of
+// failures since the last succeeded one.
+func (r *ClusterAutoPatchReconciler) autoPatchHistory(reqCtx intctrlutil.RequestCtx,
+	cluster *appsv1alpha1.Cluster, now time.Time) (autoPatchHistoryResult, error) {
+	opsList := &appsv1alpha1.OpsRequestList{}
+	if err := r.Client.List(reqCtx.Ctx, opsList, client.InNamespace(cluster.Namespace),
+		client.MatchingLabels{constant.AppInstanceLabelKey: cluster.Name, constant.OpsRequestAutoPatchLabelKey: "true"}); err != nil {
+		return autoPatchHistoryResult{}, err
+	}
+	return buildAutoPatchHistory(opsList.Items, now), nil
+}
+
+func buildAutoPatchHistory(opsList []appsv1alpha1.OpsRequest, now time.Time) autoPatchHistoryResult {
+	result := autoPatchHistoryResult{failedTargets: sets.New[string]()}
+	var lastSucceed, lastFailed time.Time
+	for _, ops := range opsList {
+		completed := ops.Status.CompletionTimestamp
+		if completed.IsZero() {
+			continue
+		}
+		switch ops.Status.Phase {
+		case appsv1alpha1.OpsSucceedPhase:
+			if completed.After(lastSucceed) {
+				lastSucceed = completed.Time
+			}
+		case appsv1alpha1.OpsFailedPhase:
+			if ops.Spec.Upgrade != nil {
+				for _, comp := range ops.Spec.Upgrade.Components {
+					if comp.ServiceVersion != nil {
+						result.failedTargets.Insert(autoPatchTarget(comp.ComponentName, *comp.ServiceVersion))
+					}
+				}
+			}
+			if completed.After(lastFailed) {
+				lastFailed = completed.Time
+			}
+		}
+	}
+	failures := 0
+	for _, ops := range opsList {
+		completed := ops.Status.CompletionTimestamp
+		if ops.Status.Phase == appsv1alpha1.OpsFailedPhase && completed.After(lastSucceed) {
+			failures++
+		}
+	}
+	if failures == 0 {
+		return result
+	}
+	backoff := autoPatchBackoffMax
+	if failures <= 8 {
+		backoff = min(autoPatchBackoffBase*time.Duration(1<<(failures-1)), autoPatchBackoffMax)
+	}
+	result.backoff = max(lastFailed.Add(backoff).Sub(now), 0)
+	return result
+}
+
+func autoPatchTarget(compName, serviceVersion string) string {
+	return fmt.Sprintf("%s/%s", compName, serviceVersion)
+}
+
+// latestPatchVersion returns the latest patch release compatible with the component or sharding, or empty if it
+// is already at the latest one.
+func (r *ClusterAutoPatchReconciler) latestPatchVersion(reqCtx intctrlutil.RequestCtx,
+	cluster *appsv1alpha1.Cluster, compName string) (string, error) {
+	comp, err := r.getAutoPatchComponent(reqCtx, cluster, compName)
+	if err != nil || comp == nil {
+		return "", err
+	}
+	if comp.Spec.ServiceVersion == "" {
+		return "", nil
+	}
+	compDef := &appsv1alpha1.ComponentDefinition{}
+	if err := r.Client.Get(reqCtx.Ctx, types.NamespacedName{Name: comp.Spec.CompDef}, compDef); err != nil {
+		return "", client.IgnoreNotFound(err)
+	}
+	compVersions, err := component.CompatibleCompVersions4Definition(reqCtx.Ctx, r.Client, compDef)
+	if err != nil {
+		return "", err
+	}
+	candidates := sets.New[string]()
+	for _, compVersion := range compVersions {
+		candidates = candidates.Union(compatibleServiceVersions4Definition(compDef, compVersion))
+	}
+	return latestPatchRelease(comp.Spec.ServiceVersion, sets.List(candidates)), nil
+}
+
+// getAutoPatchComponent returns the Component object of the component, or of any shard if it is a sharding,
+// the shards share the same definition and service version.
+func (r *ClusterAutoPatchReconciler) getAutoPatchComponent(reqCtx intctrlutil.RequestCtx,
+	cluster *appsv1alpha1.Cluster, compName string) (*appsv1alpha1.Component, error) {
+	if cluster.Spec.GetComponentByName(compName) != nil {
+		comp := &appsv1alpha1.Component{}
+		compKey := types.NamespacedName{
+			Namespace: cluster.Namespace,
+			Name:      constant.GenerateClusterComponentName(cluster.Name, compName),
+		}
+		if err := r.Client.Get(reqCtx.Ctx, compKey, comp); err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+		return comp, nil
+	}
+	compList := &appsv1alpha1.ComponentList{}
+	if err := r.Client.List(reqCtx.Ctx, compList, client.InNamespace(cluster.Namespace),
+		client.MatchingLabels{constant.AppInstanceLabelKey: cluster.Name, constant.KBAppShardingNameLabelKey: compName}); err != nil {
+		return nil, err
+	}
+	if len(compList.Items) == 0 {
+		return nil, nil
+	}
+	return &compList.Items[0], nil
+}
+
+func (r *ClusterAutoPatchReconciler) createUpgradeOpsRequest(reqCtx intctrlutil.RequestCtx,
+	cluster *appsv1alpha1.Cluster, compName, serviceVersion string) error {
+	ops := &appsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:    cluster.Namespace,
+			GenerateName: fmt.Sprintf("%s-auto-patch-", cluster.Name),
+			Labels: map[string]string{
+				constant.AppInstanceLabelKey:         cluster.Name,
+				constant.OpsRequestTypeLabelKey:      string(appsv1alpha1.UpgradeType),
+				constant.OpsRequestAutoPatchLabelKey: "true",
+			},
+		},
+		Spec: appsv1alpha1.OpsRequestSpec{
+			ClusterName:            cluster.Name,
+			Type:                   appsv1alpha1.UpgradeType,
+			TTLSecondsAfterSucceed: int32(autoPatchOpsTTL.Seconds()),
+			SpecificOpsRequest: appsv1alpha1.SpecificOpsRequest{
+				Upgrade: &appsv1alpha1.Upgrade{
+					Components: []appsv1alpha1.UpgradeComponent{
+						{
+							ComponentOps:   appsv1alpha1.ComponentOps{ComponentName: compName},
+							ServiceVersion: &serviceVersion,
+						},
+					},
+				},
+			},
+		},
+	}
+	if err := r.Client.Create(reqCtx.Ctx, ops); err != nil {
+		return err
+	}
+	r.Recorder.Eventf(cluster, corev1.EventTypeNormal, reasonAutoPatchCreated,
+		"created OpsRequest %s to upgrade component %s to service version %s", ops.Name, compName, serviceVersion)
+	return nil
+}
+
+func autoPatchEnabled(cluster *appsv1alpha1.Cluster) bool {
+	if model.IsObjectDeleting(cluster) {
+		return false
+	}
+	if cluster.Spec.UpgradePolicy == nil || !cluster.Spec.UpgradePolicy.AutoPatch {
+		return false
+	}
+	return cluster.Annotations[constant.SkipAutoPatchAnnotationKey] != "true"
+}
+
+// autoPatchComponentOrder returns the components and shardings in the order of the upgrade policy, followed by the
+// ones not listed in alphabetical order.
+func autoPatchComponentOrder(cluster *appsv1alpha1.Cluster) []string {
+	names := make([]string, 0, len(cluster.Spec.ComponentSpecs)+len(cluster.Spec.ShardingSpecs))
+	for _, spec := range cluster.Spec.ComponentSpecs {
+		names = append(names, spec.Name)
+	}
+	for _, spec := range cluster.Spec.ShardingSpecs {
+		names = append(names, spec.Name)
+	}
+	slices.Sort(names)
+	ordered := make([]string, 0, len(names))
+	for _, name := range cluster.Spec.UpgradePolicy.ComponentOrder {
+		if slices.Contains(names, name) && !slices.Contains(ordered, name) {
+			ordered = append(ordered, name)
+		}
+	}
+	for _, name := range names {
+		if !slices.Contains(ordered, name) {
+			ordered = append(ordered, name)
+		}
+	}
+	return ordered
+}
+
+// latestPatchRelease returns the latest release in candidates that shares the major and minor version with
+// the current service version and has a higher patch version. Pre-releases are never chosen.
+func latestPatchRelease(current string, candidates []string) string {
+	currentVer, err := version.ParseSemantic(current)
+	if err != nil {
+		return ""
+	}
+	var latest *version.Version
+	latestName := ""
+	for _, candidate := range candidates {
+		ver, err := version.ParseSemantic(candidate)
+		if err != nil || ver.PreRelease() != "" {
+			continue
+		}
+		if ver.Major() != currentVer.Major() || ver.Minor() != currentVer.Minor() || !currentVer.LessThan(ver) {
+			continue
+		}
+		if latest == nil || latest.LessThan(ver) {
+			latest, latestName = ver, candidate
+		}
+	}
+	return latestName
+}
+
+// inMaintenanceWindow checks whether the time is within the maintenance window, and returns the duration
+// to wait for the next opening of the window if not.
+func inMaintenanceWindow(window *appsv1alpha1.MaintenanceWindow, now time.Time) (bool, time.Duration) {
+	open, wait := intctrlutil.InMaintenanceWindow(window, now)
+	if !open && wait == 0 {
+		wait = autoPatchCheckInterval
+	}
+	return open, wait
+}
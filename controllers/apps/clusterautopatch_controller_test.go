@@ -0,0 +1,147 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+var _ = Describe("cluster auto patch", func() {
+	Context("latest patch release", func() {
+		It("picks the latest patch of the same minor version", func() {
+			candidates := []string{"8.0.30", "8.0.32", "8.0.33-rc.1", "8.1.0", "9.0.1", "5.7.44"}
+			Expect(latestPatchRelease("8.0.30", candidates)).Should(Equal("8.0.32"))
+			Expect(latestPatchRelease("8.0.32", candidates)).Should(BeEmpty())
+			Expect(latestPatchRelease("5.7.40", candidates)).Should(Equal("5.7.44"))
+			Expect(latestPatchRelease("invalid", candidates)).Should(BeEmpty())
+		})
+	})
+
+	Context("component order", func() {
+		It("orders the listed components first", func() {
+			cluster := &appsv1alpha1.Cluster{
+				Spec: appsv1alpha1.ClusterSpec{
+					ComponentSpecs: []appsv1alpha1.ClusterComponentSpec{
+						{Name: "proxy"}, {Name: "data"}, {Name: "config"},
+					},
+					UpgradePolicy: &appsv1alpha1.ClusterUpgradePolicy{
+						AutoPatch:      true,
+						ComponentOrder: []string{"data", "unknown"},
+					},
+				},
+			}
+			Expect(autoPatchComponentOrder(cluster)).Should(Equal([]string{"data", "config", "proxy"}))
+		})
+
+		It("orders the shardings with the components", func() {
+			cluster := &appsv1alpha1.Cluster{
+				Spec: appsv1alpha1.ClusterSpec{
+					ComponentSpecs: []appsv1alpha1.ClusterComponentSpec{{Name: "proxy"}},
+					ShardingSpecs:  []appsv1alpha1.ShardingSpec{{Name: "shard"}},
+					UpgradePolicy: &appsv1alpha1.ClusterUpgradePolicy{
+						AutoPatch:      true,
+						ComponentOrder: []string{"shard"},
+					},
+				},
+			}
+			Expect(autoPatchComponentOrder(cluster)).Should(Equal([]string{"shard", "proxy"}))
+		})
+	})
+
+	Context("history", func() {
+		now := time.Date(2024, 6, 3, 12, 0, 0, 0, time.UTC)
+		newOps := func(phase appsv1alpha1.OpsPhase, compName, serviceVersion string, completed time.Time) appsv1alpha1.OpsRequest {
+			return appsv1alpha1.OpsRequest{
+				Spec: appsv1alpha1.OpsRequestSpec{
+					SpecificOpsRequest: appsv1alpha1.SpecificOpsRequest{
+						Upgrade: &appsv1alpha1.Upgrade{
+							Components: []appsv1alpha1.UpgradeComponent{{
+								ComponentOps:   appsv1alpha1.ComponentOps{ComponentName: compName},
+								ServiceVersion: &serviceVersion,
+							}},
+						},
+					},
+				},
+				Status: appsv1alpha1.OpsRequestStatus{
+					Phase:               phase,
+					CompletionTimestamp: metav1.Time{Time: completed},
+				},
+			}
+		}
+
+		It("does not back off without failures", func() {
+			history := buildAutoPatchHistory([]appsv1alpha1.OpsRequest{
+				newOps(appsv1alpha1.OpsSucceedPhase, "mysql", "8.0.32", now.Add(-time.Minute)),
+			}, now)
+			Expect(history.backoff).Should(BeZero())
+			Expect(history.failedTargets.Len()).Should(BeZero())
+		})
+
+		It("skips the failed targets and backs off exponentially", func() {
+			history := buildAutoPatchHistory([]appsv1alpha1.OpsRequest{
+				newOps(appsv1alpha1.OpsFailedPhase, "mysql", "8.0.31", now.Add(-3*time.Hour)),
+				newOps(appsv1alpha1.OpsSucceedPhase, "mysql", "8.0.32", now.Add(-2*time.Hour)),
+				newOps(appsv1alpha1.OpsFailedPhase, "mysql", "8.0.33", now.Add(-time.Hour)),
+				newOps(appsv1alpha1.OpsFailedPhase, "proxy", "2.0.1", now.Add(-time.Minute)),
+			}, now)
+			Expect(history.failedTargets.UnsortedList()).Should(ConsistOf("mysql/8.0.31", "mysql/8.0.33", "proxy/2.0.1"))
+			// two failures since the last succeeded one
+			Expect(history.backoff).Should(Equal(19 * time.Minute))
+		})
+	})
+
+	Context("maintenance window", func() {
+		// 2024-06-03 is a Monday
+		window := &appsv1alpha1.MaintenanceWindow{
+			DaysOfWeek: []appsv1alpha1.Weekday{"Monday"},
+			StartTime:  "22:00",
+			Duration:   metav1.Duration{Duration: 4 * time.Hour},
+		}
+
+		It("is always open without a window", func() {
+			open, _ := inMaintenanceWindow(nil, time.Now())
+			Expect(open).Should(BeTrue())
+		})
+
+		It("checks the time within the window", func() {
+			open, _ := inMaintenanceWindow(window, time.Date(2024, 6, 3, 23, 0, 0, 0, time.UTC))
+			Expect(open).Should(BeTrue())
+			// the window opened on Monday lasts till Tuesday
+			open, _ = inMaintenanceWindow(window, time.Date(2024, 6, 4, 1, 0, 0, 0, time.UTC))
+			Expect(open).Should(BeTrue())
+		})
+
+		It("returns the duration to the next opening", func() {
+			open, wait := inMaintenanceWindow(window, time.Date(2024, 6, 3, 21, 0, 0, 0, time.UTC))
+			Expect(open).Should(BeFalse())
+			Expect(wait).Should(Equal(time.Hour))
+			open, wait = inMaintenanceWindow(window, time.Date(2024, 6, 4, 3, 0, 0, 0, time.UTC))
+			Expect(open).Should(BeFalse())
+			Expect(wait).Should(Equal(6*24*time.Hour + 19*time.Hour))
+		})
+	})
+})
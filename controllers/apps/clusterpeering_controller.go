@@ -0,0 +1,520 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
+)
+
+const (
+	// clusterPeeringRequeueDuration is the interval to check again whether the source is ready to be peered.
+	clusterPeeringRequeueDuration = 10 * time.Second
+	// clusterPeeringLagProbeInterval is the interval to probe the replication lag of the read-replica cluster.
+	clusterPeeringLagProbeInterval = 30 * time.Second
+
+	clusterPeeringKubeConfigKey = "kubeconfig"
+
+	replicationSourceHostEnv     = "KB_REPLICATION_SOURCE_HOST"
+	replicationSourcePortEnv     = "KB_REPLICATION_SOURCE_PORT"
+	replicationSourceUserEnv     = "KB_REPLICATION_SOURCE_USER"
+	replicationSourcePasswordEnv = "KB_REPLICATION_SOURCE_PASSWORD"
+)
+
+// newClusterPeeringRemoteClient builds the client to the remote Kubernetes cluster from its kubeconfig.
+// HACK: it's a variable to be replaced in the test.
+var newClusterPeeringRemoteClient = func(kubeConfig []byte, scheme *runtime.Scheme) (*rest.Config, client.Client, error) {
+	cfg, err := clientcmd.RESTConfigFromKubeConfig(kubeConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	cli, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, nil, err
+	}
+	return cfg, cli, nil
+}
+
+// ClusterPeeringReconciler reconciles a ClusterPeering object
+type ClusterPeeringReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// clusterPeeringRemote holds the clients to the remote Kubernetes cluster.
+type clusterPeeringRemote struct {
+	config *rest.Config
+	client.Client
+}
+
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=clusterpeerings,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=clusterpeerings/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=clusterpeerings/finalizers,verbs=update
+
+// Reconcile creates the read-replica cluster of the source Component in the remote Kubernetes cluster,
+// keeps its replication source and credential up to date, and probes its replication lag.
+func (r *ClusterPeeringReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("clusterPeering", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	peering := &appsv1alpha1.ClusterPeering{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, peering); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+
+	res, err := intctrlutil.HandleCRDeletion(reqCtx, r, peering, constant.ClusterPeeringFinalizerName, func() (*ctrl.Result, error) {
+		return nil, r.deleteReplicaCluster(reqCtx, peering)
+	})
+	if res != nil {
+		return *res, err
+	}
+
+	if peering.Status.Phase == appsv1alpha1.ClusterPeeringPromotedPhase {
+		// the read-replica cluster is detached from the peering.
+		return intctrlutil.Reconciled()
+	}
+
+	remote, err := r.buildRemote(reqCtx, peering)
+	if err != nil {
+		return r.failed(reqCtx, peering, err)
+	}
+
+	if peering.Spec.Promote {
+		if err = r.promote(reqCtx, peering, remote); err != nil {
+			return r.failed(reqCtx, peering, err)
+		}
+		peering.Status.Lag = nil
+		if err = r.updateStatus(reqCtx, peering, appsv1alpha1.ClusterPeeringPromotedPhase, ""); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		r.Recorder.Eventf(peering, corev1.EventTypeNormal, "Promoted",
+			"the read-replica cluster %s/%s is promoted to a standalone cluster", peering.GetRemoteNamespace(), peering.GetRemoteClusterName())
+		return intctrlutil.Reconciled()
+	}
+
+	cluster, comp, reason, err := r.getSource(reqCtx, peering)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if reason == "" {
+		peering.Status.SourceEndpoint, reason, err = r.resolveSourceEndpoint(reqCtx, peering)
+		if err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+	}
+	if reason != "" {
+		if err = r.updateStatus(reqCtx, peering, appsv1alpha1.ClusterPeeringPendingPhase, reason); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		return intctrlutil.RequeueAfter(clusterPeeringRequeueDuration, reqCtx.Log, reason)
+	}
+
+	if err = r.syncReplicationCredential(reqCtx, peering, remote); err != nil {
+		return r.failed(reqCtx, peering, err)
+	}
+	if err = r.syncReplicaCluster(reqCtx, peering, remote, cluster, comp); err != nil {
+		return r.failed(reqCtx, peering, err)
+	}
+
+	message := ""
+	if err = r.probeLag(reqCtx, peering, remote); err != nil {
+		message = fmt.Sprintf("failed to probe the replication lag: %s", err.Error())
+	}
+	if err = r.updateStatus(reqCtx, peering, appsv1alpha1.ClusterPeeringReplicatingPhase, message); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	return intctrlutil.RequeueAfter(clusterPeeringLagProbeInterval, reqCtx.Log, "")
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterPeeringReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return intctrlutil.NewNamespacedControllerManagedBy(mgr).
+		For(&appsv1alpha1.ClusterPeering{}).
+		Complete(r)
+}
+
+func (r *ClusterPeeringReconciler) buildRemote(reqCtx intctrlutil.RequestCtx, peering *appsv1alpha1.ClusterPeering) (*clusterPeeringRemote, error) {
+	secret := &corev1.Secret{}
+	secretKey := types.NamespacedName{Namespace: peering.Namespace, Name: peering.Spec.Remote.KubeConfigSecretName}
+	if err := r.Client.Get(reqCtx.Ctx, secretKey, secret); err != nil {
+		return nil, err
+	}
+	kubeConfig, ok := secret.Data[clusterPeeringKubeConfigKey]
+	if !ok {
+		return nil, fmt.Errorf("the key %s is not found in the secret %s", clusterPeeringKubeConfigKey, secretKey.Name)
+	}
+	cfg, cli, err := newClusterPeeringRemoteClient(kubeConfig, r.Scheme)
+	if err != nil {
+		return nil, err
+	}
+	return &clusterPeeringRemote{config: cfg, Client: cli}, nil
+}
+
+// getSource gets the source Cluster and Component, it returns the reason if they are not ready to be peered yet.
+func (r *ClusterPeeringReconciler) getSource(reqCtx intctrlutil.RequestCtx,
+	peering *appsv1alpha1.ClusterPeering) (*appsv1alpha1.Cluster, *appsv1alpha1.Component, string, error) {
+	cluster := &appsv1alpha1.Cluster{}
+	if err := r.Client.Get(reqCtx.Ctx, types.NamespacedName{Namespace: peering.Namespace, Name: peering.Spec.ClusterName}, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil, "the source cluster is not found", nil
+		}
+		return nil, nil, "", err
+	}
+	comp := &appsv1alpha1.Component{}
+	compKey := types.NamespacedName{
+		Namespace: peering.Namespace,
+		Name:      constant.GenerateClusterComponentName(peering.Spec.ClusterName, peering.Spec.ComponentName),
+	}
+	if err := r.Client.Get(reqCtx.Ctx, compKey, comp); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil, "the source component is not found", nil
+		}
+		return nil, nil, "", err
+	}
+	if comp.Status.Phase != appsv1alpha1.RunningClusterCompPhase {
+		return nil, nil, fmt.Sprintf("the source component is %s, waiting for it to be running", comp.Status.Phase), nil
+	}
+	return cluster, comp, "", nil
+}
+
+// resolveSourceEndpoint resolves the replication endpoint from the load balancer ingress of the replication Service.
+func (r *ClusterPeeringReconciler) resolveSourceEndpoint(reqCtx intctrlutil.RequestCtx,
+	peering *appsv1alpha1.ClusterPeering) (string, string, error) {
+	svc := &corev1.Service{}
+	svcKey := types.NamespacedName{Namespace: peering.Namespace, Name: peering.Spec.ReplicationService.Name}
+	if err := r.Client.Get(reqCtx.Ctx, svcKey, svc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", "the replication service is not found", nil
+		}
+		return "", "", err
+	}
+	port := peering.Spec.ReplicationService.Port
+	if port == 0 && len(svc.Spec.Ports) > 0 {
+		port = svc.Spec.Ports[0].Port
+	}
+	if port == 0 {
+		return "", "", fmt.Errorf("the replication service %s has no ports", svc.Name)
+	}
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		host := ingress.IP
+		if host == "" {
+			host = ingress.Hostname
+		}
+		if host != "" {
+			return net.JoinHostPort(host, strconv.Itoa(int(port))), "", nil
+		}
+	}
+	return "", "waiting for the load balancer ingress of the replication service", nil
+}
+
+func replicationCredentialSecretName(peering *appsv1alpha1.ClusterPeering) string {
+	return fmt.Sprintf("%s-replication-source", peering.GetRemoteClusterName())
+}
+
+// syncReplicationCredential copies the credential of the replication account to the remote Kubernetes cluster.
+func (r *ClusterPeeringReconciler) syncReplicationCredential(reqCtx intctrlutil.RequestCtx,
+	peering *appsv1alpha1.ClusterPeering, remote *clusterPeeringRemote) error {
+	accountSecret := &corev1.Secret{}
+	accountSecretKey := types.NamespacedName{
+		Namespace: peering.Namespace,
+		Name:      constant.GenerateAccountSecretName(peering.Spec.ClusterName, peering.Spec.ComponentName, peering.Spec.ReplicationAccount),
+	}
+	if err := r.Client.Get(reqCtx.Ctx, accountSecretKey, accountSecret); err != nil {
+		return err
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: peering.GetRemoteNamespace(),
+			Name:      replicationCredentialSecretName(peering),
+			Labels: map[string]string{
+				constant.AppManagedByLabelKey:       constant.AppName,
+				constant.ClusterPeeringNameLabelKey: peering.Name,
+			},
+		},
+		Data: map[string][]byte{
+			constant.AccountNameForSecret:   accountSecret.Data[constant.AccountNameForSecret],
+			constant.AccountPasswdForSecret: accountSecret.Data[constant.AccountPasswdForSecret],
+		},
+	}
+	existing := &corev1.Secret{}
+	if err := remote.Get(reqCtx.Ctx, client.ObjectKeyFromObject(secret), existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		return remote.Create(reqCtx.Ctx, secret)
+	}
+	if reflect.DeepEqual(existing.Data, secret.Data) {
+		return nil
+	}
+	patch := client.MergeFrom(existing.DeepCopy())
+	existing.Data = secret.Data
+	return remote.Patch(reqCtx.Ctx, existing, patch)
+}
+
+// syncReplicaCluster creates the read-replica cluster in the remote Kubernetes cluster, or updates its replicas
+// and replication source.
+func (r *ClusterPeeringReconciler) syncReplicaCluster(reqCtx intctrlutil.RequestCtx, peering *appsv1alpha1.ClusterPeering,
+	remote *clusterPeeringRemote, cluster *appsv1alpha1.Cluster, comp *appsv1alpha1.Component) error {
+	replica, err := buildReplicaCluster(peering, cluster, comp)
+	if err != nil {
+		return err
+	}
+	existing := &appsv1alpha1.Cluster{}
+	if err = remote.Get(reqCtx.Ctx, client.ObjectKeyFromObject(replica), existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		if err = remote.Create(reqCtx.Ctx, replica); err != nil {
+			return err
+		}
+		r.Recorder.Eventf(peering, corev1.EventTypeNormal, "ReplicaCreated",
+			"the read-replica cluster %s/%s is created", replica.Namespace, replica.Name)
+		return nil
+	}
+	if existing.Labels[constant.ClusterPeeringNameLabelKey] != peering.Name {
+		return fmt.Errorf("the cluster %s/%s already exists in the remote and is not created by the peering", existing.Namespace, existing.Name)
+	}
+	if len(existing.Spec.ComponentSpecs) != 1 {
+		return fmt.Errorf("the read-replica cluster %s/%s is expected to have one component", existing.Namespace, existing.Name)
+	}
+	expected := replica.Spec.ComponentSpecs[0]
+	compSpec := existing.Spec.ComponentSpecs[0]
+	if compSpec.Replicas == expected.Replicas && reflect.DeepEqual(compSpec.Env, expected.Env) {
+		return nil
+	}
+	patch := client.MergeFrom(existing.DeepCopy())
+	existing.Spec.ComponentSpecs[0].Replicas = expected.Replicas
+	existing.Spec.ComponentSpecs[0].Env = expected.Env
+	return remote.Patch(reqCtx.Ctx, existing, patch)
+}
+
+// buildReplicaCluster builds the read-replica cluster with the source Component only, the definition and
+// the service version of which are resolved from the source Component to be independent of the ClusterDefinition topology.
+func buildReplicaCluster(peering *appsv1alpha1.ClusterPeering, cluster *appsv1alpha1.Cluster,
+	comp *appsv1alpha1.Component) (*appsv1alpha1.Cluster, error) {
+	var compSpec *appsv1alpha1.ClusterComponentSpec
+	for i, spec := range cluster.Spec.ComponentSpecs {
+		if spec.Name == peering.Spec.ComponentName {
+			compSpec = cluster.Spec.ComponentSpecs[i].DeepCopy()
+		}
+	}
+	if compSpec == nil {
+		return nil, fmt.Errorf("the component %s is not found in the spec of the source cluster", peering.Spec.ComponentName)
+	}
+
+	compSpec.ComponentDefRef = ""
+	compSpec.ComponentDef = comp.Spec.CompDef
+	compSpec.ServiceVersion = comp.Spec.ServiceVersion
+	compSpec.Replicas = peering.Spec.Replicas
+	if compSpec.Replicas == 0 {
+		compSpec.Replicas = 1
+	}
+	// the references to the objects of the source Kubernetes cluster don't apply to the remote.
+	compSpec.ServiceRefs = nil
+	compSpec.UserResourceRefs = nil
+	compSpec.SystemAccounts = nil
+	compSpec.Instances = nil
+	compSpec.OfflineInstances = nil
+
+	host, port, _ := net.SplitHostPort(peering.Status.SourceEndpoint)
+	secretKeyRef := func(key string) *corev1.EnvVarSource {
+		return &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: replicationCredentialSecretName(peering)},
+				Key:                  key,
+			},
+		}
+	}
+	env := make([]corev1.EnvVar, 0, len(compSpec.Env)+4)
+	for _, e := range compSpec.Env {
+		if !strings.HasPrefix(e.Name, "KB_REPLICATION_SOURCE_") {
+			env = append(env, e)
+		}
+	}
+	compSpec.Env = append(env,
+		corev1.EnvVar{Name: replicationSourceHostEnv, Value: host},
+		corev1.EnvVar{Name: replicationSourcePortEnv, Value: port},
+		corev1.EnvVar{Name: replicationSourceUserEnv, ValueFrom: secretKeyRef(constant.AccountNameForSecret)},
+		corev1.EnvVar{Name: replicationSourcePasswordEnv, ValueFrom: secretKeyRef(constant.AccountPasswdForSecret)})
+
+	return &appsv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: peering.GetRemoteNamespace(),
+			Name:      peering.GetRemoteClusterName(),
+			Labels: map[string]string{
+				constant.ClusterPeeringNameLabelKey: peering.Name,
+			},
+			Annotations: map[string]string{
+				constant.ClusterPeeringSourceAnnotationKey: fmt.Sprintf("%s/%s/%s", peering.Namespace, peering.Spec.ClusterName, peering.Spec.ComponentName),
+			},
+		},
+		Spec: appsv1alpha1.ClusterSpec{
+			TerminationPolicy: cluster.Spec.TerminationPolicy,
+			ComponentSpecs:    []appsv1alpha1.ClusterComponentSpec{*compSpec},
+		},
+	}, nil
+}
+
+// probeLag probes the replication lag through the agent of an instance of the read-replica cluster.
+func (r *ClusterPeeringReconciler) probeLag(reqCtx intctrlutil.RequestCtx, peering *appsv1alpha1.ClusterPeering,
+	remote *clusterPeeringRemote) error {
+	pods, err := component.ListOwnedPods(reqCtx.Ctx, remote, peering.GetRemoteNamespace(),
+		peering.GetRemoteClusterName(), peering.Spec.ComponentName)
+	if err != nil {
+		return err
+	}
+	var pod *corev1.Pod
+	for i := range pods {
+		if pods[i].Status.Phase == corev1.PodRunning {
+			pod = pods[i]
+			break
+		}
+	}
+	if pod == nil {
+		return nil
+	}
+	// the pods of the remote are not reachable by IP, call the agent through the exec API of the remote.
+	lorryCli, err := lorry.NewK8sExecClientWithPod(rest.CopyConfig(remote.config), pod)
+	if err != nil {
+		return err
+	}
+	if lorryCli == nil {
+		return fmt.Errorf("the agent of the read-replica cluster is not available")
+	}
+	lag, err := lorryCli.GetLag(reqCtx.Ctx)
+	if err != nil {
+		return err
+	}
+	peering.Status.Lag = &lag
+	peering.Status.LastLagProbeTime = &metav1.Time{Time: time.Now()}
+	return nil
+}
+
+// promote stops the replication of the read-replica cluster and detaches it from the peering.
+func (r *ClusterPeeringReconciler) promote(reqCtx intctrlutil.RequestCtx, peering *appsv1alpha1.ClusterPeering,
+	remote *clusterPeeringRemote) error {
+	replica := &appsv1alpha1.Cluster{}
+	replicaKey := types.NamespacedName{Namespace: peering.GetRemoteNamespace(), Name: peering.GetRemoteClusterName()}
+	if err := remote.Get(reqCtx.Ctx, replicaKey, replica); err != nil {
+		return err
+	}
+	if replica.Labels[constant.ClusterPeeringNameLabelKey] == peering.Name {
+		patch := client.MergeFrom(replica.DeepCopy())
+		for i, compSpec := range replica.Spec.ComponentSpecs {
+			env := make([]corev1.EnvVar, 0, len(compSpec.Env))
+			for _, e := range compSpec.Env {
+				if !strings.HasPrefix(e.Name, "KB_REPLICATION_SOURCE_") {
+					env = append(env, e)
+				}
+			}
+			replica.Spec.ComponentSpecs[i].Env = env
+		}
+		delete(replica.Labels, constant.ClusterPeeringNameLabelKey)
+		delete(replica.Annotations, constant.ClusterPeeringSourceAnnotationKey)
+		if err := remote.Patch(reqCtx.Ctx, replica, patch); err != nil {
+			return err
+		}
+	}
+	secret := &corev1.Secret{}
+	secret.Namespace = peering.GetRemoteNamespace()
+	secret.Name = replicationCredentialSecretName(peering)
+	return client.IgnoreNotFound(remote.Delete(reqCtx.Ctx, secret))
+}
+
+// deleteReplicaCluster deletes the read-replica cluster and the replication credential from the remote,
+// unless the read-replica cluster has been promoted.
+func (r *ClusterPeeringReconciler) deleteReplicaCluster(reqCtx intctrlutil.RequestCtx, peering *appsv1alpha1.ClusterPeering) error {
+	if peering.Status.Phase == appsv1alpha1.ClusterPeeringPromotedPhase {
+		return nil
+	}
+	remote, err := r.buildRemote(reqCtx, peering)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// the remote is not accessible any more, nothing can be cleaned up.
+			return nil
+		}
+		return err
+	}
+	replica := &appsv1alpha1.Cluster{}
+	replicaKey := types.NamespacedName{Namespace: peering.GetRemoteNamespace(), Name: peering.GetRemoteClusterName()}
+	if err = remote.Get(reqCtx.Ctx, replicaKey, replica); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err == nil && replica.Labels[constant.ClusterPeeringNameLabelKey] == peering.Name {
+		if err = remote.Delete(reqCtx.Ctx, replica); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	secret := &corev1.Secret{}
+	secret.Namespace = peering.GetRemoteNamespace()
+	secret.Name = replicationCredentialSecretName(peering)
+	return client.IgnoreNotFound(remote.Delete(reqCtx.Ctx, secret))
+}
+
+func (r *ClusterPeeringReconciler) failed(reqCtx intctrlutil.RequestCtx, peering *appsv1alpha1.ClusterPeering, err error) (ctrl.Result, error) {
+	if err1 := r.updateStatus(reqCtx, peering, appsv1alpha1.ClusterPeeringFailedPhase, err.Error()); err1 != nil {
+		return intctrlutil.CheckedRequeueWithError(err1, reqCtx.Log, "")
+	}
+	return intctrlutil.RequeueWithErrorAndRecordEvent(peering, r.Recorder, err, reqCtx.Log)
+}
+
+func (r *ClusterPeeringReconciler) updateStatus(reqCtx intctrlutil.RequestCtx, peering *appsv1alpha1.ClusterPeering,
+	phase appsv1alpha1.ClusterPeeringPhase, message string) error {
+	status := peering.Status.DeepCopy()
+	latest := &appsv1alpha1.ClusterPeering{}
+	if err := r.Client.Get(reqCtx.Ctx, client.ObjectKeyFromObject(peering), latest); err != nil {
+		return err
+	}
+	patch := client.MergeFrom(latest.DeepCopy())
+	latest.Status = *status
+	latest.Status.ObservedGeneration = peering.Generation
+	latest.Status.Phase = phase
+	latest.Status.Message = message
+	return r.Client.Status().Patch(reqCtx.Ctx, latest, patch)
+}
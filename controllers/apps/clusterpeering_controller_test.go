@@ -0,0 +1,157 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("test ClusterPeering controller", func() {
+
+	var (
+		randomStr = testCtx.GetRandomStr()
+	)
+
+	cleanEnv := func() {
+		By("clean resources")
+
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		testapps.ClearResources(&testCtx, generics.ClusterPeeringSignature, inNS, ml)
+		testapps.ClearResources(&testCtx, generics.SecretSignature, inNS, ml)
+	}
+	BeforeEach(func() {
+		cleanEnv()
+		// the test env plays the role of the remote Kubernetes cluster.
+		newClusterPeeringRemoteClient = func([]byte, *runtime.Scheme) (*rest.Config, client.Client, error) {
+			return cfg, k8sClient, nil
+		}
+	})
+
+	AfterEach(cleanEnv)
+
+	Context("test ClusterPeering controller", func() {
+		It("waits for the source cluster", func() {
+			By("create the kubeconfig secret of the remote")
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testCtx.DefaultNamespace,
+					Name:      "remote-" + randomStr,
+					Labels:    map[string]string{testCtx.TestObjLabelKey: "true"},
+				},
+				Data: map[string][]byte{clusterPeeringKubeConfigKey: []byte("kubeconfig")},
+			}
+			Expect(testCtx.CreateObj(testCtx.Ctx, secret)).Should(Succeed())
+
+			By("create a ClusterPeering obj")
+			peering := &appsv1alpha1.ClusterPeering{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testCtx.DefaultNamespace,
+					Name:      "replica-" + randomStr,
+					Labels:    map[string]string{testCtx.TestObjLabelKey: "true"},
+				},
+				Spec: appsv1alpha1.ClusterPeeringSpec{
+					ClusterName:        "cluster-" + randomStr,
+					ComponentName:      "mysql",
+					ReplicationService: appsv1alpha1.ClusterPeeringReplicationService{Name: "cluster-" + randomStr + "-mysql-repl"},
+					ReplicationAccount: "kbreplicator",
+					Remote:             appsv1alpha1.ClusterPeeringRemote{KubeConfigSecretName: secret.Name},
+				},
+			}
+			Expect(testCtx.CreateObj(testCtx.Ctx, peering)).Should(Succeed())
+
+			By("check the peering is pending on the source cluster")
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(peering),
+				func(g Gomega, peering *appsv1alpha1.ClusterPeering) {
+					g.Expect(peering.Finalizers).Should(ContainElement(constant.ClusterPeeringFinalizerName))
+					g.Expect(peering.Spec.Replicas).Should(BeEquivalentTo(1))
+					g.Expect(peering.Status.Phase).Should(Equal(appsv1alpha1.ClusterPeeringPendingPhase))
+					g.Expect(peering.Status.Message).Should(Equal("the source cluster is not found"))
+				})).Should(Succeed())
+
+			By("delete the ClusterPeering obj")
+			Expect(testCtx.Cli.Delete(testCtx.Ctx, peering)).Should(Succeed())
+			Eventually(testapps.CheckObjExists(&testCtx, client.ObjectKeyFromObject(peering),
+				&appsv1alpha1.ClusterPeering{}, false)).Should(Succeed())
+		})
+
+		It("builds the read-replica cluster", func() {
+			peering := &appsv1alpha1.ClusterPeering{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "replica"},
+				Spec: appsv1alpha1.ClusterPeeringSpec{
+					ClusterName:   "source",
+					ComponentName: "mysql",
+					Remote:        appsv1alpha1.ClusterPeeringRemote{Namespace: "remote"},
+					Replicas:      2,
+				},
+				Status: appsv1alpha1.ClusterPeeringStatus{SourceEndpoint: "10.0.0.1:3306"},
+			}
+			cluster := &appsv1alpha1.Cluster{
+				Spec: appsv1alpha1.ClusterSpec{
+					ClusterDefRef:     "mysql",
+					Topology:          "replication",
+					TerminationPolicy: appsv1alpha1.Delete,
+					ComponentSpecs: []appsv1alpha1.ClusterComponentSpec{
+						{Name: "proxy", Replicas: 1},
+						{Name: "mysql", ComponentDefRef: "mysql", Replicas: 3, OfflineInstances: []string{"source-mysql-0"}},
+					},
+				},
+			}
+			comp := &appsv1alpha1.Component{
+				Spec: appsv1alpha1.ComponentSpec{CompDef: "mysql-8.0", ServiceVersion: "8.0.30"},
+			}
+
+			replica, err := buildReplicaCluster(peering, cluster, comp)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(replica.Namespace).Should(Equal("remote"))
+			Expect(replica.Name).Should(Equal("replica"))
+			Expect(replica.Labels).Should(HaveKeyWithValue(constant.ClusterPeeringNameLabelKey, "replica"))
+			Expect(replica.Annotations).Should(HaveKeyWithValue(constant.ClusterPeeringSourceAnnotationKey, "default/source/mysql"))
+			Expect(replica.Spec.ClusterDefRef).Should(BeEmpty())
+			Expect(replica.Spec.ComponentSpecs).Should(HaveLen(1))
+			compSpec := replica.Spec.ComponentSpecs[0]
+			Expect(compSpec.ComponentDefRef).Should(BeEmpty())
+			Expect(compSpec.ComponentDef).Should(Equal("mysql-8.0"))
+			Expect(compSpec.ServiceVersion).Should(Equal("8.0.30"))
+			Expect(compSpec.Replicas).Should(BeEquivalentTo(2))
+			Expect(compSpec.OfflineInstances).Should(BeEmpty())
+			Expect(compSpec.Env).Should(ContainElements(
+				corev1.EnvVar{Name: replicationSourceHostEnv, Value: "10.0.0.1"},
+				corev1.EnvVar{Name: replicationSourcePortEnv, Value: "3306"}))
+
+			By("build the read-replica cluster of a component not in the spec")
+			peering.Spec.ComponentName = "redis"
+			_, err = buildReplicaCluster(peering, cluster, comp)
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+})
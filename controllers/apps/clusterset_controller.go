@@ -0,0 +1,285 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// ClusterSetReconciler reconciles a ClusterSet object
+type ClusterSetReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=clustersets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=clustersets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=clustersets/finalizers,verbs=update
+
+// Reconcile stamps out the member clusters from the template of the ClusterSet, scales the member clusters
+// to the desired replicas, and rolls out the changes of the template to the member clusters gradually.
+func (r *ClusterSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("clusterSet", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	clusterSet := &appsv1alpha1.ClusterSet{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, clusterSet); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if model.IsObjectDeleting(clusterSet) {
+		// the member clusters are garbage collected by their owner references.
+		return intctrlutil.Reconciled()
+	}
+
+	revision, err := intctrlutil.ComputeClusterSetRevision(clusterSet)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+
+	members, err := r.listMembers(reqCtx, clusterSet)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+
+	if err = r.scale(reqCtx, clusterSet, members, revision); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+
+	if err = r.rollingUpdate(reqCtx, clusterSet, members, revision); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+
+	if err = r.updateStatus(reqCtx, clusterSet, members, revision); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	return intctrlutil.Reconciled()
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return intctrlutil.NewNamespacedControllerManagedBy(mgr).
+		For(&appsv1alpha1.ClusterSet{}).
+		Owns(&appsv1alpha1.Cluster{}).
+		Complete(r)
+}
+
+// listMembers lists the member clusters owned by the ClusterSet, keyed by their indexes.
+func (r *ClusterSetReconciler) listMembers(reqCtx intctrlutil.RequestCtx,
+	clusterSet *appsv1alpha1.ClusterSet) (map[int32]*appsv1alpha1.Cluster, error) {
+	clusterList := &appsv1alpha1.ClusterList{}
+	if err := r.Client.List(reqCtx.Ctx, clusterList, client.InNamespace(clusterSet.Namespace),
+		client.MatchingLabels{constant.KBAppClusterSetLabelKey: clusterSet.Name}); err != nil {
+		return nil, err
+	}
+	members := make(map[int32]*appsv1alpha1.Cluster)
+	for i, cluster := range clusterList.Items {
+		if !model.IsOwnerOf(clusterSet, &clusterList.Items[i]) {
+			continue
+		}
+		index, err := intctrlutil.ClusterSetMemberIndex(&cluster)
+		if err != nil {
+			reqCtx.Log.Info("ignore the member cluster with invalid index", "cluster", cluster.Name, "error", err.Error())
+			continue
+		}
+		members[index] = &clusterList.Items[i]
+	}
+	return members, nil
+}
+
+// scale creates the missing member clusters and deletes the member clusters out of the desired replicas.
+func (r *ClusterSetReconciler) scale(reqCtx intctrlutil.RequestCtx, clusterSet *appsv1alpha1.ClusterSet,
+	members map[int32]*appsv1alpha1.Cluster, revision string) error {
+	replicas := intctrlutil.ClusterSetReplicas(clusterSet)
+	for index := int32(0); index < replicas; index++ {
+		if _, ok := members[index]; ok {
+			continue
+		}
+		cluster, err := intctrlutil.BuildClusterSetMember(clusterSet, index, revision)
+		if err != nil {
+			return err
+		}
+		if err = controllerutil.SetControllerReference(clusterSet, cluster, r.Scheme); err != nil {
+			return err
+		}
+		if err = r.Client.Create(reqCtx.Ctx, cluster); err != nil {
+			if !apierrors.IsAlreadyExists(err) {
+				return err
+			}
+			if cluster, err = r.existingMember(reqCtx, clusterSet, cluster); err != nil {
+				return err
+			}
+		}
+		members[index] = cluster
+	}
+	for index, cluster := range members {
+		if index < replicas {
+			continue
+		}
+		if err := r.removeMember(reqCtx, clusterSet, cluster); err != nil {
+			return err
+		}
+		delete(members, index)
+	}
+	return nil
+}
+
+// existingMember returns the existing member cluster which has not been observed yet. A cluster with the same name
+// but not owned by the ClusterSet, e.g. the one released by the previous scaling down, is never taken over.
+func (r *ClusterSetReconciler) existingMember(reqCtx intctrlutil.RequestCtx, clusterSet *appsv1alpha1.ClusterSet,
+	cluster *appsv1alpha1.Cluster) (*appsv1alpha1.Cluster, error) {
+	existing := &appsv1alpha1.Cluster{}
+	if err := r.Client.Get(reqCtx.Ctx, client.ObjectKeyFromObject(cluster), existing); err != nil {
+		return nil, err
+	}
+	if !model.IsOwnerOf(clusterSet, existing) {
+		r.Recorder.Eventf(clusterSet, corev1.EventTypeWarning, "ScaleUpBlocked",
+			"cluster %s already exists and is not a member of the ClusterSet, delete it to scale up", cluster.Name)
+		return nil, fmt.Errorf("cluster %s already exists and is not a member of the ClusterSet", cluster.Name)
+	}
+	return existing, nil
+}
+
+// removeMember removes the member cluster out of the desired replicas according to the scale down policy,
+// the member cluster is released from the ClusterSet and kept running unless the policy is Delete.
+func (r *ClusterSetReconciler) removeMember(reqCtx intctrlutil.RequestCtx, clusterSet *appsv1alpha1.ClusterSet,
+	cluster *appsv1alpha1.Cluster) error {
+	if clusterSet.Spec.ScaleDownPolicy == appsv1alpha1.ClusterSetScaleDownDelete {
+		if model.IsObjectDeleting(cluster) {
+			return nil
+		}
+		if err := r.Client.Delete(reqCtx.Ctx, cluster); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		r.Recorder.Eventf(clusterSet, corev1.EventTypeNormal, "ScaleDown", "deleted the member cluster %s", cluster.Name)
+		return nil
+	}
+
+	patch := client.MergeFrom(cluster.DeepCopy())
+	if err := controllerutil.RemoveControllerReference(clusterSet, cluster, r.Scheme); err != nil {
+		return err
+	}
+	delete(cluster.Labels, constant.KBAppClusterSetLabelKey)
+	delete(cluster.Labels, constant.KBAppClusterSetIndexLabelKey)
+	delete(cluster.Annotations, constant.ClusterSetRevisionAnnotationKey)
+	delete(cluster.Annotations, constant.ClusterSetLastAppliedAnnotationKey)
+	if err := r.Client.Patch(reqCtx.Ctx, cluster, patch); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	r.Recorder.Eventf(clusterSet, corev1.EventTypeNormal, "ScaleDown", "released the member cluster %s from the ClusterSet", cluster.Name)
+	return nil
+}
+
+// rollingUpdate updates the outdated member clusters to the latest template, from the highest index to the lowest,
+// while keeping the number of unavailable member clusters within the limit of the update strategy.
+func (r *ClusterSetReconciler) rollingUpdate(reqCtx intctrlutil.RequestCtx, clusterSet *appsv1alpha1.ClusterSet,
+	members map[int32]*appsv1alpha1.Cluster, revision string) error {
+	var (
+		unavailable int32
+		outdated    []int32
+	)
+	for index, cluster := range members {
+		if !isClusterSetMemberAvailable(cluster) {
+			unavailable++
+		}
+		if cluster.Annotations[constant.ClusterSetRevisionAnnotationKey] != revision {
+			outdated = append(outdated, index)
+		}
+	}
+	sort.Slice(outdated, func(i, j int) bool { return outdated[i] > outdated[j] })
+
+	maxUnavailable := intctrlutil.ClusterSetMaxUnavailable(clusterSet)
+	for _, index := range outdated {
+		cluster := members[index]
+		available := isClusterSetMemberAvailable(cluster)
+		if available && unavailable >= maxUnavailable {
+			break
+		}
+		expected, err := intctrlutil.BuildClusterSetMember(clusterSet, index, revision)
+		if err != nil {
+			return err
+		}
+		patch := client.MergeFrom(cluster.DeepCopy())
+		if err = intctrlutil.MergeClusterSetMember(cluster, expected); err != nil {
+			return err
+		}
+		if err = r.Client.Patch(reqCtx.Ctx, cluster, patch); err != nil {
+			return err
+		}
+		if available {
+			unavailable++
+		}
+	}
+	return nil
+}
+
+func (r *ClusterSetReconciler) updateStatus(reqCtx intctrlutil.RequestCtx, clusterSet *appsv1alpha1.ClusterSet,
+	members map[int32]*appsv1alpha1.Cluster, revision string) error {
+	patch := client.MergeFrom(clusterSet.DeepCopy())
+	status := appsv1alpha1.ClusterSetStatus{
+		ObservedGeneration: clusterSet.Generation,
+		UpdateRevision:     revision,
+	}
+	for index, cluster := range members {
+		status.Replicas++
+		if isClusterSetMemberAvailable(cluster) {
+			status.ReadyReplicas++
+		}
+		memberRevision := cluster.Annotations[constant.ClusterSetRevisionAnnotationKey]
+		if memberRevision == revision {
+			status.UpdatedReplicas++
+		}
+		status.Clusters = append(status.Clusters, appsv1alpha1.ClusterSetMemberStatus{
+			Name:     cluster.Name,
+			Index:    index,
+			Phase:    cluster.Status.Phase,
+			Revision: memberRevision,
+		})
+	}
+	sort.Slice(status.Clusters, func(i, j int) bool { return status.Clusters[i].Index < status.Clusters[j].Index })
+	clusterSet.Status = status
+	return r.Client.Status().Patch(reqCtx.Ctx, clusterSet, patch)
+}
+
+// isClusterSetMemberAvailable checks whether the member cluster is running with its latest spec.
+func isClusterSetMemberAvailable(cluster *appsv1alpha1.Cluster) bool {
+	return cluster.Status.ObservedGeneration == cluster.Generation &&
+		cluster.Status.Phase == appsv1alpha1.RunningClusterPhase
+}
@@ -0,0 +1,132 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("test ClusterSet controller", func() {
+
+	var (
+		randomStr = testCtx.GetRandomStr()
+	)
+
+	cleanEnv := func() {
+		By("clean resources")
+
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		testapps.ClearResources(&testCtx, generics.ClusterSetSignature, inNS, client.HasLabels{testCtx.TestObjLabelKey})
+		testapps.ClearClusterResources(&testCtx)
+	}
+	BeforeEach(cleanEnv)
+
+	AfterEach(cleanEnv)
+
+	Context("test ClusterSet controller", func() {
+		It("stamps out and scales the member clusters", func() {
+			By("create a ClusterSet obj")
+			clusterSet := &appsv1alpha1.ClusterSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testCtx.DefaultNamespace,
+					Name:      "cluster-set-" + randomStr,
+				},
+				Spec: appsv1alpha1.ClusterSetSpec{
+					Replicas: pointer.Int32(2),
+					Template: appsv1alpha1.ClusterTemplate{
+						Labels: map[string]string{
+							testCtx.TestObjLabelKey: "true",
+							"tenant":                "$(KB_CLUSTER_NAME)",
+						},
+						Spec: appsv1alpha1.ClusterSpec{
+							TerminationPolicy: appsv1alpha1.WipeOut,
+							ComponentSpecs: []appsv1alpha1.ClusterComponentSpec{
+								{Name: "mysql", ComponentDef: "mysql", Replicas: 1},
+							},
+						},
+					},
+				},
+			}
+			Expect(testCtx.CreateObj(testCtx.Ctx, clusterSet)).Should(Succeed())
+
+			By("check the member clusters are created")
+			for _, index := range []int32{0, 1} {
+				name := intctrlutil.ClusterSetMemberName(clusterSet.Name, index)
+				Eventually(testapps.CheckObj(&testCtx, client.ObjectKey{Namespace: testCtx.DefaultNamespace, Name: name},
+					func(g Gomega, cluster *appsv1alpha1.Cluster) {
+						g.Expect(cluster.Labels).Should(HaveKeyWithValue(constant.KBAppClusterSetLabelKey, clusterSet.Name))
+						g.Expect(cluster.Labels).Should(HaveKeyWithValue("tenant", name))
+					})).Should(Succeed())
+			}
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(clusterSet),
+				func(g Gomega, set *appsv1alpha1.ClusterSet) {
+					g.Expect(set.Status.Replicas).Should(BeEquivalentTo(2))
+					g.Expect(set.Status.UpdatedReplicas).Should(BeEquivalentTo(2))
+				})).Should(Succeed())
+
+			By("scale the member cluster as an OpsRequest does")
+			member := client.ObjectKey{Namespace: testCtx.DefaultNamespace, Name: intctrlutil.ClusterSetMemberName(clusterSet.Name, 0)}
+			Expect(testapps.GetAndChangeObj(&testCtx, member, func(cluster *appsv1alpha1.Cluster) {
+				cluster.Spec.ComponentSpecs[0].Replicas = 3
+			})()).Should(Succeed())
+
+			By("update the template, only the changed fields are updated to the member clusters")
+			Expect(testapps.GetAndChangeObj(&testCtx, client.ObjectKeyFromObject(clusterSet), func(set *appsv1alpha1.ClusterSet) {
+				set.Spec.Template.Spec.TerminationPolicy = appsv1alpha1.Delete
+				set.Spec.UpdateStrategy = &appsv1alpha1.ClusterSetUpdateStrategy{MaxUnavailable: &intstr.IntOrString{Type: intstr.String, StrVal: "100%"}}
+			})()).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, member, func(g Gomega, cluster *appsv1alpha1.Cluster) {
+				g.Expect(cluster.Spec.TerminationPolicy).Should(Equal(appsv1alpha1.Delete))
+				g.Expect(cluster.Spec.ComponentSpecs[0].Replicas).Should(BeEquivalentTo(3))
+			})).Should(Succeed())
+
+			By("scale down the ClusterSet")
+			Expect(testapps.GetAndChangeObj(&testCtx, client.ObjectKeyFromObject(clusterSet), func(set *appsv1alpha1.ClusterSet) {
+				set.Spec.Replicas = pointer.Int32(1)
+			})()).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(clusterSet),
+				func(g Gomega, set *appsv1alpha1.ClusterSet) {
+					g.Expect(set.Status.Replicas).Should(BeEquivalentTo(1))
+					g.Expect(set.Status.Clusters).Should(HaveLen(1))
+					g.Expect(set.Status.Clusters[0].Index).Should(BeEquivalentTo(0))
+				})).Should(Succeed())
+
+			By("check the member cluster out of the replicas is released rather than deleted")
+			released := client.ObjectKey{Namespace: testCtx.DefaultNamespace, Name: intctrlutil.ClusterSetMemberName(clusterSet.Name, 1)}
+			Eventually(testapps.CheckObj(&testCtx, released, func(g Gomega, cluster *appsv1alpha1.Cluster) {
+				g.Expect(cluster.Labels).ShouldNot(HaveKey(constant.KBAppClusterSetLabelKey))
+				g.Expect(cluster.OwnerReferences).Should(BeEmpty())
+				g.Expect(cluster.DeletionTimestamp).Should(BeNil())
+			})).Should(Succeed())
+		})
+	})
+})
@@ -26,7 +26,6 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
@@ -101,7 +100,8 @@ type ComponentReconciler struct {
 // +kubebuilder:rbac:groups=core,resources=pods/exec,verbs=create
 
 // read only + watch access
-// +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=volumeattributesclasses,verbs=get;list;watch;create
 
 // +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=serviceaccounts/status,verbs=get
@@ -138,11 +138,20 @@ func (r *ComponentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		if re, ok := err.(intctrlutil.RequeueError); ok {
 			return intctrlutil.RequeueAfter(re.RequeueAfter(), reqCtx.Log, re.Reason())
 		}
-		if apierrors.IsConflict(err) {
+		class := intctrlutil.RecordReconcileError("component", err)
+		if class == intctrlutil.ErrorClassConflict {
 			return intctrlutil.Requeue(reqCtx.Log, err.Error())
 		}
 		c := planBuilder.(*componentPlanBuilder)
 		sendWarningEventWithError(r.Recorder, c.transCtx.Component, corev1.EventTypeWarning, err)
+		if class == intctrlutil.ErrorClassTerminal {
+			// retrying will not help, record the error in the status and wait for the spec to be changed.
+			if patchErr := patchTerminalErrorCondition(reqCtx.Ctx, r.Client, c.transCtx.Component, &c.transCtx.Component.Status.Conditions, err); patchErr != nil {
+				return intctrlutil.RequeueWithError(patchErr, reqCtx.Log, "")
+			}
+			reqCtx.Log.Info("terminal error, stop requeueing", "error", err.Error())
+			return intctrlutil.Reconciled()
+		}
 		return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
 	}
 
@@ -180,6 +189,12 @@ func (r *ComponentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			&componentRestoreTransformer{Client: r.Client},
 			// handle upgrade from the legacy RSM API to the InstanceSet API
 			&componentWorkloadUpgradeTransformer{},
+			// handle the adoption of the existing StatefulSet
+			&componentWorkloadAdoptionTransformer{},
+			// provision the volumes by the StorageClasses with the cloud tags
+			&componentCloudTagsTransformer{},
+			// generate the VolumeAttributesClasses for the volumes with performance
+			&componentVolumeAttributesClassTransformer{},
 			// handle the component workload
 			&componentWorkloadTransformer{Client: r.Client},
 			// handle RBAC for component workloads
@@ -199,6 +214,9 @@ func (r *ComponentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	if errBuild != nil {
 		return requeueError(errBuild)
 	}
+	if c := planBuilder.(*componentPlanBuilder); c.transCtx.RequeueAfter > 0 {
+		return intctrlutil.RequeueAfter(c.transCtx.RequeueAfter, reqCtx.Log, "")
+	}
 	return intctrlutil.Reconciled()
 }
 
@@ -0,0 +1,288 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"fmt"
+
+	vsv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/factory"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+)
+
+const defaultVolumeSnapshotClassAnnotationKey = "snapshot.storage.kubernetes.io/is-default-class"
+
+// snapshotDataClone clones the data volume of the newest replica by a CSI VolumeSnapshot, and provisions
+// the volumes of the new replicas from the snapshot directly. It is much faster than the backup and restore
+// of the data, and is selected automatically if the CSI driver of the volume supports the snapshot.
+type snapshotDataClone struct {
+	baseDataClone
+}
+
+var _ dataClone = &snapshotDataClone{}
+
+// isSnapshotDataCloneSupported checks whether the volumes of the component can be cloned by the CSI VolumeSnapshot.
+func isSnapshotDataCloneSupported(ctx context.Context, cli client.Client,
+	comp *component.SynthesizedComponent, itsObj *workloads.InstanceSet) (bool, error) {
+	if !dputils.SupportsVolumeSnapshotV1() {
+		return false, nil
+	}
+	return isVolumeSnapshotEnabled(ctx, cli, itsObj, backupVCT(comp))
+}
+
+func (d *snapshotDataClone) Succeed() (bool, error) {
+	if len(d.component.VolumeClaimTemplates) == 0 {
+		return true, nil
+	}
+	return d.checkAllPVCsExist()
+}
+
+// CloneData creates the snapshot of the source volume first, and then the volumes of the new replicas once the
+// snapshot is ready to use. All the objects are created in the data context.
+func (d *snapshotDataClone) CloneData(dataClone) ([]client.Object, []client.Object, error) {
+	status, err := d.CheckBackupStatus()
+	if err != nil {
+		return nil, nil, err
+	}
+	switch status {
+	case backupStatusNotCreated:
+		snapshotObjs, err := d.backup()
+		return nil, snapshotObjs, err
+	case backupStatusProcessing, backupStatusFailed:
+		return nil, nil, nil
+	}
+	objs := make([]client.Object, 0)
+	for _, podName := range d.desiredPodNames {
+		if _, ok := d.currentPodNameSet[podName]; ok {
+			continue
+		}
+		templateName, index, err := component.GetTemplateNameAndOrdinal(d.itsObj.Name, podName)
+		if err != nil {
+			return nil, nil, err
+		}
+		restoreStatus, err := d.CheckRestoreStatus(templateName, index)
+		if err != nil {
+			return nil, nil, err
+		}
+		if restoreStatus == dpv1alpha1.RestorePhaseCompleted {
+			continue
+		}
+		pvcObjs, err := d.restore(templateName, index)
+		if err != nil {
+			return nil, nil, err
+		}
+		objs = append(objs, pvcObjs...)
+	}
+	pvcObjs, err := d.createPVCs(d.excludeBackupVCTs())
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, append(objs, pvcObjs...), nil
+}
+
+// GetTmpResources returns the snapshot once all the volumes provisioned from it are bound,
+// the snapshot is retained until then as the provisioning may be delayed to the first consumer.
+func (d *snapshotDataClone) GetTmpResources() ([]client.Object, error) {
+	snapshotList := &vsv1.VolumeSnapshotList{}
+	if err := d.cli.List(d.reqCtx.Ctx, snapshotList, client.InNamespace(d.cluster.Namespace),
+		client.MatchingLabels(d.getBRLabels()), inDataContext4C()); err != nil {
+		return nil, err
+	}
+	if len(snapshotList.Items) == 0 {
+		return nil, nil
+	}
+	pvcList := &corev1.PersistentVolumeClaimList{}
+	if err := d.cli.List(d.reqCtx.Ctx, pvcList, client.InNamespace(d.cluster.Namespace),
+		client.MatchingLabels(constant.GetComponentWellKnownLabels(d.cluster.Name, d.component.Name)), inDataContext4C()); err != nil {
+		return nil, err
+	}
+	objs := make([]client.Object, 0)
+	for i, snapshot := range snapshotList.Items {
+		if !d.isSnapshotReleased(snapshot.Name, pvcList.Items) {
+			continue
+		}
+		objs = append(objs, &snapshotList.Items[i])
+	}
+	return objs, nil
+}
+
+func (d *snapshotDataClone) isSnapshotReleased(snapshotName string, pvcs []corev1.PersistentVolumeClaim) bool {
+	for _, pvc := range pvcs {
+		dataSource := pvc.Spec.DataSource
+		if dataSource == nil || dataSource.Kind != constant.VolumeSnapshotKind || dataSource.Name != snapshotName {
+			continue
+		}
+		if pvc.Status.Phase != corev1.ClaimBound {
+			return false
+		}
+	}
+	return true
+}
+
+func (d *snapshotDataClone) CheckBackupStatus() (backupStatus, error) {
+	snapshot := &vsv1.VolumeSnapshot{}
+	if err := d.cli.Get(d.reqCtx.Ctx, d.backupKey, snapshot, inDataContext4C()); err != nil {
+		if errors.IsNotFound(err) {
+			return backupStatusNotCreated, nil
+		}
+		return backupStatusFailed, err
+	}
+	if snapshot.Status == nil {
+		return backupStatusProcessing, nil
+	}
+	if snapshot.Status.Error != nil && snapshot.Status.Error.Message != nil {
+		d.reqCtx.Recorder.Event(d.cluster, corev1.EventTypeWarning, string(intctrlutil.ErrorTypeBackupFailed),
+			fmt.Sprintf("volume snapshot for horizontalScaling failed: %s", *snapshot.Status.Error.Message))
+		return backupStatusFailed, nil
+	}
+	if snapshot.Status.ReadyToUse != nil && *snapshot.Status.ReadyToUse {
+		return backupStatusReadyToUse, nil
+	}
+	return backupStatusProcessing, nil
+}
+
+// backup creates the snapshot of the data volume of the newest replica, which is the leader if there is one.
+func (d *snapshotDataClone) backup() ([]client.Object, error) {
+	vct := d.backupVCT()
+	sourcePodName, err := d.snapshotSourcePodName()
+	if err != nil {
+		return nil, err
+	}
+	pvcKey := types.NamespacedName{
+		Namespace: d.itsObj.Namespace,
+		Name:      fmt.Sprintf("%s-%s", vct.Name, sourcePodName),
+	}
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err = d.cli.Get(d.reqCtx.Ctx, pvcKey, pvc, inDataContext4C()); err != nil {
+		return nil, err
+	}
+	snapshot := &vsv1.VolumeSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: d.backupKey.Namespace,
+			Name:      d.backupKey.Name,
+			Labels:    d.getBRLabels(),
+		},
+		Spec: vsv1.VolumeSnapshotSpec{
+			Source: vsv1.VolumeSnapshotSource{
+				PersistentVolumeClaimName: &pvc.Name,
+			},
+		},
+	}
+	vscName, err := getVolumeSnapshotClassName(d.reqCtx.Ctx, d.cli, pvc.Spec.VolumeName)
+	if err != nil {
+		return nil, err
+	}
+	if vscName != "" {
+		snapshot.Spec.VolumeSnapshotClassName = &vscName
+	}
+	d.reqCtx.Recorder.Eventf(d.cluster, corev1.EventTypeNormal, "HorizontalScale",
+		"scale out component %s by cloning the volume %s of the instance %s", d.component.Name, pvc.Name, sourcePodName)
+	return []client.Object{snapshot}, nil
+}
+
+// snapshotSourcePodName returns the leader instance, or the first one if there is no leader.
+func (d *snapshotDataClone) snapshotSourcePodName() (string, error) {
+	pods, err := component.ListOwnedPods(d.reqCtx.Ctx, d.cli, d.cluster.Namespace, d.cluster.Name, d.component.Name)
+	if err != nil {
+		return "", err
+	}
+	for _, pod := range pods {
+		if _, ok := d.currentPodNameSet[pod.Name]; !ok {
+			continue
+		}
+		for _, role := range d.itsObj.Spec.Roles {
+			if role.IsLeader && pod.Labels[constant.RoleLabelKey] == role.Name {
+				return pod.Name, nil
+			}
+		}
+	}
+	return fmt.Sprintf("%s-%d", d.itsObj.Name, 0), nil
+}
+
+// restore provisions the data volume of the new replica from the snapshot.
+func (d *snapshotDataClone) restore(templateName string, startingIndex int32) ([]client.Object, error) {
+	vct := d.backupVCT()
+	pvcKey := types.NamespacedName{
+		Namespace: d.itsObj.Namespace,
+		Name:      fmt.Sprintf("%s-%s", vct.Name, d.podName(templateName, startingIndex)),
+	}
+	pvc := factory.BuildPVC(d.cluster, d.component, vct, pvcKey, templateName, d.backupKey.Name)
+	return []client.Object{pvc}, nil
+}
+
+// CheckRestoreStatus checks whether the data volume of the new replica has been provisioned from the snapshot.
+func (d *snapshotDataClone) CheckRestoreStatus(templateName string, startingIndex int32) (dpv1alpha1.RestorePhase, error) {
+	pvcKey := types.NamespacedName{
+		Namespace: d.itsObj.Namespace,
+		Name:      fmt.Sprintf("%s-%s", d.backupVCT().Name, d.podName(templateName, startingIndex)),
+	}
+	exist, err := d.isPVCExists(pvcKey)
+	if err != nil || !exist {
+		return "", err
+	}
+	return dpv1alpha1.RestorePhaseCompleted, nil
+}
+
+func (d *snapshotDataClone) podName(templateName string, ordinal int32) string {
+	if templateName == "" {
+		return fmt.Sprintf("%s-%d", d.itsObj.Name, ordinal)
+	}
+	return fmt.Sprintf("%s-%s-%d", d.itsObj.Name, templateName, ordinal)
+}
+
+// getVolumeSnapshotClassName returns the VolumeSnapshotClass of the CSI driver of the volume, the default one is preferred.
+func getVolumeSnapshotClassName(ctx context.Context, cli client.Client, pvName string) (string, error) {
+	pv := &corev1.PersistentVolume{}
+	if err := cli.Get(ctx, types.NamespacedName{Name: pvName}, pv, inDataContext4C()); err != nil {
+		return "", err
+	}
+	if pv.Spec.CSI == nil {
+		return "", nil
+	}
+	vscList := &vsv1.VolumeSnapshotClassList{}
+	if err := cli.List(ctx, vscList, inDataContext4C()); err != nil {
+		return "", err
+	}
+	vscName := ""
+	for _, vsc := range vscList.Items {
+		if vsc.Driver != pv.Spec.CSI.Driver {
+			continue
+		}
+		if vsc.Annotations[defaultVolumeSnapshotClassAnnotationKey] == "true" {
+			return vsc.Name, nil
+		}
+		if vscName == "" {
+			vscName = vsc.Name
+		}
+	}
+	return vscName, nil
+}
@@ -0,0 +1,141 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	vsv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+var _ = Describe("snapshot data clone", func() {
+	const (
+		namespace   = "default"
+		clusterName = "mycluster"
+		compName    = "mysql"
+		csiDriver   = "hostpath.csi.k8s.io"
+	)
+
+	It("scales out by cloning the volume snapshot of the leader", func() {
+		itsName := constant.GenerateClusterComponentName(clusterName, compName)
+		cluster := &appsv1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: clusterName}}
+		synthesizeComp := &component.SynthesizedComponent{
+			ClusterName:                         clusterName,
+			Name:                                compName,
+			Replicas:                            2,
+			HorizontalScaleBackupPolicyTemplate: pointer.String("backup-policy-template"),
+			VolumeClaimTemplates:                []corev1.PersistentVolumeClaimTemplate{{ObjectMeta: metav1.ObjectMeta{Name: "data"}}},
+		}
+		its := &workloads.InstanceSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: itsName},
+			Spec: workloads.InstanceSetSpec{
+				Replicas: pointer.Int32(1),
+				Roles:    []workloads.ReplicaRole{{Name: "leader", IsLeader: true}},
+			},
+		}
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      itsName + "-0",
+				Labels:    constant.GetComponentWellKnownLabels(clusterName, compName),
+			},
+		}
+		pod.Labels[constant.RoleLabelKey] = "leader"
+		pv := &corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "pv-0"},
+			Spec: corev1.PersistentVolumeSpec{
+				PersistentVolumeSource: corev1.PersistentVolumeSource{CSI: &corev1.CSIPersistentVolumeSource{Driver: csiDriver}},
+			},
+		}
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "data-" + itsName + "-0"},
+			Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: pv.Name},
+		}
+		vsc := &vsv1.VolumeSnapshotClass{ObjectMeta: metav1.ObjectMeta{Name: "csi-hostpath-snapclass"}, Driver: csiDriver}
+
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+		Expect(vsv1.AddToScheme(scheme)).Should(Succeed())
+		cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pod, pv, pvc, vsc).
+			WithStatusSubresource(&vsv1.VolumeSnapshot{}).Build()
+		reqCtx := intctrlutil.RequestCtx{Ctx: context.Background(), Log: logr.Discard(), Recorder: record.NewFakeRecorder(10)}
+		backupKey := types.NamespacedName{Namespace: namespace, Name: constant.GenerateResourceNameWithScalingSuffix(itsName)}
+
+		d, err := newDataClone(reqCtx, cli, cluster, synthesizeComp, its, its, backupKey)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(d).Should(BeAssignableToTypeOf(&snapshotDataClone{}))
+		Expect(d.Succeed()).Should(BeFalse())
+
+		By("expect the snapshot of the leader's volume to be created")
+		_, objs, err := d.CloneData(d)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(objs).Should(HaveLen(1))
+		snapshot := objs[0].(*vsv1.VolumeSnapshot)
+		Expect(*snapshot.Spec.Source.PersistentVolumeClaimName).Should(Equal(pvc.Name))
+		Expect(*snapshot.Spec.VolumeSnapshotClassName).Should(Equal(vsc.Name))
+		Expect(cli.Create(reqCtx.Ctx, snapshot)).Should(Succeed())
+
+		By("expect to wait for the snapshot to be ready")
+		_, objs, err = d.CloneData(d)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(objs).Should(BeEmpty())
+		snapshot.Status = &vsv1.VolumeSnapshotStatus{ReadyToUse: pointer.Bool(true)}
+		Expect(cli.Status().Update(reqCtx.Ctx, snapshot)).Should(Succeed())
+
+		By("expect the volume of the new replica to be provisioned from the snapshot")
+		_, objs, err = d.CloneData(d)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(objs).Should(HaveLen(1))
+		newPVC := objs[0].(*corev1.PersistentVolumeClaim)
+		Expect(newPVC.Name).Should(Equal("data-" + itsName + "-1"))
+		Expect(newPVC.Spec.DataSource.Kind).Should(Equal(constant.VolumeSnapshotKind))
+		Expect(newPVC.Spec.DataSource.Name).Should(Equal(backupKey.Name))
+		Expect(cli.Create(reqCtx.Ctx, newPVC)).Should(Succeed())
+		Expect(d.Succeed()).Should(BeTrue())
+
+		By("expect the snapshot to be retained until the volume is bound")
+		tmpObjs, err := d.GetTmpResources()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(tmpObjs).Should(BeEmpty())
+		newPVC.Status.Phase = corev1.ClaimBound
+		Expect(cli.Status().Update(reqCtx.Ctx, newPVC)).Should(Succeed())
+		tmpObjs, err = d.GetTmpResources()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(tmpObjs).Should(HaveLen(1))
+	})
+})
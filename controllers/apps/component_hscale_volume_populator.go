@@ -97,19 +97,26 @@ func newDataClone(reqCtx intctrlutil.RequestCtx,
 			},
 		}, nil
 	}
-	return &backupDataClone{
-		baseDataClone{
-			reqCtx:            reqCtx,
-			cli:               cli,
-			cluster:           cluster,
-			component:         component,
-			itsObj:            itsObj,
-			itsProto:          itsProto,
-			backupKey:         backupKey,
-			desiredPodNames:   desiredPodNames,
-			currentPodNameSet: sets.New(currentPodNames...),
-		},
-	}, nil
+	base := baseDataClone{
+		reqCtx:            reqCtx,
+		cli:               cli,
+		cluster:           cluster,
+		component:         component,
+		itsObj:            itsObj,
+		itsProto:          itsProto,
+		backupKey:         backupKey,
+		desiredPodNames:   desiredPodNames,
+		currentPodNameSet: sets.New(currentPodNames...),
+	}
+	snapshotSupported, err := isSnapshotDataCloneSupported(reqCtx.Ctx, cli, component, itsObj)
+	if err != nil {
+		return nil, err
+	}
+	// the volume snapshot can not be restored to a smaller volume.
+	if snapshotSupported && !isVolumeShrinking(cluster, component.Name) {
+		return &snapshotDataClone{base}, nil
+	}
+	return &backupDataClone{base}, nil
 }
 
 type baseDataClone struct {
@@ -22,6 +22,7 @@ package apps
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -52,6 +53,8 @@ type componentTransformContext struct {
 	SynthesizeComponent *component.SynthesizedComponent
 	RunningWorkload     client.Object
 	ProtoWorkload       client.Object
+	// RequeueAfter is set if the component needs to be reconciled again at some point even if nothing changes.
+	RequeueAfter time.Duration
 }
 
 func (c *componentTransformContext) GetContext() context.Context {
@@ -129,11 +132,20 @@ func (c *componentPlanBuilder) Build() (graph.Plan, error) {
 }
 
 func (p *componentPlan) Execute() error {
-	err := p.dag.WalkReverseTopoOrder(p.walkFunc, nil)
-	if err != nil {
-		p.transCtx.Logger.V(1).Info(fmt.Sprintf("execute error: %s", err.Error()))
-	}
-	return err
+	// the plan is executed to completion even if the operator is shutting down, to not leave the objects half-updated.
+	key := fmt.Sprintf("component-plan.%s.%s", p.transCtx.Component.Namespace, p.transCtx.Component.Name)
+	return intctrlutil.RunToCompletion(p.transCtx.Context, key, func(ctx context.Context) error {
+		reqCtx := p.transCtx.Context
+		p.transCtx.Context = ctx
+		defer func() {
+			p.transCtx.Context = reqCtx
+		}()
+		err := p.dag.WalkReverseTopoOrder(p.walkFunc, nil)
+		if err != nil {
+			p.transCtx.Logger.V(1).Info(fmt.Sprintf("execute error: %s", err.Error()))
+		}
+		return err
+	})
 }
 
 // newComponentPlanBuilder returns a componentPlanBuilder powered PlanBuilder
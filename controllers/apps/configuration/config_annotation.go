@@ -32,6 +32,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/configuration/core"
 	"github.com/apecloud/kubeblocks/pkg/configuration/util"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	configctrl "github.com/apecloud/kubeblocks/pkg/controller/configuration"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
@@ -165,7 +166,14 @@ func updateAppliedConfigs(cli client.Client, ctx intctrlutil.RequestCtx, config
 		b, _ := json.Marshal(result)
 		config.ObjectMeta.Annotations[core.GenerateRevisionPhaseKey(revision)] = string(b)
 	}
-	config.ObjectMeta.Annotations[constant.LastAppliedConfigAnnotationKey] = string(configData)
+	if configctrl.IsSecretBackedConfigMap(config) {
+		// keep the contents of the files out of the configmap.
+		if err := configctrl.UpdateSecretBackedLastApplied(ctx.Ctx, cli, config, string(configData)); err != nil {
+			return false, err
+		}
+	} else {
+		config.ObjectMeta.Annotations[constant.LastAppliedConfigAnnotationKey] = string(configData)
+	}
 	hash, err := util.ComputeHash(config.Data)
 	if err != nil {
 		return false, err
@@ -364,7 +364,8 @@ func createConfigPatch(cfg *corev1.ConfigMap, formatter *appsv1beta1.FileFormatC
 		return nil, false, core.WrapError(err, "failed to get last version data. config[%v]", client.ObjectKeyFromObject(cfg))
 	}
 
-	return core.CreateConfigPatch(lastConfig, cfg.Data, formatter.Format, cmKeys, true)
+	// the role files are applied online to the pods of the roles, but not to the whole component.
+	return core.CreateConfigPatch(configuration.WithoutRoleOverlayFiles(lastConfig), configuration.WithoutRoleOverlayFiles(cfg.Data), formatter.Format, cmKeys, true)
 }
 
 func updateConfigSchema(cc *appsv1beta1.ConfigConstraint, cli client.Client, ctx context.Context) error {
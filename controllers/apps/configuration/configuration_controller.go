@@ -117,6 +117,9 @@ func (r *ConfigurationReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return r.failWithInvalidComponent(config, reqCtx)
 	}
 	if err := r.runTasks(TaskContext{config, reqCtx, fetcherTask}, tasks); err != nil {
+		if intctrlutil.RecordReconcileError("configuration", err) == intctrlutil.ErrorClassConflict {
+			return intctrlutil.Requeue(reqCtx.Log, err.Error())
+		}
 		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "failed to run configuration reconcile task.")
 	}
 	if !isAllReady(config) {
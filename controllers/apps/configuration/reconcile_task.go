@@ -92,6 +92,7 @@ func syncImpl(fetcher *Task,
 		PrepareForTemplate().
 		RerenderTemplate().
 		ApplyParameters().
+		ApplyRoleOverlays().
 		UpdateConfigVersion(revision).
 		Sync().
 		Complete()
@@ -31,6 +31,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -103,11 +104,17 @@ func (r *ReconfigureReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	if !checkConfigurationObject(config) {
 		return intctrlutil.Reconciled()
 	}
+	if err := configctrl.LoadSecretBackedData(reqCtx.Ctx, r.Client, config); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "cannot load the secret of configmap")
+	}
 
 	reqCtx.Log = reqCtx.Log.
 		WithValues("ClusterName", config.Labels[constant.AppInstanceLabelKey]).
 		WithValues("ComponentName", config.Labels[constant.KBAppComponentLabelKey])
 	if hash, ok := config.Labels[constant.CMInsConfigurationHashLabelKey]; ok && hash == config.ResourceVersion {
+		if err := r.syncRoleOverlays(reqCtx, config); err != nil {
+			return intctrlutil.RequeueAfter(ConfigReconcileInterval, reqCtx.Log, "failed to sync role overlays", "error", err)
+		}
 		return intctrlutil.Reconciled()
 	}
 
@@ -115,6 +122,10 @@ func (r *ReconfigureReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	if err != nil {
 		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "failed to check last-applied-configuration")
 	} else if isAppliedConfigs {
+		// the role overlays are applied on top of the base configuration which has been applied.
+		if err := r.syncRoleOverlays(reqCtx, config); err != nil {
+			return intctrlutil.RequeueAfter(ConfigReconcileInterval, reqCtx.Log, "failed to sync role overlays", "error", err)
+		}
 		return updateConfigPhase(r.Client, reqCtx, config, appsv1alpha1.CFinishedPhase, configurationNoChangedMessage)
 	}
 
@@ -138,13 +149,18 @@ func (r *ReconfigureReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 // SetupWithManager sets up the controller with the Manager.
 func (r *ReconfigureReconciler) SetupWithManager(mgr ctrl.Manager, multiClusterMgr multicluster.Manager) error {
 	b := intctrlutil.NewNamespacedControllerManagedBy(mgr).
-		For(&corev1.ConfigMap{}).
+		For(&corev1.ConfigMap{}, builder.WithPredicates(predicate.NewPredicateFuncs(checkConfigurationObject))).
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(r.filterRoleOverlayConfigMaps), builder.WithPredicates(rolePodPredicate())).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: int(math.Ceil(viper.GetFloat64(constant.CfgKBReconcileWorkers) / 4)),
 		})
 
 	if multiClusterMgr != nil {
 		eventHandler := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+			// the predicate of For doesn't apply to the configmaps of the data clusters.
+			if !checkConfigurationObject(obj) {
+				return nil
+			}
 			return []reconcile.Request{
 				{
 					NamespacedName: types.NamespacedName{
@@ -157,7 +173,7 @@ func (r *ReconfigureReconciler) SetupWithManager(mgr ctrl.Manager, multiClusterM
 		multiClusterMgr.Watch(b, &corev1.ConfigMap{}, eventHandler)
 	}
 
-	return b.WithEventFilter(predicate.NewPredicateFuncs(checkConfigurationObject)).Complete(r)
+	return b.Complete(r)
 }
 
 func checkConfigurationObject(object client.Object) bool {
@@ -0,0 +1,176 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package configuration
+
+import (
+	"context"
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/apecloud/kubeblocks/pkg/configuration/core"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	configctrl "github.com/apecloud/kubeblocks/pkg/controller/configuration"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// syncRoleOverlays applies the role overlays of the configmap to the pods according to their current roles,
+// the overlay of the previous role is reverted when the role of a pod changes.
+func (r *ReconfigureReconciler) syncRoleOverlays(reqCtx intctrlutil.RequestCtx, config *corev1.ConfigMap) error {
+	if _, ok := config.Annotations[constant.CMRoleConfigOverlaysAnnotationKey]; !ok {
+		return nil
+	}
+	overlays, err := configctrl.GetRoleOverlays(config)
+	if err != nil {
+		return err
+	}
+
+	podList := &corev1.PodList{}
+	if err := r.Client.List(reqCtx.Ctx, podList,
+		client.InNamespace(config.Namespace),
+		client.MatchingLabels{
+			constant.AppInstanceLabelKey:    config.Labels[constant.AppInstanceLabelKey],
+			constant.KBAppComponentLabelKey: config.Labels[constant.KBAppComponentLabelKey],
+		}); err != nil {
+		return err
+	}
+
+	configSpec := config.Labels[constant.CMConfigurationSpecProviderLabelKey]
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if !intctrlutil.PodIsReady(pod) {
+			continue
+		}
+		if err := r.syncPodRoleOverlay(reqCtx.Ctx, pod, configSpec, overlays); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ReconfigureReconciler) syncPodRoleOverlay(ctx context.Context, pod *corev1.Pod, configSpec string, overlays map[string]configctrl.RoleOverlay) error {
+	applied, err := configctrl.GetAppliedRoleOverlay(pod, configSpec)
+	if err != nil {
+		return err
+	}
+	restarts := podRestarts(pod)
+	if applied != nil && applied.Restarts != restarts {
+		// the restarted engine has loaded the configuration files, the overlay applied online is lost.
+		applied.Hash = ""
+		applied.Revert = nil
+	}
+	role := pod.Labels[constant.RoleLabelKey]
+	desired, ok := overlays[role]
+	switch {
+	case applied == nil && !ok:
+		return nil
+	case applied != nil && ok && applied.Role == role && applied.Hash == desired.Hash:
+		return nil
+	}
+
+	params := make(map[string]string)
+	if applied != nil {
+		for key, value := range applied.Revert {
+			params[key] = value
+		}
+	}
+	for key, value := range desired.Apply {
+		params[key] = value
+	}
+	if len(params) != 0 {
+		if err := GetInstanceSetRollingUpgradeFuncs().OnlineUpdatePodFunc(pod, ctx, GetClientFactory(), configSpec, params); err != nil {
+			return err
+		}
+	}
+
+	patch := client.MergeFrom(pod.DeepCopy())
+	annotationKey := core.GenerateUniqKeyWithConfig(constant.RoleConfigOverlayAnnotationKeyPrefix, configSpec)
+	if !ok {
+		delete(pod.Annotations, annotationKey)
+		return r.Client.Patch(ctx, pod, patch)
+	}
+	b, err := json.Marshal(configctrl.AppliedRoleOverlay{Role: role, Hash: desired.Hash, Revert: desired.Revert, Restarts: restarts})
+	if err != nil {
+		return err
+	}
+	if pod.Annotations == nil {
+		pod.Annotations = make(map[string]string)
+	}
+	pod.Annotations[annotationKey] = string(b)
+	return r.Client.Patch(ctx, pod, patch)
+}
+
+// podRestarts returns the total restart count of the containers of the pod.
+func podRestarts(pod *corev1.Pod) int32 {
+	var restarts int32
+	for _, status := range pod.Status.ContainerStatuses {
+		restarts += status.RestartCount
+	}
+	return restarts
+}
+
+// filterRoleOverlayConfigMaps maps the pod to the configmaps of its component that have role overlays.
+func (r *ReconfigureReconciler) filterRoleOverlayConfigMaps(ctx context.Context, obj client.Object) []reconcile.Request {
+	labels := obj.GetLabels()
+	if labels[constant.AppInstanceLabelKey] == "" || labels[constant.KBAppComponentLabelKey] == "" {
+		return nil
+	}
+	cmList := &corev1.ConfigMapList{}
+	if err := r.Client.List(ctx, cmList,
+		client.InNamespace(obj.GetNamespace()),
+		client.MatchingLabels{
+			constant.AppInstanceLabelKey:         labels[constant.AppInstanceLabelKey],
+			constant.KBAppComponentLabelKey:      labels[constant.KBAppComponentLabelKey],
+			constant.CMConfigurationTypeLabelKey: constant.ConfigInstanceType,
+		}); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, cm := range cmList.Items {
+		if _, ok := cm.Annotations[constant.CMRoleConfigOverlaysAnnotationKey]; ok {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: cm.Namespace, Name: cm.Name}})
+		}
+	}
+	return requests
+}
+
+// rolePodPredicate selects the pods whose role, readiness or restart count changed.
+func rolePodPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldPod, ok1 := e.ObjectOld.(*corev1.Pod)
+			newPod, ok2 := e.ObjectNew.(*corev1.Pod)
+			if !ok1 || !ok2 {
+				return false
+			}
+			return oldPod.Labels[constant.RoleLabelKey] != newPod.Labels[constant.RoleLabelKey] ||
+				intctrlutil.PodIsReady(oldPod) != intctrlutil.PodIsReady(newPod) ||
+				podRestarts(oldPod) != podRestarts(newPod)
+		},
+	}
+}
@@ -0,0 +1,206 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
+)
+
+// databaseRequeueDuration is the interval to check again whether the Component is ready to be provisioned.
+const databaseRequeueDuration = 10 * time.Second
+
+// DatabaseReconciler reconciles a Database object
+type DatabaseReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=databases,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=databases/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=databases/finalizers,verbs=update
+
+// Reconcile creates the database in the engine of the Component through the agent of its writable Pod,
+// and drops it when the Database is deleted with the `Delete` policy.
+func (r *DatabaseReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("database", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	database := &appsv1alpha1.Database{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, database); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+
+	res, err := intctrlutil.HandleCRDeletion(reqCtx, r, database, constant.DatabaseFinalizerName, func() (*ctrl.Result, error) {
+		return r.deleteDatabase(reqCtx, database)
+	})
+	if res != nil {
+		return *res, err
+	}
+
+	if database.Status.ObservedGeneration == database.Generation &&
+		database.Status.Phase == appsv1alpha1.DatabaseProvisionedPhase {
+		return intctrlutil.Reconciled()
+	}
+
+	lorryCli, reason, err := buildDatabaseLorryClient(reqCtx, r.Client, database.Namespace,
+		database.Spec.ClusterName, database.Spec.ComponentName)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if lorryCli == nil {
+		if err = r.updateStatus(reqCtx, database, appsv1alpha1.DatabasePendingPhase, reason); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		return intctrlutil.RequeueAfter(databaseRequeueDuration, reqCtx.Log, reason)
+	}
+
+	if err = lorryCli.CreateDatabase(reqCtx.Ctx, database.GetDatabaseName()); err != nil {
+		if err1 := r.updateStatus(reqCtx, database, appsv1alpha1.DatabaseFailedPhase, err.Error()); err1 != nil {
+			return intctrlutil.CheckedRequeueWithError(err1, reqCtx.Log, "")
+		}
+		return intctrlutil.RequeueWithErrorAndRecordEvent(database, r.Recorder, err, reqCtx.Log)
+	}
+
+	if err = r.updateStatus(reqCtx, database, appsv1alpha1.DatabaseProvisionedPhase, ""); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	intctrlutil.RecordCreatedEvent(r.Recorder, database)
+	return intctrlutil.Reconciled()
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DatabaseReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return intctrlutil.NewNamespacedControllerManagedBy(mgr).
+		For(&appsv1alpha1.Database{}).
+		Complete(r)
+}
+
+// deleteDatabase drops the database from the engine if the deletion policy is `Delete`.
+func (r *DatabaseReconciler) deleteDatabase(reqCtx intctrlutil.RequestCtx, database *appsv1alpha1.Database) (*ctrl.Result, error) {
+	if database.Spec.DeletionPolicy != appsv1alpha1.DatabaseDeletionPolicyDelete ||
+		database.Status.Phase != appsv1alpha1.DatabaseProvisionedPhase {
+		return nil, nil
+	}
+	lorryCli, reason, err := buildDatabaseLorryClient(reqCtx, r.Client, database.Namespace,
+		database.Spec.ClusterName, database.Spec.ComponentName)
+	if err != nil {
+		return nil, err
+	}
+	if lorryCli == nil {
+		if reason == databaseComponentNotFound {
+			// the database is gone along with the component.
+			return nil, nil
+		}
+		return intctrlutil.ResultToP(intctrlutil.RequeueAfter(databaseRequeueDuration, reqCtx.Log, reason))
+	}
+	if err = lorryCli.DropDatabase(reqCtx.Ctx, database.GetDatabaseName()); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (r *DatabaseReconciler) updateStatus(reqCtx intctrlutil.RequestCtx, database *appsv1alpha1.Database,
+	phase appsv1alpha1.DatabasePhase, message string) error {
+	patch := client.MergeFrom(database.DeepCopy())
+	database.Status.ObservedGeneration = database.Generation
+	database.Status.Phase = phase
+	database.Status.Message = message
+	return r.Client.Status().Patch(reqCtx.Ctx, database, patch)
+}
+
+const databaseComponentNotFound = "the component is not found"
+
+// buildDatabaseLorryClient builds the lorry client to the Pod with the writable role of the Component.
+// It returns a nil client with the reason if the Component is not ready to be provisioned yet.
+func buildDatabaseLorryClient(reqCtx intctrlutil.RequestCtx, cli client.Client,
+	namespace, clusterName, compName string) (lorry.Client, string, error) {
+	comp := &appsv1alpha1.Component{}
+	compKey := types.NamespacedName{
+		Namespace: namespace,
+		Name:      constant.GenerateClusterComponentName(clusterName, compName),
+	}
+	if err := cli.Get(reqCtx.Ctx, compKey, comp); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, databaseComponentNotFound, nil
+		}
+		return nil, "", err
+	}
+	if comp.Status.Phase != appsv1alpha1.RunningClusterCompPhase {
+		return nil, fmt.Sprintf("the component is %s, waiting for it to be running", comp.Status.Phase), nil
+	}
+
+	compDef := &appsv1alpha1.ComponentDefinition{}
+	if err := cli.Get(reqCtx.Ctx, types.NamespacedName{Name: comp.Spec.CompDef}, compDef); err != nil {
+		return nil, "", err
+	}
+	roleName := ""
+	for _, role := range compDef.Spec.Roles {
+		if role.Serviceable && role.Writable {
+			roleName = role.Name
+		}
+	}
+
+	var (
+		pods []*corev1.Pod
+		err  error
+	)
+	if roleName == "" {
+		pods, err = component.ListOwnedPods(reqCtx.Ctx, cli, namespace, clusterName, compName)
+	} else {
+		pods, err = component.ListOwnedPodsWithRole(reqCtx.Ctx, cli, namespace, clusterName, compName, roleName)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	if len(pods) == 0 {
+		return nil, "no writable pod of the component is available", nil
+	}
+
+	lorryCli, err := lorry.NewClient(*pods[0])
+	if err != nil {
+		return nil, "", err
+	}
+	if intctrlutil.IsNil(lorryCli) {
+		return nil, "the agent of the component is not available", nil
+	}
+	return lorryCli, "", nil
+}
@@ -0,0 +1,288 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/common"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
+	lorryModel "github.com/apecloud/kubeblocks/pkg/lorry/engines/models"
+)
+
+// DatabaseUserReconciler reconciles a DatabaseUser object
+type DatabaseUserReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=databaseusers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=databaseusers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=databaseusers/finalizers,verbs=update
+
+// Reconcile generates the credential Secret of the DatabaseUser, creates the user in the engine of the Component
+// through the agent of its writable Pod, and grants or revokes the privileges on the databases to match the spec.
+func (r *DatabaseUserReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("databaseUser", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	user := &appsv1alpha1.DatabaseUser{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, user); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+
+	res, err := intctrlutil.HandleCRDeletion(reqCtx, r, user, constant.DatabaseUserFinalizerName, func() (*ctrl.Result, error) {
+		return r.deleteUser(reqCtx, user)
+	})
+	if res != nil {
+		return *res, err
+	}
+
+	if user.Status.ObservedGeneration == user.Generation &&
+		user.Status.Phase == appsv1alpha1.DatabaseProvisionedPhase {
+		return intctrlutil.Reconciled()
+	}
+
+	secret, err := r.ensureSecret(reqCtx, user)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	user.Status.SecretName = secret.Name
+
+	lorryCli, reason, err := buildDatabaseLorryClient(reqCtx, r.Client, user.Namespace,
+		user.Spec.ClusterName, user.Spec.ComponentName)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if lorryCli == nil {
+		if err = r.updateStatus(reqCtx, user, appsv1alpha1.DatabasePendingPhase, reason); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		return intctrlutil.RequeueAfter(databaseRequeueDuration, reqCtx.Log, reason)
+	}
+
+	if err = r.provision(reqCtx, user, secret, lorryCli); err != nil {
+		if err1 := r.updateStatus(reqCtx, user, appsv1alpha1.DatabaseFailedPhase, err.Error()); err1 != nil {
+			return intctrlutil.CheckedRequeueWithError(err1, reqCtx.Log, "")
+		}
+		return intctrlutil.RequeueWithErrorAndRecordEvent(user, r.Recorder, err, reqCtx.Log)
+	}
+
+	if err = r.updateStatus(reqCtx, user, appsv1alpha1.DatabaseProvisionedPhase, ""); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	intctrlutil.RecordCreatedEvent(r.Recorder, user)
+	return intctrlutil.Reconciled()
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DatabaseUserReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return intctrlutil.NewNamespacedControllerManagedBy(mgr).
+		For(&appsv1alpha1.DatabaseUser{}).
+		Owns(&corev1.Secret{}).
+		Complete(r)
+}
+
+// ensureSecret gets the credential Secret of the user, or generates it if it doesn't exist.
+// The Secret is owned by the DatabaseUser and is garbage collected along with it.
+func (r *DatabaseUserReconciler) ensureSecret(reqCtx intctrlutil.RequestCtx, user *appsv1alpha1.DatabaseUser) (*corev1.Secret, error) {
+	secretKey := types.NamespacedName{
+		Namespace: user.Namespace,
+		Name:      generateDatabaseUserSecretName(user.Name),
+	}
+	secret := &corev1.Secret{}
+	if err := r.Client.Get(reqCtx.Ctx, secretKey, secret); err == nil {
+		return secret, nil
+	} else if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	secret = builder.NewSecretBuilder(secretKey.Namespace, secretKey.Name).
+		AddLabelsInMap(user.Labels).
+		AddLabelsInMap(constant.GetClusterWellKnownLabels(user.Spec.ClusterName)).
+		PutData(constant.AccountNameForSecret, []byte(user.GetUserName())).
+		PutData(constant.AccountPasswdForSecret, generateDatabaseUserPassword(user.Spec.PasswordConfig)).
+		SetImmutable(true).
+		GetObject()
+	if err := controllerutil.SetControllerReference(user, secret, r.Scheme); err != nil {
+		return nil, err
+	}
+	if err := r.Client.Create(reqCtx.Ctx, secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// provision creates the user in the engine if it doesn't exist, and applies the difference between the grants
+// in the spec and the grants recorded in the status.
+func (r *DatabaseUserReconciler) provision(reqCtx intctrlutil.RequestCtx, user *appsv1alpha1.DatabaseUser,
+	secret *corev1.Secret, lorryCli lorry.Client) error {
+	userName := user.GetUserName()
+	userInfo, err := lorryCli.DescribeUser(reqCtx.Ctx, userName)
+	if err != nil || len(userInfo) == 0 {
+		password := string(secret.Data[constant.AccountPasswdForSecret])
+		if err = lorryCli.CreateUser(reqCtx.Ctx, userName, password, "", ""); err != nil {
+			return err
+		}
+	}
+
+	desired := make(map[string]appsv1alpha1.DatabaseRole)
+	for _, grant := range user.Spec.Grants {
+		desired[grant.Database] = databaseGrantRole(grant)
+	}
+
+	// the status records the grants applied so far, so that a failed grant can be resumed from where it stops.
+	granted := make([]appsv1alpha1.DatabaseGrant, 0, len(user.Status.Grants))
+	defer func() {
+		user.Status.Grants = granted
+	}()
+	for _, grant := range user.Status.Grants {
+		if role, ok := desired[grant.Database]; ok && role == databaseGrantRole(grant) {
+			granted = append(granted, grant)
+			continue
+		}
+		if err = lorryCli.RevokeDatabaseRole(reqCtx.Ctx, userName, grant.Database,
+			databaseRoleToLorryRole(databaseGrantRole(grant))); err != nil {
+			granted = append(granted, grant)
+			return err
+		}
+	}
+	for _, grant := range user.Spec.Grants {
+		if isDatabaseGranted(granted, grant) {
+			continue
+		}
+		if err = lorryCli.GrantDatabaseRole(reqCtx.Ctx, userName, grant.Database,
+			databaseRoleToLorryRole(databaseGrantRole(grant))); err != nil {
+			return err
+		}
+		granted = append(granted, appsv1alpha1.DatabaseGrant{Database: grant.Database, Role: databaseGrantRole(grant)})
+	}
+	return nil
+}
+
+// deleteUser deletes the user from the engine if the deletion policy is `Delete`.
+func (r *DatabaseUserReconciler) deleteUser(reqCtx intctrlutil.RequestCtx, user *appsv1alpha1.DatabaseUser) (*ctrl.Result, error) {
+	if user.Spec.DeletionPolicy == appsv1alpha1.DatabaseDeletionPolicyRetain {
+		return nil, nil
+	}
+	lorryCli, reason, err := buildDatabaseLorryClient(reqCtx, r.Client, user.Namespace,
+		user.Spec.ClusterName, user.Spec.ComponentName)
+	if err != nil {
+		return nil, err
+	}
+	if lorryCli == nil {
+		if reason == databaseComponentNotFound {
+			// the user is gone along with the component.
+			return nil, nil
+		}
+		return intctrlutil.ResultToP(intctrlutil.RequeueAfter(databaseRequeueDuration, reqCtx.Log, reason))
+	}
+	if userInfo, err := lorryCli.DescribeUser(reqCtx.Ctx, user.GetUserName()); err != nil || len(userInfo) == 0 {
+		// the user has not been created in the engine.
+		return nil, nil
+	}
+	if err = lorryCli.DeleteUser(reqCtx.Ctx, user.GetUserName()); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (r *DatabaseUserReconciler) updateStatus(reqCtx intctrlutil.RequestCtx, user *appsv1alpha1.DatabaseUser,
+	phase appsv1alpha1.DatabasePhase, message string) error {
+	status := user.Status.DeepCopy()
+	status.ObservedGeneration = user.Generation
+	status.Phase = phase
+	status.Message = message
+
+	latest := &appsv1alpha1.DatabaseUser{}
+	if err := r.Client.Get(reqCtx.Ctx, client.ObjectKeyFromObject(user), latest); err != nil {
+		return err
+	}
+	patch := client.MergeFrom(latest.DeepCopy())
+	latest.Status = *status
+	return r.Client.Status().Patch(reqCtx.Ctx, latest, patch)
+}
+
+func generateDatabaseUserSecretName(name string) string {
+	return name + "-credential"
+}
+
+func generateDatabaseUserPassword(config *appsv1alpha1.PasswordConfig) []byte {
+	if config == nil {
+		config = &appsv1alpha1.PasswordConfig{Length: 16, NumDigits: 4, LetterCase: appsv1alpha1.MixedCases}
+	}
+	passwd, _ := common.GeneratePassword((int)(config.Length), (int)(config.NumDigits), (int)(config.NumSymbols), false, config.Seed)
+	switch config.LetterCase {
+	case appsv1alpha1.UpperCases:
+		passwd = strings.ToUpper(passwd)
+	case appsv1alpha1.LowerCases:
+		passwd = strings.ToLower(passwd)
+	}
+	return []byte(passwd)
+}
+
+func databaseGrantRole(grant appsv1alpha1.DatabaseGrant) appsv1alpha1.DatabaseRole {
+	if grant.Role == "" {
+		return appsv1alpha1.DatabaseReadOnlyRole
+	}
+	return grant.Role
+}
+
+func isDatabaseGranted(granted []appsv1alpha1.DatabaseGrant, grant appsv1alpha1.DatabaseGrant) bool {
+	for _, g := range granted {
+		if g.Database == grant.Database && g.Role == databaseGrantRole(grant) {
+			return true
+		}
+	}
+	return false
+}
+
+// databaseRoleToLorryRole maps the role on a database to the role type of lorry,
+// the owner of a database has the superuser privileges on the database only.
+func databaseRoleToLorryRole(role appsv1alpha1.DatabaseRole) string {
+	switch role {
+	case appsv1alpha1.DatabaseOwnerRole:
+		return string(lorryModel.SuperUserRole)
+	case appsv1alpha1.DatabaseReadWriteRole:
+		return string(lorryModel.ReadWriteRole)
+	default:
+		return string(lorryModel.ReadOnlyRole)
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("test DatabaseUser controller", func() {
+
+	var (
+		randomStr = testCtx.GetRandomStr()
+	)
+
+	cleanEnv := func() {
+		By("clean resources")
+
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		testapps.ClearResources(&testCtx, generics.DatabaseUserSignature, inNS, ml)
+		testapps.ClearResources(&testCtx, generics.SecretSignature, inNS, ml)
+	}
+	BeforeEach(cleanEnv)
+
+	AfterEach(cleanEnv)
+
+	Context("test DatabaseUser controller", func() {
+		It("generates the credential and waits for the component", func() {
+			By("create a DatabaseUser obj")
+			user := &appsv1alpha1.DatabaseUser{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testCtx.DefaultNamespace,
+					Name:      "app-" + randomStr,
+					Labels:    map[string]string{testCtx.TestObjLabelKey: "true"},
+				},
+				Spec: appsv1alpha1.DatabaseUserSpec{
+					ClusterName:   "cluster-" + randomStr,
+					ComponentName: "mysql",
+					Grants: []appsv1alpha1.DatabaseGrant{
+						{Database: "orders", Role: appsv1alpha1.DatabaseReadWriteRole},
+					},
+				},
+			}
+			Expect(testCtx.CreateObj(testCtx.Ctx, user)).Should(Succeed())
+
+			By("check the user is pending on the component")
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(user),
+				func(g Gomega, user *appsv1alpha1.DatabaseUser) {
+					g.Expect(user.Finalizers).Should(ContainElement(constant.DatabaseUserFinalizerName))
+					g.Expect(user.Status.Phase).Should(Equal(appsv1alpha1.DatabasePendingPhase))
+					g.Expect(user.Status.Message).Should(Equal(databaseComponentNotFound))
+					g.Expect(user.Status.SecretName).Should(Equal(generateDatabaseUserSecretName(user.Name)))
+				})).Should(Succeed())
+
+			By("check the credential secret is generated")
+			secretKey := client.ObjectKey{Namespace: user.Namespace, Name: generateDatabaseUserSecretName(user.Name)}
+			Eventually(testapps.CheckObj(&testCtx, secretKey, func(g Gomega, secret *corev1.Secret) {
+				g.Expect(secret.Data).Should(HaveKeyWithValue(constant.AccountNameForSecret, []byte(user.Name)))
+				g.Expect(secret.Data[constant.AccountPasswdForSecret]).Should(HaveLen(16))
+				g.Expect(secret.OwnerReferences).Should(HaveLen(1))
+				g.Expect(secret.OwnerReferences[0].Name).Should(Equal(user.Name))
+			})).Should(Succeed())
+
+			By("delete the DatabaseUser obj")
+			Expect(testCtx.Cli.Delete(testCtx.Ctx, user)).Should(Succeed())
+			Eventually(testapps.CheckObjExists(&testCtx, client.ObjectKeyFromObject(user),
+				&appsv1alpha1.DatabaseUser{}, false)).Should(Succeed())
+		})
+	})
+})
@@ -0,0 +1,331 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+const (
+	// fleetStatusClustersKey is the key of the fleet status ConfigMap which holds the status of all clusters.
+	fleetStatusClustersKey = "clusters.json"
+	// fleetStatusSummaryKey is the key of the fleet status ConfigMap which holds the summary of all clusters.
+	fleetStatusSummaryKey = "summary.json"
+
+	// maxFleetStatusClustersSize is the max size of the cluster status in the ConfigMap, to keep it under
+	// the size limit of the ConfigMap. Only the summary is exported if it is exceeded.
+	maxFleetStatusClustersSize = 900 * 1024
+)
+
+var (
+	fleetClusters = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kubeblocks_fleet_clusters",
+			Help: "Number of clusters per phase.",
+		},
+		[]string{"phase"},
+	)
+	fleetClusterPendingOps = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kubeblocks_fleet_cluster_pending_ops",
+			Help: "Number of the OpsRequests of the cluster which are not completed.",
+		},
+		[]string{"namespace", "cluster"},
+	)
+	fleetClusterLastBackupTimestamp = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kubeblocks_fleet_cluster_last_backup_timestamp_seconds",
+			Help: "The completion time of the latest completed backup of the cluster.",
+		},
+		[]string{"namespace", "cluster"},
+	)
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(fleetClusters, fleetClusterPendingOps, fleetClusterLastBackupTimestamp)
+}
+
+// FleetClusterStatus summarizes the status of a cluster for the fleet dashboards.
+type FleetClusterStatus struct {
+	Namespace       string                    `json:"namespace"`
+	Name            string                    `json:"name"`
+	Phase           appsv1alpha1.ClusterPhase `json:"phase,omitempty"`
+	ClusterDef      string                    `json:"clusterDef,omitempty"`
+	ServiceVersions []string                  `json:"serviceVersions,omitempty"`
+	PendingOps      int                       `json:"pendingOps"`
+	LastBackupTime  *metav1.Time              `json:"lastBackupTime,omitempty"`
+	Replicas        int32                     `json:"replicas"`
+	CPU             string                    `json:"cpu,omitempty"`
+	Memory          string                    `json:"memory,omitempty"`
+	Storage         string                    `json:"storage,omitempty"`
+}
+
+// FleetSummary summarizes all clusters for the fleet dashboards.
+type FleetSummary struct {
+	UpdateTime      metav1.Time                               `json:"updateTime"`
+	Clusters        int                                       `json:"clusters"`
+	Phases          map[appsv1alpha1.ClusterPhase]int         `json:"phases"`
+	PendingOps      int                                       `json:"pendingOps"`
+	ClustersTrimmed bool                                      `json:"clustersTrimmed,omitempty"`
+	Capacity        map[corev1.ResourceName]resource.Quantity `json:"capacity"`
+}
+
+// FleetStatusExporter periodically summarizes all clusters across namespaces, including the phase, versions,
+// pending OpsRequests, backup freshness and capacity, and exports them to a ConfigMap and the metrics,
+// so that the fleet dashboards don't need to list thousands of objects directly.
+type FleetStatusExporter struct {
+	Client client.Client
+	Log    logr.Logger
+	// the interval to export the fleet status.
+	Interval time.Duration
+	// the namespace and name of the ConfigMap to export to.
+	Namespace string
+	Name      string
+}
+
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=clusters,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsrequests,verbs=get;list;watch
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch
+
+// NeedLeaderElection implements the manager.LeaderElectionRunnable interface, only the leader exports the status.
+func (e *FleetStatusExporter) NeedLeaderElection() bool {
+	return true
+}
+
+// Start implements the manager.Runnable interface.
+func (e *FleetStatusExporter) Start(ctx context.Context) error {
+	ticker := time.NewTicker(e.Interval)
+	defer ticker.Stop()
+	for {
+		if err := e.Export(ctx); err != nil {
+			e.Log.Error(err, "failed to export the fleet status")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Export summarizes all clusters and exports them.
+func (e *FleetStatusExporter) Export(ctx context.Context) error {
+	clusters, summary, err := e.summarize(ctx)
+	if err != nil {
+		return err
+	}
+	e.exportMetrics(clusters, summary)
+	return e.exportConfigMap(ctx, clusters, summary)
+}
+
+func (e *FleetStatusExporter) summarize(ctx context.Context) ([]FleetClusterStatus, *FleetSummary, error) {
+	clusterList := &appsv1alpha1.ClusterList{}
+	if err := e.Client.List(ctx, clusterList); err != nil {
+		return nil, nil, err
+	}
+	opsList := &appsv1alpha1.OpsRequestList{}
+	if err := e.Client.List(ctx, opsList); err != nil {
+		return nil, nil, err
+	}
+	backupList := &dpv1alpha1.BackupList{}
+	if err := e.Client.List(ctx, backupList); err != nil && !apierrors.IsNotFound(err) {
+		return nil, nil, err
+	}
+
+	clusterKey := func(namespace, name string) string {
+		return namespace + "/" + name
+	}
+	pendingOps := map[string]int{}
+	for _, ops := range opsList.Items {
+		if !ops.IsComplete() {
+			pendingOps[clusterKey(ops.Namespace, ops.Spec.GetClusterName())]++
+		}
+	}
+	lastBackups := map[string]*metav1.Time{}
+	for i, backup := range backupList.Items {
+		clusterName := backup.Labels[constant.AppInstanceLabelKey]
+		if clusterName == "" || backup.Status.Phase != dpv1alpha1.BackupPhaseCompleted || backup.Status.CompletionTimestamp == nil {
+			continue
+		}
+		key := clusterKey(backup.Namespace, clusterName)
+		if last := lastBackups[key]; last == nil || last.Before(backup.Status.CompletionTimestamp) {
+			lastBackups[key] = backupList.Items[i].Status.CompletionTimestamp
+		}
+	}
+
+	summary := &FleetSummary{
+		UpdateTime: metav1.Now(),
+		Clusters:   len(clusterList.Items),
+		Phases:     map[appsv1alpha1.ClusterPhase]int{},
+		Capacity:   map[corev1.ResourceName]resource.Quantity{},
+	}
+	clusters := make([]FleetClusterStatus, 0, len(clusterList.Items))
+	for i := range clusterList.Items {
+		cluster := &clusterList.Items[i]
+		key := clusterKey(cluster.Namespace, cluster.Name)
+		status := FleetClusterStatus{
+			Namespace:      cluster.Namespace,
+			Name:           cluster.Name,
+			Phase:          cluster.Status.Phase,
+			ClusterDef:     cluster.Spec.ClusterDefRef,
+			PendingOps:     pendingOps[key],
+			LastBackupTime: lastBackups[key],
+		}
+		capacity := clusterCapacity(cluster)
+		status.Replicas = capacity.replicas
+		status.ServiceVersions = capacity.serviceVersions
+		if q, ok := capacity.resources[corev1.ResourceCPU]; ok {
+			status.CPU = q.String()
+		}
+		if q, ok := capacity.resources[corev1.ResourceMemory]; ok {
+			status.Memory = q.String()
+		}
+		if q, ok := capacity.resources[corev1.ResourceStorage]; ok {
+			status.Storage = q.String()
+		}
+		clusters = append(clusters, status)
+
+		summary.Phases[cluster.Status.Phase]++
+		summary.PendingOps += status.PendingOps
+		for name, q := range capacity.resources {
+			total := summary.Capacity[name]
+			total.Add(q)
+			summary.Capacity[name] = total
+		}
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusterKey(clusters[i].Namespace, clusters[i].Name) < clusterKey(clusters[j].Namespace, clusters[j].Name)
+	})
+	return clusters, summary, nil
+}
+
+type fleetClusterCapacity struct {
+	replicas        int32
+	serviceVersions []string
+	resources       corev1.ResourceList
+}
+
+// clusterCapacity sums up the replicas and the requested resources of the components and shardings of the cluster.
+func clusterCapacity(cluster *appsv1alpha1.Cluster) fleetClusterCapacity {
+	capacity := fleetClusterCapacity{resources: corev1.ResourceList{}}
+	versions := sets.New[string]()
+	add := func(compSpec appsv1alpha1.ClusterComponentSpec, count int32) {
+		replicas := compSpec.Replicas * count
+		capacity.replicas += replicas
+		if compSpec.ServiceVersion != "" {
+			versions.Insert(compSpec.ServiceVersion)
+		}
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			if q, ok := compSpec.Resources.Requests[name]; ok {
+				addQuantity(capacity.resources, name, q, replicas)
+			}
+		}
+		for _, vct := range compSpec.VolumeClaimTemplates {
+			if q, ok := vct.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+				addQuantity(capacity.resources, corev1.ResourceStorage, q, replicas)
+			}
+		}
+	}
+	for _, compSpec := range cluster.Spec.ComponentSpecs {
+		add(compSpec, 1)
+	}
+	for _, shardingSpec := range cluster.Spec.ShardingSpecs {
+		add(shardingSpec.Template, shardingSpec.Shards)
+	}
+	capacity.serviceVersions = sets.List(versions)
+	return capacity
+}
+
+func addQuantity(resources corev1.ResourceList, name corev1.ResourceName, q resource.Quantity, times int32) {
+	total := resources[name]
+	total.Add(*resource.NewMilliQuantity(q.MilliValue()*int64(times), q.Format))
+	resources[name] = total
+}
+
+func (e *FleetStatusExporter) exportMetrics(clusters []FleetClusterStatus, summary *FleetSummary) {
+	fleetClusters.Reset()
+	for phase, count := range summary.Phases {
+		fleetClusters.WithLabelValues(string(phase)).Set(float64(count))
+	}
+	fleetClusterPendingOps.Reset()
+	fleetClusterLastBackupTimestamp.Reset()
+	for _, cluster := range clusters {
+		fleetClusterPendingOps.WithLabelValues(cluster.Namespace, cluster.Name).Set(float64(cluster.PendingOps))
+		if cluster.LastBackupTime != nil {
+			fleetClusterLastBackupTimestamp.WithLabelValues(cluster.Namespace, cluster.Name).Set(float64(cluster.LastBackupTime.Unix()))
+		}
+	}
+}
+
+func (e *FleetStatusExporter) exportConfigMap(ctx context.Context, clusters []FleetClusterStatus, summary *FleetSummary) error {
+	clustersJSON, err := json.Marshal(clusters)
+	if err != nil {
+		return err
+	}
+	if len(clustersJSON) > maxFleetStatusClustersSize {
+		summary.ClustersTrimmed = true
+		clustersJSON = []byte("[]")
+	}
+	summaryJSON, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	data := map[string]string{
+		fleetStatusClustersKey: string(clustersJSON),
+		fleetStatusSummaryKey:  string(summaryJSON),
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err = e.Client.Get(ctx, client.ObjectKey{Namespace: e.Namespace, Name: e.Name}, cm); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: e.Namespace,
+				Name:      e.Name,
+				Labels:    map[string]string{constant.AppManagedByLabelKey: constant.AppName},
+			},
+			Data: data,
+		}
+		return e.Client.Create(ctx, cm)
+	}
+	patch := client.MergeFrom(cm.DeepCopy())
+	cm.Data = data
+	return e.Client.Patch(ctx, cm, patch)
+}
@@ -0,0 +1,125 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+var _ = Describe("fleet status exporter", func() {
+	const (
+		namespace   = "default"
+		clusterName = "mycluster"
+	)
+
+	It("summarizes the clusters into the ConfigMap", func() {
+		cluster := &appsv1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: clusterName},
+			Spec: appsv1alpha1.ClusterSpec{
+				ComponentSpecs: []appsv1alpha1.ClusterComponentSpec{
+					{
+						Name:           "mysql",
+						Replicas:       3,
+						ServiceVersion: "8.0.30",
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("500m"),
+								corev1.ResourceMemory: resource.MustParse("1Gi"),
+							},
+						},
+					},
+				},
+			},
+			Status: appsv1alpha1.ClusterStatus{Phase: appsv1alpha1.RunningClusterPhase},
+		}
+		ops := &appsv1alpha1.OpsRequest{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "restart"},
+			Spec: appsv1alpha1.OpsRequestSpec{
+				ClusterName: clusterName,
+				Type:        appsv1alpha1.RestartType,
+			},
+			Status: appsv1alpha1.OpsRequestStatus{Phase: appsv1alpha1.OpsRunningPhase},
+		}
+		completedAt := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+		backup := &dpv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      "backup",
+				Labels:    map[string]string{constant.AppInstanceLabelKey: clusterName},
+			},
+			Status: dpv1alpha1.BackupStatus{
+				Phase:               dpv1alpha1.BackupPhaseCompleted,
+				CompletionTimestamp: &completedAt,
+			},
+		}
+
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+		Expect(appsv1alpha1.AddToScheme(scheme)).Should(Succeed())
+		Expect(dpv1alpha1.AddToScheme(scheme)).Should(Succeed())
+		cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, ops, backup).Build()
+		exporter := &FleetStatusExporter{
+			Client:    cli,
+			Interval:  time.Minute,
+			Namespace: namespace,
+			Name:      "kubeblocks-fleet-status",
+		}
+		Expect(exporter.Export(context.Background())).Should(Succeed())
+
+		cm := &corev1.ConfigMap{}
+		Expect(cli.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: exporter.Name}, cm)).Should(Succeed())
+		var clusters []FleetClusterStatus
+		Expect(json.Unmarshal([]byte(cm.Data[fleetStatusClustersKey]), &clusters)).Should(Succeed())
+		Expect(clusters).Should(HaveLen(1))
+		Expect(clusters[0].Phase).Should(Equal(appsv1alpha1.RunningClusterPhase))
+		Expect(clusters[0].ServiceVersions).Should(Equal([]string{"8.0.30"}))
+		Expect(clusters[0].PendingOps).Should(Equal(1))
+		Expect(clusters[0].LastBackupTime.Equal(&completedAt)).Should(BeTrue())
+		Expect(clusters[0].Replicas).Should(BeEquivalentTo(3))
+		Expect(clusters[0].CPU).Should(Equal("1500m"))
+		Expect(clusters[0].Memory).Should(Equal("3Gi"))
+
+		summary := &FleetSummary{}
+		Expect(json.Unmarshal([]byte(cm.Data[fleetStatusSummaryKey]), summary)).Should(Succeed())
+		Expect(summary.Clusters).Should(Equal(1))
+		Expect(summary.Phases[appsv1alpha1.RunningClusterPhase]).Should(Equal(1))
+		Expect(summary.PendingOps).Should(Equal(1))
+
+		By("export again to update the ConfigMap")
+		Expect(exporter.Export(context.Background())).Should(Succeed())
+	})
+})
@@ -0,0 +1,449 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	// nodeRebootCheckInterval is the interval to check the draining instances on the nodes which require a reboot.
+	nodeRebootCheckInterval = 30 * time.Second
+
+	// podNodeNameField is the field index of the pods by their nodes.
+	podNodeNameField = "spec.nodeName"
+
+	reasonNodeRebootCordon   = "NodeRebootCordon"
+	reasonNodeRebootEvict    = "NodeRebootEvict"
+	reasonNodeRebootInPlace  = "NodeRebootInPlace"
+	reasonNodeRebootSwitch   = "NodeRebootSwitchover"
+	reasonNodeRebootUncordon = "NodeRebootUncordon"
+)
+
+// nodeRebootState is the state of draining the instances off the node which requires a reboot.
+type nodeRebootState struct {
+	// the value of the reboot required annotation which is handled.
+	RequiredAt string `json:"requiredAt"`
+	// the boot ID of the node when the draining started, the node has been rebooted once it changes.
+	BootID string `json:"bootID"`
+	// whether the node is cordoned by KubeBlocks, it's uncordoned after the reboot then.
+	Cordoned bool `json:"cordoned,omitempty"`
+	// whether the node has been rebooted.
+	Rebooted bool `json:"rebooted,omitempty"`
+}
+
+// NodeRebootReconciler drains the instances off the nodes which require a reboot after the kernel or OS patching,
+// e.g. signaled by kured, in a role-aware order and within the disruption windows of the clusters.
+//
+// It cordons the node, switches over the writable instances and evicts the instances one by one per component.
+// The instances pinned to the node by their local volumes can't be moved, they are switched over and labeled with
+// apps.kubeblocks.io/node-reboot-in-place instead. The rebooter is expected to wait for the draining, e.g. kured with
+// --blocking-pod-selector=app.kubernetes.io/managed-by=kubeblocks,!apps.kubeblocks.io/node-reboot-in-place,
+// and the node is uncordoned after the reboot.
+type NodeRebootReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// the node annotation which signals that the node requires a reboot.
+	Annotation string
+}
+
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=core,resources=pods/eviction,verbs=create
+// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get
+// +kubebuilder:rbac:groups=core,resources=persistentvolumes,verbs=get
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=clusters,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsrequests,verbs=get;create;delete
+// +kubebuilder:rbac:groups=workloads.kubeblocks.io,resources=instancesets,verbs=get;list;watch
+
+// Reconcile drains the instances off the node which were created before the reboot is required.
+// For each component, at most one instance is evicted at a time, the followers are evicted before the leader,
+// and only if all the instances of the component are ready and the cluster is within its disruption windows.
+func (r *NodeRebootReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("node", req.Name),
+		Recorder: r.Recorder,
+	}
+
+	node := &corev1.Node{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, node); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	state, err := getNodeRebootState(node)
+	if err != nil {
+		reqCtx.Log.Info("invalid node reboot state of the node", "error", err.Error())
+	}
+	value, ok := node.Annotations[r.Annotation]
+	if !ok {
+		if state == nil {
+			return intctrlutil.Reconciled()
+		}
+		// the reboot is not required anymore, e.g. it's cancelled or done.
+		if err = r.finishReboot(reqCtx, node, state); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		return intctrlutil.Reconciled()
+	}
+	if state != nil && state.RequiredAt == value {
+		if state.Rebooted {
+			return intctrlutil.Reconciled()
+		}
+		if node.Status.NodeInfo.BootID != state.BootID {
+			if err = r.finishReboot(reqCtx, node, state); err != nil {
+				return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+			}
+			return intctrlutil.Reconciled()
+		}
+	}
+	requiredAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		reqCtx.Log.Info("invalid reboot required time of the node", "annotation", r.Annotation, "value", value)
+		return intctrlutil.Reconciled()
+	}
+
+	pods, err := r.affectedPods(reqCtx, node, requiredAt)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if len(pods) == 0 {
+		return intctrlutil.Reconciled()
+	}
+	if state == nil || state.RequiredAt != value {
+		// cordon the node first, so that the evicted instances are not scheduled back to it.
+		if err = r.cordon(reqCtx, node, value); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+	}
+
+	var wait time.Duration
+	for key, compPods := range groupPodsByComponent(pods) {
+		next, err := r.drainNextInstance(reqCtx, key, compPods)
+		if err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		if wait == 0 || (next > 0 && next < wait) {
+			wait = next
+		}
+	}
+	if wait <= 0 {
+		wait = nodeRebootCheckInterval
+	}
+	return intctrlutil.RequeueAfter(wait, reqCtx.Log, "wait for the instances to be drained")
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NodeRebootReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &corev1.Pod{}, podNodeNameField, func(obj client.Object) []string {
+		return []string{obj.(*corev1.Pod).Spec.NodeName}
+	}); err != nil {
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("node-reboot").
+		For(&corev1.Node{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			_, required := obj.GetAnnotations()[r.Annotation]
+			_, draining := obj.GetAnnotations()[constant.NodeRebootStateAnnotationKey]
+			return required || draining
+		}))).
+		Complete(r)
+}
+
+// affectedPods returns the pods managed by KubeBlocks on the node, which were created before the reboot is required.
+func (r *NodeRebootReconciler) affectedPods(reqCtx intctrlutil.RequestCtx, node *corev1.Node, requiredAt time.Time) ([]corev1.Pod, error) {
+	podList := &corev1.PodList{}
+	if err := r.Client.List(reqCtx.Ctx, podList,
+		client.MatchingFields{podNodeNameField: node.Name},
+		client.MatchingLabels{constant.AppManagedByLabelKey: constant.AppName}); err != nil {
+		return nil, err
+	}
+	var pods []corev1.Pod
+	for _, pod := range podList.Items {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		if pod.Labels[constant.AppInstanceLabelKey] == "" || pod.Labels[constant.KBAppComponentLabelKey] == "" {
+			continue
+		}
+		if !pod.CreationTimestamp.Time.Before(requiredAt) {
+			continue
+		}
+		pods = append(pods, pod)
+	}
+	return pods, nil
+}
+
+// cordon marks the node unschedulable and records the draining state on it.
+func (r *NodeRebootReconciler) cordon(reqCtx intctrlutil.RequestCtx, node *corev1.Node, requiredAt string) error {
+	state := nodeRebootState{
+		RequiredAt: requiredAt,
+		BootID:     node.Status.NodeInfo.BootID,
+		Cordoned:   !node.Spec.Unschedulable,
+	}
+	b, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	patch := client.MergeFrom(node.DeepCopy())
+	node.Spec.Unschedulable = true
+	if node.Annotations == nil {
+		node.Annotations = map[string]string{}
+	}
+	node.Annotations[constant.NodeRebootStateAnnotationKey] = string(b)
+	if err = r.Client.Patch(reqCtx.Ctx, node, patch); err != nil {
+		return err
+	}
+	r.Recorder.Eventf(node, corev1.EventTypeNormal, reasonNodeRebootCordon,
+		"cordoned the node to drain the instances of KubeBlocks before the reboot")
+	return nil
+}
+
+// finishReboot uncordons the node if it's cordoned by KubeBlocks, and releases the instances rebooted in place.
+func (r *NodeRebootReconciler) finishReboot(reqCtx intctrlutil.RequestCtx, node *corev1.Node, state *nodeRebootState) error {
+	podList := &corev1.PodList{}
+	if err := r.Client.List(reqCtx.Ctx, podList,
+		client.MatchingFields{podNodeNameField: node.Name},
+		client.HasLabels{constant.NodeRebootInPlaceLabelKey}); err != nil {
+		return err
+	}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		patch := client.MergeFrom(pod.DeepCopy())
+		delete(pod.Labels, constant.NodeRebootInPlaceLabelKey)
+		if err := r.Client.Patch(reqCtx.Ctx, pod, patch); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	uncordon := state.Cordoned
+	patch := client.MergeFrom(node.DeepCopy())
+	if uncordon {
+		node.Spec.Unschedulable = false
+	}
+	if _, ok := node.Annotations[r.Annotation]; ok {
+		// keep the state to not drain the node again for the same reboot.
+		state.Rebooted = true
+		state.Cordoned = false
+		b, err := json.Marshal(state)
+		if err != nil {
+			return err
+		}
+		node.Annotations[constant.NodeRebootStateAnnotationKey] = string(b)
+	} else {
+		delete(node.Annotations, constant.NodeRebootStateAnnotationKey)
+	}
+	if err := r.Client.Patch(reqCtx.Ctx, node, patch); err != nil {
+		return err
+	}
+	if uncordon {
+		r.Recorder.Eventf(node, corev1.EventTypeNormal, reasonNodeRebootUncordon, "uncordoned the node after the reboot")
+	}
+	return nil
+}
+
+// drainNextInstance drains the next instance of the component in the role-aware order, and returns the duration
+// to wait before checking the component again.
+func (r *NodeRebootReconciler) drainNextInstance(reqCtx intctrlutil.RequestCtx,
+	key types.NamespacedName, pods []corev1.Pod) (time.Duration, error) {
+	clusterName := pods[0].Labels[constant.AppInstanceLabelKey]
+	cluster := &appsv1alpha1.Cluster{}
+	if err := r.Client.Get(reqCtx.Ctx, types.NamespacedName{Namespace: key.Namespace, Name: clusterName}, cluster); err != nil {
+		return 0, client.IgnoreNotFound(err)
+	}
+	if open, wait := intctrlutil.InDisruptionWindows(intctrlutil.GetClusterDisruptionWindows(cluster), time.Now()); !open {
+		return wait, nil
+	}
+
+	its := &workloads.InstanceSet{}
+	if err := r.Client.Get(reqCtx.Ctx, key, its); err != nil {
+		return 0, client.IgnoreNotFound(err)
+	}
+	// drain the instances one by one, wait for the previous one to be ready on another node.
+	if !instanceset.IsInstanceSetReady(its) {
+		return nodeRebootCheckInterval, nil
+	}
+
+	instanceset.SortPods(pods, instanceset.ComposeRolePriorityMap(its.Spec.Roles), false)
+	for i := range pods {
+		pod := &pods[i]
+		if _, ok := pod.Labels[constant.NodeRebootInPlaceLabelKey]; ok {
+			continue
+		}
+		switched, err := r.switchover(reqCtx, cluster, pod)
+		if err != nil || !switched {
+			return nodeRebootCheckInterval, err
+		}
+		pinned, err := r.isPinnedToNode(reqCtx.Ctx, pod)
+		if err != nil {
+			return 0, err
+		}
+		if pinned {
+			if err = r.markInPlace(reqCtx, cluster, pod); err != nil {
+				return 0, err
+			}
+			continue
+		}
+		eviction := &policyv1.Eviction{ObjectMeta: metav1.ObjectMeta{Namespace: pod.Namespace, Name: pod.Name}}
+		if err = r.Client.SubResource("eviction").Create(reqCtx.Ctx, pod, eviction); err != nil {
+			if apierrors.IsTooManyRequests(err) {
+				// the eviction is blocked by the PodDisruptionBudget or the eviction webhook, retry later.
+				reqCtx.Log.Info("the eviction of the instance is blocked", "pod", pod.Name, "error", err.Error())
+				return nodeRebootCheckInterval, nil
+			}
+			return 0, client.IgnoreNotFound(err)
+		}
+		r.Recorder.Eventf(cluster, corev1.EventTypeNormal, reasonNodeRebootEvict,
+			"evicted instance %s from node %s which requires a reboot", pod.Name, pod.Spec.NodeName)
+		return nodeRebootCheckInterval, nil
+	}
+	return 0, nil
+}
+
+// switchover hands over the writable role of the instance to another one before it's drained, by the same OpsRequest
+// as the eviction webhook. It returns whether the instance is not writable, or there is no other instance to hand over to.
+func (r *NodeRebootReconciler) switchover(reqCtx intctrlutil.RequestCtx, cluster *appsv1alpha1.Cluster, pod *corev1.Pod) (bool, error) {
+	if pod.Labels[constant.AccessModeLabelKey] != string(appsv1alpha1.ReadWrite) {
+		return true, nil
+	}
+	compName := pod.Labels[constant.KBAppComponentLabelKey]
+	// the switchover OpsRequest does not support the components of shardings yet.
+	compSpec := cluster.Spec.GetComponentByName(compName)
+	if compSpec == nil || compSpec.Replicas < 2 {
+		return true, nil
+	}
+
+	opsKey := evictionSwitchoverOpsKey(pod)
+	opsRequest := &appsv1alpha1.OpsRequest{}
+	if err := r.Client.Get(reqCtx.Ctx, opsKey, opsRequest); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, err
+		}
+		if err = r.Client.Create(reqCtx.Ctx, buildEvictionSwitchoverOps(opsKey, cluster.Name, compName)); err != nil && !apierrors.IsAlreadyExists(err) {
+			return false, err
+		}
+		r.Recorder.Eventf(cluster, corev1.EventTypeNormal, reasonNodeRebootSwitch,
+			"switching over the writable instance %s by OpsRequest %s before the node reboot", pod.Name, opsKey.Name)
+		return false, nil
+	}
+	switch opsRequest.Status.Phase {
+	case appsv1alpha1.OpsSucceedPhase:
+		// the instance takes the writable role again after the last switchover, start over.
+		return false, client.IgnoreNotFound(r.Client.Delete(reqCtx.Ctx, opsRequest))
+	case appsv1alpha1.OpsFailedPhase, appsv1alpha1.OpsAbortedPhase, appsv1alpha1.OpsCancelledPhase:
+		r.Recorder.Eventf(cluster, corev1.EventTypeWarning, reasonNodeRebootSwitch,
+			"the switchover OpsRequest %s of the instance %s is %s, delete it to retry the switchover", opsKey.Name, pod.Name, opsRequest.Status.Phase)
+	}
+	return false, nil
+}
+
+// isPinnedToNode checks whether the instance is bound to the node by its local volumes, which can't be moved off the node.
+func (r *NodeRebootReconciler) isPinnedToNode(ctx context.Context, pod *corev1.Pod) (bool, error) {
+	for _, vol := range pod.Spec.Volumes {
+		if vol.HostPath != nil {
+			return true, nil
+		}
+		if vol.PersistentVolumeClaim == nil {
+			continue
+		}
+		pvc := &corev1.PersistentVolumeClaim{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: vol.PersistentVolumeClaim.ClaimName}, pvc); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return false, err
+		}
+		if pvc.Spec.VolumeName == "" {
+			continue
+		}
+		pv := &corev1.PersistentVolume{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: pvc.Spec.VolumeName}, pv); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return false, err
+		}
+		if pv.Spec.Local != nil || pv.Spec.HostPath != nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// markInPlace labels the instance pinned to the node, which is not drained but rebooted with the node.
+func (r *NodeRebootReconciler) markInPlace(reqCtx intctrlutil.RequestCtx, cluster *appsv1alpha1.Cluster, pod *corev1.Pod) error {
+	patch := client.MergeFrom(pod.DeepCopy())
+	pod.Labels[constant.NodeRebootInPlaceLabelKey] = "true"
+	if err := r.Client.Patch(reqCtx.Ctx, pod, patch); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	r.Recorder.Eventf(cluster, corev1.EventTypeWarning, reasonNodeRebootInPlace,
+		"instance %s is pinned to node %s by its local volumes, it will be rebooted with the node", pod.Name, pod.Spec.NodeName)
+	return nil
+}
+
+// getNodeRebootState returns the draining state recorded on the node, or nil if not draining.
+func getNodeRebootState(node *corev1.Node) (*nodeRebootState, error) {
+	value, ok := node.Annotations[constant.NodeRebootStateAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	state := &nodeRebootState{}
+	if err := json.Unmarshal([]byte(value), state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// groupPodsByComponent groups the pods by the InstanceSets of their components.
+func groupPodsByComponent(pods []corev1.Pod) map[types.NamespacedName][]corev1.Pod {
+	groups := map[types.NamespacedName][]corev1.Pod{}
+	for _, pod := range pods {
+		key := types.NamespacedName{
+			Namespace: pod.Namespace,
+			Name: constant.GenerateClusterComponentName(pod.Labels[constant.AppInstanceLabelKey],
+				pod.Labels[constant.KBAppComponentLabelKey]),
+		}
+		groups[key] = append(groups[key], pod)
+	}
+	return groups
+}
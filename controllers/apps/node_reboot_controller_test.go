@@ -0,0 +1,196 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+var _ = Describe("node reboot", func() {
+	const (
+		annotation  = "weave.works/kured-most-recent-reboot-needed"
+		namespace   = "default"
+		clusterName = "mycluster"
+		compName    = "mysql"
+		nodeName    = "node-0"
+	)
+
+	newPod := func(name, role string, created time.Time) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         namespace,
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(created),
+				Labels: map[string]string{
+					constant.AppManagedByLabelKey:   constant.AppName,
+					constant.AppInstanceLabelKey:    clusterName,
+					constant.KBAppComponentLabelKey: compName,
+					constant.RoleLabelKey:           role,
+					constant.AccessModeLabelKey:     string(appsv1alpha1.Readonly),
+				},
+			},
+			Spec: corev1.PodSpec{NodeName: nodeName},
+		}
+	}
+
+	It("drains the instances off the node in the role-aware order", func() {
+		requiredAt := time.Now().Add(-time.Hour)
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        nodeName,
+				Annotations: map[string]string{annotation: requiredAt.Format(time.RFC3339)},
+			},
+			Status: corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{BootID: "boot-0"}},
+		}
+		cluster := &appsv1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: clusterName},
+			Spec: appsv1alpha1.ClusterSpec{
+				ComponentSpecs: []appsv1alpha1.ClusterComponentSpec{{Name: compName, Replicas: 4}},
+			},
+		}
+		its := &workloads.InstanceSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      constant.GenerateClusterComponentName(clusterName, compName),
+			},
+			Spec: workloads.InstanceSetSpec{
+				Replicas: pointer.Int32(4),
+				Roles: []workloads.ReplicaRole{
+					{Name: "leader", IsLeader: true, CanVote: true, AccessMode: workloads.ReadWriteMode},
+					{Name: "follower", CanVote: true, AccessMode: workloads.ReadonlyMode},
+				},
+			},
+			Status: workloads.InstanceSetStatus{
+				Replicas:        4,
+				ReadyReplicas:   4,
+				UpdatedReplicas: 4,
+			},
+		}
+		leader := newPod(its.Name+"-0", "leader", requiredAt.Add(-time.Hour))
+		leader.Labels[constant.AccessModeLabelKey] = string(appsv1alpha1.ReadWrite)
+		follower := newPod(its.Name+"-1", "follower", requiredAt.Add(-time.Hour))
+		// the instance created after the reboot is required is not affected
+		restarted := newPod(its.Name+"-2", "follower", requiredAt.Add(time.Minute))
+		// the instance on the local volume can't be moved off the node
+		pinned := newPod(its.Name+"-3", "follower", requiredAt.Add(-time.Hour))
+		pinned.Spec.Volumes = []corev1.Volume{{
+			Name: "data",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "data-" + pinned.Name},
+			},
+		}}
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "data-" + pinned.Name},
+			Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: "local-pv"},
+		}
+		pv := &corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "local-pv"},
+			Spec: corev1.PersistentVolumeSpec{
+				PersistentVolumeSource: corev1.PersistentVolumeSource{Local: &corev1.LocalVolumeSource{Path: "/data"}},
+			},
+		}
+
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+		Expect(appsv1alpha1.AddToScheme(scheme)).Should(Succeed())
+		Expect(workloads.AddToScheme(scheme)).Should(Succeed())
+		cli := fake.NewClientBuilder().WithScheme(scheme).
+			WithIndex(&corev1.Pod{}, podNodeNameField, func(obj client.Object) []string {
+				return []string{obj.(*corev1.Pod).Spec.NodeName}
+			}).
+			WithObjects(node, cluster, its, leader, follower, restarted, pinned, pvc, pv).Build()
+		reconciler := &NodeRebootReconciler{
+			Client:     cli,
+			Scheme:     scheme,
+			Recorder:   record.NewFakeRecorder(100),
+			Annotation: annotation,
+		}
+
+		ctx := context.Background()
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: nodeName}}
+		res, err := reconciler.Reconcile(ctx, req)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(res.RequeueAfter).Should(Equal(nodeRebootCheckInterval))
+
+		// the node is cordoned and the follower is evicted first
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(node), node)).Should(Succeed())
+		Expect(node.Spec.Unschedulable).Should(BeTrue())
+		Expect(node.Annotations).Should(HaveKey(constant.NodeRebootStateAnnotationKey))
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(follower), &corev1.Pod{})).ShouldNot(Succeed())
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(leader), &corev1.Pod{})).Should(Succeed())
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(restarted), &corev1.Pod{})).Should(Succeed())
+
+		// the pinned instance is rebooted in place, and the leader is switched over before the eviction
+		_, err = reconciler.Reconcile(ctx, req)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(pinned), pinned)).Should(Succeed())
+		Expect(pinned.Labels).Should(HaveKey(constant.NodeRebootInPlaceLabelKey))
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(leader), leader)).Should(Succeed())
+		ops := &appsv1alpha1.OpsRequest{}
+		Expect(cli.Get(ctx, evictionSwitchoverOpsKey(leader), ops)).Should(Succeed())
+		Expect(ops.Spec.Type).Should(Equal(appsv1alpha1.SwitchoverType))
+
+		// the leader is evicted after it hands over the writable role
+		leader.Labels[constant.RoleLabelKey] = "follower"
+		leader.Labels[constant.AccessModeLabelKey] = string(appsv1alpha1.Readonly)
+		Expect(cli.Update(ctx, leader)).Should(Succeed())
+		_, err = reconciler.Reconcile(ctx, req)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(leader), &corev1.Pod{})).ShouldNot(Succeed())
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(pinned), &corev1.Pod{})).Should(Succeed())
+
+		// the node is uncordoned after the reboot
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(node), node)).Should(Succeed())
+		node.Status.NodeInfo.BootID = "boot-1"
+		Expect(cli.Update(ctx, node)).Should(Succeed())
+		_, err = reconciler.Reconcile(ctx, req)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(node), node)).Should(Succeed())
+		Expect(node.Spec.Unschedulable).Should(BeFalse())
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(pinned), pinned)).Should(Succeed())
+		Expect(pinned.Labels).ShouldNot(HaveKey(constant.NodeRebootInPlaceLabelKey))
+
+		// the node is not drained again for the same reboot
+		res, err = reconciler.Reconcile(ctx, req)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(res.RequeueAfter).Should(BeZero())
+		Expect(cli.Get(ctx, client.ObjectKeyFromObject(node), node)).Should(Succeed())
+		Expect(node.Spec.Unschedulable).Should(BeFalse())
+	})
+})
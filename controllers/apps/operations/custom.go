@@ -78,6 +78,7 @@ func (c CustomOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli cli
 		completedActionCount int
 		compFailedCount      int
 		compCompleteCount    int
+		requeueAfter         time.Duration
 	)
 	// TODO: support Parallelism
 	for _, v := range customSpec.CustomOpsComponents {
@@ -103,14 +104,18 @@ func (c CustomOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli cli
 			}
 		}
 		completedActionCount += workflowStatus.CompletedCount
+		if workflowStatus.RequeueAfter > 0 && (requeueAfter == 0 || workflowStatus.RequeueAfter < requeueAfter) {
+			requeueAfter = workflowStatus.RequeueAfter
+		}
 	}
 	// sync progress
-	if err := syncProgressToOpsRequest(reqCtx, cli, opsRes, oldOpsRequest, completedActionCount, compCount*len(opsRes.OpsDef.Spec.Actions)); err != nil {
+	flushAfter, err := syncProgressToOpsRequest(reqCtx, cli, opsRes, oldOpsRequest, completedActionCount, compCount*len(opsRes.OpsDef.Spec.Actions))
+	if err != nil {
 		return opsRequestPhase, 0, err
 	}
 	// check if the ops has been finished.
 	if compCompleteCount != compCount {
-		return opsRequestPhase, 0, nil
+		return opsRequestPhase, requeueForStatusFlush(requeueAfter, flushAfter), nil
 	}
 	if compFailedCount == 0 {
 		return appsv1alpha1.OpsSucceedPhase, 0, nil
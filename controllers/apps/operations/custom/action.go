@@ -21,6 +21,7 @@ package custom
 
 import (
 	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -44,6 +45,9 @@ type ActionStatus struct {
 	ExistFailure bool
 	// return the action tasks(required).
 	ActionTasks []appsv1alpha1.ActionTask
+	// the duration after which the action status should be checked again, for the actions which
+	// are not driven by the events of the workloads.
+	RequeueAfter time.Duration
 }
 
 func NewActiontatus() *ActionStatus {
@@ -56,6 +60,8 @@ type ActionContext struct {
 	ReqCtx intctrlutil.RequestCtx
 	Client client.Client
 	Action *appsv1alpha1.OpsAction
+	// the outputs of the previous actions.
+	Outputs map[string]string
 }
 
 func (actionCtx ActionContext) createActionK8sWorkload(
@@ -142,6 +148,7 @@ func (actionCtx ActionContext) checkPodTaskStatus(
 		switch pod.Status.Phase {
 		case corev1.PodSucceeded:
 			completed = true
+			task.Outputs = getPodOutputs(pod, actionCtx.Action.Outputs)
 		case corev1.PodFailed:
 			if task.Retries < backOffLimit {
 				task.Retries += 1
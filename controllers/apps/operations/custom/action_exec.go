@@ -29,6 +29,9 @@ import (
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
+// execOutputScript runs the command of the arguments, and copies its output into the termination message.
+const execOutputScript = `"$@" > /dev/termination-log; rc=$?; cat /dev/termination-log; exit $rc`
+
 type ExecAction struct {
 	OpsRequest     *appsv1alpha1.OpsRequest
 	Cluster        *appsv1alpha1.Cluster
@@ -146,6 +149,8 @@ func (e *ExecAction) buildExecPodSpec(actionCtx ActionContext,
 	if err != nil {
 		return nil, err
 	}
+	// inject the outputs of the previous actions.
+	env = append(env, buildOutputEnvs(actionCtx.Outputs)...)
 	execAction := actionCtx.Action.Exec
 	containerName := execAction.ContainerName
 	if containerName == "" {
@@ -167,6 +172,11 @@ func (e *ExecAction) buildExecPodSpec(actionCtx ActionContext,
 			"--",
 		}, execAction.Command...),
 	}
+	if len(actionCtx.Action.Outputs) > 0 {
+		// write the output of the command into the termination message to report the outputs.
+		container.Args = append([]string{"-c", execOutputScript, "sh", "kubectl"}, container.Args...)
+		container.Command = []string{"sh"}
+	}
 	intctrlutil.InjectZeroResourcesLimitsIfEmpty(container)
 	return &corev1.PodSpec{
 		Containers: []corev1.Container{*container},
@@ -0,0 +1,157 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package custom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	kbagent "github.com/apecloud/kubeblocks/pkg/kb_agent/client"
+)
+
+// kbAgentRetryInterval is the interval to retry the failed kb-agent action.
+const kbAgentRetryInterval = 5 * time.Second
+
+type KBAgentAction struct {
+	OpsRequest     *appsv1alpha1.OpsRequest
+	Cluster        *appsv1alpha1.Cluster
+	OpsDef         *appsv1alpha1.OpsDefinition
+	CustomCompOps  *appsv1alpha1.CustomOpsComponent
+	Comp           *appsv1alpha1.ClusterComponentSpec
+	progressDetail appsv1alpha1.ProgressStatusDetail
+}
+
+func NewKBAgentAction(opsRequest *appsv1alpha1.OpsRequest,
+	cluster *appsv1alpha1.Cluster,
+	opsDef *appsv1alpha1.OpsDefinition,
+	customCompOps *appsv1alpha1.CustomOpsComponent,
+	comp *appsv1alpha1.ClusterComponentSpec,
+	progressDetail appsv1alpha1.ProgressStatusDetail) *KBAgentAction {
+	return &KBAgentAction{
+		OpsRequest:     opsRequest,
+		Cluster:        cluster,
+		OpsDef:         opsDef,
+		CustomCompOps:  customCompOps,
+		Comp:           comp,
+		progressDetail: progressDetail,
+	}
+}
+
+func (k *KBAgentAction) Execute(actionCtx ActionContext) (*ActionStatus, error) {
+	if actionCtx.Action.KBAgent == nil {
+		return nil, nil
+	}
+	podInfoExtractorName := actionCtx.Action.KBAgent.PodInfoExtractorName
+	podInfoExtractor := getTargetPodInfoExtractor(k.OpsDef, podInfoExtractorName)
+	if podInfoExtractor == nil {
+		return nil, intctrlutil.NewFatalError("can not found the podInfoExtractor: " + podInfoExtractorName)
+	}
+	targetPods, err := getTargetPods(actionCtx.ReqCtx.Ctx, actionCtx.Client, k.Cluster, podInfoExtractor.PodSelector, k.CustomCompOps.ComponentName)
+	if err != nil {
+		return nil, err
+	}
+	actionStatus := NewActiontatus()
+	for i := range targetPods {
+		// the actions are invoked when checking the status of the tasks.
+		actionStatus.ActionTasks = append(actionStatus.ActionTasks, appsv1alpha1.ActionTask{
+			Namespace:     targetPods[i].Namespace,
+			ObjectKey:     fmt.Sprintf("%s/%s", constant.PodKind, targetPods[i].Name),
+			TargetPodName: targetPods[i].Name,
+			Status:        appsv1alpha1.ProcessingActionTaskStatus,
+		})
+	}
+	// check the tasks at once.
+	actionStatus.RequeueAfter = time.Second
+	return actionStatus, nil
+}
+
+func (k *KBAgentAction) CheckStatus(actionCtx ActionContext) (*ActionStatus, error) {
+	actionStatus, err := actionCtx.checkActionStatus(k.progressDetail, k.invokeAction)
+	if err != nil {
+		return nil, err
+	}
+	if !actionStatus.IsCompleted {
+		actionStatus.RequeueAfter = kbAgentRetryInterval
+	}
+	return actionStatus, nil
+}
+
+// invokeAction invokes the action on the target pod of the task, the failed action is retried until
+// the backoffLimit is reached.
+func (k *KBAgentAction) invokeAction(actionCtx ActionContext, task *appsv1alpha1.ActionTask, _ int) (bool, bool, error) {
+	switch task.Status {
+	case appsv1alpha1.FailedActionTaskStatus:
+		return true, true, nil
+	case appsv1alpha1.SucceedActionTaskStatus:
+		return true, false, nil
+	}
+	pod := &corev1.Pod{}
+	if err := actionCtx.Client.Get(actionCtx.ReqCtx.Ctx, client.ObjectKey{Name: task.TargetPodName, Namespace: task.Namespace}, pod); err != nil {
+		return false, false, err
+	}
+	agentCli, err := kbagent.NewClient(*pod)
+	if err != nil {
+		return false, false, err
+	}
+	if intctrlutil.IsNil(agentCli) {
+		return false, false, intctrlutil.NewFatalError(fmt.Sprintf(`the instance "%s" doesn't run the kb-agent to invoke the action "%s"`,
+			pod.Name, actionCtx.Action.KBAgent.ActionName))
+	}
+	parameters := map[string]any{}
+	for name, value := range buildActionVars(actionCtx, k.CustomCompOps) {
+		parameters[name] = value
+	}
+	var output string
+	invocationKey := fmt.Sprintf("%s.%s.%s", k.OpsRequest.UID, actionCtx.Action.Name, pod.Name)
+	handedOff, err := intctrlutil.InvokeNonIdempotent(actionCtx.ReqCtx.Ctx, actionCtx.Client, invocationKey, func(ctx context.Context) error {
+		var invokeErr error
+		output, invokeErr = agentCli.Action(ctx, actionCtx.Action.KBAgent.ActionName, parameters)
+		return invokeErr
+	})
+	if handedOff {
+		// the action may be not idempotent, it's not invoked again if the outcome is unknown.
+		actionCtx.ReqCtx.Log.Info("the kb-agent action was interrupted by the shutdown of the operator, the outcome is unknown",
+			"action", actionCtx.Action.KBAgent.ActionName, "pod", pod.Name)
+		return true, true, nil
+	}
+	if err != nil {
+		if !errors.Is(err, kbagent.ErrActionFailed) {
+			return false, false, err
+		}
+		actionCtx.ReqCtx.Log.Info("the kb-agent action failed", "action", actionCtx.Action.KBAgent.ActionName,
+			"pod", pod.Name, "retries", task.Retries, "error", err.Error())
+		// the action killed or refused by its sandbox fails again if retried as it is.
+		if !errors.Is(err, kbagent.ErrActionSandboxViolated) && task.Retries < actionCtx.Action.KBAgent.BackoffLimit {
+			task.Retries += 1
+			return false, false, nil
+		}
+		return true, true, nil
+	}
+	task.Outputs = parseOutputs(output, actionCtx.Action.Outputs)
+	return true, false, nil
+}
@@ -0,0 +1,158 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package custom
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+type WaitForConditionAction struct {
+	OpsRequest     *appsv1alpha1.OpsRequest
+	Cluster        *appsv1alpha1.Cluster
+	CustomCompOps  *appsv1alpha1.CustomOpsComponent
+	progressDetail appsv1alpha1.ProgressStatusDetail
+}
+
+func NewWaitForConditionAction(opsRequest *appsv1alpha1.OpsRequest,
+	cluster *appsv1alpha1.Cluster,
+	customCompOps *appsv1alpha1.CustomOpsComponent,
+	progressDetail appsv1alpha1.ProgressStatusDetail) *WaitForConditionAction {
+	return &WaitForConditionAction{
+		OpsRequest:     opsRequest,
+		Cluster:        cluster,
+		CustomCompOps:  customCompOps,
+		progressDetail: progressDetail,
+	}
+}
+
+func (w *WaitForConditionAction) Execute(actionCtx ActionContext) (*ActionStatus, error) {
+	if actionCtx.Action.WaitForCondition == nil {
+		return nil, nil
+	}
+	gk, name := w.getResource(actionCtx)
+	actionStatus := NewActiontatus()
+	actionStatus.ActionTasks = append(actionStatus.ActionTasks, appsv1alpha1.ActionTask{
+		Namespace: w.OpsRequest.Namespace,
+		ObjectKey: fmt.Sprintf("%s/%s", gk.String(), name),
+		Status:    appsv1alpha1.ProcessingActionTaskStatus,
+	})
+	actionStatus.RequeueAfter = w.period(actionCtx)
+	return actionStatus, nil
+}
+
+func (w *WaitForConditionAction) CheckStatus(actionCtx ActionContext) (*ActionStatus, error) {
+	actionStatus, err := actionCtx.checkActionStatus(w.progressDetail, w.checkCondition)
+	if err != nil {
+		return nil, err
+	}
+	if !actionStatus.IsCompleted {
+		actionStatus.RequeueAfter = w.period(actionCtx)
+	}
+	return actionStatus, nil
+}
+
+func (w *WaitForConditionAction) period(actionCtx ActionContext) time.Duration {
+	if periodSeconds := actionCtx.Action.WaitForCondition.PeriodSeconds; periodSeconds > 0 {
+		return time.Duration(periodSeconds) * time.Second
+	}
+	return 5 * time.Second
+}
+
+// getResource gets the group kind and the name of the object to wait for.
+func (w *WaitForConditionAction) getResource(actionCtx ActionContext) (schema.GroupKind, string) {
+	resource := actionCtx.Action.WaitForCondition.Resource
+	if resource != nil {
+		var group string
+		if resource.APIGroup != nil {
+			group = *resource.APIGroup
+		}
+		return schema.GroupKind{Group: group, Kind: resource.Kind}, replaceVars(resource.Name, buildActionVars(actionCtx, w.CustomCompOps))
+	}
+	if w.Cluster.Spec.GetShardingByName(w.CustomCompOps.ComponentName) != nil {
+		return schema.GroupKind{Group: appsv1alpha1.GroupVersion.Group, Kind: appsv1alpha1.ClusterKind}, w.Cluster.Name
+	}
+	return schema.GroupKind{Group: appsv1alpha1.GroupVersion.Group, Kind: appsv1alpha1.ComponentKind},
+		constant.GenerateClusterComponentName(w.Cluster.Name, w.CustomCompOps.ComponentName)
+}
+
+// checkCondition evaluates the match expressions with the object.
+func (w *WaitForConditionAction) checkCondition(actionCtx ActionContext, task *appsv1alpha1.ActionTask, _ int) (bool, bool, error) {
+	switch task.Status {
+	case appsv1alpha1.FailedActionTaskStatus:
+		return true, true, nil
+	case appsv1alpha1.SucceedActionTaskStatus:
+		return true, false, nil
+	}
+	gk, name := w.getResource(actionCtx)
+	mapping, err := actionCtx.Client.RESTMapper().RESTMapping(gk)
+	if err != nil {
+		return false, false, intctrlutil.NewFatalError(fmt.Sprintf(`unknown resource "%s": %s`, gk.String(), err.Error()))
+	}
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(mapping.GroupVersionKind)
+	if err = actionCtx.Client.Get(actionCtx.ReqCtx.Ctx, client.ObjectKey{Name: name, Namespace: task.Namespace}, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			// wait for the object to be created.
+			return false, false, nil
+		}
+		return false, false, err
+	}
+	vars := buildActionVars(actionCtx, w.CustomCompOps)
+	matchExpressions := actionCtx.Action.WaitForCondition.MatchExpressions
+	if matchExpressions.Failure != "" {
+		failed, err := evaluateExpression(replaceVars(matchExpressions.Failure, vars), obj)
+		if err != nil {
+			return false, false, err
+		}
+		if failed {
+			return true, true, nil
+		}
+	}
+	succeed, err := evaluateExpression(replaceVars(matchExpressions.Success, vars), obj)
+	if err != nil {
+		return false, false, err
+	}
+	return succeed, false, nil
+}
+
+// evaluateExpression evaluates the Go template expression with the object, it returns true if the result is "true".
+func evaluateExpression(expression string, obj *unstructured.Unstructured) (bool, error) {
+	tmpl, err := template.New("matchExpression").Parse(expression)
+	if err != nil {
+		return false, intctrlutil.NewFatalError(fmt.Sprintf(`invalid expression "%s": %s`, expression, err.Error()))
+	}
+	var buf strings.Builder
+	if err = tmpl.Execute(&buf, obj.Object); err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(buf.String()) == "true", nil
+}
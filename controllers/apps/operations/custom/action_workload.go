@@ -83,7 +83,7 @@ func (w *WorkloadAction) Execute(actionCtx ActionContext) (*ActionStatus, error)
 		}
 		var targetPodName string
 		if targetPod != nil {
-			targetPodName = ""
+			targetPodName = targetPod.Name
 		}
 		return w.createWorkload(actionCtx, podSpec, targetPodName, index)
 	}
@@ -150,6 +150,8 @@ func (w *WorkloadAction) buildPodSpec(actionCtx ActionContext,
 	if err != nil {
 		return nil, err
 	}
+	// inject the outputs of the previous actions.
+	env = append(env, buildOutputEnvs(actionCtx.Outputs)...)
 	if podInfoExtractor != nil {
 		// mount the target pod's volumes.
 		for _, volumeMount := range podInfoExtractor.VolumeMounts {
@@ -28,13 +28,17 @@ import (
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/util/jsonpath"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/common"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
@@ -344,27 +348,88 @@ func getTargetPods(
 	if err != nil {
 		return nil, err
 	}
+	if podSelector.Ordinals != nil {
+		if pods, err = filterPodsByOrdinals(pods, *podSelector.Ordinals); err != nil {
+			return nil, err
+		}
+	}
 	if len(pods) == 0 {
 		return nil, intctrlutil.NewFatalError("can not find any pod which matches the podSelector for the component " + compName)
 	}
-	sort.Sort(intctrlutil.ByPodName(func() []corev1.Pod {
-		l := make([]corev1.Pod, 0)
+	sortPodsByOrdinal(pods)
+	if podSelector.MultiPodSelectionPolicy == appsv1alpha1.Any {
+		// Preferably select available pod.
 		for i := range pods {
-			l = append(l, *pods[i])
+			if intctrlutil.IsAvailable(pods[i], 0) {
+				return []*corev1.Pod{pods[i]}, nil
+			}
 		}
-		return l
-	}()))
+		return pods[0:1], nil
+	}
+	if podSelector.Sample != nil {
+		return samplePods(pods, *podSelector.Sample)
+	}
+	return pods, nil
+}
+
+// sortPodsByOrdinal sorts the pods by their ordinals, and by their names if the ordinals are equal.
+func sortPodsByOrdinal(pods []*corev1.Pod) {
+	sort.SliceStable(pods, func(i, j int) bool {
+		_, ordinal1 := instanceset.ParseParentNameAndOrdinal(pods[i].Name)
+		_, ordinal2 := instanceset.ParseParentNameAndOrdinal(pods[j].Name)
+		if ordinal1 != ordinal2 {
+			return ordinal1 < ordinal2
+		}
+		return pods[i].Name < pods[j].Name
+	})
+}
+
+// filterPodsByOrdinals filters the pods whose ordinal is within the ordinals.
+func filterPodsByOrdinals(pods []*corev1.Pod, ordinals workloads.Ordinals) ([]*corev1.Pod, error) {
+	ordinalList, err := instanceset.ConvertOrdinalsToSortedList(ordinals)
+	if err != nil {
+		return nil, intctrlutil.NewFatalError(err.Error())
+	}
+	ordinalSet := sets.New(ordinalList...)
 	var targetPods []*corev1.Pod
 	for i := range pods {
-		pod := pods[i]
-		targetPods = append(targetPods, pod)
-		// Preferably select available pod.
-		if podSelector.MultiPodSelectionPolicy == appsv1alpha1.Any && intctrlutil.IsAvailable(pod, 0) {
-			return []*corev1.Pod{pod}, nil
+		_, ordinal := instanceset.ParseParentNameAndOrdinal(pods[i].Name)
+		if ordinal >= 0 && ordinalSet.Has(int32(ordinal)) {
+			targetPods = append(targetPods, pods[i])
 		}
 	}
-	if podSelector.MultiPodSelectionPolicy == appsv1alpha1.Any {
-		return targetPods[0:1], nil
+	return targetPods, nil
+}
+
+// samplePods selects the number or percentage of the pods, the available pods are preferred.
+// the pods should be sorted by ordinal, and the selected pods keep the order.
+func samplePods(pods []*corev1.Pod, sample intstr.IntOrString) ([]*corev1.Pod, error) {
+	count, err := intstr.GetScaledValueFromIntOrPercent(&sample, len(pods), true)
+	if err != nil {
+		return nil, intctrlutil.NewFatalError(fmt.Sprintf("invalid sample %s of the podSelector: %s", sample.String(), err.Error()))
+	}
+	if count < 1 {
+		count = 1
+	}
+	if count >= len(pods) {
+		return pods, nil
+	}
+	selected := sets.New[string]()
+	for i := range pods {
+		if selected.Len() < count && intctrlutil.IsAvailable(pods[i], 0) {
+			selected.Insert(pods[i].Name)
+		}
+	}
+	for i := range pods {
+		if selected.Len() < count {
+			selected.Insert(pods[i].Name)
+		}
+	}
+	var targetPods []*corev1.Pod
+	for i := range pods {
+		if selected.Has(pods[i].Name) {
+			targetPods = append(targetPods, pods[i])
+		}
 	}
 	return targetPods, nil
 }
@@ -384,3 +449,70 @@ func getNameFromObjectKey(objectKey string) string {
 	}
 	return objectKey
 }
+
+// parseOutputs parses the declared output variables from the `KEY=VALUE` lines of the message.
+func parseOutputs(message string, names []string) map[string]string {
+	if len(names) == 0 {
+		return nil
+	}
+	declared := sets.New(names...)
+	outputs := map[string]string{}
+	for _, line := range strings.Split(message, "\n") {
+		key, value, found := strings.Cut(strings.TrimSpace(line), "=")
+		if !found || !declared.Has(key) {
+			continue
+		}
+		outputs[key] = value
+	}
+	if len(outputs) == 0 {
+		return nil
+	}
+	return outputs
+}
+
+// getPodOutputs gets the declared output variables from the termination messages of the containers.
+func getPodOutputs(pod *corev1.Pod, names []string) map[string]string {
+	if len(names) == 0 {
+		return nil
+	}
+	var messages []string
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Terminated != nil && status.State.Terminated.Message != "" {
+			messages = append(messages, status.State.Terminated.Message)
+		}
+	}
+	return parseOutputs(strings.Join(messages, "\n"), names)
+}
+
+// buildOutputEnvs builds the env vars of the outputs of the previous actions.
+func buildOutputEnvs(outputs map[string]string) []corev1.EnvVar {
+	var env []corev1.EnvVar
+	for k, v := range outputs {
+		env = append(env, corev1.EnvVar{Name: k, Value: v})
+	}
+	sort.Slice(env, func(i, j int) bool {
+		return env[i].Name < env[j].Name
+	})
+	return env
+}
+
+// replaceVars replaces the $(NAME) references with the values of the parameters and the outputs.
+func replaceVars(value string, vars map[string]string) string {
+	for k, v := range vars {
+		value = strings.ReplaceAll(value, fmt.Sprintf("$(%s)", k), v)
+	}
+	return value
+}
+
+// buildActionVars builds the variables which can be referenced by the action,
+// the outputs of the previous actions override the parameters with the same names.
+func buildActionVars(actionCtx ActionContext, compCustomItem *appsv1alpha1.CustomOpsComponent) map[string]string {
+	vars := map[string]string{}
+	for _, param := range compCustomItem.Parameters {
+		vars[param.Name] = param.Value
+	}
+	for k, v := range actionCtx.Outputs {
+		vars[k] = v
+	}
+	return vars
+}
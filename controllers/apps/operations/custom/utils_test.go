@@ -0,0 +1,117 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package custom
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+)
+
+func newTestPod(name string, available bool) *corev1.Pod {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if available {
+		pod.Status.Phase = corev1.PodRunning
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	}
+	return pod
+}
+
+func podNames(pods []*corev1.Pod) []string {
+	var names []string
+	for _, pod := range pods {
+		names = append(names, pod.Name)
+	}
+	return names
+}
+
+func TestSelectTargetPods(t *testing.T) {
+	var pods []*corev1.Pod
+	for _, name := range []string{"foo-10", "foo-2", "foo-0", "foo-3", "foo-1"} {
+		pods = append(pods, newTestPod(name, name != "foo-0"))
+	}
+	sortPodsByOrdinal(pods)
+	if got := podNames(pods); len(got) != 5 || got[0] != "foo-0" || got[2] != "foo-2" || got[4] != "foo-10" {
+		t.Errorf("unexpected order: %v", got)
+	}
+
+	filtered, err := filterPodsByOrdinals(pods, workloads.Ordinals{
+		Ranges:   []workloads.Range{{Start: 0, End: 2}},
+		Discrete: []int32{10},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := podNames(filtered); len(got) != 4 || got[3] != "foo-10" {
+		t.Errorf("unexpected filtered pods: %v", got)
+	}
+	if _, err = filterPodsByOrdinals(pods, workloads.Ordinals{Ranges: []workloads.Range{{Start: 2, End: 1}}}); err == nil {
+		t.Errorf("expected error for the invalid range")
+	}
+
+	sampled, err := samplePods(pods, intstr.FromString("40%"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// the unavailable pod foo-0 is skipped
+	if got := podNames(sampled); len(got) != 2 || got[0] != "foo-1" || got[1] != "foo-2" {
+		t.Errorf("unexpected sampled pods: %v", got)
+	}
+	sampled, _ = samplePods(pods, intstr.FromString("1%"))
+	if len(sampled) != 1 {
+		t.Errorf("expected at least one pod, got %v", podNames(sampled))
+	}
+	sampled, _ = samplePods(pods, intstr.FromInt32(10))
+	if len(sampled) != len(pods) {
+		t.Errorf("expected all pods, got %v", podNames(sampled))
+	}
+}
+
+func TestActionOutputs(t *testing.T) {
+	outputs := parseOutputs("LSN=0/3000060\nignored=true\n  ROLE=primary  \nbroken line", []string{"LSN", "ROLE", "MISSING"})
+	if len(outputs) != 2 || outputs["LSN"] != "0/3000060" || outputs["ROLE"] != "primary" {
+		t.Errorf("unexpected outputs: %v", outputs)
+	}
+	if outputs = parseOutputs("LSN=0/3000060", nil); outputs != nil {
+		t.Errorf("expected no outputs if none is declared, got %v", outputs)
+	}
+
+	pod := &corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+		{State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: "A=1"}}},
+		{State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+		{State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: "B=2"}}},
+	}}}
+	if outputs = getPodOutputs(pod, []string{"A", "B"}); len(outputs) != 2 || outputs["A"] != "1" || outputs["B"] != "2" {
+		t.Errorf("unexpected pod outputs: %v", outputs)
+	}
+
+	env := buildOutputEnvs(map[string]string{"B": "2", "A": "1"})
+	if len(env) != 2 || env[0].Name != "A" || env[1].Value != "2" {
+		t.Errorf("unexpected envs: %v", env)
+	}
+
+	if got := replaceVars("$(CLUSTER)-$(COMP)-$(UNKNOWN)", map[string]string{"CLUSTER": "mycluster", "COMP": "mysql"}); got != "mycluster-mysql-$(UNKNOWN)" {
+		t.Errorf("unexpected replaced value: %s", got)
+	}
+}
@@ -43,11 +43,14 @@ func (w *WorkloadAction) createJob(actionCtx ActionContext,
 			common.CutString(w.Comp.Name, 18), actionCtx.Action.Name)
 		return fmt.Sprintf("%s-%d", common.CutString(jobName, 57), taskIndex)
 	}
-	job := builder.NewJobBuilder(w.OpsRequest.Namespace, buildJobName()).
+	jobBuilder := builder.NewJobBuilder(w.OpsRequest.Namespace, buildJobName()).
 		SetBackoffLimit(actionCtx.Action.Workload.BackoffLimit).
 		AddLabelsInMap(buildLabels(w.OpsRequest.Name, actionCtx.Action.Name)).
-		SetPodTemplateSpec(corev1.PodTemplateSpec{Spec: *podSpec}).
-		GetObject()
+		SetPodTemplateSpec(corev1.PodTemplateSpec{Spec: *podSpec})
+	if ttl := actionCtx.Action.Workload.TTLSecondsAfterFinished; ttl != nil {
+		jobBuilder.SetTTLSecondsAfterFinished(*ttl)
+	}
+	job := jobBuilder.GetObject()
 	job.Kind = constant.JobKind
 	return actionCtx.createActionK8sWorkload(w.OpsRequest, job, targetPodName)
 }
@@ -95,6 +98,9 @@ func (w *WorkloadAction) checkJobStatus(actionCtx ActionContext, task *appsv1alp
 			}
 			if c.Type == batchv1.JobComplete {
 				completed = true
+				if task.Outputs, err = w.getJobOutputs(actionCtx, job); err != nil {
+					return false, false, err
+				}
 				break
 			}
 			if c.Type == batchv1.JobFailed {
@@ -105,3 +111,21 @@ func (w *WorkloadAction) checkJobStatus(actionCtx ActionContext, task *appsv1alp
 	}
 	return completed, existFailure, nil
 }
+
+// getJobOutputs gets the declared output variables from the succeeded pod of the job.
+func (w *WorkloadAction) getJobOutputs(actionCtx ActionContext, job *batchv1.Job) (map[string]string, error) {
+	if len(actionCtx.Action.Outputs) == 0 {
+		return nil, nil
+	}
+	podList := &corev1.PodList{}
+	if err := actionCtx.Client.List(actionCtx.ReqCtx.Ctx, podList, client.InNamespace(job.Namespace),
+		client.MatchingLabels{batchv1.JobNameLabel: job.Name}); err != nil {
+		return nil, err
+	}
+	for i := range podList.Items {
+		if podList.Items[i].Status.Phase == corev1.PodSucceeded {
+			return getPodOutputs(&podList.Items[i], actionCtx.Action.Outputs), nil
+		}
+	}
+	return nil, nil
+}
@@ -21,6 +21,8 @@ package operations
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -33,6 +35,8 @@ type WorkflowStatus struct {
 	IsCompleted    bool
 	ExistFailure   bool
 	CompletedCount int
+	// the duration after which the workflow should be reconciled again, zero means it's driven by the events.
+	RequeueAfter time.Duration
 }
 
 type WorkflowContext struct {
@@ -61,9 +65,11 @@ func (w *WorkflowContext) Run(compCustomSpec *appsv1alpha1.CustomOpsComponent) (
 		workflowStatus = &WorkflowStatus{}
 		actions        = w.OpsRes.OpsDef.Spec.Actions
 		compSpec       = getComponentSpecOrShardingTemplate(w.OpsRes.Cluster, compCustomSpec.ComponentName)
+		// the outputs of the completed actions, which are passed to the subsequent actions.
+		outputs = map[string]string{}
 	)
 	defer func() {
-		if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+		if intctrlutil.IsTerminalError(err) {
 			// if the error is Fatal, mark the workflow is Failed.
 			compStatus.Message = err.Error()
 			workflowStatus.IsCompleted = true
@@ -93,10 +99,11 @@ steps:
 				err = intctrlutil.NewFatalError("the action type is not implement for action " + actions[i].Name)
 				return nil, err
 			}
-			actionStatus, err = ac.Execute(custom.ActionContext{ReqCtx: w.reqCtx, Client: w.Cli, Action: &actions[i]})
+			actionStatus, err = ac.Execute(custom.ActionContext{ReqCtx: w.reqCtx, Client: w.Cli, Action: &actions[i], Outputs: outputs})
 			if err != nil {
 				return nil, err
 			}
+			workflowStatus.RequeueAfter = w.getRequeueAfter(actions[i], progressDetail, actionStatus)
 			progressDetail.ActionTasks = actionStatus.ActionTasks
 			progressDetail.SetStatusAndMessage(appsv1alpha1.ProcessingProgressStatus,
 				fmt.Sprintf(`Start to processing action "%s" of the component %s`, actions[i].Name, compCustomSpec.ComponentName))
@@ -110,19 +117,32 @@ steps:
 				err = intctrlutil.NewFatalError("the action type is not implement for action " + actions[i].Name)
 				return nil, err
 			}
-			actionStatus, err = ac.CheckStatus(custom.ActionContext{ReqCtx: w.reqCtx, Client: w.Cli, Action: &actions[i]})
+			actionStatus, err = ac.CheckStatus(custom.ActionContext{ReqCtx: w.reqCtx, Client: w.Cli, Action: &actions[i], Outputs: outputs})
 			if err != nil {
 				return nil, err
 			}
 			progressDetail.ActionTasks = actionStatus.ActionTasks
+			if !actionStatus.IsCompleted && isActionTimedOut(actions[i], progressDetail) {
+				// fail the unfinished tasks, and the failure is handled by the failurePolicy of the action.
+				for j := range progressDetail.ActionTasks {
+					if progressDetail.ActionTasks[j].Status == appsv1alpha1.ProcessingActionTaskStatus {
+						progressDetail.ActionTasks[j].Status = appsv1alpha1.FailedActionTaskStatus
+					}
+				}
+				progressDetail.SetStatusAndMessage(appsv1alpha1.FailedProgressStatus,
+					fmt.Sprintf(`the action "%s" of the component "%s" is timed out after %ds`,
+						actions[i].Name, compCustomSpec.ComponentName, *actions[i].TimeoutSeconds))
+			} else if !actionStatus.IsCompleted {
+				workflowStatus.RequeueAfter = w.getRequeueAfter(actions[i], progressDetail, actionStatus)
+			}
 			if actionStatus.IsCompleted {
 				if actionStatus.ExistFailure {
 					progressDetail.Status = appsv1alpha1.FailedProgressStatus
 				} else {
 					progressDetail.Status = appsv1alpha1.SucceedProgressStatus
 				}
-				progressDetail.Message = fmt.Sprintf(`the action "%s" of the component "%s" is %s`,
-					actions[i].Name, compCustomSpec.ComponentName, progressDetail.Status)
+				progressDetail.Message = fmt.Sprintf(`the action "%s" of the component "%s" is %s%s`,
+					actions[i].Name, compCustomSpec.ComponentName, progressDetail.Status, buildActionTasksSummary(progressDetail.ActionTasks))
 			}
 			setComponentStatusProgressDetail(w.reqCtx.Recorder, w.OpsRes.OpsRequest, &compStatus.ProgressDetails, progressDetail)
 			break steps
@@ -138,12 +158,82 @@ steps:
 			}
 		case appsv1alpha1.SucceedProgressStatus:
 			// if the action is final action, mark workflow to succeed
+			collectActionOutputs(outputs, *actionProgress)
 			setSucceedWorkflowStatus(i)
 		}
 	}
 	return workflowStatus, nil
 }
 
+// buildActionTasksSummary aggregates the results of the action tasks fanned out to the target pods.
+func buildActionTasksSummary(tasks []appsv1alpha1.ActionTask) string {
+	if len(tasks) <= 1 {
+		return ""
+	}
+	var (
+		succeedCount  int
+		failedTargets []string
+	)
+	for _, task := range tasks {
+		switch task.Status {
+		case appsv1alpha1.SucceedActionTaskStatus:
+			succeedCount++
+		case appsv1alpha1.FailedActionTaskStatus:
+			target := task.TargetPodName
+			if target == "" {
+				target = task.ObjectKey
+			}
+			failedTargets = append(failedTargets, target)
+		}
+	}
+	summary := fmt.Sprintf(", %d/%d tasks succeeded", succeedCount, len(tasks))
+	if len(failedTargets) > 0 {
+		summary += fmt.Sprintf(", failed targets: %s", strings.Join(failedTargets, ","))
+	}
+	return summary
+}
+
+// getRequeueAfter returns the duration to check the action again, which is the earlier one of the action
+// requeue and the action timeout.
+func (w *WorkflowContext) getRequeueAfter(action appsv1alpha1.OpsAction,
+	progressDetail appsv1alpha1.ProgressStatusDetail,
+	actionStatus *custom.ActionStatus) time.Duration {
+	requeueAfter := actionStatus.RequeueAfter
+	if action.TimeoutSeconds == nil {
+		return requeueAfter
+	}
+	startTime := progressDetail.StartTime.Time
+	if startTime.IsZero() {
+		startTime = time.Now()
+	}
+	untilTimeout := time.Until(startTime.Add(time.Duration(*action.TimeoutSeconds) * time.Second))
+	if untilTimeout <= 0 {
+		untilTimeout = time.Second
+	}
+	if requeueAfter == 0 || untilTimeout < requeueAfter {
+		return untilTimeout
+	}
+	return requeueAfter
+}
+
+// isActionTimedOut checks if the action is not completed within the timeout since it started.
+func isActionTimedOut(action appsv1alpha1.OpsAction, progressDetail appsv1alpha1.ProgressStatusDetail) bool {
+	if action.TimeoutSeconds == nil || progressDetail.StartTime.IsZero() {
+		return false
+	}
+	return time.Since(progressDetail.StartTime.Time) > time.Duration(*action.TimeoutSeconds)*time.Second
+}
+
+// collectActionOutputs collects the outputs of the tasks of the succeeded action,
+// the outputs of the latter tasks override the former ones with the same names.
+func collectActionOutputs(outputs map[string]string, progressDetail appsv1alpha1.ProgressStatusDetail) {
+	for _, task := range progressDetail.ActionTasks {
+		for k, v := range task.Outputs {
+			outputs[k] = v
+		}
+	}
+}
+
 func (w *WorkflowContext) getAction(action appsv1alpha1.OpsAction,
 	compCustomItem *appsv1alpha1.CustomOpsComponent,
 	compSpec *appsv1alpha1.ClusterComponentSpec,
@@ -155,6 +245,11 @@ func (w *WorkflowContext) getAction(action appsv1alpha1.OpsAction,
 	case action.Exec != nil:
 		return custom.NewExecAction(w.OpsRes.OpsRequest, w.OpsRes.Cluster,
 			w.OpsRes.OpsDef, compCustomItem, compSpec, progressDetail)
+	case action.KBAgent != nil:
+		return custom.NewKBAgentAction(w.OpsRes.OpsRequest, w.OpsRes.Cluster,
+			w.OpsRes.OpsDef, compCustomItem, compSpec, progressDetail)
+	case action.WaitForCondition != nil:
+		return custom.NewWaitForConditionAction(w.OpsRes.OpsRequest, w.OpsRes.Cluster, compCustomItem, progressDetail)
 	case action.ResourceModifier != nil:
 		// TODO: implement it.
 		return nil
@@ -20,8 +20,14 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"path"
+	"sort"
+	"strconv"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/sethvargo/go-password/password"
@@ -35,15 +41,27 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/common"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/scheduling"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/lorry/engines"
 	"github.com/apecloud/kubeblocks/pkg/lorry/engines/register"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 var _ OpsHandler = DataScriptOpsHandler{}
 
+const (
+	// dataScriptContainerName is the name of the container which executes the scripts.
+	dataScriptContainerName = "datascript"
+	// dataScriptParamEnvPrefix is the prefix of the env vars which hold the parameters sourced from the Secrets.
+	dataScriptParamEnvPrefix = "KB_SCRIPT_PARAM_"
+	// terminationMessageMaxBytes is the limit of the termination message enforced by the kubelet,
+	// the results of the scripts are written to the termination message.
+	terminationMessageMaxBytes = 4096
+)
+
 // DataScriptOpsHandler handles DataScript operation, it is more like a one-time command operation.
 type DataScriptOpsHandler struct {
 }
@@ -137,12 +155,19 @@ func (o DataScriptOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli
 	)
 
 	expectedCount = len(jobList.Items)
+	patch := client.MergeFrom(opsRequest.DeepCopy())
 	// check job status
-	for _, job := range jobList.Items {
-		if meetsJobConditions(&job, batchv1.JobComplete, corev1.ConditionTrue) {
+	for i := range jobList.Items {
+		job := &jobList.Items[i]
+		if meetsJobConditions(job, batchv1.JobComplete, corev1.ConditionTrue) {
 			succeedCount++
-		} else if meetsJobConditions(&job, batchv1.JobFailed, corev1.ConditionTrue) {
+		} else if meetsJobConditions(job, batchv1.JobFailed, corev1.ConditionTrue) {
 			failedCount++
+		} else {
+			continue
+		}
+		if err := collectScriptResults(reqCtx, cli, opsRequest, job); err != nil {
+			return appsv1alpha1.OpsRunningPhase, 0, err
 		}
 	}
 
@@ -153,7 +178,6 @@ func (o DataScriptOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli
 		opsStatus = appsv1alpha1.OpsFailedPhase
 	}
 
-	patch := client.MergeFrom(opsRequest.DeepCopy())
 	opsRequest.Status.Progress = fmt.Sprintf("%d/%d", succeedCount, expectedCount)
 
 	// patch OpsRequest.status.components
@@ -236,7 +260,7 @@ func buildDataScriptJobs(reqCtx intctrlutil.RequestCtx, cli client.Client, clust
 		return nil, intctrlutil.NewFatalError(err.Error())
 	}
 
-	buildJob := func(endpoint string) (*batchv1.Job, error) {
+	buildJob := func(endpoint, target string) (*batchv1.Job, error) {
 		envs := []corev1.EnvVar{}
 
 		envs = append(envs, corev1.EnvVar{
@@ -283,13 +307,22 @@ func buildDataScriptJobs(reqCtx intctrlutil.RequestCtx, cli client.Client, clust
 		if err != nil {
 			return nil, intctrlutil.NewFatalError(err.Error())
 		}
+		scripts, paramEnvs, err := renderScripts(reqCtx, cli, cluster, component, ops.Spec.ScriptSpec, scripts)
+		if err != nil {
+			return nil, intctrlutil.NewFatalError(err.Error())
+		}
+		envs = append(envs, paramEnvs...)
+		policy := common.NewStatementPolicy(viper.GetString(constant.KBDataScriptAllowedStatements))
+		if err = policy.Check(strings.Join(scripts, ";\n")); err != nil {
+			return nil, intctrlutil.NewFatalError(err.Error())
+		}
 
 		envs = append(envs, corev1.EnvVar{
 			Name:  "KB_SCRIPT",
 			Value: strings.Join(scripts, "\n"),
 		})
 
-		jobCmdTpl, envVars, err := engineForJob.ExecuteCommand(scripts)
+		jobCmdTpl, envVars, err := buildScriptsCommand(engineForJob, scripts)
 		if err != nil {
 			return nil, intctrlutil.NewFatalError(err.Error())
 		}
@@ -305,7 +338,7 @@ func buildDataScriptJobs(reqCtx intctrlutil.RequestCtx, cli client.Client, clust
 		}
 
 		container := corev1.Container{
-			Name:            "datascript",
+			Name:            dataScriptContainerName,
 			Image:           containerImg,
 			ImagePullPolicy: corev1.PullPolicy(viper.GetString(constant.KBImagePullPolicy)),
 			Command:         jobCmdTpl,
@@ -313,14 +346,16 @@ func buildDataScriptJobs(reqCtx intctrlutil.RequestCtx, cli client.Client, clust
 		}
 		randomStr, _ := password.Generate(4, 0, 0, true, false)
 		jobName := fmt.Sprintf("%s-%s-%s-%s", cluster.Name, "script", ops.Name, randomStr)
-		if len(jobName) > 63 {
-			jobName = strings.TrimSuffix(jobName[:63], "-")
-		}
+		jobName = common.ShortenName(jobName, common.DNS1123LabelMaxLength)
 
 		job := &batchv1.Job{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      jobName,
 				Namespace: cluster.Namespace,
+				Annotations: map[string]string{
+					constant.DataScriptTargetAnnotationKey: target,
+					constant.DataScriptCountAnnotationKey:  strconv.Itoa(len(scripts)),
+				},
 			},
 		}
 		intctrlutil.InjectZeroResourcesLimitsIfEmpty(&container)
@@ -355,7 +390,7 @@ func buildDataScriptJobs(reqCtx intctrlutil.RequestCtx, cli client.Client, clust
 		if endpoint, err = getTargetService(reqCtx, cli, client.ObjectKeyFromObject(cluster), component.Name); err != nil {
 			return nil, intctrlutil.NewFatalError(err.Error())
 		}
-		if job, err = buildJob(endpoint); err != nil {
+		if job, err = buildJob(endpoint, "Service/"+endpoint); err != nil {
 			return nil, intctrlutil.NewFatalError(err.Error())
 		}
 		jobs = append(jobs, job)
@@ -382,7 +417,7 @@ func buildDataScriptJobs(reqCtx intctrlutil.RequestCtx, cli client.Client, clust
 
 	for _, pod := range pods.Items {
 		endpoint = pod.Status.PodIP
-		if job, err = buildJob(endpoint); err != nil {
+		if job, err = buildJob(endpoint, "Pod/"+pod.Name); err != nil {
 			return nil, intctrlutil.NewFatalError(err.Error())
 		} else {
 			jobs = append(jobs, job)
@@ -399,3 +434,189 @@ func getDataScriptJobLabels(cluster, component, request string) map[string]strin
 		constant.OpsRequestTypeLabelKey: string(appsv1alpha1.DataScriptType),
 	}
 }
+
+// renderScripts renders the scripts as Go templates if the scripts are templated, and returns the env vars
+// which hold the parameters sourced from the Secrets.
+// The parameters sourced from the Secrets are rendered as the references to the env vars, so that the secret
+// values are expanded by the shell of the Job, instead of being rendered into the Job.
+func renderScripts(reqCtx intctrlutil.RequestCtx, cli client.Client, cluster *appsv1alpha1.Cluster,
+	component *appsv1alpha1.ClusterComponentSpec, spec *appsv1alpha1.ScriptSpec, scripts []string) ([]string, []corev1.EnvVar, error) {
+	if !spec.Templated {
+		return scripts, nil, nil
+	}
+	var envs []corev1.EnvVar
+	params := map[string]string{}
+	for _, param := range spec.Parameters {
+		if param.SecretKeyRef == nil {
+			params[param.Name] = param.Value
+			continue
+		}
+		secret := &corev1.Secret{}
+		if err := cli.Get(reqCtx.Ctx, types.NamespacedName{Namespace: cluster.Namespace, Name: param.SecretKeyRef.Name}, secret); err != nil {
+			return nil, nil, err
+		}
+		if _, ok := secret.Data[param.SecretKeyRef.Key]; !ok {
+			return nil, nil, fmt.Errorf("secret %s/%s does not have key %s", cluster.Namespace, param.SecretKeyRef.Name, param.SecretKeyRef.Key)
+		}
+		envName := dataScriptParamEnvPrefix + param.Name
+		envs = append(envs, corev1.EnvVar{
+			Name:      envName,
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: param.SecretKeyRef.DeepCopy()},
+		})
+		params[param.Name] = fmt.Sprintf("${%s}", envName)
+	}
+
+	// get the built-in objects and covert the json tag
+	b, err := json.Marshal(map[string]interface{}{
+		"cluster":    cluster,
+		"component":  component,
+		"parameters": params,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	data := map[string]interface{}{}
+	if err = json.Unmarshal(b, &data); err != nil {
+		return nil, nil, err
+	}
+
+	rendered := make([]string, 0, len(scripts))
+	for i, script := range scripts {
+		tmpl, err := template.New(fmt.Sprintf("script-%d", i)).Option("missingkey=error").Parse(script)
+		if err != nil {
+			return nil, nil, err
+		}
+		var buf strings.Builder
+		if err = tmpl.Execute(&buf, data); err != nil {
+			return nil, nil, err
+		}
+		rendered = append(rendered, buf.String())
+	}
+	return rendered, envs, nil
+}
+
+// buildScriptsCommand builds the command to execute the scripts one by one, which writes the exit code and
+// the tail of the output of each script to the termination message, and stops at the first failed script.
+// It falls back to the command of the engine if the engine doesn't execute the scripts through a shell.
+func buildScriptsCommand(engine engines.ClusterCommands, scripts []string) ([]string, []corev1.EnvVar, error) {
+	var (
+		lines   = []string{": > " + corev1.TerminationMessagePathDefault}
+		envVars []corev1.EnvVar
+	)
+	tailBytes := scriptOutputTailBytes(len(scripts))
+	for i, script := range scripts {
+		cmd, vars, err := engine.ExecuteCommand([]string{script})
+		if err != nil {
+			return nil, nil, err
+		}
+		shellCmd, ok := shellCommandOf(cmd)
+		if !ok {
+			return engine.ExecuteCommand(scripts)
+		}
+		if i == 0 {
+			envVars = vars
+		}
+		lines = append(lines,
+			fmt.Sprintf("out=$(%s 2>&1); rc=$?", shellCmd),
+			`printf '%s\n' "$out"`,
+			fmt.Sprintf(`printf '%d %%s %%s\n' "$rc" "$(printf '%%s' "$out" | tail -c %d | base64 | tr -d '\n')" >> %s`,
+				i, tailBytes, corev1.TerminationMessagePathDefault),
+			`[ "$rc" -eq 0 ] || exit "$rc"`)
+	}
+	return []string{"/bin/sh", "-c", strings.Join(lines, "\n")}, envVars, nil
+}
+
+// shellCommandOf returns the command string if the command is executed through a shell, e.g. `/bin/sh -c <command>`.
+func shellCommandOf(cmd []string) (string, bool) {
+	if len(cmd) < 3 {
+		return "", false
+	}
+	switch path.Base(cmd[0]) {
+	case "sh", "bash":
+	default:
+		return "", false
+	}
+	for _, arg := range cmd[1 : len(cmd)-1] {
+		if arg == "-c" {
+			return cmd[len(cmd)-1], true
+		}
+	}
+	return "", false
+}
+
+// scriptOutputTailBytes returns the bytes of the output kept for each script, so that the results of all the scripts
+// fit in the termination message after they are encoded in base64.
+func scriptOutputTailBytes(count int) int {
+	if count == 0 {
+		return 0
+	}
+	// reserve the bytes for the index, the exit code and the separators of each line.
+	perScript := terminationMessageMaxBytes/count - 16
+	if perScript <= 0 {
+		return 0
+	}
+	return perScript / 4 * 3
+}
+
+// parseScriptResults parses the results of the scripts written to the termination message by the datascript Job.
+// The scripts which are not in the message are recorded without the exit code.
+func parseScriptResults(message, jobName, target string, count int) []appsv1alpha1.ScriptResult {
+	results := make([]appsv1alpha1.ScriptResult, count)
+	for i := range results {
+		results[i] = appsv1alpha1.ScriptResult{JobName: jobName, Target: target, Index: int32(i)}
+	}
+	for _, line := range strings.Split(message, "\n") {
+		fields := strings.SplitN(strings.TrimSpace(line), " ", 3)
+		if len(fields) < 2 {
+			continue
+		}
+		index, err := strconv.Atoi(fields[0])
+		if err != nil || index < 0 || index >= count {
+			continue
+		}
+		exitCode, err := strconv.ParseInt(fields[1], 10, 32)
+		if err != nil {
+			continue
+		}
+		results[index].ExitCode = pointer.Int32(int32(exitCode))
+		if len(fields) == 3 {
+			if output, err := base64.StdEncoding.DecodeString(fields[2]); err == nil {
+				results[index].Output = string(output)
+			}
+		}
+	}
+	return results
+}
+
+// collectScriptResults records the results of the scripts executed by the finished Job to the status of the OpsRequest.
+func collectScriptResults(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRequest *appsv1alpha1.OpsRequest, job *batchv1.Job) error {
+	for _, result := range opsRequest.Status.ScriptResults {
+		if result.JobName == job.Name {
+			return nil
+		}
+	}
+	count, err := strconv.Atoi(job.Annotations[constant.DataScriptCountAnnotationKey])
+	if err != nil {
+		// the Job is created by an earlier version, which doesn't capture the results.
+		return nil
+	}
+	podList := &corev1.PodList{}
+	if err = cli.List(reqCtx.Ctx, podList, client.InNamespace(job.Namespace),
+		client.MatchingLabels{batchv1.JobNameLabel: job.Name}); err != nil {
+		return err
+	}
+	for _, pod := range podList.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name != dataScriptContainerName || status.State.Terminated == nil {
+				continue
+			}
+			results := parseScriptResults(status.State.Terminated.Message, job.Name, job.Annotations[constant.DataScriptTargetAnnotationKey], count)
+			opsRequest.Status.ScriptResults = append(opsRequest.Status.ScriptResults, results...)
+			sort.SliceStable(opsRequest.Status.ScriptResults, func(i, j int) bool {
+				return opsRequest.Status.ScriptResults[i].JobName < opsRequest.Status.ScriptResults[j].JobName
+			})
+			return nil
+		}
+	}
+	return nil
+}
@@ -20,6 +20,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"encoding/base64"
 	"fmt"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -36,6 +37,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	"github.com/apecloud/kubeblocks/pkg/generics"
+	"github.com/apecloud/kubeblocks/pkg/lorry/engines/register"
 	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
@@ -443,5 +445,78 @@ var _ = Describe("DataScriptOps", func() {
 			_, err = getScriptContent(reqCtx, k8sClient, ops.Spec.ScriptSpec)
 			Expect(err).Should(Succeed())
 		})
+
+		It("render the templated scripts with the parameters", func() {
+			secretName := "test-param-secret-" + testCtx.GetRandomStr()
+			comp := clusterObj.Spec.GetComponentByName(defaultCompName)
+			spec := &appsv1alpha1.ScriptSpec{
+				ComponentOps: appsv1alpha1.ComponentOps{ComponentName: defaultCompName},
+				Templated:    true,
+				Parameters: []appsv1alpha1.ScriptParameter{
+					{Name: "db", Value: "orders"},
+					{Name: "pwd", SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+						Key:                  "password",
+					}},
+				},
+			}
+			scripts := []string{
+				"CREATE DATABASE {{ .parameters.db }};",
+				"CREATE USER '{{ .cluster.metadata.name }}'@'%' IDENTIFIED BY '{{ .parameters.pwd }}';",
+			}
+
+			By("fail with missing secret")
+			_, _, err := renderScripts(reqCtx, k8sClient, clusterObj, comp, spec, scripts)
+			Expect(err).Should(HaveOccurred())
+
+			By("render the scripts, the secret value is referenced by env var")
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: clusterObj.Namespace},
+				StringData: map[string]string{"password": "123456"},
+			}
+			Expect(k8sClient.Create(testCtx.Ctx, secret)).Should(Succeed())
+			rendered, envs, err := renderScripts(reqCtx, k8sClient, clusterObj, comp, spec, scripts)
+			Expect(err).Should(Succeed())
+			Expect(rendered).Should(Equal([]string{
+				"CREATE DATABASE orders;",
+				fmt.Sprintf("CREATE USER '%s'@'%%' IDENTIFIED BY '${KB_SCRIPT_PARAM_pwd}';", clusterObj.Name),
+			}))
+			Expect(envs).Should(HaveLen(1))
+			Expect(envs[0].Name).Should(Equal("KB_SCRIPT_PARAM_pwd"))
+			Expect(envs[0].ValueFrom.SecretKeyRef.Name).Should(Equal(secretName))
+
+			By("fail with the undefined parameter")
+			_, _, err = renderScripts(reqCtx, k8sClient, clusterObj, comp, spec, []string{"DROP DATABASE {{ .parameters.missing }};"})
+			Expect(err).Should(HaveOccurred())
+
+			By("keep the scripts as is if not templated")
+			spec.Templated = false
+			rendered, envs, err = renderScripts(reqCtx, k8sClient, clusterObj, comp, spec, scripts)
+			Expect(err).Should(Succeed())
+			Expect(rendered).Should(Equal(scripts))
+			Expect(envs).Should(BeEmpty())
+		})
+
+		It("capture the results of the scripts", func() {
+			engine, err := register.NewClusterCommands("mysql")
+			Expect(err).Should(Succeed())
+			cmd, _, err := buildScriptsCommand(engine, []string{"SELECT 1;", "SELECT 2;"})
+			Expect(err).Should(Succeed())
+			Expect(cmd).Should(HaveLen(3))
+			Expect(cmd[2]).Should(ContainSubstring(`printf '0 %s %s\n'`))
+			Expect(cmd[2]).Should(ContainSubstring(`printf '1 %s %s\n'`))
+			Expect(cmd[2]).Should(ContainSubstring(corev1.TerminationMessagePathDefault))
+
+			message := fmt.Sprintf("0 0 %s\n1 1 %s\n", base64.StdEncoding.EncodeToString([]byte("1")),
+				base64.StdEncoding.EncodeToString([]byte("ERROR 1064")))
+			results := parseScriptResults(message, "job", "Service/svc", 3)
+			Expect(results).Should(HaveLen(3))
+			Expect(*results[0].ExitCode).Should(BeEquivalentTo(0))
+			Expect(results[0].Output).Should(Equal("1"))
+			Expect(*results[1].ExitCode).Should(BeEquivalentTo(1))
+			Expect(results[1].Output).Should(Equal("ERROR 1064"))
+			Expect(results[1].Target).Should(Equal("Service/svc"))
+			Expect(results[2].ExitCode).Should(BeNil())
+		})
 	})
 })
@@ -0,0 +1,253 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// ExternalOpsCall is the call of the OpsRequest dispatched to the webhook of an ExternalOpsHandler.
+type ExternalOpsCall string
+
+const (
+	ExternalOpsActionCall    ExternalOpsCall = "Action"
+	ExternalOpsReconcileCall ExternalOpsCall = "Reconcile"
+	ExternalOpsCancelCall    ExternalOpsCall = "Cancel"
+
+	defaultExternalOpsTimeout      = 10 * time.Second
+	defaultExternalOpsRequeueAfter = 5 * time.Second
+	maxExternalOpsResponseSize     = 1 << 20
+)
+
+// ExternalOpsReview is the payload posted to the webhook of an ExternalOpsHandler.
+type ExternalOpsReview struct {
+	Call       ExternalOpsCall          `json:"call"`
+	Parameters map[string]string        `json:"parameters,omitempty"`
+	OpsRequest *appsv1alpha1.OpsRequest `json:"opsRequest"`
+	Cluster    *appsv1alpha1.Cluster    `json:"cluster"`
+}
+
+// ExternalOpsResult is the response of the webhook of an ExternalOpsHandler.
+type ExternalOpsResult struct {
+	// Phase is the phase of the operation, only the Reconcile call reports the Succeed and Failed phases.
+	// The operation is failed if the Action call reports the Failed phase.
+	Phase appsv1alpha1.OpsPhase `json:"phase,omitempty"`
+	// Message describes the progress of the operation, or the reason of the failure.
+	Message string `json:"message,omitempty"`
+	// RequeueAfterSeconds is the interval to reconcile the running operation again.
+	RequeueAfterSeconds int32 `json:"requeueAfterSeconds,omitempty"`
+}
+
+// ExternalOpsHandler dispatches the Action, Reconcile and Cancel calls of the OpsRequests of type External
+// to the webhook registered by the ExternalOpsHandler object which the OpsRequest refers to.
+type ExternalOpsHandler struct{}
+
+var _ OpsHandler = ExternalOpsHandler{}
+
+func init() {
+	externalBehaviour := OpsBehaviour{
+		QueueByCluster: true,
+		CancelFunc:     ExternalOpsHandler{}.Cancel,
+		OpsHandler:     ExternalOpsHandler{},
+	}
+
+	opsMgr := GetOpsManager()
+	opsMgr.RegisterOps(appsv1alpha1.ExternalType, externalBehaviour)
+}
+
+// ActionStartedCondition the started condition when handling the external request.
+func (e ExternalOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*metav1.Condition, error) {
+	return appsv1alpha1.NewExternalOpsCondition(opsRes.OpsRequest), nil
+}
+
+// Action sends the Action call to the webhook, the operation is failed if the webhook rejects it.
+func (e ExternalOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	result, err := e.call(reqCtx, cli, opsRes, ExternalOpsActionCall)
+	if err != nil {
+		return err
+	}
+	if result.Phase == appsv1alpha1.OpsFailedPhase {
+		return intctrlutil.NewFatalError(result.Message)
+	}
+	return nil
+}
+
+// ReconcileAction sends the Reconcile call to the webhook repeatedly, until the webhook reports
+// that the operation is succeeded or failed.
+func (e ExternalOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	opsRequestPhase := opsRes.OpsRequest.Status.Phase
+	result, err := e.call(reqCtx, cli, opsRes, ExternalOpsReconcileCall)
+	if err != nil {
+		return opsRequestPhase, 0, err
+	}
+	switch result.Phase {
+	case appsv1alpha1.OpsSucceedPhase:
+		return appsv1alpha1.OpsSucceedPhase, 0, nil
+	case appsv1alpha1.OpsFailedPhase:
+		return appsv1alpha1.OpsFailedPhase, 0, errors.New(result.Message)
+	}
+	requeueAfter := defaultExternalOpsRequeueAfter
+	if result.RequeueAfterSeconds > 0 {
+		requeueAfter = time.Duration(result.RequeueAfterSeconds) * time.Second
+	}
+	return opsRequestPhase, requeueAfter, nil
+}
+
+// SaveLastConfiguration records last configuration to the OpsRequest.status.lastConfiguration
+func (e ExternalOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return nil
+}
+
+// Cancel sends the Cancel call to the webhook, and the webhook reports the Succeed phase
+// in the following Reconcile calls once the operation is cancelled.
+func (e ExternalOpsHandler) Cancel(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	handler, err := e.getHandler(reqCtx, cli, opsRes)
+	if err != nil {
+		return err
+	}
+	if !handler.Spec.Cancellable {
+		return intctrlutil.NewErrorf(intctrlutil.ErrorIgnoreCancel,
+			`the ExternalOpsHandler "%s" does not support cancelling the operation`, handler.Name)
+	}
+	result, err := e.call(reqCtx, cli, opsRes, ExternalOpsCancelCall)
+	if err != nil {
+		return err
+	}
+	if result.Phase == appsv1alpha1.OpsFailedPhase {
+		return intctrlutil.NewFatalError(result.Message)
+	}
+	return nil
+}
+
+func (e ExternalOpsHandler) getHandler(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*appsv1alpha1.ExternalOpsHandler, error) {
+	externalOps := opsRes.OpsRequest.Spec.ExternalOps
+	if externalOps == nil {
+		return nil, intctrlutil.NewFatalError("spec.external can not be empty")
+	}
+	handler := &appsv1alpha1.ExternalOpsHandler{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: externalOps.HandlerName}, handler); err != nil {
+		return nil, err
+	}
+	return handler, nil
+}
+
+// call posts the call of the OpsRequest to the webhook and decodes the result.
+func (e ExternalOpsHandler) call(reqCtx intctrlutil.RequestCtx, cli client.Client,
+	opsRes *OpsResource, call ExternalOpsCall) (*ExternalOpsResult, error) {
+	handler, err := e.getHandler(reqCtx, cli, opsRes)
+	if err != nil {
+		return nil, err
+	}
+	url, err := buildExternalOpsHandlerURL(handler)
+	if err != nil {
+		return nil, err
+	}
+	httpClient, err := buildExternalOpsHandlerClient(handler)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(ExternalOpsReview{
+		Call:       call,
+		Parameters: opsRes.OpsRequest.Spec.ExternalOps.Parameters,
+		OpsRequest: opsRes.OpsRequest,
+		Cluster:    opsRes.Cluster,
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(reqCtx.Ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to call the ExternalOpsHandler "%s": %w`, handler.Name, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxExternalOpsResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf(`the ExternalOpsHandler "%s" responds to the %s call with status %d: %s`,
+			handler.Name, call, resp.StatusCode, string(data))
+	}
+	result := &ExternalOpsResult{}
+	if err = json.Unmarshal(data, result); err != nil {
+		return nil, fmt.Errorf(`failed to decode the response of the ExternalOpsHandler "%s": %w`, handler.Name, err)
+	}
+	return result, nil
+}
+
+func buildExternalOpsHandlerURL(handler *appsv1alpha1.ExternalOpsHandler) (string, error) {
+	config := handler.Spec.ClientConfig
+	switch {
+	case config.URL != nil:
+		return *config.URL, nil
+	case config.Service != nil:
+		port := config.Service.Port
+		if port == 0 {
+			port = 443
+		}
+		path := ""
+		if config.Service.Path != nil {
+			path = *config.Service.Path
+		}
+		return fmt.Sprintf("https://%s.%s.svc:%d%s", config.Service.Name, config.Service.Namespace, port, path), nil
+	default:
+		return "", intctrlutil.NewErrorf(intctrlutil.ErrorTypeFatal,
+			`neither url nor service is specified by the ExternalOpsHandler "%s"`, handler.Name)
+	}
+}
+
+func buildExternalOpsHandlerClient(handler *appsv1alpha1.ExternalOpsHandler) (*http.Client, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if len(handler.Spec.ClientConfig.CABundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(handler.Spec.ClientConfig.CABundle) {
+			return nil, intctrlutil.NewErrorf(intctrlutil.ErrorTypeFatal,
+				`failed to parse the caBundle of the ExternalOpsHandler "%s"`, handler.Name)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	timeout := defaultExternalOpsTimeout
+	if handler.Spec.TimeoutSeconds > 0 {
+		timeout = time.Duration(handler.Spec.TimeoutSeconds) * time.Second
+	}
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+	}, nil
+}
@@ -0,0 +1,154 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+var _ = Describe("external ops handler", func() {
+	const (
+		namespace   = "default"
+		clusterName = "mycluster"
+		handlerName = "vacuum"
+	)
+
+	var (
+		server *httptest.Server
+		calls  []ExternalOpsReview
+		result ExternalOpsResult
+	)
+
+	BeforeEach(func() {
+		calls = nil
+		result = ExternalOpsResult{}
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			review := ExternalOpsReview{}
+			if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			calls = append(calls, review)
+			_ = json.NewEncoder(w).Encode(result)
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	newOpsResource := func(cancellable bool) (*OpsResource, intctrlutil.RequestCtx, *fake.ClientBuilder) {
+		url := server.URL
+		handler := &appsv1alpha1.ExternalOpsHandler{
+			ObjectMeta: metav1.ObjectMeta{Name: handlerName},
+			Spec: appsv1alpha1.ExternalOpsHandlerSpec{
+				ClientConfig: appsv1alpha1.ExternalOpsHandlerClientConfig{URL: &url},
+				Cancellable:  cancellable,
+			},
+		}
+		scheme := runtime.NewScheme()
+		Expect(appsv1alpha1.AddToScheme(scheme)).Should(Succeed())
+		opsRes := &OpsResource{
+			Cluster: &appsv1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: clusterName}},
+			OpsRequest: &appsv1alpha1.OpsRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "ops-vacuum"},
+				Spec: appsv1alpha1.OpsRequestSpec{
+					ClusterName: clusterName,
+					Type:        appsv1alpha1.ExternalType,
+					SpecificOpsRequest: appsv1alpha1.SpecificOpsRequest{
+						ExternalOps: &appsv1alpha1.ExternalOps{
+							HandlerName: handlerName,
+							Parameters:  map[string]string{"table": "orders"},
+						},
+					},
+				},
+				Status: appsv1alpha1.OpsRequestStatus{Phase: appsv1alpha1.OpsRunningPhase},
+			},
+		}
+		reqCtx := intctrlutil.RequestCtx{Ctx: context.Background(), Log: logr.Discard()}
+		return opsRes, reqCtx, fake.NewClientBuilder().WithScheme(scheme).WithObjects(handler)
+	}
+
+	It("dispatches the calls of the OpsRequest to the webhook", func() {
+		opsRes, reqCtx, builder := newOpsResource(false)
+		cli := builder.Build()
+		handler := ExternalOpsHandler{}
+
+		By("expect the action call carries the OpsRequest and the parameters")
+		Expect(handler.Action(reqCtx, cli, opsRes)).Should(Succeed())
+		Expect(calls).Should(HaveLen(1))
+		Expect(calls[0].Call).Should(Equal(ExternalOpsActionCall))
+		Expect(calls[0].Parameters).Should(HaveKeyWithValue("table", "orders"))
+		Expect(calls[0].OpsRequest.Name).Should(Equal(opsRes.OpsRequest.Name))
+		Expect(calls[0].Cluster.Name).Should(Equal(clusterName))
+
+		By("expect the operation keeps running until the webhook reports the completion")
+		result = ExternalOpsResult{Phase: appsv1alpha1.OpsRunningPhase, RequeueAfterSeconds: 30}
+		phase, requeueAfter, err := handler.ReconcileAction(reqCtx, cli, opsRes)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(phase).Should(Equal(appsv1alpha1.OpsRunningPhase))
+		Expect(requeueAfter).Should(Equal(30 * time.Second))
+
+		result = ExternalOpsResult{Phase: appsv1alpha1.OpsFailedPhase, Message: "table is locked"}
+		phase, _, err = handler.ReconcileAction(reqCtx, cli, opsRes)
+		Expect(phase).Should(Equal(appsv1alpha1.OpsFailedPhase))
+		Expect(err).Should(MatchError("table is locked"))
+
+		result = ExternalOpsResult{Phase: appsv1alpha1.OpsSucceedPhase}
+		phase, _, err = handler.ReconcileAction(reqCtx, cli, opsRes)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(phase).Should(Equal(appsv1alpha1.OpsSucceedPhase))
+		Expect(calls).Should(HaveLen(4))
+	})
+
+	It("fails the operation if the webhook rejects the action", func() {
+		opsRes, reqCtx, builder := newOpsResource(false)
+		result = ExternalOpsResult{Phase: appsv1alpha1.OpsFailedPhase, Message: "unsupported engine"}
+		err := ExternalOpsHandler{}.Action(reqCtx, builder.Build(), opsRes)
+		Expect(intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal)).Should(BeTrue())
+	})
+
+	It("cancels the operation only if the handler is cancellable", func() {
+		opsRes, reqCtx, builder := newOpsResource(false)
+		err := ExternalOpsHandler{}.Cancel(reqCtx, builder.Build(), opsRes)
+		Expect(intctrlutil.IsTargetError(err, intctrlutil.ErrorIgnoreCancel)).Should(BeTrue())
+		Expect(calls).Should(BeEmpty())
+
+		opsRes, reqCtx, builder = newOpsResource(true)
+		Expect(ExternalOpsHandler{}.Cancel(reqCtx, builder.Build(), opsRes)).Should(Succeed())
+		Expect(calls).Should(HaveLen(1))
+		Expect(calls[0].Call).Should(Equal(ExternalOpsCancelCall))
+	})
+})
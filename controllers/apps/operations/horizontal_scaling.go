@@ -22,8 +22,10 @@ package operations
 import (
 	"fmt"
 	"slices"
+	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/pointer"
@@ -35,6 +37,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlcomp "github.com/apecloud/kubeblocks/pkg/controller/component"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
 )
 
 type horizontalScalingOpsHandler struct{}
@@ -48,8 +51,14 @@ func init() {
 		FromClusterPhases: appsv1alpha1.GetClusterUpRunningPhases(),
 		ToClusterPhase:    appsv1alpha1.UpdatingClusterPhase,
 		QueueByCluster:    true,
-		OpsHandler:        hsHandler,
-		CancelFunc:        hsHandler.Cancel,
+		ConflictPolicies: map[appsv1alpha1.OpsType]OpsConflictPolicy{
+			appsv1alpha1.HorizontalScalingType: ConflictPolicyAbort,
+			appsv1alpha1.StartType:             ConflictPolicyAbort,
+		},
+		OpsHandler: hsHandler,
+		CancelFunc: hsHandler.Cancel,
+
+		HonorMaintenanceWindow: true,
 	}
 	opsMgr := GetOpsManager()
 	opsMgr.RegisterOps(appsv1alpha1.HorizontalScalingType, horizontalScalingBehaviour)
@@ -68,7 +77,7 @@ func (hs horizontalScalingOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli
 	}
 	compOpsSet := newComponentOpsHelper(opsRes.OpsRequest.Spec.HorizontalScalingList)
 	// abort earlier running horizontal scaling opsRequest.
-	if err := abortEarlierOpsRequestWithSameKind(reqCtx, cli, opsRes, []appsv1alpha1.OpsType{appsv1alpha1.HorizontalScalingType, appsv1alpha1.StartType},
+	if err := abortConflictingOpsRequests(reqCtx, cli, opsRes,
 		func(earlierOps *appsv1alpha1.OpsRequest) (bool, error) {
 			if slices.Contains([]appsv1alpha1.OpsType{appsv1alpha1.StartType, appsv1alpha1.StopType}, earlierOps.Spec.Type) {
 				return true, nil
@@ -97,6 +106,9 @@ func (hs horizontalScalingOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli
 		return err
 	}
 
+	// only scale the components in execution if the components are scaled one by one.
+	compOpsSet = compOpsSet.withExecutedComponents(opsRes.OpsRequest,
+		getSerialExecutionOrder(opsRes.OpsRequest, opsRes.OpsRequest.Spec.HorizontalScalingList))
 	if err := compOpsSet.updateClusterComponentsAndShardings(opsRes.Cluster, func(compSpec *appsv1alpha1.ClusterComponentSpec, obj ComponentOpsInterface) error {
 		horizontalScaling := obj.(appsv1alpha1.HorizontalScaling)
 		lastCompConfiguration := opsRes.OpsRequest.Status.LastConfiguration.Components[obj.GetComponentName()]
@@ -127,9 +139,22 @@ func (hs horizontalScalingOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli
 				horizontalScaling.ComponentName)
 			return intctrlutil.NewFatalError(errMsg)
 		}
+		if err = hs.checkScaleInReplicationLag(reqCtx, cli, opsRes, compSpec, lastCompConfiguration,
+			horizontalScaling.ScaleIn, replicas, instances, offlineInstances); err != nil {
+			return err
+		}
+		if err = invokePreScaleInActions(reqCtx, cli, opsRes, horizontalScaling, lastCompConfiguration,
+			replicas, instances, offlineInstances); err != nil {
+			return err
+		}
 		compSpec.Replicas = replicas
 		compSpec.Instances = instances
 		compSpec.OfflineInstances = offlineInstances
+		var pvcRetentionPolicy appsv1alpha1.ScaleInPVCRetentionPolicy
+		if horizontalScaling.ScaleIn != nil {
+			pvcRetentionPolicy = horizontalScaling.ScaleIn.PVCRetentionPolicy
+		}
+		setScaleInPVCRetentionPolicy(opsRes.Cluster, obj.GetComponentName(), pvcRetentionPolicy)
 		return nil
 	}); err != nil {
 		return err
@@ -154,10 +179,74 @@ func (hs horizontalScalingOpsHandler) ReconcileAction(reqCtx intctrlutil.Request
 			return 0, 0, err
 		}
 		pgRes.noWaitComponentCompleted = true
-		return handleComponentProgressForScalingReplicas(reqCtx, cli, opsRes, pgRes, compStatus)
+		expectCount, completedCount, err := handleComponentProgressForScalingReplicas(reqCtx, cli, opsRes, pgRes, compStatus)
+		if err != nil || expectCount != completedCount {
+			return expectCount, completedCount, err
+		}
+		return expectCount, completedCount, invokePostScaleOutActions(reqCtx, cli, opsRes, pgRes, compStatus)
+	}
+	order := getSerialExecutionOrder(opsRes.OpsRequest, opsRes.OpsRequest.Spec.HorizontalScalingList)
+	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.HorizontalScalingList).
+		withExecutedComponents(opsRes.OpsRequest, order)
+	phase, requeueAfter, err := compOpsHelper.reconcileActionWithComponentOps(reqCtx, cli, opsRes, "", handleComponentProgress)
+	if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+		// the post-scale-out action with the Abort failure policy fails.
+		if clearErr := hs.clearScaleInPVCRetentionPolicies(reqCtx, cli, opsRes); clearErr != nil {
+			return opsRes.OpsRequest.Status.Phase, 0, clearErr
+		}
+		return appsv1alpha1.OpsFailedPhase, 0, err
 	}
-	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.HorizontalScalingList)
-	return compOpsHelper.reconcileActionWithComponentOps(reqCtx, cli, opsRes, "", handleComponentProgress)
+	phase, requeueAfter, err = executeNextComponent(reqCtx, cli, opsRes, order, hs, phase, requeueAfter, err)
+	if phase == appsv1alpha1.OpsSucceedPhase || phase == appsv1alpha1.OpsFailedPhase {
+		if clearErr := hs.clearScaleInPVCRetentionPolicies(reqCtx, cli, opsRes); clearErr != nil {
+			return opsRes.OpsRequest.Status.Phase, 0, clearErr
+		}
+	}
+	return phase, requeueAfter, err
+}
+
+// clearScaleInPVCRetentionPolicies removes the PVC retention policies of the components from the cluster
+// once the OpsRequest is completed, so that the later scale-in of the components deletes the PVCs by default.
+func (hs horizontalScalingOpsHandler) clearScaleInPVCRetentionPolicies(reqCtx intctrlutil.RequestCtx,
+	cli client.Client, opsRes *OpsResource) error {
+	if opsRes.Cluster.Annotations[constant.ScaleInPVCRetentionAnnotationKey] == "" {
+		return nil
+	}
+	patch := client.MergeFrom(opsRes.Cluster.DeepCopy())
+	for _, v := range opsRes.OpsRequest.Spec.HorizontalScalingList {
+		setScaleInPVCRetentionPolicy(opsRes.Cluster, v.ComponentName, "")
+	}
+	return cli.Patch(reqCtx.Ctx, opsRes.Cluster, patch)
+}
+
+// setScaleInPVCRetentionPolicy records the PVC retention policy of the component in the annotation of the cluster,
+// the policy is removed if it's Delete, which is the default behavior.
+func setScaleInPVCRetentionPolicy(cluster *appsv1alpha1.Cluster, compName string, policy appsv1alpha1.ScaleInPVCRetentionPolicy) {
+	policies := map[string]string{}
+	if value := cluster.Annotations[constant.ScaleInPVCRetentionAnnotationKey]; value != "" {
+		for _, item := range strings.Split(value, ",") {
+			name, p, _ := strings.Cut(item, "=")
+			policies[name] = p
+		}
+	}
+	if policy == "" || policy == appsv1alpha1.ScaleInPVCRetentionPolicyDelete {
+		delete(policies, compName)
+	} else {
+		policies[compName] = string(policy)
+	}
+	if len(policies) == 0 {
+		delete(cluster.Annotations, constant.ScaleInPVCRetentionAnnotationKey)
+		return
+	}
+	items := make([]string, 0, len(policies))
+	for name, p := range policies {
+		items = append(items, fmt.Sprintf("%s=%s", name, p))
+	}
+	slices.Sort(items)
+	if cluster.Annotations == nil {
+		cluster.Annotations = map[string]string{}
+	}
+	cluster.Annotations[constant.ScaleInPVCRetentionAnnotationKey] = strings.Join(items, ",")
 }
 
 // SaveLastConfiguration records last configuration to the OpsRequest.status.lastConfiguration
@@ -175,6 +264,68 @@ func (hs horizontalScalingOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.R
 	return nil
 }
 
+// Plan computes the expected replicas and instances of the components, and the pods to be created and deleted.
+func (hs horizontalScalingOpsHandler) Plan(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*appsv1alpha1.OpsPlan, error) {
+	plan := &appsv1alpha1.OpsPlan{Components: map[string]appsv1alpha1.OpsComponentPlan{}}
+	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.HorizontalScalingList)
+	planComponent := func(compSpec appsv1alpha1.ClusterComponentSpec, componentName string, fullCompNames []string) error {
+		obj, ok := compOpsHelper.componentOpsSet[componentName]
+		if !ok {
+			return nil
+		}
+		horizontalScaling := obj.(appsv1alpha1.HorizontalScaling)
+		lastCompConfiguration := opsRes.OpsRequest.Status.LastConfiguration.Components[componentName]
+		replicas, instances, offlineInstances, err := hs.getExpectedCompValues(opsRes, compSpec.DeepCopy(),
+			lastCompConfiguration, horizontalScaling)
+		if err != nil {
+			return err
+		}
+		compPlan := appsv1alpha1.OpsComponentPlan{
+			CurrentReplicas:  lastCompConfiguration.Replicas,
+			Replicas:         pointer.Int32(replicas),
+			Instances:        instances,
+			OfflineInstances: offlineInstances,
+		}
+		podsToCreate, podsToDelete := sets.New[string](), sets.New[string]()
+		for _, fullCompName := range fullCompNames {
+			createdPodSet, deletedPodSet, err := hs.getCreateAndDeletePodSet(opsRes, lastCompConfiguration, compSpec,
+				horizontalScaling, fullCompName)
+			if err != nil {
+				return err
+			}
+			podsToCreate.Insert(sets.List(sets.KeySet(createdPodSet))...)
+			podsToDelete.Insert(sets.List(sets.KeySet(deletedPodSet))...)
+		}
+		compPlan.PodsToCreate = sets.List(podsToCreate)
+		compPlan.PodsToDelete = sets.List(podsToDelete)
+		plan.Components[componentName] = compPlan
+		return nil
+	}
+
+	for _, compSpec := range opsRes.Cluster.Spec.ComponentSpecs {
+		if err := planComponent(compSpec, compSpec.Name, []string{compSpec.Name}); err != nil {
+			return nil, err
+		}
+	}
+	for _, shardingSpec := range opsRes.Cluster.Spec.ShardingSpecs {
+		if _, ok := compOpsHelper.componentOpsSet[shardingSpec.Name]; !ok {
+			continue
+		}
+		shardingComps, err := intctrlutil.ListShardingComponents(reqCtx.Ctx, cli, opsRes.Cluster, shardingSpec.Name)
+		if err != nil {
+			return nil, err
+		}
+		var fullCompNames []string
+		for _, comp := range shardingComps {
+			fullCompNames = append(fullCompNames, comp.Labels[constant.KBAppComponentLabelKey])
+		}
+		if err = planComponent(shardingSpec.Template, shardingSpec.Name, fullCompNames); err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
+}
+
 // getCreateAndDeletePodSet gets the pod set that are created and deleted in this opsRequest.
 func (hs horizontalScalingOpsHandler) getCreateAndDeletePodSet(opsRes *OpsResource,
 	lastCompConfiguration appsv1alpha1.LastComponentConfiguration,
@@ -218,11 +369,7 @@ func (hs horizontalScalingOpsHandler) getCreateAndDeletePodSet(opsRes *OpsResour
 // Cancel this function defines the cancel horizontalScaling action.
 func (hs horizontalScalingOpsHandler) Cancel(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
 	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.HorizontalScalingList)
-	if err := compOpsHelper.cancelComponentOps(reqCtx.Ctx, cli, opsRes, func(lastConfig *appsv1alpha1.LastComponentConfiguration, comp *appsv1alpha1.ClusterComponentSpec) {
-		comp.Replicas = *lastConfig.Replicas
-		comp.Instances = lastConfig.Instances
-		comp.OfflineInstances = lastConfig.OfflineInstances
-	}); err != nil {
+	if err := compOpsHelper.cancelComponentOps(reqCtx.Ctx, cli, opsRes, rollbackHorizontalScaling); err != nil {
 		return err
 	}
 	// delete the running restore resource to release PVC of the pod which will be deleted after cancelling the ops.
@@ -282,6 +429,8 @@ func (hs horizontalScalingOpsHandler) checkIntersectionWithEarlierOps(opsRes *Op
 		if _, ok := createdPodSetForEarlier[deletedPod]; ok {
 			errMsg := fmt.Sprintf(`instance "%s" cannot be taken offline as it has been created by another running opsRequest "%s"`,
 				deletedPod, earlierOps.Name)
+			opsRes.OpsRequest.AppendTimelineEntry(appsv1alpha1.AbortedTimelineEntry, "IntersectionWithEarlierOpsRequest",
+				getProgressObjectKey(constant.OpsRequestKind, earlierOps.Name), errMsg)
 			return intctrlutil.NewFatalError(errMsg)
 		}
 	}
@@ -370,7 +519,7 @@ func (hs horizontalScalingOpsHandler) autoSyncReplicaChanges(
 			insTplName := appsv1alpha1.GetInstanceTemplateName(opsRes.Cluster.Name, horizontalScaling.ComponentName, insName)
 			onlineInsCountMap[insTplName]++
 		}
-		scaleOut.Instances, scaleOut.ReplicaChanges = getSyncedInstancesAndReplicaChanges(onlineInsCountMap, scaleOut.ReplicaChanger, scaleOut.NewInstances)
+		scaleOut.Instances, scaleOut.ReplicaChanges = getSyncedInstancesAndReplicaChanges(onlineInsCountMap, scaleOut.ReplicaChanger, scaleOut.GetNewInstances())
 	}
 	return nil
 }
@@ -416,7 +565,7 @@ func (hs horizontalScalingOpsHandler) getCompExpectedInstances(
 		}
 	}
 	if horizontalScaling.ScaleOut != nil {
-		compInstanceTpls = append(compInstanceTpls, horizontalScaling.ScaleOut.NewInstances...)
+		compInstanceTpls = append(compInstanceTpls, horizontalScaling.ScaleOut.GetNewInstances()...)
 		handleInstanceTplReplicaChanges(horizontalScaling.ScaleOut.Instances, false)
 	}
 	if horizontalScaling.ScaleIn != nil {
@@ -447,3 +596,131 @@ func (hs horizontalScalingOpsHandler) getCompExpectedOfflineInstances(
 	}
 	return compOfflineInstances
 }
+
+// checkScaleInReplicationLag checks that the instances to be taken offline are not the only up-to-date replicas
+// of the replication component (or of each shard), the replication lag of each secondary replica is reported by its agent.
+// The check fails closed, it doesn't pass if the replication lag of any secondary replica can't be retrieved.
+func (hs horizontalScalingOpsHandler) checkScaleInReplicationLag(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	compSpec *appsv1alpha1.ClusterComponentSpec,
+	lastCompConfiguration appsv1alpha1.LastComponentConfiguration,
+	scaleIn *appsv1alpha1.ScaleIn,
+	expectReplicas int32,
+	expectInstances []appsv1alpha1.InstanceTemplate,
+	expectOfflineInstances []string) error {
+	if scaleIn == nil || scaleIn.MaxReplicationLag == nil {
+		return nil
+	}
+	fullCompNames, err := getFullComponentNames(reqCtx, cli, opsRes.Cluster, compSpec.Name)
+	if err != nil || len(fullCompNames) == 0 {
+		return err
+	}
+	// the shards of a sharding share the same roles.
+	writableRoles, err := getWritableRoles(reqCtx, cli, opsRes, compSpec, fullCompNames[0])
+	if err != nil {
+		return err
+	}
+	if writableRoles.Len() == 0 {
+		// not a replication component.
+		return nil
+	}
+	checkFailed := func(errMsg string) error {
+		if scaleIn.FailOnReplicationLag {
+			return intctrlutil.NewFatalError(errMsg)
+		}
+		return intctrlutil.NewTransientError(errMsg)
+	}
+	clusterName := opsRes.Cluster.Name
+	for _, fullCompName := range fullCompNames {
+		lastPodSet, err := intctrlcomp.GenerateAllPodNamesToSet(*lastCompConfiguration.Replicas, lastCompConfiguration.Instances,
+			lastCompConfiguration.OfflineInstances, clusterName, fullCompName)
+		if err != nil {
+			return err
+		}
+		expectPodSet, err := intctrlcomp.GenerateAllPodNamesToSet(expectReplicas, expectInstances, expectOfflineInstances, clusterName, fullCompName)
+		if err != nil {
+			return err
+		}
+		pods, err := intctrlcomp.ListOwnedPods(reqCtx.Ctx, cli, opsRes.Cluster.Namespace, clusterName, fullCompName)
+		if err != nil {
+			return err
+		}
+		var hasDeleted, deletedUpToDate, remainingUpToDate bool
+		for _, pod := range pods {
+			if writableRoles.Has(pod.Labels[constant.RoleLabelKey]) {
+				continue
+			}
+			_, last := lastPodSet[pod.Name]
+			_, expected := expectPodSet[pod.Name]
+			lag, err := getReplicationLag(reqCtx, pod)
+			if err != nil {
+				return checkFailed(fmt.Sprintf(`failed to get the replication lag of pod "%s": %s`, pod.Name, err.Error()))
+			}
+			upToDate := lag <= *scaleIn.MaxReplicationLag
+			if last && !expected {
+				hasDeleted = true
+				deletedUpToDate = deletedUpToDate || upToDate
+			} else {
+				remainingUpToDate = remainingUpToDate || upToDate
+			}
+		}
+		if hasDeleted && deletedUpToDate && !remainingUpToDate {
+			return checkFailed(fmt.Sprintf(`the instances to be taken offline are the only up-to-date replicas of component "%s", `+
+				`none of the remaining replicas has a replication lag not greater than %d`, fullCompName, *scaleIn.MaxReplicationLag))
+		}
+	}
+	return nil
+}
+
+// getWritableRoles returns the writable roles defined for the component.
+func getWritableRoles(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	compSpec *appsv1alpha1.ClusterComponentSpec,
+	fullCompName string) (sets.Set[string], error) {
+	var roles []appsv1alpha1.ReplicaRole
+	if len(compSpec.ComponentDef) > 0 {
+		_, compDef, err := intctrlcomp.GetCompNCompDefByName(reqCtx.Ctx, cli, opsRes.Cluster.Namespace,
+			constant.GenerateClusterComponentName(opsRes.Cluster.Name, fullCompName))
+		if err != nil {
+			return nil, err
+		}
+		roles = compDef.Spec.Roles
+	} else {
+		synthesizedComp, err := buildSynthesizedComp(reqCtx, cli, opsRes, compSpec)
+		if err != nil {
+			return nil, err
+		}
+		roles = synthesizedComp.Roles
+	}
+	writableRoles := sets.New[string]()
+	for _, role := range roles {
+		if role.Writable {
+			writableRoles.Insert(role.Name)
+		}
+	}
+	return writableRoles, nil
+}
+
+// getReplicationLag returns the replication lag reported by the agent of the pod.
+func getReplicationLag(reqCtx intctrlutil.RequestCtx, pod *corev1.Pod) (int64, error) {
+	lorryCli, err := lorry.NewClient(*pod)
+	if err != nil {
+		return 0, err
+	}
+	if intctrlutil.IsNil(lorryCli) {
+		return 0, fmt.Errorf("the agent of pod %s is not available", pod.Name)
+	}
+	return lorryCli.GetLag(reqCtx.Ctx)
+}
+
+// rollbackHorizontalScaling reverts the replicas and instances of the component to the last configuration.
+func rollbackHorizontalScaling(lastConfig *appsv1alpha1.LastComponentConfiguration, comp *appsv1alpha1.ClusterComponentSpec) {
+	if lastConfig.Replicas == nil {
+		return
+	}
+	comp.Replicas = *lastConfig.Replicas
+	comp.Instances = lastConfig.Instances
+	comp.OfflineInstances = lastConfig.OfflineInstances
+}
@@ -0,0 +1,214 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlcomp "github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	kbagent "github.com/apecloud/kubeblocks/pkg/kb_agent/client"
+)
+
+// getFullComponentNames returns the names of the components of the component or sharding.
+func getFullComponentNames(reqCtx intctrlutil.RequestCtx, cli client.Client, cluster *appsv1alpha1.Cluster, compName string) ([]string, error) {
+	if cluster.Spec.GetShardingByName(compName) == nil {
+		return []string{compName}, nil
+	}
+	shardingComps, err := intctrlutil.ListShardingComponents(reqCtx.Ctx, cli, cluster, compName)
+	if err != nil {
+		return nil, err
+	}
+	var fullCompNames []string
+	for _, comp := range shardingComps {
+		fullCompNames = append(fullCompNames, comp.Labels[constant.KBAppComponentLabelKey])
+	}
+	return fullCompNames, nil
+}
+
+// listPodsToScaleIn lists the existing pods which will be deleted when the component is scaled to the expected values.
+func listPodsToScaleIn(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	compName string,
+	lastCompConfiguration appsv1alpha1.LastComponentConfiguration,
+	expectReplicas int32,
+	expectInstances []appsv1alpha1.InstanceTemplate,
+	expectOfflineInstances []string) ([]*corev1.Pod, error) {
+	clusterName := opsRes.Cluster.Name
+	fullCompNames, err := getFullComponentNames(reqCtx, cli, opsRes.Cluster, compName)
+	if err != nil {
+		return nil, err
+	}
+	var pods []*corev1.Pod
+	for _, fullCompName := range fullCompNames {
+		lastPodSet, err := intctrlcomp.GenerateAllPodNamesToSet(*lastCompConfiguration.Replicas, lastCompConfiguration.Instances,
+			lastCompConfiguration.OfflineInstances, clusterName, fullCompName)
+		if err != nil {
+			return nil, err
+		}
+		expectPodSet, err := intctrlcomp.GenerateAllPodNamesToSet(expectReplicas, expectInstances, expectOfflineInstances, clusterName, fullCompName)
+		if err != nil {
+			return nil, err
+		}
+		compPods, err := intctrlcomp.ListOwnedPods(reqCtx.Ctx, cli, opsRes.Cluster.Namespace, clusterName, fullCompName)
+		if err != nil {
+			return nil, err
+		}
+		for _, pod := range compPods {
+			_, last := lastPodSet[pod.Name]
+			_, expected := expectPodSet[pod.Name]
+			if last && !expected {
+				pods = append(pods, pod)
+			}
+		}
+	}
+	return pods, nil
+}
+
+// invokePreScaleInActions invokes the pre-scale-in actions on the pods to be deleted of the component.
+func invokePreScaleInActions(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	horizontalScaling appsv1alpha1.HorizontalScaling,
+	lastCompConfiguration appsv1alpha1.LastComponentConfiguration,
+	expectReplicas int32,
+	expectInstances []appsv1alpha1.InstanceTemplate,
+	expectOfflineInstances []string) error {
+	if len(horizontalScaling.PreScaleInActions) == 0 {
+		return nil
+	}
+	pods, err := listPodsToScaleIn(reqCtx, cli, opsRes, horizontalScaling.ComponentName, lastCompConfiguration,
+		expectReplicas, expectInstances, expectOfflineInstances)
+	if err != nil {
+		return err
+	}
+	opsRequest := opsRes.OpsRequest
+	if opsRequest.Status.Components == nil {
+		opsRequest.Status.Components = map[string]appsv1alpha1.OpsRequestComponentStatus{}
+	}
+	compStatus := opsRequest.Status.Components[horizontalScaling.ComponentName]
+	defer func() {
+		opsRequest.Status.Components[horizontalScaling.ComponentName] = compStatus
+	}()
+	return invokeScalingActions(reqCtx, cli, opsRes, &compStatus, horizontalScaling.PreScaleInActions, pods)
+}
+
+// invokePostScaleOutActions invokes the post-scale-out actions on the created pods of the component once they are ready.
+func invokePostScaleOutActions(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	pgRes *progressResource,
+	compStatus *appsv1alpha1.OpsRequestComponentStatus) error {
+	horizontalScaling := pgRes.compOps.(appsv1alpha1.HorizontalScaling)
+	if len(horizontalScaling.PostScaleOutActions) == 0 || len(pgRes.createdPodSet) == 0 ||
+		opsRes.OpsRequest.Status.Phase == appsv1alpha1.OpsCancellingPhase {
+		return nil
+	}
+	compPods, err := intctrlcomp.ListOwnedPods(reqCtx.Ctx, cli, opsRes.Cluster.Namespace, opsRes.Cluster.Name, pgRes.fullComponentName)
+	if err != nil {
+		return err
+	}
+	var pods []*corev1.Pod
+	for _, pod := range compPods {
+		if _, ok := pgRes.createdPodSet[pod.Name]; ok && intctrlutil.PodIsReady(pod) {
+			pods = append(pods, pod)
+		}
+	}
+	return invokeScalingActions(reqCtx, cli, opsRes, compStatus, horizontalScaling.PostScaleOutActions, pods)
+}
+
+// invokeScalingActions invokes the actions in order on each pod through the kb-agent, the invoked actions
+// are recorded in the status of the component and won't be invoked again.
+func invokeScalingActions(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	compStatus *appsv1alpha1.OpsRequestComponentStatus,
+	actions []appsv1alpha1.ScalingAction,
+	pods []*corev1.Pod) error {
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Name < pods[j].Name
+	})
+	for _, pod := range pods {
+		for _, action := range actions {
+			key := fmt.Sprintf("%s/%s", action.Name, pod.Name)
+			if slices.Contains(compStatus.InvokedActions, key) {
+				continue
+			}
+			if err := invokeScalingAction(reqCtx, cli, opsRes, pod, action); err != nil {
+				if !intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+					return err
+				}
+				if action.FailurePolicy != appsv1alpha1.ScalingActionFailurePolicyIgnore {
+					return err
+				}
+				opsRes.Recorder.Eventf(opsRes.OpsRequest, corev1.EventTypeWarning, "ScalingActionFailed",
+					"ignore the failure of the action %s: %s", key, err.Error())
+			}
+			compStatus.InvokedActions = append(compStatus.InvokedActions, key)
+		}
+	}
+	return nil
+}
+
+// invokeScalingAction invokes the action on the pod through the kb-agent, it returns a fatal error if the action fails
+// or it was interrupted by the last shutdown of the operator, since the action may be not idempotent.
+func invokeScalingAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource,
+	pod *corev1.Pod, action appsv1alpha1.ScalingAction) error {
+	agentCli, err := kbagent.NewClient(*pod)
+	if err != nil {
+		return err
+	}
+	if intctrlutil.IsNil(agentCli) {
+		return intctrlutil.NewFatalError(fmt.Sprintf(`the instance "%s" doesn't run the kb-agent to invoke the action "%s"`,
+			pod.Name, action.Name))
+	}
+	parameters := map[string]any{}
+	for k, v := range action.Parameters {
+		parameters[k] = v
+	}
+	invocationKey := fmt.Sprintf("%s.%s.%s", opsRes.OpsRequest.UID, action.Name, pod.Name)
+	handedOff, err := intctrlutil.InvokeNonIdempotent(reqCtx.Ctx, cli, invocationKey, func(ctx context.Context) error {
+		_, err := agentCli.Action(ctx, action.Name, parameters)
+		return err
+	})
+	if handedOff {
+		return intctrlutil.NewFatalError(fmt.Sprintf(`the action "%s" on the instance "%s" was interrupted by the shutdown of the operator, the outcome is unknown`,
+			action.Name, pod.Name))
+	}
+	if err != nil {
+		if errors.Is(err, kbagent.ErrActionFailed) {
+			return intctrlutil.NewFatalError(fmt.Sprintf(`the action "%s" failed on the instance "%s": %s`,
+				action.Name, pod.Name, err.Error()))
+		}
+		return err
+	}
+	reqCtx.Log.Info("invoked the scaling action", "action", action.Name, "pod", pod.Name)
+	return nil
+}
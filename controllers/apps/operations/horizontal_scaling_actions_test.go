@@ -0,0 +1,102 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	kbagent "github.com/apecloud/kubeblocks/pkg/kb_agent/client"
+	kbagentutil "github.com/apecloud/kubeblocks/pkg/kb_agent/util"
+)
+
+type mockAgentClient struct {
+	invoked []string
+	failed  map[string]bool
+}
+
+func (c *mockAgentClient) Action(_ context.Context, action string, _ map[string]any) (string, error) {
+	c.invoked = append(c.invoked, action)
+	if c.failed[action] {
+		return "", fmt.Errorf("%w: exit status 1", kbagent.ErrActionFailed)
+	}
+	return "", nil
+}
+
+func (c *mockAgentClient) Refresh(_ context.Context, _ map[string]kbagentutil.HandlerSpec) error {
+	return nil
+}
+
+var _ = Describe("horizontal scaling actions", func() {
+	var (
+		agentCli *mockAgentClient
+		opsRes   *OpsResource
+		reqCtx   intctrlutil.RequestCtx
+		pods     []*corev1.Pod
+	)
+
+	BeforeEach(func() {
+		agentCli = &mockAgentClient{failed: map[string]bool{"rebalance": true}}
+		kbagent.SetMockClient(agentCli, nil)
+		opsRes = &OpsResource{
+			OpsRequest: &appsv1alpha1.OpsRequest{ObjectMeta: metav1.ObjectMeta{Name: "ops"}},
+			Recorder:   record.NewFakeRecorder(10),
+		}
+		reqCtx = intctrlutil.RequestCtx{Ctx: context.Background(), Log: logr.Discard()}
+		pods = []*corev1.Pod{
+			{ObjectMeta: metav1.ObjectMeta{Name: "pod-1"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "pod-0"}},
+		}
+	})
+
+	AfterEach(func() {
+		kbagent.UnsetMockClient()
+	})
+
+	It("invokes the actions on each pod once", func() {
+		compStatus := &appsv1alpha1.OpsRequestComponentStatus{}
+		actions := []appsv1alpha1.ScalingAction{{Name: "drain"}, {Name: "rebalance", FailurePolicy: appsv1alpha1.ScalingActionFailurePolicyIgnore}}
+		Expect(invokeScalingActions(reqCtx, k8sClient, opsRes, compStatus, actions, pods)).Should(Succeed())
+		Expect(agentCli.invoked).Should(Equal([]string{"drain", "rebalance", "drain", "rebalance"}))
+		Expect(compStatus.InvokedActions).Should(Equal([]string{"drain/pod-0", "rebalance/pod-0", "drain/pod-1", "rebalance/pod-1"}))
+
+		By("expect the invoked actions to be skipped")
+		Expect(invokeScalingActions(reqCtx, k8sClient, opsRes, compStatus, actions, pods)).Should(Succeed())
+		Expect(agentCli.invoked).Should(HaveLen(4))
+	})
+
+	It("aborts if the action fails", func() {
+		compStatus := &appsv1alpha1.OpsRequestComponentStatus{}
+		actions := []appsv1alpha1.ScalingAction{{Name: "rebalance", FailurePolicy: appsv1alpha1.ScalingActionFailurePolicyAbort}}
+		err := invokeScalingActions(reqCtx, k8sClient, opsRes, compStatus, actions, pods)
+		Expect(intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal)).Should(BeTrue())
+		Expect(compStatus.InvokedActions).Should(BeEmpty())
+	})
+})
@@ -27,6 +27,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/pointer"
@@ -256,6 +257,38 @@ var _ = Describe("HorizontalScaling OpsRequest", func() {
 			})
 		})
 
+		It("fails the opsRequest if no progress is made within the progress deadline", func() {
+			By("scale out replicas from 3 to 5 with the progress deadline")
+			horizontalScaling := appsv1alpha1.HorizontalScaling{
+				ScaleOut:                &appsv1alpha1.ScaleOut{},
+				ProgressDeadlineSeconds: pointer.Int32(60),
+			}
+			horizontalScaling.ScaleOut.ReplicaChanges = pointer.Int32(2)
+			reqCtx := intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
+			opsRes, _ := commonHScaleConsensusCompTest(reqCtx, nil, horizontalScaling)
+			compStatus := opsRes.OpsRequest.Status.Components[defaultCompName]
+			Expect(compStatus.LastProgressTime.IsZero()).Should(BeFalse())
+
+			By("expect the opsRequest is still running within the progress deadline")
+			requeueAfter, err := GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(opsRes.OpsRequest.Status.Phase).Should(Equal(appsv1alpha1.OpsRunningPhase))
+			Expect(requeueAfter).Should(BeNumerically(">", 0))
+
+			By("mock no pod is created within the progress deadline")
+			compStatus = opsRes.OpsRequest.Status.Components[defaultCompName]
+			compStatus.LastProgressTime = metav1.NewTime(time.Now().Add(-time.Minute))
+			opsRes.OpsRequest.Status.Components[defaultCompName] = compStatus
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(opsRes.OpsRequest.Status.Phase).Should(Equal(appsv1alpha1.OpsFailedPhase))
+			for _, v := range opsRes.OpsRequest.Status.Components[defaultCompName].ProgressDetails {
+				Expect(v.Status).Should(Equal(appsv1alpha1.FailedProgressStatus))
+			}
+			Expect(meta.IsStatusConditionTrue(opsRes.OpsRequest.Status.Conditions,
+				appsv1alpha1.ConditionTypeProgressDeadlineExceeded)).Should(BeTrue())
+		})
+
 		It("cancel the opsRequest which scaling in replicas with `replicas`", func() {
 			By("scale in replicas of component from 3 to 1")
 			testCancelHScale(appsv1alpha1.HorizontalScaling{Replicas: pointer.Int32(1)}, true)
@@ -500,6 +533,44 @@ var _ = Describe("HorizontalScaling OpsRequest", func() {
 				fmt.Sprintf(`instance "%s" specified in onlineInstancesToOffline is not online`, offlineInsName)))
 		})
 
+		It("dry run the opsRequest which scaling in replicas with `scaleIn`", func() {
+			By("init operations resources with CLusterDefinition/Hybrid components Cluster/consensus Pods")
+			opsRes, _, _ := initOperationsResources(compDefName, clusterName)
+			testapps.MockInstanceSetComponent(&testCtx, clusterName, defaultCompName)
+			initClusterAnnotationAndPhaseForOps(opsRes)
+			reqCtx := intctrlutil.RequestCtx{Ctx: ctx}
+			lastReplicas := opsRes.Cluster.Spec.GetComponentByName(defaultCompName).Replicas
+
+			By("create a dry-run opsRequest to scale in one replica")
+			ops := testapps.NewOpsRequestObj("horizontal-scaling-ops-"+testCtx.GetRandomStr(), testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.HorizontalScalingType)
+			ops.Spec.DryRun = true
+			ops.Spec.HorizontalScalingList = []appsv1alpha1.HorizontalScaling{
+				{
+					ComponentOps: appsv1alpha1.ComponentOps{ComponentName: defaultCompName},
+					ScaleIn:      &appsv1alpha1.ScaleIn{ReplicaChanger: appsv1alpha1.ReplicaChanger{ReplicaChanges: pointer.Int32(1)}},
+				},
+			}
+			opsRes.OpsRequest = testapps.CreateOpsRequest(ctx, testCtx, ops)
+			opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsPendingPhase
+
+			By("expect the plan is recorded and the cluster is not changed")
+			_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest), func(g Gomega, ops *appsv1alpha1.OpsRequest) {
+				g.Expect(ops.Status.Phase).Should(Equal(appsv1alpha1.OpsSucceedPhase))
+				g.Expect(ops.Status.Plan).ShouldNot(BeNil())
+				compPlan := ops.Status.Plan.Components[defaultCompName]
+				g.Expect(*compPlan.CurrentReplicas).Should(Equal(lastReplicas))
+				g.Expect(*compPlan.Replicas).Should(Equal(lastReplicas - 1))
+				g.Expect(compPlan.PodsToCreate).Should(BeEmpty())
+				g.Expect(compPlan.PodsToDelete).Should(Equal([]string{fmt.Sprintf("%s-%s-%d", clusterName, defaultCompName, lastReplicas-1)}))
+			})).Should(Succeed())
+			Consistently(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.Cluster), func(g Gomega, cluster *appsv1alpha1.Cluster) {
+				g.Expect(cluster.Spec.GetComponentByName(defaultCompName).Replicas).Should(Equal(lastReplicas))
+			})).Should(Succeed())
+		})
+
 		It("test run multi horizontalScaling opsRequest with force flag", func() {
 			By("init operations resources with CLusterDefinition/Hybrid components Cluster/consensus Pods")
 			opsRes, _, _ := initOperationsResources(compDefName, clusterName)
@@ -528,6 +599,10 @@ var _ = Describe("HorizontalScaling OpsRequest", func() {
 			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(appsv1alpha1.OpsFailedPhase))
 			conditions := opsRes.OpsRequest.Status.Conditions
 			Expect(conditions[len(conditions)-1].Message).Should(ContainSubstring(fmt.Sprintf(`instance "%s" cannot be taken offline as it has been created by another running opsRequest`, offlineInsName)))
+			Expect(opsRes.OpsRequest.Status.Timeline).Should(ContainElement(And(
+				HaveField("Type", appsv1alpha1.AbortedTimelineEntry),
+				HaveField("Reason", "IntersectionWithEarlierOpsRequest"),
+				HaveField("ObjectKey", getProgressObjectKey(constant.OpsRequestKind, ops1.Name)))))
 
 			By("create a opsRequest to delete 1 replicas which is created by another running opsRequest and expect it to fail")
 			_ = createOpsAndToCreatingPhase(reqCtx, opsRes, appsv1alpha1.HorizontalScaling{
@@ -544,6 +619,13 @@ var _ = Describe("HorizontalScaling OpsRequest", func() {
 			})
 			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(ops1))).Should(Equal(appsv1alpha1.OpsAbortedPhase))
 			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(ops2))).Should(Equal(appsv1alpha1.OpsAbortedPhase))
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(ops1), func(g Gomega, ops *appsv1alpha1.OpsRequest) {
+				timeline := ops.Status.Timeline
+				g.Expect(timeline).ShouldNot(BeEmpty())
+				g.Expect(timeline[len(timeline)-1].Type).Should(Equal(appsv1alpha1.PhaseChangedTimelineEntry))
+				g.Expect(timeline[len(timeline)-1].Reason).Should(BeEquivalentTo(appsv1alpha1.OpsAbortedPhase))
+			})).Should(Succeed())
+			Expect(ops3.Status.Timeline).Should(ContainElement(HaveField("ObjectKey", getProgressObjectKey(constant.OpsRequestKind, ops1.Name))))
 
 			By("create a opsRequest with `scaleOut` field and expect to abort last running ops")
 			// if running opsRequest exists an overwrite replicas operation for a component or instanceTemplate, need to abort.
@@ -0,0 +1,93 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+	"fmt"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// opsApproveVerb is the verb on the opsrequests resource which allows a subject to approve the OpsRequests.
+const opsApproveVerb = "approve"
+
+// authorizeApprover checks whether the approver is allowed to approve the OpsRequest with a SubjectAccessReview,
+// it returns the reason if the approver is not allowed.
+// The groups of the approver are unknown to the controller, so the approve verb should be granted to the
+// approver directly rather than to its groups.
+var authorizeApprover = func(ctx context.Context, cli client.Client, opsRequest *appsv1alpha1.OpsRequest, approver string) (bool, string, error) {
+	sar := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User: approver,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: opsRequest.Namespace,
+				Verb:      opsApproveVerb,
+				Group:     appsv1alpha1.GroupVersion.Group,
+				Resource:  "opsrequests",
+				Name:      opsRequest.Name,
+			},
+		},
+	}
+	if err := cli.Create(ctx, sar); err != nil {
+		return false, "", err
+	}
+	if sar.Status.Allowed {
+		return true, "", nil
+	}
+	if sar.Status.Reason != "" {
+		return false, sar.Status.Reason, nil
+	}
+	return false, fmt.Sprintf(`"%s" is not allowed to %s the OpsRequests in namespace "%s"`,
+		approver, opsApproveVerb, opsRequest.Namespace), nil
+}
+
+// checkApproval handles the OpsRequest in the PendingApproval phase, it moves the OpsRequest back to the Pending phase
+// with the Approved condition once the approver recorded in the annotation is verified.
+func checkApproval(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*ctrl.Result, error) {
+	opsRequest := opsRes.OpsRequest
+	approver := opsRequest.Annotations[constant.OpsApprovedByAnnotationKey]
+	if approver == "" {
+		// the annotation change triggers the next reconciliation.
+		return intctrlutil.ResultToP(intctrlutil.Reconciled())
+	}
+	allowed, reason, err := authorizeApprover(reqCtx.Ctx, cli, opsRequest, approver)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		condition := appsv1alpha1.NewApprovalRejectedCondition(opsRequest, approver, reason)
+		if last := meta.FindStatusCondition(opsRequest.Status.Conditions, appsv1alpha1.ConditionTypeApproved); last != nil &&
+			last.Reason == condition.Reason && last.Message == condition.Message {
+			return intctrlutil.ResultToP(intctrlutil.Reconciled())
+		}
+		return intctrlutil.ResultToP(intctrlutil.Reconciled()), PatchOpsStatus(reqCtx.Ctx, cli, opsRes,
+			appsv1alpha1.OpsPendingApprovalPhase, condition)
+	}
+	return &ctrl.Result{}, PatchOpsStatus(reqCtx.Ctx, cli, opsRes, appsv1alpha1.OpsPendingPhase,
+		appsv1alpha1.NewApprovedCondition(opsRequest, approver))
+}
@@ -0,0 +1,106 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+var _ = Describe("ops approval", func() {
+	var (
+		reqCtx             = intctrlutil.RequestCtx{Ctx: context.Background(), Log: logr.Discard()}
+		originAuthorizer   = authorizeApprover
+		allowedApprovers   map[string]bool
+		authorizedSubjects []string
+	)
+
+	BeforeEach(func() {
+		allowedApprovers = map[string]bool{"alice": true}
+		authorizedSubjects = nil
+		authorizeApprover = func(_ context.Context, _ client.Client, _ *appsv1alpha1.OpsRequest, approver string) (bool, string, error) {
+			authorizedSubjects = append(authorizedSubjects, approver)
+			if allowedApprovers[approver] {
+				return true, "", nil
+			}
+			return false, "not allowed", nil
+		}
+	})
+
+	AfterEach(func() {
+		authorizeApprover = originAuthorizer
+	})
+
+	It("holds the OpsRequest until it is approved by an allowed approver", func() {
+		opsRequest := &appsv1alpha1.OpsRequest{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "stop-ops"},
+			Spec:       appsv1alpha1.OpsRequestSpec{ClusterName: "mycluster", Type: appsv1alpha1.StopType, ApprovalRequired: true},
+			Status:     appsv1alpha1.OpsRequestStatus{Phase: appsv1alpha1.OpsPendingApprovalPhase},
+		}
+		opsRequest.SetStatusCondition(*appsv1alpha1.NewWaitingForApprovalCondition(opsRequest))
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+		Expect(appsv1alpha1.AddToScheme(scheme)).Should(Succeed())
+		cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(opsRequest).WithStatusSubresource(opsRequest).Build()
+		opsRes := &OpsResource{OpsRequest: opsRequest, Recorder: record.NewFakeRecorder(10)}
+
+		By("waits for the annotation")
+		_, err := checkApproval(reqCtx, cli, opsRes)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(opsRequest.Status.Phase).Should(Equal(appsv1alpha1.OpsPendingApprovalPhase))
+		Expect(authorizedSubjects).Should(BeEmpty())
+
+		By("rejects the approver which isn't allowed to approve")
+		opsRequest.Annotations = map[string]string{constant.OpsApprovedByAnnotationKey: "bob"}
+		_, err = checkApproval(reqCtx, cli, opsRes)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(opsRequest.Status.Phase).Should(Equal(appsv1alpha1.OpsPendingApprovalPhase))
+		condition := meta.FindStatusCondition(opsRequest.Status.Conditions, appsv1alpha1.ConditionTypeApproved)
+		Expect(condition).ShouldNot(BeNil())
+		Expect(condition.Reason).Should(Equal(appsv1alpha1.ReasonApprovalRejected))
+		Expect(opsRequest.IsApproved()).Should(BeFalse())
+
+		By("moves back to Pending once approved, with the approver recorded")
+		opsRequest.Annotations[constant.OpsApprovedByAnnotationKey] = "alice"
+		_, err = checkApproval(reqCtx, cli, opsRes)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(opsRequest.Status.Phase).Should(Equal(appsv1alpha1.OpsPendingPhase))
+		Expect(opsRequest.IsApproved()).Should(BeTrue())
+		condition = meta.FindStatusCondition(opsRequest.Status.Conditions, appsv1alpha1.ConditionTypeApproved)
+		Expect(condition.Reason).Should(Equal(appsv1alpha1.ReasonApproved))
+		Expect(condition.Message).Should(ContainSubstring(`"alice"`))
+		Expect(authorizedSubjects).Should(Equal([]string{"bob", "alice"}))
+	})
+})
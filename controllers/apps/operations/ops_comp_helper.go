@@ -22,10 +22,11 @@ package operations
 import (
 	"context"
 	"fmt"
-	"reflect"
 	"slices"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
@@ -53,6 +54,69 @@ func newComponentOpsHelper[T ComponentOpsInterface](compOpsList []T) componentOp
 	return compOpsHelper
 }
 
+// getSerialExecutionOrder returns the names of the components in the order to apply the changes,
+// or nil if the changes are applied to all the components at once.
+func getSerialExecutionOrder[T ComponentOpsInterface](ops *appsv1alpha1.OpsRequest, compOpsList []T) []string {
+	executionOrder := ops.Spec.ExecutionOrder
+	if executionOrder == nil || executionOrder.Policy != appsv1alpha1.SerialExecutionPolicy {
+		return nil
+	}
+	order := slices.Clone(executionOrder.Components)
+	for i := range compOpsList {
+		if compName := compOpsList[i].GetComponentName(); !slices.Contains(order, compName) {
+			order = append(order, compName)
+		}
+	}
+	return order
+}
+
+// withExecutedComponents returns the helper which only contains the components the changes have been applied to,
+// if the changes are applied component-by-component.
+func (c componentOpsHelper) withExecutedComponents(ops *appsv1alpha1.OpsRequest, order []string) componentOpsHelper {
+	if len(order) == 0 {
+		return c
+	}
+	if len(ops.Status.ExecutedComponents) == 0 {
+		ops.Status.ExecutedComponents = []string{order[0]}
+	}
+	executed := componentOpsHelper{componentOpsSet: map[string]ComponentOpsInterface{}}
+	for _, compName := range ops.Status.ExecutedComponents {
+		if compOps, ok := c.componentOpsSet[compName]; ok {
+			executed.componentOpsSet[compName] = compOps
+		}
+	}
+	return executed
+}
+
+// executeNextComponent applies the changes to the next component once the progress of the executed components
+// completes, if the changes are applied component-by-component. The opsRequest keeps running until
+// the changes of all the components are applied and completed.
+func executeNextComponent(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	order []string,
+	handler OpsHandler,
+	phase appsv1alpha1.OpsPhase,
+	requeueAfter time.Duration,
+	err error) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	opsRequest := opsRes.OpsRequest
+	if err != nil || phase != appsv1alpha1.OpsSucceedPhase || len(opsRequest.Status.ExecutedComponents) >= len(order) {
+		return phase, requeueAfter, err
+	}
+	opsDeepCopy := opsRequest.DeepCopy()
+	nextComp := order[len(opsRequest.Status.ExecutedComponents)]
+	opsRequest.Status.ExecutedComponents = append(opsRequest.Status.ExecutedComponents, nextComp)
+	if err = handler.Action(reqCtx, cli, opsRes); err != nil {
+		return appsv1alpha1.OpsRunningPhase, 0, err
+	}
+	opsRes.Recorder.Eventf(opsRequest, corev1.EventTypeNormal, "ExecuteNextComponent",
+		"Start to apply the changes to Component: %s", nextComp)
+	if _, err = intctrlutil.PatchStatus(reqCtx.Ctx, cli, opsRequest, opsDeepCopy); err != nil {
+		return appsv1alpha1.OpsRunningPhase, 0, err
+	}
+	return appsv1alpha1.OpsRunningPhase, 0, nil
+}
+
 func (c componentOpsHelper) updateClusterComponentsAndShardings(cluster *appsv1alpha1.Cluster,
 	updateFunc func(compSpec *appsv1alpha1.ClusterComponentSpec, compOpsItem ComponentOpsInterface) error) error {
 	updateComponentSpecs := func(compSpec *appsv1alpha1.ClusterComponentSpec, componentName string) error {
@@ -109,9 +173,15 @@ func (c componentOpsHelper) cancelComponentOps(ctx context.Context,
 	cli client.Client,
 	opsRes *OpsResource,
 	updateCompSpec func(lastConfig *appsv1alpha1.LastComponentConfiguration, comp *appsv1alpha1.ClusterComponentSpec)) error {
-	rollBackCompSpec := func(compSpec *appsv1alpha1.ClusterComponentSpec,
-		lastCompInfos map[string]appsv1alpha1.LastComponentConfiguration,
-		componentName string) {
+	rollbackComponentSpecs(opsRes.Cluster, opsRes.OpsRequest.Status.LastConfiguration.Components, updateCompSpec)
+	return cli.Update(ctx, opsRes.Cluster)
+}
+
+// rollbackComponentSpecs reverts the components and shardings of the cluster to the last configurations.
+func rollbackComponentSpecs(cluster *appsv1alpha1.Cluster,
+	lastCompInfos map[string]appsv1alpha1.LastComponentConfiguration,
+	updateCompSpec func(lastConfig *appsv1alpha1.LastComponentConfiguration, comp *appsv1alpha1.ClusterComponentSpec)) {
+	rollBackCompSpec := func(compSpec *appsv1alpha1.ClusterComponentSpec, componentName string) {
 		lastConfig, ok := lastCompInfos[componentName]
 		if !ok {
 			return
@@ -121,17 +191,15 @@ func (c componentOpsHelper) cancelComponentOps(ctx context.Context,
 	}
 
 	// 1. rollback the clusterComponentSpecs
-	lastCompInfos := opsRes.OpsRequest.Status.LastConfiguration.Components
-	for index := range opsRes.Cluster.Spec.ComponentSpecs {
-		compSpec := &opsRes.Cluster.Spec.ComponentSpecs[index]
-		rollBackCompSpec(compSpec, lastCompInfos, compSpec.Name)
+	for index := range cluster.Spec.ComponentSpecs {
+		compSpec := &cluster.Spec.ComponentSpecs[index]
+		rollBackCompSpec(compSpec, compSpec.Name)
 	}
 	// 2. rollback the shardingSpecs
-	for index := range opsRes.Cluster.Spec.ShardingSpecs {
-		shardingSpec := &opsRes.Cluster.Spec.ShardingSpecs[index]
-		rollBackCompSpec(&shardingSpec.Template, lastCompInfos, shardingSpec.Name)
+	for index := range cluster.Spec.ShardingSpecs {
+		shardingSpec := &cluster.Spec.ShardingSpecs[index]
+		rollBackCompSpec(&shardingSpec.Template, shardingSpec.Name)
 	}
-	return cli.Update(ctx, opsRes.Cluster)
 }
 
 func (c componentOpsHelper) existFailure(ops *appsv1alpha1.OpsRequest, componentName string) bool {
@@ -170,7 +238,6 @@ func (c componentOpsHelper) reconcileActionWithComponentOps(reqCtx intctrlutil.R
 	}
 	// if no specified components, we should check the all components phase of cluster.
 	oldOpsRequest := opsRequest.DeepCopy()
-	patch := client.MergeFrom(oldOpsRequest)
 	if opsRequest.Status.Components == nil {
 		opsRequest.Status.Components = map[string]appsv1alpha1.OpsRequestComponentStatus{}
 	}
@@ -234,7 +301,10 @@ func (c componentOpsHelper) reconcileActionWithComponentOps(reqCtx intctrlutil.R
 		}
 	}
 	opsIsCompleted := true
-	existFailure := false
+	var (
+		failedComponents []string
+		requeueAfter     time.Duration
+	)
 	for i := range progressResources {
 		pgResource := progressResources[i]
 		opsCompStatus := opsRequest.Status.Components[pgResource.compOps.GetComponentName()]
@@ -244,8 +314,11 @@ func (c componentOpsHelper) reconcileActionWithComponentOps(reqCtx intctrlutil.R
 		}
 		expectProgressCount += expectCount
 		completedProgressCount += completedCount
+		if pgResource.requeueAfter > 0 && (requeueAfter == 0 || pgResource.requeueAfter < requeueAfter) {
+			requeueAfter = pgResource.requeueAfter
+		}
 		if c.existFailure(opsRes.OpsRequest, pgResource.compOps.GetComponentName()) {
-			existFailure = true
+			failedComponents = append(failedComponents, pgResource.compOps.GetComponentName())
 		}
 		componentPhase := opsRes.Cluster.Status.Components[pgResource.compOps.GetComponentName()].Phase
 		if !pgResource.isShardingComponent {
@@ -274,22 +347,85 @@ func (c componentOpsHelper) reconcileActionWithComponentOps(reqCtx intctrlutil.R
 		}
 		opsRequest.Status.Components[pgResource.compOps.GetComponentName()] = opsCompStatus
 	}
+	existFailure := len(failedComponents) > 0
+	if opsIsCompleted && existFailure {
+		// component failure may be temporary, retry the failed components according to the retry policy.
+		requeueTimeAfterFailed = c.retryFailedComponents(opsRequest, failedComponents)
+	}
 	// TODO: wait for sharding cluster to completed for next opsRequest.
 	opsRequest.Status.Progress = fmt.Sprintf("%d/%d", completedProgressCount, expectProgressCount)
-	if !reflect.DeepEqual(opsRequest.Status, oldOpsRequest.Status) {
-		if err = cli.Status().Patch(reqCtx.Ctx, opsRequest, patch); err != nil {
-			return opsRequestPhase, 0, err
-		}
+	var patchOpts []intctrlutil.StatusPatchOption
+	if !opsIsCompleted {
+		// the progress details churn while the components are being processed, which can be coalesced.
+		patchOpts = append(patchOpts, intctrlutil.WithCoalesce())
+	}
+	flushAfter, err := intctrlutil.PatchStatus(reqCtx.Ctx, cli, opsRequest, oldOpsRequest, patchOpts...)
+	if err != nil {
+		return opsRequestPhase, 0, err
 	}
 	if !opsIsCompleted {
-		return opsRequestPhase, 0, nil
+		return opsRequestPhase, requeueForStatusFlush(requeueAfter, flushAfter), nil
 	}
 	if existFailure {
 		if requeueTimeAfterFailed != 0 {
-			// component failure may be temporary, waiting for component failure timeout.
+			// waiting for the failed components to be retried.
 			return opsRequestPhase, requeueTimeAfterFailed, nil
 		}
 		return appsv1alpha1.OpsFailedPhase, 0, nil
 	}
 	return appsv1alpha1.OpsSucceedPhase, 0, nil
 }
+
+// retryFailedComponents retries the failed components according to `opsRequest.spec.retryPolicy`.
+// the failed progressDetails of the components are removed, so that the instances will be re-checked in the next reconciliation,
+// the changes are not applied again, the failed instances are expected to be recovered by the workloads.
+// the components whose retries are exhausted are skipped, and it returns 0 if all the failed components are exhausted,
+// which means the opsRequest should be marked as Failed, otherwise the duration to requeue.
+func (c componentOpsHelper) retryFailedComponents(opsRequest *appsv1alpha1.OpsRequest, failedComponents []string) time.Duration {
+	retryPolicy := opsRequest.Spec.RetryPolicy
+	if retryPolicy == nil || retryPolicy.MaxRetries == 0 {
+		return 0
+	}
+	var requeueAfter time.Duration
+	for _, compName := range failedComponents {
+		compStatus := opsRequest.Status.Components[compName]
+		if compStatus.Retries >= retryPolicy.MaxRetries {
+			continue
+		}
+		var failedTime time.Time
+		for _, v := range compStatus.ProgressDetails {
+			if v.Status == appsv1alpha1.FailedProgressStatus && v.EndTime.After(failedTime) {
+				failedTime = v.EndTime.Time
+			}
+		}
+		if waitTime := time.Until(failedTime.Add(getRetryBackoff(retryPolicy, compStatus.Retries))); waitTime > 0 {
+			if requeueAfter == 0 || waitTime < requeueAfter {
+				requeueAfter = waitTime
+			}
+			continue
+		}
+		var progressDetails []appsv1alpha1.ProgressStatusDetail
+		for _, v := range compStatus.ProgressDetails {
+			if v.Status != appsv1alpha1.FailedProgressStatus {
+				progressDetails = append(progressDetails, v)
+			}
+		}
+		compStatus.ProgressDetails = progressDetails
+		compStatus.Retries += 1
+		compStatus.LastRetryTime = metav1.Now()
+		compStatus.Message = fmt.Sprintf("retrying the failed actions, attempt %d/%d", compStatus.Retries, retryPolicy.MaxRetries)
+		opsRequest.Status.Components[compName] = compStatus
+		requeueAfter = time.Second
+	}
+	return requeueAfter
+}
+
+// getRetryBackoff gets the wait time before the next retry, which doubles after each retry.
+func getRetryBackoff(retryPolicy *appsv1alpha1.OpsRetryPolicy, retries int32) time.Duration {
+	backoff := time.Duration(max(retryPolicy.BackoffSeconds, 1)) * time.Second
+	maxBackoff := time.Duration(max(retryPolicy.MaxBackoffSeconds, retryPolicy.BackoffSeconds, 1)) * time.Second
+	for i := int32(0); i < retries && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxBackoff)
+}
@@ -0,0 +1,152 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"sort"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	opsutil "github.com/apecloud/kubeblocks/controllers/apps/operations/util"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// GetOpsRequestTTL returns the duration that the finished OpsRequest remains before automatic deletion.
+// 0 means the OpsRequest is not deleted automatically.
+func GetOpsRequestTTL(ops *appsv1alpha1.OpsRequest) time.Duration {
+	ttlSeconds := ops.Spec.TTLSecondsAfterUnsuccessfulCompletion
+	if ops.Status.Phase == appsv1alpha1.OpsSucceedPhase {
+		ttlSeconds = ops.Spec.TTLSecondsAfterSucceed
+	}
+	if ttlSeconds == 0 && ops.Spec.TTLSecondsAfterFinished != nil {
+		ttlSeconds = *ops.Spec.TTLSecondsAfterFinished
+	}
+	return time.Duration(ttlSeconds) * time.Second
+}
+
+// GetOpsRequestExpiration returns the time when the finished OpsRequest expires,
+// it returns false if the OpsRequest is not finished or has no TTL.
+func GetOpsRequestExpiration(ops *appsv1alpha1.OpsRequest) (time.Time, bool) {
+	ttl := GetOpsRequestTTL(ops)
+	if !ops.IsComplete() || ops.Status.CompletionTimestamp.IsZero() || ttl == 0 {
+		return time.Time{}, false
+	}
+	return ops.Status.CompletionTimestamp.Add(ttl), true
+}
+
+// GCOpsRequestHistory deletes the earliest finished OpsRequests of the cluster beyond `spec.maxOpsRequestHistory`,
+// and compacts the OpsRequest annotation of the cluster by removing the finished or non-existent OpsRequests.
+func GCOpsRequestHistory(reqCtx intctrlutil.RequestCtx, cli client.Client, cluster *appsv1alpha1.Cluster) error {
+	if cluster == nil || !cluster.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	opsRequestSlice, _ := opsutil.GetOpsRequestSliceFromCluster(cluster)
+	if cluster.Spec.MaxOpsRequestHistory == nil && len(opsRequestSlice) == 0 {
+		return nil
+	}
+	opsList := &appsv1alpha1.OpsRequestList{}
+	if err := cli.List(reqCtx.Ctx, opsList, client.InNamespace(cluster.Namespace),
+		client.MatchingLabels{constant.AppInstanceLabelKey: cluster.Name}); err != nil {
+		return err
+	}
+	if err := pruneFinishedOpsRequests(reqCtx, cli, cluster, opsList.Items); err != nil {
+		return err
+	}
+	return compactClusterOpsAnnotation(reqCtx, cli, cluster, opsRequestSlice, opsList.Items)
+}
+
+// pruneFinishedOpsRequests deletes the earliest finished OpsRequests beyond `spec.maxOpsRequestHistory`.
+func pruneFinishedOpsRequests(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	cluster *appsv1alpha1.Cluster,
+	opsRequests []appsv1alpha1.OpsRequest) error {
+	if cluster.Spec.MaxOpsRequestHistory == nil {
+		return nil
+	}
+	var finished []*appsv1alpha1.OpsRequest
+	for i := range opsRequests {
+		ops := &opsRequests[i]
+		// keep the Stop OpsRequest until the stopped components are started automatically.
+		if ops.IsComplete() && ops.DeletionTimestamp.IsZero() && !isAutoStartPending(ops) {
+			finished = append(finished, ops)
+		}
+	}
+	pruneCount := len(finished) - int(*cluster.Spec.MaxOpsRequestHistory)
+	if pruneCount <= 0 {
+		return nil
+	}
+	finishedTime := func(ops *appsv1alpha1.OpsRequest) time.Time {
+		if !ops.Status.CompletionTimestamp.IsZero() {
+			return ops.Status.CompletionTimestamp.Time
+		}
+		return ops.CreationTimestamp.Time
+	}
+	sort.SliceStable(finished, func(i, j int) bool {
+		return finishedTime(finished[i]).Before(finishedTime(finished[j]))
+	})
+	for _, ops := range finished[:pruneCount] {
+		if err := cli.Delete(reqCtx.Ctx, ops); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		reqCtx.Log.Info("deleted the finished OpsRequest beyond the maxOpsRequestHistory of the cluster", "opsRequest", ops.Name)
+	}
+	return nil
+}
+
+// compactClusterOpsAnnotation removes the finished or non-existent OpsRequests from the OpsRequest annotation of the cluster.
+func compactClusterOpsAnnotation(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	cluster *appsv1alpha1.Cluster,
+	opsRequestSlice []appsv1alpha1.OpsRecorder,
+	opsRequests []appsv1alpha1.OpsRequest) error {
+	if len(opsRequestSlice) == 0 {
+		return nil
+	}
+	opsMap := map[string]*appsv1alpha1.OpsRequest{}
+	for i := range opsRequests {
+		opsMap[opsRequests[i].Name] = &opsRequests[i]
+	}
+	compacted := make([]appsv1alpha1.OpsRecorder, 0, len(opsRequestSlice))
+	for _, recorder := range opsRequestSlice {
+		ops, ok := opsMap[recorder.Name]
+		if !ok {
+			// the OpsRequest may be not labeled yet, check whether it exists.
+			ops = &appsv1alpha1.OpsRequest{}
+			if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: recorder.Name}, ops); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return err
+			}
+		}
+		if ops.IsComplete() {
+			continue
+		}
+		compacted = append(compacted, recorder)
+	}
+	if len(compacted) == len(opsRequestSlice) {
+		return nil
+	}
+	return opsutil.UpdateClusterOpsAnnotations(reqCtx.Ctx, cli, cluster, compacted)
+}
@@ -0,0 +1,127 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	opsutil "github.com/apecloud/kubeblocks/controllers/apps/operations/util"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+var _ = Describe("OpsRequest garbage collection", func() {
+	const (
+		namespace   = "default"
+		clusterName = "mycluster"
+	)
+
+	newOps := func(name string, phase appsv1alpha1.OpsPhase, completedAgo time.Duration) *appsv1alpha1.OpsRequest {
+		ops := &appsv1alpha1.OpsRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+				Labels:    map[string]string{constant.AppInstanceLabelKey: clusterName},
+			},
+			Spec: appsv1alpha1.OpsRequestSpec{
+				ClusterName: clusterName,
+				Type:        appsv1alpha1.RestartType,
+			},
+			Status: appsv1alpha1.OpsRequestStatus{Phase: phase},
+		}
+		if completedAgo > 0 {
+			ops.Status.CompletionTimestamp = metav1.NewTime(time.Now().Add(-completedAgo))
+		}
+		return ops
+	}
+
+	It("computes the TTL of the finished OpsRequests", func() {
+		ops := newOps("ops", appsv1alpha1.OpsSucceedPhase, time.Hour)
+		_, ok := GetOpsRequestExpiration(ops)
+		Expect(ok).Should(BeFalse())
+
+		ops.Spec.TTLSecondsAfterFinished = pointer.Int32(60)
+		Expect(GetOpsRequestTTL(ops)).Should(Equal(time.Minute))
+		ops.Spec.TTLSecondsAfterSucceed = 120
+		Expect(GetOpsRequestTTL(ops)).Should(Equal(2 * time.Minute))
+
+		ops.Status.Phase = appsv1alpha1.OpsFailedPhase
+		Expect(GetOpsRequestTTL(ops)).Should(Equal(time.Minute))
+		deadline, ok := GetOpsRequestExpiration(ops)
+		Expect(ok).Should(BeTrue())
+		Expect(deadline.Before(time.Now())).Should(BeTrue())
+
+		ops.Status.Phase = appsv1alpha1.OpsRunningPhase
+		_, ok = GetOpsRequestExpiration(ops)
+		Expect(ok).Should(BeFalse())
+	})
+
+	It("prunes the finished OpsRequests and compacts the cluster annotation", func() {
+		cluster := &appsv1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: clusterName},
+			Spec:       appsv1alpha1.ClusterSpec{MaxOpsRequestHistory: pointer.Int32(2)},
+		}
+		objs := []client.Object{cluster}
+		for i := 1; i <= 4; i++ {
+			objs = append(objs, newOps(fmt.Sprintf("finished-%d", i), appsv1alpha1.OpsSucceedPhase, time.Duration(i)*time.Hour))
+		}
+		objs = append(objs, newOps("running", appsv1alpha1.OpsRunningPhase, 0))
+		opsutil.SetOpsRequestToCluster(cluster, []appsv1alpha1.OpsRecorder{
+			{Name: "finished-1", Type: appsv1alpha1.RestartType},
+			{Name: "running", Type: appsv1alpha1.RestartType},
+			{Name: "deleted", Type: appsv1alpha1.RestartType},
+		})
+
+		scheme := runtime.NewScheme()
+		Expect(appsv1alpha1.AddToScheme(scheme)).Should(Succeed())
+		cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+		reqCtx := intctrlutil.RequestCtx{Ctx: context.Background(), Log: logr.Discard()}
+		Expect(GCOpsRequestHistory(reqCtx, cli, cluster)).Should(Succeed())
+
+		By("expect the earliest finished OpsRequests to be deleted")
+		opsList := &appsv1alpha1.OpsRequestList{}
+		Expect(cli.List(context.Background(), opsList)).Should(Succeed())
+		var names []string
+		for _, ops := range opsList.Items {
+			names = append(names, ops.Name)
+		}
+		Expect(names).Should(ConsistOf("finished-1", "finished-2", "running"))
+
+		By("expect the finished and non-existent OpsRequests to be removed from the cluster annotation")
+		Expect(cli.Get(context.Background(), client.ObjectKeyFromObject(cluster), cluster)).Should(Succeed())
+		opsRequestSlice, err := opsutil.GetOpsRequestSliceFromCluster(cluster)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(opsRequestSlice).Should(HaveLen(1))
+		Expect(opsRequestSlice[0].Name).Should(Equal("running"))
+	})
+})
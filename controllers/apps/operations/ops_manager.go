@@ -20,6 +20,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"errors"
+	"fmt"
 	"slices"
 	"strings"
 	"sync"
@@ -35,6 +37,13 @@ import (
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
+const (
+	opsRequestControllerName = "opsrequest"
+
+	// actionRequeueAfter is the interval to requeue the OpsRequest if its action is not ready to apply.
+	actionRequeueAfter = 5 * time.Second
+)
+
 var (
 	opsManagerOnce sync.Once
 	opsManager     *OpsManager
@@ -61,6 +70,30 @@ func (opsMgr *OpsManager) Do(reqCtx intctrlutil.RequestCtx, cli client.Client, o
 		return &ctrl.Result{}, PatchOpsHandlerNotSupported(reqCtx.Ctx, cli, opsRes)
 	}
 
+	if opsRequest.Status.Phase == appsv1alpha1.OpsPendingApprovalPhase {
+		return checkApproval(reqCtx, cli, opsRes)
+	}
+
+	if opsRequest.Status.Phase == appsv1alpha1.OpsScheduledPhase {
+		// move the OpsRequest back to Pending once the maintenance window opens.
+		if open, wait := intctrlutil.InMaintenanceWindow(getOpsMaintenanceWindow(opsRequest), time.Now()); !open {
+			return intctrlutil.ResultToP(intctrlutil.RequeueAfter(maintenanceWindowRequeueAfter(wait), reqCtx.Log, ""))
+		}
+		return &ctrl.Result{}, PatchOpsStatus(reqCtx.Ctx, cli, opsRes, appsv1alpha1.OpsPendingPhase)
+	}
+
+	if opsRequest.Status.Phase == appsv1alpha1.OpsPendingPhase {
+		if err = validateMaintenanceWindow(opsRequest, opsBehaviour); err != nil {
+			return &ctrl.Result{}, patchValidateErrorCondition(reqCtx.Ctx, cli, opsRes, err.Error())
+		}
+		if err = validateIdempotencyKey(reqCtx, cli, opsRequest); intctrlutil.IsTerminalError(err) {
+			condition := appsv1alpha1.NewValidateFailedCondition(appsv1alpha1.ReasonDuplicateOpsRequest, err.Error())
+			return &ctrl.Result{}, PatchOpsStatus(reqCtx.Ctx, cli, opsRes, appsv1alpha1.OpsFailedPhase, condition)
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
 	if opsRequest.Spec.Type == appsv1alpha1.CustomType {
 		err = initOpsDefAndValidate(reqCtx, cli, opsRes)
 		if err != nil {
@@ -71,6 +104,9 @@ func (opsMgr *OpsManager) Do(reqCtx intctrlutil.RequestCtx, cli client.Client, o
 		if err = validateOpsWaitingPhase(opsRes.Cluster, opsRequest, opsBehaviour); err != nil {
 			// check if the error is caused by WaitForClusterPhaseErr  error
 			if _, ok := err.(*WaitForClusterPhaseErr); ok {
+				if err = patchQueuedCondition(reqCtx.Ctx, cli, opsRes, appsv1alpha1.ReasonWaitingForClusterPhase, err.Error()); err != nil {
+					return nil, err
+				}
 				return intctrlutil.ResultToP(intctrlutil.RequeueAfter(time.Second, reqCtx.Log, ""))
 			}
 			return &ctrl.Result{}, patchValidateErrorCondition(reqCtx.Ctx, cli, opsRes, err.Error())
@@ -86,28 +122,65 @@ func (opsMgr *OpsManager) Do(reqCtx intctrlutil.RequestCtx, cli client.Client, o
 		if opsRequest.Spec.Cancel {
 			return &ctrl.Result{}, PatchOpsStatus(reqCtx.Ctx, cli, opsRes, appsv1alpha1.OpsCancelledPhase)
 		}
+		if opsRequest.Spec.DryRun {
+			return &ctrl.Result{}, opsMgr.dryRun(reqCtx, cli, opsRes, opsBehaviour)
+		}
+		// hold the OpsRequest in the PendingApproval phase until it is approved.
+		if !opsRequest.IsApproved() {
+			return intctrlutil.ResultToP(intctrlutil.Reconciled()), PatchOpsStatus(reqCtx.Ctx, cli, opsRes,
+				appsv1alpha1.OpsPendingApprovalPhase, appsv1alpha1.NewWaitingForApprovalCondition(opsRequest))
+		}
+		// hold the OpsRequest in the Scheduled phase until the maintenance window opens.
+		if open, wait := intctrlutil.InMaintenanceWindow(getOpsMaintenanceWindow(opsRequest), time.Now()); !open {
+			message := "wait for the maintenance window to open"
+			if wait > 0 {
+				message = fmt.Sprintf("wait for the maintenance window to open at %s", time.Now().Add(wait).UTC().Format(time.RFC3339))
+			}
+			if err = PatchOpsStatus(reqCtx.Ctx, cli, opsRes, appsv1alpha1.OpsScheduledPhase, appsv1alpha1.NewQueuedCondition(opsRequest,
+				appsv1alpha1.ReasonWaitingForMaintenanceWindow, message)); err != nil {
+				return nil, err
+			}
+			return intctrlutil.ResultToP(intctrlutil.RequeueAfter(maintenanceWindowRequeueAfter(wait), reqCtx.Log, ""))
+		}
 		// TODO: abort last OpsRequest if using 'force' and intersecting with cluster component name or shard name.
 		if opsBehaviour.QueueByCluster || opsBehaviour.QueueBySelf {
 			// if ToClusterPhase is not empty, enqueue OpsRequest to the cluster Annotation.
-			opsRecorde, err := enqueueOpsRequestToClusterAnnotation(reqCtx.Ctx, cli, opsRes, opsBehaviour)
-			if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+			opsRecorde, conflictWith, err := enqueueOpsRequestToClusterAnnotation(reqCtx.Ctx, cli, opsRes, opsBehaviour)
+			if intctrlutil.IsTerminalError(err) {
 				return &ctrl.Result{}, patchValidateErrorCondition(reqCtx.Ctx, cli, opsRes, err.Error())
 			} else if err != nil {
 				return nil, err
 			}
 			if opsRecorde != nil && opsRecorde.InQueue {
 				// if the opsRequest is in the queue, return
-				return intctrlutil.ResultToP(intctrlutil.Reconciled())
+				if conflictWith != nil {
+					return intctrlutil.ResultToP(intctrlutil.Reconciled()), patchQueuedCondition(reqCtx.Ctx, cli, opsRes,
+						appsv1alpha1.ReasonConflictWith, fmt.Sprintf(`ConflictWith=%s: wait for the %s OpsRequest "%s" to complete`,
+							conflictWith.Name, conflictWith.Type, conflictWith.Name))
+				}
+				return intctrlutil.ResultToP(intctrlutil.Reconciled()), patchQueuedCondition(reqCtx.Ctx, cli, opsRes,
+					appsv1alpha1.ReasonWaitingInQueue, "wait for the earlier OpsRequests of the Cluster to complete")
 			}
 		}
 
 		// validate if the dependent ops have been successful
-		if pass, err := opsMgr.validateDependOnSuccessfulOps(reqCtx, cli, opsRes); intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+		if pass, err := opsMgr.validateDependOnSuccessfulOps(reqCtx, cli, opsRes); intctrlutil.IsTerminalError(err) {
 			return &ctrl.Result{}, patchValidateErrorCondition(reqCtx.Ctx, cli, opsRes, err.Error())
 		} else if err != nil {
 			return nil, err
 		} else if !pass {
-			return intctrlutil.ResultToP(intctrlutil.Reconciled())
+			if opsRequest.IsComplete() {
+				return intctrlutil.ResultToP(intctrlutil.Reconciled())
+			}
+			return intctrlutil.ResultToP(intctrlutil.Reconciled()), patchQueuedCondition(reqCtx.Ctx, cli, opsRes,
+				appsv1alpha1.ReasonWaitingForDependentOps, "wait for the dependent OpsRequests to succeed")
+		}
+		// run the pre-checks which may veto the OpsRequest before the action is applied
+		if err = opsMgr.preCheck(reqCtx, cli, opsRes); intctrlutil.IsTerminalError(err) {
+			condition := appsv1alpha1.NewValidateFailedCondition(appsv1alpha1.ReasonPreCheckRejected, err.Error())
+			return &ctrl.Result{}, PatchOpsStatus(reqCtx.Ctx, cli, opsRes, appsv1alpha1.OpsFailedPhase, condition)
+		} else if err != nil {
+			return nil, err
 		}
 		opsDeepCopy := opsRequest.DeepCopy()
 		// save last configuration into status.lastConfiguration
@@ -121,16 +194,31 @@ func (opsMgr *OpsManager) Do(reqCtx intctrlutil.RequestCtx, cli client.Client, o
 	if err = updateHAConfigIfNecessary(reqCtx, cli, opsRes.OpsRequest, "false"); err != nil {
 		return nil, err
 	}
+	opsDeepCopy := opsRequest.DeepCopy()
 	if err = opsBehaviour.OpsHandler.Action(reqCtx, cli, opsRes); err != nil {
-		// patch the status.phase to Failed when the error is Fatal, which means the operation is failed and there is no need to retry
-		if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
-			return &ctrl.Result{}, patchFatalFailErrorCondition(reqCtx.Ctx, cli, opsRes, err)
+		// patch the status.phase to Failed when the error is terminal, which means the operation is failed and there is no need to retry
+		if intctrlutil.RecordReconcileError(opsRequestControllerName, err) == intctrlutil.ErrorClassTerminal {
+			return &ctrl.Result{}, patchFatalFailErrorCondition(reqCtx.Ctx, cli, opsRes, opsDeepCopy, err)
 		}
 		if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeNeedWaiting) {
 			return intctrlutil.ResultToP(intctrlutil.Reconciled())
 		}
-		return nil, err
+		if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeRequeue) {
+			return intctrlutil.ResultToP(intctrlutil.RequeueAfter(actionRequeueAfter, reqCtx.Log, err.Error()))
+		}
+		if opsRequest.Spec.FailurePolicy == nil {
+			return nil, err
+		}
+		requeueAfter, retry, err := retryTransientFailure(reqCtx.Ctx, cli, opsRes, err)
+		if !retry {
+			return &ctrl.Result{}, patchFatalFailErrorCondition(reqCtx.Ctx, cli, opsRes, opsDeepCopy, err)
+		} else if err != nil {
+			return nil, err
+		}
+		return intctrlutil.ResultToP(intctrlutil.RequeueAfter(requeueAfter, reqCtx.Log, ""))
 	}
+	// the status is patched with the Running phase after the action is applied.
+	opsRequest.Status.FailedAttempts = 0
 	return nil, nil
 }
 
@@ -158,8 +246,20 @@ func (opsMgr *OpsManager) Reconcile(reqCtx intctrlutil.RequestCtx, cli client.Cl
 	}
 	if opsRequestPhase, requeueAfter, err = opsBehaviour.OpsHandler.ReconcileAction(reqCtx, cli, opsRes); err != nil &&
 		!isOpsRequestFailedPhase(opsRequestPhase) {
-		// if the opsRequest phase is not failed, skipped
-		return requeueAfter, err
+		intctrlutil.RecordReconcileError(opsRequestControllerName, err)
+		if opsRequest.Spec.FailurePolicy == nil || intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeNeedWaiting) {
+			// if the opsRequest phase is not failed, skipped
+			return requeueAfter, err
+		}
+		backoff, retry, retryErr := retryTransientFailure(reqCtx.Ctx, cli, opsRes, err)
+		if retry {
+			return backoff, retryErr
+		}
+		opsRequestPhase, err = appsv1alpha1.OpsFailedPhase, retryErr
+	} else if err == nil {
+		if err = resetFailedAttempts(reqCtx.Ctx, cli, opsRes); err != nil {
+			return 0, err
+		}
 	}
 	switch opsRequestPhase {
 	case appsv1alpha1.OpsSucceedPhase:
@@ -168,7 +268,20 @@ func (opsMgr *OpsManager) Reconcile(reqCtx intctrlutil.RequestCtx, cli client.Cl
 	case appsv1alpha1.OpsFailedPhase:
 		return 0, opsMgr.handleOpsCompleted(reqCtx, cli, opsRes, opsRequestPhase,
 			appsv1alpha1.NewCancelFailedCondition(opsRequest, err), appsv1alpha1.NewFailedCondition(opsRequest, err))
+	case appsv1alpha1.OpsWaitingForConfirmPhase:
+		if opsRequest.Status.Phase == appsv1alpha1.OpsRunningPhase {
+			if err = PatchOpsStatus(reqCtx.Ctx, cli, opsRes, opsRequestPhase, appsv1alpha1.NewWaitingForConfirmCondition(opsRequest)); err != nil {
+				return 0, err
+			}
+		}
+		return opsMgr.checkAndHandleOpsTimeout(reqCtx, cli, opsRes, requeueAfter)
 	default:
+		if opsRequest.Status.Phase == appsv1alpha1.OpsWaitingForConfirmPhase {
+			// the OpsRequest is approved to continue.
+			if err = PatchOpsStatus(reqCtx.Ctx, cli, opsRes, appsv1alpha1.OpsRunningPhase); err != nil {
+				return 0, err
+			}
+		}
 		return opsMgr.checkAndHandleOpsTimeout(reqCtx, cli, opsRes, requeueAfter)
 	}
 }
@@ -183,11 +296,43 @@ func (opsMgr *OpsManager) handleOpsCompleted(reqCtx intctrlutil.RequestCtx,
 		return err
 	}
 	if opsRes.OpsRequest.Status.Phase == appsv1alpha1.OpsCancellingPhase {
-		return PatchOpsStatus(reqCtx.Ctx, cli, opsRes, appsv1alpha1.OpsCancelledPhase, cancelledCondition)
+		// the cancel function restores the last configuration, which is rolled back once the OpsRequest is cancelled.
+		var rollbackErr error
+		if cancelledCondition.Reason == appsv1alpha1.ReasonOpsCancelFailed {
+			rollbackErr = errors.New(cancelledCondition.Message)
+		}
+		return PatchOpsStatus(reqCtx.Ctx, cli, opsRes, appsv1alpha1.OpsCancelledPhase, cancelledCondition,
+			appsv1alpha1.NewRolledBackCondition(opsRes.OpsRequest, rollbackErr))
 	}
 	return PatchOpsStatus(reqCtx.Ctx, cli, opsRes, opsRequestPhase, completedCondition)
 }
 
+// dryRun records the expected changes of the OpsRequest to status.plan without mutating the cluster,
+// and the OpsRequest succeeds once the plan is generated.
+func (opsMgr *OpsManager) dryRun(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	opsBehaviour OpsBehaviour) error {
+	planner, ok := opsBehaviour.OpsHandler.(OpsPlanner)
+	if !ok {
+		return patchValidateErrorCondition(reqCtx.Ctx, cli, opsRes,
+			fmt.Sprintf(`the dry run is not supported by the OpsRequest of type "%s"`, opsRes.OpsRequest.Spec.Type))
+	}
+	opsDeepCopy := opsRes.OpsRequest.DeepCopy()
+	if err := opsBehaviour.OpsHandler.SaveLastConfiguration(reqCtx, cli, opsRes); err != nil {
+		return err
+	}
+	plan, err := planner.Plan(reqCtx, cli, opsRes)
+	if intctrlutil.IsTerminalError(err) {
+		return patchValidateErrorCondition(reqCtx.Ctx, cli, opsRes, err.Error())
+	} else if err != nil {
+		return err
+	}
+	opsRes.OpsRequest.Status.Plan = plan
+	return PatchOpsStatusWithOpsDeepCopy(reqCtx.Ctx, cli, opsRes, opsDeepCopy, appsv1alpha1.OpsSucceedPhase,
+		appsv1alpha1.NewDryRunSucceedCondition(opsRes.OpsRequest))
+}
+
 // validateDependOnOps validates if the dependent ops have been successful
 func (opsMgr *OpsManager) validateDependOnSuccessfulOps(reqCtx intctrlutil.RequestCtx,
 	cli client.Client,
@@ -0,0 +1,95 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+var (
+	opsDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "kubeblocks_opsrequest_duration_seconds",
+			Help:    "Duration of the OpsRequests from the start to the completion, by type, cluster and the completed phase.",
+			Buckets: prometheus.ExponentialBuckets(1, 2, 16),
+		},
+		[]string{"type", "cluster", "phase"},
+	)
+	opsQueueWaitSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "kubeblocks_opsrequest_queue_wait_seconds",
+			Help:    "Time that the OpsRequests wait from the creation to the start, by type and cluster.",
+			Buckets: prometheus.ExponentialBuckets(1, 2, 14),
+		},
+		[]string{"type", "cluster"},
+	)
+	opsPhaseTransitionsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kubeblocks_opsrequest_phase_transitions_total",
+			Help: "Total number of the phase transitions of the OpsRequests, by type, cluster, component and the new phase.",
+		},
+		[]string{"type", "cluster", "component", "phase"},
+	)
+	opsCancelsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kubeblocks_opsrequest_cancels_total",
+			Help: "Total number of the cancelled OpsRequests, by type and cluster.",
+		},
+		[]string{"type", "cluster"},
+	)
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(opsDurationSeconds, opsQueueWaitSeconds, opsPhaseTransitionsTotal, opsCancelsTotal)
+}
+
+// recordOpsPhaseMetrics records the metrics of the OpsRequest once its phase is changed from the old phase.
+func recordOpsPhaseMetrics(opsRequest *appsv1alpha1.OpsRequest, oldPhase appsv1alpha1.OpsPhase) {
+	phase := opsRequest.Status.Phase
+	if phase == oldPhase {
+		return
+	}
+	opsType := string(opsRequest.Spec.Type)
+	clusterName := opsRequest.Spec.GetClusterName()
+	if len(opsRequest.Status.Components) == 0 {
+		opsPhaseTransitionsTotal.WithLabelValues(opsType, clusterName, "", string(phase)).Inc()
+	}
+	for compName := range opsRequest.Status.Components {
+		opsPhaseTransitionsTotal.WithLabelValues(opsType, clusterName, compName, string(phase)).Inc()
+	}
+	switch {
+	case phase == appsv1alpha1.OpsCreatingPhase && !opsRequest.Status.StartTimestamp.IsZero():
+		opsQueueWaitSeconds.WithLabelValues(opsType, clusterName).
+			Observe(opsRequest.Status.StartTimestamp.Sub(opsRequest.CreationTimestamp.Time).Seconds())
+	case opsRequest.IsComplete(phase):
+		startTime := opsRequest.Status.StartTimestamp
+		if startTime.IsZero() {
+			startTime = opsRequest.CreationTimestamp
+		}
+		opsDurationSeconds.WithLabelValues(opsType, clusterName, string(phase)).
+			Observe(opsRequest.Status.CompletionTimestamp.Sub(startTime.Time).Seconds())
+		if phase == appsv1alpha1.OpsCancelledPhase {
+			opsCancelsTotal.WithLabelValues(opsType, clusterName).Inc()
+		}
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+var _ = Describe("OpsRequest metrics", func() {
+	It("should record the metrics on the phase transitions", func() {
+		clusterName := "metrics-cluster-" + testCtx.GetRandomStr()
+		opsType := string(appsv1alpha1.RestartType)
+		now := time.Now()
+		ops := &appsv1alpha1.OpsRequest{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-time.Minute))},
+			Spec: appsv1alpha1.OpsRequestSpec{
+				ClusterName: clusterName,
+				Type:        appsv1alpha1.RestartType,
+			},
+		}
+
+		By("the OpsRequest starts after waiting in the queue")
+		ops.Status.Phase = appsv1alpha1.OpsCreatingPhase
+		ops.Status.StartTimestamp = metav1.NewTime(now)
+		recordOpsPhaseMetrics(ops, appsv1alpha1.OpsPendingPhase)
+		Expect(testutil.ToFloat64(opsPhaseTransitionsTotal.WithLabelValues(opsType, clusterName, "", string(appsv1alpha1.OpsCreatingPhase)))).Should(Equal(float64(1)))
+		Expect(testutil.CollectAndCount(opsQueueWaitSeconds)).Should(BeNumerically(">=", 1))
+
+		By("the unchanged phase is not recorded")
+		recordOpsPhaseMetrics(ops, appsv1alpha1.OpsCreatingPhase)
+		Expect(testutil.ToFloat64(opsPhaseTransitionsTotal.WithLabelValues(opsType, clusterName, "", string(appsv1alpha1.OpsCreatingPhase)))).Should(Equal(float64(1)))
+
+		By("the OpsRequest is cancelled")
+		ops.Status.Components = map[string]appsv1alpha1.OpsRequestComponentStatus{defaultCompName: {}}
+		ops.Status.Phase = appsv1alpha1.OpsCancelledPhase
+		ops.Status.CompletionTimestamp = metav1.NewTime(now.Add(time.Minute))
+		recordOpsPhaseMetrics(ops, appsv1alpha1.OpsCancellingPhase)
+		Expect(testutil.ToFloat64(opsPhaseTransitionsTotal.WithLabelValues(opsType, clusterName, defaultCompName, string(appsv1alpha1.OpsCancelledPhase)))).Should(Equal(float64(1)))
+		Expect(testutil.ToFloat64(opsCancelsTotal.WithLabelValues(opsType, clusterName))).Should(Equal(float64(1)))
+		Expect(testutil.CollectAndCount(opsDurationSeconds)).Should(BeNumerically(">=", 1))
+	})
+})
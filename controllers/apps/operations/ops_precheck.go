@@ -0,0 +1,371 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	defaultPreCheckCalloutTimeout = 10 * time.Second
+
+	// preCheckCalloutFailurePolicyIgnore admits the OpsRequest if the callout can't be reached.
+	preCheckCalloutFailurePolicyIgnore = "Ignore"
+)
+
+// disruptiveOpsTypes are the types of the OpsRequests which restart the pods of the cluster.
+var disruptiveOpsTypes = []appsv1alpha1.OpsType{
+	appsv1alpha1.RestartType,
+	appsv1alpha1.VerticalScalingType,
+	appsv1alpha1.UpgradeType,
+}
+
+func init() {
+	opsMgr := GetOpsManager()
+	opsMgr.RegisterPreCheck(resourceQuotaPreCheck{})
+	opsMgr.RegisterPreCheck(podDisruptionBudgetPreCheck{})
+	opsMgr.RegisterPreCheck(calloutPreCheck{})
+}
+
+// RegisterPreCheck registers a pre-check which is run before the action of each OpsRequest is applied.
+func (opsMgr *OpsManager) RegisterPreCheck(check OpsPreCheck) {
+	opsMgr.PreChecks = append(opsMgr.PreChecks, check)
+}
+
+// preCheck runs the registered pre-checks in order, it returns a fatal error if the OpsRequest is rejected by any of them.
+func (opsMgr *OpsManager) preCheck(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	for _, check := range opsMgr.PreChecks {
+		err := check.Check(reqCtx, cli, opsRes)
+		if err == nil {
+			continue
+		}
+		if intctrlutil.IsTerminalError(err) {
+			return intctrlutil.NewFatalError(fmt.Sprintf(`rejected by the pre-check "%s": %s`, check.Name(), err.Error()))
+		}
+		return err
+	}
+	return nil
+}
+
+// resourceQuotaPreCheck rejects the OpsRequest which requests more resources than the ResourceQuotas
+// of the namespace allow, it is skipped if `spec.force` is true.
+// The resources of the instance templates and the scoped ResourceQuotas are not taken into account.
+type resourceQuotaPreCheck struct{}
+
+func (c resourceQuotaPreCheck) Name() string {
+	return "ResourceQuota"
+}
+
+func (c resourceQuotaPreCheck) Check(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	if opsRes.OpsRequest.Spec.Force {
+		return nil
+	}
+	increment := getRequestedResourceIncrement(opsRes)
+	if len(increment) == 0 {
+		return nil
+	}
+	quotas := &corev1.ResourceQuotaList{}
+	if err := cli.List(reqCtx.Ctx, quotas, client.InNamespace(opsRes.OpsRequest.Namespace)); err != nil {
+		return err
+	}
+	for _, quota := range quotas.Items {
+		if len(quota.Spec.Scopes) > 0 || quota.Spec.ScopeSelector != nil {
+			continue
+		}
+		for name, delta := range increment {
+			hard, ok := quota.Status.Hard[name]
+			if !ok {
+				continue
+			}
+			used := quota.Status.Used[name]
+			expected := used.DeepCopy()
+			expected.Add(delta)
+			if expected.Cmp(hard) > 0 {
+				left := subtractQuantity(hard, used)
+				return intctrlutil.NewFatalError(fmt.Sprintf(`%s %s is requested, but only %s of %s is left in the ResourceQuota "%s"`,
+					delta.String(), name, left.String(), hard.String(), quota.Name))
+			}
+		}
+	}
+	return nil
+}
+
+// getRequestedResourceIncrement gets the resources in the names of the ResourceQuota that the OpsRequest requests additionally.
+func getRequestedResourceIncrement(opsRes *OpsResource) corev1.ResourceList {
+	ops := opsRes.OpsRequest
+	increment := corev1.ResourceList{}
+	switch ops.Spec.Type {
+	case appsv1alpha1.HorizontalScalingType:
+		for _, hScale := range ops.Spec.HorizontalScalingList {
+			compSpec, shards := getComponentSpecAndShards(opsRes.Cluster, hScale.ComponentName)
+			if compSpec == nil {
+				continue
+			}
+			delta := ops.GetHorizontalScalingExpectedReplicas(opsRes.Cluster.Name, hScale, compSpec.Replicas) - compSpec.Replicas
+			if delta <= 0 {
+				continue
+			}
+			addResourceIncrement(increment, compSpec.Resources, int(delta*shards))
+			addQuantity(increment, corev1.ResourcePods, *resource.NewQuantity(int64(delta*shards), resource.DecimalSI))
+		}
+	case appsv1alpha1.VerticalScalingType:
+		for _, vScale := range ops.Spec.VerticalScalingList {
+			compSpec, shards := getComponentSpecAndShards(opsRes.Cluster, vScale.ComponentName)
+			if compSpec == nil {
+				continue
+			}
+			delta := corev1.ResourceRequirements{
+				Requests: getResourceListIncrement(compSpec.Resources.Requests, vScale.Requests),
+				Limits:   getResourceListIncrement(compSpec.Resources.Limits, vScale.Limits),
+			}
+			addResourceIncrement(increment, delta, int(compSpec.Replicas*shards))
+		}
+	}
+	return increment
+}
+
+// getComponentSpecAndShards gets the spec of the component or the template of the sharding, and the number of the shards.
+func getComponentSpecAndShards(cluster *appsv1alpha1.Cluster, name string) (*appsv1alpha1.ClusterComponentSpec, int32) {
+	for i := range cluster.Spec.ComponentSpecs {
+		if cluster.Spec.ComponentSpecs[i].Name == name {
+			return &cluster.Spec.ComponentSpecs[i], 1
+		}
+	}
+	for i := range cluster.Spec.ShardingSpecs {
+		if cluster.Spec.ShardingSpecs[i].Name == name {
+			return &cluster.Spec.ShardingSpecs[i].Template, cluster.Spec.ShardingSpecs[i].Shards
+		}
+	}
+	return nil, 0
+}
+
+// getResourceListIncrement gets the resources increased from the current to the desired.
+func getResourceListIncrement(current, desired corev1.ResourceList) corev1.ResourceList {
+	increment := corev1.ResourceList{}
+	for name, quantity := range desired {
+		delta := subtractQuantity(quantity, current[name])
+		if delta.Sign() > 0 {
+			increment[name] = delta
+		}
+	}
+	return increment
+}
+
+// addResourceIncrement adds the resources of the replicas to the increment in the names of the ResourceQuota,
+// e.g. the requests of cpu are added to both "requests.cpu" and "cpu".
+func addResourceIncrement(increment corev1.ResourceList, resources corev1.ResourceRequirements, replicas int) {
+	for name, quantity := range resources.Requests {
+		total := multiplyQuantity(quantity, replicas)
+		addQuantity(increment, corev1.ResourceName("requests."+string(name)), total)
+		if name == corev1.ResourceCPU || name == corev1.ResourceMemory {
+			addQuantity(increment, name, total)
+		}
+	}
+	for name, quantity := range resources.Limits {
+		addQuantity(increment, corev1.ResourceName("limits."+string(name)), multiplyQuantity(quantity, replicas))
+	}
+}
+
+func addQuantity(list corev1.ResourceList, name corev1.ResourceName, quantity resource.Quantity) {
+	total := list[name]
+	total.Add(quantity)
+	list[name] = total
+}
+
+func multiplyQuantity(quantity resource.Quantity, times int) resource.Quantity {
+	total := resource.Quantity{Format: quantity.Format}
+	for i := 0; i < times; i++ {
+		total.Add(quantity)
+	}
+	return total
+}
+
+func subtractQuantity(x, y resource.Quantity) resource.Quantity {
+	delta := x.DeepCopy()
+	delta.Sub(y)
+	return delta
+}
+
+// podDisruptionBudgetPreCheck rejects the OpsRequest which restarts the pods protected by a PodDisruptionBudget
+// allowing no disruption currently, it is skipped if `spec.force` is true.
+// The PodDisruptionBudgets managed by KubeBlocks are not taken into account, as the OpsRequests may repair the cluster.
+type podDisruptionBudgetPreCheck struct{}
+
+func (c podDisruptionBudgetPreCheck) Name() string {
+	return "PodDisruptionBudget"
+}
+
+func (c podDisruptionBudgetPreCheck) Check(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	ops := opsRes.OpsRequest
+	if ops.Spec.Force || !slices.Contains(disruptiveOpsTypes, ops.Spec.Type) {
+		return nil
+	}
+	pdbs := &policyv1.PodDisruptionBudgetList{}
+	if err := cli.List(reqCtx.Ctx, pdbs, client.InNamespace(ops.Namespace)); err != nil {
+		return err
+	}
+	var pods *corev1.PodList
+	for _, pdb := range pdbs.Items {
+		if pdb.Labels[constant.AppManagedByLabelKey] == constant.AppName || pdb.Status.DisruptionsAllowed > 0 || pdb.Spec.Selector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			continue
+		}
+		if pods == nil {
+			pods = &corev1.PodList{}
+			if err = cli.List(reqCtx.Ctx, pods, client.InNamespace(ops.Namespace),
+				client.MatchingLabels{constant.AppInstanceLabelKey: opsRes.Cluster.Name}); err != nil {
+				return err
+			}
+		}
+		for _, pod := range pods.Items {
+			if selector.Matches(labels.Set(pod.Labels)) {
+				return intctrlutil.NewFatalError(fmt.Sprintf(`the PodDisruptionBudget "%s" allows no disruption of the pod "%s" currently`,
+					pdb.Name, pod.Name))
+			}
+		}
+	}
+	return nil
+}
+
+// OpsPreCheckCallout configures an external pre-check called out through HTTP(S) by the operator.
+type OpsPreCheckCallout struct {
+	// Name is the name of the callout.
+	Name string `json:"name"`
+	// URL is the endpoint which the OpsPreCheckReview is posted to.
+	URL string `json:"url"`
+	// OpsTypes are the types of the OpsRequests to check, all types are checked if empty.
+	OpsTypes []appsv1alpha1.OpsType `json:"opsTypes,omitempty"`
+	// TimeoutSeconds is the timeout of the callout, defaults to 10 seconds.
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+	// FailurePolicy is "Fail" or "Ignore", which defines how the failures of the callout are handled, defaults to "Fail".
+	// The OpsRequest is retried rather than rejected if the callout fails with the "Fail" policy.
+	FailurePolicy string `json:"failurePolicy,omitempty"`
+}
+
+// OpsPreCheckReview is the payload posted to an OpsPreCheckCallout.
+type OpsPreCheckReview struct {
+	OpsRequest *appsv1alpha1.OpsRequest `json:"opsRequest"`
+	Cluster    *appsv1alpha1.Cluster    `json:"cluster,omitempty"`
+}
+
+// OpsPreCheckResult is the response of an OpsPreCheckCallout.
+type OpsPreCheckResult struct {
+	// Allowed indicates whether the OpsRequest is admitted.
+	Allowed bool `json:"allowed"`
+	// Message describes the reason why the OpsRequest is rejected.
+	Message string `json:"message,omitempty"`
+}
+
+// calloutPreCheck calls out the external pre-checks configured by CfgKeyOpsPreCheckCallouts.
+type calloutPreCheck struct{}
+
+func (c calloutPreCheck) Name() string {
+	return "Callout"
+}
+
+func (c calloutPreCheck) Check(reqCtx intctrlutil.RequestCtx, _ client.Client, opsRes *OpsResource) error {
+	val := viper.GetString(constant.CfgKeyOpsPreCheckCallouts)
+	if len(val) == 0 {
+		return nil
+	}
+	var callouts []OpsPreCheckCallout
+	if err := json.Unmarshal([]byte(val), &callouts); err != nil {
+		return fmt.Errorf("failed to parse the pre-check callouts of the OpsRequests: %w", err)
+	}
+	for _, callout := range callouts {
+		if len(callout.OpsTypes) > 0 && !slices.Contains(callout.OpsTypes, opsRes.OpsRequest.Spec.Type) {
+			continue
+		}
+		result, err := callPreCheckCallout(reqCtx, callout, opsRes)
+		if err != nil {
+			if callout.FailurePolicy == preCheckCalloutFailurePolicyIgnore {
+				reqCtx.Log.Info("ignore the failure of the pre-check callout", "callout", callout.Name, "error", err.Error())
+				continue
+			}
+			return err
+		}
+		if !result.Allowed {
+			return intctrlutil.NewFatalError(fmt.Sprintf(`denied by "%s": %s`, callout.Name, result.Message))
+		}
+	}
+	return nil
+}
+
+func callPreCheckCallout(reqCtx intctrlutil.RequestCtx, callout OpsPreCheckCallout, opsRes *OpsResource) (*OpsPreCheckResult, error) {
+	body, err := json.Marshal(OpsPreCheckReview{
+		OpsRequest: opsRes.OpsRequest,
+		Cluster:    opsRes.Cluster,
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(reqCtx.Ctx, http.MethodPost, callout.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	timeout := defaultPreCheckCalloutTimeout
+	if callout.TimeoutSeconds > 0 {
+		timeout = time.Duration(callout.TimeoutSeconds) * time.Second
+	}
+	httpClient := &http.Client{
+		Timeout:   timeout,
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{MinVersion: tls.VersionTLS12}},
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to call the pre-check callout "%s": %w`, callout.Name, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxExternalOpsResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf(`the pre-check callout "%s" responds with status %d: %s`, callout.Name, resp.StatusCode, string(data))
+	}
+	result := &OpsPreCheckResult{}
+	if err = json.Unmarshal(data, result); err != nil {
+		return nil, fmt.Errorf(`failed to decode the response of the pre-check callout "%s": %w`, callout.Name, err)
+	}
+	return result, nil
+}
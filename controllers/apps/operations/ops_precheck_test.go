@@ -0,0 +1,210 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+var _ = Describe("ops pre-checks", func() {
+	const (
+		namespace   = "default"
+		clusterName = "mycluster"
+		compName    = "mysql"
+	)
+
+	var reqCtx = intctrlutil.RequestCtx{Ctx: context.Background(), Log: logr.Discard()}
+
+	newOpsResource := func(opsType appsv1alpha1.OpsType) *OpsResource {
+		return &OpsResource{
+			Cluster: &appsv1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: clusterName},
+				Spec: appsv1alpha1.ClusterSpec{
+					ComponentSpecs: []appsv1alpha1.ClusterComponentSpec{{
+						Name:     compName,
+						Replicas: 3,
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+							Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+						},
+					}},
+				},
+			},
+			OpsRequest: &appsv1alpha1.OpsRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "ops-" + string(opsType)},
+				Spec:       appsv1alpha1.OpsRequestSpec{ClusterName: clusterName, Type: opsType},
+			},
+		}
+	}
+
+	newClient := func(objs ...client.Object) client.Client {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+		Expect(appsv1alpha1.AddToScheme(scheme)).Should(Succeed())
+		return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	}
+
+	expectQuantity := func(list corev1.ResourceList, name corev1.ResourceName, expected string) {
+		quantity := list[name]
+		ExpectWithOffset(1, quantity.Cmp(resource.MustParse(expected))).Should(BeZero())
+	}
+
+	Context("resource quota", func() {
+		It("computes the resources requested additionally", func() {
+			opsRes := newOpsResource(appsv1alpha1.HorizontalScalingType)
+			opsRes.OpsRequest.Spec.HorizontalScalingList = []appsv1alpha1.HorizontalScaling{{
+				ComponentOps: appsv1alpha1.ComponentOps{ComponentName: compName},
+				ScaleOut:     &appsv1alpha1.ScaleOut{ReplicaChanger: appsv1alpha1.ReplicaChanger{ReplicaChanges: pointer.Int32(2)}},
+			}}
+			increment := getRequestedResourceIncrement(opsRes)
+			expectQuantity(increment, corev1.ResourcePods, "2")
+			expectQuantity(increment, "requests.cpu", "2")
+			expectQuantity(increment, corev1.ResourceMemory, "2Gi")
+			expectQuantity(increment, "limits.cpu", "4")
+
+			By("only the increased resources are requested by the vertical scaling")
+			opsRes = newOpsResource(appsv1alpha1.VerticalScalingType)
+			opsRes.OpsRequest.Spec.VerticalScalingList = []appsv1alpha1.VerticalScaling{{
+				ComponentOps: appsv1alpha1.ComponentOps{ComponentName: compName},
+				ResourceRequirements: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1500m"), corev1.ResourceMemory: resource.MustParse("512Mi")},
+				},
+			}}
+			increment = getRequestedResourceIncrement(opsRes)
+			Expect(increment).Should(HaveLen(2))
+			expectQuantity(increment, "requests.cpu", "1500m")
+			expectQuantity(increment, corev1.ResourceCPU, "1500m")
+		})
+
+		It("rejects the scale-out beyond the quota", func() {
+			quota := &corev1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "quota"},
+				Status: corev1.ResourceQuotaStatus{
+					Hard: corev1.ResourceList{"requests.cpu": resource.MustParse("4")},
+					Used: corev1.ResourceList{"requests.cpu": resource.MustParse("3")},
+				},
+			}
+			cli := newClient(quota)
+			opsRes := newOpsResource(appsv1alpha1.HorizontalScalingType)
+			opsRes.OpsRequest.Spec.HorizontalScalingList = []appsv1alpha1.HorizontalScaling{{
+				ComponentOps: appsv1alpha1.ComponentOps{ComponentName: compName},
+				Replicas:     pointer.Int32(5),
+			}}
+			err := resourceQuotaPreCheck{}.Check(reqCtx, cli, opsRes)
+			Expect(intctrlutil.IsTerminalError(err)).Should(BeTrue())
+			Expect(err.Error()).Should(ContainSubstring(`ResourceQuota "quota"`))
+
+			By("the scale-out within the quota is admitted")
+			opsRes.OpsRequest.Spec.HorizontalScalingList[0].Replicas = pointer.Int32(4)
+			Expect(resourceQuotaPreCheck{}.Check(reqCtx, cli, opsRes)).Should(Succeed())
+		})
+	})
+
+	It("rejects restarting the pods protected by a PodDisruptionBudget allowing no disruption", func() {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      fmt.Sprintf("%s-%s-0", clusterName, compName),
+			Labels:    map[string]string{constant.AppInstanceLabelKey: clusterName, "app": "orders"},
+		}}
+		pdb := &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "orders"},
+			Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "orders"}}},
+			Status:     policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 0},
+		}
+		cli := newClient(pod, pdb)
+		err := podDisruptionBudgetPreCheck{}.Check(reqCtx, cli, newOpsResource(appsv1alpha1.RestartType))
+		Expect(intctrlutil.IsTerminalError(err)).Should(BeTrue())
+
+		By("the OpsRequests which don't restart the pods are admitted")
+		Expect(podDisruptionBudgetPreCheck{}.Check(reqCtx, cli, newOpsResource(appsv1alpha1.ExposeType))).Should(Succeed())
+
+		By("the forced OpsRequests are admitted")
+		opsRes := newOpsResource(appsv1alpha1.RestartType)
+		opsRes.OpsRequest.Spec.Force = true
+		Expect(podDisruptionBudgetPreCheck{}.Check(reqCtx, cli, opsRes)).Should(Succeed())
+	})
+
+	It("calls out the external pre-checks", func() {
+		var result OpsPreCheckResult
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			review := OpsPreCheckReview{}
+			if err := json.NewDecoder(r.Body).Decode(&review); err != nil || review.OpsRequest == nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(result)
+		}))
+		defer server.Close()
+		setCallouts := func(callouts ...OpsPreCheckCallout) {
+			data, err := json.Marshal(callouts)
+			Expect(err).ShouldNot(HaveOccurred())
+			viper.Set(constant.CfgKeyOpsPreCheckCallouts, string(data))
+		}
+		defer viper.Set(constant.CfgKeyOpsPreCheckCallouts, "")
+
+		opsRes := newOpsResource(appsv1alpha1.HorizontalScalingType)
+		setCallouts(OpsPreCheckCallout{Name: "cost-control", URL: server.URL})
+		result = OpsPreCheckResult{Allowed: false, Message: "over budget"}
+		err := calloutPreCheck{}.Check(reqCtx, nil, opsRes)
+		Expect(intctrlutil.IsTerminalError(err)).Should(BeTrue())
+		Expect(err.Error()).Should(ContainSubstring("over budget"))
+
+		result = OpsPreCheckResult{Allowed: true}
+		Expect(calloutPreCheck{}.Check(reqCtx, nil, opsRes)).Should(Succeed())
+
+		By("the callouts of other types are skipped")
+		result = OpsPreCheckResult{Allowed: false}
+		setCallouts(OpsPreCheckCallout{Name: "cost-control", URL: server.URL, OpsTypes: []appsv1alpha1.OpsType{appsv1alpha1.VerticalScalingType}})
+		Expect(calloutPreCheck{}.Check(reqCtx, nil, opsRes)).Should(Succeed())
+
+		By("the unreachable callouts are retried unless the failures are ignored")
+		unreachable := httptest.NewServer(http.NotFoundHandler())
+		unreachable.Close()
+		setCallouts(OpsPreCheckCallout{Name: "unreachable", URL: unreachable.URL})
+		err = calloutPreCheck{}.Check(reqCtx, nil, opsRes)
+		Expect(err).Should(HaveOccurred())
+		Expect(intctrlutil.IsTerminalError(err)).Should(BeFalse())
+		setCallouts(OpsPreCheckCallout{Name: "unreachable", URL: unreachable.URL, FailurePolicy: preCheckCalloutFailurePolicyIgnore})
+		Expect(calloutPreCheck{}.Check(reqCtx, nil, opsRes)).Should(Succeed())
+	})
+})
@@ -21,7 +21,6 @@ package operations
 
 import (
 	"fmt"
-	"reflect"
 	"strings"
 	"time"
 
@@ -74,6 +73,7 @@ func setComponentStatusProgressDetail(
 		updateProgressDetailTime(&newProgressDetail)
 		*progressDetails = append(*progressDetails, newProgressDetail)
 		sendProgressDetailEvent(recorder, opsRequest, newProgressDetail)
+		appendProgressTimelineEntry(opsRequest, newProgressDetail)
 		return
 	}
 	if existingProgressDetail.Status == newProgressDetail.Status &&
@@ -90,6 +90,7 @@ func setComponentStatusProgressDetail(
 	existingProgressDetail.ActionTasks = newProgressDetail.ActionTasks
 	updateProgressDetailTime(existingProgressDetail)
 	sendProgressDetailEvent(recorder, opsRequest, newProgressDetail)
+	appendProgressTimelineEntry(opsRequest, newProgressDetail)
 }
 
 // findStatusProgressDetail finds the progressDetail of the specified objectKey in progressDetails.
@@ -145,6 +146,19 @@ func sendProgressDetailEvent(recorder record.EventRecorder,
 		getProgressDetailEventReason(status), progressDetail.Message)
 }
 
+// appendProgressTimelineEntry records the change of the progress detail to the timeline of the OpsRequest.
+func appendProgressTimelineEntry(opsRequest *appsv1alpha1.OpsRequest, progressDetail appsv1alpha1.ProgressStatusDetail) {
+	if progressDetail.Status == appsv1alpha1.PendingProgressStatus {
+		return
+	}
+	objectKey := progressDetail.ObjectKey
+	if objectKey == "" {
+		objectKey = getProgressObjectKey("Action", progressDetail.ActionName)
+	}
+	opsRequest.AppendTimelineEntry(appsv1alpha1.ProgressChangedTimelineEntry, string(progressDetail.Status),
+		objectKey, progressDetail.Message)
+}
+
 // updateProgressDetailTime updates the progressDetail startTime or endTime according to the status.
 func updateProgressDetailTime(progressDetail *appsv1alpha1.ProgressStatusDetail) {
 	if progressDetail.Status == appsv1alpha1.ProcessingProgressStatus &&
@@ -218,7 +232,7 @@ func handleProgressForPodsRollingUpdate(
 	var completedCount int32
 	for _, v := range pods {
 		objectKey := getProgressObjectKey(constant.PodKind, v.Name)
-		progressDetail := appsv1alpha1.ProgressStatusDetail{ObjectKey: objectKey}
+		progressDetail := appsv1alpha1.ProgressStatusDetail{ObjectKey: objectKey, Group: pgRes.updatedPodSet[v.Name]}
 		if podProcessedSuccessful(pgRes, opsRequest, v, minReadySeconds, podApplyOps) {
 			completedCount += 1
 			handleSucceedProgressDetail(opsRes, pgRes, compStatus, progressDetail)
@@ -226,7 +240,7 @@ func handleProgressForPodsRollingUpdate(
 		}
 		// no re-create the pod or no any changes applied in place.
 		if notRecreatedDuringOperation(opsRequest.Status.StartTimestamp, v) &&
-			!podApplyOps(opsRequest, v, pgRes.compOps, v.Name) {
+			!podApplyOps(opsRequest, v, pgRes.compOps, pgRes.updatedPodSet[v.Name]) {
 			handlePendingProgressDetail(opsRes, compStatus, progressDetail)
 			continue
 		}
@@ -255,7 +269,7 @@ func handleCancelProgressForPodsRollingUpdate(
 	var completedCount int32
 	for _, pod := range pods {
 		objectKey := getProgressObjectKey(constant.PodKind, pod.Name)
-		progressDetail := appsv1alpha1.ProgressStatusDetail{ObjectKey: objectKey}
+		progressDetail := appsv1alpha1.ProgressStatusDetail{ObjectKey: objectKey, Group: pgRes.updatedPodSet[pod.Name]}
 		if podProcessedSuccessful(pgRes, opsRes.OpsRequest, pod, minReadySeconds, podApplyOps) {
 			completedCount += 1
 			handleSucceedProgressDetail(opsRes, pgRes, compStatus, progressDetail)
@@ -393,6 +407,7 @@ func handleComponentProgressForScalingReplicas(reqCtx intctrlutil.RequestCtx,
 	if updatedPodCount == 0 {
 		return 0, 0, nil
 	}
+	lastProgressDetails := slices.Clone(compStatus.ProgressDetails)
 	itsName := constant.GenerateClusterComponentName(opsRes.Cluster.Name, pgRes.fullComponentName)
 	its := &workloads.InstanceSet{}
 	if err = cli.Get(reqCtx.Ctx, client.ObjectKey{Name: itsName, Namespace: opsRes.OpsRequest.Namespace}, its); err != nil {
@@ -412,9 +427,75 @@ func handleComponentProgressForScalingReplicas(reqCtx intctrlutil.RequestCtx,
 		}
 		completedCount += scaleInCompletedCount
 	}
+	if err == nil && handleProgressDeadline(opsRes, pgRes, compStatus, lastProgressDetails) {
+		// the uncompleted pods are marked as Failed.
+		completedCount = updatedPodCount
+	}
 	return updatedPodCount, completedCount, err
 }
 
+// progressDeadlineGetter is implemented by the component ops which can declare a progress deadline.
+type progressDeadlineGetter interface {
+	GetProgressDeadlineSeconds() *int32
+}
+
+// handleProgressDeadline marks the uncompleted progressDetails of the component as Failed if they have not changed
+// within the progress deadline of the component ops.
+// @return whether the progress deadline is exceeded.
+func handleProgressDeadline(opsRes *OpsResource,
+	pgRes *progressResource,
+	compStatus *appsv1alpha1.OpsRequestComponentStatus,
+	lastProgressDetails []appsv1alpha1.ProgressStatusDetail) bool {
+	getter, ok := pgRes.compOps.(progressDeadlineGetter)
+	if !ok || getter.GetProgressDeadlineSeconds() == nil {
+		return false
+	}
+	deadlineSeconds := *getter.GetProgressDeadlineSeconds()
+	deadline := time.Duration(deadlineSeconds) * time.Second
+	if compStatus.LastProgressTime.IsZero() || progressDetailsChanged(lastProgressDetails, compStatus.ProgressDetails) {
+		compStatus.LastProgressTime = metav1.Now()
+		pgRes.requeueAfter = deadline
+		return false
+	}
+	if waitTime := time.Until(compStatus.LastProgressTime.Add(deadline)); waitTime > 0 {
+		// requeue to check the deadline, as no event may be triggered if the component is stuck.
+		pgRes.requeueAfter = waitTime
+		return false
+	}
+	var failedCount int32
+	groupPrefix := pgRes.fullComponentName + "/"
+	for _, v := range compStatus.ProgressDetails {
+		if isCompletedProgressStatus(v.Status) || !strings.HasPrefix(v.Group, groupPrefix) {
+			continue
+		}
+		v.Status = appsv1alpha1.FailedProgressStatus
+		v.Message = fmt.Sprintf("%s, but no progress is made within the progress deadline of %ds", v.Message, deadlineSeconds)
+		setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, v)
+		failedCount += 1
+	}
+	if failedCount > 0 {
+		condition := appsv1alpha1.NewProgressDeadlineExceededCondition(opsRes.OpsRequest, pgRes.clusterComponent.Name, deadlineSeconds)
+		compStatus.Reason = condition.Reason
+		compStatus.Message = condition.Message
+		opsRes.OpsRequest.SetStatusCondition(*condition)
+	}
+	return true
+}
+
+// progressDetailsChanged checks whether any progressDetail is added, removed or changes its status.
+func progressDetailsChanged(oldProgressDetails, newProgressDetails []appsv1alpha1.ProgressStatusDetail) bool {
+	if len(oldProgressDetails) != len(newProgressDetails) {
+		return true
+	}
+	for _, v := range newProgressDetails {
+		oldProgressDetail := findStatusProgressDetail(oldProgressDetails, v.ObjectKey)
+		if oldProgressDetail == nil || oldProgressDetail.Status != v.Status {
+			return true
+		}
+	}
+	return false
+}
+
 func updateProgressDetailForHScale(
 	opsRes *OpsResource,
 	pgRes *progressResource,
@@ -507,16 +588,27 @@ func handleScaleInProgressWithInstanceSet(
 	return completedCount, nil
 }
 
+// syncProgressToOpsRequest patches the progress of the OpsRequest, the patch of the progress details churning before
+// the completion may be coalesced, it returns the time after which the OpsRequest should be requeued to flush it.
 func syncProgressToOpsRequest(
 	reqCtx intctrlutil.RequestCtx,
 	cli client.Client,
 	opsRes *OpsResource,
 	oldOpsRequest *appsv1alpha1.OpsRequest,
-	completedCount, expectCount int) error {
+	completedCount, expectCount int) (time.Duration, error) {
 	// sync progress
 	opsRes.OpsRequest.Status.Progress = fmt.Sprintf("%d/%d", completedCount, expectCount)
-	if !reflect.DeepEqual(opsRes.OpsRequest.Status, oldOpsRequest.Status) {
-		return cli.Status().Patch(reqCtx.Ctx, opsRes.OpsRequest, client.MergeFrom(oldOpsRequest))
+	var patchOpts []intctrlutil.StatusPatchOption
+	if completedCount < expectCount {
+		patchOpts = append(patchOpts, intctrlutil.WithCoalesce())
 	}
-	return nil
+	return intctrlutil.PatchStatus(reqCtx.Ctx, cli, opsRes.OpsRequest, oldOpsRequest, patchOpts...)
+}
+
+// requeueForStatusFlush returns the requeue time no later than the flush of the status patch deferred by the coalescing.
+func requeueForStatusFlush(requeueAfter, flushAfter time.Duration) time.Duration {
+	if flushAfter > 0 && (requeueAfter == 0 || flushAfter < requeueAfter) {
+		return flushAfter
+	}
+	return requeueAfter
 }
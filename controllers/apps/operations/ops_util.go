@@ -27,6 +27,7 @@ import (
 	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -35,6 +36,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/configuration/core"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 var _ error = &WaitForClusterPhaseErr{}
@@ -75,20 +77,30 @@ func PatchOpsStatusWithOpsDeepCopy(ctx context.Context,
 	condition ...*metav1.Condition) error {
 
 	opsRequest := opsRes.OpsRequest
-	patch := client.MergeFrom(opsRequestDeepCopy)
 	for _, v := range condition {
 		if v == nil {
 			continue
 		}
+		// emit an event only if the condition changes, the status is patched repeatedly with the same condition.
+		last := meta.FindStatusCondition(opsRequest.Status.Conditions, v.Type)
+		changed := last == nil || last.Status != v.Status || last.Reason != v.Reason || last.Message != v.Message
 		opsRequest.SetStatusCondition(*v)
-		// emit an event
+		if !changed {
+			continue
+		}
 		eventType := corev1.EventTypeNormal
 		if phase == appsv1alpha1.OpsFailedPhase {
 			eventType = corev1.EventTypeWarning
 		}
 		opsRes.Recorder.Event(opsRequest, eventType, v.Reason, v.Message)
 	}
+	if phase != opsRequestDeepCopy.Status.Phase {
+		appendPhaseTimelineEntry(opsRequest, opsRequestDeepCopy.Status.Phase, phase, condition...)
+	}
 	opsRequest.Status.Phase = phase
+	if opsRequest.IsComplete(phase) && meta.FindStatusCondition(opsRequest.Status.Conditions, appsv1alpha1.ConditionTypeProgressCompleted) == nil {
+		opsRequest.SetStatusCondition(*appsv1alpha1.NewProgressCompletedCondition(opsRequest, phase))
+	}
 	if opsRequest.IsComplete(phase) {
 		opsRequest.Status.CompletionTimestamp = metav1.Time{Time: time.Now()}
 		// when OpsRequest is completed, remove it from annotation
@@ -99,7 +111,26 @@ func PatchOpsStatusWithOpsDeepCopy(ctx context.Context,
 	if phase == appsv1alpha1.OpsCreatingPhase && opsRequest.Status.StartTimestamp.IsZero() {
 		opsRequest.Status.StartTimestamp = metav1.Time{Time: time.Now()}
 	}
-	return cli.Status().Patch(ctx, opsRequest, patch)
+	if _, err := intctrlutil.PatchStatus(ctx, cli, opsRequest, opsRequestDeepCopy); err != nil {
+		return err
+	}
+	recordOpsPhaseMetrics(opsRequest, opsRequestDeepCopy.Status.Phase)
+	return nil
+}
+
+// appendPhaseTimelineEntry records the transition of the phase to the timeline of the OpsRequest,
+// the message of the last condition is recorded as the details of the transition.
+func appendPhaseTimelineEntry(opsRequest *appsv1alpha1.OpsRequest,
+	fromPhase, toPhase appsv1alpha1.OpsPhase,
+	condition ...*metav1.Condition) {
+	message := fmt.Sprintf("%s -> %s", fromPhase, toPhase)
+	for i := len(condition) - 1; i >= 0; i-- {
+		if condition[i] != nil && condition[i].Message != "" {
+			message = fmt.Sprintf("%s: %s", message, condition[i].Message)
+			break
+		}
+	}
+	opsRequest.AppendTimelineEntry(appsv1alpha1.PhaseChangedTimelineEntry, string(toPhase), "", message)
 }
 
 // PatchOpsStatus patches OpsRequest.status
@@ -132,9 +163,70 @@ func patchValidateErrorCondition(ctx context.Context, cli client.Client, opsRes
 }
 
 // patchFatalFailErrorCondition patches a new failed condition to the OpsRequest.status.conditions.
-func patchFatalFailErrorCondition(ctx context.Context, cli client.Client, opsRes *OpsResource, err error) error {
+// opsDeepCopy is the OpsRequest before the action is applied, so that the status changed by the action is patched too.
+func patchFatalFailErrorCondition(ctx context.Context, cli client.Client, opsRes *OpsResource,
+	opsDeepCopy *appsv1alpha1.OpsRequest, err error) error {
 	condition := appsv1alpha1.NewFailedCondition(opsRes.OpsRequest, err)
-	return PatchOpsStatus(ctx, cli, opsRes, appsv1alpha1.OpsFailedPhase, condition)
+	return PatchOpsStatusWithOpsDeepCopy(ctx, cli, opsRes, opsDeepCopy, appsv1alpha1.OpsFailedPhase, condition,
+		appsv1alpha1.NewActionApplyFailedCondition(opsRes.OpsRequest, err))
+}
+
+// maxFailureBackoff is the upper limit of the wait time between two attempts retried by `spec.failurePolicy`.
+const maxFailureBackoff = 10 * time.Minute
+
+// retryTransientFailure records the attempt failed with a transient error according to `spec.failurePolicy`,
+// and returns the wait time before the next attempt.
+// It returns false with the error to fail the OpsRequest once the retries are exhausted.
+func retryTransientFailure(ctx context.Context, cli client.Client, opsRes *OpsResource, err error) (time.Duration, bool, error) {
+	opsRequest := opsRes.OpsRequest
+	policy := opsRequest.Spec.FailurePolicy
+	if opsRequest.Status.FailedAttempts >= policy.Retries {
+		return 0, false, fmt.Errorf("failed after %d retries: %w", opsRequest.Status.FailedAttempts, err)
+	}
+	opsDeepCopy := opsRequest.DeepCopy()
+	opsRequest.Status.FailedAttempts++
+	opsRequest.Status.LastFailedAttempt = &appsv1alpha1.OpsFailedAttempt{
+		Time:    metav1.Now(),
+		Message: err.Error(),
+	}
+	if _, patchErr := intctrlutil.PatchStatus(ctx, cli, opsRequest, opsDeepCopy); patchErr != nil {
+		return 0, true, patchErr
+	}
+	backoff := getFailureBackoff(policy, opsRequest.Status.FailedAttempts)
+	opsRes.Recorder.Eventf(opsRequest, corev1.EventTypeWarning, appsv1alpha1.ReasonRetryingFailedAttempt,
+		"attempt %d/%d failed, retry after %s: %s", opsRequest.Status.FailedAttempts, policy.Retries, backoff, err.Error())
+	return backoff, true, nil
+}
+
+// getFailureBackoff gets the wait time before the next attempt, which doubles after each failed attempt.
+func getFailureBackoff(policy *appsv1alpha1.OpsFailurePolicy, failedAttempts int32) time.Duration {
+	backoff := time.Duration(max(policy.BackoffSeconds, 1)) * time.Second
+	for i := int32(1); i < failedAttempts && backoff < maxFailureBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxFailureBackoff)
+}
+
+// resetFailedAttempts resets the counter of the consecutive failed attempts once an attempt succeeds.
+func resetFailedAttempts(ctx context.Context, cli client.Client, opsRes *OpsResource) error {
+	if opsRes.OpsRequest.Status.FailedAttempts == 0 {
+		return nil
+	}
+	opsDeepCopy := opsRes.OpsRequest.DeepCopy()
+	opsRes.OpsRequest.Status.FailedAttempts = 0
+	_, err := intctrlutil.PatchStatus(ctx, cli, opsRes.OpsRequest, opsDeepCopy)
+	return err
+}
+
+// patchQueuedCondition patches the Queued condition with the reason to the Pending OpsRequest,
+// it's skipped if the OpsRequest is already queued for the same reason and message to avoid the duplicate events.
+func patchQueuedCondition(ctx context.Context, cli client.Client, opsRes *OpsResource, reason, message string) error {
+	queued := meta.FindStatusCondition(opsRes.OpsRequest.Status.Conditions, appsv1alpha1.ConditionTypeQueued)
+	if queued != nil && queued.Status == metav1.ConditionTrue && queued.Reason == reason && queued.Message == message {
+		return nil
+	}
+	return PatchOpsStatus(ctx, cli, opsRes, appsv1alpha1.OpsPendingPhase,
+		appsv1alpha1.NewQueuedCondition(opsRes.OpsRequest, reason, message))
 }
 
 // GetOpsRecorderFromSlice gets OpsRequest recorder from slice by target cluster phase
@@ -161,7 +253,8 @@ func patchOpsRequestToCreating(reqCtx intctrlutil.RequestCtx,
 	if err != nil {
 		return err
 	}
-	return PatchOpsStatusWithOpsDeepCopy(reqCtx.Ctx, cli, opsRes, opsDeepCoy, appsv1alpha1.OpsCreatingPhase, validatePassCondition, condition)
+	return PatchOpsStatusWithOpsDeepCopy(reqCtx.Ctx, cli, opsRes, opsDeepCoy, appsv1alpha1.OpsCreatingPhase, validatePassCondition,
+		appsv1alpha1.NewDequeuedCondition(opsRes.OpsRequest), condition)
 }
 
 // isOpsRequestFailedPhase checks the OpsRequest phase is Failed
@@ -195,6 +288,40 @@ func updateReconfigureStatusByCM(reconfiguringStatus *appsv1alpha1.Reconfiguring
 	return handleReconfigureStatus(cmStatus)
 }
 
+// getOpsMaintenanceWindow returns the maintenance window of the OpsRequest, nil if not specified.
+func getOpsMaintenanceWindow(ops *appsv1alpha1.OpsRequest) *appsv1alpha1.MaintenanceWindow {
+	if ops.Spec.SchedulingPolicy == nil {
+		return nil
+	}
+	return ops.Spec.SchedulingPolicy.MaintenanceWindow
+}
+
+// validateMaintenanceWindow validates whether the maintenance window of the OpsRequest is valid and honored by the OpsType.
+func validateMaintenanceWindow(ops *appsv1alpha1.OpsRequest, opsBehaviour OpsBehaviour) error {
+	window := getOpsMaintenanceWindow(ops)
+	if window == nil {
+		return nil
+	}
+	if !opsBehaviour.HonorMaintenanceWindow {
+		return fmt.Errorf(`spec.schedulingPolicy.maintenanceWindow is not supported by the OpsRequest type "%s"`, ops.Spec.Type)
+	}
+	if _, err := time.Parse("15:04", window.StartTime); err != nil {
+		return fmt.Errorf(`invalid spec.schedulingPolicy.maintenanceWindow.startTime "%s"`, window.StartTime)
+	}
+	if window.Duration.Duration <= 0 {
+		return fmt.Errorf("spec.schedulingPolicy.maintenanceWindow.duration must be greater than 0")
+	}
+	return nil
+}
+
+// maintenanceWindowRequeueAfter returns the duration to requeue the OpsRequest waiting for the maintenance window.
+func maintenanceWindowRequeueAfter(wait time.Duration) time.Duration {
+	if wait <= 0 {
+		return time.Minute
+	}
+	return wait
+}
+
 // validateOpsWaitingPhase validates whether the current cluster phase is expected, and whether the waiting time exceeds the limit.
 // only requests with `Pending` phase will be validated.
 func validateOpsWaitingPhase(cluster *appsv1alpha1.Cluster, ops *appsv1alpha1.OpsRequest, opsBehaviour OpsBehaviour) error {
@@ -231,11 +358,16 @@ func needWaitPreConditionDeadline(ops *appsv1alpha1.OpsRequest) bool {
 	return time.Now().Before(ops.GetCreationTimestamp().Add(time.Duration(*ops.Spec.PreConditionDeadlineSeconds) * time.Second))
 }
 
-func abortEarlierOpsRequestWithSameKind(reqCtx intctrlutil.RequestCtx,
+// abortConflictingOpsRequests aborts the earlier running opsRequests whose types are declared with ConflictPolicyAbort
+// by the opsRequest and match the abort condition.
+func abortConflictingOpsRequests(reqCtx intctrlutil.RequestCtx,
 	cli client.Client,
 	opsRes *OpsResource,
-	sameKinds []appsv1alpha1.OpsType,
 	matchAbortCondition func(earlierOps *appsv1alpha1.OpsRequest) (bool, error)) error {
+	abortKinds := getAbortOpsTypes(opsRes.OpsRequest.Spec.Type)
+	if len(abortKinds) == 0 {
+		return nil
+	}
 	opsRequestSlice, err := opsutil.GetOpsRequestSliceFromCluster(opsRes.Cluster)
 	if err != nil {
 		return err
@@ -243,7 +375,7 @@ func abortEarlierOpsRequestWithSameKind(reqCtx intctrlutil.RequestCtx,
 	// get the running opsRequest before this opsRequest to running.
 	var earlierRunningOpsSlice []appsv1alpha1.OpsRecorder
 	for i := range opsRequestSlice {
-		if !slices.Contains(sameKinds, opsRequestSlice[i].Type) {
+		if !slices.Contains(abortKinds, opsRequestSlice[i].Type) {
 			continue
 		}
 		if opsRequestSlice[i].Name == opsRes.OpsRequest.Name {
@@ -274,14 +406,21 @@ func abortEarlierOpsRequestWithSameKind(reqCtx intctrlutil.RequestCtx,
 		if needAborted {
 			// abort the opsRequest that matches the abort condition.
 			patch := client.MergeFrom(earlierOps.DeepCopy())
-			earlierOps.Status.Phase = appsv1alpha1.OpsAbortedPhase
 			abortedCondition := appsv1alpha1.NewAbortedCondition(fmt.Sprintf(`Aborted as a result of the latest opsRequest "%s" being overridden`, earlierOps.Name))
+			earlierOps.AppendTimelineEntry(appsv1alpha1.AbortedTimelineEntry, abortedCondition.Reason,
+				getProgressObjectKey(constant.OpsRequestKind, opsRes.OpsRequest.Name), abortedCondition.Message)
+			appendPhaseTimelineEntry(earlierOps, earlierOps.Status.Phase, appsv1alpha1.OpsAbortedPhase)
+			earlierOps.Status.Phase = appsv1alpha1.OpsAbortedPhase
 			earlierOps.SetStatusCondition(*abortedCondition)
+			earlierOps.SetStatusCondition(*appsv1alpha1.NewProgressCompletedCondition(earlierOps, appsv1alpha1.OpsAbortedPhase))
 			earlierOps.Status.CompletionTimestamp = metav1.Time{Time: time.Now()}
 			if err = cli.Status().Patch(reqCtx.Ctx, earlierOps, patch); err != nil {
 				return err
 			}
 			opsRes.Recorder.Event(earlierOps, corev1.EventTypeNormal, abortedCondition.Type, abortedCondition.Message)
+			opsRes.OpsRequest.AppendTimelineEntry(appsv1alpha1.AbortedTimelineEntry, "AbortedEarlierOpsRequest",
+				getProgressObjectKey(constant.OpsRequestKind, earlierOps.Name),
+				fmt.Sprintf(`aborted the earlier %s opsRequest "%s"`, earlierOps.Spec.Type, earlierOps.Name))
 			index, _ := GetOpsRecorderFromSlice(opsRequestSlice, earlierOps.Name)
 			if index != -1 {
 				opsRequestSlice = slices.Delete(opsRequestSlice, index, index+1)
@@ -321,3 +460,43 @@ func getComponentSpecOrShardingTemplate(cluster *appsv1alpha1.Cluster, component
 	}
 	return nil
 }
+
+// validateIdempotencyKey checks whether an earlier OpsRequest with the same idempotency key has been submitted
+// to the same cluster within the TTL, the OpsRequests rejected as duplicates are ignored.
+func validateIdempotencyKey(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRequest *appsv1alpha1.OpsRequest) error {
+	key := opsRequest.Spec.IdempotencyKey
+	if key == "" {
+		return nil
+	}
+	opsList := &appsv1alpha1.OpsRequestList{}
+	if err := cli.List(reqCtx.Ctx, opsList, client.InNamespace(opsRequest.Namespace)); err != nil {
+		return err
+	}
+	ttl := viper.GetDuration(constant.CfgKeyOpsIdempotencyKeyTTL)
+	isEarlier := func(ops *appsv1alpha1.OpsRequest) bool {
+		if ops.CreationTimestamp.Equal(&opsRequest.CreationTimestamp) {
+			return ops.Name < opsRequest.Name
+		}
+		return ops.CreationTimestamp.Before(&opsRequest.CreationTimestamp)
+	}
+	isDuplicate := func(ops *appsv1alpha1.OpsRequest) bool {
+		condition := meta.FindStatusCondition(ops.Status.Conditions, appsv1alpha1.ConditionTypeValidated)
+		return condition != nil && condition.Reason == appsv1alpha1.ReasonDuplicateOpsRequest
+	}
+	for i := range opsList.Items {
+		ops := &opsList.Items[i]
+		if ops.Name == opsRequest.Name || ops.Spec.IdempotencyKey != key ||
+			ops.Spec.GetClusterName() != opsRequest.Spec.GetClusterName() {
+			continue
+		}
+		if !isEarlier(ops) || isDuplicate(ops) {
+			continue
+		}
+		if ttl > 0 && opsRequest.CreationTimestamp.Sub(ops.CreationTimestamp.Time) > ttl {
+			continue
+		}
+		return intctrlutil.NewFatalError(fmt.Sprintf(`OpsRequest "%s" with the same idempotencyKey "%s" has been submitted to the cluster`,
+			ops.Name, key))
+	}
+	return nil
+}
@@ -20,12 +20,15 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -37,6 +40,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/generics"
 	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
 	testk8s "github.com/apecloud/kubeblocks/pkg/testutil/k8s"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 var _ = Describe("OpsUtil functions", func() {
@@ -88,6 +92,95 @@ var _ = Describe("OpsUtil functions", func() {
 			Expect(PatchClusterNotFound(ctx, k8sClient, opsRes)).Should(Succeed())
 		})
 
+		It("Test retry policy for the failed components", func() {
+			ops := testapps.NewOpsRequestObj("restart-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.RestartType)
+			failedTime := metav1.NewTime(time.Now().Add(-time.Minute))
+			ops.Status.Components = map[string]appsv1alpha1.OpsRequestComponentStatus{
+				defaultCompName: {
+					ProgressDetails: []appsv1alpha1.ProgressStatusDetail{
+						{ObjectKey: "Pod/pod-0", Status: appsv1alpha1.SucceedProgressStatus},
+						{ObjectKey: "Pod/pod-1", Status: appsv1alpha1.FailedProgressStatus, EndTime: failedTime},
+					},
+				},
+			}
+			compOpsHelper := newComponentOpsHelper([]appsv1alpha1.ComponentOps{{ComponentName: defaultCompName}})
+
+			By("expect no retry if the retry policy is not set")
+			Expect(compOpsHelper.retryFailedComponents(ops, []string{defaultCompName})).Should(BeZero())
+
+			By("expect the failed progressDetails to be removed when retrying")
+			ops.Spec.RetryPolicy = &appsv1alpha1.OpsRetryPolicy{MaxRetries: 1, BackoffSeconds: 5, MaxBackoffSeconds: 20}
+			Expect(compOpsHelper.retryFailedComponents(ops, []string{defaultCompName})).ShouldNot(BeZero())
+			compStatus := ops.Status.Components[defaultCompName]
+			Expect(compStatus.Retries).Should(BeEquivalentTo(1))
+			Expect(compStatus.ProgressDetails).Should(HaveLen(1))
+			Expect(compStatus.ProgressDetails[0].ObjectKey).Should(Equal("Pod/pod-0"))
+
+			By("expect the other failed components are still retried if the retries of one component are exhausted")
+			proxyCompName := "proxy"
+			ops.Status.Components[proxyCompName] = appsv1alpha1.OpsRequestComponentStatus{
+				ProgressDetails: []appsv1alpha1.ProgressStatusDetail{
+					{ObjectKey: "Pod/pod-2", Status: appsv1alpha1.FailedProgressStatus, EndTime: failedTime},
+				},
+			}
+			Expect(compOpsHelper.retryFailedComponents(ops, []string{defaultCompName, proxyCompName})).ShouldNot(BeZero())
+			Expect(ops.Status.Components[defaultCompName].Retries).Should(BeEquivalentTo(1))
+			Expect(ops.Status.Components[proxyCompName].Retries).Should(BeEquivalentTo(1))
+			Expect(ops.Status.Components[proxyCompName].ProgressDetails).Should(BeEmpty())
+
+			By("expect no retry if the retries of all the failed components are exhausted")
+			Expect(compOpsHelper.retryFailedComponents(ops, []string{defaultCompName, proxyCompName})).Should(BeZero())
+
+			By("test the backoff of retries")
+			Expect(getRetryBackoff(ops.Spec.RetryPolicy, 0)).Should(Equal(5 * time.Second))
+			Expect(getRetryBackoff(ops.Spec.RetryPolicy, 1)).Should(Equal(10 * time.Second))
+			Expect(getRetryBackoff(ops.Spec.RetryPolicy, 5)).Should(Equal(20 * time.Second))
+		})
+
+		It("Test failure policy for the transient errors", func() {
+			By("init operations resources ")
+			opsRes, _, _ := initOperationsResources(compDefName, clusterName)
+			ops := testapps.NewOpsRequestObj("restart-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.RestartType)
+			ops.Spec.RestartList = []appsv1alpha1.Restart{{ComponentOps: appsv1alpha1.ComponentOps{ComponentName: defaultCompName}}}
+			ops.Spec.FailurePolicy = &appsv1alpha1.OpsFailurePolicy{Retries: 2, BackoffSeconds: 5}
+			opsRes.OpsRequest = testapps.CreateOpsRequest(ctx, testCtx, ops)
+
+			By("expect the failed attempts to be recorded with the exponential backoff")
+			transientErr := fmt.Errorf("the object has been modified")
+			requeueAfter, retry, err := retryTransientFailure(ctx, k8sClient, opsRes, transientErr)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(retry).Should(BeTrue())
+			Expect(requeueAfter).Should(Equal(5 * time.Second))
+			requeueAfter, retry, err = retryTransientFailure(ctx, k8sClient, opsRes, transientErr)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(retry).Should(BeTrue())
+			Expect(requeueAfter).Should(Equal(10 * time.Second))
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest),
+				func(g Gomega, fetched *appsv1alpha1.OpsRequest) {
+					g.Expect(fetched.Status.FailedAttempts).Should(BeEquivalentTo(2))
+					g.Expect(fetched.Status.LastFailedAttempt).ShouldNot(BeNil())
+					g.Expect(fetched.Status.LastFailedAttempt.Message).Should(Equal(transientErr.Error()))
+				})).Should(Succeed())
+
+			By("expect no retry if the retries are exhausted")
+			_, retry, err = retryTransientFailure(ctx, k8sClient, opsRes, transientErr)
+			Expect(retry).Should(BeFalse())
+			Expect(errors.Is(err, transientErr)).Should(BeTrue())
+
+			By("expect the failed attempts to be reset once an attempt succeeds")
+			Expect(resetFailedAttempts(ctx, k8sClient, opsRes)).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest),
+				func(g Gomega, fetched *appsv1alpha1.OpsRequest) {
+					g.Expect(fetched.Status.FailedAttempts).Should(BeZero())
+				})).Should(Succeed())
+
+			By("test the backoff of the failed attempts")
+			Expect(getFailureBackoff(ops.Spec.FailurePolicy, 3)).Should(Equal(20 * time.Second))
+			Expect(getFailureBackoff(ops.Spec.FailurePolicy, 20)).Should(Equal(maxFailureBackoff))
+		})
+
 		It("Test opsRequest failed cases", func() {
 			By("init operations resources ")
 			opsRes, _, _ := initOperationsResources(compDefName, clusterName)
@@ -97,7 +190,7 @@ var _ = Describe("OpsUtil functions", func() {
 			By("Test the functions in ops_util.go")
 			ops := testapps.NewOpsRequestObj("restart-ops-"+randomStr, testCtx.DefaultNamespace,
 				clusterName, appsv1alpha1.RestartType)
-			ops.Spec.RestartList = []appsv1alpha1.ComponentOps{{ComponentName: defaultCompName}}
+			ops.Spec.RestartList = []appsv1alpha1.Restart{{ComponentOps: appsv1alpha1.ComponentOps{ComponentName: defaultCompName}}}
 			opsRes.OpsRequest = testapps.CreateOpsRequest(ctx, testCtx, ops)
 			opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsRunningPhase
 			opsRes.OpsRequest.Status.StartTimestamp = metav1.Now()
@@ -139,6 +232,59 @@ var _ = Describe("OpsUtil functions", func() {
 			Expect(opsPhase).Should(Equal(appsv1alpha1.OpsFailedPhase))
 		})
 
+		It("Test the failed components are retried through Reconcile", func() {
+			By("init operations resources ")
+			opsRes, _, _ := initOperationsResources(compDefName, clusterName)
+			testapps.MockInstanceSetComponent(&testCtx, clusterName, defaultCompName)
+			pods := testapps.MockInstanceSetPods(&testCtx, nil, opsRes.Cluster, defaultCompName)
+
+			By("create a restart opsRequest with retry policy")
+			ops := testapps.NewOpsRequestObj("restart-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.RestartType)
+			ops.Spec.RestartList = []appsv1alpha1.Restart{{ComponentOps: appsv1alpha1.ComponentOps{ComponentName: defaultCompName}}}
+			ops.Spec.RetryPolicy = &appsv1alpha1.OpsRetryPolicy{MaxRetries: 1, BackoffSeconds: 1, MaxBackoffSeconds: 1}
+			opsRes.OpsRequest = testapps.CreateOpsRequest(ctx, testCtx, ops)
+			Expect(testapps.ChangeObjStatus(&testCtx, opsRes.OpsRequest, func() {
+				opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsRunningPhase
+				opsRes.OpsRequest.Status.StartTimestamp = metav1.Now()
+			})).Should(Succeed())
+
+			By("mock one pod recreates failed")
+			clusterComp := opsRes.Cluster.Status.Components[defaultCompName]
+			clusterComp.Phase = appsv1alpha1.FailedClusterCompPhase
+			opsRes.Cluster.Status.SetComponentStatus(defaultCompName, clusterComp)
+			testk8s.MockPodIsTerminating(ctx, testCtx, pods[2])
+			testk8s.RemovePodFinalizer(ctx, testCtx, pods[2])
+			pod := testapps.MockInstanceSetPod(&testCtx, nil, clusterName, defaultCompName, pods[2].Name, "follower", "Readonly")
+			testk8s.MockPodIsFailed(ctx, testCtx, pod)
+
+			By("expect the failed component to be retried instead of failing the opsRequest")
+			reqCtx := intctrlutil.RequestCtx{Ctx: ctx, Recorder: k8sManager.GetEventRecorderFor("opsrequest-controller")}
+			Eventually(func(g Gomega) {
+				_, err := GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+				g.Expect(err).ShouldNot(HaveOccurred())
+				g.Expect(opsRes.OpsRequest.Status.Phase).Should(Equal(appsv1alpha1.OpsRunningPhase))
+				g.Expect(opsRes.OpsRequest.Status.Components[defaultCompName].Retries).Should(BeEquivalentTo(1))
+			}).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest),
+				func(g Gomega, fetched *appsv1alpha1.OpsRequest) {
+					g.Expect(fetched.Status.Components[defaultCompName].Retries).Should(BeEquivalentTo(1))
+				})).Should(Succeed())
+
+			By("expect the failed pod to be re-checked, and the opsRequest fails once the retries are exhausted")
+			Eventually(func(g Gomega) {
+				_, err := GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+				g.Expect(err).ShouldNot(HaveOccurred())
+				g.Expect(opsRes.OpsRequest.Status.Phase).Should(Equal(appsv1alpha1.OpsFailedPhase))
+			}).Should(Succeed())
+			Expect(opsRes.OpsRequest.Status.Components[defaultCompName].Retries).Should(BeEquivalentTo(1))
+
+			By("expect the failed pod is not deleted by the retries")
+			Consistently(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(pod), func(g Gomega, fetched *corev1.Pod) {
+				g.Expect(fetched.DeletionTimestamp).Should(BeNil())
+			})).Should(Succeed())
+		})
+
 		It("Test opsRequest with disable ha", func() {
 			By("init operations resources ")
 			opsRes, _, _ := initOperationsResources(compDefName, clusterName)
@@ -146,7 +292,7 @@ var _ = Describe("OpsUtil functions", func() {
 			By("Test the functions in ops_util.go")
 			ops := testapps.NewOpsRequestObj("restart-ops-"+randomStr, testCtx.DefaultNamespace,
 				clusterName, appsv1alpha1.RestartType)
-			ops.Spec.RestartList = []appsv1alpha1.ComponentOps{{ComponentName: defaultCompName}}
+			ops.Spec.RestartList = []appsv1alpha1.Restart{{ComponentOps: appsv1alpha1.ComponentOps{ComponentName: defaultCompName}}}
 			opsRes.OpsRequest = testapps.CreateOpsRequest(ctx, testCtx, ops)
 			Expect(testapps.ChangeObjStatus(&testCtx, opsRes.OpsRequest, func() {
 				opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsCreatingPhase
@@ -223,7 +369,7 @@ var _ = Describe("OpsUtil functions", func() {
 
 			By("test enqueueOpsRequestToClusterAnnotation function with Reentry")
 			opsBehaviour := opsManager.OpsMap[ops2.Spec.Type]
-			_, _ = enqueueOpsRequestToClusterAnnotation(ctx, k8sClient, opsRes, opsBehaviour)
+			_, _, _ = enqueueOpsRequestToClusterAnnotation(ctx, k8sClient, opsRes, opsBehaviour)
 			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(opsRes.Cluster), cluster)).Should(Succeed())
 			opsSlice, _ = opsutil.GetOpsRequestSliceFromCluster(cluster)
 			Expect(len(opsSlice)).Should(Equal(2))
@@ -301,6 +447,48 @@ var _ = Describe("OpsUtil functions", func() {
 			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(ops2))).Should(Equal(appsv1alpha1.OpsCancelledPhase))
 		})
 
+		It("Test opsRequest idempotency key", func() {
+			By("init operations resources ")
+			reqCtx := intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
+			opsRes, _, _ := initOperationsResources(compDefName, clusterName)
+
+			createRestartOps := func(name, key string) *appsv1alpha1.OpsRequest {
+				ops := testapps.NewOpsRequestObj(name, testCtx.DefaultNamespace, clusterName, appsv1alpha1.RestartType)
+				ops.Spec.RestartList = []appsv1alpha1.Restart{{ComponentOps: appsv1alpha1.ComponentOps{ComponentName: defaultCompName}}}
+				ops.Spec.IdempotencyKey = key
+				opsRequest := testapps.CreateOpsRequest(ctx, testCtx, ops)
+				opsRequest.Status.Phase = appsv1alpha1.OpsPendingPhase
+				return opsRequest
+			}
+			suffix := testCtx.GetRandomStr()
+			ops1 := createRestartOps("restart-ops-1-"+suffix, "restart-"+suffix)
+			ops2 := createRestartOps("restart-ops-2-"+suffix, "restart-"+suffix)
+			ops3 := createRestartOps("restart-ops-3-"+suffix, "another-"+suffix)
+
+			By("expect the earlier and the different keyed opsRequests to pass")
+			Expect(validateIdempotencyKey(reqCtx, k8sClient, ops1)).Should(Succeed())
+			Expect(validateIdempotencyKey(reqCtx, k8sClient, ops3)).Should(Succeed())
+
+			By("expect the duplicate opsRequest to fail")
+			opsRes.OpsRequest = ops2
+			_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(ops2), func(g Gomega, ops *appsv1alpha1.OpsRequest) {
+				g.Expect(ops.Status.Phase).Should(Equal(appsv1alpha1.OpsFailedPhase))
+				condition := meta.FindStatusCondition(ops.Status.Conditions, appsv1alpha1.ConditionTypeValidated)
+				g.Expect(condition).ShouldNot(BeNil())
+				g.Expect(condition.Reason).Should(Equal(appsv1alpha1.ReasonDuplicateOpsRequest))
+				g.Expect(condition.Message).Should(ContainSubstring(ops1.Name))
+			})).Should(Succeed())
+
+			By("expect the duplicates to be out of the TTL")
+			viper.Set(constant.CfgKeyOpsIdempotencyKeyTTL, time.Nanosecond)
+			defer viper.Set(constant.CfgKeyOpsIdempotencyKeyTTL, "24h")
+			ops4 := createRestartOps("restart-ops-4-"+suffix, "restart-"+suffix)
+			ops4.CreationTimestamp = metav1.NewTime(ops4.CreationTimestamp.Add(time.Second))
+			Expect(validateIdempotencyKey(reqCtx, k8sClient, ops4)).Should(Succeed())
+		})
+
 		It("Test EnqueueOnForce=true", func() {
 			By("init operations resources ")
 			opsRes, _, _ := initOperationsResources(compDefName, clusterName)
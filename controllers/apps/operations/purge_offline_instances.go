@@ -0,0 +1,188 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const purgeOfflineInstancesMessageKey = "purge offline instance"
+
+type purgeOfflineInstancesOpsHandler struct{}
+
+var _ OpsHandler = purgeOfflineInstancesOpsHandler{}
+
+func init() {
+	purgeBehaviour := OpsBehaviour{
+		FromClusterPhases: appsv1alpha1.GetClusterUpRunningPhases(),
+		OpsHandler:        purgeOfflineInstancesOpsHandler{},
+	}
+
+	opsMgr := GetOpsManager()
+	opsMgr.RegisterOps(appsv1alpha1.PurgeOfflineInstancesType, purgeBehaviour)
+}
+
+// ActionStartedCondition the started condition when handling the purge offline instances request.
+func (p purgeOfflineInstancesOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*metav1.Condition, error) {
+	return appsv1alpha1.NewPurgeOfflineInstancesCondition(opsRes.OpsRequest), nil
+}
+
+// Action deletes the retained PVCs of the offline instances that satisfy the purge policy,
+// and records the deleted PVCs in the progress details of the components.
+func (p purgeOfflineInstancesOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	opsRequest := opsRes.OpsRequest
+	if opsRequest.Status.Components == nil {
+		opsRequest.Status.Components = map[string]appsv1alpha1.OpsRequestComponentStatus{}
+	}
+	for _, purge := range opsRequest.Spec.PurgeOfflineInstancesList {
+		pvcNames, err := p.pvcsToPurge(reqCtx, cli, opsRes, purge)
+		if err != nil {
+			return err
+		}
+		compStatus := opsRequest.Status.Components[purge.ComponentName]
+		for _, pvcName := range pvcNames {
+			pvc := &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: opsRes.Cluster.Namespace,
+					Name:      pvcName,
+				},
+			}
+			if err = intctrlutil.BackgroundDeleteObject(cli, reqCtx.Ctx, pvc); err != nil {
+				return err
+			}
+			objectKey := getProgressObjectKey(constant.PersistentVolumeClaimKind, pvcName)
+			progressDetail := appsv1alpha1.ProgressStatusDetail{ObjectKey: objectKey}
+			progressDetail.SetStatusAndMessage(appsv1alpha1.ProcessingProgressStatus,
+				getProgressProcessingMessage(purgeOfflineInstancesMessageKey, objectKey, purge.ComponentName))
+			setComponentStatusProgressDetail(opsRes.Recorder, opsRequest, &compStatus.ProgressDetails, progressDetail)
+		}
+		opsRequest.Status.Components[purge.ComponentName] = compStatus
+	}
+	return nil
+}
+
+// ReconcileAction waits for the PVCs recorded in the progress details to be deleted.
+func (p purgeOfflineInstancesOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	opsRequest := opsRes.OpsRequest
+	oldOpsRequest := opsRequest.DeepCopy()
+	var expectCount, completedCount int
+	for compName, compStatus := range opsRequest.Status.Components {
+		for i := range compStatus.ProgressDetails {
+			progressDetail := &compStatus.ProgressDetails[i]
+			expectCount++
+			if progressDetail.Status == appsv1alpha1.SucceedProgressStatus {
+				completedCount++
+				continue
+			}
+			pvcName := progressDetail.ObjectKey[len(constant.PersistentVolumeClaimKind)+1:]
+			pvc := &corev1.PersistentVolumeClaim{}
+			err := cli.Get(reqCtx.Ctx, types.NamespacedName{Namespace: opsRes.Cluster.Namespace, Name: pvcName}, pvc)
+			if err == nil {
+				continue
+			}
+			if !apierrors.IsNotFound(err) {
+				return "", 0, err
+			}
+			completedCount++
+			progressDetail.SetStatusAndMessage(appsv1alpha1.SucceedProgressStatus,
+				getProgressSucceedMessage(purgeOfflineInstancesMessageKey, progressDetail.ObjectKey, compName))
+			updateProgressDetailTime(progressDetail)
+		}
+		opsRequest.Status.Components[compName] = compStatus
+	}
+	flushAfter, err := syncProgressToOpsRequest(reqCtx, cli, opsRes, oldOpsRequest, completedCount, expectCount)
+	if err != nil {
+		return "", 0, err
+	}
+	if completedCount < expectCount {
+		return appsv1alpha1.OpsRunningPhase, requeueForStatusFlush(5*time.Second, flushAfter), nil
+	}
+	return appsv1alpha1.OpsSucceedPhase, 0, nil
+}
+
+// SaveLastConfiguration records last configuration to the OpsRequest.status.lastConfiguration
+func (p purgeOfflineInstancesOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return nil
+}
+
+// pvcsToPurge returns the retained PVCs of the offline instances that have been offline longer than the threshold.
+// The instances that are no longer offline, or whose pods still exist, are skipped.
+func (p purgeOfflineInstancesOpsHandler) pvcsToPurge(reqCtx intctrlutil.RequestCtx, cli client.Client,
+	opsRes *OpsResource, purge appsv1alpha1.PurgeOfflineInstances) ([]string, error) {
+	compSpec := opsRes.Cluster.Spec.GetComponentByName(purge.ComponentName)
+	if compSpec == nil {
+		return nil, nil
+	}
+	comp := &appsv1alpha1.Component{}
+	compKey := types.NamespacedName{
+		Namespace: opsRes.Cluster.Namespace,
+		Name:      constant.GenerateClusterComponentName(opsRes.Cluster.Name, purge.ComponentName),
+	}
+	if err := cli.Get(reqCtx.Ctx, compKey, comp); err != nil {
+		return nil, err
+	}
+	var pvcNames []string
+	for _, ins := range eligibleOfflineInstances(comp.Status.OfflineInstances, compSpec.OfflineInstances, purge, time.Now()) {
+		pod := &corev1.Pod{}
+		err := cli.Get(reqCtx.Ctx, types.NamespacedName{Namespace: comp.Namespace, Name: ins.Name}, pod)
+		if err == nil {
+			reqCtx.Log.Info(fmt.Sprintf("skip purging the offline instance %s as its pod still exists", ins.Name))
+			continue
+		}
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		for _, pvc := range ins.RetainedPVCs {
+			pvcNames = append(pvcNames, pvc.Name)
+		}
+	}
+	return pvcNames, nil
+}
+
+// eligibleOfflineInstances filters the offline instances reported by the component status with the purge policy.
+func eligibleOfflineInstances(statuses []appsv1alpha1.OfflineInstanceStatus, offlineInstances []string,
+	purge appsv1alpha1.PurgeOfflineInstances, now time.Time) []appsv1alpha1.OfflineInstanceStatus {
+	var instances []appsv1alpha1.OfflineInstanceStatus
+	for _, ins := range statuses {
+		if !slices.Contains(offlineInstances, ins.Name) {
+			continue
+		}
+		if len(purge.InstanceNames) > 0 && !slices.Contains(purge.InstanceNames, ins.Name) {
+			continue
+		}
+		if now.Sub(ins.OfflineSince.Time) < purge.OfflineLongerThan.Duration {
+			continue
+		}
+		instances = append(instances, ins)
+	}
+	return instances
+}
@@ -0,0 +1,69 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+var _ = Describe("PurgeOfflineInstances OpsRequest", func() {
+	Context("eligible offline instances", func() {
+		now := time.Now()
+		statuses := []appsv1alpha1.OfflineInstanceStatus{
+			{Name: "test-mysql-0", OfflineSince: metav1.NewTime(now.Add(-48 * time.Hour))},
+			{Name: "test-mysql-1", OfflineSince: metav1.NewTime(now.Add(-time.Hour))},
+			{Name: "test-mysql-2", OfflineSince: metav1.NewTime(now.Add(-72 * time.Hour))},
+		}
+		names := func(instances []appsv1alpha1.OfflineInstanceStatus) []string {
+			var result []string
+			for _, ins := range instances {
+				result = append(result, ins.Name)
+			}
+			return result
+		}
+
+		It("filters the instances by the offline duration", func() {
+			purge := appsv1alpha1.PurgeOfflineInstances{
+				ComponentOps:      appsv1alpha1.ComponentOps{ComponentName: defaultCompName},
+				OfflineLongerThan: metav1.Duration{Duration: 24 * time.Hour},
+			}
+			offlineInstances := []string{"test-mysql-0", "test-mysql-1", "test-mysql-2"}
+			Expect(names(eligibleOfflineInstances(statuses, offlineInstances, purge, now))).
+				Should(Equal([]string{"test-mysql-0", "test-mysql-2"}))
+		})
+
+		It("skips the instances that are online again or not specified", func() {
+			purge := appsv1alpha1.PurgeOfflineInstances{
+				ComponentOps:      appsv1alpha1.ComponentOps{ComponentName: defaultCompName},
+				OfflineLongerThan: metav1.Duration{Duration: 24 * time.Hour},
+				InstanceNames:     []string{"test-mysql-0", "test-mysql-1"},
+			}
+			offlineInstances := []string{"test-mysql-1", "test-mysql-2"}
+			Expect(eligibleOfflineInstances(statuses, offlineInstances, purge, now)).Should(BeEmpty())
+		})
+	})
+})
@@ -67,14 +67,16 @@ func DequeueOpsRequestInClusterAnnotation(ctx context.Context, cli client.Client
 				return err
 			}
 			patch := client.MergeFrom(ops.DeepCopy())
-			ops.Status.Phase = appsv1alpha1.OpsCancelledPhase
-			ops.Status.CompletionTimestamp = metav1.Time{Time: time.Now()}
-			ops.SetStatusCondition(metav1.Condition{
+			cancelledCondition := metav1.Condition{
 				Type:    appsv1alpha1.ConditionTypeCancelled,
 				Reason:  appsv1alpha1.ReasonOpsCancelByController,
 				Status:  metav1.ConditionTrue,
 				Message: fmt.Sprintf(`Cancelled by controller due to the failure of previous OpsRequest "%s"`, opsRes.OpsRequest.Name),
-			})
+			}
+			appendPhaseTimelineEntry(ops, ops.Status.Phase, appsv1alpha1.OpsCancelledPhase, &cancelledCondition)
+			ops.Status.Phase = appsv1alpha1.OpsCancelledPhase
+			ops.Status.CompletionTimestamp = metav1.Time{Time: time.Now()}
+			ops.SetStatusCondition(cancelledCondition)
 			if err = cli.Status().Patch(ctx, ops, patch); err != nil && apierrors.IsNotFound(err) {
 				return err
 			}
@@ -89,27 +91,31 @@ func DequeueOpsRequestInClusterAnnotation(ctx context.Context, cli client.Client
 }
 
 // enqueueOpsRequestToClusterAnnotation adds the OpsRequest Annotation to Cluster.metadata.Annotations to acquire the lock.
-func enqueueOpsRequestToClusterAnnotation(ctx context.Context, cli client.Client, opsRes *OpsResource, opsBehaviour OpsBehaviour) (*appsv1alpha1.OpsRecorder, error) {
+// If the OpsRequest is queued, the OpsRequest it conflicts with is returned too.
+func enqueueOpsRequestToClusterAnnotation(ctx context.Context, cli client.Client, opsRes *OpsResource,
+	opsBehaviour OpsBehaviour) (*appsv1alpha1.OpsRecorder, *appsv1alpha1.OpsRecorder, error) {
 	var (
 		opsRequestSlice []appsv1alpha1.OpsRecorder
+		conflictWith    *appsv1alpha1.OpsRecorder
 		err             error
 	)
 	if !opsBehaviour.QueueByCluster && !opsBehaviour.QueueBySelf {
-		return nil, nil
+		return nil, nil, nil
 	}
 	// if the running opsRequest is deleted, do not enqueue the opsRequest to cluster annotation.
 	if !opsRes.OpsRequest.DeletionTimestamp.IsZero() {
-		return nil, nil
+		return nil, nil, nil
 	}
 	if opsRequestSlice, err = opsutil.GetOpsRequestSliceFromCluster(opsRes.Cluster); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	inQueue := func() bool {
 		if opsRes.OpsRequest.Force() && !opsRes.OpsRequest.Spec.EnqueueOnForce {
 			return false
 		}
-		return existOtherRunningOps(opsRequestSlice, opsRes.OpsRequest.Spec.Type, opsBehaviour)
+		conflictWith = findConflictingOps(opsRequestSlice, opsRes.OpsRequest.Name, opsRes.OpsRequest.Spec.Type, opsBehaviour)
+		return conflictWith != nil
 	}
 
 	index, opsRecorder := GetOpsRecorderFromSlice(opsRequestSlice, opsRes.OpsRequest.Name)
@@ -117,7 +123,7 @@ func enqueueOpsRequestToClusterAnnotation(ctx context.Context, cli client.Client
 	case -1:
 		// if not exists but reach the queue limit size, throw an error
 		if len(opsRequestSlice) >= opsRequestQueueLimitSize {
-			return nil, intctrlutil.NewFatalError(fmt.Sprintf("The opsRequest queue is limited to a size of %d", opsRequestQueueLimitSize))
+			return nil, nil, intctrlutil.NewFatalError(fmt.Sprintf("The opsRequest queue is limited to a size of %d", opsRequestQueueLimitSize))
 		}
 		// if not exists, enqueue
 		if opsRequestSlice == nil {
@@ -134,30 +140,59 @@ func enqueueOpsRequestToClusterAnnotation(ctx context.Context, cli client.Client
 	default:
 		if !opsRecorder.InQueue {
 			// the opsRequest is already running.
-			return &opsRecorder, nil
+			return &opsRecorder, nil, nil
 		}
-		if !opsRes.OpsRequest.Spec.Force && existOtherRunningOps(opsRequestSlice, opsRecorder.Type, opsBehaviour) {
-			// if exists other running opsRequest, return.
-			return &opsRecorder, nil
+		if !opsRes.OpsRequest.Spec.Force {
+			if conflictWith = findConflictingOps(opsRequestSlice, opsRecorder.Name, opsRecorder.Type, opsBehaviour); conflictWith != nil {
+				// if exists other conflicting opsRequest, return.
+				return &opsRecorder, conflictWith, nil
+			}
 		}
 		// mark to handle the next opsRequest
 		opsRequestSlice[index].InQueue = false
 	}
-	return &opsRecorder, opsutil.UpdateClusterOpsAnnotations(ctx, cli, opsRes.Cluster, opsRequestSlice)
+	return &opsRecorder, conflictWith, opsutil.UpdateClusterOpsAnnotations(ctx, cli, opsRes.Cluster, opsRequestSlice)
 }
 
-// existOtherRunningOps checks if exists other running opsRequest.
-func existOtherRunningOps(opsRecorderSlice []appsv1alpha1.OpsRecorder, opsType appsv1alpha1.OpsType, opsBehaviour OpsBehaviour) bool {
+// findConflictingOps finds the opsRequest that the opsRequest has to wait for, it returns nil if the opsRequest can run.
+// The running opsRequests and the queued ones ahead of the opsRequest are checked against the ConflictPolicies,
+// and the opsRequests of the types without policy are checked against the queue scope of the opsRequest.
+func findConflictingOps(opsRecorderSlice []appsv1alpha1.OpsRecorder, opsName string,
+	opsType appsv1alpha1.OpsType, opsBehaviour OpsBehaviour) *appsv1alpha1.OpsRecorder {
+	ahead := true
 	for i := range opsRecorderSlice {
-		if opsBehaviour.QueueByCluster && opsRecorderSlice[i].QueueBySelf {
+		recorder := opsRecorderSlice[i]
+		if recorder.Name == opsName {
+			ahead = false
+			continue
+		}
+		if recorder.InQueue && !ahead {
 			continue
 		}
-		if opsBehaviour.QueueBySelf && opsRecorderSlice[i].Type != opsType {
+		if policy, ok := opsBehaviour.ConflictPolicies[recorder.Type]; ok {
+			if policy == ConflictPolicyParallel {
+				continue
+			}
+			return &recorder
+		}
+		if opsBehaviour.QueueByCluster && recorder.QueueBySelf {
+			continue
+		}
+		if opsBehaviour.QueueBySelf && recorder.Type != opsType {
 			continue
 		}
-		if !opsRecorderSlice[i].InQueue {
-			return true
+		return &recorder
+	}
+	return nil
+}
+
+// getAbortOpsTypes returns the types of the opsRequests which should be aborted by the opsRequest of the type.
+func getAbortOpsTypes(opsType appsv1alpha1.OpsType) []appsv1alpha1.OpsType {
+	var opsTypes []appsv1alpha1.OpsType
+	for t, policy := range GetOpsManager().OpsMap[opsType].ConflictPolicies {
+		if policy == ConflictPolicyAbort {
+			opsTypes = append(opsTypes, t)
 		}
 	}
-	return false
+	return opsTypes
 }
@@ -0,0 +1,74 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+var _ = Describe("ops conflict policies", func() {
+	behaviourOf := func(opsType appsv1alpha1.OpsType) OpsBehaviour {
+		return GetOpsManager().OpsMap[opsType]
+	}
+
+	It("queues the opsRequest behind the conflicting ones", func() {
+		opsSlice := []appsv1alpha1.OpsRecorder{
+			{Name: "hscale", Type: appsv1alpha1.HorizontalScalingType},
+		}
+		conflictWith := findConflictingOps(opsSlice, "restart", appsv1alpha1.RestartType, behaviourOf(appsv1alpha1.RestartType))
+		Expect(conflictWith).ShouldNot(BeNil())
+		Expect(conflictWith.Name).Should(Equal("hscale"))
+
+		By("the queued opsRequests behind are ignored")
+		opsSlice = []appsv1alpha1.OpsRecorder{
+			{Name: "restart", Type: appsv1alpha1.RestartType, InQueue: true},
+			{Name: "hscale", Type: appsv1alpha1.HorizontalScalingType, InQueue: true},
+		}
+		Expect(findConflictingOps(opsSlice, "restart", appsv1alpha1.RestartType, behaviourOf(appsv1alpha1.RestartType))).Should(BeNil())
+		conflictWith = findConflictingOps(opsSlice, "hscale", appsv1alpha1.HorizontalScalingType, behaviourOf(appsv1alpha1.HorizontalScalingType))
+		Expect(conflictWith).ShouldNot(BeNil())
+		Expect(conflictWith.Name).Should(Equal("restart"))
+	})
+
+	It("runs the opsRequests in parallel", func() {
+		opsSlice := []appsv1alpha1.OpsRecorder{
+			{Name: "reconfigure", Type: appsv1alpha1.ReconfiguringType},
+			{Name: "expand", Type: appsv1alpha1.VolumeExpansionType, QueueBySelf: true},
+		}
+		Expect(findConflictingOps(opsSlice, "expand", appsv1alpha1.VolumeExpansionType, behaviourOf(appsv1alpha1.VolumeExpansionType))).Should(BeNil())
+		Expect(findConflictingOps(opsSlice, "reconfigure", appsv1alpha1.ReconfiguringType, behaviourOf(appsv1alpha1.ReconfiguringType))).Should(BeNil())
+
+		By("the volume expansions still queue by themselves")
+		opsSlice = append(opsSlice, appsv1alpha1.OpsRecorder{Name: "expand2", Type: appsv1alpha1.VolumeExpansionType, QueueBySelf: true, InQueue: true})
+		conflictWith := findConflictingOps(opsSlice, "expand2", appsv1alpha1.VolumeExpansionType, behaviourOf(appsv1alpha1.VolumeExpansionType))
+		Expect(conflictWith).ShouldNot(BeNil())
+		Expect(conflictWith.Name).Should(Equal("expand"))
+	})
+
+	It("aborts the conflicting opsRequests", func() {
+		Expect(getAbortOpsTypes(appsv1alpha1.StopType)).Should(ConsistOf(appsv1alpha1.HorizontalScalingType,
+			appsv1alpha1.StartType, appsv1alpha1.RestartType, appsv1alpha1.VerticalScalingType))
+		Expect(getAbortOpsTypes(appsv1alpha1.RestartType)).Should(ConsistOf(appsv1alpha1.RestartType))
+		Expect(getAbortOpsTypes(appsv1alpha1.ExposeType)).Should(BeEmpty())
+	})
+})
@@ -0,0 +1,250 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlcomp "github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	kbagent "github.com/apecloud/kubeblocks/pkg/kb_agent/client"
+)
+
+const (
+	rebalanceMessageKey = "rebalance shard"
+
+	rebalancePhaseRunning   = "Running"
+	rebalancePhaseCompleted = "Completed"
+	rebalancePhaseFailed    = "Failed"
+)
+
+// rebalanceProgress is the output of the rebalance action, which reports the migration progress of a shard.
+type rebalanceProgress struct {
+	Phase   string `json:"phase"`
+	Message string `json:"message,omitempty"`
+}
+
+// rebalanceOpsHandler rebalances the data among the shards of the shardings. The rebalance action of the
+// Component is invoked on one ready instance of each shard repeatedly, until all the shards report that
+// the migration is completed.
+type rebalanceOpsHandler struct{}
+
+var _ OpsHandler = rebalanceOpsHandler{}
+
+func init() {
+	rebalanceBehaviour := OpsBehaviour{
+		FromClusterPhases: appsv1alpha1.GetClusterUpRunningPhases(),
+		QueueByCluster:    true,
+		OpsHandler:        rebalanceOpsHandler{},
+	}
+
+	opsMgr := GetOpsManager()
+	opsMgr.RegisterOps(appsv1alpha1.RebalanceType, rebalanceBehaviour)
+}
+
+// ActionStartedCondition the started condition when handling the rebalance request.
+func (r rebalanceOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*metav1.Condition, error) {
+	return appsv1alpha1.NewRebalanceCondition(opsRes.OpsRequest), nil
+}
+
+// Action checks that the shards define the rebalance action, and records a pending progress detail for each shard.
+func (r rebalanceOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	opsRequest := opsRes.OpsRequest
+	if opsRequest.Status.Components == nil {
+		opsRequest.Status.Components = map[string]appsv1alpha1.OpsRequestComponentStatus{}
+	}
+	for _, rebalance := range opsRequest.Spec.RebalanceList {
+		if opsRes.Cluster.Spec.GetShardingByName(rebalance.ComponentName) == nil {
+			return intctrlutil.NewFatalError(fmt.Sprintf(`sharding "%s" not found in cluster.spec.shardingSpecs`, rebalance.ComponentName))
+		}
+		shardComps, err := intctrlutil.ListShardingComponents(reqCtx.Ctx, cli, opsRes.Cluster, rebalance.ComponentName)
+		if err != nil {
+			return err
+		}
+		compStatus := opsRequest.Status.Components[rebalance.ComponentName]
+		for _, comp := range shardComps {
+			if err = r.checkRebalanceAction(reqCtx, cli, &comp); err != nil {
+				return err
+			}
+			fullCompName := comp.Labels[constant.KBAppComponentLabelKey]
+			progressDetail := appsv1alpha1.ProgressStatusDetail{
+				ObjectKey: getProgressObjectKey(appsv1alpha1.ComponentKind, fullCompName),
+				Status:    appsv1alpha1.PendingProgressStatus,
+			}
+			setComponentStatusProgressDetail(opsRes.Recorder, opsRequest, &compStatus.ProgressDetails, progressDetail)
+		}
+		opsRequest.Status.Components[rebalance.ComponentName] = compStatus
+	}
+	return nil
+}
+
+// ReconcileAction invokes the rebalance action on the shards which have not completed the migration,
+// and updates the progress details with the progress reported by the action.
+func (r rebalanceOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	var (
+		opsRequest     = opsRes.OpsRequest
+		oldOpsRequest  = opsRequest.DeepCopy()
+		expectCount    int
+		completedCount int
+		existFailure   bool
+	)
+	for _, rebalance := range opsRequest.Spec.RebalanceList {
+		compStatus := opsRequest.Status.Components[rebalance.ComponentName]
+		for i := range compStatus.ProgressDetails {
+			progressDetail := compStatus.ProgressDetails[i]
+			expectCount++
+			if !isCompletedProgressStatus(progressDetail.Status) {
+				fullCompName := strings.TrimPrefix(progressDetail.ObjectKey, appsv1alpha1.ComponentKind+"/")
+				progress, err := r.invokeRebalanceAction(reqCtx, cli, opsRes, fullCompName, rebalance)
+				if err != nil {
+					return "", 0, err
+				}
+				if progress == nil {
+					continue
+				}
+				switch progress.Phase {
+				case rebalancePhaseCompleted:
+					progressDetail.SetStatusAndMessage(appsv1alpha1.SucceedProgressStatus,
+						getProgressSucceedMessage(rebalanceMessageKey, progressDetail.ObjectKey, rebalance.ComponentName))
+				case rebalancePhaseFailed:
+					progressDetail.SetStatusAndMessage(appsv1alpha1.FailedProgressStatus,
+						getProgressFailedMessage(rebalanceMessageKey, progressDetail.ObjectKey, rebalance.ComponentName, progress.Message))
+				default:
+					message := getProgressProcessingMessage(rebalanceMessageKey, progressDetail.ObjectKey, rebalance.ComponentName)
+					if progress.Message != "" {
+						message = fmt.Sprintf("%s, progress: %s", message, progress.Message)
+					}
+					progressDetail.SetStatusAndMessage(appsv1alpha1.ProcessingProgressStatus, message)
+				}
+				setComponentStatusProgressDetail(opsRes.Recorder, opsRequest, &compStatus.ProgressDetails, progressDetail)
+			}
+			switch compStatus.ProgressDetails[i].Status {
+			case appsv1alpha1.SucceedProgressStatus:
+				completedCount++
+			case appsv1alpha1.FailedProgressStatus:
+				completedCount++
+				existFailure = true
+			}
+		}
+		opsRequest.Status.Components[rebalance.ComponentName] = compStatus
+	}
+	flushAfter, err := syncProgressToOpsRequest(reqCtx, cli, opsRes, oldOpsRequest, completedCount, expectCount)
+	if err != nil {
+		return "", 0, err
+	}
+	if completedCount < expectCount {
+		return appsv1alpha1.OpsRunningPhase, requeueForStatusFlush(5*time.Second, flushAfter), nil
+	}
+	if existFailure {
+		return appsv1alpha1.OpsFailedPhase, 0, nil
+	}
+	return appsv1alpha1.OpsSucceedPhase, 0, nil
+}
+
+// SaveLastConfiguration records last configuration to the OpsRequest.status.lastConfiguration
+func (r rebalanceOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return nil
+}
+
+// checkRebalanceAction checks that the ComponentDefinition of the shard defines the rebalance action.
+func (r rebalanceOpsHandler) checkRebalanceAction(reqCtx intctrlutil.RequestCtx, cli client.Client, comp *appsv1alpha1.Component) error {
+	compDef := &appsv1alpha1.ComponentDefinition{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: comp.Spec.CompDef}, compDef); err != nil {
+		return err
+	}
+	if compDef.Spec.LifecycleActions == nil || compDef.Spec.LifecycleActions.Rebalance == nil {
+		return intctrlutil.NewFatalError(fmt.Sprintf(`the rebalance action is not defined in the componentDefinition "%s"`, compDef.Name))
+	}
+	return nil
+}
+
+// invokeRebalanceAction invokes the rebalance action on a ready instance of the shard, and returns the progress
+// reported by the action. It returns nil if there is no ready instance or the kb-agent is unreachable for now,
+// so that the action is invoked again in the next reconciliation.
+func (r rebalanceOpsHandler) invokeRebalanceAction(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	fullCompName string,
+	rebalance appsv1alpha1.Rebalance) (*rebalanceProgress, error) {
+	pods, err := intctrlcomp.ListOwnedPods(reqCtx.Ctx, cli, opsRes.Cluster.Namespace, opsRes.Cluster.Name, fullCompName)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Name < pods[j].Name
+	})
+	for _, pod := range pods {
+		if !intctrlutil.PodIsReady(pod) {
+			continue
+		}
+		agentCli, err := kbagent.NewClient(*pod)
+		if err != nil {
+			return nil, err
+		}
+		if intctrlutil.IsNil(agentCli) {
+			return &rebalanceProgress{
+				Phase:   rebalancePhaseFailed,
+				Message: fmt.Sprintf(`the instance "%s" doesn't run the kb-agent to invoke the rebalance action`, pod.Name),
+			}, nil
+		}
+		parameters := map[string]any{}
+		for k, v := range rebalance.Parameters {
+			parameters[k] = v
+		}
+		output, err := agentCli.Action(reqCtx.Ctx, constant.RebalanceAction, parameters)
+		if err != nil {
+			if errors.Is(err, kbagent.ErrActionFailed) {
+				return &rebalanceProgress{Phase: rebalancePhaseFailed, Message: err.Error()}, nil
+			}
+			reqCtx.Log.Info("failed to invoke the rebalance action, will retry", "pod", pod.Name, "error", err.Error())
+			return nil, nil
+		}
+		return parseRebalanceProgress(output), nil
+	}
+	return nil, nil
+}
+
+// parseRebalanceProgress parses the output of the rebalance action, an empty output means the migration is completed.
+func parseRebalanceProgress(output string) *rebalanceProgress {
+	output = strings.TrimSpace(output)
+	if output == "" {
+		return &rebalanceProgress{Phase: rebalancePhaseCompleted}
+	}
+	progress := &rebalanceProgress{}
+	if err := json.Unmarshal([]byte(output), progress); err != nil {
+		return &rebalanceProgress{Phase: rebalancePhaseFailed, Message: fmt.Sprintf("invalid output of the rebalance action: %s", output)}
+	}
+	switch progress.Phase {
+	case rebalancePhaseRunning, rebalancePhaseCompleted, rebalancePhaseFailed:
+	default:
+		return &rebalanceProgress{Phase: rebalancePhaseFailed, Message: fmt.Sprintf("unknown phase of the rebalance action: %s", progress.Phase)}
+	}
+	return progress
+}
@@ -0,0 +1,104 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	kbagent "github.com/apecloud/kubeblocks/pkg/kb_agent/client"
+	kbagentutil "github.com/apecloud/kubeblocks/pkg/kb_agent/util"
+)
+
+type rebalanceAgentClient struct {
+	output string
+}
+
+func (c *rebalanceAgentClient) Action(_ context.Context, action string, _ map[string]any) (string, error) {
+	return c.output, nil
+}
+
+func (c *rebalanceAgentClient) Refresh(_ context.Context, _ map[string]kbagentutil.HandlerSpec) error {
+	return nil
+}
+
+var _ = Describe("rebalance ops handler", func() {
+	const (
+		namespace    = "default"
+		clusterName  = "mycluster"
+		fullCompName = "shard-abc"
+	)
+
+	AfterEach(func() {
+		kbagent.UnsetMockClient()
+	})
+
+	It("parses the output of the rebalance action", func() {
+		Expect(parseRebalanceProgress("").Phase).Should(Equal(rebalancePhaseCompleted))
+		Expect(*parseRebalanceProgress(`{"phase": "Running", "message": "3/10"}`)).Should(Equal(rebalanceProgress{Phase: rebalancePhaseRunning, Message: "3/10"}))
+		Expect(parseRebalanceProgress(`{"phase": "Completed"}`).Phase).Should(Equal(rebalancePhaseCompleted))
+		Expect(parseRebalanceProgress("migrated").Phase).Should(Equal(rebalancePhaseFailed))
+		Expect(parseRebalanceProgress(`{"phase": "Unknown"}`).Phase).Should(Equal(rebalancePhaseFailed))
+	})
+
+	It("invokes the rebalance action on a ready instance of the shard", func() {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      clusterName + "-" + fullCompName + "-0",
+				Labels:    constant.GetComponentWellKnownLabels(clusterName, fullCompName),
+			},
+		}
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+		cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pod).Build()
+		reqCtx := intctrlutil.RequestCtx{Ctx: context.Background(), Log: logr.Discard()}
+		opsRes := &OpsResource{
+			Cluster: &appsv1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: clusterName}},
+		}
+		agentCli := &rebalanceAgentClient{output: `{"phase": "Running", "message": "3/10"}`}
+		kbagent.SetMockClient(agentCli, nil)
+
+		By("expect no progress if the instance is not ready")
+		progress, err := rebalanceOpsHandler{}.invokeRebalanceAction(reqCtx, cli, opsRes, fullCompName, appsv1alpha1.Rebalance{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(progress).Should(BeNil())
+
+		By("expect the progress reported by the action once the instance is ready")
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+		Expect(cli.Status().Update(context.Background(), pod)).Should(Succeed())
+		progress, err = rebalanceOpsHandler{}.invokeRebalanceAction(reqCtx, cli, opsRes, fullCompName, appsv1alpha1.Rebalance{})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(progress.Phase).Should(Equal(rebalancePhaseRunning))
+		Expect(progress.Message).Should(Equal("3/10"))
+	})
+})
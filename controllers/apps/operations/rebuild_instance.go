@@ -195,7 +195,7 @@ func (r rebuildInstanceOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx
 		} else {
 			// rebuild instances with horizontal scaling
 			if subCompletedCount, subFailedCount, err = r.rebuildInstancesWithHScaling(reqCtx, cli, opsRes, v, &compStatus); err != nil {
-				if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+				if intctrlutil.IsTerminalError(err) {
 					return appsv1alpha1.OpsFailedPhase, 0, err
 				}
 				return opsRequestPhase, 0, err
@@ -211,12 +211,13 @@ func (r rebuildInstanceOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx
 			return opsRequestPhase, 0, err
 		}
 	}
-	if err = syncProgressToOpsRequest(reqCtx, cli, opsRes, oldOpsRequest, completedCount, expectCount); err != nil {
+	flushAfter, err := syncProgressToOpsRequest(reqCtx, cli, opsRes, oldOpsRequest, completedCount, expectCount)
+	if err != nil {
 		return opsRequestPhase, 0, err
 	}
 	// check if the ops has been finished.
 	if completedCount != expectCount {
-		return opsRequestPhase, 0, nil
+		return opsRequestPhase, flushAfter, nil
 	}
 	if failedCount == 0 {
 		return appsv1alpha1.OpsSucceedPhase, 0, r.cleanupTmpResources(reqCtx, cli, opsRes)
@@ -245,7 +246,7 @@ func (r rebuildInstanceOpsHandler) rebuildInstancesInPlace(reqCtx intctrlutil.Re
 		}
 		// rebuild instance
 		completed, err := r.rebuildInstanceInPlace(reqCtx, cli, opsRes, &progressDetail, rebuildInstance, instance, i)
-		if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+		if intctrlutil.IsTerminalError(err) {
 			// If a fatal error occurs, this instance rebuilds failed.
 			progressDetail.SetStatusAndMessage(appsv1alpha1.FailedProgressStatus, err.Error())
 			setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, progressDetail)
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -46,7 +47,10 @@ func init() {
 		// TODO: add cluster reconcile Reconfiguring phase.
 		ToClusterPhase: appsv1alpha1.UpdatingClusterPhase,
 		QueueByCluster: true,
-		OpsHandler:     &reAction,
+		ConflictPolicies: map[appsv1alpha1.OpsType]OpsConflictPolicy{
+			appsv1alpha1.VolumeExpansionType: ConflictPolicyParallel,
+		},
+		OpsHandler: &reAction,
 	}
 	opsManager.RegisterOps(appsv1alpha1.ReconfiguringType, reconfigureBehaviour)
 }
@@ -56,7 +60,89 @@ func (r *reconfigureAction) ActionStartedCondition(reqCtx intctrlutil.RequestCtx
 	return appsv1alpha1.NewReconfigureCondition(opsRes.OpsRequest), nil
 }
 
+// SaveLastConfiguration records the parameters of the configurations prior to the reconfiguring,
+// which are used to roll back the OpsRequest.
 func (r *reconfigureAction) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	var reconfigures []appsv1alpha1.Reconfigure
+	if opsRes.OpsRequest.Spec.Reconfigure != nil {
+		reconfigures = append(reconfigures, *opsRes.OpsRequest.Spec.Reconfigure)
+	}
+	reconfigures = append(reconfigures, opsRes.OpsRequest.Spec.Reconfigures...)
+
+	lastConfiguration := &opsRes.OpsRequest.Status.LastConfiguration
+	lastConfiguration.Components = map[string]appsv1alpha1.LastComponentConfiguration{}
+	for _, reconfigure := range reconfigures {
+		if len(reconfigure.Configurations) == 0 {
+			continue
+		}
+		config := &appsv1alpha1.Configuration{}
+		configKey := client.ObjectKey{
+			Namespace: opsRes.Cluster.Namespace,
+			Name:      core.GenerateComponentConfigurationName(opsRes.Cluster.Name, reconfigure.ComponentName),
+		}
+		if err := cli.Get(reqCtx.Ctx, configKey, config); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		item := config.Spec.GetConfigurationItem(reconfigure.Configurations[0].Name)
+		// the parameters of the secret-backed configuration may be credentials, which are not recorded in the ops.
+		if item == nil || (item.ConfigSpec != nil && configctrl.IsSecretBackedConfig(*item.ConfigSpec)) {
+			continue
+		}
+		lastCompConfiguration := lastConfiguration.Components[reconfigure.ComponentName]
+		lastCompConfiguration.Configurations = append(lastCompConfiguration.Configurations, appsv1alpha1.LastConfigurationItem{
+			Name:             item.Name,
+			ConfigFileParams: item.ConfigFileParams,
+			RoleOverlays:     item.RoleOverlays,
+		})
+		lastConfiguration.Components[reconfigure.ComponentName] = lastCompConfiguration
+	}
+	return nil
+}
+
+// Plan computes the parameters to be patched, the current values are referred to the parameters recorded
+// in the status.lastConfiguration.
+func (r *reconfigureAction) Plan(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*appsv1alpha1.OpsPlan, error) {
+	plan := &appsv1alpha1.OpsPlan{Components: map[string]appsv1alpha1.OpsComponentPlan{}}
+	for _, params := range fromReconfigureOperations(opsRes.OpsRequest.Spec, reqCtx, cli, opsRes) {
+		lastCompConfiguration := opsRes.OpsRequest.Status.LastConfiguration.Components[params.componentName]
+		currentParams := lastConfigFileParams(lastCompConfiguration, params.configurationItem)
+		compPlan := plan.Components[params.componentName]
+		for _, key := range params.configurationItem.Keys {
+			for _, parameter := range key.Parameters {
+				compPlan.ParameterPatches = append(compPlan.ParameterPatches, appsv1alpha1.ParameterPatch{
+					Name:         params.configurationItem.Name,
+					FileName:     key.Key,
+					Key:          parameter.Key,
+					CurrentValue: currentParams[key.Key].Parameters[parameter.Key],
+					Value:        parameter.Value,
+				})
+			}
+		}
+		plan.Components[params.componentName] = compPlan
+	}
+	return plan, nil
+}
+
+// lastConfigFileParams returns the parameters of the configuration item recorded in the last configuration,
+// the parameters of the role overlay are returned if the item is scoped to a role.
+func lastConfigFileParams(lastCompConfiguration appsv1alpha1.LastComponentConfiguration,
+	item appsv1alpha1.ConfigurationItem) map[string]appsv1alpha1.ConfigParams {
+	for _, lastItem := range lastCompConfiguration.Configurations {
+		if lastItem.Name != item.Name {
+			continue
+		}
+		if item.Role == "" {
+			return lastItem.ConfigFileParams
+		}
+		for _, overlay := range lastItem.RoleOverlays {
+			if overlay.Role == item.Role {
+				return overlay.ConfigFileParams
+			}
+		}
+	}
 	return nil
 }
 
@@ -111,11 +197,12 @@ func (r *reconfigureAction) syncDependResources(reqCtx intctrlutil.RequestCtx, c
 }
 
 func (r *reconfigureAction) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, resource *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
-	var (
-		isFinished = true
-		opsRequest = resource.OpsRequest.Spec
-	)
+	return r.reconcileReconfigures(reqCtx, cli, resource, resource.OpsRequest.Spec)
+}
 
+// reconcileReconfigures syncs the status of the configurations reconfigured by the reconfigures of the OpsRequest spec.
+func (r *reconfigureAction) reconcileReconfigures(reqCtx intctrlutil.RequestCtx, cli client.Client, resource *OpsResource, opsRequest appsv1alpha1.OpsRequestSpec) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	isFinished := true
 	// Node: support multiple component
 	opsDeepCopy := resource.OpsRequest.DeepCopy()
 	statusAsComponents := make([]appsv1alpha1.ConfigurationItemStatus, 0)
@@ -22,8 +22,10 @@ package operations
 import (
 	"fmt"
 	"slices"
+	"strings"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -35,6 +37,8 @@ import (
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
+const reasonObsoleteParameters = "ObsoleteParameters"
+
 type reconfigureContext struct {
 	// reconfiguring request
 	config appsv1alpha1.ConfigurationItem
@@ -148,16 +152,24 @@ func (p *pipeline) doMergeImpl(parameters appsv1alpha1.ConfigurationItem) error
 	}
 	filter := validate.WithKeySelector(configSpec.Keys)
 	paramFilter := createImmutableParamsFilter(p.configConstraint)
+	if parameters.Role != "" {
+		if err := p.mergeRoleOverlay(item, parameters, filter, paramFilter); err != nil {
+			return err
+		}
+		p.updatedObject = newConfigObj
+		return p.createUpdatePatch(item, configSpec)
+	}
 	for _, key := range parameters.Keys {
 		// patch parameters
 		if configSpec.ConfigConstraintRef != "" && filter(key.Key) {
 			if key.FileContent != "" {
 				return cfgcore.MakeError("not allowed to update file content: %s", key.Key)
 			}
-			updateParameters(item, key.Key, key.Parameters, paramFilter)
+			keyParameters := p.migrateParameters(key.Parameters)
+			updateParameters(item, key.Key, keyParameters, paramFilter)
 			p.updatedParameters = append(p.updatedParameters, cfgcore.ParamPairs{
 				Key:           key.Key,
-				UpdatedParams: fromKeyValuePair(key.Parameters),
+				UpdatedParams: fromKeyValuePair(keyParameters),
 			})
 			continue
 		}
@@ -172,6 +184,69 @@ func (p *pipeline) doMergeImpl(parameters appsv1alpha1.ConfigurationItem) error
 	return p.createUpdatePatch(item, configSpec)
 }
 
+// mergeRoleOverlay merges the parameters into the overlay of the role, which are applied online to the pods of the role only.
+func (p *pipeline) mergeRoleOverlay(item *appsv1alpha1.ConfigurationItemDetail, parameters appsv1alpha1.ConfigurationItem, keyFilter, paramFilter validate.ValidatorOptions) error {
+	if p.configConstraint == nil {
+		return cfgcore.MakeError("the role-scoped reconfiguring requires the config constraint: %s", parameters.Name)
+	}
+
+	index := slices.IndexFunc(item.RoleOverlays, func(overlay appsv1alpha1.RoleConfigOverlay) bool {
+		return overlay.Role == parameters.Role
+	})
+	if index < 0 {
+		item.RoleOverlays = append(item.RoleOverlays, appsv1alpha1.RoleConfigOverlay{Role: parameters.Role})
+		index = len(item.RoleOverlays) - 1
+	}
+	overlay := &appsv1alpha1.ConfigurationItemDetail{ConfigFileParams: item.RoleOverlays[index].ConfigFileParams}
+	if overlay.ConfigFileParams == nil {
+		overlay.ConfigFileParams = make(map[string]appsv1alpha1.ConfigParams)
+	}
+	for _, key := range parameters.Keys {
+		if !keyFilter(key.Key) || key.FileContent != "" {
+			return cfgcore.MakeError("not allowed to update file content for role[%s]: %s", parameters.Role, key.Key)
+		}
+		keyParameters := p.migrateParameters(key.Parameters)
+		for _, param := range keyParameters {
+			if !cfgcore.IsDynamicParameter(param.Key, &p.configConstraint.Spec) {
+				p.isFailed = true
+				return cfgcore.MakeError("only dynamic parameters are allowed for role[%s], parameter[%s] is static", parameters.Role, param.Key)
+			}
+		}
+		updateParameters(overlay, key.Key, keyParameters, paramFilter)
+		p.updatedParameters = append(p.updatedParameters, cfgcore.ParamPairs{
+			Key:           key.Key,
+			UpdatedParams: fromKeyValuePair(keyParameters),
+		})
+	}
+	item.RoleOverlays[index].ConfigFileParams = overlay.ConfigFileParams
+	return nil
+}
+
+// migrateParameters translates the obsolete parameters declared by the ConfigConstraint, and warns about them.
+func (p *pipeline) migrateParameters(parameters []appsv1alpha1.ParameterPair) []appsv1alpha1.ParameterPair {
+	if p.configConstraint == nil || len(p.configConstraint.Spec.ParameterMigrations) == 0 {
+		return parameters
+	}
+	params := make(map[string]*string, len(parameters))
+	for _, param := range parameters {
+		params[param.Key] = param.Value
+	}
+	migrated, warnings := cfgcore.MigrateParameters(params, p.configConstraint.Spec.ParameterMigrations)
+	if len(warnings) == 0 {
+		return parameters
+	}
+	if p.resource != nil && p.resource.Recorder != nil {
+		p.resource.Recorder.Eventf(p.resource.OpsRequest, corev1.EventTypeWarning, reasonObsoleteParameters,
+			"config %s: %s", p.config.Name, strings.Join(warnings, "; "))
+	}
+	result := make([]appsv1alpha1.ParameterPair, 0, len(migrated))
+	for key, value := range migrated {
+		result = append(result, appsv1alpha1.ParameterPair{Key: key, Value: value})
+	}
+	slices.SortFunc(result, func(a, b appsv1alpha1.ParameterPair) int { return strings.Compare(a.Key, b.Key) })
+	return result
+}
+
 func (p *pipeline) createUpdatePatch(item *appsv1alpha1.ConfigurationItemDetail, configSpec *appsv1alpha1.ComponentConfigSpec) error {
 	if p.configConstraint == nil {
 		return nil
@@ -204,9 +279,11 @@ func (p *pipeline) Merge() *pipeline {
 
 func (p *pipeline) UpdateOpsLabel() *pipeline {
 	updateFn := func() error {
+		// the parameters of the secret-backed configuration may be credentials, which are not recorded in the ops.
 		if len(p.updatedParameters) == 0 ||
 			p.configConstraint == nil ||
-			p.configConstraint.Spec.FileFormatConfig == nil {
+			p.configConstraint.Spec.FileFormatConfig == nil ||
+			configctrl.IsSecretBackedConfigMap(p.ConfigMapObj) {
 			return nil
 		}
 
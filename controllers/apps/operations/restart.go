@@ -32,6 +32,7 @@ import (
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
@@ -47,7 +48,13 @@ func init() {
 		FromClusterPhases: appsv1alpha1.GetClusterUpRunningPhases(),
 		ToClusterPhase:    appsv1alpha1.UpdatingClusterPhase,
 		QueueByCluster:    true,
-		OpsHandler:        restartOpsHandler{},
+		ConflictPolicies: map[appsv1alpha1.OpsType]OpsConflictPolicy{
+			appsv1alpha1.RestartType:           ConflictPolicyAbort,
+			appsv1alpha1.HorizontalScalingType: ConflictPolicyQueue,
+		},
+		OpsHandler: restartOpsHandler{},
+
+		HonorMaintenanceWindow: true,
 	}
 
 	opsMgr := GetOpsManager()
@@ -65,13 +72,18 @@ func (r restartOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Clie
 		return fmt.Errorf("status.startTimestamp can not be null")
 	}
 	// abort earlier running vertical scaling opsRequest.
-	if err := abortEarlierOpsRequestWithSameKind(reqCtx, cli, opsRes, []appsv1alpha1.OpsType{appsv1alpha1.RestartType},
+	if err := abortConflictingOpsRequests(reqCtx, cli, opsRes,
 		func(earlierOps *appsv1alpha1.OpsRequest) (bool, error) {
 			return true, nil
 		}); err != nil {
 		return err
 	}
-	r.compOpsHelper = newComponentOpsHelper(opsRes.OpsRequest.Spec.RestartList)
+	// only restart the components in execution if the components are restarted one by one.
+	r.compOpsHelper = newComponentOpsHelper(opsRes.OpsRequest.Spec.RestartList).
+		withExecutedComponents(opsRes.OpsRequest, getSerialExecutionOrder(opsRes.OpsRequest, opsRes.OpsRequest.Spec.RestartList))
+	if err := r.markComponentsMigratingToKBAgent(reqCtx, cli, opsRes); err != nil {
+		return err
+	}
 	componentKindList := []client.ObjectList{
 		&appv1.StatefulSetList{},
 		&workloads.InstanceSetList{},
@@ -84,19 +96,78 @@ func (r restartOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Clie
 	return nil
 }
 
+// markComponentsMigratingToKBAgent requests the restarted components to replace the legacy lorry containers with
+// the kb-agent if the cluster opts in to the migration, the pods are rebuilt with the kb-agent during the restart.
+func (r restartOpsHandler) markComponentsMigratingToKBAgent(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	if opsRes.Cluster.Annotations[constant.MigrateToKBAgentAnnotationKey] != "true" {
+		return nil
+	}
+	compList := &appsv1alpha1.ComponentList{}
+	if err := cli.List(reqCtx.Ctx, compList, client.InNamespace(opsRes.Cluster.Namespace),
+		client.MatchingLabels{constant.AppInstanceLabelKey: opsRes.Cluster.Name}); err != nil {
+		return err
+	}
+	for i := range compList.Items {
+		comp := &compList.Items[i]
+		if component.IsMigratingToKBAgent(comp) || !r.isRestartTarget(comp) {
+			continue
+		}
+		patch := client.MergeFrom(comp.DeepCopy())
+		if comp.Annotations == nil {
+			comp.Annotations = map[string]string{}
+		}
+		comp.Annotations[constant.MigrateToKBAgentAnnotationKey] = "true"
+		if err := cli.Patch(reqCtx.Ctx, comp, patch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isRestartTarget checks whether the component or the sharding it belongs to is restarted by the OpsRequest.
+func (r restartOpsHandler) isRestartTarget(comp *appsv1alpha1.Component) bool {
+	if _, ok := r.compOpsHelper.componentOpsSet[comp.Labels[constant.KBAppComponentLabelKey]]; ok {
+		return true
+	}
+	_, ok := r.compOpsHelper.componentOpsSet[comp.Labels[constant.KBAppShardingNameLabelKey]]
+	return ok
+}
+
 // ReconcileAction will be performed when action is done and loops till OpsRequest.status.phase is Succeed/Failed.
 // the Reconcile function for restart opsRequest.
 func (r restartOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
-	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.RestartList)
+	order := getSerialExecutionOrder(opsRes.OpsRequest, opsRes.OpsRequest.Spec.RestartList)
+	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.RestartList).withExecutedComponents(opsRes.OpsRequest, order)
 	handleRestartProgress := func(reqCtx intctrlutil.RequestCtx,
 		cli client.Client,
 		opsRes *OpsResource,
 		pgRes *progressResource,
 		compStatus *appsv1alpha1.OpsRequestComponentStatus) (expectProgressCount int32, completedCount int32, err error) {
+		if isInstanceRestart(pgRes.compOps) {
+			// only the specified instances are restarted, which may not belong to all the shards of a sharding.
+			pgRes.noWaitComponentCompleted = true
+			updatedPodSet, err := r.getRestartedPodSet(opsRes, pgRes)
+			if err != nil || len(updatedPodSet) == 0 {
+				return 0, 0, err
+			}
+			pgRes.updatedPodSet = updatedPodSet
+		}
 		return handleComponentStatusProgress(reqCtx, cli, opsRes, pgRes, compStatus, r.podApplyCompOps)
 	}
-	return compOpsHelper.reconcileActionWithComponentOps(reqCtx, cli, opsRes,
+	canaryStatus, err := r.reconcileCanaryRestart(reqCtx, cli, opsRes, compOpsHelper)
+	if err != nil {
+		return "", 0, err
+	}
+	phase, requeueAfter, err := compOpsHelper.reconcileActionWithComponentOps(reqCtx, cli, opsRes,
 		"restart", handleRestartProgress)
+	if err == nil && !canaryStatus.completed && phase != appsv1alpha1.OpsFailedPhase {
+		// the components may be running before all the instances are restarted by the canary strategy.
+		if canaryStatus.waitingForConfirm {
+			return appsv1alpha1.OpsWaitingForConfirmPhase, 0, nil
+		}
+		return appsv1alpha1.OpsRunningPhase, canaryRestartRequeueAfter, nil
+	}
+	return executeNextComponent(reqCtx, cli, opsRes, order, r, phase, requeueAfter, err)
 }
 
 // SaveLastConfiguration this operation only restart the pods of the component, no changes for Cluster.spec.
@@ -105,6 +176,23 @@ func (r restartOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx,
 	return nil
 }
 
+// getRestartedPodSet gets the specified instances to restart which belong to the component.
+func (r restartOpsHandler) getRestartedPodSet(opsRes *OpsResource, pgRes *progressResource) (map[string]string, error) {
+	compSpec := pgRes.clusterComponent
+	podSet, err := component.GenerateAllPodNamesToSet(compSpec.Replicas, compSpec.Instances, compSpec.OfflineInstances,
+		opsRes.Cluster.Name, pgRes.fullComponentName)
+	if err != nil {
+		return nil, err
+	}
+	updatedPodSet := map[string]string{}
+	for _, name := range pgRes.compOps.(appsv1alpha1.Restart).InstanceNames {
+		if templateName, ok := podSet[name]; ok {
+			updatedPodSet[name] = templateName
+		}
+	}
+	return updatedPodSet, nil
+}
+
 func (r restartOpsHandler) podApplyCompOps(
 	ops *appsv1alpha1.OpsRequest,
 	pod *corev1.Pod,
@@ -139,16 +227,17 @@ func (r restartOpsHandler) restartComponent(reqCtx intctrlutil.RequestCtx, cli c
 
 // isRestarted checks whether the component has been restarted
 func (r restartOpsHandler) isRestarted(opsRes *OpsResource, object client.Object, podTemplate *corev1.PodTemplateSpec) bool {
-	cName := object.GetLabels()[constant.KBAppComponentLabelKey]
-	shardingName := object.GetLabels()[constant.KBAppShardingNameLabelKey]
-	if shardingName != "" {
-		if _, ok := r.compOpsHelper.componentOpsSet[shardingName]; !ok {
-			return true
-		}
-	} else {
-		if _, ok := r.compOpsHelper.componentOpsSet[cName]; !ok {
-			return true
-		}
+	compName := object.GetLabels()[constant.KBAppComponentLabelKey]
+	if shardingName := object.GetLabels()[constant.KBAppShardingNameLabelKey]; shardingName != "" {
+		compName = shardingName
+	}
+	compOps, ok := r.compOpsHelper.componentOpsSet[compName]
+	if !ok {
+		return true
+	}
+	// the instances of the canary components or the specified instances are restarted one by one in ReconcileAction.
+	if isRestartedOneByOne(compOps) {
+		return true
 	}
 	if podTemplate.Annotations == nil {
 		podTemplate.Annotations = map[string]string{}
@@ -0,0 +1,199 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const canaryRestartRequeueAfter = 5 * time.Second
+
+// canaryRestartStatus summarizes the canary restart of the components.
+type canaryRestartStatus struct {
+	// all the instances of the canary components have been restarted.
+	completed bool
+	// the canary instances have been restarted, and the remaining instances are waiting for the approval.
+	waitingForConfirm bool
+}
+
+// isCanaryRestart checks whether the component is restarted by the "Canary" strategy.
+func isCanaryRestart(compOps ComponentOpsInterface) bool {
+	restart, ok := compOps.(appsv1alpha1.Restart)
+	return ok && restart.Strategy == appsv1alpha1.CanaryRestartStrategy
+}
+
+// isInstanceRestart checks whether only the specified instances of the component are restarted.
+func isInstanceRestart(compOps ComponentOpsInterface) bool {
+	restart, ok := compOps.(appsv1alpha1.Restart)
+	return ok && len(restart.InstanceNames) > 0
+}
+
+// isRestartedOneByOne checks whether the instances of the component are restarted one by one by deleting the pods,
+// rather than rolling update the workload.
+func isRestartedOneByOne(compOps ComponentOpsInterface) bool {
+	return isCanaryRestart(compOps) || isInstanceRestart(compOps)
+}
+
+// getCanaryCount returns the number of the canary instances to restart first.
+func getCanaryCount(restart appsv1alpha1.Restart, replicas int) int {
+	count := 1
+	switch {
+	case restart.CanaryCount != nil:
+		count = int(*restart.CanaryCount)
+	case restart.CanaryPercent != nil:
+		count = int(math.Ceil(float64(replicas) * float64(*restart.CanaryPercent) / 100))
+	}
+	return max(1, min(count, replicas))
+}
+
+// reconcileCanaryRestart restarts the instances of the components with the "Canary" strategy or the specified
+// instances one by one, by deleting the pods which are created before the OpsRequest starts. The canary instances
+// are restarted first, and the remaining ones are restarted after the OpsRequest is approved.
+func (r restartOpsHandler) reconcileCanaryRestart(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	compOpsHelper componentOpsHelper) (canaryRestartStatus, error) {
+	var (
+		status      = canaryRestartStatus{completed: true}
+		approved    = opsRes.OpsRequest.Annotations[constant.OpsCanaryApprovedAnnotationKey] == "true"
+		waiting     bool
+		progressing bool
+	)
+	for compName, compOps := range compOpsHelper.componentOpsSet {
+		if !isRestartedOneByOne(compOps) {
+			continue
+		}
+		fullCompNames, err := getFullComponentNames(reqCtx, cli, opsRes.Cluster, compName)
+		if err != nil {
+			return status, err
+		}
+		for _, fullCompName := range fullCompNames {
+			done, paused, err := r.restartCanaryComponent(reqCtx, cli, opsRes, compOps.(appsv1alpha1.Restart), fullCompName, approved)
+			if err != nil {
+				return status, err
+			}
+			if done {
+				continue
+			}
+			status.completed = false
+			if paused {
+				waiting = true
+			} else {
+				progressing = true
+			}
+		}
+	}
+	status.waitingForConfirm = waiting && !progressing
+	return status, nil
+}
+
+// restartCanaryComponent restarts the next instance of the component if the restarted ones are ready,
+// only the specified instances are restarted if restart.instanceNames is set.
+// It returns whether all the instances have been restarted, and whether the restart is paused for the approval.
+func (r restartOpsHandler) restartCanaryComponent(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	restart appsv1alpha1.Restart,
+	fullCompName string,
+	approved bool) (bool, bool, error) {
+	its := &workloads.InstanceSet{}
+	itsKey := client.ObjectKey{
+		Namespace: opsRes.Cluster.Namespace,
+		Name:      constant.GenerateClusterComponentName(opsRes.Cluster.Name, fullCompName),
+	}
+	if err := cli.Get(reqCtx.Ctx, itsKey, its); err != nil {
+		return false, false, err
+	}
+	pods, err := component.ListOwnedPods(reqCtx.Ctx, cli, opsRes.Cluster.Namespace, opsRes.Cluster.Name, fullCompName)
+	if err != nil {
+		return false, false, err
+	}
+	if its.Spec.Replicas != nil && len(pods) < int(*its.Spec.Replicas) {
+		// waiting for the deleted instance to be recreated.
+		return false, false, nil
+	}
+	sortPodsByRestartOrder(pods, its.Spec.Roles)
+	var (
+		startTimestamp = opsRes.OpsRequest.Status.StartTimestamp
+		instanceNames  = sets.New(restart.InstanceNames...)
+		restartedCount int
+		next           *corev1.Pod
+	)
+	for _, pod := range pods {
+		if instanceNames.Len() > 0 && !instanceNames.Has(pod.Name) {
+			continue
+		}
+		if !pod.CreationTimestamp.Before(&startTimestamp) {
+			if !intctrlutil.PodIsReady(pod) {
+				return false, false, nil
+			}
+			restartedCount++
+			continue
+		}
+		if pod.DeletionTimestamp != nil {
+			return false, false, nil
+		}
+		if next == nil {
+			next = pod
+		}
+	}
+	if next == nil {
+		return true, false, nil
+	}
+	if isCanaryRestart(restart) && !approved && restartedCount >= getCanaryCount(restart, len(pods)) {
+		return false, true, nil
+	}
+	if err = intctrlutil.BackgroundDeleteObject(cli, reqCtx.Ctx, next); err != nil {
+		return false, false, err
+	}
+	opsRes.Recorder.Eventf(opsRes.OpsRequest, corev1.EventTypeNormal, "RestartInstance",
+		"Restart the instance %s of Component: %s", next.Name, fullCompName)
+	return false, false, nil
+}
+
+// sortPodsByRestartOrder sorts the pods to restart the followers before the leader.
+func sortPodsByRestartOrder(pods []*corev1.Pod, roles []workloads.ReplicaRole) {
+	isLeader := func(pod *corev1.Pod) bool {
+		for _, role := range roles {
+			if role.IsLeader && pod.Labels[constant.RoleLabelKey] == role.Name {
+				return true
+			}
+		}
+		return false
+	}
+	sort.SliceStable(pods, func(i, j int) bool {
+		if li, lj := isLeader(pods[i]), isLeader(pods[j]); li != lj {
+			return lj
+		}
+		return pods[i].Name < pods[j].Name
+	})
+}
@@ -0,0 +1,81 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+var _ = Describe("canary restart", func() {
+	It("computes the number of the canary instances", func() {
+		restart := appsv1alpha1.Restart{Strategy: appsv1alpha1.CanaryRestartStrategy}
+		Expect(getCanaryCount(restart, 3)).Should(Equal(1))
+
+		restart.CanaryCount = pointer.Int32(2)
+		Expect(getCanaryCount(restart, 3)).Should(Equal(2))
+		restart.CanaryCount = pointer.Int32(5)
+		Expect(getCanaryCount(restart, 3)).Should(Equal(3))
+
+		restart.CanaryCount = nil
+		restart.CanaryPercent = pointer.Int32(50)
+		Expect(getCanaryCount(restart, 5)).Should(Equal(3))
+		restart.CanaryPercent = pointer.Int32(1)
+		Expect(getCanaryCount(restart, 5)).Should(Equal(1))
+	})
+
+	It("restarts the followers before the leader", func() {
+		newPod := func(name, role string) *corev1.Pod {
+			return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{constant.RoleLabelKey: role},
+			}}
+		}
+		pods := []*corev1.Pod{
+			newPod("mysql-0", "leader"),
+			newPod("mysql-2", "follower"),
+			newPod("mysql-1", "follower"),
+		}
+		roles := []workloads.ReplicaRole{{Name: "leader", IsLeader: true}, {Name: "follower"}}
+		sortPodsByRestartOrder(pods, roles)
+		Expect(pods[0].Name).Should(Equal("mysql-1"))
+		Expect(pods[1].Name).Should(Equal("mysql-2"))
+		Expect(pods[2].Name).Should(Equal("mysql-0"))
+	})
+
+	It("restarts the instances one by one for the Canary strategy or the specified instances", func() {
+		Expect(isCanaryRestart(appsv1alpha1.Restart{})).Should(BeFalse())
+		Expect(isCanaryRestart(appsv1alpha1.Restart{Strategy: appsv1alpha1.RollingRestartStrategy})).Should(BeFalse())
+		Expect(isCanaryRestart(appsv1alpha1.Restart{Strategy: appsv1alpha1.CanaryRestartStrategy})).Should(BeTrue())
+
+		instanceRestart := appsv1alpha1.Restart{InstanceNames: []string{"mycluster-mysql-1"}}
+		Expect(isInstanceRestart(instanceRestart)).Should(BeTrue())
+		Expect(isRestartedOneByOne(instanceRestart)).Should(BeTrue())
+		Expect(isRestartedOneByOne(appsv1alpha1.Restart{})).Should(BeFalse())
+	})
+})
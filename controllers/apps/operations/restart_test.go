@@ -20,9 +20,12 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
@@ -91,6 +94,40 @@ var _ = Describe("Restart OpsRequest", func() {
 			Expect(err == nil).Should(BeTrue())
 		})
 
+		It("holds the restart OpsRequest until the maintenance window opens", func() {
+			windowAt := func(start time.Time) *appsv1alpha1.OpsSchedulingPolicy {
+				return &appsv1alpha1.OpsSchedulingPolicy{
+					MaintenanceWindow: &appsv1alpha1.MaintenanceWindow{
+						StartTime: start.UTC().Format("15:04"),
+						Duration:  metav1.Duration{Duration: time.Hour},
+					},
+				}
+			}
+
+			By("create Restart opsRequest out of the maintenance window")
+			opsRes.OpsRequest = createRestartOpsObj(clusterName, "restart-ops-"+randomStr)
+			opsRes.OpsRequest.Spec.SchedulingPolicy = windowAt(time.Now().Add(3 * time.Hour))
+			res, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res.RequeueAfter).Should(BeNumerically(">", 2*time.Hour))
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest),
+				func(g Gomega, fetched *appsv1alpha1.OpsRequest) {
+					g.Expect(fetched.Status.Phase).To(Equal(appsv1alpha1.OpsScheduledPhase))
+					condition := meta.FindStatusCondition(fetched.Status.Conditions, appsv1alpha1.ConditionTypeQueued)
+					g.Expect(condition).ShouldNot(BeNil())
+					g.Expect(condition.Reason).Should(Equal(appsv1alpha1.ReasonWaitingForMaintenanceWindow))
+				})).Should(Succeed())
+
+			By("the opsRequest is moved back to Pending once the window opens")
+			opsRes.OpsRequest.Spec.SchedulingPolicy = windowAt(time.Now().Add(-10 * time.Minute))
+			_, err = GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(appsv1alpha1.OpsPendingPhase))
+			_, err = GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(appsv1alpha1.OpsCreatingPhase))
+		})
+
 		It("expect failed when cluster is stopped", func() {
 			By("mock cluster is stopped")
 			Expect(testapps.ChangeObjStatus(&testCtx, cluster, func() {
@@ -113,8 +150,8 @@ var _ = Describe("Restart OpsRequest", func() {
 func createRestartOpsObj(clusterName, restartOpsName string) *appsv1alpha1.OpsRequest {
 	ops := testapps.NewOpsRequestObj(restartOpsName, testCtx.DefaultNamespace,
 		clusterName, appsv1alpha1.RestartType)
-	ops.Spec.RestartList = []appsv1alpha1.ComponentOps{
-		{ComponentName: defaultCompName},
+	ops.Spec.RestartList = []appsv1alpha1.Restart{
+		{ComponentOps: appsv1alpha1.ComponentOps{ComponentName: defaultCompName}},
 	}
 	opsRequest := testapps.CreateOpsRequest(ctx, testCtx, ops)
 	opsRequest.Status.Phase = appsv1alpha1.OpsPendingPhase
@@ -192,6 +192,10 @@ func (r RestoreOpsHandler) getClusterObjFromBackup(backup *dpv1alpha1.Backup, op
 	if err != nil {
 		return nil, err
 	}
+	restoreAnnotation, err = restore.SetMaskingRulesToRestoreAnnotation(restoreAnnotation, restoreSpec.MaskingRules)
+	if err != nil {
+		return nil, err
+	}
 	if cluster.Annotations == nil {
 		cluster.Annotations = map[string]string{}
 	}
@@ -0,0 +1,255 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/configuration/core"
+	intctrlcomp "github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+type rollbackOpsHandler struct{}
+
+var _ OpsHandler = rollbackOpsHandler{}
+
+func init() {
+	rollbackBehaviour := OpsBehaviour{
+		// if cluster is Abnormal or Failed, new opsRequest may repair it.
+		FromClusterPhases: appsv1alpha1.GetClusterUpRunningPhases(),
+		ToClusterPhase:    appsv1alpha1.UpdatingClusterPhase,
+		QueueByCluster:    true,
+		OpsHandler:        rollbackOpsHandler{},
+	}
+
+	opsMgr := GetOpsManager()
+	opsMgr.RegisterOps(appsv1alpha1.RollbackType, rollbackBehaviour)
+}
+
+// ActionStartedCondition the started condition when handling the rollback request.
+func (r rollbackOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*metav1.Condition, error) {
+	return appsv1alpha1.NewRollingBackCondition(opsRes.OpsRequest), nil
+}
+
+// Action reverts the Cluster to the last configuration recorded by the target OpsRequest.
+func (r rollbackOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	targetOps, err := r.getTargetOpsRequest(reqCtx, cli, opsRes)
+	if err != nil {
+		return err
+	}
+	lastCompConfigs := targetOps.Status.LastConfiguration.Components
+	switch targetOps.Spec.Type {
+	case appsv1alpha1.HorizontalScalingType:
+		rollbackComponentSpecs(opsRes.Cluster, lastCompConfigs, rollbackHorizontalScaling)
+	case appsv1alpha1.VerticalScalingType:
+		rollbackComponentSpecs(opsRes.Cluster, lastCompConfigs, rollbackVerticalScaling)
+	case appsv1alpha1.ReconfiguringType:
+		return r.rollbackConfigurations(reqCtx, cli, opsRes, lastCompConfigs)
+	default:
+		return intctrlutil.NewFatalError(fmt.Sprintf(`the OpsRequest "%s" of type "%s" can not be rolled back`, targetOps.Name, targetOps.Spec.Type))
+	}
+	// all the components are reverted by one update, so the cluster is rolled back atomically.
+	return cli.Update(reqCtx.Ctx, opsRes.Cluster)
+}
+
+// ReconcileAction will be performed when action is done and loops till OpsRequest.status.phase is Succeed/Failed.
+// the Reconcile function for rollback opsRequest.
+func (r rollbackOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	targetOps, err := r.getTargetOpsRequest(reqCtx, cli, opsRes)
+	if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+		return appsv1alpha1.OpsFailedPhase, 0, err
+	} else if err != nil {
+		return "", 0, err
+	}
+	switch targetOps.Spec.Type {
+	case appsv1alpha1.HorizontalScalingType:
+		return r.reconcileHorizontalScaling(reqCtx, cli, opsRes, targetOps)
+	case appsv1alpha1.VerticalScalingType:
+		var verticalScalingList []appsv1alpha1.VerticalScaling
+		for _, compName := range r.getComponentNames(targetOps.Status.LastConfiguration.Components) {
+			lastCompConfiguration := targetOps.Status.LastConfiguration.Components[compName]
+			verticalScalingList = append(verticalScalingList, appsv1alpha1.VerticalScaling{
+				ComponentOps:         appsv1alpha1.ComponentOps{ComponentName: compName},
+				ResourceRequirements: lastCompConfiguration.ResourceRequirements,
+				Instances:            verticalScalingHandler{}.lastInstanceResources(lastCompConfiguration),
+			})
+		}
+		return verticalScalingHandler{}.reconcileVerticalScaling(reqCtx, cli, opsRes, verticalScalingList)
+	case appsv1alpha1.ReconfiguringType:
+		reAction := &reconfigureAction{}
+		return reAction.reconcileReconfigures(reqCtx, cli, opsRes, targetOps.Spec)
+	default:
+		return appsv1alpha1.OpsFailedPhase, 0, fmt.Errorf(`the OpsRequest "%s" of type "%s" can not be rolled back`, targetOps.Name, targetOps.Spec.Type)
+	}
+}
+
+// SaveLastConfiguration records the configuration prior to the rollback in the same way as the target OpsRequest,
+// so that the changes of the rollback are traceable.
+func (r rollbackOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	targetOps, err := r.getTargetOpsRequest(reqCtx, cli, opsRes)
+	if err != nil {
+		return err
+	}
+	opsBehaviour, ok := GetOpsManager().OpsMap[targetOps.Spec.Type]
+	if !ok || opsBehaviour.OpsHandler == nil {
+		return intctrlutil.NewFatalError(fmt.Sprintf(`the OpsRequest "%s" of type "%s" can not be rolled back`, targetOps.Name, targetOps.Spec.Type))
+	}
+	targetRes := &OpsResource{
+		OpsRequest: targetOps,
+		Cluster:    opsRes.Cluster,
+		Recorder:   opsRes.Recorder,
+	}
+	if err = opsBehaviour.OpsHandler.SaveLastConfiguration(reqCtx, cli, targetRes); err != nil {
+		return err
+	}
+	opsRes.OpsRequest.Status.LastConfiguration = targetOps.Status.LastConfiguration
+	return nil
+}
+
+// getTargetOpsRequest gets the OpsRequest to be rolled back.
+func (r rollbackOpsHandler) getTargetOpsRequest(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*appsv1alpha1.OpsRequest, error) {
+	rollback := opsRes.OpsRequest.Spec.Rollback
+	if rollback == nil {
+		return nil, intctrlutil.NewFatalError("spec.rollback can not be empty")
+	}
+	targetOps := &appsv1alpha1.OpsRequest{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: rollback.OpsRequestName, Namespace: opsRes.OpsRequest.Namespace}, targetOps); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, intctrlutil.NewFatalError(err.Error())
+		}
+		return nil, err
+	}
+	return targetOps, nil
+}
+
+// getComponentNames returns the sorted names of the components recorded in the last configuration.
+func (r rollbackOpsHandler) getComponentNames(lastCompConfigs map[string]appsv1alpha1.LastComponentConfiguration) []string {
+	compNames := make([]string, 0, len(lastCompConfigs))
+	for compName := range lastCompConfigs {
+		compNames = append(compNames, compName)
+	}
+	sort.Strings(compNames)
+	return compNames
+}
+
+// rollbackConfigurations reverts the parameters of the configurations to the last configuration.
+func (r rollbackOpsHandler) rollbackConfigurations(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	lastCompConfigs map[string]appsv1alpha1.LastComponentConfiguration) error {
+	for _, compName := range r.getComponentNames(lastCompConfigs) {
+		lastCompConfiguration := lastCompConfigs[compName]
+		if len(lastCompConfiguration.Configurations) == 0 {
+			continue
+		}
+		config := &appsv1alpha1.Configuration{}
+		configKey := client.ObjectKey{
+			Namespace: opsRes.Cluster.Namespace,
+			Name:      core.GenerateComponentConfigurationName(opsRes.Cluster.Name, compName),
+		}
+		if err := cli.Get(reqCtx.Ctx, configKey, config); err != nil {
+			return err
+		}
+		patch := client.MergeFrom(config.DeepCopy())
+		for _, lastItem := range lastCompConfiguration.Configurations {
+			item := config.Spec.GetConfigurationItem(lastItem.Name)
+			if item == nil {
+				return intctrlutil.NewFatalError(fmt.Sprintf(`the configuration "%s" is not found in the component "%s"`, lastItem.Name, compName))
+			}
+			item.ConfigFileParams = lastItem.ConfigFileParams
+			item.RoleOverlays = lastItem.RoleOverlays
+		}
+		if err := cli.Patch(reqCtx.Ctx, config, patch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileHorizontalScaling reconciles the progress of the components until the pods are scaled back.
+func (r rollbackOpsHandler) reconcileHorizontalScaling(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	targetOps *appsv1alpha1.OpsRequest) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	handleComponentProgress := func(
+		reqCtx intctrlutil.RequestCtx,
+		cli client.Client,
+		opsRes *OpsResource,
+		pgRes *progressResource,
+		compStatus *appsv1alpha1.OpsRequestComponentStatus) (int32, int32, error) {
+		var err error
+		// the last configuration of the rollback OpsRequest records the pods before rolling back.
+		lastCompConfiguration := opsRes.OpsRequest.Status.LastConfiguration.Components[pgRes.compOps.GetComponentName()]
+		pgRes.createdPodSet, pgRes.deletedPodSet, err = r.getCreateAndDeletePodSet(opsRes, lastCompConfiguration, *pgRes.clusterComponent, pgRes.fullComponentName)
+		if err != nil {
+			return 0, 0, err
+		}
+		pgRes.noWaitComponentCompleted = true
+		return handleComponentProgressForScalingReplicas(reqCtx, cli, opsRes, pgRes, compStatus)
+	}
+	var compOpsList []appsv1alpha1.ComponentOps
+	for _, compName := range r.getComponentNames(targetOps.Status.LastConfiguration.Components) {
+		compOpsList = append(compOpsList, appsv1alpha1.ComponentOps{ComponentName: compName})
+	}
+	compOpsHelper := newComponentOpsHelper(compOpsList)
+	return compOpsHelper.reconcileActionWithComponentOps(reqCtx, cli, opsRes, "", handleComponentProgress)
+}
+
+// getCreateAndDeletePodSet gets the pod sets that are created and deleted by rolling back the horizontal scaling.
+func (r rollbackOpsHandler) getCreateAndDeletePodSet(opsRes *OpsResource,
+	lastCompConfiguration appsv1alpha1.LastComponentConfiguration,
+	currCompSpec appsv1alpha1.ClusterComponentSpec,
+	fullCompName string) (map[string]string, map[string]string, error) {
+	if lastCompConfiguration.Replicas == nil {
+		return nil, nil, nil
+	}
+	clusterName := opsRes.Cluster.Name
+	lastPodSet, err := intctrlcomp.GenerateAllPodNamesToSet(*lastCompConfiguration.Replicas,
+		lastCompConfiguration.Instances, lastCompConfiguration.OfflineInstances, clusterName, fullCompName)
+	if err != nil {
+		return nil, nil, err
+	}
+	currPodSet, err := intctrlcomp.GenerateAllPodNamesToSet(currCompSpec.Replicas, currCompSpec.Instances,
+		currCompSpec.OfflineInstances, clusterName, fullCompName)
+	if err != nil {
+		return nil, nil, err
+	}
+	createPodSet := map[string]string{}
+	deletePodSet := map[string]string{}
+	for k := range currPodSet {
+		if _, ok := lastPodSet[k]; !ok {
+			createPodSet[k] = appsv1alpha1.GetInstanceTemplateName(clusterName, fullCompName, k)
+		}
+	}
+	for k := range lastPodSet {
+		if _, ok := currPodSet[k]; !ok {
+			deletePodSet[k] = appsv1alpha1.GetInstanceTemplateName(clusterName, fullCompName, k)
+		}
+	}
+	return createPodSet, deletePodSet, nil
+}
@@ -0,0 +1,138 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("Rollback OpsRequest", func() {
+
+	var (
+		randomStr   = testCtx.GetRandomStr()
+		compDefName = "test-compdef-" + randomStr
+		clusterName = "test-cluster-" + randomStr
+		reqCtx      intctrlutil.RequestCtx
+	)
+
+	cleanEnv := func() {
+		reqCtx = intctrlutil.RequestCtx{Ctx: ctx}
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+		// delete cluster(and all dependent sub-resources), cluster definition
+		testapps.ClearClusterResourcesWithRemoveFinalizerOption(&testCtx)
+
+		// delete rest resources
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		// namespaced
+		testapps.ClearResources(&testCtx, generics.OpsRequestSignature, inNS, ml)
+	}
+
+	BeforeEach(cleanEnv)
+
+	AfterEach(cleanEnv)
+
+	Context("Test OpsRequest", func() {
+		It("rolls back the succeeded VerticalScaling OpsRequest", func() {
+			By("init operations resources ")
+			opsRes, _, cluster := initOperationsResources(compDefName, clusterName)
+			lastResources := *cluster.Spec.ComponentSpecs[0].Resources.DeepCopy()
+
+			By("mock a succeeded VerticalScaling OpsRequest")
+			vsOps := testapps.NewOpsRequestObj("vertical-scaling-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.VerticalScalingType)
+			vsOps.Spec.VerticalScalingList = []appsv1alpha1.VerticalScaling{
+				{
+					ComponentOps: appsv1alpha1.ComponentOps{ComponentName: defaultCompName},
+					ResourceRequirements: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("800m")},
+					},
+				},
+			}
+			vsOps = testapps.CreateOpsRequest(ctx, testCtx, vsOps)
+			Expect(testapps.ChangeObjStatus(&testCtx, vsOps, func() {
+				vsOps.Status.Phase = appsv1alpha1.OpsSucceedPhase
+				vsOps.Status.LastConfiguration.Components = map[string]appsv1alpha1.LastComponentConfiguration{
+					defaultCompName: {ResourceRequirements: lastResources},
+				}
+			})).Should(Succeed())
+			Expect(testapps.ChangeObj(&testCtx, cluster, func(cluster *appsv1alpha1.Cluster) {
+				cluster.Spec.ComponentSpecs[0].Resources = vsOps.Spec.VerticalScalingList[0].ResourceRequirements
+			})).Should(Succeed())
+
+			By("create Rollback opsRequest")
+			ops := testapps.NewOpsRequestObj("rollback-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.RollbackType)
+			ops.Spec.Rollback = &appsv1alpha1.Rollback{OpsRequestName: vsOps.Name}
+			opsRes.OpsRequest = testapps.CreateOpsRequest(ctx, testCtx, ops)
+			opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsPendingPhase
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cluster), opsRes.Cluster)).Should(Succeed())
+
+			By("the configuration prior to the rollback is recorded")
+			_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(ops))).Should(Equal(appsv1alpha1.OpsCreatingPhase))
+			lastCompConfiguration := opsRes.OpsRequest.Status.LastConfiguration.Components[defaultCompName]
+			Expect(lastCompConfiguration.Limits.Cpu().String()).Should(Equal("800m"))
+
+			By("the cluster is reverted to the last configuration of the VerticalScaling OpsRequest")
+			Expect(rollbackOpsHandler{}.Action(reqCtx, k8sClient, opsRes)).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(cluster), func(g Gomega, cluster *appsv1alpha1.Cluster) {
+				g.Expect(cluster.Spec.ComponentSpecs[0].Resources).Should(Equal(lastResources))
+			})).Should(Succeed())
+		})
+
+		It("rejects to roll back the OpsRequest which does not support rollback", func() {
+			By("init operations resources ")
+			opsRes, _, _ := initOperationsResources(compDefName, clusterName)
+
+			restartOps := createRestartOpsObj(clusterName, "restart-ops-"+randomStr)
+			ops := testapps.NewOpsRequestObj("rollback-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.RollbackType)
+			ops.Spec.Rollback = &appsv1alpha1.Rollback{OpsRequestName: restartOps.Name}
+			opsRes.OpsRequest = testapps.CreateOpsRequest(ctx, testCtx, ops)
+			opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsPendingPhase
+
+			_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest),
+				func(g Gomega, fetched *appsv1alpha1.OpsRequest) {
+					g.Expect(fetched.Status.Phase).To(Equal(appsv1alpha1.OpsFailedPhase))
+					condition := meta.FindStatusCondition(fetched.Status.Conditions, appsv1alpha1.ConditionTypeValidated)
+					g.Expect(condition.Message).Should(ContainSubstring("can not be rolled back"))
+				})).Should(Succeed())
+		})
+	})
+})
@@ -0,0 +1,628 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/common"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlcomp "github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
+)
+
+const (
+	shardingConversionProvisionMessageKey = "provision sharding"
+	shardingConversionFreezeMessageKey    = "freeze writes of instance"
+	shardingConversionVerifyMessageKey    = "verify data of sharding"
+	shardingConversionCutoverMessageKey   = "cut over component"
+
+	shardingKind     = "Sharding"
+	verificationKind = "Verification"
+
+	shardingConversionQueryTimeout = 30 * time.Second
+
+	kbEnvConversionSourceComponent = "KB_CONVERSION_SOURCE_COMPONENT"
+	kbEnvConversionShards          = "KB_CONVERSION_SHARDS"
+)
+
+// shardingConversionOpsHandler converts a standalone Component into a sharding in the following stages:
+//  1. provisions the sharding with the specification of the source Component and waits for all shards to be running;
+//  2. freezes the writes of the source Component by setting its writable instances read-only;
+//  3. runs the actions of the redistribution OpsDefinition against the sharding to migrate the data;
+//  4. verifies that the count returned by the verification query on the source Component equals the sum of the shards;
+//  5. waits for the cutover to be confirmed, the source Component keeps serving the reads until then;
+//  6. cuts over by removing the source Component from the Cluster.
+//
+// If the redistribution or the verification fails, or the OpsRequest is cancelled before the cutover,
+// the sharding is removed and the writes of the source Component are resumed.
+type shardingConversionOpsHandler struct{}
+
+var _ OpsHandler = shardingConversionOpsHandler{}
+
+func init() {
+	conversionBehaviour := OpsBehaviour{
+		FromClusterPhases: appsv1alpha1.GetClusterUpRunningPhases(),
+		ToClusterPhase:    appsv1alpha1.UpdatingClusterPhase,
+		QueueByCluster:    true,
+		OpsHandler:        shardingConversionOpsHandler{},
+		CancelFunc:        shardingConversionOpsHandler{}.Cancel,
+	}
+
+	opsMgr := GetOpsManager()
+	opsMgr.RegisterOps(appsv1alpha1.ShardingConversionType, conversionBehaviour)
+}
+
+// ActionStartedCondition the started condition when handling the sharding conversion request.
+func (s shardingConversionOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*metav1.Condition, error) {
+	return appsv1alpha1.NewShardingConversionCondition(opsRes.OpsRequest), nil
+}
+
+// Action adds the sharding which copies the specification of the source Component to the Cluster.
+func (s shardingConversionOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	conversion := opsRes.OpsRequest.Spec.ShardingConversion
+	if err := s.validateParameters(reqCtx, cli, conversion); err != nil {
+		return err
+	}
+	if opsRes.Cluster.Spec.GetShardingByName(conversion.ShardingName) != nil {
+		return nil
+	}
+	compSpec := opsRes.Cluster.Spec.GetComponentByName(conversion.ComponentName)
+	if compSpec == nil {
+		return intctrlutil.NewFatalError(fmt.Sprintf(`component "%s" not found in cluster.spec.componentSpecs`, conversion.ComponentName))
+	}
+	patch := client.MergeFromWithOptions(opsRes.Cluster.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	template := compSpec.DeepCopy()
+	template.Name = conversion.ShardingName
+	opsRes.Cluster.Spec.ShardingSpecs = append(opsRes.Cluster.Spec.ShardingSpecs, appsv1alpha1.ShardingSpec{
+		Name:     conversion.ShardingName,
+		Template: *template,
+		Shards:   conversion.Shards,
+	})
+	return cli.Patch(reqCtx.Ctx, opsRes.Cluster, patch)
+}
+
+// Cancel removes the sharding and resumes the writes of the source Component,
+// the conversion can't be cancelled once the source Component is removed.
+func (s shardingConversionOpsHandler) Cancel(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	conversion := opsRes.OpsRequest.Spec.ShardingConversion
+	if opsRes.Cluster.Spec.GetComponentByName(conversion.ComponentName) == nil {
+		return intctrlutil.NewErrorf(intctrlutil.ErrorIgnoreCancel,
+			`the source component "%s" has been removed, the sharding conversion can not be cancelled`, conversion.ComponentName)
+	}
+	return s.rollback(reqCtx, cli, opsRes)
+}
+
+// ReconcileAction drives the conversion through the provision, freeze, redistribution, verification and cutover stages.
+// The sharding is removed and the source Component is resumed if the redistribution or the verification fails.
+func (s shardingConversionOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	var (
+		opsRequest     = opsRes.OpsRequest
+		oldOpsRequest  = opsRequest.DeepCopy()
+		conversion     = opsRequest.Spec.ShardingConversion
+		completedCount int
+	)
+	if opsRequest.Status.Phase == appsv1alpha1.OpsCancellingPhase {
+		return s.reconcileCancel(reqCtx, cli, opsRes)
+	}
+	opsDef := &appsv1alpha1.OpsDefinition{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: conversion.RedistributionOpsDefinitionName}, opsDef); err != nil {
+		return "", 0, err
+	}
+	opsRes.OpsDef = opsDef
+	// provision, freeze, verification and cutover stages besides the redistribution actions.
+	expectCount := len(opsDef.Spec.Actions) + 4
+	if opsRequest.Status.Components == nil {
+		opsRequest.Status.Components = map[string]appsv1alpha1.OpsRequestComponentStatus{}
+	}
+	syncProgress := func(phase appsv1alpha1.OpsPhase, requeueAfter time.Duration) (appsv1alpha1.OpsPhase, time.Duration, error) {
+		flushAfter, err := syncProgressToOpsRequest(reqCtx, cli, opsRes, oldOpsRequest, completedCount, expectCount)
+		if err != nil {
+			return "", 0, err
+		}
+		return phase, requeueForStatusFlush(requeueAfter, flushAfter), nil
+	}
+	failed := func() (appsv1alpha1.OpsPhase, time.Duration, error) {
+		if err := s.rollback(reqCtx, cli, opsRes); err != nil {
+			return "", 0, err
+		}
+		return syncProgress(appsv1alpha1.OpsFailedPhase, 0)
+	}
+
+	// 1. wait for all shards of the sharding to be running.
+	provisioned, err := s.reconcileProvision(reqCtx, cli, opsRes)
+	if err != nil {
+		return "", 0, err
+	}
+	if !provisioned {
+		return syncProgress(appsv1alpha1.OpsRunningPhase, 5*time.Second)
+	}
+	completedCount++
+
+	// 2. freeze the writes of the source Component, so that no writes are lost after the redistribution.
+	frozen, err := s.reconcileFreeze(reqCtx, cli, opsRes)
+	if err != nil {
+		return "", 0, err
+	}
+	if !frozen {
+		return syncProgress(appsv1alpha1.OpsRunningPhase, 5*time.Second)
+	}
+	completedCount++
+
+	// 3. redistribute the data of the source Component into the shards.
+	workflowStatus, err := s.reconcileRedistribution(reqCtx, cli, opsRes)
+	if err != nil {
+		return "", 0, err
+	}
+	completedCount += workflowStatus.CompletedCount
+	if !workflowStatus.IsCompleted {
+		return syncProgress(appsv1alpha1.OpsRunningPhase, 0)
+	}
+	if workflowStatus.ExistFailure {
+		return failed()
+	}
+
+	// 4. verify the data redistributed into the shards.
+	verified, verifyFailed, err := s.reconcileVerification(reqCtx, cli, opsRes)
+	if err != nil {
+		return "", 0, err
+	}
+	if verifyFailed {
+		return failed()
+	}
+	if !verified {
+		return syncProgress(appsv1alpha1.OpsRunningPhase, 5*time.Second)
+	}
+	completedCount++
+
+	// 5. keep the source Component until the cutover is confirmed.
+	if opsRequest.Annotations[constant.OpsCutoverConfirmedAnnotationKey] != "true" {
+		return syncProgress(appsv1alpha1.OpsWaitingForConfirmPhase, 0)
+	}
+
+	// 6. cut over to the sharding by removing the source Component.
+	cutover, err := s.reconcileCutover(reqCtx, cli, opsRes)
+	if err != nil {
+		return "", 0, err
+	}
+	if !cutover {
+		return syncProgress(appsv1alpha1.OpsRunningPhase, 5*time.Second)
+	}
+	completedCount++
+	return syncProgress(appsv1alpha1.OpsSucceedPhase, 0)
+}
+
+// SaveLastConfiguration records last configuration to the OpsRequest.status.lastConfiguration
+func (s shardingConversionOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return nil
+}
+
+// validateParameters validates the parameters of the redistribution actions with the parametersSchema of the OpsDefinition.
+func (s shardingConversionOpsHandler) validateParameters(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	conversion *appsv1alpha1.ShardingConversion) error {
+	opsDef := &appsv1alpha1.OpsDefinition{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: conversion.RedistributionOpsDefinitionName}, opsDef); err != nil {
+		if apierrors.IsNotFound(err) {
+			return intctrlutil.NewFatalError(err.Error())
+		}
+		return err
+	}
+	if len(opsDef.Spec.Actions) == 0 {
+		return intctrlutil.NewFatalError(fmt.Sprintf(`the OpsDefinition "%s" has no actions to redistribute the data`, opsDef.Name))
+	}
+	if opsDef.Spec.ParametersSchema == nil || opsDef.Spec.ParametersSchema.OpenAPIV3Schema == nil {
+		return nil
+	}
+	schema := opsDef.Spec.ParametersSchema.OpenAPIV3Schema
+	params, err := common.CoverStringToInterfaceBySchemaType(schema, covertParametersToMap(conversion.Parameters))
+	if err != nil {
+		return intctrlutil.NewFatalError(err.Error())
+	}
+	if err = common.ValidateDataWithSchema(schema, params); err != nil {
+		return intctrlutil.NewFatalError(err.Error())
+	}
+	return nil
+}
+
+// reconcileProvision checks if the expected number of shards are created and running.
+func (s shardingConversionOpsHandler) reconcileProvision(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource) (bool, error) {
+	conversion := opsRes.OpsRequest.Spec.ShardingConversion
+	objectKey := getProgressObjectKey(shardingKind, conversion.ShardingName)
+	compStatus := opsRes.OpsRequest.Status.Components[conversion.ComponentName]
+	defer func() {
+		opsRes.OpsRequest.Status.Components[conversion.ComponentName] = compStatus
+	}()
+	if progressDetail := findStatusProgressDetail(compStatus.ProgressDetails, objectKey); progressDetail != nil &&
+		progressDetail.Status == appsv1alpha1.SucceedProgressStatus {
+		return true, nil
+	}
+	shardComps, err := intctrlutil.ListShardingComponents(reqCtx.Ctx, cli, opsRes.Cluster, conversion.ShardingName)
+	if err != nil {
+		return false, err
+	}
+	progressDetail := appsv1alpha1.ProgressStatusDetail{ObjectKey: objectKey}
+	if !isShardingRunning(shardComps, conversion.Shards) {
+		progressDetail.SetStatusAndMessage(appsv1alpha1.ProcessingProgressStatus,
+			getProgressProcessingMessage(shardingConversionProvisionMessageKey, objectKey, conversion.ComponentName))
+		setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, progressDetail)
+		return false, nil
+	}
+	progressDetail.SetStatusAndMessage(appsv1alpha1.SucceedProgressStatus,
+		getProgressSucceedMessage(shardingConversionProvisionMessageKey, objectKey, conversion.ComponentName))
+	setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, progressDetail)
+	return true, nil
+}
+
+// reconcileFreeze sets the writable instances of the source Component read-only through the agent.
+// It's checked on each reconciliation before the cutover, in case the writable role is switched to another instance.
+func (s shardingConversionOpsHandler) reconcileFreeze(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource) (bool, error) {
+	conversion := opsRes.OpsRequest.Spec.ShardingConversion
+	compSpec := opsRes.Cluster.Spec.GetComponentByName(conversion.ComponentName)
+	if compSpec == nil {
+		// the source Component has been removed by the cutover.
+		return true, nil
+	}
+	compStatus := opsRes.OpsRequest.Status.Components[conversion.ComponentName]
+	defer func() {
+		opsRes.OpsRequest.Status.Components[conversion.ComponentName] = compStatus
+	}()
+	pods, err := getWritablePods(reqCtx, cli, opsRes, compSpec, conversion.ComponentName)
+	if err != nil || len(pods) == 0 {
+		return false, err
+	}
+	frozen := true
+	for _, pod := range pods {
+		objectKey := getProgressObjectKey(constant.PodKind, pod.Name)
+		if progressDetail := findStatusProgressDetail(compStatus.ProgressDetails, objectKey); progressDetail != nil &&
+			progressDetail.Status == appsv1alpha1.SucceedProgressStatus {
+			continue
+		}
+		progressDetail := appsv1alpha1.ProgressStatusDetail{ObjectKey: objectKey}
+		if err = setInstanceReadonly(reqCtx, pod, true); err != nil {
+			frozen = false
+			progressDetail.SetStatusAndMessage(appsv1alpha1.ProcessingProgressStatus,
+				getProgressFailedMessage(shardingConversionFreezeMessageKey, objectKey, conversion.ComponentName, err.Error()))
+		} else {
+			progressDetail.SetStatusAndMessage(appsv1alpha1.SucceedProgressStatus,
+				getProgressSucceedMessage(shardingConversionFreezeMessageKey, objectKey, conversion.ComponentName))
+		}
+		setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, progressDetail)
+	}
+	return frozen, nil
+}
+
+// reconcileRedistribution runs the actions of the redistribution OpsDefinition against the sharding.
+func (s shardingConversionOpsHandler) reconcileRedistribution(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource) (*WorkflowStatus, error) {
+	conversion := opsRes.OpsRequest.Spec.ShardingConversion
+	compStatus := opsRes.OpsRequest.Status.Components[conversion.ShardingName]
+	if len(compStatus.ProgressDetails) == 0 {
+		for i := range opsRes.OpsDef.Spec.Actions {
+			compStatus.ProgressDetails = append(compStatus.ProgressDetails, appsv1alpha1.ProgressStatusDetail{
+				Status:     appsv1alpha1.PendingProgressStatus,
+				ActionName: opsRes.OpsDef.Spec.Actions[i].Name,
+			})
+		}
+		opsRes.OpsRequest.Status.Components[conversion.ShardingName] = compStatus
+	}
+	return NewWorkflowContext(reqCtx, cli, opsRes).Run(buildRedistributionComponent(conversion))
+}
+
+// reconcileVerification compares the count returned by the verification query on the source Component
+// with the sum of the counts of the shards. It returns whether the data is verified and whether the verification fails,
+// the query is retried if it can't be executed, e.g. the agent is not available.
+func (s shardingConversionOpsHandler) reconcileVerification(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource) (bool, bool, error) {
+	var (
+		conversion = opsRes.OpsRequest.Spec.ShardingConversion
+		objectKey  = getProgressObjectKey(verificationKind, conversion.ShardingName)
+		compStatus = opsRes.OpsRequest.Status.Components[conversion.ComponentName]
+	)
+	defer func() {
+		opsRes.OpsRequest.Status.Components[conversion.ComponentName] = compStatus
+	}()
+	if progressDetail := findStatusProgressDetail(compStatus.ProgressDetails, objectKey); progressDetail != nil &&
+		isCompletedProgressStatus(progressDetail.Status) {
+		return progressDetail.Status == appsv1alpha1.SucceedProgressStatus, progressDetail.Status == appsv1alpha1.FailedProgressStatus, nil
+	}
+	progressDetail := appsv1alpha1.ProgressStatusDetail{ObjectKey: objectKey}
+	setProgress := func(status appsv1alpha1.ProgressStatus, message string) {
+		progressDetail.SetStatusAndMessage(status, message)
+		setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, progressDetail)
+	}
+	retry := func(message string) (bool, bool, error) {
+		setProgress(appsv1alpha1.ProcessingProgressStatus,
+			getProgressFailedMessage(shardingConversionVerifyMessageKey, objectKey, conversion.ComponentName, message))
+		return false, false, nil
+	}
+	sourceCount, err := s.queryCount(reqCtx, cli, opsRes, conversion.ComponentName, conversion.ComponentName)
+	if err != nil {
+		return retry(err.Error())
+	}
+	shardNames, err := getFullComponentNames(reqCtx, cli, opsRes.Cluster, conversion.ShardingName)
+	if err != nil {
+		return false, false, err
+	}
+	if len(shardNames) != int(conversion.Shards) {
+		return retry(fmt.Sprintf("expected %d shards, but got %d", conversion.Shards, len(shardNames)))
+	}
+	var shardsCount int64
+	for _, shardName := range shardNames {
+		count, err := s.queryCount(reqCtx, cli, opsRes, conversion.ShardingName, shardName)
+		if err != nil {
+			return retry(err.Error())
+		}
+		shardsCount += count
+	}
+	if sourceCount != shardsCount {
+		setProgress(appsv1alpha1.FailedProgressStatus, getProgressFailedMessage(shardingConversionVerifyMessageKey, objectKey,
+			conversion.ComponentName, fmt.Sprintf("the count of the source component is %d, but the sum of the shards is %d", sourceCount, shardsCount)))
+		return false, true, nil
+	}
+	setProgress(appsv1alpha1.SucceedProgressStatus,
+		getProgressSucceedMessage(shardingConversionVerifyMessageKey, objectKey, conversion.ComponentName))
+	return true, false, nil
+}
+
+// queryCount executes the verification query on the writable instance of the component.
+func (s shardingConversionOpsHandler) queryCount(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	specName, fullCompName string) (int64, error) {
+	compSpec := opsRes.Cluster.Spec.GetComponentByName(specName)
+	if compSpec == nil {
+		sharding := opsRes.Cluster.Spec.GetShardingByName(specName)
+		if sharding == nil {
+			return 0, fmt.Errorf(`component "%s" not found in cluster`, specName)
+		}
+		compSpec = &sharding.Template
+	}
+	pods, err := getWritablePods(reqCtx, cli, opsRes, compSpec, fullCompName)
+	if err != nil {
+		return 0, err
+	}
+	if len(pods) == 0 {
+		return 0, fmt.Errorf(`no writable instance of component "%s" is found`, fullCompName)
+	}
+	lorryCli, err := lorry.NewClient(*pods[0])
+	if err != nil {
+		return 0, err
+	}
+	if intctrlutil.IsNil(lorryCli) {
+		return 0, fmt.Errorf("the agent of pod %s is not available", pods[0].Name)
+	}
+	result, err := lorryCli.Query(reqCtx.Ctx, opsRes.OpsRequest.Spec.ShardingConversion.VerificationQuery, shardingConversionQueryTimeout)
+	if err != nil {
+		return 0, err
+	}
+	return parseVerificationCount(result)
+}
+
+// rollback removes the sharding from the Cluster and resumes the writes of the source Component.
+func (s shardingConversionOpsHandler) rollback(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource) error {
+	var (
+		conversion = opsRes.OpsRequest.Spec.ShardingConversion
+		cluster    = opsRes.Cluster
+	)
+	compSpec := cluster.Spec.GetComponentByName(conversion.ComponentName)
+	if compSpec == nil {
+		// the source Component has been removed by the cutover.
+		return nil
+	}
+	pods, err := getWritablePods(reqCtx, cli, opsRes, compSpec, conversion.ComponentName)
+	if err != nil {
+		return err
+	}
+	for _, pod := range pods {
+		if err = setInstanceReadonly(reqCtx, pod, false); err != nil {
+			return err
+		}
+	}
+	if cluster.Spec.GetShardingByName(conversion.ShardingName) == nil {
+		return nil
+	}
+	patch := client.MergeFromWithOptions(cluster.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	var shardingSpecs []appsv1alpha1.ShardingSpec
+	for _, v := range cluster.Spec.ShardingSpecs {
+		if v.Name != conversion.ShardingName {
+			shardingSpecs = append(shardingSpecs, v)
+		}
+	}
+	cluster.Spec.ShardingSpecs = shardingSpecs
+	if err = cli.Patch(reqCtx.Ctx, cluster, patch); err != nil {
+		return err
+	}
+	opsRes.Recorder.Eventf(opsRes.OpsRequest, corev1.EventTypeNormal, "ShardingRemoved",
+		"Remove the sharding %s and resume the writes of the component %s", conversion.ShardingName, conversion.ComponentName)
+	return nil
+}
+
+// reconcileCancel waits for the shards to be deleted after the OpsRequest is cancelled.
+func (s shardingConversionOpsHandler) reconcileCancel(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	shardComps, err := intctrlutil.ListShardingComponents(reqCtx.Ctx, cli, opsRes.Cluster, opsRes.OpsRequest.Spec.ShardingConversion.ShardingName)
+	if err != nil {
+		return "", 0, err
+	}
+	if len(shardComps) > 0 {
+		return appsv1alpha1.OpsRunningPhase, 5 * time.Second, nil
+	}
+	return appsv1alpha1.OpsSucceedPhase, 0, nil
+}
+
+// reconcileCutover removes the source Component from the Cluster and waits for it to be deleted.
+func (s shardingConversionOpsHandler) reconcileCutover(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource) (bool, error) {
+	var (
+		conversion = opsRes.OpsRequest.Spec.ShardingConversion
+		cluster    = opsRes.Cluster
+		objectKey  = getProgressObjectKey(appsv1alpha1.ComponentKind, conversion.ComponentName)
+		compStatus = opsRes.OpsRequest.Status.Components[conversion.ComponentName]
+	)
+	defer func() {
+		opsRes.OpsRequest.Status.Components[conversion.ComponentName] = compStatus
+	}()
+	if cluster.Spec.GetComponentByName(conversion.ComponentName) != nil {
+		patch := client.MergeFromWithOptions(cluster.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		var compSpecs []appsv1alpha1.ClusterComponentSpec
+		for _, v := range cluster.Spec.ComponentSpecs {
+			if v.Name != conversion.ComponentName {
+				compSpecs = append(compSpecs, v)
+			}
+		}
+		cluster.Spec.ComponentSpecs = compSpecs
+		if err := cli.Patch(reqCtx.Ctx, cluster, patch); err != nil {
+			return false, err
+		}
+	}
+	progressDetail := appsv1alpha1.ProgressStatusDetail{ObjectKey: objectKey}
+	comp := &appsv1alpha1.Component{}
+	compKey := types.NamespacedName{
+		Namespace: cluster.Namespace,
+		Name:      constant.GenerateClusterComponentName(cluster.Name, conversion.ComponentName),
+	}
+	err := cli.Get(reqCtx.Ctx, compKey, comp)
+	if err == nil {
+		progressDetail.SetStatusAndMessage(appsv1alpha1.ProcessingProgressStatus,
+			getProgressProcessingMessage(shardingConversionCutoverMessageKey, objectKey, conversion.ComponentName))
+		setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, progressDetail)
+		return false, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return false, err
+	}
+	progressDetail.SetStatusAndMessage(appsv1alpha1.SucceedProgressStatus,
+		getProgressSucceedMessage(shardingConversionCutoverMessageKey, objectKey, conversion.ComponentName))
+	setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, progressDetail)
+	return true, nil
+}
+
+// isShardingRunning checks if the expected number of shard components are all running.
+func isShardingRunning(shardComps []appsv1alpha1.Component, shards int32) bool {
+	var runningCount int32
+	for _, comp := range shardComps {
+		if comp.DeletionTimestamp != nil {
+			continue
+		}
+		if comp.Status.Phase != appsv1alpha1.RunningClusterCompPhase {
+			return false
+		}
+		runningCount++
+	}
+	return runningCount == shards
+}
+
+// buildRedistributionComponent builds the custom ops item targeting the sharding,
+// in which the source Component and the number of shards are passed to the actions as parameters.
+func buildRedistributionComponent(conversion *appsv1alpha1.ShardingConversion) *appsv1alpha1.CustomOpsComponent {
+	params := append([]appsv1alpha1.Parameter{}, conversion.Parameters...)
+	params = append(params,
+		appsv1alpha1.Parameter{Name: kbEnvConversionSourceComponent, Value: conversion.ComponentName},
+		appsv1alpha1.Parameter{Name: kbEnvConversionShards, Value: strconv.Itoa(int(conversion.Shards))},
+	)
+	return &appsv1alpha1.CustomOpsComponent{
+		ComponentOps: appsv1alpha1.ComponentOps{ComponentName: conversion.ShardingName},
+		Parameters:   params,
+	}
+}
+
+// getWritablePods returns the instances of the component with the writable roles,
+// all the instances are writable if the component has no roles.
+func getWritablePods(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	compSpec *appsv1alpha1.ClusterComponentSpec,
+	fullCompName string) ([]*corev1.Pod, error) {
+	writableRoles, err := getWritableRoles(reqCtx, cli, opsRes, compSpec, fullCompName)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := intctrlcomp.ListOwnedPods(reqCtx.Ctx, cli, opsRes.Cluster.Namespace, opsRes.Cluster.Name, fullCompName)
+	if err != nil {
+		return nil, err
+	}
+	var writablePods []*corev1.Pod
+	for _, pod := range pods {
+		if writableRoles.Len() == 0 || writableRoles.Has(pod.Labels[constant.RoleLabelKey]) {
+			writablePods = append(writablePods, pod)
+		}
+	}
+	return writablePods, nil
+}
+
+// setInstanceReadonly sets the instance read-only or read-write through the agent of the pod.
+func setInstanceReadonly(reqCtx intctrlutil.RequestCtx, pod *corev1.Pod, readonly bool) error {
+	lorryCli, err := lorry.NewClient(*pod)
+	if err != nil {
+		return err
+	}
+	if intctrlutil.IsNil(lorryCli) {
+		return fmt.Errorf("the agent of pod %s is not available", pod.Name)
+	}
+	if readonly {
+		return lorryCli.Lock(reqCtx.Ctx)
+	}
+	return lorryCli.Unlock(reqCtx.Ctx)
+}
+
+// parseVerificationCount parses the count from the result of the verification query,
+// which is the JSON array of the rows returned by the agent.
+func parseVerificationCount(result string) (int64, error) {
+	var rows []map[string]any
+	decoder := json.NewDecoder(bytes.NewBufferString(result))
+	decoder.UseNumber()
+	if err := decoder.Decode(&rows); err != nil {
+		return 0, fmt.Errorf("failed to parse the result of the verification query: %s", err.Error())
+	}
+	if len(rows) != 1 || len(rows[0]) != 1 {
+		return 0, fmt.Errorf("the verification query is expected to return a single row with a single count, but got: %s", result)
+	}
+	for _, v := range rows[0] {
+		count, err := strconv.ParseInt(fmt.Sprint(v), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("the result of the verification query is not a count: %s", result)
+		}
+		return count, nil
+	}
+	return 0, nil
+}
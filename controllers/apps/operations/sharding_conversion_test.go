@@ -0,0 +1,103 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+var _ = Describe("ShardingConversion OpsRequest", func() {
+	Context("sharding readiness", func() {
+		newShardComp := func(phase appsv1alpha1.ClusterComponentPhase) appsv1alpha1.Component {
+			return appsv1alpha1.Component{Status: appsv1alpha1.ComponentStatus{Phase: phase}}
+		}
+
+		It("waits for all shards to be created and running", func() {
+			shardComps := []appsv1alpha1.Component{
+				newShardComp(appsv1alpha1.RunningClusterCompPhase),
+				newShardComp(appsv1alpha1.RunningClusterCompPhase),
+			}
+			Expect(isShardingRunning(shardComps, 3)).Should(BeFalse())
+			Expect(isShardingRunning(shardComps, 2)).Should(BeTrue())
+
+			shardComps = append(shardComps, newShardComp(appsv1alpha1.CreatingClusterCompPhase))
+			Expect(isShardingRunning(shardComps, 3)).Should(BeFalse())
+		})
+
+		It("ignores the deleting shards", func() {
+			deleting := newShardComp(appsv1alpha1.DeletingClusterCompPhase)
+			now := metav1.Now()
+			deleting.DeletionTimestamp = &now
+			shardComps := []appsv1alpha1.Component{newShardComp(appsv1alpha1.RunningClusterCompPhase), deleting}
+			Expect(isShardingRunning(shardComps, 1)).Should(BeTrue())
+		})
+	})
+
+	Context("redistribution parameters", func() {
+		It("passes the source component and the number of shards to the actions", func() {
+			conversion := &appsv1alpha1.ShardingConversion{
+				ComponentOps:                    appsv1alpha1.ComponentOps{ComponentName: defaultCompName},
+				ShardingName:                    "shard",
+				Shards:                          3,
+				RedistributionOpsDefinitionName: "redistribute",
+				Parameters:                      []appsv1alpha1.Parameter{{Name: "batchSize", Value: "1000"}},
+			}
+			customComp := buildRedistributionComponent(conversion)
+			Expect(customComp.ComponentName).Should(Equal("shard"))
+			Expect(customComp.Parameters).Should(Equal([]appsv1alpha1.Parameter{
+				{Name: "batchSize", Value: "1000"},
+				{Name: kbEnvConversionSourceComponent, Value: defaultCompName},
+				{Name: kbEnvConversionShards, Value: "3"},
+			}))
+			Expect(conversion.Parameters).Should(HaveLen(1))
+		})
+	})
+
+	Context("data verification", func() {
+		It("parses the count from the result of the verification query", func() {
+			count, err := parseVerificationCount(`[{"COUNT(*)":10000000}]`)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(count).Should(Equal(int64(10000000)))
+
+			count, err = parseVerificationCount(`[{"count":"42"}]`)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(count).Should(Equal(int64(42)))
+		})
+
+		It("rejects the result which is not a single count", func() {
+			for _, result := range []string{
+				``,
+				`[]`,
+				`[{"count":1},{"count":2}]`,
+				`[{"count":1,"sum":2}]`,
+				`[{"name":"orders"}]`,
+				`[{"count":1.5}]`,
+			} {
+				_, err := parseVerificationCount(result)
+				Expect(err).Should(HaveOccurred(), result)
+			}
+		})
+	})
+})
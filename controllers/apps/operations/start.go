@@ -36,7 +36,8 @@ var _ OpsHandler = StartOpsHandler{}
 
 func init() {
 	stopBehaviour := OpsBehaviour{
-		FromClusterPhases: []appsv1alpha1.ClusterPhase{appsv1alpha1.StoppedClusterPhase},
+		// the cluster is running if only part of its components are stopped.
+		FromClusterPhases: []appsv1alpha1.ClusterPhase{appsv1alpha1.StoppedClusterPhase, appsv1alpha1.RunningClusterPhase},
 		ToClusterPhase:    appsv1alpha1.UpdatingClusterPhase,
 		QueueByCluster:    true,
 		OpsHandler:        StartOpsHandler{},
@@ -55,15 +56,23 @@ func (start StartOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCt
 func (start StartOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
 	var (
 		cluster   = opsRes.Cluster
-		startComp = func(compSpec *appsv1alpha1.ClusterComponentSpec) {
+		startComp = func(compSpec *appsv1alpha1.ClusterComponentSpec, _ ComponentOpsInterface) error {
 			compSpec.Stop = nil
+			return nil
 		}
 	)
+	if len(opsRes.OpsRequest.Spec.StartList) > 0 {
+		compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.StartList)
+		if err := compOpsHelper.updateClusterComponentsAndShardings(cluster, startComp); err != nil {
+			return err
+		}
+		return cli.Update(reqCtx.Ctx, cluster)
+	}
 	for i := range cluster.Spec.ComponentSpecs {
-		startComp(&cluster.Spec.ComponentSpecs[i])
+		_ = startComp(&cluster.Spec.ComponentSpecs[i], nil)
 	}
 	for i := range cluster.Spec.ShardingSpecs {
-		startComp(&cluster.Spec.ShardingSpecs[i].Template)
+		_ = startComp(&cluster.Spec.ShardingSpecs[i].Template, nil)
 	}
 	return cli.Update(reqCtx.Ctx, cluster)
 }
@@ -84,7 +93,7 @@ func (start StartOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli
 		}
 		return handleComponentProgressForScalingReplicas(reqCtx, cli, opsRes, pgRes, compStatus)
 	}
-	compOpsHelper := newComponentOpsHelper([]appsv1alpha1.ComponentOps{})
+	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.StartList)
 	return compOpsHelper.reconcileActionWithComponentOps(reqCtx, cli, opsRes, "start", handleComponentProgress)
 }
 
@@ -20,13 +20,17 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"fmt"
 	"time"
 
 	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlcomp "github.com/apecloud/kubeblocks/pkg/controller/component"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
@@ -40,7 +44,13 @@ func init() {
 		FromClusterPhases: append(appsv1alpha1.GetClusterUpRunningPhases(), appsv1alpha1.UpdatingClusterPhase),
 		ToClusterPhase:    appsv1alpha1.StoppingClusterPhase,
 		QueueByCluster:    true,
-		OpsHandler:        StopOpsHandler{},
+		ConflictPolicies: map[appsv1alpha1.OpsType]OpsConflictPolicy{
+			appsv1alpha1.HorizontalScalingType: ConflictPolicyAbort,
+			appsv1alpha1.StartType:             ConflictPolicyAbort,
+			appsv1alpha1.RestartType:           ConflictPolicyAbort,
+			appsv1alpha1.VerticalScalingType:   ConflictPolicyAbort,
+		},
+		OpsHandler: StopOpsHandler{},
 	}
 
 	opsMgr := GetOpsManager()
@@ -65,23 +75,30 @@ func (stop StopOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Clie
 	}
 
 	// abort earlier running vertical scaling opsRequest.
-	if err := abortEarlierOpsRequestWithSameKind(reqCtx, cli, opsRes, []appsv1alpha1.OpsType{appsv1alpha1.HorizontalScalingType,
-		appsv1alpha1.StartType, appsv1alpha1.RestartType, appsv1alpha1.VerticalScalingType},
+	if err := abortConflictingOpsRequests(reqCtx, cli, opsRes,
 		func(earlierOps *appsv1alpha1.OpsRequest) (bool, error) {
 			return true, nil
 		}); err != nil {
 		return err
 	}
 
-	stopComp := func(compSpec *appsv1alpha1.ClusterComponentSpec) {
+	stopComp := func(compSpec *appsv1alpha1.ClusterComponentSpec, _ ComponentOpsInterface) error {
 		compSpec.Stop = func() *bool { b := true; return &b }()
+		return nil
 	}
 
+	if len(opsRes.OpsRequest.Spec.StopList) > 0 {
+		compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.StopList)
+		if err := compOpsHelper.updateClusterComponentsAndShardings(cluster, stopComp); err != nil {
+			return err
+		}
+		return cli.Update(reqCtx.Ctx, cluster)
+	}
 	for i := range cluster.Spec.ComponentSpecs {
-		stopComp(&cluster.Spec.ComponentSpecs[i])
+		_ = stopComp(&cluster.Spec.ComponentSpecs[i], nil)
 	}
 	for i := range cluster.Spec.ShardingSpecs {
-		stopComp(&cluster.Spec.ShardingSpecs[i].Template)
+		_ = stopComp(&cluster.Spec.ShardingSpecs[i].Template, nil)
 	}
 	return cli.Update(reqCtx.Ctx, cluster)
 }
@@ -106,7 +123,7 @@ func (stop StopOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli cl
 		}
 		return expectProgressCount, completedCount, nil
 	}
-	compOpsHelper := newComponentOpsHelper([]appsv1alpha1.ComponentOps{})
+	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.StopList)
 	return compOpsHelper.reconcileActionWithComponentOps(reqCtx, cli, opsRes, "stop", handleComponentProgress)
 }
 
@@ -114,3 +131,64 @@ func (stop StopOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli cl
 func (stop StopOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
 	return nil
 }
+
+// ReconcileStoppedPeriod records the stopped period of the succeeded Stop OpsRequest, and creates the Start OpsRequest
+// for the stopped Components once `spec.autoStartAfterSeconds` elapses.
+// It returns the duration to requeue until the Components are to be started automatically.
+func ReconcileStoppedPeriod(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (time.Duration, error) {
+	opsRequest := opsRes.OpsRequest
+	if opsRequest.Spec.Type != appsv1alpha1.StopType || opsRequest.Status.Phase != appsv1alpha1.OpsSucceedPhase {
+		return 0, nil
+	}
+	if opsRequest.Status.StoppedPeriod == nil {
+		opsDeepCopy := opsRequest.DeepCopy()
+		stoppedPeriod := &appsv1alpha1.OpsStoppedPeriod{StopTimestamp: opsRequest.Status.CompletionTimestamp}
+		if opsRequest.Spec.AutoStartAfterSeconds != nil {
+			autoStartTime := stoppedPeriod.StopTimestamp.Add(time.Duration(*opsRequest.Spec.AutoStartAfterSeconds) * time.Second)
+			stoppedPeriod.AutoStartTimestamp = &metav1.Time{Time: autoStartTime}
+		}
+		opsRequest.Status.StoppedPeriod = stoppedPeriod
+		if err := cli.Status().Patch(reqCtx.Ctx, opsRequest, client.MergeFrom(opsDeepCopy)); err != nil {
+			return 0, err
+		}
+	}
+	if !isAutoStartPending(opsRequest) {
+		return 0, nil
+	}
+	if now := time.Now(); now.Before(opsRequest.Status.StoppedPeriod.AutoStartTimestamp.Time) {
+		return opsRequest.Status.StoppedPeriod.AutoStartTimestamp.Sub(now), nil
+	}
+
+	startOps := &appsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: opsRequest.Namespace,
+			Name:      fmt.Sprintf("%s-auto-start", opsRequest.Name),
+			Labels: map[string]string{
+				constant.AppInstanceLabelKey:    opsRequest.Spec.GetClusterName(),
+				constant.OpsRequestTypeLabelKey: string(appsv1alpha1.StartType),
+			},
+		},
+		Spec: appsv1alpha1.OpsRequestSpec{
+			ClusterName:            opsRequest.Spec.GetClusterName(),
+			Type:                   appsv1alpha1.StartType,
+			TTLSecondsAfterSucceed: opsRequest.Spec.TTLSecondsAfterSucceed,
+			SpecificOpsRequest: appsv1alpha1.SpecificOpsRequest{
+				StartList: slices.Clone(opsRequest.Spec.StopList),
+			},
+		},
+	}
+	if err := cli.Create(reqCtx.Ctx, startOps); err != nil && !apierrors.IsAlreadyExists(err) {
+		return 0, err
+	}
+	opsRes.Recorder.Eventf(opsRequest, corev1.EventTypeNormal, "AutoStart",
+		"the stopped components are started automatically by the OpsRequest %s", startOps.Name)
+	opsDeepCopy := opsRequest.DeepCopy()
+	opsRequest.Status.StoppedPeriod.StartOpsName = startOps.Name
+	return 0, cli.Status().Patch(reqCtx.Ctx, opsRequest, client.MergeFrom(opsDeepCopy))
+}
+
+// isAutoStartPending checks whether the Components stopped by the OpsRequest are waiting to be started automatically.
+func isAutoStartPending(opsRequest *appsv1alpha1.OpsRequest) bool {
+	stoppedPeriod := opsRequest.Status.StoppedPeriod
+	return stoppedPeriod != nil && stoppedPeriod.AutoStartTimestamp != nil && stoppedPeriod.StartOpsName == ""
+}
@@ -20,12 +20,17 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	"github.com/apecloud/kubeblocks/pkg/generics"
 	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
@@ -87,5 +92,57 @@ var _ = Describe("Stop OpsRequest", func() {
 			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
 			Expect(err).Should(BeNil())
 		})
+
+		It("Test stop OpsRequest with the auto start", func() {
+			reqCtx := intctrlutil.RequestCtx{Ctx: ctx}
+			opsRes, _, _ := initOperationsResources(compDefName, clusterName)
+			testapps.MockInstanceSetComponent(&testCtx, clusterName, defaultCompName)
+			By("create Stop opsRequest for the specified component")
+			ops := testapps.NewOpsRequestObj("stop-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.StopType)
+			ops.Spec.StopList = []appsv1alpha1.ComponentOps{{ComponentName: defaultCompName}}
+			ops.Spec.AutoStartAfterSeconds = pointer.Int32(60)
+			opsRes.OpsRequest = testapps.CreateOpsRequest(ctx, testCtx, ops)
+			opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsPendingPhase
+
+			By("expect for only the specified component to be stopped")
+			_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(appsv1alpha1.OpsCreatingPhase))
+			_, err = GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			for _, v := range opsRes.Cluster.Spec.ComponentSpecs {
+				if v.Name == defaultCompName {
+					Expect(v.Stop).ShouldNot(BeNil())
+					Expect(*v.Stop).Should(BeTrue())
+				} else {
+					Expect(v.Stop == nil || !*v.Stop).Should(BeTrue())
+				}
+			}
+
+			By("mock the opsRequest succeed before the auto start time")
+			Expect(testapps.ChangeObjStatus(&testCtx, opsRes.OpsRequest, func() {
+				opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsSucceedPhase
+				opsRes.OpsRequest.Status.CompletionTimestamp = metav1.NewTime(time.Now().Add(-2 * time.Minute))
+			})).Should(Succeed())
+
+			By("expect for the Start opsRequest to be created")
+			requeueAfter, err := ReconcileStoppedPeriod(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(requeueAfter).Should(BeZero())
+			startOpsKey := client.ObjectKey{Name: opsRes.OpsRequest.Name + "-auto-start", Namespace: testCtx.DefaultNamespace}
+			Eventually(testapps.CheckObj(&testCtx, startOpsKey, func(g Gomega, startOps *appsv1alpha1.OpsRequest) {
+				g.Expect(startOps.Spec.Type).Should(Equal(appsv1alpha1.StartType))
+				g.Expect(startOps.Spec.StartList).Should(Equal(ops.Spec.StopList))
+			})).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest), func(g Gomega, stopOps *appsv1alpha1.OpsRequest) {
+				g.Expect(stopOps.Status.StoppedPeriod).ShouldNot(BeNil())
+				g.Expect(stopOps.Status.StoppedPeriod.AutoStartTimestamp).ShouldNot(BeNil())
+				g.Expect(stopOps.Status.StoppedPeriod.StartOpsName).Should(Equal(startOpsKey.Name))
+			})).Should(Succeed())
+			Expect(isAutoStartPending(opsRes.OpsRequest)).Should(BeFalse())
+			testapps.ClearResources(&testCtx, generics.OpsRequestSignature, client.InNamespace(testCtx.DefaultNamespace),
+				client.MatchingLabels{constant.OpsRequestTypeLabelKey: string(appsv1alpha1.StartType)})
+		})
 	})
 })
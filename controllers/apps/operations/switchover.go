@@ -154,7 +154,9 @@ func doSwitchoverComponents(reqCtx intctrlutil.RequestCtx, cli client.Client, op
 				ProgressDetails: []appsv1alpha1.ProgressStatusDetail{},
 			}
 		}
-		if err := createSwitchoverJob(reqCtx, cli, opsRes.Cluster, synthesizedComp, &switchover); err != nil {
+		// jobName named with generation to distinguish different switchover jobs.
+		jobName := genSwitchoverJobName(opsRes.Cluster.Name, synthesizedComp.Name, opsRes.Cluster.Generation)
+		if err := createSwitchoverJob(reqCtx, cli, opsRes.Cluster, synthesizedComp, &switchover, jobName); err != nil {
 			return err
 		}
 	}
@@ -183,6 +185,7 @@ func handleSwitchoverProgress(reqCtx intctrlutil.RequestCtx, cli client.Client,
 	)
 	patch := client.MergeFrom(opsRequest.DeepCopy())
 	succeedJobs := make([]string, 0, len(opsRes.OpsRequest.Spec.SwitchoverList))
+	completedFallbackJobs := make([]string, 0)
 	for _, switchover := range opsRequest.Spec.SwitchoverList {
 		switchoverCondition := meta.FindStatusCondition(opsRes.OpsRequest.Status.Conditions, appsv1alpha1.ConditionTypeSwitchover)
 		if switchoverCondition == nil {
@@ -196,6 +199,19 @@ func handleSwitchoverProgress(reqCtx intctrlutil.RequestCtx, cli client.Client,
 			completedCount += 1
 			continue
 		}
+		// if the component is switching back to the original primary, check the fallback progress instead
+		fallbackJobName := genSwitchoverFallbackJobName(opsRes.Cluster.Name, switchover.ComponentName, switchoverCondition.ObservedGeneration)
+		if findStatusProgressDetail(opsRequest.Status.Components[switchover.ComponentName].ProgressDetails,
+			getProgressObjectKey(KBSwitchoverFallbackJobKey, fallbackJobName)) != nil {
+			if handleSwitchoverFallbackProgress(reqCtx, cli, opsRes, &switchover, switchoverCondition, fallbackJobName) {
+				// the switchover is failed even if switching back to the original primary is succeed
+				completedCount += 1
+				failedCount += 1
+				completedFallbackJobs = append(completedFallbackJobs, fallbackJobName)
+			}
+			continue
+		}
+
 		// check the current component switchoverJob whether succeed
 		jobName := genSwitchoverJobName(opsRes.Cluster.Name, switchover.ComponentName, switchoverCondition.ObservedGeneration)
 		checkJobProcessDetail := appsv1alpha1.ProgressStatusDetail{
@@ -203,7 +219,7 @@ func handleSwitchoverProgress(reqCtx intctrlutil.RequestCtx, cli client.Client,
 			Status:    appsv1alpha1.ProcessingProgressStatus,
 		}
 		if err = job.CheckJobSucceed(reqCtx.Ctx, cli, opsRes.Cluster, jobName); err != nil {
-			if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+			if intctrlutil.IsTerminalError(err) {
 				// means this job is failed
 				completedCount += 1
 				failedCount += 1
@@ -250,6 +266,38 @@ func handleSwitchoverProgress(reqCtx intctrlutil.RequestCtx, cli client.Client,
 			setComponentSwitchoverProgressDetails(reqCtx.Recorder, opsRequest, appsv1alpha1.UpdatingClusterCompPhase, checkRoleLabelProcessDetail, switchover.ComponentName)
 		}
 
+		// verify the new primary serves writes, or switch back to the original primary if the verification window expires
+		verifyProcessDetail := appsv1alpha1.ProgressStatusDetail{
+			ObjectKey: getProgressObjectKey(KBSwitchoverVerifyKey, switchover.ComponentName),
+			Status:    appsv1alpha1.ProcessingProgressStatus,
+			Message:   fmt.Sprintf("waiting for the new primary of component %s to serve writes after switchover", switchover.ComponentName),
+		}
+		if switchover.VerifyWindowSeconds > 0 && !isSwitchoverVerified(opsRequest, switchover.ComponentName, verifyProcessDetail.ObjectKey) {
+			writable, verifyErr := verifySwitchoverReadWrite(reqCtx.Ctx, cli, *synthesizedComp, &switchover)
+			if writable && switchover.MaxReplicationLag != nil {
+				writable, verifyErr = verifySwitchoverReplicationLag(reqCtx, cli, *synthesizedComp, *switchover.MaxReplicationLag)
+			}
+			if !writable {
+				if verifyErr != nil {
+					verifyProcessDetail.Message = fmt.Sprintf("verify the new primary of component %s failed: %s", switchover.ComponentName, verifyErr.Error())
+				}
+				setComponentSwitchoverProgressDetails(reqCtx.Recorder, opsRequest, appsv1alpha1.UpdatingClusterCompPhase, verifyProcessDetail, switchover.ComponentName)
+				verifyStartTime := findStatusProgressDetail(opsRequest.Status.Components[switchover.ComponentName].ProgressDetails, verifyProcessDetail.ObjectKey).StartTime
+				if time.Since(verifyStartTime.Time) < time.Duration(switchover.VerifyWindowSeconds)*time.Second {
+					continue
+				}
+				verifyProcessDetail.Status = appsv1alpha1.FailedProgressStatus
+				verifyProcessDetail.Message = fmt.Sprintf("the new primary of component %s is not verified within %ds, switch back to the original primary",
+					switchover.ComponentName, switchover.VerifyWindowSeconds)
+				setComponentSwitchoverProgressDetails(reqCtx.Recorder, opsRequest, appsv1alpha1.UpdatingClusterCompPhase, verifyProcessDetail, switchover.ComponentName)
+				startSwitchoverFallback(reqCtx, cli, opsRes, synthesizedComp, &switchover, switchoverCondition, fallbackJobName)
+				continue
+			}
+			verifyProcessDetail.Status = appsv1alpha1.SucceedProgressStatus
+			verifyProcessDetail.Message = fmt.Sprintf("the new primary of component %s serves writes after switchover", switchover.ComponentName)
+			setComponentSwitchoverProgressDetails(reqCtx.Recorder, opsRequest, appsv1alpha1.UpdatingClusterCompPhase, verifyProcessDetail, switchover.ComponentName)
+		}
+
 		// component switchover is successful
 		completedCount += 1
 		succeedJobs = append(succeedJobs, jobName)
@@ -280,11 +328,105 @@ func handleSwitchoverProgress(reqCtx intctrlutil.RequestCtx, cli client.Client,
 				return expectCount, completedCount, failedCount, err
 			}
 		}
+		for _, jobName := range completedFallbackJobs {
+			if err := job.CleanJobByName(reqCtx.Ctx, cli, opsRes.Cluster, jobName); client.IgnoreNotFound(err) != nil {
+				reqCtx.Log.Error(err, "clean switchover fallback job failed", "jobName", jobName)
+				return expectCount, completedCount, failedCount, err
+			}
+		}
 	}
 
 	return expectCount, completedCount, failedCount, nil
 }
 
+// isSwitchoverVerified checks whether the new primary of the component has been verified to serve writes.
+func isSwitchoverVerified(opsRequest *appsv1alpha1.OpsRequest, componentName, objectKey string) bool {
+	verifyProcessDetail := findStatusProgressDetail(opsRequest.Status.Components[componentName].ProgressDetails, objectKey)
+	return verifyProcessDetail != nil && verifyProcessDetail.Status == appsv1alpha1.SucceedProgressStatus
+}
+
+// startSwitchoverFallback creates the job to switch back to the original primary of the component.
+// if the job can not be created, the fallback progressDetail is marked as Failed.
+func startSwitchoverFallback(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	synthesizedComp *component.SynthesizedComponent,
+	switchover *appsv1alpha1.Switchover,
+	switchoverCondition *metav1.Condition,
+	fallbackJobName string) {
+	fallbackProcessDetail := appsv1alpha1.ProgressStatusDetail{
+		ObjectKey: getProgressObjectKey(KBSwitchoverFallbackJobKey, fallbackJobName),
+		Status:    appsv1alpha1.ProcessingProgressStatus,
+	}
+	oldPrimary, err := getSwitchoverOldPrimary(switchoverCondition, switchover.ComponentName)
+	if err == nil {
+		fallbackSwitchover := &appsv1alpha1.Switchover{
+			ComponentOps: switchover.ComponentOps,
+			InstanceName: oldPrimary,
+		}
+		err = createSwitchoverJob(reqCtx, cli, opsRes.Cluster, synthesizedComp, fallbackSwitchover, fallbackJobName)
+	}
+	if err != nil {
+		fallbackProcessDetail.Status = appsv1alpha1.FailedProgressStatus
+		fallbackProcessDetail.Message = fmt.Sprintf("switch back to the original primary of component %s failed: %s", switchover.ComponentName, err.Error())
+	} else {
+		fallbackProcessDetail.Message = fmt.Sprintf("switching back to the original primary %s by job %s", oldPrimary, fallbackJobName)
+	}
+	setComponentSwitchoverProgressDetails(reqCtx.Recorder, opsRes.OpsRequest, appsv1alpha1.UpdatingClusterCompPhase, fallbackProcessDetail, switchover.ComponentName)
+}
+
+// handleSwitchoverFallbackProgress handles the progressDetail of switching back to the original primary.
+// returns true if the fallback is completed, either succeed or failed.
+func handleSwitchoverFallbackProgress(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	switchover *appsv1alpha1.Switchover,
+	switchoverCondition *metav1.Condition,
+	fallbackJobName string) bool {
+	opsRequest := opsRes.OpsRequest
+	objectKey := getProgressObjectKey(KBSwitchoverFallbackJobKey, fallbackJobName)
+	if fallbackProcessDetail := findStatusProgressDetail(opsRequest.Status.Components[switchover.ComponentName].ProgressDetails,
+		objectKey); isCompletedProgressStatus(fallbackProcessDetail.Status) {
+		return true
+	}
+	fallbackProcessDetail := appsv1alpha1.ProgressStatusDetail{
+		ObjectKey: objectKey,
+		Status:    appsv1alpha1.ProcessingProgressStatus,
+	}
+	setFallbackProgress := func(phase appsv1alpha1.ClusterComponentPhase, status appsv1alpha1.ProgressStatus, message string) {
+		fallbackProcessDetail.Status = status
+		fallbackProcessDetail.Message = message
+		setComponentSwitchoverProgressDetails(reqCtx.Recorder, opsRequest, phase, fallbackProcessDetail, switchover.ComponentName)
+	}
+	if err := job.CheckJobSucceed(reqCtx.Ctx, cli, opsRes.Cluster, fallbackJobName); err != nil {
+		if intctrlutil.IsTerminalError(err) {
+			setFallbackProgress(appsv1alpha1.UpdatingClusterCompPhase, appsv1alpha1.FailedProgressStatus,
+				fmt.Sprintf("switchover fallback job %s is failed", fallbackJobName))
+			return true
+		}
+		return false
+	}
+	oldPrimary, err := getSwitchoverOldPrimary(switchoverCondition, switchover.ComponentName)
+	if err != nil {
+		setFallbackProgress(appsv1alpha1.UpdatingClusterCompPhase, appsv1alpha1.FailedProgressStatus, err.Error())
+		return true
+	}
+	compSpec := opsRes.Cluster.Spec.GetComponentByName(switchover.ComponentName)
+	synthesizedComp, err := buildSynthesizedComp(reqCtx, cli, opsRes, compSpec)
+	if err != nil {
+		return false
+	}
+	pod, err := getServiceableNWritablePod(reqCtx.Ctx, cli, *synthesizedComp)
+	if err != nil || pod.Name != oldPrimary {
+		setFallbackProgress(appsv1alpha1.UpdatingClusterCompPhase, appsv1alpha1.ProcessingProgressStatus,
+			fmt.Sprintf("waiting for the original primary %s to be the primary again", oldPrimary))
+		return false
+	}
+	setFallbackProgress(appsv1alpha1.RunningClusterCompPhase, appsv1alpha1.SucceedProgressStatus,
+		fmt.Sprintf("switched back to the original primary %s", oldPrimary))
+	return true
+}
+
 // setComponentSwitchoverProgressDetails sets component switchover progress details.
 func setComponentSwitchoverProgressDetails(recorder record.EventRecorder,
 	opsRequest *appsv1alpha1.OpsRequest,
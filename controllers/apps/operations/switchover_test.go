@@ -20,8 +20,10 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"encoding/json"
 	"fmt"
 
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -32,8 +34,10 @@ import (
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	"github.com/apecloud/kubeblocks/pkg/generics"
+	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
 	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
 )
 
@@ -212,5 +216,113 @@ var _ = Describe("", func() {
 			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
 			Expect(err).ShouldNot(HaveOccurred())
 		})
+
+		It("Test switchover verification and fallback helpers", func() {
+			By("get the original primary from the switchover condition")
+			msg, err := json.Marshal(map[string]SwitchoverMessage{
+				defaultCompName: {
+					Switchover: appsv1alpha1.Switchover{
+						ComponentOps: appsv1alpha1.ComponentOps{ComponentName: defaultCompName},
+						InstanceName: "pod-1",
+					},
+					OldPrimary: "pod-0",
+					Cluster:    clusterName,
+				},
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			switchoverCondition := appsv1alpha1.NewSwitchoveringCondition(1, string(msg))
+			oldPrimary, err := getSwitchoverOldPrimary(switchoverCondition, defaultCompName)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(oldPrimary).Should(Equal("pod-0"))
+			_, err = getSwitchoverOldPrimary(switchoverCondition, "unknown")
+			Expect(err).Should(HaveOccurred())
+
+			By("the fallback job name should be different from the switchover job name")
+			Expect(genSwitchoverFallbackJobName(clusterName, defaultCompName, 1)).
+				ShouldNot(Equal(genSwitchoverJobName(clusterName, defaultCompName, 1)))
+
+			By("check whether the new primary has been verified")
+			ops := testapps.NewOpsRequestObj("switchover-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.SwitchoverType)
+			objectKey := getProgressObjectKey(KBSwitchoverVerifyKey, defaultCompName)
+			ops.Status.Components = map[string]appsv1alpha1.OpsRequestComponentStatus{
+				defaultCompName: {
+					ProgressDetails: []appsv1alpha1.ProgressStatusDetail{
+						{ObjectKey: objectKey, Status: appsv1alpha1.ProcessingProgressStatus},
+					},
+				},
+			}
+			Expect(isSwitchoverVerified(ops, defaultCompName, objectKey)).Should(BeFalse())
+			ops.Status.Components[defaultCompName].ProgressDetails[0].Status = appsv1alpha1.SucceedProgressStatus
+			Expect(isSwitchoverVerified(ops, defaultCompName, objectKey)).Should(BeTrue())
+		})
+
+		It("Test switchover verification probes the target instance", func() {
+			mockController := gomock.NewController(GinkgoT())
+			lorryCli := lorry.NewMockClient(mockController)
+			lorry.SetMockClient(lorryCli, nil)
+			defer lorry.UnsetMockClient()
+
+			By("create the switchover target instance")
+			targetPod := testapps.NewPodFactory(testCtx.DefaultNamespace, fmt.Sprintf("%s-%s-1", clusterName, defaultCompName)).
+				AddContainer(corev1.Container{Name: "mock-container-name", Image: testapps.ApeCloudMySQLImage}).
+				AddAppInstanceLabel(clusterName).
+				AddAppComponentLabel(defaultCompName).
+				AddAppManagedByLabel().
+				Create(&testCtx).GetObject()
+			defer testapps.DeleteObject(&testCtx, client.ObjectKeyFromObject(targetPod), &corev1.Pod{})
+
+			synthesizedComp := component.SynthesizedComponent{
+				Namespace:   testCtx.DefaultNamespace,
+				ClusterName: clusterName,
+				Name:        defaultCompName,
+				Roles: []appsv1alpha1.ReplicaRole{
+					{Name: constant.Leader, Serviceable: true, Writable: true},
+					{Name: constant.Follower, Serviceable: true},
+				},
+			}
+			switchover := &appsv1alpha1.Switchover{
+				ComponentOps: appsv1alpha1.ComponentOps{ComponentName: defaultCompName},
+				InstanceName: targetPod.Name,
+			}
+
+			By("the target instance serves writes")
+			lorryCli.EXPECT().CheckReadWrite(gomock.Any()).Return(nil).Times(1)
+			writable, err := verifySwitchoverReadWrite(testCtx.Ctx, k8sClient, synthesizedComp, switchover)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(writable).Should(BeTrue())
+
+			By("check the replication lag of the secondaries")
+			reqCtx := intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
+			lorryCli.EXPECT().GetLag(gomock.Any()).Return(int64(10), nil).Times(2)
+			upToDate, err := verifySwitchoverReplicationLag(reqCtx, k8sClient, synthesizedComp, 5)
+			Expect(err).Should(HaveOccurred())
+			Expect(upToDate).Should(BeFalse())
+			upToDate, err = verifySwitchoverReplicationLag(reqCtx, k8sClient, synthesizedComp, 10)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(upToDate).Should(BeTrue())
+
+			By("the write probe on the target instance fails")
+			lorryCli.EXPECT().CheckReadWrite(gomock.Any()).Return(fmt.Errorf("read-only")).Times(1)
+			writable, err = verifySwitchoverReadWrite(testCtx.Ctx, k8sClient, synthesizedComp, switchover)
+			Expect(err).Should(HaveOccurred())
+			Expect(writable).Should(BeFalse())
+
+			By("fall back to the role reported by the agent if the probe is not implemented")
+			lorryCli.EXPECT().CheckReadWrite(gomock.Any()).Return(lorry.NotImplemented).Times(2)
+			lorryCli.EXPECT().GetRole(gomock.Any()).Return(constant.Follower, nil).Times(1)
+			writable, err = verifySwitchoverReadWrite(testCtx.Ctx, k8sClient, synthesizedComp, switchover)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(writable).Should(BeFalse())
+			lorryCli.EXPECT().GetRole(gomock.Any()).Return(constant.Leader, nil).Times(1)
+			writable, err = verifySwitchoverReadWrite(testCtx.Ctx, k8sClient, synthesizedComp, switchover)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(writable).Should(BeTrue())
+
+			By("the target instance does not exist")
+			switchover.InstanceName = fmt.Sprintf("%s-%s-9", clusterName, defaultCompName)
+			_, err = verifySwitchoverReadWrite(testCtx.Ctx, k8sClient, synthesizedComp, switchover)
+			Expect(err).Should(HaveOccurred())
+		})
 	})
 })
@@ -38,6 +38,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
 	"github.com/apecloud/kubeblocks/pkg/controller/job"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
 )
 
 // switchover constants
@@ -52,6 +53,8 @@ const (
 	KBSwitchoverJobContainerName  = "kb-switchover-job-container"
 	KBSwitchoverCheckJobKey       = "CheckJob"
 	KBSwitchoverCheckRoleLabelKey = "CheckRoleLabel"
+	KBSwitchoverVerifyKey         = "VerifyReadWrite"
+	KBSwitchoverFallbackJobKey    = "FallbackJob"
 
 	KBSwitchoverCandidateName = "KB_SWITCHOVER_CANDIDATE_NAME"
 	KBSwitchoverCandidateFqdn = "KB_SWITCHOVER_CANDIDATE_FQDN"
@@ -100,8 +103,9 @@ func createSwitchoverJob(reqCtx intctrlutil.RequestCtx,
 	cli client.Client,
 	cluster *appsv1alpha1.Cluster,
 	synthesizedComp *component.SynthesizedComponent,
-	switchover *appsv1alpha1.Switchover) error {
-	switchoverJob, err := renderSwitchoverCmdJob(reqCtx.Ctx, cli, cluster, synthesizedComp, switchover)
+	switchover *appsv1alpha1.Switchover,
+	jobName string) error {
+	switchoverJob, err := renderSwitchoverCmdJob(reqCtx.Ctx, cli, cluster, synthesizedComp, switchover, jobName)
 	if err != nil {
 		return err
 	}
@@ -170,12 +174,104 @@ func checkPodRoleLabelConsistency(ctx context.Context,
 	return false, nil
 }
 
+// verifySwitchoverReadWrite writes a probe record on the switchover target instance through its agent and
+// reads it back. If the agent of the engine does not support the probe, it falls back to check the role
+// reported by the agent of the target instance.
+func verifySwitchoverReadWrite(ctx context.Context,
+	cli client.Client,
+	synthesizedComp component.SynthesizedComponent,
+	switchover *appsv1alpha1.Switchover) (bool, error) {
+	pod, err := getSwitchoverTargetPod(ctx, cli, synthesizedComp, switchover)
+	if err != nil {
+		return false, err
+	}
+	lorryCli, err := lorry.NewClient(*pod)
+	if err != nil {
+		return false, err
+	}
+	if intctrlutil.IsNil(lorryCli) {
+		return false, fmt.Errorf("failed to build the agent client of instance %s", pod.Name)
+	}
+	err = lorryCli.CheckReadWrite(ctx)
+	if err == nil {
+		return true, nil
+	}
+	if !errors.Is(err, lorry.NotImplemented) {
+		return false, err
+	}
+	role, err := lorryCli.GetRole(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, r := range synthesizedComp.Roles {
+		if strings.EqualFold(r.Name, role) {
+			return r.Serviceable && r.Writable, nil
+		}
+	}
+	return false, nil
+}
+
+// verifySwitchoverReplicationLag checks whether all the secondaries of the component replicate from the new primary
+// with a lag not greater than maxLag.
+func verifySwitchoverReplicationLag(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	synthesizedComp component.SynthesizedComponent,
+	maxLag int64) (bool, error) {
+	pods, err := component.ListOwnedPods(reqCtx.Ctx, cli, synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name)
+	if err != nil {
+		return false, err
+	}
+	writableRoles := make(map[string]bool)
+	for _, role := range synthesizedComp.Roles {
+		writableRoles[role.Name] = role.Writable
+	}
+	for _, pod := range pods {
+		if writableRoles[pod.Labels[constant.RoleLabelKey]] {
+			continue
+		}
+		if !isReplicaUpToDate(reqCtx, pod, maxLag) {
+			return false, fmt.Errorf("the replication lag of instance %s is greater than %d", pod.Name, maxLag)
+		}
+	}
+	return true, nil
+}
+
+// getSwitchoverTargetPod gets the instance that is expected to be the primary after switchover.
+// if the candidate is not specified, the current serviceable and writable instance is the target.
+func getSwitchoverTargetPod(ctx context.Context,
+	cli client.Client,
+	synthesizedComp component.SynthesizedComponent,
+	switchover *appsv1alpha1.Switchover) (*corev1.Pod, error) {
+	if switchover.InstanceName == KBSwitchoverCandidateInstanceForAnyPod {
+		return getServiceableNWritablePod(ctx, cli, synthesizedComp)
+	}
+	pod := &corev1.Pod{}
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: synthesizedComp.Namespace, Name: switchover.InstanceName}, pod); err != nil {
+		return nil, err
+	}
+	return pod, nil
+}
+
+// getSwitchoverOldPrimary gets the original primary of the component recorded in the switchover condition.
+func getSwitchoverOldPrimary(switchoverCondition *metav1.Condition, componentName string) (string, error) {
+	var switchoverMessageMap map[string]SwitchoverMessage
+	if err := json.Unmarshal([]byte(switchoverCondition.Message), &switchoverMessageMap); err != nil {
+		return "", err
+	}
+	switchoverMessage, ok := switchoverMessageMap[componentName]
+	if !ok || switchoverMessage.OldPrimary == "" {
+		return "", fmt.Errorf("the original primary of component %s is not found", componentName)
+	}
+	return switchoverMessage.OldPrimary, nil
+}
+
 // renderSwitchoverCmdJob renders and creates the switchover command jobs.
 func renderSwitchoverCmdJob(ctx context.Context,
 	cli client.Client,
 	cluster *appsv1alpha1.Cluster,
 	synthesizedComp *component.SynthesizedComponent,
-	switchover *appsv1alpha1.Switchover) (*batchv1.Job, error) {
+	switchover *appsv1alpha1.Switchover,
+	jobName string) (*batchv1.Job, error) {
 	if synthesizedComp.LifecycleActions == nil || synthesizedComp.LifecycleActions.Switchover == nil || switchover == nil {
 		return nil, errors.New("switchover spec not found")
 	}
@@ -244,9 +340,6 @@ func renderSwitchoverCmdJob(ctx context.Context,
 			return nil, errors.New("switchover exec action not found")
 		}
 		volumes, volumeMounts := renderJobPodVolumes(scriptSpecSelectors)
-
-		// jobName named with generation to distinguish different switchover jobs.
-		jobName := genSwitchoverJobName(cluster.Name, synthesizedComp.Name, cluster.Generation)
 		job := &batchv1.Job{
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace: cluster.Namespace,
@@ -302,6 +395,11 @@ func genSwitchoverJobName(clusterName, componentName string, generation int64) s
 	return fmt.Sprintf("%s-%s-%s-%d", KBSwitchoverJobNamePrefix, clusterName, componentName, generation)
 }
 
+// genSwitchoverFallbackJobName generates the job name for switching back to the original primary.
+func genSwitchoverFallbackJobName(clusterName, componentName string, generation int64) string {
+	return fmt.Sprintf("%s-fallback", genSwitchoverJobName(clusterName, componentName, generation))
+}
+
 // getSwitchoverCmdJobLabel gets the labels for job that execute the switchover commands.
 func getSwitchoverCmdJobLabel(clusterName, componentName string) map[string]string {
 	return map[string]string{
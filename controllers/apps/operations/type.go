@@ -48,6 +48,37 @@ type OpsHandler interface {
 	SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsResource *OpsResource) error
 }
 
+// OpsPlanner is implemented by the OpsHandlers which support the dry run.
+type OpsPlanner interface {
+	// Plan computes the expected changes of the OpsRequest without mutating the cluster,
+	// it is executed after SaveLastConfiguration, so the status.lastConfiguration can be referred to.
+	Plan(reqCtx intctrlutil.RequestCtx, cli client.Client, opsResource *OpsResource) (*appsv1alpha1.OpsPlan, error)
+}
+
+// OpsPreCheck is an admission-like plugin which can veto an OpsRequest before its action is applied,
+// e.g. a cost-control plugin rejecting the scale-out beyond the budget.
+type OpsPreCheck interface {
+	// Name returns the name of the pre-check, which is shown in the message of the rejected OpsRequest.
+	Name() string
+
+	// Check returns a fatal error created by intctrlutil.NewFatalError to reject the OpsRequest,
+	// other errors are retried.
+	Check(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error
+}
+
+// OpsConflictPolicy defines how an OpsRequest treats the other OpsRequests of a type on the same Cluster.
+type OpsConflictPolicy string
+
+const (
+	// ConflictPolicyQueue queues the OpsRequest behind the running and the earlier queued OpsRequests of the type.
+	ConflictPolicyQueue OpsConflictPolicy = "Queue"
+	// ConflictPolicyParallel runs the OpsRequest in parallel with the OpsRequests of the type.
+	ConflictPolicyParallel OpsConflictPolicy = "Parallel"
+	// ConflictPolicyAbort queues the OpsRequest like ConflictPolicyQueue, and aborts the earlier OpsRequests
+	// of the type which are still running when it starts, e.g. it is forced to run.
+	ConflictPolicyAbort OpsConflictPolicy = "Abort"
+)
+
 type OpsBehaviour struct {
 	FromClusterPhases []appsv1alpha1.ClusterPhase
 
@@ -68,6 +99,14 @@ type OpsBehaviour struct {
 	// QueueWithSelf indicates that the operation is queued for execution within opsType scope.
 	QueueBySelf bool
 
+	// ConflictPolicies declares how the operation treats the other OpsRequests of the Cluster by their types,
+	// it takes precedence over the queue scope defined by QueueByCluster and QueueBySelf.
+	ConflictPolicies map[appsv1alpha1.OpsType]OpsConflictPolicy
+
+	// HonorMaintenanceWindow indicates that the operation only begins in the maintenance window
+	// specified by spec.schedulingPolicy.maintenanceWindow.
+	HonorMaintenanceWindow bool
+
 	OpsHandler OpsHandler
 }
 
@@ -93,6 +132,9 @@ type OpsResource struct {
 
 type OpsManager struct {
 	OpsMap map[appsv1alpha1.OpsType]OpsBehaviour
+
+	// PreChecks are run in order before the action of an OpsRequest is applied.
+	PreChecks []OpsPreCheck
 }
 
 type progressResource struct {
@@ -116,4 +158,6 @@ type progressResource struct {
 	// checks if it needs to wait the component to complete.
 	// if only updates a part of pods, set it to false.
 	noWaitComponentCompleted bool
+	// the duration to requeue the opsRequest if it is not completed, e.g. to check the progress deadline.
+	requeueAfter time.Duration
 }
@@ -21,13 +21,19 @@ package operations
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	appsv1beta1 "github.com/apecloud/kubeblocks/apis/apps/v1beta1"
+	cfgcore "github.com/apecloud/kubeblocks/pkg/configuration/core"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
@@ -42,7 +48,10 @@ func init() {
 		FromClusterPhases: appsv1alpha1.GetClusterUpRunningPhases(),
 		ToClusterPhase:    appsv1alpha1.UpdatingClusterPhase,
 		QueueByCluster:    true,
-		OpsHandler:        upgradeOpsHandler{},
+		ConflictPolicies: map[appsv1alpha1.OpsType]OpsConflictPolicy{
+			appsv1alpha1.UpgradeType: ConflictPolicyAbort,
+		},
+		OpsHandler: upgradeOpsHandler{},
 	}
 
 	opsMgr := GetOpsManager()
@@ -60,6 +69,10 @@ func (u upgradeOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Clie
 	if u.existClusterVersion(opsRes.OpsRequest) {
 		return fmt.Errorf("not implemented")
 	} else {
+		// gate the upgrade until the images of the target versions are pulled on the nodes.
+		if err := u.prePullImages(reqCtx, cli, opsRes); err != nil {
+			return err
+		}
 		compOpsHelper = newComponentOpsHelper(upgradeSpec.Components)
 		if err := compOpsHelper.updateClusterComponentsAndShardings(opsRes.Cluster, func(compSpec *appsv1alpha1.ClusterComponentSpec, obj ComponentOpsInterface) error {
 			upgradeComp := obj.(appsv1alpha1.UpgradeComponent)
@@ -75,7 +88,7 @@ func (u upgradeOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Clie
 		}
 	}
 	// abort earlier running upgrade opsRequest.
-	if err := abortEarlierOpsRequestWithSameKind(reqCtx, cli, opsRes, []appsv1alpha1.OpsType{appsv1alpha1.UpgradeType},
+	if err := abortConflictingOpsRequests(reqCtx, cli, opsRes,
 		func(earlierOps *appsv1alpha1.OpsRequest) (bool, error) {
 			if u.existClusterVersion(earlierOps) {
 				return true, nil
@@ -90,6 +103,10 @@ func (u upgradeOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Clie
 		}); err != nil {
 		return err
 	}
+	// the parameters stored in the Configuration are translated to the target versions before the components are reconciled.
+	if err := u.migrateConfigParameters(reqCtx, cli, opsRes); err != nil {
+		return err
+	}
 	return cli.Update(reqCtx.Ctx, opsRes.Cluster)
 }
 
@@ -164,6 +181,108 @@ func (u upgradeOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx,
 	return nil
 }
 
+// migrateConfigParameters translates the obsolete parameters in the Configurations of the upgraded components,
+// according to the ParameterMigrations of the ConfigConstraints referred by the target ComponentDefinitions.
+func (u upgradeOpsHandler) migrateConfigParameters(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	cluster := opsRes.Cluster
+	for _, v := range opsRes.OpsRequest.Spec.Upgrade.Components {
+		compSpec := getComponentSpecOrShardingTemplate(cluster, v.ComponentName)
+		if compSpec == nil || compSpec.ComponentDef == "" {
+			continue
+		}
+		compNames := []string{v.ComponentName}
+		if cluster.Spec.GetComponentByName(v.ComponentName) == nil {
+			shardingComps, err := intctrlutil.ListShardingComponents(reqCtx.Ctx, cli, cluster, v.ComponentName)
+			if err != nil {
+				return err
+			}
+			compNames = compNames[:0]
+			for _, comp := range shardingComps {
+				compNames = append(compNames, comp.Labels[constant.KBAppComponentLabelKey])
+			}
+		}
+		compDef, err := component.GetCompDefByName(reqCtx.Ctx, cli, compSpec.ComponentDef)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		for _, compName := range compNames {
+			if err = u.migrateComponentConfigParameters(reqCtx, cli, opsRes, compDef, compName); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (u upgradeOpsHandler) migrateComponentConfigParameters(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	compDef *appsv1alpha1.ComponentDefinition,
+	compName string) error {
+	config := &appsv1alpha1.Configuration{}
+	configKey := client.ObjectKey{
+		Namespace: opsRes.Cluster.Namespace,
+		Name:      cfgcore.GenerateComponentConfigurationName(opsRes.Cluster.Name, compName),
+	}
+	if err := cli.Get(reqCtx.Ctx, configKey, config); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	configConstraintRef := func(name string) string {
+		for _, configSpec := range compDef.Spec.Configs {
+			if configSpec.Name == name {
+				return configSpec.ConfigConstraintRef
+			}
+		}
+		return ""
+	}
+
+	var warnings []string
+	patch := client.MergeFrom(config.DeepCopy())
+	for i := range config.Spec.ConfigItemDetails {
+		item := &config.Spec.ConfigItemDetails[i]
+		ccName := configConstraintRef(item.Name)
+		if ccName == "" || len(item.ConfigFileParams) == 0 {
+			continue
+		}
+		cc := &appsv1beta1.ConfigConstraint{}
+		if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: ccName}, cc); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if len(cc.Spec.ParameterMigrations) == 0 {
+			continue
+		}
+		for file, params := range item.ConfigFileParams {
+			migrated, fileWarnings := cfgcore.MigrateParameters(params.Parameters, cc.Spec.ParameterMigrations)
+			if len(fileWarnings) == 0 {
+				continue
+			}
+			params.Parameters = migrated
+			item.ConfigFileParams[file] = params
+			for _, w := range fileWarnings {
+				warnings = append(warnings, fmt.Sprintf("%s/%s: %s", item.Name, file, w))
+			}
+		}
+	}
+	if len(warnings) == 0 {
+		return nil
+	}
+	if err := cli.Patch(reqCtx.Ctx, config, patch); err != nil {
+		return err
+	}
+	if opsRes.Recorder != nil {
+		slices.Sort(warnings)
+		opsRes.Recorder.Eventf(opsRes.OpsRequest, corev1.EventTypeWarning, reasonObsoleteParameters,
+			"config %s: %s", config.Name, strings.Join(warnings, "; "))
+	}
+	return nil
+}
+
 // getComponentDefMapWithUpdatedImages gets the desired componentDefinition map
 // that is updated with the corresponding images of the ComponentDefinition and service version.
 func (u upgradeOpsHandler) getComponentDefMapWithUpdatedImages(reqCtx intctrlutil.RequestCtx,
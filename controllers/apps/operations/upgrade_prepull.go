@@ -0,0 +1,250 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/common"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	prePullImagesTimeout = 10 * time.Minute
+
+	// prePullJobLabelKey labels the Jobs which pre-pull the images for the upgrade OpsRequest.
+	prePullJobLabelKey = "ops.kubeblocks.io/image-prepull"
+)
+
+// the waiting reasons of the containers which indicate the image can't be pulled.
+var imagePullFailedReasons = []string{"ErrImagePull", "ImagePullBackOff", "InvalidImageName", "ErrImageNeverPull"}
+
+// prePullImages pre-pulls the images of the target versions on the nodes hosting the Pods of the components to upgrade,
+// by running a Job on each node with the images. It returns a requeue error until all the Jobs are finished.
+func (u upgradeOpsHandler) prePullImages(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	opsRequest := opsRes.OpsRequest
+	if opsRequest.Spec.Upgrade == nil || !opsRequest.Spec.Upgrade.PrePullImages {
+		return nil
+	}
+	if !opsRequest.Status.StartTimestamp.IsZero() && time.Since(opsRequest.Status.StartTimestamp.Time) > prePullImagesTimeout {
+		opsRes.Recorder.Eventf(opsRequest, corev1.EventTypeWarning, "PrePullImagesTimeout",
+			"timed out pre-pulling the images after %s, start to upgrade", prePullImagesTimeout)
+		return u.cleanupPrePullJobs(reqCtx, cli, opsRequest)
+	}
+	nodeImages, err := u.getNodeImagesToPrePull(reqCtx, cli, opsRes)
+	if err != nil {
+		return err
+	}
+	jobList := &batchv1.JobList{}
+	if err = cli.List(reqCtx.Ctx, jobList, client.InNamespace(opsRequest.Namespace),
+		client.MatchingLabels{constant.OpsRequestNameLabelKey: opsRequest.Name, prePullJobLabelKey: "true"}); err != nil {
+		return err
+	}
+	jobs := map[string]*batchv1.Job{}
+	for i := range jobList.Items {
+		jobs[jobList.Items[i].Spec.Template.Spec.NodeName] = &jobList.Items[i]
+	}
+	pulling := 0
+	for nodeName, images := range nodeImages {
+		job, ok := jobs[nodeName]
+		if !ok {
+			if job, err = buildPrePullJob(opsRequest, nodeName, images); err != nil {
+				return err
+			}
+			if err = cli.Create(reqCtx.Ctx, job); err != nil && !apierrors.IsAlreadyExists(err) {
+				return err
+			}
+			pulling++
+			continue
+		}
+		finished, err := u.prePullJobFinished(reqCtx, cli, job)
+		if err != nil {
+			return err
+		}
+		if !finished {
+			pulling++
+		}
+	}
+	if pulling > 0 {
+		return intctrlutil.NewErrorf(intctrlutil.ErrorTypeRequeue, "wait for the images to be pre-pulled on %d nodes", pulling)
+	}
+	return u.cleanupPrePullJobs(reqCtx, cli, opsRequest)
+}
+
+// getNodeImagesToPrePull returns the images of the target versions to pre-pull for each node hosting the Pods of the components.
+func (u upgradeOpsHandler) getNodeImagesToPrePull(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource) (map[string][]string, error) {
+	cluster := opsRes.Cluster
+	nodeImages := map[string]sets.Set[string]{}
+	for _, upgradeComp := range opsRes.OpsRequest.Spec.Upgrade.Components {
+		compSpec := getComponentSpecOrShardingTemplate(cluster, upgradeComp.ComponentName)
+		if compSpec == nil {
+			return nil, intctrlutil.NewFatalError(fmt.Sprintf(`can not found the component "%s" in the cluster "%s"`,
+				upgradeComp.ComponentName, cluster.Name))
+		}
+		compDefName, serviceVersion := compSpec.ComponentDef, compSpec.ServiceVersion
+		if u.needUpdateCompDef(upgradeComp, cluster) {
+			compDefName = *upgradeComp.ComponentDefinitionName
+		}
+		if upgradeComp.ServiceVersion != nil {
+			serviceVersion = *upgradeComp.ServiceVersion
+		}
+		if compDefName == "" {
+			continue
+		}
+		compDef, err := component.GetCompDefByName(reqCtx.Ctx, cli, compDefName)
+		if err != nil {
+			return nil, err
+		}
+		if err = component.UpdateCompDefinitionImages4ServiceVersion(reqCtx.Ctx, cli, compDef, serviceVersion); err != nil {
+			return nil, err
+		}
+		images := sets.New[string]()
+		for _, c := range append(slices.Clone(compDef.Spec.Runtime.InitContainers), compDef.Spec.Runtime.Containers...) {
+			if c.Image != "" {
+				images.Insert(c.Image)
+			}
+		}
+		if images.Len() == 0 {
+			continue
+		}
+		podList := &corev1.PodList{}
+		labels := constant.GetComponentWellKnownLabels(cluster.Name, upgradeComp.ComponentName)
+		if slices.ContainsFunc(cluster.Spec.ShardingSpecs, func(spec appsv1alpha1.ShardingSpec) bool {
+			return spec.Name == upgradeComp.ComponentName
+		}) {
+			labels = map[string]string{
+				constant.AppInstanceLabelKey:       cluster.Name,
+				constant.KBAppShardingNameLabelKey: upgradeComp.ComponentName,
+			}
+		}
+		if err = cli.List(reqCtx.Ctx, podList, client.InNamespace(cluster.Namespace), client.MatchingLabels(labels)); err != nil {
+			return nil, err
+		}
+		for _, pod := range podList.Items {
+			if pod.Spec.NodeName == "" {
+				continue
+			}
+			if _, ok := nodeImages[pod.Spec.NodeName]; !ok {
+				nodeImages[pod.Spec.NodeName] = sets.New[string]()
+			}
+			nodeImages[pod.Spec.NodeName].Insert(images.UnsortedList()...)
+		}
+	}
+	result := map[string][]string{}
+	for nodeName, images := range nodeImages {
+		result[nodeName] = sets.List(images)
+	}
+	return result, nil
+}
+
+// prePullJobFinished checks whether the images of the pre-pull Job are pulled.
+// The Job is finished once its containers have been started, no matter whether they exit successfully or not,
+// since the images may not contain the shell to run the command.
+func (u upgradeOpsHandler) prePullJobFinished(reqCtx intctrlutil.RequestCtx, cli client.Client, job *batchv1.Job) (bool, error) {
+	if job.Status.Succeeded > 0 || job.Status.Failed > 0 {
+		return true, nil
+	}
+	podList := &corev1.PodList{}
+	if err := cli.List(reqCtx.Ctx, podList, client.InNamespace(job.Namespace),
+		client.MatchingLabels{"job-name": job.Name}); err != nil {
+		return false, err
+	}
+	for _, pod := range podList.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.State.Waiting != nil && slices.Contains(imagePullFailedReasons, status.State.Waiting.Reason) {
+				return false, intctrlutil.NewFatalError(fmt.Sprintf("failed to pre-pull the image %s on node %s: %s",
+					status.Image, pod.Spec.NodeName, status.State.Waiting.Message))
+			}
+		}
+	}
+	return false, nil
+}
+
+// cleanupPrePullJobs deletes the pre-pull Jobs of the OpsRequest.
+func (u upgradeOpsHandler) cleanupPrePullJobs(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRequest *appsv1alpha1.OpsRequest) error {
+	jobList := &batchv1.JobList{}
+	if err := cli.List(reqCtx.Ctx, jobList, client.InNamespace(opsRequest.Namespace),
+		client.MatchingLabels{constant.OpsRequestNameLabelKey: opsRequest.Name, prePullJobLabelKey: "true"}); err != nil {
+		return err
+	}
+	for i := range jobList.Items {
+		if err := intctrlutil.BackgroundDeleteObject(cli, reqCtx.Ctx, &jobList.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildPrePullJob builds the Job which pulls the images on the node.
+func buildPrePullJob(opsRequest *appsv1alpha1.OpsRequest, nodeName string, images []string) (*batchv1.Job, error) {
+	hf := fnv.New32a()
+	_, _ = hf.Write([]byte(nodeName))
+	jobName := common.ShortenName(fmt.Sprintf("%s-prepull-%08x", opsRequest.Name, hf.Sum32()), common.DNS1123LabelMaxLength)
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: opsRequest.Namespace,
+			Labels: map[string]string{
+				constant.OpsRequestNameLabelKey:      opsRequest.Name,
+				constant.OpsRequestNamespaceLabelKey: opsRequest.Namespace,
+				prePullJobLabelKey:                   "true",
+			},
+		},
+	}
+	for i, image := range images {
+		container := corev1.Container{
+			Name:            fmt.Sprintf("image-%d", i),
+			Image:           image,
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Command:         []string{"sh", "-c", "exit 0"},
+		}
+		intctrlutil.InjectZeroResourcesLimitsIfEmpty(&container)
+		job.Spec.Template.Spec.Containers = append(job.Spec.Template.Spec.Containers, container)
+	}
+	// set backoff limit to 0, so that the job will not be restarted
+	job.Spec.BackoffLimit = pointer.Int32(0)
+	job.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyNever
+	job.Spec.Template.Spec.NodeName = nodeName
+	job.Spec.Template.Spec.ImagePullSecrets = intctrlutil.BuildImagePullSecrets()
+	// the Job runs on the node hosting the Pods of the cluster, so tolerates all the taints of the node.
+	job.Spec.Template.Spec.Tolerations = []corev1.Toleration{{Operator: corev1.TolerationOpExists}}
+	scheme, _ := appsv1alpha1.SchemeBuilder.Build()
+	if err := controllerutil.SetOwnerReference(opsRequest, job, scheme); err != nil {
+		return nil, intctrlutil.NewFatalError(err.Error())
+	}
+	return job, nil
+}
@@ -384,6 +384,22 @@ var _ = Describe("Upgrade OpsRequest", func() {
 				g.Expect(cluster.Spec.ComponentSpecs[0].ServiceVersion).Should(Equal(""))
 			})).Should(Succeed())
 		})
+
+		It("Test building the Job to pre-pull the images", func() {
+			ops := testapps.NewOpsRequestObj("upgrade-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.UpgradeType)
+			job, err := buildPrePullJob(ops, "node-0", []string{"mysql:8.0.33", "busybox:1.35"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(job.Spec.Template.Spec.NodeName).Should(Equal("node-0"))
+			Expect(job.Spec.Template.Spec.Containers).Should(HaveLen(2))
+			Expect(job.Spec.Template.Spec.Containers[0].Image).Should(Equal("mysql:8.0.33"))
+			Expect(job.Labels).Should(HaveKeyWithValue(prePullJobLabelKey, "true"))
+			Expect(job.OwnerReferences).Should(HaveLen(1))
+
+			By("expect the Jobs on different nodes to have different names")
+			anotherJob, _ := buildPrePullJob(ops, "node-1", []string{"mysql:8.0.33"})
+			Expect(anotherJob.Name).ShouldNot(Equal(job.Name))
+		})
 		// TODO: add case with ClusterDefinition and topology
 	})
 })
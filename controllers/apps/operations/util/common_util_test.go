@@ -64,8 +64,8 @@ var _ = Describe("OpsRequest Controller", func() {
 			testOpsName := "restart-" + randomStr
 			ops := testapps.NewOpsRequestObj(testOpsName, testCtx.DefaultNamespace,
 				clusterName, appsv1alpha1.RestartType)
-			ops.Spec.RestartList = []appsv1alpha1.ComponentOps{
-				{ComponentName: defaultCompName},
+			ops.Spec.RestartList = []appsv1alpha1.Restart{
+				{ComponentOps: appsv1alpha1.ComponentOps{ComponentName: defaultCompName}},
 			}
 			testapps.CreateOpsRequest(ctx, testCtx, ops)
 
@@ -20,6 +20,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"fmt"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -44,7 +45,12 @@ func init() {
 		ToClusterPhase:    appsv1alpha1.UpdatingClusterPhase,
 		OpsHandler:        vsHandler,
 		QueueByCluster:    true,
-		CancelFunc:        vsHandler.Cancel,
+		ConflictPolicies: map[appsv1alpha1.OpsType]OpsConflictPolicy{
+			appsv1alpha1.VerticalScalingType: ConflictPolicyAbort,
+		},
+		CancelFunc: vsHandler.Cancel,
+
+		HonorMaintenanceWindow: true,
 	}
 
 	opsMgr := GetOpsManager()
@@ -65,18 +71,25 @@ func (vs verticalScalingHandler) Action(reqCtx intctrlutil.RequestCtx, cli clien
 			compSpec.Resources = verticalScaling.ResourceRequirements
 		}
 		for _, v := range verticalScaling.Instances {
+			found := false
 			for i := range compSpec.Instances {
 				if compSpec.Instances[i].Name == v.Name {
-					compSpec.Instances[i].Resources = &v.ResourceRequirements
+					compSpec.Instances[i].Resources = v.ResourceRequirements.DeepCopy()
+					found = true
 					break
 				}
 			}
+			// the instance templates may be removed from the cluster after the OpsRequest is validated.
+			if !found {
+				return intctrlutil.NewFatalError(fmt.Sprintf(`instance template "%s" not found in the component "%s"`,
+					v.Name, verticalScaling.ComponentName))
+			}
 		}
 		return nil
 	}
 	compOpsSet := newComponentOpsHelper(opsRes.OpsRequest.Spec.VerticalScalingList)
 	// abort earlier running vertical scaling opsRequest.
-	if err := abortEarlierOpsRequestWithSameKind(reqCtx, cli, opsRes, []appsv1alpha1.OpsType{appsv1alpha1.VerticalScalingType},
+	if err := abortConflictingOpsRequests(reqCtx, cli, opsRes,
 		func(earlierOps *appsv1alpha1.OpsRequest) (bool, error) {
 			for _, v := range earlierOps.Spec.VerticalScalingList {
 				// abort the earlierOps if exists the same component.
@@ -97,7 +110,13 @@ func (vs verticalScalingHandler) Action(reqCtx intctrlutil.RequestCtx, cli clien
 // ReconcileAction will be performed when action is done and loops till OpsRequest.status.phase is Succeed/Failed.
 // the Reconcile function for vertical scaling opsRequest.
 func (vs verticalScalingHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
-	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.VerticalScalingList)
+	return vs.reconcileVerticalScaling(reqCtx, cli, opsRes, opsRes.OpsRequest.Spec.VerticalScalingList)
+}
+
+// reconcileVerticalScaling reconciles the progress of the components until the pods are applied with the resources of the verticalScalingList.
+func (vs verticalScalingHandler) reconcileVerticalScaling(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource,
+	verticalScalingList []appsv1alpha1.VerticalScaling) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	compOpsHelper := newComponentOpsHelper(verticalScalingList)
 	handleComponentStatusProgressForVS := func(
 		reqCtx intctrlutil.RequestCtx,
 		cli client.Client,
@@ -128,7 +147,6 @@ func (vs verticalScalingHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx,
 				for _, podName := range templatePodNames {
 					updatedPodSet[podName] = ins.Name
 				}
-				break
 			}
 			if vs.verticalScalingComp(verticalScaling) && templateReplicasCnt < pgRes.clusterComponent.Replicas {
 				podNames, err := instanceset.GenerateInstanceNamesFromTemplate(workloadName, "", pgRes.clusterComponent.Replicas-templateReplicasCnt, pgRes.clusterComponent.OfflineInstances, nil)
@@ -162,6 +180,7 @@ func (vs verticalScalingHandler) podApplyCompOps(
 		lastCompConfiguration := ops.Status.LastConfiguration.Components[verticalScaling.ComponentName]
 		verticalScaling.Requests = lastCompConfiguration.Requests
 		verticalScaling.Limits = lastCompConfiguration.Limits
+		verticalScaling.Instances = vs.lastInstanceResources(lastCompConfiguration)
 	}
 	matchResources := func(podResources, vsResources corev1.ResourceRequirements) bool {
 		if vsResources.Requests == nil {
@@ -188,12 +207,29 @@ func (vs verticalScalingHandler) podApplyCompOps(
 	}
 	for _, insTpl := range verticalScaling.Instances {
 		if insTpl.Name == insTemplateName {
-			return matchResources(pod.Spec.Containers[0].Resources, verticalScaling.ResourceRequirements)
+			return matchResources(pod.Spec.Containers[0].Resources, insTpl.ResourceRequirements)
 		}
 	}
 	return false
 }
 
+// lastInstanceResources returns the resources of the instance templates before the vertical scaling,
+// which are the expected resources of the template pods when cancelling.
+func (vs verticalScalingHandler) lastInstanceResources(lastCompConfiguration appsv1alpha1.LastComponentConfiguration) []appsv1alpha1.InstanceResourceTemplate {
+	var instances []appsv1alpha1.InstanceResourceTemplate
+	for _, lastIns := range lastCompConfiguration.Instances {
+		insTpl := appsv1alpha1.InstanceResourceTemplate{Name: lastIns.Name}
+		if lastIns.Resources != nil {
+			insTpl.ResourceRequirements = *lastIns.Resources
+		} else {
+			// the template inherits the resources of the component if not specified.
+			insTpl.ResourceRequirements = lastCompConfiguration.ResourceRequirements
+		}
+		instances = append(instances, insTpl)
+	}
+	return instances
+}
+
 // SaveLastConfiguration records last configuration to the OpsRequest.status.lastConfiguration
 func (vs verticalScalingHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
 	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.VerticalScalingList)
@@ -220,19 +256,37 @@ func (vs verticalScalingHandler) SaveLastConfiguration(reqCtx intctrlutil.Reques
 	return nil
 }
 
+// Plan computes the expected resources of the components and the instance templates.
+func (vs verticalScalingHandler) Plan(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*appsv1alpha1.OpsPlan, error) {
+	plan := &appsv1alpha1.OpsPlan{Components: map[string]appsv1alpha1.OpsComponentPlan{}}
+	for _, verticalScaling := range opsRes.OpsRequest.Spec.VerticalScalingList {
+		compPlan := appsv1alpha1.OpsComponentPlan{
+			InstanceResources: verticalScaling.Instances,
+		}
+		if vs.verticalScalingComp(verticalScaling) {
+			compPlan.Resources = verticalScaling.ResourceRequirements.DeepCopy()
+		}
+		plan.Components[verticalScaling.ComponentName] = compPlan
+	}
+	return plan, nil
+}
+
 // Cancel this function defines the cancel verticalScaling action.
 func (vs verticalScalingHandler) Cancel(reqCxt intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
 	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.VerticalScalingList)
-	return compOpsHelper.cancelComponentOps(reqCxt.Ctx, cli, opsRes, func(lastConfig *appsv1alpha1.LastComponentConfiguration, comp *appsv1alpha1.ClusterComponentSpec) {
-		comp.Resources = lastConfig.ResourceRequirements
-		for _, lastIns := range lastConfig.Instances {
-			for i := range comp.Instances {
-				if comp.Instances[i].Name != lastIns.Name {
-					continue
-				}
-				comp.Instances[i].Resources = lastIns.Resources
-				break
+	return compOpsHelper.cancelComponentOps(reqCxt.Ctx, cli, opsRes, rollbackVerticalScaling)
+}
+
+// rollbackVerticalScaling reverts the resources of the component and its instance templates to the last configuration.
+func rollbackVerticalScaling(lastConfig *appsv1alpha1.LastComponentConfiguration, comp *appsv1alpha1.ClusterComponentSpec) {
+	comp.Resources = lastConfig.ResourceRequirements
+	for _, lastIns := range lastConfig.Instances {
+		for i := range comp.Instances {
+			if comp.Instances[i].Name != lastIns.Name {
+				continue
 			}
+			comp.Instances[i].Resources = lastIns.Resources
+			break
 		}
-	})
+	}
 }
@@ -116,6 +116,45 @@ var _ = Describe("VerticalScaling OpsRequest", func() {
 			testVerticalScaling(verticalScaling)
 		})
 
+		It("matches the pods of instance templates with the template resources", func() {
+			compResources := corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("400m")},
+			}
+			tplResources := corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("800m")},
+			}
+			verticalScaling := appsv1alpha1.VerticalScaling{
+				ComponentOps:         appsv1alpha1.ComponentOps{ComponentName: defaultCompName},
+				ResourceRequirements: compResources,
+				Instances: []appsv1alpha1.InstanceResourceTemplate{
+					{Name: "foo", ResourceRequirements: tplResources},
+				},
+			}
+			newPod := func(resources corev1.ResourceRequirements) *corev1.Pod {
+				// the requests default to the limits
+				resources.Requests = resources.Limits
+				return &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Resources: resources}}}}
+			}
+			ops := &appsv1alpha1.OpsRequest{}
+			vsHandler := verticalScalingHandler{}
+			Expect(vsHandler.podApplyCompOps(ops, newPod(compResources), verticalScaling, "")).Should(BeTrue())
+			Expect(vsHandler.podApplyCompOps(ops, newPod(tplResources), verticalScaling, "")).Should(BeFalse())
+			Expect(vsHandler.podApplyCompOps(ops, newPod(tplResources), verticalScaling, "foo")).Should(BeTrue())
+			Expect(vsHandler.podApplyCompOps(ops, newPod(compResources), verticalScaling, "foo")).Should(BeFalse())
+			Expect(vsHandler.podApplyCompOps(ops, newPod(tplResources), verticalScaling, "bar")).Should(BeFalse())
+
+			By("expect the template pods are rolled back to the last resources when cancelling")
+			ops.Spec.Cancel = true
+			ops.Status.LastConfiguration.Components = map[string]appsv1alpha1.LastComponentConfiguration{
+				defaultCompName: {
+					ResourceRequirements: tplResources,
+					Instances:            []appsv1alpha1.InstanceTemplate{{Name: "foo", Resources: &compResources}},
+				},
+			}
+			Expect(vsHandler.podApplyCompOps(ops, newPod(compResources), verticalScaling, "foo")).Should(BeTrue())
+			Expect(vsHandler.podApplyCompOps(ops, newPod(tplResources), verticalScaling, "")).Should(BeTrue())
+		})
+
 		It("cancel vertical scaling opsRequest", func() {
 			By("init operations resources with CLusterDefinition/Hybrid components Cluster/consensus Pods")
 			reqCtx := intctrlutil.RequestCtx{Ctx: ctx}
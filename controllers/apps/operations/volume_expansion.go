@@ -30,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
@@ -62,6 +63,10 @@ func init() {
 	volumeExpansionBehaviour := OpsBehaviour{
 		OpsHandler:  volumeExpansionOpsHandler{},
 		QueueBySelf: true,
+		// the volumes can be expanded while the configurations are being changed.
+		ConflictPolicies: map[appsv1alpha1.OpsType]OpsConflictPolicy{
+			appsv1alpha1.ReconfiguringType: ConflictPolicyParallel,
+		},
 	}
 	opsMgr := GetOpsManager()
 	opsMgr.RegisterOps(appsv1alpha1.VolumeExpansionType, volumeExpansionBehaviour)
@@ -103,6 +108,16 @@ func (ve volumeExpansionOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli cl
 	if err := compOpsSet.updateClusterComponentsAndShardings(opsRes.Cluster, applyVolumeExpansion); err != nil {
 		return err
 	}
+	shrinkingComps := ve.getShrinkingComponents(opsRes.OpsRequest)
+	for i := range opsRes.Cluster.Spec.ComponentSpecs {
+		compSpec := &opsRes.Cluster.Spec.ComponentSpecs[i]
+		if !shrinkingComps.Has(compSpec.Name) {
+			continue
+		}
+		if err := ve.shrinkVolumes(reqCtx, cli, opsRes, compSpec); err != nil {
+			return err
+		}
+	}
 	return cli.Update(reqCtx.Ctx, opsRes.Cluster)
 }
 
@@ -115,6 +130,7 @@ func (ve volumeExpansionOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCt
 		err                    error
 		opsRequestPhase        = appsv1alpha1.OpsRunningPhase
 		oldOpsRequestStatus    = opsRequest.Status.DeepCopy()
+		oldCluster             = opsRes.Cluster.DeepCopy()
 		expectProgressCount    int
 		succeedProgressCount   int
 		completedProgressCount int
@@ -132,6 +148,7 @@ func (ve volumeExpansionOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCt
 	}
 	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.VolumeExpansionList)
 	storageMap := ve.getRequestStorageMap(opsRequest)
+	shrinkingComps := ve.getShrinkingComponents(opsRequest)
 	var veHelpers []volumeExpansionHelper
 	setVeHelpers := func(compSpec appsv1alpha1.ClusterComponentSpec, compOps ComponentOpsInterface, fullComponentName string) {
 		volumeExpansion := compOps.(appsv1alpha1.VolumeExpansion)
@@ -163,7 +180,7 @@ func (ve volumeExpansionOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCt
 	}
 	for _, compSpec := range opsRes.Cluster.Spec.ComponentSpecs {
 		compOps, ok := compOpsHelper.componentOpsSet[compSpec.Name]
-		if !ok {
+		if !ok || shrinkingComps.Has(compSpec.Name) {
 			continue
 		}
 		setVeHelpers(compSpec, compOps, compSpec.Name)
@@ -200,6 +217,30 @@ func (ve volumeExpansionOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCt
 		completedProgressCount += completedCount
 		opsRequest.Status.Components[veHelper.compOps.GetComponentName()] = opsCompStatus
 	}
+	// replace the instances of the components whose volumes are shrunk.
+	for i := range opsRes.Cluster.Spec.ComponentSpecs {
+		compSpec := &opsRes.Cluster.Spec.ComponentSpecs[i]
+		if !shrinkingComps.Has(compSpec.Name) {
+			continue
+		}
+		opsCompStatus := opsRequest.Status.Components[compSpec.Name]
+		expectCount, succeedCount, completedCount, err := ve.handleVolumeShrinkProgress(reqCtx, cli, opsRes, &opsCompStatus, compSpec)
+		if err != nil {
+			return opsRequestPhase, requeueAfter, err
+		}
+		if expectCount == completedCount {
+			ve.setVolumeShrinkingAnnotation(opsRes.Cluster, compSpec.Name, false)
+		}
+		expectProgressCount += expectCount
+		succeedProgressCount += succeedCount
+		completedProgressCount += completedCount
+		opsRequest.Status.Components[compSpec.Name] = opsCompStatus
+	}
+	if !reflect.DeepEqual(oldCluster.Spec, opsRes.Cluster.Spec) || !reflect.DeepEqual(oldCluster.Annotations, opsRes.Cluster.Annotations) {
+		if err = cli.Update(reqCtx.Ctx, opsRes.Cluster); err != nil {
+			return opsRequestPhase, requeueAfter, err
+		}
+	}
 	if completedProgressCount != expectProgressCount {
 		requeueAfter = time.Minute
 	}
@@ -220,8 +261,9 @@ func (ve volumeExpansionOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCt
 		}
 		return opsRequestPhase, requeueAfter, err
 	}
-	// check whether the volume expansion operation has timed out
-	if time.Now().After(opsRequest.Status.StartTimestamp.Add(VolumeExpansionTimeOut)) {
+	// check whether the volume expansion operation has timed out,
+	// the shrinking is not limited as the time to clone the data depends on the size of it.
+	if shrinkingComps.Len() == 0 && time.Now().After(opsRequest.Status.StartTimestamp.Add(VolumeExpansionTimeOut)) {
 		// if volume expansion timed out
 		opsRequestPhase = appsv1alpha1.OpsFailedPhase
 		err = errors.New(fmt.Sprintf("Timed out waiting for volume expansion to complete, the timeout value is %g minutes", VolumeExpansionTimeOut.Minutes()))
@@ -238,7 +280,7 @@ func (ve volumeExpansionOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.Req
 		getLastVCTs := func(vcts []appsv1alpha1.ClusterComponentVolumeClaimTemplate, templateName string) []appsv1alpha1.ClusterComponentVolumeClaimTemplate {
 			lastVCTs := make([]appsv1alpha1.ClusterComponentVolumeClaimTemplate, 0)
 			for _, vct := range vcts {
-				key := getComponentVCTKey(comOps.GetComponentName(), comOps.GetComponentName(), templateName)
+				key := getComponentVCTKey(comOps.GetComponentName(), templateName, vct.Name)
 				if _, ok := storageMap[key]; !ok {
 					continue
 				}
@@ -268,10 +310,16 @@ func (ve volumeExpansionOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.Req
 				Storage: v.Spec.Resources.Requests[corev1.ResourceStorage],
 			})
 		}
-		return appsv1alpha1.LastComponentConfiguration{
+		lastCompConfiguration := appsv1alpha1.LastComponentConfiguration{
 			VolumeClaimTemplates: convertedLastVCTs,
 			Instances:            instanceTemplates,
 		}
+		if volumeExpansion.Shrink {
+			// the instances are replaced when shrinking the volumes
+			lastCompConfiguration.Replicas = pointer.Int32(compSpec.Replicas)
+			lastCompConfiguration.OfflineInstances = compSpec.OfflineInstances
+		}
+		return lastCompConfiguration
 	})
 	return nil
 }
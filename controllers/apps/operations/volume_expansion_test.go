@@ -30,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/kubectl/pkg/util/storage"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
@@ -248,5 +249,38 @@ var _ = Describe("OpsRequest Controller Volume Expansion Handler", func() {
 			By("Test delete the Running VolumeExpansion OpsRequest")
 			testDeleteRunningVolumeExpansion(clusterObject, opsRes)
 		})
+
+		It("VolumeExpansion should find the components to shrink the volumes", func() {
+			ops := testapps.NewOpsRequestObj("volumeexpansion-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.VolumeExpansionType)
+			ops.Spec.VolumeExpansionList = []appsv1alpha1.VolumeExpansion{
+				{
+					ComponentOps:         appsv1alpha1.ComponentOps{ComponentName: consensusCompName},
+					VolumeClaimTemplates: []appsv1alpha1.OpsRequestVolumeClaimTemplate{{Name: vctName, Storage: resource.MustParse("1Gi")}},
+					Shrink:               true,
+				},
+			}
+			ops.Status.LastConfiguration.Components = map[string]appsv1alpha1.LastComponentConfiguration{
+				consensusCompName: {
+					Replicas:             pointer.Int32(3),
+					VolumeClaimTemplates: []appsv1alpha1.OpsRequestVolumeClaimTemplate{{Name: vctName, Storage: resource.MustParse("2Gi")}},
+				},
+			}
+			handler := volumeExpansionOpsHandler{}
+			Expect(handler.getShrinkingComponents(ops).UnsortedList()).Should(ConsistOf(consensusCompName))
+
+			By("expect for no component to shrink if the shrinking is not allowed")
+			ops.Spec.VolumeExpansionList[0].Shrink = false
+			Expect(handler.getShrinkingComponents(ops).Len()).Should(BeZero())
+
+			By("expect for the volume shrinking annotation to be set and removed")
+			cluster := &appsv1alpha1.Cluster{}
+			handler.setVolumeShrinkingAnnotation(cluster, consensusCompName, true)
+			handler.setVolumeShrinkingAnnotation(cluster, "other", true)
+			Expect(cluster.Annotations[constant.VolumeShrinkingAnnotationKey]).Should(Equal(consensusCompName + ",other"))
+			handler.setVolumeShrinkingAnnotation(cluster, consensusCompName, false)
+			handler.setVolumeShrinkingAnnotation(cluster, "other", false)
+			Expect(cluster.Annotations).ShouldNot(HaveKey(constant.VolumeShrinkingAnnotationKey))
+		})
 	})
 })
@@ -0,0 +1,218 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlcomp "github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// The volumes can not be shrunk natively, so the shrinking replaces the instances of the Component:
+//  1. the storage of the volumeClaimTemplates is updated and the replicas are doubled, the new instances are created
+//     with the smaller volumes, and their data is restored from a backup by the horizontal scaling data clone.
+//  2. once all the new instances are available, the original instances are taken offline, and their volumes are
+//     deleted by the scale-in of the InstanceSet.
+// The Component is recorded in the annotation of the Cluster during the shrinking, so that the existing volumes
+// are kept as-is and the data is not cloned by the volume snapshot, which can not be restored to a smaller volume.
+
+// getShrinkingComponents returns the Components whose volumes are requested to shrink.
+func (ve volumeExpansionOpsHandler) getShrinkingComponents(opsRequest *appsv1alpha1.OpsRequest) sets.Set[string] {
+	shrinkingComps := sets.New[string]()
+	for _, v := range opsRequest.Spec.VolumeExpansionList {
+		if !v.Shrink {
+			continue
+		}
+		lastCompConfiguration, ok := opsRequest.Status.LastConfiguration.Components[v.ComponentName]
+		if !ok {
+			continue
+		}
+		for _, vct := range v.VolumeClaimTemplates {
+			for _, lastVCT := range lastCompConfiguration.VolumeClaimTemplates {
+				if lastVCT.Name == vct.Name && vct.Storage.Cmp(lastVCT.Storage) < 0 {
+					shrinkingComps.Insert(v.ComponentName)
+				}
+			}
+		}
+	}
+	return shrinkingComps
+}
+
+// shrinkVolumes scales out the new instances with the smaller volumes for the shrinking Component.
+func (ve volumeExpansionOpsHandler) shrinkVolumes(reqCtx intctrlutil.RequestCtx, cli client.Client,
+	opsRes *OpsResource, compSpec *appsv1alpha1.ClusterComponentSpec) error {
+	if len(compSpec.Instances) > 0 {
+		return intctrlutil.NewFatalError(fmt.Sprintf(`shrinking the volumes of component "%s" with instance templates is not supported`, compSpec.Name))
+	}
+	synthesizedComp, err := rebuildInstanceOpsHandler{}.buildSynthesizedComponent(reqCtx, cli, opsRes.Cluster, compSpec.Name)
+	if err != nil {
+		return err
+	}
+	if synthesizedComp.HorizontalScaleBackupPolicyTemplate == nil {
+		return intctrlutil.NewFatalError(fmt.Sprintf(`component "%s" does not support to clone the data by backup, can not shrink the volumes`, compSpec.Name))
+	}
+	lastCompConfiguration := opsRes.OpsRequest.Status.LastConfiguration.Components[compSpec.Name]
+	compSpec.Replicas = *lastCompConfiguration.Replicas * 2
+	ve.setVolumeShrinkingAnnotation(opsRes.Cluster, compSpec.Name, true)
+	return nil
+}
+
+// handleVolumeShrinkProgress checks whether the original instances of the Component are replaced by the new ones,
+// it returns the expected, succeed and completed count of the original instances.
+func (ve volumeExpansionOpsHandler) handleVolumeShrinkProgress(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	compStatus *appsv1alpha1.OpsRequestComponentStatus,
+	compSpec *appsv1alpha1.ClusterComponentSpec) (int, int, int, error) {
+	var (
+		succeedCount   int
+		completedCount int
+		clusterName    = opsRes.Cluster.Name
+	)
+	lastCompConfiguration := opsRes.OpsRequest.Status.LastConfiguration.Components[compSpec.Name]
+	replicas := *lastCompConfiguration.Replicas
+	oldPodNames, err := intctrlcomp.GenerateAllPodNames(replicas, nil, lastCompConfiguration.OfflineInstances, clusterName, compSpec.Name)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	allPodNames, err := intctrlcomp.GenerateAllPodNames(replicas*2, nil, lastCompConfiguration.OfflineInstances, clusterName, compSpec.Name)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	newPodNames := slices.DeleteFunc(allPodNames, func(name string) bool {
+		return slices.Contains(oldPodNames, name)
+	})
+	expectCount := len(oldPodNames)
+	if expectCount == 0 {
+		return 0, 0, 0, nil
+	}
+	setProgress := func(oldPodName string, status appsv1alpha1.ProgressStatus, message string) {
+		setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails,
+			appsv1alpha1.ProgressStatusDetail{
+				ObjectKey: getProgressObjectKey(constant.PodKind, oldPodName),
+				Status:    status,
+				Message:   message,
+			})
+	}
+
+	if !slices.Contains(compSpec.OfflineInstances, oldPodNames[0]) {
+		// 1. wait for the new instances to be available.
+		synthesizedComp, err := rebuildInstanceOpsHandler{}.buildSynthesizedComponent(reqCtx, cli, opsRes.Cluster, compSpec.Name)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		availableCount := 0
+		for i, newPodName := range newPodNames {
+			pod := &corev1.Pod{}
+			exist, err := intctrlutil.CheckResourceExists(reqCtx.Ctx, cli, client.ObjectKey{Name: newPodName, Namespace: opsRes.Cluster.Namespace}, pod)
+			if err != nil {
+				return 0, 0, 0, err
+			}
+			if !exist {
+				setProgress(oldPodNames[i], appsv1alpha1.PendingProgressStatus,
+					fmt.Sprintf("Waiting for the new pod %s with the shrunk volumes to be created", newPodName))
+				continue
+			}
+			isAvailable, err := instanceIsAvailable(synthesizedComp, pod, opsRes.OpsRequest.Annotations[ignoreRoleCheckAnnotationKey])
+			if err != nil {
+				// roll back to the original instances and volumes.
+				for _, oldPodName := range oldPodNames {
+					setProgress(oldPodName, appsv1alpha1.FailedProgressStatus, err.Error())
+				}
+				ve.rollbackVolumeShrinking(opsRes, compSpec)
+				return expectCount, 0, expectCount, nil
+			}
+			if !isAvailable {
+				setProgress(oldPodNames[i], appsv1alpha1.ProcessingProgressStatus,
+					fmt.Sprintf("Waiting for the new pod %s with the shrunk volumes to be available", newPodName))
+				continue
+			}
+			availableCount += 1
+		}
+		if availableCount != len(newPodNames) {
+			return expectCount, 0, 0, nil
+		}
+		// 2. take the original instances offline.
+		compSpec.OfflineInstances = append(compSpec.OfflineInstances, oldPodNames...)
+		compSpec.Replicas = replicas
+	}
+
+	// 3. check whether the original instances are deleted.
+	for i, oldPodName := range oldPodNames {
+		exist, err := intctrlutil.CheckResourceExists(reqCtx.Ctx, cli, client.ObjectKey{Name: oldPodName, Namespace: opsRes.Cluster.Namespace}, &corev1.Pod{})
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		if exist {
+			setProgress(oldPodName, appsv1alpha1.ProcessingProgressStatus,
+				fmt.Sprintf("Taking the pod offline, which is replaced by the new pod %s", newPodNames[i]))
+			continue
+		}
+		succeedCount += 1
+		completedCount += 1
+		setProgress(oldPodName, appsv1alpha1.SucceedProgressStatus,
+			fmt.Sprintf("Successfully replaced by the new pod %s with the shrunk volumes", newPodNames[i]))
+	}
+	return expectCount, succeedCount, completedCount, nil
+}
+
+// rollbackVolumeShrinking restores the replicas and the storage of the Component, the new instances are scaled in.
+func (ve volumeExpansionOpsHandler) rollbackVolumeShrinking(opsRes *OpsResource, compSpec *appsv1alpha1.ClusterComponentSpec) {
+	lastCompConfiguration := opsRes.OpsRequest.Status.LastConfiguration.Components[compSpec.Name]
+	compSpec.Replicas = *lastCompConfiguration.Replicas
+	for _, lastVCT := range lastCompConfiguration.VolumeClaimTemplates {
+		for i := range compSpec.VolumeClaimTemplates {
+			if compSpec.VolumeClaimTemplates[i].Name == lastVCT.Name {
+				compSpec.VolumeClaimTemplates[i].Spec.Resources.Requests[corev1.ResourceStorage] = lastVCT.Storage
+			}
+		}
+	}
+}
+
+// setVolumeShrinkingAnnotation adds or removes the Component in the volume shrinking annotation of the Cluster.
+func (ve volumeExpansionOpsHandler) setVolumeShrinkingAnnotation(cluster *appsv1alpha1.Cluster, compName string, shrinking bool) {
+	var comps []string
+	if value := cluster.Annotations[constant.VolumeShrinkingAnnotationKey]; value != "" {
+		comps = strings.Split(value, ",")
+	}
+	comps = slices.DeleteFunc(comps, func(name string) bool {
+		return name == compName
+	})
+	if shrinking {
+		comps = append(comps, compName)
+	}
+	if len(comps) == 0 {
+		delete(cluster.Annotations, constant.VolumeShrinkingAnnotationKey)
+		return
+	}
+	if cluster.Annotations == nil {
+		cluster.Annotations = map[string]string{}
+	}
+	cluster.Annotations[constant.VolumeShrinkingAnnotationKey] = strings.Join(comps, ",")
+}
@@ -0,0 +1,270 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlcomp "github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+type volumeTuningOpsHandler struct{}
+
+var _ OpsHandler = volumeTuningOpsHandler{}
+
+const (
+	// VolumeTuningTimeOut volume tuning timeout.
+	VolumeTuningTimeOut = 30 * time.Minute
+)
+
+func init() {
+	volumeTuningBehaviour := OpsBehaviour{
+		OpsHandler:  volumeTuningOpsHandler{},
+		QueueBySelf: true,
+		// the performance of the volumes can be modified while the configurations are being changed.
+		ConflictPolicies: map[appsv1alpha1.OpsType]OpsConflictPolicy{
+			appsv1alpha1.ReconfiguringType: ConflictPolicyParallel,
+		},
+	}
+	opsMgr := GetOpsManager()
+	opsMgr.RegisterOps(appsv1alpha1.VolumeTuningType, volumeTuningBehaviour)
+}
+
+// ActionStartedCondition the started condition when handle the volume tuning request.
+func (vt volumeTuningOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*metav1.Condition, error) {
+	return appsv1alpha1.NewVolumeTuningCondition(opsRes.OpsRequest), nil
+}
+
+// Action modifies Cluster.spec.components[*].VolumeClaimTemplates[*].spec.performance,
+// the volumeClaimTemplates with the same name in the instance templates are modified too.
+func (vt volumeTuningOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	applyVolumeTuning := func(compSpec *appsv1alpha1.ClusterComponentSpec, obj ComponentOpsInterface) error {
+		setVolumePerformance := func(tuningVCTs []appsv1alpha1.OpsRequestVolumeTuningTemplate,
+			targetVCTs []appsv1alpha1.ClusterComponentVolumeClaimTemplate) {
+			for _, v := range tuningVCTs {
+				for i, vct := range targetVCTs {
+					if vct.Name != v.Name {
+						continue
+					}
+					targetVCTs[i].Spec.Performance = v.Performance.DeepCopy()
+				}
+			}
+		}
+		volumeTuning := obj.(appsv1alpha1.VolumeTuning)
+		setVolumePerformance(volumeTuning.VolumeClaimTemplates, compSpec.VolumeClaimTemplates)
+		for i := range compSpec.Instances {
+			setVolumePerformance(volumeTuning.VolumeClaimTemplates, compSpec.Instances[i].VolumeClaimTemplates)
+		}
+		return nil
+	}
+	compOpsSet := newComponentOpsHelper(opsRes.OpsRequest.Spec.VolumeTuningList)
+	if err := compOpsSet.updateClusterComponentsAndShardings(opsRes.Cluster, applyVolumeTuning); err != nil {
+		return err
+	}
+	return cli.Update(reqCtx.Ctx, opsRes.Cluster)
+}
+
+// ReconcileAction will be performed when action is done and loops till OpsRequest.status.phase is Succeed/Failed.
+// the Reconcile function for volume tuning opsRequest.
+func (vt volumeTuningOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	var (
+		opsRequest             = opsRes.OpsRequest
+		requeueAfter           time.Duration
+		err                    error
+		opsRequestPhase        = appsv1alpha1.OpsRunningPhase
+		oldOpsRequestStatus    = opsRequest.Status.DeepCopy()
+		expectProgressCount    int
+		succeedProgressCount   int
+		completedProgressCount int
+	)
+	patch := client.MergeFrom(opsRequest.DeepCopy())
+	if opsRequest.Status.Components == nil {
+		opsRequest.Status.Components = map[string]appsv1alpha1.OpsRequestComponentStatus{}
+		for _, v := range opsRequest.Spec.VolumeTuningList {
+			opsRequest.Status.Components[v.ComponentName] = appsv1alpha1.OpsRequestComponentStatus{}
+		}
+	}
+	compOpsHelper := newComponentOpsHelper(opsRequest.Spec.VolumeTuningList)
+	handleComponent := func(compSpec appsv1alpha1.ClusterComponentSpec, compOps ComponentOpsInterface, fullComponentName string) error {
+		opsCompStatus := opsRequest.Status.Components[compOps.GetComponentName()]
+		expectCount, succeedCount, completedCount, err := vt.handleVolumeTuningProgress(reqCtx, cli, opsRes,
+			&opsCompStatus, compSpec, compOps.(appsv1alpha1.VolumeTuning), fullComponentName)
+		if err != nil {
+			return err
+		}
+		expectProgressCount += expectCount
+		succeedProgressCount += succeedCount
+		completedProgressCount += completedCount
+		opsRequest.Status.Components[compOps.GetComponentName()] = opsCompStatus
+		return nil
+	}
+	for _, compSpec := range opsRes.Cluster.Spec.ComponentSpecs {
+		compOps, ok := compOpsHelper.componentOpsSet[compSpec.Name]
+		if !ok {
+			continue
+		}
+		if err = handleComponent(compSpec, compOps, compSpec.Name); err != nil {
+			return opsRequestPhase, 0, err
+		}
+	}
+	for _, shardingSpec := range opsRes.Cluster.Spec.ShardingSpecs {
+		compOps, ok := compOpsHelper.componentOpsSet[shardingSpec.Name]
+		if !ok {
+			continue
+		}
+		shardingComps, err := intctrlutil.ListShardingComponents(reqCtx.Ctx, cli, opsRes.Cluster, shardingSpec.Name)
+		if err != nil {
+			return opsRequestPhase, 0, err
+		}
+		for _, v := range shardingComps {
+			if err = handleComponent(shardingSpec.Template, compOps, v.Labels[constant.KBAppComponentLabelKey]); err != nil {
+				return opsRequestPhase, 0, err
+			}
+		}
+	}
+	if completedProgressCount != expectProgressCount {
+		requeueAfter = time.Minute
+	}
+	opsRequest.Status.Progress = fmt.Sprintf("%d/%d", completedProgressCount, expectProgressCount)
+	if !reflect.DeepEqual(*oldOpsRequestStatus, opsRequest.Status) {
+		if err = cli.Status().Patch(reqCtx.Ctx, opsRequest, patch); err != nil {
+			return opsRequestPhase, requeueAfter, err
+		}
+	}
+	if expectProgressCount == completedProgressCount {
+		if expectProgressCount == succeedProgressCount {
+			opsRequestPhase = appsv1alpha1.OpsSucceedPhase
+		} else {
+			opsRequestPhase = appsv1alpha1.OpsFailedPhase
+		}
+		return opsRequestPhase, requeueAfter, nil
+	}
+	if time.Now().After(opsRequest.Status.StartTimestamp.Add(VolumeTuningTimeOut)) {
+		opsRequestPhase = appsv1alpha1.OpsFailedPhase
+		err = errors.New(fmt.Sprintf("Timed out waiting for volume tuning to complete, the timeout value is %g minutes", VolumeTuningTimeOut.Minutes()))
+	}
+	return opsRequestPhase, requeueAfter, err
+}
+
+// SaveLastConfiguration records last configuration to the OpsRequest.status.lastConfiguration
+func (vt volumeTuningOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return nil
+}
+
+// handleVolumeTuningProgress checks whether the volume attributes class of the PVCs is modified to the expected one,
+// it returns the expected, succeed and completed count of the PVCs.
+func (vt volumeTuningOpsHandler) handleVolumeTuningProgress(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	compStatus *appsv1alpha1.OpsRequestComponentStatus,
+	compSpec appsv1alpha1.ClusterComponentSpec,
+	volumeTuning appsv1alpha1.VolumeTuning,
+	fullComponentName string) (int, int, int, error) {
+	var (
+		expectCount    int
+		succeedCount   int
+		completedCount int
+	)
+	podSet, err := intctrlcomp.GenerateAllPodNamesToSet(compSpec.Replicas, compSpec.Instances, compSpec.OfflineInstances,
+		opsRes.Cluster.Name, fullComponentName)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	// getExpectedVACName gets the volume attributes class of the vct which the pod is created from.
+	getExpectedVACName := func(vctName, templateName string) *string {
+		vcts := compSpec.VolumeClaimTemplates
+		for _, ins := range compSpec.Instances {
+			if ins.Name == templateName && len(ins.VolumeClaimTemplates) > 0 {
+				vcts = ins.VolumeClaimTemplates
+				break
+			}
+		}
+		for _, vct := range vcts {
+			if vct.Name == vctName {
+				return vct.Spec.ToV1PersistentVolumeClaimSpec().VolumeAttributesClassName
+			}
+		}
+		return nil
+	}
+	for _, vct := range volumeTuning.VolumeClaimTemplates {
+		for podName, templateName := range podSet {
+			expectedVACName := pointer.StringDeref(getExpectedVACName(vct.Name, templateName), "")
+			if expectedVACName == "" {
+				continue
+			}
+			expectCount += 1
+			pvcName := fmt.Sprintf("%s-%s", vct.Name, podName)
+			objectKey := getPVCProgressObjectKey(pvcName)
+			progressDetail := findStatusProgressDetail(compStatus.ProgressDetails, objectKey)
+			if progressDetail == nil {
+				progressDetail = &appsv1alpha1.ProgressStatusDetail{ObjectKey: objectKey, Group: vct.Name}
+			}
+			if isCompletedProgressStatus(progressDetail.Status) {
+				completedCount += 1
+				if progressDetail.Status == appsv1alpha1.SucceedProgressStatus {
+					succeedCount += 1
+				}
+				continue
+			}
+			pvc := &corev1.PersistentVolumeClaim{}
+			if err = cli.Get(reqCtx.Ctx, client.ObjectKey{Name: pvcName, Namespace: opsRes.Cluster.Namespace}, pvc); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return 0, 0, 0, err
+			}
+			modifyStatus := pvc.Status.ModifyVolumeStatus
+			switch {
+			case pointer.StringDeref(pvc.Status.CurrentVolumeAttributesClassName, "") == expectedVACName:
+				succeedCount += 1
+				completedCount += 1
+				message := fmt.Sprintf("Successfully tune volume: %s in component: %s", objectKey, volumeTuning.ComponentName)
+				progressDetail.SetStatusAndMessage(appsv1alpha1.SucceedProgressStatus, message)
+			case modifyStatus != nil && modifyStatus.TargetVolumeAttributesClassName == expectedVACName &&
+				modifyStatus.Status == corev1.PersistentVolumeClaimModifyVolumeInfeasible:
+				completedCount += 1
+				message := fmt.Sprintf("Failed to tune volume: %s in component: %s, the volume attributes class %s is infeasible",
+					objectKey, volumeTuning.ComponentName, expectedVACName)
+				progressDetail.SetStatusAndMessage(appsv1alpha1.FailedProgressStatus, message)
+			case modifyStatus != nil && modifyStatus.TargetVolumeAttributesClassName == expectedVACName &&
+				modifyStatus.Status == corev1.PersistentVolumeClaimModifyVolumeInProgress:
+				message := fmt.Sprintf("Start tuning volume: %s in component: %s", objectKey, volumeTuning.ComponentName)
+				progressDetail.SetStatusAndMessage(appsv1alpha1.ProcessingProgressStatus, message)
+			default:
+				message := fmt.Sprintf("Waiting for an external controller to process the pvc: %s in component: %s", objectKey, volumeTuning.ComponentName)
+				progressDetail.SetStatusAndMessage(appsv1alpha1.PendingProgressStatus, message)
+			}
+			setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, *progressDetail)
+		}
+	}
+	return expectCount, succeedCount, completedCount, nil
+}
@@ -21,8 +21,8 @@ package apps
 
 import (
 	"context"
+	"fmt"
 	"math"
-	"reflect"
 	"strings"
 	"time"
 
@@ -62,6 +62,9 @@ type OpsRequestReconciler struct {
 // +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsrequests,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsrequests/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsrequests/finalizers,verbs=update
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=externalopshandlers,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=resourcequotas,verbs=get;list;watch
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -75,6 +78,10 @@ func (r *OpsRequestReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		Recorder: r.Recorder,
 	}
 	reqCtx.Log.Info("reconcile", "opsRequest", req.NamespacedName)
+	if intctrlutil.IsShuttingDown() {
+		// don't take new ops work, it's picked up after the restart.
+		return intctrlutil.RequeueAfter(time.Second, reqCtx.Log, "the operator is shutting down")
+	}
 	opsCtrlHandler := &opsControllerHandler{}
 	return opsCtrlHandler.Handle(reqCtx, &operations.OpsResource{Recorder: r.Recorder},
 		r.fetchOpsRequest,
@@ -122,7 +129,8 @@ func (r *OpsRequestReconciler) fetchOpsRequest(reqCtx intctrlutil.RequestCtx, op
 
 // handleDeletion handles the delete event of the OpsRequest.
 func (r *OpsRequestReconciler) handleDeletion(reqCtx intctrlutil.RequestCtx, opsRes *operations.OpsResource) (*ctrl.Result, error) {
-	if opsRes.OpsRequest.Status.Phase == appsv1alpha1.OpsRunningPhase && !opsRes.Cluster.IsDeleting() {
+	phase := opsRes.OpsRequest.Status.Phase
+	if (phase == appsv1alpha1.OpsRunningPhase || phase == appsv1alpha1.OpsWaitingForConfirmPhase) && !opsRes.Cluster.IsDeleting() {
 		return nil, nil
 	}
 	return intctrlutil.HandleCRDeletion(reqCtx, r, opsRes.OpsRequest, constant.OpsRequestFinalizerName, func() (*ctrl.Result, error) {
@@ -171,12 +179,12 @@ func (r *OpsRequestReconciler) handleOpsRequestByPhase(reqCtx intctrlutil.Reques
 			return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
 		}
 		return intctrlutil.ResultToP(intctrlutil.Reconciled())
-	case appsv1alpha1.OpsPendingPhase, appsv1alpha1.OpsCreatingPhase:
+	case appsv1alpha1.OpsPendingPhase, appsv1alpha1.OpsPendingApprovalPhase, appsv1alpha1.OpsScheduledPhase, appsv1alpha1.OpsCreatingPhase:
 		return r.doOpsRequestAction(reqCtx, opsRes)
-	case appsv1alpha1.OpsRunningPhase, appsv1alpha1.OpsCancellingPhase:
+	case appsv1alpha1.OpsRunningPhase, appsv1alpha1.OpsWaitingForConfirmPhase, appsv1alpha1.OpsCancellingPhase:
 		return r.reconcileStatusDuringRunningOrCanceling(reqCtx, opsRes)
 	case appsv1alpha1.OpsSucceedPhase:
-		return r.handleSucceedOpsRequest(reqCtx, opsRes.OpsRequest)
+		return r.handleSucceedOpsRequest(reqCtx, opsRes)
 	default:
 		return r.handleUnsuccessfulCompletionOpsRequest(reqCtx, opsRes)
 	}
@@ -191,7 +199,8 @@ func (r *OpsRequestReconciler) handleCancelSignal(reqCtx intctrlutil.RequestCtx,
 	if opsRequest.IsComplete() || opsRequest.Status.Phase == appsv1alpha1.OpsCancellingPhase {
 		return nil, nil
 	}
-	if opsRequest.Status.Phase == appsv1alpha1.OpsPendingPhase {
+	if slices.Contains([]appsv1alpha1.OpsPhase{appsv1alpha1.OpsPendingPhase, appsv1alpha1.OpsPendingApprovalPhase,
+		appsv1alpha1.OpsScheduledPhase}, opsRequest.Status.Phase) {
 		return &ctrl.Result{}, operations.PatchOpsStatus(reqCtx.Ctx, r.Client, opsRes, appsv1alpha1.OpsCancelledPhase)
 	}
 	opsBehaviour := operations.GetOpsManager().OpsMap[opsRequest.Spec.Type]
@@ -217,45 +226,48 @@ func (r *OpsRequestReconciler) handleCancelSignal(reqCtx intctrlutil.RequestCtx,
 	return intctrlutil.ResultToP(intctrlutil.Reconciled())
 }
 
-// handleSucceedOpsRequest the opsRequest will be deleted after one hour when status.phase is Succeed
-func (r *OpsRequestReconciler) handleSucceedOpsRequest(reqCtx intctrlutil.RequestCtx, opsRequest *appsv1alpha1.OpsRequest) (*ctrl.Result, error) {
-	if err := r.annotateRelatedOps(reqCtx, opsRequest); err != nil {
+// handleSucceedOpsRequest the opsRequest will be deleted after its TTL when status.phase is Succeed
+func (r *OpsRequestReconciler) handleSucceedOpsRequest(reqCtx intctrlutil.RequestCtx, opsRes *operations.OpsResource) (*ctrl.Result, error) {
+	if err := r.annotateRelatedOps(reqCtx, opsRes.OpsRequest); err != nil {
 		return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
 	}
-	if err := r.deleteExternalJobs(reqCtx.Ctx, opsRequest); err != nil {
+	if err := r.deleteExternalJobs(reqCtx.Ctx, opsRes.OpsRequest); err != nil {
 		return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
 	}
-	if opsRequest.Status.CompletionTimestamp.IsZero() || opsRequest.Spec.TTLSecondsAfterSucceed == 0 {
-		return intctrlutil.ResultToP(intctrlutil.Reconciled())
-	}
-	deadline := opsRequest.Status.CompletionTimestamp.Add(time.Duration(opsRequest.Spec.TTLSecondsAfterSucceed) * time.Second)
-	if time.Now().Before(deadline) {
-		return intctrlutil.ResultToP(intctrlutil.RequeueAfter(time.Until(deadline), reqCtx.Log, ""))
-	}
-	// the opsRequest will be deleted after spec.ttlSecondsAfterSucceed seconds when status.phase is Succeed
-	if err := r.Client.Delete(reqCtx.Ctx, opsRequest); err != nil {
+	// the OpsRequest is kept over its TTL until the stopped components are started automatically.
+	if requeueAfter, err := operations.ReconcileStoppedPeriod(reqCtx, r.Client, opsRes); err != nil {
 		return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
+	} else if requeueAfter != 0 {
+		return intctrlutil.ResultToP(intctrlutil.RequeueAfter(requeueAfter, reqCtx.Log, ""))
 	}
-	return intctrlutil.ResultToP(intctrlutil.Reconciled())
+	return r.handleFinishedOpsRequest(reqCtx, opsRes)
 }
 
 func (r *OpsRequestReconciler) handleUnsuccessfulCompletionOpsRequest(reqCtx intctrlutil.RequestCtx, opsRes *operations.OpsResource) (*ctrl.Result, error) {
-	opsRequest := opsRes.OpsRequest
 	if err := r.annotateRelatedOps(reqCtx, opsRes.OpsRequest); err != nil {
 		return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
 	}
 	if err := r.cleanupOpsAnnotationForCluster(reqCtx, opsRes.Cluster); err != nil {
 		return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
 	}
-	if opsRequest.Status.CompletionTimestamp.IsZero() || opsRequest.Spec.TTLSecondsAfterUnsuccessfulCompletion == 0 {
+	return r.handleFinishedOpsRequest(reqCtx, opsRes)
+}
+
+// handleFinishedOpsRequest garbage-collects the finished OpsRequests of the cluster,
+// and deletes the OpsRequest after its TTL expires.
+func (r *OpsRequestReconciler) handleFinishedOpsRequest(reqCtx intctrlutil.RequestCtx, opsRes *operations.OpsResource) (*ctrl.Result, error) {
+	opsRequest := opsRes.OpsRequest
+	if err := operations.GCOpsRequestHistory(reqCtx, r.Client, opsRes.Cluster); err != nil {
+		return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
+	}
+	deadline, ok := operations.GetOpsRequestExpiration(opsRequest)
+	if !ok {
 		return intctrlutil.ResultToP(intctrlutil.Reconciled())
 	}
-	deadline := opsRequest.Status.CompletionTimestamp.Add(time.Duration(opsRequest.Spec.TTLSecondsAfterUnsuccessfulCompletion) * time.Second)
 	if time.Now().Before(deadline) {
 		return intctrlutil.ResultToP(intctrlutil.RequeueAfter(time.Until(deadline), reqCtx.Log, ""))
 	}
-	// the opsRequest will be deleted after spec.ttlSecondsAfterUnsuccessfulCompletion seconds when status.phase is Failed, Cancelled or Aborted
-	if err := r.Client.Delete(reqCtx.Ctx, opsRequest); err != nil {
+	if err := client.IgnoreNotFound(r.Client.Delete(reqCtx.Ctx, opsRequest)); err != nil {
 		return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
 	}
 	return intctrlutil.ResultToP(intctrlutil.Reconciled())
@@ -325,10 +337,8 @@ func (r *OpsRequestReconciler) doOpsRequestAction(reqCtx intctrlutil.RequestCtx,
 		if !apierrors.IsConflict(err) {
 			r.Recorder.Eventf(opsRequest, corev1.EventTypeWarning, reasonOpsDoActionFailed, "Failed to process the operation of OpsRequest: %s", err.Error())
 		}
-		if !reflect.DeepEqual(opsRequest.Status, opsDeepCopy.Status) {
-			if patchErr := r.Client.Status().Patch(reqCtx.Ctx, opsRequest, client.MergeFrom(opsDeepCopy)); patchErr != nil {
-				return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
-			}
+		if _, patchErr := intctrlutil.PatchStatus(reqCtx.Ctx, r.Client, opsRequest, opsDeepCopy); patchErr != nil {
+			return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
 		}
 		return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
 	}
@@ -336,9 +346,12 @@ func (r *OpsRequestReconciler) doOpsRequestAction(reqCtx intctrlutil.RequestCtx,
 	if res != nil {
 		return res, nil
 	}
+	opsRequest.AppendTimelineEntry(appsv1alpha1.PhaseChangedTimelineEntry, string(appsv1alpha1.OpsRunningPhase), "",
+		fmt.Sprintf("%s -> %s", opsRequest.Status.Phase, appsv1alpha1.OpsRunningPhase))
 	opsRequest.Status.Phase = appsv1alpha1.OpsRunningPhase
 	opsRequest.Status.ClusterGeneration = opsRes.Cluster.Generation
-	if err = r.Client.Status().Patch(reqCtx.Ctx, opsRequest, client.MergeFrom(opsDeepCopy)); err != nil {
+	opsRequest.SetStatusCondition(*appsv1alpha1.NewActionAppliedCondition(opsRequest))
+	if _, err = intctrlutil.PatchStatus(reqCtx.Ctx, r.Client, opsRequest, opsDeepCopy); err != nil {
 		return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
 	}
 	return intctrlutil.ResultToP(intctrlutil.Reconciled())
@@ -374,43 +387,19 @@ func (r *OpsRequestReconciler) getRunningOpsRequestsFromCluster(cluster *appsv1a
 		opsRequestSlice []appsv1alpha1.OpsRecorder
 		err             error
 		requests        []reconcile.Request
-		clusterType     = "cluster"
-		typeSet         = map[string]appsv1alpha1.OpsRecorder{}
 	)
 	if opsRequestSlice, err = opsutil.GetOpsRequestSliceFromCluster(cluster); err != nil {
 		return nil
 	}
+	// append the running opsRequests, and the queued ones as well, since whether a queued opsRequest
+	// can run depends on the conflict policies of its type rather than the position in the queue.
 	for i := range opsRequestSlice {
-		ops := opsRequestSlice[i]
-		if !ops.InQueue {
-			// append running opsRequest
-			requests = append(requests, reconcile.Request{
-				NamespacedName: types.NamespacedName{
-					Namespace: cluster.Namespace,
-					Name:      ops.Name,
-				},
-			})
-		}
-		opsType := string(ops.Type)
-		if !opsRequestSlice[i].QueueBySelf {
-			// If opsRequest is not the type-scope queue, unified as "cluster" scope.
-			opsType = clusterType
-		}
-		if _, ok := typeSet[opsType]; !ok {
-			typeSet[opsType] = ops
-		}
-	}
-
-	for _, v := range typeSet {
-		if v.InQueue {
-			// append the first opsRequest which is in the queue.
-			requests = append(requests, reconcile.Request{
-				NamespacedName: types.NamespacedName{
-					Namespace: cluster.Namespace,
-					Name:      v.Name,
-				},
-			})
-		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: cluster.Namespace,
+				Name:      opsRequestSlice[i].Name,
+			},
+		})
 	}
 	return requests
 }
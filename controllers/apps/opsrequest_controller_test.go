@@ -709,8 +709,8 @@ var _ = Describe("OpsRequest Controller", func() {
 			opsName := fmt.Sprintf("restart-ops-%d", index)
 			ops := testapps.NewOpsRequestObj(opsName, testCtx.DefaultNamespace,
 				clusterName, appsv1alpha1.RestartType)
-			ops.Spec.RestartList = []appsv1alpha1.ComponentOps{
-				{ComponentName: mysqlCompName},
+			ops.Spec.RestartList = []appsv1alpha1.Restart{
+				{ComponentOps: appsv1alpha1.ComponentOps{ComponentName: mysqlCompName}},
 			}
 			if len(force) > 0 {
 				ops.Spec.Force = force[0]
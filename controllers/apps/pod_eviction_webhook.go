@@ -0,0 +1,167 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/controllers/apps/operations"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+const (
+	// PodEvictionWebhookPath is the path to serve the pod eviction webhook.
+	PodEvictionWebhookPath = "/validate-v1-pod-eviction"
+
+	reasonEvictionSwitchover = "EvictionSwitchover"
+)
+
+// PodEvictionHandler intercepts the evictions of the writable instances of KubeBlocks, e.g. issued by
+// the cluster-autoscaler, the descheduler or the node drain, it hands over the writable role to another instance
+// by a switchover OpsRequest first, and only allows the eviction once the instance is no longer writable.
+//
+// The evictions are denied with 429 TooManyRequests in the meantime, which the eviction clients retry,
+// the same as the evictions blocked by a PodDisruptionBudget.
+type PodEvictionHandler struct {
+	Client   client.Client
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:webhook:path=/validate-v1-pod-eviction,mutating=false,failurePolicy=ignore,sideEffects=NoneOnDryRun,groups="",resources=pods/eviction,verbs=create,versions=v1,name=vpodeviction.kb.io,admissionReviewVersions=v1
+
+var _ admission.Handler = &PodEvictionHandler{}
+
+// Handle handles the eviction of the pod.
+func (h *PodEvictionHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	pod := &corev1.Pod{}
+	if err := h.Client.Get(ctx, types.NamespacedName{Namespace: req.Namespace, Name: req.Name}, pod); err != nil {
+		if apierrors.IsNotFound(err) {
+			return admission.Allowed("")
+		}
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if pod.Labels[constant.AppManagedByLabelKey] != constant.AppName ||
+		pod.Labels[constant.AccessModeLabelKey] != string(appsv1alpha1.ReadWrite) {
+		return admission.Allowed("")
+	}
+
+	cluster := &appsv1alpha1.Cluster{}
+	if err := h.Client.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: pod.Labels[constant.AppInstanceLabelKey]}, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return admission.Allowed("")
+		}
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	compName := pod.Labels[constant.KBAppComponentLabelKey]
+	// the switchover OpsRequest does not support the components of shardings yet.
+	compSpec := cluster.Spec.GetComponentByName(compName)
+	if !cluster.DeletionTimestamp.IsZero() || compSpec == nil || compSpec.Replicas < 2 {
+		return admission.Allowed("there is no other instance to hand over the writable role to")
+	}
+
+	opsRequest := &appsv1alpha1.OpsRequest{}
+	opsKey := evictionSwitchoverOpsKey(pod)
+	if err := h.Client.Get(ctx, opsKey, opsRequest); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		if req.DryRun != nil && *req.DryRun {
+			return evictionTooManyRequests(fmt.Sprintf("the instance %s is writable, it will be switched over before the eviction", pod.Name))
+		}
+		if err = h.Client.Create(ctx, buildEvictionSwitchoverOps(opsKey, cluster.Name, compName)); err != nil && !apierrors.IsAlreadyExists(err) {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		h.Recorder.Eventf(pod, corev1.EventTypeNormal, reasonEvictionSwitchover,
+			"switching over the writable role of the instance before the eviction by OpsRequest %s", opsKey.Name)
+		return evictionTooManyRequests(fmt.Sprintf("the instance %s is writable, switching over by OpsRequest %s before the eviction", pod.Name, opsKey.Name))
+	}
+
+	switch opsRequest.Status.Phase {
+	case appsv1alpha1.OpsSucceedPhase:
+		// the instance takes the writable role again after the last switchover, start over.
+		if req.DryRun == nil || !*req.DryRun {
+			if err := h.Client.Delete(ctx, opsRequest); client.IgnoreNotFound(err) != nil {
+				return admission.Errored(http.StatusInternalServerError, err)
+			}
+		}
+		return evictionTooManyRequests(fmt.Sprintf("the instance %s is writable again after OpsRequest %s, it will be switched over before the eviction", pod.Name, opsKey.Name))
+	case appsv1alpha1.OpsFailedPhase, appsv1alpha1.OpsAbortedPhase, appsv1alpha1.OpsCancelledPhase:
+		return evictionTooManyRequests(fmt.Sprintf("the switchover OpsRequest %s of the instance %s is %s, delete it to retry the switchover",
+			opsKey.Name, pod.Name, opsRequest.Status.Phase))
+	default:
+		return evictionTooManyRequests(fmt.Sprintf("the instance %s is writable, waiting for the switchover OpsRequest %s before the eviction", pod.Name, opsKey.Name))
+	}
+}
+
+// evictionSwitchoverOpsKey returns the key of the switchover OpsRequest before evicting the pod.
+func evictionSwitchoverOpsKey(pod *corev1.Pod) types.NamespacedName {
+	return types.NamespacedName{Namespace: pod.Namespace, Name: fmt.Sprintf("%s-eviction-switchover", pod.Name)}
+}
+
+// buildEvictionSwitchoverOps builds the switchover OpsRequest to hand over the writable role of the component to any other instance.
+func buildEvictionSwitchoverOps(opsKey types.NamespacedName, clusterName, compName string) *appsv1alpha1.OpsRequest {
+	return &appsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: opsKey.Namespace,
+			Name:      opsKey.Name,
+			Labels: map[string]string{
+				constant.AppInstanceLabelKey:    clusterName,
+				constant.OpsRequestTypeLabelKey: string(appsv1alpha1.SwitchoverType),
+			},
+		},
+		Spec: appsv1alpha1.OpsRequestSpec{
+			ClusterName: clusterName,
+			Type:        appsv1alpha1.SwitchoverType,
+			SpecificOpsRequest: appsv1alpha1.SpecificOpsRequest{
+				SwitchoverList: []appsv1alpha1.Switchover{
+					{
+						ComponentOps: appsv1alpha1.ComponentOps{ComponentName: compName},
+						InstanceName: operations.KBSwitchoverCandidateInstanceForAnyPod,
+					},
+				},
+			},
+		},
+	}
+}
+
+func evictionTooManyRequests(message string) admission.Response {
+	return admission.Response{
+		AdmissionResponse: admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Code:    http.StatusTooManyRequests,
+				Reason:  metav1.StatusReasonTooManyRequests,
+				Message: message,
+			},
+		},
+	}
+}
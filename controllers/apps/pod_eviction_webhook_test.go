@@ -0,0 +1,142 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+var _ = Describe("pod eviction webhook", func() {
+	const (
+		namespace   = "default"
+		clusterName = "mycluster"
+		compName    = "mysql"
+	)
+
+	newPod := func(name string, accessMode appsv1alpha1.AccessMode) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+				Labels: map[string]string{
+					constant.AppManagedByLabelKey:   constant.AppName,
+					constant.AppInstanceLabelKey:    clusterName,
+					constant.KBAppComponentLabelKey: compName,
+					constant.AccessModeLabelKey:     string(accessMode),
+				},
+			},
+		}
+	}
+
+	newRequest := func(pod *corev1.Pod) admission.Request {
+		return admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Namespace: pod.Namespace,
+				Name:      pod.Name,
+				Operation: admissionv1.Create,
+			},
+		}
+	}
+
+	It("switches over the writable instance before allowing the eviction", func() {
+		cluster := &appsv1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: clusterName},
+			Spec: appsv1alpha1.ClusterSpec{
+				ComponentSpecs: []appsv1alpha1.ClusterComponentSpec{{Name: compName, Replicas: 2}},
+			},
+		}
+		primary := newPod(constant.GenerateClusterComponentName(clusterName, compName)+"-0", appsv1alpha1.ReadWrite)
+		secondary := newPod(constant.GenerateClusterComponentName(clusterName, compName)+"-1", appsv1alpha1.Readonly)
+
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+		Expect(appsv1alpha1.AddToScheme(scheme)).Should(Succeed())
+		cli := fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(cluster, primary, secondary).WithStatusSubresource(&appsv1alpha1.OpsRequest{}).Build()
+		handler := &PodEvictionHandler{Client: cli, Recorder: record.NewFakeRecorder(10)}
+		ctx := context.Background()
+
+		By("the eviction of the secondary is allowed")
+		Expect(handler.Handle(ctx, newRequest(secondary)).Allowed).Should(BeTrue())
+
+		By("the eviction of the primary is denied until the switchover is done")
+		resp := handler.Handle(ctx, newRequest(primary))
+		Expect(resp.Allowed).Should(BeFalse())
+		Expect(resp.Result.Code).Should(BeEquivalentTo(http.StatusTooManyRequests))
+		opsRequest := &appsv1alpha1.OpsRequest{}
+		opsKey := types.NamespacedName{Namespace: namespace, Name: primary.Name + "-eviction-switchover"}
+		Expect(cli.Get(ctx, opsKey, opsRequest)).Should(Succeed())
+		Expect(opsRequest.Spec.Type).Should(Equal(appsv1alpha1.SwitchoverType))
+		Expect(opsRequest.Spec.SwitchoverList).Should(HaveLen(1))
+		Expect(opsRequest.Spec.SwitchoverList[0].ComponentName).Should(Equal(compName))
+		Expect(handler.Handle(ctx, newRequest(primary)).Allowed).Should(BeFalse())
+
+		By("the eviction is allowed once the instance is no longer writable")
+		primary.Labels[constant.AccessModeLabelKey] = string(appsv1alpha1.Readonly)
+		Expect(cli.Update(ctx, primary)).Should(Succeed())
+		Expect(handler.Handle(ctx, newRequest(primary)).Allowed).Should(BeTrue())
+
+		By("the succeed switchover is started over if the instance becomes writable again")
+		primary.Labels[constant.AccessModeLabelKey] = string(appsv1alpha1.ReadWrite)
+		Expect(cli.Update(ctx, primary)).Should(Succeed())
+		opsRequest.Status.Phase = appsv1alpha1.OpsSucceedPhase
+		Expect(cli.Status().Update(ctx, opsRequest)).Should(Succeed())
+		Expect(handler.Handle(ctx, newRequest(primary)).Allowed).Should(BeFalse())
+		Expect(cli.Get(ctx, opsKey, &appsv1alpha1.OpsRequest{})).ShouldNot(Succeed())
+	})
+
+	It("allows the eviction if there is no other instance", func() {
+		cluster := &appsv1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: clusterName},
+			Spec: appsv1alpha1.ClusterSpec{
+				ComponentSpecs: []appsv1alpha1.ClusterComponentSpec{{Name: compName, Replicas: 1}},
+			},
+		}
+		primary := newPod(constant.GenerateClusterComponentName(clusterName, compName)+"-0", appsv1alpha1.ReadWrite)
+
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+		Expect(appsv1alpha1.AddToScheme(scheme)).Should(Succeed())
+		cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, primary).Build()
+		handler := &PodEvictionHandler{Client: cli, Recorder: record.NewFakeRecorder(10)}
+		Expect(handler.Handle(context.Background(), newRequest(primary)).Allowed).Should(BeTrue())
+		opsList := &appsv1alpha1.OpsRequestList{}
+		Expect(cli.List(context.Background(), opsList, client.InNamespace(namespace))).Should(Succeed())
+		Expect(opsList.Items).Should(BeEmpty())
+	})
+})
@@ -28,6 +28,8 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
@@ -131,6 +133,15 @@ func sendWarningEventWithError(
 	recorder.Event(obj, corev1.EventTypeWarning, reason, err.Error())
 }
 
+// patchTerminalErrorCondition records the terminal error in the ApplyResources condition of the object,
+// so that the reconciliation, which will not be retried, does not stop silently.
+func patchTerminalErrorCondition(ctx context.Context, cli client.Client,
+	obj client.Object, conditions *[]metav1.Condition, err error) error {
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	meta.SetStatusCondition(conditions, newTerminalErrorCondition(obj.GetGeneration(), err))
+	return cli.Status().Patch(ctx, obj, patch)
+}
+
 func isVolumeResourceRequirementsEqual(a, b corev1.VolumeResourceRequirements) bool {
 	return isResourceEqual(a.Requests, b.Requests) && isResourceEqual(a.Limits, b.Limits)
 }
@@ -0,0 +1,85 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// clusterAdoptionTransformer generates the specs of the components to adopt the existing StatefulSets,
+// the StatefulSets are taken over by the components later in the component controller.
+type clusterAdoptionTransformer struct{}
+
+var _ graph.Transformer = &clusterAdoptionTransformer{}
+
+func (t *clusterAdoptionTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*clusterTransformContext)
+	cluster := transCtx.Cluster
+	if model.IsObjectDeleting(cluster) {
+		return nil
+	}
+
+	comps := intctrlutil.GetComponentsToAdopt(cluster)
+	if len(comps) == 0 {
+		return nil
+	}
+
+	declared := sets.New[string]()
+	for _, compSpec := range cluster.Spec.ComponentSpecs {
+		declared.Insert(compSpec.Name)
+	}
+	for _, shardingSpec := range cluster.Spec.ShardingSpecs {
+		declared.Insert(shardingSpec.Name)
+	}
+
+	var generated []string
+	for _, compName := range sets.List(sets.KeySet(comps)) {
+		compDef := comps[compName]
+		if len(compDef) == 0 || declared.Has(compName) {
+			continue
+		}
+		sts := &appsv1.StatefulSet{}
+		stsKey := client.ObjectKey{Namespace: cluster.Namespace, Name: constant.GenerateClusterComponentName(cluster.Name, compName)}
+		if err := transCtx.Client.Get(transCtx.Context, stsKey, sts); err != nil {
+			return fmt.Errorf("failed to get the StatefulSet %s to adopt: %s", stsKey.Name, err.Error())
+		}
+		compSpec := intctrlutil.BuildComponentSpecForAdoption(sts, compName, compDef)
+		cluster.Spec.ComponentSpecs = append(cluster.Spec.ComponentSpecs, compSpec)
+		generated = append(generated, compName)
+	}
+	if len(generated) == 0 {
+		return nil
+	}
+
+	transCtx.EventRecorder.Eventf(cluster, corev1.EventTypeNormal, workloadAdoptedReason,
+		"the components %v are generated to adopt the existing StatefulSets", generated)
+	// the spec is updated along with the cluster object, the components are created in the next reconciliation.
+	return graph.ErrPrematureStop
+}
@@ -0,0 +1,101 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+)
+
+var _ = Describe("clusterAdoptionTransformer", func() {
+	var (
+		transCtx *clusterTransformContext
+		reader   *mockReader
+		dag      *graph.DAG
+		cluster  *appsv1alpha1.Cluster
+	)
+
+	BeforeEach(func() {
+		cluster = &appsv1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      "test-cluster",
+				Annotations: map[string]string{
+					constant.AdoptStatefulSetsAnnotationKey: "mysql:mysql-8.0,proxy",
+				},
+			},
+		}
+		reader = &mockReader{}
+		transCtx = &clusterTransformContext{
+			Context:       testCtx.Ctx,
+			Client:        model.NewGraphClient(reader),
+			EventRecorder: clusterRecorder,
+			Logger:        logger,
+			Cluster:       cluster.DeepCopy(),
+			OrigCluster:   cluster,
+		}
+		dag = graph.NewDAG()
+		transCtx.Client.(model.GraphClient).Root(dag, transCtx.OrigCluster, transCtx.Cluster, model.ActionStatusPtr())
+	})
+
+	It("generates the component to adopt the StatefulSet", func() {
+		reader.objs = []client.Object{
+			&appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testCtx.DefaultNamespace,
+					Name:      "test-cluster-mysql",
+				},
+				Spec: appsv1.StatefulSetSpec{
+					Replicas: pointer.Int32(3),
+				},
+			},
+		}
+
+		transformer := &clusterAdoptionTransformer{}
+		Expect(transformer.Transform(transCtx, dag)).Should(Equal(graph.ErrPrematureStop))
+		Expect(transCtx.Cluster.Spec.ComponentSpecs).Should(HaveLen(1))
+		compSpec := transCtx.Cluster.Spec.ComponentSpecs[0]
+		Expect(compSpec.Name).Should(Equal("mysql"))
+		Expect(compSpec.ComponentDef).Should(Equal("mysql-8.0"))
+		Expect(compSpec.Replicas).Should(BeEquivalentTo(3))
+
+		By("the declared component is not generated again")
+		Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+		Expect(transCtx.Cluster.Spec.ComponentSpecs).Should(HaveLen(1))
+	})
+
+	It("fails if the StatefulSet to adopt is not found", func() {
+		transformer := &clusterAdoptionTransformer{}
+		err := transformer.Transform(transCtx, dag)
+		Expect(err).ShouldNot(BeNil())
+		Expect(err.Error()).Should(ContainSubstring("test-cluster-mysql"))
+		Expect(transCtx.Cluster.Spec.ComponentSpecs).Should(BeEmpty())
+	})
+})
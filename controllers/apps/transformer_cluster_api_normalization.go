@@ -28,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/common"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
@@ -76,6 +77,49 @@ func (t *ClusterAPINormalizationTransformer) Transform(ctx graph.TransformContex
 }
 
 func (t *ClusterAPINormalizationTransformer) validateSpec(cluster *appsv1alpha1.Cluster) error {
+	if err := component.ValidateIPStack(cluster.Spec.IPStack); err != nil {
+		return err
+	}
+	for _, v := range cluster.Spec.ComponentSpecs {
+		if err := component.ValidatePodTemplateOverlay(v.PodTemplateOverlay); err != nil {
+			return fmt.Errorf("component %s: %s", v.Name, err.Error())
+		}
+		if err := component.ValidateKernelTuning(v.KernelTuning); err != nil {
+			return fmt.Errorf("component %s: %s", v.Name, err.Error())
+		}
+		if err := component.ValidateHostNetwork(v.HostNetwork); err != nil {
+			return fmt.Errorf("component %s: %s", v.Name, err.Error())
+		}
+		if err := component.ValidateReplicaWeights(v.ReplicaWeights, v.Instances); err != nil {
+			return fmt.Errorf("component %s: %s", v.Name, err.Error())
+		}
+		if err := validateCompNames(cluster.Name, v.Name, v.Replicas, v.Instances); err != nil {
+			return err
+		}
+	}
+	for _, v := range cluster.Spec.ShardingSpecs {
+		if err := component.ValidatePodTemplateOverlay(v.Template.PodTemplateOverlay); err != nil {
+			return fmt.Errorf("sharding %s: %s", v.Name, err.Error())
+		}
+		if err := component.ValidateKernelTuning(v.Template.KernelTuning); err != nil {
+			return fmt.Errorf("sharding %s: %s", v.Name, err.Error())
+		}
+		if err := component.ValidateHostNetwork(v.Template.HostNetwork); err != nil {
+			return fmt.Errorf("sharding %s: %s", v.Name, err.Error())
+		}
+		if err := component.ValidateReplicaWeights(v.Template.ReplicaWeights, v.Template.Instances); err != nil {
+			return fmt.Errorf("sharding %s: %s", v.Name, err.Error())
+		}
+		// the names of the shard components are generated with a random suffix of fixed length.
+		shardCompName := common.SimpleNameGenerator.GenerateName(constant.GenerateShardingNamePrefix(v.Name))
+		if err := validateCompNames(cluster.Name, shardCompName, v.Template.Replicas, v.Template.Instances); err != nil {
+			return fmt.Errorf("sharding %s: %s", v.Name, err.Error())
+		}
+		// the shards would conflict on the same static host ports
+		if v.Template.HostNetwork != nil && len(v.Template.HostNetwork.StaticPorts) > 0 && v.Shards > 1 {
+			return fmt.Errorf("sharding %s: the static host ports are not supported for multiple shards", v.Name)
+		}
+	}
 	if len(cluster.Spec.ShardingSpecs) == 0 {
 		return nil
 	}
@@ -91,6 +135,19 @@ func (t *ClusterAPINormalizationTransformer) validateSpec(cluster *appsv1alpha1.
 	return nil
 }
 
+// validateCompNames checks the names generated for the component in advance, the cluster is rejected rather
+// than failing to create the invalid objects later in the reconciliation.
+func validateCompNames(clusterName, compName string, replicas int32, instances []appsv1alpha1.InstanceTemplate) error {
+	templates := make(map[string]int32, len(instances))
+	for _, tpl := range instances {
+		templates[tpl.Name] = 1
+		if tpl.Replicas != nil {
+			templates[tpl.Name] = *tpl.Replicas
+		}
+	}
+	return common.ValidateComponentNames(clusterName, compName, replicas, templates)
+}
+
 func (t *ClusterAPINormalizationTransformer) buildCompSpecs(transCtx *clusterTransformContext,
 	cluster *appsv1alpha1.Cluster) ([]*appsv1alpha1.ClusterComponentSpec, error) {
 	if withClusterTopology(cluster) {
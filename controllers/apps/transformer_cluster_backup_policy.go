@@ -352,6 +352,12 @@ func (r *clusterBackupPolicyTransformer) syncBackupPolicy(comp componentItem, ba
 		backupPolicy.Spec.BackupRepoName = &r.Cluster.Spec.Backup.RepoName
 	}
 	backupPolicy.Spec.BackoffLimit = r.backupPolicy.BackoffLimit
+	if r.backupPolicy.PriorityClassName != "" {
+		backupPolicy.Spec.PriorityClassName = r.backupPolicy.PriorityClassName
+	}
+	if r.backupPolicy.TTLSecondsAfterFinished != nil {
+		backupPolicy.Spec.TTLSecondsAfterFinished = r.backupPolicy.TTLSecondsAfterFinished
+	}
 	r.syncBackupMethods(backupPolicy, comp)
 	r.syncBackupPolicyTargetSpec(backupPolicy, comp)
 }
@@ -408,6 +414,8 @@ func (r *clusterBackupPolicyTransformer) buildBackupPolicy(comp componentItem, b
 	}
 	bpSpec.PathPrefix = buildBackupPathPrefix(cluster, comp.componentName)
 	bpSpec.BackoffLimit = r.backupPolicy.BackoffLimit
+	bpSpec.PriorityClassName = r.backupPolicy.PriorityClassName
+	bpSpec.TTLSecondsAfterFinished = r.backupPolicy.TTLSecondsAfterFinished
 	backupPolicy.Spec = bpSpec
 	r.setDefaultEncryptionConfig(backupPolicy)
 	r.syncBackupPolicyTargetSpec(backupPolicy, comp)
@@ -222,11 +222,14 @@ func copyAndMergeComponent(oldCompObj, newCompObj *appsv1alpha1.Component) *apps
 	compObjCopy.Spec.PodUpdatePolicy = compProto.Spec.PodUpdatePolicy
 	compObjCopy.Spec.Affinity = compProto.Spec.Affinity
 	compObjCopy.Spec.Tolerations = compProto.Spec.Tolerations
+	compObjCopy.Spec.SchedulingHints = compProto.Spec.SchedulingHints
 	compObjCopy.Spec.TLSConfig = compProto.Spec.TLSConfig
 	compObjCopy.Spec.Instances = compProto.Spec.Instances
 	compObjCopy.Spec.OfflineInstances = compProto.Spec.OfflineInstances
+	compObjCopy.Spec.InstanceIP = compProto.Spec.InstanceIP
 	compObjCopy.Spec.RuntimeClassName = compProto.Spec.RuntimeClassName
 	compObjCopy.Spec.DisableExporter = compProto.Spec.DisableExporter
+	compObjCopy.Spec.BackupReplica = compProto.Spec.BackupReplica
 	compObjCopy.Spec.Stop = compProto.Spec.Stop
 
 	if reflect.DeepEqual(oldCompObj.Annotations, compObjCopy.Annotations) &&
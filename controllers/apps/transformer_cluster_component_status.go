@@ -21,14 +21,18 @@ package apps
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
@@ -58,6 +62,10 @@ func (t *clusterComponentStatusTransformer) reconcileComponentsStatus(transCtx *
 		cluster.Status.Components = make(map[string]appsv1alpha1.ClusterComponentStatus)
 	}
 	// We cannot use cluster.status.components here because of simplified API generated component is not in it.
+	var (
+		riskMessages          []string
+		notConvergedCompNames []string
+	)
 	for _, compSpec := range transCtx.ComponentSpecs {
 		compKey := types.NamespacedName{
 			Namespace: cluster.Namespace,
@@ -66,15 +74,52 @@ func (t *clusterComponentStatusTransformer) reconcileComponentsStatus(transCtx *
 		comp := &appsv1alpha1.Component{}
 		if err := transCtx.Client.Get(transCtx.Context, compKey, comp); err != nil {
 			if apierrors.IsNotFound(err) {
+				notConvergedCompNames = append(notConvergedCompNames, compSpec.Name)
 				continue
 			}
 			return err
 		}
-		cluster.Status.Components[compSpec.Name] = t.buildClusterCompStatus(transCtx, comp, compSpec.Name)
+		status := t.buildClusterCompStatus(transCtx, comp, compSpec.Name)
+		cluster.Status.Components[compSpec.Name] = status
+		if status.LastAppliedGeneration != cluster.Generation {
+			notConvergedCompNames = append(notConvergedCompNames, compSpec.Name)
+		}
+		if cond := meta.FindStatusCondition(comp.Status.Conditions, appsv1alpha1.ConditionTypeServiceVersionRisk); cond != nil {
+			riskMessages = append(riskMessages, fmt.Sprintf("%s: %s", compSpec.Name, cond.Message))
+		}
 	}
+	t.syncServiceVersionRiskCondition(cluster, riskMessages)
+	t.syncConvergedCondition(cluster, notConvergedCompNames)
 	return nil
 }
 
+// syncConvergedCondition sets the Converged condition, which turns true only when all the components
+// have rolled out the current generation of the cluster.
+func (t *clusterComponentStatusTransformer) syncConvergedCondition(cluster *appsv1alpha1.Cluster, notConvergedCompNames []string) {
+	if len(notConvergedCompNames) > 0 {
+		meta.SetStatusCondition(&cluster.Status.Conditions, newNotConvergedCondition(cluster.Generation, notConvergedCompNames))
+		return
+	}
+	meta.SetStatusCondition(&cluster.Status.Conditions, newConvergedCondition(cluster.Generation))
+}
+
+// syncServiceVersionRiskCondition surfaces the components running service versions which are end of life
+// or have known vulnerabilities.
+func (t *clusterComponentStatusTransformer) syncServiceVersionRiskCondition(cluster *appsv1alpha1.Cluster, messages []string) {
+	if len(messages) == 0 {
+		meta.RemoveStatusCondition(&cluster.Status.Conditions, appsv1alpha1.ConditionTypeServiceVersionRisk)
+		return
+	}
+	slices.Sort(messages)
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:               appsv1alpha1.ConditionTypeServiceVersionRisk,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: cluster.Generation,
+		Reason:             ReasonServiceVersionRisk,
+		Message:            strings.Join(messages, "; "),
+	})
+}
+
 // buildClusterCompStatus builds cluster component status from specified component object.
 func (t *clusterComponentStatusTransformer) buildClusterCompStatus(transCtx *clusterTransformContext,
 	comp *appsv1alpha1.Component, compName string) appsv1alpha1.ClusterComponentStatus {
@@ -111,6 +156,9 @@ func (t *clusterComponentStatusTransformer) updateClusterComponentStatus(comp *a
 			}
 		}
 	}
+	if generation, ok := t.getAppliedGeneration(comp); ok {
+		status.LastAppliedGeneration = generation
+	}
 	// if ready flag not changed, don't update the ready time
 	ready := t.isClusterComponentPodsReady(comp.Status.Phase)
 	if status.PodsReady == nil || *status.PodsReady != ready {
@@ -122,6 +170,23 @@ func (t *clusterComponentStatusTransformer) updateClusterComponentStatus(comp *a
 	}
 }
 
+// getAppliedGeneration returns the generation of the cluster which has been rolled out to the component.
+// The component is rolled out only if the component controller has observed the latest spec of the component
+// and the workload has been updated to it, which is indicated by the Running or Stopped phase.
+func (t *clusterComponentStatusTransformer) getAppliedGeneration(comp *appsv1alpha1.Component) (int64, bool) {
+	if comp.Status.ObservedGeneration != comp.Generation {
+		return 0, false
+	}
+	if comp.Status.Phase != appsv1alpha1.RunningClusterCompPhase && comp.Status.Phase != appsv1alpha1.StoppedClusterCompPhase {
+		return 0, false
+	}
+	generation, err := strconv.ParseInt(comp.Annotations[constant.KubeBlocksGenerationKey], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return generation, true
+}
+
 func (t *clusterComponentStatusTransformer) isClusterComponentPodsReady(phase appsv1alpha1.ClusterComponentPhase) bool {
 	podsReadyPhases := []appsv1alpha1.ClusterComponentPhase{
 		appsv1alpha1.RunningClusterCompPhase,
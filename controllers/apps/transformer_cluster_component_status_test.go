@@ -0,0 +1,107 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+)
+
+var _ = Describe("cluster component status transformer", func() {
+	const (
+		namespace   = "default"
+		clusterName = "mycluster"
+	)
+
+	newComponent := func(compName, clusterGeneration string, phase appsv1alpha1.ClusterComponentPhase) *appsv1alpha1.Component {
+		return &appsv1alpha1.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   namespace,
+				Name:        component.FullName(clusterName, compName),
+				Generation:  1,
+				Annotations: map[string]string{constant.KubeBlocksGenerationKey: clusterGeneration},
+			},
+			Status: appsv1alpha1.ComponentStatus{
+				ObservedGeneration: 1,
+				Phase:              phase,
+			},
+		}
+	}
+
+	It("tracks the convergence of the components", func() {
+		cluster := &appsv1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: clusterName, Generation: 2},
+			Status:     appsv1alpha1.ClusterStatus{ObservedGeneration: 2},
+		}
+		compSpecs := []*appsv1alpha1.ClusterComponentSpec{{Name: "mysql"}, {Name: "proxy"}}
+
+		reconcile := func(comps ...*appsv1alpha1.Component) {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+			Expect(appsv1alpha1.AddToScheme(scheme)).Should(Succeed())
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			for _, comp := range comps {
+				builder.WithObjects(comp)
+			}
+			transCtx := &clusterTransformContext{
+				Context:        context.Background(),
+				Client:         builder.Build(),
+				EventRecorder:  record.NewFakeRecorder(10),
+				Logger:         logr.Discard(),
+				Cluster:        cluster,
+				OrigCluster:    cluster.DeepCopy(),
+				ComponentSpecs: compSpecs,
+			}
+			Expect((&clusterComponentStatusTransformer{}).reconcileComponentsStatus(transCtx)).Should(Succeed())
+		}
+
+		By("the proxy component is still updating")
+		reconcile(newComponent("mysql", "2", appsv1alpha1.RunningClusterCompPhase),
+			newComponent("proxy", "1", appsv1alpha1.UpdatingClusterCompPhase))
+		Expect(cluster.Status.Components["mysql"].LastAppliedGeneration).Should(BeEquivalentTo(2))
+		Expect(cluster.Status.Components["proxy"].LastAppliedGeneration).Should(BeEquivalentTo(0))
+		cond := meta.FindStatusCondition(cluster.Status.Conditions, appsv1alpha1.ConditionTypeConverged)
+		Expect(cond).ShouldNot(BeNil())
+		Expect(cond.Status).Should(Equal(metav1.ConditionFalse))
+		Expect(cond.Message).Should(ContainSubstring("proxy"))
+
+		By("all the components are rolled out")
+		reconcile(newComponent("mysql", "2", appsv1alpha1.RunningClusterCompPhase),
+			newComponent("proxy", "2", appsv1alpha1.RunningClusterCompPhase))
+		Expect(cluster.Status.Components["proxy"].LastAppliedGeneration).Should(BeEquivalentTo(2))
+		cond = meta.FindStatusCondition(cluster.Status.Conditions, appsv1alpha1.ConditionTypeConverged)
+		Expect(cond.Status).Should(Equal(metav1.ConditionTrue))
+		Expect(cond.ObservedGeneration).Should(BeEquivalentTo(2))
+	})
+})
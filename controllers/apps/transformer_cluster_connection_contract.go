@@ -0,0 +1,226 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controller/plan"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+// clusterConnectionContractTransformer publishes the connection contract of the cluster into a well-known Secret,
+// so that the applications can mount one Secret to find the endpoints, credentials and TLS material of the cluster.
+type clusterConnectionContractTransformer struct{}
+
+var _ graph.Transformer = &clusterConnectionContractTransformer{}
+
+func (t *clusterConnectionContractTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*clusterTransformContext)
+	if model.IsObjectDeleting(transCtx.OrigCluster) {
+		return nil
+	}
+
+	cluster := transCtx.Cluster
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+
+	contract, err := t.buildContract(transCtx, cluster)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(contract)
+	if err != nil {
+		return err
+	}
+	secret := builder.NewSecretBuilder(cluster.Namespace, constant.GenerateClusterConnectionContractName(cluster.Name)).
+		AddLabelsInMap(constant.GetClusterWellKnownLabels(cluster.Name)).
+		SetData(map[string][]byte{intctrlutil.ConnectionContractDataKey: data}).
+		GetObject()
+
+	runningSecret := &corev1.Secret{}
+	if err = transCtx.Client.Get(transCtx.Context, client.ObjectKeyFromObject(secret), runningSecret); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		graphCli.Create(dag, secret)
+		return nil
+	}
+	if string(runningSecret.Data[intctrlutil.ConnectionContractDataKey]) != string(data) {
+		secretCopy := runningSecret.DeepCopy()
+		secretCopy.Data = secret.Data
+		graphCli.Update(dag, runningSecret, secretCopy)
+	}
+	return nil
+}
+
+func (t *clusterConnectionContractTransformer) buildContract(transCtx *clusterTransformContext,
+	cluster *appsv1alpha1.Cluster) (*intctrlutil.ConnectionContract, error) {
+	contract := &intctrlutil.ConnectionContract{
+		Version:    intctrlutil.ConnectionContractVersion,
+		Cluster:    cluster.Name,
+		Namespace:  cluster.Namespace,
+		Components: make([]intctrlutil.ComponentConnection, 0),
+	}
+	comps := map[string]*intctrlutil.ComponentConnection{}
+	compDefs := map[string]*appsv1alpha1.ComponentDefinition{}
+	for _, compSpec := range transCtx.ComponentSpecs {
+		conn := intctrlutil.ComponentConnection{Name: compSpec.Name}
+		if compSpec.TLS {
+			conn.TLS = t.buildTLSRef(cluster, compSpec)
+		}
+		compDef := transCtx.ComponentDefs[compSpec.ComponentDef]
+		if compDef != nil && component.IsClusterCompHostNetworkEnabled(cluster, compSpec, compDef) {
+			hostPorts, err := t.buildHostPorts(cluster, compSpec, compDef)
+			if err != nil {
+				return nil, err
+			}
+			conn.HostNetwork = true
+			conn.HostPorts = hostPorts
+		}
+		contract.Components = append(contract.Components, conn)
+		compDefs[compSpec.Name] = compDef
+	}
+	for i := range contract.Components {
+		comps[contract.Components[i].Name] = &contract.Components[i]
+	}
+
+	services, err := t.listServices(transCtx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	clusterDomain := viper.GetString(constant.KubernetesClusterDomainEnv)
+	for i, svc := range services {
+		compName := svc.Labels[constant.KBAppComponentLabelKey]
+		if len(compName) == 0 {
+			contract.Endpoints = append(contract.Endpoints, intctrlutil.BuildConnectionEndpoint(&services[i], clusterDomain, nil))
+			continue
+		}
+		conn, ok := comps[compName]
+		if !ok {
+			continue
+		}
+		conn.Endpoints = append(conn.Endpoints, intctrlutil.BuildConnectionEndpoint(&services[i], clusterDomain, roleWritable(compDefs[compName])))
+	}
+
+	secrets, err := t.listAccountSecrets(transCtx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	for _, secret := range secrets {
+		conn, ok := comps[secret.Labels[constant.KBAppComponentLabelKey]]
+		if !ok {
+			continue
+		}
+		conn.Credentials = append(conn.Credentials, intctrlutil.BuildCredentialRef(secret.Labels[constant.ClusterAccountLabelKey], secret.Name))
+	}
+
+	intctrlutil.SortConnectionContract(contract)
+	return contract, nil
+}
+
+func (t *clusterConnectionContractTransformer) buildHostPorts(cluster *appsv1alpha1.Cluster,
+	compSpec *appsv1alpha1.ClusterComponentSpec, compDef *appsv1alpha1.ComponentDefinition) ([]intctrlutil.HostPort, error) {
+	pm := intctrlutil.GetPortManager()
+	if pm == nil {
+		return nil, nil
+	}
+	var hostPorts []intctrlutil.HostPort
+	for _, c := range compDef.Spec.HostNetwork.ContainerPorts {
+		for _, p := range c.Ports {
+			port, err := pm.GetPort(intctrlutil.BuildHostPortName(cluster.Name, compSpec.Name, c.Container, p))
+			if err != nil {
+				return nil, err
+			}
+			// not allocated yet
+			if port == 0 {
+				continue
+			}
+			hostPorts = append(hostPorts, intctrlutil.HostPort{Container: c.Container, Name: p, Port: port})
+		}
+	}
+	return hostPorts, nil
+}
+
+func (t *clusterConnectionContractTransformer) buildTLSRef(cluster *appsv1alpha1.Cluster,
+	compSpec *appsv1alpha1.ClusterComponentSpec) *intctrlutil.TLSRef {
+	if compSpec.Issuer != nil && compSpec.Issuer.Name == appsv1alpha1.IssuerUserProvided {
+		if compSpec.Issuer.SecretRef == nil {
+			return nil
+		}
+		return &intctrlutil.TLSRef{
+			SecretName: compSpec.Issuer.SecretRef.Name,
+			CAKey:      compSpec.Issuer.SecretRef.CA,
+			CertKey:    compSpec.Issuer.SecretRef.Cert,
+			KeyKey:     compSpec.Issuer.SecretRef.Key,
+		}
+	}
+	return &intctrlutil.TLSRef{
+		SecretName: plan.GenerateTLSSecretName(cluster.Name, compSpec.Name),
+		CAKey:      constant.CAName,
+		CertKey:    constant.CertName,
+		KeyKey:     constant.KeyName,
+	}
+}
+
+func (t *clusterConnectionContractTransformer) listServices(transCtx *clusterTransformContext,
+	cluster *appsv1alpha1.Cluster) ([]corev1.Service, error) {
+	svcList := &corev1.ServiceList{}
+	if err := transCtx.Client.List(transCtx.Context, svcList, client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(constant.GetClusterWellKnownLabels(cluster.Name))); err != nil {
+		return nil, err
+	}
+	return svcList.Items, nil
+}
+
+func (t *clusterConnectionContractTransformer) listAccountSecrets(transCtx *clusterTransformContext,
+	cluster *appsv1alpha1.Cluster) ([]corev1.Secret, error) {
+	secretList := &corev1.SecretList{}
+	if err := transCtx.Client.List(transCtx.Context, secretList, client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(constant.GetClusterWellKnownLabels(cluster.Name)), client.HasLabels{constant.ClusterAccountLabelKey}); err != nil {
+		return nil, err
+	}
+	return secretList.Items, nil
+}
+
+// roleWritable returns a function to tell whether the role defined in the component definition is writable.
+func roleWritable(compDef *appsv1alpha1.ComponentDefinition) func(string) (bool, bool) {
+	if compDef == nil {
+		return nil
+	}
+	return func(role string) (bool, bool) {
+		for _, r := range compDef.Spec.Roles {
+			if r.Name == role {
+				return r.Writable, true
+			}
+		}
+		return false, false
+	}
+}
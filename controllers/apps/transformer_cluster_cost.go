@@ -0,0 +1,75 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+// clusterCostTransformer estimates the monthly cost of the cluster with the pricing table of the provider,
+// and records it in the annotation of the cluster, so the estimation follows the scaling of the cluster.
+type clusterCostTransformer struct{}
+
+var _ graph.Transformer = &clusterCostTransformer{}
+
+func (t *clusterCostTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*clusterTransformContext)
+	cluster := transCtx.Cluster
+	cmName := viper.GetString(constant.CfgKeyClusterPricingConfigMap)
+	if cmName == "" || cluster.IsDeleting() {
+		return nil
+	}
+
+	cm := &corev1.ConfigMap{}
+	cmKey := client.ObjectKey{Namespace: viper.GetString(constant.CfgKeyCtrlrMgrNS), Name: cmName}
+	if err := transCtx.Client.Get(transCtx.Context, cmKey, cm); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	table, err := intctrlutil.LoadPricingTable(cm.Data, viper.GetString(constant.CfgKeyProvider))
+	if err != nil {
+		// a broken pricing table should not block the reconciliation of the cluster.
+		transCtx.Logger.Error(err, "failed to load the pricing table", "configmap", cmKey)
+		return nil
+	}
+	if table == nil {
+		return nil
+	}
+
+	cost, err := json.Marshal(intctrlutil.EstimateClusterCost(cluster, table))
+	if err != nil {
+		return err
+	}
+	if cluster.Annotations[constant.EstimatedCostAnnotationKey] == string(cost) {
+		return nil
+	}
+	if cluster.Annotations == nil {
+		cluster.Annotations = map[string]string{}
+	}
+	cluster.Annotations[constant.EstimatedCostAnnotationKey] = string(cost)
+	return nil
+}
@@ -20,8 +20,10 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package apps
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
@@ -31,6 +33,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -40,6 +45,18 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	dpbackup "github.com/apecloud/kubeblocks/pkg/dataprotection/backup"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+)
+
+const (
+	// maxArtifactsDeletionRetries is the max times to retry the removal of the backup artifacts when the cluster is wiped out.
+	maxArtifactsDeletionRetries = 3
+
+	clusterArchiveSpecKey   = "cluster"
+	clusterArchiveBackupKey = "backup"
 )
 
 // clusterDeletionTransformer handles cluster deletion
@@ -71,6 +88,28 @@ func (t *clusterDeletionTransformer) Transform(ctx graph.TransformContext, dag *
 		toDeleteNamespacedKinds, toDeleteNonNamespacedKinds = kindsForDelete()
 	case appsv1alpha1.WipeOut:
 		toDeleteNamespacedKinds, toDeleteNonNamespacedKinds = kindsForWipeOut()
+	case appsv1alpha1.Retain:
+		toDeleteNamespacedKinds, toDeleteNonNamespacedKinds = kindsForDelete()
+	}
+
+	// take the final backup before any workload or PVC is removed.
+	if cluster.Spec.TerminationPolicy != appsv1alpha1.Halt && isFinalBackupOnDelete(cluster) {
+		if isFinalBackupSkipped(cluster) {
+			transCtx.EventRecorder.Eventf(cluster, corev1.EventTypeWarning, ReasonFinalBackupSkipped,
+				"The final backup is skipped by the annotation %s", constant.SkipFinalBackupAnnotationKey)
+		} else {
+			completed, err := t.finalBackup(transCtx, dag)
+			if err != nil || !completed {
+				return err
+			}
+		}
+	}
+
+	// archive the spec and the final backup of the cluster before anything is removed.
+	if cluster.Spec.TerminationPolicy == appsv1alpha1.Retain {
+		if err := t.archiveCluster(transCtx, dag); err != nil {
+			return err
+		}
 	}
 
 	transCtx.EventRecorder.Eventf(cluster, corev1.EventTypeNormal, constant.ReasonDeletingCR, "Deleting %s: %s",
@@ -122,6 +161,18 @@ func (t *clusterDeletionTransformer) Transform(ctx graph.TransformContext, dag *
 	}
 	delObjs = append(delObjs, toDeleteObjs(nonNamespacedObjs)...)
 
+	// verify the removal of the backup artifacts, the backups handled there are not deleted again.
+	var (
+		removingArtifacts bool
+		leftBackups       []string
+	)
+	if cluster.Spec.TerminationPolicy == appsv1alpha1.WipeOut {
+		delObjs, removingArtifacts, leftBackups, err = t.removeBackupArtifacts(transCtx, dag, delObjs)
+		if err != nil {
+			return err
+		}
+	}
+
 	delKindMap := map[string]sets.Empty{}
 	for _, o := range delObjs {
 		// skip the objects owned by the component and InstanceSet controller
@@ -133,7 +184,10 @@ func (t *clusterDeletionTransformer) Transform(ctx graph.TransformContext, dag *
 	}
 
 	// set cluster action to noop until all the sub-resources deleted
-	if len(delObjs) == 0 {
+	if len(delObjs) == 0 && !removingArtifacts {
+		if cluster.Spec.TerminationPolicy == appsv1alpha1.WipeOut {
+			reportWipeOut(transCtx, leftBackups)
+		}
 		graphCli.Delete(dag, cluster)
 	} else {
 		transCtx.Logger.Info(fmt.Sprintf("deleting the sub-resource kinds: %v", maps.Keys(delKindMap)))
@@ -146,6 +200,278 @@ func (t *clusterDeletionTransformer) Transform(ctx graph.TransformContext, dag *
 	return graph.ErrPrematureStop
 }
 
+// finalBackup creates the final backup of the cluster if it does not exist, and returns whether it has completed.
+func (t *clusterDeletionTransformer) finalBackup(transCtx *clusterTransformContext, dag *graph.DAG) (bool, error) {
+	cluster := transCtx.OrigCluster
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+
+	setCondition := func(condition metav1.Condition) {
+		meta.SetStatusCondition(&transCtx.Cluster.Status.Conditions, condition)
+		graphCli.Status(dag, cluster, transCtx.Cluster)
+	}
+
+	backup := &dpv1alpha1.Backup{}
+	err := transCtx.Client.Get(transCtx.Context, client.ObjectKey{Namespace: cluster.Namespace, Name: finalBackupName(cluster)}, backup)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return false, err
+	}
+	if apierrors.IsNotFound(err) {
+		backup, err = buildFinalBackup(transCtx, cluster)
+		if err != nil {
+			setCondition(newFinalBackupFailedCondition(cluster.Name, err.Error()))
+			return false, newRequeueError(requeueDuration, err.Error())
+		}
+		transCtx.EventRecorder.Eventf(cluster, corev1.EventTypeNormal, ReasonFinalBackupRunning,
+			"Creating the final backup %s before deleting the cluster", backup.Name)
+		graphCli.Create(dag, backup)
+		setCondition(newFinalBackupRunningCondition(cluster.Name, backup.Name))
+		return false, newRequeueError(requeueDuration, fmt.Sprintf("wait for the final backup %s to complete", backup.Name))
+	}
+
+	switch backup.Status.Phase {
+	case dpv1alpha1.BackupPhaseCompleted:
+		if !meta.IsStatusConditionTrue(transCtx.Cluster.Status.Conditions, appsv1alpha1.ConditionTypeFinalBackup) {
+			transCtx.EventRecorder.Eventf(cluster, corev1.EventTypeNormal, ReasonFinalBackupCompleted,
+				"The final backup %s is completed", backup.Name)
+			// persist the condition before the sub-resources are deleted.
+			setCondition(newFinalBackupCompletedCondition(backup.Name))
+		}
+		return true, nil
+	case dpv1alpha1.BackupPhaseFailed:
+		// the deletion is blocked until the final backup is deleted to retry, or the final backup is skipped.
+		message := fmt.Sprintf("the final backup %s failed: %s, delete it to retry or annotate the cluster with %s=true to skip it",
+			backup.Name, backup.Status.FailureReason, constant.SkipFinalBackupAnnotationKey)
+		condition := meta.FindStatusCondition(transCtx.Cluster.Status.Conditions, appsv1alpha1.ConditionTypeFinalBackup)
+		if condition == nil || condition.Reason != ReasonFinalBackupFailed {
+			transCtx.EventRecorder.Event(cluster, corev1.EventTypeWarning, ReasonFinalBackupFailed, message)
+		}
+		setCondition(newFinalBackupFailedCondition(cluster.Name, message))
+		return false, newRequeueError(requeueDuration, message)
+	default:
+		setCondition(newFinalBackupRunningCondition(cluster.Name, backup.Name))
+		return false, newRequeueError(requeueDuration, fmt.Sprintf("wait for the final backup %s to complete", backup.Name))
+	}
+}
+
+// removeBackupArtifacts checks the removal of the artifacts of the backups being deleted. The failed deletion is retried
+// by re-creating the deletion job for at most maxArtifactsDeletionRetries times, after that, the backup is left over to
+// be reported in the final report and removed manually.
+// It returns the objects still to be deleted, whether the artifacts are still being removed, and the left-over backups.
+func (t *clusterDeletionTransformer) removeBackupArtifacts(transCtx *clusterTransformContext, dag *graph.DAG,
+	delObjs []client.Object) ([]client.Object, bool, []string, error) {
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+
+	var (
+		objs        []client.Object
+		removing    []string
+		leftBackups []string
+	)
+	for _, obj := range delObjs {
+		backup, ok := obj.(*dpv1alpha1.Backup)
+		if !ok || backup.DeletionTimestamp == nil {
+			objs = append(objs, obj)
+			continue
+		}
+		job := &batchv1.Job{}
+		if err := transCtx.Client.Get(transCtx.Context, dpbackup.BuildDeleteBackupFilesJobKey(backup, false), job); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, false, nil, err
+			}
+			objs = append(objs, obj)
+			continue
+		}
+		if _, finishedType, _ := dputils.IsJobFinished(job); finishedType != batchv1.JobFailed {
+			objs = append(objs, obj)
+			continue
+		}
+		retries, _ := strconv.Atoi(backup.Annotations[constant.ArtifactsDeletionRetriesAnnotationKey])
+		if retries >= maxArtifactsDeletionRetries {
+			leftBackups = append(leftBackups, fmt.Sprintf("%s(%s)", backup.Name, backup.Status.FailureReason))
+			continue
+		}
+		// delete the failed job to re-create it, and the annotation triggers the reconciliation of the backup.
+		backupCopy := backup.DeepCopy()
+		if backupCopy.Annotations == nil {
+			backupCopy.Annotations = map[string]string{}
+		}
+		backupCopy.Annotations[constant.ArtifactsDeletionRetriesAnnotationKey] = strconv.Itoa(retries + 1)
+		graphCli.Patch(dag, backup, backupCopy, inUniversalContext4G())
+		graphCli.Delete(dag, job, inUniversalContext4G())
+		removing = append(removing, backup.Name)
+	}
+
+	if len(removing) > 0 {
+		meta.SetStatusCondition(&transCtx.Cluster.Status.Conditions, newArtifactsRemovingCondition(
+			fmt.Sprintf("retry to remove the artifacts of the backups: %s", strings.Join(removing, ","))))
+	}
+	return objs, len(removing) > 0, leftBackups, nil
+}
+
+// reportWipeOut reports the final result of the removal of the backup artifacts when the cluster is wiped out.
+func reportWipeOut(transCtx *clusterTransformContext, leftBackups []string) {
+	cluster := transCtx.OrigCluster
+	if len(leftBackups) == 0 {
+		transCtx.EventRecorder.Event(cluster, corev1.EventTypeNormal, ReasonWipeOutCompleted,
+			"all the backup artifacts of the cluster are removed and verified")
+		return
+	}
+	transCtx.EventRecorder.Eventf(cluster, corev1.EventTypeWarning, ReasonWipeOutIncomplete,
+		"failed to remove the artifacts of the backups after %d retries, please remove them manually: %s",
+		maxArtifactsDeletionRetries, strings.Join(leftBackups, ","))
+}
+
+// archiveCluster archives the spec and the final backup of the cluster in a ConfigMap, which is retained after
+// the cluster is deleted and can be used to recreate the cluster.
+func (t *clusterDeletionTransformer) archiveCluster(transCtx *clusterTransformContext, dag *graph.DAG) error {
+	cluster := transCtx.OrigCluster
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+
+	cm := &corev1.ConfigMap{}
+	err := transCtx.Client.Get(transCtx.Context, client.ObjectKey{Namespace: cluster.Namespace, Name: clusterArchiveName(cluster)}, cm)
+	if err == nil || !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	backupName, err := getFinalBackupPointer(transCtx, cluster)
+	if err != nil {
+		return err
+	}
+	archived := &appsv1alpha1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1alpha1.GroupVersion.String(),
+			Kind:       appsv1alpha1.ClusterKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   cluster.Namespace,
+			Name:        cluster.Name,
+			Labels:      cluster.Labels,
+			Annotations: cluster.Annotations,
+		},
+		Spec: cluster.Spec,
+	}
+	data, err := json.Marshal(archived)
+	if err != nil {
+		return err
+	}
+	cm = &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cluster.Namespace,
+			Name:      clusterArchiveName(cluster),
+			Labels: map[string]string{
+				constant.AppInstanceLabelKey: cluster.Name,
+				// retain the archive even if a cluster with the same name is wiped out.
+				constant.BackupProtectionLabelKey: constant.BackupRetain,
+				constant.ClusterArchiveLabelKey:   "true",
+			},
+		},
+		Data: map[string]string{
+			clusterArchiveSpecKey:   string(data),
+			clusterArchiveBackupKey: backupName,
+		},
+	}
+	graphCli.Create(dag, cm)
+	transCtx.EventRecorder.Eventf(cluster, corev1.EventTypeNormal, ReasonClusterArchived,
+		"The cluster is archived in the ConfigMap %s with the backup %q", cm.Name, backupName)
+	return nil
+}
+
+// getFinalBackupPointer returns the final backup of the cluster if it's taken, or the latest completed backup.
+func getFinalBackupPointer(transCtx *clusterTransformContext, cluster *appsv1alpha1.Cluster) (string, error) {
+	if isFinalBackupOnDelete(cluster) && !isFinalBackupSkipped(cluster) {
+		return finalBackupName(cluster), nil
+	}
+	backupList := &dpv1alpha1.BackupList{}
+	if err := transCtx.Client.List(transCtx.Context, backupList, client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(map[string]string{constant.AppInstanceLabelKey: cluster.Name})); err != nil {
+		return "", err
+	}
+	var latest *dpv1alpha1.Backup
+	for i, backup := range backupList.Items {
+		if backup.Status.Phase != dpv1alpha1.BackupPhaseCompleted || backup.Status.CompletionTimestamp == nil {
+			continue
+		}
+		if latest == nil || latest.Status.CompletionTimestamp.Before(backup.Status.CompletionTimestamp) {
+			latest = &backupList.Items[i]
+		}
+	}
+	if latest == nil {
+		return "", nil
+	}
+	return latest.Name, nil
+}
+
+func clusterArchiveName(cluster *appsv1alpha1.Cluster) string {
+	uid := string(cluster.UID)
+	if len(uid) > 8 {
+		uid = uid[:8]
+	}
+	return fmt.Sprintf("%s-archive-%s", cluster.Name, uid)
+}
+
+func isFinalBackupOnDelete(cluster *appsv1alpha1.Cluster) bool {
+	return cluster.Spec.Backup != nil && boolptr.IsSetToTrue(cluster.Spec.Backup.FinalBackupOnDelete)
+}
+
+// isFinalBackupSkipped checks whether the final backup is skipped by the annotation, which unblocks the deletion
+// of the cluster if the final backup fails or never completes.
+func isFinalBackupSkipped(cluster *appsv1alpha1.Cluster) bool {
+	return strings.EqualFold(cluster.Annotations[constant.SkipFinalBackupAnnotationKey], "true")
+}
+
+func finalBackupName(cluster *appsv1alpha1.Cluster) string {
+	// the UID is included to avoid conflicting with the retained final backup of a previous cluster with the same name.
+	uid := string(cluster.UID)
+	if len(uid) > 8 {
+		uid = uid[:8]
+	}
+	return fmt.Sprintf("%s-final-%s", cluster.Name, uid)
+}
+
+// buildFinalBackup builds the final backup with the default backup policy of the cluster
+// and the backup method specified in cluster.spec.backup.
+func buildFinalBackup(transCtx *clusterTransformContext, cluster *appsv1alpha1.Cluster) (*dpv1alpha1.Backup, error) {
+	backupPolicyList := &dpv1alpha1.BackupPolicyList{}
+	if err := transCtx.Client.List(transCtx.Context, backupPolicyList, client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(map[string]string{constant.AppInstanceLabelKey: cluster.Name})); err != nil {
+		return nil, err
+	}
+	var backupPolicyName string
+	for _, backupPolicy := range backupPolicyList.Items {
+		if backupPolicy.GetAnnotations()[dptypes.DefaultBackupPolicyAnnotationKey] != "true" {
+			continue
+		}
+		if backupPolicyName != "" {
+			return nil, fmt.Errorf(`cluster "%s" has multiple default backup policies`, cluster.Name)
+		}
+		backupPolicyName = backupPolicy.Name
+	}
+	if backupPolicyName == "" {
+		return nil, fmt.Errorf(`not found any default backup policy for cluster "%s"`, cluster.Name)
+	}
+	_, backupMethods := dputils.GetBackupMethodsFromBackupPolicy(backupPolicyList, backupPolicyName)
+	if _, ok := backupMethods[cluster.Spec.Backup.Method]; !ok {
+		return nil, fmt.Errorf(`backup method "%s" is not supported by the backup policy "%s"`, cluster.Spec.Backup.Method, backupPolicyName)
+	}
+
+	return &dpv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      finalBackupName(cluster),
+			Namespace: cluster.Namespace,
+			Labels: map[string]string{
+				constant.AppInstanceLabelKey: cluster.Name,
+				// retain the final backup even if the cluster is wiped out.
+				constant.BackupProtectionLabelKey: constant.BackupRetain,
+				constant.FinalBackupLabelKey:      "true",
+			},
+		},
+		Spec: dpv1alpha1.BackupSpec{
+			BackupPolicyName: backupPolicyName,
+			BackupMethod:     cluster.Spec.Backup.Method,
+			DeletionPolicy:   dpv1alpha1.BackupDeletionPolicyRetain,
+		},
+	}, nil
+}
+
 func kindsForDoNotTerminate() ([]client.ObjectList, []client.ObjectList) {
 	return []client.ObjectList{}, []client.ObjectList{}
 }
@@ -20,18 +20,29 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package apps
 
 import (
+	"encoding/json"
 	"slices"
+	"strconv"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dpbackup "github.com/apecloud/kubeblocks/pkg/dataprotection/backup"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
 )
 
@@ -156,4 +167,165 @@ var _ = Describe("clusterDeletionTransformer", func() {
 		Expect(err).Should(Equal(graph.ErrPrematureStop))
 		Expect(dag.Vertices()).Should(HaveLen(1))
 	})
+
+	It("w/ final backup on delete", func() {
+		cluster.UID = "6fb6e3ea-2b4c-4d1e-9a3f-2d5c1c1f6e1a"
+		cluster.Spec.Backup = &appsv1alpha1.ClusterBackup{
+			Method:              "xtrabackup",
+			FinalBackupOnDelete: pointer.Bool(true),
+		}
+		transCtx.Cluster = cluster.DeepCopy()
+		mockReader := reader.(*mockReader)
+		mockReader.objs = append(mockReader.objs, &dpv1alpha1.BackupPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   testCtx.DefaultNamespace,
+				Name:        "test-cluster-backup-policy",
+				Annotations: map[string]string{dptypes.DefaultBackupPolicyAnnotationKey: "true"},
+			},
+			Spec: dpv1alpha1.BackupPolicySpec{
+				BackupMethods: []dpv1alpha1.BackupMethod{{Name: "xtrabackup"}},
+			},
+			Status: dpv1alpha1.BackupPolicyStatus{Phase: dpv1alpha1.AvailablePhase},
+		})
+
+		By("create the final backup before deleting the components")
+		transformer := &clusterDeletionTransformer{}
+		dag = newDag(transCtx.Client.(model.GraphClient))
+		err := transformer.Transform(transCtx, dag)
+		Expect(intctrlutil.IsRequeueError(err)).Should(BeTrue())
+		Expect(dag.Vertices()).Should(HaveLen(1 + 1))
+		var backup *dpv1alpha1.Backup
+		for _, v := range dag.Vertices() {
+			if obj, ok := v.(*model.ObjectVertex).Obj.(*dpv1alpha1.Backup); ok {
+				backup = obj
+			}
+		}
+		Expect(backup).ShouldNot(BeNil())
+		Expect(backup.Name).Should(Equal(finalBackupName(cluster)))
+		Expect(backup.Spec.BackupMethod).Should(Equal("xtrabackup"))
+		Expect(backup.Labels).Should(HaveKeyWithValue(constant.BackupProtectionLabelKey, constant.BackupRetain))
+		Expect(backup.Labels).Should(HaveKeyWithValue(constant.FinalBackupLabelKey, "true"))
+		condition := meta.FindStatusCondition(transCtx.Cluster.Status.Conditions, appsv1alpha1.ConditionTypeFinalBackup)
+		Expect(condition).ShouldNot(BeNil())
+		Expect(condition.Reason).Should(Equal(ReasonFinalBackupRunning))
+
+		By("block the deletion if the final backup failed")
+		backup.Status.Phase = dpv1alpha1.BackupPhaseFailed
+		mockReader.objs = append(mockReader.objs, backup)
+		dag = newDag(transCtx.Client.(model.GraphClient))
+		err = transformer.Transform(transCtx, dag)
+		Expect(intctrlutil.IsRequeueError(err)).Should(BeTrue())
+		Expect(dag.Vertices()).Should(HaveLen(1))
+		condition = meta.FindStatusCondition(transCtx.Cluster.Status.Conditions, appsv1alpha1.ConditionTypeFinalBackup)
+		Expect(condition.Reason).Should(Equal(ReasonFinalBackupFailed))
+
+		By("delete the components if the final backup is skipped")
+		cluster.SetAnnotations(map[string]string{constant.SkipFinalBackupAnnotationKey: "true"})
+		dag = newDag(transCtx.Client.(model.GraphClient))
+		err = transformer.Transform(transCtx, dag)
+		Expect(err).ShouldNot(BeNil())
+		Expect(err.Error()).Should(ContainSubstring("are not ready"))
+		delete(cluster.Annotations, constant.SkipFinalBackupAnnotationKey)
+
+		By("delete the components after the final backup completed")
+		backup.Status.Phase = dpv1alpha1.BackupPhaseCompleted
+		dag = newDag(transCtx.Client.(model.GraphClient))
+		err = transformer.Transform(transCtx, dag)
+		Expect(err).ShouldNot(BeNil())
+		Expect(err.Error()).Should(ContainSubstring("are not ready"))
+		Expect(meta.IsStatusConditionTrue(transCtx.Cluster.Status.Conditions, appsv1alpha1.ConditionTypeFinalBackup)).Should(BeTrue())
+	})
+
+	It("w/ Retain archives the cluster", func() {
+		cluster.UID = "6fb6e3ea-2b4c-4d1e-9a3f-2d5c1c1f6e1a"
+		cluster.Spec.TerminationPolicy = appsv1alpha1.Retain
+		transCtx.Cluster = cluster.DeepCopy()
+		mockReader := reader.(*mockReader)
+		for i, name := range []string{"backup-1", "backup-2"} {
+			mockReader.objs = append(mockReader.objs, &dpv1alpha1.Backup{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testCtx.DefaultNamespace, Name: name},
+				Status: dpv1alpha1.BackupStatus{
+					Phase:               dpv1alpha1.BackupPhaseCompleted,
+					CompletionTimestamp: &metav1.Time{Time: time.Now().Add(time.Duration(i) * time.Hour)},
+				},
+			})
+		}
+
+		transformer := &clusterDeletionTransformer{}
+		dag = newDag(transCtx.Client.(model.GraphClient))
+		err := transformer.Transform(transCtx, dag)
+		Expect(err).ShouldNot(BeNil())
+		Expect(err.Error()).Should(ContainSubstring("are not ready"))
+		var cm *corev1.ConfigMap
+		for _, v := range dag.Vertices() {
+			if obj, ok := v.(*model.ObjectVertex).Obj.(*corev1.ConfigMap); ok {
+				cm = obj
+			}
+		}
+		Expect(cm).ShouldNot(BeNil())
+		Expect(cm.Name).Should(Equal(clusterArchiveName(cluster)))
+		Expect(cm.Labels).Should(HaveKeyWithValue(constant.BackupProtectionLabelKey, constant.BackupRetain))
+		Expect(cm.Data).Should(HaveKeyWithValue(clusterArchiveBackupKey, "backup-2"))
+		archived := &appsv1alpha1.Cluster{}
+		Expect(json.Unmarshal([]byte(cm.Data[clusterArchiveSpecKey]), archived)).Should(Succeed())
+		Expect(archived.Name).Should(Equal(cluster.Name))
+		Expect(archived.Spec.TerminationPolicy).Should(Equal(appsv1alpha1.Retain))
+		Expect(archived.Spec.ComponentSpecs).Should(HaveLen(3))
+
+		By("the backups are retained")
+		for _, v := range dag.Vertices() {
+			_, ok := v.(*model.ObjectVertex).Obj.(*dpv1alpha1.Backup)
+			Expect(ok).Should(BeFalse())
+		}
+	})
+
+	It("w/ WipeOut retries the removal of the backup artifacts", func() {
+		mockReader := reader.(*mockReader)
+		mockReader.objs = slices.DeleteFunc(mockReader.objs, func(obj client.Object) bool {
+			_, ok := obj.(*appsv1alpha1.Component)
+			return ok
+		})
+		backup := &dpv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         testCtx.DefaultNamespace,
+				Name:              "backup",
+				UID:               "0a1b2c3d-2b4c-4d1e-9a3f-2d5c1c1f6e1a",
+				DeletionTimestamp: &metav1.Time{Time: time.Now()},
+			},
+		}
+		jobKey := dpbackup.BuildDeleteBackupFilesJobKey(backup, false)
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Namespace: jobKey.Namespace, Name: jobKey.Name},
+			Status: batchv1.JobStatus{
+				Conditions: []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}},
+			},
+		}
+		mockReader.objs = append(mockReader.objs, backup, job)
+
+		findBackup := func() *dpv1alpha1.Backup {
+			for _, v := range dag.Vertices() {
+				if obj, ok := v.(*model.ObjectVertex).Obj.(*dpv1alpha1.Backup); ok {
+					return obj
+				}
+			}
+			return nil
+		}
+
+		By("retry the failed deletion job")
+		transformer := &clusterDeletionTransformer{}
+		dag = newDag(transCtx.Client.(model.GraphClient))
+		err := transformer.Transform(transCtx, dag)
+		Expect(intctrlutil.IsRequeueError(err)).Should(BeTrue())
+		Expect(findBackup()).ShouldNot(BeNil())
+		Expect(findBackup().Annotations).Should(HaveKeyWithValue(constant.ArtifactsDeletionRetriesAnnotationKey, "1"))
+		condition := meta.FindStatusCondition(transCtx.Cluster.Status.Conditions, appsv1alpha1.ConditionTypeArtifactsRemoval)
+		Expect(condition).ShouldNot(BeNil())
+		Expect(condition.Reason).Should(Equal(ReasonArtifactsRemoving))
+
+		By("leave the backup over after the retries are exhausted")
+		backup.Annotations = map[string]string{constant.ArtifactsDeletionRetriesAnnotationKey: strconv.Itoa(maxArtifactsDeletionRetries)}
+		dag = newDag(transCtx.Client.(model.GraphClient))
+		_ = transformer.Transform(transCtx, dag)
+		Expect(findBackup()).Should(BeNil())
+	})
 })
@@ -0,0 +1,95 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+// clusterHistoryTransformer records the changes of the last N generations of the cluster spec and the resulting
+// status into a ConfigMap, to tell what changed and when for the cluster.
+type clusterHistoryTransformer struct{}
+
+var _ graph.Transformer = &clusterHistoryTransformer{}
+
+func (t *clusterHistoryTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*clusterTransformContext)
+	origCluster := transCtx.OrigCluster
+	cluster := transCtx.Cluster
+	limit := viper.GetInt(constant.CfgKeyClusterHistoryLimit)
+	// record the resulting status only after the generation has been applied.
+	if limit <= 0 || !origCluster.IsStatusUpdating() {
+		return nil
+	}
+
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	cmKey := client.ObjectKey{Namespace: cluster.Namespace, Name: constant.GenerateClusterHistoryConfigMapName(cluster.Name)}
+	runningCM := &corev1.ConfigMap{}
+	if err := transCtx.Client.Get(transCtx.Context, cmKey, runningCM); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		runningCM = nil
+	}
+
+	var data map[string]string
+	if runningCM != nil {
+		data = runningCM.Data
+	}
+	history, err := intctrlutil.ParseClusterHistory(data)
+	if err != nil {
+		// the history is corrupted, start over.
+		transCtx.Logger.Error(err, "failed to parse the cluster history, reset it")
+		history = &intctrlutil.ClusterHistory{}
+	}
+	changed, err := history.Record(cluster, limit)
+	if err != nil || !changed {
+		return err
+	}
+	historyData, err := history.Data()
+	if err != nil {
+		return err
+	}
+
+	cm := builder.NewConfigMapBuilder(cmKey.Namespace, cmKey.Name).
+		AddLabelsInMap(constant.GetClusterWellKnownLabels(cluster.Name)).
+		SetData(historyData).
+		GetObject()
+	if err = intctrlutil.SetOwnership(cluster, cm, rscheme, constant.DBClusterFinalizerName); err != nil {
+		return err
+	}
+	if runningCM == nil {
+		graphCli.Create(dag, cm)
+	} else {
+		cmCopy := runningCM.DeepCopy()
+		cmCopy.Data = cm.Data
+		graphCli.Update(dag, runningCM, cmCopy)
+	}
+	return nil
+}
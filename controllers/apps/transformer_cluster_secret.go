@@ -24,6 +24,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 )
@@ -41,7 +42,8 @@ func (c *clusterSecretTransformer) Transform(ctx graph.TransformContext, dag *gr
 	secrets = graphCli.FindAll(dag, &corev1.Secret{})
 	noneClusterObjects = graphCli.FindAll(dag, &appsv1alpha1.Cluster{}, &model.HaveDifferentTypeWithOption{})
 	for _, secret := range secrets {
-		if graphCli.IsAction(dag, secret, model.ActionUpdatePtr()) {
+		// the connection contract follows the topology of the cluster, so it is allowed to be updated.
+		if graphCli.IsAction(dag, secret, model.ActionUpdatePtr()) && !isConnectionContractSecret(transCtx.Cluster, secret) {
 			graphCli.Noop(dag, secret)
 		}
 		for _, object := range noneClusterObjects {
@@ -55,3 +57,7 @@ func (c *clusterSecretTransformer) Transform(ctx graph.TransformContext, dag *gr
 	}
 	return nil
 }
+
+func isConnectionContractSecret(cluster *appsv1alpha1.Cluster, secret client.Object) bool {
+	return secret.GetName() == constant.GenerateClusterConnectionContractName(cluster.Name)
+}
@@ -34,8 +34,11 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/common"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controller/plan"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
 // clusterServiceTransformer handles cluster services.
@@ -54,6 +57,11 @@ func (t *clusterServiceTransformer) Transform(ctx graph.TransformContext, dag *g
 	}
 
 	cluster := transCtx.Cluster
+	if plan.HasPendingDataMasking(cluster) {
+		// hold the services until the restored data is masked, the sensitive data should not be accessed through them.
+		transCtx.V(1).Info("the restored data of cluster is being masked, hold the services", "cluster", client.ObjectKeyFromObject(cluster))
+		return nil
+	}
 	graphCli, _ := transCtx.Client.(model.GraphClient)
 
 	services, err := t.listOwnedClusterServices(transCtx, cluster)
@@ -172,6 +180,9 @@ func (t *clusterServiceTransformer) buildService(transCtx *clusterTransformConte
 	serviceName := constant.GenerateClusterServiceName(cluster.Name, genSvc.ServiceName)
 	builder := builder.NewServiceBuilder(namespace, serviceName).
 		AddLabelsInMap(constant.GetClusterWellKnownLabels(clusterName)).
+		AddAnnotationsInMap(intctrlutil.BuildServiceCloudTagsAnnotations(cluster.Spec.CloudTags, genSvc.Spec.Type)).
+		AddAnnotationsInMap(intctrlutil.BuildServiceDNSAnnotations(cluster.Spec.DNS,
+			namespace, clusterName, genSvc.ComponentSelector, serviceName, genSvc.Spec.Type)).
 		AddAnnotationsInMap(genSvc.Annotations).
 		SetSpec(&genSvc.Spec).
 		AddSelectorsInMap(t.builtinSelector(cluster)).
@@ -197,7 +208,9 @@ func (t *clusterServiceTransformer) buildService(transCtx *clusterTransformConte
 		builder.AddSelector(constant.RoleLabelKey, genSvc.RoleSelector)
 	}
 
-	return builder.GetObject(), nil
+	svcObj := builder.GetObject()
+	component.ApplyIPStack(cluster.Spec.IPStack, &svcObj.Spec)
+	return svcObj, nil
 }
 
 func (t *clusterServiceTransformer) genMultiServiceIfNeed(transCtx *clusterTransformContext,
@@ -309,6 +322,7 @@ func createOrUpdateService(ctx graph.TransformContext, dag *graph.DAG, graphCli
 
 	objCopy := obj.DeepCopy()
 	objCopy.Spec = service.Spec
+	intctrlutil.SyncServiceDNSAnnotations(service, objCopy)
 
 	resolveServiceDefaultFields(&obj.Spec, &objCopy.Spec)
 
@@ -345,7 +359,9 @@ func resolveServiceDefaultFields(obj, objCopy *corev1.ServiceSpec) {
 	if len(objCopy.SessionAffinity) == 0 {
 		objCopy.SessionAffinity = obj.SessionAffinity
 	}
-	if len(objCopy.IPFamilies) == 0 || (len(objCopy.IPFamilies) == 1 && *objCopy.IPFamilyPolicy != corev1.IPFamilyPolicySingleStack) {
+	// the secondary family is assigned by the API server if the policy is dual-stack.
+	if len(objCopy.IPFamilies) == 0 || (len(objCopy.IPFamilies) == 1 && objCopy.IPFamilyPolicy != nil &&
+		*objCopy.IPFamilyPolicy != corev1.IPFamilyPolicySingleStack) {
 		objCopy.IPFamilies = obj.IPFamilies
 	}
 	if objCopy.IPFamilyPolicy == nil {
@@ -0,0 +1,233 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	kbagent "github.com/apecloud/kubeblocks/pkg/kb_agent/client"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+// shardRoutingActionTimeout is the timeout to invoke the shardRouting action on a shard.
+const shardRoutingActionTimeout = 5 * time.Second
+
+// clusterShardingRoutingTransformer publishes the routing metadata of each sharding into a well-known ConfigMap,
+// so that the client-side routers and proxies can bootstrap without engine-specific discovery.
+// The hash slots of each shard are reported by the shardRouting action of the Component, the slots published last time
+// are kept if the action fails, and the shards without the action fall back to the slots specified by the annotation.
+type clusterShardingRoutingTransformer struct{}
+
+var _ graph.Transformer = &clusterShardingRoutingTransformer{}
+
+func (t *clusterShardingRoutingTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*clusterTransformContext)
+	if model.IsObjectDeleting(transCtx.OrigCluster) {
+		return nil
+	}
+
+	cluster := transCtx.Cluster
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+
+	runningCMs, err := t.listOwnedRoutingConfigMaps(transCtx, cluster)
+	if err != nil {
+		return err
+	}
+	for _, sharding := range cluster.Spec.ShardingSpecs {
+		cmName := constant.GenerateShardingRoutingConfigMapName(cluster.Name, sharding.Name)
+		runningCM := runningCMs[cmName]
+		cm, err := t.buildRoutingConfigMap(transCtx, cluster, sharding.Name, runningCM)
+		if err != nil {
+			return err
+		}
+		switch {
+		case runningCM == nil:
+			graphCli.Create(dag, cm)
+		case !reflect.DeepEqual(runningCM.Data, cm.Data) || !reflect.DeepEqual(runningCM.OwnerReferences, cm.OwnerReferences):
+			cmCopy := runningCM.DeepCopy()
+			cmCopy.Data = cm.Data
+			cmCopy.OwnerReferences = cm.OwnerReferences
+			cmCopy.Finalizers = cm.Finalizers
+			graphCli.Update(dag, runningCM, cmCopy)
+		}
+		delete(runningCMs, cmName)
+	}
+	// the shardings have been removed.
+	for _, cm := range runningCMs {
+		graphCli.Delete(dag, cm)
+	}
+	return nil
+}
+
+func (t *clusterShardingRoutingTransformer) buildRoutingConfigMap(transCtx *clusterTransformContext,
+	cluster *appsv1alpha1.Cluster, shardingName string, runningCM *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+	shards := make([]string, 0)
+	for _, compSpec := range transCtx.ShardingComponentSpecs[shardingName] {
+		shards = append(shards, compSpec.Name)
+	}
+
+	var publishedSlots map[string][]intctrlutil.HashSlotRange
+	if runningCM != nil {
+		publishedSlots = intctrlutil.ParseShardingRoutingSlots(runningCM.Data[intctrlutil.ShardingRoutingDataKey])
+	}
+	slots := map[string][]intctrlutil.HashSlotRange{}
+	comps, err := intctrlutil.ListShardingComponents(transCtx.Context, transCtx.Client, cluster, shardingName)
+	if err != nil {
+		return nil, err
+	}
+	for i := range comps {
+		comp := &comps[i]
+		shard, err := component.ShortName(cluster.Name, comp.Name)
+		if err != nil {
+			return nil, err
+		}
+		shardSlots, err := t.shardSlots(transCtx, cluster, comp, publishedSlots[shard])
+		if err != nil {
+			return nil, err
+		}
+		if len(shardSlots) > 0 {
+			slots[shard] = shardSlots
+		}
+	}
+
+	clusterDomain := viper.GetString(constant.KubernetesClusterDomainEnv)
+	routing := intctrlutil.BuildShardingRouting(shardingName, shards, slots, func(shard string) (string, intctrlutil.ShardEndpoints) {
+		return constant.GenerateClusterComponentName(cluster.Name, shard), intctrlutil.ShardEndpoints{
+			Service: fmt.Sprintf("%s.%s.svc.%s",
+				constant.GenerateDefaultComponentServiceName(cluster.Name, shard), cluster.Namespace, clusterDomain),
+			HeadlessService: fmt.Sprintf("%s.%s.svc.%s",
+				constant.GenerateDefaultComponentHeadlessServiceName(cluster.Name, shard), cluster.Namespace, clusterDomain),
+		}
+	})
+	data, err := json.Marshal(routing)
+	if err != nil {
+		return nil, err
+	}
+
+	cm := builder.NewConfigMapBuilder(cluster.Namespace, constant.GenerateShardingRoutingConfigMapName(cluster.Name, shardingName)).
+		AddLabelsInMap(constant.GetClusterWellKnownLabels(cluster.Name)).
+		AddLabels(constant.KBAppShardingNameLabelKey, shardingName).
+		SetData(map[string]string{intctrlutil.ShardingRoutingDataKey: string(data)}).
+		GetObject()
+	if err = intctrlutil.SetOwnership(cluster, cm, rscheme, constant.DBClusterFinalizerName); err != nil {
+		return nil, err
+	}
+	return cm, nil
+}
+
+// shardSlots returns the hash slots served by the shard. The slots are reported by the shardRouting action if the
+// Component defines it, and the published slots are kept if the action can't be invoked for now.
+// Otherwise, the slots are specified by the annotation of the Component.
+func (t *clusterShardingRoutingTransformer) shardSlots(transCtx *clusterTransformContext,
+	cluster *appsv1alpha1.Cluster, comp *appsv1alpha1.Component, published []intctrlutil.HashSlotRange) ([]intctrlutil.HashSlotRange, error) {
+	compDef := transCtx.ComponentDefs[comp.Spec.CompDef]
+	if compDef == nil || compDef.Spec.LifecycleActions == nil || compDef.Spec.LifecycleActions.ShardRouting == nil {
+		value, ok := comp.Annotations[constant.ShardHashSlotsAnnotationKey]
+		if !ok {
+			return nil, nil
+		}
+		slots, err := intctrlutil.ParseHashSlotRanges(value)
+		if err != nil {
+			return nil, fmt.Errorf("the annotation %s of component %s is invalid: %s", constant.ShardHashSlotsAnnotationKey, comp.Name, err.Error())
+		}
+		return slots, nil
+	}
+	if comp.DeletionTimestamp != nil || comp.Status.Phase != appsv1alpha1.RunningClusterCompPhase {
+		return published, nil
+	}
+	shard, _ := component.ShortName(cluster.Name, comp.Name)
+	output, err := t.invokeShardRoutingAction(transCtx, cluster, shard)
+	if err != nil {
+		transCtx.Logger.Info("failed to invoke the shardRouting action, keep the published slots",
+			"component", comp.Name, "error", err.Error())
+		return published, nil
+	}
+	slots, err := intctrlutil.ParseHashSlotRanges(output)
+	if err != nil {
+		transCtx.Logger.Info("the output of the shardRouting action is invalid, keep the published slots",
+			"component", comp.Name, "error", err.Error())
+		return published, nil
+	}
+	sort.Slice(slots, func(i, j int) bool {
+		return slots[i].Start < slots[j].Start
+	})
+	return slots, nil
+}
+
+// invokeShardRoutingAction invokes the shardRouting action on a ready instance of the shard.
+func (t *clusterShardingRoutingTransformer) invokeShardRoutingAction(transCtx *clusterTransformContext,
+	cluster *appsv1alpha1.Cluster, shard string) (string, error) {
+	pods, err := component.ListOwnedPods(transCtx.Context, transCtx.Client, cluster.Namespace, cluster.Name, shard)
+	if err != nil {
+		return "", err
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Name < pods[j].Name
+	})
+	for _, pod := range pods {
+		if !intctrlutil.PodIsReady(pod) {
+			continue
+		}
+		agentCli, err := kbagent.NewClient(*pod)
+		if err != nil {
+			return "", err
+		}
+		if intctrlutil.IsNil(agentCli) {
+			return "", fmt.Errorf(`the instance "%s" doesn't run the kb-agent to invoke the shardRouting action`, pod.Name)
+		}
+		ctx, cancel := context.WithTimeout(transCtx.Context, shardRoutingActionTimeout)
+		output, err := agentCli.Action(ctx, constant.ShardRoutingAction, nil)
+		cancel()
+		return strings.TrimSpace(output), err
+	}
+	return "", fmt.Errorf(`no ready instance of the shard "%s"`, shard)
+}
+
+func (t *clusterShardingRoutingTransformer) listOwnedRoutingConfigMaps(transCtx *clusterTransformContext,
+	cluster *appsv1alpha1.Cluster) (map[string]*corev1.ConfigMap, error) {
+	cmList := &corev1.ConfigMapList{}
+	if err := transCtx.Client.List(transCtx.Context, cmList, client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(constant.GetClusterWellKnownLabels(cluster.Name)), client.HasLabels{constant.KBAppShardingNameLabelKey}); err != nil {
+		return nil, err
+	}
+	cms := make(map[string]*corev1.ConfigMap)
+	for i, cm := range cmList.Items {
+		if cm.Name == constant.GenerateShardingRoutingConfigMapName(cluster.Name, cm.Labels[constant.KBAppShardingNameLabelKey]) {
+			cms[cm.Name] = &cmList.Items[i]
+		}
+	}
+	return cms, nil
+}
@@ -27,6 +27,7 @@ import (
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controller/plan"
 )
 
 type clusterStatusTransformer struct {
@@ -47,6 +48,8 @@ func (t *clusterStatusTransformer) Transform(ctx graph.TransformContext, dag *gr
 	updateObservedGeneration := func() {
 		cluster.Status.ObservedGeneration = cluster.Generation
 		cluster.Status.ClusterDefGeneration = transCtx.ClusterDef.Generation
+		// the changes of the new generation are applied, but not rolled out to the components yet.
+		meta.SetStatusCondition(&cluster.Status.Conditions, newNotConvergedCondition(cluster.Generation, nil))
 	}
 
 	switch {
@@ -90,6 +93,8 @@ func (t *clusterStatusTransformer) reconcileClusterPhase(cluster *appsv1alpha1.C
 		isAllComponentFailed         = true
 		hasComponentAbnormalOrFailed = false
 	)
+	// part of the components are stopped, e.g. by a Stop OpsRequest with the selected components.
+	isAllComponentRunningOrStopped := true
 	isPhaseIn := func(phase appsv1alpha1.ClusterComponentPhase, phases ...appsv1alpha1.ClusterComponentPhase) bool {
 		for _, p := range phases {
 			if p == phase {
@@ -117,6 +122,9 @@ func (t *clusterStatusTransformer) reconcileClusterPhase(cluster *appsv1alpha1.C
 		if !isPhaseIn(phase, appsv1alpha1.StoppedClusterCompPhase) {
 			isAllComponentStopped = false
 		}
+		if !isPhaseIn(phase, appsv1alpha1.RunningClusterCompPhase, appsv1alpha1.StoppedClusterCompPhase) {
+			isAllComponentRunningOrStopped = false
+		}
 		if !isPhaseIn(phase, appsv1alpha1.FailedClusterCompPhase) {
 			isAllComponentFailed = false
 		}
@@ -138,6 +146,10 @@ func (t *clusterStatusTransformer) reconcileClusterPhase(cluster *appsv1alpha1.C
 		if cluster.Status.Phase != appsv1alpha1.StoppedClusterPhase {
 			t.syncClusterPhaseToStopped(cluster)
 		}
+	case isAllComponentRunningOrStopped:
+		if cluster.Status.Phase != appsv1alpha1.RunningClusterPhase {
+			t.syncClusterPhaseToRunning(cluster)
+		}
 	case hasComponentStopping:
 		cluster.Status.Phase = appsv1alpha1.StoppingClusterPhase
 	case isAllComponentFailed:
@@ -172,6 +184,9 @@ func (t *clusterStatusTransformer) reconcileClusterStatus(transCtx *clusterTrans
 	// sync the cluster phase.
 	t.reconcileClusterPhase(cluster)
 
+	// the cluster is not ready until the restored data is masked.
+	t.syncReadyConditionForDataMasking(cluster)
+
 	// removes the component of status.components which is created by simplified API.
 	t.removeInnerCompStatus(transCtx, cluster)
 	return nil
@@ -239,6 +254,21 @@ func (t *clusterStatusTransformer) syncReadyConditionForCluster(cluster *appsv1a
 	}
 }
 
+// syncReadyConditionForDataMasking keeps the ready condition false until the restored data of the cluster is masked.
+func (t *clusterStatusTransformer) syncReadyConditionForDataMasking(cluster *appsv1alpha1.Cluster) {
+	if cluster.Status.Phase != appsv1alpha1.RunningClusterPhase {
+		return
+	}
+	if plan.HasPendingDataMasking(cluster) {
+		meta.SetStatusCondition(&cluster.Status.Conditions, newDataMaskingCondition(cluster.Name))
+		return
+	}
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, appsv1alpha1.ConditionTypeReady)
+	if condition != nil && condition.Reason == ReasonDataMasking {
+		meta.SetStatusCondition(&cluster.Status.Conditions, newClusterReadyCondition(cluster.Name))
+	}
+}
+
 // syncClusterPhaseToRunning syncs the cluster phase to Running.
 func (t *clusterStatusTransformer) syncClusterPhaseToRunning(cluster *appsv1alpha1.Cluster) {
 	cluster.Status.Phase = appsv1alpha1.RunningClusterPhase
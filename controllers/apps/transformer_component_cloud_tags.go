@@ -0,0 +1,144 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// componentCloudTagsTransformer provisions the volumes of the component by the StorageClasses with the cloud tags
+// of the cluster, the CSI drivers tag the provisioned volumes with the parameters of the StorageClasses.
+// The StorageClass of the PVCs is immutable, so the volumes of the running workload keep their StorageClasses,
+// and the tags only apply to the volumes provisioned by the new claim templates.
+type componentCloudTagsTransformer struct{}
+
+var _ graph.Transformer = &componentCloudTagsTransformer{}
+
+func (t *componentCloudTagsTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*componentTransformContext)
+	if model.IsObjectDeleting(transCtx.ComponentOrig) {
+		return nil
+	}
+	synthesizeComp := transCtx.SynthesizeComponent
+	if len(synthesizeComp.CloudTags) == 0 {
+		return nil
+	}
+
+	workloadList, err := component.ListOwnedWorkloads(transCtx.Context, transCtx.Client,
+		synthesizeComp.Namespace, synthesizeComp.ClusterName, synthesizeComp.Name)
+	if err != nil {
+		return err
+	}
+	var runningITS *workloads.InstanceSet
+	if len(workloadList) > 0 {
+		runningITS = workloadList[0]
+	}
+
+	storageClasses := map[string]*string{}
+	taggedStorageClass := func(running []corev1.PersistentVolumeClaim, vctName string, storageClassName *string) (*string, error) {
+		for _, pvc := range running {
+			if pvc.Name == vctName {
+				return pvc.Spec.StorageClassName, nil
+			}
+		}
+		name := pointer.StringDeref(storageClassName, "")
+		if tagged, ok := storageClasses[name]; ok {
+			return tagged, nil
+		}
+		tagged, err := t.buildStorageClass(transCtx, dag, name)
+		if err != nil {
+			return nil, err
+		}
+		if tagged == nil {
+			tagged = storageClassName
+		}
+		storageClasses[name] = tagged
+		return tagged, nil
+	}
+
+	var runningVCTs []corev1.PersistentVolumeClaim
+	if runningITS != nil {
+		runningVCTs = runningITS.Spec.VolumeClaimTemplates
+	}
+	for i, vct := range synthesizeComp.VolumeClaimTemplates {
+		if synthesizeComp.VolumeClaimTemplates[i].Spec.StorageClassName, err =
+			taggedStorageClass(runningVCTs, vct.Name, vct.Spec.StorageClassName); err != nil {
+			return err
+		}
+	}
+
+	// the instance templates are shared with the Component object, copy them before updating.
+	instances := make([]appsv1alpha1.InstanceTemplate, len(synthesizeComp.Instances))
+	for i := range synthesizeComp.Instances {
+		synthesizeComp.Instances[i].DeepCopyInto(&instances[i])
+		var runningVCTs []corev1.PersistentVolumeClaim
+		if runningITS != nil {
+			for _, tpl := range runningITS.Spec.Instances {
+				if tpl.Name == instances[i].Name {
+					runningVCTs = tpl.VolumeClaimTemplates
+				}
+			}
+		}
+		for j, vct := range instances[i].VolumeClaimTemplates {
+			if instances[i].VolumeClaimTemplates[j].Spec.StorageClassName, err =
+				taggedStorageClass(runningVCTs, vct.Name, vct.Spec.ToV1PersistentVolumeClaimSpec().StorageClassName); err != nil {
+				return err
+			}
+		}
+	}
+	if len(instances) > 0 {
+		synthesizeComp.Instances = instances
+	}
+	return nil
+}
+
+// buildStorageClass creates the StorageClass with the cloud tags for the StorageClass, and returns its name.
+// It returns nil if the CSI driver of the StorageClass doesn't support tagging the volumes.
+func (t *componentCloudTagsTransformer) buildStorageClass(transCtx *componentTransformContext, dag *graph.DAG,
+	storageClassName string) (*string, error) {
+	sc, err := getStorageClass(transCtx, storageClassName)
+	if err != nil {
+		return nil, err
+	}
+	tagged := intctrlutil.BuildCloudTagsStorageClass(sc, transCtx.SynthesizeComponent.CloudTags)
+	if tagged == nil {
+		return nil, nil
+	}
+	err = transCtx.Client.Get(transCtx.Context, client.ObjectKeyFromObject(tagged), &storagev1.StorageClass{}, inDataContext4C())
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	if apierrors.IsNotFound(err) {
+		graphCli, _ := transCtx.Client.(model.GraphClient)
+		graphCli.Create(dag, tagged, inDataContext4G())
+	}
+	return &tagged.Name, nil
+}
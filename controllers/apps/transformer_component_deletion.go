@@ -115,7 +115,7 @@ func (t *componentDeletionTransformer) handleCompDeleteWhenClusterDelete(transCt
 	case appsv1alpha1.Halt:
 		toPreserveKinds = compOwnedPreserveKinds()
 		toDeleteKinds = kindsForCompHalt()
-	case appsv1alpha1.Delete:
+	case appsv1alpha1.Delete, appsv1alpha1.Retain:
 		toDeleteKinds = kindsForCompDelete()
 	case appsv1alpha1.WipeOut:
 		toDeleteKinds = kindsForCompWipeOut()
@@ -20,6 +20,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package apps
 
 import (
+	"fmt"
 	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
@@ -72,6 +73,13 @@ func allocateHostPorts(synthesizedComp *component.SynthesizedComponent) (map[str
 		}
 		return containerPorts[p]
 	}
+	if synthesizedComp.HostNetworkMode != nil {
+		for _, p := range synthesizedComp.HostNetworkMode.StaticPorts {
+			if !needAllocate(p.Container, p.Port) {
+				return nil, fmt.Errorf("the static host port of %s/%s is not declared in the host-network of the component definition", p.Container, p.Port)
+			}
+		}
+	}
 	return allocateHostPortsWithFunc(pm, synthesizedComp, needAllocate)
 }
 
@@ -88,9 +96,16 @@ func allocateHostPortsWithFunc(pm *intctrlutil.PortManager, synthesizedComp *com
 		for _, p := range c.Ports {
 			portKey := intctrlutil.BuildHostPortName(synthesizedComp.ClusterName, synthesizedComp.Name, c.Name, p.Name)
 			if needAllocate(c.Name, p.Name) {
-				port, err := pm.AllocatePort(portKey)
-				if err != nil {
-					return nil, err
+				port := component.GetStaticHostPort(synthesizedComp, c.Name, p.Name)
+				if port > 0 {
+					if err := pm.UsePort(portKey, port); err != nil {
+						return nil, err
+					}
+				} else {
+					var err error
+					if port, err = pm.AllocatePort(portKey); err != nil {
+						return nil, err
+					}
 				}
 				insert(c.Name, p.Name, port)
 			} else {
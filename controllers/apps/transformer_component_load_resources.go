@@ -21,7 +21,10 @@ package apps
 
 import (
 	"fmt"
+	"time"
 
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
@@ -96,6 +99,9 @@ func (t *componentLoadResourcesTransformer) transformForNativeComponent(transCtx
 	if err = component.UpdateCompDefinitionImages4ServiceVersion(ctx, cli, compDef, comp.Spec.ServiceVersion); err != nil {
 		return newRequeueError(requeueDuration, err.Error())
 	}
+	if err = t.checkServiceVersionRisk(transCtx, compDef); err != nil {
+		return err
+	}
 	transCtx.CompDef = compDef
 
 	reqCtx := ictrlutil.RequestCtx{
@@ -112,3 +118,30 @@ func (t *componentLoadResourcesTransformer) transformForNativeComponent(transCtx
 
 	return nil
 }
+
+// checkServiceVersionRisk reports the risks of the service version recorded in the ComponentVersions by the condition,
+// and checks the risks again once the service version reaches the end of life. The clusters with the risky service
+// versions are rejected by the cluster webhook if the policy is "Block".
+func (t *componentLoadResourcesTransformer) checkServiceVersionRisk(transCtx *componentTransformContext,
+	compDef *appsv1alpha1.ComponentDefinition) error {
+	comp := transCtx.Component
+	risk, nextEndOfLife, err := component.ResolveServiceVersionRisk(transCtx.Context, transCtx.Client, compDef, comp.Spec.ServiceVersion)
+	if err != nil {
+		return newRequeueError(requeueDuration, err.Error())
+	}
+	if !nextEndOfLife.IsZero() {
+		transCtx.RequeueAfter = time.Until(nextEndOfLife)
+	}
+	if risk == nil {
+		meta.RemoveStatusCondition(&comp.Status.Conditions, appsv1alpha1.ConditionTypeServiceVersionRisk)
+		return nil
+	}
+	meta.SetStatusCondition(&comp.Status.Conditions, metav1.Condition{
+		Type:               appsv1alpha1.ConditionTypeServiceVersionRisk,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: comp.Generation,
+		Reason:             risk.Reason(),
+		Message:            risk.Message(),
+	})
+	return nil
+}
@@ -21,6 +21,7 @@ package apps
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -28,6 +29,7 @@ import (
 
 	"golang.org/x/exp/maps"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
@@ -40,6 +42,8 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	"github.com/apecloud/kubeblocks/pkg/controller/multicluster"
+	"github.com/apecloud/kubeblocks/pkg/controller/plan"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
 var (
@@ -48,10 +52,15 @@ var (
 
 	multiClusterServicePlacementInMirror = "mirror"
 	multiClusterServicePlacementInUnique = "unique"
+
+	instanceIPServiceName = "instance-ip"
 )
 
 // componentServiceTransformer handles component services.
-type componentServiceTransformer struct{}
+type componentServiceTransformer struct {
+	// readServingLabeled indicates whether all the pods of the component carry the read serving label.
+	readServingLabeled bool
+}
 
 var _ graph.Transformer = &componentServiceTransformer{}
 
@@ -71,12 +80,22 @@ func (t *componentServiceTransformer) Transform(ctx graph.TransformContext, dag
 		return err
 	}
 
+	if t.readServingLabeled, err = t.isReadServingLabeled(transCtx, synthesizeComp); err != nil {
+		return err
+	}
+
+	// hold the services until the restored data is masked, the sensitive data should not be accessed through them.
+	holdServices := t.isDataMaskingPending(transCtx)
+
 	graphCli, _ := transCtx.Client.(model.GraphClient)
-	for _, service := range synthesizeComp.ComponentServices {
+	for _, service := range t.withInstanceIPService(synthesizeComp) {
 		// component controller does not handle the default headless service; the default headless service is managed by the InstanceSet.
 		if t.skipDefaultHeadlessSvc(synthesizeComp, &service) {
 			continue
 		}
+		if holdServices && service.Name != instanceIPServiceName {
+			continue
+		}
 		services, err := t.buildCompService(transCtx.Component, synthesizeComp, &service)
 		if err != nil {
 			return err
@@ -96,6 +115,61 @@ func (t *componentServiceTransformer) Transform(ctx graph.TransformContext, dag
 	return nil
 }
 
+// isDataMaskingPending checks whether the restored data of the component is waiting to be masked.
+func (t *componentServiceTransformer) isDataMaskingPending(transCtx *componentTransformContext) bool {
+	if transCtx.Cluster == nil || transCtx.Component.Annotations[constant.RestoreDoneAnnotationKey] == "true" {
+		return false
+	}
+	compName := transCtx.Component.Labels[constant.KBAppShardingNameLabelKey]
+	if len(compName) == 0 {
+		compName = transCtx.SynthesizeComponent.Name
+	}
+	return plan.IsDataMaskingPending(transCtx.Cluster, compName)
+}
+
+// withInstanceIPService appends a ClusterIP pod service to the component services if the instances of the component
+// are required to have stable IPs through Services.
+func (t *componentServiceTransformer) withInstanceIPService(synthesizeComp *component.SynthesizedComponent) []appsv1alpha1.ComponentService {
+	if synthesizeComp.InstanceIP == nil || synthesizeComp.InstanceIP.Type != appsv1alpha1.InstanceIPService {
+		return synthesizeComp.ComponentServices
+	}
+	services := make([]appsv1alpha1.ComponentService, 0, len(synthesizeComp.ComponentServices)+1)
+	services = append(services, synthesizeComp.ComponentServices...)
+	return append(services, appsv1alpha1.ComponentService{
+		Service: appsv1alpha1.Service{
+			Name:        instanceIPServiceName,
+			ServiceName: instanceIPServiceName,
+			Spec: corev1.ServiceSpec{
+				Type:  corev1.ServiceTypeClusterIP,
+				Ports: t.instanceIPServicePorts(synthesizeComp),
+			},
+		},
+		PodService: func() *bool { b := true; return &b }(),
+	})
+}
+
+func (t *componentServiceTransformer) instanceIPServicePorts(synthesizeComp *component.SynthesizedComponent) []corev1.ServicePort {
+	ports := make([]corev1.ServicePort, 0)
+	if synthesizeComp.PodSpec == nil {
+		return ports
+	}
+	for _, container := range synthesizeComp.PodSpec.Containers {
+		for _, port := range container.Ports {
+			name := port.Name
+			if len(name) == 0 {
+				name = fmt.Sprintf("%s-%d", strings.ToLower(string(port.Protocol)), port.ContainerPort)
+			}
+			ports = append(ports, corev1.ServicePort{
+				Name:       name,
+				Protocol:   port.Protocol,
+				Port:       port.ContainerPort,
+				TargetPort: intstr.FromInt32(port.ContainerPort),
+			})
+		}
+	}
+	return ports
+}
+
 func (t *componentServiceTransformer) listOwnedServices(ctx context.Context, cli client.Reader,
 	comp *appsv1alpha1.Component, synthesizedComp *component.SynthesizedComponent) (map[string]*corev1.Service, error) {
 	services, err := component.ListOwnedServices(ctx, cli, synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name)
@@ -200,6 +274,9 @@ func (t *componentServiceTransformer) buildService(comp *appsv1alpha1.Component,
 	labels := constant.GetComponentWellKnownLabels(clusterName, compName)
 	builder := builder.NewServiceBuilder(namespace, serviceFullName).
 		AddLabelsInMap(labels).
+		AddAnnotationsInMap(intctrlutil.BuildServiceCloudTagsAnnotations(synthesizeComp.CloudTags, service.Spec.Type)).
+		AddAnnotationsInMap(intctrlutil.BuildServiceDNSAnnotations(synthesizeComp.DNS,
+			namespace, clusterName, compName, serviceFullName, service.Spec.Type)).
 		AddAnnotationsInMap(service.Annotations).
 		SetSpec(&service.Spec).
 		AddSelectorsInMap(t.builtinSelector(comp)).
@@ -210,9 +287,21 @@ func (t *componentServiceTransformer) buildService(comp *appsv1alpha1.Component,
 			return nil, err
 		}
 		builder.AddSelector(constant.RoleLabelKey, service.RoleSelector)
+		if t.isReadService(synthesizeComp, service.RoleSelector) {
+			// exclude the backup replica from the read service while a backup runs on it.
+			builder.AddSelector(constant.ReadServingLabelKey, "true")
+		}
+		if t.isReadOnlyRole(synthesizeComp, service.RoleSelector) {
+			annotations, err := t.buildReplicaWeightsAnnotations(synthesizeComp)
+			if err != nil {
+				return nil, err
+			}
+			builder.AddAnnotationsInMap(annotations)
+		}
 	}
 
 	svcObj := builder.GetObject()
+	component.ApplyIPStack(synthesizeComp.IPStack, &svcObj.Spec)
 	if err := setCompOwnershipNFinalizer(comp, svcObj); err != nil {
 		return nil, err
 	}
@@ -245,6 +334,62 @@ func (t *componentServiceTransformer) checkRoleSelector(synthesizeComp *componen
 	return nil
 }
 
+// isReadOnlyRole checks whether the role is a non-writable role of the component.
+func (t *componentServiceTransformer) isReadOnlyRole(synthesizeComp *component.SynthesizedComponent, roleSelector string) bool {
+	for _, role := range synthesizeComp.Roles {
+		if strings.EqualFold(role.Name, roleSelector) {
+			return !role.Writable
+		}
+	}
+	return false
+}
+
+// buildReplicaWeightsAnnotations renders the weights of the replicas into the annotations of the read service,
+// so that the proxies and load balancers supporting weighted routing can balance the read traffic by the weights.
+func (t *componentServiceTransformer) buildReplicaWeightsAnnotations(synthesizeComp *component.SynthesizedComponent) (map[string]string, error) {
+	weights, err := component.BuildReplicaWeights(synthesizeComp)
+	if err != nil || len(weights) == 0 {
+		return nil, err
+	}
+	data, err := json.Marshal(weights)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{constant.ReplicaWeightsAnnotationKey: string(data)}, nil
+}
+
+// isReadServingLabeled checks whether all the pods of the component with the backup replica enabled carry the read serving label.
+// The label is set through the pod template, the read services select it only after all the pods are updated,
+// otherwise the services would have no endpoints until then.
+func (t *componentServiceTransformer) isReadServingLabeled(transCtx *componentTransformContext,
+	synthesizeComp *component.SynthesizedComponent) (bool, error) {
+	if synthesizeComp.BackupReplica == nil || !*synthesizeComp.BackupReplica {
+		return false, nil
+	}
+	pods, err := component.ListOwnedPods(transCtx.Context, transCtx.Client,
+		synthesizeComp.Namespace, synthesizeComp.ClusterName, synthesizeComp.Name)
+	if err != nil {
+		return false, err
+	}
+	if len(pods) < int(synthesizeComp.Replicas) {
+		return false, nil
+	}
+	for _, pod := range pods {
+		if _, ok := pod.Labels[constant.ReadServingLabelKey]; !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// isReadService checks whether the service selects a non-writable role of the component with the backup replica enabled.
+func (t *componentServiceTransformer) isReadService(synthesizeComp *component.SynthesizedComponent, roleSelector string) bool {
+	if !t.readServingLabeled {
+		return false
+	}
+	return t.isReadOnlyRole(synthesizeComp, roleSelector)
+}
+
 func (t *componentServiceTransformer) skipDefaultHeadlessSvc(synthesizeComp *component.SynthesizedComponent, service *appsv1alpha1.ComponentService) bool {
 	svcName := constant.GenerateComponentServiceName(synthesizeComp.ClusterName, synthesizeComp.Name, service.ServiceName)
 	defaultHeadlessSvcName := constant.GenerateDefaultComponentHeadlessServiceName(synthesizeComp.ClusterName, synthesizeComp.Name)
@@ -20,6 +20,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package apps
 
 import (
+	"encoding/json"
 	"fmt"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -235,4 +236,48 @@ var _ = Describe(" component service transformer test", func() {
 			Expect(graphCli.IsAction(dag, svc, model.ActionCreatePtr())).Should(BeTrue())
 		})
 	})
+
+	Context("replica weights", func() {
+		It("renders the weights into the read service", func() {
+			synthesizeComp := transCtx.SynthesizeComponent
+			synthesizeComp.Roles = []appsv1alpha1.ReplicaRole{
+				{Name: "leader", Serviceable: true, Writable: true},
+				{Name: "follower", Serviceable: true},
+			}
+			synthesizeComp.ComponentServices = append(synthesizeComp.ComponentServices, appsv1alpha1.ComponentService{
+				Service: appsv1alpha1.Service{
+					Name:         "read",
+					ServiceName:  "read",
+					RoleSelector: "follower",
+				},
+			})
+			synthesizeComp.Instances = []appsv1alpha1.InstanceTemplate{{Name: "large", Replicas: func() *int32 { r := int32(1); return &r }()}}
+			synthesizeComp.ReplicaWeights = []appsv1alpha1.ReplicaWeight{
+				{Template: "large", Weight: 4},
+				{Instances: []string{constant.GenerateWorkloadNamePattern(clusterName, compName) + "-1"}, Weight: 2},
+			}
+
+			transformer := &componentServiceTransformer{}
+			Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+
+			graphCli := transCtx.Client.(model.GraphClient)
+			objs := graphCli.FindAll(dag, &corev1.Service{})
+			Expect(objs).Should(HaveLen(2))
+			workloadName := constant.GenerateWorkloadNamePattern(clusterName, compName)
+			for _, obj := range objs {
+				svc := obj.(*corev1.Service)
+				if svc.Name == constant.GenerateComponentServiceName(clusterName, compName, "default") {
+					Expect(svc.Annotations).ShouldNot(HaveKey(constant.ReplicaWeightsAnnotationKey))
+					continue
+				}
+				weights := map[string]int32{}
+				Expect(json.Unmarshal([]byte(svc.Annotations[constant.ReplicaWeightsAnnotationKey]), &weights)).Should(Succeed())
+				Expect(weights).Should(Equal(map[string]int32{
+					workloadName + "-0":       1,
+					workloadName + "-1":       2,
+					workloadName + "-large-0": 4,
+				}))
+			}
+		})
+	})
 })
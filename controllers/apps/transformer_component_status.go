@@ -26,6 +26,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -161,6 +162,10 @@ func (t *componentStatusTransformer) reconcileStatus(transCtx *componentTransfor
 	// check if the component is available
 	isComponentAvailable := t.isComponentAvailable()
 
+	if err = t.reconcileOfflineInstances(transCtx); err != nil {
+		return err
+	}
+
 	// check if the component is in creating phase
 	isInCreatingPhase := func() bool {
 		phase := t.comp.Status.Phase
@@ -357,6 +362,52 @@ func (t *componentStatusTransformer) hasVolumeExpansionRunning(transCtx *compone
 	return running, failed, nil
 }
 
+// reconcileOfflineInstances reports the offline instances of the component and the PVCs retained for them.
+func (t *componentStatusTransformer) reconcileOfflineInstances(transCtx *componentTransformContext) error {
+	if len(t.synthesizeComp.OfflineInstances) == 0 {
+		t.comp.Status.OfflineInstances = nil
+		return nil
+	}
+	pvcs, err := component.ListOwnedPVCs(transCtx.Context, t.Client, t.synthesizeComp.Namespace, t.synthesizeComp.ClusterName, t.synthesizeComp.Name)
+	if err != nil {
+		return err
+	}
+	t.comp.Status.OfflineInstances = buildOfflineInstancesStatus(t.synthesizeComp, pvcs, t.comp.Status.OfflineInstances, metav1.Now())
+	return nil
+}
+
+func buildOfflineInstancesStatus(synthesizeComp *component.SynthesizedComponent, pvcs []*corev1.PersistentVolumeClaim,
+	lastStatus []appsv1alpha1.OfflineInstanceStatus, now metav1.Time) []appsv1alpha1.OfflineInstanceStatus {
+	pvcMap := make(map[string]*corev1.PersistentVolumeClaim)
+	for i := range pvcs {
+		pvcMap[pvcs[i].Name] = pvcs[i]
+	}
+	offlineSince := make(map[string]metav1.Time)
+	for _, ins := range lastStatus {
+		offlineSince[ins.Name] = ins.OfflineSince
+	}
+	var statuses []appsv1alpha1.OfflineInstanceStatus
+	for _, insName := range synthesizeComp.OfflineInstances {
+		status := appsv1alpha1.OfflineInstanceStatus{Name: insName, OfflineSince: now}
+		if since, ok := offlineSince[insName]; ok {
+			status.OfflineSince = since
+		}
+		for _, vct := range synthesizeComp.VolumeClaimTemplates {
+			pvc, ok := pvcMap[fmt.Sprintf("%s-%s", vct.Name, insName)]
+			if !ok {
+				continue
+			}
+			status.RetainedPVCs = append(status.RetainedPVCs, appsv1alpha1.RetainedPVC{
+				Name:              pvc.Name,
+				Size:              pvc.Spec.Resources.Requests[corev1.ResourceStorage],
+				CreationTimestamp: pvc.CreationTimestamp,
+			})
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
 // hasFailedPod checks if the instance set has failed pod.
 func (t *componentStatusTransformer) hasFailedPod() (bool, appsv1alpha1.ComponentMessageMap) {
 	messages := appsv1alpha1.ComponentMessageMap{}
@@ -23,8 +23,17 @@ import (
 	"fmt"
 	"math"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 var (
@@ -54,6 +63,15 @@ func (t *componentValidationTransformer) Transform(ctx graph.TransformContext, d
 	if err = validateCompReplicas(comp, transCtx.CompDef); err != nil {
 		return newRequeueError(requeueDuration, err.Error())
 	}
+	if err = validateCompReplicasProtection(transCtx); err != nil {
+		return newRequeueError(requeueDuration, err.Error())
+	}
+	if err = validateCompKernelTuning(transCtx); err != nil {
+		return newRequeueError(requeueDuration, err.Error())
+	}
+	if err = validateCompArchitectures(transCtx); err != nil {
+		return newRequeueError(requeueDuration, err.Error())
+	}
 	// if err = validateSidecarContainers(comp, transCtx.CompDef); err != nil {
 	// 	return newRequeueError(requeueDuration, err.Error())
 	// }
@@ -102,6 +120,88 @@ func validateCompReplicas(comp *appsv1alpha1.Component, compDef *appsv1alpha1.Co
 	return replicasOutOfLimitError(replicas, *replicasLimit)
 }
 
+// validateCompReplicasProtection rejects setting the replicas of a running component to 0 by editing the spec,
+// the Stop OpsRequest should be used to stop the component instead.
+func validateCompReplicasProtection(transCtx *componentTransformContext) error {
+	comp := transCtx.Component
+	if !viper.GetBool(constant.FeatureGateReplicasProtection) || comp.Spec.Replicas != 0 || boolptr.IsSetToTrue(comp.Spec.Stop) {
+		return nil
+	}
+	synthesizeComp := transCtx.SynthesizeComponent
+	objs, err := component.ListOwnedWorkloads(transCtx.Context, transCtx.Client,
+		synthesizeComp.Namespace, synthesizeComp.ClusterName, synthesizeComp.Name)
+	if err != nil {
+		return err
+	}
+	// the component is being created with 0 replicas, or it has been scaled to 0 already.
+	if len(objs) == 0 || objs[0].Spec.Replicas == nil || *objs[0].Spec.Replicas == 0 {
+		return nil
+	}
+	return fmt.Errorf("setting the replicas of a running component to 0 is rejected, use the Stop OpsRequest to stop it instead")
+}
+
+// validateCompKernelTuning checks whether there is a schedulable node which satisfies the kernel tuning of the component,
+// i.e. it is labeled with the node-level sysctls and has enough huge pages.
+func validateCompKernelTuning(transCtx *componentTransformContext) error {
+	kernelTuning := transCtx.Component.Spec.KernelTuning
+	if kernelTuning == nil {
+		return nil
+	}
+	if err := component.ValidateKernelTuning(kernelTuning); err != nil {
+		return err
+	}
+	selector := component.KernelTuningNodeSelector(kernelTuning)
+	if len(selector) == 0 && kernelTuning.HugePages == nil {
+		return nil
+	}
+	nodes := &corev1.NodeList{}
+	if err := transCtx.Client.List(transCtx.Context, nodes, client.MatchingLabels(selector)); err != nil {
+		return err
+	}
+	for _, node := range nodes.Items {
+		if node.Spec.Unschedulable {
+			continue
+		}
+		if kernelTuning.HugePages != nil {
+			allocatable, ok := node.Status.Allocatable[component.HugePagesResourceName(kernelTuning.HugePages)]
+			if !ok || allocatable.Cmp(kernelTuning.HugePages.Size) < 0 {
+				continue
+			}
+		}
+		return nil
+	}
+	if kernelTuning.HugePages != nil {
+		return fmt.Errorf("there is no schedulable node labeled with %v and has %s of %s allocatable", selector,
+			kernelTuning.HugePages.Size.String(), component.HugePagesResourceName(kernelTuning.HugePages))
+	}
+	return fmt.Errorf("there is no schedulable node labeled with %v", selector)
+}
+
+// validateCompArchitectures checks whether there is a schedulable node with the architectures supported by the images
+// of the component, so that the pods won't be pending forever in a cluster without such nodes.
+func validateCompArchitectures(transCtx *componentTransformContext) error {
+	archs := component.SupportedArchitectures(transCtx.CompDef)
+	if len(archs) == 0 {
+		return nil
+	}
+	requirement, err := labels.NewRequirement(corev1.LabelArchStable, selection.In, archs)
+	if err != nil {
+		return err
+	}
+	nodes := &corev1.NodeList{}
+	if err = transCtx.Client.List(transCtx.Context, nodes,
+		client.MatchingLabelsSelector{Selector: labels.NewSelector().Add(*requirement)}); err != nil {
+		return err
+	}
+	for _, node := range nodes.Items {
+		if !node.Spec.Unschedulable {
+			return nil
+		}
+	}
+	return fmt.Errorf("there is no schedulable node with the architectures %v supported by the images of %s",
+		archs, transCtx.CompDef.Name)
+}
+
 func replicasOutOfLimitError(replicas int32, replicasLimit appsv1alpha1.ReplicasLimit) error {
 	return fmt.Errorf("replicas %d out-of-limit [%d, %d]", replicas, replicasLimit.MinReplicas, replicasLimit.MaxReplicas)
 }
@@ -0,0 +1,139 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"fmt"
+	"strconv"
+
+	storagev1 "k8s.io/api/storage/v1"
+	storagev1alpha1 "k8s.io/api/storage/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+)
+
+const (
+	defaultStorageClassAnnotationKey = "storageclass.kubernetes.io/is-default-class"
+	azureDiskCSIDriverName           = "disk.csi.azure.com"
+)
+
+// componentVolumeAttributesClassTransformer generates the VolumeAttributesClasses for the volumes
+// with the IOPS and throughput specified, the CSI driver modifies the performance of the volumes
+// online once the PVCs are updated to refer to them.
+type componentVolumeAttributesClassTransformer struct{}
+
+var _ graph.Transformer = &componentVolumeAttributesClassTransformer{}
+
+func (t *componentVolumeAttributesClassTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*componentTransformContext)
+	if model.IsObjectDeleting(transCtx.ComponentOrig) {
+		return nil
+	}
+
+	comp := transCtx.Component
+	vcts := comp.Spec.VolumeClaimTemplates
+	for _, ins := range comp.Spec.Instances {
+		vcts = append(vcts, ins.VolumeClaimTemplates...)
+	}
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	created := map[string]bool{}
+	for _, vct := range vcts {
+		pvcSpec := vct.Spec.ToV1PersistentVolumeClaimSpec()
+		if pvcSpec.VolumeAttributesClassName == nil || created[*pvcSpec.VolumeAttributesClassName] {
+			continue
+		}
+		vac, err := t.buildVolumeAttributesClass(transCtx, *pvcSpec.VolumeAttributesClassName,
+			pointer.StringDeref(pvcSpec.StorageClassName, ""), vct.Spec.Performance)
+		if err != nil {
+			return err
+		}
+		if vac != nil {
+			graphCli.Create(dag, vac, inDataContext4G())
+		}
+		created[*pvcSpec.VolumeAttributesClassName] = true
+	}
+	return nil
+}
+
+// buildVolumeAttributesClass builds the VolumeAttributesClass, it returns nil if the class already exists.
+// The VolumeAttributesClass is immutable and shared by the volumes with the same performance.
+func (t *componentVolumeAttributesClassTransformer) buildVolumeAttributesClass(transCtx *componentTransformContext,
+	name, storageClassName string, performance *appsv1alpha1.VolumePerformance) (*storagev1alpha1.VolumeAttributesClass, error) {
+	vac := &storagev1alpha1.VolumeAttributesClass{}
+	err := transCtx.Client.Get(transCtx.Context, client.ObjectKey{Name: name}, vac, inDataContext4C())
+	if err == nil {
+		return nil, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	sc, err := getStorageClass(transCtx, storageClassName)
+	if err != nil {
+		return nil, err
+	}
+	iopsKey, throughputKey := "iops", "throughput"
+	if sc.Provisioner == azureDiskCSIDriverName {
+		iopsKey, throughputKey = "DiskIOPSReadWrite", "DiskMBpsReadWrite"
+	}
+	parameters := map[string]string{}
+	if performance.IOPS != nil {
+		parameters[iopsKey] = strconv.FormatInt(*performance.IOPS, 10)
+	}
+	if performance.Throughput != nil {
+		parameters[throughputKey] = strconv.FormatInt(*performance.Throughput, 10)
+	}
+	return &storagev1alpha1.VolumeAttributesClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{constant.AppManagedByLabelKey: constant.AppName},
+		},
+		DriverName: sc.Provisioner,
+		Parameters: parameters,
+	}, nil
+}
+
+// getStorageClass gets the StorageClass of the volume, the default StorageClass of the cluster is used if not specified.
+func getStorageClass(transCtx *componentTransformContext, name string) (*storagev1.StorageClass, error) {
+	if name != "" {
+		sc := &storagev1.StorageClass{}
+		if err := transCtx.Client.Get(transCtx.Context, client.ObjectKey{Name: name}, sc, inDataContext4C()); err != nil {
+			return nil, err
+		}
+		return sc, nil
+	}
+	scList := &storagev1.StorageClassList{}
+	if err := transCtx.Client.List(transCtx.Context, scList, inDataContext4C()); err != nil {
+		return nil, err
+	}
+	for i, sc := range scList.Items {
+		if sc.Annotations[defaultStorageClassAnnotationKey] == "true" {
+			return &scList.Items[i], nil
+		}
+	}
+	return nil, fmt.Errorf("the default storage class is not found")
+}
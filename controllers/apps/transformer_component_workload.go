@@ -21,15 +21,19 @@ package apps
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
+	vsv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
 	"github.com/spf13/viper"
 	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -42,8 +46,11 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/configuration"
 	"github.com/apecloud/kubeblocks/pkg/controller/factory"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controller/scheduling"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
 	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
 )
 
@@ -101,7 +108,7 @@ func (t *componentWorkloadTransformer) Transform(ctx graph.TransformContext, dag
 	}
 	transCtx.ProtoWorkload = protoITS
 
-	if err = t.reconcileWorkload(synthesizeComp, transCtx.Component, runningITS, protoITS); err != nil {
+	if err = t.reconcileWorkload(transCtx, cluster, synthesizeComp, transCtx.Component, runningITS, protoITS); err != nil {
 		return err
 	}
 
@@ -137,8 +144,8 @@ func (t *componentWorkloadTransformer) runningInstanceSetObject(ctx graph.Transf
 	return objs[0], nil
 }
 
-func (t *componentWorkloadTransformer) reconcileWorkload(synthesizedComp *component.SynthesizedComponent,
-	comp *appsv1alpha1.Component, runningITS, protoITS *workloads.InstanceSet) error {
+func (t *componentWorkloadTransformer) reconcileWorkload(ctx graph.TransformContext, cluster *appsv1alpha1.Cluster,
+	synthesizedComp *component.SynthesizedComponent, comp *appsv1alpha1.Component, runningITS, protoITS *workloads.InstanceSet) error {
 	if runningITS != nil {
 		*protoITS.Spec.Selector = *runningITS.Spec.Selector
 		protoITS.Spec.Template.Labels = intctrlutil.MergeMetadataMaps(runningITS.Spec.Template.Labels, synthesizedComp.UserDefinedLabels)
@@ -146,6 +153,21 @@ func (t *componentWorkloadTransformer) reconcileWorkload(synthesizedComp *compon
 
 	buildInstanceSetPlacementAnnotation(comp, protoITS)
 
+	if err := t.buildInstanceSetIPsAnnotation(ctx, synthesizedComp, runningITS, protoITS); err != nil {
+		return err
+	}
+
+	// the instances are only updated within the disruption windows of the cluster
+	disruptionWindows, err := intctrlutil.BuildDisruptionWindowsAnnotation(cluster)
+	if err != nil {
+		return err
+	}
+	protoITS.Annotations = intctrlutil.MergeMetadataMaps(protoITS.Annotations, disruptionWindows)
+
+	if err := buildInstanceSetConsumerAffinityAnnotation(synthesizedComp, protoITS); err != nil {
+		return err
+	}
+
 	// build configuration template annotations to workload
 	configuration.BuildConfigTemplateAnnotations(protoITS, synthesizedComp)
 
@@ -204,6 +226,11 @@ func (t *componentWorkloadTransformer) handleWorkloadUpdate(reqCtx intctrlutil.R
 		return err
 	}
 
+	// refresh the actions and probes of the running kb-agents
+	if err := cwo.refreshKBAgents(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -311,6 +338,10 @@ func copyAndMergeITS(oldITS, newITS *workloads.InstanceSet, synthesizeComp *comp
 		maps.DeleteFunc(itsObjCopy.Annotations, func(k, v string) bool {
 			return strings.HasPrefix(k, "monitor.kubeblocks.io")
 		})
+		// the instance IPs, disruption windows and consumer affinity are always rebuilt from the component and cluster
+		delete(itsObjCopy.Annotations, constant.InstanceIPsAnnotationKey)
+		delete(itsObjCopy.Annotations, constant.DisruptionWindowsAnnotationKey)
+		delete(itsObjCopy.Annotations, constant.ConsumerAffinityAnnotationKey)
 	}
 	mergeMetadataMap(itsObjCopy.Annotations, &itsProto.Annotations)
 	itsObjCopy.Annotations = itsProto.Annotations
@@ -325,6 +356,7 @@ func copyAndMergeITS(oldITS, newITS *workloads.InstanceSet, synthesizeComp *comp
 	itsObjCopy.Spec.RoleProbe = itsProto.Spec.RoleProbe
 	itsObjCopy.Spec.MembershipReconfiguration = itsProto.Spec.MembershipReconfiguration
 	itsObjCopy.Spec.MemberUpdateStrategy = itsProto.Spec.MemberUpdateStrategy
+	itsObjCopy.Spec.QuorumGuard = itsProto.Spec.QuorumGuard
 	itsObjCopy.Spec.Credential = itsProto.Spec.Credential
 	itsObjCopy.Spec.Instances = itsProto.Spec.Instances
 	itsObjCopy.Spec.OfflineInstances = itsProto.Spec.OfflineInstances
@@ -408,6 +440,18 @@ func checkNRollbackProtoImages(itsObj, itsProto *workloads.InstanceSet) {
 }
 
 // expandVolume handles workload expand volume
+// refreshKBAgents pushes the updated actions and probes to the running kb-agents before the workload is updated,
+// the workload is updated after all the kb-agents are refreshed, so a failed refresh is retried in the next reconcile.
+func (r *componentWorkloadOps) refreshKBAgents() error {
+	runningHandlers := r.runningITS.Spec.Template.Annotations[constant.KBAgentHandlersAnnotationKey]
+	protoHandlers := r.protoITS.Spec.Template.Annotations[constant.KBAgentHandlersAnnotationKey]
+	// the pods are rebuilt if they didn't run the kb-agent before
+	if len(runningHandlers) == 0 || len(protoHandlers) == 0 || runningHandlers == protoHandlers {
+		return nil
+	}
+	return component.RefreshKBAgents(r.reqCtx.Ctx, r.cli, r.synthesizeComp, protoHandlers)
+}
+
 func (r *componentWorkloadOps) expandVolume() error {
 	for _, vct := range r.runningITS.Spec.VolumeClaimTemplates {
 		var proto *corev1.PersistentVolumeClaimTemplate
@@ -521,15 +565,129 @@ func (r *componentWorkloadOps) scaleIn(itsObj *workloads.InstanceSet) error {
 		r.reqCtx.Log.Info("scale in to 0, keep all PVCs")
 		return nil
 	}
+	policy := getScaleInPVCRetentionPolicy(r.cluster, r.synthesizeComp)
+	if policy == appsv1alpha1.ScaleInPVCRetentionPolicyBackup {
+		supported, ready, err := r.snapshotPVCs4ScaleIn(itsObj)
+		if err != nil {
+			return err
+		}
+		switch {
+		case !supported:
+			r.reqCtx.Recorder.Eventf(r.cluster, corev1.EventTypeWarning, "HorizontalScale",
+				"the volumes of component %s don't support snapshots, retain the PVCs of the instances to scale in", r.synthesizeComp.Name)
+			policy = appsv1alpha1.ScaleInPVCRetentionPolicyRetain
+		case !ready:
+			// hold the scaling-in until the snapshots are ready, the instances are still there to be snapshotted.
+			return intctrlutil.NewDelayedRequeueError(time.Second*5,
+				fmt.Sprintf("wait for the snapshots of the PVCs of component %s to scale in", r.synthesizeComp.Name))
+		}
+	}
 	// TODO: check the component definition to determine whether we need to call leave member before deleting replicas.
 	err := r.leaveMember4ScaleIn()
 	if err != nil {
 		r.reqCtx.Log.Info(fmt.Sprintf("leave member at scaling-in error, retry later: %s", err.Error()))
 		return err
 	}
+	if policy == appsv1alpha1.ScaleInPVCRetentionPolicyRetain {
+		r.reqCtx.Log.Info("the PVC retention policy of scaling-in is Retain, keep the PVCs of the instances to scale in")
+		return nil
+	}
 	return r.deletePVCs4ScaleIn(itsObj)
 }
 
+// snapshotPVCs4ScaleIn takes the snapshots of the PVCs of the instances to scale in before deleting them.
+// It returns whether the volumes support snapshots, and whether all the snapshots are ready to use.
+func (r *componentWorkloadOps) snapshotPVCs4ScaleIn(itsObj *workloads.InstanceSet) (bool, bool, error) {
+	if !dputils.SupportsVolumeSnapshotV1() {
+		return false, false, nil
+	}
+	graphCli := model.NewGraphClient(r.cli)
+	ready := true
+	for _, podName := range r.runningItsPodNames {
+		if _, ok := r.desiredCompPodNameSet[podName]; ok {
+			continue
+		}
+		for _, vct := range itsObj.Spec.VolumeClaimTemplates {
+			pvcKey := types.NamespacedName{
+				Namespace: itsObj.Namespace,
+				Name:      fmt.Sprintf("%s-%s", vct.Name, podName),
+			}
+			pvc := &corev1.PersistentVolumeClaim{}
+			if err := r.cli.Get(r.reqCtx.Ctx, pvcKey, pvc, inDataContext4C()); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return false, false, err
+			}
+			// the UID of the PVC is part of the name, so that the snapshot taken by an earlier scaling-in of the
+			// same instance won't be mistaken.
+			uid := string(pvc.UID)
+			if len(uid) > 8 {
+				uid = uid[:8]
+			}
+			snapshotKey := types.NamespacedName{
+				Namespace: pvc.Namespace,
+				Name:      fmt.Sprintf("%s-scale-in-%s", pvc.Name, uid),
+			}
+			snapshot := &vsv1.VolumeSnapshot{}
+			err := r.cli.Get(r.reqCtx.Ctx, snapshotKey, snapshot, inDataContext4C())
+			switch {
+			case err == nil:
+				if snapshot.Status != nil && snapshot.Status.Error != nil && snapshot.Status.Error.Message != nil {
+					return false, false, fmt.Errorf("failed to snapshot the PVC %s to scale in: %s", pvc.Name, *snapshot.Status.Error.Message)
+				}
+				if snapshot.Status == nil || snapshot.Status.ReadyToUse == nil || !*snapshot.Status.ReadyToUse {
+					ready = false
+				}
+			case apierrors.IsNotFound(err):
+				enabled, err := dputils.IsVolumeSnapshotEnabled(r.reqCtx.Ctx, r.cli, pvc.Spec.VolumeName)
+				if err != nil {
+					return false, false, err
+				}
+				if !enabled {
+					return false, false, nil
+				}
+				if err = r.createSnapshot4ScaleIn(graphCli, snapshotKey, pvc); err != nil {
+					return false, false, err
+				}
+				ready = false
+			default:
+				return false, false, err
+			}
+		}
+	}
+	return true, ready, nil
+}
+
+// createSnapshot4ScaleIn creates the snapshot of the PVC to scale in, the snapshot is not owned by the cluster
+// so that it can be used to restore the data after the cluster is deleted.
+func (r *componentWorkloadOps) createSnapshot4ScaleIn(graphCli model.GraphClient, snapshotKey types.NamespacedName,
+	pvc *corev1.PersistentVolumeClaim) error {
+	snapshot := &vsv1.VolumeSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: snapshotKey.Namespace,
+			Name:      snapshotKey.Name,
+			Labels:    constant.GetComponentWellKnownLabels(r.cluster.Name, r.synthesizeComp.Name),
+		},
+		Spec: vsv1.VolumeSnapshotSpec{
+			Source: vsv1.VolumeSnapshotSource{
+				PersistentVolumeClaimName: &pvc.Name,
+			},
+		},
+	}
+	vscName, err := getVolumeSnapshotClassName(r.reqCtx.Ctx, r.cli, pvc.Spec.VolumeName)
+	if err != nil {
+		return err
+	}
+	if vscName != "" {
+		snapshot.Spec.VolumeSnapshotClassName = &vscName
+	}
+	graphCli.Do(r.dag, nil, snapshot, model.ActionCreatePtr(), nil, inDataContext4G())
+	r.reqCtx.Recorder.Eventf(r.cluster, corev1.EventTypeNormal, "HorizontalScale",
+		"snapshot the PVC %s of component %s before scaling in", pvc.Name, r.synthesizeComp.Name)
+	return nil
+}
+
 func (r *componentWorkloadOps) scaleOut(itsObj *workloads.InstanceSet) error {
 	var (
 		backupKey = types.NamespacedName{
@@ -714,6 +872,10 @@ func (r *componentWorkloadOps) expandVolumes(vctName string, proto *corev1.Persi
 		if !pvcNotFound {
 			quantity := pvc.Spec.Resources.Requests.Storage()
 			newQuantity := proto.Spec.Resources.Requests.Storage()
+			if newQuantity.Cmp(*quantity) < 0 && isVolumeShrinking(r.cluster, r.synthesizeComp.Name) {
+				// the volume is kept as-is, and the instance will be replaced by a new one with the smaller volume.
+				continue
+			}
 			if quantity.Cmp(*pvc.Status.Capacity.Storage()) == 0 && newQuantity.Cmp(*quantity) < 0 {
 				errMsg := fmt.Sprintf("shrinking the volume is not supported, volume: %s, quantity: %s, new quantity: %s",
 					pvc.GetName(), quantity.String(), newQuantity.String())
@@ -762,6 +924,10 @@ func (r *componentWorkloadOps) updatePVCSize(pvcKey types.NamespacedName,
 		}
 	} else {
 		newPVC.Spec.Resources.Requests[corev1.ResourceStorage] = vctProto.Spec.Resources.Requests[corev1.ResourceStorage]
+		// modify the IOPS and throughput of the volume online through the volume attributes class
+		if vctProto.Spec.VolumeAttributesClassName != nil {
+			newPVC.Spec.VolumeAttributesClassName = vctProto.Spec.VolumeAttributesClassName
+		}
 		// delete annotation to make it re-bind
 		delete(newPVC.Annotations, "pv.kubernetes.io/bind-completed")
 	}
@@ -881,7 +1047,8 @@ func (r *componentWorkloadOps) updatePVCSize(pvcKey types.NamespacedName,
 		updatePVCByRecreateFromStep(pvPolicyRetainStep)
 		return nil
 	}
-	if pvcQuantity := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; pvcQuantity.Cmp(vctProto.Spec.Resources.Requests[corev1.ResourceStorage]) != 0 {
+	if pvcQuantity := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; pvcQuantity.Cmp(vctProto.Spec.Resources.Requests[corev1.ResourceStorage]) != 0 ||
+		!reflect.DeepEqual(pvc.Spec.VolumeAttributesClassName, newPVC.Spec.VolumeAttributesClassName) {
 		// use pvc's update without anything extra
 		graphCli.Update(r.dag, nil, newPVC, inDataContext4G())
 		return nil
@@ -891,6 +1058,33 @@ func (r *componentWorkloadOps) updatePVCSize(pvcKey types.NamespacedName,
 	return nil
 }
 
+// isVolumeShrinking checks whether the volumes of the component are being shrunk by replacing the instances.
+func isVolumeShrinking(cluster *appsv1alpha1.Cluster, compName string) bool {
+	value := cluster.Annotations[constant.VolumeShrinkingAnnotationKey]
+	return value != "" && slices.Contains(strings.Split(value, ","), compName)
+}
+
+// getScaleInPVCRetentionPolicy returns the policy to handle the PVCs of the instances to scale in, which is
+// specified by the HorizontalScaling OpsRequest for the component or the sharding it belongs to.
+func getScaleInPVCRetentionPolicy(cluster *appsv1alpha1.Cluster, synthesizeComp *component.SynthesizedComponent) appsv1alpha1.ScaleInPVCRetentionPolicy {
+	value := cluster.Annotations[constant.ScaleInPVCRetentionAnnotationKey]
+	if value == "" {
+		return appsv1alpha1.ScaleInPVCRetentionPolicyDelete
+	}
+	policies := map[string]string{}
+	for _, item := range strings.Split(value, ",") {
+		name, policy, _ := strings.Cut(item, "=")
+		policies[name] = policy
+	}
+	if policy, ok := policies[synthesizeComp.Name]; ok {
+		return appsv1alpha1.ScaleInPVCRetentionPolicy(policy)
+	}
+	if policy, ok := policies[synthesizeComp.Labels[constant.KBAppShardingNameLabelKey]]; ok {
+		return appsv1alpha1.ScaleInPVCRetentionPolicy(policy)
+	}
+	return appsv1alpha1.ScaleInPVCRetentionPolicyDelete
+}
+
 // buildProtoITSWorkloadVertex builds protoITS workload vertex
 func (r *componentWorkloadOps) buildProtoITSWorkloadVertex() *model.ObjectVertex {
 	for _, vertex := range r.dag.Vertices() {
@@ -931,6 +1125,112 @@ func buildInstanceSetPlacementAnnotation(comp *appsv1alpha1.Component, its *work
 	}
 }
 
+// buildInstanceSetIPsAnnotation records the stable IPs of the instances on the InstanceSet if required, which
+// are advertised on the pods and injected into the containers.
+func (t *componentWorkloadTransformer) buildInstanceSetIPsAnnotation(ctx graph.TransformContext,
+	synthesizedComp *component.SynthesizedComponent, runningITS, protoITS *workloads.InstanceSet) error {
+	policy := synthesizedComp.InstanceIP
+	if policy == nil {
+		return nil
+	}
+	podNames, err := generatePodNames(synthesizedComp)
+	if err != nil {
+		return err
+	}
+
+	var instanceIPs *instanceset.InstanceIPs
+	switch policy.Type {
+	case appsv1alpha1.InstanceIPService:
+		instanceIPs, err = t.instanceServiceIPs(ctx, synthesizedComp, podNames)
+	case appsv1alpha1.InstanceIPStaticIP:
+		instanceIPs, err = t.instanceStaticIPs(synthesizedComp, runningITS, podNames)
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	value, err := json.Marshal(instanceIPs)
+	if err != nil {
+		return err
+	}
+	if protoITS.Annotations == nil {
+		protoITS.Annotations = make(map[string]string)
+	}
+	protoITS.Annotations[constant.InstanceIPsAnnotationKey] = string(value)
+	return nil
+}
+
+// instanceServiceIPs collects the cluster IPs of the instance Services. The workload is held until all the
+// instances have got their IPs, so that the instances are created with the IPs.
+func (t *componentWorkloadTransformer) instanceServiceIPs(ctx graph.TransformContext,
+	synthesizedComp *component.SynthesizedComponent, podNames []string) (*instanceset.InstanceIPs, error) {
+	services, err := component.ListOwnedServices(ctx.GetContext(), ctx.GetClient(),
+		synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name)
+	if err != nil {
+		return nil, err
+	}
+	prefix := constant.GenerateComponentServiceName(synthesizedComp.ClusterName, synthesizedComp.Name, instanceIPServiceName) + "-"
+	ips := make(map[string]string)
+	for _, svc := range services {
+		if !strings.HasPrefix(svc.Name, prefix) || len(svc.Spec.ClusterIP) == 0 || svc.Spec.ClusterIP == corev1.ClusterIPNone {
+			continue
+		}
+		if podName, ok := svc.Spec.Selector[constant.KBAppPodNameLabelKey]; ok {
+			ips[podName] = svc.Spec.ClusterIP
+		}
+	}
+	for _, podName := range podNames {
+		if _, ok := ips[podName]; !ok {
+			return nil, intctrlutil.NewDelayedRequeueError(time.Second,
+				fmt.Sprintf("wait for the cluster IP of the instance service of %s", podName))
+		}
+	}
+	return &instanceset.InstanceIPs{IPs: ips}, nil
+}
+
+// instanceStaticIPs assigns the static IPs to the instances, the IPs assigned to the running instances are kept unchanged.
+func (t *componentWorkloadTransformer) instanceStaticIPs(synthesizedComp *component.SynthesizedComponent,
+	runningITS *workloads.InstanceSet, podNames []string) (*instanceset.InstanceIPs, error) {
+	policy := synthesizedComp.InstanceIP
+	if len(policy.NetworkName) == 0 || len(policy.IPs) == 0 {
+		return nil, fmt.Errorf("the network name and IPs are required for the static IPs of component %s", synthesizedComp.Name)
+	}
+	var assigned map[string]string
+	if runningITS != nil {
+		instanceIPs, err := instanceset.GetInstanceIPs(runningITS.Annotations)
+		if err != nil {
+			return nil, err
+		}
+		if instanceIPs != nil && instanceIPs.Network == policy.NetworkName {
+			assigned = instanceIPs.IPs
+		}
+	}
+	ips, err := instanceset.AssignInstanceStaticIPs(podNames, policy.IPs, assigned)
+	if err != nil {
+		return nil, err
+	}
+	return &instanceset.InstanceIPs{Network: policy.NetworkName, IPs: ips}, nil
+}
+
+// buildInstanceSetConsumerAffinityAnnotation passes the consumer affinity to the InstanceSet, which is applied to
+// the instances when they are created rather than rendered into the pod template, to avoid recreating the running instances.
+func buildInstanceSetConsumerAffinityAnnotation(synthesizedComp *component.SynthesizedComponent, protoITS *workloads.InstanceSet) error {
+	terms := scheduling.BuildConsumerAffinityTerms(synthesizedComp.Namespace, synthesizedComp.SchedulingHints)
+	if len(terms) == 0 {
+		return nil
+	}
+	value, err := json.Marshal(terms)
+	if err != nil {
+		return err
+	}
+	if protoITS.Annotations == nil {
+		protoITS.Annotations = make(map[string]string)
+	}
+	protoITS.Annotations[constant.ConsumerAffinityAnnotationKey] = string(value)
+	return nil
+}
+
 func newComponentWorkloadOps(reqCtx intctrlutil.RequestCtx,
 	cli client.Client,
 	cluster *appsv1alpha1.Cluster,
@@ -0,0 +1,164 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+)
+
+const (
+	workloadAdoptedReason = "WorkloadAdopted"
+)
+
+// componentWorkloadAdoptionTransformer adopts the existing user-deployed StatefulSet of the same name as the component,
+// the Pods and PVCs are taken over by the InstanceSet of the component. The Secrets referenced by the StatefulSet are
+// left as they are, they are created by the user and should not be deleted along with the component.
+type componentWorkloadAdoptionTransformer struct{}
+
+var _ graph.Transformer = &componentWorkloadAdoptionTransformer{}
+
+func (t *componentWorkloadAdoptionTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*componentTransformContext)
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	comp := transCtx.Component
+	synthesizeComp := transCtx.SynthesizeComponent
+
+	if model.IsObjectDeleting(comp) || comp.Annotations[constant.AdoptStatefulSetAnnotationKey] != "true" {
+		return nil
+	}
+
+	sts := &appsv1.StatefulSet{}
+	if err := graphCli.Get(transCtx.Context, client.ObjectKeyFromObject(comp), sts); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if model.IsObjectDeleting(sts) {
+		return nil
+	}
+	if err := t.validate(synthesizeComp, sts); err != nil {
+		return err
+	}
+
+	var parent *model.ObjectVertex
+	adopt := func(object client.Object, labels map[string]string) {
+		for k, v := range labels {
+			object.GetLabels()[k] = v
+		}
+		// remove the owner reference of the StatefulSet, the InstanceSet will take over the object.
+		var refs []metav1.OwnerReference
+		for _, ref := range object.GetOwnerReferences() {
+			if ref.UID != sts.UID {
+				refs = append(refs, ref)
+			}
+		}
+		object.SetOwnerReferences(refs)
+		parent = graphCli.Do(dag, nil, object, model.ActionUpdatePtr(), parent)
+	}
+
+	labels := constant.GetComponentWellKnownLabels(synthesizeComp.ClusterName, synthesizeComp.Name)
+	labels[instanceset.WorkloadsManagedByLabelKey] = workloads.Kind
+	labels[instanceset.WorkloadsInstanceLabelKey] = comp.Name
+
+	// adopt the Pods, they have no revision of the InstanceSet and will be updated by the InstanceSet in rolling.
+	selector, err := metav1.LabelSelectorAsSelector(sts.Spec.Selector)
+	if err != nil {
+		return fmt.Errorf("invalid selector of StatefulSet %s to adopt: %s", sts.Name, err.Error())
+	}
+	pods := &corev1.PodList{}
+	if err = graphCli.List(transCtx.Context, pods, client.InNamespace(comp.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return err
+	}
+	for i := range pods.Items {
+		if pods.Items[i].Labels == nil {
+			pods.Items[i].Labels = map[string]string{}
+		}
+		adopt(&pods.Items[i], labels)
+	}
+
+	// adopt the PVCs, which share the same names between the StatefulSet and the InstanceSet.
+	for _, vct := range sts.Spec.VolumeClaimTemplates {
+		for ordinal := int32(0); ordinal < statefulSetReplicas(sts); ordinal++ {
+			pvc := &corev1.PersistentVolumeClaim{}
+			pvcKey := client.ObjectKey{Namespace: comp.Namespace, Name: fmt.Sprintf("%s-%s-%d", vct.Name, sts.Name, ordinal)}
+			if err := graphCli.Get(transCtx.Context, pvcKey, pvc); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return err
+			}
+			if pvc.Labels == nil {
+				pvc.Labels = map[string]string{}
+			}
+			pvcLabels := map[string]string{constant.VolumeClaimTemplateNameLabelKey: vct.Name}
+			for k, v := range labels {
+				pvcLabels[k] = v
+			}
+			adopt(pvc, pvcLabels)
+		}
+	}
+
+	// remove the StatefulSet and orphan its dependents
+	graphCli.Do(dag, nil, sts, model.ActionDeletePtr(), parent, model.WithPropagationPolicy(client.PropagationPolicy(metav1.DeletePropagationOrphan)))
+	transCtx.EventRecorder.Eventf(comp, corev1.EventTypeNormal, workloadAdoptedReason, "the StatefulSet %s is adopted", sts.Name)
+
+	// set status.observedGeneration to zero to trigger a creation reconciliation loop of the component controller.
+	comp.Status.ObservedGeneration = 0
+	return graph.ErrPrematureStop
+}
+
+// validate checks that the StatefulSet matches the layout of the component, so that the data can be taken over as it is.
+func (t *componentWorkloadAdoptionTransformer) validate(synthesizeComp *component.SynthesizedComponent, sts *appsv1.StatefulSet) error {
+	if replicas := statefulSetReplicas(sts); replicas != synthesizeComp.Replicas {
+		return fmt.Errorf("the replicas of StatefulSet %s to adopt is %d, but %d expected", sts.Name, replicas, synthesizeComp.Replicas)
+	}
+	vcts := sets.New[string]()
+	for _, vct := range sts.Spec.VolumeClaimTemplates {
+		vcts.Insert(vct.Name)
+	}
+	for _, vct := range synthesizeComp.VolumeClaimTemplates {
+		if !vcts.Has(vct.Name) {
+			return fmt.Errorf("the volume claim template %s is not found in StatefulSet %s to adopt", vct.Name, sts.Name)
+		}
+	}
+	return nil
+}
+
+func statefulSetReplicas(sts *appsv1.StatefulSet) int32 {
+	if sts.Spec.Replicas == nil {
+		return 1
+	}
+	return *sts.Spec.Replicas
+}
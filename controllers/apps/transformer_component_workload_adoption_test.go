@@ -0,0 +1,203 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+)
+
+// selectorReader filters the listed objects by the label selector, which is ignored by the mockReader.
+type selectorReader struct {
+	mockReader
+}
+
+func (r *selectorReader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	selector := listOpts.LabelSelector
+	if selector == nil {
+		selector = labels.Everything()
+	}
+	reader := &mockReader{}
+	for _, obj := range r.objs {
+		if selector.Matches(labels.Set(obj.GetLabels())) {
+			reader.objs = append(reader.objs, obj)
+		}
+	}
+	return reader.List(ctx, list)
+}
+
+var _ = Describe("componentWorkloadAdoptionTransformer", func() {
+	const (
+		compName = "test-cluster-mysql"
+	)
+
+	var (
+		transCtx *componentTransformContext
+		reader   *selectorReader
+		dag      *graph.DAG
+		comp     *appsv1alpha1.Component
+		sts      *appsv1.StatefulSet
+		secret   *corev1.Secret
+	)
+
+	newPod := func(name string, podLabels map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      name,
+				Labels:    podLabels,
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		comp = &appsv1alpha1.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      compName,
+				UID:       "9b1b3c43-5a6f-4d8e-8a0e-6c7e2a1f0b4d",
+				Annotations: map[string]string{
+					constant.AdoptStatefulSetAnnotationKey: "true",
+				},
+			},
+		}
+		sts = &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      compName,
+				UID:       "2f0e9c6a-3d1b-4e7f-9c2a-8b5d4e3f1a0c",
+			},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: pointer.Int32(1),
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"app": "mysql"},
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "tier", Operator: metav1.LabelSelectorOpIn, Values: []string{"db"}},
+					},
+				},
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Volumes: []corev1.Volume{
+							{Name: "auth", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "mysql-auth"}}},
+						},
+					},
+				},
+				VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+					{ObjectMeta: metav1.ObjectMeta{Name: "data"}},
+				},
+			},
+		}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      "mysql-auth",
+			},
+		}
+		reader = &selectorReader{
+			mockReader: mockReader{
+				objs: []client.Object{
+					sts,
+					secret,
+					newPod(compName+"-0", map[string]string{"app": "mysql", "tier": "db"}),
+					newPod("mysql-backup", map[string]string{"app": "mysql", "tier": "backup"}),
+					&corev1.PersistentVolumeClaim{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: testCtx.DefaultNamespace,
+							Name:      "data-" + compName + "-0",
+						},
+					},
+				},
+			},
+		}
+
+		graphCli := model.NewGraphClient(reader)
+		transCtx = &componentTransformContext{
+			Context:       testCtx.Ctx,
+			Client:        graphCli,
+			EventRecorder: clusterRecorder,
+			Logger:        logger,
+			Component:     comp,
+			ComponentOrig: comp.DeepCopy(),
+			SynthesizeComponent: &component.SynthesizedComponent{
+				ClusterName: "test-cluster",
+				Name:        "mysql",
+				Replicas:    1,
+				VolumeClaimTemplates: []corev1.PersistentVolumeClaimTemplate{
+					{ObjectMeta: metav1.ObjectMeta{Name: "data"}},
+				},
+			},
+		}
+		dag = graph.NewDAG()
+		graphCli.Root(dag, transCtx.ComponentOrig, transCtx.Component, model.ActionStatusPtr())
+	})
+
+	It("adopts the pods selected and the pvcs, and leaves the secrets as they are", func() {
+		transformer := &componentWorkloadAdoptionTransformer{}
+		Expect(transformer.Transform(transCtx, dag)).Should(Equal(graph.ErrPrematureStop))
+
+		updated := map[string]*model.ObjectVertex{}
+		var deleted *model.ObjectVertex
+		for _, v := range dag.Vertices() {
+			vertex := v.(*model.ObjectVertex)
+			switch *vertex.Action {
+			case model.UPDATE:
+				updated[vertex.Obj.GetName()] = vertex
+			case model.DELETE:
+				deleted = vertex
+			}
+		}
+		Expect(updated).Should(HaveLen(2))
+		Expect(updated).Should(HaveKey(compName + "-0"))
+		Expect(updated).Should(HaveKey("data-" + compName + "-0"))
+		Expect(updated).ShouldNot(HaveKey("mysql-backup"))
+		Expect(updated).ShouldNot(HaveKey(secret.Name))
+		Expect(updated[compName+"-0"].Obj.GetLabels()).Should(HaveKeyWithValue(constant.KBAppComponentLabelKey, "mysql"))
+
+		Expect(deleted).ShouldNot(BeNil())
+		Expect(deleted.Obj.GetName()).Should(Equal(sts.Name))
+		Expect(deleted.PropagationPolicy).Should(Equal(client.PropagationPolicy(metav1.DeletePropagationOrphan)))
+		Expect(transCtx.Component.Status.ObservedGeneration).Should(BeZero())
+	})
+
+	It("fails if the replicas mismatch", func() {
+		sts.Spec.Replicas = pointer.Int32(3)
+		transformer := &componentWorkloadAdoptionTransformer{}
+		err := transformer.Transform(transCtx, dag)
+		Expect(err).ShouldNot(BeNil())
+		Expect(err.Error()).Should(ContainSubstring("replicas"))
+	})
+})
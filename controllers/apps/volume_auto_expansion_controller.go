@@ -0,0 +1,552 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	statsv1alpha1 "k8s.io/kubelet/pkg/apis/stats/v1alpha1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
+)
+
+const (
+	// volumeAutoExpansionCheckInterval is the interval to check the usage of the volumes.
+	volumeAutoExpansionCheckInterval = time.Minute
+
+	defaultVolumeAutoExpansionThreshold = 80
+
+	reasonVolumeAutoExpansionCreated      = "VolumeAutoExpansionCreated"
+	reasonVolumeAutoExpansionLimitReached = "VolumeAutoExpansionLimitReached"
+	reasonDiskPressureProtected           = "DiskPressureProtected"
+	reasonDiskPressureProtectFailed       = "DiskPressureProtectFailed"
+	reasonDiskPressureReleased            = "DiskPressureReleased"
+	reasonVolumeUsageCritical             = "VolumeUsageCritical"
+	reasonVolumeUsageNormal               = "VolumeUsageNormal"
+)
+
+// volumeUsage is the usage of the file system of a volume, in bytes.
+type volumeUsage struct {
+	used     int64
+	capacity int64
+}
+
+// volumeStatsGetter gets the usage of the PVCs mounted by the pods on a node.
+type volumeStatsGetter interface {
+	getNodeVolumeUsages(ctx context.Context, nodeName string) (map[types.NamespacedName]volumeUsage, error)
+}
+
+// kubeletVolumeStatsGetter gets the usage of the volumes from the stats summary of kubelet, through the node proxy
+// of the API server.
+type kubeletVolumeStatsGetter struct {
+	restClient rest.Interface
+}
+
+var _ volumeStatsGetter = &kubeletVolumeStatsGetter{}
+
+func (g *kubeletVolumeStatsGetter) getNodeVolumeUsages(ctx context.Context, nodeName string) (map[types.NamespacedName]volumeUsage, error) {
+	raw, err := g.restClient.Get().Resource("nodes").Name(nodeName).
+		SubResource("proxy").Suffix("stats/summary").DoRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+	summary := &statsv1alpha1.Summary{}
+	if err = json.Unmarshal(raw, summary); err != nil {
+		return nil, err
+	}
+	usages := make(map[types.NamespacedName]volumeUsage)
+	for _, pod := range summary.Pods {
+		for _, stats := range pod.VolumeStats {
+			if stats.PVCRef == nil || stats.UsedBytes == nil || stats.CapacityBytes == nil || *stats.CapacityBytes == 0 {
+				continue
+			}
+			usages[types.NamespacedName{Namespace: stats.PVCRef.Namespace, Name: stats.PVCRef.Name}] = volumeUsage{
+				used:     int64(*stats.UsedBytes),
+				capacity: int64(*stats.CapacityBytes),
+			}
+		}
+	}
+	return usages, nil
+}
+
+// VolumeAutoExpansionReconciler expands the volumes of the components automatically before they are full,
+// by creating VolumeExpansion OpsRequests when the usage of the volumes crosses the threshold.
+// It also switches the instances into the read-only protective mode when their volumes cross the critical
+// threshold, and raises the DiskPressure condition on the components until the volumes are released.
+type VolumeAutoExpansionReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	statsGetter volumeStatsGetter
+}
+
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=clusters,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsrequests,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=components/status,verbs=get;patch
+// +kubebuilder:rbac:groups=core,resources=nodes/proxy,verbs=get
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch
+
+// Reconcile checks the usage of the volumes of the components and shardings with the auto expansion enabled,
+// and creates a VolumeExpansion OpsRequest for the first one whose volumes cross the threshold.
+// Only one OpsRequest is in progress for a Cluster at a time, and the expansions of a component are
+// at least minIntervalSeconds apart.
+// The instances crossing the critical threshold are protected regardless of the phase of the Cluster
+// and the running OpsRequests, as a full disk may corrupt the data.
+func (r *VolumeAutoExpansionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("cluster", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	cluster := &appsv1alpha1.Cluster{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, cluster); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if model.IsObjectDeleting(cluster) || !hasVolumeAutoExpansion(cluster) {
+		return intctrlutil.Reconciled()
+	}
+
+	targets := volumeAutoExpansionTargets(cluster)
+	usages := make([]*targetVolumeUsages, len(targets))
+	for i, target := range targets {
+		var err error
+		if usages[i], err = r.volumeUsages(reqCtx.Ctx, cluster, target); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		if err = r.protect(reqCtx, cluster, target, usages[i]); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+	}
+
+	if cluster.Status.Phase != appsv1alpha1.RunningClusterPhase && cluster.Status.Phase != appsv1alpha1.AbnormalClusterPhase {
+		return intctrlutil.RequeueAfter(volumeAutoExpansionCheckInterval, reqCtx.Log, "cluster is not running")
+	}
+
+	running, err := hasRunningOpsRequest(reqCtx.Ctx, r.Client, cluster)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if running {
+		return intctrlutil.RequeueAfter(volumeAutoExpansionCheckInterval, reqCtx.Log, "cluster has running OpsRequests")
+	}
+
+	for i, target := range targets {
+		wait, err := r.expand(reqCtx, cluster, target, usages[i])
+		if err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		if wait > 0 {
+			return intctrlutil.RequeueAfter(wait, reqCtx.Log, "")
+		}
+	}
+	return intctrlutil.RequeueAfter(volumeAutoExpansionCheckInterval, reqCtx.Log, "")
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *VolumeAutoExpansionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.statsGetter == nil {
+		clientSet, err := kubernetes.NewForConfig(mgr.GetConfig())
+		if err != nil {
+			return err
+		}
+		r.statsGetter = &kubeletVolumeStatsGetter{restClient: clientSet.CoreV1().RESTClient()}
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("volume-auto-expansion").
+		For(&appsv1alpha1.Cluster{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			cluster, ok := obj.(*appsv1alpha1.Cluster)
+			return ok && hasVolumeAutoExpansion(cluster)
+		}))).
+		Complete(r)
+}
+
+// volumeAutoExpansionTarget is a component or a sharding with the auto expansion or the disk protection enabled.
+type volumeAutoExpansionTarget struct {
+	name     string
+	sharding bool
+	spec     *appsv1alpha1.ClusterComponentSpec
+}
+
+func volumeAutoExpansionTargets(cluster *appsv1alpha1.Cluster) []volumeAutoExpansionTarget {
+	targets := make([]volumeAutoExpansionTarget, 0)
+	for i, spec := range cluster.Spec.ComponentSpecs {
+		if hasVolumePersistencePolicy(spec.Persistence) {
+			targets = append(targets, volumeAutoExpansionTarget{name: spec.Name, spec: &cluster.Spec.ComponentSpecs[i]})
+		}
+	}
+	for i, spec := range cluster.Spec.ShardingSpecs {
+		if hasVolumePersistencePolicy(spec.Template.Persistence) {
+			targets = append(targets, volumeAutoExpansionTarget{name: spec.Name, sharding: true, spec: &cluster.Spec.ShardingSpecs[i].Template})
+		}
+	}
+	return targets
+}
+
+func hasVolumePersistencePolicy(persistence *appsv1alpha1.ClusterComponentPersistence) bool {
+	return persistence != nil && (persistence.AutoExpansion != nil || persistence.CriticalThresholdPercent > 0)
+}
+
+func hasVolumeAutoExpansion(cluster *appsv1alpha1.Cluster) bool {
+	return len(volumeAutoExpansionTargets(cluster)) > 0
+}
+
+// expand creates a VolumeExpansion OpsRequest for the target if its volumes cross the threshold, and returns
+// the duration to wait before checking the cluster again if an OpsRequest is created or the expansion is
+// rate limited.
+func (r *VolumeAutoExpansionReconciler) expand(reqCtx intctrlutil.RequestCtx,
+	cluster *appsv1alpha1.Cluster, target volumeAutoExpansionTarget, usages *targetVolumeUsages) (time.Duration, error) {
+	if target.spec.Persistence.AutoExpansion == nil {
+		return 0, nil
+	}
+	vcts, limited := planVolumeAutoExpansion(target.spec, usages.vcts)
+	for _, name := range limited {
+		r.Recorder.Eventf(cluster, corev1.EventTypeWarning, reasonVolumeAutoExpansionLimitReached,
+			"the volume %s of %s crosses the threshold, but it has reached the max size", name, target.name)
+	}
+	if len(vcts) == 0 {
+		return 0, nil
+	}
+
+	last, err := r.lastAutoExpansionTime(reqCtx.Ctx, cluster, target.name)
+	if err != nil {
+		return 0, err
+	}
+	interval := time.Duration(target.spec.Persistence.AutoExpansion.MinIntervalSeconds) * time.Second
+	if elapsed := time.Since(last); elapsed < interval {
+		reqCtx.Log.V(1).Info("volume auto expansion is rate limited", "target", target.name)
+		return interval - elapsed, nil
+	}
+
+	ops := buildVolumeAutoExpansionOpsRequest(cluster, target.name, vcts)
+	if err = r.Client.Create(reqCtx.Ctx, ops); err != nil {
+		return 0, err
+	}
+	r.Recorder.Eventf(cluster, corev1.EventTypeNormal, reasonVolumeAutoExpansionCreated,
+		"created OpsRequest %s to expand the volumes of %s", ops.Name, target.name)
+	return volumeAutoExpansionCheckInterval, nil
+}
+
+// targetVolumeUsages is the usage percentages of the volumes of a target.
+type targetVolumeUsages struct {
+	// vcts is the highest usage percentage among the instances for each volumeClaimTemplate.
+	vcts map[string]int64
+	// pods is the highest usage percentage among the volumes for each instance.
+	pods map[string]int64
+	// instances is the pods of the target.
+	instances []corev1.Pod
+}
+
+// volumeUsages returns the usage percentages of the volumes of the target.
+func (r *VolumeAutoExpansionReconciler) volumeUsages(ctx context.Context,
+	cluster *appsv1alpha1.Cluster, target volumeAutoExpansionTarget) (*targetVolumeUsages, error) {
+	labels := constant.GetComponentWellKnownLabels(cluster.Name, target.name)
+	if target.sharding {
+		labels = constant.GetClusterWellKnownLabels(cluster.Name)
+		labels[constant.KBAppShardingNameLabelKey] = target.name
+	}
+	inNS := client.InNamespace(cluster.Namespace)
+
+	pvcs := &corev1.PersistentVolumeClaimList{}
+	if err := r.Client.List(ctx, pvcs, inNS, client.MatchingLabels(labels)); err != nil {
+		return nil, err
+	}
+	vctNames := make(map[types.NamespacedName]string)
+	for i := range pvcs.Items {
+		if vctName := pvcs.Items[i].Labels[constant.VolumeClaimTemplateNameLabelKey]; vctName != "" {
+			vctNames[client.ObjectKeyFromObject(&pvcs.Items[i])] = vctName
+		}
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.Client.List(ctx, pods, inNS, client.MatchingLabels(labels)); err != nil {
+		return nil, err
+	}
+	nodes := make([]string, 0)
+	podNames := make(map[types.NamespacedName]string)
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName != "" && !slices.Contains(nodes, pod.Spec.NodeName) {
+			nodes = append(nodes, pod.Spec.NodeName)
+		}
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil {
+				podNames[types.NamespacedName{Namespace: pod.Namespace, Name: volume.PersistentVolumeClaim.ClaimName}] = pod.Name
+			}
+		}
+	}
+
+	usages := &targetVolumeUsages{
+		vcts:      make(map[string]int64),
+		pods:      make(map[string]int64),
+		instances: pods.Items,
+	}
+	for _, node := range nodes {
+		nodeUsages, err := r.statsGetter.getNodeVolumeUsages(ctx, node)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the volume stats of node %s: %s", node, err.Error())
+		}
+		for key, usage := range nodeUsages {
+			vctName, ok := vctNames[key]
+			if !ok {
+				continue
+			}
+			percent := usage.used * 100 / usage.capacity
+			if percent > usages.vcts[vctName] {
+				usages.vcts[vctName] = percent
+			}
+			if podName, ok := podNames[key]; ok && percent > usages.pods[podName] {
+				usages.pods[podName] = percent
+			}
+		}
+	}
+	return usages, nil
+}
+
+// protect switches the instances of the target whose volumes cross the critical threshold into the read-only
+// protective mode, releases the instances whose volumes drop below the threshold, and updates the DiskPressure
+// condition of the components accordingly.
+func (r *VolumeAutoExpansionReconciler) protect(reqCtx intctrlutil.RequestCtx,
+	cluster *appsv1alpha1.Cluster, target volumeAutoExpansionTarget, usages *targetVolumeUsages) error {
+	threshold := target.spec.Persistence.CriticalThresholdPercent
+	pressured := make(map[string][]string)
+	for i := range usages.instances {
+		pod := &usages.instances[i]
+		compName := pod.Labels[constant.KBAppComponentLabelKey]
+		critical := isVolumeUsageCritical(usages.pods[pod.Name], threshold)
+		_, protected := pod.Annotations[constant.DiskPressureProtectedAnnotationKey]
+		switch {
+		case critical && !protected:
+			if err := r.setInstanceReadOnly(reqCtx, pod, true); err != nil {
+				return err
+			}
+			r.Recorder.Eventf(cluster, corev1.EventTypeWarning, reasonDiskPressureProtected,
+				"the volume usage of %s crosses the critical threshold %d%%, switched it to read-only", pod.Name, threshold)
+		case !critical && protected:
+			if err := r.setInstanceReadOnly(reqCtx, pod, false); err != nil {
+				return err
+			}
+			r.Recorder.Eventf(cluster, corev1.EventTypeNormal, reasonDiskPressureReleased,
+				"the volume usage of %s drops below the critical threshold, switched it back to read-write", pod.Name)
+		}
+		if critical {
+			pressured[compName] = append(pressured[compName], pod.Name)
+		} else if _, ok := pressured[compName]; !ok {
+			pressured[compName] = nil
+		}
+	}
+	for compName, podNames := range pressured {
+		if err := r.updateDiskPressureCondition(reqCtx.Ctx, cluster, compName, podNames, threshold); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setInstanceReadOnly switches the instance into or out of the read-only protective mode through the agent,
+// and records it in the annotation of the pod.
+// The instances whose engine doesn't support the protective mode are only marked, with a warning event.
+func (r *VolumeAutoExpansionReconciler) setInstanceReadOnly(reqCtx intctrlutil.RequestCtx, pod *corev1.Pod, readOnly bool) error {
+	lorryCli, err := lorry.NewClient(*pod)
+	if err != nil {
+		return err
+	}
+	if lorryCli == nil {
+		err = lorry.NotImplemented
+	} else if readOnly {
+		err = lorryCli.Lock(reqCtx.Ctx)
+	} else {
+		err = lorryCli.Unlock(reqCtx.Ctx)
+	}
+	if err != nil {
+		if !errors.Is(err, lorry.NotImplemented) {
+			return fmt.Errorf("failed to switch the read-only mode of %s: %s", pod.Name, err.Error())
+		}
+		r.Recorder.Eventf(pod, corev1.EventTypeWarning, reasonDiskPressureProtectFailed,
+			"the engine of %s doesn't support the read-only protective mode", pod.Name)
+	}
+
+	patch := client.MergeFrom(pod.DeepCopy())
+	if readOnly {
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[constant.DiskPressureProtectedAnnotationKey] = "true"
+	} else {
+		delete(pod.Annotations, constant.DiskPressureProtectedAnnotationKey)
+	}
+	return r.Client.Patch(reqCtx.Ctx, pod, patch)
+}
+
+// updateDiskPressureCondition sets the DiskPressure condition of the component to True if any of the pods are
+// pressured, or to False if the condition has been raised before.
+func (r *VolumeAutoExpansionReconciler) updateDiskPressureCondition(ctx context.Context,
+	cluster *appsv1alpha1.Cluster, compName string, podNames []string, threshold int32) error {
+	comp := &appsv1alpha1.Component{}
+	compKey := types.NamespacedName{Namespace: cluster.Namespace, Name: constant.GenerateClusterComponentName(cluster.Name, compName)}
+	if err := r.Client.Get(ctx, compKey, comp); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if len(podNames) == 0 && meta.FindStatusCondition(comp.Status.Conditions, appsv1alpha1.ConditionTypeDiskPressure) == nil {
+		return nil
+	}
+	patch := client.MergeFrom(comp.DeepCopy())
+	conditions := slices.Clone(comp.Status.Conditions)
+	condition := buildDiskPressureCondition(podNames, threshold)
+	condition.ObservedGeneration = comp.Generation
+	meta.SetStatusCondition(&comp.Status.Conditions, condition)
+	if reflect.DeepEqual(conditions, comp.Status.Conditions) {
+		return nil
+	}
+	return r.Client.Status().Patch(ctx, comp, patch)
+}
+
+func isVolumeUsageCritical(percent int64, threshold int32) bool {
+	return threshold > 0 && percent >= int64(threshold)
+}
+
+func buildDiskPressureCondition(podNames []string, threshold int32) metav1.Condition {
+	if len(podNames) == 0 {
+		return metav1.Condition{
+			Type:    appsv1alpha1.ConditionTypeDiskPressure,
+			Status:  metav1.ConditionFalse,
+			Reason:  reasonVolumeUsageNormal,
+			Message: "the volume usage of all the instances is below the critical threshold",
+		}
+	}
+	slices.Sort(podNames)
+	return metav1.Condition{
+		Type:   appsv1alpha1.ConditionTypeDiskPressure,
+		Status: metav1.ConditionTrue,
+		Reason: reasonVolumeUsageCritical,
+		Message: fmt.Sprintf("the volume usage of %s crosses the critical threshold %d%%, switched to read-only",
+			strings.Join(podNames, ","), threshold),
+	}
+}
+
+// lastAutoExpansionTime returns the creation time of the latest auto expansion OpsRequest of the target.
+func (r *VolumeAutoExpansionReconciler) lastAutoExpansionTime(ctx context.Context,
+	cluster *appsv1alpha1.Cluster, targetName string) (time.Time, error) {
+	opsList := &appsv1alpha1.OpsRequestList{}
+	if err := r.Client.List(ctx, opsList, client.InNamespace(cluster.Namespace),
+		client.MatchingLabels{
+			constant.AppInstanceLabelKey:             cluster.Name,
+			constant.OpsRequestAutoExpansionLabelKey: targetName,
+		}); err != nil {
+		return time.Time{}, err
+	}
+	var last time.Time
+	for _, ops := range opsList.Items {
+		if ops.CreationTimestamp.After(last) {
+			last = ops.CreationTimestamp.Time
+		}
+	}
+	return last, nil
+}
+
+// planVolumeAutoExpansion returns the volumeClaimTemplates to expand with their new sizes, and the names of the
+// volumeClaimTemplates crossing the threshold which can't be expanded any more.
+func planVolumeAutoExpansion(spec *appsv1alpha1.ClusterComponentSpec,
+	usages map[string]int64) ([]appsv1alpha1.OpsRequestVolumeClaimTemplate, []string) {
+	policy := spec.Persistence.AutoExpansion
+	threshold := int64(policy.ThresholdPercent)
+	if threshold <= 0 {
+		threshold = defaultVolumeAutoExpansionThreshold
+	}
+	vcts := make([]appsv1alpha1.OpsRequestVolumeClaimTemplate, 0)
+	limited := make([]string, 0)
+	for _, vct := range spec.VolumeClaimTemplates {
+		if len(policy.VolumeNames) > 0 && !slices.Contains(policy.VolumeNames, vct.Name) {
+			continue
+		}
+		if usage, ok := usages[vct.Name]; !ok || usage < threshold {
+			continue
+		}
+		current := vct.Spec.Resources.Requests.Storage()
+		if current.Cmp(policy.MaxSize) >= 0 {
+			limited = append(limited, vct.Name)
+			continue
+		}
+		size := current.DeepCopy()
+		size.Add(policy.Increment)
+		if size.Cmp(policy.MaxSize) > 0 {
+			size = policy.MaxSize.DeepCopy()
+		}
+		vcts = append(vcts, appsv1alpha1.OpsRequestVolumeClaimTemplate{Name: vct.Name, Storage: size})
+	}
+	return vcts, limited
+}
+
+func buildVolumeAutoExpansionOpsRequest(cluster *appsv1alpha1.Cluster, targetName string,
+	vcts []appsv1alpha1.OpsRequestVolumeClaimTemplate) *appsv1alpha1.OpsRequest {
+	return &appsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:    cluster.Namespace,
+			GenerateName: fmt.Sprintf("%s-auto-expansion-", cluster.Name),
+			Labels: map[string]string{
+				constant.AppInstanceLabelKey:             cluster.Name,
+				constant.OpsRequestTypeLabelKey:          string(appsv1alpha1.VolumeExpansionType),
+				constant.OpsRequestAutoExpansionLabelKey: targetName,
+			},
+		},
+		Spec: appsv1alpha1.OpsRequestSpec{
+			ClusterName: cluster.Name,
+			Type:        appsv1alpha1.VolumeExpansionType,
+			SpecificOpsRequest: appsv1alpha1.SpecificOpsRequest{
+				VolumeExpansionList: []appsv1alpha1.VolumeExpansion{
+					{
+						ComponentOps:         appsv1alpha1.ComponentOps{ComponentName: targetName},
+						VolumeClaimTemplates: vcts,
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+var _ = Describe("volume auto expansion", func() {
+	newVCT := func(name, size string) appsv1alpha1.ClusterComponentVolumeClaimTemplate {
+		return appsv1alpha1.ClusterComponentVolumeClaimTemplate{
+			Name: name,
+			Spec: appsv1alpha1.PersistentVolumeClaimSpec{
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)},
+				},
+			},
+		}
+	}
+
+	newCompSpec := func(policy *appsv1alpha1.VolumeAutoExpansion) *appsv1alpha1.ClusterComponentSpec {
+		return &appsv1alpha1.ClusterComponentSpec{
+			Name:                 "mysql",
+			VolumeClaimTemplates: []appsv1alpha1.ClusterComponentVolumeClaimTemplate{newVCT("data", "20Gi"), newVCT("log", "10Gi")},
+			Persistence:          &appsv1alpha1.ClusterComponentPersistence{AutoExpansion: policy},
+		}
+	}
+
+	Context("plan the expansion", func() {
+		It("expands the volumes crossing the threshold", func() {
+			spec := newCompSpec(&appsv1alpha1.VolumeAutoExpansion{
+				ThresholdPercent: 85,
+				Increment:        resource.MustParse("10Gi"),
+				MaxSize:          resource.MustParse("100Gi"),
+			})
+			vcts, limited := planVolumeAutoExpansion(spec, map[string]int64{"data": 90, "log": 50})
+			Expect(limited).Should(BeEmpty())
+			Expect(vcts).Should(HaveLen(1))
+			Expect(vcts[0].Name).Should(Equal("data"))
+			Expect(vcts[0].Storage.Cmp(resource.MustParse("30Gi"))).Should(Equal(0))
+		})
+
+		It("takes the default threshold and the volume names", func() {
+			spec := newCompSpec(&appsv1alpha1.VolumeAutoExpansion{
+				VolumeNames: []string{"log"},
+				Increment:   resource.MustParse("10Gi"),
+				MaxSize:     resource.MustParse("100Gi"),
+			})
+			vcts, _ := planVolumeAutoExpansion(spec, map[string]int64{"data": 90, "log": 79})
+			Expect(vcts).Should(BeEmpty())
+			vcts, _ = planVolumeAutoExpansion(spec, map[string]int64{"data": 90, "log": 80})
+			Expect(vcts).Should(HaveLen(1))
+			Expect(vcts[0].Name).Should(Equal("log"))
+		})
+
+		It("caps the size at the max size", func() {
+			spec := newCompSpec(&appsv1alpha1.VolumeAutoExpansion{
+				Increment: resource.MustParse("10Gi"),
+				MaxSize:   resource.MustParse("25Gi"),
+			})
+			vcts, _ := planVolumeAutoExpansion(spec, map[string]int64{"data": 95})
+			Expect(vcts).Should(HaveLen(1))
+			Expect(vcts[0].Storage.Cmp(resource.MustParse("25Gi"))).Should(Equal(0))
+
+			spec.VolumeClaimTemplates[0] = newVCT("data", "25Gi")
+			vcts, limited := planVolumeAutoExpansion(spec, map[string]int64{"data": 95})
+			Expect(vcts).Should(BeEmpty())
+			Expect(limited).Should(Equal([]string{"data"}))
+		})
+	})
+
+	Context("targets", func() {
+		It("includes the components and shardings with the auto expansion", func() {
+			policy := &appsv1alpha1.VolumeAutoExpansion{
+				Increment: resource.MustParse("10Gi"),
+				MaxSize:   resource.MustParse("100Gi"),
+			}
+			cluster := &appsv1alpha1.Cluster{
+				Spec: appsv1alpha1.ClusterSpec{
+					ComponentSpecs: []appsv1alpha1.ClusterComponentSpec{*newCompSpec(policy), {Name: "proxy"}},
+					ShardingSpecs: []appsv1alpha1.ShardingSpec{
+						{Name: "shard", Template: *newCompSpec(policy)},
+					},
+				},
+			}
+			targets := volumeAutoExpansionTargets(cluster)
+			Expect(targets).Should(HaveLen(2))
+			Expect(targets[0].name).Should(Equal("mysql"))
+			Expect(targets[0].sharding).Should(BeFalse())
+			Expect(targets[1].name).Should(Equal("shard"))
+			Expect(targets[1].sharding).Should(BeTrue())
+
+			ops := buildVolumeAutoExpansionOpsRequest(cluster, "shard", []appsv1alpha1.OpsRequestVolumeClaimTemplate{
+				{Name: "data", Storage: resource.MustParse("30Gi")},
+			})
+			Expect(ops.Spec.Type).Should(Equal(appsv1alpha1.VolumeExpansionType))
+			Expect(ops.Spec.VolumeExpansionList[0].ComponentName).Should(Equal("shard"))
+		})
+	})
+
+	Context("disk pressure", func() {
+		It("includes the components with the critical threshold only", func() {
+			cluster := &appsv1alpha1.Cluster{
+				Spec: appsv1alpha1.ClusterSpec{
+					ComponentSpecs: []appsv1alpha1.ClusterComponentSpec{
+						{Name: "mysql", Persistence: &appsv1alpha1.ClusterComponentPersistence{CriticalThresholdPercent: 95}},
+						{Name: "proxy", Persistence: &appsv1alpha1.ClusterComponentPersistence{}},
+					},
+				},
+			}
+			targets := volumeAutoExpansionTargets(cluster)
+			Expect(targets).Should(HaveLen(1))
+			Expect(targets[0].name).Should(Equal("mysql"))
+		})
+
+		It("checks the usage against the critical threshold", func() {
+			Expect(isVolumeUsageCritical(99, 0)).Should(BeFalse())
+			Expect(isVolumeUsageCritical(94, 95)).Should(BeFalse())
+			Expect(isVolumeUsageCritical(95, 95)).Should(BeTrue())
+		})
+
+		It("builds the condition", func() {
+			condition := buildDiskPressureCondition([]string{"mysql-1", "mysql-0"}, 95)
+			Expect(condition.Type).Should(Equal(appsv1alpha1.ConditionTypeDiskPressure))
+			Expect(condition.Status).Should(Equal(metav1.ConditionTrue))
+			Expect(condition.Message).Should(ContainSubstring("mysql-0,mysql-1"))
+
+			condition = buildDiskPressureCondition(nil, 95)
+			Expect(condition.Status).Should(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).Should(Equal(reasonVolumeUsageNormal))
+		})
+	})
+})
@@ -127,7 +127,7 @@ func (r *BackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 			}
 			return r.handleRunningPhase(reqCtx, backup)
 		}
-		return intctrlutil.Reconciled()
+		return r.handleFailedPhase(reqCtx, backup)
 	default:
 		return intctrlutil.Reconciled()
 	}
@@ -671,6 +671,20 @@ func (r *BackupReconciler) handleCompletedPhase(
 	return intctrlutil.Reconciled()
 }
 
+// handleFailedPhase handles the backup object in failed phase.
+// The jobs are kept for troubleshooting, and released once they are deleted by the TTL controller.
+func (r *BackupReconciler) handleFailedPhase(
+	reqCtx intctrlutil.RequestCtx,
+	backup *dpv1alpha1.Backup) (ctrl.Result, error) {
+	labels := dpbackup.BuildBackupWorkloadLabels(backup)
+	for _, namespace := range []string{backup.Namespace, viper.GetString(constant.CfgKeyCtrlrMgrNS)} {
+		if err := releaseDeletingJobs(reqCtx, r.Client, namespace, labels); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+	}
+	return intctrlutil.Reconciled()
+}
+
 func (r *BackupReconciler) updateStatusIfFailed(
 	reqCtx intctrlutil.RequestCtx,
 	original *dpv1alpha1.Backup,
@@ -679,6 +693,10 @@ func (r *BackupReconciler) updateStatusIfFailed(
 	if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeRequeue) {
 		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
 	}
+	if intctrlutil.RecordReconcileError("backup", err) == intctrlutil.ErrorClassConflict {
+		// the backup or its workloads are changed by others, retry with the latest version instead of failing the backup.
+		return intctrlutil.Requeue(reqCtx.Log, err.Error())
+	}
 	sendWarningEventForError(r.Recorder, backup, err)
 	backup.Status.Phase = dpv1alpha1.BackupPhaseFailed
 	backup.Status.FailureReason = err.Error()
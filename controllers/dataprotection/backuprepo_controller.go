@@ -28,7 +28,6 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
-	"reflect"
 	"slices"
 	"sort"
 	"strings"
@@ -328,11 +327,9 @@ func (r *BackupRepoReconciler) updateStatus(reqCtx intctrlutil.RequestCtx, repo
 		repo.Status.ObservedGeneration = repo.Generation
 	}
 
-	if !reflect.DeepEqual(old.Status, repo.Status) {
-		if err := r.Client.Status().Patch(reqCtx.Ctx, repo, client.MergeFrom(old),
-			multicluster.InControlContext()); err != nil {
-			return fmt.Errorf("updateStatus failed: %w", err)
-		}
+	if _, err := intctrlutil.PatchStatus(reqCtx.Ctx, r.Client, repo, old,
+		intctrlutil.WithPatchOptions(multicluster.InControlContext())); err != nil {
+		return fmt.Errorf("updateStatus failed: %w", err)
 	}
 	return nil
 }
@@ -513,12 +510,9 @@ func (r *BackupRepoReconciler) createStorageClassAndSecret(reconCtx *reconcileCo
 			metav1.ConditionTrue, ReasonStorageClassCreated, "")
 	}
 
-	if !reflect.DeepEqual(oldRepo.Status, reconCtx.repo.Status) {
-		err := r.Client.Status().Patch(reconCtx.Ctx, reconCtx.repo, client.MergeFrom(oldRepo),
-			multicluster.InControlContext())
-		if err != nil {
-			return fmt.Errorf("failed to patch backup repo: %w", err)
-		}
+	if _, err := intctrlutil.PatchStatus(reconCtx.Ctx, r.Client, reconCtx.repo, oldRepo,
+		intctrlutil.WithPatchOptions(multicluster.InControlContext())); err != nil {
+		return fmt.Errorf("failed to patch backup repo: %w", err)
 	}
 	reason = ReasonStorageClassCreated
 	return nil
@@ -1780,6 +1774,8 @@ func randomNameForDerivedObject(repo *dpv1alpha1.BackupRepo, prefix string) stri
 }
 
 func cutName(name string) string {
+	// the names are prefixed with the repo UID to be unique, they are truncated as before to keep
+	// tracking the existing objects.
 	if len(name) > 63 {
 		return strings.TrimSuffix(name[:63], "-")
 	}
@@ -167,10 +167,8 @@ func (r *BackupScheduleReconciler) patchStatusFailed(reqCtx intctrlutil.RequestC
 	backupScheduleDeepCopy := backupSchedule.DeepCopy()
 	backupSchedule.Status.Phase = dpv1alpha1.BackupSchedulePhaseFailed
 	backupSchedule.Status.FailureReason = err.Error()
-	if !reflect.DeepEqual(backupSchedule.Status, backupScheduleDeepCopy.Status) {
-		if patchErr := r.Client.Status().Patch(reqCtx.Ctx, backupSchedule, client.MergeFrom(backupScheduleDeepCopy)); patchErr != nil {
-			return intctrlutil.RequeueWithError(patchErr, reqCtx.Log, "")
-		}
+	if _, patchErr := intctrlutil.PatchStatus(reqCtx.Ctx, r.Client, backupSchedule, backupScheduleDeepCopy); patchErr != nil {
+		return intctrlutil.RequeueWithError(patchErr, reqCtx.Log, "")
 	}
 	r.Recorder.Event(backupSchedule, corev1.EventTypeWarning, reason, err.Error())
 	return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
@@ -192,7 +192,7 @@ func (r *LogCollectionReconciler) patchRestoreStatus(reqCtx intctrlutil.RequestC
 		return err
 	}
 	actions := restore.Status.Actions
-	if len(actions.PrepareData) == 0 && len(actions.PostReady) == 0 {
+	if len(actions.PrepareData) == 0 && len(actions.PostReady) == 0 && len(actions.Masking) == 0 {
 		return nil
 	}
 
@@ -225,6 +225,9 @@ func (r *LogCollectionReconciler) patchRestoreStatus(reqCtx intctrlutil.RequestC
 	if err = doLogsPatch(actions.PostReady); err != nil {
 		return err
 	}
+	if err = doLogsPatch(actions.Masking); err != nil {
+		return err
+	}
 	if hasPatchedLogs {
 		return nil
 	}
@@ -63,6 +63,7 @@ type RestoreReconciler struct {
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=clusters,verbs=get;list;watch;create;delete
 
 func (r *RestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	reqCtx := intctrlutil.RequestCtx{
@@ -86,6 +87,10 @@ func (r *RestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return *res, err
 	}
 
+	if restore.Spec.Rehearsal {
+		return r.reconcileRehearsal(reqCtx, restore)
+	}
+
 	switch restore.Status.Phase {
 	case "":
 		return r.newAction(reqCtx, restore)
@@ -125,6 +130,9 @@ func (r *RestoreReconciler) parseRestoreJob(ctx context.Context, object client.O
 }
 
 func (r *RestoreReconciler) deleteExternalResources(reqCtx intctrlutil.RequestCtx, restore *dpv1alpha1.Restore) error {
+	if err := r.teardownRehearsal(reqCtx, restore); err != nil {
+		return err
+	}
 	labels := map[string]string{dprestore.DataProtectionRestoreLabelKey: restore.Name}
 	if err := deleteRelatedJobs(reqCtx, r.Client, restore.Namespace, labels); err != nil {
 		return err
@@ -205,7 +213,7 @@ func (r *RestoreReconciler) newAction(reqCtx intctrlutil.RequestCtx, restore *dp
 	waitBackupRepo := false
 	repoName, err := CheckBackupRepoForRestore(reqCtx, r.Client, restore)
 	switch {
-	case intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal):
+	case intctrlutil.IsTerminalError(err):
 		dprestore.SetRestoreCheckBackupRepoCondition(restore, dprestore.ReasonCheckBackupRepoFailed, err.Error())
 		restore.Status.Phase = dpv1alpha1.RestorePhaseFailed
 		restore.Status.CompletionTimestamp = &metav1.Time{Time: time.Now()}
@@ -281,7 +289,7 @@ func (r *RestoreReconciler) handleRunningPhase(reqCtx intctrlutil.RequestCtx, re
 		// handle restore actions
 		err = r.HandleRestoreActions(reqCtx, restoreMgr)
 	}
-	if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+	if intctrlutil.RecordReconcileError("restore", err) == intctrlutil.ErrorClassTerminal {
 		// set restore phase to failed if the error is fatal.
 		restoreMgr.Restore.Status.Phase = dpv1alpha1.RestorePhaseFailed
 		restoreMgr.Restore.Status.CompletionTimestamp = &metav1.Time{Time: time.Now()}
@@ -291,7 +299,7 @@ func (r *RestoreReconciler) handleRunningPhase(reqCtx intctrlutil.RequestCtx, re
 	}
 	// patch restore status if changes occur
 	if !reflect.DeepEqual(restoreMgr.OriginalRestore.Status, restoreMgr.Restore.Status) {
-		err = r.Client.Status().Patch(reqCtx.Ctx, restoreMgr.Restore, client.MergeFrom(restoreMgr.OriginalRestore))
+		_, err = intctrlutil.PatchStatus(reqCtx.Ctx, r.Client, restoreMgr.Restore, restoreMgr.OriginalRestore)
 	}
 	if err != nil {
 		r.Recorder.Event(restore, corev1.EventTypeWarning, corev1.EventTypeWarning, err.Error())
@@ -317,6 +325,15 @@ func (r *RestoreReconciler) HandleRestoreActions(reqCtx intctrlutil.RequestCtx,
 	if err != nil {
 		return err
 	}
+	if !isCompleted {
+		return nil
+	}
+	reqCtx.Log.V(1).Info("start to mask data", "restore", reqCtx.Req.NamespacedName)
+	// 3. handle the masking stage.
+	isCompleted, err = r.mask(reqCtx, restoreMgr)
+	if err != nil {
+		return err
+	}
 	if isCompleted {
 		restoreMgr.Restore.Status.Phase = dpv1alpha1.RestorePhaseCompleted
 		restoreMgr.Restore.Status.CompletionTimestamp = &metav1.Time{Time: time.Now()}
@@ -413,6 +430,43 @@ func (r *RestoreReconciler) postReady(reqCtx intctrlutil.RequestCtx, restoreMgr
 	return true, nil
 }
 
+// mask handles the masking stage, which scrubs the sensitive data by the masking actions of the latest backup.
+func (r *RestoreReconciler) mask(reqCtx intctrlutil.RequestCtx, restoreMgr *dprestore.RestoreManager) (bool, error) {
+	if restoreMgr.Restore.Spec.MaskingConfig == nil {
+		return true, nil
+	}
+	if meta.IsStatusConditionTrue(restoreMgr.Restore.Status.Conditions, dprestore.ConditionTypeRestoreMasking) {
+		return true, nil
+	}
+	var (
+		err         error
+		isCompleted bool
+	)
+	defer func() {
+		r.handleRestoreStageError(restoreMgr.Restore, dpv1alpha1.Masking, err)
+	}()
+	if len(restoreMgr.MaskingBackupSets) == 0 {
+		// never leave the sensitive data unmasked silently.
+		err = intctrlutil.NewFatalError("spec.maskingConfig is specified, but no masking actions are declared in the actionSet")
+		return false, err
+	}
+	dprestore.SetRestoreStageCondition(restoreMgr.Restore, dpv1alpha1.Masking, dprestore.ReasonProcessing, "processing masking stage")
+	// the data is masked once after all backups are restored.
+	backupSet := restoreMgr.MaskingBackupSets[len(restoreMgr.MaskingBackupSets)-1]
+	for i := range backupSet.ActionSet.Spec.Restore.Masking {
+		isCompleted, err = r.handleBackupActionSet(reqCtx, restoreMgr, backupSet, dpv1alpha1.Masking, i)
+		if err != nil {
+			return false, err
+		}
+		// waiting for masking jobs finished.
+		if !isCompleted {
+			return false, nil
+		}
+	}
+	dprestore.SetRestoreStageCondition(restoreMgr.Restore, dpv1alpha1.Masking, dprestore.ReasonSucceed, "processing masking stage successfully")
+	return true, nil
+}
+
 func (r *RestoreReconciler) handleBackupActionSet(reqCtx intctrlutil.RequestCtx,
 	restoreMgr *dprestore.RestoreManager,
 	backupSet dprestore.BackupActionSet,
@@ -458,6 +512,8 @@ func (r *RestoreReconciler) handleBackupActionSet(reqCtx intctrlutil.RequestCtx,
 	case dpv1alpha1.PostReady:
 		// 2. build jobs for postReady action
 		jobs, err = restoreMgr.BuildPostReadyActionJobs(reqCtx, r.Client, backupSet, target, step)
+	case dpv1alpha1.Masking:
+		jobs, err = restoreMgr.BuildMaskingActionJobs(reqCtx, r.Client, backupSet, target, step)
 	}
 	if err != nil {
 		return false, err
@@ -0,0 +1,341 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dataprotection
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dprestore "github.com/apecloud/kubeblocks/pkg/dataprotection/restore"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+)
+
+const (
+	// rehearsalCheckInterval is the interval to check the ephemeral cluster of a restore rehearsal.
+	rehearsalCheckInterval = 10 * time.Second
+	// defaultRehearsalDeadline is the deadline of a restore rehearsal if not specified.
+	defaultRehearsalDeadline = 2 * time.Hour
+
+	rehearsalValidationContainerName = "validation"
+)
+
+// reconcileRehearsal restores the backup into an ephemeral cluster, validates it, records the result in the backup
+// and tears the cluster down.
+func (r *RestoreReconciler) reconcileRehearsal(reqCtx intctrlutil.RequestCtx, restore *dpv1alpha1.Restore) (ctrl.Result, error) {
+	switch restore.Status.Phase {
+	case "":
+		return r.startRehearsal(reqCtx, restore)
+	case dpv1alpha1.RestorePhaseRunning:
+		return r.checkRehearsal(reqCtx, restore)
+	case dpv1alpha1.RestorePhaseCompleted, dpv1alpha1.RestorePhaseFailed:
+		if err := r.deleteExternalResources(reqCtx, restore); err != nil {
+			return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
+		}
+	}
+	return intctrlutil.Reconciled()
+}
+
+func (r *RestoreReconciler) startRehearsal(reqCtx intctrlutil.RequestCtx, restore *dpv1alpha1.Restore) (ctrl.Result, error) {
+	if restore.Spec.Backup.Namespace != restore.Namespace {
+		// the ephemeral cluster is created in the namespace of the restore, never rehearse the backups of other namespaces.
+		return r.finishRehearsal(reqCtx, restore, false,
+			fmt.Sprintf("backup %s/%s is not in the namespace of the rehearsal", restore.Spec.Backup.Namespace, restore.Spec.Backup.Name))
+	}
+	backup := &dpv1alpha1.Backup{}
+	if err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Namespace: restore.Spec.Backup.Namespace, Name: restore.Spec.Backup.Name}, backup); err != nil {
+		if apierrors.IsNotFound(err) {
+			return r.finishRehearsal(reqCtx, restore, false, err.Error())
+		}
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if backup.Status.Phase != dpv1alpha1.BackupPhaseCompleted {
+		return r.finishRehearsal(reqCtx, restore, false,
+			fmt.Sprintf("backup %s status is %s, only completed backup can be rehearsed", backup.Name, backup.Status.Phase))
+	}
+	cluster, err := buildRehearsalCluster(restore, backup)
+	if err != nil {
+		return r.finishRehearsal(reqCtx, restore, false, err.Error())
+	}
+	if err = controllerutil.SetControllerReference(restore, cluster, r.Scheme); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if err = r.Client.Create(reqCtx.Ctx, cluster); err != nil && !apierrors.IsAlreadyExists(err) {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+
+	patch := client.MergeFrom(restore.DeepCopy())
+	restore.Status.Phase = dpv1alpha1.RestorePhaseRunning
+	restore.Status.StartTimestamp = &metav1.Time{Time: time.Now()}
+	restore.Status.RehearsalClusterName = cluster.Name
+	if err = r.Client.Status().Patch(reqCtx.Ctx, restore, patch); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	r.Recorder.Eventf(restore, corev1.EventTypeNormal, dprestore.ReasonRestoreStarting,
+		"start to rehearse the restore into cluster %s", cluster.Name)
+	return intctrlutil.RequeueAfter(rehearsalCheckInterval, reqCtx.Log, "")
+}
+
+func (r *RestoreReconciler) checkRehearsal(reqCtx intctrlutil.RequestCtx, restore *dpv1alpha1.Restore) (ctrl.Result, error) {
+	timeLeft := rehearsalTimeLeft(restore, time.Now())
+	if timeLeft <= 0 {
+		return r.finishRehearsal(reqCtx, restore, false, "the rehearsal is not finished before the deadline")
+	}
+	requeueAfter := min(timeLeft, rehearsalCheckInterval)
+
+	cluster := &appsv1alpha1.Cluster{}
+	if err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Namespace: restore.Namespace, Name: restore.Status.RehearsalClusterName}, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return r.finishRehearsal(reqCtx, restore, false, fmt.Sprintf("cluster %s of the rehearsal is not found", restore.Status.RehearsalClusterName))
+		}
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	switch cluster.Status.Phase {
+	case appsv1alpha1.FailedClusterPhase:
+		return r.finishRehearsal(reqCtx, restore, false, fmt.Sprintf("cluster %s of the rehearsal is failed", cluster.Name))
+	case appsv1alpha1.RunningClusterPhase:
+	default:
+		return intctrlutil.RequeueAfter(requeueAfter, reqCtx.Log, "wait for the cluster of the rehearsal to be running")
+	}
+	if restore.Spec.RehearsalValidation == nil {
+		return r.finishRehearsal(reqCtx, restore, true, "")
+	}
+
+	job := &batchv1.Job{}
+	err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Namespace: restore.Namespace, Name: rehearsalValidationJobName(restore)}, job)
+	if apierrors.IsNotFound(err) {
+		job = buildRehearsalValidationJob(restore, cluster)
+		if err = controllerutil.SetControllerReference(restore, job, r.Scheme); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		if err = r.Client.Create(reqCtx.Ctx, job); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		return intctrlutil.RequeueAfter(timeLeft, reqCtx.Log, "")
+	}
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	finished, condType, msg := utils.IsJobFinished(job)
+	if !finished {
+		return intctrlutil.RequeueAfter(timeLeft, reqCtx.Log, "")
+	}
+	return r.finishRehearsal(reqCtx, restore, condType == batchv1.JobComplete, msg)
+}
+
+// finishRehearsal records the result of the rehearsal in the restore and the backup, and tears the cluster down.
+func (r *RestoreReconciler) finishRehearsal(reqCtx intctrlutil.RequestCtx, restore *dpv1alpha1.Restore,
+	succeeded bool, message string) (ctrl.Result, error) {
+	now := metav1.Now()
+	patch := client.MergeFrom(restore.DeepCopy())
+	if succeeded {
+		restore.Status.Phase = dpv1alpha1.RestorePhaseCompleted
+	} else {
+		restore.Status.Phase = dpv1alpha1.RestorePhaseFailed
+	}
+	if restore.Status.StartTimestamp == nil {
+		restore.Status.StartTimestamp = &now
+	}
+	restore.Status.CompletionTimestamp = &now
+	restore.Status.Duration = dprestore.GetRestoreDuration(restore.Status)
+
+	backup := &dpv1alpha1.Backup{}
+	err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Namespace: restore.Spec.Backup.Namespace, Name: restore.Spec.Backup.Name}, backup)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if err == nil {
+		backupPatch := client.MergeFrom(backup.DeepCopy())
+		backup.Status.LastRestoreRehearsal = &dpv1alpha1.RestoreRehearsalRecord{
+			RestoreName:         restore.Name,
+			Succeeded:           succeeded,
+			StartTimestamp:      restore.Status.StartTimestamp,
+			CompletionTimestamp: restore.Status.CompletionTimestamp,
+			Duration:            restore.Status.Duration,
+			Message:             message,
+		}
+		if err = r.Client.Status().Patch(reqCtx.Ctx, backup, backupPatch); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+	}
+
+	if err = r.Client.Status().Patch(reqCtx.Ctx, restore, patch); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if succeeded {
+		r.Recorder.Event(restore, corev1.EventTypeNormal, dprestore.ReasonRestoreCompleted, "restore rehearsal succeeded.")
+	} else {
+		r.Recorder.Eventf(restore, corev1.EventTypeWarning, dprestore.ReasonRestoreFailed, "restore rehearsal failed: %s", message)
+	}
+	// tear down the cluster and delete the validation job.
+	if err = r.deleteExternalResources(reqCtx, restore); err != nil {
+		return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
+	}
+	return intctrlutil.Reconciled()
+}
+
+// rehearsalTimeLeft returns the time left before the deadline of the rehearsal.
+func rehearsalTimeLeft(restore *dpv1alpha1.Restore, now time.Time) time.Duration {
+	if restore.Status.StartTimestamp == nil {
+		return defaultRehearsalDeadline
+	}
+	deadline := defaultRehearsalDeadline
+	if restore.Spec.RehearsalDeadlineSeconds != nil {
+		deadline = time.Duration(*restore.Spec.RehearsalDeadlineSeconds) * time.Second
+	}
+	return restore.Status.StartTimestamp.Add(deadline).Sub(now)
+}
+
+// teardownRehearsal deletes the ephemeral cluster of the rehearsal.
+func (r *RestoreReconciler) teardownRehearsal(reqCtx intctrlutil.RequestCtx, restore *dpv1alpha1.Restore) error {
+	if restore.Status.RehearsalClusterName == "" {
+		return nil
+	}
+	cluster := &appsv1alpha1.Cluster{}
+	if err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Namespace: restore.Namespace, Name: restore.Status.RehearsalClusterName}, cluster); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !metav1.IsControlledBy(cluster, restore) {
+		return nil
+	}
+	return intctrlutil.BackgroundDeleteObject(r.Client, reqCtx.Ctx, cluster)
+}
+
+func rehearsalClusterName(restore *dpv1alpha1.Restore, sourceClusterName string) string {
+	return fmt.Sprintf("%s-rehearsal-%s", sourceClusterName, string(restore.UID)[:5])
+}
+
+func rehearsalValidationJobName(restore *dpv1alpha1.Restore) string {
+	return fmt.Sprintf("restore-rehearsal-%s", string(restore.UID)[:8])
+}
+
+// buildRehearsalCluster builds the ephemeral cluster from the cluster snapshot of the backup.
+func buildRehearsalCluster(restore *dpv1alpha1.Restore, backup *dpv1alpha1.Backup) (*appsv1alpha1.Cluster, error) {
+	snapshot, ok := backup.Annotations[constant.ClusterSnapshotAnnotationKey]
+	if !ok {
+		return nil, fmt.Errorf("missing snapshot annotation in backup %s, %s is empty in Annotations", backup.Name, constant.ClusterSnapshotAnnotationKey)
+	}
+	source := &appsv1alpha1.Cluster{}
+	if err := json.Unmarshal([]byte(snapshot), source); err != nil {
+		return nil, err
+	}
+	restoreAnnotation, err := dprestore.GetRestoreFromBackupAnnotation(backup,
+		string(dpv1alpha1.VolumeClaimRestorePolicyParallel), restore.Spec.RestoreTime, false)
+	if err != nil {
+		return nil, err
+	}
+	sourceClusterName := backup.Labels[constant.AppInstanceLabelKey]
+	if sourceClusterName == "" {
+		sourceClusterName = source.Name
+	}
+	cluster := &appsv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: restore.Namespace,
+			Name:      rehearsalClusterName(restore, sourceClusterName),
+			Labels: map[string]string{
+				dprestore.DataProtectionRestoreLabelKey: restore.Name,
+			},
+			Annotations: map[string]string{
+				constant.RestoreFromBackupAnnotationKey: restoreAnnotation,
+			},
+		},
+		Spec: source.Spec,
+	}
+	// the ephemeral cluster is wiped out after the rehearsal, and never backed up or patched.
+	cluster.Spec.TerminationPolicy = appsv1alpha1.WipeOut
+	cluster.Spec.Backup = nil
+	cluster.Spec.UpgradePolicy = nil
+	var services []appsv1alpha1.ClusterService
+	for i := range cluster.Spec.Services {
+		svc := cluster.Spec.Services[i]
+		if svc.Service.Spec.Type == corev1.ServiceTypeLoadBalancer {
+			continue
+		}
+		if svc.Service.Spec.Type == corev1.ServiceTypeNodePort {
+			for j := range svc.Spec.Ports {
+				svc.Spec.Ports[j].NodePort = 0
+			}
+		}
+		if svc.Service.Spec.Selector != nil {
+			delete(svc.Service.Spec.Selector, constant.AppInstanceLabelKey)
+		}
+		services = append(services, svc)
+	}
+	cluster.Spec.Services = services
+	for i := range cluster.Spec.ComponentSpecs {
+		cluster.Spec.ComponentSpecs[i].OfflineInstances = nil
+	}
+	return cluster, nil
+}
+
+// buildRehearsalValidationJob builds the job to validate the ephemeral cluster of the rehearsal.
+func buildRehearsalValidationJob(restore *dpv1alpha1.Restore, cluster *appsv1alpha1.Cluster) *batchv1.Job {
+	validation := restore.Spec.RehearsalValidation
+	env := append([]corev1.EnvVar{
+		{Name: constant.KBEnvClusterName, Value: cluster.Name},
+		{Name: constant.KBEnvNamespace, Value: cluster.Namespace},
+	}, validation.Env...)
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: restore.Namespace,
+			Name:      rehearsalValidationJobName(restore),
+			Labels: map[string]string{
+				constant.AppManagedByLabelKey:                    dptypes.AppName,
+				dprestore.DataProtectionRestoreLabelKey:          restore.Name,
+				dprestore.DataProtectionRestoreNamespaceLabelKey: restore.Namespace,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: pointer.Int32(0),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: restore.Spec.ServiceAccountName,
+					Containers: []corev1.Container{
+						{
+							Name:    rehearsalValidationContainerName,
+							Image:   validation.Image,
+							Command: validation.Command,
+							Env:     env,
+						},
+					},
+				},
+			},
+		},
+	}
+	if validation.TimeoutSeconds > 0 {
+		job.Spec.ActiveDeadlineSeconds = pointer.Int64(int64(validation.TimeoutSeconds))
+	}
+	return job
+}
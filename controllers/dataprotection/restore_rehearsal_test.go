@@ -0,0 +1,143 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dataprotection
+
+import (
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	dprestore "github.com/apecloud/kubeblocks/pkg/dataprotection/restore"
+)
+
+var _ = Describe("Restore rehearsal", func() {
+	newRestore := func() *dpv1alpha1.Restore {
+		return &dpv1alpha1.Restore{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "rehearsal", UID: "0123456789abcdef"},
+			Spec: dpv1alpha1.RestoreSpec{
+				Backup:    dpv1alpha1.BackupRef{Name: "backup", Namespace: "default"},
+				Rehearsal: true,
+			},
+		}
+	}
+
+	newBackup := func(source *appsv1alpha1.Cluster) *dpv1alpha1.Backup {
+		backup := &dpv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "backup",
+				Labels: map[string]string{
+					constant.AppInstanceLabelKey:    source.Name,
+					constant.KBAppComponentLabelKey: "mysql",
+				},
+				Annotations: map[string]string{},
+			},
+			Status: dpv1alpha1.BackupStatus{Phase: dpv1alpha1.BackupPhaseCompleted},
+		}
+		snapshot, _ := json.Marshal(source)
+		backup.Annotations[constant.ClusterSnapshotAnnotationKey] = string(snapshot)
+		return backup
+	}
+
+	It("builds the ephemeral cluster from the cluster snapshot", func() {
+		source := &appsv1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mysql"},
+			Spec: appsv1alpha1.ClusterSpec{
+				TerminationPolicy: appsv1alpha1.Delete,
+				Backup:            &appsv1alpha1.ClusterBackup{},
+				ComponentSpecs: []appsv1alpha1.ClusterComponentSpec{
+					{Name: "mysql", Replicas: 3, OfflineInstances: []string{"mysql-mysql-1"}},
+				},
+				Services: []appsv1alpha1.ClusterService{
+					{Service: appsv1alpha1.Service{Name: "lb", Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer}}},
+					{Service: appsv1alpha1.Service{Name: "np", Spec: corev1.ServiceSpec{
+						Type:     corev1.ServiceTypeNodePort,
+						Ports:    []corev1.ServicePort{{Port: 3306, NodePort: 30306}},
+						Selector: map[string]string{constant.AppInstanceLabelKey: "mysql"},
+					}}},
+				},
+			},
+		}
+		restore := newRestore()
+		cluster, err := buildRehearsalCluster(restore, newBackup(source))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cluster.Name).Should(Equal("mysql-rehearsal-01234"))
+		Expect(cluster.Namespace).Should(Equal(restore.Namespace))
+		Expect(cluster.Labels).Should(HaveKeyWithValue(dprestore.DataProtectionRestoreLabelKey, restore.Name))
+		Expect(cluster.Annotations).Should(HaveKey(constant.RestoreFromBackupAnnotationKey))
+		Expect(cluster.Spec.TerminationPolicy).Should(Equal(appsv1alpha1.WipeOut))
+		Expect(cluster.Spec.Backup).Should(BeNil())
+		Expect(cluster.Spec.ComponentSpecs[0].OfflineInstances).Should(BeEmpty())
+		Expect(cluster.Spec.Services).Should(HaveLen(1))
+		Expect(cluster.Spec.Services[0].Spec.Ports[0].NodePort).Should(BeZero())
+		Expect(cluster.Spec.Services[0].Spec.Selector).ShouldNot(HaveKey(constant.AppInstanceLabelKey))
+	})
+
+	It("fails to build the ephemeral cluster without the cluster snapshot", func() {
+		backup := newBackup(&appsv1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "mysql"}})
+		delete(backup.Annotations, constant.ClusterSnapshotAnnotationKey)
+		_, err := buildRehearsalCluster(newRestore(), backup)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("computes the time left before the deadline", func() {
+		restore := newRestore()
+		now := time.Now()
+		Expect(rehearsalTimeLeft(restore, now)).Should(Equal(defaultRehearsalDeadline))
+
+		restore.Status.StartTimestamp = &metav1.Time{Time: now.Add(-time.Hour)}
+		Expect(rehearsalTimeLeft(restore, now)).Should(Equal(time.Hour))
+
+		restore.Spec.RehearsalDeadlineSeconds = pointer.Int32(1800)
+		Expect(rehearsalTimeLeft(restore, now)).Should(Equal(-30 * time.Minute))
+	})
+
+	It("builds the validation job", func() {
+		restore := newRestore()
+		restore.Spec.RehearsalValidation = &dpv1alpha1.RestoreRehearsalValidation{
+			Image:          "busybox",
+			Command:        []string{"sh", "-c", "true"},
+			Env:            []corev1.EnvVar{{Name: "FOO", Value: "bar"}},
+			TimeoutSeconds: 60,
+		}
+		cluster := &appsv1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mysql-rehearsal-01234"}}
+		job := buildRehearsalValidationJob(restore, cluster)
+		Expect(job.Name).Should(Equal("restore-rehearsal-01234567"))
+		Expect(job.Labels).Should(HaveKeyWithValue(dprestore.DataProtectionRestoreLabelKey, restore.Name))
+		Expect(*job.Spec.BackoffLimit).Should(BeZero())
+		Expect(*job.Spec.ActiveDeadlineSeconds).Should(Equal(int64(60)))
+		container := job.Spec.Template.Spec.Containers[0]
+		Expect(container.Image).Should(Equal("busybox"))
+		Expect(container.Env).Should(ContainElements(
+			corev1.EnvVar{Name: constant.KBEnvClusterName, Value: cluster.Name},
+			corev1.EnvVar{Name: constant.KBEnvNamespace, Value: cluster.Namespace},
+			corev1.EnvVar{Name: "FOO", Value: "bar"},
+		))
+	})
+})
@@ -160,7 +160,10 @@ func GetTargetPods(reqCtx intctrlutil.RequestCtx,
 		case dpv1alpha1.PodSelectionStrategyAny:
 			var pod *corev1.Pod
 			if len(selectedPodNames) == 0 || backupType == dpv1alpha1.BackupTypeContinuous {
-				pod = dputils.GetFirstIndexRunningPod(pods)
+				// prefer the replica designated for backups.
+				if pod = dputils.GetBackupReplicaPod(pods); pod == nil {
+					pod = dputils.GetFirstIndexRunningPod(pods)
+				}
 			} else {
 				// if already selected target pods and backupType is not Continuous, we should re-use them.
 				pod = dputils.GetPodByName(pods, selectedPodNames[0])
@@ -316,6 +319,28 @@ func deleteRelatedJobs(reqCtx intctrlutil.RequestCtx, cli client.Client, namespa
 	return nil
 }
 
+// releaseDeletingJobs removes the finalizer of the jobs being deleted, e.g. by the TTL controller.
+func releaseDeletingJobs(reqCtx intctrlutil.RequestCtx, cli client.Client, namespace string, labels map[string]string) error {
+	if labels == nil || namespace == "" {
+		return nil
+	}
+	jobs := &batchv1.JobList{}
+	if err := cli.List(reqCtx.Ctx, jobs,
+		client.InNamespace(namespace), client.MatchingLabels(labels)); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if job.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if err := dputils.RemoveDataProtectionFinalizer(reqCtx.Ctx, cli, job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func RecorderEventAndRequeue(reqCtx intctrlutil.RequestCtx, recorder record.EventRecorder,
 	obj client.Object, err error) (reconcile.Result, error) {
 	sendWarningEventForError(recorder, obj, err)
@@ -0,0 +1,269 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package experimental
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	experimental "github.com/apecloud/kubeblocks/apis/experimental/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	testScenarioRequeueInterval = 5 * time.Second
+
+	defaultStepTimeoutSeconds = 1800
+)
+
+// TestScenarioReconciler reconciles a TestScenario object
+type TestScenarioReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=experimental.kubeblocks.io,resources=testscenarios,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=experimental.kubeblocks.io,resources=testscenarios/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=experimental.kubeblocks.io,resources=testscenarios/finalizers,verbs=update
+
+// Reconcile runs the steps of the TestScenario one by one, and records the result and timings of each step.
+func (r *TestScenarioReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("testScenario", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	scenario := &experimental.TestScenario{}
+	if err := r.Client.Get(ctx, req.NamespacedName, scenario); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if scenario.GetDeletionTimestamp() != nil || scenario.IsComplete() {
+		return intctrlutil.Reconciled()
+	}
+
+	original := scenario.DeepCopy()
+	requeue, err := r.runScenario(reqCtx, scenario)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if err = r.Client.Status().Patch(ctx, scenario, client.MergeFrom(original)); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if scenario.IsComplete() {
+		r.Recorder.Eventf(scenario, corev1.EventTypeNormal, string(scenario.Status.Phase),
+			"the scenario is %s in %s", scenario.Status.Phase, scenario.Status.Duration)
+		if scenario.Spec.CleanupOnCompletion {
+			if err = r.cleanup(reqCtx, scenario); err != nil {
+				return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+			}
+		}
+		return intctrlutil.Reconciled()
+	}
+	if requeue {
+		return intctrlutil.RequeueAfter(testScenarioRequeueInterval, reqCtx.Log, "")
+	}
+	return intctrlutil.Reconciled()
+}
+
+// runScenario runs the step in progress, and moves to the next step once it succeeds.
+// It returns true if the scenario should be checked again later.
+func (r *TestScenarioReconciler) runScenario(reqCtx intctrlutil.RequestCtx, scenario *experimental.TestScenario) (bool, error) {
+	now := metav1.Now()
+	if scenario.Status.Phase == "" || scenario.Status.Phase == experimental.PendingTestScenarioPhase {
+		scenario.Status.Phase = experimental.RunningTestScenarioPhase
+		scenario.Status.StartTimestamp = &now
+		scenario.Status.CurrentStep = 0
+		scenario.Status.Steps = make([]experimental.ScenarioStepStatus, len(scenario.Spec.Steps))
+		for i, step := range scenario.Spec.Steps {
+			scenario.Status.Steps[i] = experimental.ScenarioStepStatus{
+				Name:  step.Name,
+				Phase: experimental.PendingTestScenarioPhase,
+			}
+		}
+	}
+
+	for int(scenario.Status.CurrentStep) < len(scenario.Spec.Steps) {
+		index := scenario.Status.CurrentStep
+		step := scenario.Spec.Steps[index]
+		stepStatus := &scenario.Status.Steps[index]
+		if err := r.runStep(reqCtx, scenario, step, stepStatus); err != nil {
+			return false, err
+		}
+		switch stepStatus.Phase {
+		case experimental.SucceededTestScenarioPhase:
+			scenario.Status.CurrentStep++
+		case experimental.FailedTestScenarioPhase:
+			r.completeScenario(scenario, experimental.FailedTestScenarioPhase,
+				fmt.Sprintf("step %s failed: %s", step.Name, stepStatus.Message))
+			return false, nil
+		default:
+			return true, nil
+		}
+	}
+	r.completeScenario(scenario, experimental.SucceededTestScenarioPhase, "all steps succeeded")
+	return false, nil
+}
+
+// runStep creates the object of the step if not yet, and checks whether the object reaches the expected phases.
+func (r *TestScenarioReconciler) runStep(reqCtx intctrlutil.RequestCtx, scenario *experimental.TestScenario,
+	step experimental.ScenarioStep, stepStatus *experimental.ScenarioStepStatus) error {
+	failStep := func(message string) {
+		r.completeStep(stepStatus, experimental.FailedTestScenarioPhase, message)
+	}
+
+	obj, err := r.buildStepObject(scenario, step)
+	if err != nil {
+		failStep(err.Error())
+		return nil
+	}
+	if stepStatus.Phase == experimental.PendingTestScenarioPhase {
+		if step.Object != nil {
+			if err = controllerutil.SetOwnerReference(scenario, obj, r.Scheme); err != nil {
+				return err
+			}
+			if err = r.Client.Create(reqCtx.Ctx, obj); err != nil && !apierrors.IsAlreadyExists(err) {
+				if apierrors.IsInvalid(err) || apierrors.IsBadRequest(err) {
+					failStep(fmt.Sprintf("failed to create %s %s: %s", obj.GetKind(), obj.GetName(), err.Error()))
+					return nil
+				}
+				return err
+			}
+		}
+		now := metav1.Now()
+		stepStatus.Phase = experimental.RunningTestScenarioPhase
+		stepStatus.StartTimestamp = &now
+		r.Recorder.Eventf(scenario, corev1.EventTypeNormal, "StepStarted", "step %s is started", step.Name)
+	}
+
+	if err = r.Client.Get(reqCtx.Ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+	} else {
+		phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+		stepStatus.ObjectPhase = phase
+		switch {
+		case slices.Contains(step.ExpectedPhases, phase):
+			r.completeStep(stepStatus, experimental.SucceededTestScenarioPhase,
+				fmt.Sprintf("%s %s is %s", obj.GetKind(), obj.GetName(), phase))
+			return nil
+		case slices.Contains(step.FailurePhases, phase):
+			failStep(fmt.Sprintf("%s %s is %s", obj.GetKind(), obj.GetName(), phase))
+			return nil
+		}
+	}
+
+	timeoutSeconds := step.TimeoutSeconds
+	if timeoutSeconds <= 0 {
+		timeoutSeconds = defaultStepTimeoutSeconds
+	}
+	if time.Since(stepStatus.StartTimestamp.Time) > time.Duration(timeoutSeconds)*time.Second {
+		failStep(fmt.Sprintf("timed out after %ds waiting for %s %s to be %v, the current phase is %q",
+			timeoutSeconds, obj.GetKind(), obj.GetName(), step.ExpectedPhases, stepStatus.ObjectPhase))
+	}
+	return nil
+}
+
+// buildStepObject builds the object which the step creates or verifies, in the namespace of the scenario.
+func (r *TestScenarioReconciler) buildStepObject(scenario *experimental.TestScenario, step experimental.ScenarioStep) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	switch {
+	case step.Object != nil:
+		if err := obj.UnmarshalJSON(step.Object.Raw); err != nil {
+			return nil, fmt.Errorf("invalid object of step %s: %s", step.Name, err.Error())
+		}
+		if obj.GetNamespace() != "" && obj.GetNamespace() != scenario.Namespace {
+			return nil, fmt.Errorf("the object of step %s should be in the namespace %s", step.Name, scenario.Namespace)
+		}
+	case step.Verify != nil:
+		obj.SetAPIVersion(step.Verify.APIVersion)
+		obj.SetKind(step.Verify.Kind)
+		obj.SetName(step.Verify.Name)
+	default:
+		return nil, fmt.Errorf("either object or verify should be specified in step %s", step.Name)
+	}
+	if obj.GetName() == "" {
+		return nil, fmt.Errorf("the name of the object of step %s is required", step.Name)
+	}
+	obj.SetNamespace(scenario.Namespace)
+	return obj, nil
+}
+
+func (r *TestScenarioReconciler) completeStep(stepStatus *experimental.ScenarioStepStatus,
+	phase experimental.TestScenarioPhase, message string) {
+	now := metav1.Now()
+	if stepStatus.StartTimestamp == nil {
+		stepStatus.StartTimestamp = &now
+	}
+	stepStatus.Phase = phase
+	stepStatus.Message = message
+	stepStatus.CompletionTimestamp = &now
+	stepStatus.Duration = now.Sub(stepStatus.StartTimestamp.Time).Round(time.Second).String()
+}
+
+func (r *TestScenarioReconciler) completeScenario(scenario *experimental.TestScenario,
+	phase experimental.TestScenarioPhase, message string) {
+	now := metav1.Now()
+	scenario.Status.Phase = phase
+	scenario.Status.Message = message
+	scenario.Status.CompletionTimestamp = &now
+	scenario.Status.Duration = now.Sub(scenario.Status.StartTimestamp.Time).Round(time.Second).String()
+}
+
+// cleanup deletes the objects created by the scenario in reverse order.
+func (r *TestScenarioReconciler) cleanup(reqCtx intctrlutil.RequestCtx, scenario *experimental.TestScenario) error {
+	for i := len(scenario.Spec.Steps) - 1; i >= 0; i-- {
+		step := scenario.Spec.Steps[i]
+		if step.Object == nil {
+			continue
+		}
+		obj, err := r.buildStepObject(scenario, step)
+		if err != nil {
+			continue
+		}
+		if err = r.Client.Delete(reqCtx.Ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TestScenarioReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&experimental.TestScenario{}).
+		Complete(r)
+}
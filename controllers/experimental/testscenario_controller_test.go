@@ -0,0 +1,146 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package experimental
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	experimentalv1alpha1 "github.com/apecloud/kubeblocks/apis/experimental/v1alpha1"
+)
+
+var _ = Describe("test scenario controller test", func() {
+	var (
+		ctx        = context.Background()
+		reconciler *TestScenarioReconciler
+		cli        client.Client
+		key        = types.NamespacedName{Namespace: namespace, Name: "scenario"}
+	)
+
+	newScenario := func(expectedPhases ...string) *experimentalv1alpha1.TestScenario {
+		return &experimentalv1alpha1.TestScenario{
+			ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+			Spec: experimentalv1alpha1.TestScenarioSpec{
+				Steps: []experimentalv1alpha1.ScenarioStep{
+					{
+						Name: "create-cluster",
+						Object: &runtime.RawExtension{
+							Raw: []byte(`{"apiVersion":"apps.kubeblocks.io/v1alpha1","kind":"Cluster","metadata":{"name":"mycluster"}}`),
+						},
+						ExpectedPhases: expectedPhases,
+						FailurePhases:  []string{string(appsv1alpha1.FailedClusterPhase)},
+						TimeoutSeconds: 600,
+					},
+					{
+						Name: "verify",
+						Verify: &experimentalv1alpha1.ScenarioObjectReference{
+							APIVersion: appsv1alpha1.GroupVersion.String(),
+							Kind:       appsv1alpha1.ClusterKind,
+							Name:       "mycluster",
+						},
+						ExpectedPhases: expectedPhases,
+						TimeoutSeconds: 600,
+					},
+				},
+			},
+		}
+	}
+
+	setup := func(scenario *experimentalv1alpha1.TestScenario) {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+		Expect(appsv1alpha1.AddToScheme(scheme)).Should(Succeed())
+		Expect(experimentalv1alpha1.AddToScheme(scheme)).Should(Succeed())
+		cli = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithStatusSubresource(&experimentalv1alpha1.TestScenario{}, &appsv1alpha1.Cluster{}).
+			WithObjects(scenario).
+			Build()
+		reconciler = &TestScenarioReconciler{
+			Client:   cli,
+			Scheme:   scheme,
+			Recorder: record.NewFakeRecorder(100),
+		}
+	}
+
+	reconcile := func() *experimentalv1alpha1.TestScenario {
+		_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).Should(Succeed())
+		scenario := &experimentalv1alpha1.TestScenario{}
+		Expect(cli.Get(ctx, key, scenario)).Should(Succeed())
+		return scenario
+	}
+
+	mockClusterPhase := func(phase appsv1alpha1.ClusterPhase) {
+		cluster := &appsv1alpha1.Cluster{}
+		Expect(cli.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "mycluster"}, cluster)).Should(Succeed())
+		patch := client.MergeFrom(cluster.DeepCopy())
+		cluster.Status.Phase = phase
+		Expect(cli.Status().Patch(ctx, cluster, patch)).Should(Succeed())
+	}
+
+	It("runs the steps in order", func() {
+		setup(newScenario(string(appsv1alpha1.RunningClusterPhase)))
+
+		By("create the cluster of the first step")
+		scenario := reconcile()
+		Expect(scenario.Status.Phase).Should(Equal(experimentalv1alpha1.RunningTestScenarioPhase))
+		Expect(scenario.Status.CurrentStep).Should(BeEquivalentTo(0))
+		Expect(scenario.Status.Steps[0].Phase).Should(Equal(experimentalv1alpha1.RunningTestScenarioPhase))
+		cluster := &appsv1alpha1.Cluster{}
+		Expect(cli.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "mycluster"}, cluster)).Should(Succeed())
+		Expect(cluster.OwnerReferences).Should(HaveLen(1))
+
+		By("mock the cluster to be running")
+		mockClusterPhase(appsv1alpha1.RunningClusterPhase)
+		scenario = reconcile()
+		Expect(scenario.Status.Phase).Should(Equal(experimentalv1alpha1.SucceededTestScenarioPhase))
+		Expect(scenario.Status.CurrentStep).Should(BeEquivalentTo(2))
+		for _, stepStatus := range scenario.Status.Steps {
+			Expect(stepStatus.Phase).Should(Equal(experimentalv1alpha1.SucceededTestScenarioPhase))
+			Expect(stepStatus.Duration).ShouldNot(BeEmpty())
+		}
+		Expect(scenario.Status.Duration).ShouldNot(BeEmpty())
+	})
+
+	It("fails the scenario once a step fails", func() {
+		setup(newScenario(string(appsv1alpha1.RunningClusterPhase)))
+
+		reconcile()
+		mockClusterPhase(appsv1alpha1.FailedClusterPhase)
+		scenario := reconcile()
+		Expect(scenario.Status.Phase).Should(Equal(experimentalv1alpha1.FailedTestScenarioPhase))
+		Expect(scenario.Status.Steps[0].Phase).Should(Equal(experimentalv1alpha1.FailedTestScenarioPhase))
+		Expect(scenario.Status.Steps[1].Phase).Should(Equal(experimentalv1alpha1.PendingTestScenarioPhase))
+		Expect(scenario.Status.Message).Should(ContainSubstring("create-cluster"))
+	})
+})
@@ -26,7 +26,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/Masterminds/semver/v3"
 	ctrlerihandler "github.com/authzed/controller-idioms/handler"
 	"golang.org/x/exp/slices"
 	v1 "k8s.io/api/apps/v1"
@@ -42,6 +41,7 @@ import (
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	extensionsv1alpha1 "github.com/apecloud/kubeblocks/apis/extensions/v1alpha1"
+	kbaddon "github.com/apecloud/kubeblocks/pkg/addon"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
@@ -1193,7 +1193,7 @@ func checkAnnotationsConstraint(ctx context.Context, reconciler *AddonReconciler
 		if err != nil {
 			return false, err
 		}
-		if ok, err := validateVersion(addon.Annotations[KBVersionValidate], kbVersion); err == nil && !ok {
+		if ok, err := kbaddon.ValidateKubeBlocksVersion(addon.Annotations[KBVersionValidate], kbVersion); err == nil && !ok {
 			// kb version is mismatch, set the event and modify the status of the addon
 			reconciler.Event(addon, corev1.EventTypeWarning, "Kubeblocks Version Mismatch",
 				fmt.Sprintf("The version of kubeblocks needs to be %s, current is %s", addon.Annotations[KBVersionValidate], kbVersion))
@@ -1219,37 +1219,6 @@ func checkAnnotationsConstraint(ctx context.Context, reconciler *AddonReconciler
 	return true, nil
 }
 
-func validateVersion(annotations, kbVersion string) (bool, error) {
-	if kbVersion == "" {
-		return false, nil
-	}
-	addPreReleaseInfo := func(constraint string) string {
-		constraint = strings.Trim(constraint, " ")
-		split := strings.Split(constraint, "-")
-		if len(split) == 1 && (strings.HasPrefix(constraint, ">") || strings.Contains(constraint, "<")) {
-			constraint += "-0"
-		}
-		return constraint
-	}
-	if strings.Contains(kbVersion, "-") {
-		rules := strings.Split(annotations, ",")
-		for i := range rules {
-			rules[i] = addPreReleaseInfo(rules[i])
-		}
-		annotations = strings.Join(rules, ",")
-	}
-	constraint, err := semver.NewConstraint(annotations)
-	if err != nil {
-		return false, err
-	}
-	v, err := semver.NewVersion(kbVersion)
-	if err != nil {
-		return false, err
-	}
-	validate, _ := constraint.Validate(v)
-	return validate, nil
-}
-
 func checkAddonSpec(addon *extensionsv1alpha1.Addon) error {
 	if addon.Spec.Type == extensionsv1alpha1.HelmType {
 		if addon.Spec.Helm == nil {
@@ -19,6 +19,10 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 
 package extensions
 
+import (
+	kbaddon "github.com/apecloud/kubeblocks/pkg/addon"
+)
+
 const (
 	// name of our custom finalizer
 	addonFinalizerName = "addon.kubeblocks.io/finalizer"
@@ -28,7 +32,7 @@ const (
 	SkipInstallableCheck = "extensions.kubeblocks.io/skip-installable-check"
 	NoDeleteJobs         = "extensions.kubeblocks.io/no-delete-jobs"
 	AddonDefaultIsEmpty  = "addons.extensions.kubeblocks.io/default-is-empty"
-	KBVersionValidate    = "addon.kubeblocks.io/kubeblocks-version"
+	KBVersionValidate    = kbaddon.KubeBlocksVersionAnnotationKey
 
 	// label keys
 	AddonProvider = "addon.kubeblocks.io/provider"
@@ -90,8 +90,9 @@ func (r *InstanceSetReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		Do(instanceset.NewUpdateReconciler()).
 		Commit()
 
-	// TODO(free6om): handle error based on ErrorCode (after defined)
-
+	if err != nil && intctrlutil.RecordReconcileError("instanceset", err) == intctrlutil.ErrorClassConflict {
+		return intctrlutil.Requeue(logger, err.Error())
+	}
 	return res, err
 }
 
@@ -0,0 +1,61 @@
+{{/*
+Resolve the apiVersion of ValidatingAdmissionPolicy served by the cluster.
+*/}}
+{{- define "kubeblocks.admissionPolicyAPIVersion" -}}
+{{- if .Capabilities.APIVersions.Has "admissionregistration.k8s.io/v1/ValidatingAdmissionPolicy" }}
+{{- print "admissionregistration.k8s.io/v1" }}
+{{- else if .Capabilities.APIVersions.Has "admissionregistration.k8s.io/v1beta1/ValidatingAdmissionPolicy" }}
+{{- print "admissionregistration.k8s.io/v1beta1" }}
+{{- else }}
+{{- fail "admissionPolicies.enabled requires ValidatingAdmissionPolicy support (Kubernetes 1.28+ with admissionregistration.k8s.io/v1beta1 enabled, or 1.30+)." }}
+{{- end }}
+{{- end }}
+
+{{/*
+The common validations of the validating webhooks, expressed in CEL.
+Validations which need to look up other objects are left to the controllers.
+*/}}
+{{- define "kubeblocks.admissionPolicies" -}}
+policies:
+- name: vcluster
+  apiGroup: apps.kubeblocks.io
+  apiVersion: v1alpha1
+  resource: clusters
+  validations:
+  - expression: "has(object.spec.clusterDefinitionRef) || (has(object.spec.componentSpecs) && size(object.spec.componentSpecs) > 0) || (has(object.spec.shardingSpecs) && size(object.spec.shardingSpecs) > 0)"
+    message: "either spec.clusterDefinitionRef, spec.componentSpecs or spec.shardingSpecs should be specified"
+  - expression: "!has(object.spec.shardingSpecs) || object.spec.shardingSpecs.all(x, size(object.spec.shardingSpecs.filter(s, s.name == x.name)) == 1)"
+    message: "duplicated sharding spec name"
+  - expression: "!has(object.spec.shardingSpecs) || !has(object.spec.componentSpecs) || object.spec.shardingSpecs.all(s, !object.spec.componentSpecs.exists(c, c.name == s.name))"
+    message: "sharding spec name conflicts with component name"
+- name: vopsrequest
+  apiGroup: apps.kubeblocks.io
+  apiVersion: v1alpha1
+  resource: opsrequests
+  validations:
+  - expression: "has(object.spec.clusterName) || has(object.spec.clusterRef)"
+    message: "spec.clusterName is required"
+  {{- range $opsType, $field := dict "HorizontalScaling" "horizontalScaling" "VerticalScaling" "verticalScaling" "VolumeExpansion" "volumeExpansion" "Restart" "restart" "Switchover" "switchover" "Expose" "expose" "RebuildInstance" "rebuildFrom" "PurgeOfflineInstances" "purgeOfflineInstances" }}
+  - expression: "object.spec.type != '{{ $opsType }}' || (has(object.spec.{{ $field }}) && size(object.spec.{{ $field }}) > 0)"
+    message: "spec.{{ $field }} is required for {{ $opsType }} OpsRequest"
+  {{- end }}
+  {{- range $opsType, $field := dict "Upgrade" "upgrade" "DataScript" "scriptSpec" "Custom" "custom" }}
+  - expression: "object.spec.type != '{{ $opsType }}' || has(object.spec.{{ $field }})"
+    message: "spec.{{ $field }} is required for {{ $opsType }} OpsRequest"
+  {{- end }}
+  - expression: "object.spec.type != 'Reconfiguring' || has(object.spec.reconfigure) || (has(object.spec.reconfigures) && size(object.spec.reconfigures) > 0)"
+    message: "spec.reconfigure or spec.reconfigures is required for Reconfiguring OpsRequest"
+  - expression: "!has(object.metadata.annotations) || !('ops.kubeblocks.io/approved-by' in object.metadata.annotations) || (oldObject != null && has(oldObject.metadata.annotations) && 'ops.kubeblocks.io/approved-by' in oldObject.metadata.annotations && oldObject.metadata.annotations['ops.kubeblocks.io/approved-by'] == object.metadata.annotations['ops.kubeblocks.io/approved-by']) || (object.metadata.annotations['ops.kubeblocks.io/approved-by'] == request.userInfo.username && authorizer.group('apps.kubeblocks.io').resource('opsrequests').namespace(object.metadata.namespace).name(object.metadata.name).check('approve').allowed())"
+    message: "the ops.kubeblocks.io/approved-by annotation must be set to the name of the requesting user, who is allowed to approve the OpsRequest"
+- name: vinstanceset
+  apiGroup: workloads.kubeblocks.io
+  apiVersion: v1alpha1
+  resource: instancesets
+  validations:
+  - expression: "!has(object.spec.roles) || object.spec.roles.all(x, size(object.spec.roles.filter(r, r.name == x.name)) == 1)"
+    message: "duplicated role name"
+  - expression: "!has(object.spec.roles) || size(object.spec.roles.filter(r, has(r.isLeader) && r.isLeader)) <= 1"
+    message: "at most one role can be the leader"
+  - expression: "!has(object.spec.instances) || object.spec.instances.all(x, size(object.spec.instances.filter(t, t.name == x.name)) == 1)"
+    message: "duplicated instance template name"
+{{- end }}
@@ -0,0 +1,49 @@
+# Code generated by hack/terraform. DO NOT EDIT.
+
+locals {
+  spec = {
+    for k, v in {
+      backupMethod     = var.backup_method
+      backupPolicyName = var.backup_policy_name
+      deletionPolicy   = var.deletion_policy
+      parentBackupName = var.parent_backup_name
+      retentionPeriod  = var.retention_period
+    } : k => v if v != null
+  }
+}
+
+resource "kubernetes_manifest" "backup" {
+  manifest = {
+    apiVersion = "dataprotection.kubeblocks.io/v1alpha1"
+    kind       = "Backup"
+    metadata = {
+      name        = var.name
+      namespace   = var.namespace
+      labels      = var.labels
+      annotations = var.annotations
+    }
+    spec = local.spec
+  }
+
+  dynamic "wait" {
+    for_each = var.wait_for_ready ? [1] : []
+    content {
+      fields = {
+        "status.phase" = "^(Completed|Failed)$"
+      }
+    }
+  }
+
+  lifecycle {
+    postcondition {
+      condition     = !var.wait_for_ready || contains(["Completed"], try(self.object.status.phase, ""))
+      error_message = "The Backup is not ready, see the status of the Backup for the details."
+    }
+  }
+
+  timeouts {
+    create = var.timeouts.create
+    update = var.timeouts.update
+    delete = var.timeouts.delete
+  }
+}
@@ -0,0 +1,16 @@
+# Code generated by hack/terraform. DO NOT EDIT.
+
+output "name" {
+  description = "The name of the Backup."
+  value       = kubernetes_manifest.backup.manifest.metadata.name
+}
+
+output "namespace" {
+  description = "The namespace of the Backup."
+  value       = kubernetes_manifest.backup.manifest.metadata.namespace
+}
+
+output "status" {
+  description = "The status of the Backup."
+  value       = try(kubernetes_manifest.backup.object.status, null)
+}
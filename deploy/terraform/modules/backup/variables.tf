@@ -0,0 +1,68 @@
+# Code generated by hack/terraform. DO NOT EDIT.
+
+variable "name" {
+  description = "The name of the Backup."
+  type        = string
+}
+
+variable "namespace" {
+  description = "The namespace of the Backup."
+  type        = string
+  default     = "default"
+}
+
+variable "labels" {
+  description = "The labels of the Backup."
+  type        = map(string)
+  default     = {}
+}
+
+variable "annotations" {
+  description = "The annotations of the Backup."
+  type        = map(string)
+  default     = {}
+}
+
+variable "wait_for_ready" {
+  description = "Whether to wait until the phase of the Backup is settled, the apply fails unless the phase is one of Completed."
+  type        = bool
+  default     = true
+}
+
+variable "timeouts" {
+  description = "The timeouts to create, update and delete the Backup."
+  type = object({
+    create = optional(string, "30m")
+    update = optional(string, "30m")
+    delete = optional(string, "30m")
+  })
+  default = {}
+}
+
+variable "backup_method" {
+  description = "Specifies the backup method name that is defined in the backup policy."
+  type        = any
+}
+
+variable "backup_policy_name" {
+  description = "Specifies the backup policy to be applied for this backup."
+  type        = any
+}
+
+variable "deletion_policy" {
+  description = "Determines whether the backup contents stored in the backup repository should be deleted when the backup custom resource(CR) is deleted."
+  type        = any
+  default     = null
+}
+
+variable "parent_backup_name" {
+  description = "Determines the parent backup name for incremental or differential backup."
+  type        = any
+  default     = null
+}
+
+variable "retention_period" {
+  description = "Determines a duration up to which the backup should be kept."
+  type        = any
+  default     = null
+}
@@ -0,0 +1,67 @@
+# Code generated by hack/terraform. DO NOT EDIT.
+
+locals {
+  spec = {
+    for k, v in {
+      affinity             = var.affinity
+      availabilityPolicy   = var.availability_policy
+      backup               = var.backup
+      cloudTags            = var.cloud_tags
+      clusterDefinitionRef = var.cluster_definition_ref
+      clusterVersionRef    = var.cluster_version_ref
+      componentSpecs       = var.component_specs
+      disruptionWindows    = var.disruption_windows
+      ipStack              = var.ip_stack
+      maxOpsRequestHistory = var.max_ops_request_history
+      network              = var.network
+      replicas             = var.replicas
+      resources            = var.resources
+      runtimeClassName     = var.runtime_class_name
+      schedulingPolicy     = var.scheduling_policy
+      services             = var.services
+      shardingSpecs        = var.sharding_specs
+      storage              = var.storage
+      tenancy              = var.tenancy
+      terminationPolicy    = var.termination_policy
+      tolerations          = var.tolerations
+      topology             = var.topology
+      upgradePolicy        = var.upgrade_policy
+    } : k => v if v != null
+  }
+}
+
+resource "kubernetes_manifest" "cluster" {
+  manifest = {
+    apiVersion = "apps.kubeblocks.io/v1alpha1"
+    kind       = "Cluster"
+    metadata = {
+      name        = var.name
+      namespace   = var.namespace
+      labels      = var.labels
+      annotations = var.annotations
+    }
+    spec = local.spec
+  }
+
+  dynamic "wait" {
+    for_each = var.wait_for_ready ? [1] : []
+    content {
+      fields = {
+        "status.phase" = "^(Running|Stopped|Failed)$"
+      }
+    }
+  }
+
+  lifecycle {
+    postcondition {
+      condition     = !var.wait_for_ready || contains(["Running", "Stopped"], try(self.object.status.phase, ""))
+      error_message = "The Cluster is not ready, see the status of the Cluster for the details."
+    }
+  }
+
+  timeouts {
+    create = var.timeouts.create
+    update = var.timeouts.update
+    delete = var.timeouts.delete
+  }
+}
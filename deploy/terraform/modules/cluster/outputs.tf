@@ -0,0 +1,16 @@
+# Code generated by hack/terraform. DO NOT EDIT.
+
+output "name" {
+  description = "The name of the Cluster."
+  value       = kubernetes_manifest.cluster.manifest.metadata.name
+}
+
+output "namespace" {
+  description = "The namespace of the Cluster."
+  value       = kubernetes_manifest.cluster.manifest.metadata.namespace
+}
+
+output "status" {
+  description = "The status of the Cluster."
+  value       = try(kubernetes_manifest.cluster.object.status, null)
+}
@@ -0,0 +1,177 @@
+# Code generated by hack/terraform. DO NOT EDIT.
+
+variable "name" {
+  description = "The name of the Cluster."
+  type        = string
+}
+
+variable "namespace" {
+  description = "The namespace of the Cluster."
+  type        = string
+  default     = "default"
+}
+
+variable "labels" {
+  description = "The labels of the Cluster."
+  type        = map(string)
+  default     = {}
+}
+
+variable "annotations" {
+  description = "The annotations of the Cluster."
+  type        = map(string)
+  default     = {}
+}
+
+variable "wait_for_ready" {
+  description = "Whether to wait until the phase of the Cluster is settled, the apply fails unless the phase is one of Running, Stopped."
+  type        = bool
+  default     = true
+}
+
+variable "timeouts" {
+  description = "The timeouts to create, update and delete the Cluster."
+  type = object({
+    create = optional(string, "30m")
+    update = optional(string, "30m")
+    delete = optional(string, "30m")
+  })
+  default = {}
+}
+
+variable "affinity" {
+  description = "Defines a set of node affinity scheduling rules for the Cluster's Pods."
+  type        = any
+  default     = null
+}
+
+variable "availability_policy" {
+  description = "Describes the availability policy, including zone, node, and none."
+  type        = any
+  default     = null
+}
+
+variable "backup" {
+  description = "Specifies the backup configuration of the Cluster."
+  type        = any
+  default     = null
+}
+
+variable "cloud_tags" {
+  description = "Specifies the tags to be propagated to the cloud resources created indirectly for the Cluster, such as the volumes provisioned for the PVCs and the load balancers provisioned for the Services."
+  type        = any
+  default     = null
+}
+
+variable "cluster_definition_ref" {
+  description = "Specifies the name of the ClusterDefinition to use when creating a Cluster."
+  type        = any
+  default     = null
+}
+
+variable "cluster_version_ref" {
+  description = "Refers to the ClusterVersion name."
+  type        = any
+  default     = null
+}
+
+variable "component_specs" {
+  description = "Specifies a list of ClusterComponentSpec objects used to define the individual Components that make up a Cluster."
+  type        = any
+  default     = null
+}
+
+variable "disruption_windows" {
+  description = "Specifies the time windows in which the non-urgent disruptive actions are allowed to execute, such as the rolling updates of the instances and the OpsRequests created by KubeBlocks automatically."
+  type        = any
+  default     = null
+}
+
+variable "ip_stack" {
+  description = "Specifies the IP families of the Services of the Cluster, to support the IPv6 and dual-stack networks."
+  type        = any
+  default     = null
+}
+
+variable "max_ops_request_history" {
+  description = "Specifies the maximum number of the finished OpsRequests, i.e. those in \"Succeed\", \"Failed\", \"Cancelled\" or \"Aborted\" phase, to retain for the Cluster."
+  type        = any
+  default     = null
+}
+
+variable "network" {
+  description = "The configuration of network."
+  type        = any
+  default     = null
+}
+
+variable "replicas" {
+  description = "Specifies the replicas of the first componentSpec, if the replicas of the first componentSpec is specified, this value will be ignored."
+  type        = any
+  default     = null
+}
+
+variable "resources" {
+  description = "Specifies the resources of the first componentSpec, if the resources of the first componentSpec is specified, this value will be ignored."
+  type        = any
+  default     = null
+}
+
+variable "runtime_class_name" {
+  description = "Specifies runtimeClassName for all Pods managed by this Cluster."
+  type        = any
+  default     = null
+}
+
+variable "scheduling_policy" {
+  description = "Specifies the scheduling policy for the Cluster."
+  type        = any
+  default     = null
+}
+
+variable "services" {
+  description = "Defines a list of additional Services that are exposed by a Cluster."
+  type        = any
+  default     = null
+}
+
+variable "sharding_specs" {
+  description = "Specifies a list of ShardingSpec objects that manage the sharding topology for Cluster Components."
+  type        = any
+  default     = null
+}
+
+variable "storage" {
+  description = "Specifies the storage of the first componentSpec, if the storage of the first componentSpec is specified, this value will be ignored."
+  type        = any
+  default     = null
+}
+
+variable "tenancy" {
+  description = "Describes how Pods are distributed across node."
+  type        = any
+  default     = null
+}
+
+variable "termination_policy" {
+  description = "Specifies the behavior when a Cluster is deleted."
+  type        = any
+}
+
+variable "tolerations" {
+  description = "An array that specifies tolerations attached to the Cluster's Pods, allowing them to be scheduled onto nodes with matching taints."
+  type        = any
+  default     = null
+}
+
+variable "topology" {
+  description = "Specifies the name of the ClusterTopology to be used when creating the Cluster."
+  type        = any
+  default     = null
+}
+
+variable "upgrade_policy" {
+  description = "Specifies the policy for automatically upgrading the service versions of the components."
+  type        = any
+  default     = null
+}
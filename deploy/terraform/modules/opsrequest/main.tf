@@ -0,0 +1,87 @@
+# Code generated by hack/terraform. DO NOT EDIT.
+
+locals {
+  spec = {
+    for k, v in {
+      approvalRequired                      = var.approval_required
+      autoStartAfterSeconds                 = var.auto_start_after_seconds
+      backup                                = var.backup
+      backupSpec                            = var.backup_spec
+      cancel                                = var.cancel
+      clusterName                           = var.cluster_name
+      clusterRef                            = var.cluster_ref
+      custom                                = var.custom
+      dryRun                                = var.dry_run
+      enqueueOnForce                        = var.enqueue_on_force
+      executionOrder                        = var.execution_order
+      expose                                = var.expose
+      external                              = var.external
+      failurePolicy                         = var.failure_policy
+      force                                 = var.force
+      horizontalScaling                     = var.horizontal_scaling
+      idempotencyKey                        = var.idempotency_key
+      preConditionDeadlineSeconds           = var.pre_condition_deadline_seconds
+      purgeOfflineInstances                 = var.purge_offline_instances
+      rebalance                             = var.rebalance
+      rebuildFrom                           = var.rebuild_from
+      reconfigure                           = var.reconfigure
+      reconfigures                          = var.reconfigures
+      restart                               = var.restart
+      restore                               = var.restore
+      restoreSpec                           = var.restore_spec
+      retryPolicy                           = var.retry_policy
+      rollback                              = var.rollback
+      schedulingPolicy                      = var.scheduling_policy
+      scriptSpec                            = var.script_spec
+      shardingConversion                    = var.sharding_conversion
+      start                                 = var.start
+      stop                                  = var.stop
+      switchover                            = var.switchover
+      timeoutSeconds                        = var.timeout_seconds
+      ttlSecondsAfterFinished               = var.ttl_seconds_after_finished
+      ttlSecondsAfterSucceed                = var.ttl_seconds_after_succeed
+      ttlSecondsAfterUnsuccessfulCompletion = var.ttl_seconds_after_unsuccessful_completion
+      type                                  = var.type
+      upgrade                               = var.upgrade
+      verticalScaling                       = var.vertical_scaling
+      volumeExpansion                       = var.volume_expansion
+      volumeTuning                          = var.volume_tuning
+    } : k => v if v != null
+  }
+}
+
+resource "kubernetes_manifest" "opsrequest" {
+  manifest = {
+    apiVersion = "apps.kubeblocks.io/v1alpha1"
+    kind       = "OpsRequest"
+    metadata = {
+      name        = var.name
+      namespace   = var.namespace
+      labels      = var.labels
+      annotations = var.annotations
+    }
+    spec = local.spec
+  }
+
+  dynamic "wait" {
+    for_each = var.wait_for_ready ? [1] : []
+    content {
+      fields = {
+        "status.phase" = "^(Succeed|Failed|Cancelled|Aborted)$"
+      }
+    }
+  }
+
+  lifecycle {
+    postcondition {
+      condition     = !var.wait_for_ready || contains(["Succeed"], try(self.object.status.phase, ""))
+      error_message = "The OpsRequest is not ready, see the status of the OpsRequest for the details."
+    }
+  }
+
+  timeouts {
+    create = var.timeouts.create
+    update = var.timeouts.update
+    delete = var.timeouts.delete
+  }
+}
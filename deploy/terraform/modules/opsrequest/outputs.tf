@@ -0,0 +1,16 @@
+# Code generated by hack/terraform. DO NOT EDIT.
+
+output "name" {
+  description = "The name of the OpsRequest."
+  value       = kubernetes_manifest.opsrequest.manifest.metadata.name
+}
+
+output "namespace" {
+  description = "The namespace of the OpsRequest."
+  value       = kubernetes_manifest.opsrequest.manifest.metadata.namespace
+}
+
+output "status" {
+  description = "The status of the OpsRequest."
+  value       = try(kubernetes_manifest.opsrequest.object.status, null)
+}
@@ -0,0 +1,297 @@
+# Code generated by hack/terraform. DO NOT EDIT.
+
+variable "name" {
+  description = "The name of the OpsRequest."
+  type        = string
+}
+
+variable "namespace" {
+  description = "The namespace of the OpsRequest."
+  type        = string
+  default     = "default"
+}
+
+variable "labels" {
+  description = "The labels of the OpsRequest."
+  type        = map(string)
+  default     = {}
+}
+
+variable "annotations" {
+  description = "The annotations of the OpsRequest."
+  type        = map(string)
+  default     = {}
+}
+
+variable "wait_for_ready" {
+  description = "Whether to wait until the phase of the OpsRequest is settled, the apply fails unless the phase is one of Succeed."
+  type        = bool
+  default     = true
+}
+
+variable "timeouts" {
+  description = "The timeouts to create, update and delete the OpsRequest."
+  type = object({
+    create = optional(string, "30m")
+    update = optional(string, "30m")
+    delete = optional(string, "30m")
+  })
+  default = {}
+}
+
+variable "approval_required" {
+  description = "Indicates whether the OpsRequest needs to be approved before its action is applied."
+  type        = any
+  default     = null
+}
+
+variable "auto_start_after_seconds" {
+  description = "Specifies the seconds after which the Components stopped by the Stop OpsRequest are started automatically."
+  type        = any
+  default     = null
+}
+
+variable "backup" {
+  description = "Specifies the parameters to backup a Cluster."
+  type        = any
+  default     = null
+}
+
+variable "backup_spec" {
+  description = "Deprecated: since v0.9, use backup instead."
+  type        = any
+  default     = null
+}
+
+variable "cancel" {
+  description = "Indicates whether the current operation should be canceled and terminated gracefully if it's in the \"Pending\", \"Creating\", or \"Running\" state."
+  type        = any
+  default     = null
+}
+
+variable "cluster_name" {
+  description = "Specifies the name of the Cluster resource that this operation is targeting."
+  type        = any
+  default     = null
+}
+
+variable "cluster_ref" {
+  description = "Deprecated: since v0.9, use clusterName instead."
+  type        = any
+  default     = null
+}
+
+variable "custom" {
+  description = "Specifies a custom operation defined by OpsDefinition."
+  type        = any
+  default     = null
+}
+
+variable "dry_run" {
+  description = "Specifies whether to only preview the changes of the OpsRequest without mutating the Cluster."
+  type        = any
+  default     = null
+}
+
+variable "enqueue_on_force" {
+  description = "Indicates whether opsRequest should continue to queue when 'force' is set to true."
+  type        = any
+  default     = null
+}
+
+variable "execution_order" {
+  description = "Specifies the order to apply the changes to the Components of the \"HorizontalScaling\" and \"Restart\" OpsRequests."
+  type        = any
+  default     = null
+}
+
+variable "expose" {
+  description = "Lists Expose objects, each specifying a Component and its services to be exposed."
+  type        = any
+  default     = null
+}
+
+variable "external" {
+  description = "Specifies an operation handled by the webhook registered by an ExternalOpsHandler."
+  type        = any
+  default     = null
+}
+
+variable "failure_policy" {
+  description = "Specifies how the transient errors, such as API conflicts, are retried while the OpsRequest is executing its action or reconciling its progress."
+  type        = any
+  default     = null
+}
+
+variable "force" {
+  description = "Instructs the system to bypass pre-checks (including cluster state checks and customized pre-conditions hooks) and immediately execute the opsRequest, except for the opsRequest of 'Start' type, which will still undergo pre-checks even if `force` is true."
+  type        = any
+  default     = null
+}
+
+variable "horizontal_scaling" {
+  description = "Lists HorizontalScaling objects, each specifying scaling requirements for a Component, including desired replica changes, configurations for new instances, modifications for existing instances, and take offline/online the specified instances."
+  type        = any
+  default     = null
+}
+
+variable "idempotency_key" {
+  description = "Specifies a key to deduplicate the OpsRequests submitted by automation, e.g. when a request is retried."
+  type        = any
+  default     = null
+}
+
+variable "pre_condition_deadline_seconds" {
+  description = "Specifies the maximum time in seconds that the OpsRequest will wait for its pre-conditions to be met before it aborts the operation."
+  type        = any
+  default     = null
+}
+
+variable "purge_offline_instances" {
+  description = "Lists the Components whose retained PVCs of the offline instances are to be deleted."
+  type        = any
+  default     = null
+}
+
+variable "rebalance" {
+  description = "Lists the shardings to rebalance the data among the shards, e.g. after the shards are scaled out."
+  type        = any
+  default     = null
+}
+
+variable "rebuild_from" {
+  description = "Specifies the parameters to rebuild some instances."
+  type        = any
+  default     = null
+}
+
+variable "reconfigure" {
+  description = "Specifies a component and its configuration updates."
+  type        = any
+  default     = null
+}
+
+variable "reconfigures" {
+  description = "Lists Reconfigure objects, each specifying a Component and its configuration updates."
+  type        = any
+  default     = null
+}
+
+variable "restart" {
+  description = "Lists Components to be restarted."
+  type        = any
+  default     = null
+}
+
+variable "restore" {
+  description = "Specifies the parameters to restore a Cluster."
+  type        = any
+  default     = null
+}
+
+variable "restore_spec" {
+  description = "Deprecated: since v0.9, use restore instead."
+  type        = any
+  default     = null
+}
+
+variable "retry_policy" {
+  description = "Specifies how failed Component actions are retried before the whole OpsRequest is marked as Failed."
+  type        = any
+  default     = null
+}
+
+variable "rollback" {
+  description = "Specifies the parameters to roll back the changes of a succeeded OpsRequest."
+  type        = any
+  default     = null
+}
+
+variable "scheduling_policy" {
+  description = "Specifies the scheduling policy of the OpsRequest, e.g. the maintenance window in which it is allowed to begin."
+  type        = any
+  default     = null
+}
+
+variable "script_spec" {
+  description = "Specifies the image and scripts for executing engine-specific operations such as creating databases or users."
+  type        = any
+  default     = null
+}
+
+variable "sharding_conversion" {
+  description = "Specifies the parameters to convert a standalone Component into a sharding of the same engine."
+  type        = any
+  default     = null
+}
+
+variable "start" {
+  description = "Lists Components to be started."
+  type        = any
+  default     = null
+}
+
+variable "stop" {
+  description = "Lists Components to be stopped."
+  type        = any
+  default     = null
+}
+
+variable "switchover" {
+  description = "Lists Switchover objects, each specifying a Component to perform the switchover operation."
+  type        = any
+  default     = null
+}
+
+variable "timeout_seconds" {
+  description = "Specifies the maximum duration (in seconds) that an opsRequest is allowed to run."
+  type        = any
+  default     = null
+}
+
+variable "ttl_seconds_after_finished" {
+  description = "Specifies the duration in seconds that an OpsRequest will remain in the system after it finishes in any phase (\"Succeed\", \"Failed\", \"Cancelled\" or \"Aborted\") before automatic deletion."
+  type        = any
+  default     = null
+}
+
+variable "ttl_seconds_after_succeed" {
+  description = "Specifies the duration in seconds that an OpsRequest will remain in the system after successfully completing (when `opsRequest.status.phase` is \"Succeed\") before automatic deletion."
+  type        = any
+  default     = null
+}
+
+variable "ttl_seconds_after_unsuccessful_completion" {
+  description = "Specifies the duration in seconds that an OpsRequest will remain in the system after completion for any phase other than \"Succeed\" (e.g., \"Failed\", \"Cancelled\", \"Aborted\") before automatic deletion."
+  type        = any
+  default     = null
+}
+
+variable "type" {
+  description = "Specifies the type of this operation."
+  type        = any
+}
+
+variable "upgrade" {
+  description = "Specifies the desired new version of the Cluster."
+  type        = any
+  default     = null
+}
+
+variable "vertical_scaling" {
+  description = "Lists VerticalScaling objects, each specifying a component and its desired compute resources for vertical scaling."
+  type        = any
+  default     = null
+}
+
+variable "volume_expansion" {
+  description = "Lists VolumeExpansion objects, each specifying a component and its corresponding volumeClaimTemplates that requires storage expansion."
+  type        = any
+  default     = null
+}
+
+variable "volume_tuning" {
+  description = "Lists VolumeTuning objects, each specifying a Component and the desired performance of its volumeClaimTemplates."
+  type        = any
+  default     = null
+}
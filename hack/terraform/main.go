@@ -0,0 +1,346 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// module describes a Terraform module to generate for a KubeBlocks API.
+type module struct {
+	// the file name of the CRD in the CRD directory
+	crd string
+	// the name of the generated module
+	name string
+	// the phases the object settles in, the wait ends once the object reaches any of them
+	finalPhases []string
+	// the phases of the settled object which are ready, the others fail the apply
+	readyPhases []string
+}
+
+var modules = []module{
+	{
+		crd:  "apps.kubeblocks.io_clusters.yaml",
+		name: "cluster",
+		// the cluster is stopped if all the components are stopped in the spec
+		finalPhases: []string{"Running", "Stopped", "Failed"},
+		readyPhases: []string{"Running", "Stopped"},
+	},
+	{
+		crd:         "apps.kubeblocks.io_opsrequests.yaml",
+		name:        "opsrequest",
+		finalPhases: []string{"Succeed", "Failed", "Cancelled", "Aborted"},
+		readyPhases: []string{"Succeed"},
+	},
+	{
+		crd:         "dataprotection.kubeblocks.io_backups.yaml",
+		name:        "backup",
+		finalPhases: []string{"Completed", "Failed"},
+		readyPhases: []string{"Completed"},
+	},
+}
+
+type specField struct {
+	Name        string
+	Padding     string
+	Variable    string
+	Description string
+	Required    bool
+}
+
+type moduleData struct {
+	Name        string
+	APIVersion  string
+	Kind        string
+	Fields      []specField
+	FinalPhases string
+	ReadyPhases []string
+}
+
+const versionsTF = `# Code generated by hack/terraform. DO NOT EDIT.
+
+terraform {
+  required_version = ">= 1.3"
+  required_providers {
+    kubernetes = {
+      source  = "hashicorp/kubernetes"
+      version = ">= 2.23"
+    }
+  }
+}
+`
+
+const variablesTF = `# Code generated by hack/terraform. DO NOT EDIT.
+
+variable "name" {
+  description = "The name of the {{ .Kind }}."
+  type        = string
+}
+
+variable "namespace" {
+  description = "The namespace of the {{ .Kind }}."
+  type        = string
+  default     = "default"
+}
+
+variable "labels" {
+  description = "The labels of the {{ .Kind }}."
+  type        = map(string)
+  default     = {}
+}
+
+variable "annotations" {
+  description = "The annotations of the {{ .Kind }}."
+  type        = map(string)
+  default     = {}
+}
+
+variable "wait_for_ready" {
+  description = "Whether to wait until the phase of the {{ .Kind }} is settled, the apply fails unless the phase is one of {{ range $i, $p := .ReadyPhases }}{{ if $i }}, {{ end }}{{ $p }}{{ end }}."
+  type        = bool
+  default     = true
+}
+
+variable "timeouts" {
+  description = "The timeouts to create, update and delete the {{ .Kind }}."
+  type = object({
+    create = optional(string, "30m")
+    update = optional(string, "30m")
+    delete = optional(string, "30m")
+  })
+  default = {}
+}
+{{ range .Fields }}
+variable "{{ .Variable }}" {
+  description = {{ printf "%q" .Description }}
+  type        = any
+{{- if not .Required }}
+  default     = null
+{{- end }}
+}
+{{ end -}}
+`
+
+const mainTF = `# Code generated by hack/terraform. DO NOT EDIT.
+
+locals {
+  spec = {
+    for k, v in {
+{{- range .Fields }}
+      {{ .Name }}{{ .Padding }} = var.{{ .Variable }}
+{{- end }}
+    } : k => v if v != null
+  }
+}
+
+resource "kubernetes_manifest" "{{ .Name }}" {
+  manifest = {
+    apiVersion = "{{ .APIVersion }}"
+    kind       = "{{ .Kind }}"
+    metadata = {
+      name        = var.name
+      namespace   = var.namespace
+      labels      = var.labels
+      annotations = var.annotations
+    }
+    spec = local.spec
+  }
+
+  dynamic "wait" {
+    for_each = var.wait_for_ready ? [1] : []
+    content {
+      fields = {
+        "status.phase" = "^({{ .FinalPhases }})$"
+      }
+    }
+  }
+
+  lifecycle {
+    postcondition {
+      condition     = !var.wait_for_ready || contains([{{ range $i, $p := .ReadyPhases }}{{ if $i }}, {{ end }}"{{ $p }}"{{ end }}], try(self.object.status.phase, ""))
+      error_message = "The {{ .Kind }} is not ready, see the status of the {{ .Kind }} for the details."
+    }
+  }
+
+  timeouts {
+    create = var.timeouts.create
+    update = var.timeouts.update
+    delete = var.timeouts.delete
+  }
+}
+`
+
+const outputsTF = `# Code generated by hack/terraform. DO NOT EDIT.
+
+output "name" {
+  description = "The name of the {{ .Kind }}."
+  value       = kubernetes_manifest.{{ .Name }}.manifest.metadata.name
+}
+
+output "namespace" {
+  description = "The namespace of the {{ .Kind }}."
+  value       = kubernetes_manifest.{{ .Name }}.manifest.metadata.namespace
+}
+
+output "status" {
+  description = "The status of the {{ .Kind }}."
+  value       = try(kubernetes_manifest.{{ .Name }}.object.status, null)
+}
+`
+
+func main() {
+	var crdDir, outDir string
+	flag.StringVar(&crdDir, "crd-dir", "config/crd/bases", "the directory of the CRDs")
+	flag.StringVar(&outDir, "out-dir", "deploy/terraform/modules", "the directory to write the Terraform modules")
+	flag.Parse()
+
+	for _, m := range modules {
+		data, err := buildModuleData(filepath.Join(crdDir, m.crd), m)
+		if err != nil {
+			log.Fatalf("build module %s failed: %v", m.name, err)
+		}
+		if err = writeModule(filepath.Join(outDir, m.name), data); err != nil {
+			log.Fatalf("write module %s failed: %v", m.name, err)
+		}
+	}
+}
+
+func buildModuleData(path string, m module) (*moduleData, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err = yaml.Unmarshal(content, crd); err != nil {
+		return nil, err
+	}
+
+	var version *apiextensionsv1.CustomResourceDefinitionVersion
+	for i, v := range crd.Spec.Versions {
+		if v.Storage {
+			version = &crd.Spec.Versions[i]
+		}
+	}
+	if version == nil || version.Schema == nil || version.Schema.OpenAPIV3Schema == nil {
+		return nil, fmt.Errorf("no storage version with schema found in %s", path)
+	}
+	spec, ok := version.Schema.OpenAPIV3Schema.Properties["spec"]
+	if !ok {
+		return nil, fmt.Errorf("no spec found in %s", path)
+	}
+
+	required := map[string]bool{}
+	for _, name := range spec.Required {
+		required[name] = true
+	}
+	data := &moduleData{
+		Name:        m.name,
+		APIVersion:  fmt.Sprintf("%s/%s", crd.Spec.Group, version.Name),
+		Kind:        crd.Spec.Names.Kind,
+		FinalPhases: strings.Join(m.finalPhases, "|"),
+		ReadyPhases: m.readyPhases,
+	}
+	for name, prop := range spec.Properties {
+		data.Fields = append(data.Fields, specField{
+			Name:        name,
+			Variable:    toSnakeCase(name),
+			Description: firstSentence(prop.Description),
+			Required:    required[name],
+		})
+	}
+	sort.Slice(data.Fields, func(i, j int) bool { return data.Fields[i].Name < data.Fields[j].Name })
+	// align the assignments as terraform fmt does
+	width := 0
+	for _, f := range data.Fields {
+		width = max(width, len(f.Name))
+	}
+	for i := range data.Fields {
+		data.Fields[i].Padding = strings.Repeat(" ", width-len(data.Fields[i].Name))
+	}
+	return data, nil
+}
+
+func writeModule(dir string, data *moduleData) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	files := map[string]string{
+		"versions.tf":  versionsTF,
+		"variables.tf": variablesTF,
+		"main.tf":      mainTF,
+		"outputs.tf":   outputsTF,
+	}
+	for name, text := range files {
+		tpl, err := template.New(name).Parse(text)
+		if err != nil {
+			return err
+		}
+		buf := &bytes.Buffer{}
+		if err = tpl.Execute(buf, data); err != nil {
+			return err
+		}
+		if err = os.WriteFile(filepath.Join(dir, name), buf.Bytes(), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toSnakeCase converts the camel case name of the field to the snake case name of the variable.
+func toSnakeCase(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteRune('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// firstSentence returns the first sentence of the description, joined into a single line.
+func firstSentence(description string) string {
+	description = strings.Join(strings.Fields(description), " ")
+	for start := 0; ; {
+		i := strings.Index(description[start:], ". ")
+		if i < 0 {
+			return description
+		}
+		end := start + i + 1
+		// the abbreviations don't end the sentence
+		if !strings.HasSuffix(description[:end], "i.e.") && !strings.HasSuffix(description[:end], "e.g.") {
+			return description[:end]
+		}
+		start = end
+	}
+}
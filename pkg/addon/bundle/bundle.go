@@ -0,0 +1,465 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package bundle defines the offline bundle of addons, which packs the charts, the addon definitions and the list
+// of images required, so that the addons can be installed in the air-gapped environments.
+//
+// A bundle is a gzipped tarball with the following layout:
+//
+//	bundle.yaml          the manifest of the bundle, which records the digests of all the other files
+//	charts/*.tgz         the Helm charts of the addons
+//	definitions/*.yaml   the Addon objects
+//
+// The manifest is the first file of the tarball, and its digest is published along with the bundle, e.g. in the
+// release notes. The bundle is loaded only if the digest of the manifest matches the published one, and all the
+// other files are verified against the digests in the manifest while they are extracted, so that a bundle tampered
+// with is rejected. The images are not packed in the bundle, they are pinned by digest in the manifest instead.
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	extensionsv1alpha1 "github.com/apecloud/kubeblocks/apis/extensions/v1alpha1"
+)
+
+const (
+	APIVersion = "bundle.kubeblocks.io/v1"
+
+	ManifestFile   = "bundle.yaml"
+	ChartsDir      = "charts"
+	DefinitionsDir = "definitions"
+
+	digestPrefix = "sha256:"
+
+	// maxManifestSize limits the size of the manifest.
+	maxManifestSize = 1 << 20
+	// maxFileSize limits the size of a single file in the bundle.
+	maxFileSize = 1 << 30
+	// maxTotalSize limits the total size of the files extracted from the bundle.
+	maxTotalSize = 4 << 30
+	// maxFiles limits the number of the files in the bundle.
+	maxFiles = 1024
+)
+
+// Manifest describes the content of a bundle.
+type Manifest struct {
+	APIVersion string `json:"apiVersion"`
+	Name       string `json:"name"`
+	Version    string `json:"version,omitempty"`
+
+	// ChartsImage is the image that contains the charts of the bundle, it is used to install the addons
+	// from the local charts, see HelmTypeInstallSpec.ChartsImage. It must be pinned by digest.
+	ChartsImage string `json:"chartsImage,omitempty"`
+
+	// Images lists the images required by the addons, which should be pushed to the private registry before installing.
+	// They must be pinned by digest, so that the images pushed can be verified by the container runtime when pulling.
+	Images []string `json:"images,omitempty"`
+
+	// Files records the digests of the files in the bundle, keyed by the path of the file.
+	Files map[string]string `json:"files"`
+}
+
+// Bundle is a loaded and verified bundle, the files of which are extracted to a local directory.
+type Bundle struct {
+	Manifest Manifest
+	dir      string
+}
+
+// Digest returns the digest of the content.
+func Digest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return digestPrefix + hex.EncodeToString(sum[:])
+}
+
+func digestOf(h hash.Hash) string {
+	return digestPrefix + hex.EncodeToString(h.Sum(nil))
+}
+
+// Write packs the files under the directory into a bundle, the files are expected to be laid out as in the bundle.
+// The digests of the files are computed and recorded in the manifest, and the digest of the manifest is returned,
+// which should be published along with the bundle.
+func Write(w io.Writer, manifest Manifest, dir string) (string, error) {
+	names, err := listFiles(dir)
+	if err != nil {
+		return "", err
+	}
+	if len(names) > maxFiles {
+		return "", fmt.Errorf("too many files in bundle, at most %d files are allowed", maxFiles)
+	}
+	if err = validateImages(manifest); err != nil {
+		return "", err
+	}
+
+	manifest.APIVersion = APIVersion
+	manifest.Files = make(map[string]string, len(names))
+	for _, name := range names {
+		if manifest.Files[name], err = fileDigest(filepath.Join(dir, filepath.FromSlash(name))); err != nil {
+			return "", err
+		}
+	}
+	data, err := yaml.Marshal(manifest)
+	if err != nil {
+		return "", err
+	}
+	if len(data) > maxManifestSize {
+		return "", fmt.Errorf("%s of bundle is too large", ManifestFile)
+	}
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	// the manifest goes first, so that the files can be verified while they are read.
+	if err = tw.WriteHeader(&tar.Header{Name: ManifestFile, Mode: 0644, Size: int64(len(data))}); err != nil {
+		return "", err
+	}
+	if _, err = tw.Write(data); err != nil {
+		return "", err
+	}
+	for _, name := range names {
+		if err = writeFile(tw, name, filepath.Join(dir, filepath.FromSlash(name))); err != nil {
+			return "", err
+		}
+	}
+	if err = tw.Close(); err != nil {
+		return "", err
+	}
+	if err = gw.Close(); err != nil {
+		return "", err
+	}
+	return Digest(data), nil
+}
+
+func listFiles(dir string) ([]string, error) {
+	var names []string
+	for _, sub := range []string{ChartsDir, DefinitionsDir} {
+		entries, err := os.ReadDir(filepath.Join(dir, sub))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		for _, entry := range entries {
+			name := path.Join(sub, entry.Name())
+			if !entry.Type().IsRegular() {
+				return nil, fmt.Errorf("unexpected file %s in bundle, only the regular files are allowed", name)
+			}
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func fileDigest(file string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return digestOf(h), nil
+}
+
+func writeFile(tw *tar.Writer, name, file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() > maxFileSize {
+		return fmt.Errorf("file %s in bundle is too large", name)
+	}
+	if err = tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: info.Size()}); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// LoadFile loads the bundle from a file, see Load.
+func LoadFile(file, dir, manifestDigest string) (*Bundle, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Load(f, dir, manifestDigest)
+}
+
+// Load extracts the bundle to the directory, the manifest is verified against the digest published along with
+// the bundle, and the files are verified against the digests in the manifest while they are extracted.
+func Load(r io.Reader, dir, manifestDigest string) (*Bundle, error) {
+	if !strings.HasPrefix(manifestDigest, digestPrefix) {
+		return nil, fmt.Errorf("the digest of %s is required to load the bundle, in the format of %s<hex>", ManifestFile, digestPrefix)
+	}
+
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gr.Close()
+	tr := tar.NewReader(gr)
+
+	bundle := &Bundle{dir: dir}
+	if err = bundle.loadManifest(tr, manifestDigest); err != nil {
+		return nil, err
+	}
+
+	extracted := map[string]bool{}
+	var total int64
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag == tar.TypeDir {
+			continue
+		}
+		if header.Typeflag != tar.TypeReg {
+			return nil, fmt.Errorf("unsupported file type of %s in bundle", header.Name)
+		}
+		name := path.Clean(header.Name)
+		if err = validatePath(name); err != nil {
+			return nil, err
+		}
+		digest, ok := bundle.Manifest.Files[name]
+		if !ok {
+			return nil, fmt.Errorf("file %s is not recorded in %s", name, ManifestFile)
+		}
+		if extracted[name] {
+			return nil, fmt.Errorf("duplicated file %s in bundle", name)
+		}
+		if header.Size > maxFileSize {
+			return nil, fmt.Errorf("file %s in bundle is too large", name)
+		}
+		if total += header.Size; total > maxTotalSize {
+			return nil, fmt.Errorf("bundle is too large, at most %d bytes are allowed", int64(maxTotalSize))
+		}
+		if err = bundle.extract(tr, name, header.Size, digest); err != nil {
+			return nil, err
+		}
+		extracted[name] = true
+	}
+	for name := range bundle.Manifest.Files {
+		if !extracted[name] {
+			return nil, fmt.Errorf("file %s not found in bundle", name)
+		}
+	}
+	return bundle, nil
+}
+
+// loadManifest reads the manifest, which is expected to be the first file of the bundle.
+func (b *Bundle) loadManifest(tr *tar.Reader, manifestDigest string) error {
+	header, err := tr.Next()
+	if err != nil {
+		return fmt.Errorf("failed to read %s of bundle: %s", ManifestFile, err.Error())
+	}
+	if path.Clean(header.Name) != ManifestFile || header.Typeflag != tar.TypeReg {
+		return fmt.Errorf("%s is expected to be the first file of bundle, but got %s", ManifestFile, header.Name)
+	}
+	if header.Size > maxManifestSize {
+		return fmt.Errorf("%s of bundle is too large", ManifestFile)
+	}
+	data, err := io.ReadAll(io.LimitReader(tr, maxManifestSize))
+	if err != nil {
+		return err
+	}
+	if actual := Digest(data); actual != manifestDigest {
+		return fmt.Errorf("digest of %s mismatched, expected: %s, actual: %s", ManifestFile, manifestDigest, actual)
+	}
+	if err = yaml.Unmarshal(data, &b.Manifest); err != nil {
+		return fmt.Errorf("invalid %s: %s", ManifestFile, err.Error())
+	}
+	if b.Manifest.APIVersion != APIVersion {
+		return fmt.Errorf("unsupported bundle version %s, %s expected", b.Manifest.APIVersion, APIVersion)
+	}
+	if len(b.Manifest.Files) > maxFiles {
+		return fmt.Errorf("too many files in bundle, at most %d files are allowed", maxFiles)
+	}
+	for name := range b.Manifest.Files {
+		if err = validatePath(name); err != nil {
+			return err
+		}
+	}
+	return validateImages(b.Manifest)
+}
+
+// extract streams the file to the directory through the hasher, the file is removed if the digest mismatches.
+func (b *Bundle) extract(r io.Reader, name string, size int64, digest string) (err error) {
+	file := b.path(name)
+	if err = os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			_ = os.Remove(file)
+		}
+	}()
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(f, h), io.LimitReader(r, size))
+	if err != nil {
+		return err
+	}
+	if n != size {
+		return fmt.Errorf("file %s in bundle is truncated", name)
+	}
+	if actual := digestOf(h); actual != digest {
+		return fmt.Errorf("digest of file %s mismatched, expected: %s, actual: %s", name, digest, actual)
+	}
+	return nil
+}
+
+func (b *Bundle) path(name string) string {
+	return filepath.Join(b.dir, filepath.FromSlash(name))
+}
+
+// Charts returns the local paths of the charts in the bundle, keyed by the file name of the chart.
+func (b *Bundle) Charts() map[string]string {
+	charts := map[string]string{}
+	for name := range b.Manifest.Files {
+		if dir, file := path.Split(name); dir == ChartsDir+"/" {
+			charts[file] = b.path(name)
+		}
+	}
+	return charts
+}
+
+// Addons returns the Addon objects defined in the bundle.
+func (b *Bundle) Addons() ([]*extensionsv1alpha1.Addon, error) {
+	var addons []*extensionsv1alpha1.Addon
+	for name := range b.Manifest.Files {
+		if dir, _ := path.Split(name); dir != DefinitionsDir+"/" {
+			continue
+		}
+		content, err := os.ReadFile(b.path(name))
+		if err != nil {
+			return nil, err
+		}
+		addon := &extensionsv1alpha1.Addon{}
+		if err = yaml.Unmarshal(content, addon); err != nil {
+			return nil, fmt.Errorf("invalid addon definition %s: %s", name, err.Error())
+		}
+		addons = append(addons, addon)
+	}
+	sort.Slice(addons, func(i, j int) bool { return addons[i].Name < addons[j].Name })
+	return addons, nil
+}
+
+// Localize rewrites the addon to install from the local charts of the bundle, with the charts image
+// pulled from the private registry.
+func (b *Bundle) Localize(addon *extensionsv1alpha1.Addon, registry string) error {
+	if addon.Spec.Helm == nil {
+		return nil
+	}
+	if len(b.Manifest.ChartsImage) == 0 {
+		return fmt.Errorf("no charts image specified in bundle %s", b.Manifest.Name)
+	}
+	chart := path.Base(addon.Spec.Helm.ChartLocationURL)
+	if _, ok := b.Charts()[chart]; !ok {
+		return fmt.Errorf("chart %s of addon %s not found in bundle", chart, addon.Name)
+	}
+	addon.Spec.Helm.ChartLocationURL = "file:///" + chart
+	addon.Spec.Helm.ChartsImage = RewriteImage(b.Manifest.ChartsImage, registry)
+	return nil
+}
+
+// Images returns the images required by the bundle, mapped to the ones in the private registry.
+func (b *Bundle) Images(registry string) map[string]string {
+	images := map[string]string{}
+	for _, image := range b.Manifest.Images {
+		images[image] = RewriteImage(image, registry)
+	}
+	if len(b.Manifest.ChartsImage) > 0 {
+		images[b.Manifest.ChartsImage] = RewriteImage(b.Manifest.ChartsImage, registry)
+	}
+	return images
+}
+
+// RewriteImage rewrites the registry of the image to the private registry, the tag and the digest are kept.
+func RewriteImage(image, registry string) string {
+	if len(registry) == 0 {
+		return image
+	}
+	repo := image
+	// the first component is the registry if it contains a "." or ":", or is "localhost"
+	if i := strings.Index(image, "/"); i > 0 {
+		if host := image[:i]; strings.ContainsAny(host, ".:") || host == "localhost" {
+			repo = image[i+1:]
+		}
+	}
+	return strings.TrimSuffix(registry, "/") + "/" + repo
+}
+
+func validatePath(name string) error {
+	if path.IsAbs(name) || name != path.Clean(name) || strings.HasPrefix(name, "../") || name == ".." {
+		return fmt.Errorf("invalid file path %s in bundle", name)
+	}
+	dir, _ := path.Split(name)
+	if dir != ChartsDir+"/" && dir != DefinitionsDir+"/" {
+		return fmt.Errorf("unexpected file %s in bundle, only the files under %s/ and %s/ are allowed", name, ChartsDir, DefinitionsDir)
+	}
+	return nil
+}
+
+// validateImages checks that the images are pinned by digest.
+func validateImages(manifest Manifest) error {
+	images := manifest.Images
+	if len(manifest.ChartsImage) > 0 {
+		images = append([]string{manifest.ChartsImage}, images...)
+	}
+	for _, image := range images {
+		_, digest, ok := strings.Cut(image, "@")
+		if !ok || !strings.HasPrefix(digest, digestPrefix) || len(digest) != len(digestPrefix)+sha256.Size*2 {
+			return fmt.Errorf("image %s in bundle is not pinned by digest", image)
+		}
+	}
+	return nil
+}
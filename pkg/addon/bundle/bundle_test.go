@@ -0,0 +1,254 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	extensionsv1alpha1 "github.com/apecloud/kubeblocks/apis/extensions/v1alpha1"
+)
+
+const mysqlAddon = `apiVersion: extensions.kubeblocks.io/v1alpha1
+kind: Addon
+metadata:
+  name: mysql
+spec:
+  type: Helm
+  helm:
+    chartLocationURL: https://jihulab.com/api/v4/projects/85949/packages/helm/stable/charts/mysql-0.9.0.tgz
+`
+
+const (
+	chartsImageDigest = "sha256:4f53cda18c2baa0c0354bb5f9a3ecbe5ed12ab4d8e11ba873c2f11161202b945"
+	mysqlImageDigest  = "sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+)
+
+func testFiles(t *testing.T) string {
+	dir := t.TempDir()
+	files := map[string]string{
+		"charts/mysql-0.9.0.tgz": "chart",
+		"definitions/mysql.yaml": mysqlAddon,
+	}
+	for name, content := range files {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func testManifest() Manifest {
+	return Manifest{
+		Name:        "kubeblocks-addons",
+		Version:     "0.9.0",
+		ChartsImage: "docker.io/apecloud/kubeblocks-charts:0.9.0@" + chartsImageDigest,
+		Images:      []string{"docker.io/apecloud/mysql:8.0.33@" + mysqlImageDigest},
+	}
+}
+
+func testBundle(t *testing.T) ([]byte, string) {
+	buf := &bytes.Buffer{}
+	digest, err := Write(buf, testManifest(), testFiles(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes(), digest
+}
+
+func TestLoad(t *testing.T) {
+	data, digest := testBundle(t)
+	bundle, err := Load(bytes.NewReader(data), t.TempDir(), digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bundle.Manifest.Files) != 2 || len(bundle.Manifest.Images) != 1 {
+		t.Errorf("unexpected manifest: %v", bundle.Manifest)
+	}
+	images := bundle.Images("registry.local:5000")
+	if images[testManifest().Images[0]] != "registry.local:5000/apecloud/mysql:8.0.33@"+mysqlImageDigest {
+		t.Errorf("unexpected images: %v", images)
+	}
+	chart, ok := bundle.Charts()["mysql-0.9.0.tgz"]
+	if !ok {
+		t.Fatalf("chart not found")
+	}
+	if content, err := os.ReadFile(chart); err != nil || string(content) != "chart" {
+		t.Errorf("unexpected chart extracted: %s, %v", content, err)
+	}
+
+	addons, err := bundle.Addons()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(addons) != 1 || addons[0].Name != "mysql" {
+		t.Fatalf("unexpected addons: %v", addons)
+	}
+	if err = bundle.Localize(addons[0], "registry.local:5000"); err != nil {
+		t.Fatal(err)
+	}
+	helm := addons[0].Spec.Helm
+	if helm.ChartLocationURL != "file:///mysql-0.9.0.tgz" {
+		t.Errorf("unexpected chart location: %s", helm.ChartLocationURL)
+	}
+	if helm.ChartsImage != "registry.local:5000/apecloud/kubeblocks-charts:0.9.0@"+chartsImageDigest {
+		t.Errorf("unexpected charts image: %s", helm.ChartsImage)
+	}
+}
+
+func TestLoadManifestDigestMismatch(t *testing.T) {
+	data, _ := testBundle(t)
+	if _, err := Load(bytes.NewReader(data), t.TempDir(), ""); err == nil {
+		t.Errorf("the digest of the manifest expected to be required")
+	}
+	_, err := Load(bytes.NewReader(data), t.TempDir(), Digest([]byte("another manifest")))
+	if err == nil || !strings.Contains(err.Error(), "digest of "+ManifestFile) {
+		t.Errorf("digest mismatch of the manifest expected, got: %v", err)
+	}
+}
+
+// rewrite repacks the bundle with the files changed by the function.
+func rewrite(t *testing.T, data []byte, change func(name string, content []byte) []byte) []byte {
+	gr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gr)
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			break
+		}
+		content := &bytes.Buffer{}
+		if _, err = content.ReadFrom(tr); err != nil {
+			t.Fatal(err)
+		}
+		changed := change(header.Name, content.Bytes())
+		if changed == nil {
+			continue
+		}
+		header.Size = int64(len(changed))
+		if err = tw.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = tw.Write(changed); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_ = tw.Close()
+	_ = gw.Close()
+	return buf.Bytes()
+}
+
+func TestLoadFileDigestMismatch(t *testing.T) {
+	data, digest := testBundle(t)
+
+	tampered := rewrite(t, data, func(name string, content []byte) []byte {
+		if name == "charts/mysql-0.9.0.tgz" {
+			return []byte("tampered")
+		}
+		return content
+	})
+	dir := t.TempDir()
+	_, err := Load(bytes.NewReader(tampered), dir, digest)
+	if err == nil || !strings.Contains(err.Error(), "digest of file") {
+		t.Errorf("digest mismatch expected, got: %v", err)
+	}
+	if _, err = os.Stat(filepath.Join(dir, "charts", "mysql-0.9.0.tgz")); !os.IsNotExist(err) {
+		t.Errorf("the tampered file expected to be removed, got: %v", err)
+	}
+
+	missing := rewrite(t, data, func(name string, content []byte) []byte {
+		if name == "charts/mysql-0.9.0.tgz" {
+			return nil
+		}
+		return content
+	})
+	if _, err = Load(bytes.NewReader(missing), t.TempDir(), digest); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("missing file expected, got: %v", err)
+	}
+}
+
+func TestWriteInvalidBundle(t *testing.T) {
+	dir := testFiles(t)
+	if err := os.MkdirAll(filepath.Join(dir, "charts", "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Write(&bytes.Buffer{}, testManifest(), dir); err == nil {
+		t.Errorf("the sub directory expected to be rejected")
+	}
+
+	manifest := testManifest()
+	manifest.Images = []string{"docker.io/apecloud/mysql:8.0.33"}
+	if _, err := Write(&bytes.Buffer{}, manifest, testFiles(t)); err == nil || !strings.Contains(err.Error(), "pinned by digest") {
+		t.Errorf("the image not pinned by digest expected to be rejected, got: %v", err)
+	}
+}
+
+func TestValidatePath(t *testing.T) {
+	for _, name := range []string{"../charts/a.tgz", "/charts/a.tgz", "images/a.tar", "charts/sub/a.tgz", ManifestFile} {
+		if err := validatePath(name); err == nil {
+			t.Errorf("invalid path %s expected to be rejected", name)
+		}
+	}
+}
+
+func TestLocalizeChartNotFound(t *testing.T) {
+	data, digest := testBundle(t)
+	bundle, err := Load(bytes.NewReader(data), t.TempDir(), digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addon := &extensionsv1alpha1.Addon{
+		Spec: extensionsv1alpha1.AddonSpec{
+			Helm: &extensionsv1alpha1.HelmTypeInstallSpec{ChartLocationURL: "https://example.com/redis-0.9.0.tgz"},
+		},
+	}
+	if err = bundle.Localize(addon, ""); err == nil {
+		t.Errorf("chart not found error expected")
+	}
+}
+
+func TestRewriteImage(t *testing.T) {
+	cases := map[string]string{
+		"docker.io/apecloud/mysql:8.0.33":              "registry.local/apecloud/mysql:8.0.33",
+		"apecloud/mysql:8.0.33":                        "registry.local/apecloud/mysql:8.0.33",
+		"localhost/mysql:8.0.33":                       "registry.local/mysql:8.0.33",
+		"busybox":                                      "registry.local/busybox",
+		"docker.io/apecloud/mysql@" + mysqlImageDigest: "registry.local/apecloud/mysql@" + mysqlImageDigest,
+	}
+	for image, expected := range cases {
+		if actual := RewriteImage(image, "registry.local/"); actual != expected {
+			t.Errorf("image %s, expected: %s, actual: %s", image, expected, actual)
+		}
+	}
+}
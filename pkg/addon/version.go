@@ -0,0 +1,62 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package addon
+
+import (
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// KubeBlocksVersionAnnotationKey is the annotation of the addon which declares the versions of KubeBlocks it supports.
+const KubeBlocksVersionAnnotationKey = "addon.kubeblocks.io/kubeblocks-version"
+
+// ValidateKubeBlocksVersion checks whether the version of KubeBlocks satisfies the constraint declared by the addon.
+// The pre-release versions are treated as satisfying the lower bound of the constraint.
+func ValidateKubeBlocksVersion(constraint, kbVersion string) (bool, error) {
+	if kbVersion == "" {
+		return false, nil
+	}
+	addPreReleaseInfo := func(constraint string) string {
+		constraint = strings.Trim(constraint, " ")
+		split := strings.Split(constraint, "-")
+		if len(split) == 1 && (strings.HasPrefix(constraint, ">") || strings.Contains(constraint, "<")) {
+			constraint += "-0"
+		}
+		return constraint
+	}
+	if strings.Contains(kbVersion, "-") {
+		rules := strings.Split(constraint, ",")
+		for i := range rules {
+			rules[i] = addPreReleaseInfo(rules[i])
+		}
+		constraint = strings.Join(rules, ",")
+	}
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return false, err
+	}
+	v, err := semver.NewVersion(kbVersion)
+	if err != nil {
+		return false, err
+	}
+	validate, _ := c.Validate(v)
+	return validate, nil
+}
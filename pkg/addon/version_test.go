@@ -0,0 +1,48 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package addon
+
+import (
+	"testing"
+)
+
+func TestValidateKubeBlocksVersion(t *testing.T) {
+	cases := []struct {
+		constraint string
+		version    string
+		expected   bool
+	}{
+		{">=0.9.0", "0.9.0", true},
+		{">=0.9.0", "0.8.3", false},
+		{">=0.9.0, <1.0.0", "0.9.1", true},
+		{">=0.9.0, <1.0.0", "1.0.0", false},
+		{">=0.9.0", "0.9.0-beta.1", true},
+		{">=0.9.0", "", false},
+	}
+	for _, c := range cases {
+		ok, err := ValidateKubeBlocksVersion(c.constraint, c.version)
+		if err != nil {
+			t.Fatalf("validate %s against %s failed: %v", c.version, c.constraint, err)
+		}
+		if ok != c.expected {
+			t.Errorf("validate %s against %s: expected %v, got %v", c.version, c.constraint, c.expected, ok)
+		}
+	}
+}
@@ -31,10 +31,16 @@ type AppsV1alpha1Interface interface {
 	BackupPolicyTemplatesGetter
 	ClustersGetter
 	ClusterDefinitionsGetter
+	ClusterPeeringsGetter
+	ClusterSetsGetter
 	ComponentsGetter
 	ComponentDefinitionsGetter
 	ComponentVersionsGetter
 	ConfigConstraintsGetter
+	DatabasesGetter
+	DatabaseUsersGetter
+	ExternalOpsHandlersGetter
+	NamespacePoliciesGetter
 	OpsDefinitionsGetter
 	OpsRequestsGetter
 	ServiceDescriptorsGetter
@@ -57,6 +63,14 @@ func (c *AppsV1alpha1Client) ClusterDefinitions() ClusterDefinitionInterface {
 	return newClusterDefinitions(c)
 }
 
+func (c *AppsV1alpha1Client) ClusterPeerings(namespace string) ClusterPeeringInterface {
+	return newClusterPeerings(c, namespace)
+}
+
+func (c *AppsV1alpha1Client) ClusterSets(namespace string) ClusterSetInterface {
+	return newClusterSets(c, namespace)
+}
+
 func (c *AppsV1alpha1Client) Components(namespace string) ComponentInterface {
 	return newComponents(c, namespace)
 }
@@ -73,6 +87,22 @@ func (c *AppsV1alpha1Client) ConfigConstraints() ConfigConstraintInterface {
 	return newConfigConstraints(c)
 }
 
+func (c *AppsV1alpha1Client) Databases(namespace string) DatabaseInterface {
+	return newDatabases(c, namespace)
+}
+
+func (c *AppsV1alpha1Client) DatabaseUsers(namespace string) DatabaseUserInterface {
+	return newDatabaseUsers(c, namespace)
+}
+
+func (c *AppsV1alpha1Client) ExternalOpsHandlers() ExternalOpsHandlerInterface {
+	return newExternalOpsHandlers(c)
+}
+
+func (c *AppsV1alpha1Client) NamespacePolicies() NamespacePolicyInterface {
+	return newNamespacePolicies(c)
+}
+
 func (c *AppsV1alpha1Client) OpsDefinitions() OpsDefinitionInterface {
 	return newOpsDefinitions(c)
 }
@@ -40,6 +40,14 @@ func (c *FakeAppsV1alpha1) ClusterDefinitions() v1alpha1.ClusterDefinitionInterf
 	return &FakeClusterDefinitions{c}
 }
 
+func (c *FakeAppsV1alpha1) ClusterPeerings(namespace string) v1alpha1.ClusterPeeringInterface {
+	return &FakeClusterPeerings{c, namespace}
+}
+
+func (c *FakeAppsV1alpha1) ClusterSets(namespace string) v1alpha1.ClusterSetInterface {
+	return &FakeClusterSets{c, namespace}
+}
+
 func (c *FakeAppsV1alpha1) Components(namespace string) v1alpha1.ComponentInterface {
 	return &FakeComponents{c, namespace}
 }
@@ -56,6 +64,22 @@ func (c *FakeAppsV1alpha1) ConfigConstraints() v1alpha1.ConfigConstraintInterfac
 	return &FakeConfigConstraints{c}
 }
 
+func (c *FakeAppsV1alpha1) Databases(namespace string) v1alpha1.DatabaseInterface {
+	return &FakeDatabases{c, namespace}
+}
+
+func (c *FakeAppsV1alpha1) DatabaseUsers(namespace string) v1alpha1.DatabaseUserInterface {
+	return &FakeDatabaseUsers{c, namespace}
+}
+
+func (c *FakeAppsV1alpha1) ExternalOpsHandlers() v1alpha1.ExternalOpsHandlerInterface {
+	return &FakeExternalOpsHandlers{c}
+}
+
+func (c *FakeAppsV1alpha1) NamespacePolicies() v1alpha1.NamespacePolicyInterface {
+	return &FakeNamespacePolicies{c}
+}
+
 func (c *FakeAppsV1alpha1) OpsDefinitions() v1alpha1.OpsDefinitionInterface {
 	return &FakeOpsDefinitions{c}
 }
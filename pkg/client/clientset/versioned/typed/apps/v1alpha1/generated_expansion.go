@@ -24,6 +24,10 @@ type ClusterExpansion interface{}
 
 type ClusterDefinitionExpansion interface{}
 
+type ClusterPeeringExpansion interface{}
+
+type ClusterSetExpansion interface{}
+
 type ComponentExpansion interface{}
 
 type ComponentDefinitionExpansion interface{}
@@ -32,6 +36,14 @@ type ComponentVersionExpansion interface{}
 
 type ConfigConstraintExpansion interface{}
 
+type DatabaseExpansion interface{}
+
+type DatabaseUserExpansion interface{}
+
+type ExternalOpsHandlerExpansion interface{}
+
+type NamespacePolicyExpansion interface{}
+
 type OpsDefinitionExpansion interface{}
 
 type OpsRequestExpansion interface{}
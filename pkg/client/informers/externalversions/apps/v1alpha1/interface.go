@@ -30,6 +30,10 @@ type Interface interface {
 	Clusters() ClusterInformer
 	// ClusterDefinitions returns a ClusterDefinitionInformer.
 	ClusterDefinitions() ClusterDefinitionInformer
+	// ClusterPeerings returns a ClusterPeeringInformer.
+	ClusterPeerings() ClusterPeeringInformer
+	// ClusterSets returns a ClusterSetInformer.
+	ClusterSets() ClusterSetInformer
 	// Components returns a ComponentInformer.
 	Components() ComponentInformer
 	// ComponentDefinitions returns a ComponentDefinitionInformer.
@@ -38,6 +42,14 @@ type Interface interface {
 	ComponentVersions() ComponentVersionInformer
 	// ConfigConstraints returns a ConfigConstraintInformer.
 	ConfigConstraints() ConfigConstraintInformer
+	// Databases returns a DatabaseInformer.
+	Databases() DatabaseInformer
+	// DatabaseUsers returns a DatabaseUserInformer.
+	DatabaseUsers() DatabaseUserInformer
+	// ExternalOpsHandlers returns a ExternalOpsHandlerInformer.
+	ExternalOpsHandlers() ExternalOpsHandlerInformer
+	// NamespacePolicies returns a NamespacePolicyInformer.
+	NamespacePolicies() NamespacePolicyInformer
 	// OpsDefinitions returns a OpsDefinitionInformer.
 	OpsDefinitions() OpsDefinitionInformer
 	// OpsRequests returns a OpsRequestInformer.
@@ -72,6 +84,16 @@ func (v *version) ClusterDefinitions() ClusterDefinitionInformer {
 	return &clusterDefinitionInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// ClusterPeerings returns a ClusterPeeringInformer.
+func (v *version) ClusterPeerings() ClusterPeeringInformer {
+	return &clusterPeeringInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// ClusterSets returns a ClusterSetInformer.
+func (v *version) ClusterSets() ClusterSetInformer {
+	return &clusterSetInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Components returns a ComponentInformer.
 func (v *version) Components() ComponentInformer {
 	return &componentInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -92,6 +114,26 @@ func (v *version) ConfigConstraints() ConfigConstraintInformer {
 	return &configConstraintInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// Databases returns a DatabaseInformer.
+func (v *version) Databases() DatabaseInformer {
+	return &databaseInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// DatabaseUsers returns a DatabaseUserInformer.
+func (v *version) DatabaseUsers() DatabaseUserInformer {
+	return &databaseUserInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// ExternalOpsHandlers returns a ExternalOpsHandlerInformer.
+func (v *version) ExternalOpsHandlers() ExternalOpsHandlerInformer {
+	return &externalOpsHandlerInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// NamespacePolicies returns a NamespacePolicyInformer.
+func (v *version) NamespacePolicies() NamespacePolicyInformer {
+	return &namespacePolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // OpsDefinitions returns a OpsDefinitionInformer.
 func (v *version) OpsDefinitions() OpsDefinitionInformer {
 	return &opsDefinitionInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
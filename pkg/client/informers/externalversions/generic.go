@@ -64,6 +64,10 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().Clusters().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("clusterdefinitions"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().ClusterDefinitions().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("clusterpeerings"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().ClusterPeerings().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("clustersets"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().ClusterSets().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("components"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().Components().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("componentdefinitions"):
@@ -72,6 +76,14 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().ComponentVersions().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("configconstraints"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().ConfigConstraints().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("databases"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().Databases().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("databaseusers"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().DatabaseUsers().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("externalopshandlers"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().ExternalOpsHandlers().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("namespacepolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().NamespacePolicies().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("opsdefinitions"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().OpsDefinitions().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("opsrequests"):
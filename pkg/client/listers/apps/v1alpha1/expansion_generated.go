@@ -34,6 +34,22 @@ type ClusterNamespaceListerExpansion interface{}
 // ClusterDefinitionLister.
 type ClusterDefinitionListerExpansion interface{}
 
+// ClusterPeeringListerExpansion allows custom methods to be added to
+// ClusterPeeringLister.
+type ClusterPeeringListerExpansion interface{}
+
+// ClusterPeeringNamespaceListerExpansion allows custom methods to be added to
+// ClusterPeeringNamespaceLister.
+type ClusterPeeringNamespaceListerExpansion interface{}
+
+// ClusterSetListerExpansion allows custom methods to be added to
+// ClusterSetLister.
+type ClusterSetListerExpansion interface{}
+
+// ClusterSetNamespaceListerExpansion allows custom methods to be added to
+// ClusterSetNamespaceLister.
+type ClusterSetNamespaceListerExpansion interface{}
+
 // ComponentListerExpansion allows custom methods to be added to
 // ComponentLister.
 type ComponentListerExpansion interface{}
@@ -54,6 +70,30 @@ type ComponentVersionListerExpansion interface{}
 // ConfigConstraintLister.
 type ConfigConstraintListerExpansion interface{}
 
+// DatabaseListerExpansion allows custom methods to be added to
+// DatabaseLister.
+type DatabaseListerExpansion interface{}
+
+// DatabaseNamespaceListerExpansion allows custom methods to be added to
+// DatabaseNamespaceLister.
+type DatabaseNamespaceListerExpansion interface{}
+
+// DatabaseUserListerExpansion allows custom methods to be added to
+// DatabaseUserLister.
+type DatabaseUserListerExpansion interface{}
+
+// DatabaseUserNamespaceListerExpansion allows custom methods to be added to
+// DatabaseUserNamespaceLister.
+type DatabaseUserNamespaceListerExpansion interface{}
+
+// ExternalOpsHandlerListerExpansion allows custom methods to be added to
+// ExternalOpsHandlerLister.
+type ExternalOpsHandlerListerExpansion interface{}
+
+// NamespacePolicyListerExpansion allows custom methods to be added to
+// NamespacePolicyLister.
+type NamespacePolicyListerExpansion interface{}
+
 // OpsDefinitionListerExpansion allows custom methods to be added to
 // OpsDefinitionLister.
 type OpsDefinitionListerExpansion interface{}
@@ -0,0 +1,98 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package common
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+const (
+	// DNS1123LabelMaxLength is the max length of the names used as labels, hostnames and service names.
+	DNS1123LabelMaxLength = validation.DNS1123LabelMaxLength
+	// DNS1123SubdomainMaxLength is the max length of the names of most of the objects, such as ConfigMaps and Secrets.
+	DNS1123SubdomainMaxLength = validation.DNS1123SubdomainMaxLength
+
+	nameHashLength = 8
+)
+
+// ShortenName returns the name as it is if it fits the max length, otherwise the name is truncated and suffixed
+// with the hash of the full name, so that the shortened name is deterministic and different names with the same
+// prefix don't collide.
+func ShortenName(name string, maxLength int) string {
+	if len(name) <= maxLength {
+		return name
+	}
+	hf := fnv.New32a()
+	_, _ = hf.Write([]byte(name))
+	hash := fmt.Sprintf("%08x", hf.Sum32())
+	prefix := strings.TrimRight(name[:maxLength-nameHashLength-1], "-.")
+	return fmt.Sprintf("%s-%s", prefix, hash)
+}
+
+// ValidateComponentNames checks that the names generated for the component fit the limits, the pod names
+// (used as the hostnames) and the headless service name of the component can't be shortened since they are
+// referenced by the name patterns. The templates map the names of the instance templates to their replicas,
+// the pods of a template are named as $(cluster)-$(component)-$(template)-$(ordinal).
+func ValidateComponentNames(clusterName, compName string, replicas int32, templates map[string]int32) error {
+	defaultReplicas := replicas
+	for tplName, tplReplicas := range templates {
+		defaultReplicas -= tplReplicas
+		name := fmt.Sprintf("%s-%s-%d", constant.GenerateClusterComponentName(clusterName, compName), tplName, maxOrdinal(tplReplicas))
+		if len(name) > DNS1123LabelMaxLength {
+			return fmt.Errorf("the pod name %s generated for instance template %s of component %s exceeds %d characters",
+				name, tplName, compName, DNS1123LabelMaxLength)
+		}
+	}
+	names := []string{
+		constant.GeneratePodName(clusterName, compName, maxOrdinal(defaultReplicas)),
+		constant.GenerateDefaultComponentHeadlessServiceName(clusterName, compName),
+	}
+	for _, name := range names {
+		if len(name) > DNS1123LabelMaxLength {
+			return fmt.Errorf("the name %s generated for component %s exceeds %d characters, the total length of cluster name and component name should be no more than %d",
+				name, compName, DNS1123LabelMaxLength, maxClusterComponentNameLength(defaultReplicas))
+		}
+	}
+	return nil
+}
+
+func maxOrdinal(replicas int32) int {
+	if replicas > 0 {
+		return int(replicas) - 1
+	}
+	return 0
+}
+
+// maxClusterComponentNameLength returns the max length of the cluster name and component name in total.
+func maxClusterComponentNameLength(replicas int32) int {
+	suffix := len("-headless")
+	if ordinal := len(strconv.Itoa(int(replicas))) + 1; ordinal > suffix {
+		suffix = ordinal
+	}
+	// the dash between the cluster name and component name
+	return DNS1123LabelMaxLength - suffix - 1
+}
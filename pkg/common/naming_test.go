@@ -0,0 +1,72 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package common
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestShortenName(t *testing.T) {
+	if name := ShortenName("mycluster-mysql", DNS1123LabelMaxLength); name != "mycluster-mysql" {
+		t.Errorf("expected the name unchanged, actual: %s", name)
+	}
+
+	long1 := strings.Repeat("a", 60) + "-backup-1"
+	long2 := strings.Repeat("a", 60) + "-backup-2"
+	name1 := ShortenName(long1, DNS1123LabelMaxLength)
+	name2 := ShortenName(long2, DNS1123LabelMaxLength)
+	if len(name1) != DNS1123LabelMaxLength || len(name2) != DNS1123LabelMaxLength {
+		t.Errorf("expected the names shortened to %d characters, actual: %s, %s", DNS1123LabelMaxLength, name1, name2)
+	}
+	if name1 == name2 {
+		t.Errorf("expected the shortened names different, actual: %s", name1)
+	}
+	if name1 != ShortenName(long1, DNS1123LabelMaxLength) {
+		t.Errorf("expected the shortened name deterministic")
+	}
+
+	// the trailing dashes of the truncated prefix are trimmed
+	name := ShortenName(strings.Repeat("a", 53)+"-----------", DNS1123LabelMaxLength)
+	if strings.Contains(name, "--") {
+		t.Errorf("unexpected shortened name: %s", name)
+	}
+}
+
+func TestValidateComponentNames(t *testing.T) {
+	if err := ValidateComponentNames("mycluster", "mysql", 3, nil); err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	if err := ValidateComponentNames(strings.Repeat("a", 40), strings.Repeat("b", 13), 3, nil); err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	if err := ValidateComponentNames(strings.Repeat("a", 40), strings.Repeat("b", 14), 3, nil); err == nil {
+		t.Errorf("expected the headless service name exceeding the limit")
+	}
+	// the pods of the instance templates are named with the template names
+	templates := map[string]int32{"tpl": 1}
+	if err := ValidateComponentNames(strings.Repeat("a", 40), strings.Repeat("b", 13), 3, templates); err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	templates = map[string]int32{strings.Repeat("c", 10): 1}
+	if err := ValidateComponentNames(strings.Repeat("a", 40), strings.Repeat("b", 13), 3, templates); err == nil {
+		t.Errorf("expected the pod name of the instance template exceeding the limit")
+	}
+}
@@ -0,0 +1,298 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package common
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ReadOnlyStatements are the leading keywords of the statements which do not modify data.
+// The WITH statements are read-only unless they contain a data-modifying statement, e.g. "WITH ... DELETE".
+var ReadOnlyStatements = []string{"SELECT", "SHOW", "EXPLAIN", "DESCRIBE", "DESC", "WITH"}
+
+// dataModifyingKeywords are the keywords of the data-modifying statements which can be nested in a WITH statement.
+var dataModifyingKeywords = map[string]struct{}{"INSERT": {}, "UPDATE": {}, "DELETE": {}, "MERGE": {}, "REPLACE": {}}
+
+// StatementPolicy restricts the SQL statements by their leading keywords.
+// A nil policy allows all statements.
+type StatementPolicy struct {
+	allowed map[string]struct{}
+}
+
+// NewStatementPolicy creates a policy from a comma-separated list of keywords, e.g. "SELECT,SHOW".
+// It returns nil if the list is empty.
+func NewStatementPolicy(allowlist string) *StatementPolicy {
+	var keywords []string
+	for _, keyword := range strings.Split(allowlist, ",") {
+		if keyword = strings.TrimSpace(keyword); keyword != "" {
+			keywords = append(keywords, keyword)
+		}
+	}
+	if len(keywords) == 0 {
+		return nil
+	}
+	return NewStatementPolicyFromKeywords(keywords...)
+}
+
+// NewStatementPolicyFromKeywords creates a policy which allows the statements starting with the keywords.
+func NewStatementPolicyFromKeywords(keywords ...string) *StatementPolicy {
+	policy := &StatementPolicy{allowed: make(map[string]struct{}, len(keywords))}
+	for _, keyword := range keywords {
+		policy.allowed[strings.ToUpper(keyword)] = struct{}{}
+	}
+	return policy
+}
+
+// Check returns an error if any statement of the script is not allowed by the policy.
+// The script is tokenized with the lexical rules of both MySQL and PostgreSQL, and it is allowed only if it passes
+// with both of them, so that a statement can't be hidden from the policy by the rules of the other engine,
+// e.g. the "#" comments of MySQL are operators in PostgreSQL.
+func (p *StatementPolicy) Check(script string) error {
+	if p == nil {
+		return nil
+	}
+	for _, dialect := range []sqlDialect{mysqlDialect, postgresDialect} {
+		for _, stmt := range splitStatements(script, dialect) {
+			if _, ok := p.allowed[stmt.keyword()]; !ok {
+				return fmt.Errorf("statement %q is not allowed by the policy", CutString(stmt.text, 64))
+			}
+		}
+	}
+	return nil
+}
+
+// sqlDialect describes the lexical rules which differ between the engines.
+type sqlDialect struct {
+	// hashComments indicates that "#" starts a line comment.
+	hashComments bool
+	// strictDashComments indicates that "--" starts a line comment only if it's followed by a whitespace.
+	strictDashComments bool
+	// backslashEscapes indicates that the backslash escapes the next character in the quoted strings.
+	backslashEscapes bool
+	// executableComments indicates that the contents of the "/*! */" comments are executed.
+	executableComments bool
+	// dollarQuotes indicates that the strings can be dollar-quoted, e.g. $$ ... $$ or $tag$ ... $tag$,
+	// the escape strings (E'...') accept backslash escapes, and the block comments can be nested.
+	dollarQuotes bool
+}
+
+var (
+	mysqlDialect    = sqlDialect{hashComments: true, strictDashComments: true, backslashEscapes: true, executableComments: true}
+	postgresDialect = sqlDialect{dollarQuotes: true}
+)
+
+// statement is a statement of the script, the quoted strings and identifiers are blanked out in the masked text,
+// so that the keywords can be looked up without being confused by the quoted contents.
+type statement struct {
+	text   string
+	masked string
+}
+
+// keyword returns the keyword which the statement is checked by. It's the leading keyword, except for the
+// WITH statements which contain a data-modifying statement, e.g. "WITH t AS (...) DELETE FROM ...".
+func (s statement) keyword() string {
+	keyword := leadingKeyword(s.masked)
+	if keyword != "WITH" {
+		return keyword
+	}
+	for _, word := range strings.FieldsFunc(s.masked, func(r rune) bool {
+		return !isIdentifierRune(r)
+	}) {
+		if _, ok := dataModifyingKeywords[strings.ToUpper(word)]; ok {
+			return strings.ToUpper(word)
+		}
+	}
+	return keyword
+}
+
+// SplitStatements splits the script into statements by semicolons with the lexical rules of MySQL, the comments
+// are removed, and the semicolons in quoted strings or identifiers are ignored.
+func SplitStatements(script string) []string {
+	var stmts []string
+	for _, stmt := range splitStatements(script, mysqlDialect) {
+		stmts = append(stmts, stmt.text)
+	}
+	return stmts
+}
+
+func splitStatements(script string, dialect sqlDialect) []statement {
+	var (
+		stmts           []statement
+		current, masked strings.Builder
+		runes           = []rune(script)
+		// the quote of the string or identifier being scanned, and whether the backslash escapes in it.
+		quote   rune
+		escapes bool
+		// the tag of the dollar-quoted string being scanned.
+		dollarTag string
+		// whether the contents of an executable comment are being scanned.
+		inExecutableComment bool
+	)
+	write := func(r rune, mask bool) {
+		current.WriteRune(r)
+		if mask && !unicode.IsSpace(r) {
+			masked.WriteRune('_')
+		} else {
+			masked.WriteRune(r)
+		}
+	}
+	flush := func() {
+		if stmt := strings.TrimSpace(current.String()); stmt != "" {
+			stmts = append(stmts, statement{text: stmt, masked: strings.TrimSpace(masked.String())})
+		}
+		current.Reset()
+		masked.Reset()
+	}
+	hasPrefix := func(i int, prefix string) bool {
+		return strings.HasPrefix(string(runes[i:]), prefix)
+	}
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case dollarTag != "":
+			if hasPrefix(i, dollarTag) {
+				for _, c := range dollarTag {
+					write(c, false)
+				}
+				i += len([]rune(dollarTag)) - 1
+				dollarTag = ""
+			} else {
+				write(r, true)
+			}
+			continue
+		case quote != 0:
+			switch {
+			case r == '\\' && escapes && i+1 < len(runes):
+				write(r, true)
+				i++
+				write(runes[i], true)
+			case r == quote && i+1 < len(runes) && runes[i+1] == quote:
+				write(r, true)
+				i++
+				write(runes[i], true)
+			case r == quote:
+				quote = 0
+				write(r, false)
+			default:
+				write(r, true)
+			}
+			continue
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+			escapes = dialect.backslashEscapes && r != '`'
+			if dialect.dollarQuotes && r == '\'' && i > 0 && (runes[i-1] == 'E' || runes[i-1] == 'e') &&
+				(i == 1 || !isIdentifierRune(runes[i-2])) {
+				escapes = true
+			}
+		case r == '$' && dialect.dollarQuotes && (i == 0 || !isIdentifierRune(runes[i-1])):
+			if tag := dollarQuoteTag(runes[i:]); tag != "" {
+				dollarTag = tag
+				for _, c := range tag {
+					write(c, false)
+				}
+				i += len([]rune(tag)) - 1
+				continue
+			}
+		case r == '#' && dialect.hashComments, r == '-' && hasPrefix(i, "--") && isDashComment(runes[i+2:], dialect):
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			write(' ', false)
+			continue
+		case r == '/' && dialect.executableComments && hasPrefix(i, "/*!"):
+			// the contents of the executable comments, e.g. "/*!50000 DROP TABLE t */", are executed by MySQL.
+			i += 2
+			for i+1 < len(runes) && unicode.IsDigit(runes[i+1]) {
+				i++
+			}
+			inExecutableComment = true
+			write(' ', false)
+			continue
+		case r == '*' && inExecutableComment && hasPrefix(i, "*/"):
+			i++
+			inExecutableComment = false
+			write(' ', false)
+			continue
+		case r == '/' && hasPrefix(i, "/*"):
+			depth := 0
+			for ; i < len(runes); i++ {
+				if hasPrefix(i, "/*") && (depth == 0 || dialect.dollarQuotes) {
+					depth++
+					i++
+				} else if hasPrefix(i, "*/") {
+					depth--
+					i++
+					if depth == 0 {
+						break
+					}
+				}
+			}
+			write(' ', false)
+			continue
+		case r == ';':
+			flush()
+			continue
+		}
+		write(r, false)
+	}
+	flush()
+	return stmts
+}
+
+// isDashComment checks if the "--" followed by the runes starts a line comment.
+// In MySQL, it does only if it's followed by a whitespace or a control character, e.g. "1--1" is an expression.
+func isDashComment(following []rune, dialect sqlDialect) bool {
+	if !dialect.strictDashComments || len(following) == 0 {
+		return true
+	}
+	return unicode.IsSpace(following[0]) || unicode.IsControl(following[0])
+}
+
+// dollarQuoteTag returns the opening tag of a dollar-quoted string, e.g. "$$" or "$tag$", or "" if it's not one.
+// The positional parameters, e.g. "$1", are not dollar quotes.
+func dollarQuoteTag(runes []rune) string {
+	for i := 1; i < len(runes); i++ {
+		switch r := runes[i]; {
+		case r == '$':
+			return string(runes[:i+1])
+		case unicode.IsLetter(r) || r == '_' || (i > 1 && unicode.IsDigit(r)):
+		default:
+			return ""
+		}
+	}
+	return ""
+}
+
+func isIdentifierRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '$'
+}
+
+func leadingKeyword(stmt string) string {
+	stmt = strings.TrimLeft(stmt, "( \t\r\n")
+	end := strings.IndexFunc(stmt, func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '_'
+	})
+	if end >= 0 {
+		stmt = stmt[:end]
+	}
+	return strings.ToUpper(stmt)
+}
@@ -0,0 +1,121 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package common
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		script string
+		want   []string
+	}{
+		{"select 1; select 2;", []string{"select 1", "select 2"}},
+		{"select ';' from t", []string{"select ';' from t"}},
+		{"-- drop table t\nselect 1", []string{"select 1"}},
+		{"/* ; */ show tables /* unclosed", []string{"show tables"}},
+		{" ; ;", nil},
+		{"select 'it\\'s;' from t # drop; \n; select 2", []string{"select 'it\\'s;' from t", "select 2"}},
+		{"select 1--1; select 2", []string{"select 1--1", "select 2"}},
+		{"/*!50000 select 1; */ select 2", []string{"select 1", "select 2"}},
+	}
+	for _, tt := range tests {
+		if got := SplitStatements(tt.script); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SplitStatements(%q) = %q, want %q", tt.script, got, tt.want)
+		}
+	}
+}
+
+func TestStatementPolicy(t *testing.T) {
+	var policy *StatementPolicy
+	if err := policy.Check("drop table t"); err != nil {
+		t.Errorf("nil policy should allow all statements, got %v", err)
+	}
+	if NewStatementPolicy(" , ") != nil {
+		t.Error("empty allowlist should result in a nil policy")
+	}
+
+	policy = NewStatementPolicyFromKeywords(ReadOnlyStatements...)
+	for _, script := range []string{"SELECT 1", "(select 1) union (select 2)", "show tables; explain select 1", "desc t"} {
+		if err := policy.Check(script); err != nil {
+			t.Errorf("script %q should be allowed, got %v", script, err)
+		}
+	}
+	for _, script := range []string{"select 1; drop table t", "/* select */ delete from t", "insert into t values (';')"} {
+		if err := policy.Check(script); err == nil {
+			t.Errorf("script %q should be rejected", script)
+		}
+	}
+
+	policy = NewStatementPolicy("create, grant")
+	if err := policy.Check("CREATE USER u; GRANT ALL ON *.* TO u"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestSplitPostgresStatements(t *testing.T) {
+	tests := []struct {
+		script string
+		want   []string
+	}{
+		{"select $$;$$, $tag$ $$; $tag$; select $1", []string{"select $$;$$, $tag$ $$; $tag$", "select $1"}},
+		{"select 1 # 2; select 'a\\'; select E'\\';'", []string{"select 1 # 2", "select 'a\\'", "select E'\\';'"}},
+		{"/* /* ; */ ; */ select 1", []string{"select 1"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, stmt := range splitStatements(tt.script, postgresDialect) {
+			got = append(got, stmt.text)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitStatements(%q) = %q, want %q", tt.script, got, tt.want)
+		}
+	}
+}
+
+func TestStatementPolicyHiddenStatements(t *testing.T) {
+	policy := NewStatementPolicyFromKeywords(ReadOnlyStatements...)
+	for _, script := range []string{
+		"select $$ ' $$; select '\\'",
+		"with t as (select 1) select * from t where `delete` = 'update'",
+	} {
+		if err := policy.Check(script); err != nil {
+			t.Errorf("script %q should be allowed, got %v", script, err)
+		}
+	}
+	for _, script := range []string{
+		// the statements hidden by the comments or the strings of the other engine
+		"select 1 # 2; drop table t",
+		"select 'a\\'; drop table t; -- '",
+		"select 1--1; drop table t",
+		"select $$; drop table t; $$",
+		"/*!50000 drop table t */",
+		"select E'\\''; drop table t; --'",
+		// the data-modifying WITH statements
+		"with t as (select 1) delete from t",
+		"WITH d AS (DELETE FROM t RETURNING *) SELECT * FROM d",
+	} {
+		if err := policy.Check(script); err == nil {
+			t.Errorf("script %q should be rejected", script)
+		}
+	}
+}
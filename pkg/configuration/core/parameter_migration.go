@@ -0,0 +1,136 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cast"
+
+	appsv1beta1 "github.com/apecloud/kubeblocks/apis/apps/v1beta1"
+	"github.com/apecloud/kubeblocks/pkg/unstructured"
+)
+
+// MigrateParameters translates the renamed parameters to the new names and drops the deprecated ones,
+// the messages describing the migrated parameters are returned as warnings.
+// The value of the new parameter takes precedence if both the old and the new are set.
+func MigrateParameters(params map[string]*string, migrations []appsv1beta1.ParameterMigration) (map[string]*string, []string) {
+	if len(params) == 0 || len(migrations) == 0 {
+		return params, nil
+	}
+
+	var warnings []string
+	migrated := make(map[string]*string, len(params))
+	for key, value := range params {
+		migrated[key] = value
+	}
+	for _, migration := range migrations {
+		value, ok := migrated[migration.Name]
+		if !ok {
+			continue
+		}
+		delete(migrated, migration.Name)
+		if len(migration.RenamedTo) == 0 {
+			warnings = append(warnings, fmt.Sprintf("parameter %s is deprecated%s and dropped", migration.Name, since(migration)))
+			continue
+		}
+		if _, ok = params[migration.RenamedTo]; ok {
+			warnings = append(warnings, fmt.Sprintf("parameter %s is renamed to %s%s, and overridden by %s",
+				migration.Name, migration.RenamedTo, since(migration), migration.RenamedTo))
+			continue
+		}
+		migrated[migration.RenamedTo] = value
+		warnings = append(warnings, fmt.Sprintf("parameter %s is renamed to %s%s", migration.Name, migration.RenamedTo, since(migration)))
+	}
+	sort.Strings(warnings)
+	return migrated, warnings
+}
+
+// MigrateConfigFiles translates the obsolete parameters already in the configuration files, e.g. the files rendered
+// for the older engine version, the files selected by the keys (all if empty) are migrated. It returns the files
+// changed only, along with the warnings.
+func MigrateConfigFiles(files map[string]string, keys []string, cc *appsv1beta1.ConfigConstraintSpec) (map[string]string, []string, error) {
+	if cc == nil || cc.FileFormatConfig == nil || len(cc.ParameterMigrations) == 0 {
+		return nil, nil, nil
+	}
+
+	keySet := FromCMKeysSelector(keys)
+	paramKey := func(name string) string {
+		if prefix := NestedPrefixField(cc.FileFormatConfig); len(prefix) > 0 {
+			return strings.Join([]string{prefix, name}, unstructured.DelimiterDot)
+		}
+		return name
+	}
+	migratedFiles := make(map[string]string)
+	var warnings []string
+	for file, content := range files {
+		if keySet != nil && !keySet.InArray(file) {
+			continue
+		}
+		cfg, err := unstructured.LoadConfig(file, content, cc.FileFormatConfig.Format)
+		if err != nil {
+			return nil, nil, WrapError(err, "failed to load config file [%s]", file)
+		}
+		params := make(map[string]*string)
+		for _, migration := range cc.ParameterMigrations {
+			for _, name := range []string{migration.Name, migration.RenamedTo} {
+				if value := cfg.Get(paramKey(name)); len(name) > 0 && value != nil {
+					str := cast.ToString(value)
+					params[name] = &str
+				}
+			}
+		}
+		migrated, fileWarnings := MigrateParameters(params, cc.ParameterMigrations)
+		if len(fileWarnings) == 0 {
+			continue
+		}
+		for name := range params {
+			if _, ok := migrated[name]; !ok {
+				if err = cfg.RemoveKey(paramKey(name)); err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+		for name, value := range migrated {
+			if _, ok := params[name]; !ok {
+				if err = cfg.Update(paramKey(name), *value); err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+		if migratedFiles[file], err = cfg.Marshal(); err != nil {
+			return nil, nil, err
+		}
+		for _, warning := range fileWarnings {
+			warnings = append(warnings, fmt.Sprintf("%s: %s", file, warning))
+		}
+	}
+	sort.Strings(warnings)
+	return migratedFiles, warnings, nil
+}
+
+func since(migration appsv1beta1.ParameterMigration) string {
+	if len(migration.Since) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" since %s", migration.Since)
+}
@@ -0,0 +1,123 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package core
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/utils/pointer"
+
+	appsv1beta1 "github.com/apecloud/kubeblocks/apis/apps/v1beta1"
+	"github.com/apecloud/kubeblocks/pkg/unstructured"
+)
+
+func TestMigrateParameters(t *testing.T) {
+	migrations := []appsv1beta1.ParameterMigration{
+		{Name: "query_cache_size", Since: "8.0"},
+		{Name: "tx_isolation", RenamedTo: "transaction_isolation", Since: "8.0"},
+		{Name: "log_warnings", RenamedTo: "log_error_verbosity"},
+	}
+	tests := []struct {
+		name         string
+		params       map[string]*string
+		want         map[string]*string
+		wantWarnings int
+	}{{
+		name:   "no obsolete parameters",
+		params: map[string]*string{"max_connections": pointer.String("1000")},
+		want:   map[string]*string{"max_connections": pointer.String("1000")},
+	}, {
+		name: "renamed and deprecated parameters",
+		params: map[string]*string{
+			"max_connections":  pointer.String("1000"),
+			"tx_isolation":     pointer.String("READ-COMMITTED"),
+			"query_cache_size": pointer.String("0"),
+		},
+		want: map[string]*string{
+			"max_connections":       pointer.String("1000"),
+			"transaction_isolation": pointer.String("READ-COMMITTED"),
+		},
+		wantWarnings: 2,
+	}, {
+		name: "the new parameter takes precedence",
+		params: map[string]*string{
+			"log_warnings":        pointer.String("2"),
+			"log_error_verbosity": pointer.String("3"),
+		},
+		want:         map[string]*string{"log_error_verbosity": pointer.String("3")},
+		wantWarnings: 1,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, warnings := MigrateParameters(tt.params, migrations)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MigrateParameters() got = %v, want %v", got, tt.want)
+			}
+			if len(warnings) != tt.wantWarnings {
+				t.Errorf("MigrateParameters() warnings = %v, want %d warnings", warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestMigrateConfigFiles(t *testing.T) {
+	cc := &appsv1beta1.ConfigConstraintSpec{
+		ParameterMigrations: []appsv1beta1.ParameterMigration{
+			{Name: "query_cache_size", Since: "8.0"},
+			{Name: "tx_isolation", RenamedTo: "transaction_isolation", Since: "8.0"},
+		},
+		FileFormatConfig: &appsv1beta1.FileFormatConfig{
+			FormatterAction: appsv1beta1.FormatterAction{
+				IniConfig: &appsv1beta1.IniConfig{SectionName: "mysqld"},
+			},
+			Format: appsv1beta1.Ini,
+		},
+	}
+	files := map[string]string{
+		"my.cnf": "[mysqld]\nmax_connections=1000\ntx_isolation=READ-COMMITTED\nquery_cache_size=0\n",
+		"other":  "[mysqld]\nmax_connections=1000\n",
+	}
+
+	migrated, warnings, err := MigrateConfigFiles(files, nil, cc)
+	if err != nil {
+		t.Fatalf("MigrateConfigFiles() error = %v", err)
+	}
+	if len(migrated) != 1 || len(warnings) != 2 {
+		t.Fatalf("MigrateConfigFiles() got = %v, warnings = %v", migrated, warnings)
+	}
+	cfg, err := unstructured.LoadConfig("my.cnf", migrated["my.cnf"], appsv1beta1.Ini)
+	if err != nil {
+		t.Fatalf("load the migrated file error = %v", err)
+	}
+	if cfg.Get("mysqld.tx_isolation") != nil || cfg.Get("mysqld.query_cache_size") != nil {
+		t.Errorf("the obsolete parameters are not removed: %s", migrated["my.cnf"])
+	}
+	if value, _ := cfg.GetString("mysqld.transaction_isolation"); value != "READ-COMMITTED" {
+		t.Errorf("the renamed parameter is not set: %s", migrated["my.cnf"])
+	}
+
+	t.Run("the files not selected", func(t *testing.T) {
+		migrated, _, err := MigrateConfigFiles(files, []string{"other"}, cc)
+		if err != nil || len(migrated) != 0 {
+			t.Errorf("MigrateConfigFiles() got = %v, error = %v", migrated, err)
+		}
+	})
+}
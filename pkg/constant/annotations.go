@@ -45,10 +45,126 @@ const (
 	DisableHAAnnotationKey                   = "kubeblocks.io/disable-ha"
 	OpsDependentOnSuccessfulOpsAnnoKey       = "ops.kubeblocks.io/dependent-on-successful-ops" // OpsDependentOnSuccessfulOpsAnnoKey wait for the dependent ops to succeed before executing the current ops. If it fails, this ops will also fail.
 	RelatedOpsAnnotationKey                  = "ops.kubeblocks.io/related-ops"
+	OpsCanaryApprovedAnnotationKey           = "ops.kubeblocks.io/canary-approved"   // OpsCanaryApprovedAnnotationKey approves the canary OpsRequest to continue after the canary instances are restarted.
+	OpsApprovedByAnnotationKey               = "ops.kubeblocks.io/approved-by"       // OpsApprovedByAnnotationKey records the approver of the OpsRequest which requires the approval.
+	OpsCutoverConfirmedAnnotationKey         = "ops.kubeblocks.io/cutover-confirmed" // OpsCutoverConfirmedAnnotationKey confirms the ShardingConversion OpsRequest to remove the source component.
+	DataScriptTargetAnnotationKey            = "ops.kubeblocks.io/datascript-target" // DataScriptTargetAnnotationKey records the target that the datascript Job executes the scripts on.
+	DataScriptCountAnnotationKey             = "ops.kubeblocks.io/datascript-count"  // DataScriptCountAnnotationKey records the number of the scripts executed by the datascript Job.
+
+	// EstimatedCostAnnotationKey records the estimated monthly cost of the cluster in JSON, e.g. {"currency":"USD","compute":10.5,"storage":2,"total":12.5}.
+	EstimatedCostAnnotationKey = "kubeblocks.io/estimated-monthly-cost"
+
+	// ShardHashSlotsAnnotationKey specifies the hash slots served by a sharding component, e.g. "0-5460,10923-10999".
+	// It's published in the routing metadata of the sharding if the Component doesn't define the shardRouting action
+	// to report the hash slots served by the engine.
+	ShardHashSlotsAnnotationKey = "apps.kubeblocks.io/shard-hash-slots"
+
+	// ClusterSetRevisionAnnotationKey records the revision of the ClusterSet template that the cluster is updated to.
+	ClusterSetRevisionAnnotationKey = "apps.kubeblocks.io/cluster-set-revision"
+
+	// ClusterSetLastAppliedAnnotationKey records the spec of the ClusterSet template last applied to the cluster in JSON,
+	// which is used to find out the fields changed in the template.
+	ClusterSetLastAppliedAnnotationKey = "apps.kubeblocks.io/cluster-set-last-applied"
+
+	// AdoptStatefulSetsAnnotationKey specifies the components of the cluster to adopt the existing StatefulSets,
+	// in the format of "comp1[:compDef1],comp2[:compDef2]". The StatefulSet to adopt is expected to be named as
+	// "<cluster>-<component>", and the spec of the component is generated from the StatefulSet if the component
+	// definition is given and the component is not declared in the cluster.
+	AdoptStatefulSetsAnnotationKey = "apps.kubeblocks.io/adopt-statefulsets"
+
+	// VolumeShrinkingAnnotationKey is set on the Cluster by the VolumeExpansion OpsRequest which shrinks the volumes,
+	// in the format of "comp1,comp2". The existing volumes of the components are kept as-is until their instances are
+	// taken offline, and the data of the new instances is cloned by the backup rather than the volume snapshot.
+	VolumeShrinkingAnnotationKey = "apps.kubeblocks.io/volume-shrinking"
+
+	// ScaleInPVCRetentionAnnotationKey is set on the Cluster by the HorizontalScaling OpsRequest to specify how to handle
+	// the PVCs of the instances taken offline, in the format of "comp1=Retain,comp2=Backup". The name can be a component
+	// or a sharding, and the PVCs are deleted if the component is not listed.
+	ScaleInPVCRetentionAnnotationKey = "apps.kubeblocks.io/scale-in-pvc-retention"
+
+	// SupportedArchitecturesAnnotationKey is set on the ComponentDefinition by the addon to declare the CPU architectures
+	// supported by its images, in the format of "amd64,arm64". The pods are only placed on the nodes with these architectures.
+	SupportedArchitecturesAnnotationKey = "addon.kubeblocks.io/supported-architectures"
+
+	// AdoptStatefulSetAnnotationKey marks the component to adopt the existing StatefulSet of the same name.
+	AdoptStatefulSetAnnotationKey = "apps.kubeblocks.io/adopt-statefulset"
 
 	// SkipImmutableCheckAnnotationKey specifies to skip the mutation check for the object.
 	// The mutation check is only applied to the fields that are declared as immutable.
 	SkipImmutableCheckAnnotationKey = "apps.kubeblocks.io/skip-immutable-check"
+
+	// SkipAutoPatchAnnotationKey suspends the automatic patch upgrades of the cluster if set to "true".
+	SkipAutoPatchAnnotationKey = "apps.kubeblocks.io/skip-auto-patch"
+
+	// SkipFinalBackupAnnotationKey skips the final backup of the cluster if set to "true", to unblock the deletion
+	// of the cluster whose final backup failed or never completes.
+	SkipFinalBackupAnnotationKey = "apps.kubeblocks.io/skip-final-backup"
+
+	// IgnoreDisruptionWindowsAnnotationKey allows the disruptive actions of the cluster to execute out of
+	// the disruption windows if set to "true", for emergencies.
+	IgnoreDisruptionWindowsAnnotationKey = "apps.kubeblocks.io/ignore-disruption-windows"
+
+	// DisruptionWindowsAnnotationKey records the disruption windows of the InstanceSet in JSON,
+	// the instances are only updated within the windows.
+	DisruptionWindowsAnnotationKey = "workloads.kubeblocks.io/disruption-windows"
+
+	// ConsumerAffinityAnnotationKey records the preferred pod affinity terms toward the consumer applications in JSON,
+	// which are applied to the instances of the InstanceSet when they are created.
+	ConsumerAffinityAnnotationKey = "workloads.kubeblocks.io/consumer-affinity"
+
+	// InstanceIPsAnnotationKey records the stable IPs of the instances of the InstanceSet in JSON, either the static IPs
+	// on a multus network, e.g. {"network":"default/macvlan","ips":{"mycluster-mysql-0":"10.1.1.10/24"}},
+	// or the cluster IPs of the instance Services, e.g. {"ips":{"mycluster-mysql-0":"10.96.10.10"}}.
+	InstanceIPsAnnotationKey = "workloads.kubeblocks.io/instance-ips"
+
+	// InstanceIPAnnotationKey advertises the stable IP of the instance on its pod.
+	InstanceIPAnnotationKey = "workloads.kubeblocks.io/instance-ip"
+
+	// IPStackAnnotationKey is set on the InstanceSet to render the IP families into its headless Service,
+	// in the format of "<ipFamilyPolicy>:<ipFamily>[,<ipFamily>]", e.g. "PreferDualStack:IPv6,IPv4".
+	IPStackAnnotationKey = "workloads.kubeblocks.io/ip-stack"
+
+	// MultusNetworksAnnotationKey specifies the secondary networks that the pod attaches to through multus.
+	MultusNetworksAnnotationKey = "k8s.v1.cni.cncf.io/networks"
+
+	// DiskPressureProtectedAnnotationKey marks the instance which is switched into the read-only protective mode
+	// as its volumes cross the critical usage threshold.
+	DiskPressureProtectedAnnotationKey = "apps.kubeblocks.io/disk-pressure-protected"
+
+	// MigrateToKBAgentAnnotationKey requests to replace the legacy lorry containers with the kb-agent if set to "true".
+	// It's set on the Cluster to opt in, and the Restart OpsRequest sets it on the restarted Components,
+	// whose pods are rebuilt with the kb-agent then.
+	MigrateToKBAgentAnnotationKey = "apps.kubeblocks.io/migrate-to-kb-agent"
+
+	// KBAgentHandlersAnnotationKey is set on the pods running the kb-agent with the handler specs of the actions and
+	// the probes in JSON, which the kb-agent reads on start. The annotation is updated in place, and the running
+	// kb-agents are refreshed by the controller, so that the updated actions and probes don't restart the pods.
+	KBAgentHandlersAnnotationKey = "apps.kubeblocks.io/kb-agent-handlers"
+
+	// ReplicaWeightsAnnotationKey is set on the read Services of the Component with a JSON object mapping the pod names
+	// to their weights in the read traffic, which is consumed by the proxies and load balancers supporting weighted routing.
+	ReplicaWeightsAnnotationKey = "apps.kubeblocks.io/replica-weights"
+
+	// ArtifactsDeletionRetriesAnnotationKey records how many times the deletion of the backup artifacts has been retried
+	// for the Backup which is deleted by the Cluster with the `WipeOut` termination policy.
+	ArtifactsDeletionRetriesAnnotationKey = "apps.kubeblocks.io/artifacts-deletion-retries"
+
+	// SimulateSpecAnnotationKey requests the cluster controller to simulate a change of the Cluster without applying it,
+	// the value is a JSON merge patch of the Cluster, e.g. {"spec":{"componentSpecs":[...]}}.
+	// The changes of the child objects it would cause are written to status.simulation.
+	SimulateSpecAnnotationKey = "apps.kubeblocks.io/simulate"
+
+	// NamespacePolicyAnnotationKey records the name of the NamespacePolicy applied to the Cluster when it was created,
+	// the value is empty if no policy matches the namespace of the Cluster.
+	NamespacePolicyAnnotationKey = "apps.kubeblocks.io/namespace-policy"
+
+	// ClusterPeeringSourceAnnotationKey is set on the read-replica cluster created by a ClusterPeering
+	// to record its source in the format of "<namespace>/<cluster>/<component>".
+	ClusterPeeringSourceAnnotationKey = "apps.kubeblocks.io/cluster-peering-source"
+
+	// NodeRebootStateAnnotationKey is set on the node which requires a reboot to record the state of draining
+	// the instances off it in JSON, e.g. {"requiredAt":"2024-01-01T00:00:00Z","bootID":"...","cordoned":true}.
+	NodeRebootStateAnnotationKey = "apps.kubeblocks.io/node-reboot-state"
 )
 
 // annotations for multi-cluster
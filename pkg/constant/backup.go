@@ -38,4 +38,5 @@ const (
 	RestoreTimeKeyForRestore          = "restoreTime"
 	ConnectionPassword                = "connectionPassword"
 	EncryptedSystemAccounts           = "encryptedSystemAccounts"
+	MaskingRulesKeyForRestore         = "maskingRules"
 )
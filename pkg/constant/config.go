@@ -57,6 +57,15 @@ const (
 	KBParameterUpdateSourceAnnotationKey        = "config.kubeblocks.io/reconfigure-source"
 	UpgradeRestartAnnotationKey                 = "config.kubeblocks.io/restart"
 	ConfigAppliedVersionAnnotationKey           = "config.kubeblocks.io/config-applied-version"
+
+	// CMRoleConfigOverlaysAnnotationKey holds the rendered role-scoped overlays of the configuration.
+	CMRoleConfigOverlaysAnnotationKey = "config.kubeblocks.io/role-overlays"
+	// RoleConfigOverlayAnnotationKeyPrefix is the prefix of the pod annotation recording the overlay applied to the pod,
+	// suffixed with the name of the config spec.
+	RoleConfigOverlayAnnotationKeyPrefix = "config.kubeblocks.io/role-overlay"
+
+	// CMConfigurationSecretBackedAnnotationKey marks the configmap whose rendered files are stored in the Secret of the same name.
+	CMConfigurationSecretBackedAnnotationKey = "config.kubeblocks.io/secret-backed"
 )
 
 const (
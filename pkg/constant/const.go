@@ -39,14 +39,27 @@ const (
 	KBImagePullPolicy        = "KUBEBLOCKS_IMAGE_PULL_POLICY"
 	KBImagePullSecrets       = "KUBEBLOCKS_IMAGE_PULL_SECRETS"
 	KBDataScriptClientsImage = "KUBEBLOCKS_DATASCRIPT_CLIENTS_IMAGE"
+
+	// KBDataScriptAllowedStatements is a comma-separated list of the statement keywords allowed in the
+	// DataScript ops and the sql operations of lorry, e.g. "SELECT,SHOW,CREATE". Empty means no restriction.
+	KBDataScriptAllowedStatements = "KUBEBLOCKS_DATASCRIPT_ALLOWED_STATEMENTS"
+
+	// KBSidecarResources is a JSON map from the name of the sidecar containers to their default resources,
+	// the exporter can be referred to as ExporterSidecarName.
+	KBSidecarResources = "KUBEBLOCKS_SIDECAR_RESOURCES"
 )
 
+// ExporterSidecarName is the name to refer to the exporter container of a component, whatever its container name is.
+const ExporterSidecarName = "exporter"
+
 const (
-	StatefulSetKind    = "StatefulSet"
-	PodKind            = "Pod"
-	JobKind            = "Job"
-	VolumeSnapshotKind = "VolumeSnapshot"
-	ServiceKind        = "Service"
+	StatefulSetKind           = "StatefulSet"
+	PodKind                   = "Pod"
+	JobKind                   = "Job"
+	VolumeSnapshotKind        = "VolumeSnapshot"
+	ServiceKind               = "Service"
+	PersistentVolumeClaimKind = "PersistentVolumeClaim"
+	OpsRequestKind            = "OpsRequest"
 )
 
 // username and password are keys in created secrets for others to refer to.
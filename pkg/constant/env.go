@@ -40,6 +40,12 @@ const (
 	KBEnvClusterUIDPostfix8Deprecated = "KB_CLUSTER_UID_POSTFIX_8"
 )
 
+// ClusterSet
+const (
+	KBEnvClusterSetName  = "KB_CLUSTER_SET_NAME"
+	KBEnvClusterSetIndex = "KB_CLUSTER_SET_INDEX"
+)
+
 // Component
 const (
 	KBEnvCompName           = "KB_COMP_NAME"
@@ -57,6 +63,8 @@ const (
 	KBEnvPodOrdinal       = "KB_POD_ORDINAL"
 	KBEnvPodIPDeprecated  = "KB_PODIP"
 	KBEnvPodIPsDeprecated = "KB_PODIPS"
+	KBEnvBindAddress      = "KB_BIND_ADDRESS"
+	KBEnvInstanceIP       = "KB_INSTANCE_IP"
 )
 
 // Host
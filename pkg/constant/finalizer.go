@@ -26,4 +26,7 @@ const (
 	ConfigFinalizerName            = "config.kubeblocks.io/finalizer"
 	ServiceDescriptorFinalizerName = "servicedescriptor.kubeblocks.io/finalizer"
 	OpsRequestFinalizerName        = "opsrequest.kubeblocks.io/finalizer"
+	DatabaseFinalizerName          = "database.kubeblocks.io/finalizer"
+	DatabaseUserFinalizerName      = "databaseuser.kubeblocks.io/finalizer"
+	ClusterPeeringFinalizerName    = "clusterpeering.kubeblocks.io/finalizer"
 )
@@ -44,4 +44,12 @@ const (
 	// FeatureGateInPlacePodVerticalScaling specifies to enable in-place pod vertical scaling
 	// NOTE: This feature depends on the InPlacePodVerticalScaling feature of the K8s cluster in which the KubeBlocks runs.
 	FeatureGateInPlacePodVerticalScaling = "IN_PLACE_POD_VERTICAL_SCALING"
+
+	// FeatureGateReplicasProtection specifies to reject setting the replicas of a component to 0 by editing the cluster spec,
+	// and the h-scale OpsRequests that would drop the replicas below the minimum declared in the component definition.
+	FeatureGateReplicasProtection = "REPLICAS_PROTECTION"
+
+	// FeatureGateTestScenario specifies to enable the TestScenario controller, which runs the declarative scenarios
+	// to validate KubeBlocks in-cluster. It works with the experimental controllers only.
+	FeatureGateTestScenario = "TEST_SCENARIO"
 )
@@ -37,6 +37,8 @@ const (
 // well-known labels for KubeBlocks and its resources
 const (
 	BackupProtectionLabelKey               = "kubeblocks.io/backup-protection" // BackupProtectionLabelKey Backup delete protection policy label
+	FinalBackupLabelKey                    = "kubeblocks.io/final-backup"      // FinalBackupLabelKey marks the final backup taken before the cluster is deleted
+	ClusterArchiveLabelKey                 = "kubeblocks.io/cluster-archive"   // ClusterArchiveLabelKey marks the archive of the cluster deleted with the Retain termination policy
 	RoleLabelKey                           = "kubeblocks.io/role"              // RoleLabelKey consensusSet and replicationSet role label key
 	AccessModeLabelKey                     = "workloads.kubeblocks.io/access-mode"
 	ReadyWithoutPrimaryKey                 = "kubeblocks.io/ready-without-primary"
@@ -44,12 +46,16 @@ const (
 	KBAppClusterUIDLabelKey                = "apps.kubeblocks.io/cluster-uid"
 	KBAppComponentLabelKey                 = "apps.kubeblocks.io/component-name"
 	KBAppShardingNameLabelKey              = "apps.kubeblocks.io/sharding-name"
-	KBManagedByKey                         = "apps.kubeblocks.io/managed-by" // KBManagedByKey marks resources that auto created
+	KBAppClusterSetLabelKey                = "apps.kubeblocks.io/cluster-set"       // KBAppClusterSetLabelKey refers to the ClusterSet the cluster belongs to
+	KBAppClusterSetIndexLabelKey           = "apps.kubeblocks.io/cluster-set-index" // KBAppClusterSetIndexLabelKey refers to the index of the cluster in the ClusterSet
+	KBManagedByKey                         = "apps.kubeblocks.io/managed-by"        // KBManagedByKey marks resources that auto created
 	PVCNameLabelKey                        = "apps.kubeblocks.io/pvc-name"
 	VolumeClaimTemplateNameLabelKey        = "apps.kubeblocks.io/vct-name"
 	KBAppComponentInstanceTemplateLabelKey = "apps.kubeblocks.io/instance-template"
 	KBAppServiceVersionKey                 = "apps.kubeblocks.io/service-version"
 	KBAppPodNameLabelKey                   = "apps.kubeblocks.io/pod-name"
+	BackupReplicaLabelKey                  = "apps.kubeblocks.io/backup-replica" // BackupReplicaLabelKey marks the replica designated for backups
+	ReadServingLabelKey                    = "apps.kubeblocks.io/read-serving"   // ReadServingLabelKey marks whether the replica serves the read Services
 	ClusterDefLabelKey                     = "clusterdefinition.kubeblocks.io/name"
 	ComponentDefinitionLabelKey            = "componentdefinition.kubeblocks.io/name"
 	ComponentVersionLabelKey               = "componentversion.kubeblocks.io/name"
@@ -58,7 +64,12 @@ const (
 	OpsRequestTypeLabelKey                 = "ops.kubeblocks.io/ops-type"
 	OpsRequestNameLabelKey                 = "ops.kubeblocks.io/ops-name"
 	OpsRequestNamespaceLabelKey            = "ops.kubeblocks.io/ops-namespace"
+	OpsRequestAutoPatchLabelKey            = "ops.kubeblocks.io/auto-patch"
+	OpsRequestAutoExpansionLabelKey        = "ops.kubeblocks.io/auto-expansion"
 	ServiceDescriptorNameLabelKey          = "servicedescriptor.kubeblocks.io/name"
+	SysctlNodeLabelKeyPrefix               = "sysctl.kubeblocks.io/" // SysctlNodeLabelKeyPrefix marks the node-level sysctls tuned on the node
+	ClusterPeeringNameLabelKey             = "apps.kubeblocks.io/cluster-peering"
+	NodeRebootInPlaceLabelKey              = "apps.kubeblocks.io/node-reboot-in-place" // NodeRebootInPlaceLabelKey marks the instance pinned to the node which is rebooted in place
 )
 
 // GetKBConfigMapWellKnownLabels returns the well-known labels for KB ConfigMap
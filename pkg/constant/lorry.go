@@ -28,6 +28,10 @@ const (
 	VolumeProtectionProbeContainerName = "kb-volume-protection"
 	LorryRoleProbePath                 = "/v1.0/checkrole"
 	LorryVolumeProtectPath             = "/v1.0/volumeprotection"
+
+	KBAgentContainerName     = "kb-agent"
+	KBAgentInitContainerName = "init-kb-agent"
+	KBAgentHTTPPortName      = "kb-agent-http"
 )
 
 // action keys
@@ -44,4 +48,6 @@ const (
 	PreTerminateAction     = "preTerminate"
 	DataDumpAction         = "dataDump"
 	DataLoadAction         = "dataLoad"
+	RebalanceAction        = "rebalance"
+	ShardRoutingAction     = "shardRouting"
 )
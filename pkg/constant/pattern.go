@@ -29,6 +29,11 @@ func GenerateClusterComponentName(clusterName, compName string) string {
 	return fmt.Sprintf("%s-%s", clusterName, compName)
 }
 
+// GenerateClusterHistoryConfigMapName generates the name of the ConfigMap recording the history of the cluster.
+func GenerateClusterHistoryConfigMapName(clusterName string) string {
+	return fmt.Sprintf("kb-%s-history", clusterName)
+}
+
 // GenerateAccountSecretName generates the secret name of system accounts.
 func GenerateAccountSecretName(clusterName, compName, name string) string {
 	replacedName := strings.ReplaceAll(name, "_", "-")
@@ -94,6 +99,16 @@ func GenerateResourceNameWithScalingSuffix(name string) string {
 	return fmt.Sprintf("%s-%s", name, SlashScalingLowerSuffix)
 }
 
+// GenerateShardingRoutingConfigMapName generates the name of the ConfigMap publishing the routing metadata of the sharding.
+func GenerateShardingRoutingConfigMapName(clusterName, shardingName string) string {
+	return fmt.Sprintf("%s-%s-routing", clusterName, shardingName)
+}
+
+// GenerateClusterConnectionContractName generates the name of the Secret publishing the connection contract of the cluster.
+func GenerateClusterConnectionContractName(clusterName string) string {
+	return fmt.Sprintf("%s-connection-contract", clusterName)
+}
+
 // GenerateShardingNamePrefix generates sharding name prefix.
 func GenerateShardingNamePrefix(shardingName string) string {
 	return fmt.Sprintf("%s-", shardingName)
@@ -49,6 +49,48 @@ const (
 	CfgKeyDPBackupEncryptionSecretKeyRef = "DP_BACKUP_ENCRYPTION_SECRET_KEY_REF"
 	CfgKeyDPBackupEncryptionAlgorithm    = "DP_BACKUP_ENCRYPTION_ALGORITHM"
 
+	// the max number of generations recorded in the history of a cluster, 0 means disabled.
+	CfgKeyClusterHistoryLimit = "CLUSTER_HISTORY_LIMIT"
+
+	// the name of the ConfigMap holding the pricing tables to estimate the cost of clusters, empty means disabled.
+	CfgKeyClusterPricingConfigMap = "CLUSTER_PRICING_CM_NAME"
+
+	// the policy for the service versions which are end of life or have known vulnerabilities, "Warn" or "Block".
+	CfgKeyServiceVersionRiskPolicy = "SERVICE_VERSION_RISK_POLICY"
+
+	// the window to coalesce the successive status patches of an object which only carry insignificant changes,
+	// e.g. the progress details of OpsRequests, 0 means disabled.
+	CfgKeyStatusPatchCoalesceWindow = "STATUS_PATCH_COALESCE_WINDOW"
+
+	// the duration in which the OpsRequests with the same spec.idempotencyKey are treated as duplicates.
+	CfgKeyOpsIdempotencyKeyTTL = "OPS_IDEMPOTENCY_KEY_TTL"
+
+	// the external pre-checks called out before the OpsRequests are applied, in JSON, e.g.
+	// [{"name":"cost-control","url":"https://cost-control.svc/precheck","opsTypes":["HorizontalScaling"],"failurePolicy":"Fail"}].
+	CfgKeyOpsPreCheckCallouts = "OPS_PRECHECK_CALLOUTS"
+
+	// the node annotation which signals that the node requires a reboot, e.g. the kured annotation
+	// "weave.works/kured-most-recent-reboot-needed", its value is the time in RFC3339 format when the reboot is required.
+	// the instances on the node are drained in a role-aware order if set.
+	CfgKeyNodeRebootRequiredAnnotation = "NODE_REBOOT_REQUIRED_ANNOTATION"
+
+	// whether the admission webhooks are served.
+	CfgKeyEnableWebhooks = "ENABLE_WEBHOOKS"
+
+	// whether to switch over the writable instances before allowing their evictions, by the pod eviction webhook.
+	CfgKeyPodEvictionSwitchover = "POD_EVICTION_SWITCHOVER"
+
+	// the interval to export the fleet status which summarizes all clusters, 0 means disabled.
+	CfgKeyFleetStatusExportInterval = "FLEET_STATUS_EXPORT_INTERVAL"
+	// the name of the ConfigMap in the namespace of the controller manager to export the fleet status to.
+	CfgKeyFleetStatusConfigMap = "FLEET_STATUS_CM_NAME"
+
+	// the timeout to drain the in-flight actions and plans when the operator is shutting down.
+	CfgKeyShutdownDrainTimeout = "SHUTDOWN_DRAIN_TIMEOUT"
+	// the name of the ConfigMap in the namespace of the controller manager to record the actions
+	// which are interrupted by the shutdown, they are not invoked again after the restart.
+	CfgKeyShutdownHandoffConfigMap = "SHUTDOWN_HANDOFF_CM_NAME"
+
 	CfgKBReconcileWorkers = "KUBEBLOCKS_RECONCILE_WORKERS"
 	CfgClientQPS          = "CLIENT_QPS"
 	CfgClientBurst        = "CLIENT_BURST"
@@ -21,6 +21,7 @@ package builder
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
@@ -67,6 +68,11 @@ func (builder *ComponentBuilder) SetSchedulingPolicy(schedulingPolicy *appsv1alp
 	return builder
 }
 
+func (builder *ComponentBuilder) SetSchedulingHints(schedulingHints *appsv1alpha1.SchedulingHints) *ComponentBuilder {
+	builder.get().Spec.SchedulingHints = schedulingHints
+	return builder
+}
+
 func (builder *ComponentBuilder) SetReplicas(replicas int32) *ComponentBuilder {
 	builder.get().Spec.Replicas = replicas
 	return builder
@@ -102,6 +108,41 @@ func (builder *ComponentBuilder) SetDisableExporter(disableExporter *bool) *Comp
 	return builder
 }
 
+func (builder *ComponentBuilder) SetSidecarResources(sidecarResources []appsv1alpha1.SidecarResources) *ComponentBuilder {
+	builder.get().Spec.SidecarResources = sidecarResources
+	return builder
+}
+
+func (builder *ComponentBuilder) SetPodTemplateOverlay(overlay *runtime.RawExtension) *ComponentBuilder {
+	builder.get().Spec.PodTemplateOverlay = overlay
+	return builder
+}
+
+func (builder *ComponentBuilder) SetKernelTuning(kernelTuning *appsv1alpha1.KernelTuning) *ComponentBuilder {
+	builder.get().Spec.KernelTuning = kernelTuning
+	return builder
+}
+
+func (builder *ComponentBuilder) SetHostNetwork(hostNetwork *appsv1alpha1.ComponentHostNetwork) *ComponentBuilder {
+	builder.get().Spec.HostNetwork = hostNetwork
+	return builder
+}
+
+func (builder *ComponentBuilder) SetReplicaWeights(weights []appsv1alpha1.ReplicaWeight) *ComponentBuilder {
+	builder.get().Spec.ReplicaWeights = weights
+	return builder
+}
+
+func (builder *ComponentBuilder) SetIPStack(ipStack *appsv1alpha1.IPStack) *ComponentBuilder {
+	builder.get().Spec.IPStack = ipStack
+	return builder
+}
+
+func (builder *ComponentBuilder) SetBackupReplica(backupReplica *bool) *ComponentBuilder {
+	builder.get().Spec.BackupReplica = backupReplica
+	return builder
+}
+
 func (builder *ComponentBuilder) SetEnabledLogs(logNames []string) *ComponentBuilder {
 	builder.get().Spec.EnabledLogs = logNames
 	return builder
@@ -175,6 +216,11 @@ func (builder *ComponentBuilder) SetOfflineInstances(offlineInstances []string)
 	return builder
 }
 
+func (builder *ComponentBuilder) SetInstanceIP(instanceIP *appsv1alpha1.InstanceIPPolicy) *ComponentBuilder {
+	builder.get().Spec.InstanceIP = instanceIP
+	return builder
+}
+
 func (builder *ComponentBuilder) SetRuntimeClassName(runtimeClassName *string) *ComponentBuilder {
 	if runtimeClassName != nil {
 		className := *runtimeClassName
@@ -114,6 +114,16 @@ func (builder *ServiceBuilder) SetPublishNotReadyAddresses(enabled bool) *Servic
 	return builder
 }
 
+func (builder *ServiceBuilder) SetIPFamilyPolicy(policy *corev1.IPFamilyPolicy) *ServiceBuilder {
+	builder.get().Spec.IPFamilyPolicy = policy
+	return builder
+}
+
+func (builder *ServiceBuilder) SetIPFamilies(families ...corev1.IPFamily) *ServiceBuilder {
+	builder.get().Spec.IPFamilies = families
+	return builder
+}
+
 func (builder *ServiceBuilder) Optimize4ExternalTraffic() *ServiceBuilder {
 	if builder.get().Spec.Type == corev1.ServiceTypeLoadBalancer && len(builder.get().Spec.ExternalTrafficPolicy) == 0 {
 		// Set externalTrafficPolicy to Local has two benefits:
@@ -54,6 +54,7 @@ var _ = Describe("service builder", func() {
 			},
 		}
 		serviceType := corev1.ServiceTypeLoadBalancer
+		ipFamilyPolicy := corev1.IPFamilyPolicyPreferDualStack
 		svc := NewHeadlessServiceBuilder(ns, name).
 			AddSelector(selectorKey1, selectorValue1).
 			AddSelectors(selectorKey2, selectorValue2, selectorKey3, selectorValue3).
@@ -62,6 +63,8 @@ var _ = Describe("service builder", func() {
 			AddContainerPorts(containerPorts...).
 			SetType(serviceType).
 			SetPublishNotReadyAddresses(true).
+			SetIPFamilyPolicy(&ipFamilyPolicy).
+			SetIPFamilies(corev1.IPv6Protocol, corev1.IPv4Protocol).
 			GetObject()
 
 		Expect(svc.Name).Should(Equal(name))
@@ -77,6 +80,8 @@ var _ = Describe("service builder", func() {
 		Expect(svc.Spec.Ports[0]).Should(Equal(ports[0]))
 		Expect(svc.Spec.Type).Should(Equal(serviceType))
 		Expect(svc.Spec.PublishNotReadyAddresses).Should(Equal(true))
+		Expect(*svc.Spec.IPFamilyPolicy).Should(Equal(ipFamilyPolicy))
+		Expect(svc.Spec.IPFamilies).Should(Equal([]corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol}))
 		Expect(svc.Spec.ExternalTrafficPolicy).Should(Equal(corev1.ServiceExternalTrafficPolicyTypeLocal))
 		hasPort := func(containerPort corev1.ContainerPort, servicePorts []corev1.ServicePort) bool {
 			for _, servicePort := range servicePorts {
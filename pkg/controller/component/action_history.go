@@ -0,0 +1,138 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/multicluster"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	lorryutil "github.com/apecloud/kubeblocks/pkg/lorry/util"
+)
+
+const (
+	// MaxActionHistoryLength is the max number of the action executions kept in the status of a Component.
+	MaxActionHistoryLength = 16
+
+	// actionEventHandledAnnotKey is used to mark the action execution event has been recorded.
+	actionEventHandledAnnotKey = "component.kubeblocks.io/action-event-handled"
+)
+
+// ActionHistoryEventHandler records the action executions reported by the agents into the status of the Component.
+type ActionHistoryEventHandler struct{}
+
+func (h *ActionHistoryEventHandler) Handle(cli client.Client, reqCtx intctrlutil.RequestCtx, _ record.EventRecorder, event *corev1.Event) error {
+	if event.Reason != lorryutil.ActionExecutedEventReason || event.InvolvedObject.Kind != "Pod" {
+		return nil
+	}
+	count := fmt.Sprintf("count-%d", event.Count)
+	if event.Annotations != nil && event.Annotations[actionEventHandledAnnotKey] == count {
+		return nil
+	}
+
+	if err := recordActionExecution(cli, reqCtx, event); err != nil {
+		return err
+	}
+
+	patch := client.MergeFrom(event.DeepCopy())
+	if event.Annotations == nil {
+		event.Annotations = map[string]string{}
+	}
+	event.Annotations[actionEventHandledAnnotKey] = count
+	return cli.Patch(reqCtx.Ctx, event, patch, multicluster.InDataContextUnspecified())
+}
+
+func recordActionExecution(cli client.Client, reqCtx intctrlutil.RequestCtx, event *corev1.Event) error {
+	execution, err := lorryutil.ParseActionExecution(event.Message)
+	if err != nil {
+		reqCtx.Log.Info("parse action execution event failed", "message", event.Message, "error", err.Error())
+		return nil
+	}
+
+	pod := &corev1.Pod{}
+	podKey := types.NamespacedName{Namespace: event.InvolvedObject.Namespace, Name: event.InvolvedObject.Name}
+	if err = cli.Get(reqCtx.Ctx, podKey, pod, multicluster.InDataContextUnspecified()); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	// the event belongs to an old pod with the same name
+	if len(event.InvolvedObject.UID) > 0 && event.InvolvedObject.UID != pod.UID {
+		return nil
+	}
+	clusterName, compName := pod.Labels[constant.AppInstanceLabelKey], pod.Labels[constant.KBAppComponentLabelKey]
+	if len(clusterName) == 0 || len(compName) == 0 {
+		return nil
+	}
+
+	comp, err := GetComponentByName(reqCtx.Ctx, cli, pod.Namespace, constant.GenerateClusterComponentName(clusterName, compName))
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	entry := appsv1alpha1.ActionExecutionRecord{
+		Action:    execution.Action,
+		PodName:   pod.Name,
+		StartTime: metav1.NewTime(execution.StartTime),
+		Duration:  metav1.Duration{Duration: time.Duration(execution.DurationMillis) * time.Millisecond},
+		Succeeded: execution.Succeeded,
+		Output:    lorryutil.TruncateActionOutput(execution.Output),
+		Error:     lorryutil.TruncateActionOutput(execution.Error),
+	}
+	history, changed := AppendActionExecution(comp.Status.ActionHistory, entry)
+	if !changed {
+		return nil
+	}
+	patch := client.MergeFrom(comp.DeepCopy())
+	comp.Status.ActionHistory = history
+	return cli.Status().Patch(reqCtx.Ctx, comp, patch)
+}
+
+// AppendActionExecution adds the entry into the action history, which is ordered by the start time and
+// bounded by MaxActionHistoryLength. It returns false if the entry exists already or is older than all the
+// records in a full history.
+func AppendActionExecution(history []appsv1alpha1.ActionExecutionRecord,
+	entry appsv1alpha1.ActionExecutionRecord) ([]appsv1alpha1.ActionExecutionRecord, bool) {
+	sameRecord := func(r appsv1alpha1.ActionExecutionRecord) bool {
+		return r.Action == entry.Action && r.PodName == entry.PodName && r.StartTime.Equal(&entry.StartTime)
+	}
+	if slices.ContainsFunc(history, sameRecord) {
+		return history, false
+	}
+	result := append(slices.Clone(history), entry)
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].StartTime.Before(&result[j].StartTime)
+	})
+	if len(result) > MaxActionHistoryLength {
+		result = result[len(result)-MaxActionHistoryLength:]
+		if !slices.ContainsFunc(result, sameRecord) {
+			return history, false
+		}
+	}
+	return result, true
+}
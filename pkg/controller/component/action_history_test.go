@@ -0,0 +1,77 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	lorryutil "github.com/apecloud/kubeblocks/pkg/lorry/util"
+)
+
+var _ = Describe("action history", func() {
+	now := time.Now().Truncate(time.Second)
+	newRecord := func(pod string, offset int) appsv1alpha1.ActionExecutionRecord {
+		return appsv1alpha1.ActionExecutionRecord{
+			Action:    "memberJoin",
+			PodName:   pod,
+			StartTime: metav1.NewTime(now.Add(time.Duration(offset) * time.Second)),
+			Succeeded: true,
+		}
+	}
+
+	It("keeps the history ordered and bounded", func() {
+		var history []appsv1alpha1.ActionExecutionRecord
+		for i := 0; i < MaxActionHistoryLength+4; i++ {
+			var changed bool
+			history, changed = AppendActionExecution(history, newRecord(fmt.Sprintf("pod-%d", i%3), i))
+			Expect(changed).Should(BeTrue())
+		}
+		Expect(history).Should(HaveLen(MaxActionHistoryLength))
+		Expect(history[0].StartTime.Time).Should(Equal(now.Add(4 * time.Second)))
+		Expect(history[MaxActionHistoryLength-1].StartTime.Time).Should(Equal(now.Add(time.Duration(MaxActionHistoryLength+3) * time.Second)))
+
+		By("the out-of-order record is inserted by the start time")
+		history, changed := AppendActionExecution(history, newRecord("pod-x", 10))
+		Expect(changed).Should(BeTrue())
+		Expect(history).Should(HaveLen(MaxActionHistoryLength))
+		Expect(history[0].StartTime.Time).Should(Equal(now.Add(5 * time.Second)))
+
+		By("the duplicated or stale record is ignored")
+		_, changed = AppendActionExecution(history, newRecord("pod-x", 10))
+		Expect(changed).Should(BeFalse())
+		_, changed = AppendActionExecution(history, newRecord("pod-y", 0))
+		Expect(changed).Should(BeFalse())
+	})
+
+	It("truncates the output", func() {
+		Expect(lorryutil.TruncateActionOutput("ok")).Should(Equal("ok"))
+		output := lorryutil.TruncateActionOutput(strings.Repeat("x", 1024))
+		Expect(len(output)).Should(BeNumerically("<", 300))
+		Expect(output).Should(HaveSuffix("...(truncated)"))
+	})
+})
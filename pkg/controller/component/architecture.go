@@ -0,0 +1,97 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+// SupportedArchitectures returns the CPU architectures supported by the images of the component definition,
+// which are declared by the addon, e.g. ["amd64", "arm64"].
+// It returns nil if they are not declared, and the pods can be placed on the nodes of any architecture.
+func SupportedArchitectures(compDef *appsv1alpha1.ComponentDefinition) []string {
+	if compDef == nil {
+		return nil
+	}
+	var archs []string
+	for _, arch := range strings.Split(compDef.Annotations[constant.SupportedArchitecturesAnnotationKey], ",") {
+		if arch = strings.TrimSpace(arch); len(arch) > 0 {
+			archs = append(archs, arch)
+		}
+	}
+	return archs
+}
+
+// buildArchitectureAffinity requires the pods to be placed on the nodes with the architectures supported by the images,
+// it's skipped if the architecture of the nodes has been specified in the scheduling policy of the component.
+func buildArchitectureAffinity(synthesizeComp *SynthesizedComponent, compDef *appsv1alpha1.ComponentDefinition) {
+	archs := SupportedArchitectures(compDef)
+	if len(archs) == 0 {
+		return
+	}
+	podSpec := synthesizeComp.PodSpec
+	if _, ok := podSpec.NodeSelector[corev1.LabelArchStable]; ok || hasArchitectureNodeAffinity(podSpec.Affinity) {
+		return
+	}
+	requirement := corev1.NodeSelectorRequirement{
+		Key:      corev1.LabelArchStable,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   archs,
+	}
+	// the affinity may be shared with the scheduling policy of the component, copy it before updating
+	affinity := &corev1.Affinity{}
+	if podSpec.Affinity != nil {
+		affinity = podSpec.Affinity.DeepCopy()
+	}
+	if affinity.NodeAffinity == nil {
+		affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	required := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if required == nil || len(required.NodeSelectorTerms) == 0 {
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{requirement}}},
+		}
+	} else {
+		// the node selector terms are ORed, the requirement should be added to each of them
+		for i := range required.NodeSelectorTerms {
+			required.NodeSelectorTerms[i].MatchExpressions = append(required.NodeSelectorTerms[i].MatchExpressions, requirement)
+		}
+	}
+	podSpec.Affinity = affinity
+}
+
+func hasArchitectureNodeAffinity(affinity *corev1.Affinity) bool {
+	if affinity == nil || affinity.NodeAffinity == nil || affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return false
+	}
+	for _, term := range affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		for _, expr := range term.MatchExpressions {
+			if expr.Key == corev1.LabelArchStable {
+				return true
+			}
+		}
+	}
+	return false
+}
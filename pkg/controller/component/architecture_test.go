@@ -0,0 +1,85 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+var _ = Describe("architecture", func() {
+	newCompDef := func(archs string) *appsv1alpha1.ComponentDefinition {
+		return &appsv1alpha1.ComponentDefinition{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "test-compdef",
+				Annotations: map[string]string{constant.SupportedArchitecturesAnnotationKey: archs},
+			},
+		}
+	}
+
+	It("parses the architectures declared by the addon", func() {
+		Expect(SupportedArchitectures(nil)).Should(BeEmpty())
+		Expect(SupportedArchitectures(newCompDef(""))).Should(BeEmpty())
+		Expect(SupportedArchitectures(newCompDef("amd64, arm64,"))).Should(Equal([]string{"amd64", "arm64"}))
+	})
+
+	It("adds the architectures to each required node selector term", func() {
+		zoneTerm := corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{
+			{Key: corev1.LabelTopologyZone, Operator: corev1.NodeSelectorOpIn, Values: []string{"zone-a"}},
+		}}
+		affinity := &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{zoneTerm, {}},
+			},
+		}}
+		synthesizeComp := &SynthesizedComponent{PodSpec: &corev1.PodSpec{Affinity: affinity}}
+		buildArchitectureAffinity(synthesizeComp, newCompDef("arm64"))
+
+		archRequirement := corev1.NodeSelectorRequirement{
+			Key: corev1.LabelArchStable, Operator: corev1.NodeSelectorOpIn, Values: []string{"arm64"},
+		}
+		terms := synthesizeComp.PodSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+		Expect(terms).Should(HaveLen(2))
+		for _, term := range terms {
+			Expect(term.MatchExpressions).Should(ContainElement(archRequirement))
+		}
+
+		By("the shared affinity of the scheduling policy is not changed")
+		Expect(affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0]).Should(Equal(zoneTerm))
+	})
+
+	It("respects the architecture specified by the user", func() {
+		synthesizeComp := &SynthesizedComponent{PodSpec: &corev1.PodSpec{
+			NodeSelector: map[string]string{corev1.LabelArchStable: "amd64"},
+		}}
+		buildArchitectureAffinity(synthesizeComp, newCompDef("amd64,arm64"))
+		Expect(synthesizeComp.PodSpec.Affinity).Should(BeNil())
+
+		synthesizeComp = &SynthesizedComponent{PodSpec: &corev1.PodSpec{}}
+		buildArchitectureAffinity(synthesizeComp, newCompDef(""))
+		Expect(synthesizeComp.PodSpec.Affinity).Should(BeNil())
+	})
+})
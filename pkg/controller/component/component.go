@@ -85,7 +85,14 @@ func BuildComponent(cluster *appsv1alpha1.Cluster, compSpec *appsv1alpha1.Cluste
 		SetAnnotations(compSpec.Annotations).
 		SetEnv(compSpec.Env).
 		SetSchedulingPolicy(schedulingPolicy).
+		SetSchedulingHints(compSpec.SchedulingHints).
 		SetDisableExporter(compSpec.GetDisableExporter()).
+		SetSidecarResources(compSpec.SidecarResources).
+		SetPodTemplateOverlay(compSpec.PodTemplateOverlay).
+		SetKernelTuning(compSpec.KernelTuning).
+		SetHostNetwork(compSpec.HostNetwork).
+		SetReplicaWeights(compSpec.ReplicaWeights).
+		SetBackupReplica(compSpec.BackupReplica).
 		SetReplicas(compSpec.Replicas).
 		SetResources(compSpec.Resources).
 		SetServiceAccountName(compSpec.ServiceAccountName).
@@ -99,7 +106,9 @@ func BuildComponent(cluster *appsv1alpha1.Cluster, compSpec *appsv1alpha1.Cluste
 		SetTLSConfig(compSpec.TLS, compSpec.Issuer).
 		SetInstances(compSpec.Instances).
 		SetOfflineInstances(compSpec.OfflineInstances).
+		SetInstanceIP(compSpec.InstanceIP).
 		SetRuntimeClassName(cluster.Spec.RuntimeClassName).
+		SetIPStack(cluster.Spec.IPStack).
 		SetSystemAccounts(compSpec.SystemAccounts).
 		SetStop(compSpec.Stop)
 	if labels != nil {
@@ -113,6 +122,9 @@ func BuildComponent(cluster *appsv1alpha1.Cluster, compSpec *appsv1alpha1.Cluste
 		if ok {
 			compBuilder.AddAnnotations(constant.KBAppMultiClusterPlacementKey, p)
 		}
+		if intctrlutil.IsComponentToAdopt(cluster, compSpec.Name) {
+			compBuilder.AddAnnotations(constant.AdoptStatefulSetAnnotationKey, "true")
+		}
 	}
 	if !IsGenerated(compBuilder.GetObject()) {
 		compBuilder.SetServices(compSpec.Services)
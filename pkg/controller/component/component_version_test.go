@@ -20,6 +20,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package component
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -126,5 +128,45 @@ var _ = Describe("Component Version", func() {
 			Expect(err).Should(Succeed())
 			Expect(compDefObj.Spec.Runtime.Containers[0].Image).Should(Equal(releases[2].Images[testapps.AppName]))
 		})
+
+		It("resolve service version risks", func() {
+			compVersionObj := &appsv1alpha1.ComponentVersion{
+				Spec: appsv1alpha1.ComponentVersionSpec{
+					Releases: []appsv1alpha1.ComponentVersionRelease{
+						{Name: "r0", ServiceVersion: "8.0.30", EndOfLifeDate: "2024-04-30", CVEs: []string{"CVE-2024-20961"}},
+						{Name: "r1", ServiceVersion: "8.0.30", CVEs: []string{"CVE-2024-20961", "CVE-2024-20960"}},
+						{Name: "r2", ServiceVersion: "8.0.36", EndOfLifeDate: "2026-04-30"},
+					},
+				},
+			}
+			compVersions := []*appsv1alpha1.ComponentVersion{compVersionObj}
+			now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+			By("end of life and known vulnerabilities")
+			risk, nextEndOfLife, err := resolveServiceVersionRisk(compVersions, "8.0.30", now)
+			Expect(err).Should(Succeed())
+			Expect(nextEndOfLife.IsZero()).Should(BeTrue())
+			Expect(risk).ShouldNot(BeNil())
+			Expect(risk.EndOfLifeDate).Should(Equal("2024-04-30"))
+			Expect(risk.CVEs).Should(Equal([]string{"CVE-2024-20960", "CVE-2024-20961"}))
+			Expect(risk.Reason()).Should(Equal(ReasonServiceVersionEndOfLife))
+
+			By("not reached the end of life yet")
+			risk, nextEndOfLife, err = resolveServiceVersionRisk(compVersions, "8.0.36", now)
+			Expect(err).Should(Succeed())
+			Expect(risk).Should(BeNil())
+			Expect(nextEndOfLife.Format("2006-01-02")).Should(Equal(compVersionObj.Spec.Releases[2].EndOfLifeDate))
+
+			By("reached the end of life")
+			risk, _, err = resolveServiceVersionRisk(compVersions, "8.0.36", now.AddDate(2, 0, 0))
+			Expect(err).Should(Succeed())
+			Expect(risk).ShouldNot(BeNil())
+			Expect(risk.CVEs).Should(BeEmpty())
+
+			By("invalid end of life date")
+			compVersionObj.Spec.Releases[2].EndOfLifeDate = "2026/04/30"
+			_, _, err = resolveServiceVersionRisk(compVersions, "8.0.36", now)
+			Expect(err).ShouldNot(Succeed())
+		})
 	})
 })
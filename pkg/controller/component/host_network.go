@@ -0,0 +1,66 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"fmt"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+// ValidateHostNetwork checks the host-network mode of the component.
+func ValidateHostNetwork(hostNetwork *appsv1alpha1.ComponentHostNetwork) error {
+	if hostNetwork == nil {
+		return nil
+	}
+	if len(hostNetwork.StaticPorts) > 0 && !hostNetwork.Enabled {
+		return fmt.Errorf("invalid hostNetwork: the static ports are specified but the host-network is not enabled")
+	}
+	ports := map[string]bool{}
+	hostPorts := map[int32]bool{}
+	for _, p := range hostNetwork.StaticPorts {
+		if p.HostPort < 1 || p.HostPort > 65535 {
+			return fmt.Errorf("invalid hostNetwork: the host port %d of %s/%s is out of range", p.HostPort, p.Container, p.Port)
+		}
+		key := fmt.Sprintf("%s/%s", p.Container, p.Port)
+		if ports[key] {
+			return fmt.Errorf("invalid hostNetwork: the static port of %s is duplicated", key)
+		}
+		if hostPorts[p.HostPort] {
+			return fmt.Errorf("invalid hostNetwork: the host port %d is duplicated", p.HostPort)
+		}
+		ports[key] = true
+		hostPorts[p.HostPort] = true
+	}
+	return nil
+}
+
+// GetStaticHostPort returns the static host port of the container port, and 0 if not specified.
+func GetStaticHostPort(synthesizedComp *SynthesizedComponent, container, port string) int32 {
+	if synthesizedComp.HostNetworkMode == nil {
+		return 0
+	}
+	for _, p := range synthesizedComp.HostNetworkMode.StaticPorts {
+		if p.Container == container && p.Port == port {
+			return p.HostPort
+		}
+	}
+	return 0
+}
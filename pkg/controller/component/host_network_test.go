@@ -0,0 +1,79 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+var _ = Describe("host network", func() {
+	It("validates the static ports", func() {
+		Expect(ValidateHostNetwork(nil)).Should(Succeed())
+		Expect(ValidateHostNetwork(&appsv1alpha1.ComponentHostNetwork{Enabled: true})).Should(Succeed())
+		Expect(ValidateHostNetwork(&appsv1alpha1.ComponentHostNetwork{
+			Enabled: true,
+			StaticPorts: []appsv1alpha1.HostNetworkStaticPort{
+				{Container: "mysql", Port: "mysql", HostPort: 3306},
+				{Container: "mysql", Port: "paxos", HostPort: 13306},
+			},
+		})).Should(Succeed())
+
+		for _, hostNetwork := range []*appsv1alpha1.ComponentHostNetwork{
+			{StaticPorts: []appsv1alpha1.HostNetworkStaticPort{{Container: "mysql", Port: "mysql", HostPort: 3306}}},
+			{Enabled: true, StaticPorts: []appsv1alpha1.HostNetworkStaticPort{{Container: "mysql", Port: "mysql", HostPort: 0}}},
+			{Enabled: true, StaticPorts: []appsv1alpha1.HostNetworkStaticPort{
+				{Container: "mysql", Port: "mysql", HostPort: 3306},
+				{Container: "mysql", Port: "mysql", HostPort: 3307},
+			}},
+			{Enabled: true, StaticPorts: []appsv1alpha1.HostNetworkStaticPort{
+				{Container: "mysql", Port: "mysql", HostPort: 3306},
+				{Container: "mysql", Port: "paxos", HostPort: 3306},
+			}},
+		} {
+			Expect(ValidateHostNetwork(hostNetwork)).ShouldNot(Succeed())
+		}
+	})
+
+	It("enables the host network by the component spec", func() {
+		synthesizedComp := &SynthesizedComponent{
+			Name:        "mysql",
+			PodSpec:     &corev1.PodSpec{},
+			HostNetwork: &appsv1alpha1.HostNetwork{},
+		}
+		Expect(IsHostNetworkEnabled(synthesizedComp)).Should(BeFalse())
+
+		synthesizedComp.HostNetworkMode = &appsv1alpha1.ComponentHostNetwork{
+			Enabled:     true,
+			StaticPorts: []appsv1alpha1.HostNetworkStaticPort{{Container: "mysql", Port: "mysql", HostPort: 3306}},
+		}
+		Expect(IsHostNetworkEnabled(synthesizedComp)).Should(BeTrue())
+		Expect(GetStaticHostPort(synthesizedComp, "mysql", "mysql")).Should(BeEquivalentTo(3306))
+		Expect(GetStaticHostPort(synthesizedComp, "mysql", "paxos")).Should(BeEquivalentTo(0))
+
+		By("the component definition doesn't have the host-network capability")
+		synthesizedComp.HostNetwork = nil
+		Expect(IsHostNetworkEnabled(synthesizedComp)).Should(BeFalse())
+	})
+})
@@ -0,0 +1,100 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+const (
+	ipv4BindAddress = "0.0.0.0"
+	ipv6BindAddress = "::"
+)
+
+// ValidateIPStack checks the IP families and the IP family policy.
+func ValidateIPStack(ipStack *appsv1alpha1.IPStack) error {
+	if ipStack == nil {
+		return nil
+	}
+	for i, family := range ipStack.IPFamilies {
+		if family != corev1.IPv4Protocol && family != corev1.IPv6Protocol {
+			return fmt.Errorf("invalid ipStack: unsupported IP family %s", family)
+		}
+		if slices.Contains(ipStack.IPFamilies[:i], family) {
+			return fmt.Errorf("invalid ipStack: the IP family %s is duplicated", family)
+		}
+	}
+	policy := ipStack.IPFamilyPolicy
+	if len(ipStack.IPFamilies) > 1 && (policy == nil || *policy == corev1.IPFamilyPolicySingleStack) {
+		return fmt.Errorf("invalid ipStack: two IP families require the PreferDualStack or RequireDualStack policy")
+	}
+	return nil
+}
+
+// ApplyIPStack sets the IP families and the IP family policy on the Service if it doesn't specify them.
+func ApplyIPStack(ipStack *appsv1alpha1.IPStack, svc *corev1.ServiceSpec) {
+	if ipStack == nil || svc.Type == corev1.ServiceTypeExternalName {
+		return
+	}
+	if svc.IPFamilyPolicy == nil && ipStack.IPFamilyPolicy != nil {
+		policy := *ipStack.IPFamilyPolicy
+		svc.IPFamilyPolicy = &policy
+	}
+	if len(svc.IPFamilies) == 0 && len(ipStack.IPFamilies) > 0 {
+		svc.IPFamilies = slices.Clone(ipStack.IPFamilies)
+	}
+}
+
+// BindAddress returns the wildcard address which the engines should bind to with the IP stack,
+// the IPv6 wildcard address also accepts the IPv4 connections on the dual-stack networks.
+func BindAddress(ipStack *appsv1alpha1.IPStack) string {
+	if ipStack == nil {
+		return ipv4BindAddress
+	}
+	if slices.Contains(ipStack.IPFamilies, corev1.IPv6Protocol) {
+		return ipv6BindAddress
+	}
+	if len(ipStack.IPFamilies) == 0 && ipStack.IPFamilyPolicy != nil && *ipStack.IPFamilyPolicy != corev1.IPFamilyPolicySingleStack {
+		return ipv6BindAddress
+	}
+	return ipv4BindAddress
+}
+
+// FormatIPStack formats the IP stack as the value of the annotation IPStackAnnotationKey of the InstanceSet.
+func FormatIPStack(ipStack *appsv1alpha1.IPStack) string {
+	if ipStack == nil {
+		return ""
+	}
+	var policy string
+	if ipStack.IPFamilyPolicy != nil {
+		policy = string(*ipStack.IPFamilyPolicy)
+	}
+	families := make([]string, 0, len(ipStack.IPFamilies))
+	for _, family := range ipStack.IPFamilies {
+		families = append(families, string(family))
+	}
+	return policy + ":" + strings.Join(families, ",")
+}
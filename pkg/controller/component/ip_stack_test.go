@@ -0,0 +1,95 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+var _ = Describe("ip stack", func() {
+	policy := func(p corev1.IPFamilyPolicy) *corev1.IPFamilyPolicy {
+		return &p
+	}
+
+	It("validates the IP stack", func() {
+		Expect(ValidateIPStack(nil)).Should(Succeed())
+		Expect(ValidateIPStack(&appsv1alpha1.IPStack{
+			IPFamilies: []corev1.IPFamily{corev1.IPv6Protocol},
+		})).Should(Succeed())
+		Expect(ValidateIPStack(&appsv1alpha1.IPStack{
+			IPFamilyPolicy: policy(corev1.IPFamilyPolicyRequireDualStack),
+			IPFamilies:     []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol},
+		})).Should(Succeed())
+		Expect(ValidateIPStack(&appsv1alpha1.IPStack{
+			IPFamilies: []corev1.IPFamily{"IPv5"},
+		})).ShouldNot(Succeed())
+		Expect(ValidateIPStack(&appsv1alpha1.IPStack{
+			IPFamilyPolicy: policy(corev1.IPFamilyPolicyPreferDualStack),
+			IPFamilies:     []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv4Protocol},
+		})).ShouldNot(Succeed())
+		Expect(ValidateIPStack(&appsv1alpha1.IPStack{
+			IPFamilyPolicy: policy(corev1.IPFamilyPolicySingleStack),
+			IPFamilies:     []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol},
+		})).ShouldNot(Succeed())
+	})
+
+	It("applies the IP stack to the services", func() {
+		ipStack := &appsv1alpha1.IPStack{
+			IPFamilyPolicy: policy(corev1.IPFamilyPolicyPreferDualStack),
+			IPFamilies:     []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol},
+		}
+		svc := &corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP}
+		ApplyIPStack(ipStack, svc)
+		Expect(*svc.IPFamilyPolicy).Should(Equal(corev1.IPFamilyPolicyPreferDualStack))
+		Expect(svc.IPFamilies).Should(Equal(ipStack.IPFamilies))
+
+		By("the IP families of the service take precedence")
+		svc = &corev1.ServiceSpec{
+			Type:           corev1.ServiceTypeClusterIP,
+			IPFamilyPolicy: policy(corev1.IPFamilyPolicySingleStack),
+			IPFamilies:     []corev1.IPFamily{corev1.IPv4Protocol},
+		}
+		ApplyIPStack(ipStack, svc)
+		Expect(*svc.IPFamilyPolicy).Should(Equal(corev1.IPFamilyPolicySingleStack))
+		Expect(svc.IPFamilies).Should(Equal([]corev1.IPFamily{corev1.IPv4Protocol}))
+
+		By("the ExternalName service is skipped")
+		svc = &corev1.ServiceSpec{Type: corev1.ServiceTypeExternalName}
+		ApplyIPStack(ipStack, svc)
+		Expect(svc.IPFamilyPolicy).Should(BeNil())
+		Expect(svc.IPFamilies).Should(BeEmpty())
+	})
+
+	It("derives the bind address", func() {
+		Expect(BindAddress(nil)).Should(Equal("0.0.0.0"))
+		Expect(BindAddress(&appsv1alpha1.IPStack{IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol}})).Should(Equal("0.0.0.0"))
+		Expect(BindAddress(&appsv1alpha1.IPStack{IPFamilies: []corev1.IPFamily{corev1.IPv6Protocol}})).Should(Equal("::"))
+		Expect(BindAddress(&appsv1alpha1.IPStack{IPFamilyPolicy: policy(corev1.IPFamilyPolicyRequireDualStack)})).Should(Equal("::"))
+		Expect(FormatIPStack(&appsv1alpha1.IPStack{
+			IPFamilyPolicy: policy(corev1.IPFamilyPolicyRequireDualStack),
+			IPFamilies:     []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol},
+		})).Should(Equal("RequireDualStack:IPv6,IPv4"))
+	})
+})
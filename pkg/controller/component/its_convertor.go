@@ -48,6 +48,7 @@ func BuildWorkloadFrom(synthesizeComp *SynthesizedComponent, protoITS *workloads
 		"credential":                       &itsCredentialConvertor{},
 		"membershipreconfiguration":        &itsMembershipReconfigurationConvertor{},
 		"memberupdatestrategy":             &itsMemberUpdateStrategyConvertor{},
+		"quorumguard":                      &itsQuorumGuardConvertor{},
 		"podmanagementpolicy":              &itsPodManagementPolicyConvertor{},
 		"parallelpodmanagementconcurrency": &itsParallelPodManagementConcurrencyConvertor{},
 		"podupdatepolicy":                  &itsPodUpdatePolicyConvertor{},
@@ -90,6 +91,23 @@ func (c *itsMemberUpdateStrategyConvertor) convert(args ...any) (any, error) {
 	return getMemberUpdateStrategy(synthesizeComp), nil
 }
 
+// itsQuorumGuardConvertor is an implementation of the convertor interface, used to convert the given object into InstanceSet.Spec.QuorumGuard.
+type itsQuorumGuardConvertor struct{}
+
+// convert guards the quorum of the components which have the votable roles, the quorum defaults to the majority.
+func (c *itsQuorumGuardConvertor) convert(args ...any) (any, error) {
+	synthesizedComp, err := parseITSConvertorArgs(args...)
+	if err != nil {
+		return nil, err
+	}
+	for _, role := range synthesizedComp.Roles {
+		if role.Votable {
+			return &workloads.QuorumGuard{CheckSyncedReplicas: true}, nil
+		}
+	}
+	return nil, nil
+}
+
 // itsPodManagementPolicyConvertor is an implementation of the convertor interface, used to convert the given object into InstanceSet.Spec.PodManagementPolicy.
 type itsPodManagementPolicyConvertor struct{}
 
@@ -0,0 +1,326 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+	kbagent "github.com/apecloud/kubeblocks/pkg/kb_agent/client"
+	kbagentutil "github.com/apecloud/kubeblocks/pkg/kb_agent/util"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const kbAgentVolume = "kubeblocks"
+
+// IsMigratingToKBAgent checks whether the component is requested to run the kb-agent instead of the lorry containers.
+func IsMigratingToKBAgent(comp *appsv1alpha1.Component) bool {
+	return comp != nil && comp.Annotations[constant.MigrateToKBAgentAnnotationKey] == "true"
+}
+
+// CanMigrateToKBAgent checks whether the actions of the component can be served by the kb-agent.
+// Only the components whose actions are all defined as exec actions can be migrated, the built-in handlers
+// are implemented by lorry only.
+func CanMigrateToKBAgent(synthesizeComp *SynthesizedComponent) bool {
+	return getBuiltinActionHandler(synthesizeComp) == appsv1alpha1.CustomActionHandler
+}
+
+// buildAgentContainers builds the kb-agent container for the component migrating to the kb-agent,
+// and falls back to the lorry containers otherwise.
+func buildAgentContainers(reqCtx intctrlutil.RequestCtx, synthesizeComp *SynthesizedComponent,
+	comp *appsv1alpha1.Component, clusterCompSpec *appsv1alpha1.ClusterComponentSpec) error {
+	if !IsMigratingToKBAgent(comp) {
+		return buildLorryContainers(reqCtx, synthesizeComp, clusterCompSpec)
+	}
+	if !CanMigrateToKBAgent(synthesizeComp) {
+		reqCtx.Log.Info("the actions of the component are served by the built-in handlers of lorry, skip migrating to kb-agent")
+		return buildLorryContainers(reqCtx, synthesizeComp, clusterCompSpec)
+	}
+	return buildKBAgentContainers(reqCtx, synthesizeComp)
+}
+
+// buildKBAgentContainers builds the kb-agent container, which executes the exec actions of the component
+// within the image of the actions, and serves them through the HTTP API only.
+func buildKBAgentContainers(reqCtx intctrlutil.RequestCtx, synthesizeComp *SynthesizedComponent) error {
+	handlers, execImage, containerName := buildKBAgentHandlers(synthesizeComp)
+	if len(handlers) == 0 {
+		return nil
+	}
+	execContainer := getExecContainer(synthesizeComp.PodSpec.Containers, containerName)
+	if execImage == "" {
+		if execContainer == nil {
+			return fmt.Errorf("the container %s to execute the actions is not found", containerName)
+		}
+		execImage = execContainer.Image
+	}
+
+	port := viper.GetInt32(constant.KBEnvLorryHTTPPort)
+	if synthesizeComp.PodSpec.HostNetwork {
+		port = 51
+	}
+	availablePorts, err := getAvailableContainerPorts(synthesizeComp.PodSpec.Containers, []int32{port})
+	if err != nil {
+		reqCtx.Log.Info("get kb-agent container port failed", "error", err)
+		return err
+	}
+	port = availablePorts[0]
+
+	handlersJSON, err := json.Marshal(handlers)
+	if err != nil {
+		return err
+	}
+	synthesizeComp.KBAgentHandlers = string(handlersJSON)
+	volumeMount := corev1.VolumeMount{Name: kbAgentVolume, MountPath: "/kubeblocks"}
+	container := corev1.Container{
+		Name:            constant.KBAgentContainerName,
+		Image:           execImage,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         []string{"/kubeblocks/kb_agent", "--port", strconv.Itoa(int(port))},
+		Ports: []corev1.ContainerPort{
+			{
+				ContainerPort: port,
+				Name:          constant.KBAgentHTTPPortName,
+				Protocol:      corev1.ProtocolTCP,
+			},
+		},
+		Env: []corev1.EnvVar{
+			{
+				// the handlers are read from the annotation of the pod, which is updated in place,
+				// so that the updated actions and probes don't change the container and restart the pod.
+				Name: constant.KBEnvActionHandlers,
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{
+						FieldPath: fmt.Sprintf("metadata.annotations['%s']", constant.KBAgentHandlersAnnotationKey),
+					},
+				},
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{volumeMount},
+		StartupProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(int(port))},
+			},
+		},
+	}
+	if repo := synthesizeComp.ArtifactBackupRepo; repo != nil {
+		injectArtifactBackupRepo(synthesizeComp.PodSpec, &container, repo)
+	}
+	if execContainer != nil {
+		// the actions run in the kb-agent container, they see the same environment and volumes as the exec container.
+		envSet := sets.New(constant.KBEnvActionHandlers)
+		for _, env := range execContainer.Env {
+			if !envSet.Has(env.Name) {
+				container.Env = append(container.Env, env)
+			}
+		}
+		for _, vm := range execContainer.VolumeMounts {
+			if vm.Name != kbAgentVolume {
+				container.VolumeMounts = append(container.VolumeMounts, vm)
+			}
+		}
+	}
+
+	initContainer := corev1.Container{
+		Name:            constant.KBAgentInitContainerName,
+		Image:           viper.GetString(constant.KBToolsImage),
+		ImagePullPolicy: corev1.PullPolicy(viper.GetString(constant.KBImagePullPolicy)),
+		Command:         []string{"cp", "-r", "/bin/kb_agent", "/bin/curl", "/kubeblocks/"},
+		VolumeMounts:    []corev1.VolumeMount{volumeMount},
+	}
+
+	if !slices.ContainsFunc(synthesizeComp.PodSpec.Volumes, func(v corev1.Volume) bool { return v.Name == kbAgentVolume }) {
+		synthesizeComp.PodSpec.Volumes = append(synthesizeComp.PodSpec.Volumes, corev1.Volume{
+			Name:         kbAgentVolume,
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		})
+	}
+	synthesizeComp.PodSpec.InitContainers = append(synthesizeComp.PodSpec.InitContainers, initContainer)
+	synthesizeComp.PodSpec.Containers = append(synthesizeComp.PodSpec.Containers, container)
+
+	if synthesizeComp.HostNetwork != nil {
+		synthesizeComp.HostNetwork.ContainerPorts = append(synthesizeComp.HostNetwork.ContainerPorts,
+			appsv1alpha1.HostNetworkContainerPort{
+				Container: container.Name,
+				Ports:     []string{constant.KBAgentHTTPPortName},
+			})
+	}
+	reqCtx.Log.V(1).Info("kb-agent", "container", container)
+	return nil
+}
+
+// getArtifactBackupRepo returns the BackupRepo the kb-agent uploads the artifacts of the actions to, which is the repo
+// of the cluster backup, or the default repo if not specified. Only the repos accessed by tool are supported,
+// the repos accessed by mount need the PVC of the repo to be created in the namespace before the pods can start.
+func getArtifactBackupRepo(ctx context.Context, cli client.Reader, cluster *appsv1alpha1.Cluster) (*dpv1alpha1.BackupRepo, error) {
+	var repo *dpv1alpha1.BackupRepo
+	if cluster != nil && cluster.Spec.Backup != nil && cluster.Spec.Backup.RepoName != "" {
+		repo = &dpv1alpha1.BackupRepo{}
+		if err := cli.Get(ctx, client.ObjectKey{Name: cluster.Spec.Backup.RepoName}, repo); err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+	} else {
+		repos := &dpv1alpha1.BackupRepoList{}
+		if err := cli.List(ctx, repos); err != nil {
+			return nil, err
+		}
+		for i := range repos.Items {
+			if repos.Items[i].Status.IsDefault {
+				repo = &repos.Items[i]
+				break
+			}
+		}
+	}
+	if repo == nil || repo.Status.Phase != dpv1alpha1.BackupRepoReady || !repo.AccessByTool() {
+		return nil, nil
+	}
+	return repo, nil
+}
+
+// injectArtifactBackupRepo injects datasafed and the config to access the BackupRepo into the kb-agent container.
+// The config secret is created in the namespace once a backup is taken to the repo, it's mounted optionally,
+// so that the pods can start without it, the kb-agent fails to upload the artifacts until it's created then.
+func injectArtifactBackupRepo(podSpec *corev1.PodSpec, container *corev1.Container, repo *dpv1alpha1.BackupRepo) {
+	agentPodSpec := &corev1.PodSpec{Containers: []corev1.Container{*container}}
+	dputils.InjectDatasafedWithConfig(agentPodSpec, repo.Status.ToolConfigSecretName, "")
+	for _, v := range agentPodSpec.Volumes {
+		if v.Secret != nil {
+			v.Secret.Optional = pointer.Bool(true)
+		}
+		if !slices.ContainsFunc(podSpec.Volumes, func(vol corev1.Volume) bool { return vol.Name == v.Name }) {
+			podSpec.Volumes = append(podSpec.Volumes, v)
+		}
+	}
+	podSpec.InitContainers = append(podSpec.InitContainers, agentPodSpec.InitContainers...)
+	*container = agentPodSpec.Containers[0]
+}
+
+// buildKBAgentHandlers builds the handler specs of the exec actions for the kb-agent, the role probe is
+// run periodically as a cron job of the kb-agent.
+func buildKBAgentHandlers(synthesizeComp *SynthesizedComponent) (map[string]kbagentutil.HandlerSpec, string, string) {
+	actionCommands, execImage, containerName := getActionCommandsWithExecImageOrContainerName(synthesizeComp)
+	actions := getLifecycleActionHandlers(synthesizeComp)
+	handlers := make(map[string]kbagentutil.HandlerSpec)
+	for action, command := range actionCommands {
+		handlers[action] = buildKBAgentHandlerSpec(command, actions[action].CustomHandler)
+	}
+	if roleProbe, ok := handlers[constant.RoleProbeAction]; ok {
+		probe := synthesizeComp.LifecycleActions.RoleProbe
+		roleProbe.TimeoutSeconds = int(probe.TimeoutSeconds)
+		roleProbe.CronJob = &kbagentutil.CronJob{
+			PeriodSeconds:    int(probe.PeriodSeconds),
+			SuccessThreshold: int(probe.SuccessThreshold),
+			FailureThreshold: int(probe.FailureThreshold),
+		}
+		handlers[constant.RoleProbeAction] = roleProbe
+	}
+	return handlers, execImage, containerName
+}
+
+// buildKBAgentHandlerSpec builds the handler spec of the exec action, with the timeout, the retry policy and
+// the sandbox of the action.
+func buildKBAgentHandlerSpec(command []string, action *appsv1alpha1.Action) kbagentutil.HandlerSpec {
+	spec := kbagentutil.HandlerSpec{
+		Command:        command,
+		TimeoutSeconds: int(action.TimeoutSeconds),
+		Parameters:     buildKBAgentActionParameters(action),
+	}
+	if action.RetryPolicy != nil && action.RetryPolicy.MaxRetries > 0 {
+		spec.RetryPolicy = &kbagentutil.RetryPolicy{
+			MaxRetries:           action.RetryPolicy.MaxRetries,
+			RetryIntervalSeconds: int(action.RetryPolicy.RetryInterval / time.Second),
+		}
+	}
+	if sandbox := action.Sandbox; sandbox != nil {
+		// the resources are always set to enable the sandbox, which runs the action as a non-root user by default.
+		spec.Resources = &kbagentutil.ResourceLimits{CPUSeconds: sandbox.CPUSeconds}
+		if sandbox.Memory != nil {
+			spec.Resources.MemoryBytes = sandbox.Memory.Value()
+		}
+		spec.RunAsUser = sandbox.RunAsUser
+		spec.RunAsGroup = sandbox.RunAsGroup
+	}
+	return spec
+}
+
+// buildKBAgentActionParameters declares the environment variables defined by the exec action as its parameters,
+// since the kb-agent passes the parameters of a call to the command as the environment variables with the same names.
+func buildKBAgentActionParameters(action *appsv1alpha1.Action) map[string]kbagentutil.ParameterSpec {
+	if action.Exec == nil || len(action.Exec.Env) == 0 {
+		return nil
+	}
+	parameters := make(map[string]kbagentutil.ParameterSpec, len(action.Exec.Env))
+	for _, env := range action.Exec.Env {
+		parameters[env.Name] = kbagentutil.ParameterSpec{
+			Type:        "string",
+			Description: fmt.Sprintf("passed to the command as the environment variable %s", env.Name),
+		}
+	}
+	return parameters
+}
+
+// RefreshKBAgents pushes the handler specs of the actions and the probes to the kb-agents of the running pods,
+// so that the updated actions and probes take effect without restarting the pods.
+// The pods not running yet read the handler specs from their annotation on start, and the kb-agents of
+// the earlier versions, which can't be refreshed, keep the handler specs until they are restarted.
+func RefreshKBAgents(ctx context.Context, cli client.Reader, synthesizeComp *SynthesizedComponent, handlersJSON string) error {
+	handlers := map[string]kbagentutil.HandlerSpec{}
+	if err := json.Unmarshal([]byte(handlersJSON), &handlers); err != nil {
+		return err
+	}
+	pods, err := ListOwnedPods(ctx, cli, synthesizeComp.Namespace, synthesizeComp.ClusterName, synthesizeComp.Name)
+	if err != nil {
+		return err
+	}
+	for _, pod := range pods {
+		if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" {
+			continue
+		}
+		agent, err := kbagent.NewClient(*pod)
+		if err != nil {
+			return err
+		}
+		if agent == nil {
+			continue
+		}
+		if err = agent.Refresh(ctx, handlers); err != nil {
+			if errors.Is(err, kbagent.ErrRefreshNotSupported) {
+				continue
+			}
+			return errors.Wrapf(err, "refresh the kb-agent of pod %s", pod.Name)
+		}
+	}
+	return nil
+}
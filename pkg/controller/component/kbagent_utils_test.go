@@ -0,0 +1,188 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	kbagent "github.com/apecloud/kubeblocks/pkg/kb_agent/client"
+	kbagentutil "github.com/apecloud/kubeblocks/pkg/kb_agent/util"
+)
+
+type refreshAgentClient struct {
+	refreshed []map[string]kbagentutil.HandlerSpec
+}
+
+func (c *refreshAgentClient) Action(_ context.Context, _ string, _ map[string]any) (string, error) {
+	return "", nil
+}
+
+func (c *refreshAgentClient) Refresh(_ context.Context, handlers map[string]kbagentutil.HandlerSpec) error {
+	c.refreshed = append(c.refreshed, handlers)
+	return nil
+}
+
+var _ = Describe("kb-agent utils", func() {
+	var (
+		reqCtx         intctrlutil.RequestCtx
+		synthesizeComp *SynthesizedComponent
+		migratingComp  *appsv1alpha1.Component
+	)
+
+	BeforeEach(func() {
+		reqCtx = intctrlutil.RequestCtx{Ctx: ctx, Log: logger}
+		synthesizeComp = &SynthesizedComponent{
+			PodSpec: &corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "main", Image: "main-image"}},
+			},
+			LifecycleActions: &appsv1alpha1.ComponentLifecycleActions{
+				RoleProbe: &appsv1alpha1.Probe{
+					Action: appsv1alpha1.Action{
+						Exec:           &appsv1alpha1.ExecAction{Command: []string{"role.sh"}},
+						TimeoutSeconds: 2,
+					},
+					PeriodSeconds: 5,
+				},
+			},
+		}
+		migratingComp = &appsv1alpha1.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{constant.MigrateToKBAgentAnnotationKey: "true"},
+			},
+		}
+	})
+
+	It("builds the lorry containers if the component is not migrating", func() {
+		Expect(buildAgentContainers(reqCtx, synthesizeComp, &appsv1alpha1.Component{}, nil)).Should(Succeed())
+		Expect(synthesizeComp.PodSpec.Containers[1].Name).Should(Equal(constant.LorryContainerName))
+	})
+
+	It("builds the kb-agent container if the component is migrating", func() {
+		Expect(buildAgentContainers(reqCtx, synthesizeComp, migratingComp, nil)).Should(Succeed())
+		Expect(synthesizeComp.PodSpec.Containers).Should(HaveLen(2))
+		container := synthesizeComp.PodSpec.Containers[1]
+		Expect(container.Name).Should(Equal(constant.KBAgentContainerName))
+		Expect(container.Image).Should(Equal("main-image"))
+		Expect(container.Ports).Should(HaveLen(1))
+		Expect(synthesizeComp.PodSpec.InitContainers[0].Name).Should(Equal(constant.KBAgentInitContainerName))
+		Expect(synthesizeComp.PodSpec.Volumes[0].Name).Should(Equal(kbAgentVolume))
+
+		Expect(container.Env[0].ValueFrom.FieldRef.FieldPath).Should(ContainSubstring(constant.KBAgentHandlersAnnotationKey))
+		handlers := map[string]kbagentutil.HandlerSpec{}
+		Expect(json.Unmarshal([]byte(synthesizeComp.KBAgentHandlers), &handlers)).Should(Succeed())
+		Expect(handlers[constant.RoleProbeAction].Command).Should(Equal([]string{"role.sh"}))
+		Expect(handlers[constant.RoleProbeAction].CronJob.PeriodSeconds).Should(Equal(5))
+	})
+
+	It("injects datasafed into the kb-agent container to upload the artifacts", func() {
+		synthesizeComp.ArtifactBackupRepo = &dpv1alpha1.BackupRepo{
+			Status: dpv1alpha1.BackupRepoStatus{ToolConfigSecretName: "tool-config"},
+		}
+		Expect(buildAgentContainers(reqCtx, synthesizeComp, migratingComp, nil)).Should(Succeed())
+		Expect(synthesizeComp.PodSpec.Containers[0].Env).Should(BeEmpty())
+		container := synthesizeComp.PodSpec.Containers[1]
+		Expect(container.Env).Should(ContainElement(HaveField("Name", dptypes.DPDatasafedBinPath)))
+		Expect(container.VolumeMounts).Should(HaveLen(3))
+		Expect(synthesizeComp.PodSpec.InitContainers).Should(HaveLen(2))
+		for _, v := range synthesizeComp.PodSpec.Volumes {
+			if v.Secret != nil {
+				Expect(v.Secret.SecretName).Should(Equal("tool-config"))
+				Expect(*v.Secret.Optional).Should(BeTrue())
+			}
+		}
+	})
+
+	It("builds the handler specs with the retry policy and the sandbox of the actions", func() {
+		uid := int64(1001)
+		memory := resource.MustParse("64Mi")
+		synthesizeComp.LifecycleActions.PostProvision = &appsv1alpha1.LifecycleActionHandler{
+			CustomHandler: &appsv1alpha1.Action{
+				Exec: &appsv1alpha1.ExecAction{
+					Command: []string{"init.sh"},
+					Env:     []corev1.EnvVar{{Name: "INIT_MODE", Value: "fast"}},
+				},
+				TimeoutSeconds: 30,
+				RetryPolicy:    &appsv1alpha1.RetryPolicy{MaxRetries: 3, RetryInterval: 10 * time.Second},
+				Sandbox:        &appsv1alpha1.ActionSandbox{RunAsUser: &uid, CPUSeconds: 5, Memory: &memory},
+			},
+		}
+		handlers, _, _ := buildKBAgentHandlers(synthesizeComp)
+
+		spec := handlers[constant.PostProvisionAction]
+		Expect(spec.Command).Should(Equal([]string{"init.sh"}))
+		Expect(spec.TimeoutSeconds).Should(Equal(30))
+		Expect(*spec.RetryPolicy).Should(Equal(kbagentutil.RetryPolicy{MaxRetries: 3, RetryIntervalSeconds: 10}))
+		Expect(*spec.Resources).Should(Equal(kbagentutil.ResourceLimits{CPUSeconds: 5, MemoryBytes: 64 << 20}))
+		Expect(*spec.RunAsUser).Should(Equal(uid))
+		Expect(spec.RunAsGroup).Should(BeNil())
+		Expect(spec.Parameters).Should(HaveKey("INIT_MODE"))
+		Expect(spec.Parameters["INIT_MODE"].Type).Should(Equal("string"))
+
+		Expect(handlers[constant.RoleProbeAction].Sandbox()).Should(BeNil())
+	})
+
+	It("refreshes the kb-agents of the running pods", func() {
+		agentCli := &refreshAgentClient{}
+		kbagent.SetMockClient(agentCli, nil)
+		defer kbagent.UnsetMockClient()
+
+		synthesizeComp.Namespace = "default"
+		synthesizeComp.ClusterName = "test-cluster"
+		synthesizeComp.Name = "test-comp"
+		pod := func(name string, phase corev1.PodPhase) *corev1.Pod {
+			return &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: synthesizeComp.Namespace,
+					Name:      name,
+					Labels:    constant.GetComponentWellKnownLabels(synthesizeComp.ClusterName, synthesizeComp.Name),
+				},
+				Status: corev1.PodStatus{Phase: phase, PodIP: "10.0.0.1"},
+			}
+		}
+		cli := fake.NewClientBuilder().WithObjects(pod("pod-0", corev1.PodRunning), pod("pod-1", corev1.PodPending)).Build()
+
+		Expect(RefreshKBAgents(ctx, cli, synthesizeComp, `{"roleProbe":{"command":["role.sh"]}}`)).Should(Succeed())
+		Expect(agentCli.refreshed).Should(HaveLen(1))
+		Expect(agentCli.refreshed[0][constant.RoleProbeAction].Command).Should(Equal([]string{"role.sh"}))
+	})
+
+	It("keeps the lorry containers for the built-in handlers", func() {
+		handler := appsv1alpha1.MySQLBuiltinActionHandler
+		synthesizeComp.LifecycleActions.RoleProbe.BuiltinHandler = &handler
+		Expect(CanMigrateToKBAgent(synthesizeComp)).Should(BeFalse())
+		Expect(buildAgentContainers(reqCtx, synthesizeComp, migratingComp, nil)).Should(Succeed())
+		Expect(synthesizeComp.PodSpec.Containers[1].Name).Should(Equal(constant.LorryContainerName))
+	})
+})
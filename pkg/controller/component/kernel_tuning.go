@@ -0,0 +1,213 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+const (
+	hugePagesVolumeName       = "hugepages"
+	defaultHugePagesMountPath = "/dev/hugepages"
+)
+
+var (
+	// the same format of the sysctl names as the kubelet.
+	sysctlNameRegexp = regexp.MustCompile(`^([a-z0-9]([-_a-z0-9]*[a-z0-9])?[\./])*[a-z0-9]([-_a-z0-9]*[a-z0-9])?$`)
+
+	// the prefixes of the sysctls namespaced by the kernel, which can be set in the security context of the pods.
+	namespacedSysctlPrefixes = []string{"kernel.shm", "kernel.msg", "fs.mqueue.", "net."}
+)
+
+// ValidateKernelTuning checks whether the sysctls and the huge pages of the kernel tuning are well-formed.
+func ValidateKernelTuning(kernelTuning *appsv1alpha1.KernelTuning) error {
+	if kernelTuning == nil {
+		return nil
+	}
+	for _, sysctl := range kernelTuning.Sysctls {
+		if len(sysctl.Name) > 253 || !sysctlNameRegexp.MatchString(sysctl.Name) {
+			return fmt.Errorf("invalid sysctl name %q", sysctl.Name)
+		}
+		if isNamespacedSysctl(sysctl.Name) {
+			continue
+		}
+		// the node-level sysctls are required from the node labels
+		if errs := validation.IsQualifiedName(sysctlNodeLabelKey(sysctl.Name)); len(errs) > 0 {
+			return fmt.Errorf("invalid node-level sysctl name %q: %s", sysctl.Name, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(sysctl.Value); len(errs) > 0 {
+			return fmt.Errorf("invalid value of the node-level sysctl %q: %s", sysctl.Name, strings.Join(errs, "; "))
+		}
+	}
+	if hugePages := kernelTuning.HugePages; hugePages != nil {
+		pageSize, err := resource.ParseQuantity(hugePages.PageSize)
+		if err != nil || (pageSize.Cmp(resource.MustParse("2Mi")) != 0 && pageSize.Cmp(resource.MustParse("1Gi")) != 0) {
+			return fmt.Errorf("invalid huge page size %q, only 2Mi and 1Gi are supported", hugePages.PageSize)
+		}
+		if hugePages.Size.Sign() <= 0 || hugePages.Size.Value()%pageSize.Value() != 0 {
+			return fmt.Errorf("the size of the huge pages %s is not a multiple of the page size %s", hugePages.Size.String(), hugePages.PageSize)
+		}
+	}
+	return nil
+}
+
+// KernelTuningNodeSelector returns the node labels required by the node-level sysctls of the kernel tuning.
+func KernelTuningNodeSelector(kernelTuning *appsv1alpha1.KernelTuning) map[string]string {
+	if kernelTuning == nil {
+		return nil
+	}
+	selector := map[string]string{}
+	for _, sysctl := range kernelTuning.Sysctls {
+		if !isNamespacedSysctl(sysctl.Name) {
+			selector[sysctlNodeLabelKey(sysctl.Name)] = sysctl.Value
+		}
+	}
+	return selector
+}
+
+// HugePagesResourceName returns the name of the resource of the huge pages, e.g. "hugepages-2Mi".
+func HugePagesResourceName(hugePages *appsv1alpha1.HugePages) corev1.ResourceName {
+	return corev1.ResourceName(corev1.ResourceHugePagesPrefix + hugePages.PageSize)
+}
+
+func isNamespacedSysctl(name string) bool {
+	// the kubelet accepts both "." and "/" as the separator
+	name = strings.ReplaceAll(name, "/", ".")
+	if name == "kernel.sem" {
+		return true
+	}
+	for _, prefix := range namespacedSysctlPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func sysctlNodeLabelKey(name string) string {
+	return constant.SysctlNodeLabelKeyPrefix + strings.ReplaceAll(name, "/", ".")
+}
+
+// buildKernelTuning renders the sysctls and the huge pages into the pod template of the component.
+func buildKernelTuning(synthesizeComp *SynthesizedComponent, kernelTuning *appsv1alpha1.KernelTuning) error {
+	if kernelTuning == nil {
+		return nil
+	}
+	if err := ValidateKernelTuning(kernelTuning); err != nil {
+		return err
+	}
+	podSpec := synthesizeComp.PodSpec
+
+	for _, sysctl := range kernelTuning.Sysctls {
+		if !isNamespacedSysctl(sysctl.Name) {
+			continue
+		}
+		if podSpec.SecurityContext == nil {
+			podSpec.SecurityContext = &corev1.PodSecurityContext{}
+		}
+		podSpec.SecurityContext.Sysctls = mergeSysctl(podSpec.SecurityContext.Sysctls, sysctl)
+	}
+
+	if selector := KernelTuningNodeSelector(kernelTuning); len(selector) > 0 {
+		// the node selector may be shared with the scheduling policy of the component, copy it before updating
+		nodeSelector := make(map[string]string, len(podSpec.NodeSelector)+len(selector))
+		for k, v := range podSpec.NodeSelector {
+			nodeSelector[k] = v
+		}
+		for k, v := range selector {
+			nodeSelector[k] = v
+		}
+		podSpec.NodeSelector = nodeSelector
+	}
+
+	if hugePages := kernelTuning.HugePages; hugePages != nil && len(podSpec.Containers) > 0 {
+		buildHugePages(podSpec, hugePages)
+	}
+	return nil
+}
+
+func mergeSysctl(sysctls []corev1.Sysctl, sysctl corev1.Sysctl) []corev1.Sysctl {
+	for i := range sysctls {
+		if sysctls[i].Name == sysctl.Name {
+			sysctls[i].Value = sysctl.Value
+			return sysctls
+		}
+	}
+	return append(sysctls, sysctl)
+}
+
+// buildHugePages allocates the huge pages to the main container, and mounts them with a volume of the HugePages medium.
+func buildHugePages(podSpec *corev1.PodSpec, hugePages *appsv1alpha1.HugePages) {
+	container := &podSpec.Containers[0]
+	resourceName := HugePagesResourceName(hugePages)
+	// the resources may be shared with the spec of the component, copy them before updating
+	container.Resources.Requests = container.Resources.Requests.DeepCopy()
+	container.Resources.Limits = container.Resources.Limits.DeepCopy()
+	if container.Resources.Requests == nil {
+		container.Resources.Requests = corev1.ResourceList{}
+	}
+	if container.Resources.Limits == nil {
+		container.Resources.Limits = corev1.ResourceList{}
+	}
+	// the request and the limit of the huge pages must be equal
+	container.Resources.Requests[resourceName] = hugePages.Size
+	container.Resources.Limits[resourceName] = hugePages.Size
+
+	volume := corev1.Volume{
+		Name: hugePagesVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{
+				Medium: corev1.StorageMedium(corev1.StorageMediumHugePagesPrefix + hugePages.PageSize),
+			},
+		},
+	}
+	podSpec.Volumes = mergeVolume(podSpec.Volumes, volume)
+
+	mountPath := hugePages.MountPath
+	if mountPath == "" {
+		mountPath = defaultHugePagesMountPath
+	}
+	for i := range container.VolumeMounts {
+		if container.VolumeMounts[i].Name == hugePagesVolumeName {
+			container.VolumeMounts[i].MountPath = mountPath
+			return
+		}
+	}
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: hugePagesVolumeName, MountPath: mountPath})
+}
+
+func mergeVolume(volumes []corev1.Volume, volume corev1.Volume) []corev1.Volume {
+	for i := range volumes {
+		if volumes[i].Name == volume.Name {
+			volumes[i] = volume
+			return volumes
+		}
+	}
+	return append(volumes, volume)
+}
@@ -0,0 +1,77 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+var _ = Describe("kernel tuning", func() {
+	It("rejects the invalid sysctls and huge pages", func() {
+		Expect(ValidateKernelTuning(nil)).Should(Succeed())
+		for _, kernelTuning := range []appsv1alpha1.KernelTuning{
+			{Sysctls: []corev1.Sysctl{{Name: "Net.Core", Value: "1"}}},
+			{Sysctls: []corev1.Sysctl{{Name: "vm.overcommit_memory", Value: "1 2"}}},
+			{HugePages: &appsv1alpha1.HugePages{PageSize: "4Ki", Size: resource.MustParse("4Mi")}},
+			{HugePages: &appsv1alpha1.HugePages{PageSize: "2Mi", Size: resource.MustParse("3Mi")}},
+		} {
+			Expect(ValidateKernelTuning(&kernelTuning)).ShouldNot(Succeed())
+		}
+	})
+
+	It("renders the sysctls and huge pages into the pod template", func() {
+		resources := corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+		}
+		synthesizeComp := &SynthesizedComponent{
+			PodSpec: &corev1.PodSpec{
+				Containers:   []corev1.Container{{Name: "redis", Resources: resources}},
+				NodeSelector: map[string]string{"disk": "ssd"},
+			},
+		}
+		Expect(buildKernelTuning(synthesizeComp, &appsv1alpha1.KernelTuning{
+			Sysctls: []corev1.Sysctl{
+				{Name: "net.core.somaxconn", Value: "1024"},
+				{Name: "vm.overcommit_memory", Value: "1"},
+			},
+			HugePages: &appsv1alpha1.HugePages{PageSize: "2Mi", Size: resource.MustParse("128Mi")},
+		})).Should(Succeed())
+
+		podSpec := synthesizeComp.PodSpec
+		Expect(podSpec.SecurityContext.Sysctls).Should(Equal([]corev1.Sysctl{{Name: "net.core.somaxconn", Value: "1024"}}))
+		Expect(podSpec.NodeSelector).Should(HaveKeyWithValue("disk", "ssd"))
+		Expect(podSpec.NodeSelector).Should(HaveKeyWithValue("sysctl.kubeblocks.io/vm.overcommit_memory", "1"))
+		container := podSpec.Containers[0]
+		Expect(container.Resources.Requests[corev1.ResourceName("hugepages-2Mi")]).Should(Equal(resource.MustParse("128Mi")))
+		Expect(container.Resources.Limits[corev1.ResourceName("hugepages-2Mi")]).Should(Equal(resource.MustParse("128Mi")))
+		Expect(container.VolumeMounts).Should(Equal([]corev1.VolumeMount{{Name: "hugepages", MountPath: "/dev/hugepages"}}))
+		Expect(podSpec.Volumes).Should(HaveLen(1))
+		Expect(podSpec.Volumes[0].EmptyDir.Medium).Should(Equal(corev1.StorageMedium("HugePages-2Mi")))
+
+		By("the resources of the component spec are not changed")
+		Expect(resources.Limits).Should(HaveLen(1))
+	})
+})
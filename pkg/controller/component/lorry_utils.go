@@ -273,6 +273,10 @@ func buildLorryEnvs(container *corev1.Container, synthesizeComp *SynthesizedComp
 		envs = append(envs, buildEnv4VolumeProtection(synthesizeComp))
 	}
 	envs = append(envs, buildEnv4CronJobs(synthesizeComp)...)
+	// share the statement allowlist of the DataScript ops with the sql operations of lorry.
+	if allowed := viper.GetString(constant.KBDataScriptAllowedStatements); allowed != "" {
+		envs = append(envs, corev1.EnvVar{Name: constant.KBDataScriptAllowedStatements, Value: allowed})
+	}
 
 	container.Env = append(container.Env, envs...)
 }
@@ -438,6 +442,8 @@ func getBuiltinActionHandler(synthesizeComp *SynthesizedComponent) appsv1alpha1.
 		synthesizeComp.LifecycleActions.DataLoad,
 		synthesizeComp.LifecycleActions.Reconfigure,
 		synthesizeComp.LifecycleActions.AccountProvision,
+		synthesizeComp.LifecycleActions.Rebalance,
+		synthesizeComp.LifecycleActions.ShardRouting,
 	}
 
 	hasAction := false
@@ -455,9 +461,10 @@ func getBuiltinActionHandler(synthesizeComp *SynthesizedComponent) appsv1alpha1.
 	return appsv1alpha1.UnknownBuiltinActionHandler
 }
 
-func getActionCommandsWithExecImageOrContainerName(synthesizeComp *SynthesizedComponent) (map[string][]string, string, string) {
+// getLifecycleActionHandlers returns the handlers of the lifecycle actions served by the agent, keyed by the action name.
+func getLifecycleActionHandlers(synthesizeComp *SynthesizedComponent) map[string]*appsv1alpha1.LifecycleActionHandler {
 	if synthesizeComp.LifecycleActions == nil {
-		return nil, "", ""
+		return nil
 	}
 
 	actions := map[string]*appsv1alpha1.LifecycleActionHandler{
@@ -470,6 +477,8 @@ func getActionCommandsWithExecImageOrContainerName(synthesizeComp *SynthesizedCo
 		constant.DataDumpAction:         synthesizeComp.LifecycleActions.DataDump,
 		constant.DataLoadAction:         synthesizeComp.LifecycleActions.DataLoad,
 		constant.AccountProvisionAction: synthesizeComp.LifecycleActions.AccountProvision,
+		constant.RebalanceAction:        synthesizeComp.LifecycleActions.Rebalance,
+		constant.ShardRoutingAction:     synthesizeComp.LifecycleActions.ShardRouting,
 		// "reconfigure":                synthesizeComp.LifecycleActions.Reconfigure,
 	}
 
@@ -478,6 +487,14 @@ func getActionCommandsWithExecImageOrContainerName(synthesizeComp *SynthesizedCo
 			CustomHandler: &synthesizeComp.LifecycleActions.RoleProbe.Action,
 		}
 	}
+	return actions
+}
+
+func getActionCommandsWithExecImageOrContainerName(synthesizeComp *SynthesizedComponent) (map[string][]string, string, string) {
+	actions := getLifecycleActionHandlers(synthesizeComp)
+	if actions == nil {
+		return nil, "", ""
+	}
 
 	var toolImage string
 	var containerName string
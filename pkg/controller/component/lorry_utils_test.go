@@ -116,6 +116,8 @@ var _ = Describe("Lorry Utils", func() {
 				Ctx: ctx,
 				Log: logger,
 			}
+			viper.Set(constant.KBDataScriptAllowedStatements, "SELECT,SHOW")
+			defer viper.Set(constant.KBDataScriptAllowedStatements, "")
 			// all other services are disabled
 			defaultBuiltInHandler := appsv1alpha1.MySQLBuiltinActionHandler
 			component.LifecycleActions = &appsv1alpha1.ComponentLifecycleActions{
@@ -127,6 +129,10 @@ var _ = Describe("Lorry Utils", func() {
 			Expect(component.PodSpec.Containers).Should(HaveLen(1))
 			Expect(component.PodSpec.InitContainers).Should(HaveLen(0))
 			Expect(component.PodSpec.Containers[0].Name).Should(Equal(constant.LorryContainerName))
+			Expect(component.PodSpec.Containers[0].Env).Should(ContainElement(corev1.EnvVar{
+				Name:  constant.KBDataScriptAllowedStatements,
+				Value: "SELECT,SHOW",
+			}))
 		})
 
 		It("build lorry container if any exec specified", func() {
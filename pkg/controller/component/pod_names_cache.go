@@ -0,0 +1,105 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"bytes"
+	"encoding/binary"
+	"slices"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/utils/lru"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
+)
+
+// podNamesCacheSize is the max number of the pod name lists kept in the cache.
+const podNamesCacheSize = 1024
+
+// podNamesCache memoizes the pod names generated for the components, which are computed repeatedly
+// in the reconciliation of components and OpsRequests, and expensive for components with hundreds of instances.
+var podNamesCache = lru.New(podNamesCacheSize)
+
+var podNamesCacheRequestsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "kubeblocks_pod_names_cache_requests_total",
+		Help: "Total number of lookups of the generated pod names cache, by result.",
+	},
+	[]string{"result"},
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(podNamesCacheRequestsTotal)
+}
+
+// newPodNamesCacheKey encodes all the inputs of the pod name generation into the cache key. The full inputs
+// are kept rather than a hash of them, so that the different inputs never share an entry on a hash collision.
+func newPodNamesCacheKey(workloadName string, replicas int32, templates []instanceset.InstanceTemplate, offlineInstances []string) string {
+	var buf bytes.Buffer
+	writeString := func(s string) {
+		_ = binary.Write(&buf, binary.LittleEndian, int64(len(s)))
+		buf.WriteString(s)
+	}
+	writeInt := func(i int32) {
+		_ = binary.Write(&buf, binary.LittleEndian, i)
+	}
+	writeString(workloadName)
+	writeInt(replicas)
+	writeInt(int32(len(templates)))
+	for _, template := range templates {
+		writeString(template.GetName())
+		writeInt(template.GetReplicas())
+		ordinals := template.GetOrdinals()
+		writeInt(int32(len(ordinals.Ranges)))
+		for _, r := range ordinals.Ranges {
+			writeInt(r.Start)
+			writeInt(r.End)
+		}
+		writeInt(int32(len(ordinals.Discrete)))
+		for _, d := range ordinals.Discrete {
+			writeInt(d)
+		}
+	}
+	writeInt(int32(len(offlineInstances)))
+	for _, name := range offlineInstances {
+		writeString(name)
+	}
+	return buf.String()
+}
+
+// generateInstanceNamesCached returns the instance names generated by instanceset.GenerateAllInstanceNames,
+// from the cache if the same inputs have been computed before.
+func generateInstanceNamesCached(workloadName string, replicas int32, templates []instanceset.InstanceTemplate,
+	offlineInstances []string) ([]string, error) {
+	key := newPodNamesCacheKey(workloadName, replicas, templates, offlineInstances)
+	if names, ok := podNamesCache.Get(key); ok {
+		podNamesCacheRequestsTotal.WithLabelValues("hit").Inc()
+		return slices.Clone(names.([]string)), nil
+	}
+	podNamesCacheRequestsTotal.WithLabelValues("miss").Inc()
+	names, err := instanceset.GenerateAllInstanceNames(workloadName, replicas, templates, offlineInstances, workloads.Ordinals{})
+	if err != nil {
+		return nil, err
+	}
+	podNamesCache.Add(key, slices.Clone(names))
+	return names, nil
+}
@@ -0,0 +1,84 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/utils/pointer"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
+)
+
+var _ = Describe("pod names cache", func() {
+	hits := func() float64 {
+		return testutil.ToFloat64(podNamesCacheRequestsTotal.WithLabelValues("hit"))
+	}
+
+	It("should return the cached pod names for the same inputs", func() {
+		instances := []appsv1alpha1.InstanceTemplate{{Name: "foo", Replicas: pointer.Int32(2)}}
+		names, err := GenerateAllPodNames(5, instances, []string{"test-cluster-comp-1"}, "test-cluster", "comp")
+		Expect(err).Should(Succeed())
+		Expect(names).Should(Equal([]string{"test-cluster-comp-0", "test-cluster-comp-2", "test-cluster-comp-3",
+			"test-cluster-comp-foo-0", "test-cluster-comp-foo-1"}))
+
+		before := hits()
+		cached, err := GenerateAllPodNames(5, instances, []string{"test-cluster-comp-1"}, "test-cluster", "comp")
+		Expect(err).Should(Succeed())
+		Expect(cached).Should(Equal(names))
+		Expect(hits()).Should(Equal(before + 1))
+
+		By("modifying the returned names should not affect the cache")
+		cached[0] = "modified"
+		cached, err = GenerateAllPodNames(5, instances, []string{"test-cluster-comp-1"}, "test-cluster", "comp")
+		Expect(err).Should(Succeed())
+		Expect(cached).Should(Equal(names))
+	})
+
+	It("should not hit the cache if the inputs change", func() {
+		instances := []appsv1alpha1.InstanceTemplate{{Name: "bar", Replicas: pointer.Int32(1)}}
+		_, err := GenerateAllPodNames(3, instances, nil, "test-cluster", "comp")
+		Expect(err).Should(Succeed())
+
+		before := hits()
+		names, err := GenerateAllPodNames(3, instances, []string{"test-cluster-comp-0"}, "test-cluster", "comp")
+		Expect(err).Should(Succeed())
+		Expect(names).Should(Equal([]string{"test-cluster-comp-1", "test-cluster-comp-2", "test-cluster-comp-bar-0"}))
+
+		instances[0].Replicas = pointer.Int32(2)
+		names, err = GenerateAllPodNames(3, instances, nil, "test-cluster", "comp")
+		Expect(err).Should(Succeed())
+		Expect(names).Should(ContainElement("test-cluster-comp-bar-1"))
+		Expect(hits()).Should(Equal(before))
+	})
+
+	It("should compare the full inputs in the cache key", func() {
+		key := func(offlineInstances ...string) string {
+			foo := &appsv1alpha1.InstanceTemplate{Name: "foo", Replicas: pointer.Int32(1)}
+			return newPodNamesCacheKey("test-cluster-comp", 3, []instanceset.InstanceTemplate{foo}, offlineInstances)
+		}
+		Expect(key("test-cluster-comp-0")).Should(Equal(key("test-cluster-comp-0")))
+		Expect(key("test-cluster-comp-0", "test-cluster-comp-1")).ShouldNot(Equal(key("test-cluster-comp-0test-cluster-comp-1")))
+		Expect(key()).ShouldNot(Equal(key("")))
+	})
+})
@@ -0,0 +1,192 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+var (
+	// the fields of the pod spec which are not allowed in the pod template overlay.
+	disallowedPodSpecOverlayFields = sets.New("hostNetwork", "hostPID", "hostIPC", "serviceAccountName",
+		"serviceAccount", "nodeName", "restartPolicy")
+
+	// the fields of the containers which are not allowed in the pod template overlay.
+	disallowedContainerOverlayFields = sets.New("image", "command", "args", "securityContext")
+)
+
+// ValidatePodTemplateOverlay checks whether the pod template overlay only contains the allowed fields.
+func ValidatePodTemplateOverlay(overlay *runtime.RawExtension) error {
+	if overlay == nil || len(overlay.Raw) == 0 {
+		return nil
+	}
+	errorf := func(format string, a ...any) error {
+		return fmt.Errorf("invalid podTemplateOverlay: "+format, a...)
+	}
+
+	fields := map[string]any{}
+	if err := json.Unmarshal(overlay.Raw, &fields); err != nil {
+		return errorf("%s", err.Error())
+	}
+	if err := json.Unmarshal(overlay.Raw, &corev1.PodTemplateSpec{}); err != nil {
+		return errorf("%s", err.Error())
+	}
+	if path := findPatchDirective(fields, ""); path != "" {
+		return errorf("the patch directive %s is not allowed", path)
+	}
+	for key, value := range fields {
+		switch key {
+		case "metadata":
+			metadata, _ := value.(map[string]any)
+			for field := range metadata {
+				if field != "labels" && field != "annotations" {
+					return errorf("metadata.%s is not allowed", field)
+				}
+			}
+		case "spec":
+			spec, _ := value.(map[string]any)
+			for field := range spec {
+				if disallowedPodSpecOverlayFields.Has(field) {
+					return errorf("spec.%s is not allowed", field)
+				}
+			}
+			if securityContext, ok := spec["securityContext"].(map[string]any); ok {
+				for field := range securityContext {
+					if field != "sysctls" {
+						return errorf("spec.securityContext.%s is not allowed", field)
+					}
+				}
+			}
+			for _, containersKey := range []string{"containers", "initContainers"} {
+				containers, _ := spec[containersKey].([]any)
+				for i, c := range containers {
+					container, _ := c.(map[string]any)
+					for field := range container {
+						if disallowedContainerOverlayFields.Has(field) {
+							return errorf("spec.%s[%d].%s is not allowed", containersKey, i, field)
+						}
+					}
+				}
+			}
+		default:
+			return errorf("%s is not allowed", key)
+		}
+	}
+	return nil
+}
+
+// findPatchDirective returns the path of the first strategic merge patch directive, e.g. "$patch", in the fields.
+func findPatchDirective(value any, path string) string {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if strings.HasPrefix(key, "$") {
+				return path + "." + key
+			}
+			if p := findPatchDirective(field, path+"."+key); p != "" {
+				return p
+			}
+		}
+	case []any:
+		for i, item := range v {
+			if p := findPatchDirective(item, fmt.Sprintf("%s[%d]", path, i)); p != "" {
+				return p
+			}
+		}
+	}
+	return ""
+}
+
+// applyPodTemplateOverlay strategic-merges the pod template overlay into the pod template of the component.
+func applyPodTemplateOverlay(synthesizeComp *SynthesizedComponent, overlay *runtime.RawExtension) error {
+	if overlay == nil || len(overlay.Raw) == 0 {
+		return nil
+	}
+	if err := ValidatePodTemplateOverlay(overlay); err != nil {
+		return err
+	}
+	template := &corev1.PodTemplateSpec{}
+	if err := json.Unmarshal(overlay.Raw, template); err != nil {
+		return err
+	}
+	if err := checkOverlayContainers(synthesizeComp.PodSpec, &template.Spec); err != nil {
+		return err
+	}
+
+	if len(template.Labels) > 0 {
+		synthesizeComp.UserDefinedLabels = intctrlutil.MergeMetadataMaps(synthesizeComp.UserDefinedLabels, template.Labels)
+	}
+	if len(template.Annotations) > 0 {
+		synthesizeComp.UserDefinedAnnotations = intctrlutil.MergeMetadataMaps(synthesizeComp.UserDefinedAnnotations, template.Annotations)
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(overlay.Raw, &fields); err != nil {
+		return err
+	}
+	specPatch, ok := fields["spec"]
+	if !ok {
+		return nil
+	}
+	original, err := json.Marshal(synthesizeComp.PodSpec)
+	if err != nil {
+		return err
+	}
+	merged, err := strategicpatch.StrategicMergePatch(original, specPatch, corev1.PodSpec{})
+	if err != nil {
+		return fmt.Errorf("failed to apply podTemplateOverlay: %s", err.Error())
+	}
+	podSpec := &corev1.PodSpec{}
+	if err = json.Unmarshal(merged, podSpec); err != nil {
+		return err
+	}
+	synthesizeComp.PodSpec = podSpec
+	return nil
+}
+
+// checkOverlayContainers checks that the containers in the overlay exist in the pod template, the overlay
+// can only patch the existing containers but not add new ones.
+func checkOverlayContainers(podSpec *corev1.PodSpec, overlay *corev1.PodSpec) error {
+	check := func(kind string, containers, overlayContainers []corev1.Container) error {
+		names := sets.New[string]()
+		for _, c := range containers {
+			names.Insert(c.Name)
+		}
+		for _, c := range overlayContainers {
+			if !names.Has(c.Name) {
+				return fmt.Errorf("invalid podTemplateOverlay: the %s %q is not found in the pod template", kind, c.Name)
+			}
+		}
+		return nil
+	}
+	if err := check("container", podSpec.Containers, overlay.Containers); err != nil {
+		return err
+	}
+	return check("init container", podSpec.InitContainers, overlay.InitContainers)
+}
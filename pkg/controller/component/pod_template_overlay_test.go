@@ -0,0 +1,79 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var _ = Describe("pod template overlay", func() {
+	overlay := func(raw string) *runtime.RawExtension {
+		return &runtime.RawExtension{Raw: []byte(raw)}
+	}
+
+	It("rejects the disallowed fields", func() {
+		Expect(ValidatePodTemplateOverlay(nil)).Should(Succeed())
+		for _, raw := range []string{
+			`{"spec":{"hostNetwork":true}}`,
+			`{"spec":{"serviceAccountName":"admin"}}`,
+			`{"spec":{"securityContext":{"runAsUser":0}}}`,
+			`{"spec":{"containers":[{"name":"mysql","image":"busybox"}]}}`,
+			`{"spec":{"containers":[{"name":"mysql","$patch":"delete"}]}}`,
+			`{"metadata":{"name":"foo"}}`,
+			`{"status":{}}`,
+		} {
+			Expect(ValidatePodTemplateOverlay(overlay(raw))).ShouldNot(Succeed(), raw)
+		}
+		Expect(ValidatePodTemplateOverlay(overlay(`{"spec":{"securityContext":{"sysctls":[{"name":"net.core.somaxconn","value":"1024"}]}}}`))).Should(Succeed())
+	})
+
+	It("merges the overlay into the pod template", func() {
+		synthesizeComp := &SynthesizedComponent{
+			PodSpec: &corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "mysql", Image: "mysql:8.0", VolumeMounts: []corev1.VolumeMount{{Name: "data", MountPath: "/data"}}},
+					{Name: "lorry", Image: "lorry"},
+				},
+				Volumes: []corev1.Volume{{Name: "data"}},
+			},
+		}
+		Expect(applyPodTemplateOverlay(synthesizeComp, overlay(`{
+			"metadata": {"labels": {"team": "dba"}},
+			"spec": {
+				"hostAliases": [{"ip": "10.0.0.1", "hostnames": ["db.local"]}],
+				"volumes": [{"name": "extra", "emptyDir": {}}],
+				"containers": [{"name": "mysql", "volumeMounts": [{"name": "extra", "mountPath": "/extra"}]}]
+			}
+		}`))).Should(Succeed())
+		Expect(synthesizeComp.UserDefinedLabels).Should(HaveKeyWithValue("team", "dba"))
+		Expect(synthesizeComp.PodSpec.HostAliases).Should(HaveLen(1))
+		Expect(synthesizeComp.PodSpec.Volumes).Should(HaveLen(2))
+		Expect(synthesizeComp.PodSpec.Containers).Should(HaveLen(2))
+		Expect(synthesizeComp.PodSpec.Containers[0].Image).Should(Equal("mysql:8.0"))
+		Expect(synthesizeComp.PodSpec.Containers[0].VolumeMounts).Should(HaveLen(2))
+
+		By("the containers not in the pod template are rejected")
+		Expect(applyPodTemplateOverlay(synthesizeComp, overlay(`{"spec":{"containers":[{"name":"extra"}]}}`))).ShouldNot(Succeed())
+	})
+})
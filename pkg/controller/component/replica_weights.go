@@ -0,0 +1,101 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+// defaultReplicaWeight is the weight of the replicas which are not matched by any replica weight.
+const defaultReplicaWeight int32 = 1
+
+// ValidateReplicaWeights checks that the replica weights refer to the instance templates of the component,
+// and each instance has at most one explicit weight.
+func ValidateReplicaWeights(weights []appsv1alpha1.ReplicaWeight, instances []appsv1alpha1.InstanceTemplate) error {
+	templates := sets.New[string]()
+	for _, tpl := range instances {
+		templates.Insert(tpl.Name)
+	}
+	weightedTemplates := sets.New[string]()
+	weightedInstances := sets.New[string]()
+	for _, w := range weights {
+		if len(w.Template) == 0 && len(w.Instances) == 0 {
+			return fmt.Errorf("invalid replicaWeights: either template or instances must be provided")
+		}
+		if w.Weight < 1 || w.Weight > 100 {
+			return fmt.Errorf("invalid replicaWeights: the weight %d is out of range [1, 100]", w.Weight)
+		}
+		if len(w.Template) > 0 {
+			if !templates.Has(w.Template) {
+				return fmt.Errorf("invalid replicaWeights: the instance template %s is not found", w.Template)
+			}
+			if weightedTemplates.Has(w.Template) {
+				return fmt.Errorf("invalid replicaWeights: the instance template %s is duplicated", w.Template)
+			}
+			weightedTemplates.Insert(w.Template)
+		}
+		for _, ins := range w.Instances {
+			if weightedInstances.Has(ins) {
+				return fmt.Errorf("invalid replicaWeights: the instance %s is duplicated", ins)
+			}
+			weightedInstances.Insert(ins)
+		}
+	}
+	return nil
+}
+
+// BuildReplicaWeights returns the weights of all the replicas of the component, keyed by the pod name,
+// or nil if no replica weight is specified.
+func BuildReplicaWeights(synthesizeComp *SynthesizedComponent) (map[string]int32, error) {
+	if len(synthesizeComp.ReplicaWeights) == 0 {
+		return nil, nil
+	}
+	podSet, err := GenerateAllPodNamesToSet(synthesizeComp.Replicas, synthesizeComp.Instances,
+		synthesizeComp.OfflineInstances, synthesizeComp.ClusterName, synthesizeComp.Name)
+	if err != nil {
+		return nil, err
+	}
+	templateWeights := map[string]int32{}
+	instanceWeights := map[string]int32{}
+	for _, w := range synthesizeComp.ReplicaWeights {
+		if len(w.Template) > 0 {
+			templateWeights[w.Template] = w.Weight
+		}
+		for _, ins := range w.Instances {
+			instanceWeights[ins] = w.Weight
+		}
+	}
+	weights := make(map[string]int32, len(podSet))
+	for podName, templateName := range podSet {
+		weight, ok := instanceWeights[podName]
+		if !ok {
+			weight, ok = templateWeights[templateName]
+		}
+		if !ok {
+			weight = defaultReplicaWeight
+		}
+		weights[podName] = weight
+	}
+	return weights, nil
+}
@@ -22,6 +22,7 @@ package component
 import (
 	"context"
 	"fmt"
+	"net"
 	"regexp"
 	"strings"
 
@@ -170,7 +171,8 @@ func referencedServiceVars(ctx context.Context, cli client.Reader, namespace str
 			// pod-service, the port value has format: host1:port1,host2,port2,...
 			return &appsv1alpha1.CredentialVar{Value: port.Value}
 		}
-		return &appsv1alpha1.CredentialVar{Value: fmt.Sprintf("%s:%s", hval, port.Value)}
+		// the IPv6 host is enclosed in brackets.
+		return &appsv1alpha1.CredentialVar{Value: net.JoinHostPort(hval, port.Value)}
 	}
 	return endpoint(), host, port, nil
 }
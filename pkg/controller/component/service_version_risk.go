@@ -0,0 +1,133 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/slices"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+const (
+	// ServiceVersionRiskPolicyWarn reports the risks of the service versions by conditions only.
+	ServiceVersionRiskPolicyWarn = "Warn"
+	// ServiceVersionRiskPolicyBlock reports the risks, and rejects the clusters which add components with the risky
+	// service versions, or change the service versions of the components to the risky ones.
+	ServiceVersionRiskPolicyBlock = "Block"
+
+	ReasonServiceVersionEndOfLife  = "EndOfLife"
+	ReasonServiceVersionVulnerable = "KnownVulnerabilities"
+
+	catalogDateLayout = "2006-01-02"
+)
+
+// ServiceVersionRisk describes the risks of a service version recorded in the ComponentVersions.
+type ServiceVersionRisk struct {
+	ServiceVersion string
+	// EndOfLifeDate is set if the service version has reached the end of life.
+	EndOfLifeDate string
+	CVEs          []string
+}
+
+// Reason returns the reason of the condition for the risk, the end of life takes precedence.
+func (r *ServiceVersionRisk) Reason() string {
+	if r.EndOfLifeDate != "" {
+		return ReasonServiceVersionEndOfLife
+	}
+	return ReasonServiceVersionVulnerable
+}
+
+func (r *ServiceVersionRisk) Message() string {
+	var msgs []string
+	if r.EndOfLifeDate != "" {
+		msgs = append(msgs, fmt.Sprintf("service version %s reached the end of life on %s", r.ServiceVersion, r.EndOfLifeDate))
+	}
+	if len(r.CVEs) > 0 {
+		msgs = append(msgs, fmt.Sprintf("service version %s has known vulnerabilities: %s", r.ServiceVersion, strings.Join(r.CVEs, ", ")))
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// ResolveServiceVersionRisk resolves the risks of the service version from the releases of the ComponentVersions
+// compatible with the component definition, it returns nil if there is no risk. It also returns the nearest
+// end of life date of the service version yet to come, or the zero time if there is none, so that the caller
+// can check the risks again once the date is reached.
+func ResolveServiceVersionRisk(ctx context.Context, cli client.Reader,
+	compDef *appsv1alpha1.ComponentDefinition, serviceVersion string) (*ServiceVersionRisk, time.Time, error) {
+	if serviceVersion == "" {
+		serviceVersion = compDef.Spec.ServiceVersion
+	}
+	if serviceVersion == "" {
+		return nil, time.Time{}, nil
+	}
+	compVersions, err := CompatibleCompVersions4Definition(ctx, cli, compDef)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return resolveServiceVersionRisk(compVersions, serviceVersion, time.Now())
+}
+
+func resolveServiceVersionRisk(compVersions []*appsv1alpha1.ComponentVersion, serviceVersion string,
+	now time.Time) (*ServiceVersionRisk, time.Time, error) {
+	var nextEndOfLife time.Time
+	risk := &ServiceVersionRisk{ServiceVersion: serviceVersion}
+	for _, compVersion := range compVersions {
+		for _, release := range compVersion.Spec.Releases {
+			match, err := CompareServiceVersion(serviceVersion, release.ServiceVersion)
+			if err != nil {
+				return nil, time.Time{}, err
+			}
+			if !match {
+				continue
+			}
+			if release.EndOfLifeDate != "" {
+				eol, err := time.Parse(catalogDateLayout, release.EndOfLifeDate)
+				if err != nil {
+					return nil, time.Time{}, fmt.Errorf("invalid end of life date of release %s in ComponentVersion %s: %s",
+						release.Name, compVersion.Name, err.Error())
+				}
+				switch {
+				case now.Before(eol):
+					if nextEndOfLife.IsZero() || eol.Before(nextEndOfLife) {
+						nextEndOfLife = eol
+					}
+				case risk.EndOfLifeDate == "" || release.EndOfLifeDate < risk.EndOfLifeDate:
+					risk.EndOfLifeDate = release.EndOfLifeDate
+				}
+			}
+			for _, cve := range release.CVEs {
+				if !slices.Contains(risk.CVEs, cve) {
+					risk.CVEs = append(risk.CVEs, cve)
+				}
+			}
+		}
+	}
+	if risk.EndOfLifeDate == "" && len(risk.CVEs) == 0 {
+		return nil, nextEndOfLife, nil
+	}
+	slices.Sort(risk.CVEs)
+	return risk, nextEndOfLife, nil
+}
@@ -147,6 +147,7 @@ func buildSynthesizedComponent(reqCtx intctrlutil.RequestCtx,
 		UserDefinedAnnotations:           comp.Spec.Annotations,
 		PodSpec:                          &compDef.Spec.Runtime,
 		HostNetwork:                      compDefObj.Spec.HostNetwork,
+		HostNetworkMode:                  comp.Spec.HostNetwork,
 		ComponentServices:                compDefObj.Spec.Services,
 		LogConfigs:                       compDefObj.Spec.LogConfigs,
 		ConfigTemplates:                  compDefObj.Spec.Configs,
@@ -163,12 +164,20 @@ func buildSynthesizedComponent(reqCtx intctrlutil.RequestCtx,
 		ServiceAccountName:               comp.Spec.ServiceAccountName,
 		Instances:                        comp.Spec.Instances,
 		OfflineInstances:                 comp.Spec.OfflineInstances,
+		InstanceIP:                       comp.Spec.InstanceIP,
+		SchedulingHints:                  comp.Spec.SchedulingHints,
 		DisableExporter:                  comp.Spec.DisableExporter,
+		SidecarResources:                 comp.Spec.SidecarResources,
+		BackupReplica:                    comp.Spec.BackupReplica,
+		ReplicaWeights:                   comp.Spec.ReplicaWeights,
+		IPStack:                          comp.Spec.IPStack,
 		Stop:                             comp.Spec.Stop,
 		PodManagementPolicy:              compDef.Spec.PodManagementPolicy,
 		ParallelPodManagementConcurrency: comp.Spec.ParallelPodManagementConcurrency,
 		PodUpdatePolicy:                  comp.Spec.PodUpdatePolicy,
 		EnabledLogs:                      comp.Spec.EnabledLogs,
+		CloudTags:                        cluster.Spec.CloudTags,
+		DNS:                              cluster.Spec.DNS,
 	}
 
 	buildCompatibleHorizontalScalePolicy(compDefObj, synthesizeComp)
@@ -182,10 +191,17 @@ func buildSynthesizedComponent(reqCtx intctrlutil.RequestCtx,
 		reqCtx.Log.Error(err, "failed to build scheduling policy")
 		return nil, err
 	}
+	buildArchitectureAffinity(synthesizeComp, compDef)
 
 	// update resources
 	buildAndUpdateResources(synthesizeComp, comp)
 
+	// build sysctls & huge pages
+	if err = buildKernelTuning(synthesizeComp, comp.Spec.KernelTuning); err != nil {
+		reqCtx.Log.Error(err, "build kernel tuning failed.")
+		return nil, err
+	}
+
 	// build labels and annotations
 	buildLabelsAndAnnotations(compDef, comp, synthesizeComp)
 
@@ -210,10 +226,16 @@ func buildSynthesizedComponent(reqCtx intctrlutil.RequestCtx,
 	// build runtimeClassName
 	buildRuntimeClassName(synthesizeComp, comp)
 
-	// build lorryContainer
+	if IsMigratingToKBAgent(comp) {
+		if synthesizeComp.ArtifactBackupRepo, err = getArtifactBackupRepo(reqCtx.Ctx, cli, cluster); err != nil {
+			return nil, err
+		}
+	}
+
+	// build lorryContainer, or the kb-agent container if the component is migrating to the kb-agent
 	// TODO(xingran): buildLorryContainers relies on synthesizeComp.CharacterType, which will be deprecated in the future.
-	if err := buildLorryContainers(reqCtx, synthesizeComp, clusterCompSpec); err != nil {
-		reqCtx.Log.Error(err, "build lorry containers failed.")
+	if err := buildAgentContainers(reqCtx, synthesizeComp, comp, clusterCompSpec); err != nil {
+		reqCtx.Log.Error(err, "build agent containers failed.")
 		return nil, err
 	}
 
@@ -222,6 +244,12 @@ func buildSynthesizedComponent(reqCtx intctrlutil.RequestCtx,
 		return nil, err
 	}
 
+	// apply the pod template overlay of the user at last, so that it can patch the containers injected by KubeBlocks
+	if err = applyPodTemplateOverlay(synthesizeComp, comp.Spec.PodTemplateOverlay); err != nil {
+		reqCtx.Log.Error(err, "apply pod template overlay failed.")
+		return nil, err
+	}
+
 	return synthesizeComp, nil
 }
 
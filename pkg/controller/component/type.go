@@ -26,6 +26,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 )
 
@@ -57,6 +58,8 @@ type SynthesizedComponent struct {
 	EnvFromSources                   []corev1.EnvFromSource              `json:"envFromSources,omitempty"`
 	Instances                        []v1alpha1.InstanceTemplate         `json:"instances,omitempty"`
 	OfflineInstances                 []string                            `json:"offlineInstances,omitempty"`
+	InstanceIP                       *v1alpha1.InstanceIPPolicy          `json:"instanceIP,omitempty"`
+	SchedulingHints                  *v1alpha1.SchedulingHints           `json:"schedulingHints,omitempty"`
 	Roles                            []v1alpha1.ReplicaRole              `json:"roles,omitempty"`
 	Labels                           map[string]string                   `json:"labels,omitempty"`
 	Annotations                      map[string]string                   `json:"annotations,omitempty"`
@@ -69,11 +72,20 @@ type SynthesizedComponent struct {
 	SystemAccounts                   []v1alpha1.SystemAccount            `json:"systemAccounts,omitempty"`
 	Volumes                          []v1alpha1.ComponentVolume          `json:"volumes,omitempty"`
 	HostNetwork                      *v1alpha1.HostNetwork               `json:"hostNetwork,omitempty"`
+	HostNetworkMode                  *v1alpha1.ComponentHostNetwork      `json:"hostNetworkMode,omitempty"`
 	ComponentServices                []v1alpha1.ComponentService         `json:"componentServices,omitempty"`
 	MinReadySeconds                  int32                               `json:"minReadySeconds,omitempty"`
 	Sidecars                         []string                            `json:"sidecars,omitempty"`
 	DisableExporter                  *bool                               `json:"disableExporter,omitempty"`
+	SidecarResources                 []v1alpha1.SidecarResources         `json:"sidecarResources,omitempty"`
+	BackupReplica                    *bool                               `json:"backupReplica,omitempty"`
+	ReplicaWeights                   []v1alpha1.ReplicaWeight            `json:"replicaWeights,omitempty"`
+	IPStack                          *v1alpha1.IPStack                   `json:"ipStack,omitempty"`
 	Stop                             *bool
+	CloudTags                        map[string]string      `json:"cloudTags,omitempty"`
+	DNS                              *v1alpha1.ClusterDNS   `json:"dns,omitempty"`
+	KBAgentHandlers                  string                 `json:"kbAgentHandlers,omitempty"` // the handler specs of the kb-agent in JSON
+	ArtifactBackupRepo               *dpv1alpha1.BackupRepo // the BackupRepo the kb-agent uploads the artifacts of the actions to
 
 	// TODO(xingran): The following fields will be deprecated after KubeBlocks version 0.8.0
 	ClusterDefName                      string   `json:"clusterDefName,omitempty"` // the name of the clusterDefinition
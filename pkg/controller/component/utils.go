@@ -45,9 +45,24 @@ func IsHostNetworkEnabled(synthesizedComp *SynthesizedComponent) bool {
 	if synthesizedComp.PodSpec.HostNetwork {
 		return true
 	}
+	if synthesizedComp.HostNetworkMode != nil && synthesizedComp.HostNetworkMode.Enabled {
+		return true
+	}
 	return hasHostNetworkEnabled(synthesizedComp.Annotations, synthesizedComp.Name)
 }
 
+// IsClusterCompHostNetworkEnabled checks whether the host-network is enabled for the component of the cluster.
+func IsClusterCompHostNetworkEnabled(cluster *appsv1alpha1.Cluster, compSpec *appsv1alpha1.ClusterComponentSpec,
+	compDef *appsv1alpha1.ComponentDefinition) bool {
+	if !hasHostNetworkCapability(nil, compDef) {
+		return false
+	}
+	if compSpec.HostNetwork != nil && compSpec.HostNetwork.Enabled {
+		return true
+	}
+	return hasHostNetworkEnabled(cluster.Annotations, compSpec.Name)
+}
+
 func isHostNetworkEnabled(ctx context.Context, cli client.Reader, synthesizedComp *SynthesizedComponent, compName string) (bool, error) {
 	// fast path: refer to self
 	if compName == synthesizedComp.Name {
@@ -63,7 +78,7 @@ func isHostNetworkEnabled(ctx context.Context, cli client.Reader, synthesizedCom
 	if err := cli.Get(ctx, compKey, comp, inDataContext()); err != nil {
 		return false, err
 	}
-	if !hasHostNetworkEnabled(comp.Annotations, compName) {
+	if !hasHostNetworkEnabled(comp.Annotations, compName) && (comp.Spec.HostNetwork == nil || !comp.Spec.HostNetwork.Enabled) {
 		return false, nil
 	}
 
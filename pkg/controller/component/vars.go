@@ -323,6 +323,10 @@ func buildDefaultEnvVars(synthesizedComp *SynthesizedComponent, legacy bool) []c
 			},
 		})
 	}
+	// only set for the IPv6 and dual-stack networks to keep the pods of the existing clusters unchanged.
+	if synthesizedComp.IPStack != nil {
+		vars = append(vars, corev1.EnvVar{Name: constant.KBEnvBindAddress, Value: BindAddress(synthesizedComp.IPStack)})
+	}
 	clusterCompName := func() string {
 		return constant.GenerateClusterComponentName(synthesizedComp.ClusterName, synthesizedComp.Name)
 	}()
@@ -137,7 +137,7 @@ func GenerateAllPodNames(
 	for i := range instances {
 		templates = append(templates, &instances[i])
 	}
-	return instanceset.GenerateAllInstanceNames(workloadName, compReplicas, templates, offlineInstances, workloads.Ordinals{})
+	return generateInstanceNamesCached(workloadName, compReplicas, templates, offlineInstances)
 }
 
 // GenerateAllPodNamesToSet generate all pod names for a component
@@ -51,6 +51,9 @@ const (
 	// TODO: This function migrate to configuration template
 	builtInMysqlCalBufferFunctionName = "callBufferSizeByResource"
 
+	// Network Built-in
+	builtInGetBindAddressFunctionName = "getBindAddress"
+
 	// TLS Built-in
 	builtInGetCAFile   = "getCAFile"
 	builtInGetCertFile = "getCertFile"
@@ -316,6 +319,13 @@ func getKeyFile() string {
 	return constant.MountPath + "/" + constant.KeyName
 }
 
+// wrapGetBindAddress returns the wildcard address which the engine should bind to, e.g. "::" for the IPv6 and dual-stack networks.
+func wrapGetBindAddress(synthesizedComp *component.SynthesizedComponent) func() string {
+	return func() string {
+		return component.BindAddress(synthesizedComp.IPStack)
+	}
+}
+
 // BuiltInCustomFunctions builds a map of customized functions for KubeBlocks
 func BuiltInCustomFunctions(c *configTemplateBuilder, component *component.SynthesizedComponent, localObjs []client.Object) *gotemplate.BuiltInObjectsFunc {
 	return &gotemplate.BuiltInObjectsFunc{
@@ -334,6 +344,7 @@ func BuiltInCustomFunctions(c *configTemplateBuilder, component *component.Synth
 		builtInGetCAFile:                             getCAFile,
 		builtInGetCertFile:                           getCertFile,
 		builtInGetKeyFile:                            getKeyFile,
+		builtInGetBindAddressFunctionName:            wrapGetBindAddress(component),
 	}
 
 }
@@ -27,6 +27,16 @@ import (
 )
 
 func DoMerge(baseData map[string]string, patch map[string]appsv1alpha1.ConfigParams, cc *appsv1beta1.ConfigConstraint, configSpec appsv1alpha1.ComponentConfigSpec) (map[string]string, error) {
+	if hasParameterMigrations(cc) {
+		// the base config may be rendered from a template of the older engine versions,
+		// translate the obsolete parameters in it as well.
+		migratedFiles, _, err := core.MigrateConfigFiles(baseData, configSpec.Keys, &cc.Spec)
+		if err != nil {
+			return nil, err
+		}
+		baseData = core.MergeUpdatedConfig(baseData, migratedFiles)
+	}
+
 	var (
 		updatedFiles  = make(map[string]string, len(patch))
 		updatedParams = make([]core.ParamPairs, 0, len(patch))
@@ -37,15 +47,24 @@ func DoMerge(baseData map[string]string, patch map[string]appsv1alpha1.ConfigPar
 			updatedFiles[key] = *params.Content
 		}
 		if len(params.Parameters) > 0 {
+			parameters := params.Parameters
+			if cc != nil {
+				// translate the parameters set by the older engine versions.
+				parameters, _ = core.MigrateParameters(parameters, cc.Spec.ParameterMigrations)
+			}
 			updatedParams = append(updatedParams, core.ParamPairs{
 				Key:           key,
-				UpdatedParams: core.FromStringMap(params.Parameters),
+				UpdatedParams: core.FromStringMap(parameters),
 			})
 		}
 	}
 	return mergeUpdatedParams(baseData, updatedFiles, updatedParams, cc, configSpec)
 }
 
+func hasParameterMigrations(cc *appsv1beta1.ConfigConstraint) bool {
+	return cc != nil && len(cc.Spec.ParameterMigrations) > 0
+}
+
 func mergeUpdatedParams(base map[string]string,
 	updatedFiles map[string]string,
 	updatedParams []core.ParamPairs,
@@ -20,6 +20,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package configuration
 
 import (
+	"encoding/json"
 	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
@@ -179,9 +180,12 @@ func CheckAndUpdateItemStatus(updated *appsv1alpha1.Configuration, item appsv1al
 
 func (p *pipeline) UpdatePodVolumes() *pipeline {
 	return p.Wrap(func() error {
-		return intctrlutil.CreateOrUpdatePodVolumes(p.ctx.PodSpec,
-			p.renderWrapper.volumes,
-			configSetFromComponent(p.ctx.SynthesizedComponent.ConfigTemplates))
+		configSet := configSetFromComponent(p.ctx.SynthesizedComponent.ConfigTemplates)
+		volumes, secretVolumes := splitSecretBackedVolumes(p.renderWrapper.volumes, p.ctx.SynthesizedComponent.ConfigTemplates)
+		if err := intctrlutil.CreateOrUpdatePodVolumes(p.ctx.PodSpec, volumes, configSet); err != nil {
+			return err
+		}
+		return intctrlutil.CreateOrUpdatePodSecretVolumes(p.ctx.PodSpec, secretVolumes, configSet)
 	})
 }
 
@@ -196,6 +200,11 @@ func (p *pipeline) UpdateConfigRelatedObject() *pipeline {
 		if err := injectTemplateEnvFrom(p.ctx.Cluster, p.ctx.SynthesizedComponent, p.ctx.PodSpec, p.Client, p.Context, p.renderWrapper.renderedObjs); err != nil {
 			return err
 		}
+		for _, secret := range p.renderWrapper.renderedSecrets {
+			if err := createOrUpdateConfigSecret(p.Context, p.Client, secret); err != nil {
+				return err
+			}
+		}
 		return createConfigObjects(p.Client, p.Context, p.renderWrapper.renderedObjs)
 	}
 
@@ -306,7 +315,7 @@ func (p *updatePipeline) RerenderTemplate() *updatePipeline {
 
 func (p *updatePipeline) ApplyParameters() *updatePipeline {
 	patchMerge := func(p *updatePipeline, spec appsv1alpha1.ComponentConfigSpec, cm *corev1.ConfigMap, item appsv1alpha1.ConfigurationItemDetail) error {
-		if p.isDone() || len(item.ConfigFileParams) == 0 {
+		if p.isDone() || (len(item.ConfigFileParams) == 0 && !hasParameterMigrations(p.ConfigConstraintObj)) {
 			return nil
 		}
 		newData, err := DoMerge(cm.Data, item.ConfigFileParams, p.ConfigConstraintObj, spec)
@@ -338,6 +347,39 @@ func (p *updatePipeline) ApplyParameters() *updatePipeline {
 	})
 }
 
+func (p *updatePipeline) ApplyRoleOverlays() *updatePipeline {
+	return p.Wrap(func() error {
+		if p.isDone() {
+			return nil
+		}
+		if p.newCM.Annotations == nil {
+			p.newCM.Annotations = make(map[string]string)
+		}
+		// the role files are rendered again from the base configuration.
+		p.newCM.Data = WithoutRoleOverlayFiles(p.newCM.Data)
+		if len(p.item.RoleOverlays) == 0 {
+			// clear the overlays rendered before, the annotations of the existing configmap are retained on sync.
+			if p.ConfigMapObj != nil && p.ConfigMapObj.Annotations[constant.CMRoleConfigOverlaysAnnotationKey] != "" {
+				p.newCM.Annotations[constant.CMRoleConfigOverlaysAnnotationKey] = ""
+			}
+			return nil
+		}
+		overlays, files, err := RenderRoleOverlays(p.newCM.Data, p.item.RoleOverlays, p.ConfigConstraintObj, *p.configSpec)
+		if err != nil {
+			return err
+		}
+		for key, value := range files {
+			p.newCM.Data[key] = value
+		}
+		b, err := json.Marshal(overlays)
+		if err != nil {
+			return err
+		}
+		p.newCM.Annotations[constant.CMRoleConfigOverlaysAnnotationKey] = string(b)
+		return nil
+	})
+}
+
 func (p *updatePipeline) UpdateConfigVersion(revision string) *updatePipeline {
 	return p.Wrap(func() error {
 		if p.isDone() {
@@ -374,6 +416,11 @@ func (p *updatePipeline) Sync() *updatePipeline {
 		case p.isDone():
 			return nil
 		case p.ConfigMapObj == nil && p.newCM != nil:
+			if IsSecretBackedConfig(*p.configSpec) {
+				if err := createOrUpdateConfigSecret(p.Context, p.Client, splitConfigSecret(p.newCM)); err != nil {
+					return err
+				}
+			}
 			return p.Client.Create(p.Context, p.newCM)
 		case p.ConfigMapObj != nil:
 			patch := client.MergeFrom(p.ConfigMapObj)
@@ -381,6 +428,10 @@ func (p *updatePipeline) Sync() *updatePipeline {
 				p.newCM.Labels = intctrlutil.MergeMetadataMaps(p.newCM.Labels, p.ConfigMapObj.Labels)
 				p.newCM.Annotations = intctrlutil.MergeMetadataMaps(p.newCM.Annotations, p.ConfigMapObj.Annotations)
 			}
+			// write the files to the Secret before the configmap, which triggers the reconfiguring.
+			if err := SyncSecretBackedData(p.Context, p.Client, p.newCM); err != nil {
+				return err
+			}
 			return p.Client.Patch(p.Context, p.newCM, patch)
 		}
 		return core.MakeError("unexpected condition")
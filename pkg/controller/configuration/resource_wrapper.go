@@ -168,7 +168,10 @@ func (r *ResourceFetcher[T]) ConfigMap(configSpec string) *T {
 
 	return r.Wrap(func() error {
 		r.ConfigMapObj = &corev1.ConfigMap{}
-		return r.Client.Get(r.Context, cmKey, r.ConfigMapObj, inDataContextUnspecified())
+		if err := r.Client.Get(r.Context, cmKey, r.ConfigMapObj, inDataContextUnspecified()); err != nil {
+			return err
+		}
+		return LoadSecretBackedData(r.Context, r.Client, r.ConfigMapObj)
 	})
 }
 
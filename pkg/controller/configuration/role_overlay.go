@@ -0,0 +1,160 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package configuration
+
+import (
+	"encoding/json"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	appsv1beta1 "github.com/apecloud/kubeblocks/apis/apps/v1beta1"
+	"github.com/apecloud/kubeblocks/pkg/configuration/core"
+	cfgutil "github.com/apecloud/kubeblocks/pkg/configuration/util"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+// roleOverlayFileInfix separates the configuration file and the role in the key of a rendered role file.
+const roleOverlayFileInfix = ".role-"
+
+// RoleOverlay is the rendered overlay of a role, which is applied online to the pods of the role.
+type RoleOverlay struct {
+	// Hash identifies the overlay, the pods record it after the overlay has been applied.
+	Hash string `json:"hash"`
+	// Apply holds the parameters to set on the pods that take the role.
+	Apply map[string]string `json:"apply,omitempty"`
+	// Revert holds the base values of the overlaid parameters, which restore the pods that leave the role.
+	Revert map[string]string `json:"revert,omitempty"`
+}
+
+// AppliedRoleOverlay is the overlay recorded on a pod.
+type AppliedRoleOverlay struct {
+	Role   string            `json:"role"`
+	Hash   string            `json:"hash"`
+	Revert map[string]string `json:"revert,omitempty"`
+	// Restarts is the restart count of the containers when the overlay was applied, a restarted engine
+	// loads the configuration files again and drops the parameters applied online.
+	Restarts int32 `json:"restarts,omitempty"`
+}
+
+// RoleOverlayFileKey returns the key of the configuration file rendered for the role in the configmap.
+func RoleOverlayFileKey(key, role string) string {
+	return key + roleOverlayFileInfix + role
+}
+
+// WithoutRoleOverlayFiles returns the configuration files of the configmap data except the rendered role files.
+func WithoutRoleOverlayFiles(data map[string]string) map[string]string {
+	r := make(map[string]string, len(data))
+	for key, value := range data {
+		if !strings.Contains(key, roleOverlayFileInfix) {
+			r[key] = value
+		}
+	}
+	return r
+}
+
+// RenderRoleOverlays renders the role overlays against the base configuration, it returns the overlays
+// to apply online and the configuration files of the roles, keyed by RoleOverlayFileKey, to store in the configmap.
+func RenderRoleOverlays(baseData map[string]string, overlays []appsv1alpha1.RoleConfigOverlay, cc *appsv1beta1.ConfigConstraint, configSpec appsv1alpha1.ComponentConfigSpec) (map[string]RoleOverlay, map[string]string, error) {
+	if cc == nil {
+		return nil, nil, core.MakeError("the role overlays of config spec[%s] require the config constraint", configSpec.Name)
+	}
+
+	rendered := make(map[string]RoleOverlay, len(overlays))
+	files := make(map[string]string)
+	for _, overlay := range overlays {
+		if len(overlay.ConfigFileParams) == 0 {
+			continue
+		}
+		roleData, err := DoMerge(baseData, overlay.ConfigFileParams, cc, configSpec)
+		if err != nil {
+			return nil, nil, err
+		}
+		apply, err := diffParameters(baseData, roleData, cc, configSpec)
+		if err != nil {
+			return nil, nil, err
+		}
+		revert, err := diffParameters(roleData, baseData, cc, configSpec)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(apply) == 0 && len(revert) == 0 {
+			continue
+		}
+		hash, err := cfgutil.ComputeHash(map[string]map[string]string{"apply": apply, "revert": revert})
+		if err != nil {
+			return nil, nil, err
+		}
+		rendered[overlay.Role] = RoleOverlay{Hash: hash, Apply: apply, Revert: revert}
+		for key, value := range roleData {
+			if value != baseData[key] {
+				files[RoleOverlayFileKey(key, overlay.Role)] = value
+			}
+		}
+	}
+	return rendered, files, nil
+}
+
+// diffParameters returns the parameters updated from the old configuration to the new one.
+// The parameters removed by the new configuration are not included, as they cannot be unset online.
+func diffParameters(oldData, newData map[string]string, cc *appsv1beta1.ConfigConstraint, configSpec appsv1alpha1.ComponentConfigSpec) (map[string]string, error) {
+	patch, _, err := core.CreateConfigPatch(oldData, newData, cc.Spec.FileFormatConfig.Format, configSpec.Keys, false)
+	if err != nil {
+		return nil, err
+	}
+	r := make(map[string]string)
+	for _, key := range core.GenerateVisualizedParamsList(patch, cc.Spec.FileFormatConfig, nil) {
+		if key.UpdateType != core.UpdatedType {
+			continue
+		}
+		for _, p := range key.Parameters {
+			if p.Value != nil {
+				r[p.Key] = *p.Value
+			}
+		}
+	}
+	return r, nil
+}
+
+// GetRoleOverlays returns the rendered role overlays of the configmap.
+func GetRoleOverlays(cm *corev1.ConfigMap) (map[string]RoleOverlay, error) {
+	overlays := make(map[string]RoleOverlay)
+	if cm == nil || cm.Annotations[constant.CMRoleConfigOverlaysAnnotationKey] == "" {
+		return overlays, nil
+	}
+	if err := json.Unmarshal([]byte(cm.Annotations[constant.CMRoleConfigOverlaysAnnotationKey]), &overlays); err != nil {
+		return nil, err
+	}
+	return overlays, nil
+}
+
+// GetAppliedRoleOverlay returns the overlay of the config spec applied to the pod, or nil if none is applied.
+func GetAppliedRoleOverlay(pod *corev1.Pod, configSpec string) (*AppliedRoleOverlay, error) {
+	value := pod.Annotations[core.GenerateUniqKeyWithConfig(constant.RoleConfigOverlayAnnotationKeyPrefix, configSpec)]
+	if value == "" {
+		return nil, nil
+	}
+	applied := &AppliedRoleOverlay{}
+	if err := json.Unmarshal([]byte(value), applied); err != nil {
+		return nil, err
+	}
+	return applied, nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package configuration
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	appsv1beta1 "github.com/apecloud/kubeblocks/apis/apps/v1beta1"
+	cfgutil "github.com/apecloud/kubeblocks/pkg/configuration/util"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+var _ = Describe("RoleOverlay test", func() {
+	var (
+		configSpec = appsv1alpha1.ComponentConfigSpec{
+			ComponentTemplateSpec: appsv1alpha1.ComponentTemplateSpec{
+				Name: "mysql-config",
+			},
+		}
+		cc = &appsv1beta1.ConfigConstraint{
+			Spec: appsv1beta1.ConfigConstraintSpec{
+				FileFormatConfig: &appsv1beta1.FileFormatConfig{
+					Format: appsv1beta1.Ini,
+					FormatterAction: appsv1beta1.FormatterAction{
+						IniConfig: &appsv1beta1.IniConfig{
+							SectionName: "mysqld",
+						},
+					},
+				},
+			},
+		}
+		baseData = map[string]string{
+			"my.cnf": "[mysqld]\nmax_connections=1000\nread_only=0\n",
+		}
+	)
+
+	It("renders the overlay of the role", func() {
+		overlays, files, err := RenderRoleOverlays(baseData, []appsv1alpha1.RoleConfigOverlay{
+			{
+				Role: "follower",
+				ConfigFileParams: map[string]appsv1alpha1.ConfigParams{
+					"my.cnf": {Parameters: map[string]*string{"read_only": cfgutil.ToPointer("1")}},
+				},
+			},
+			{
+				Role: "leader",
+			},
+		}, cc, configSpec)
+		Expect(err).Should(Succeed())
+		Expect(overlays).Should(HaveLen(1))
+		Expect(overlays["follower"].Apply).Should(Equal(map[string]string{"read_only": "1"}))
+		Expect(overlays["follower"].Revert).Should(Equal(map[string]string{"read_only": "0"}))
+		Expect(overlays["follower"].Hash).ShouldNot(BeEmpty())
+		Expect(files).Should(HaveLen(1))
+		Expect(files).Should(HaveKey(RoleOverlayFileKey("my.cnf", "follower")))
+		Expect(files[RoleOverlayFileKey("my.cnf", "follower")]).Should(MatchRegexp(`read_only\s*=\s*1`))
+	})
+
+	It("excludes the role files from the configuration files", func() {
+		data := map[string]string{
+			"my.cnf":                                 baseData["my.cnf"],
+			RoleOverlayFileKey("my.cnf", "follower"): "[mysqld]\nmax_connections=1000\nread_only=1\n",
+		}
+		Expect(WithoutRoleOverlayFiles(data)).Should(Equal(baseData))
+	})
+
+	It("requires the config constraint", func() {
+		_, _, err := RenderRoleOverlays(baseData, []appsv1alpha1.RoleConfigOverlay{{Role: "follower"}}, nil, configSpec)
+		Expect(err).ShouldNot(Succeed())
+	})
+
+	It("parses the overlays of the configmap and the pod", func() {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					constant.CMRoleConfigOverlaysAnnotationKey: `{"follower":{"hash":"abc","apply":{"read_only":"1"}}}`,
+				},
+			},
+		}
+		overlays, err := GetRoleOverlays(cm)
+		Expect(err).Should(Succeed())
+		Expect(overlays["follower"].Apply).Should(HaveKeyWithValue("read_only", "1"))
+
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					constant.RoleConfigOverlayAnnotationKeyPrefix + "-mysql-config": `{"role":"follower","hash":"abc"}`,
+				},
+			},
+		}
+		applied, err := GetAppliedRoleOverlay(pod, "mysql-config")
+		Expect(err).Should(Succeed())
+		Expect(applied.Role).Should(Equal("follower"))
+
+		applied, err = GetAppliedRoleOverlay(&corev1.Pod{}, "mysql-config")
+		Expect(err).Should(Succeed())
+		Expect(applied).Should(BeNil())
+	})
+})
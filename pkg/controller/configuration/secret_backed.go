@@ -0,0 +1,181 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package configuration
+
+import (
+	"context"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/configuration/core"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+// IsSecretBackedConfig checks whether the rendered files of the config spec are stored in a Secret.
+func IsSecretBackedConfig(configSpec appsv1alpha1.ComponentConfigSpec) bool {
+	return configSpec.AsSecret != nil && *configSpec.AsSecret
+}
+
+// IsSecretBackedConfigMap checks whether the files of the configmap are stored in the Secret of the same name.
+func IsSecretBackedConfigMap(cm *corev1.ConfigMap) bool {
+	if cm == nil || len(cm.Annotations) == 0 {
+		return false
+	}
+	b, err := strconv.ParseBool(cm.Annotations[constant.CMConfigurationSecretBackedAnnotationKey])
+	return err == nil && b
+}
+
+func validateSecretBackedConfig(configSpec appsv1alpha1.ComponentConfigSpec) error {
+	if IsSecretBackedConfig(configSpec) && (len(configSpec.InjectEnvTo) != 0 || len(configSpec.AsEnvFrom) != 0) {
+		return core.MakeError("config spec[%s] stored as secret can't be injected as env", configSpec.Name)
+	}
+	return nil
+}
+
+// splitSecretBackedVolumes splits the volumes of the templates stored in Secrets from the volumes of the configmaps.
+func splitSecretBackedVolumes(volumes map[string]appsv1alpha1.ComponentTemplateSpec, configSpecs []appsv1alpha1.ComponentConfigSpec) (map[string]appsv1alpha1.ComponentTemplateSpec, map[string]appsv1alpha1.ComponentTemplateSpec) {
+	secretBacked := make(map[string]bool)
+	for _, configSpec := range configSpecs {
+		if IsSecretBackedConfig(configSpec) {
+			secretBacked[configSpec.Name] = true
+		}
+	}
+	cmVolumes := make(map[string]appsv1alpha1.ComponentTemplateSpec, len(volumes))
+	secretVolumes := make(map[string]appsv1alpha1.ComponentTemplateSpec)
+	for name, templateSpec := range volumes {
+		if secretBacked[templateSpec.Name] {
+			secretVolumes[name] = templateSpec
+		} else {
+			cmVolumes[name] = templateSpec
+		}
+	}
+	return cmVolumes, secretVolumes
+}
+
+// splitConfigSecret moves the files of the configmap into a Secret of the same name, and marks the configmap as secret-backed.
+func splitConfigSecret(cm *corev1.ConfigMap) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            cm.Name,
+			Namespace:       cm.Namespace,
+			Labels:          make(map[string]string, len(cm.Labels)),
+			OwnerReferences: cm.OwnerReferences,
+		},
+		Type: corev1.SecretTypeOpaque,
+	}
+	for k, v := range cm.Labels {
+		secret.Labels[k] = v
+	}
+	secret.Data = toSecretData(cm.Data)
+	stripConfigData(cm)
+	return secret
+}
+
+// stripConfigData removes the file contents from the configmap, which are kept in the Secret.
+func stripConfigData(cm *corev1.ConfigMap) {
+	cm.Data = nil
+	delete(cm.Annotations, constant.LastAppliedConfigAnnotationKey)
+	if cm.Annotations == nil {
+		cm.Annotations = make(map[string]string)
+	}
+	cm.Annotations[constant.CMConfigurationSecretBackedAnnotationKey] = strconv.FormatBool(true)
+}
+
+func toSecretData(data map[string]string) map[string][]byte {
+	r := make(map[string][]byte, len(data))
+	for k, v := range data {
+		r[k] = []byte(v)
+	}
+	return r
+}
+
+// LoadSecretBackedData fills the secret-backed configmap with the files and the last applied configuration
+// stored in its Secret, so the reconfiguring pipelines handle it as a plain configmap.
+func LoadSecretBackedData(ctx context.Context, cli client.Client, cm *corev1.ConfigMap) error {
+	if !IsSecretBackedConfigMap(cm) {
+		return nil
+	}
+	secret := &corev1.Secret{}
+	if err := cli.Get(ctx, client.ObjectKeyFromObject(cm), secret, inDataContextUnspecified()); err != nil {
+		return err
+	}
+	cm.Data = make(map[string]string, len(secret.Data))
+	for k, v := range secret.Data {
+		cm.Data[k] = string(v)
+	}
+	if lastApplied, ok := secret.Annotations[constant.LastAppliedConfigAnnotationKey]; ok {
+		cm.Annotations[constant.LastAppliedConfigAnnotationKey] = lastApplied
+	}
+	return nil
+}
+
+// SyncSecretBackedData writes the files of the loaded secret-backed configmap to its Secret, and strips them from the configmap.
+func SyncSecretBackedData(ctx context.Context, cli client.Client, cm *corev1.ConfigMap) error {
+	if !IsSecretBackedConfigMap(cm) {
+		return nil
+	}
+	return patchConfigSecret(ctx, cli, cm, func(secret *corev1.Secret) {
+		secret.Data = toSecretData(cm.Data)
+		stripConfigData(cm)
+	})
+}
+
+// UpdateSecretBackedLastApplied records the last applied configuration of the secret-backed configmap in its Secret.
+func UpdateSecretBackedLastApplied(ctx context.Context, cli client.Client, cm *corev1.ConfigMap, lastApplied string) error {
+	return patchConfigSecret(ctx, cli, cm, func(secret *corev1.Secret) {
+		if secret.Annotations == nil {
+			secret.Annotations = make(map[string]string)
+		}
+		secret.Annotations[constant.LastAppliedConfigAnnotationKey] = lastApplied
+	})
+}
+
+func createOrUpdateConfigSecret(ctx context.Context, cli client.Client, secret *corev1.Secret) error {
+	err := cli.Create(ctx, secret, inDataContext())
+	if err == nil || !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	existing := &corev1.Secret{}
+	if err := cli.Get(ctx, client.ObjectKeyFromObject(secret), existing, inDataContext()); err != nil {
+		return err
+	}
+	patch := client.MergeFrom(existing.DeepCopy())
+	existing.Data = secret.Data
+	return cli.Patch(ctx, existing, patch, inDataContext())
+}
+
+func patchConfigSecret(ctx context.Context, cli client.Client, cm *corev1.ConfigMap, mutate func(secret *corev1.Secret)) error {
+	secret := &corev1.Secret{}
+	err := cli.Get(ctx, client.ObjectKeyFromObject(cm), secret, inDataContextUnspecified())
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err != nil {
+		return core.MakeError("not found the secret of secret-backed configmap: %s", client.ObjectKeyFromObject(cm))
+	}
+	patch := client.MergeFrom(secret.DeepCopy())
+	mutate(secret)
+	return cli.Patch(ctx, secret, patch, inDataContextUnspecified())
+}
@@ -0,0 +1,86 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package configuration
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	cfgutil "github.com/apecloud/kubeblocks/pkg/configuration/util"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+var _ = Describe("SecretBacked test", func() {
+	It("splits the files of the configmap into the secret", func() {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-mysql-config",
+				Namespace: "default",
+				Labels: map[string]string{
+					constant.AppInstanceLabelKey: "test",
+				},
+				Annotations: map[string]string{
+					constant.LastAppliedConfigAnnotationKey: `{"my.cnf":"[mysqld]"}`,
+				},
+			},
+			Data: map[string]string{
+				"my.cnf": "[mysqld]\nreplication_password=secret\n",
+			},
+		}
+		secret := splitConfigSecret(cm)
+		Expect(secret.Name).Should(Equal(cm.Name))
+		Expect(secret.Labels).Should(Equal(cm.Labels))
+		Expect(string(secret.Data["my.cnf"])).Should(ContainSubstring("replication_password"))
+		Expect(cm.Data).Should(BeNil())
+		Expect(cm.Annotations).ShouldNot(HaveKey(constant.LastAppliedConfigAnnotationKey))
+		Expect(IsSecretBackedConfigMap(cm)).Should(BeTrue())
+	})
+
+	It("splits the secret volumes", func() {
+		configSpecs := []appsv1alpha1.ComponentConfigSpec{
+			{ComponentTemplateSpec: appsv1alpha1.ComponentTemplateSpec{Name: "mysql-config"}, AsSecret: cfgutil.ToPointer(true)},
+			{ComponentTemplateSpec: appsv1alpha1.ComponentTemplateSpec{Name: "mysql-custom"}},
+		}
+		volumes := map[string]appsv1alpha1.ComponentTemplateSpec{
+			"test-mysql-config": configSpecs[0].ComponentTemplateSpec,
+			"test-mysql-custom": configSpecs[1].ComponentTemplateSpec,
+		}
+		cmVolumes, secretVolumes := splitSecretBackedVolumes(volumes, configSpecs)
+		Expect(cmVolumes).Should(HaveKey("test-mysql-custom"))
+		Expect(secretVolumes).Should(HaveKey("test-mysql-config"))
+		Expect(cmVolumes).Should(HaveLen(1))
+		Expect(secretVolumes).Should(HaveLen(1))
+	})
+
+	It("rejects the secret-backed config injected as env", func() {
+		configSpec := appsv1alpha1.ComponentConfigSpec{
+			ComponentTemplateSpec: appsv1alpha1.ComponentTemplateSpec{Name: "mysql-config"},
+			AsSecret:              cfgutil.ToPointer(true),
+			InjectEnvTo:           []string{"mysql"},
+		}
+		Expect(validateSecretBackedConfig(configSpec)).ShouldNot(Succeed())
+		configSpec.InjectEnvTo = nil
+		Expect(validateSecretBackedConfig(configSpec)).Should(Succeed())
+	})
+})
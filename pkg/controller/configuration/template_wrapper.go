@@ -51,6 +51,7 @@ type renderWrapper struct {
 	volumes             map[string]appsv1alpha1.ComponentTemplateSpec
 	templateAnnotations map[string]string
 	renderedObjs        []client.Object
+	renderedSecrets     []*corev1.Secret
 
 	ctx       context.Context
 	cli       client.Client
@@ -104,6 +105,9 @@ func (wrapper *renderWrapper) renderConfigTemplate(cluster *appsv1alpha1.Cluster
 	revision := fromConfiguration(configuration)
 	for _, configSpec := range component.ConfigTemplates {
 		var item *appsv1alpha1.ConfigurationItemDetail
+		if err := validateSecretBackedConfig(configSpec); err != nil {
+			return err
+		}
 		cmName := core.GetComponentCfgName(cluster.Name, component.Name, configSpec.Name)
 		origCMObj, err := wrapper.checkRerenderTemplateSpec(cmName, localObjs)
 		if err != nil {
@@ -133,6 +137,9 @@ func (wrapper *renderWrapper) renderConfigTemplate(cluster *appsv1alpha1.Cluster
 		if err := updateConfigMetaForCM(newCMObj, item, revision); err != nil {
 			return err
 		}
+		if IsSecretBackedConfig(configSpec) {
+			wrapper.renderedSecrets = append(wrapper.renderedSecrets, splitConfigSecret(newCMObj))
+		}
 	}
 	return nil
 }
@@ -170,7 +177,7 @@ func applyUpdatedParameters(item *appsv1alpha1.ConfigurationItemDetail, cm *core
 	var newData map[string]string
 	var configConstraint *appsv1beta1.ConfigConstraint
 
-	if item == nil || len(item.ConfigFileParams) == 0 {
+	if item == nil {
 		return
 	}
 	if configSpec.ConfigConstraintRef != "" {
@@ -179,6 +186,9 @@ func applyUpdatedParameters(item *appsv1alpha1.ConfigurationItemDetail, cm *core
 	if err != nil {
 		return
 	}
+	if len(item.ConfigFileParams) == 0 && !hasParameterMigrations(configConstraint) {
+		return
+	}
 	newData, err = DoMerge(cm.Data, item.ConfigFileParams, configConstraint, configSpec)
 	if err != nil {
 		return
@@ -21,6 +21,7 @@ package factory
 
 import (
 	"encoding/json"
+	"fmt"
 	"path/filepath"
 	"strconv"
 
@@ -80,6 +81,13 @@ func BuildInstanceSet(synthesizedComp *component.SynthesizedComponent, component
 		podBuilder.AddAnnotations(constant.ComponentReplicasAnnotationKey, replicasStr)
 
 	}
+	if synthesizedComp.BackupReplica != nil && *synthesizedComp.BackupReplica {
+		// all the replicas serve the read services by default, the backup replica stops serving while a backup runs on it.
+		podBuilder.AddLabels(constant.ReadServingLabelKey, "true")
+	}
+	if len(synthesizedComp.KBAgentHandlers) > 0 {
+		podBuilder.AddAnnotations(constant.KBAgentHandlersAnnotationKey, synthesizedComp.KBAgentHandlers)
+	}
 	template := corev1.PodTemplateSpec{
 		ObjectMeta: podBuilder.GetObject().ObjectMeta,
 		Spec:       *synthesizedComp.PodSpec.DeepCopy(),
@@ -104,6 +112,9 @@ func BuildInstanceSet(synthesizedComp *component.SynthesizedComponent, component
 		itsBuilder.AddAnnotations(constant.FeatureReconciliationInCompactModeAnnotationKey,
 			synthesizedComp.Annotations[constant.FeatureReconciliationInCompactModeAnnotationKey])
 	}
+	if synthesizedComp.IPStack != nil {
+		itsBuilder.AddAnnotations(constant.IPStackAnnotationKey, component.FormatIPStack(synthesizedComp.IPStack))
+	}
 
 	// convert componentDef attributes to workload attributes. including service, credential, roles, roleProbe, membershipReconfiguration, memberUpdateStrategy, etc.
 	itsObj, err := component.BuildWorkloadFrom(synthesizedComp, itsBuilder.GetObject())
@@ -120,6 +131,9 @@ func BuildInstanceSet(synthesizedComp *component.SynthesizedComponent, component
 		}
 	}
 
+	if err = setSidecarResources(synthesizedComp, componentDef, itsObj); err != nil {
+		return nil, err
+	}
 	setDefaultResourceLimits(itsObj)
 
 	return itsObj, nil
@@ -162,6 +176,50 @@ func getBuiltinContainer(synthesizedComp *component.SynthesizedComponent, contai
 	return nil
 }
 
+// setSidecarResources sets the resources of the sidecar containers, the ones specified in the component take
+// precedence over the defaults of the operator. The main container is left untouched.
+func setSidecarResources(synthesizedComp *component.SynthesizedComponent,
+	componentDef *appsv1alpha1.ComponentDefinition, its *workloads.InstanceSet) error {
+	exporterContainerName := ""
+	if componentDef != nil {
+		if exporter := component.GetExporter(componentDef.Spec); exporter != nil {
+			exporterContainerName = exporter.ContainerName
+		}
+	}
+	containerName := func(name string) string {
+		if name == constant.ExporterSidecarName && exporterContainerName != "" {
+			return exporterContainerName
+		}
+		return name
+	}
+
+	resources := map[string]corev1.ResourceRequirements{}
+	if data := viper.GetString(constant.KBSidecarResources); data != "" {
+		defaults := map[string]corev1.ResourceRequirements{}
+		if err := json.Unmarshal([]byte(data), &defaults); err != nil {
+			return fmt.Errorf("invalid sidecar resources of the operator: %s", err.Error())
+		}
+		for name, r := range defaults {
+			resources[containerName(name)] = r
+		}
+	}
+	for _, r := range synthesizedComp.SidecarResources {
+		resources[containerName(r.Name)] = r.Resources
+	}
+	if len(resources) == 0 {
+		return nil
+	}
+
+	containers := its.Spec.Template.Spec.Containers
+	// the first container is the main container, whose resources are specified by the component.
+	for i := 1; i < len(containers); i++ {
+		if r, ok := resources[containers[i].Name]; ok {
+			containers[i].Resources = *r.DeepCopy()
+		}
+	}
+	return nil
+}
+
 func setDefaultResourceLimits(its *workloads.InstanceSet) {
 	for _, cc := range []*[]corev1.Container{&its.Spec.Template.Spec.Containers, &its.Spec.Template.Spec.InitContainers} {
 		for i := range *cc {
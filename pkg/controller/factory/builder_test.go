@@ -27,6 +27,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -186,6 +187,49 @@ var _ = Describe("builder", func() {
 			// test member update strategy
 			Expect(its.Spec.MemberUpdateStrategy).ShouldNot(BeNil())
 			Expect(*its.Spec.MemberUpdateStrategy).Should(BeEquivalentTo(workloads.BestEffortParallelUpdateStrategy))
+
+			// test quorum guard of the votable roles
+			Expect(its.Spec.QuorumGuard).ShouldNot(BeNil())
+			Expect(its.Spec.QuorumGuard.CheckSyncedReplicas).Should(BeTrue())
+		})
+
+		It("builds InstanceSet with sidecar resources correctly", func() {
+			compDef, _, synthesizedComponent := newClusterObjs(nil)
+			compDef.Spec.Exporter.ContainerName = "metrics"
+			synthesizedComponent.PodSpec.Containers = append(synthesizedComponent.PodSpec.Containers,
+				corev1.Container{Name: constant.LorryContainerName}, corev1.Container{Name: "metrics"})
+			mainResources := synthesizedComponent.PodSpec.Containers[0].Resources
+
+			newResources := func(cpu string) corev1.ResourceRequirements {
+				return corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+				}
+			}
+			defaults, _ := json.Marshal(map[string]corev1.ResourceRequirements{
+				constant.LorryContainerName:  newResources("100m"),
+				constant.ExporterSidecarName: newResources("200m"),
+			})
+			viper.Set(constant.KBSidecarResources, string(defaults))
+			defer viper.Set(constant.KBSidecarResources, "")
+
+			By("apply the defaults of the operator")
+			its, err := BuildInstanceSet(synthesizedComponent, compDef)
+			Expect(err).Should(BeNil())
+			containers := its.Spec.Template.Spec.Containers
+			Expect(containers[len(containers)-2].Resources.Requests.Cpu().String()).Should(Equal("100m"))
+			Expect(containers[len(containers)-1].Resources.Requests.Cpu().String()).Should(Equal("200m"))
+
+			By("the resources of the component take precedence, and the main container is left untouched")
+			synthesizedComponent.SidecarResources = []appsv1alpha1.SidecarResources{
+				{Name: constant.LorryContainerName, Resources: newResources("300m")},
+				{Name: containers[0].Name, Resources: newResources("4")},
+			}
+			its, err = BuildInstanceSet(synthesizedComponent, compDef)
+			Expect(err).Should(BeNil())
+			containers = its.Spec.Template.Spec.Containers
+			Expect(containers[0].Resources.Requests).Should(Equal(mainResources.Requests))
+			Expect(containers[len(containers)-2].Resources.Requests.Cpu().String()).Should(Equal("300m"))
+			Expect(containers[len(containers)-1].Resources.Requests.Cpu().String()).Should(Equal("200m"))
 		})
 
 		It("builds BackupJob correctly", func() {
@@ -314,6 +314,10 @@ func getPodUpdatePolicy(its *workloads.InstanceSet, pod *corev1.Pod) (PodUpdateP
 	if err != nil {
 		return NoOpsPolicy, err
 	}
+	if isInstanceIPChanged(pod, inst.pod) {
+		return RecreatePolicy, nil
+	}
+
 	basicUpdate := !equalBasicInPlaceFields(pod, inst.pod)
 	if viper.GetBool(FeatureGateIgnorePodVerticalScaling) {
 		if basicUpdate {
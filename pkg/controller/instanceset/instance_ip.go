@@ -0,0 +1,148 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package instanceset
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+// InstanceIPs is the content of the annotation InstanceIPsAnnotationKey.
+type InstanceIPs struct {
+	// Network is the multus NetworkAttachmentDefinition that the static IPs belong to, in the format of "[namespace/]name".
+	// It is empty if the IPs are the cluster IPs of the instance Services.
+	Network string `json:"network,omitempty"`
+	// IPs maps the instance name to its IP.
+	IPs map[string]string `json:"ips"`
+}
+
+// multusNetworkSelection is the element of the multus networks annotation.
+type multusNetworkSelection struct {
+	Name      string   `json:"name"`
+	Namespace string   `json:"namespace,omitempty"`
+	IPs       []string `json:"ips,omitempty"`
+}
+
+// GetInstanceIPs parses the stable IPs of the instances from the annotations, nil if not present.
+func GetInstanceIPs(annotations map[string]string) (*InstanceIPs, error) {
+	value, ok := annotations[constant.InstanceIPsAnnotationKey]
+	if !ok || len(value) == 0 {
+		return nil, nil
+	}
+	instanceIPs := &InstanceIPs{}
+	if err := json.Unmarshal([]byte(value), instanceIPs); err != nil {
+		return nil, err
+	}
+	return instanceIPs, nil
+}
+
+// AssignInstanceStaticIPs assigns an IP from the pool to each instance.
+// The instances keep the IPs assigned previously as long as the IPs are still in the pool,
+// and the free IPs are assigned to the rest instances in the order of their names.
+func AssignInstanceStaticIPs(instanceNames []string, pool []string, assigned map[string]string) (map[string]string, error) {
+	poolSet := sets.New(pool...)
+	result := make(map[string]string)
+	used := sets.New[string]()
+	for _, name := range instanceNames {
+		if ip, ok := assigned[name]; ok && poolSet.Has(ip) && !used.Has(ip) {
+			result[name] = ip
+			used.Insert(ip)
+		}
+	}
+
+	names := slices.Clone(instanceNames)
+	slices.Sort(names)
+	next := 0
+	for _, name := range names {
+		if _, ok := result[name]; ok {
+			continue
+		}
+		for next < len(pool) && used.Has(pool[next]) {
+			next++
+		}
+		if next >= len(pool) {
+			return nil, fmt.Errorf("the static IP pool is exhausted, %d IPs for %d instances", len(pool), len(instanceNames))
+		}
+		result[name] = pool[next]
+		used.Insert(pool[next])
+	}
+	return result, nil
+}
+
+// setInstanceIP advertises the stable IP of the instance through the pod annotation InstanceIPAnnotationKey and
+// injects it into the containers as the env KB_INSTANCE_IP. If the IP is a static one, the pod is also attached
+// to the multus network with it.
+func setInstanceIP(pod *corev1.Pod, parent *workloads.InstanceSet) error {
+	instanceIPs, err := GetInstanceIPs(parent.Annotations)
+	if err != nil || instanceIPs == nil {
+		return err
+	}
+	ip, ok := instanceIPs.IPs[pod.Name]
+	if !ok {
+		return nil
+	}
+	if pod.Annotations == nil {
+		pod.Annotations = make(map[string]string)
+	}
+	// the static IPs are in CIDR notation
+	address, _, _ := strings.Cut(ip, "/")
+	pod.Annotations[constant.InstanceIPAnnotationKey] = address
+	env := corev1.EnvVar{Name: constant.KBEnvInstanceIP, Value: address}
+	for i := range pod.Spec.InitContainers {
+		pod.Spec.InitContainers[i].Env = append(pod.Spec.InitContainers[i].Env, env)
+	}
+	for i := range pod.Spec.Containers {
+		pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, env)
+	}
+
+	if len(instanceIPs.Network) == 0 {
+		return nil
+	}
+	network := multusNetworkSelection{Name: instanceIPs.Network, IPs: []string{ip}}
+	if namespace, name, found := strings.Cut(instanceIPs.Network, "/"); found {
+		network.Namespace, network.Name = namespace, name
+	}
+	value, err := json.Marshal([]multusNetworkSelection{network})
+	if err != nil {
+		return err
+	}
+	pod.Annotations[constant.MultusNetworksAnnotationKey] = string(value)
+	return nil
+}
+
+// isInstanceIPChanged tells whether the stable IP of the instance has been changed. The pod has to be recreated
+// to take the new IP, as the env is fixed once the containers are started and the multus network is attached
+// only when the pod sandbox is created.
+func isInstanceIPChanged(old, new *corev1.Pod) bool {
+	for _, key := range []string{constant.InstanceIPAnnotationKey, constant.MultusNetworksAnnotationKey} {
+		if value, ok := new.Annotations[key]; ok && value != old.Annotations[key] {
+			return true
+		}
+	}
+	return false
+}
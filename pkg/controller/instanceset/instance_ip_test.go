@@ -0,0 +1,105 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package instanceset
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+)
+
+var _ = Describe("instance ip test", func() {
+	Context("AssignInstanceStaticIPs function", func() {
+		pool := []string{"10.1.1.10/24", "10.1.1.11/24", "10.1.1.12/24"}
+
+		It("should assign the free IPs in the order of names", func() {
+			ips, err := AssignInstanceStaticIPs([]string{"foo-1", "foo-0"}, pool, nil)
+			Expect(err).Should(BeNil())
+			Expect(ips).Should(Equal(map[string]string{"foo-0": "10.1.1.10/24", "foo-1": "10.1.1.11/24"}))
+		})
+
+		It("should keep the IPs assigned previously", func() {
+			assigned := map[string]string{"foo-1": "10.1.1.10/24", "foo-2": "10.1.1.99/24"}
+			ips, err := AssignInstanceStaticIPs([]string{"foo-0", "foo-1", "foo-2"}, pool, assigned)
+			Expect(err).Should(BeNil())
+			Expect(ips).Should(Equal(map[string]string{"foo-0": "10.1.1.11/24", "foo-1": "10.1.1.10/24", "foo-2": "10.1.1.12/24"}))
+		})
+
+		It("should fail if the pool is exhausted", func() {
+			_, err := AssignInstanceStaticIPs([]string{"foo-0", "foo-1", "foo-2", "foo-3"}, pool, nil)
+			Expect(err).ShouldNot(BeNil())
+		})
+	})
+
+	Context("setInstanceIP function", func() {
+		newPod := func(name string) *corev1.Pod {
+			return builder.NewPodBuilder(namespace, name).
+				SetPodSpec(corev1.PodSpec{Containers: []corev1.Container{{Name: "foo"}}}).
+				GetObject()
+		}
+
+		It("should attach the pod to the network with its static IP", func() {
+			parent := builder.NewInstanceSetBuilder(namespace, name).
+				AddAnnotations(constant.InstanceIPsAnnotationKey, `{"network":"kube-system/macvlan","ips":{"bar-0":"10.1.1.10/24"}}`).
+				GetObject()
+			pod := newPod("bar-0")
+			Expect(setInstanceIP(pod, parent)).Should(Succeed())
+			Expect(pod.Annotations[constant.MultusNetworksAnnotationKey]).Should(Equal(`[{"name":"macvlan","namespace":"kube-system","ips":["10.1.1.10/24"]}]`))
+			Expect(pod.Annotations[constant.InstanceIPAnnotationKey]).Should(Equal("10.1.1.10"))
+			Expect(pod.Spec.Containers[0].Env).Should(ContainElement(corev1.EnvVar{Name: constant.KBEnvInstanceIP, Value: "10.1.1.10"}))
+
+			pod = newPod("bar-1")
+			Expect(setInstanceIP(pod, parent)).Should(Succeed())
+			Expect(pod.Annotations).ShouldNot(HaveKey(constant.MultusNetworksAnnotationKey))
+			Expect(pod.Annotations).ShouldNot(HaveKey(constant.InstanceIPAnnotationKey))
+		})
+
+		It("should advertise the cluster IP of the instance Service", func() {
+			parent := builder.NewInstanceSetBuilder(namespace, name).
+				AddAnnotations(constant.InstanceIPsAnnotationKey, `{"ips":{"bar-0":"10.96.10.10"}}`).
+				GetObject()
+			pod := newPod("bar-0")
+			Expect(setInstanceIP(pod, parent)).Should(Succeed())
+			Expect(pod.Annotations).ShouldNot(HaveKey(constant.MultusNetworksAnnotationKey))
+			Expect(pod.Annotations[constant.InstanceIPAnnotationKey]).Should(Equal("10.96.10.10"))
+			Expect(pod.Spec.Containers[0].Env).Should(ContainElement(corev1.EnvVar{Name: constant.KBEnvInstanceIP, Value: "10.96.10.10"}))
+		})
+	})
+
+	Context("isInstanceIPChanged function", func() {
+		It("should recreate the pod once its IP is changed", func() {
+			parent := builder.NewInstanceSetBuilder(namespace, name).
+				AddAnnotations(constant.InstanceIPsAnnotationKey, `{"network":"macvlan","ips":{"bar-0":"10.1.1.10/24"}}`).
+				GetObject()
+			oldPod := builder.NewPodBuilder(namespace, "bar-0").GetObject()
+			Expect(setInstanceIP(oldPod, parent)).Should(Succeed())
+			Expect(isInstanceIPChanged(oldPod, oldPod.DeepCopy())).Should(BeFalse())
+
+			parent.Annotations[constant.InstanceIPsAnnotationKey] = `{"network":"macvlan","ips":{"bar-0":"10.1.1.11/24"}}`
+			newPod := builder.NewPodBuilder(namespace, "bar-0").GetObject()
+			Expect(setInstanceIP(newPod, parent)).Should(Succeed())
+			Expect(isInstanceIPChanged(oldPod, newPod)).Should(BeTrue())
+		})
+	})
+})
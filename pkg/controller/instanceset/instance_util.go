@@ -381,6 +381,12 @@ func buildInstanceByTemplate(name string, template *instanceTemplateExt, parent
 	// Set these immutable fields only on initial Pod creation, not updates.
 	pod.Spec.Hostname = pod.Name
 	pod.Spec.Subdomain = getHeadlessSvcName(parent.Name)
+	if err = setInstanceIP(pod, parent); err != nil {
+		return nil, err
+	}
+	if err = setInstanceConsumerAffinity(pod, parent); err != nil {
+		return nil, err
+	}
 
 	// 2. build pvcs from template
 	pvcMap := make(map[string]*corev1.PersistentVolumeClaim)
@@ -427,6 +433,27 @@ func buildInstanceByTemplate(name string, template *instanceTemplateExt, parent
 	return inst, nil
 }
 
+// setInstanceConsumerAffinity appends the preferred pod affinity terms toward the consumer applications to the pod, if any.
+func setInstanceConsumerAffinity(pod *corev1.Pod, parent *workloads.InstanceSet) error {
+	value, ok := parent.Annotations[constant.ConsumerAffinityAnnotationKey]
+	if !ok || len(value) == 0 {
+		return nil
+	}
+	var terms []corev1.WeightedPodAffinityTerm
+	if err := json.Unmarshal([]byte(value), &terms); err != nil {
+		return err
+	}
+	if pod.Spec.Affinity == nil {
+		pod.Spec.Affinity = &corev1.Affinity{}
+	}
+	if pod.Spec.Affinity.PodAffinity == nil {
+		pod.Spec.Affinity.PodAffinity = &corev1.PodAffinity{}
+	}
+	pod.Spec.Affinity.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(
+		pod.Spec.Affinity.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution, terms...)
+	return nil
+}
+
 func buildInstancePVCByTemplate(name string, template *instanceTemplateExt, parent *workloads.InstanceSet) []*corev1.PersistentVolumeClaim {
 	// 2. build pvcs from template
 	var pvcs []*corev1.PersistentVolumeClaim
@@ -474,6 +501,13 @@ func copyAndMerge(oldObj, newObj client.Object) client.Object {
 		oldSvc.Spec.PublishNotReadyAddresses = newSvc.Spec.PublishNotReadyAddresses
 		// ignore NodePort&LB svc here, instanceSet only supports default headless svc
 		oldSvc.Spec.Ports = newSvc.Spec.Ports
+		// a single-stack service can be upgraded to dual-stack, but the primary family is immutable.
+		if newSvc.Spec.IPFamilyPolicy != nil {
+			oldSvc.Spec.IPFamilyPolicy = newSvc.Spec.IPFamilyPolicy
+		}
+		if len(newSvc.Spec.IPFamilies) > len(oldSvc.Spec.IPFamilies) {
+			oldSvc.Spec.IPFamilies = newSvc.Spec.IPFamilies
+		}
 		return oldSvc
 	}
 
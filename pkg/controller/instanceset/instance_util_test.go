@@ -211,6 +211,25 @@ var _ = Describe("instance util test", func() {
 			Expect(instance.pvcs[0].Labels[constant.VolumeClaimTemplateNameLabelKey]).Should(Equal(volumeClaimTemplates[0].Name))
 			Expect(instance.pvcs[0].Spec.Resources).Should(Equal(volumeClaimTemplates[0].Spec.Resources))
 		})
+
+		It("should apply the consumer affinity", func() {
+			its.Annotations = map[string]string{
+				constant.ConsumerAffinityAnnotationKey: `[{"weight":80,"podAffinityTerm":{"labelSelector":{"matchLabels":{"app":"web"}},"topologyKey":"kubernetes.io/hostname"}}]`,
+			}
+			itsExt, err := buildInstanceSetExt(its, nil)
+			Expect(err).Should(BeNil())
+			nameTemplate, err := buildInstanceName2TemplateMap(itsExt)
+			Expect(err).Should(BeNil())
+			name := name + "-0"
+			instance, err := buildInstanceByTemplate(name, nameTemplate[name], its, "")
+			Expect(err).Should(BeNil())
+			Expect(instance.pod.Spec.Affinity).ShouldNot(BeNil())
+			Expect(instance.pod.Spec.Affinity.PodAffinity).ShouldNot(BeNil())
+			terms := instance.pod.Spec.Affinity.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+			Expect(terms).Should(HaveLen(1))
+			Expect(terms[0].Weight).Should(BeEquivalentTo(80))
+			Expect(terms[0].PodAffinityTerm.LabelSelector.MatchLabels).Should(HaveKeyWithValue("app", "web"))
+		})
 	})
 
 	Context("buildInstancePVCByTemplate", func() {
@@ -58,6 +58,9 @@ func buildHeadlessSvc(its workloads.InstanceSet, labels, selectors map[string]st
 		AddSelectorsInMap(selectors).
 		AddAnnotationsInMap(annotations).
 		SetPublishNotReadyAddresses(true)
+	if ipFamilyPolicy, ipFamilies := parseIPStack(its.Annotations); ipFamilyPolicy != nil || len(ipFamilies) > 0 {
+		hdlBuilder.SetIPFamilyPolicy(ipFamilyPolicy).SetIPFamilies(ipFamilies...)
+	}
 
 	for _, container := range its.Spec.Template.Spec.Containers {
 		for _, port := range container.Ports {
@@ -79,6 +82,25 @@ func buildHeadlessSvc(its workloads.InstanceSet, labels, selectors map[string]st
 	return hdlBuilder.GetObject()
 }
 
+// parseIPStack parses the IP family policy and the IP families of the annotation IPStackAnnotationKey.
+func parseIPStack(annotations map[string]string) (*corev1.IPFamilyPolicy, []corev1.IPFamily) {
+	policy, families, found := strings.Cut(annotations[constant.IPStackAnnotationKey], ":")
+	if !found {
+		return nil, nil
+	}
+	var ipFamilyPolicy *corev1.IPFamilyPolicy
+	if policy != "" {
+		ipFamilyPolicy = (*corev1.IPFamilyPolicy)(&policy)
+	}
+	var ipFamilies []corev1.IPFamily
+	for _, family := range strings.Split(families, ",") {
+		if family != "" {
+			ipFamilies = append(ipFamilies, corev1.IPFamily(family))
+		}
+	}
+	return ipFamilyPolicy, ipFamilies
+}
+
 func getHeadlessSvcName(itsName string) string {
 	return strings.Join([]string{itsName, "headless"}, "-")
 }
@@ -224,6 +224,20 @@ var _ = Describe("object generation transformer test.", func() {
 		})
 	})
 
+	Context("buildHeadlessSvc function", func() {
+		It("should render the IP stack", func() {
+			svc := buildHeadlessSvc(*its, nil, selectors)
+			Expect(svc.Spec.IPFamilyPolicy).Should(BeNil())
+			Expect(svc.Spec.IPFamilies).Should(BeEmpty())
+
+			its.Annotations = map[string]string{constant.IPStackAnnotationKey: "PreferDualStack:IPv6,IPv4"}
+			svc = buildHeadlessSvc(*its, nil, selectors)
+			Expect(svc.Spec.IPFamilyPolicy).ShouldNot(BeNil())
+			Expect(*svc.Spec.IPFamilyPolicy).Should(Equal(corev1.IPFamilyPolicyPreferDualStack))
+			Expect(svc.Spec.IPFamilies).Should(Equal([]corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol}))
+		})
+	})
+
 	Context("getHeadlessSvcName function", func() {
 		It("should work well", func() {
 			Expect(getHeadlessSvcName(its.Name)).Should(Equal("bar-headless"))
@@ -0,0 +1,127 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package instanceset
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
+)
+
+// checkQuorumGuard checks whether the pod can be deleted without breaking the quorum of the voting members,
+// a non-empty message explaining the reason is returned if the deletion should be blocked.
+// The pod deleted on scale-in leaves the membership, so the quorum is computed without it.
+func checkQuorumGuard(its *workloads.InstanceSet, pods []*corev1.Pod, pod *corev1.Pod, scaleIn bool) string {
+	guard := its.Spec.QuorumGuard
+	if guard == nil || isTerminating(pod) {
+		return ""
+	}
+	voterRoles := map[string]bool{}
+	for _, role := range its.Spec.Roles {
+		if role.CanVote {
+			voterRoles[strings.ToLower(role.Name)] = true
+		}
+	}
+	if !voterRoles[getRoleName(pod)] {
+		return ""
+	}
+
+	members := 0
+	available := 0
+	for _, p := range pods {
+		// the terminating members deleted on scale-in have left the membership.
+		if !voterRoles[getRoleName(p)] || (scaleIn && isTerminating(p)) {
+			continue
+		}
+		members++
+		if p.Name != pod.Name && isHealthy(p) {
+			available++
+		}
+	}
+	voters, maxAvailable := members, members-1
+	if scaleIn {
+		voters--
+		maxAvailable = voters
+	}
+	if voters <= 0 {
+		// the last voting member is deleted.
+		return ""
+	}
+	quorum := voters/2 + 1
+	if guard.MinAvailableVoters != nil {
+		quorum = int(*guard.MinAvailableVoters)
+	} else if quorum > maxAvailable {
+		// the membership can't keep the quorum after the deletion in any case, e.g. the only two voting members
+		// are updated, waiting doesn't help.
+		return ""
+	}
+	if available < quorum {
+		return fmt.Sprintf("deleting the voting member %s would leave %d available voting members, less than the quorum %d",
+			pod.Name, available, quorum)
+	}
+
+	// the writes are committed on the majority of the voting members before the deletion, one of the synced replicas
+	// is kept only if the remaining available voting members intersect any majority of them.
+	if guard.CheckSyncedReplicas && available < members-(members/2+1)+1 {
+		return fmt.Sprintf("the voting member %s may be the only synced replica, %d of the %d voting members are available",
+			pod.Name, available, members)
+	}
+	return ""
+}
+
+// checkScaleInQuorumGuard checks the pods to be deleted on scale-in, the message of the first blocked deletion is returned.
+func checkScaleInQuorumGuard(its *workloads.InstanceSet, pods []*corev1.Pod, deleteNameSet sets.String) string {
+	for _, pod := range pods {
+		if !deleteNameSet.Has(pod.Name) {
+			continue
+		}
+		if message := checkQuorumGuard(its, pods, pod, true); len(message) > 0 {
+			return message
+		}
+	}
+	return ""
+}
+
+// setQuorumGuardedCondition sets the InstanceQuorumGuarded condition with the message, or removes it if the message is empty.
+// The condition is owned by the update reconciler, which runs after the scale-in and the update of the instances.
+func setQuorumGuardedCondition(tree *kubebuilderx.ObjectTree, its *workloads.InstanceSet, message string) {
+	if len(message) == 0 {
+		meta.RemoveStatusCondition(&its.Status.Conditions, string(workloads.InstanceQuorumGuarded))
+		return
+	}
+	cond := meta.FindStatusCondition(its.Status.Conditions, string(workloads.InstanceQuorumGuarded))
+	if (cond == nil || cond.Message != message) && tree.EventRecorder != nil {
+		tree.EventRecorder.Event(its, corev1.EventTypeWarning, EventReasonQuorumGuard, message)
+	}
+	meta.SetStatusCondition(&its.Status.Conditions, metav1.Condition{
+		Type:               string(workloads.InstanceQuorumGuarded),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: its.Generation,
+		Reason:             workloads.ReasonInstanceQuorumGuarded,
+		Message:            message,
+	})
+}
@@ -0,0 +1,133 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package instanceset
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/pointer"
+
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
+)
+
+var _ = Describe("quorum guard test", func() {
+	buildPods := func(roleNames ...string) []*corev1.Pod {
+		var pods []*corev1.Pod
+		for i, roleName := range roleNames {
+			p := builder.NewPodBuilder(namespace, fmt.Sprintf("%s-%d", name, i)).
+				AddLabels(constant.RoleLabelKey, roleName).
+				GetObject()
+			p.Status.Phase = corev1.PodRunning
+			p.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+			pods = append(pods, p)
+		}
+		return pods
+	}
+
+	BeforeEach(func() {
+		its = builder.NewInstanceSetBuilder(namespace, name).
+			SetReplicas(3).
+			SetRoles(roles).
+			GetObject()
+		its.Spec.QuorumGuard = &workloads.QuorumGuard{}
+	})
+
+	Context("checkQuorumGuard", func() {
+		It("should allow the deletion if the guard is disabled or the member doesn't vote", func() {
+			pods := buildPods("leader", "follower", "learner")
+			pods[1].Status.Phase = corev1.PodPending
+			Expect(checkQuorumGuard(its, pods, pods[2], false)).Should(BeEmpty())
+
+			its.Spec.QuorumGuard = nil
+			Expect(checkQuorumGuard(its, pods, pods[0], false)).Should(BeEmpty())
+		})
+
+		It("should block the deletion if the quorum would be broken", func() {
+			pods := buildPods("leader", "follower", "follower")
+			Expect(checkQuorumGuard(its, pods, pods[0], false)).Should(BeEmpty())
+
+			pods[1].Status.Phase = corev1.PodPending
+			Expect(checkQuorumGuard(its, pods, pods[0], false)).Should(ContainSubstring("less than the quorum 2"))
+
+			By("the quorum is computed without the member deleted on scale-in")
+			pods = buildPods("leader", "follower")
+			Expect(checkQuorumGuard(its, pods, pods[1], true)).Should(BeEmpty())
+
+			By("the quorum is overridden")
+			pods = buildPods("leader", "follower", "follower")
+			its.Spec.QuorumGuard.MinAvailableVoters = pointer.Int32(3)
+			Expect(checkQuorumGuard(its, pods, pods[2], false)).Should(ContainSubstring("less than the quorum 3"))
+		})
+
+		It("should not block the deletion which can't keep the quorum in any case", func() {
+			By("the only voting member is updated")
+			pods := buildPods("leader")
+			Expect(checkQuorumGuard(its, pods, pods[0], false)).Should(BeEmpty())
+
+			By("the last voting members are scaled in")
+			pods = buildPods("leader", "follower", "follower")
+			pods[1].DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			pods[2].DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			Expect(checkQuorumGuard(its, pods, pods[0], true)).Should(BeEmpty())
+		})
+
+		It("should block the deletion of the only synced replica", func() {
+			its.Spec.QuorumGuard.CheckSyncedReplicas = true
+			its.Spec.QuorumGuard.MinAvailableVoters = pointer.Int32(1)
+			pods := buildPods("leader", "follower", "follower")
+			pods[1].Status.Phase = corev1.PodPending
+			Expect(checkQuorumGuard(its, pods, pods[0], false)).Should(ContainSubstring("only synced replica"))
+
+			pods[1].Status.Phase = corev1.PodRunning
+			Expect(checkQuorumGuard(its, pods, pods[0], false)).Should(BeEmpty())
+		})
+	})
+
+	Context("setQuorumGuardedCondition", func() {
+		It("should set and remove the condition", func() {
+			pods := buildPods("leader", "follower", "follower")
+			pods[1].Status.Phase = corev1.PodPending
+			message := checkScaleInQuorumGuard(its, pods, sets.NewString(pods[1].Name))
+			Expect(message).Should(BeEmpty())
+			message = checkScaleInQuorumGuard(its, pods, sets.NewString(pods[0].Name, pods[1].Name))
+			Expect(message).Should(ContainSubstring(pods[0].Name))
+
+			tree := kubebuilderx.NewObjectTree()
+			setQuorumGuardedCondition(tree, its, message)
+			cond := meta.FindStatusCondition(its.Status.Conditions, string(workloads.InstanceQuorumGuarded))
+			Expect(cond).ShouldNot(BeNil())
+			Expect(cond.Message).Should(Equal(message))
+
+			setQuorumGuardedCondition(tree, its, "")
+			Expect(meta.FindStatusCondition(its.Status.Conditions, string(workloads.InstanceQuorumGuarded))).Should(BeNil())
+		})
+	})
+})
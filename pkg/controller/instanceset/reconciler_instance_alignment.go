@@ -169,14 +169,22 @@ func (r *instanceAlignmentReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (
 	// delete useless instances
 	priorities := make(map[string]int)
 	sortObjects(oldInstanceList, priorities, false)
+	var oldPodList []*corev1.Pod
 	for _, object := range oldInstanceList {
 		pod, _ := object.(*corev1.Pod)
+		oldPodList = append(oldPodList, pod)
+	}
+	for _, pod := range oldPodList {
 		if _, ok := deleteNameSet[pod.Name]; !ok {
 			continue
 		}
 		if !isOrderedReady && concurrency <= 0 {
 			break
 		}
+		// the InstanceQuorumGuarded condition is reported by the update reconciler.
+		if len(checkQuorumGuard(its, oldPodList, pod, true)) > 0 {
+			break
+		}
 		if isOrderedReady && !isRunningAndReady(pod) {
 			tree.EventRecorder.Eventf(its, corev1.EventTypeWarning, "InstanceSet %s/%s is waiting for Pod %s to be Running and Ready",
 				its.Namespace,
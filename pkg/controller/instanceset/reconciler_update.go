@@ -21,6 +21,7 @@ package instanceset
 
 import (
 	"fmt"
+	"time"
 
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -32,8 +33,12 @@ import (
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
+// disruptionWindowsCheckInterval is the interval to recheck the disruption windows if the next opening is unknown.
+const disruptionWindowsCheckInterval = time.Hour
+
 // updateReconciler handles the updates of instances based on the UpdateStrategy.
 // Currently, two update strategies are supported: 'OnDelete' and 'RollingUpdate'.
 type updateReconciler struct{}
@@ -88,12 +93,15 @@ func (r *updateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilder
 	updateNameSet := oldNameSet.Intersection(newNameSet)
 	if len(updateNameSet) != len(oldNameSet) || len(updateNameSet) != len(newNameSet) {
 		tree.Logger.Info(fmt.Sprintf("InstanceSet %s/%s instances are not aligned", its.Namespace, its.Name))
+		// the scale-in may be blocked by the quorum guard.
+		setQuorumGuardedCondition(tree, its, checkScaleInQuorumGuard(its, oldPodList, oldNameSet.Difference(newNameSet)))
 		return kubebuilderx.Continue, nil
 	}
 
 	// 3. do update
 	// do nothing if UpdateStrategyType is 'OnDelete'
 	if its.Spec.UpdateStrategy.Type == apps.OnDeleteStatefulSetStrategyType {
+		setQuorumGuardedCondition(tree, its, "")
 		return kubebuilderx.Continue, nil
 	}
 
@@ -120,10 +128,33 @@ func (r *updateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilder
 	}
 	updateCount := len(podsToBeUpdated)
 
+	// defer the updates until the disruption windows open
+	if updateCount > 0 {
+		windows, err := intctrlutil.GetDisruptionWindows(its.Annotations)
+		if err != nil {
+			return kubebuilderx.Continue, err
+		}
+		if open, wait := intctrlutil.InDisruptionWindows(windows, time.Now()); !open {
+			message := fmt.Sprintf("InstanceSet %s/%s is waiting for the disruption windows to update %d instances", its.Namespace, its.Name, updateCount)
+			if cond := meta.FindStatusCondition(its.Status.Conditions, string(workloads.InstanceUpdateRestricted)); cond == nil || cond.Message != message {
+				if tree != nil && tree.EventRecorder != nil {
+					tree.EventRecorder.Eventf(its, corev1.EventTypeNormal, EventReasonWaitingForDisruptionWindows, message)
+				}
+			}
+			meta.SetStatusCondition(&its.Status.Conditions, *buildBlockedCondition(its, message))
+			setQuorumGuardedCondition(tree, its, "")
+			if wait <= 0 {
+				wait = disruptionWindowsCheckInterval
+			}
+			return kubebuilderx.RetryAfter(wait), nil
+		}
+	}
+
 	updatingPods := 0
 	updatedPods := 0
 	priorities := ComposeRolePriorityMap(its.Spec.Roles)
 	isBlocked := false
+	guardedMessage := ""
 	sortObjects(oldPodList, priorities, false)
 	for _, pod := range oldPodList {
 		if updatingPods >= updateCount || updatingPods >= unavailable {
@@ -163,6 +194,9 @@ func (r *updateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilder
 			}
 			updatingPods++
 		} else if updatePolicy == RecreatePolicy {
+			if guardedMessage = checkQuorumGuard(its, oldPodList, pod, false); len(guardedMessage) > 0 {
+				break
+			}
 			if !isTerminating(pod) {
 				if err = tree.Delete(pod); err != nil {
 					return kubebuilderx.Continue, err
@@ -175,6 +209,7 @@ func (r *updateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilder
 	if !isBlocked {
 		meta.RemoveStatusCondition(&its.Status.Conditions, string(workloads.InstanceUpdateRestricted))
 	}
+	setQuorumGuardedCondition(tree, its, guardedMessage)
 	return kubebuilderx.Continue, nil
 }
 
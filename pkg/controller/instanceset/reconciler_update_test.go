@@ -20,6 +20,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package instanceset
 
 import (
+	"fmt"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -28,11 +29,13 @@ import (
 	"golang.org/x/exp/slices"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/builder"
 	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
 )
@@ -242,6 +245,22 @@ var _ = Describe("update reconciler test", func() {
 			Expect(err).Should(BeNil())
 			Expect(res).Should(Equal(kubebuilderx.Continue))
 			expectUpdatedPods(strictInPlaceTree, []string{})
+
+			By("reconcile out of the disruption windows")
+			windowTree, err := tree.DeepCopy()
+			Expect(err).Should(BeNil())
+			root, ok = windowTree.GetRoot().(*workloads.InstanceSet)
+			Expect(ok).Should(BeTrue())
+			startTime := time.Now().UTC().Add(2 * time.Hour).Format("15:04")
+			root.Annotations = map[string]string{
+				constant.DisruptionWindowsAnnotationKey: fmt.Sprintf(`[{"startTime":"%s","duration":"1h"}]`, startTime),
+			}
+			res, err = reconciler.Reconcile(windowTree)
+			Expect(err).Should(BeNil())
+			Expect(res).ShouldNot(Equal(kubebuilderx.Continue))
+			Expect(res.RetryAfter).Should(BeNumerically("~", 2*time.Hour, time.Minute))
+			expectUpdatedPods(windowTree, []string{})
+			Expect(meta.IsStatusConditionTrue(root.Status.Conditions, string(workloads.InstanceUpdateRestricted))).Should(BeTrue())
 		})
 	})
 })
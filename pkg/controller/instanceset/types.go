@@ -62,8 +62,10 @@ const (
 )
 
 const (
-	EventReasonInvalidSpec   = "InvalidSpec"
-	EventReasonStrictInPlace = "StrictInPlace"
+	EventReasonInvalidSpec                 = "InvalidSpec"
+	EventReasonStrictInPlace               = "StrictInPlace"
+	EventReasonQuorumGuard                 = "QuorumGuard"
+	EventReasonWaitingForDisruptionWindows = "WaitingForDisruptionWindows"
 )
 
 const (
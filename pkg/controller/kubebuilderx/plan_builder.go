@@ -37,6 +37,7 @@ import (
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
 type transformContext struct {
@@ -56,6 +57,8 @@ type PlanBuilder struct {
 type Plan struct {
 	vertices []*model.ObjectVertex
 	walkFunc graph.WalkFunc
+	transCtx *transformContext
+	key      string
 }
 
 var _ graph.TransformContext = &transformContext{}
@@ -101,6 +104,11 @@ func (b *PlanBuilder) Build() (graph.Plan, error) {
 	plan := &Plan{
 		walkFunc: b.defaultWalkFunc,
 		vertices: vertices,
+		transCtx: b.transCtx,
+	}
+	if b.currentTree != nil && b.currentTree.GetRoot() != nil {
+		root := b.currentTree.GetRoot()
+		plan.key = fmt.Sprintf("plan.%s.%s", root.GetNamespace(), root.GetName())
 	}
 	return plan, nil
 }
@@ -209,13 +217,20 @@ func getRemainingFinalizer(obj client.Object) string {
 // Plan implementation
 
 func (p *Plan) Execute() error {
-	var err error
-	for i := len(p.vertices) - 1; i >= 0; i-- {
-		if err = p.walkFunc(p.vertices[i]); err != nil {
-			return err
+	// the plan is executed to completion even if the operator is shutting down, to not leave the objects half-updated.
+	return intctrlutil.RunToCompletion(p.transCtx.ctx, p.key, func(ctx context.Context) error {
+		reqCtx := p.transCtx.ctx
+		p.transCtx.ctx = ctx
+		defer func() {
+			p.transCtx.ctx = reqCtx
+		}()
+		for i := len(p.vertices) - 1; i >= 0; i-- {
+			if err := p.walkFunc(p.vertices[i]); err != nil {
+				return err
+			}
 		}
-	}
-	return nil
+		return nil
+	})
 }
 
 // Do the real works
@@ -57,6 +57,7 @@ type RestoreManager struct {
 	restoreTime                       string
 	volumeRestorePolicy               dpv1alpha1.VolumeClaimRestorePolicy
 	doReadyRestoreAfterClusterRunning bool
+	maskingRules                      []dpv1alpha1.MaskingRule
 	startingIndex                     int32
 	replicas                          int32
 	restoreLabels                     map[string]string
@@ -275,6 +276,9 @@ func (r *RestoreManager) DoPostReady(comp *component.SynthesizedComponent,
 		restore.Spec.ReadyConfig.JobAction.Target.PodSelector.Strategy = sourceTarget.PodSelector.Strategy
 	}
 	restore.Spec.ReadyConfig.JobAction.RequiredPolicyForAllPodSelection = r.buildRequiredPolicy(sourceTarget)
+	if len(r.maskingRules) > 0 {
+		restore.Spec.MaskingConfig = &dpv1alpha1.MaskingConfig{Rules: r.maskingRules}
+	}
 	backupMethod := backupObj.Status.BackupMethod
 	if backupMethod.TargetVolumes != nil {
 		restore.Spec.ReadyConfig.JobAction.Target.VolumeMounts = backupMethod.TargetVolumes.VolumeMounts
@@ -359,6 +363,11 @@ func (r *RestoreManager) initFromAnnotation(synthesizedComponent *component.Synt
 	if doReadyRestoreAfterClusterRunning == "true" {
 		r.doReadyRestoreAfterClusterRunning = true
 	}
+	if maskingRules := backupSource[constant.MaskingRulesKeyForRestore]; maskingRules != "" {
+		if err = json.Unmarshal([]byte(maskingRules), &r.maskingRules); err != nil {
+			return nil, err
+		}
+	}
 	return GetBackupFromClusterAnnotation(r.Ctx, r.Client, backupSource, synthesizedComponent.Name, r.Cluster.Namespace)
 }
 
@@ -429,6 +438,34 @@ func CleanupClusterRestoreAnnotation(cluster *appsv1alpha1.Cluster, compName str
 	return true, nil
 }
 
+// HasPendingDataMasking checks if the restored data of any component of the cluster is waiting to be masked.
+func HasPendingDataMasking(cluster *appsv1alpha1.Cluster) bool {
+	for _, backupSource := range getRestoreInfoFromAnnotation(cluster) {
+		if backupSource[constant.MaskingRulesKeyForRestore] != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDataMaskingPending checks if the restored data of the component is waiting to be masked,
+// the compName is the name of the sharding for the shard components.
+func IsDataMaskingPending(cluster *appsv1alpha1.Cluster, compName string) bool {
+	return getRestoreInfoFromAnnotation(cluster)[compName][constant.MaskingRulesKeyForRestore] != ""
+}
+
+func getRestoreInfoFromAnnotation(cluster *appsv1alpha1.Cluster) map[string]map[string]string {
+	restoreInfo := cluster.Annotations[constant.RestoreFromBackupAnnotationKey]
+	if restoreInfo == "" {
+		return nil
+	}
+	restoreInfoMap := map[string]map[string]string{}
+	if err := json.Unmarshal([]byte(restoreInfo), &restoreInfoMap); err != nil {
+		return nil
+	}
+	return restoreInfoMap
+}
+
 func GetBackupFromClusterAnnotation(
 	ctx context.Context,
 	cli client.Reader,
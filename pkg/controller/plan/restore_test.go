@@ -267,6 +267,25 @@ var _ = Describe("Restore", func() {
 				g.Expect(tmpCluster.Annotations[constant.RestoreFromBackupAnnotationKey]).Should(BeEmpty())
 			})).Should(Succeed())
 		})
+
+		It("Test pending data masking", func() {
+			cluster := &appsv1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						constant.RestoreFromBackupAnnotationKey: `{"mysql":{"name":"backup","maskingRules":"[{}]"},"proxy":{"name":"backup"}}`,
+					},
+				},
+			}
+			Expect(HasPendingDataMasking(cluster)).Should(BeTrue())
+			Expect(IsDataMaskingPending(cluster, "mysql")).Should(BeTrue())
+			Expect(IsDataMaskingPending(cluster, "proxy")).Should(BeFalse())
+
+			By("the masked component is cleaned up from the annotation")
+			_, err := CleanupClusterRestoreAnnotation(cluster, "mysql")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(HasPendingDataMasking(cluster)).Should(BeFalse())
+			Expect(IsDataMaskingPending(cluster, "mysql")).Should(BeFalse())
+		})
 	})
 })
 
@@ -321,3 +321,30 @@ func mergeAffinity(dest, src *corev1.Affinity) *corev1.Affinity {
 	}
 	return rst
 }
+
+// BuildConsumerAffinityTerms translates the consumer affinity of the scheduling hints into the preferred pod affinity terms.
+func BuildConsumerAffinityTerms(namespace string, hints *appsv1alpha1.SchedulingHints) []corev1.WeightedPodAffinityTerm {
+	if hints == nil || len(hints.ConsumerAffinity) == 0 {
+		return nil
+	}
+	terms := make([]corev1.WeightedPodAffinityTerm, 0, len(hints.ConsumerAffinity))
+	for _, consumer := range hints.ConsumerAffinity {
+		namespaces := consumer.Namespaces
+		if len(namespaces) == 0 {
+			namespaces = []string{namespace}
+		}
+		topologyKey := consumer.TopologyKey
+		if len(topologyKey) == 0 {
+			topologyKey = corev1.LabelHostname
+		}
+		terms = append(terms, corev1.WeightedPodAffinityTerm{
+			Weight: consumer.Weight,
+			PodAffinityTerm: corev1.PodAffinityTerm{
+				LabelSelector: consumer.LabelSelector.DeepCopy(),
+				Namespaces:    namespaces,
+				TopologyKey:   topologyKey,
+			},
+		})
+	}
+	return terms
+}
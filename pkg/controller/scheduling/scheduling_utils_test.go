@@ -26,6 +26,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
@@ -166,4 +167,33 @@ var _ = Describe("affinity utils", func() {
 			Expect(tolerations[1].Key).Should(Equal(dpTolerationKey))
 		})
 	})
+
+	Context("with consumer affinity", func() {
+		It("should build the preferred pod affinity terms", func() {
+			hints := &appsv1alpha1.SchedulingHints{
+				ConsumerAffinity: []appsv1alpha1.ConsumerAffinityTerm{
+					{
+						LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+						Weight:        80,
+					},
+					{
+						LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "batch"}},
+						Namespaces:    []string{"jobs"},
+						TopologyKey:   topologyKey,
+						Weight:        20,
+					},
+				},
+			}
+			Expect(BuildConsumerAffinityTerms("default", nil)).Should(BeNil())
+
+			terms := BuildConsumerAffinityTerms("default", hints)
+			Expect(terms).Should(HaveLen(2))
+			Expect(terms[0].Weight).Should(BeEquivalentTo(80))
+			Expect(terms[0].PodAffinityTerm.Namespaces).Should(Equal([]string{"default"}))
+			Expect(terms[0].PodAffinityTerm.TopologyKey).Should(Equal(corev1.LabelHostname))
+			Expect(terms[0].PodAffinityTerm.LabelSelector.MatchLabels).Should(HaveKeyWithValue("app", "web"))
+			Expect(terms[1].PodAffinityTerm.Namespaces).Should(Equal([]string{"jobs"}))
+			Expect(terms[1].PodAffinityTerm.TopologyKey).Should(Equal(topologyKey))
+		})
+	})
 })
@@ -0,0 +1,126 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+// loadBalancerTagsAnnotationKeys are the annotations of the cloud providers to tag the provisioned load balancers,
+// keyed by the provider configured for KubeBlocks.
+var loadBalancerTagsAnnotationKeys = map[string]string{
+	"aws":    "service.beta.kubernetes.io/aws-load-balancer-additional-resource-tags",
+	"aliyun": "service.beta.kubernetes.io/alibaba-cloud-loadbalancer-additional-resource-tags",
+	"azure":  "service.beta.kubernetes.io/azure-pip-tags",
+}
+
+// storageClassTagsParameters build the parameters of the StorageClass to tag the provisioned volumes,
+// keyed by the CSI drivers which read the tags from the parameters of the StorageClass on provisioning.
+var storageClassTagsParameters = map[string]func(tags map[string]string) map[string]string{
+	"ebs.csi.aws.com": func(tags map[string]string) map[string]string {
+		parameters := map[string]string{}
+		for i, k := range sortedKeys(tags) {
+			parameters[fmt.Sprintf("tagSpecification_%d", i+1)] = fmt.Sprintf("%s=%s", k, tags[k])
+		}
+		return parameters
+	},
+	"disk.csi.azure.com": func(tags map[string]string) map[string]string {
+		return map[string]string{"tags": formatCloudTags(tags, "=")}
+	},
+	"diskplugin.csi.alibabacloud.com": func(tags map[string]string) map[string]string {
+		return map[string]string{"diskTags": formatCloudTags(tags, ":")}
+	},
+	"pd.csi.storage.gke.io": func(tags map[string]string) map[string]string {
+		return map[string]string{"labels": formatCloudTags(tags, "=")}
+	},
+}
+
+func sortedKeys(tags map[string]string) []string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// formatCloudTags formats the tags as "k1=v1,k2=v2" ordered by key, with the separator between the key and the value.
+func formatCloudTags(tags map[string]string, sep string) string {
+	pairs := make([]string, 0, len(tags))
+	for _, k := range sortedKeys(tags) {
+		pairs = append(pairs, k+sep+tags[k])
+	}
+	return strings.Join(pairs, ",")
+}
+
+// BuildCloudTagsStorageClass builds the StorageClass to provision the volumes tagged with the cloud tags, which
+// copies the StorageClass with the tags added to its parameters. It returns nil if the CSI driver of the
+// StorageClass doesn't support tagging the volumes.
+// The StorageClass is named by the hash of the tags, so that it's shared by the clusters with the same tags.
+func BuildCloudTagsStorageClass(sc *storagev1.StorageClass, tags map[string]string) *storagev1.StorageClass {
+	buildParameters, ok := storageClassTagsParameters[sc.Provisioner]
+	if len(tags) == 0 || !ok {
+		return nil
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(formatCloudTags(tags, "=")))
+	parameters := map[string]string{}
+	for k, v := range sc.Parameters {
+		parameters[k] = v
+	}
+	for k, v := range buildParameters(tags) {
+		parameters[k] = v
+	}
+	return &storagev1.StorageClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   fmt.Sprintf("kb-%s-%x", sc.Name, hash.Sum32()),
+			Labels: map[string]string{constant.AppManagedByLabelKey: constant.AppName},
+		},
+		Provisioner:          sc.Provisioner,
+		Parameters:           parameters,
+		ReclaimPolicy:        sc.ReclaimPolicy,
+		MountOptions:         sc.MountOptions,
+		AllowVolumeExpansion: sc.AllowVolumeExpansion,
+		VolumeBindingMode:    sc.VolumeBindingMode,
+		AllowedTopologies:    sc.AllowedTopologies,
+	}
+}
+
+// BuildServiceCloudTagsAnnotations builds the annotations of the cloud provider to propagate the cloud tags to
+// the load balancer provisioned for the Service, it's only set for the LoadBalancer Service.
+func BuildServiceCloudTagsAnnotations(tags map[string]string, svcType corev1.ServiceType) map[string]string {
+	if len(tags) == 0 || svcType != corev1.ServiceTypeLoadBalancer {
+		return nil
+	}
+	if key, ok := loadBalancerTagsAnnotationKeys[viper.GetString(constant.CfgKeyProvider)]; ok {
+		return map[string]string{key: formatCloudTags(tags, "=")}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+func TestBuildCloudTagsStorageClass(t *testing.T) {
+	tags := map[string]string{"team": "dba", "env": "prod"}
+	sc := &storagev1.StorageClass{
+		ObjectMeta:  metav1.ObjectMeta{Name: "gp3"},
+		Provisioner: "ebs.csi.aws.com",
+		Parameters:  map[string]string{"type": "gp3"},
+	}
+	if tagged := BuildCloudTagsStorageClass(sc, nil); tagged != nil {
+		t.Errorf("expected no StorageClass for empty tags, but got %s", tagged.Name)
+	}
+	if tagged := BuildCloudTagsStorageClass(&storagev1.StorageClass{Provisioner: "rancher.io/local-path"}, tags); tagged != nil {
+		t.Errorf("expected no StorageClass for the driver not supporting tags, but got %s", tagged.Name)
+	}
+
+	tagged := BuildCloudTagsStorageClass(sc, tags)
+	expected := map[string]string{"type": "gp3", "tagSpecification_1": "env=prod", "tagSpecification_2": "team=dba"}
+	for k, v := range expected {
+		if tagged.Parameters[k] != v {
+			t.Errorf("expected parameter %s=%s, but got %s", k, v, tagged.Parameters[k])
+		}
+	}
+	if len(sc.Parameters) != 1 {
+		t.Errorf("expected the parameters of the StorageClass unchanged, but got %v", sc.Parameters)
+	}
+	if again := BuildCloudTagsStorageClass(sc, map[string]string{"env": "prod", "team": "dba"}); again.Name != tagged.Name {
+		t.Errorf("expected the same StorageClass %s for the same tags, but got %s", tagged.Name, again.Name)
+	}
+	if other := BuildCloudTagsStorageClass(sc, map[string]string{"env": "test"}); other.Name == tagged.Name {
+		t.Errorf("expected different StorageClasses for the different tags")
+	}
+
+	sc.Provisioner = "diskplugin.csi.alibabacloud.com"
+	if tagged = BuildCloudTagsStorageClass(sc, tags); tagged.Parameters["diskTags"] != "env:prod,team:dba" {
+		t.Errorf("expected parameter diskTags=env:prod,team:dba, but got %s", tagged.Parameters["diskTags"])
+	}
+}
+
+func TestBuildServiceCloudTagsAnnotations(t *testing.T) {
+	tags := map[string]string{"team": "dba", "env": "prod"}
+	expected := "env=prod,team=dba"
+
+	viper.Set(constant.CfgKeyProvider, "aws")
+	defer viper.Set(constant.CfgKeyProvider, "")
+
+	lbKey := loadBalancerTagsAnnotationKeys["aws"]
+	if annotations := BuildServiceCloudTagsAnnotations(tags, corev1.ServiceTypeClusterIP); annotations != nil {
+		t.Errorf("expected no annotations for ClusterIP service, but got %v", annotations)
+	}
+	annotations := BuildServiceCloudTagsAnnotations(tags, corev1.ServiceTypeLoadBalancer)
+	if annotations[lbKey] != expected {
+		t.Errorf("expected load balancer annotation %s, but got %s", expected, annotations[lbKey])
+	}
+}
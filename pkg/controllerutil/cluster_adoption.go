@@ -0,0 +1,76 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+// GetComponentsToAdopt returns the components of the cluster specified to adopt the existing StatefulSets,
+// mapped to the component definitions given to generate their specs, which may be empty.
+func GetComponentsToAdopt(cluster *appsv1alpha1.Cluster) map[string]string {
+	comps := map[string]string{}
+	for _, item := range strings.Split(cluster.Annotations[constant.AdoptStatefulSetsAnnotationKey], ",") {
+		name, compDef, _ := strings.Cut(strings.TrimSpace(item), ":")
+		if name = strings.TrimSpace(name); len(name) > 0 {
+			comps[name] = strings.TrimSpace(compDef)
+		}
+	}
+	return comps
+}
+
+// IsComponentToAdopt checks whether the component of the cluster is specified to adopt the existing StatefulSet.
+func IsComponentToAdopt(cluster *appsv1alpha1.Cluster, compName string) bool {
+	_, ok := GetComponentsToAdopt(cluster)[compName]
+	return ok
+}
+
+// BuildComponentSpecForAdoption generates the spec of the component @compName to adopt the existing StatefulSet,
+// the StatefulSet is expected to be named as "<cluster>-<compName>", so that the PVCs can be reused as they are.
+func BuildComponentSpecForAdoption(sts *appsv1.StatefulSet, compName, compDef string) appsv1alpha1.ClusterComponentSpec {
+	compSpec := appsv1alpha1.ClusterComponentSpec{
+		Name:         compName,
+		ComponentDef: compDef,
+		Replicas:     1,
+	}
+	if sts.Spec.Replicas != nil {
+		compSpec.Replicas = *sts.Spec.Replicas
+	}
+	if len(sts.Spec.Template.Spec.Containers) > 0 {
+		compSpec.Resources = sts.Spec.Template.Spec.Containers[0].Resources
+	}
+	for _, vct := range sts.Spec.VolumeClaimTemplates {
+		compSpec.VolumeClaimTemplates = append(compSpec.VolumeClaimTemplates, appsv1alpha1.ClusterComponentVolumeClaimTemplate{
+			Name: vct.Name,
+			Spec: appsv1alpha1.PersistentVolumeClaimSpec{
+				AccessModes:      vct.Spec.AccessModes,
+				Resources:        vct.Spec.Resources,
+				StorageClassName: vct.Spec.StorageClassName,
+				VolumeMode:       vct.Spec.VolumeMode,
+			},
+		})
+	}
+	return compSpec
+}
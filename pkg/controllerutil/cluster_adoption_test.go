@@ -0,0 +1,73 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/pointer"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+func TestGetComponentsToAdopt(t *testing.T) {
+	cluster := &appsv1alpha1.Cluster{}
+	cluster.Annotations = map[string]string{
+		constant.AdoptStatefulSetsAnnotationKey: "mysql:mysql-8.0, proxy ,,",
+	}
+	comps := GetComponentsToAdopt(cluster)
+	if len(comps) != 2 || comps["mysql"] != "mysql-8.0" || comps["proxy"] != "" {
+		t.Errorf("unexpected components to adopt: %v", comps)
+	}
+	if !IsComponentToAdopt(cluster, "mysql") || !IsComponentToAdopt(cluster, "proxy") || IsComponentToAdopt(cluster, "redis") {
+		t.Errorf("unexpected components to adopt: %s", cluster.Annotations[constant.AdoptStatefulSetsAnnotationKey])
+	}
+}
+
+func TestBuildComponentSpecForAdoption(t *testing.T) {
+	sts := &appsv1.StatefulSet{}
+	sts.Namespace = "default"
+	sts.Name = "mydb-mysql"
+	sts.Spec.Replicas = pointer.Int32(3)
+	sts.Spec.Template.Spec.Containers = []corev1.Container{{
+		Name: "mysql",
+		Resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+		},
+	}}
+	sts.Spec.VolumeClaimTemplates = []corev1.PersistentVolumeClaim{{}}
+	sts.Spec.VolumeClaimTemplates[0].Name = "data"
+	sts.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")}
+
+	compSpec := BuildComponentSpecForAdoption(sts, "mysql", "mysql-8.0")
+	if compSpec.Name != "mysql" || compSpec.ComponentDef != "mysql-8.0" || compSpec.Replicas != 3 {
+		t.Errorf("unexpected component spec: %+v", compSpec)
+	}
+	if !compSpec.Resources.Limits.Cpu().Equal(resource.MustParse("1")) {
+		t.Errorf("unexpected resources: %+v", compSpec.Resources)
+	}
+	if len(compSpec.VolumeClaimTemplates) != 1 || compSpec.VolumeClaimTemplates[0].Name != "data" {
+		t.Errorf("unexpected volume claim templates: %+v", compSpec.VolumeClaimTemplates)
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"fmt"
+	"math"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/yaml"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+const (
+	// HoursPerMonth is the average hours of a month used to estimate the monthly cost.
+	HoursPerMonth = 730
+
+	// DefaultPricingKey is the key of the pricing table used if there is no table for the provider.
+	DefaultPricingKey = "default"
+)
+
+// PricingModel prices the resources used by a cluster, it allows to plug in the pricing of different providers.
+type PricingModel interface {
+	// Currency returns the currency of the prices.
+	Currency() string
+	// ComputeCost returns the monthly cost of the cpu and memory.
+	ComputeCost(cpu, memory resource.Quantity) float64
+	// StorageCost returns the monthly cost of the storage provisioned by the storage class.
+	StorageCost(storageClass string, size resource.Quantity) float64
+}
+
+// PricingTable is a PricingModel of the unit prices, configured for each provider.
+type PricingTable struct {
+	CurrencyCode string `json:"currency,omitempty"`
+	// the price of a CPU core per hour.
+	CPUCoreHour float64 `json:"cpuCoreHour"`
+	// the price of a GiB memory per hour.
+	MemoryGiBHour float64 `json:"memoryGiBHour"`
+	// the price of a GiB storage per month, keyed by the storage class, the empty key is for the default storage class.
+	StorageGiBMonth map[string]float64 `json:"storageGiBMonth,omitempty"`
+}
+
+var _ PricingModel = &PricingTable{}
+
+func (t *PricingTable) Currency() string {
+	if t.CurrencyCode == "" {
+		return "USD"
+	}
+	return t.CurrencyCode
+}
+
+func (t *PricingTable) ComputeCost(cpu, memory resource.Quantity) float64 {
+	cores := cpu.AsApproximateFloat64()
+	gibs := memory.AsApproximateFloat64() / (1 << 30)
+	return (cores*t.CPUCoreHour + gibs*t.MemoryGiBHour) * HoursPerMonth
+}
+
+func (t *PricingTable) StorageCost(storageClass string, size resource.Quantity) float64 {
+	price, ok := t.StorageGiBMonth[storageClass]
+	if !ok {
+		price = t.StorageGiBMonth[""]
+	}
+	return size.AsApproximateFloat64() / (1 << 30) * price
+}
+
+// LoadPricingTable loads the pricing table of the provider from the data of the pricing ConfigMap,
+// it falls back to the default table, and returns nil if neither is configured.
+func LoadPricingTable(data map[string]string, provider string) (*PricingTable, error) {
+	content, ok := data[provider]
+	if !ok || provider == "" {
+		content, ok = data[DefaultPricingKey]
+	}
+	if !ok {
+		return nil, nil
+	}
+	table := &PricingTable{}
+	if err := yaml.Unmarshal([]byte(content), table); err != nil {
+		return nil, fmt.Errorf("failed to parse the pricing table: %s", err.Error())
+	}
+	return table, nil
+}
+
+// ClusterCost is the estimated monthly cost of a cluster.
+type ClusterCost struct {
+	Currency string  `json:"currency"`
+	Compute  float64 `json:"compute"`
+	Storage  float64 `json:"storage"`
+	Total    float64 `json:"total"`
+}
+
+// EstimateClusterCost estimates the monthly cost of the cluster based on the resources and volumes of the components,
+// the requests are used if specified, otherwise the limits. The instance templates are priced as the component.
+func EstimateClusterCost(cluster *appsv1alpha1.Cluster, model PricingModel) *ClusterCost {
+	cost := &ClusterCost{Currency: model.Currency()}
+	add := func(spec *appsv1alpha1.ClusterComponentSpec, copies int32) {
+		replicas := float64(spec.Replicas) * float64(copies)
+		cpu := requestOrLimit(spec.Resources, corev1.ResourceCPU)
+		memory := requestOrLimit(spec.Resources, corev1.ResourceMemory)
+		cost.Compute += model.ComputeCost(cpu, memory) * replicas
+		for _, vct := range spec.VolumeClaimTemplates {
+			storageClass := ""
+			if vct.Spec.StorageClassName != nil {
+				storageClass = *vct.Spec.StorageClassName
+			}
+			size := vct.Spec.Resources.Requests[corev1.ResourceStorage]
+			cost.Storage += model.StorageCost(storageClass, size) * replicas
+		}
+	}
+	for i := range cluster.Spec.ComponentSpecs {
+		add(&cluster.Spec.ComponentSpecs[i], 1)
+	}
+	for i := range cluster.Spec.ShardingSpecs {
+		add(&cluster.Spec.ShardingSpecs[i].Template, cluster.Spec.ShardingSpecs[i].Shards)
+	}
+	cost.Compute = roundCost(cost.Compute)
+	cost.Storage = roundCost(cost.Storage)
+	cost.Total = roundCost(cost.Compute + cost.Storage)
+	return cost
+}
+
+func requestOrLimit(resources corev1.ResourceRequirements, name corev1.ResourceName) resource.Quantity {
+	if q, ok := resources.Requests[name]; ok {
+		return q
+	}
+	return resources.Limits[name]
+}
+
+func roundCost(cost float64) float64 {
+	return math.Round(cost*100) / 100
+}
@@ -0,0 +1,103 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+func TestLoadPricingTable(t *testing.T) {
+	data := map[string]string{
+		DefaultPricingKey: "cpuCoreHour: 0.1\nmemoryGiBHour: 0.01",
+		"aws":             "currency: EUR\ncpuCoreHour: 0.2",
+	}
+	table, err := LoadPricingTable(data, "aws")
+	if err != nil || table.Currency() != "EUR" || table.CPUCoreHour != 0.2 {
+		t.Errorf("unexpected pricing table for aws: %v, %v", table, err)
+	}
+	table, err = LoadPricingTable(data, "gcp")
+	if err != nil || table.Currency() != "USD" || table.CPUCoreHour != 0.1 {
+		t.Errorf("expected the default pricing table for gcp, but got %v, %v", table, err)
+	}
+	if table, err = LoadPricingTable(nil, "aws"); table != nil || err != nil {
+		t.Errorf("expected no pricing table, but got %v, %v", table, err)
+	}
+	if _, err = LoadPricingTable(map[string]string{DefaultPricingKey: "cpuCoreHour: [x"}, ""); err == nil {
+		t.Error("expected error for the malformed pricing table")
+	}
+}
+
+func TestEstimateClusterCost(t *testing.T) {
+	ssd := "ssd"
+	compSpec := func(replicas int32) appsv1alpha1.ClusterComponentSpec {
+		return appsv1alpha1.ClusterComponentSpec{
+			Replicas: replicas,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("2"),
+					corev1.ResourceMemory: resource.MustParse("2Gi"),
+				},
+			},
+			VolumeClaimTemplates: []appsv1alpha1.ClusterComponentVolumeClaimTemplate{
+				{
+					Name: "data",
+					Spec: appsv1alpha1.PersistentVolumeClaimSpec{
+						StorageClassName: &ssd,
+						Resources: corev1.VolumeResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+						},
+					},
+				},
+				{
+					Name: "log",
+					Spec: appsv1alpha1.PersistentVolumeClaimSpec{
+						Resources: corev1.VolumeResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("5Gi")},
+						},
+					},
+				},
+			},
+		}
+	}
+	cluster := &appsv1alpha1.Cluster{
+		Spec: appsv1alpha1.ClusterSpec{
+			ComponentSpecs: []appsv1alpha1.ClusterComponentSpec{compSpec(3)},
+			ShardingSpecs:  []appsv1alpha1.ShardingSpec{{Name: "shard", Shards: 2, Template: compSpec(1)}},
+		},
+	}
+	table := &PricingTable{
+		CPUCoreHour:     0.1,
+		MemoryGiBHour:   0.01,
+		StorageGiBMonth: map[string]float64{"": 0.1, ssd: 0.2},
+	}
+
+	// 5 replicas, each with 0.5 core (request) and 2Gi memory (limit), 10Gi ssd and 5Gi default storage.
+	cost := EstimateClusterCost(cluster, table)
+	expected := &ClusterCost{Currency: "USD", Compute: 255.5, Storage: 12.5, Total: 268}
+	if *cost != *expected {
+		t.Errorf("expected cost %v, but got %v", expected, cost)
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"encoding/json"
+	"reflect"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+const (
+	// ClusterHistoryDataKey is the key of the cluster history in the data of the history ConfigMap.
+	ClusterHistoryDataKey = "history"
+	// ClusterHistorySpecDataKey is the key of the cluster spec of the latest generation recorded in the data of
+	// the history ConfigMap, the changes of the next generation are computed against it.
+	ClusterHistorySpecDataKey = "spec"
+
+	// maxClusterHistorySize is the max size of the history data, the oldest entries are trimmed to keep
+	// the ConfigMap well under the 1MiB size limit of the objects.
+	maxClusterHistorySize = 512 * 1024
+)
+
+// ClusterHistoryEntry records a generation of the cluster spec and the resulting status.
+type ClusterHistoryEntry struct {
+	Generation int64       `json:"generation"`
+	Timestamp  metav1.Time `json:"timestamp"`
+	// Changes is the JSON merge patch of the cluster spec from the previous generation recorded,
+	// it's omitted for the first generation recorded.
+	Changes json.RawMessage `json:"changes,omitempty"`
+	// ChangesTruncated tells the changes are dropped since they are too large to be recorded.
+	ChangesTruncated bool `json:"changesTruncated,omitempty"`
+	// Phase is the latest phase of the cluster observed for the generation.
+	Phase appsv1alpha1.ClusterPhase `json:"phase,omitempty"`
+	// Components records the latest phase of each component observed for the generation.
+	Components map[string]appsv1alpha1.ClusterComponentPhase `json:"components,omitempty"`
+}
+
+// ClusterHistory is the history of the cluster generations, ordered by generation.
+type ClusterHistory struct {
+	Entries []ClusterHistoryEntry
+	// Spec is the cluster spec of the latest generation recorded.
+	Spec json.RawMessage
+}
+
+// ParseClusterHistory parses the cluster history from the data of the history ConfigMap.
+func ParseClusterHistory(data map[string]string) (*ClusterHistory, error) {
+	history := &ClusterHistory{}
+	if len(data[ClusterHistoryDataKey]) == 0 {
+		return history, nil
+	}
+	if err := json.Unmarshal([]byte(data[ClusterHistoryDataKey]), &history.Entries); err != nil {
+		return nil, err
+	}
+	history.Spec = json.RawMessage(data[ClusterHistorySpecDataKey])
+	return history, nil
+}
+
+// Record records the current generation and status of the cluster into the history, at most limit entries are kept.
+// It returns whether the history is changed.
+func (h *ClusterHistory) Record(cluster *appsv1alpha1.Cluster, limit int) (bool, error) {
+	var components map[string]appsv1alpha1.ClusterComponentPhase
+	for name, status := range cluster.Status.Components {
+		if components == nil {
+			components = map[string]appsv1alpha1.ClusterComponentPhase{}
+		}
+		components[name] = status.Phase
+	}
+
+	changed := false
+	if len(h.Entries) == 0 || h.Entries[len(h.Entries)-1].Generation != cluster.Generation {
+		spec, err := json.Marshal(cluster.Spec)
+		if err != nil {
+			return false, err
+		}
+		entry := ClusterHistoryEntry{
+			Generation: cluster.Generation,
+			Timestamp:  metav1.Now(),
+		}
+		if len(h.Spec) > 0 {
+			if entry.Changes, err = jsonpatch.CreateMergePatch(h.Spec, spec); err != nil {
+				return false, err
+			}
+		}
+		h.Entries = append(h.Entries, entry)
+		h.Spec = spec
+		changed = true
+	}
+	latest := &h.Entries[len(h.Entries)-1]
+	if latest.Phase != cluster.Status.Phase || !reflect.DeepEqual(latest.Components, components) {
+		latest.Phase = cluster.Status.Phase
+		latest.Components = components
+		changed = true
+	}
+	if len(h.Entries) > limit {
+		h.Entries = h.Entries[len(h.Entries)-limit:]
+		changed = true
+	}
+	return changed, nil
+}
+
+// Data builds the data of the history ConfigMap, the oldest entries are trimmed if the data exceeds the max size,
+// and the changes of the latest entry are dropped if they are still too large.
+func (h *ClusterHistory) Data() (map[string]string, error) {
+	for {
+		entries, err := json.Marshal(h.Entries)
+		if err != nil {
+			return nil, err
+		}
+		if len(entries)+len(h.Spec) <= maxClusterHistorySize {
+			return map[string]string{
+				ClusterHistoryDataKey:     string(entries),
+				ClusterHistorySpecDataKey: string(h.Spec),
+			}, nil
+		}
+		if len(h.Entries) > 1 {
+			h.Entries = h.Entries[1:]
+			continue
+		}
+		if h.Entries[0].Changes == nil {
+			// the spec alone exceeds the max size, which never happens since the size of the objects is limited.
+			return map[string]string{ClusterHistoryDataKey: string(entries)}, nil
+		}
+		h.Entries[0].Changes = nil
+		h.Entries[0].ChangesTruncated = true
+	}
+}
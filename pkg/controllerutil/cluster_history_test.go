@@ -0,0 +1,107 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"strings"
+	"testing"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+func TestRecordClusterHistory(t *testing.T) {
+	cluster := &appsv1alpha1.Cluster{}
+	cluster.Generation = 1
+	cluster.Spec.TerminationPolicy = appsv1alpha1.Delete
+	cluster.Status.Phase = appsv1alpha1.CreatingClusterPhase
+	cluster.Status.Components = map[string]appsv1alpha1.ClusterComponentStatus{
+		"mysql": {Phase: appsv1alpha1.CreatingClusterCompPhase},
+	}
+
+	history := &ClusterHistory{}
+	changed, err := history.Record(cluster, 2)
+	if err != nil || !changed || len(history.Entries) != 1 || history.Entries[0].Changes != nil {
+		t.Fatalf("expected a new entry without changes, but got %+v, changed: %v, err: %v", history.Entries, changed, err)
+	}
+
+	// round trip through the ConfigMap data.
+	data, err := history.Data()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if history, err = ParseClusterHistory(data); err != nil {
+		t.Fatal(err)
+	}
+	if changed, _ = history.Record(cluster, 2); changed {
+		t.Error("expected the history not changed")
+	}
+
+	cluster.Status.Phase = appsv1alpha1.RunningClusterPhase
+	cluster.Status.Components["mysql"] = appsv1alpha1.ClusterComponentStatus{Phase: appsv1alpha1.RunningClusterCompPhase}
+	changed, _ = history.Record(cluster, 2)
+	if !changed || len(history.Entries) != 1 || history.Entries[0].Components["mysql"] != appsv1alpha1.RunningClusterCompPhase {
+		t.Errorf("expected the status of the generation updated, but got %+v", history.Entries)
+	}
+
+	cluster.Generation = 2
+	cluster.Spec.TerminationPolicy = appsv1alpha1.WipeOut
+	_, _ = history.Record(cluster, 2)
+	if changes := string(history.Entries[1].Changes); changes != `{"terminationPolicy":"WipeOut"}` {
+		t.Errorf("expected only the changed fields recorded, but got %s", changes)
+	}
+
+	cluster.Generation = 3
+	_, _ = history.Record(cluster, 2)
+	if len(history.Entries) != 2 || history.Entries[0].Generation != 2 || history.Entries[1].Generation != 3 {
+		t.Errorf("expected the last 2 generations kept, but got %+v", history.Entries)
+	}
+}
+
+func TestClusterHistoryData(t *testing.T) {
+	cluster := &appsv1alpha1.Cluster{}
+	history := &ClusterHistory{}
+	for generation := int64(1); generation <= 4; generation++ {
+		cluster.Generation = generation
+		cluster.Spec.ClusterDefRef = strings.Repeat("x", maxClusterHistorySize/2) + string(rune('a'+generation))
+		if _, err := history.Record(cluster, 10); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, err := history.Data()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size := len(data[ClusterHistoryDataKey]) + len(data[ClusterHistorySpecDataKey]); size > maxClusterHistorySize {
+		t.Errorf("expected the history trimmed under %d bytes, but got %d", maxClusterHistorySize, size)
+	}
+	if len(history.Entries) != 1 || history.Entries[0].Generation != 4 || !history.Entries[0].ChangesTruncated {
+		t.Errorf("expected only the latest generation kept with the changes truncated, but got %d entries", len(history.Entries))
+	}
+}
+
+func TestParseClusterHistory(t *testing.T) {
+	history, err := ParseClusterHistory(nil)
+	if err != nil || len(history.Entries) != 0 {
+		t.Errorf("expected empty history, but got %+v, err: %v", history, err)
+	}
+	if _, err = ParseClusterHistory(map[string]string{ClusterHistoryDataKey: "{"}); err == nil {
+		t.Error("expected error for corrupted history")
+	}
+}
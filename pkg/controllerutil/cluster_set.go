@@ -0,0 +1,230 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+// ClusterSetMemberName returns the name of the member cluster with the given index in the ClusterSet.
+func ClusterSetMemberName(clusterSetName string, index int32) string {
+	return fmt.Sprintf("%s-%d", clusterSetName, index)
+}
+
+// ClusterSetMemberIndex returns the index of the member cluster in the ClusterSet, which is recorded in the labels.
+func ClusterSetMemberIndex(cluster *appsv1alpha1.Cluster) (int32, error) {
+	index, err := strconv.ParseInt(cluster.Labels[constant.KBAppClusterSetIndexLabelKey], 10, 32)
+	if err != nil {
+		return -1, fmt.Errorf("invalid index of the ClusterSet member cluster %s: %s", cluster.Name, err.Error())
+	}
+	return int32(index), nil
+}
+
+// ComputeClusterSetRevision computes the revision of the template of the ClusterSet.
+func ComputeClusterSetRevision(clusterSet *appsv1alpha1.ClusterSet) (string, error) {
+	data, err := json.Marshal(clusterSet.Spec.Template)
+	if err != nil {
+		return "", err
+	}
+	hf := fnv.New32()
+	_, _ = hf.Write(data)
+	return rand.SafeEncodeString(fmt.Sprint(hf.Sum32())), nil
+}
+
+// ClusterSetMaxUnavailable returns the maximum number of member clusters that can be unavailable during the update,
+// at least one member cluster is allowed to be updated at a time.
+func ClusterSetMaxUnavailable(clusterSet *appsv1alpha1.ClusterSet) int32 {
+	replicas := ClusterSetReplicas(clusterSet)
+	maxUnavailable := 1
+	if clusterSet.Spec.UpdateStrategy != nil && clusterSet.Spec.UpdateStrategy.MaxUnavailable != nil {
+		if v, err := intstr.GetScaledValueFromIntOrPercent(clusterSet.Spec.UpdateStrategy.MaxUnavailable, int(replicas), false); err == nil {
+			maxUnavailable = v
+		}
+	}
+	if maxUnavailable < 1 {
+		maxUnavailable = 1
+	}
+	return int32(maxUnavailable)
+}
+
+// ClusterSetReplicas returns the desired number of member clusters of the ClusterSet.
+func ClusterSetReplicas(clusterSet *appsv1alpha1.ClusterSet) int32 {
+	if clusterSet.Spec.Replicas == nil {
+		return 1
+	}
+	return *clusterSet.Spec.Replicas
+}
+
+// BuildClusterSetMember builds the member cluster with the given index from the template of the ClusterSet,
+// the placeholders in the string values of the template are substituted with the name and index of the member cluster.
+func BuildClusterSetMember(clusterSet *appsv1alpha1.ClusterSet, index int32, revision string) (*appsv1alpha1.Cluster, error) {
+	name := ClusterSetMemberName(clusterSet.Name, index)
+	replacer := strings.NewReplacer(
+		constant.EnvPlaceHolder(constant.KBEnvClusterSetName), clusterSet.Name,
+		constant.EnvPlaceHolder(constant.KBEnvClusterSetIndex), strconv.Itoa(int(index)),
+		constant.EnvPlaceHolder(constant.KBEnvClusterName), name,
+	)
+
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&clusterSet.Spec.Template)
+	if err != nil {
+		return nil, err
+	}
+	template := &appsv1alpha1.ClusterTemplate{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(substituteStringValues(obj, replacer).(map[string]any), template); err != nil {
+		return nil, err
+	}
+	lastApplied, err := json.Marshal(template.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster := &appsv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   clusterSet.Namespace,
+			Name:        name,
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+		},
+		Spec: template.Spec,
+	}
+	for k, v := range template.Labels {
+		cluster.Labels[k] = v
+	}
+	for k, v := range template.Annotations {
+		cluster.Annotations[k] = v
+	}
+	cluster.Labels[constant.KBAppClusterSetLabelKey] = clusterSet.Name
+	cluster.Labels[constant.KBAppClusterSetIndexLabelKey] = strconv.Itoa(int(index))
+	cluster.Annotations[constant.ClusterSetRevisionAnnotationKey] = revision
+	cluster.Annotations[constant.ClusterSetLastAppliedAnnotationKey] = string(lastApplied)
+	return cluster, nil
+}
+
+// substituteStringValues substitutes the placeholders in the string values of the unstructured object,
+// the keys are left untouched.
+func substituteStringValues(obj any, replacer *strings.Replacer) any {
+	switch v := obj.(type) {
+	case string:
+		return replacer.Replace(v)
+	case map[string]any:
+		for key, value := range v {
+			v[key] = substituteStringValues(value, replacer)
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = substituteStringValues(value, replacer)
+		}
+	}
+	return obj
+}
+
+// MergeClusterSetMember updates the member cluster to the expected one built from the latest template.
+// Only the fields changed in the template since it was last applied are updated, so that the changes made to the
+// member cluster by others, e.g. the OpsRequests, are kept. If the last applied template is unknown, the fields
+// of the template are updated and the rest are kept.
+func MergeClusterSetMember(cluster, expected *appsv1alpha1.Cluster) error {
+	current, err := json.Marshal(cluster.Spec)
+	if err != nil {
+		return err
+	}
+	modified, err := json.Marshal(expected.Spec)
+	if err != nil {
+		return err
+	}
+	var patch []byte
+	if lastApplied := cluster.Annotations[constant.ClusterSetLastAppliedAnnotationKey]; len(lastApplied) > 0 {
+		patch, err = strategicpatch.CreateTwoWayMergePatch([]byte(lastApplied), modified, appsv1alpha1.ClusterSpec{})
+	} else {
+		lookupPatchMeta, lookupErr := strategicpatch.NewPatchMetaFromStruct(appsv1alpha1.ClusterSpec{})
+		if lookupErr != nil {
+			return lookupErr
+		}
+		// no fields are deleted as the original is taken as the same as the modified one.
+		patch, err = strategicpatch.CreateThreeWayMergePatch(modified, modified, current, lookupPatchMeta, true)
+	}
+	if err != nil {
+		return err
+	}
+	patch, err = dropRetainKeysDirectives(patch)
+	if err != nil {
+		return err
+	}
+	merged, err := strategicpatch.StrategicMergePatch(current, patch, appsv1alpha1.ClusterSpec{})
+	if err != nil {
+		return err
+	}
+	spec := appsv1alpha1.ClusterSpec{}
+	if err = json.Unmarshal(merged, &spec); err != nil {
+		return err
+	}
+	cluster.Spec = spec
+
+	if cluster.Labels == nil {
+		cluster.Labels = map[string]string{}
+	}
+	for k, v := range expected.Labels {
+		cluster.Labels[k] = v
+	}
+	if cluster.Annotations == nil {
+		cluster.Annotations = map[string]string{}
+	}
+	for k, v := range expected.Annotations {
+		cluster.Annotations[k] = v
+	}
+	return nil
+}
+
+// dropRetainKeysDirectives removes the $retainKeys directives from the strategic merge patch, which would clear
+// the fields not defined in the template.
+func dropRetainKeysDirectives(patch []byte) ([]byte, error) {
+	obj := map[string]any{}
+	if err := json.Unmarshal(patch, &obj); err != nil {
+		return nil, err
+	}
+	var drop func(any)
+	drop = func(obj any) {
+		switch v := obj.(type) {
+		case map[string]any:
+			delete(v, "$retainKeys")
+			for _, value := range v {
+				drop(value)
+			}
+		case []any:
+			for _, value := range v {
+				drop(value)
+			}
+		}
+	}
+	drop(obj)
+	return json.Marshal(obj)
+}
@@ -0,0 +1,143 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+func TestBuildClusterSetMember(t *testing.T) {
+	clusterSet := &appsv1alpha1.ClusterSet{}
+	clusterSet.Namespace = "default"
+	clusterSet.Name = "tenants"
+	clusterSet.Spec.Template = appsv1alpha1.ClusterTemplate{
+		Labels: map[string]string{"tenant": "$(KB_CLUSTER_SET_NAME)-$(KB_CLUSTER_SET_INDEX)"},
+		Spec: appsv1alpha1.ClusterSpec{
+			ClusterDefRef: "$(KB_CLUSTER_NAME)",
+		},
+	}
+
+	revision, err := ComputeClusterSetRevision(clusterSet)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cluster, err := BuildClusterSetMember(clusterSet, 3, revision)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cluster.Name != "tenants-3" || cluster.Namespace != "default" {
+		t.Errorf("unexpected member cluster %s/%s", cluster.Namespace, cluster.Name)
+	}
+	if cluster.Labels["tenant"] != "tenants-3" || cluster.Spec.ClusterDefRef != "tenants-3" {
+		t.Errorf("placeholders are not substituted: %v, %s", cluster.Labels, cluster.Spec.ClusterDefRef)
+	}
+	if cluster.Annotations[constant.ClusterSetRevisionAnnotationKey] != revision {
+		t.Errorf("expected revision %s, but got %s", revision, cluster.Annotations[constant.ClusterSetRevisionAnnotationKey])
+	}
+	if index, err := ClusterSetMemberIndex(cluster); err != nil || index != 3 {
+		t.Errorf("expected index 3, but got %d, error: %v", index, err)
+	}
+
+	clusterSet.Spec.Template.Spec.ClusterDefRef = "another"
+	if newRevision, _ := ComputeClusterSetRevision(clusterSet); newRevision == revision {
+		t.Errorf("expected the revision changed after the template updated")
+	}
+}
+
+func TestMergeClusterSetMember(t *testing.T) {
+	clusterSet := &appsv1alpha1.ClusterSet{}
+	clusterSet.Name = "tenants"
+	clusterSet.Spec.Template = appsv1alpha1.ClusterTemplate{
+		Labels: map[string]string{"owner": `"$(KB_CLUSTER_NAME)"`},
+		Spec: appsv1alpha1.ClusterSpec{
+			TerminationPolicy: appsv1alpha1.Delete,
+			ComponentSpecs: []appsv1alpha1.ClusterComponentSpec{
+				{Name: "mysql", Replicas: 1},
+				{Name: "proxy", Replicas: 1},
+			},
+		},
+	}
+	cluster, err := BuildClusterSetMember(clusterSet, 0, "1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cluster.Labels["owner"] != `"tenants-0"` {
+		t.Errorf("the quoted placeholder is not substituted: %s", cluster.Labels["owner"])
+	}
+
+	// the changes made by the OpsRequests
+	cluster.Spec.ComponentSpecs[0].Replicas = 3
+	cluster.Spec.ComponentSpecs[0].ServiceVersion = "8.0.30"
+
+	clusterSet.Spec.Template.Spec.TerminationPolicy = appsv1alpha1.WipeOut
+	clusterSet.Spec.Template.Spec.ComponentSpecs[1].Replicas = 2
+	expected, err := BuildClusterSetMember(clusterSet, 0, "2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err = MergeClusterSetMember(cluster, expected); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cluster.Spec.TerminationPolicy != appsv1alpha1.WipeOut || cluster.Spec.ComponentSpecs[1].Replicas != 2 {
+		t.Errorf("the changes of the template are not updated: %v", cluster.Spec)
+	}
+	if cluster.Spec.ComponentSpecs[0].Replicas != 3 || cluster.Spec.ComponentSpecs[0].ServiceVersion != "8.0.30" {
+		t.Errorf("the changes made to the member cluster are not kept: %v", cluster.Spec.ComponentSpecs[0])
+	}
+	if cluster.Annotations[constant.ClusterSetRevisionAnnotationKey] != "2" {
+		t.Errorf("expected revision 2, but got %s", cluster.Annotations[constant.ClusterSetRevisionAnnotationKey])
+	}
+
+	// the fields of the template are updated if the last applied template is unknown
+	delete(cluster.Annotations, constant.ClusterSetLastAppliedAnnotationKey)
+	clusterSet.Spec.Template.Spec.ComponentSpecs[0].Replicas = 5
+	expected, _ = BuildClusterSetMember(clusterSet, 0, "3")
+	if err = MergeClusterSetMember(cluster, expected); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cluster.Spec.ComponentSpecs[0].Replicas != 5 || cluster.Spec.ComponentSpecs[0].ServiceVersion != "8.0.30" {
+		t.Errorf("unexpected component spec: %v", cluster.Spec.ComponentSpecs[0])
+	}
+}
+
+func TestClusterSetMaxUnavailable(t *testing.T) {
+	clusterSet := &appsv1alpha1.ClusterSet{}
+	clusterSet.Spec.Replicas = pointer.Int32(10)
+	if v := ClusterSetMaxUnavailable(clusterSet); v != 1 {
+		t.Errorf("expected default max unavailable 1, but got %d", v)
+	}
+
+	maxUnavailable := intstr.FromString("25%")
+	clusterSet.Spec.UpdateStrategy = &appsv1alpha1.ClusterSetUpdateStrategy{MaxUnavailable: &maxUnavailable}
+	if v := ClusterSetMaxUnavailable(clusterSet); v != 2 {
+		t.Errorf("expected max unavailable 2, but got %d", v)
+	}
+
+	maxUnavailable = intstr.FromString("5%")
+	if v := ClusterSetMaxUnavailable(clusterSet); v != 1 {
+		t.Errorf("expected max unavailable at least 1, but got %d", v)
+	}
+}
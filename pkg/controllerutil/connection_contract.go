@@ -0,0 +1,167 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"fmt"
+
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+const (
+	// ConnectionContractDataKey is the key of the connection contract in the data of the connection contract Secret.
+	ConnectionContractDataKey = "contract.json"
+
+	// ConnectionContractVersion is the version of the connection contract schema, it should be bumped on
+	// any incompatible change of the schema.
+	ConnectionContractVersion = "v1"
+
+	ConnectionAccessReadWrite = "ReadWrite"
+	ConnectionAccessReadOnly  = "ReadOnly"
+)
+
+// ConnectionContract describes how to connect to a cluster in a stable schema, the applications can mount
+// the connection contract Secret rather than chasing the names generated for the services and secrets.
+type ConnectionContract struct {
+	Version   string `json:"version"`
+	Cluster   string `json:"cluster"`
+	Namespace string `json:"namespace"`
+	// Endpoints are the cluster-level services, ordered by name.
+	Endpoints []ConnectionEndpoint `json:"endpoints,omitempty"`
+	// Components are ordered by name.
+	Components []ComponentConnection `json:"components"`
+}
+
+// ComponentConnection describes how to connect to a component.
+type ComponentConnection struct {
+	// Name is the short name of the component.
+	Name string `json:"name"`
+	// Endpoints are ordered by name.
+	Endpoints []ConnectionEndpoint `json:"endpoints,omitempty"`
+	// Credentials are ordered by the account name.
+	Credentials []CredentialRef `json:"credentials,omitempty"`
+	TLS         *TLSRef         `json:"tls,omitempty"`
+	// HostNetwork tells whether the pods of the component run in the host network, the clients connect to
+	// the IPs of the nodes with the host ports then.
+	HostNetwork bool `json:"hostNetwork,omitempty"`
+	// HostPorts are the host ports allocated for the component, ordered by the container and port name.
+	HostPorts []HostPort `json:"hostPorts,omitempty"`
+}
+
+// HostPort is a host port allocated for a container port.
+type HostPort struct {
+	Container string `json:"container"`
+	Name      string `json:"name"`
+	Port      int32  `json:"port"`
+}
+
+// ConnectionEndpoint is a service to access the cluster or component.
+type ConnectionEndpoint struct {
+	Name     string           `json:"name"`
+	Host     string           `json:"host"`
+	Ports    []ConnectionPort `json:"ports,omitempty"`
+	Headless bool             `json:"headless,omitempty"`
+	// Role is the role of the replicas selected by the service, if any.
+	Role string `json:"role,omitempty"`
+	// Access is either ReadWrite or ReadOnly for the services selecting the replicas by role, and empty otherwise.
+	Access string `json:"access,omitempty"`
+}
+
+// ConnectionPort is a port of the endpoint.
+type ConnectionPort struct {
+	Name     string `json:"name,omitempty"`
+	Port     int32  `json:"port"`
+	Protocol string `json:"protocol,omitempty"`
+}
+
+// CredentialRef refers to the Secret of an account.
+type CredentialRef struct {
+	Account     string `json:"account"`
+	SecretName  string `json:"secretName"`
+	UsernameKey string `json:"usernameKey"`
+	PasswordKey string `json:"passwordKey"`
+}
+
+// TLSRef refers to the Secret of the TLS material.
+type TLSRef struct {
+	SecretName string `json:"secretName"`
+	CAKey      string `json:"caKey,omitempty"`
+	CertKey    string `json:"certKey,omitempty"`
+	KeyKey     string `json:"keyKey,omitempty"`
+}
+
+// BuildConnectionEndpoint builds the endpoint of the service, writable tells whether the role is writable and
+// whether the role is known.
+func BuildConnectionEndpoint(svc *corev1.Service, clusterDomain string, writable func(role string) (bool, bool)) ConnectionEndpoint {
+	endpoint := ConnectionEndpoint{
+		Name:     svc.Name,
+		Host:     fmt.Sprintf("%s.%s.svc.%s", svc.Name, svc.Namespace, clusterDomain),
+		Headless: svc.Spec.ClusterIP == corev1.ClusterIPNone,
+		Role:     svc.Spec.Selector[constant.RoleLabelKey],
+	}
+	for _, port := range svc.Spec.Ports {
+		endpoint.Ports = append(endpoint.Ports, ConnectionPort{
+			Name:     port.Name,
+			Port:     port.Port,
+			Protocol: string(port.Protocol),
+		})
+	}
+	if len(endpoint.Role) > 0 && writable != nil {
+		if ok, found := writable(endpoint.Role); found {
+			endpoint.Access = ConnectionAccessReadOnly
+			if ok {
+				endpoint.Access = ConnectionAccessReadWrite
+			}
+		}
+	}
+	return endpoint
+}
+
+// BuildCredentialRef builds the reference to the Secret of the account.
+func BuildCredentialRef(account, secretName string) CredentialRef {
+	return CredentialRef{
+		Account:     account,
+		SecretName:  secretName,
+		UsernameKey: constant.AccountNameForSecret,
+		PasswordKey: constant.AccountPasswdForSecret,
+	}
+}
+
+// SortConnectionContract orders the lists in the contract, so that the contract is stable across reconciliations.
+func SortConnectionContract(contract *ConnectionContract) {
+	sortEndpoints := func(endpoints []ConnectionEndpoint) {
+		slices.SortFunc(endpoints, func(a, b ConnectionEndpoint) bool { return a.Name < b.Name })
+	}
+	sortEndpoints(contract.Endpoints)
+	slices.SortFunc(contract.Components, func(a, b ComponentConnection) bool { return a.Name < b.Name })
+	for i := range contract.Components {
+		sortEndpoints(contract.Components[i].Endpoints)
+		slices.SortFunc(contract.Components[i].Credentials, func(a, b CredentialRef) bool { return a.Account < b.Account })
+		slices.SortFunc(contract.Components[i].HostPorts, func(a, b HostPort) bool {
+			if a.Container != b.Container {
+				return a.Container < b.Container
+			}
+			return a.Name < b.Name
+		})
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+func TestBuildConnectionEndpoint(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-mysql-readonly"},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{constant.RoleLabelKey: "follower"},
+			Ports:    []corev1.ServicePort{{Name: "mysql", Port: 3306, Protocol: corev1.ProtocolTCP}},
+		},
+	}
+	writable := func(role string) (bool, bool) {
+		switch role {
+		case "leader":
+			return true, true
+		case "follower":
+			return false, true
+		}
+		return false, false
+	}
+	endpoint := BuildConnectionEndpoint(svc, "cluster.local", writable)
+	if endpoint.Host != "test-mysql-readonly.default.svc.cluster.local" || endpoint.Headless {
+		t.Errorf("unexpected endpoint: %+v", endpoint)
+	}
+	if endpoint.Role != "follower" || endpoint.Access != ConnectionAccessReadOnly {
+		t.Errorf("expected read-only access, but got %+v", endpoint)
+	}
+	if len(endpoint.Ports) != 1 || endpoint.Ports[0].Port != 3306 || endpoint.Ports[0].Protocol != "TCP" {
+		t.Errorf("unexpected ports: %+v", endpoint.Ports)
+	}
+
+	svc.Spec.Selector[constant.RoleLabelKey] = "leader"
+	if endpoint = BuildConnectionEndpoint(svc, "cluster.local", writable); endpoint.Access != ConnectionAccessReadWrite {
+		t.Errorf("expected read-write access, but got %+v", endpoint)
+	}
+
+	svc.Spec.Selector = nil
+	svc.Spec.ClusterIP = corev1.ClusterIPNone
+	if endpoint = BuildConnectionEndpoint(svc, "cluster.local", writable); !endpoint.Headless || endpoint.Access != "" {
+		t.Errorf("expected headless endpoint without access, but got %+v", endpoint)
+	}
+}
+
+func TestSortConnectionContract(t *testing.T) {
+	contract := &ConnectionContract{
+		Components: []ComponentConnection{
+			{
+				Name:        "proxy",
+				Endpoints:   []ConnectionEndpoint{{Name: "test-proxy-b"}, {Name: "test-proxy-a"}},
+				Credentials: []CredentialRef{BuildCredentialRef("root", "s1"), BuildCredentialRef("admin", "s2")},
+			},
+			{Name: "mysql"},
+		},
+	}
+	SortConnectionContract(contract)
+	if contract.Components[0].Name != "mysql" {
+		t.Errorf("expected components ordered by name, but got %+v", contract.Components)
+	}
+	proxy := contract.Components[1]
+	if proxy.Endpoints[0].Name != "test-proxy-a" || proxy.Credentials[0].Account != "admin" {
+		t.Errorf("expected endpoints and credentials ordered, but got %+v", proxy)
+	}
+	if proxy.Credentials[0].UsernameKey != constant.AccountNameForSecret || proxy.Credentials[0].PasswordKey != constant.AccountPasswdForSecret {
+		t.Errorf("unexpected credential keys: %+v", proxy.Credentials[0])
+	}
+}
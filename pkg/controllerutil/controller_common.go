@@ -458,6 +458,7 @@ func (pm *PortManager) update(key string, port int32) error {
 	if cm.Data == nil {
 		cm.Data = make(map[string]string)
 	}
+	oldPort, _ := pm.parsePort(cm.Data[key])
 	cm.Data[key] = fmt.Sprintf("%d", port)
 	err = pm.cli.Update(context.Background(), cm)
 	if err != nil {
@@ -465,6 +466,10 @@ func (pm *PortManager) update(key string, port int32) error {
 	}
 
 	pm.cm = cm
+	// the key moves to another port, release the port used previously
+	if oldPort != 0 && oldPort != port && pm.used[oldPort] == key {
+		delete(pm.used, oldPort)
+	}
 	pm.used[port] = key
 	return nil
 }
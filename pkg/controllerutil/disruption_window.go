@@ -0,0 +1,138 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/slices"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+// InMaintenanceWindow checks whether the time is within the window, and returns the duration to wait for
+// the next opening of the window if not, zero if it can't be determined.
+// A nil window is always open.
+func InMaintenanceWindow(window *appsv1alpha1.MaintenanceWindow, now time.Time) (bool, time.Duration) {
+	if window == nil {
+		return true, 0
+	}
+	start, err := time.Parse("15:04", window.StartTime)
+	if err != nil || window.Duration.Duration <= 0 {
+		return false, 0
+	}
+	now = now.UTC()
+	allowed := func(t time.Time) bool {
+		if len(window.DaysOfWeek) == 0 {
+			return true
+		}
+		return slices.Contains(window.DaysOfWeek, appsv1alpha1.Weekday(t.Weekday().String()))
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), start.Hour(), start.Minute(), 0, 0, time.UTC)
+	// the window opened on the previous days may still be open if its duration is longer than a day
+	for opened := today; now.Sub(opened) < window.Duration.Duration; opened = opened.AddDate(0, 0, -1) {
+		if !opened.After(now) && allowed(opened) {
+			return true, 0
+		}
+	}
+	for next := today; next.Before(today.AddDate(0, 0, 8)); next = next.AddDate(0, 0, 1) {
+		if next.After(now) && allowed(next) {
+			return false, next.Sub(now)
+		}
+	}
+	return false, 0
+}
+
+// InDisruptionWindows checks whether the time is within any of the windows, and returns the duration to wait for
+// the earliest opening of the windows if not, zero if it can't be determined.
+// The disruptions are always allowed if there are no windows.
+func InDisruptionWindows(windows []appsv1alpha1.MaintenanceWindow, now time.Time) (bool, time.Duration) {
+	if len(windows) == 0 {
+		return true, 0
+	}
+	var wait time.Duration
+	for i := range windows {
+		open, next := InMaintenanceWindow(&windows[i], now)
+		if open {
+			return true, 0
+		}
+		if next > 0 && (wait == 0 || next < wait) {
+			wait = next
+		}
+	}
+	return false, wait
+}
+
+// GetClusterDisruptionWindows returns the disruption windows of the cluster, nil if the windows are ignored.
+func GetClusterDisruptionWindows(cluster *appsv1alpha1.Cluster) []appsv1alpha1.MaintenanceWindow {
+	if strings.EqualFold(cluster.Annotations[constant.IgnoreDisruptionWindowsAnnotationKey], "true") {
+		return nil
+	}
+	return cluster.Spec.DisruptionWindows
+}
+
+// BuildDisruptionWindowsAnnotation builds the annotation to pass the disruption windows of the cluster to the workloads.
+// The windows are not passed while an OpsRequest is running on the cluster, the updates requested by it are not deferred.
+func BuildDisruptionWindowsAnnotation(cluster *appsv1alpha1.Cluster) (map[string]string, error) {
+	windows := GetClusterDisruptionWindows(cluster)
+	if len(windows) == 0 {
+		return nil, nil
+	}
+	running, err := hasRunningOpsRequest(cluster)
+	if err != nil || running {
+		return nil, err
+	}
+	value, err := json.Marshal(windows)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{constant.DisruptionWindowsAnnotationKey: string(value)}, nil
+}
+
+// hasRunningOpsRequest checks whether there is an OpsRequest running on the cluster, the queued ones are not counted.
+func hasRunningOpsRequest(cluster *appsv1alpha1.Cluster) (bool, error) {
+	value := cluster.Annotations[constant.OpsRequestAnnotationKey]
+	if len(value) == 0 {
+		return false, nil
+	}
+	var records []appsv1alpha1.OpsRecorder
+	if err := json.Unmarshal([]byte(value), &records); err != nil {
+		return false, err
+	}
+	return slices.ContainsFunc(records, func(r appsv1alpha1.OpsRecorder) bool {
+		return !r.InQueue
+	}), nil
+}
+
+// GetDisruptionWindows parses the disruption windows from the annotations of the workload.
+func GetDisruptionWindows(annotations map[string]string) ([]appsv1alpha1.MaintenanceWindow, error) {
+	value, ok := annotations[constant.DisruptionWindowsAnnotationKey]
+	if !ok || len(value) == 0 {
+		return nil, nil
+	}
+	var windows []appsv1alpha1.MaintenanceWindow
+	if err := json.Unmarshal([]byte(value), &windows); err != nil {
+		return nil, err
+	}
+	return windows, nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+func TestInDisruptionWindows(t *testing.T) {
+	windows := []appsv1alpha1.MaintenanceWindow{
+		{
+			DaysOfWeek: []appsv1alpha1.Weekday{"Monday"},
+			StartTime:  "22:00",
+			Duration:   metav1.Duration{Duration: 2 * time.Hour},
+		},
+		{
+			StartTime: "04:00",
+			Duration:  metav1.Duration{Duration: time.Hour},
+		},
+	}
+
+	if open, _ := InDisruptionWindows(nil, time.Now()); !open {
+		t.Errorf("expected the disruptions to be allowed without windows")
+	}
+	// 2024-06-03 is a Monday
+	if open, _ := InDisruptionWindows(windows, time.Date(2024, 6, 3, 23, 0, 0, 0, time.UTC)); !open {
+		t.Errorf("expected to be within the Monday window")
+	}
+	if open, _ := InDisruptionWindows(windows, time.Date(2024, 6, 5, 4, 30, 0, 0, time.UTC)); !open {
+		t.Errorf("expected to be within the daily window")
+	}
+	open, wait := InDisruptionWindows(windows, time.Date(2024, 6, 3, 21, 0, 0, 0, time.UTC))
+	if open || wait != time.Hour {
+		t.Errorf("expected to wait an hour for the Monday window, but got open: %v, wait: %v", open, wait)
+	}
+	open, wait = InDisruptionWindows(windows, time.Date(2024, 6, 4, 2, 0, 0, 0, time.UTC))
+	if open || wait != 2*time.Hour {
+		t.Errorf("expected to wait two hours for the daily window, but got open: %v, wait: %v", open, wait)
+	}
+}
+
+func TestDisruptionWindowsAnnotation(t *testing.T) {
+	cluster := &appsv1alpha1.Cluster{
+		Spec: appsv1alpha1.ClusterSpec{
+			DisruptionWindows: []appsv1alpha1.MaintenanceWindow{
+				{StartTime: "01:00", Duration: metav1.Duration{Duration: time.Hour}},
+			},
+		},
+	}
+	annotations, err := BuildDisruptionWindowsAnnotation(cluster)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	windows, err := GetDisruptionWindows(annotations)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(windows) != 1 || windows[0].StartTime != "01:00" || windows[0].Duration.Duration != time.Hour {
+		t.Errorf("unexpected windows: %v", windows)
+	}
+
+	cluster.Annotations = map[string]string{constant.IgnoreDisruptionWindowsAnnotationKey: "true"}
+	if annotations, _ = BuildDisruptionWindowsAnnotation(cluster); annotations != nil {
+		t.Errorf("expected no annotations if the windows are ignored, but got %v", annotations)
+	}
+
+	cluster.Annotations = map[string]string{constant.OpsRequestAnnotationKey: `[{"name":"restart","type":"Restart","inQueue":true}]`}
+	if annotations, _ = BuildDisruptionWindowsAnnotation(cluster); annotations == nil {
+		t.Errorf("expected the annotations if the OpsRequest is queued")
+	}
+
+	cluster.Annotations = map[string]string{constant.OpsRequestAnnotationKey: `[{"name":"restart","type":"Restart"}]`}
+	if annotations, _ = BuildDisruptionWindowsAnnotation(cluster); annotations != nil {
+		t.Errorf("expected no annotations if an OpsRequest is running, but got %v", annotations)
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// ErrorClass classifies an error by how a controller should react to it.
+type ErrorClass string
+
+const (
+	// ErrorClassTerminal indicates that retrying will not help, the object should be marked as failed.
+	ErrorClassTerminal ErrorClass = "Terminal"
+	// ErrorClassTransient indicates a temporary failure, the reconciliation should be retried with backoff.
+	ErrorClassTransient ErrorClass = "Transient"
+	// ErrorClassConflict indicates an optimistic-lock conflict, the reconciliation should be requeued immediately.
+	ErrorClassConflict ErrorClass = "Conflict"
+	// ErrorClassExternalDependency indicates that a dependent resource or service is not ready yet.
+	ErrorClassExternalDependency ErrorClass = "ExternalDependency"
+)
+
+var reconcileErrorsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "kubeblocks_reconcile_errors_total",
+		Help: "Total number of reconcile errors per controller and error class.",
+	},
+	[]string{"controller", "class"},
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(reconcileErrorsTotal)
+}
+
+// NewTransientError returns a new Error with ErrorTypeTransient.
+func NewTransientError(format string, a ...any) *Error {
+	return NewErrorf(ErrorTypeTransient, format, a...)
+}
+
+// NewExternalDependencyError returns a new Error with ErrorTypeExternalDependency.
+func NewExternalDependencyError(format string, a ...any) *Error {
+	return NewErrorf(ErrorTypeExternalDependency, format, a...)
+}
+
+// ClassifyError returns the class of the error, unknown errors are treated as transient.
+// Only the errors explicitly marked as fatal are terminal, the errors returned by the API server,
+// e.g. Invalid for an object generated from a dependent definition, may be fixed by others and are retried.
+func ClassifyError(err error) ErrorClass {
+	if err == nil {
+		return ""
+	}
+	if ctrlErr := UnwrapControllerError(err); ctrlErr != nil {
+		switch ctrlErr.Type {
+		case ErrorTypeFatal:
+			return ErrorClassTerminal
+		case ErrorTypeExternalDependency, ErrorTypeNotFound:
+			return ErrorClassExternalDependency
+		}
+		return ErrorClassTransient
+	}
+	switch {
+	case apierrors.IsConflict(err):
+		return ErrorClassConflict
+	case apierrors.IsNotFound(err):
+		return ErrorClassExternalDependency
+	}
+	return ErrorClassTransient
+}
+
+// IsTerminalError checks if the error can not be recovered by retrying.
+func IsTerminalError(err error) bool {
+	return ClassifyError(err) == ErrorClassTerminal
+}
+
+// IsRetryableError checks if the error can be recovered by retrying.
+func IsRetryableError(err error) bool {
+	return err != nil && !IsTerminalError(err)
+}
+
+// RecordReconcileError increases the error counter of the controller by the class of the error.
+func RecordReconcileError(controller string, err error) ErrorClass {
+	class := ClassifyError(err)
+	if class != "" {
+		reconcileErrorsTotal.WithLabelValues(controller, string(class)).Inc()
+	}
+	return class
+}
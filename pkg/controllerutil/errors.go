@@ -51,6 +51,9 @@ const (
 
 	ErrorTypeFatal ErrorType = "Fatal" // fatal error
 
+	ErrorTypeTransient          ErrorType = "Transient"          // transient error, retry with backoff
+	ErrorTypeExternalDependency ErrorType = "ExternalDependency" // dependent resource or service is not ready
+
 	// ErrorType for cluster controller
 	ErrorTypeBackupFailed  ErrorType = "BackupFailed"
 	ErrorTypeRestoreFailed ErrorType = "RestoreFailed"
@@ -23,6 +23,8 @@ import (
 	"testing"
 
 	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func TestIsTargetError(t *testing.T) {
@@ -39,3 +41,31 @@ func TestIsTargetError(t *testing.T) {
 		t.Error("IsTargetError expects a true return, but got false")
 	}
 }
+
+func TestClassifyError(t *testing.T) {
+	gr := schema.GroupResource{Group: "apps.kubeblocks.io", Resource: "clusters"}
+	cases := []struct {
+		err   error
+		class ErrorClass
+	}{
+		{nil, ""},
+		{NewFatalError("fatal"), ErrorClassTerminal},
+		{errors.Wrap(NewFatalError("fatal"), "wrapped"), ErrorClassTerminal},
+		{NewTransientError("transient"), ErrorClassTransient},
+		{NewExternalDependencyError("backup repo %s not ready", "repo"), ErrorClassExternalDependency},
+		{apierrors.NewConflict(gr, "test", errors.New("conflict")), ErrorClassConflict},
+		{apierrors.NewAlreadyExists(gr, "test"), ErrorClassTransient},
+		{apierrors.NewBadRequest("bad request"), ErrorClassTransient},
+		{apierrors.NewInvalid(schema.GroupKind{Group: "apps", Kind: "StatefulSet"}, "test", nil), ErrorClassTransient},
+		{apierrors.NewNotFound(gr, "test"), ErrorClassExternalDependency},
+		{errors.New("unknown"), ErrorClassTransient},
+	}
+	for _, c := range cases {
+		if class := ClassifyError(c.err); class != c.class {
+			t.Errorf("ClassifyError(%v) expects %s, but got %s", c.err, c.class, class)
+		}
+	}
+	if IsRetryableError(NewFatalError("fatal")) {
+		t.Error("IsRetryableError expects a false return for fatal error, but got true")
+	}
+}
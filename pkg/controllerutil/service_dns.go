@@ -0,0 +1,98 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/common"
+)
+
+const (
+	ExternalDNSHostnameAnnotationKey = "external-dns.alpha.kubernetes.io/hostname"
+	ExternalDNSTTLAnnotationKey      = "external-dns.alpha.kubernetes.io/ttl"
+
+	defaultHostnameTemplate = "$(SERVICE_NAME).$(NAMESPACE)"
+)
+
+// serviceDNSAnnotationKeys are the annotations managed for the DNS records of the Services.
+var serviceDNSAnnotationKeys = []string{ExternalDNSHostnameAnnotationKey, ExternalDNSTTLAnnotationKey}
+
+// BuildServiceHostname renders the hostname of the Service with the DNS configuration of the cluster.
+func BuildServiceHostname(dns *appsv1alpha1.ClusterDNS, namespace, clusterName, compName, serviceName string) string {
+	tpl, ok := dns.ServiceHostnameTemplates[strings.TrimPrefix(serviceName, clusterName+"-")]
+	if !ok {
+		tpl = dns.HostnameTemplate
+	}
+	if tpl == "" {
+		tpl = defaultHostnameTemplate
+	}
+	vars := map[string]string{
+		"SERVICE_NAME":   serviceName,
+		"CLUSTER_NAME":   clusterName,
+		"COMPONENT_NAME": compName,
+		"NAMESPACE":      namespace,
+	}
+	hostname := strings.Trim(common.Expand(tpl, common.MappingFuncFor(vars)), ".")
+	return hostname + "." + strings.Trim(dns.Domain, ".")
+}
+
+// BuildServiceDNSAnnotations builds the annotations to manage the DNS record of the Service,
+// only the Services exposed outside of the Kubernetes cluster are given a hostname.
+func BuildServiceDNSAnnotations(dns *appsv1alpha1.ClusterDNS, namespace, clusterName, compName, serviceName string,
+	svcType corev1.ServiceType) map[string]string {
+	if dns == nil || dns.Domain == "" {
+		return nil
+	}
+	if svcType != corev1.ServiceTypeLoadBalancer && svcType != corev1.ServiceTypeNodePort {
+		return nil
+	}
+	if dns.Provider != "" && dns.Provider != appsv1alpha1.ExternalDNSProvider {
+		return nil
+	}
+	annotations := map[string]string{
+		ExternalDNSHostnameAnnotationKey: BuildServiceHostname(dns, namespace, clusterName, compName, serviceName),
+	}
+	if dns.TTL != nil {
+		annotations[ExternalDNSTTLAnnotationKey] = strconv.Itoa(int(*dns.TTL))
+	}
+	return annotations
+}
+
+// SyncServiceDNSAnnotations syncs the DNS annotations of the desired Service to the running one,
+// since the hostnames change with the DNS configuration and the type of the Service.
+func SyncServiceDNSAnnotations(desired, running *corev1.Service) {
+	for _, key := range serviceDNSAnnotationKeys {
+		value, ok := desired.Annotations[key]
+		switch {
+		case ok:
+			if running.Annotations == nil {
+				running.Annotations = map[string]string{}
+			}
+			running.Annotations[key] = value
+		case running.Annotations != nil:
+			delete(running.Annotations, key)
+		}
+	}
+}
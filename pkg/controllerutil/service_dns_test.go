@@ -0,0 +1,80 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+func TestBuildServiceDNSAnnotations(t *testing.T) {
+	ttl := int32(60)
+	dns := &appsv1alpha1.ClusterDNS{
+		Domain: "db.example.com.",
+		TTL:    &ttl,
+		ServiceHostnameTemplates: map[string]string{
+			"mysql-vpc": "$(CLUSTER_NAME)-$(COMPONENT_NAME)",
+		},
+	}
+
+	if annotations := BuildServiceDNSAnnotations(nil, "ns", "mycluster", "", "mycluster-vpc", corev1.ServiceTypeLoadBalancer); annotations != nil {
+		t.Errorf("expected no annotations without dns, but got %v", annotations)
+	}
+	if annotations := BuildServiceDNSAnnotations(dns, "ns", "mycluster", "", "mycluster-vpc", corev1.ServiceTypeClusterIP); annotations != nil {
+		t.Errorf("expected no annotations for ClusterIP service, but got %v", annotations)
+	}
+
+	annotations := BuildServiceDNSAnnotations(dns, "ns", "mycluster", "", "mycluster-vpc", corev1.ServiceTypeLoadBalancer)
+	if hostname := annotations[ExternalDNSHostnameAnnotationKey]; hostname != "mycluster-vpc.ns.db.example.com" {
+		t.Errorf("unexpected default hostname: %s", hostname)
+	}
+	if annotations[ExternalDNSTTLAnnotationKey] != "60" {
+		t.Errorf("unexpected ttl: %s", annotations[ExternalDNSTTLAnnotationKey])
+	}
+
+	annotations = BuildServiceDNSAnnotations(dns, "ns", "mycluster", "mysql", "mycluster-mysql-vpc", corev1.ServiceTypeNodePort)
+	if hostname := annotations[ExternalDNSHostnameAnnotationKey]; hostname != "mycluster-mysql.db.example.com" {
+		t.Errorf("unexpected hostname of the service template: %s", hostname)
+	}
+}
+
+func TestSyncServiceDNSAnnotations(t *testing.T) {
+	running := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+		ExternalDNSTTLAnnotationKey: "60",
+		"other":                     "value",
+	}}}
+	desired := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+		ExternalDNSHostnameAnnotationKey: "a.example.com",
+	}}}
+	SyncServiceDNSAnnotations(desired, running)
+	if running.Annotations[ExternalDNSHostnameAnnotationKey] != "a.example.com" {
+		t.Errorf("expected the hostname to be synced, but got %v", running.Annotations)
+	}
+	if _, ok := running.Annotations[ExternalDNSTTLAnnotationKey]; ok {
+		t.Errorf("expected the ttl to be removed, but got %v", running.Annotations)
+	}
+	if running.Annotations["other"] != "value" {
+		t.Errorf("expected other annotations to be kept, but got %v", running.Annotations)
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// ShardingRoutingDataKey is the key of the routing metadata in the data of the sharding routing ConfigMap.
+	ShardingRoutingDataKey = "routing.json"
+
+	// DefaultShardingHashSlots is the number of hash slots distributed among the shards by default.
+	DefaultShardingHashSlots = 16384
+)
+
+// ShardingRouting is the routing metadata of a sharding, which is published for the client-side routers and proxies.
+type ShardingRouting struct {
+	Sharding  string `json:"sharding"`
+	HashSlots int32  `json:"hashSlots"`
+	// Shards are ordered by name.
+	Shards []ShardRouting `json:"shards"`
+}
+
+// ShardRouting is the routing metadata of a shard.
+type ShardRouting struct {
+	// Name is the name of the shard, which is the short name of the sharding component.
+	Name      string          `json:"name"`
+	Component string          `json:"component"`
+	Slots     []HashSlotRange `json:"slots,omitempty"`
+	Endpoints ShardEndpoints  `json:"endpoints"`
+}
+
+// HashSlotRange is a closed range of hash slots.
+type HashSlotRange struct {
+	Start int32 `json:"start"`
+	End   int32 `json:"end"`
+}
+
+// ShardEndpoints are the DNS names to access the shard.
+type ShardEndpoints struct {
+	Service         string `json:"service"`
+	HeadlessService string `json:"headlessService"`
+}
+
+// BuildShardingRouting builds the routing metadata of the sharding. The hash slots of each shard are taken from slots,
+// which is keyed by the shard name and reported by the engine, the shards absent from slots are published without slots.
+func BuildShardingRouting(shardingName string, shards []string, slots map[string][]HashSlotRange,
+	endpoints func(shard string) (string, ShardEndpoints)) ShardingRouting {
+	shards = slices.Clone(shards)
+	slices.Sort(shards)
+
+	routing := ShardingRouting{
+		Sharding:  shardingName,
+		HashSlots: DefaultShardingHashSlots,
+		Shards:    make([]ShardRouting, 0, len(shards)),
+	}
+	for _, shard := range shards {
+		compName, shardEndpoints := endpoints(shard)
+		routing.Shards = append(routing.Shards, ShardRouting{
+			Name:      shard,
+			Component: compName,
+			Slots:     slots[shard],
+			Endpoints: shardEndpoints,
+		})
+	}
+	return routing
+}
+
+// ParseShardingRoutingSlots returns the hash slots of the shards published in the routing metadata,
+// it returns nil if the data is not a valid routing metadata.
+func ParseShardingRoutingSlots(data string) map[string][]HashSlotRange {
+	routing := ShardingRouting{}
+	if err := json.Unmarshal([]byte(data), &routing); err != nil {
+		return nil
+	}
+	slots := map[string][]HashSlotRange{}
+	for _, shard := range routing.Shards {
+		if len(shard.Slots) > 0 {
+			slots[shard.Name] = shard.Slots
+		}
+	}
+	return slots
+}
+
+// ParseHashSlotRanges parses the hash slots in the format of "0-5460,5461,10923-16383".
+func ParseHashSlotRanges(value string) ([]HashSlotRange, error) {
+	var ranges []HashSlotRange
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		startStr, endStr, found := strings.Cut(item, "-")
+		if !found {
+			endStr = startStr
+		}
+		start, err := strconv.ParseInt(strings.TrimSpace(startStr), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hash slot range %q: %v", item, err)
+		}
+		end, err := strconv.ParseInt(strings.TrimSpace(endStr), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hash slot range %q: %v", item, err)
+		}
+		if start < 0 || start > end || end >= DefaultShardingHashSlots {
+			return nil, fmt.Errorf("invalid hash slot range %q", item)
+		}
+		ranges = append(ranges, HashSlotRange{Start: int32(start), End: int32(end)})
+	}
+	return ranges, nil
+}
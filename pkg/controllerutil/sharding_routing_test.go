@@ -0,0 +1,76 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestBuildShardingRouting(t *testing.T) {
+	endpoints := func(shard string) (string, ShardEndpoints) {
+		return "test-" + shard, ShardEndpoints{Service: "test-" + shard + ".default.svc.cluster.local"}
+	}
+	slots := map[string][]HashSlotRange{
+		"shard-a": {{Start: 0, End: 5460}},
+		"shard-b": {{Start: 5461, End: 10922}, {Start: 16000, End: 16383}},
+	}
+	routing := BuildShardingRouting("shard", []string{"shard-c", "shard-a", "shard-b"}, slots, endpoints)
+	if routing.HashSlots != DefaultShardingHashSlots || len(routing.Shards) != 3 {
+		t.Fatalf("unexpected routing: %+v", routing)
+	}
+	for i, name := range []string{"shard-a", "shard-b", "shard-c"} {
+		shard := routing.Shards[i]
+		if shard.Name != name || shard.Component != "test-"+name {
+			t.Errorf("expected shard %s at %d, but got %+v", name, i, shard)
+		}
+		if !reflect.DeepEqual(shard.Slots, slots[name]) {
+			t.Errorf("expected slots %v of shard %s, but got %v", slots[name], name, shard.Slots)
+		}
+	}
+
+	data, err := json.Marshal(routing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed := ParseShardingRoutingSlots(string(data)); !reflect.DeepEqual(parsed, slots) {
+		t.Errorf("expected the published slots %v, but got %v", slots, parsed)
+	}
+	if parsed := ParseShardingRoutingSlots("invalid"); parsed != nil {
+		t.Errorf("expected nil for the invalid routing, but got %v", parsed)
+	}
+}
+
+func TestParseHashSlotRanges(t *testing.T) {
+	ranges, err := ParseHashSlotRanges("0-5460, 5461,10923-16383")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []HashSlotRange{{Start: 0, End: 5460}, {Start: 5461, End: 5461}, {Start: 10923, End: 16383}}
+	if !reflect.DeepEqual(ranges, expected) {
+		t.Errorf("expected %v, but got %v", expected, ranges)
+	}
+	for _, value := range []string{"a-1", "10-1", "0-16384"} {
+		if _, err = ParseHashSlotRanges(value); err == nil {
+			t.Errorf("expected error for %q", value)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+// shutdownRequeueAfter is the interval to requeue the work rejected when the operator is shutting down,
+// the work is picked up by the next leader.
+const shutdownRequeueAfter = 5 * time.Second
+
+// shutdownCoordinator tracks the in-flight work which should not be interrupted, e.g. the invocations of
+// the non-idempotent actions and the execution of the DAG plans, and drains them when the operator is shutting down.
+type shutdownCoordinator struct {
+	mu           sync.Mutex
+	shuttingDown bool
+	inFlight     map[string]int
+	// handoff records the in-flight work which should not be run again after the restart if it's interrupted.
+	handoff map[string]bool
+	idle    *sync.Cond
+	// deadline is canceled when the drain timeout is reached, the in-flight work is interrupted then.
+	deadline       context.Context
+	cancelDeadline context.CancelFunc
+}
+
+func newShutdownCoordinator() *shutdownCoordinator {
+	c := &shutdownCoordinator{inFlight: map[string]int{}, handoff: map[string]bool{}}
+	c.idle = sync.NewCond(&c.mu)
+	c.deadline, c.cancelDeadline = context.WithCancel(context.Background())
+	return c
+}
+
+var coordinator = newShutdownCoordinator()
+
+// IsShuttingDown returns whether the operator is shutting down.
+func IsShuttingDown() bool {
+	coordinator.mu.Lock()
+	defer coordinator.mu.Unlock()
+	return coordinator.shuttingDown
+}
+
+// RunToCompletion runs the work identified by the key with a context which is not canceled by the shutdown of
+// the operator until the drain timeout is reached, so that the work won't be left half-done.
+// No new work is accepted once the operator is shutting down, a requeue error is returned instead.
+func RunToCompletion(ctx context.Context, key string, work func(ctx context.Context) error) error {
+	return coordinator.run(ctx, key, false, work)
+}
+
+// InvokeNonIdempotent invokes the non-idempotent action identified by the key to completion like RunToCompletion.
+// If the action was interrupted by the last shutdown of the operator, it's not invoked again since the outcome
+// is unknown, and true is returned to let the caller handle it, e.g. fail the action.
+func InvokeNonIdempotent(ctx context.Context, cli client.Client, key string, invoke func(ctx context.Context) error) (bool, error) {
+	handedOff, err := consumeHandoffMarker(ctx, cli, key)
+	if err != nil || handedOff {
+		return handedOff, err
+	}
+	return false, coordinator.run(ctx, key, true, invoke)
+}
+
+func (c *shutdownCoordinator) run(ctx context.Context, key string, handoff bool, work func(ctx context.Context) error) error {
+	done, err := c.begin(key, handoff)
+	if err != nil {
+		return err
+	}
+	defer done()
+	workCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancel()
+	stop := context.AfterFunc(c.deadline, cancel)
+	defer stop()
+	return work(workCtx)
+}
+
+func (c *shutdownCoordinator) begin(key string, handoff bool) (func(), error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.shuttingDown {
+		return nil, NewRequeueError(shutdownRequeueAfter, "the operator is shutting down")
+	}
+	c.inFlight[key]++
+	if handoff {
+		c.handoff[key] = true
+	}
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.inFlight[key]--; c.inFlight[key] <= 0 {
+			delete(c.inFlight, key)
+			delete(c.handoff, key)
+		}
+		if len(c.inFlight) == 0 {
+			c.idle.Broadcast()
+		}
+	}, nil
+}
+
+// drain rejects the new work and waits for the in-flight work to finish until the timeout, then it interrupts
+// the work still in flight, and returns the keys of them which should not be run again after the restart.
+func (c *shutdownCoordinator) drain(timeout time.Duration) []string {
+	c.mu.Lock()
+	c.shuttingDown = true
+	timer := time.AfterFunc(timeout, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.idle.Broadcast()
+	})
+	defer timer.Stop()
+	deadline := time.Now().Add(timeout)
+	for len(c.inFlight) > 0 && time.Now().Before(deadline) {
+		c.idle.Wait()
+	}
+	var pending []string
+	for key := range c.handoff {
+		pending = append(pending, key)
+	}
+	c.mu.Unlock()
+
+	c.cancelDeadline()
+	sort.Strings(pending)
+	return pending
+}
+
+// ShutdownDrainer is a manager runnable which drains the in-flight work when the manager is stopped.
+// The work not finished within the drain timeout is recorded in the handoff ConfigMap,
+// to prevent the non-idempotent actions from being invoked again after the restart.
+// The graceful shutdown timeout of the manager should be longer than the drain timeout.
+type ShutdownDrainer struct {
+	Client client.Client
+}
+
+// Start blocks until the manager is stopped, and then drains the in-flight work.
+func (d *ShutdownDrainer) Start(ctx context.Context) error {
+	<-ctx.Done()
+	logger := logf.FromContext(ctx).WithName("shutdown-drainer")
+	timeout := viper.GetDuration(constant.CfgKeyShutdownDrainTimeout)
+	logger.Info("draining the in-flight work", "timeout", timeout.String())
+	pending := coordinator.drain(timeout)
+	if len(pending) == 0 {
+		return nil
+	}
+	logger.Info("the in-flight actions are interrupted", "keys", pending)
+	handoffCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return recordHandoffMarkers(handoffCtx, d.Client, pending)
+}
+
+// NeedLeaderElection returns false to drain the work of the controllers no matter whether it's the leader.
+func (d *ShutdownDrainer) NeedLeaderElection() bool {
+	return false
+}
+
+func handoffConfigMapKey() types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: viper.GetString(constant.CfgKeyCtrlrMgrNS),
+		Name:      viper.GetString(constant.CfgKeyShutdownHandoffConfigMap),
+	}
+}
+
+// recordHandoffMarkers records the keys of the interrupted work in the handoff ConfigMap with the time of the shutdown.
+func recordHandoffMarkers(ctx context.Context, cli client.Client, keys []string) error {
+	cmKey := handoffConfigMapKey()
+	data := map[string]string{}
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, key := range keys {
+		data[key] = now
+	}
+	patch, err := json.Marshal(map[string]any{"data": data})
+	if err != nil {
+		return err
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: cmKey.Namespace, Name: cmKey.Name},
+	}
+	err = cli.Patch(ctx, cm, client.RawPatch(types.MergePatchType, patch))
+	if !apierrors.IsNotFound(err) {
+		return err
+	}
+	cm.Data = data
+	return cli.Create(ctx, cm)
+}
+
+// consumeHandoffMarker checks whether the work identified by the key was interrupted by the last shutdown,
+// the marker is removed once consumed.
+func consumeHandoffMarker(ctx context.Context, cli client.Client, key string) (bool, error) {
+	cmKey := handoffConfigMapKey()
+	if cmKey.Namespace == "" || cmKey.Name == "" {
+		return false, nil
+	}
+	cm := &corev1.ConfigMap{}
+	if err := cli.Get(ctx, cmKey, cm); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	if _, ok := cm.Data[key]; !ok {
+		return false, nil
+	}
+	patch, err := json.Marshal(map[string]any{"data": map[string]any{key: nil}})
+	if err != nil {
+		return false, err
+	}
+	if err = cli.Patch(ctx, cm, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
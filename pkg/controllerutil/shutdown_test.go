@@ -0,0 +1,94 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+func TestShutdownDrain(t *testing.T) {
+	c := newShutdownCoordinator()
+	done1, err := c.begin("action-1", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err = c.begin("action-2", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// the interrupted plan is not handed off since it's idempotent
+	if _, err = c.begin("plan", false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		done1()
+	}()
+
+	// action-2 is never done, it's interrupted when the drain timeout is reached
+	pending := c.drain(200 * time.Millisecond)
+	if len(pending) != 1 || pending[0] != "action-2" {
+		t.Errorf("expected action-2 to be pending, got %v", pending)
+	}
+	if c.deadline.Err() == nil {
+		t.Errorf("expected the in-flight work to be interrupted")
+	}
+	if _, err = c.begin("action-3", true); !IsRequeueError(err) {
+		t.Errorf("expected the new work to be rejected with a requeue error, got %v", err)
+	}
+}
+
+func TestHandoffMarkers(t *testing.T) {
+	viper.Set(constant.CfgKeyCtrlrMgrNS, "kb-system")
+	viper.Set(constant.CfgKeyShutdownHandoffConfigMap, "kubeblocks-shutdown-handoff")
+	defer viper.Set(constant.CfgKeyShutdownHandoffConfigMap, "")
+
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	cli := fake.NewClientBuilder().WithScheme(scheme).Build()
+	ctx := context.Background()
+
+	if handedOff, err := consumeHandoffMarker(ctx, cli, "action-1"); err != nil || handedOff {
+		t.Fatalf("expected no marker, got %v, %v", handedOff, err)
+	}
+	if err := recordHandoffMarkers(ctx, cli, []string{"action-1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := recordHandoffMarkers(ctx, cli, []string{"action-2"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, key := range []string{"action-1", "action-2"} {
+		if handedOff, err := consumeHandoffMarker(ctx, cli, key); err != nil || !handedOff {
+			t.Errorf("expected the marker of %s, got %v, %v", key, handedOff, err)
+		}
+		// the marker is consumed only once
+		if handedOff, err := consumeHandoffMarker(ctx, cli, key); err != nil || handedOff {
+			t.Errorf("expected the marker of %s to be consumed, got %v, %v", key, handedOff, err)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/lru"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	statusPatchPatched     = "patched"
+	statusPatchUnchanged   = "unchanged"
+	statusPatchCoalesced   = "coalesced"
+	statusPatchHistorySize = 4096
+)
+
+var statusPatchesTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "kubeblocks_status_patches_total",
+		Help: "Total number of status patches requested by the controllers, by result.",
+	},
+	[]string{"kind", "result"},
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(statusPatchesTotal)
+}
+
+// StatusWriter patches the status of objects lazily, it suppresses the patches that change nothing and coalesces
+// the rapid successive patches that are declared as coalescible, e.g. the churn of progress details,
+// within the window configured by constant.CfgKeyStatusPatchCoalesceWindow.
+type StatusWriter struct {
+	// lastPatched records the last time the status of an object is patched, keyed by the object UID.
+	lastPatched *lru.Cache
+	now         func() time.Time
+}
+
+var defaultStatusWriter = NewStatusWriter()
+
+// NewStatusWriter creates a new StatusWriter.
+func NewStatusWriter() *StatusWriter {
+	return &StatusWriter{
+		lastPatched: lru.New(statusPatchHistorySize),
+		now:         time.Now,
+	}
+}
+
+// GetStatusWriter returns the StatusWriter shared by the controllers.
+func GetStatusWriter() *StatusWriter {
+	return defaultStatusWriter
+}
+
+// StatusPatchOption configures a status patch.
+type StatusPatchOption func(*statusPatchOptions)
+
+type statusPatchOptions struct {
+	coalesce     bool
+	patchOptions []client.SubResourcePatchOption
+}
+
+// WithCoalesce declares that the patch only carries insignificant changes, which can be deferred
+// if the status of the object has been patched within the coalesce window.
+func WithCoalesce() StatusPatchOption {
+	return func(o *statusPatchOptions) {
+		o.coalesce = true
+	}
+}
+
+// WithPatchOptions passes the options to the status patch, e.g. multicluster.InControlContext().
+func WithPatchOptions(opts ...client.SubResourcePatchOption) StatusPatchOption {
+	return func(o *statusPatchOptions) {
+		o.patchOptions = append(o.patchOptions, opts...)
+	}
+}
+
+// PatchStatus patches the status of the object with a merge patch from the original object.
+// It returns false if the patch is suppressed. If the patch is deferred by the coalescing, it also returns
+// the time after which the caller should requeue the object to flush the deferred changes.
+func (w *StatusWriter) PatchStatus(ctx context.Context, cli client.Client, obj, original client.Object, opts ...StatusPatchOption) (bool, time.Duration, error) {
+	options := &statusPatchOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	kind := reflect.TypeOf(obj).Elem().Name()
+	if reflect.DeepEqual(statusOf(obj), statusOf(original)) {
+		statusPatchesTotal.WithLabelValues(kind, statusPatchUnchanged).Inc()
+		return false, 0, nil
+	}
+	if options.coalesce {
+		if flushAfter := w.coalesceWindowLeft(obj.GetUID()); flushAfter > 0 {
+			statusPatchesTotal.WithLabelValues(kind, statusPatchCoalesced).Inc()
+			return false, flushAfter, nil
+		}
+	}
+	if err := cli.Status().Patch(ctx, obj, client.MergeFrom(original), options.patchOptions...); err != nil {
+		return false, 0, err
+	}
+	w.lastPatched.Add(obj.GetUID(), w.now())
+	statusPatchesTotal.WithLabelValues(kind, statusPatchPatched).Inc()
+	return true, 0, nil
+}
+
+// coalesceWindowLeft returns the time left of the coalesce window since the status of the object was patched last,
+// zero if the object has not been patched within the window.
+func (w *StatusWriter) coalesceWindowLeft(uid types.UID) time.Duration {
+	window := viper.GetDuration(constant.CfgKeyStatusPatchCoalesceWindow)
+	if window <= 0 || len(uid) == 0 {
+		return 0
+	}
+	last, ok := w.lastPatched.Get(uid)
+	if !ok {
+		return 0
+	}
+	return max(last.(time.Time).Add(window).Sub(w.now()), 0)
+}
+
+// statusOf returns the Status field of the object, or the object itself if it has no Status field.
+func statusOf(obj client.Object) any {
+	v := reflect.ValueOf(obj)
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Struct {
+		if status := v.FieldByName("Status"); status.IsValid() {
+			return status.Interface()
+		}
+	}
+	return obj
+}
+
+// PatchStatus patches the status of the object by the shared StatusWriter, it returns the time after which
+// the caller should requeue the object if the patch is deferred by the coalescing, zero otherwise.
+func PatchStatus(ctx context.Context, cli client.Client, obj, original client.Object, opts ...StatusPatchOption) (time.Duration, error) {
+	_, flushAfter, err := defaultStatusWriter.PatchStatus(ctx, cli, obj, original, opts...)
+	return flushAfter, err
+}
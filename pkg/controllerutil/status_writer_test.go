@@ -0,0 +1,105 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+func TestStatusWriter(t *testing.T) {
+	viper.Set(constant.CfgKeyStatusPatchCoalesceWindow, "2s")
+	defer viper.Set(constant.CfgKeyStatusPatchCoalesceWindow, "")
+
+	scheme := runtime.NewScheme()
+	_ = appsv1alpha1.AddToScheme(scheme)
+	ops := &appsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "ops", UID: "ops-uid"},
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ops).WithStatusSubresource(ops).Build()
+
+	now := time.Now()
+	w := NewStatusWriter()
+	w.now = func() time.Time { return now }
+	ctx := context.Background()
+	var lastFlushAfter time.Duration
+	progress := func(p string, opts ...StatusPatchOption) bool {
+		obj := &appsv1alpha1.OpsRequest{}
+		if err := cli.Get(ctx, client.ObjectKeyFromObject(ops), obj); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		original := obj.DeepCopy()
+		obj.Status.Progress = p
+		patched, flushAfter, err := w.PatchStatus(ctx, cli, obj, original, opts...)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		lastFlushAfter = flushAfter
+		return patched
+	}
+	unchanged := func() float64 {
+		return testutil.ToFloat64(statusPatchesTotal.WithLabelValues("OpsRequest", statusPatchUnchanged))
+	}
+
+	if !progress("1/3", WithCoalesce()) {
+		t.Errorf("expected the first patch to be applied")
+	}
+	before := unchanged()
+	if progress("1/3") {
+		t.Errorf("expected the patch without changes to be suppressed")
+	}
+	if unchanged() != before+1 {
+		t.Errorf("expected the suppressed patch to be counted")
+	}
+	now = now.Add(500 * time.Millisecond)
+	if progress("2/3", WithCoalesce()) {
+		t.Errorf("expected the coalescible patch within the window to be deferred")
+	}
+	if lastFlushAfter != 1500*time.Millisecond {
+		t.Errorf("expected the deferred patch to be flushed at the end of the window, got %s", lastFlushAfter)
+	}
+	if !progress("2/3") {
+		t.Errorf("expected the significant patch to be applied within the window")
+	}
+	if lastFlushAfter != 0 {
+		t.Errorf("expected no flush for the applied patch, got %s", lastFlushAfter)
+	}
+
+	now = now.Add(3 * time.Second)
+	if !progress("3/3", WithCoalesce()) {
+		t.Errorf("expected the coalescible patch out of the window to be applied")
+	}
+	obj := &appsv1alpha1.OpsRequest{}
+	_ = cli.Get(ctx, client.ObjectKeyFromObject(ops), obj)
+	if obj.Status.Progress != "3/3" {
+		t.Errorf("unexpected progress: %s", obj.Status.Progress)
+	}
+}
@@ -106,6 +106,45 @@ func CreateOrUpdatePodVolumes(podSpec *corev1.PodSpec, volumes map[string]appsv1
 	return nil
 }
 
+// CreateOrUpdatePodSecretVolumes creates or updates the secret volumes of the templates whose rendered files are stored in Secrets.
+func CreateOrUpdatePodSecretVolumes(podSpec *corev1.PodSpec, volumes map[string]appsv1alpha1.ComponentTemplateSpec, configSet []string) error {
+	var (
+		err        error
+		podVolumes = podSpec.Volumes
+		volumeKeys = maps.Keys(volumes)
+	)
+
+	sort.Strings(volumeKeys)
+	for _, secretName := range volumeKeys {
+		templateSpec := volumes[secretName]
+		if templateSpec.VolumeName == "" {
+			continue
+		}
+		if podVolumes, err = CreateOrUpdateVolume(podVolumes, templateSpec.VolumeName, func(volumeName string) corev1.Volume {
+			return corev1.Volume{
+				Name: volumeName,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName:  secretName,
+						DefaultMode: buildVolumeMode(configSet, templateSpec),
+					},
+				},
+			}
+		}, func(volume *corev1.Volume) error {
+			secret := volume.Secret
+			if secret == nil {
+				return fmt.Errorf("mount volume[%s] requires a Secret: [%+v]", volume.Name, volume)
+			}
+			secret.SecretName = secretName
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	podSpec.Volumes = podVolumes
+	return nil
+}
+
 func buildVolumeMode(configs []string, configSpec appsv1alpha1.ComponentTemplateSpec) *int32 {
 	// If the defaultMode is not set, permissions are automatically set based on the template type.
 	if !viper.GetBool(constant.FeatureGateIgnoreConfigTemplateDefaultMode) && configSpec.DefaultMode != nil {
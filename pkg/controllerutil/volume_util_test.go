@@ -164,6 +164,27 @@ var _ = Describe("lifecycle_utils", func() {
 			Expect(volume.Name).Should(BeEquivalentTo(replicaVolumeName))
 		})
 
+		It("should succeed in adding the secret volume", func() {
+			const secretName = "my_config_secret"
+			volumes[secretName] = appsv1alpha1.ComponentTemplateSpec{
+				Name:        "myConfig",
+				TemplateRef: "myConfig",
+				VolumeName:  "myConfigVolume",
+			}
+			ps := &sts.Spec.Template.Spec
+			Expect(CreateOrUpdatePodSecretVolumes(ps, volumes, nil)).Should(Succeed())
+			Expect(len(ps.Volumes)).To(Equal(2))
+			volume := ps.Volumes[1]
+			Expect(volume.Secret).ShouldNot(BeNil())
+			Expect(volume.Secret.SecretName).Should(BeEquivalentTo(secretName))
+
+			// the volume mounted from a ConfigMap can't be updated to the secret volume
+			ps.Volumes[1].VolumeSource = corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{},
+			}
+			Expect(CreateOrUpdatePodSecretVolumes(ps, volumes, nil)).ShouldNot(Succeed())
+		})
+
 	})
 })
 
@@ -50,6 +50,12 @@ type JobAction struct {
 
 	// BackOffLimit is the number of retries before considering a JobAction as failed.
 	BackOffLimit *int32
+
+	// PriorityClassName is the name of the PriorityClass of the job pods.
+	PriorityClassName string
+
+	// TTLSecondsAfterFinished is the TTL of the finished job, after which it is deleted automatically.
+	TTLSecondsAfterFinished *int32
 }
 
 func (j *JobAction) GetName() string {
@@ -108,9 +114,13 @@ func (j *JobAction) Execute(actCtx ActionContext) (*dpv1alpha1.ActionStatus, err
 				ObjectMeta: j.ObjectMeta,
 				Spec:       *j.PodSpec,
 			},
-			BackoffLimit: j.BackOffLimit,
+			BackoffLimit:            j.BackOffLimit,
+			TTLSecondsAfterFinished: j.TTLSecondsAfterFinished,
 		},
 	}
+	if j.PriorityClassName != "" {
+		job.Spec.Template.Spec.PriorityClassName = j.PriorityClassName
+	}
 
 	controllerutil.AddFinalizer(job, types.DataProtectionFinalizerName)
 	if job.Namespace == j.Owner.GetNamespace() {
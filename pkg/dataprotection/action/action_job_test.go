@@ -116,5 +116,39 @@ var _ = Describe("JobAction Test", func() {
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(status.Phase).Should(Equal(dpv1alpha1.ActionPhaseCompleted))
 		})
+
+		It("should create job with priority class and ttl", func() {
+			ttl := int32(60)
+			act := &action.JobAction{
+				Name: actionName,
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      actionName,
+					Namespace: testCtx.DefaultNamespace,
+				},
+				PodSpec: &corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:    container,
+							Image:   testdp.KBToolImage,
+							Command: command,
+						},
+					},
+					RestartPolicy: corev1.RestartPolicyNever,
+				},
+				Owner:                   testdp.NewFakeBackup(&testCtx, nil),
+				PriorityClassName:       "low-priority",
+				TTLSecondsAfterFinished: &ttl,
+			}
+			_, err := act.Execute(buildActionCtx())
+			Expect(err).Should(Succeed())
+
+			By("check the job was created with the priority class and ttl")
+			key := client.ObjectKey{Name: actionName, Namespace: testCtx.DefaultNamespace}
+			Eventually(testapps.CheckObj(&testCtx, key, func(g Gomega, job *batchv1.Job) {
+				g.Expect(job.Spec.Template.Spec.PriorityClassName).Should(Equal("low-priority"))
+				g.Expect(job.Spec.TTLSecondsAfterFinished).Should(HaveValue(Equal(ttl)))
+			})).Should(Succeed())
+			Expect(act.PodSpec.PriorityClassName).Should(BeEmpty())
+		})
 	})
 })
@@ -165,7 +165,8 @@ func (d *Deleter) DeleteBackupFiles(backup *dpv1alpha1.Backup) (DeletionStatus,
 func (d *Deleter) buildDeleteBackupFilesScript(backupPath string) string {
 
 	// this script first deletes the directory where the backup is located (including files
-	// in the directory), and then traverses up the path level by level to clean up empty directories.
+	// in the directory) and verifies it's removed, and then traverses up the path level by level
+	// to clean up empty directories.
 	deleteScript := fmt.Sprintf(`
 set -x
 export PATH="$PATH:$%s"
@@ -174,6 +175,12 @@ targetPath="%s"
 echo "removing backup files in ${targetPath}"
 DATASAFED_KOPIA_MAINTENANCE=true datasafed rm -r "${targetPath}"
 
+# verify the backup files are removed
+if [ -n "$(datasafed list "${targetPath}" 2>/dev/null)" ]; then
+	echo "failed to remove the backup files in ${targetPath}"
+	exit 1
+fi
+
 # remove empty dirs from leaf to root
 function rmdirs() {
 	curr="$1"
@@ -382,6 +389,8 @@ func BuildDeleteBackupFilesJobKey(backup *dpv1alpha1.Backup, isPreDelete bool) c
 		preDeletePrefix = "pre"
 	}
 	jobName := fmt.Sprintf("%s-%s%s%s", backup.UID[:8], preDeletePrefix, deleteBackupFilesJobNamePrefix, backup.Name)
+	// the name is prefixed with the backup UID to be unique, it is truncated as before to keep tracking
+	// the existing jobs.
 	if len(jobName) > 63 {
 		jobName = strings.TrimSuffix(jobName[:63], "-")
 	}
@@ -177,11 +177,13 @@ func (r *Request) buildBackupDataAction(targetPod *corev1.Pod, name string) (act
 		}
 		r.InjectManagerContainer(podSpec, backupDataAct.SyncProgress, r.buildSyncProgressCommand())
 		return &action.JobAction{
-			Name:         name,
-			ObjectMeta:   *buildBackupJobObjMeta(r.Backup, name),
-			Owner:        r.Backup,
-			PodSpec:      podSpec,
-			BackOffLimit: r.BackupPolicy.Spec.BackoffLimit,
+			Name:                    name,
+			ObjectMeta:              *buildBackupJobObjMeta(r.Backup, name),
+			Owner:                   r.Backup,
+			PodSpec:                 podSpec,
+			BackOffLimit:            r.BackupPolicy.Spec.BackoffLimit,
+			PriorityClassName:       r.BackupPolicy.Spec.PriorityClassName,
+			TTLSecondsAfterFinished: r.BackupPolicy.Spec.TTLSecondsAfterFinished,
 		}, nil
 	case dpv1alpha1.BackupTypeContinuous:
 		podSpec, err := r.BuildJobActionPodSpec(r.TargetPods[0], BackupDataContainerName, &backupDataAct.JobActionSpec)
@@ -276,9 +278,11 @@ func (r *Request) buildExecAction(targetPod *corev1.Pod,
 	}
 	return &action.ExecAction{
 		JobAction: action.JobAction{
-			Name:       name,
-			ObjectMeta: objectMeta,
-			Owner:      r.Backup,
+			Name:                    name,
+			ObjectMeta:              objectMeta,
+			Owner:                   r.Backup,
+			PriorityClassName:       r.BackupPolicy.Spec.PriorityClassName,
+			TTLSecondsAfterFinished: r.BackupPolicy.Spec.TTLSecondsAfterFinished,
 		},
 		Command:            exec.Command,
 		Container:          containerName,
@@ -297,11 +301,13 @@ func (r *Request) buildJobAction(targetPod *corev1.Pod,
 		return nil, err
 	}
 	return &action.JobAction{
-		Name:         name,
-		ObjectMeta:   *buildBackupJobObjMeta(r.Backup, name),
-		Owner:        r.Backup,
-		PodSpec:      podSpec,
-		BackOffLimit: r.BackupPolicy.Spec.BackoffLimit,
+		Name:                    name,
+		ObjectMeta:              *buildBackupJobObjMeta(r.Backup, name),
+		Owner:                   r.Backup,
+		PodSpec:                 podSpec,
+		BackOffLimit:            r.BackupPolicy.Spec.BackoffLimit,
+		PriorityClassName:       r.BackupPolicy.Spec.PriorityClassName,
+		TTLSecondsAfterFinished: r.BackupPolicy.Spec.TTLSecondsAfterFinished,
 	}, nil
 }
 
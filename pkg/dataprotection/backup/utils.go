@@ -32,6 +32,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/common"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/action"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/types"
@@ -141,19 +142,27 @@ func BuildBackupWorkloadLabels(backup *dpv1alpha1.Backup) map[string]string {
 
 func buildBackupJobObjMeta(backup *dpv1alpha1.Backup, prefix string) *metav1.ObjectMeta {
 	return &metav1.ObjectMeta{
-		Name:      GenerateBackupJobName(backup, prefix),
+		Name:      backupJobName(backup, prefix),
 		Namespace: backup.Namespace,
 		Labels:    BuildBackupWorkloadLabels(backup),
 	}
 }
 
+// backupJobName returns the name of the job which has been recorded in the action status, so that the job
+// created with the name truncated by the former releases is still tracked rather than created again.
+func backupJobName(backup *dpv1alpha1.Backup, actionName string) string {
+	for _, act := range backup.Status.Actions {
+		if act.Name == actionName && act.ObjectRef != nil && act.ObjectRef.Kind == constant.JobKind && act.ObjectRef.Name != "" {
+			return act.ObjectRef.Name
+		}
+	}
+	return GenerateBackupJobName(backup, actionName)
+}
+
 func GenerateBackupJobName(backup *dpv1alpha1.Backup, prefix string) string {
 	name := fmt.Sprintf("%s-%s-%s", prefix, backup.Name, backup.UID[:8])
 	// job name cannot exceed 63 characters for label name limit.
-	if len(name) > 63 {
-		return strings.TrimSuffix(name[:63], "-")
-	}
-	return name
+	return common.ShortenName(name, common.DNS1123LabelMaxLength)
 }
 
 func generateBaseCRNameByBackupSchedule(uniqueNameWithBackupSchedule, backupScheduleNS, method string) string {
@@ -277,6 +277,17 @@ func (r *restoreJobBuilder) addTargetPodAndCredentialEnv(pod *corev1.Pod,
 	return r
 }
 
+// addMaskingRulesEnv adds the masking rules env for the masking stage.
+func (r *restoreJobBuilder) addMaskingRulesEnv() *restoreJobBuilder {
+	if r.stage != dpv1alpha1.Masking {
+		return r
+	}
+	if maskingRules := buildMaskingRulesEnvValue(r.restore); maskingRules != "" {
+		r.env = utils.MergeEnv(r.env, []corev1.EnvVar{{Name: DPMaskingRules, Value: maskingRules}})
+	}
+	return r
+}
+
 // builderRestoreJobName builds restore job name.
 func (r *restoreJobBuilder) builderRestoreJobName(jobIndex int) string {
 	jobName := fmt.Sprintf("restore-%s-%s-%s-%d", strings.ToLower(string(r.stage)), r.restore.UID[:8], r.backupSet.Backup.Name, jobIndex)
@@ -318,6 +329,7 @@ func (r *restoreJobBuilder) build() *batchv1.Job {
 	r.specificVolumes = append(r.specificVolumes, r.commonVolumes...)
 	podSpec.Volumes = r.specificVolumes
 	podSpec.ServiceAccountName = r.serviceAccount
+	podSpec.PriorityClassName = r.restore.Spec.PriorityClassName
 
 	job.Spec.Template.Spec = podSpec
 	job.Spec.Template.ObjectMeta = metav1.ObjectMeta{
@@ -328,6 +340,7 @@ func (r *restoreJobBuilder) build() *batchv1.Job {
 	} else {
 		job.Spec.BackoffLimit = &defaultBackoffLimit
 	}
+	job.Spec.TTLSecondsAfterFinished = r.restore.Spec.TTLSecondsAfterFinished
 
 	// 2. set restore container
 	r.specificVolumeMounts = append(r.specificVolumeMounts, r.commonVolumeMounts...)
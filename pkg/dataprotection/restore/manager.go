@@ -57,6 +57,7 @@ type RestoreManager struct {
 	Restore               *dpv1alpha1.Restore
 	PrepareDataBackupSets []BackupActionSet
 	PostReadyBackupSets   []BackupActionSet
+	MaskingBackupSets     []BackupActionSet
 	Schema                *runtime.Scheme
 	Recorder              record.EventRecorder
 	WorkerServiceAccount  string
@@ -68,6 +69,7 @@ func NewRestoreManager(restore *dpv1alpha1.Restore, recorder record.EventRecorde
 		Restore:               restore,
 		PrepareDataBackupSets: []BackupActionSet{},
 		PostReadyBackupSets:   []BackupActionSet{},
+		MaskingBackupSets:     []BackupActionSet{},
 		Schema:                schema,
 		Recorder:              recorder,
 	}
@@ -138,6 +140,7 @@ func (r *RestoreManager) BuildIncrementalBackupActionSets(reqCtx intctrlutil.Req
 	}
 	r.PrepareDataBackupSets = sortBackupSets(r.PrepareDataBackupSets, false)
 	r.PostReadyBackupSets = sortBackupSets(r.PostReadyBackupSets, false)
+	r.MaskingBackupSets = sortBackupSets(r.MaskingBackupSets, false)
 	return nil
 }
 
@@ -269,6 +272,10 @@ func (r *RestoreManager) SetBackupSets(backupSets ...BackupActionSet) {
 		if len(backupSets[i].ActionSet.Spec.Restore.PostReady) > 0 {
 			r.PostReadyBackupSets = append(r.PostReadyBackupSets, backupSets[i])
 		}
+
+		if len(backupSets[i].ActionSet.Spec.Restore.Masking) > 0 {
+			r.MaskingBackupSets = append(r.MaskingBackupSets, backupSets[i])
+		}
 	}
 }
 
@@ -281,17 +288,20 @@ func (r *RestoreManager) AnalysisRestoreActionsWithBackup(stage dpv1alpha1.Resto
 		existFailedAction   bool
 	)
 	restoreActions := r.Restore.Status.Actions.PostReady
-	if stage == dpv1alpha1.PrepareData {
+	switch stage {
+	case dpv1alpha1.PrepareData:
 		restoreActions = r.Restore.Status.Actions.PrepareData
 		// if the stage is prepareData, actionCount keeps up with pvc count.
 		restoreActionCount = GetRestoreActionsCountForPrepareData(r.Restore.Spec.PrepareDataConfig)
+	case dpv1alpha1.Masking:
+		restoreActions = r.Restore.Status.Actions.Masking
 	}
 	for i := range restoreActions {
 		if restoreActions[i].BackupName != backupName || restoreActions[i].Name != actionName {
 			continue
 		}
-		// if the stage is PostReady, actionCount keeps up with actions
-		if stage == dpv1alpha1.PostReady {
+		// if the stage is PostReady or Masking, actionCount keeps up with actions
+		if stage != dpv1alpha1.PrepareData {
 			restoreActionCount += 1
 		}
 		switch restoreActions[i].Status {
@@ -524,18 +534,43 @@ func (r *RestoreManager) BuildVolumePopulateJob(
 
 // BuildPostReadyActionJobs builds the post ready jobs.
 func (r *RestoreManager) BuildPostReadyActionJobs(reqCtx intctrlutil.RequestCtx, cli client.Client, backupSet BackupActionSet, target *dpv1alpha1.BackupStatusTarget, step int) ([]*batchv1.Job, error) {
-	readyConfig := r.Restore.Spec.ReadyConfig
-	if readyConfig == nil {
+	if r.Restore.Spec.ReadyConfig == nil {
 		return nil, nil
 	}
 	if !backupSet.ActionSet.HasPostReadyStage() {
 		return nil, nil
 	}
+	return r.buildReadyActionJobs(reqCtx, cli, backupSet, target, dpv1alpha1.PostReady,
+		backupSet.ActionSet.Spec.Restore.PostReady[step], step)
+}
+
+// BuildMaskingActionJobs builds the masking jobs, which are executed on the targets of spec.readyConfig.
+func (r *RestoreManager) BuildMaskingActionJobs(reqCtx intctrlutil.RequestCtx, cli client.Client, backupSet BackupActionSet, target *dpv1alpha1.BackupStatusTarget, step int) ([]*batchv1.Job, error) {
+	if r.Restore.Spec.MaskingConfig == nil {
+		return nil, nil
+	}
+	if r.Restore.Spec.ReadyConfig == nil {
+		return nil, intctrlutil.NewFatalError("spec.readyConfig can not be empty when spec.maskingConfig is specified")
+	}
+	if !backupSet.ActionSet.HasMaskingStage() {
+		return nil, nil
+	}
+	return r.buildReadyActionJobs(reqCtx, cli, backupSet, target, dpv1alpha1.Masking,
+		backupSet.ActionSet.Spec.Restore.Masking[step], step)
+}
+
+// buildReadyActionJobs builds the jobs of the action which is executed after the cluster is ready.
+func (r *RestoreManager) buildReadyActionJobs(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	backupSet BackupActionSet,
+	target *dpv1alpha1.BackupStatusTarget,
+	stage dpv1alpha1.RestoreStage,
+	actionSpec dpv1alpha1.ActionSpec,
+	step int) ([]*batchv1.Job, error) {
 	backupRepo, err := r.prepareBackupRepo(reqCtx, cli, backupSet)
 	if err != nil {
 		return nil, err
 	}
-	actionSpec := backupSet.ActionSet.Spec.Restore.PostReady[step]
 	getTargetPodList := func(labelSelector metav1.LabelSelector, msgKey string) (*corev1.PodList, error) {
 		targetPodList, err := utils.GetPodListByLabelSelector(reqCtx, cli, &labelSelector)
 		if err != nil {
@@ -547,11 +582,15 @@ func (r *RestoreManager) BuildPostReadyActionJobs(reqCtx intctrlutil.RequestCtx,
 		return targetPodList, nil
 	}
 
+	jobNamePrefix := "restore-post-ready"
+	if stage == dpv1alpha1.Masking {
+		jobNamePrefix = "restore-masking"
+	}
 	buildJobName := func(index int) string {
-		jobName := fmt.Sprintf("restore-post-ready-%s-%s-%d-%d", r.Restore.UID[:8], backupSet.Backup.Name, step, index)
+		jobName := fmt.Sprintf("%s-%s-%s-%d-%d", jobNamePrefix, r.Restore.UID[:8], backupSet.Backup.Name, step, index)
 		return cutJobName(jobName)
 	}
-	jobBuilder := newRestoreJobBuilder(r.Restore, backupSet, backupRepo, dpv1alpha1.PostReady)
+	jobBuilder := newRestoreJobBuilder(r.Restore, backupSet, backupRepo, stage)
 	buildJobsForJobAction := func() ([]*batchv1.Job, error) {
 		jobAction := r.Restore.Spec.ReadyConfig.JobAction
 		if jobAction == nil {
@@ -587,6 +626,7 @@ func (r *RestoreManager) BuildPostReadyActionJobs(reqCtx intctrlutil.RequestCtx,
 				setCommand(actionSpec.Job.Command).
 				setToleration(targetPod.Spec.Tolerations).
 				addTargetPodAndCredentialEnv(targetPod, r.Restore.Spec.ReadyConfig.ConnectionCredential).
+				addMaskingRulesEnv().
 				setServiceAccount(r.WorkerServiceAccount).
 				build()
 		}
@@ -628,7 +668,12 @@ func (r *RestoreManager) BuildPostReadyActionJobs(reqCtx intctrlutil.RequestCtx,
 			if containerName == "" {
 				containerName = targetPodList.Items[i].Spec.Containers[0].Name
 			}
-			args := append([]string{"-n", targetPodList.Items[i].Namespace, "exec", targetPodList.Items[i].Name, "-c", containerName, "--"}, actionSpec.Exec.Command...)
+			args := []string{"-n", targetPodList.Items[i].Namespace, "exec", targetPodList.Items[i].Name, "-c", containerName, "--"}
+			if maskingRules := buildMaskingRulesEnvValue(r.Restore); stage == dpv1alpha1.Masking && maskingRules != "" {
+				// the exec action runs in the target container, pass the masking rules by the "env" command.
+				args = append(args, "env", fmt.Sprintf("%s=%s", DPMaskingRules, maskingRules))
+			}
+			args = append(args, actionSpec.Exec.Command...)
 			jobBuilder.setImage(viper.GetString(constant.KBToolsImage)).setCommand([]string{"kubectl"}).setArgs(args).
 				setJobName(buildJobName(i)).
 				setToleration(targetPodList.Items[i].Spec.Tolerations)
@@ -721,8 +766,11 @@ func (r *RestoreManager) CheckJobsDone(
 		existFailedJob bool
 	)
 	restoreActions := &r.Restore.Status.Actions.PrepareData
-	if stage == dpv1alpha1.PostReady {
+	switch stage {
+	case dpv1alpha1.PostReady:
 		restoreActions = &r.Restore.Status.Actions.PostReady
+	case dpv1alpha1.Masking:
+		restoreActions = &r.Restore.Status.Actions.Masking
 	}
 	for i := range fetchedJobs {
 		statusAction := dpv1alpha1.RestoreStatusAction{
@@ -28,6 +28,7 @@ const (
 	ConditionTypeRestorePreparedData     = "PrepareData"
 	ConditionTypeReadinessProbe          = "ReadinessProbe"
 	ConditionTypeRestorePostReady        = "PostReady"
+	ConditionTypeRestoreMasking          = "Masking"
 	ConditionTypeRestoreCheckBackupRepo  = "CheckBackupRepo"
 	// condition reasons
 	ReasonRestoreStarting             = "RestoreStarting"
@@ -66,6 +67,7 @@ const (
 	DPBaseBackupStartTimestamp = "DP_BASE_BACKUP_START_TIMESTAMP"
 	DPBaseBackupStopTime       = "DP_BASE_BACKUP_STOP_TIME"
 	DPBaseBackupStopTimestamp  = "DP_BASE_BACKUP_STOP_TIMESTAMP"
+	DPMaskingRules             = "DP_MASKING_RULES"
 )
 
 // Restore constant
@@ -75,8 +75,11 @@ func SetRestoreStageCondition(restore *dpv1alpha1.Restore, stage dpv1alpha1.Rest
 		status = metav1.ConditionTrue
 	}
 	conditionType := ConditionTypeRestorePreparedData
-	if stage == dpv1alpha1.PostReady {
+	switch stage {
+	case dpv1alpha1.PostReady:
 		conditionType = ConditionTypeRestorePostReady
+	case dpv1alpha1.Masking:
+		conditionType = ConditionTypeRestoreMasking
 	}
 	SetRestoreCondition(restore, status, conditionType, reason, message)
 }
@@ -282,6 +285,8 @@ func ValidateAndInitRestoreMGR(reqCtx intctrlutil.RequestCtx,
 }
 
 func cutJobName(jobName string) string {
+	// the name contains the restore UID and ends with the job index to be unique, it is truncated as before
+	// since the jobs are tracked by their names in the restore status.
 	l := len(jobName)
 	if l > 63 {
 		return fmt.Sprintf("%s-%s", jobName[:57], jobName[l-5:l])
@@ -356,6 +361,45 @@ func GetRestoreFromBackupAnnotation(backup *dpv1alpha1.Backup, volumeRestorePoli
 	return string(bytes), nil
 }
 
+// buildMaskingRulesEnvValue builds the value of the masking rules env in JSON format.
+func buildMaskingRulesEnvValue(restore *dpv1alpha1.Restore) string {
+	if restore.Spec.MaskingConfig == nil || len(restore.Spec.MaskingConfig.Rules) == 0 {
+		return ""
+	}
+	rules := make([]dpv1alpha1.MaskingRule, len(restore.Spec.MaskingConfig.Rules))
+	for i, rule := range restore.Spec.MaskingConfig.Rules {
+		rules[i] = *rule.DeepCopy()
+		if rules[i].Method == "" {
+			rules[i].Method = dpv1alpha1.MaskingMethodRedact
+		}
+	}
+	bytes, _ := json.Marshal(rules)
+	return string(bytes)
+}
+
+// SetMaskingRulesToRestoreAnnotation sets the masking rules to the restore annotation of the cluster.
+func SetMaskingRulesToRestoreAnnotation(restoreAnnotation string, maskingRules []dpv1alpha1.MaskingRule) (string, error) {
+	if len(maskingRules) == 0 {
+		return restoreAnnotation, nil
+	}
+	rulesBytes, err := json.Marshal(maskingRules)
+	if err != nil {
+		return "", err
+	}
+	restoreForClusterMap := map[string]map[string]string{}
+	if err = json.Unmarshal([]byte(restoreAnnotation), &restoreForClusterMap); err != nil {
+		return "", err
+	}
+	for k := range restoreForClusterMap {
+		restoreForClusterMap[k][constant.MaskingRulesKeyForRestore] = string(rulesBytes)
+	}
+	bytes, err := json.Marshal(restoreForClusterMap)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
 // GetSourcePodNameFromTarget gets the source pod name from backup status target according to 'RequiredPolicyForAllPodSelection'.
 func GetSourcePodNameFromTarget(target *dpv1alpha1.BackupStatusTarget,
 	requiredPolicy *dpv1alpha1.RequiredPolicyForAllPodSelection,
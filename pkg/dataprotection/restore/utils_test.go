@@ -0,0 +1,68 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package restore
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+var _ = Describe("Data masking utils Test", func() {
+	rules := []dpv1alpha1.MaskingRule{
+		{Tables: []string{"app.users"}, Columns: []string{"email", "*_phone"}},
+		{Tables: []string{"*.orders"}, Columns: []string{"address"}, Method: dpv1alpha1.MaskingMethodHash},
+	}
+
+	It("builds the masking rules env with the default method", func() {
+		restore := &dpv1alpha1.Restore{ObjectMeta: metav1.ObjectMeta{Name: "restore"}}
+		Expect(buildMaskingRulesEnvValue(restore)).Should(BeEmpty())
+
+		restore.Spec.MaskingConfig = &dpv1alpha1.MaskingConfig{Rules: rules}
+		var envRules []dpv1alpha1.MaskingRule
+		Expect(json.Unmarshal([]byte(buildMaskingRulesEnvValue(restore)), &envRules)).Should(Succeed())
+		Expect(envRules).Should(HaveLen(2))
+		Expect(envRules[0].Method).Should(Equal(dpv1alpha1.MaskingMethodRedact))
+		Expect(envRules[1].Method).Should(Equal(dpv1alpha1.MaskingMethodHash))
+		// the rules of the restore are not mutated.
+		Expect(restore.Spec.MaskingConfig.Rules[0].Method).Should(BeEmpty())
+	})
+
+	It("sets the masking rules to the restore annotation", func() {
+		restoreAnnotation := `{"mysql":{"name":"backup","namespace":"default"}}`
+		annotation, err := SetMaskingRulesToRestoreAnnotation(restoreAnnotation, nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(annotation).Should(Equal(restoreAnnotation))
+
+		annotation, err = SetMaskingRulesToRestoreAnnotation(restoreAnnotation, rules)
+		Expect(err).ShouldNot(HaveOccurred())
+		restoreInfoMap := map[string]map[string]string{}
+		Expect(json.Unmarshal([]byte(annotation), &restoreInfoMap)).Should(Succeed())
+		Expect(restoreInfoMap["mysql"]).Should(HaveKeyWithValue(constant.BackupNameKeyForRestore, "backup"))
+		var annotationRules []dpv1alpha1.MaskingRule
+		Expect(json.Unmarshal([]byte(restoreInfoMap["mysql"][constant.MaskingRulesKeyForRestore]), &annotationRules)).Should(Succeed())
+		Expect(annotationRules).Should(Equal(rules))
+	})
+})
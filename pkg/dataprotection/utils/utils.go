@@ -289,6 +289,19 @@ func GetFirstIndexRunningPod(podList *corev1.PodList) *corev1.Pod {
 	return nil
 }
 
+// GetBackupReplicaPod gets the available pod which is designated as the backup replica.
+func GetBackupReplicaPod(podList *corev1.PodList) *corev1.Pod {
+	if podList == nil {
+		return nil
+	}
+	for i, v := range podList.Items {
+		if v.Labels[constant.BackupReplicaLabelKey] == "true" && intctrlutil.IsAvailable(&v, 0) {
+			return &podList.Items[i]
+		}
+	}
+	return nil
+}
+
 func GetPodByName(podList *corev1.PodList, name string) *corev1.Pod {
 	if podList == nil {
 		return nil
@@ -0,0 +1,113 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gateway
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Tenant is the identity of an authenticated caller, a tenant can only access the objects in its own namespace.
+type Tenant struct {
+	Name      string
+	Namespace string
+}
+
+type tenantKey struct{}
+
+// TenantFrom returns the authenticated tenant of the request.
+func TenantFrom(ctx context.Context) (*Tenant, bool) {
+	tenant, ok := ctx.Value(tenantKey{}).(*Tenant)
+	return tenant, ok
+}
+
+// TokenAuthenticator authenticates the callers by the static bearer tokens.
+type TokenAuthenticator struct {
+	tokens map[string]*Tenant
+}
+
+// NewTokenAuthenticator creates an authenticator with the tokens keyed by the token value.
+func NewTokenAuthenticator(tokens map[string]*Tenant) *TokenAuthenticator {
+	return &TokenAuthenticator{tokens: tokens}
+}
+
+// LoadTokenAuthenticator loads the tokens from a file, each line of which is in the format of "token,tenant,namespace".
+// Empty lines and lines starting with "#" are ignored.
+func LoadTokenAuthenticator(path string) (*TokenAuthenticator, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	tokens := make(map[string]*Tenant)
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if len(text) == 0 || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Split(text, ",")
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("invalid token at line %d of %s, expected format: token,tenant,namespace", line, path)
+		}
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+			if len(fields[i]) == 0 {
+				return nil, fmt.Errorf("invalid token at line %d of %s, empty field", line, path)
+			}
+		}
+		if _, ok := tokens[fields[0]]; ok {
+			return nil, fmt.Errorf("duplicated token at line %d of %s", line, path)
+		}
+		tokens[fields[0]] = &Tenant{Name: fields[1], Namespace: fields[2]}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	return NewTokenAuthenticator(tokens), nil
+}
+
+// Authenticate returns the tenant of the bearer token carried by the request.
+func (a *TokenAuthenticator) Authenticate(req *http.Request) (*Tenant, bool) {
+	auth := req.Header.Get("Authorization")
+	token, ok := strings.CutPrefix(auth, "Bearer ")
+	if !ok || len(token) == 0 {
+		return nil, false
+	}
+	tenant, ok := a.tokens[token]
+	return tenant, ok
+}
+
+// Wrap returns a handler that rejects the unauthenticated requests and passes the tenant to the next handler.
+func (a *TokenAuthenticator) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		tenant, ok := a.Authenticate(req)
+		if !ok {
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		next.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), tenantKey{}, tenant)))
+	})
+}
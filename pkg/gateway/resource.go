@@ -0,0 +1,183 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/apecloud/kubeblocks/pkg/client/clientset/versioned"
+)
+
+// maxRequestBodySize limits the size of the request body.
+const maxRequestBodySize = 1 << 20
+
+// typedClient is the subset of the methods of a namespaced typed client used by the gateway.
+type typedClient[T any, L any] interface {
+	Create(ctx context.Context, obj *T, opts metav1.CreateOptions) (*T, error)
+	Update(ctx context.Context, obj *T, opts metav1.UpdateOptions) (*T, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*T, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*L, error)
+}
+
+// resource serves the REST endpoints of a kind of objects:
+//
+//	GET    /api/v1/<resource>         list the objects
+//	POST   /api/v1/<resource>         create an object
+//	GET    /api/v1/<resource>/<name>  get an object
+//	PUT    /api/v1/<resource>/<name>  update an object, if the resource is updatable
+//	DELETE /api/v1/<resource>/<name>  delete an object
+type resource[T any, PT interface {
+	*T
+	metav1.Object
+}, L any] struct {
+	name      string
+	updatable bool
+	client    func(cli versioned.Interface, namespace string) typedClient[T, L]
+	// validate validates the object to be created or updated, e.g. it must not refer to the other namespaces.
+	validate  func(tenant *Tenant, obj PT) error
+	clientFor ClientFactory
+}
+
+func (r *resource[T, PT, L]) setClientFactory(clientFor ClientFactory) {
+	r.clientFor = clientFor
+}
+
+func (r *resource[T, PT, L]) prefix() string {
+	return fmt.Sprintf("%s/%s", apiPrefix, r.name)
+}
+
+func (r *resource[T, PT, L]) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	tenant, ok := TenantFrom(req.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	tenantCli, err := r.clientFor(tenant)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	cli := r.client(tenantCli, tenant.Namespace)
+
+	name := strings.Trim(strings.TrimPrefix(req.URL.Path, r.prefix()), "/")
+	if strings.Contains(name, "/") {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("path %s not found", req.URL.Path))
+		return
+	}
+
+	var obj any
+	switch {
+	case len(name) == 0 && req.Method == http.MethodGet:
+		obj, err = cli.List(req.Context(), metav1.ListOptions{LabelSelector: req.URL.Query().Get("labelSelector")})
+	case len(name) == 0 && req.Method == http.MethodPost:
+		var body PT
+		if body, err = r.decode(req, tenant, ""); err == nil {
+			obj, err = cli.Create(req.Context(), body, metav1.CreateOptions{})
+		}
+	case len(name) > 0 && req.Method == http.MethodGet:
+		obj, err = cli.Get(req.Context(), name, metav1.GetOptions{})
+	case len(name) > 0 && req.Method == http.MethodPut && r.updatable:
+		var body PT
+		if body, err = r.decode(req, tenant, name); err == nil {
+			obj, err = cli.Update(req.Context(), body, metav1.UpdateOptions{})
+		}
+	case len(name) > 0 && req.Method == http.MethodDelete:
+		err = cli.Delete(req.Context(), name, metav1.DeleteOptions{})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("method %s is not allowed on %s", req.Method, req.URL.Path))
+		return
+	}
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	if obj == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	status := http.StatusOK
+	if req.Method == http.MethodPost {
+		status = http.StatusCreated
+	}
+	writeJSON(w, status, obj)
+}
+
+// decode decodes the object from the request body, the object is forced into the namespace of the tenant.
+func (r *resource[T, PT, L]) decode(req *http.Request, tenant *Tenant, name string) (PT, error) {
+	data, err := io.ReadAll(io.LimitReader(req.Body, maxRequestBodySize))
+	if err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+	obj := PT(new(T))
+	if err = json.Unmarshal(data, obj); err != nil {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("invalid request body: %s", err.Error()))
+	}
+	if ns := obj.GetNamespace(); len(ns) > 0 && ns != tenant.Namespace {
+		return nil, apierrors.NewForbidden(schema.GroupResource{Resource: r.name}, obj.GetName(),
+			fmt.Errorf("namespace %s is not accessible", ns))
+	}
+	obj.SetNamespace(tenant.Namespace)
+	if len(name) > 0 {
+		if len(obj.GetName()) > 0 && obj.GetName() != name {
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("name %s in the body does not match the name %s in the path", obj.GetName(), name))
+		}
+		obj.SetName(name)
+	}
+	if r.validate != nil {
+		if err = r.validate(tenant, obj); err != nil {
+			return nil, apierrors.NewForbidden(schema.GroupResource{Resource: r.name}, obj.GetName(), err)
+		}
+	}
+	return obj, nil
+}
+
+type errorResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, obj any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(obj)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, errorResponse{Code: status, Message: msg})
+}
+
+// writeAPIError writes the error returned by the kube-apiserver, with the status code kept.
+func writeAPIError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	if apiStatus, ok := err.(apierrors.APIStatus); ok && apiStatus.Status().Code != 0 {
+		status = int(apiStatus.Status().Code)
+	}
+	writeError(w, status, err.Error())
+}
@@ -0,0 +1,209 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gateway
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/client/clientset/versioned"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+const (
+	apiPrefix   = "/api/v1"
+	healthzPath = "/healthz"
+
+	// tenantUserPrefix is the prefix of the user names impersonated for the tenants, e.g. "kubeblocks-gateway:tenant-a".
+	tenantUserPrefix = "kubeblocks-gateway:"
+	// TenantGroup is the group impersonated for all the tenants.
+	TenantGroup = "kubeblocks-gateway:tenants"
+)
+
+var logger = ctrl.Log.WithName("gateway")
+
+// Config is the configuration of the gateway server.
+type Config struct {
+	Address     string
+	TLSCertFile string
+	TLSKeyFile  string
+}
+
+// Server exposes the cluster lifecycle management of KubeBlocks as REST endpoints, so that the platforms
+// without the direct access to the kube-apiserver can integrate with KubeBlocks.
+type Server struct {
+	config Config
+	server *http.Server
+}
+
+// ClientFactory returns the typed clients to access the objects on behalf of the tenant.
+type ClientFactory func(tenant *Tenant) (versioned.Interface, error)
+
+// NewImpersonatingClientFactory returns a ClientFactory which impersonates the tenants, so that the requests
+// are authorized by the RBAC rules bound to the tenants rather than the privileges of the gateway.
+// The tenant is impersonated as the user "kubeblocks-gateway:<tenant>" in the group "kubeblocks-gateway:tenants",
+// and the gateway itself should be allowed to impersonate them.
+func NewImpersonatingClientFactory(config *rest.Config) ClientFactory {
+	var clients sync.Map
+	return func(tenant *Tenant) (versioned.Interface, error) {
+		if cli, ok := clients.Load(tenant.Name); ok {
+			return cli.(versioned.Interface), nil
+		}
+		cli, err := versioned.NewForConfig(impersonatingConfig(config, tenant))
+		if err != nil {
+			return nil, err
+		}
+		actual, _ := clients.LoadOrStore(tenant.Name, cli)
+		return actual.(versioned.Interface), nil
+	}
+}
+
+func impersonatingConfig(config *rest.Config, tenant *Tenant) *rest.Config {
+	impersonated := rest.CopyConfig(config)
+	impersonated.Impersonate = rest.ImpersonationConfig{
+		UserName: tenantUserPrefix + tenant.Name,
+		Groups:   []string{TenantGroup},
+	}
+	return impersonated
+}
+
+// NewServer creates a gateway server backed by the typed clients.
+func NewServer(config Config, clientFor ClientFactory, authenticator *TokenAuthenticator) *Server {
+	return &Server{
+		config: config,
+		server: &http.Server{
+			Addr:              config.Address,
+			Handler:           NewHandler(clientFor, authenticator),
+			ReadHeaderTimeout: 10 * time.Second,
+			TLSConfig:         &tls.Config{MinVersion: tls.VersionTLS12},
+		},
+	}
+}
+
+// NewHandler builds the HTTP handler of the gateway, all the endpoints except the health check are authenticated.
+func NewHandler(clientFor ClientFactory, authenticator *TokenAuthenticator) http.Handler {
+	resources := []interface {
+		http.Handler
+		prefix() string
+		setClientFactory(ClientFactory)
+	}{
+		&resource[appsv1alpha1.Cluster, *appsv1alpha1.Cluster, appsv1alpha1.ClusterList]{
+			name:      "clusters",
+			updatable: true,
+			validate:  validateClusterRestore,
+			client: func(cli versioned.Interface, namespace string) typedClient[appsv1alpha1.Cluster, appsv1alpha1.ClusterList] {
+				return cli.AppsV1alpha1().Clusters(namespace)
+			},
+		},
+		&resource[appsv1alpha1.OpsRequest, *appsv1alpha1.OpsRequest, appsv1alpha1.OpsRequestList]{
+			name: "opsrequests",
+			client: func(cli versioned.Interface, namespace string) typedClient[appsv1alpha1.OpsRequest, appsv1alpha1.OpsRequestList] {
+				return cli.AppsV1alpha1().OpsRequests(namespace)
+			},
+		},
+		&resource[dpv1alpha1.Backup, *dpv1alpha1.Backup, dpv1alpha1.BackupList]{
+			name: "backups",
+			client: func(cli versioned.Interface, namespace string) typedClient[dpv1alpha1.Backup, dpv1alpha1.BackupList] {
+				return cli.DataprotectionV1alpha1().Backups(namespace)
+			},
+		},
+		&resource[dpv1alpha1.Restore, *dpv1alpha1.Restore, dpv1alpha1.RestoreList]{
+			name:     "restores",
+			validate: validateRestore,
+			client: func(cli versioned.Interface, namespace string) typedClient[dpv1alpha1.Restore, dpv1alpha1.RestoreList] {
+				return cli.DataprotectionV1alpha1().Restores(namespace)
+			},
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(healthzPath, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	for _, r := range resources {
+		r.setClientFactory(clientFor)
+		handler := authenticator.Wrap(r)
+		mux.Handle(r.prefix(), handler)
+		mux.Handle(r.prefix()+"/", handler)
+	}
+	return mux
+}
+
+// StartNonBlocking starts the gateway server in a goroutine, the server is only served over TLS since
+// the bearer tokens are carried by the requests.
+func (s *Server) StartNonBlocking() error {
+	if len(s.config.TLSCertFile) == 0 || len(s.config.TLSKeyFile) == 0 {
+		return fmt.Errorf("both the TLS certificate and key files are required")
+	}
+	go func() {
+		logger.Info("starting the gateway server", "address", s.config.Address)
+		err := s.server.ListenAndServeTLS(s.config.TLSCertFile, s.config.TLSKeyFile)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error(err, "gateway server exited")
+		}
+	}()
+	return nil
+}
+
+// Shutdown gracefully shuts down the gateway server.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
+
+// validateRestore forbids restoring from the backups in the other namespaces.
+func validateRestore(tenant *Tenant, restore *dpv1alpha1.Restore) error {
+	return validateBackupNamespace(tenant, restore.Spec.Backup.Namespace)
+}
+
+// validateClusterRestore forbids creating the clusters from the backups in the other namespaces.
+func validateClusterRestore(tenant *Tenant, cluster *appsv1alpha1.Cluster) error {
+	restoreAnnotation := cluster.Annotations[constant.RestoreFromBackupAnnotationKey]
+	if len(restoreAnnotation) == 0 {
+		return nil
+	}
+	backupSources := map[string]map[string]string{}
+	if err := json.Unmarshal([]byte(restoreAnnotation), &backupSources); err != nil {
+		return fmt.Errorf("invalid annotation %s: %s", constant.RestoreFromBackupAnnotationKey, err.Error())
+	}
+	for _, source := range backupSources {
+		if err := validateBackupNamespace(tenant, source[constant.BackupNamespaceKeyForRestore]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateBackupNamespace(tenant *Tenant, namespace string) error {
+	if len(namespace) > 0 && namespace != tenant.Namespace {
+		return fmt.Errorf("the backups in namespace %s are not accessible", namespace)
+	}
+	return nil
+}
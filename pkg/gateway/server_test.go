@@ -0,0 +1,138 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gateway
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/client/clientset/versioned"
+	"github.com/apecloud/kubeblocks/pkg/client/clientset/versioned/fake"
+)
+
+func newTestHandler() http.Handler {
+	cli := fake.NewSimpleClientset(&appsv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "mycluster"},
+	})
+	authenticator := NewTokenAuthenticator(map[string]*Tenant{
+		"token-a": {Name: "a", Namespace: "tenant-a"},
+	})
+	return NewHandler(func(*Tenant) (versioned.Interface, error) {
+		return cli, nil
+	}, authenticator)
+}
+
+func doRequest(handler http.Handler, method, path, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if len(token) > 0 {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestGatewayAuthentication(t *testing.T) {
+	handler := newTestHandler()
+	if rec := doRequest(handler, http.MethodGet, "/healthz", "", ""); rec.Code != http.StatusOK {
+		t.Errorf("expected health check passed without token, but got %d", rec.Code)
+	}
+	if rec := doRequest(handler, http.MethodGet, "/api/v1/clusters", "", ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected %d without token, but got %d", http.StatusUnauthorized, rec.Code)
+	}
+	if rec := doRequest(handler, http.MethodGet, "/api/v1/clusters", "invalid", ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected %d with invalid token, but got %d", http.StatusUnauthorized, rec.Code)
+	}
+}
+
+func TestGatewayClusterLifecycle(t *testing.T) {
+	handler := newTestHandler()
+
+	rec := doRequest(handler, http.MethodPost, "/api/v1/clusters", "token-a", `{"metadata":{"name":"mycluster"},"spec":{"terminationPolicy":"Delete"}}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected %d, but got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+	cluster := &appsv1alpha1.Cluster{}
+	if err := json.Unmarshal(rec.Body.Bytes(), cluster); err != nil || cluster.Namespace != "tenant-a" {
+		t.Fatalf("expected the cluster created in the namespace of tenant, but got %s, error: %v", cluster.Namespace, err)
+	}
+
+	rec = doRequest(handler, http.MethodPost, "/api/v1/clusters", "token-a", `{"metadata":{"name":"c2","namespace":"other"}}`)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected %d when creating in other namespace, but got %d", http.StatusForbidden, rec.Code)
+	}
+
+	rec = doRequest(handler, http.MethodGet, "/api/v1/clusters", "token-a", "")
+	list := &appsv1alpha1.ClusterList{}
+	if err := json.Unmarshal(rec.Body.Bytes(), list); err != nil || len(list.Items) != 1 {
+		t.Fatalf("expected only the clusters of tenant listed, but got %d, error: %v", len(list.Items), err)
+	}
+
+	rec = doRequest(handler, http.MethodPut, "/api/v1/clusters/mycluster", "token-a", `{"spec":{"terminationPolicy":"WipeOut"}}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected %d, but got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	if rec = doRequest(handler, http.MethodPut, "/api/v1/opsrequests/myops", "token-a", `{}`); rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected %d when updating the opsrequest, but got %d", http.StatusMethodNotAllowed, rec.Code)
+	}
+
+	if rec = doRequest(handler, http.MethodDelete, "/api/v1/clusters/mycluster", "token-a", ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("expected %d, but got %d: %s", http.StatusNoContent, rec.Code, rec.Body.String())
+	}
+	if rec = doRequest(handler, http.MethodGet, "/api/v1/clusters/mycluster", "token-a", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("expected %d after deleted, but got %d", http.StatusNotFound, rec.Code)
+	}
+}
+
+func TestGatewayCrossNamespaceRestore(t *testing.T) {
+	handler := newTestHandler()
+
+	rec := doRequest(handler, http.MethodPost, "/api/v1/restores", "token-a",
+		`{"metadata":{"name":"r1"},"spec":{"backup":{"name":"b1","namespace":"other"}}}`)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected %d when restoring from other namespace, but got %d", http.StatusForbidden, rec.Code)
+	}
+	rec = doRequest(handler, http.MethodPost, "/api/v1/restores", "token-a",
+		`{"metadata":{"name":"r2"},"spec":{"backup":{"name":"b1","namespace":"tenant-a"}}}`)
+	if rec.Code != http.StatusCreated {
+		t.Errorf("expected %d, but got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+
+	rec = doRequest(handler, http.MethodPost, "/api/v1/clusters", "token-a",
+		`{"metadata":{"name":"c1","annotations":{"kubeblocks.io/restore-from-backup":"{\"mysql\":{\"name\":\"b1\",\"namespace\":\"other\"}}"}}}`)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected %d when creating the cluster from other namespace, but got %d", http.StatusForbidden, rec.Code)
+	}
+}
+
+func TestImpersonatingConfig(t *testing.T) {
+	config := impersonatingConfig(&rest.Config{Host: "https://kubernetes"}, &Tenant{Name: "a", Namespace: "tenant-a"})
+	if config.Impersonate.UserName != "kubeblocks-gateway:a" || len(config.Impersonate.Groups) != 1 || config.Impersonate.Groups[0] != TenantGroup {
+		t.Errorf("unexpected impersonation: %+v", config.Impersonate)
+	}
+}
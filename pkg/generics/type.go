@@ -88,6 +88,12 @@ var VolumeSnapshotClassSignature = func(_ snapshotv1.VolumeSnapshotClass, _ *sna
 
 var ClusterSignature = func(_ appsv1alpha1.Cluster, _ *appsv1alpha1.Cluster, _ appsv1alpha1.ClusterList, _ *appsv1alpha1.ClusterList) {
 }
+var ClusterSetSignature = func(_ appsv1alpha1.ClusterSet, _ *appsv1alpha1.ClusterSet, _ appsv1alpha1.ClusterSetList, _ *appsv1alpha1.ClusterSetList) {
+}
+var ClusterPeeringSignature = func(_ appsv1alpha1.ClusterPeering, _ *appsv1alpha1.ClusterPeering, _ appsv1alpha1.ClusterPeeringList, _ *appsv1alpha1.ClusterPeeringList) {
+}
+var NamespacePolicySignature = func(_ appsv1alpha1.NamespacePolicy, _ *appsv1alpha1.NamespacePolicy, _ appsv1alpha1.NamespacePolicyList, _ *appsv1alpha1.NamespacePolicyList) {
+}
 var ClusterDefinitionSignature = func(_ appsv1alpha1.ClusterDefinition, _ *appsv1alpha1.ClusterDefinition, _ appsv1alpha1.ClusterDefinitionList, _ *appsv1alpha1.ClusterDefinitionList) {
 }
 var ComponentSignature = func(appsv1alpha1.Component, *appsv1alpha1.Component, appsv1alpha1.ComponentList, *appsv1alpha1.ComponentList) {
@@ -96,6 +102,12 @@ var ComponentDefinitionSignature = func(appsv1alpha1.ComponentDefinition, *appsv
 }
 var ComponentVersionSignature = func(appsv1alpha1.ComponentVersion, *appsv1alpha1.ComponentVersion, appsv1alpha1.ComponentVersionList, *appsv1alpha1.ComponentVersionList) {
 }
+var DatabaseSignature = func(_ appsv1alpha1.Database, _ *appsv1alpha1.Database, _ appsv1alpha1.DatabaseList, _ *appsv1alpha1.DatabaseList) {
+}
+var DatabaseUserSignature = func(_ appsv1alpha1.DatabaseUser, _ *appsv1alpha1.DatabaseUser, _ appsv1alpha1.DatabaseUserList, _ *appsv1alpha1.DatabaseUserList) {
+}
+var ExternalOpsHandlerSignature = func(_ appsv1alpha1.ExternalOpsHandler, _ *appsv1alpha1.ExternalOpsHandler, _ appsv1alpha1.ExternalOpsHandlerList, _ *appsv1alpha1.ExternalOpsHandlerList) {
+}
 var OpsDefinitionSignature = func(_ appsv1alpha1.OpsDefinition, _ *appsv1alpha1.OpsDefinition, _ appsv1alpha1.OpsDefinitionList, _ *appsv1alpha1.OpsDefinitionList) {
 }
 var OpsRequestSignature = func(_ appsv1alpha1.OpsRequest, _ *appsv1alpha1.OpsRequest, _ appsv1alpha1.OpsRequestList, _ *appsv1alpha1.OpsRequestList) {
@@ -21,11 +21,17 @@ package gotemplate
 
 import (
 	"fmt"
+	"math/big"
+	"net"
+	"net/netip"
 	"regexp"
 	"strconv"
+	"strings"
 
+	"github.com/spf13/cast"
 	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	cfgcore "github.com/apecloud/kubeblocks/pkg/configuration/core"
 )
@@ -34,6 +40,10 @@ const (
 	GoTemplateLibraryAnnotationKey = "config.kubeblocks.io/go-template-library"
 )
 
+// LibraryVersion is the version of the built-in function library, it is bumped when functions are added or changed,
+// templates can check it with 'libraryVersion' before using the newer functions.
+const LibraryVersion = "v1"
+
 func isSystemFuncsCM(cm *corev1.ConfigMap) bool {
 	if len(cm.Annotations) == 0 {
 		return false
@@ -82,3 +92,105 @@ func fromYAMLArray(str string) ([]interface{}, error) {
 	err := yaml.Unmarshal([]byte(str), &a)
 	return a, err
 }
+
+// parseQuantity parses a quantity, e.g. "2Gi" or "500m", and returns its value in the base unit, rounded up.
+func parseQuantity(v interface{}) (int64, error) {
+	if str, ok := v.(string); ok {
+		q, err := resource.ParseQuantity(strings.TrimSpace(str))
+		if err != nil {
+			return 0, err
+		}
+		return q.Value(), nil
+	}
+	return cast.ToInt64E(v)
+}
+
+// percentOf returns the percent of the value, the value can be a quantity or a number, e.g. the memory limit.
+func percentOf(v interface{}, percent interface{}) (int64, error) {
+	value, err := parseQuantity(v)
+	if err != nil {
+		return 0, err
+	}
+	p, err := cast.ToFloat64E(percent)
+	if err != nil {
+		return 0, err
+	}
+	if p < 0 {
+		return 0, cfgcore.MakeError("invalid percent: %v", percent)
+	}
+	return int64(float64(value) * p / 100), nil
+}
+
+// formatQuantity formats the value with the binary suffixes, e.g. 2147483648 to "2Gi".
+func formatQuantity(v interface{}) (string, error) {
+	value, err := parseQuantity(v)
+	if err != nil {
+		return "", err
+	}
+	return resource.NewQuantity(value, resource.BinarySI).String(), nil
+}
+
+// cidrContains checks whether the ip is in the cidr.
+func cidrContains(cidr string, ip string) (bool, error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return false, err
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false, err
+	}
+	return prefix.Contains(addr), nil
+}
+
+// cidrHost returns the nth address of the cidr, e.g. cidrHost "10.0.0.0/24" 1 returns "10.0.0.1".
+func cidrHost(cidr string, n interface{}) (string, error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return "", err
+	}
+	index, err := cast.ToInt64E(n)
+	if err != nil {
+		return "", err
+	}
+	if index < 0 {
+		return "", cfgcore.MakeError("invalid host number: %d", index)
+	}
+	base := prefix.Masked().Addr()
+	value := new(big.Int).Add(new(big.Int).SetBytes(base.AsSlice()), big.NewInt(index))
+	b := value.Bytes()
+	if len(b) > base.BitLen()/8 {
+		return "", cfgcore.MakeError("host number %d is out of the cidr: %s", index, cidr)
+	}
+	raw := make([]byte, base.BitLen()/8)
+	copy(raw[len(raw)-len(b):], b)
+	addr, _ := netip.AddrFromSlice(raw)
+	if !prefix.Contains(addr) {
+		return "", cfgcore.MakeError("host number %d is out of the cidr: %s", index, cidr)
+	}
+	return addr.String(), nil
+}
+
+// isIPv6 checks whether the ip is an IPv6 address.
+func isIPv6(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	return err == nil && addr.Is6() && !addr.Is4In6()
+}
+
+// joinEndpoints joins the hosts with the port into the endpoints, e.g. joinEndpoints (list "a" "b") 3306 ","
+// returns "a:3306,b:3306", the IPv6 hosts are bracketed.
+func joinEndpoints(hosts interface{}, port interface{}, sep string) (string, error) {
+	hostList, err := cast.ToStringSliceE(hosts)
+	if err != nil {
+		return "", err
+	}
+	portStr, err := cast.ToStringE(port)
+	if err != nil {
+		return "", err
+	}
+	endpoints := make([]string, 0, len(hostList))
+	for _, host := range hostList {
+		endpoints = append(endpoints, net.JoinHostPort(host, portStr))
+	}
+	return strings.Join(endpoints, sep), nil
+}
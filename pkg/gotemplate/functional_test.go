@@ -165,3 +165,75 @@ func Test_fromYAMLArray(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseQuantity(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    int64
+		wantErr bool
+	}{
+		{name: "binary", value: "2Gi", want: 2 * 1024 * 1024 * 1024},
+		{name: "decimal", value: "1k", want: 1000},
+		{name: "milli", value: "500m", want: 1},
+		{name: "number", value: int64(1024), want: 1024},
+		{name: "invalid", value: "2GB", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseQuantity(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseQuantity() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("parseQuantity() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_percentOfAndFormatQuantity(t *testing.T) {
+	got, err := percentOf("4Gi", 75)
+	if err != nil || got != 3*1024*1024*1024 {
+		t.Errorf("percentOf() got = %v, err = %v", got, err)
+	}
+	if _, err := percentOf("4Gi", -1); err == nil {
+		t.Errorf("percentOf() expects error for negative percent")
+	}
+	formatted, err := formatQuantity(got)
+	if err != nil || formatted != "3Gi" {
+		t.Errorf("formatQuantity() got = %v, err = %v", formatted, err)
+	}
+}
+
+func Test_cidrFunctions(t *testing.T) {
+	if ok, err := cidrContains("10.0.0.0/24", "10.0.0.8"); err != nil || !ok {
+		t.Errorf("cidrContains() got = %v, err = %v", ok, err)
+	}
+	if ok, err := cidrContains("10.0.0.0/24", "10.0.1.8"); err != nil || ok {
+		t.Errorf("cidrContains() got = %v, err = %v", ok, err)
+	}
+	if host, err := cidrHost("10.0.0.0/24", 10); err != nil || host != "10.0.0.10" {
+		t.Errorf("cidrHost() got = %v, err = %v", host, err)
+	}
+	if host, err := cidrHost("fd00::/64", 1); err != nil || host != "fd00::1" {
+		t.Errorf("cidrHost() got = %v, err = %v", host, err)
+	}
+	if _, err := cidrHost("10.0.0.0/24", 256); err == nil {
+		t.Errorf("cidrHost() expects error for the host out of the cidr")
+	}
+	if !isIPv6("fd00::1") || isIPv6("10.0.0.1") || isIPv6("invalid") {
+		t.Errorf("isIPv6() got unexpected result")
+	}
+}
+
+func Test_joinEndpoints(t *testing.T) {
+	got, err := joinEndpoints([]interface{}{"mysql-0.mysql-headless", "fd00::1"}, 3306, ",")
+	if err != nil {
+		t.Errorf("joinEndpoints() error = %v", err)
+	}
+	if want := "mysql-0.mysql-headless:3306,[fd00::1]:3306"; got != want {
+		t.Errorf("joinEndpoints() got = %v, want %v", got, want)
+	}
+}
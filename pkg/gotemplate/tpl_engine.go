@@ -48,6 +48,15 @@ const (
 	goTemplateExtendBuildInRegexSubString      = "regexStringSubmatch"
 	goTemplateExtendBuildInFromYamlString      = "fromYaml"
 	goTemplateExtendBuildInFromYamlArrayString = "fromYamlArray"
+
+	goTemplateExtendBuildInLibraryVersion = "libraryVersion"
+	goTemplateExtendBuildInParseQuantity  = "parseQuantity"
+	goTemplateExtendBuildInPercentOf      = "percentOf"
+	goTemplateExtendBuildInFormatQuantity = "formatQuantity"
+	goTemplateExtendBuildInCIDRContains   = "cidrContains"
+	goTemplateExtendBuildInCIDRHost       = "cidrHost"
+	goTemplateExtendBuildInIsIPv6         = "isIPv6"
+	goTemplateExtendBuildInJoinEndpoints  = "joinEndpoints"
 )
 
 type TplValues map[string]interface{}
@@ -200,6 +209,16 @@ func (t *TplEngine) initSystemFunMap(funcs template.FuncMap) {
 	funcs[goTemplateExtendBuildInFromYamlString] = fromYAML
 	funcs[goTemplateExtendBuildInFromYamlArrayString] = fromYAMLArray
 
+	// Unit math, network and endpoint helpers, see LibraryVersion.
+	funcs[goTemplateExtendBuildInLibraryVersion] = func() string { return LibraryVersion }
+	funcs[goTemplateExtendBuildInParseQuantity] = parseQuantity
+	funcs[goTemplateExtendBuildInPercentOf] = percentOf
+	funcs[goTemplateExtendBuildInFormatQuantity] = formatQuantity
+	funcs[goTemplateExtendBuildInCIDRContains] = cidrContains
+	funcs[goTemplateExtendBuildInCIDRHost] = cidrHost
+	funcs[goTemplateExtendBuildInIsIPv6] = isIPv6
+	funcs[goTemplateExtendBuildInJoinEndpoints] = joinEndpoints
+
 	t.tpl.Option(DefaultTemplateOps)
 	t.tpl.Funcs(funcs)
 }
@@ -0,0 +1,197 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/kb_agent/util"
+)
+
+// ErrActionFailed indicates that the action is executed by the kb-agent but failed,
+// as opposed to the errors to reach the kb-agent.
+var ErrActionFailed = errors.New("ActionFailed")
+
+// The errors of the failed actions interpreted from the error codes returned by the kb-agent,
+// they all wrap ErrActionFailed.
+var (
+	// ErrActionTimeout indicates that the action is killed because it doesn't complete in time.
+	ErrActionTimeout = fmt.Errorf("%w: Timeout", ErrActionFailed)
+	// ErrActionSandboxViolated indicates that the action is killed or refused by the sandbox of the action,
+	// e.g. it exceeds the resource limits, which fails again if retried as it is.
+	ErrActionSandboxViolated = fmt.Errorf("%w: SandboxViolated", ErrActionFailed)
+)
+
+// ErrRefreshNotSupported indicates that the kb-agent is of an earlier version, which can't be refreshed.
+var ErrRefreshNotSupported = errors.New("RefreshNotSupported")
+
+// Client invokes the actions served by the kb-agent of a pod.
+type Client interface {
+	// Action invokes the action with the parameters, and returns the output of the action.
+	Action(ctx context.Context, action string, parameters map[string]any) (string, error)
+
+	// Refresh replaces the actions and the probes served by the kb-agent, without restarting the pod.
+	Refresh(ctx context.Context, handlers map[string]util.HandlerSpec) error
+}
+
+var (
+	mockClient      Client
+	mockClientError error
+)
+
+func SetMockClient(cli Client, err error) {
+	mockClient = cli
+	mockClientError = err
+}
+
+func UnsetMockClient() {
+	mockClient = nil
+	mockClientError = nil
+}
+
+// NewClient returns the client of the kb-agent of the pod, it returns nil if the pod doesn't run the kb-agent.
+func NewClient(pod corev1.Pod) (Client, error) {
+	if mockClient != nil || mockClientError != nil {
+		return mockClient, mockClientError
+	}
+	port, err := intctrlutil.GetPortByPortName(pod.Spec.Containers, constant.KBAgentHTTPPortName)
+	if err != nil {
+		// return Client as nil explicitly to indicate that Client interface is nil.
+		return nil, nil
+	}
+	if pod.Status.PodIP == "" {
+		return nil, fmt.Errorf("pod %v has no ip", pod.Name)
+	}
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+	}
+	host := net.JoinHostPort(pod.Status.PodIP, fmt.Sprint(port))
+	return &httpClient{
+		client: &http.Client{
+			Timeout:   time.Minute,
+			Transport: &http.Transport{Dial: dialer.Dial},
+		},
+		url:        fmt.Sprintf("http://%s/%s%s", host, util.Version, util.Path),
+		refreshURL: fmt.Sprintf("http://%s/%s/%s", host, util.Version, util.RefreshPath),
+	}, nil
+}
+
+type httpClient struct {
+	client     *http.Client
+	url        string
+	refreshURL string
+}
+
+var _ Client = &httpClient{}
+
+type actionRequest struct {
+	Action     string         `json:"action"`
+	Parameters map[string]any `json:"parameters,omitempty"`
+}
+
+type actionResponse struct {
+	Message   string `json:"message"`
+	ErrorCode string `json:"errorCode"`
+}
+
+func (cli *httpClient) Action(ctx context.Context, action string, parameters map[string]any) (string, error) {
+	body, err := json.Marshal(actionRequest{Action: action, Parameters: parameters})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cli.url, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", util.JSONContentTypeHeader)
+	resp, err := cli.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	result := &actionResponse{}
+	if len(data) > 0 {
+		if err = json.Unmarshal(data, result); err != nil {
+			return "", err
+		}
+	}
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return result.Message, nil
+	case http.StatusInternalServerError, http.StatusNotImplemented:
+		return "", errors.Wrapf(actionError(result.ErrorCode), "action %s: %s %s", action, result.ErrorCode, result.Message)
+	default:
+		return "", fmt.Errorf("invoke action %s failed with status %d: %s", action, resp.StatusCode, result.Message)
+	}
+}
+
+// actionError interprets the error code of the failed action, see the error codes of the kb-agent httpserver.
+func actionError(errorCode string) error {
+	switch errorCode {
+	case "ERR_ACTION_TIMEOUT":
+		return ErrActionTimeout
+	case "ERR_ACTION_CPU_LIMIT_EXCEEDED", "ERR_ACTION_MEMORY_LIMIT_EXCEEDED", "ERR_ACTION_RUN_AS_ROOT":
+		return ErrActionSandboxViolated
+	default:
+		return ErrActionFailed
+	}
+}
+
+func (cli *httpClient) Refresh(ctx context.Context, handlers map[string]util.HandlerSpec) error {
+	body, err := json.Marshal(util.RefreshRequest{Handlers: handlers})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cli.refreshURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", util.JSONContentTypeHeader)
+	resp, err := cli.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	case http.StatusNotFound, http.StatusMethodNotAllowed:
+		return ErrRefreshNotSupported
+	default:
+		data, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("refresh kb-agent failed with status %d: %s", resp.StatusCode, string(data))
+	}
+}
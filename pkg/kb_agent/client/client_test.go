@@ -0,0 +1,120 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/apecloud/kubeblocks/pkg/kb_agent/util"
+)
+
+func TestNewClient(t *testing.T) {
+	pod := corev1.Pod{}
+	cli, err := NewClient(pod)
+	assert.Nil(t, err)
+	assert.Nil(t, cli)
+}
+
+func TestAction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := &actionRequest{}
+		_ = json.NewDecoder(r.Body).Decode(req)
+		switch req.Action {
+		case "success":
+			_, _ = w.Write([]byte(`{"message":"done"}`))
+		case "empty":
+			w.WriteHeader(http.StatusNoContent)
+		case "failed":
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"errorCode":"ERR_ACTION_FAILED","message":"exit status 1"}`))
+		case "timeout":
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"errorCode":"ERR_ACTION_TIMEOUT","message":"action timed out"}`))
+		case "oom":
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"errorCode":"ERR_ACTION_MEMORY_LIMIT_EXCEEDED","message":"action exceeded the memory limit"}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errorCode":"ERR_MALFORMED_REQUEST_DATA","message":"no action in request"}`))
+		}
+	}))
+	defer server.Close()
+	cli := &httpClient{client: server.Client(), url: server.URL}
+
+	output, err := cli.Action(context.Background(), "success", map[string]any{"key": "value"})
+	assert.Nil(t, err)
+	assert.Equal(t, "done", output)
+
+	output, err = cli.Action(context.Background(), "empty", nil)
+	assert.Nil(t, err)
+	assert.Empty(t, output)
+
+	_, err = cli.Action(context.Background(), "failed", nil)
+	assert.True(t, errors.Is(err, ErrActionFailed))
+	assert.False(t, errors.Is(err, ErrActionTimeout))
+
+	_, err = cli.Action(context.Background(), "timeout", nil)
+	assert.True(t, errors.Is(err, ErrActionFailed))
+	assert.True(t, errors.Is(err, ErrActionTimeout))
+
+	_, err = cli.Action(context.Background(), "oom", nil)
+	assert.True(t, errors.Is(err, ErrActionFailed))
+	assert.True(t, errors.Is(err, ErrActionSandboxViolated))
+
+	_, err = cli.Action(context.Background(), "", nil)
+	assert.NotNil(t, err)
+	assert.False(t, errors.Is(err, ErrActionFailed))
+}
+
+func TestRefresh(t *testing.T) {
+	var received map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/refresh":
+			_ = json.NewDecoder(r.Body).Decode(&received)
+			w.WriteHeader(http.StatusNoContent)
+		case "/malformed":
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cli := &httpClient{client: server.Client(), refreshURL: server.URL + "/refresh"}
+	assert.Nil(t, cli.Refresh(context.Background(), map[string]util.HandlerSpec{"roleProbe": {Command: []string{"echo"}}}))
+	assert.Contains(t, received["handlers"], "roleProbe")
+
+	cli.refreshURL = server.URL + "/malformed"
+	err := cli.Refresh(context.Background(), nil)
+	assert.NotNil(t, err)
+	assert.False(t, errors.Is(err, ErrRefreshNotSupported))
+
+	cli.refreshURL = server.URL + "/legacy"
+	assert.True(t, errors.Is(cli.Refresh(context.Background(), nil), ErrRefreshNotSupported))
+}
@@ -43,6 +43,7 @@ type CommonJob struct {
 	FailedCount      int
 	ReportFrequency  int
 	Do               func() error
+	stopCh           chan struct{}
 }
 
 func NewJob(name string, cronJob *util.CronJob) (Job, error) {
@@ -53,6 +54,7 @@ func NewJob(name string, cronJob *util.CronJob) (Job, error) {
 		SuccessThreshold: 1,
 		FailureThreshold: 3,
 		ReportFrequency:  60,
+		stopCh:           make(chan struct{}),
 	}
 
 	if cronJob.PeriodSeconds != 0 {
@@ -81,7 +83,12 @@ func NewJob(name string, cronJob *util.CronJob) (Job, error) {
 func (job *CommonJob) Start() {
 	job.Ticker = time.NewTicker(time.Duration(job.PeriodSeconds) * time.Second)
 	defer job.Ticker.Stop()
-	for range job.Ticker.C {
+	for {
+		select {
+		case <-job.stopCh:
+			return
+		case <-job.Ticker.C:
+		}
 		err := job.Do()
 		if err != nil {
 			logger.Info("Failed to run job", "name", job.Name, "error", err.Error())
@@ -101,6 +108,17 @@ func (job *CommonJob) Start() {
 	}
 }
 
+// Stop stops the job, the job can't be started again once it's stopped.
 func (job *CommonJob) Stop() {
-	job.Ticker.Stop()
+	if job.Ticker != nil {
+		job.Ticker.Stop()
+	}
+	if job.stopCh == nil {
+		return
+	}
+	select {
+	case <-job.stopCh:
+	default:
+		close(job.stopCh)
+	}
 }
@@ -20,19 +20,28 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package cronjobs
 
 import (
+	"sync"
+
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/apecloud/kubeblocks/pkg/kb_agent/handlers"
+	"github.com/apecloud/kubeblocks/pkg/kb_agent/util"
 )
 
 type Manager struct {
 	Jobs map[string]Job
+	lock sync.Mutex
 }
 
 var logger = ctrl.Log.WithName("cronjobs")
 
 func NewManager() (*Manager, error) {
-	actionHandlers := handlers.GetHandlerSpecs()
+	return &Manager{
+		Jobs: buildJobs(handlers.GetHandlerSpecs()),
+	}, nil
+}
+
+func buildJobs(actionHandlers map[string]util.HandlerSpec) map[string]Job {
 	jobs := make(map[string]Job)
 	for name, handler := range actionHandlers {
 		if handler.CronJob == nil {
@@ -46,12 +55,25 @@ func NewManager() (*Manager, error) {
 		}
 		jobs[name] = job
 	}
-	return &Manager{
-		Jobs: jobs,
-	}, nil
+	return jobs
 }
 
 func (m *Manager) Start() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for _, job := range m.Jobs {
+		go job.Start()
+	}
+}
+
+// Refresh stops the running jobs and starts the jobs of the refreshed handler specs.
+func (m *Manager) Refresh(actionHandlers map[string]util.HandlerSpec) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for _, job := range m.Jobs {
+		job.Stop()
+	}
+	m.Jobs = buildJobs(actionHandlers)
 	for _, job := range m.Jobs {
 		go job.Start()
 	}
@@ -54,3 +54,23 @@ func TestNewManager(t *testing.T) {
 		assert.Equal(t, 1, len(manager.Jobs))
 	})
 }
+
+func TestManagerRefresh(t *testing.T) {
+	manager := &Manager{Jobs: map[string]Job{}}
+	manager.Refresh(map[string]util.HandlerSpec{
+		constant.RoleProbeAction: {
+			CronJob: &util.CronJob{PeriodSeconds: 1},
+		},
+		"test": {},
+	})
+	assert.Equal(t, 1, len(manager.Jobs))
+	job := manager.Jobs[constant.RoleProbeAction]
+
+	manager.Refresh(map[string]util.HandlerSpec{"test": {}})
+	assert.Equal(t, 0, len(manager.Jobs))
+	select {
+	case <-job.(*CheckRoleJob).stopCh:
+	default:
+		t.Error("the job of the removed probe is not stopped")
+	}
+}
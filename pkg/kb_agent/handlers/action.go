@@ -23,6 +23,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
@@ -34,6 +36,9 @@ import (
 )
 
 var actionHandlerSpecs = map[string]util.HandlerSpec{}
+var actionHandlerSpecsLock sync.RWMutex
+var refreshLock sync.Mutex
+var refreshHooks []func(map[string]util.HandlerSpec)
 var execHandler *ExecHandler
 var grpcHandler *GRPCHandler
 var defaultHandler Handler
@@ -66,19 +71,70 @@ func InitHandlers() error {
 }
 
 func GetHandlerSpecs() map[string]util.HandlerSpec {
+	actionHandlerSpecsLock.RLock()
+	defer actionHandlerSpecsLock.RUnlock()
 	return actionHandlerSpecs
 }
 
+// GetActionSpecs returns the specs of all the actions served by the kb-agent, including the built-in actions
+// which are not overridden by the registered handlers.
+func GetActionSpecs() map[string]util.HandlerSpec {
+	actionHandlerSpecsLock.RLock()
+	defer actionHandlerSpecsLock.RUnlock()
+	specs := make(map[string]util.HandlerSpec, len(actionHandlerSpecs)+len(builtinActions))
+	for action, builtin := range builtinActions {
+		specs[action] = util.HandlerSpec{Parameters: builtin.parameters}
+	}
+	for action, spec := range actionHandlerSpecs {
+		specs[action] = spec
+	}
+	return specs
+}
+
 func ResetHandlerSpecs() {
+	actionHandlerSpecsLock.Lock()
+	defer actionHandlerSpecsLock.Unlock()
 	actionHandlerSpecs = map[string]util.HandlerSpec{}
 }
 
+// RegisterRefreshHook registers the hook which is called with the new handler specs after they are refreshed,
+// e.g. to restart the cron jobs of the probes.
+func RegisterRefreshHook(hook func(map[string]util.HandlerSpec)) {
+	refreshHooks = append(refreshHooks, hook)
+}
+
+// RefreshHandlers replaces the handler specs of the actions and the probes, the actions in progress
+// are not affected, and the later calls are served by the new specs.
+func RefreshHandlers(specs map[string]util.HandlerSpec) error {
+	if len(specs) == 0 {
+		return errors.New("action handlers is not specified")
+	}
+	// serialize the refreshes, so that the hooks see the specs in the same order as they are refreshed
+	refreshLock.Lock()
+	defer refreshLock.Unlock()
+
+	actionHandlerSpecsLock.Lock()
+	actionHandlerSpecs = specs
+	actionHandlerSpecsLock.Unlock()
+	logger.Info("action handlers refreshed", "actions", len(specs))
+
+	for _, hook := range refreshHooks {
+		hook(specs)
+	}
+	return nil
+}
+
 func Do(ctx context.Context, action string, args map[string]any) (*Response, error) {
 	if action == "" {
 		return nil, errors.New("action is empty")
 	}
+	actionHandlerSpecsLock.RLock()
 	handlerSpec, ok := actionHandlerSpecs[action]
+	actionHandlerSpecsLock.RUnlock()
 	if !ok {
+		if builtin, ok := builtinActions[action]; ok {
+			return builtin.handler(ctx, args)
+		}
 		return nil, errors.New("action handler spec not found")
 	}
 
@@ -88,6 +144,18 @@ func Do(ctx context.Context, action string, args map[string]any) (*Response, err
 	}
 
 	resp, err := handler.Do(ctx, handlerSpec, args)
+	for retries := 0; err != nil && needRetry(handlerSpec, err, retries); retries++ {
+		logger.Info("action exec failed, retry it", "action", action, "retries", retries+1, "error", err.Error())
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(retryInterval(handlerSpec)):
+		}
+		if err = util.ResetOutput(ctx); err != nil {
+			break
+		}
+		resp, err = handler.Do(ctx, handlerSpec, args)
+	}
 	if err != nil {
 		logger.Info("action exec failed", "action", action, "handler spec", handlerSpec, "error", err.Error())
 		return nil, err
@@ -96,6 +164,30 @@ func Do(ctx context.Context, action string, args map[string]any) (*Response, err
 	return resp, nil
 }
 
+// needRetry checks whether the failed action should be retried according to its retry policy.
+func needRetry(handlerSpec util.HandlerSpec, err error, retries int) bool {
+	if handlerSpec.RetryPolicy == nil || retries >= handlerSpec.RetryPolicy.MaxRetries {
+		return false
+	}
+	// it makes no sense to retry the action which is not implemented, not allowed to run, killed by the sandbox,
+	// or whose output exceeds the max artifact size.
+	return !errors.Is(err, ErrNotImplemented) && !errors.Is(err, util.ErrActionRunAsRoot) &&
+		!errors.Is(err, util.ErrActionCPULimitExceeded) && !errors.Is(err, util.ErrActionMemoryLimitExceeded) &&
+		!errors.Is(err, util.ErrArtifactTooLarge)
+}
+
+// minRetryInterval is the minimum interval between the retries of an action, to avoid the hot loop of the retries.
+var minRetryInterval = time.Second
+
+// retryInterval returns the interval to wait before retrying the failed action.
+func retryInterval(handlerSpec util.HandlerSpec) time.Duration {
+	interval := time.Duration(handlerSpec.RetryPolicy.RetryIntervalSeconds) * time.Second
+	if interval < minRetryInterval {
+		return minRetryInterval
+	}
+	return interval
+}
+
 func GetHandler(handlerSpec util.HandlerSpec) Handler {
 	if len(handlerSpec.Command) != 0 {
 		return execHandler
@@ -23,6 +23,7 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
@@ -87,6 +88,55 @@ func TestDo(t *testing.T) {
 		assert.Equal(t, "execution failed", err.Error())
 	})
 
+	t.Run("action exec retry", func(t *testing.T) {
+		actionHandlerSpecs["action2"] = util.HandlerSpec{
+			RetryPolicy: &util.RetryPolicy{MaxRetries: 2},
+		}
+		defer delete(actionHandlerSpecs, "action2")
+		interval := minRetryInterval
+		minRetryInterval = 10 * time.Millisecond
+		defer func() { minRetryInterval = interval }()
+
+		calls := 0
+		handler := &MockHandler{}
+		handler.DoFunc = func(ctx context.Context, handlerSpec util.HandlerSpec, args map[string]interface{}) (*Response, error) {
+			calls++
+			if calls < 3 {
+				return nil, errors.New("execution failed")
+			}
+			return &Response{Message: "success"}, nil
+		}
+		SetDefaultHandler(handler)
+
+		resp, err := Do(ctx, "action2", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "success", resp.Message)
+		assert.Equal(t, 3, calls)
+
+		calls = 0
+		handler.DoFunc = func(ctx context.Context, handlerSpec util.HandlerSpec, args map[string]interface{}) (*Response, error) {
+			calls++
+			return nil, util.ErrActionRunAsRoot
+		}
+		_, err = Do(ctx, "action2", nil)
+		assert.ErrorIs(t, err, util.ErrActionRunAsRoot)
+		assert.Equal(t, 1, calls)
+
+		calls = 0
+		handler.DoFunc = func(ctx context.Context, handlerSpec util.HandlerSpec, args map[string]interface{}) (*Response, error) {
+			calls++
+			return nil, errors.Wrap(util.ErrActionMemoryLimitExceeded, "ExecHandler executes action failed")
+		}
+		_, err = Do(ctx, "action2", nil)
+		assert.ErrorIs(t, err, util.ErrActionMemoryLimitExceeded)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("action retry interval", func(t *testing.T) {
+		assert.Equal(t, minRetryInterval, retryInterval(util.HandlerSpec{RetryPolicy: &util.RetryPolicy{MaxRetries: 1}}))
+		assert.Equal(t, 5*time.Second, retryInterval(util.HandlerSpec{RetryPolicy: &util.RetryPolicy{MaxRetries: 1, RetryIntervalSeconds: 5}}))
+	})
+
 	t.Run("action exec success", func(t *testing.T) {
 		actionHandlerSpecs := map[string]util.HandlerSpec{
 			"action1": {},
@@ -0,0 +1,120 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/apecloud/kubeblocks/pkg/kb_agent/util"
+)
+
+// DiskUsageAction is the built-in action reporting the usage of the volumes mounted in the pod,
+// it is used to show the usage of the PVCs before expanding them.
+const DiskUsageAction = "df"
+
+type builtinAction struct {
+	handler    func(ctx context.Context, args map[string]any) (*Response, error)
+	parameters map[string]util.ParameterSpec
+}
+
+// builtinActions are the actions implemented by the kb-agent itself, an action handler registered with
+// the same name takes precedence.
+var builtinActions = map[string]builtinAction{
+	DiskUsageAction: {
+		handler: diskUsage,
+		parameters: map[string]util.ParameterSpec{
+			"volumes": {
+				Type:        "object",
+				Description: "the paths the PVCs are mounted at in the kb-agent container, keyed by the PVC name",
+				Required:    true,
+			},
+		},
+	},
+}
+
+// VolumeUsage is the usage of the file system of a mounted volume, in bytes.
+type VolumeUsage struct {
+	Path      string `json:"path"`
+	Capacity  int64  `json:"capacity"`
+	Used      int64  `json:"used"`
+	Available int64  `json:"available"`
+}
+
+// diskUsage reports the usage of the volumes given by the "volumes" parameter, which maps the name of
+// each PVC to the path it is mounted at in the kb-agent container. The usages are keyed by the PVC name,
+// and the volumes that are not mounted at the given paths are left out, since statfs on such a path
+// reports the file system the path lives in instead.
+func diskUsage(_ context.Context, args map[string]any) (*Response, error) {
+	volumes, err := stringMapArg(args, "volumes")
+	if err != nil {
+		return nil, err
+	}
+	if len(volumes) == 0 {
+		return nil, errors.New("no volumes specified")
+	}
+	mountPoints, err := listMountPoints()
+	if err != nil {
+		return nil, errors.Wrap(err, "list mount points failed")
+	}
+	usages := make(map[string]VolumeUsage, len(volumes))
+	for claim, path := range volumes {
+		path = filepath.Clean(path)
+		if !mountPoints.Has(path) {
+			continue
+		}
+		usage, err := statVolume(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "stat volume %s failed", claim)
+		}
+		usages[claim] = *usage
+	}
+	b, err := json.Marshal(usages)
+	if err != nil {
+		return nil, err
+	}
+	return &Response{Message: string(b)}, nil
+}
+
+func stringMapArg(args map[string]any, key string) (map[string]string, error) {
+	value, ok := args[key]
+	if !ok || value == nil {
+		return nil, nil
+	}
+	switch v := value.(type) {
+	case map[string]string:
+		return v, nil
+	case map[string]any:
+		r := make(map[string]string, len(v))
+		for k, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, errors.Errorf("invalid parameter %s: %v", key, value)
+			}
+			r[k] = s
+		}
+		return r, nil
+	default:
+		return nil, errors.Errorf("invalid parameter %s: %v", key, value)
+	}
+}
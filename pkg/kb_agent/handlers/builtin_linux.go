@@ -0,0 +1,85 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package handlers
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+var mountInfoPath = "/proc/self/mountinfo"
+
+// listMountPoints returns the mount points of the kb-agent container, which are the fifth field of
+// the lines of the mountinfo, with the spaces and the other special characters octal-escaped.
+func listMountPoints() (sets.Set[string], error) {
+	f, err := os.Open(mountInfoPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	mountPoints := sets.New[string]()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 {
+			continue
+		}
+		mountPoints.Insert(filepath.Clean(unescapeMountPoint(fields[4])))
+	}
+	return mountPoints, scanner.Err()
+}
+
+func unescapeMountPoint(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) {
+			if c, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(c))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+func statVolume(path string) (*VolumeUsage, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return nil, err
+	}
+	blockSize := int64(stat.Bsize) //nolint:unconvert // the type of Bsize differs across platforms
+	capacity := int64(stat.Blocks) * blockSize
+	available := int64(stat.Bavail) * blockSize
+	return &VolumeUsage{
+		Path:      path,
+		Capacity:  capacity,
+		Used:      capacity - int64(stat.Bfree)*blockSize,
+		Available: available,
+	}, nil
+}
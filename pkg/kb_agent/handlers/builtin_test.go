@@ -0,0 +1,78 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiskUsage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no volumes", func(t *testing.T) {
+		resp, err := Do(ctx, DiskUsageAction, nil)
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("invalid volumes", func(t *testing.T) {
+		resp, err := Do(ctx, DiskUsageAction, map[string]any{"volumes": []any{"data"}})
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("usage of mounted volumes", func(t *testing.T) {
+		if runtime.GOOS != "linux" {
+			t.Skip("the usage of volumes is only supported on linux")
+		}
+		mounted := filepath.Join(t.TempDir(), "data dir")
+		assert.NoError(t, os.Mkdir(mounted, 0755))
+		unmounted := t.TempDir()
+
+		mountInfo := filepath.Join(t.TempDir(), "mountinfo")
+		assert.NoError(t, os.WriteFile(mountInfo, []byte(fmt.Sprintf(
+			"22 1 8:1 / / rw - ext4 /dev/sda1 rw\n36 22 8:2 / %s rw - ext4 /dev/sdb rw\n",
+			strings.ReplaceAll(mounted, " ", "\\040"))), 0644))
+		defer func(path string) { mountInfoPath = path }(mountInfoPath)
+		mountInfoPath = mountInfo
+
+		resp, err := Do(ctx, DiskUsageAction, map[string]any{"volumes": map[string]any{
+			"data-mysql-0": mounted,
+			"logs-mysql-0": unmounted,
+		}})
+		assert.NoError(t, err)
+
+		var usages map[string]VolumeUsage
+		assert.NoError(t, json.Unmarshal([]byte(resp.Message), &usages))
+		assert.Len(t, usages, 1)
+		assert.Equal(t, mounted, usages["data-mysql-0"].Path)
+		assert.Greater(t, usages["data-mysql-0"].Capacity, int64(0))
+		assert.LessOrEqual(t, usages["data-mysql-0"].Used, usages["data-mysql-0"].Capacity)
+	})
+}
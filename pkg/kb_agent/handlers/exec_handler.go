@@ -63,7 +63,7 @@ func (h *ExecHandler) Do(ctx context.Context, setting util.HandlerSpec, args map
 		ctx = timeoutCtx
 	}
 
-	output, err := h.Executor.ExecCommand(ctx, setting.Command, envs)
+	output, err := h.Executor.ExecCommand(ctx, setting.Command, envs, setting.Sandbox())
 
 	if err != nil {
 		return nil, errors.Wrap(err, "ExecHandler executes action failed")
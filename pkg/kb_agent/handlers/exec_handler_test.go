@@ -51,7 +51,7 @@ func TestExecHandlerDo(t *testing.T) {
 	t.Run("execute with timeout failed", func(t *testing.T) {
 		msg := "execute timeout"
 		mockExecutor := &MockExecutor{
-			ExecCommandFunc: func(ctx context.Context, command []string, envs []string) (string, error) {
+			ExecCommandFunc: func(ctx context.Context, command []string, envs []string, sandbox *util.Sandbox) (string, error) {
 				return msg, errors.New(msg)
 			},
 		}
@@ -73,7 +73,7 @@ func TestExecHandlerDo(t *testing.T) {
 	t.Run("execute success", func(t *testing.T) {
 		msg := "execute success"
 		mockExecutor := &MockExecutor{
-			ExecCommandFunc: func(ctx context.Context, command []string, envs []string) (string, error) {
+			ExecCommandFunc: func(ctx context.Context, command []string, envs []string, sandbox *util.Sandbox) (string, error) {
 				return msg, nil
 			},
 		}
@@ -92,12 +92,12 @@ func TestExecHandlerDo(t *testing.T) {
 }
 
 type MockExecutor struct {
-	ExecCommandFunc func(ctx context.Context, command []string, envs []string) (string, error)
+	ExecCommandFunc func(ctx context.Context, command []string, envs []string, sandbox *util.Sandbox) (string, error)
 }
 
-func (e *MockExecutor) ExecCommand(ctx context.Context, command []string, envs []string) (string, error) {
+func (e *MockExecutor) ExecCommand(ctx context.Context, command []string, envs []string, sandbox *util.Sandbox) (string, error) {
 	if e.ExecCommandFunc != nil {
-		return e.ExecCommandFunc(ctx, command, envs)
+		return e.ExecCommandFunc(ctx, command, envs, sandbox)
 	}
 	return "nil", ErrNotImplemented
 }
@@ -27,6 +27,8 @@ import (
 
 type Response struct {
 	Message string `json:"message"`
+	// the reference of the artifact if the output of the action is uploaded
+	Artifact string `json:"artifact,omitempty"`
 }
 
 type Handler interface {
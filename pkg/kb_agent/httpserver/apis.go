@@ -23,6 +23,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 
 	"github.com/pkg/errors"
 	"github.com/valyala/fasthttp"
@@ -41,9 +43,38 @@ func Endpoints() []Endpoint {
 			Version: util.Version,
 			Handler: actionHandler,
 		},
+		{
+			Route:   util.SchemaPath,
+			Method:  fasthttp.MethodGet,
+			Version: util.Version,
+			Handler: schemaHandler,
+		},
+		{
+			Route:   util.RefreshPath,
+			Method:  fasthttp.MethodPost,
+			Version: util.Version,
+			Handler: refreshHandler,
+		},
 	}
 }
 
+// refreshHandler replaces the actions and the probes of the kb-agent, so that the updated definitions
+// take effect without restarting the pod.
+func refreshHandler(reqCtx *fasthttp.RequestCtx) {
+	var req util.RefreshRequest
+	if err := json.Unmarshal(reqCtx.PostBody(), &req); err != nil {
+		msg := NewErrorResponse(ErrCodeMalformedRequest, fmt.Sprintf("unmarshal HTTP body failed: %v", err))
+		respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
+		return
+	}
+	if err := handlers.RefreshHandlers(req.Handlers); err != nil {
+		msg := NewErrorResponse(ErrCodeMalformedRequestData, err.Error())
+		respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
+		return
+	}
+	respond(reqCtx, withEmpty())
+}
+
 func actionHandler(reqCtx *fasthttp.RequestCtx) {
 	ctx := context.Background()
 	body := reqCtx.PostBody()
@@ -52,7 +83,7 @@ func actionHandler(reqCtx *fasthttp.RequestCtx) {
 	if len(body) > 0 {
 		err := json.Unmarshal(body, &req)
 		if err != nil {
-			msg := NewErrorResponse("ERR_MALFORMED_REQUEST", fmt.Sprintf("unmarshal HTTP body failed: %v", err))
+			msg := NewErrorResponse(ErrCodeMalformedRequest, fmt.Sprintf("unmarshal HTTP body failed: %v", err))
 			respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
 			return
 		}
@@ -60,32 +91,67 @@ func actionHandler(reqCtx *fasthttp.RequestCtx) {
 
 	_, err := json.Marshal(req.Data)
 	if err != nil {
-		msg := NewErrorResponse("ERR_MALFORMED_REQUEST_DATA", fmt.Sprintf("marshal request data field: %v", err))
+		msg := NewErrorResponse(ErrCodeMalformedRequestData, fmt.Sprintf("marshal request data field: %v", err))
 		respond(reqCtx, withError(fasthttp.StatusInternalServerError, msg))
 		logger.Info("marshal request data field", "error", err.Error())
 		return
 	}
 
 	if req.Action == "" {
-		msg := NewErrorResponse("ERR_MALFORMED_REQUEST_DATA", "no action in request")
+		msg := NewErrorResponse(ErrCodeMalformedRequestData, "no action in request")
 		respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
 		return
 	}
 
+	var (
+		spool  *os.File
+		output io.Writer
+	)
+	if req.Artifact != nil {
+		if _, err = util.ArtifactPath(*req.Artifact); err != nil {
+			msg := NewErrorResponse(ErrCodeMalformedRequestData, err.Error())
+			respond(reqCtx, withError(fasthttp.StatusBadRequest, msg))
+			return
+		}
+		// the output is streamed to the spool on the disk instead of being held in memory.
+		if spool, err = util.NewArtifactSpool(); err != nil {
+			msg := NewErrorResponse(ErrCodeArtifactUploadFailed, fmt.Sprintf("create artifact spool failed: %s", err.Error()))
+			respond(reqCtx, withError(fasthttp.StatusInternalServerError, msg))
+			return
+		}
+		output = util.NewArtifactWriter(spool)
+		ctx = util.WithOutput(ctx, output)
+	}
+
 	resp, err := handlers.Do(ctx, req.Action, req.Parameters)
 	statusCode := fasthttp.StatusOK
 	if err != nil {
+		if spool != nil {
+			_ = spool.Close()
+			_ = os.Remove(spool.Name())
+		}
 		if errors.Is(err, handlers.ErrNotImplemented) {
 			statusCode = fasthttp.StatusNotImplemented
 		} else {
 			statusCode = fasthttp.StatusInternalServerError
 			logger.Info("action exec failed", "action", req.Action, "error", err.Error())
 		}
-		msg := NewErrorResponse("ERR_ACTION_FAILED", fmt.Sprintf("action exec failed: %s", err.Error()))
+		msg := NewErrorResponse(actionErrorCode(err), fmt.Sprintf("action exec failed: %s", err.Error()))
 		respond(reqCtx, withError(statusCode, msg))
 		return
 	}
 
+	if spool != nil {
+		artifact, err := uploadArtifact(ctx, *req.Artifact, spool, output, resp)
+		if err != nil {
+			logger.Info("upload action artifact failed", "action", req.Action, "error", err.Error())
+			msg := NewErrorResponse(ErrCodeArtifactUploadFailed, fmt.Sprintf("upload artifact failed: %s", err.Error()))
+			respond(reqCtx, withError(fasthttp.StatusInternalServerError, msg))
+			return
+		}
+		resp = &handlers.Response{Artifact: artifact}
+	}
+
 	if resp == nil {
 		respond(reqCtx, withEmpty())
 	} else {
@@ -94,6 +160,47 @@ func actionHandler(reqCtx *fasthttp.RequestCtx) {
 	}
 }
 
+// uploadArtifact uploads the output of the action spooled, the output returned by the handlers not streaming
+// it is appended to the spool first. The spool is removed once the artifact is uploaded, and is kept otherwise,
+// so that the output can still be fetched from the agent container.
+func uploadArtifact(ctx context.Context, target util.ArtifactTarget, spool *os.File, output io.Writer,
+	resp *handlers.Response) (string, error) {
+	defer spool.Close()
+	if resp != nil && resp.Message != "" {
+		if _, err := io.WriteString(output, resp.Message); err != nil {
+			return "", err
+		}
+	}
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	artifact, err := util.UploadArtifact(ctx, target, spool)
+	if err != nil {
+		return "", errors.Wrapf(err, "the output is kept in %s", spool.Name())
+	}
+	_ = os.Remove(spool.Name())
+	return artifact, nil
+}
+
+// actionErrorCode returns the error code of the failed action, so that the caller can tell
+// the violations of the action sandbox from the generic failures.
+func actionErrorCode(err error) string {
+	switch {
+	case errors.Is(err, util.ErrActionTimeout):
+		return ErrCodeActionTimeout
+	case errors.Is(err, util.ErrActionCPULimitExceeded):
+		return ErrCodeActionCPULimitExceeded
+	case errors.Is(err, util.ErrActionMemoryLimitExceeded):
+		return ErrCodeActionMemoryLimitExceeded
+	case errors.Is(err, util.ErrActionRunAsRoot):
+		return ErrCodeActionRunAsRoot
+	case errors.Is(err, util.ErrArtifactTooLarge):
+		return ErrCodeArtifactUploadFailed
+	default:
+		return ErrCodeActionFailed
+	}
+}
+
 // withJSON overrides the content-type with application/json.
 func withJSON(code int, obj []byte) option {
 	return func(ctx *fasthttp.RequestCtx) {
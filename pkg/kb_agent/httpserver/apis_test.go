@@ -24,12 +24,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/valyala/fasthttp"
 
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	"github.com/apecloud/kubeblocks/pkg/kb_agent/handlers"
 	"github.com/apecloud/kubeblocks/pkg/kb_agent/util"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
@@ -98,6 +101,22 @@ func TestActionHandler(t *testing.T) {
 		assert.Equal(t, fasthttp.StatusInternalServerError, reqCtx.Response.StatusCode())
 	})
 
+	t.Run("action exec timeout", func(t *testing.T) {
+		reqCtx := &fasthttp.RequestCtx{}
+		reqCtx.Request.Header.SetMethod(fasthttp.MethodPost)
+		reqCtx.Request.Header.SetContentType("application/json")
+		reqCtx.Request.SetBody([]byte(`{"action":"failed"}`))
+		mockHandler := &MockHandler{
+			DoFunc: func(ctx context.Context, setting util.HandlerSpec, args map[string]interface{}) (*handlers.Response, error) {
+				return nil, fmt.Errorf("exec failed: %w", util.ErrActionTimeout)
+			},
+		}
+		handlers.SetDefaultHandler(mockHandler)
+		actionHandler(reqCtx)
+		assert.Equal(t, fasthttp.StatusInternalServerError, reqCtx.Response.StatusCode())
+		assert.JSONEq(t, `{"errorCode":"ERR_ACTION_TIMEOUT","message":"action exec failed: exec failed: action timed out"}`, string(reqCtx.Response.Body()))
+	})
+
 	t.Run("action exec success", func(t *testing.T) {
 		msg := "action exec success"
 		reqCtx := &fasthttp.RequestCtx{}
@@ -115,6 +134,69 @@ func TestActionHandler(t *testing.T) {
 		expectedResponse := fmt.Sprintf(`{"message":"%s"}`, msg)
 		assert.Equal(t, expectedResponse, string(reqCtx.Response.Body()))
 	})
+
+	t.Run("invalid artifact target", func(t *testing.T) {
+		reqCtx := &fasthttp.RequestCtx{}
+		reqCtx.Request.Header.SetMethod(fasthttp.MethodPost)
+		reqCtx.Request.Header.SetContentType("application/json")
+		reqCtx.Request.SetBody([]byte(`{"action":"success","artifact":{}}`))
+		actionHandler(reqCtx)
+		assert.Equal(t, fasthttp.StatusBadRequest, reqCtx.Response.StatusCode())
+		assert.Contains(t, string(reqCtx.Response.Body()), "ERR_MALFORMED_REQUEST_DATA")
+	})
+
+	t.Run("upload action artifact failed", func(t *testing.T) {
+		util.ArtifactSpoolDir = t.TempDir()
+		t.Setenv(dptypes.DPDatasafedBinPath, "")
+		reqCtx := &fasthttp.RequestCtx{}
+		reqCtx.Request.Header.SetMethod(fasthttp.MethodPost)
+		reqCtx.Request.Header.SetContentType("application/json")
+		reqCtx.Request.SetBody([]byte(`{"action":"success","artifact":{"backupRepoPath":"dump.sql"}}`))
+		mockHandler := &MockHandler{
+			DoFunc: func(ctx context.Context, setting util.HandlerSpec, args map[string]interface{}) (*handlers.Response, error) {
+				return &handlers.Response{Message: "action exec success"}, nil
+			},
+		}
+		handlers.SetDefaultHandler(mockHandler)
+		actionHandler(reqCtx)
+		assert.Equal(t, fasthttp.StatusInternalServerError, reqCtx.Response.StatusCode())
+		assert.Contains(t, string(reqCtx.Response.Body()), "ERR_ARTIFACT_UPLOAD_FAILED")
+
+		// the output is kept in the spool if the upload fails
+		spools, err := os.ReadDir(util.ArtifactSpoolDir)
+		assert.NoError(t, err)
+		assert.Len(t, spools, 1)
+		content, err := os.ReadFile(filepath.Join(util.ArtifactSpoolDir, spools[0].Name()))
+		assert.NoError(t, err)
+		assert.Equal(t, "action exec success", string(content))
+	})
+}
+
+func TestRefreshHandler(t *testing.T) {
+	handlers.ResetHandlerSpecs()
+	var refreshed map[string]util.HandlerSpec
+	handlers.RegisterRefreshHook(func(specs map[string]util.HandlerSpec) {
+		refreshed = specs
+	})
+
+	t.Run("no handlers in request", func(t *testing.T) {
+		reqCtx := &fasthttp.RequestCtx{}
+		reqCtx.Request.Header.SetMethod(fasthttp.MethodPost)
+		reqCtx.Request.SetBody([]byte(`{}`))
+		refreshHandler(reqCtx)
+		assert.Equal(t, fasthttp.StatusBadRequest, reqCtx.Response.StatusCode())
+		assert.Nil(t, refreshed)
+	})
+
+	t.Run("refresh the handlers", func(t *testing.T) {
+		reqCtx := &fasthttp.RequestCtx{}
+		reqCtx.Request.Header.SetMethod(fasthttp.MethodPost)
+		reqCtx.Request.SetBody([]byte(`{"handlers":{"roleProbe":{"command":["echo","leader"],"cronJob":{"periodSeconds":1}}}}`))
+		refreshHandler(reqCtx)
+		assert.Equal(t, fasthttp.StatusNoContent, reqCtx.Response.StatusCode())
+		assert.Equal(t, []string{"echo", "leader"}, handlers.GetHandlerSpecs()["roleProbe"].Command)
+		assert.Equal(t, 1, refreshed["roleProbe"].CronJob.PeriodSeconds)
+	})
 }
 
 type MockHandler struct {
@@ -21,6 +21,8 @@ package httpserver
 
 import (
 	"github.com/valyala/fasthttp"
+
+	"github.com/apecloud/kubeblocks/pkg/kb_agent/util"
 )
 
 type Endpoint struct {
@@ -44,4 +46,7 @@ type Request struct {
 	Action     string         `json:"action"`
 	Data       interface{}    `json:"data,omitempty"`
 	Parameters map[string]any `json:"parameters,omitempty"`
+	// Artifact specifies where in the BackupRepo to upload the output of the action,
+	// if set, the output is streamed to the BackupRepo and its path is returned instead of the output.
+	Artifact *util.ArtifactTarget `json:"artifact,omitempty"`
 }
@@ -19,6 +19,32 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 
 package httpserver
 
+// error codes of the ErrorResponse.
+const (
+	ErrCodeMalformedRequest          = "ERR_MALFORMED_REQUEST"
+	ErrCodeMalformedRequestData      = "ERR_MALFORMED_REQUEST_DATA"
+	ErrCodeActionFailed              = "ERR_ACTION_FAILED"
+	ErrCodeActionTimeout             = "ERR_ACTION_TIMEOUT"
+	ErrCodeActionCPULimitExceeded    = "ERR_ACTION_CPU_LIMIT_EXCEEDED"
+	ErrCodeActionMemoryLimitExceeded = "ERR_ACTION_MEMORY_LIMIT_EXCEEDED"
+	ErrCodeActionRunAsRoot           = "ERR_ACTION_RUN_AS_ROOT"
+	ErrCodeArtifactUploadFailed      = "ERR_ARTIFACT_UPLOAD_FAILED"
+	ErrCodeInternal                  = "ERR_INTERNAL"
+)
+
+// errorCodes lists all the error codes, which are exposed in the API schema.
+var errorCodes = []string{
+	ErrCodeMalformedRequest,
+	ErrCodeMalformedRequestData,
+	ErrCodeActionFailed,
+	ErrCodeActionTimeout,
+	ErrCodeActionCPULimitExceeded,
+	ErrCodeActionMemoryLimitExceeded,
+	ErrCodeActionRunAsRoot,
+	ErrCodeArtifactUploadFailed,
+	ErrCodeInternal,
+}
+
 // ErrorResponse is an HTTP response message sent back to calling clients.
 type ErrorResponse struct {
 	ErrorCode string `json:"errorCode"`
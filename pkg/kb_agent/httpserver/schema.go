@@ -0,0 +1,245 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package httpserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/valyala/fasthttp"
+
+	"github.com/apecloud/kubeblocks/pkg/kb_agent/handlers"
+	"github.com/apecloud/kubeblocks/pkg/kb_agent/util"
+)
+
+const openAPIVersion = "3.0.3"
+
+func schemaHandler(reqCtx *fasthttp.RequestCtx) {
+	body, err := json.Marshal(BuildSchema(handlers.GetActionSpecs()))
+	if err != nil {
+		msg := NewErrorResponse(ErrCodeInternal, fmt.Sprintf("marshal API schema failed: %v", err))
+		respond(reqCtx, withError(fasthttp.StatusInternalServerError, msg))
+		return
+	}
+	respond(reqCtx, withJSON(fasthttp.StatusOK, body))
+}
+
+// BuildSchema generates the OpenAPI document of the kb-agent HTTP API from the registered actions,
+// each action is described by its name, parameters and the error codes it may respond with.
+func BuildSchema(specs map[string]util.HandlerSpec) map[string]any {
+	actions := make([]string, 0, len(specs))
+	for action := range specs {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+
+	requests := make([]any, 0, len(actions))
+	schemas := map[string]any{
+		"ArtifactTarget": artifactTargetSchema(),
+		"Response":       responseSchema(),
+		"ErrorResponse":  errorResponseSchema(),
+	}
+	for _, action := range actions {
+		name := "Action." + action
+		schemas[name] = actionRequestSchema(action, specs[action])
+		requests = append(requests, schemaRef(name))
+	}
+
+	return map[string]any{
+		"openapi": openAPIVersion,
+		"info": map[string]any{
+			"title":   "kb-agent",
+			"version": util.Version,
+		},
+		"paths": map[string]any{
+			endpointPath(util.Path): map[string]any{
+				"post": actionOperation(requests),
+			},
+			endpointPath(util.SchemaPath): map[string]any{
+				"get": schemaOperation(),
+			},
+			endpointPath(util.RefreshPath): map[string]any{
+				"post": refreshOperation(),
+			},
+		},
+		"components": map[string]any{
+			"schemas": schemas,
+		},
+	}
+}
+
+func endpointPath(route string) string {
+	return fmt.Sprintf("/%s/%s", util.Version, route)
+}
+
+func schemaRef(name string) map[string]any {
+	return map[string]any{"$ref": "#/components/schemas/" + name}
+}
+
+func jsonContent(schema any) map[string]any {
+	return map[string]any{
+		util.JSONContentTypeHeader: map[string]any{"schema": schema},
+	}
+}
+
+func actionOperation(requests []any) map[string]any {
+	errorResponse := func(description string) map[string]any {
+		return map[string]any{
+			"description": description,
+			"content":     jsonContent(schemaRef("ErrorResponse")),
+		}
+	}
+	return map[string]any{
+		"operationId": "action",
+		"summary":     "Call an action registered in the kb-agent",
+		"requestBody": map[string]any{
+			"required": true,
+			"content":  jsonContent(map[string]any{"oneOf": requests}),
+		},
+		"responses": map[string]any{
+			"200": map[string]any{
+				"description": "the action succeeded with output",
+				"content":     jsonContent(schemaRef("Response")),
+			},
+			"204": map[string]any{
+				"description": "the action succeeded without output",
+			},
+			"400": errorResponse("the request is malformed"),
+			"500": errorResponse("the action failed"),
+			"501": errorResponse("the action is not implemented"),
+		},
+	}
+}
+
+func schemaOperation() map[string]any {
+	return map[string]any{
+		"operationId": "schema",
+		"summary":     "Get the OpenAPI document of the kb-agent",
+		"responses": map[string]any{
+			"200": map[string]any{
+				"description": "the OpenAPI document",
+				"content":     jsonContent(map[string]any{"type": "object"}),
+			},
+		},
+	}
+}
+
+func refreshOperation() map[string]any {
+	return map[string]any{
+		"operationId": "refresh",
+		"summary":     "Replace the actions and the probes of the kb-agent",
+		"requestBody": map[string]any{
+			"required": true,
+			"content": jsonContent(map[string]any{
+				"type":     "object",
+				"required": []string{"handlers"},
+				"properties": map[string]any{
+					"handlers": map[string]any{"type": "object"},
+				},
+			}),
+		},
+		"responses": map[string]any{
+			"204": map[string]any{"description": "the actions and the probes are refreshed"},
+			"400": map[string]any{
+				"description": "malformed request",
+				"content":     jsonContent(schemaRef("ErrorResponse")),
+			},
+		},
+	}
+}
+
+func actionRequestSchema(action string, spec util.HandlerSpec) map[string]any {
+	names := make([]string, 0, len(spec.Parameters))
+	for name := range spec.Parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	properties := map[string]any{}
+	required := make([]string, 0)
+	for _, name := range names {
+		param := spec.Parameters[name]
+		typ := param.Type
+		if typ == "" {
+			typ = "string"
+		}
+		property := map[string]any{"type": typ}
+		if param.Description != "" {
+			property["description"] = param.Description
+		}
+		properties[name] = property
+		if param.Required {
+			required = append(required, name)
+		}
+	}
+	parameters := map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		parameters["required"] = required
+	}
+
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"action":     map[string]any{"type": "string", "enum": []string{action}},
+			"parameters": parameters,
+			"artifact":   schemaRef("ArtifactTarget"),
+		},
+		"required": []string{"action"},
+	}
+	if len(required) > 0 {
+		schema["required"] = []string{"action", "parameters"}
+	}
+	return schema
+}
+
+func artifactTargetSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"backupRepoPath": map[string]any{"type": "string"},
+		},
+		"required": []string{"backupRepoPath"},
+	}
+}
+
+func responseSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"message":  map[string]any{"type": "string"},
+			"artifact": map[string]any{"type": "string"},
+		},
+	}
+}
+
+func errorResponseSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"errorCode": map[string]any{"type": "string", "enum": errorCodes},
+			"message":   map[string]any{"type": "string"},
+		},
+		"required": []string{"errorCode", "message"},
+	}
+}
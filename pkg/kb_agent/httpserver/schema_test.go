@@ -0,0 +1,97 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package httpserver
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/kb_agent/handlers"
+	"github.com/apecloud/kubeblocks/pkg/kb_agent/util"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+func TestBuildSchema(t *testing.T) {
+	specs := map[string]util.HandlerSpec{
+		"switchover": {
+			Command: []string{"switchover.sh"},
+			Parameters: map[string]util.ParameterSpec{
+				"candidate": {Description: "the candidate pod", Required: true},
+				"force":     {Type: "boolean"},
+			},
+		},
+		"getRole": {
+			Command: []string{"get-role.sh"},
+		},
+	}
+	doc, err := json.Marshal(BuildSchema(specs))
+	assert.Nil(t, err)
+
+	schema := map[string]any{}
+	assert.Nil(t, json.Unmarshal(doc, &schema))
+	assert.Equal(t, openAPIVersion, schema["openapi"])
+
+	paths := schema["paths"].(map[string]any)
+	assert.Contains(t, paths, "/v1.0//action")
+	assert.Contains(t, paths, "/v1.0/schema")
+
+	requests := paths["/v1.0//action"].(map[string]any)["post"].(map[string]any)["requestBody"].(map[string]any)["content"].(map[string]any)[util.JSONContentTypeHeader].(map[string]any)["schema"].(map[string]any)["oneOf"].([]any)
+	assert.Equal(t, []any{
+		map[string]any{"$ref": "#/components/schemas/Action.getRole"},
+		map[string]any{"$ref": "#/components/schemas/Action.switchover"},
+	}, requests)
+
+	schemas := schema["components"].(map[string]any)["schemas"].(map[string]any)
+	switchover := schemas["Action.switchover"].(map[string]any)
+	assert.Equal(t, []any{"action", "parameters"}, switchover["required"])
+	properties := switchover["properties"].(map[string]any)
+	assert.Equal(t, []any{"switchover"}, properties["action"].(map[string]any)["enum"])
+	parameters := properties["parameters"].(map[string]any)
+	assert.Equal(t, []any{"candidate"}, parameters["required"])
+	assert.Equal(t, map[string]any{
+		"candidate": map[string]any{"type": "string", "description": "the candidate pod"},
+		"force":     map[string]any{"type": "boolean"},
+	}, parameters["properties"])
+
+	errorCode := schemas["ErrorResponse"].(map[string]any)["properties"].(map[string]any)["errorCode"].(map[string]any)
+	assert.Len(t, errorCode["enum"], len(errorCodes))
+}
+
+func TestSchemaHandler(t *testing.T) {
+	handlers.ResetHandlerSpecs()
+	defer handlers.ResetHandlerSpecs()
+	actionJSON, _ := json.Marshal(map[string]util.HandlerSpec{"getRole": {Command: []string{"get-role.sh"}}})
+	viper.Set(constant.KBEnvActionHandlers, string(actionJSON))
+	assert.Nil(t, handlers.InitHandlers())
+
+	reqCtx := &fasthttp.RequestCtx{}
+	reqCtx.Request.Header.SetMethod(fasthttp.MethodGet)
+	schemaHandler(reqCtx)
+	assert.Equal(t, fasthttp.StatusOK, reqCtx.Response.StatusCode())
+	assert.Equal(t, util.JSONContentTypeHeader, string(reqCtx.Response.Header.ContentType()))
+	assert.Contains(t, string(reqCtx.Response.Body()), `"Action.getRole"`)
+	// the built-in actions are described with their parameters as well
+	assert.Contains(t, string(reqCtx.Response.Body()), `"Action.df"`)
+	assert.Contains(t, string(reqCtx.Response.Body()), `"volumes"`)
+}
@@ -0,0 +1,132 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+// ArtifactTarget specifies where to upload the output of an action.
+type ArtifactTarget struct {
+	// BackupRepoPath is the path to upload the artifact to, relative to the artifacts directory in the BackupRepo
+	// of the cluster. The artifact is pushed by datasafed, which is injected into the agent container along with
+	// the config of the BackupRepo.
+	BackupRepoPath string `json:"backupRepoPath"`
+}
+
+const (
+	datasafedBin = "datasafed"
+
+	// artifactRootPath is the directory in the BackupRepo the artifacts are uploaded to, so that the requests
+	// can't overwrite the backups in the same repo.
+	artifactRootPath = "artifacts"
+
+	// MaxArtifactSize is the max size of the output of an action to be uploaded as an artifact.
+	MaxArtifactSize int64 = 4 << 30
+)
+
+var (
+	// ArtifactSpoolDir is the directory to spool the output of the actions before uploading,
+	// which is in the volume shared with the agent binary.
+	ArtifactSpoolDir = "/kubeblocks/artifacts"
+
+	ErrArtifactTooLarge = fmt.Errorf("the output exceeds the max artifact size %d", MaxArtifactSize)
+)
+
+// ArtifactPath returns the path in the BackupRepo of the artifact, the path of the target is cleaned and
+// confined to the artifacts directory.
+func ArtifactPath(target ArtifactTarget) (string, error) {
+	if target.BackupRepoPath == "" {
+		return "", errors.New("no backupRepoPath specified for the artifact")
+	}
+	// the path is cleaned as an absolute path, so that the ".." elements can't escape the artifacts directory.
+	cleaned := path.Clean("/" + target.BackupRepoPath)
+	if cleaned == "/" {
+		return "", fmt.Errorf("invalid backupRepoPath %q for the artifact", target.BackupRepoPath)
+	}
+	return path.Join("/", artifactRootPath, cleaned), nil
+}
+
+// NewArtifactSpool creates the file to spool the output of an action, the file is removed by the caller
+// after the artifact is uploaded.
+func NewArtifactSpool() (*os.File, error) {
+	if err := os.MkdirAll(ArtifactSpoolDir, 0o700); err != nil {
+		return nil, err
+	}
+	return os.CreateTemp(ArtifactSpoolDir, "artifact-*")
+}
+
+// NewArtifactWriter returns a writer to the spool which fails once more than MaxArtifactSize bytes are written,
+// the command writing to it is aborted by the broken pipe then.
+func NewArtifactWriter(spool *os.File) io.Writer {
+	return &artifactWriter{spool: spool, n: MaxArtifactSize}
+}
+
+type artifactWriter struct {
+	spool *os.File
+	n     int64
+}
+
+func (w *artifactWriter) Write(p []byte) (int, error) {
+	if int64(len(p)) > w.n {
+		return 0, ErrArtifactTooLarge
+	}
+	n, err := w.spool.Write(p)
+	w.n -= int64(n)
+	return n, err
+}
+
+// Reset discards the output written by the previous attempt of the action.
+func (w *artifactWriter) Reset() error {
+	if err := w.spool.Truncate(0); err != nil {
+		return err
+	}
+	_, err := w.spool.Seek(0, io.SeekStart)
+	w.n = MaxArtifactSize
+	return err
+}
+
+// UploadArtifact streams the artifact to the BackupRepo, and returns the path of the uploaded artifact.
+func UploadArtifact(ctx context.Context, target ArtifactTarget, artifact io.Reader) (string, error) {
+	repoPath, err := ArtifactPath(target)
+	if err != nil {
+		return "", err
+	}
+	binPath := os.Getenv(dptypes.DPDatasafedBinPath)
+	if binPath == "" {
+		return "", errors.New("datasafed is not configured in the agent container, the BackupRepo of the cluster is required")
+	}
+	cmd := exec.CommandContext(ctx, filepath.Join(binPath, datasafedBin), "push", "-", repoPath)
+	cmd.Stdin = artifact
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return "", errors.Wrapf(err, "push artifact to backup repo failed: %s", string(output))
+	}
+	return repoPath, nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package util
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+func TestArtifactPath(t *testing.T) {
+	_, err := ArtifactPath(ArtifactTarget{})
+	assert.Error(t, err)
+
+	_, err = ArtifactPath(ArtifactTarget{BackupRepoPath: "/../"})
+	assert.Error(t, err)
+
+	repoPath, err := ArtifactPath(ArtifactTarget{BackupRepoPath: "dumps/dump.sql"})
+	assert.NoError(t, err)
+	assert.Equal(t, "/artifacts/dumps/dump.sql", repoPath)
+
+	repoPath, err = ArtifactPath(ArtifactTarget{BackupRepoPath: "../../backups/dump.sql"})
+	assert.NoError(t, err)
+	assert.Equal(t, "/artifacts/backups/dump.sql", repoPath)
+}
+
+func TestArtifactWriter(t *testing.T) {
+	spool, err := os.CreateTemp(t.TempDir(), "artifact-*")
+	assert.NoError(t, err)
+	defer spool.Close()
+
+	w := NewArtifactWriter(spool)
+	_, err = w.Write([]byte("partial output"))
+	assert.NoError(t, err)
+
+	aw := w.(*artifactWriter)
+	assert.NoError(t, aw.Reset())
+	_, err = w.Write([]byte("output"))
+	assert.NoError(t, err)
+	content, err := os.ReadFile(spool.Name())
+	assert.NoError(t, err)
+	assert.Equal(t, "output", string(content))
+
+	aw.n = 1
+	_, err = w.Write([]byte("output"))
+	assert.ErrorIs(t, err, ErrArtifactTooLarge)
+}
+
+func TestUploadArtifact(t *testing.T) {
+	ctx := context.Background()
+	spool, err := os.CreateTemp(t.TempDir(), "artifact-*")
+	assert.NoError(t, err)
+	defer spool.Close()
+	_, err = spool.WriteString("test")
+	assert.NoError(t, err)
+
+	t.Run("datasafed not configured", func(t *testing.T) {
+		t.Setenv(dptypes.DPDatasafedBinPath, "")
+		_, err := UploadArtifact(ctx, ArtifactTarget{BackupRepoPath: "dump.sql"}, spool)
+		assert.ErrorContains(t, err, "datasafed is not configured")
+	})
+
+	t.Run("push to backup repo", func(t *testing.T) {
+		binPath := t.TempDir()
+		pushed := filepath.Join(binPath, "pushed")
+		script := "#!/bin/sh\ncat > " + pushed + "\n"
+		assert.NoError(t, os.WriteFile(filepath.Join(binPath, datasafedBin), []byte(script), 0o755))
+		t.Setenv(dptypes.DPDatasafedBinPath, binPath)
+
+		_, err := spool.Seek(0, 0)
+		assert.NoError(t, err)
+		repoPath, err := UploadArtifact(ctx, ArtifactTarget{BackupRepoPath: "dump.sql"}, spool)
+		assert.NoError(t, err)
+		assert.Equal(t, "/artifacts/dump.sql", repoPath)
+		content, err := os.ReadFile(pushed)
+		assert.NoError(t, err)
+		assert.Equal(t, "test", string(content))
+	})
+
+	t.Run("push to backup repo failed", func(t *testing.T) {
+		binPath := t.TempDir()
+		script := "#!/bin/sh\necho 'access denied' >&2\nexit 1\n"
+		assert.NoError(t, os.WriteFile(filepath.Join(binPath, datasafedBin), []byte(script), 0o755))
+		t.Setenv(dptypes.DPDatasafedBinPath, binPath)
+
+		_, err := UploadArtifact(ctx, ArtifactTarget{BackupRepoPath: "dump.sql"}, spool)
+		assert.ErrorContains(t, err, "access denied")
+	})
+}
@@ -20,8 +20,10 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package util
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"strings"
@@ -30,26 +32,79 @@ import (
 )
 
 type Executor interface {
-	ExecCommand(ctx context.Context, command []string, envs []string) (string, error)
+	ExecCommand(ctx context.Context, command []string, envs []string, sandbox *Sandbox) (string, error)
 }
 
 type ExecutorImpl struct{}
 
-func (e *ExecutorImpl) ExecCommand(ctx context.Context, command []string, envs []string) (string, error) {
-	return ExecCommand(ctx, command, envs)
+func (e *ExecutorImpl) ExecCommand(ctx context.Context, command []string, envs []string, sandbox *Sandbox) (string, error) {
+	return ExecCommandInSandbox(ctx, command, envs, sandbox)
 }
 
 func ExecCommand(ctx context.Context, command []string, envs []string) (string, error) {
+	return ExecCommandInSandbox(ctx, command, envs, nil)
+}
+
+// ExecCommandInSandbox executes the command with the restrictions of the sandbox,
+// the violations of the restrictions are returned as the distinct errors.
+func ExecCommandInSandbox(ctx context.Context, command []string, envs []string, sandbox *Sandbox) (string, error) {
 	if len(command) == 0 {
 		return "", errors.New("command can not be empty")
 	}
 	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
 	cmd.Env = envs
-	bytes, err := cmd.Output()
-	if exitErr, ok := err.(*exec.ExitError); ok {
-		err = errors.New(string(exitErr.Stderr))
+	if err := setupSandbox(cmd, sandbox); err != nil {
+		return "", err
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	if w := outputFromContext(ctx); w != nil {
+		cmd.Stdout = w
+	}
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		return "", err
+	}
+
+	violation := make(chan error, 1)
+	done := make(chan struct{})
+	go watchSandbox(cmd.Process, sandbox, violation, done)
+	err := cmd.Wait()
+	close(done)
+
+	select {
+	case violationErr := <-violation:
+		return stdout.String(), violationErr
+	default:
+	}
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return stdout.String(), ErrActionTimeout
+	}
+	if _, ok := err.(*exec.ExitError); ok {
+		err = errors.New(stderr.String())
+	}
+	return stdout.String(), err
+}
+
+type outputKey struct{}
+
+// WithOutput returns a context to execute the commands with, whose stdout is streamed to the writer
+// instead of being returned.
+func WithOutput(ctx context.Context, w io.Writer) context.Context {
+	return context.WithValue(ctx, outputKey{}, w)
+}
+
+func outputFromContext(ctx context.Context) io.Writer {
+	w, _ := ctx.Value(outputKey{}).(io.Writer)
+	return w
+}
+
+// ResetOutput discards the output streamed by the previous attempt of the command, before it is retried.
+func ResetOutput(ctx context.Context) error {
+	if r, ok := outputFromContext(ctx).(interface{ Reset() error }); ok {
+		return r.Reset()
 	}
-	return string(bytes), err
+	return nil
 }
 
 func GetAllEnvs(args map[string]any) []string {
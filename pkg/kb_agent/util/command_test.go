@@ -23,6 +23,7 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -40,6 +41,43 @@ func TestExecCommand(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestExecCommandInSandbox(t *testing.T) {
+	t.Run("timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		_, err := ExecCommandInSandbox(ctx, []string{"sleep", "10"}, nil, nil)
+		assert.ErrorIs(t, err, ErrActionTimeout)
+	})
+
+	t.Run("run as root", func(t *testing.T) {
+		root := int64(0)
+		_, err := ExecCommandInSandbox(context.Background(), []string{"true"}, nil, &Sandbox{RunAsUser: &root})
+		assert.ErrorIs(t, err, ErrActionRunAsRoot)
+		_, err = ExecCommandInSandbox(context.Background(), []string{"true"}, nil, &Sandbox{RunAsGroup: &root})
+		assert.ErrorIs(t, err, ErrActionRunAsRoot)
+	})
+}
+
+func TestSandboxCredential(t *testing.T) {
+	uid, gid := (&Sandbox{}).credential()
+	assert.Equal(t, uint32(defaultSandboxUser), uid)
+	assert.Equal(t, uint32(defaultSandboxGroup), gid)
+
+	user, group := int64(1001), int64(2002)
+	uid, gid = (&Sandbox{RunAsUser: &user, RunAsGroup: &group}).credential()
+	assert.Equal(t, uint32(1001), uid)
+	assert.Equal(t, uint32(2002), gid)
+}
+
+func TestSandboxCheckUsage(t *testing.T) {
+	sandbox := &Sandbox{
+		Resources: &ResourceLimits{CPUSeconds: 1, MemoryBytes: 1024},
+	}
+	assert.NoError(t, sandbox.checkUsage(processUsage{cpuTime: time.Second, memoryBytes: 1024}))
+	assert.ErrorIs(t, sandbox.checkUsage(processUsage{cpuTime: 2 * time.Second}), ErrActionCPULimitExceeded)
+	assert.ErrorIs(t, sandbox.checkUsage(processUsage{memoryBytes: 2048}), ErrActionMemoryLimitExceeded)
+}
+
 func TestGetAllEnvs(t *testing.T) {
 	args := map[string]any{
 		"test": "test",
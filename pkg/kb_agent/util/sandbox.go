@@ -0,0 +1,93 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrActionTimeout             = errors.New("action timed out")
+	ErrActionCPULimitExceeded    = errors.New("action exceeded the CPU limit")
+	ErrActionMemoryLimitExceeded = errors.New("action exceeded the memory limit")
+	ErrActionRunAsRoot           = errors.New("action is not allowed to run as root")
+)
+
+// the interval to check the resource usage of the action process.
+var sandboxCheckInterval = 100 * time.Millisecond
+
+const (
+	// the user and the group to run the action process as if not specified, which are "nobody" and "nogroup".
+	defaultSandboxUser  = 65534
+	defaultSandboxGroup = 65534
+)
+
+// Sandbox defines the restrictions enforced on the action process.
+// The action process runs as a non-root user in the sandbox, which is "nobody" if the user is not specified.
+type Sandbox struct {
+	Resources  *ResourceLimits
+	RunAsUser  *int64
+	RunAsGroup *int64
+}
+
+// processUsage is the resource usage of a process.
+type processUsage struct {
+	cpuTime     time.Duration
+	memoryBytes int64
+}
+
+func (s *Sandbox) validate() error {
+	if s.RunAsUser != nil && *s.RunAsUser == 0 || s.RunAsGroup != nil && *s.RunAsGroup == 0 {
+		return ErrActionRunAsRoot
+	}
+	return nil
+}
+
+// credential returns the user and the group to run the action process as.
+func (s *Sandbox) credential() (uint32, uint32) {
+	uid, gid := uint32(defaultSandboxUser), uint32(defaultSandboxGroup)
+	if s.RunAsUser != nil {
+		uid = uint32(*s.RunAsUser)
+	}
+	if s.RunAsGroup != nil {
+		gid = uint32(*s.RunAsGroup)
+	}
+	return uid, gid
+}
+
+// checkUsage checks whether the resource usage exceeds the limits of the sandbox.
+func (s *Sandbox) checkUsage(usage processUsage) error {
+	if s.Resources == nil {
+		return nil
+	}
+	if s.Resources.CPUSeconds > 0 && usage.cpuTime > time.Duration(s.Resources.CPUSeconds)*time.Second {
+		return fmt.Errorf("%w: used %s, limit %ds", ErrActionCPULimitExceeded, usage.cpuTime, s.Resources.CPUSeconds)
+	}
+	if s.Resources.MemoryBytes > 0 && usage.memoryBytes > s.Resources.MemoryBytes {
+		return fmt.Errorf("%w: used %d bytes, limit %d bytes", ErrActionMemoryLimitExceeded, usage.memoryBytes, s.Resources.MemoryBytes)
+	}
+	return nil
+}
+
+func (s *Sandbox) hasResourceLimits() bool {
+	return s != nil && s.Resources != nil && (s.Resources.CPUSeconds > 0 || s.Resources.MemoryBytes > 0)
+}
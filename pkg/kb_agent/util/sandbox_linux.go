@@ -0,0 +1,115 @@
+//go:build linux
+
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package util
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// the clock ticks per second of the cpu time in /proc/<pid>/stat, which is 100 on almost all Linux systems.
+const clockTicksPerSecond = 100
+
+func setupSandbox(cmd *exec.Cmd, sandbox *Sandbox) error {
+	if sandbox == nil {
+		return nil
+	}
+	if err := sandbox.validate(); err != nil {
+		return err
+	}
+	if os.Getuid() != 0 && sandbox.RunAsUser == nil && sandbox.RunAsGroup == nil {
+		// the kb-agent runs as a non-root user already, the action process inherits it.
+		return nil
+	}
+	uid, gid := sandbox.credential()
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Credential: &syscall.Credential{
+			Uid:    uid,
+			Gid:    gid,
+			Groups: []uint32{},
+		},
+	}
+	return nil
+}
+
+// watchSandbox checks the resource usage of the process periodically until done is closed,
+// the process will be killed if it exceeds the resource limits of the sandbox.
+func watchSandbox(process *os.Process, sandbox *Sandbox, violation chan<- error, done <-chan struct{}) {
+	if !sandbox.hasResourceLimits() {
+		return
+	}
+	ticker := time.NewTicker(sandboxCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			usage, err := getProcessUsage(process.Pid)
+			if err != nil {
+				// the process may have exited
+				continue
+			}
+			if err = sandbox.checkUsage(usage); err != nil {
+				violation <- err
+				_ = process.Kill()
+				return
+			}
+		}
+	}
+}
+
+// getProcessUsage reads the cpu time and resident memory of the process from /proc/<pid>/stat.
+func getProcessUsage(pid int) (processUsage, error) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return processUsage{}, err
+	}
+	// the second field is the command name in parentheses, which may contain spaces.
+	stat := string(data)
+	fields := strings.Fields(stat[strings.LastIndex(stat, ")")+1:])
+	// fields[0] is the 3rd field (state), utime, stime and rss are the 14th, 15th and 24th fields.
+	if len(fields) < 22 {
+		return processUsage{}, fmt.Errorf("invalid stat of process %d: %s", pid, stat)
+	}
+	utime, err := strconv.ParseInt(fields[11], 10, 64)
+	if err != nil {
+		return processUsage{}, err
+	}
+	stime, err := strconv.ParseInt(fields[12], 10, 64)
+	if err != nil {
+		return processUsage{}, err
+	}
+	rss, err := strconv.ParseInt(fields[21], 10, 64)
+	if err != nil {
+		return processUsage{}, err
+	}
+	return processUsage{
+		cpuTime:     time.Duration(utime+stime) * time.Second / clockTicksPerSecond,
+		memoryBytes: rss * int64(os.Getpagesize()),
+	}, nil
+}
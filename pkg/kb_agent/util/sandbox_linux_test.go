@@ -0,0 +1,47 @@
+//go:build linux
+
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package util
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetProcessUsage(t *testing.T) {
+	usage, err := getProcessUsage(os.Getpid())
+	assert.NoError(t, err)
+	assert.Greater(t, usage.memoryBytes, int64(0))
+}
+
+func TestExecCommandExceedCPULimit(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	sandbox := &Sandbox{
+		Resources: &ResourceLimits{CPUSeconds: 1},
+	}
+	_, err := ExecCommandInSandbox(ctx, []string{"sh", "-c", "while :; do :; done"}, nil, sandbox)
+	assert.ErrorIs(t, err, ErrActionCPULimitExceeded)
+}
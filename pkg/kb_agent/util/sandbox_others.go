@@ -0,0 +1,40 @@
+//go:build !linux
+
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package util
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+)
+
+func setupSandbox(cmd *exec.Cmd, sandbox *Sandbox) error {
+	if sandbox == nil {
+		return nil
+	}
+	if err := sandbox.validate(); err != nil {
+		return err
+	}
+	return errors.New("action sandbox is only supported on linux")
+}
+
+func watchSandbox(*os.Process, *Sandbox, chan<- error, <-chan struct{}) {}
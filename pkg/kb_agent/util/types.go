@@ -30,8 +30,16 @@ const (
 	JSONContentTypeHeader = "application/json"
 	Version               = "v1.0"
 	Path                  = "/action"
+	SchemaPath            = "schema"
+	RefreshPath           = "refresh"
 )
 
+// RefreshRequest carries the handler specs of the actions and the probes, which replace the ones
+// the kb-agent is started with.
+type RefreshRequest struct {
+	Handlers map[string]HandlerSpec `json:"handlers"`
+}
+
 type CronJob struct {
 	PeriodSeconds    int `json:"periodSeconds,omitempty"`
 	SuccessThreshold int `json:"successThreshold,omitempty"`
@@ -39,11 +47,48 @@ type CronJob struct {
 	ReportFrequency  int `json:"reportFrequency,omitempty"`
 }
 
+type RetryPolicy struct {
+	MaxRetries           int `json:"maxRetries,omitempty"`
+	RetryIntervalSeconds int `json:"retryIntervalSeconds,omitempty"`
+}
+
+type ResourceLimits struct {
+	// the maximum CPU time of the action process, in seconds
+	CPUSeconds int64 `json:"cpuSeconds,omitempty"`
+	// the maximum resident memory of the action process, in bytes
+	MemoryBytes int64 `json:"memoryBytes,omitempty"`
+}
+
+type ParameterSpec struct {
+	// the JSON schema type of the parameter, "string" by default
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
 type HandlerSpec struct {
 	TimeoutSeconds int               `json:"timeoutSeconds,omitempty"`
 	Command        []string          `json:"command,omitempty"`
 	GPRC           map[string]string `json:"grpc,omitempty"`
 	CronJob        *CronJob          `json:"cronJob,omitempty"`
+	RetryPolicy    *RetryPolicy      `json:"retryPolicy,omitempty"`
+	Resources      *ResourceLimits   `json:"resources,omitempty"`
+	RunAsUser      *int64            `json:"runAsUser,omitempty"`
+	RunAsGroup     *int64            `json:"runAsGroup,omitempty"`
+	// the parameters accepted by the action, which are exposed in the API schema
+	Parameters map[string]ParameterSpec `json:"parameters,omitempty"`
+}
+
+// Sandbox returns the restrictions to execute the action in, or nil if there is none.
+func (s HandlerSpec) Sandbox() *Sandbox {
+	if s.Resources == nil && s.RunAsUser == nil && s.RunAsGroup == nil {
+		return nil
+	}
+	return &Sandbox{
+		Resources:  s.Resources,
+		RunAsUser:  s.RunAsUser,
+		RunAsGroup: s.RunAsGroup,
+	}
 }
 
 type ActionMessage interface {
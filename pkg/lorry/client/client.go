@@ -22,7 +22,9 @@ package client
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/rest"
@@ -102,6 +104,24 @@ func (cli *lorryClient) GetRole(ctx context.Context) (string, error) {
 	return role.(string), nil
 }
 
+func (cli *lorryClient) GetLag(ctx context.Context) (int64, error) {
+	resp, err := cli.Request(ctx, string(GetLagOperation), http.MethodGet, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	switch lag := resp["lag"].(type) {
+	case float64:
+		return int64(lag), nil
+	case int64:
+		return lag, nil
+	case nil:
+		return 0, errors.New("no lag in response")
+	default:
+		return 0, fmt.Errorf("unexpected lag type %T", lag)
+	}
+}
+
 func (cli *lorryClient) CreateUser(ctx context.Context, userName, password, roleName, statement string) error {
 	parameters := map[string]any{
 		"userName": userName,
@@ -166,6 +186,46 @@ func (cli *lorryClient) RevokeUserRole(ctx context.Context, userName, roleName s
 	return err
 }
 
+func (cli *lorryClient) CreateDatabase(ctx context.Context, databaseName string) error {
+	parameters := map[string]any{
+		"databaseName": databaseName,
+	}
+	req := map[string]any{"parameters": parameters}
+	_, err := cli.Request(ctx, string(CreateDatabaseOp), http.MethodPost, req)
+	return err
+}
+
+func (cli *lorryClient) DropDatabase(ctx context.Context, databaseName string) error {
+	parameters := map[string]any{
+		"databaseName": databaseName,
+	}
+	req := map[string]any{"parameters": parameters}
+	_, err := cli.Request(ctx, string(DropDatabaseOp), http.MethodPost, req)
+	return err
+}
+
+func (cli *lorryClient) GrantDatabaseRole(ctx context.Context, userName, databaseName, roleName string) error {
+	parameters := map[string]any{
+		"userName":     userName,
+		"databaseName": databaseName,
+		"roleName":     roleName,
+	}
+	req := map[string]any{"parameters": parameters}
+	_, err := cli.Request(ctx, string(GrantDatabaseRoleOp), http.MethodPost, req)
+	return err
+}
+
+func (cli *lorryClient) RevokeDatabaseRole(ctx context.Context, userName, databaseName, roleName string) error {
+	parameters := map[string]any{
+		"userName":     userName,
+		"databaseName": databaseName,
+		"roleName":     roleName,
+	}
+	req := map[string]any{"parameters": parameters}
+	_, err := cli.Request(ctx, string(RevokeDatabaseRoleOp), http.MethodPost, req)
+	return err
+}
+
 func (cli *lorryClient) Switchover(ctx context.Context, primary, candidate string, force bool) error {
 	parameters := map[string]any{
 		"primary":   primary,
@@ -215,6 +275,12 @@ func (cli *lorryClient) LeaveMember(ctx context.Context) error {
 	return err
 }
 
+// CheckReadWrite sends a read-write probe request to Lorry.
+func (cli *lorryClient) CheckReadWrite(ctx context.Context) error {
+	_, err := cli.Request(ctx, string(CheckReadWriteOperation), http.MethodPost, nil)
+	return err
+}
+
 // Lock sends a set readonly request to Lorry.
 func (cli *lorryClient) Lock(ctx context.Context) error {
 	_, err := cli.Request(ctx, string(LockOperation), http.MethodPost, nil)
@@ -247,6 +313,23 @@ func (cli *lorryClient) PreTerminate(ctx context.Context) error {
 	return err
 }
 
+// Query sends a read-only sql to Lorry, the timeout is ignored if it is not positive.
+func (cli *lorryClient) Query(ctx context.Context, sql string, timeout time.Duration) (string, error) {
+	parameters := map[string]any{
+		"sql": sql,
+	}
+	if timeout > 0 {
+		parameters["timeout"] = timeout.Seconds()
+	}
+	req := map[string]any{"parameters": parameters}
+	resp, err := cli.Request(ctx, string(QueryOperation), http.MethodPost, req)
+	if err != nil {
+		return "", err
+	}
+	result, _ := resp["result"].(string)
+	return result, nil
+}
+
 // Rebuild sends a slave rebuild request to Lorry.
 func (cli *lorryClient) Rebuild(ctx context.Context) error {
 	_, err := cli.Request(ctx, "rebuild", http.MethodPost, nil)
@@ -28,6 +28,7 @@ package client
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -55,6 +56,34 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// CheckReadWrite mocks base method.
+func (m *MockClient) CheckReadWrite(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckReadWrite", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckReadWrite indicates an expected call of CheckReadWrite.
+func (mr *MockClientMockRecorder) CheckReadWrite(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckReadWrite", reflect.TypeOf((*MockClient)(nil).CheckReadWrite), arg0)
+}
+
+// CreateDatabase mocks base method.
+func (m *MockClient) CreateDatabase(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDatabase", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDatabase indicates an expected call of CreateDatabase.
+func (mr *MockClientMockRecorder) CreateDatabase(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDatabase", reflect.TypeOf((*MockClient)(nil).CreateDatabase), arg0, arg1)
+}
+
 // CreateUser mocks base method.
 func (m *MockClient) CreateUser(arg0 context.Context, arg1, arg2, arg3, arg4 string) error {
 	m.ctrl.T.Helper()
@@ -126,6 +155,35 @@ func (mr *MockClientMockRecorder) DescribeUser(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeUser", reflect.TypeOf((*MockClient)(nil).DescribeUser), arg0, arg1)
 }
 
+// DropDatabase mocks base method.
+func (m *MockClient) DropDatabase(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropDatabase", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DropDatabase indicates an expected call of DropDatabase.
+func (mr *MockClientMockRecorder) DropDatabase(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropDatabase", reflect.TypeOf((*MockClient)(nil).DropDatabase), arg0, arg1)
+}
+
+// GetLag mocks base method.
+func (m *MockClient) GetLag(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLag", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLag indicates an expected call of GetLag.
+func (mr *MockClientMockRecorder) GetLag(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLag", reflect.TypeOf((*MockClient)(nil).GetLag), arg0)
+}
+
 // GetRole mocks base method.
 func (m *MockClient) GetRole(arg0 context.Context) (string, error) {
 	m.ctrl.T.Helper()
@@ -141,6 +199,20 @@ func (mr *MockClientMockRecorder) GetRole(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockClient)(nil).GetRole), arg0)
 }
 
+// GrantDatabaseRole mocks base method.
+func (m *MockClient) GrantDatabaseRole(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantDatabaseRole", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GrantDatabaseRole indicates an expected call of GrantDatabaseRole.
+func (mr *MockClientMockRecorder) GrantDatabaseRole(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantDatabaseRole", reflect.TypeOf((*MockClient)(nil).GrantDatabaseRole), arg0, arg1, arg2, arg3)
+}
+
 // GrantUserRole mocks base method.
 func (m *MockClient) GrantUserRole(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -255,6 +327,21 @@ func (mr *MockClientMockRecorder) PreTerminate(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreTerminate", reflect.TypeOf((*MockClient)(nil).PreTerminate), arg0)
 }
 
+// Query mocks base method.
+func (m *MockClient) Query(arg0 context.Context, arg1 string, arg2 time.Duration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockClientMockRecorder) Query(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockClient)(nil).Query), arg0, arg1, arg2)
+}
+
 // Rebuild mocks base method.
 func (m *MockClient) Rebuild(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -269,6 +356,20 @@ func (mr *MockClientMockRecorder) Rebuild(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rebuild", reflect.TypeOf((*MockClient)(nil).Rebuild), arg0)
 }
 
+// RevokeDatabaseRole mocks base method.
+func (m *MockClient) RevokeDatabaseRole(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeDatabaseRole", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeDatabaseRole indicates an expected call of RevokeDatabaseRole.
+func (mr *MockClientMockRecorder) RevokeDatabaseRole(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeDatabaseRole", reflect.TypeOf((*MockClient)(nil).RevokeDatabaseRole), arg0, arg1, arg2, arg3)
+}
+
 // RevokeUserRole mocks base method.
 func (m *MockClient) RevokeUserRole(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
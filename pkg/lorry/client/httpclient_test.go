@@ -174,6 +174,29 @@ var _ = Describe("Lorry HTTP Client", func() {
 		})
 	})
 
+	Context("get replication lag", func() {
+		var lorryClient *HTTPClient
+
+		BeforeEach(func() {
+			lorryClient, _ = NewHTTPClientWithPod(pod)
+			Expect(lorryClient).ShouldNot(BeNil())
+		})
+
+		It("success", func() {
+			mockDBManager.EXPECT().GetLag(gomock.Any(), gomock.Any()).Return(int64(10), nil)
+			mockDCSStore.EXPECT().GetClusterFromCache().Return(&dcs.Cluster{})
+			Expect(lorryClient.GetLag(context.TODO())).Should(Equal(int64(10)))
+		})
+
+		It("failed", func() {
+			mockDBManager.EXPECT().GetLag(gomock.Any(), gomock.Any()).Return(int64(0), fmt.Errorf(msg))
+			mockDCSStore.EXPECT().GetClusterFromCache().Return(&dcs.Cluster{})
+			_, err := lorryClient.GetLag(context.TODO())
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring(msg))
+		})
+	})
+
 	Context("list system accounts", func() {
 		var lorryClient *HTTPClient
 		var systemAccounts []models.UserInfo
@@ -19,12 +19,21 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 
 package client
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type Client interface {
 	// GetRole return the replication role(like primary/secondary) of the target replica
 	GetRole(ctx context.Context) (string, error)
 
+	// GetLag return the replication lag of the target replica
+	GetLag(ctx context.Context) (int64, error)
+
+	// CheckReadWrite writes a probe record on the target replica and reads it back
+	CheckReadWrite(ctx context.Context) error
+
 	// user management funcs
 	CreateUser(ctx context.Context, userName, password, roleName, statement string) error
 	DeleteUser(ctx context.Context, userName string) error
@@ -34,6 +43,12 @@ type Client interface {
 	ListUsers(ctx context.Context) ([]map[string]any, error)
 	ListSystemAccounts(ctx context.Context) ([]map[string]any, error)
 
+	// database management funcs
+	CreateDatabase(ctx context.Context, databaseName string) error
+	DropDatabase(ctx context.Context, databaseName string) error
+	GrantDatabaseRole(ctx context.Context, userName, databaseName, roleName string) error
+	RevokeDatabaseRole(ctx context.Context, userName, databaseName, roleName string) error
+
 	// JoinMember sends a join member operation request to Lorry, located on the target pod that is about to join.
 	JoinMember(ctx context.Context) error
 
@@ -46,6 +61,9 @@ type Client interface {
 	PostProvision(ctx context.Context, componentNames, podNames, podIPs, podHostNames, podHostIPs string) error
 	PreTerminate(ctx context.Context) error
 
+	// Query executes a read-only sql on the target replica, and returns the result in JSON
+	Query(ctx context.Context, sql string, timeout time.Duration) (string, error)
+
 	// local rebuild slave
 	Rebuild(ctx context.Context) error
 	DataDump(ctx context.Context) error
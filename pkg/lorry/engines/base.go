@@ -230,6 +230,22 @@ func (mgr *DBManagerBase) RevokeUserRole(context.Context, string, string) error
 	return models.ErrNotImplemented
 }
 
+func (mgr *DBManagerBase) CreateDatabase(context.Context, string) error {
+	return models.ErrNotImplemented
+}
+
+func (mgr *DBManagerBase) DropDatabase(context.Context, string) error {
+	return models.ErrNotImplemented
+}
+
+func (mgr *DBManagerBase) GrantDatabaseRole(context.Context, string, string, string) error {
+	return models.ErrNotImplemented
+}
+
+func (mgr *DBManagerBase) RevokeDatabaseRole(context.Context, string, string, string) error {
+	return models.ErrNotImplemented
+}
+
 func (mgr *DBManagerBase) IsRunning() bool {
 	return false
 }
@@ -147,7 +147,9 @@ func (mgr *Manager) JoinCurrentMemberToCluster(ctx context.Context, cluster *dcs
 	if member != nil {
 		envs = append(envs, "KB_NEW_MEMBER_POD_IP"+"="+member.PodIP)
 	}
+	startTime := time.Now()
 	output, err := util.ExecCommand(ctx, memberJoinCmd, envs)
+	util.SendActionExecutionEvent(ctx, constant.MemberJoinAction, startTime, output, err)
 
 	if output != "" {
 		mgr.Logger.Info("member join", "output", output)
@@ -184,7 +186,9 @@ func (mgr *Manager) LeaveMemberFromCluster(ctx context.Context, cluster *dcs.Clu
 	if member != nil {
 		envs = append(envs, "KB_LEAVE_MEMBER_POD_IP"+"="+member.PodIP)
 	}
+	startTime := time.Now()
 	output, err := util.ExecCommand(ctx, memberLeaveCmd, envs)
+	util.SendActionExecutionEvent(ctx, constant.MemberLeaveAction, startTime, output, err)
 
 	if output != "" {
 		mgr.Logger.Info("member leave", "output", output)
@@ -311,7 +315,9 @@ func (mgr *Manager) PostProvision(ctx context.Context, componentNames, podNames,
 	envs = append(envs, "KB_CLUSTER_COMPONENT_POD_IP_LIST"+"="+podIPs)
 	envs = append(envs, "KB_CLUSTER_COMPONENT_POD_HOST_NAME_LIST"+"="+podHostNames)
 	envs = append(envs, "KB_CLUSTER_COMPONENT_POD_HOST_IP_LIST"+"="+podHostIPs)
+	startTime := time.Now()
 	output, err := util.ExecCommand(ctx, postProvisionCmd, envs)
+	util.SendActionExecutionEvent(ctx, constant.PostProvisionAction, startTime, output, err)
 
 	if output != "" {
 		mgr.Logger.Info("component postprovision", "output", output)
@@ -335,7 +341,9 @@ func (mgr *Manager) PreTerminate(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	startTime := time.Now()
 	output, err := util.ExecCommand(ctx, preTerminateCmd, envs)
+	util.SendActionExecutionEvent(ctx, constant.PreTerminateAction, startTime, output, err)
 
 	if output != "" {
 		mgr.Logger.Info("component preterminate", "output", output)
@@ -59,6 +59,20 @@ func (m *MockDBManager) EXPECT() *MockDBManagerMockRecorder {
 	return m.recorder
 }
 
+// CreateDatabase mocks base method.
+func (m *MockDBManager) CreateDatabase(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDatabase", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDatabase indicates an expected call of CreateDatabase.
+func (mr *MockDBManagerMockRecorder) CreateDatabase(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDatabase", reflect.TypeOf((*MockDBManager)(nil).CreateDatabase), arg0, arg1)
+}
+
 // CreateRoot mocks base method.
 func (m *MockDBManager) CreateRoot(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -144,6 +158,20 @@ func (mr *MockDBManagerMockRecorder) DescribeUser(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeUser", reflect.TypeOf((*MockDBManager)(nil).DescribeUser), arg0, arg1)
 }
 
+// DropDatabase mocks base method.
+func (m *MockDBManager) DropDatabase(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropDatabase", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DropDatabase indicates an expected call of DropDatabase.
+func (mr *MockDBManagerMockRecorder) DropDatabase(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropDatabase", reflect.TypeOf((*MockDBManager)(nil).DropDatabase), arg0, arg1)
+}
+
 // Exec mocks base method.
 func (m *MockDBManager) Exec(arg0 context.Context, arg1 string) (int64, error) {
 	m.ctrl.T.Helper()
@@ -274,6 +302,20 @@ func (mr *MockDBManagerMockRecorder) GetReplicaRole(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReplicaRole", reflect.TypeOf((*MockDBManager)(nil).GetReplicaRole), arg0, arg1)
 }
 
+// GrantDatabaseRole mocks base method.
+func (m *MockDBManager) GrantDatabaseRole(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantDatabaseRole", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GrantDatabaseRole indicates an expected call of GrantDatabaseRole.
+func (mr *MockDBManagerMockRecorder) GrantDatabaseRole(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantDatabaseRole", reflect.TypeOf((*MockDBManager)(nil).GrantDatabaseRole), arg0, arg1, arg2, arg3)
+}
+
 // GrantUserRole mocks base method.
 func (m *MockDBManager) GrantUserRole(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -674,6 +716,20 @@ func (mr *MockDBManagerMockRecorder) Recover(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recover", reflect.TypeOf((*MockDBManager)(nil).Recover), arg0, arg1)
 }
 
+// RevokeDatabaseRole mocks base method.
+func (m *MockDBManager) RevokeDatabaseRole(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeDatabaseRole", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeDatabaseRole indicates an expected call of RevokeDatabaseRole.
+func (mr *MockDBManagerMockRecorder) RevokeDatabaseRole(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeDatabaseRole", reflect.TypeOf((*MockDBManager)(nil).RevokeDatabaseRole), arg0, arg1, arg2, arg3)
+}
+
 // RevokeUserRole mocks base method.
 func (m *MockDBManager) RevokeUserRole(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
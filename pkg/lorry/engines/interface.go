@@ -118,6 +118,12 @@ type DBManager interface {
 	GrantUserRole(context.Context, string, string) error
 	RevokeUserRole(context.Context, string, string) error
 
+	// database management
+	CreateDatabase(context.Context, string) error
+	DropDatabase(context.Context, string) error
+	GrantDatabaseRole(context.Context, string, string, string) error
+	RevokeDatabaseRole(context.Context, string, string, string) error
+
 	GetPort() (int, error)
 
 	MoveData(context.Context, *dcs.Cluster) error
@@ -126,3 +132,15 @@ type DBManager interface {
 
 	ShutDownWithWait()
 }
+
+// ReadWriteProber is implemented by the engines that can verify the local
+// replica actually accepts a write and serves it back.
+type ReadWriteProber interface {
+	ProbeReadWrite(context.Context) error
+}
+
+// ReadOnlyQuerier is implemented by the engines that can run a query in a
+// read-only transaction, so that the engine rejects any write of the query.
+type ReadOnlyQuerier interface {
+	QueryReadOnly(context.Context, string) ([]byte, error)
+}
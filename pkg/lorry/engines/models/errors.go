@@ -28,6 +28,7 @@ const (
 	errMsgNoRoleName      = "no rolename provided"
 	errMsgInvalidRoleName = "invalid rolename, should be one of [superuser, readwrite, readonly]"
 	errMsgNoSuchUser      = "no such user"
+	errMsgNoDatabaseName  = "no database name provided"
 	errMsgNotImplemented  = "not implemented"
 )
 
@@ -38,5 +39,6 @@ var (
 	ErrNoRoleName      = fmt.Errorf(errMsgNoRoleName)
 	ErrInvalidRoleName = fmt.Errorf(errMsgInvalidRoleName)
 	ErrNoSuchUser      = fmt.Errorf(errMsgNoSuchUser)
+	ErrNoDatabaseName  = fmt.Errorf(errMsgNoDatabaseName)
 	ErrNotImplemented  = fmt.Errorf(errMsgNotImplemented)
 )
@@ -0,0 +1,101 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/apecloud/kubeblocks/pkg/lorry/engines/models"
+)
+
+const (
+	createDatabaseSQL = "CREATE DATABASE IF NOT EXISTS %s;"
+	dropDatabaseSQL   = "DROP DATABASE IF EXISTS %s;"
+	grantDatabaseSQL  = "GRANT %s ON %s.* TO '%s'@'%%';"
+	revokeDatabaseSQL = "REVOKE %s ON %s.* FROM '%s'@'%%';"
+
+	databaseSuperUserPriv = "ALL PRIVILEGES"
+	databaseReadWritePriv = "SELECT, INSERT, UPDATE, DELETE, CREATE TEMPORARY TABLES, LOCK TABLES, EXECUTE"
+	databaseReadOnlyPriv  = "SELECT, SHOW VIEW"
+)
+
+func (mgr *Manager) CreateDatabase(ctx context.Context, database string) error {
+	sql := fmt.Sprintf(createDatabaseSQL, quoteIdentifier(database))
+	if _, err := mgr.Exec(ctx, sql); err != nil {
+		mgr.Logger.Error(err, "execute sql failed", "sql", sql)
+		return err
+	}
+	return nil
+}
+
+func (mgr *Manager) DropDatabase(ctx context.Context, database string) error {
+	sql := fmt.Sprintf(dropDatabaseSQL, quoteIdentifier(database))
+	if _, err := mgr.Exec(ctx, sql); err != nil {
+		mgr.Logger.Error(err, "execute sql failed", "sql", sql)
+		return err
+	}
+	return nil
+}
+
+// GrantDatabaseRole grants the privileges of the role on the objects of the database to the user.
+func (mgr *Manager) GrantDatabaseRole(ctx context.Context, userName, database, roleName string) error {
+	privileges, err := role2DatabasePriv(roleName)
+	if err != nil {
+		return err
+	}
+	sql := fmt.Sprintf(grantDatabaseSQL, privileges, quoteIdentifier(database), userName)
+	if _, err = mgr.Exec(ctx, sql); err != nil {
+		mgr.Logger.Error(err, "execute sql failed", "sql", sql)
+		return err
+	}
+	return nil
+}
+
+// RevokeDatabaseRole revokes the privileges of the role on the objects of the database from the user.
+func (mgr *Manager) RevokeDatabaseRole(ctx context.Context, userName, database, roleName string) error {
+	privileges, err := role2DatabasePriv(roleName)
+	if err != nil {
+		return err
+	}
+	sql := fmt.Sprintf(revokeDatabaseSQL, privileges, quoteIdentifier(database), userName)
+	if _, err = mgr.Exec(ctx, sql); err != nil {
+		mgr.Logger.Error(err, "execute sql failed", "sql", sql)
+		return err
+	}
+	return nil
+}
+
+func role2DatabasePriv(roleName string) (string, error) {
+	switch models.String2RoleType(roleName) {
+	case models.SuperUserRole:
+		return databaseSuperUserPriv, nil
+	case models.ReadWriteRole:
+		return databaseReadWritePriv, nil
+	case models.ReadOnlyRole:
+		return databaseReadOnlyPriv, nil
+	}
+	return "", fmt.Errorf("role name: %s is not supported", roleName)
+}
+
+func quoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
@@ -0,0 +1,73 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManager_CreateDatabase(t *testing.T) {
+	ctx := context.TODO()
+	manager, mock, _ := mockDatabase(t)
+
+	mock.ExpectExec(regexp.QuoteMeta("CREATE DATABASE IF NOT EXISTS `my``db`;")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.Nil(t, manager.CreateDatabase(ctx, "my`db"))
+
+	mock.ExpectExec(regexp.QuoteMeta("DROP DATABASE IF EXISTS `mydb`;")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.Nil(t, manager.DropDatabase(ctx, "mydb"))
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestManager_GrantDatabaseRole(t *testing.T) {
+	ctx := context.TODO()
+	manager, mock, _ := mockDatabase(t)
+
+	t.Run("grant readonly role", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta("GRANT SELECT, SHOW VIEW ON `mydb`.* TO 'app'@'%';")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		assert.Nil(t, manager.GrantDatabaseRole(ctx, "app", "mydb", "readonly"))
+	})
+
+	t.Run("revoke superuser role", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta("REVOKE ALL PRIVILEGES ON `mydb`.* FROM 'app'@'%';")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		assert.Nil(t, manager.RevokeDatabaseRole(ctx, "app", "mydb", "superuser"))
+	})
+
+	t.Run("unsupported role", func(t *testing.T) {
+		err := manager.GrantDatabaseRole(ctx, "app", "mydb", "custom")
+		assert.NotNil(t, err)
+		assert.ErrorContains(t, err, "is not supported")
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
@@ -232,6 +232,22 @@ func (mgr *Manager) IsMemberLagging(ctx context.Context, cluster *dcs.Cluster, m
 	return true, lag
 }
 
+// GetLag returns the replication lag of the current member behind the leader, which is measured by the
+// timestamps written to the health check table.
+func (mgr *Manager) GetLag(ctx context.Context, cluster *dcs.Cluster) (int64, error) {
+	if cluster == nil || cluster.Leader == nil || cluster.Leader.DBState == nil {
+		return 0, errors.New("no leader DBState info")
+	}
+	if cluster.Leader.Name == mgr.CurrentMemberName {
+		return 0, nil
+	}
+	opTimestamp, err := mgr.GetOpTimestamp(ctx, mgr.DB)
+	if err != nil {
+		return 0, errors.Wrap(err, "get op timestamp failed")
+	}
+	return max(cluster.Leader.DBState.OpTimestamp-opTimestamp, 0), nil
+}
+
 func (mgr *Manager) IsMemberHealthy(ctx context.Context, cluster *dcs.Cluster, member *dcs.Member) bool {
 	db, err := mgr.GetMemberConnection(cluster, member)
 	if err != nil {
@@ -362,6 +378,14 @@ func (mgr *Manager) ReadCheck(ctx context.Context, db *sql.DB) error {
 	return nil
 }
 
+// ProbeReadWrite writes the health check record and reads it back.
+func (mgr *Manager) ProbeReadWrite(ctx context.Context) error {
+	if err := mgr.WriteCheck(ctx, mgr.DB); err != nil {
+		return err
+	}
+	return mgr.ReadCheck(ctx, mgr.DB)
+}
+
 func (mgr *Manager) GetOpTimestamp(ctx context.Context, db *sql.DB) (int64, error) {
 	readSQL := fmt.Sprintf(`select check_ts from kubeblocks.kb_health_check where type=%d limit 1;`, engines.CheckStatusType)
 	var opTimestamp int64
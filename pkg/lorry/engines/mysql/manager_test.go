@@ -313,6 +313,48 @@ func TestManager_IsMemberLagging(t *testing.T) {
 	}
 }
 
+func TestManager_GetLag(t *testing.T) {
+	ctx := context.TODO()
+	manager, mock, _ := mockDatabase(t)
+	cluster := &dcs.Cluster{Leader: &dcs.Leader{}, HaConfig: &dcs.HaConfig{}}
+
+	t.Run("No leader DBState info", func(t *testing.T) {
+		_, err := manager.GetLag(ctx, cluster)
+		assert.NotNil(t, err)
+	})
+
+	cluster.Leader.DBState = &dcs.DBState{OpTimestamp: 100}
+	t.Run("current member is leader", func(t *testing.T) {
+		cluster.Leader.Name = fakePodName
+		defer func() { cluster.Leader.Name = "" }()
+
+		lag, err := manager.GetLag(ctx, cluster)
+		assert.Nil(t, err)
+		assert.Zero(t, lag)
+	})
+
+	t.Run("get op timestamp failed", func(t *testing.T) {
+		mock.ExpectQuery("select check_ts").
+			WillReturnError(fmt.Errorf("some error"))
+
+		_, err := manager.GetLag(ctx, cluster)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("member is lagging", func(t *testing.T) {
+		mock.ExpectQuery("select check_ts").
+			WillReturnRows(sqlmock.NewRows([]string{"check_ts"}).AddRow(40))
+
+		lag, err := manager.GetLag(ctx, cluster)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(60), lag)
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
 func TestManager_IsMemberHealthy(t *testing.T) {
 	ctx := context.TODO()
 	manager, mock, _ := mockDatabase(t)
@@ -21,6 +21,7 @@ package mysql
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 
 	"github.com/pkg/errors"
@@ -43,6 +44,31 @@ func (mgr *Manager) Query(ctx context.Context, sql string) ([]byte, error) {
 	return result, nil
 }
 
+// QueryReadOnly runs the query in a read-only transaction, which is rolled back after the rows are read.
+func (mgr *Manager) QueryReadOnly(ctx context.Context, stmt string) ([]byte, error) {
+	mgr.Logger.Info(fmt.Sprintf("read-only query: %s", stmt))
+	tx, err := mgr.DB.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "error starting read-only transaction")
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	rows, err := tx.QueryContext(ctx, stmt)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error executing %s", stmt)
+	}
+	defer func() {
+		_ = rows.Close()
+		_ = rows.Err()
+	}()
+	result, err := jsonify(rows)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error marshalling query result for %s", stmt)
+	}
+	return result, nil
+}
+
 func (mgr *Manager) Exec(ctx context.Context, sql string) (int64, error) {
 	mgr.Logger.Info(fmt.Sprintf("exec: %s", sql))
 	res, err := mgr.DB.ExecContext(ctx, sql)
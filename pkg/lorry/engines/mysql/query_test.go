@@ -87,6 +87,17 @@ func TestQuery(t *testing.T) {
 	})
 }
 
+func TestQueryReadOnly(t *testing.T) {
+	manager, mock, _ := mockDatabase(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT \\* FROM foo").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectRollback()
+	ret, err := manager.QueryReadOnly(context.Background(), "SELECT * FROM foo")
+	assert.Nil(t, err)
+	assert.Contains(t, string(ret), "\"id\"")
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
 func TestExec(t *testing.T) {
 	manager, mock, _ := mockDatabase(t)
 	mock.ExpectExec("INSERT INTO foo \\(id, v1, ts\\) VALUES \\(.*\\)").WillReturnResult(sqlmock.NewResult(1, 1))
@@ -296,6 +296,22 @@ func (mgr *Manager) IsMemberLagging(ctx context.Context, cluster *dcs.Cluster, m
 	return healthStatus.LogDelayNum > cluster.HaConfig.GetMaxLagOnSwitchover(), healthStatus.LogDelayNum
 }
 
+// GetLag returns the replication lag of the current member reported by the consensus cluster, in number of logs.
+func (mgr *Manager) GetLag(ctx context.Context, cluster *dcs.Cluster) (int64, error) {
+	if cluster == nil {
+		return 0, errors.New("no cluster info")
+	}
+	member := cluster.GetMemberWithName(mgr.CurrentMemberName)
+	if member == nil {
+		return 0, errors.Errorf("member %s not found in cluster", mgr.CurrentMemberName)
+	}
+	healthStatus, err := mgr.getMemberHealthStatus(ctx, cluster, member)
+	if err != nil {
+		return 0, err
+	}
+	return healthStatus.LogDelayNum, nil
+}
+
 func (mgr *Manager) JoinCurrentMemberToCluster(ctx context.Context, cluster *dcs.Cluster) error {
 	// use the env KB_POD_FQDN consistently with the startup script
 	sql := fmt.Sprintf(`alter system consensus add follower '%s:%d';`,
@@ -0,0 +1,151 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/apecloud/kubeblocks/pkg/lorry/engines/models"
+)
+
+const (
+	createDatabaseTpl = "CREATE DATABASE %s;"
+	dropDatabaseTpl   = "DROP DATABASE IF EXISTS %s;"
+
+	// duplicateDatabaseCode is the SQLSTATE returned when the database to create already exists.
+	duplicateDatabaseCode = "42P04"
+)
+
+func (mgr *Manager) CreateDatabase(ctx context.Context, database string) error {
+	sql := fmt.Sprintf(createDatabaseTpl, pgx.Identifier{database}.Sanitize())
+	_, err := mgr.Exec(ctx, sql)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == duplicateDatabaseCode {
+		return nil
+	}
+	if err != nil {
+		mgr.Logger.Error(err, "execute sql failed", "sql", sql)
+		return err
+	}
+	return nil
+}
+
+func (mgr *Manager) DropDatabase(ctx context.Context, database string) error {
+	sql := fmt.Sprintf(dropDatabaseTpl, pgx.Identifier{database}.Sanitize())
+	if _, err := mgr.Exec(ctx, sql); err != nil {
+		mgr.Logger.Error(err, "execute sql failed", "sql", sql)
+		return err
+	}
+	return nil
+}
+
+// GrantDatabaseRole grants the privileges of the role on the objects of the database to the user,
+// the privileges on the schema objects are granted through a connection to the database.
+func (mgr *Manager) GrantDatabaseRole(ctx context.Context, userName, database, roleName string) error {
+	onDatabase, inDatabase, err := databaseRoleStmts(userName, database, roleName, true)
+	if err != nil {
+		return err
+	}
+	if _, err = mgr.Exec(ctx, onDatabase); err != nil {
+		mgr.Logger.Error(err, "execute sql failed", "sql", onDatabase)
+		return err
+	}
+	if err = mgr.execInDatabase(ctx, database, inDatabase); err != nil {
+		mgr.Logger.Error(err, "execute sql failed", "database", database, "sql", inDatabase)
+		return err
+	}
+	return nil
+}
+
+// RevokeDatabaseRole revokes the privileges of the role on the objects of the database from the user.
+func (mgr *Manager) RevokeDatabaseRole(ctx context.Context, userName, database, roleName string) error {
+	onDatabase, inDatabase, err := databaseRoleStmts(userName, database, roleName, false)
+	if err != nil {
+		return err
+	}
+	if err = mgr.execInDatabase(ctx, database, inDatabase); err != nil {
+		mgr.Logger.Error(err, "execute sql failed", "database", database, "sql", inDatabase)
+		return err
+	}
+	if _, err = mgr.Exec(ctx, onDatabase); err != nil {
+		mgr.Logger.Error(err, "execute sql failed", "sql", onDatabase)
+		return err
+	}
+	return nil
+}
+
+// execInDatabase executes the sql through a connection to the database other than the one of the pool.
+func (mgr *Manager) execInDatabase(ctx context.Context, database, sql string) error {
+	connConfig := config.pgxConfig.ConnConfig.Copy()
+	connConfig.Database = database
+	conn, err := pgx.ConnectConfig(ctx, connConfig)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = conn.Close(ctx)
+	}()
+	_, err = conn.Exec(ctx, sql)
+	return err
+}
+
+// databaseRoleStmts renders the statements to grant or revoke the role on the database, one to execute on the database
+// itself, and the other to execute in the database on the objects of the public schema.
+func databaseRoleStmts(userName, database, roleName string, grant bool) (string, string, error) {
+	user, db := pgx.Identifier{userName}.Sanitize(), pgx.Identifier{database}.Sanitize()
+	var tablePrivs, seqPrivs string
+	switch models.String2RoleType(roleName) {
+	case models.SuperUserRole:
+		if grant {
+			return fmt.Sprintf("ALTER DATABASE %s OWNER TO %s;", db, user),
+				fmt.Sprintf("ALTER SCHEMA public OWNER TO %s;", user), nil
+		}
+		return fmt.Sprintf("ALTER DATABASE %s OWNER TO CURRENT_USER;", db),
+			"ALTER SCHEMA public OWNER TO CURRENT_USER;", nil
+	case models.ReadWriteRole:
+		tablePrivs, seqPrivs = "SELECT, INSERT, UPDATE, DELETE", "USAGE, SELECT"
+	case models.ReadOnlyRole:
+		tablePrivs, seqPrivs = "SELECT", "SELECT"
+	default:
+		return "", "", fmt.Errorf("role name: %s is not supported", roleName)
+	}
+	if grant {
+		return fmt.Sprintf("GRANT CONNECT ON DATABASE %s TO %s;", db, user), strings.Join([]string{
+			fmt.Sprintf("GRANT USAGE ON SCHEMA public TO %s;", user),
+			fmt.Sprintf("GRANT %s ON ALL TABLES IN SCHEMA public TO %s;", tablePrivs, user),
+			fmt.Sprintf("GRANT %s ON ALL SEQUENCES IN SCHEMA public TO %s;", seqPrivs, user),
+			fmt.Sprintf("ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT %s ON TABLES TO %s;", tablePrivs, user),
+			fmt.Sprintf("ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT %s ON SEQUENCES TO %s;", seqPrivs, user),
+		}, " "), nil
+	}
+	return fmt.Sprintf("REVOKE CONNECT ON DATABASE %s FROM %s;", db, user), strings.Join([]string{
+		fmt.Sprintf("ALTER DEFAULT PRIVILEGES IN SCHEMA public REVOKE %s ON SEQUENCES FROM %s;", seqPrivs, user),
+		fmt.Sprintf("ALTER DEFAULT PRIVILEGES IN SCHEMA public REVOKE %s ON TABLES FROM %s;", tablePrivs, user),
+		fmt.Sprintf("REVOKE %s ON ALL SEQUENCES IN SCHEMA public FROM %s;", seqPrivs, user),
+		fmt.Sprintf("REVOKE %s ON ALL TABLES IN SCHEMA public FROM %s;", tablePrivs, user),
+		fmt.Sprintf("REVOKE USAGE ON SCHEMA public FROM %s;", user),
+	}, " "), nil
+}
@@ -169,6 +169,17 @@ func (mgr *Manager) WriteCheck(ctx context.Context, host string) bool {
 	return true
 }
 
+// ProbeReadWrite writes the health check record and reads it back on the local member.
+func (mgr *Manager) ProbeReadWrite(ctx context.Context) error {
+	if !mgr.WriteCheck(ctx, "") {
+		return errors.New("write check failed")
+	}
+	if !mgr.ReadCheck(ctx, "") {
+		return errors.New("read check failed")
+	}
+	return nil
+}
+
 func (mgr *Manager) PgReload(ctx context.Context) error {
 	reload := "select pg_reload_conf();"
 
@@ -282,6 +282,31 @@ func (mgr *Manager) IsMemberLagging(ctx context.Context, cluster *dcs.Cluster, m
 	return cluster.Leader.DBState.OpTimestamp-walPosition > maxLag, cluster.Leader.DBState.OpTimestamp - walPosition
 }
 
+// GetLag returns the replication lag of the current member behind the leader, in bytes of the WAL.
+func (mgr *Manager) GetLag(ctx context.Context, cluster *dcs.Cluster) (int64, error) {
+	if cluster == nil || cluster.Leader == nil || cluster.Leader.DBState == nil {
+		return 0, errors.New("no leader DBState info")
+	}
+	if cluster.Leader.Name == mgr.CurrentMemberName {
+		return 0, nil
+	}
+
+	timeLine := mgr.getTimeLineWithHost(ctx, "")
+	if timeLine == 0 {
+		return 0, errors.New("get timeline failed")
+	}
+	clusterTimeLine := cast.ToInt64(cluster.Leader.DBState.Extra[postgres.TimeLine])
+	if clusterTimeLine != 0 && clusterTimeLine != timeLine {
+		return 0, errors.Errorf("the timeline %d is different from the leader's %d", timeLine, clusterTimeLine)
+	}
+
+	walPosition, err := mgr.getWalPositionWithHost(ctx, "")
+	if err != nil {
+		return 0, errors.Wrap(err, "get wal position failed")
+	}
+	return max(cluster.Leader.DBState.OpTimestamp-walPosition, 0), nil
+}
+
 // Typically, the synchronous_commit parameter remains consistent between the primary and standby
 func (mgr *Manager) getReplicationMode(ctx context.Context) (string, error) {
 	if mgr.DBState != nil && mgr.DBState.Extra[postgres.ReplicationMode] != "" {
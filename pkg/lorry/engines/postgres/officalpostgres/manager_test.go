@@ -690,6 +690,67 @@ func TestIsMemberLagging(t *testing.T) {
 	}
 }
 
+func TestGetLag(t *testing.T) {
+	ctx := context.TODO()
+	manager, mock, _ := MockDatabase(t)
+	defer mock.Close()
+	cluster := &dcs.Cluster{
+		HaConfig: &dcs.HaConfig{},
+	}
+
+	t.Run("db state is nil", func(t *testing.T) {
+		_, err := manager.GetLag(ctx, cluster)
+		assert.NotNil(t, err)
+	})
+
+	cluster.Leader = &dcs.Leader{
+		DBState: &dcs.DBState{
+			OpTimestamp: 100,
+			Extra: map[string]string{
+				postgres.TimeLine: "1",
+			},
+		},
+	}
+
+	t.Run("current member is leader", func(t *testing.T) {
+		cluster.Leader.Name = manager.CurrentMemberName
+		defer func() { cluster.Leader.Name = "" }()
+
+		lag, err := manager.GetLag(ctx, cluster)
+		assert.Nil(t, err)
+		assert.Zero(t, lag)
+	})
+
+	t.Run("timeline not match", func(t *testing.T) {
+		manager.DBState = &dcs.DBState{
+			OpTimestamp: 40,
+			Extra: map[string]string{
+				postgres.TimeLine: "2",
+			},
+		}
+
+		_, err := manager.GetLag(ctx, cluster)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("current member is lagging", func(t *testing.T) {
+		manager.DBState = &dcs.DBState{
+			OpTimestamp: 40,
+			Extra: map[string]string{
+				postgres.TimeLine: "1",
+			},
+		}
+
+		lag, err := manager.GetLag(ctx, cluster)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(60), lag)
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
 func TestGetCurrentTimeLine(t *testing.T) {
 	ctx := context.TODO()
 	manager, mock, _ := MockDatabase(t)
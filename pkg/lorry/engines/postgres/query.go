@@ -64,6 +64,31 @@ func (mgr *Manager) QueryWithHost(ctx context.Context, sql string, host string)
 	return result, nil
 }
 
+// QueryReadOnly runs the query in a read-only transaction, which is rolled back after the rows are read.
+func (mgr *Manager) QueryReadOnly(ctx context.Context, sql string) (result []byte, err error) {
+	tx, err := mgr.Pool.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	if err != nil {
+		mgr.Logger.Error(err, "begin read-only transaction failed")
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	rows, err := tx.Query(ctx, sql)
+	if err != nil {
+		mgr.Logger.Error(err, fmt.Sprintf("query sql:%s failed", sql))
+		return nil, err
+	}
+	defer rows.Close()
+
+	result, err = parseRows(rows)
+	if err != nil {
+		mgr.Logger.Error(err, fmt.Sprintf("parse query:%s failed", sql))
+		return nil, err
+	}
+	return result, nil
+}
+
 func (mgr *Manager) QueryOthers(ctx context.Context, sql string, host string) (rows pgx.Rows, err error) {
 	conn, err := pgx.Connect(ctx, config.GetConnectURLWithHost(host))
 	if err != nil {
@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/pashagolub/pgxmock/v2"
 	"github.com/stretchr/testify/assert"
 
@@ -130,6 +131,20 @@ func TestParseQuery(t *testing.T) {
 	})
 }
 
+func TestQueryReadOnly(t *testing.T) {
+	ctx := context.TODO()
+	manager, mock, _ := MockDatabase(t)
+	defer mock.Close()
+
+	mock.ExpectBeginTx(pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	mock.ExpectQuery("select").
+		WillReturnRows(pgxmock.NewRows([]string{"1"}).AddRow("1"))
+	mock.ExpectRollback()
+	_, err := manager.QueryReadOnly(ctx, queryTest)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
 func TestExec(t *testing.T) {
 	ctx := context.TODO()
 	manager, mock, _ := MockDatabase(t)
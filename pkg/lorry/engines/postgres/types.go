@@ -95,6 +95,7 @@ type PgIFace interface {
 type PgxIFace interface {
 	Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
 	Query(ctx context.Context, query string, args ...interface{}) (pgx.Rows, error)
+	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
 	Ping(ctx context.Context) error
 }
 
@@ -0,0 +1,50 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apecloud/kubeblocks/pkg/lorry/engines/models"
+	"github.com/apecloud/kubeblocks/pkg/lorry/operations"
+)
+
+func TestDatabaseInfoParser(t *testing.T) {
+	req := &operations.OpsRequest{
+		Parameters: map[string]interface{}{
+			"databaseName": "orders",
+			"userName":     "app",
+			"roleName":     "readonly",
+		},
+	}
+
+	dbInfo, err := DatabaseInfoParser(req)
+	assert.Nil(t, err)
+	assert.Equal(t, "orders", dbInfo.DatabaseName)
+	assert.Nil(t, dbInfo.DatabaseNameAndUserRoleValidator())
+
+	dbInfo.RoleName = "custom"
+	assert.Equal(t, models.ErrInvalidRoleName, dbInfo.DatabaseNameAndUserRoleValidator())
+
+	_, err = DatabaseInfoParser(&operations.OpsRequest{})
+	assert.NotNil(t, err)
+}
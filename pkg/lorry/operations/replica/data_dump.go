@@ -23,6 +23,7 @@ import (
 	"context"
 	"encoding/json"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/spf13/viper"
@@ -67,7 +68,7 @@ func (s *dataDump) PreCheck(ctx context.Context, req *operations.OpsRequest) err
 }
 
 func (s *dataDump) Do(ctx context.Context, req *operations.OpsRequest) (*operations.OpsResponse, error) {
-	return nil, doCommonAction(ctx, s.logger, "dataDump", s.Command)
+	return nil, doCommonAction(ctx, s.logger, constant.DataDumpAction, s.Command)
 }
 
 func doCommonAction(ctx context.Context, logger logr.Logger, action string, commands []string) error {
@@ -75,7 +76,9 @@ func doCommonAction(ctx context.Context, logger logr.Logger, action string, comm
 	if err != nil {
 		return err
 	}
+	startTime := time.Now()
 	output, err := util.ExecCommand(ctx, commands, envs)
+	util.SendActionExecutionEvent(ctx, action, startTime, output, err)
 	if output != "" {
 		logger.Info(action, "output", output)
 	}
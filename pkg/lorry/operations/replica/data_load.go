@@ -67,5 +67,5 @@ func (s *dataLoad) PreCheck(ctx context.Context, req *operations.OpsRequest) err
 }
 
 func (s *dataLoad) Do(ctx context.Context, req *operations.OpsRequest) (*operations.OpsResponse, error) {
-	return nil, doCommonAction(ctx, s.logger, "dataLoad", s.Command)
+	return nil, doCommonAction(ctx, s.logger, constant.DataLoadAction, s.Command)
 }
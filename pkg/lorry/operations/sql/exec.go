@@ -66,6 +66,11 @@ func (s *Exec) Do(ctx context.Context, req *operations.OpsRequest) (*operations.
 	if sql == "" {
 		return nil, errors.New("no sql provided")
 	}
+	if err := checkStatements(sql, false); err != nil {
+		return nil, err
+	}
+	ctx, cancel := withTimeout(ctx, req)
+	defer cancel()
 
 	resp := &operations.OpsResponse{
 		Data: map[string]any{},
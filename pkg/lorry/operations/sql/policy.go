@@ -0,0 +1,61 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/apecloud/kubeblocks/pkg/common"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/lorry/operations"
+)
+
+var readOnlyPolicy = common.NewStatementPolicyFromKeywords(common.ReadOnlyStatements...)
+
+// checkStatements checks the sql against the allowlist shared with the DataScript ops,
+// only the read-only statements are allowed for query.
+func checkStatements(sql string, readonly bool) error {
+	if readonly {
+		if err := readOnlyPolicy.Check(sql); err != nil {
+			return err
+		}
+	}
+	return common.NewStatementPolicy(viper.GetString(constant.KBDataScriptAllowedStatements)).Check(sql)
+}
+
+// withTimeout limits the execution by the "timeout" parameter in seconds, if specified.
+func withTimeout(ctx context.Context, req *operations.OpsRequest) (context.Context, context.CancelFunc) {
+	var timeout time.Duration
+	switch v := req.Parameters["timeout"].(type) {
+	case float64:
+		timeout = time.Duration(v * float64(time.Second))
+	case int:
+		timeout = time.Duration(v) * time.Second
+	case string:
+		timeout, _ = time.ParseDuration(v)
+	}
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
@@ -66,10 +66,25 @@ func (s *Query) Do(ctx context.Context, req *operations.OpsRequest) (*operations
 	if sql == "" {
 		return nil, errors.New("no sql provided")
 	}
+	if err := checkStatements(sql, true); err != nil {
+		return nil, err
+	}
+	ctx, cancel := withTimeout(ctx, req)
+	defer cancel()
 
 	resp := operations.NewOpsResponse(util.QueryOperation)
 
-	result, err := s.dbManager.Query(ctx, sql)
+	// run the query in a read-only transaction if the engine supports, so that the writes which aren't
+	// recognized by the statement policy, e.g. by the functions with side effects, are rejected too.
+	var (
+		result []byte
+		err    error
+	)
+	if querier, ok := s.dbManager.(engines.ReadOnlyQuerier); ok {
+		result, err = querier.QueryReadOnly(ctx, sql)
+	} else {
+		result, err = s.dbManager.Query(ctx, sql)
+	}
 	if err != nil {
 		s.logger.Info("executing query error", "error", err)
 		return resp, err
@@ -0,0 +1,90 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+const (
+	// ActionExecutedEventReason is the reason of the events reporting the execution of the lifecycle actions.
+	ActionExecutedEventReason = "actionExecuted"
+
+	// maxActionOutputLength is the max length of the output and error kept in the action execution event.
+	maxActionOutputLength = 256
+)
+
+// ActionExecution is the message of the event reporting an execution of a lifecycle action.
+type ActionExecution struct {
+	Action         string    `json:"action"`
+	StartTime      time.Time `json:"startTime"`
+	DurationMillis int64     `json:"durationMillis"`
+	Succeeded      bool      `json:"succeeded"`
+	Output         string    `json:"output,omitempty"`
+	Error          string    `json:"error,omitempty"`
+}
+
+// ParseActionExecution parses the message of an action execution event.
+func ParseActionExecution(message string) (*ActionExecution, error) {
+	execution := &ActionExecution{}
+	if err := json.Unmarshal([]byte(message), execution); err != nil {
+		return nil, err
+	}
+	return execution, nil
+}
+
+// TruncateActionOutput truncates the output of an action to keep the event and the status small.
+func TruncateActionOutput(output string) string {
+	if len(output) <= maxActionOutputLength {
+		return output
+	}
+	return output[:maxActionOutputLength] + "...(truncated)"
+}
+
+// SendActionExecutionEvent reports an execution of the lifecycle action by an event of the current pod,
+// the event is sent asynchronously and the failure is only logged, it never fails the action.
+func SendActionExecutionEvent(ctx context.Context, action string, startTime time.Time, output string, actionErr error) {
+	execution := ActionExecution{
+		Action:         action,
+		StartTime:      startTime.UTC(),
+		DurationMillis: time.Since(startTime).Milliseconds(),
+		Succeeded:      actionErr == nil,
+		Output:         TruncateActionOutput(output),
+	}
+	if actionErr != nil {
+		execution.Error = TruncateActionOutput(actionErr.Error())
+	}
+	data := map[string]any{}
+	bytes, _ := json.Marshal(execution)
+	if err := json.Unmarshal(bytes, &data); err != nil {
+		logger.Info("marshal action execution failed", "error", err.Error())
+		return
+	}
+	event, err := CreateEvent(ActionExecutedEventReason, data)
+	if err != nil {
+		logger.Info("generate action execution event failed", "error", err.Error())
+		return
+	}
+	go func() {
+		_ = SendEvent(ctx, event)
+	}()
+}
@@ -38,15 +38,16 @@ const (
 	DeleteOperation OperationKind = "delete"
 	ListOperation   OperationKind = "list"
 
-	CheckRunningOperation OperationKind = "checkRunning"
-	HealthyCheckOperation OperationKind = "healthyCheck"
-	CheckRoleOperation    OperationKind = "checkRole"
-	GetRoleOperation      OperationKind = "getRole"
-	GetLagOperation       OperationKind = "getLag"
-	SwitchoverOperation   OperationKind = "switchover"
-	ExecOperation         OperationKind = "exec"
-	QueryOperation        OperationKind = "query"
-	CloseOperation        OperationKind = "close"
+	CheckRunningOperation   OperationKind = "checkRunning"
+	HealthyCheckOperation   OperationKind = "healthyCheck"
+	CheckRoleOperation      OperationKind = "checkRole"
+	GetRoleOperation        OperationKind = "getRole"
+	GetLagOperation         OperationKind = "getLag"
+	CheckReadWriteOperation OperationKind = "checkReadWrite"
+	SwitchoverOperation     OperationKind = "switchover"
+	ExecOperation           OperationKind = "exec"
+	QueryOperation          OperationKind = "query"
+	CloseOperation          OperationKind = "close"
 
 	LockOperation    OperationKind = "lockInstance"
 	UnlockOperation  OperationKind = "unlockInstance"
@@ -65,6 +66,12 @@ const (
 	RevokeUserRoleOp     OperationKind = "revokeUserRole"
 	ListSystemAccountsOp OperationKind = "listSystemAccounts"
 
+	// actions for cluster databases management
+	CreateDatabaseOp     OperationKind = "createDatabase"
+	DropDatabaseOp       OperationKind = "dropDatabase"
+	GrantDatabaseRoleOp  OperationKind = "grantDatabaseRole"
+	RevokeDatabaseRoleOp OperationKind = "revokeDatabaseRole"
+
 	JoinMemberOperation  OperationKind = "joinMember"
 	LeaveMemberOperation OperationKind = "leaveMember"
 
@@ -0,0 +1,126 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metricsadapter
+
+import (
+	"fmt"
+	"os"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+// Aggregation is the function to aggregate the samples of all the instances of a component.
+type Aggregation string
+
+const (
+	SumAggregation Aggregation = "sum"
+	AvgAggregation Aggregation = "avg"
+	MaxAggregation Aggregation = "max"
+	MinAggregation Aggregation = "min"
+)
+
+// MetricRule maps an external metric to the series gathered from the exporters of the components.
+type MetricRule struct {
+	// Name is the name of the external metric, e.g. "mysql-connections".
+	Name string `json:"name"`
+
+	// Series is the name of the Prometheus series exposed by the exporters,
+	// e.g. "mysql_global_status_threads_connected".
+	Series string `json:"series"`
+
+	// MatchLabels filters the samples of the series by their labels.
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+
+	// Rate computes the per-second rate of a counter series between two successive scrapes,
+	// e.g. to derive the QPS from the total number of the queries.
+	Rate bool `json:"rate,omitempty"`
+
+	// Aggregation aggregates the samples of all the instances of a component, defaults to "sum".
+	Aggregation Aggregation `json:"aggregation,omitempty"`
+}
+
+// Rules is the set of the metrics served by the adapter.
+type Rules struct {
+	Metrics []MetricRule `json:"metrics"`
+}
+
+// LoadRules loads the metric rules from a YAML file.
+func LoadRules(path string) (*Rules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	rules := &Rules{}
+	if err = yaml.UnmarshalStrict(data, rules); err != nil {
+		return nil, fmt.Errorf("failed to parse the metric rules in %s: %s", path, err.Error())
+	}
+	if err = rules.validate(); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *Rules) validate() error {
+	names := make(map[string]struct{}, len(r.Metrics))
+	for i := range r.Metrics {
+		rule := &r.Metrics[i]
+		if errs := validation.IsDNS1123Subdomain(rule.Name); len(errs) > 0 {
+			return fmt.Errorf("invalid metric name %q: %v", rule.Name, errs)
+		}
+		if _, ok := names[rule.Name]; ok {
+			return fmt.Errorf("duplicated metric %q", rule.Name)
+		}
+		names[rule.Name] = struct{}{}
+		if len(rule.Series) == 0 {
+			return fmt.Errorf("the series of metric %q is required", rule.Name)
+		}
+		switch rule.Aggregation {
+		case "":
+			rule.Aggregation = SumAggregation
+		case SumAggregation, AvgAggregation, MaxAggregation, MinAggregation:
+		default:
+			return fmt.Errorf("unsupported aggregation %q of metric %q", rule.Aggregation, rule.Name)
+		}
+	}
+	return nil
+}
+
+// aggregate aggregates the values by the aggregation function.
+func (a Aggregation) aggregate(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	result := values[0]
+	for _, v := range values[1:] {
+		switch a {
+		case MaxAggregation:
+			result = max(result, v)
+		case MinAggregation:
+			result = min(result, v)
+		default:
+			result += v
+		}
+	}
+	if a == AvgAggregation {
+		result /= float64(len(values))
+	}
+	return result
+}
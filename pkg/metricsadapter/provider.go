@@ -0,0 +1,235 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metricsadapter
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+// staleCounterSampleTimeout is the duration after which the cached counter samples of a pod are dropped.
+const staleCounterSampleTimeout = 10 * time.Minute
+
+// ExternalMetricValue is the value of an external metric, it mirrors the type of external.metrics.k8s.io/v1beta1.
+type ExternalMetricValue struct {
+	MetricName   string            `json:"metricName"`
+	MetricLabels map[string]string `json:"metricLabels"`
+	Timestamp    metav1.Time       `json:"timestamp"`
+	Value        resource.Quantity `json:"value"`
+}
+
+// ExternalMetricValueList is the list of the values of an external metric.
+type ExternalMetricValueList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ExternalMetricValue `json:"items"`
+}
+
+type counterSample struct {
+	value     float64
+	timestamp time.Time
+}
+
+// Provider computes the external metrics from the exporters of the components.
+type Provider struct {
+	cli     client.Reader
+	scraper Scraper
+	rules   map[string]MetricRule
+
+	mu sync.Mutex
+	// the last samples of the counter series, keyed by the metric and the pod, to compute the rate.
+	counters map[string]counterSample
+}
+
+// NewProvider creates a provider of the metrics defined by the rules.
+func NewProvider(cli client.Reader, scraper Scraper, rules *Rules) *Provider {
+	p := &Provider{
+		cli:      cli,
+		scraper:  scraper,
+		rules:    map[string]MetricRule{},
+		counters: map[string]counterSample{},
+	}
+	for _, rule := range rules.Metrics {
+		p.rules[rule.Name] = rule
+	}
+	return p
+}
+
+// MetricNames returns the names of all the metrics served by the provider.
+func (p *Provider) MetricNames() []string {
+	names := make([]string, 0, len(p.rules))
+	for name := range p.rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetExternalMetric returns the values of the metric, one per component of the pods selected by the selector.
+// The samples of the instances of a component are aggregated by the aggregation function of the metric.
+func (p *Provider) GetExternalMetric(ctx context.Context, namespace, metricName string, selector labels.Selector) ([]ExternalMetricValue, error) {
+	rule, ok := p.rules[metricName]
+	if !ok {
+		return nil, apierrors.NewNotFound(externalMetricsResource(metricName), metricName)
+	}
+	podList := &corev1.PodList{}
+	if err := p.cli.List(ctx, podList, client.InNamespace(namespace),
+		client.MatchingLabelsSelector{Selector: selector},
+		client.MatchingLabels{constant.AppManagedByLabelKey: constant.AppName}); err != nil {
+		return nil, err
+	}
+
+	type componentKey struct {
+		clusterName string
+		compName    string
+	}
+	components := map[componentKey][]*corev1.Pod{}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		key := componentKey{
+			clusterName: pod.Labels[constant.AppInstanceLabelKey],
+			compName:    pod.Labels[constant.KBAppComponentLabelKey],
+		}
+		if len(key.clusterName) == 0 || len(key.compName) == 0 || pod.DeletionTimestamp != nil {
+			continue
+		}
+		components[key] = append(components[key], pod)
+	}
+
+	now := time.Now()
+	values := make([]ExternalMetricValue, 0, len(components))
+	for key, pods := range components {
+		target, err := p.getScrapeTarget(ctx, namespace, key.clusterName, key.compName)
+		if err != nil {
+			return nil, err
+		}
+		if target == nil {
+			continue
+		}
+		samples := p.gatherSamples(ctx, rule, pods, *target, now)
+		if len(samples) == 0 {
+			continue
+		}
+		values = append(values, ExternalMetricValue{
+			MetricName: metricName,
+			MetricLabels: map[string]string{
+				constant.AppInstanceLabelKey:    key.clusterName,
+				constant.KBAppComponentLabelKey: key.compName,
+			},
+			Timestamp: metav1.NewTime(now),
+			Value:     *resource.NewMilliQuantity(int64(rule.Aggregation.aggregate(samples)*1000), resource.DecimalSI),
+		})
+	}
+	sort.Slice(values, func(i, j int) bool {
+		li, lj := values[i].MetricLabels, values[j].MetricLabels
+		if li[constant.AppInstanceLabelKey] != lj[constant.AppInstanceLabelKey] {
+			return li[constant.AppInstanceLabelKey] < lj[constant.AppInstanceLabelKey]
+		}
+		return li[constant.KBAppComponentLabelKey] < lj[constant.KBAppComponentLabelKey]
+	})
+	p.pruneCounters(now)
+	return values, nil
+}
+
+// getScrapeTarget returns the exporter endpoint of the component, or nil if the component has no exporter.
+func (p *Provider) getScrapeTarget(ctx context.Context, namespace, clusterName, compName string) (*ScrapeTarget, error) {
+	svc := &corev1.Service{}
+	svcKey := client.ObjectKey{
+		Namespace: namespace,
+		Name:      constant.GenerateComponentHeadlessServiceName(clusterName, compName, ""),
+	}
+	if err := p.cli.Get(ctx, svcKey, svc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	target, ok := scrapeTargetFromService(svc)
+	if !ok {
+		return nil, nil
+	}
+	return target, nil
+}
+
+// gatherSamples scrapes the pods and returns the sample of each pod, the pods failed to scrape are skipped.
+func (p *Provider) gatherSamples(ctx context.Context, rule MetricRule, pods []*corev1.Pod, target ScrapeTarget, now time.Time) []float64 {
+	var samples []float64
+	for _, pod := range pods {
+		families, err := p.scraper.Scrape(ctx, pod, target)
+		if err != nil {
+			logger.V(1).Info("failed to scrape the metrics", "pod", client.ObjectKeyFromObject(pod), "error", err.Error())
+			continue
+		}
+		family, ok := families[rule.Series]
+		if !ok {
+			continue
+		}
+		value, ok := sampleValue(family, rule.MatchLabels)
+		if !ok {
+			continue
+		}
+		if rule.Rate {
+			if value, ok = p.rate(fmt.Sprintf("%s/%s/%s", rule.Name, pod.Namespace, pod.Name), value, now); !ok {
+				continue
+			}
+		}
+		samples = append(samples, value)
+	}
+	return samples
+}
+
+// rate computes the per-second rate of the counter from the last sample, it returns false for the first sample
+// or if the counter is reset.
+func (p *Provider) rate(key string, value float64, now time.Time) (float64, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	last, ok := p.counters[key]
+	p.counters[key] = counterSample{value: value, timestamp: now}
+	if !ok || value < last.value {
+		return 0, false
+	}
+	seconds := now.Sub(last.timestamp).Seconds()
+	if seconds <= 0 {
+		return 0, false
+	}
+	return (value - last.value) / seconds, true
+}
+
+func (p *Provider) pruneCounters(now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key, sample := range p.counters {
+		if now.Sub(sample.timestamp) > staleCounterSampleTimeout {
+			delete(p.counters, key)
+		}
+	}
+}
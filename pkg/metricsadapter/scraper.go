@@ -0,0 +1,137 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metricsadapter
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/apecloud/kubeblocks/pkg/common"
+)
+
+const scrapeTimeout = 5 * time.Second
+
+// ScrapeTarget is the endpoint of the exporter of a component, which is annotated on the headless Service.
+type ScrapeTarget struct {
+	Scheme string
+	Port   string
+	Path   string
+}
+
+// scrapeTargetFromService returns the scrape target from the monitor annotations of the headless Service.
+func scrapeTargetFromService(svc *corev1.Service) (*ScrapeTarget, bool) {
+	annotations := svc.GetAnnotations()
+	if annotations[common.PrometheusScrapeAnnotationEnabled] != "true" || annotations[common.PrometheusScrapeAnnotationPort] == "" {
+		return nil, false
+	}
+	return &ScrapeTarget{
+		Scheme: annotations[common.PrometheusScrapeAnnotationScheme],
+		Port:   annotations[common.PrometheusScrapeAnnotationPort],
+		Path:   annotations[common.PrometheusScrapeAnnotationPath],
+	}, true
+}
+
+// Scraper gathers the metrics from the exporter of a pod.
+type Scraper interface {
+	Scrape(ctx context.Context, pod *corev1.Pod, target ScrapeTarget) (map[string]*dto.MetricFamily, error)
+}
+
+type httpScraper struct {
+	client *http.Client
+}
+
+// NewHTTPScraper creates a scraper that gathers the metrics in the Prometheus text format.
+func NewHTTPScraper() Scraper {
+	return &httpScraper{client: &http.Client{Timeout: scrapeTimeout}}
+}
+
+func (s *httpScraper) Scrape(ctx context.Context, pod *corev1.Pod, target ScrapeTarget) (map[string]*dto.MetricFamily, error) {
+	if len(pod.Status.PodIP) == 0 {
+		return nil, fmt.Errorf("the pod %s has no IP", pod.Name)
+	}
+	scheme := target.Scheme
+	if len(scheme) == 0 {
+		scheme = "http"
+	}
+	url := fmt.Sprintf("%s://%s%s", scheme, net.JoinHostPort(pod.Status.PodIP, target.Port), target.Path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", string(expfmt.NewFormat(expfmt.TypeTextPlain)))
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to scrape %s: %s", url, resp.Status)
+	}
+	parser := expfmt.TextParser{}
+	return parser.TextToMetricFamilies(resp.Body)
+}
+
+// sampleValue sums the values of the samples in the family which match the labels.
+func sampleValue(family *dto.MetricFamily, matchLabels map[string]string) (float64, bool) {
+	var (
+		value float64
+		found bool
+	)
+	for _, metric := range family.GetMetric() {
+		if !matchMetricLabels(metric, matchLabels) {
+			continue
+		}
+		switch {
+		case metric.GetGauge() != nil:
+			value += metric.GetGauge().GetValue()
+		case metric.GetCounter() != nil:
+			value += metric.GetCounter().GetValue()
+		case metric.GetUntyped() != nil:
+			value += metric.GetUntyped().GetValue()
+		default:
+			continue
+		}
+		found = true
+	}
+	return value, found
+}
+
+func matchMetricLabels(metric *dto.Metric, matchLabels map[string]string) bool {
+	if len(matchLabels) == 0 {
+		return true
+	}
+	labels := make(map[string]string, len(metric.GetLabel()))
+	for _, label := range metric.GetLabel() {
+		labels[label.GetName()] = label.GetValue()
+	}
+	for k, v := range matchLabels {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
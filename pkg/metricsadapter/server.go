@@ -0,0 +1,232 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metricsadapter
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	// GroupName is the API group of the external metrics.
+	GroupName = "external.metrics.k8s.io"
+	// Version is the API version of the external metrics.
+	Version = "v1beta1"
+
+	apisPath      = "/apis"
+	groupPath     = apisPath + "/" + GroupName
+	versionPath   = groupPath + "/" + Version
+	namespacePath = versionPath + "/namespaces/"
+	healthzPath   = "/healthz"
+)
+
+var logger = ctrl.Log.WithName("metrics-adapter")
+
+func externalMetricsResource(metricName string) schema.GroupResource {
+	return schema.GroupResource{Group: GroupName, Resource: metricName}
+}
+
+// Config is the configuration of the adapter server.
+type Config struct {
+	Address     string
+	TLSCertFile string
+	TLSKeyFile  string
+	// ClientCA is the PEM encoded CA bundle to verify the client certificates of the kube-apiserver,
+	// which proxies the requests of the aggregated API to the adapter.
+	ClientCA []byte
+}
+
+// Server serves the engine metrics as the external metrics API, so that HPA, KEDA and the autoscaling
+// policies can scale the components by the DB-level signals.
+type Server struct {
+	config Config
+	server *http.Server
+}
+
+// NewServer creates an adapter server backed by the provider.
+func NewServer(config Config, provider *Provider) (*Server, error) {
+	if len(config.TLSCertFile) == 0 || len(config.TLSKeyFile) == 0 {
+		return nil, fmt.Errorf("both the TLS certificate and key files are required")
+	}
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(config.ClientCA) {
+		return nil, fmt.Errorf("no valid client CA certificate is found")
+	}
+	return &Server{
+		config: config,
+		server: &http.Server{
+			Addr:              config.Address,
+			Handler:           NewHandler(provider),
+			ReadHeaderTimeout: 10 * time.Second,
+			TLSConfig: &tls.Config{
+				MinVersion: tls.VersionTLS12,
+				ClientCAs:  clientCAs,
+				// the health checks are served without the client certificates.
+				ClientAuth: tls.VerifyClientCertIfGiven,
+			},
+		},
+	}, nil
+}
+
+// NewHandler builds the HTTP handler of the external metrics API, all the endpoints except the health check
+// require a verified client certificate.
+func NewHandler(provider *Provider) http.Handler {
+	h := &handler{provider: provider}
+	mux := http.NewServeMux()
+	mux.HandleFunc(healthzPath, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.Handle(apisPath, requireClientCert(http.HandlerFunc(h.serveAPIGroupList)))
+	mux.Handle(groupPath, requireClientCert(http.HandlerFunc(h.serveAPIGroup)))
+	mux.Handle(versionPath, requireClientCert(http.HandlerFunc(h.serveAPIResourceList)))
+	mux.Handle(namespacePath, requireClientCert(http.HandlerFunc(h.serveExternalMetric)))
+	return mux
+}
+
+// requireClientCert rejects the requests without a client certificate verified by the client CA.
+func requireClientCert(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.TLS == nil || len(req.TLS.VerifiedChains) == 0 {
+			writeStatus(w, apierrors.NewUnauthorized("a verified client certificate is required"))
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+type handler struct {
+	provider *Provider
+}
+
+func (h *handler) groupVersion() metav1.GroupVersionForDiscovery {
+	return metav1.GroupVersionForDiscovery{GroupVersion: GroupName + "/" + Version, Version: Version}
+}
+
+func (h *handler) apiGroup() metav1.APIGroup {
+	return metav1.APIGroup{
+		TypeMeta:         metav1.TypeMeta{Kind: "APIGroup", APIVersion: "v1"},
+		Name:             GroupName,
+		Versions:         []metav1.GroupVersionForDiscovery{h.groupVersion()},
+		PreferredVersion: h.groupVersion(),
+	}
+}
+
+func (h *handler) serveAPIGroupList(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, metav1.APIGroupList{
+		TypeMeta: metav1.TypeMeta{Kind: "APIGroupList", APIVersion: "v1"},
+		Groups:   []metav1.APIGroup{h.apiGroup()},
+	})
+}
+
+func (h *handler) serveAPIGroup(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, h.apiGroup())
+}
+
+func (h *handler) serveAPIResourceList(w http.ResponseWriter, _ *http.Request) {
+	resources := make([]metav1.APIResource, 0)
+	for _, name := range h.provider.MetricNames() {
+		resources = append(resources, metav1.APIResource{
+			Name:       name,
+			Namespaced: true,
+			Kind:       "ExternalMetricValueList",
+			Verbs:      metav1.Verbs{"get"},
+		})
+	}
+	writeJSON(w, http.StatusOK, metav1.APIResourceList{
+		TypeMeta:     metav1.TypeMeta{Kind: "APIResourceList", APIVersion: "v1"},
+		GroupVersion: GroupName + "/" + Version,
+		APIResources: resources,
+	})
+}
+
+// serveExternalMetric serves the requests of "/apis/external.metrics.k8s.io/v1beta1/namespaces/{namespace}/{metric}".
+func (h *handler) serveExternalMetric(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		writeStatus(w, apierrors.NewMethodNotSupported(externalMetricsResource(""), req.Method))
+		return
+	}
+	segments := strings.Split(strings.TrimPrefix(req.URL.Path, namespacePath), "/")
+	if len(segments) != 2 || len(segments[0]) == 0 || len(segments[1]) == 0 {
+		writeStatus(w, apierrors.NewNotFound(externalMetricsResource(""), req.URL.Path))
+		return
+	}
+	namespace, metricName := segments[0], segments[1]
+	selector, err := labels.Parse(req.URL.Query().Get("labelSelector"))
+	if err != nil {
+		writeStatus(w, apierrors.NewBadRequest(err.Error()))
+		return
+	}
+	values, err := h.provider.GetExternalMetric(req.Context(), namespace, metricName, selector)
+	if err != nil {
+		writeStatus(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, ExternalMetricValueList{
+		TypeMeta: metav1.TypeMeta{Kind: "ExternalMetricValueList", APIVersion: GroupName + "/" + Version},
+		Items:    values,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, obj any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(obj)
+}
+
+// writeStatus writes the error as a metav1.Status, which is the error format of the Kubernetes APIs.
+func writeStatus(w http.ResponseWriter, err error) {
+	var apiStatus apierrors.APIStatus
+	if !errors.As(err, &apiStatus) {
+		apiStatus = apierrors.NewInternalError(err)
+	}
+	status := apiStatus.Status()
+	status.TypeMeta = metav1.TypeMeta{Kind: "Status", APIVersion: "v1"}
+	writeJSON(w, int(status.Code), status)
+}
+
+// StartNonBlocking starts the adapter server in a goroutine.
+func (s *Server) StartNonBlocking() error {
+	go func() {
+		logger.Info("starting the metrics adapter server", "address", s.config.Address)
+		err := s.server.ListenAndServeTLS(s.config.TLSCertFile, s.config.TLSKeyFile)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error(err, "metrics adapter server exited")
+		}
+	}()
+	return nil
+}
+
+// Shutdown gracefully shuts down the adapter server.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
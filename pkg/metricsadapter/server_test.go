@@ -0,0 +1,171 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metricsadapter
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/apecloud/kubeblocks/pkg/common"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+// staticScraper returns the metrics in the Prometheus text format keyed by the pod name.
+type staticScraper map[string]string
+
+func (s staticScraper) Scrape(_ context.Context, pod *corev1.Pod, _ ScrapeTarget) (map[string]*dto.MetricFamily, error) {
+	parser := expfmt.TextParser{}
+	return parser.TextToMetricFamilies(strings.NewReader(s[pod.Name]))
+}
+
+func newTestHandler(t *testing.T, scraper Scraper) http.Handler {
+	newPod := func(name string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+				Labels: map[string]string{
+					constant.AppManagedByLabelKey:   constant.AppName,
+					constant.AppInstanceLabelKey:    "mycluster",
+					constant.KBAppComponentLabelKey: "mysql",
+				},
+			},
+			Status: corev1.PodStatus{PodIP: "10.0.0.1"},
+		}
+	}
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      constant.GenerateComponentHeadlessServiceName("mycluster", "mysql", ""),
+			Annotations: map[string]string{
+				common.PrometheusScrapeAnnotationEnabled: "true",
+				common.PrometheusScrapeAnnotationPort:    "9104",
+			},
+		},
+	}
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newPod("mycluster-mysql-0"), newPod("mycluster-mysql-1"), svc).Build()
+	rules := &Rules{Metrics: []MetricRule{
+		{Name: "mysql-connections", Series: "mysql_global_status_threads_connected"},
+		{Name: "mysql-max-lag", Series: "mysql_slave_status_seconds_behind_master", Aggregation: MaxAggregation},
+	}}
+	if err := rules.validate(); err != nil {
+		t.Fatal(err)
+	}
+	return NewHandler(NewProvider(cli, scraper, rules))
+}
+
+func doRequest(handler http.Handler, path string, verified bool) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if verified {
+		req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{}}}}
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestExternalMetrics(t *testing.T) {
+	handler := newTestHandler(t, staticScraper{
+		"mycluster-mysql-0": "mysql_global_status_threads_connected 10\nmysql_slave_status_seconds_behind_master 0\n",
+		"mycluster-mysql-1": "mysql_global_status_threads_connected 5\nmysql_slave_status_seconds_behind_master 3\n",
+	})
+	if rec := doRequest(handler, "/healthz", false); rec.Code != http.StatusOK {
+		t.Errorf("expected health check passed without client certificate, but got %d", rec.Code)
+	}
+	if rec := doRequest(handler, versionPath, false); rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected unauthorized without client certificate, but got %d", rec.Code)
+	}
+
+	rec := doRequest(handler, versionPath, true)
+	resources := &metav1.APIResourceList{}
+	if err := json.Unmarshal(rec.Body.Bytes(), resources); err != nil {
+		t.Fatal(err)
+	}
+	if len(resources.APIResources) != 2 || resources.APIResources[0].Name != "mysql-connections" {
+		t.Errorf("unexpected resources: %v", resources.APIResources)
+	}
+
+	for metric, expected := range map[string]int64{"mysql-connections": 15, "mysql-max-lag": 3} {
+		rec = doRequest(handler, namespacePath+"default/"+metric+"?labelSelector=app.kubernetes.io/instance%3Dmycluster", true)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected metric %s served, but got %d: %s", metric, rec.Code, rec.Body.String())
+		}
+		list := &ExternalMetricValueList{}
+		if err := json.Unmarshal(rec.Body.Bytes(), list); err != nil {
+			t.Fatal(err)
+		}
+		if len(list.Items) != 1 || list.Items[0].Value.Value() != expected {
+			t.Errorf("unexpected values of metric %s: %v", metric, list.Items)
+		}
+		if list.Items[0].MetricLabels[constant.KBAppComponentLabelKey] != "mysql" {
+			t.Errorf("unexpected labels of metric %s: %v", metric, list.Items[0].MetricLabels)
+		}
+	}
+
+	if rec = doRequest(handler, namespacePath+"default/unknown", true); rec.Code != http.StatusNotFound {
+		t.Errorf("expected not found for unknown metric, but got %d", rec.Code)
+	}
+}
+
+func TestCounterRate(t *testing.T) {
+	p := NewProvider(nil, nil, &Rules{})
+	now := time.Now()
+	if _, ok := p.rate("qps", 100, now); ok {
+		t.Errorf("expected no rate for the first sample")
+	}
+	if rate, ok := p.rate("qps", 400, now.Add(10*time.Second)); !ok || rate != 30 {
+		t.Errorf("expected rate 30, but got %v", rate)
+	}
+	if _, ok := p.rate("qps", 10, now.Add(20*time.Second)); ok {
+		t.Errorf("expected no rate after the counter is reset")
+	}
+}
+
+func TestLoadRulesValidation(t *testing.T) {
+	for _, rules := range []Rules{
+		{Metrics: []MetricRule{{Name: "Invalid_Name", Series: "up"}}},
+		{Metrics: []MetricRule{{Name: "up", Series: "up"}, {Name: "up", Series: "up"}}},
+		{Metrics: []MetricRule{{Name: "up"}}},
+		{Metrics: []MetricRule{{Name: "up", Series: "up", Aggregation: "p99"}}},
+	} {
+		if err := rules.validate(); err == nil {
+			t.Errorf("expected invalid rules: %v", rules)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package ops provides a harness to unit test the ops handlers without envtest.
+//
+// The harness runs the OpsRequests through the OpsManager against a fake client,
+// it is useful for the addon and integration developers to verify how their
+// clusters are operated, e.g.
+//
+//	h, _ := testops.NewHarness("default", cluster, its, pods...)
+//	_ = h.CreateOpsRequest(ops)
+//	phase, _ := h.Run(ops.Name, 10)
+//	_ = h.ExpectProgressTransitions(ops.Name, "Pod/mycluster-mysql-0", appsv1alpha1.ProcessingProgressStatus, appsv1alpha1.SucceedProgressStatus)
+package ops
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	appsv1beta1 "github.com/apecloud/kubeblocks/apis/apps/v1beta1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/controllers/apps/operations"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// Harness drives the OpsRequests through the OpsManager with a fake client.
+type Harness struct {
+	Ctx       context.Context
+	Cli       client.Client
+	Scheme    *runtime.Scheme
+	Recorder  *record.FakeRecorder
+	Namespace string
+
+	// progress records the transitions of the progress details of the OpsRequests, keyed by the OpsRequest name.
+	progress map[string]*progressHistory
+}
+
+// NewHarness creates a harness in the namespace, which is initialized with the objects.
+func NewHarness(namespace string, objs ...client.Object) (*Harness, error) {
+	scheme := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{
+		clientgoscheme.AddToScheme,
+		appsv1alpha1.AddToScheme,
+		appsv1beta1.AddToScheme,
+		dpv1alpha1.AddToScheme,
+		workloads.AddToScheme,
+	} {
+		if err := addToScheme(scheme); err != nil {
+			return nil, err
+		}
+	}
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&appsv1alpha1.Cluster{}, &appsv1alpha1.Component{}, &appsv1alpha1.OpsRequest{},
+			&workloads.InstanceSet{}, &corev1.Pod{}).
+		WithObjects(objs...).
+		Build()
+	return &Harness{
+		Ctx:       context.Background(),
+		Cli:       cli,
+		Scheme:    scheme,
+		Recorder:  record.NewFakeRecorder(100),
+		Namespace: namespace,
+		progress:  map[string]*progressHistory{},
+	}, nil
+}
+
+// Create creates the objects with the fake client.
+func (h *Harness) Create(objs ...client.Object) error {
+	for _, obj := range objs {
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(h.Namespace)
+		}
+		if err := h.Cli.Create(h.Ctx, obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateOpsRequest creates the OpsRequest, it is processed by the following Step or Run calls.
+func (h *Harness) CreateOpsRequest(ops *appsv1alpha1.OpsRequest) error {
+	if err := h.Create(ops); err != nil {
+		return err
+	}
+	h.progress[ops.Name] = newProgressHistory()
+	return nil
+}
+
+// GetOpsRequest gets the latest OpsRequest.
+func (h *Harness) GetOpsRequest(opsName string) (*appsv1alpha1.OpsRequest, error) {
+	ops := &appsv1alpha1.OpsRequest{}
+	if err := h.Cli.Get(h.Ctx, client.ObjectKey{Namespace: h.Namespace, Name: opsName}, ops); err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
+
+// UpdateStatus mutates the status of the object with the fake client, e.g. to mock the InstanceSet
+// or the pods to be updated by the workload controllers.
+func (h *Harness) UpdateStatus(obj client.Object, mutate func()) error {
+	if err := h.Cli.Get(h.Ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		return err
+	}
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	mutate()
+	return h.Cli.Status().Patch(h.Ctx, obj, patch)
+}
+
+// NewOpsResource builds the OpsResource of the OpsRequest with the latest OpsRequest and Cluster.
+func (h *Harness) NewOpsResource(opsName string) (*operations.OpsResource, error) {
+	ops, err := h.GetOpsRequest(opsName)
+	if err != nil {
+		return nil, err
+	}
+	cluster := &appsv1alpha1.Cluster{}
+	if err = h.Cli.Get(h.Ctx, client.ObjectKey{Namespace: h.Namespace, Name: ops.Spec.GetClusterName()}, cluster); err != nil {
+		return nil, err
+	}
+	return &operations.OpsResource{
+		OpsRequest: ops,
+		Cluster:    cluster,
+		Recorder:   h.Recorder,
+	}, nil
+}
+
+func (h *Harness) reqCtx() intctrlutil.RequestCtx {
+	return intctrlutil.RequestCtx{
+		Ctx:      h.Ctx,
+		Log:      log.FromContext(h.Ctx),
+		Recorder: h.Recorder,
+	}
+}
+
+// Do calls OpsManager.Do for the OpsRequest, as the controller does for the Pending and Creating OpsRequests.
+func (h *Harness) Do(opsName string) error {
+	opsRes, err := h.NewOpsResource(opsName)
+	if err != nil {
+		return err
+	}
+	if _, err = operations.GetOpsManager().Do(h.reqCtx(), h.Cli, opsRes); err != nil {
+		return err
+	}
+	return h.recordProgress(opsName)
+}
+
+// Reconcile calls OpsManager.Reconcile for the OpsRequest, as the controller does for the Running OpsRequests.
+func (h *Harness) Reconcile(opsName string) (time.Duration, error) {
+	opsRes, err := h.NewOpsResource(opsName)
+	if err != nil {
+		return 0, err
+	}
+	requeueAfter, err := operations.GetOpsManager().Reconcile(h.reqCtx(), h.Cli, opsRes)
+	if err != nil {
+		return requeueAfter, err
+	}
+	return requeueAfter, h.recordProgress(opsName)
+}
+
+// Step processes the OpsRequest once by its phase, and returns the phase after it.
+func (h *Harness) Step(opsName string) (appsv1alpha1.OpsPhase, error) {
+	opsRes, err := h.NewOpsResource(opsName)
+	if err != nil {
+		return "", err
+	}
+	switch opsRes.OpsRequest.Status.Phase {
+	case "":
+		err = operations.PatchOpsStatus(h.Ctx, h.Cli, opsRes, appsv1alpha1.OpsPendingPhase,
+			appsv1alpha1.NewWaitForProcessingCondition(opsRes.OpsRequest))
+	case appsv1alpha1.OpsPendingPhase, appsv1alpha1.OpsPendingApprovalPhase, appsv1alpha1.OpsScheduledPhase, appsv1alpha1.OpsCreatingPhase:
+		err = h.Do(opsName)
+	case appsv1alpha1.OpsRunningPhase, appsv1alpha1.OpsWaitingForConfirmPhase, appsv1alpha1.OpsCancellingPhase:
+		_, err = h.Reconcile(opsName)
+	}
+	if err != nil {
+		return "", err
+	}
+	ops, err := h.GetOpsRequest(opsName)
+	if err != nil {
+		return "", err
+	}
+	return ops.Status.Phase, nil
+}
+
+// Run steps the OpsRequest until it is completed or the max rounds are reached, and returns its last phase.
+// The mutate functions are called after each step, e.g. to mock the workloads to be updated.
+func (h *Harness) Run(opsName string, maxRounds int, mutates ...func(phase appsv1alpha1.OpsPhase) error) (appsv1alpha1.OpsPhase, error) {
+	var phase appsv1alpha1.OpsPhase
+	for i := 0; i < maxRounds; i++ {
+		var err error
+		if phase, err = h.Step(opsName); err != nil {
+			return phase, err
+		}
+		ops := &appsv1alpha1.OpsRequest{Status: appsv1alpha1.OpsRequestStatus{Phase: phase}}
+		if ops.IsComplete() {
+			return phase, nil
+		}
+		for _, mutate := range mutates {
+			if err = mutate(phase); err != nil {
+				return phase, err
+			}
+		}
+	}
+	return phase, fmt.Errorf("the OpsRequest %s is not completed in %d rounds, the phase is %s", opsName, maxRounds, phase)
+}
@@ -0,0 +1,162 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package ops
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+	testk8s "github.com/apecloud/kubeblocks/pkg/testutil/k8s"
+)
+
+// NewRunningCluster builds a running cluster with the components of the component definition.
+func NewRunningCluster(namespace, name, compDefName string, replicas int32, compNames ...string) *appsv1alpha1.Cluster {
+	factory := testapps.NewClusterFactory(namespace, name, "")
+	for _, compName := range compNames {
+		factory.AddComponent(compName, compDefName).SetReplicas(replicas)
+	}
+	cluster := factory.GetObject()
+	cluster.Generation = 1
+	cluster.Status.ObservedGeneration = 1
+	cluster.Status.Phase = appsv1alpha1.RunningClusterPhase
+	cluster.Status.Components = map[string]appsv1alpha1.ClusterComponentStatus{}
+	for _, compName := range compNames {
+		cluster.Status.Components[compName] = appsv1alpha1.ClusterComponentStatus{
+			Phase: appsv1alpha1.RunningClusterCompPhase,
+		}
+	}
+	return cluster
+}
+
+// NewRunningComponent builds the running component object of the cluster component.
+func NewRunningComponent(cluster *appsv1alpha1.Cluster, compName string) *appsv1alpha1.Component {
+	var compDefName string
+	var replicas int32
+	if compSpec := cluster.Spec.GetComponentByName(compName); compSpec != nil {
+		compDefName = compSpec.ComponentDef
+		replicas = compSpec.Replicas
+	}
+	comp := testapps.NewComponentFactory(cluster.Namespace, constant.GenerateClusterComponentName(cluster.Name, compName), compDefName).
+		AddAppInstanceLabel(cluster.Name).
+		AddAppComponentLabel(compName).
+		AddAppManagedByLabel().
+		GetObject()
+	comp.Spec.Replicas = replicas
+	comp.Generation = 1
+	comp.Status.ObservedGeneration = 1
+	comp.Status.Phase = appsv1alpha1.RunningClusterCompPhase
+	return comp
+}
+
+// MockInstanceSet builds a ready InstanceSet of the cluster component and its pods. The roles are assigned to the
+// pods in order, the first role is the leader with read-write access, and the others are readonly, e.g.
+// MockInstanceSet(cluster, "mysql", "leader", "follower", "follower") builds three pods.
+// The pods are built without roles if no role is specified.
+func MockInstanceSet(cluster *appsv1alpha1.Cluster, compName string, roles ...string) (*workloads.InstanceSet, []*corev1.Pod) {
+	replicas := int32(len(roles))
+	if replicas == 0 {
+		if compSpec := cluster.Spec.GetComponentByName(compName); compSpec != nil {
+			replicas = compSpec.Replicas
+		}
+	}
+	var replicaRoles []workloads.ReplicaRole
+	for i, role := range roles {
+		if i == 0 {
+			replicaRoles = append(replicaRoles, workloads.ReplicaRole{Name: role, AccessMode: workloads.ReadWriteMode, CanVote: true, IsLeader: true})
+			continue
+		}
+		if !containsRole(replicaRoles, role) {
+			replicaRoles = append(replicaRoles, workloads.ReplicaRole{Name: role, AccessMode: workloads.ReadonlyMode, CanVote: true})
+		}
+	}
+	itsName := constant.GenerateClusterComponentName(cluster.Name, compName)
+	its := testapps.NewInstanceSetFactory(cluster.Namespace, itsName, cluster.Name, compName).
+		SetReplicas(replicas).
+		AddContainer(corev1.Container{Name: testapps.DefaultMySQLContainerName, Image: testapps.ApeCloudMySQLImage}).
+		SetRoles(replicaRoles).
+		GetObject()
+	its.Generation = 1
+	its.Status.UpdateRevision = fmt.Sprintf("%s-%d", itsName, its.Generation)
+
+	pods := make([]*corev1.Pod, replicas)
+	for i := range pods {
+		var role, accessMode string
+		if i < len(roles) {
+			role = roles[i]
+			accessMode = string(workloads.ReadonlyMode)
+			if i == 0 {
+				accessMode = string(workloads.ReadWriteMode)
+			}
+		}
+		pods[i] = mockPod(its, cluster.Name, compName, fmt.Sprintf("%s-%d", itsName, i), role, accessMode)
+	}
+	testk8s.MockInstanceSetReady(its, pods...)
+	return its, pods
+}
+
+func containsRole(roles []workloads.ReplicaRole, name string) bool {
+	for _, role := range roles {
+		if role.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func mockPod(its *workloads.InstanceSet, clusterName, compName, podName, role, accessMode string) *corev1.Pod {
+	factory := testapps.NewPodFactory(its.Namespace, podName).
+		SetOwnerReferences(workloads.GroupVersion.String(), workloads.Kind, its).
+		AddAppInstanceLabel(clusterName).
+		AddAppComponentLabel(compName).
+		AddAppManagedByLabel().
+		AddControllerRevisionHashLabel(its.Status.UpdateRevision).
+		AddLabelsInMap(map[string]string{
+			"workloads.kubeblocks.io/managed-by": workloads.Kind,
+			"workloads.kubeblocks.io/instance":   its.Name,
+		}).
+		AddContainer(corev1.Container{Name: testapps.DefaultMySQLContainerName, Image: testapps.ApeCloudMySQLImage})
+	if role != "" {
+		factory.AddRoleLabel(role).AddAccessModeLabel(accessMode)
+	}
+	pod := factory.GetObject()
+	pod.Status.Phase = corev1.PodRunning
+	pod.Status.Conditions = []corev1.PodCondition{
+		{
+			Type:               corev1.PodReady,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.Now(),
+		},
+	}
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+		{
+			Name:  testapps.DefaultMySQLContainerName,
+			Image: testapps.ApeCloudMySQLImage,
+			Ready: true,
+			State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+		},
+	}
+	return pod
+}
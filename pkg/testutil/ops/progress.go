@@ -0,0 +1,88 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package ops
+
+import (
+	"fmt"
+	"reflect"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+// progressHistory records the distinct statuses which the progress details have gone through,
+// keyed by the object key of the progress details.
+type progressHistory struct {
+	transitions map[string][]appsv1alpha1.ProgressStatus
+}
+
+func newProgressHistory() *progressHistory {
+	return &progressHistory{transitions: map[string][]appsv1alpha1.ProgressStatus{}}
+}
+
+func (p *progressHistory) record(ops *appsv1alpha1.OpsRequest) {
+	for _, compStatus := range ops.Status.Components {
+		for _, detail := range compStatus.ProgressDetails {
+			key := detail.ObjectKey
+			if key == "" {
+				key = detail.Group
+			}
+			statuses := p.transitions[key]
+			if len(statuses) > 0 && statuses[len(statuses)-1] == detail.Status {
+				continue
+			}
+			p.transitions[key] = append(statuses, detail.Status)
+		}
+	}
+}
+
+func (h *Harness) recordProgress(opsName string) error {
+	ops, err := h.GetOpsRequest(opsName)
+	if err != nil {
+		return err
+	}
+	history, ok := h.progress[opsName]
+	if !ok {
+		history = newProgressHistory()
+		h.progress[opsName] = history
+	}
+	history.record(ops)
+	return nil
+}
+
+// ProgressTransitions returns the statuses which the progress details of the OpsRequest have gone through,
+// keyed by the object key of the progress details, e.g. "Pod/mycluster-mysql-0".
+// The progress details are recorded after each Do and Reconcile call.
+func (h *Harness) ProgressTransitions(opsName string) map[string][]appsv1alpha1.ProgressStatus {
+	history, ok := h.progress[opsName]
+	if !ok {
+		return nil
+	}
+	return history.transitions
+}
+
+// ExpectProgressTransitions checks whether the progress detail of the object has gone through the statuses in order.
+func (h *Harness) ExpectProgressTransitions(opsName, objectKey string, statuses ...appsv1alpha1.ProgressStatus) error {
+	actual := h.ProgressTransitions(opsName)[objectKey]
+	if !reflect.DeepEqual(actual, statuses) {
+		return fmt.Errorf("the progress detail %s of the OpsRequest %s went through %v, expected %v",
+			objectKey, opsName, actual, statuses)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package render
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// UpdateGoldenEnv is the environment variable to write the golden files instead of comparing with them.
+const UpdateGoldenEnv = "UPDATE_GOLDEN"
+
+// AssertGolden compares the rendered data with the golden file, or writes the golden file if the
+// environment variable UPDATE_GOLDEN is "true".
+func AssertGolden(t testing.TB, path string, actual []byte) {
+	t.Helper()
+	if os.Getenv(UpdateGoldenEnv) == "true" {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create the directory of golden file %s: %v", path, err)
+		}
+		if err := os.WriteFile(path, actual, 0644); err != nil {
+			t.Fatalf("failed to write golden file %s: %v", path, err)
+		}
+		return
+	}
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file %s: %v, run with %s=true to write it", path, err, UpdateGoldenEnv)
+	}
+	if bytes.Equal(expected, actual) {
+		return
+	}
+	expectedLines, actualLines := strings.Split(string(expected), "\n"), strings.Split(string(actual), "\n")
+	for i := 0; i < len(expectedLines) || i < len(actualLines); i++ {
+		var expectedLine, actualLine string
+		if i < len(expectedLines) {
+			expectedLine = expectedLines[i]
+		}
+		if i < len(actualLines) {
+			actualLine = actualLines[i]
+		}
+		if expectedLine != actualLine {
+			t.Errorf("the rendered objects differ from golden file %s at line %d:\n  expected: %s\n  actual:   %s\nrun with %s=true to update it",
+				path, i+1, expectedLine, actualLine, UpdateGoldenEnv)
+			return
+		}
+	}
+}
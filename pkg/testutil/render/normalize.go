@@ -0,0 +1,118 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package render
+
+import (
+	"bytes"
+	"encoding/base64"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// StubUID replaces the UIDs of the objects and their owners.
+	StubUID = "00000000-0000-0000-0000-000000000000"
+	// StubTime replaces the timestamps in the objects.
+	StubTime = "1970-01-01T00:00:00Z"
+)
+
+// StubPassword replaces the generated passwords in the Secrets, which is encoded in base64.
+var StubPassword = base64.StdEncoding.EncodeToString([]byte("password"))
+
+var timestampPattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})$`)
+
+// Normalize converts the object into unstructured, and removes the nondeterministic fields of it:
+// the status and the server-populated metadata are dropped, the UIDs, timestamps and passwords are stubbed.
+func Normalize(scheme *runtime.Scheme, obj client.Object) (*unstructured.Unstructured, error) {
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return nil, err
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	u := &unstructured.Unstructured{Object: content}
+	u.SetGroupVersionKind(gvk)
+	unstructured.RemoveNestedField(u.Object, "status")
+	for _, field := range []string{"creationTimestamp", "resourceVersion", "generation", "managedFields", "selfLink"} {
+		unstructured.RemoveNestedField(u.Object, "metadata", field)
+	}
+	if u.GetUID() != "" {
+		u.SetUID(StubUID)
+	}
+	ownerRefs := u.GetOwnerReferences()
+	for i := range ownerRefs {
+		ownerRefs[i].UID = StubUID
+	}
+	if len(ownerRefs) > 0 {
+		u.SetOwnerReferences(ownerRefs)
+	}
+	if gvk.Kind == "Secret" {
+		if data, ok := u.Object["data"].(map[string]interface{}); ok {
+			for key := range data {
+				if strings.Contains(strings.ToLower(key), "password") {
+					data[key] = StubPassword
+				}
+			}
+		}
+	}
+	u.Object = stubTimestamps(u.Object).(map[string]interface{})
+	return u, nil
+}
+
+// stubTimestamps replaces all the string values which are timestamps.
+func stubTimestamps(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = stubTimestamps(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = stubTimestamps(item)
+		}
+	case string:
+		if timestampPattern.MatchString(v) {
+			return StubTime
+		}
+	}
+	return value
+}
+
+// ToYAML marshals the objects into a multi-document YAML, the keys are sorted.
+func ToYAML(objs []*unstructured.Unstructured) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, obj := range objs {
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteString("---\n")
+		buf.Write(data)
+	}
+	return buf.Bytes(), nil
+}
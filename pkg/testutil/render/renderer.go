@@ -0,0 +1,248 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package render renders the child objects of a Cluster into deterministic YAML snapshots without envtest.
+//
+// The Cluster is reconciled by the cluster, component and InstanceSet controllers against a fake client
+// until the objects are stable, and the objects are normalized then: the UIDs, timestamps and generated
+// passwords are replaced by stubs, and the status is dropped. It is useful for the addon developers to
+// maintain the golden-file tests, which verify that the objects generated for their component definitions
+// are stable across the KubeBlocks releases, e.g.
+//
+//	objs, _ := render.NewRenderer(compDef, cluster).Render(ctx)
+//	data, _ := render.ToYAML(objs)
+//	render.AssertGolden(t, "testdata/mysql.golden.yaml", data)
+//
+// Run the tests with the environment variable UPDATE_GOLDEN=true to write the golden files.
+package render
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	appsv1beta1 "github.com/apecloud/kubeblocks/apis/apps/v1beta1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	appsctrl "github.com/apecloud/kubeblocks/controllers/apps"
+	workloadsctrl "github.com/apecloud/kubeblocks/controllers/workloads"
+)
+
+const defaultMaxRounds = 10
+
+// renderedObjectLists are the kinds of the child objects rendered.
+var renderedObjectLists = []func() client.ObjectList{
+	func() client.ObjectList { return &appsv1alpha1.ComponentList{} },
+	func() client.ObjectList { return &workloads.InstanceSetList{} },
+	func() client.ObjectList { return &corev1.PodList{} },
+	func() client.ObjectList { return &corev1.PersistentVolumeClaimList{} },
+	func() client.ObjectList { return &corev1.ServiceList{} },
+	func() client.ObjectList { return &corev1.ConfigMapList{} },
+	func() client.ObjectList { return &corev1.SecretList{} },
+	func() client.ObjectList { return &corev1.ServiceAccountList{} },
+	func() client.ObjectList { return &rbacv1.RoleBindingList{} },
+	func() client.ObjectList { return &policyv1.PodDisruptionBudgetList{} },
+	func() client.ObjectList { return &dpv1alpha1.BackupPolicyList{} },
+	func() client.ObjectList { return &dpv1alpha1.BackupScheduleList{} },
+}
+
+// Renderer renders the child objects of the Clusters in its objects.
+type Renderer struct {
+	// MaxRounds is the max rounds to reconcile the objects until they are stable, defaults to 10.
+	MaxRounds int
+
+	objs []client.Object
+}
+
+// NewRenderer creates a renderer with the objects, which are the Clusters and the definitions they refer to,
+// e.g. ClusterDefinitions, ComponentDefinitions and ComponentVersions. The definitions are taken as available.
+func NewRenderer(objs ...client.Object) *Renderer {
+	return &Renderer{
+		MaxRounds: defaultMaxRounds,
+		objs:      objs,
+	}
+}
+
+// Render reconciles the Clusters until the child objects are stable, and returns the normalized child objects
+// which are sorted by the kind, namespace and name.
+func (r *Renderer) Render(ctx context.Context) ([]*unstructured.Unstructured, error) {
+	scheme, err := newScheme()
+	if err != nil {
+		return nil, err
+	}
+	objs := make([]client.Object, 0, len(r.objs))
+	inputs := map[string]bool{}
+	for _, obj := range r.objs {
+		obj = obj.DeepCopyObject().(client.Object)
+		markAvailable(obj)
+		objs = append(objs, obj)
+		key, err := objectKey(scheme, obj)
+		if err != nil {
+			return nil, err
+		}
+		inputs[key] = true
+	}
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&appsv1alpha1.Cluster{}, &appsv1alpha1.Component{}, &workloads.InstanceSet{}, &corev1.Pod{}).
+		WithObjects(objs...).
+		Build()
+	// the events are dropped
+	recorder := &record.FakeRecorder{}
+	reconcilers := []struct {
+		newList    func() client.ObjectList
+		reconciler reconcile.Reconciler
+	}{
+		{func() client.ObjectList { return &appsv1alpha1.ClusterList{} }, &appsctrl.ClusterReconciler{Client: cli, Scheme: scheme, Recorder: recorder}},
+		{func() client.ObjectList { return &appsv1alpha1.ComponentList{} }, &appsctrl.ComponentReconciler{Client: cli, Scheme: scheme, Recorder: recorder}},
+		{func() client.ObjectList { return &workloads.InstanceSetList{} }, &workloadsctrl.InstanceSetReconciler{Client: cli, Scheme: scheme, Recorder: recorder}},
+	}
+
+	var (
+		lastSnapshot []byte
+		lastErr      error
+	)
+	for i := 0; i < r.MaxRounds; i++ {
+		lastErr = nil
+		for _, v := range reconcilers {
+			list := v.newList()
+			if err = cli.List(ctx, list); err != nil {
+				return nil, err
+			}
+			items, err := meta.ExtractList(list)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range items {
+				req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(item.(client.Object))}
+				if _, err = v.reconciler.Reconcile(ctx, req); err != nil {
+					lastErr = err
+				}
+			}
+		}
+		rendered, err := collect(ctx, cli, scheme, inputs)
+		if err != nil {
+			return nil, err
+		}
+		snapshot, err := ToYAML(rendered)
+		if err != nil {
+			return nil, err
+		}
+		if lastErr == nil && bytes.Equal(snapshot, lastSnapshot) {
+			return rendered, nil
+		}
+		lastSnapshot = snapshot
+	}
+	if lastErr != nil {
+		return nil, lastErr
+	}
+	return nil, fmt.Errorf("the rendered objects are not stable in %d rounds", r.MaxRounds)
+}
+
+// collect lists the child objects, and normalizes them.
+func collect(ctx context.Context, cli client.Client, scheme *runtime.Scheme, inputs map[string]bool) ([]*unstructured.Unstructured, error) {
+	var rendered []*unstructured.Unstructured
+	for _, newList := range renderedObjectLists {
+		list := newList()
+		if err := cli.List(ctx, list); err != nil {
+			return nil, err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			obj := item.(client.Object)
+			key, err := objectKey(scheme, obj)
+			if err != nil {
+				return nil, err
+			}
+			if inputs[key] {
+				continue
+			}
+			u, err := Normalize(scheme, obj)
+			if err != nil {
+				return nil, err
+			}
+			rendered = append(rendered, u)
+		}
+	}
+	sort.SliceStable(rendered, func(i, j int) bool {
+		return unstructuredKey(rendered[i]) < unstructuredKey(rendered[j])
+	})
+	return rendered, nil
+}
+
+// markAvailable marks the definitions available, which is done by their controllers.
+func markAvailable(obj client.Object) {
+	switch def := obj.(type) {
+	case *appsv1alpha1.ClusterDefinition:
+		def.Status.Phase = appsv1alpha1.AvailablePhase
+		def.Status.ObservedGeneration = def.Generation
+	case *appsv1alpha1.ComponentDefinition:
+		def.Status.Phase = appsv1alpha1.AvailablePhase
+		def.Status.ObservedGeneration = def.Generation
+	case *appsv1alpha1.ComponentVersion:
+		def.Status.Phase = appsv1alpha1.AvailablePhase
+		def.Status.ObservedGeneration = def.Generation
+	}
+}
+
+func newScheme() (*runtime.Scheme, error) {
+	scheme := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{
+		clientgoscheme.AddToScheme,
+		appsv1alpha1.AddToScheme,
+		appsv1beta1.AddToScheme,
+		dpv1alpha1.AddToScheme,
+		workloads.AddToScheme,
+	} {
+		if err := addToScheme(scheme); err != nil {
+			return nil, err
+		}
+	}
+	return scheme, nil
+}
+
+func objectKey(scheme *runtime.Scheme, obj client.Object) (string, error) {
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/%s/%s", gvk.GroupKind().String(), obj.GetNamespace(), obj.GetName()), nil
+}
+
+func unstructuredKey(u *unstructured.Unstructured) string {
+	return fmt.Sprintf("%s/%s/%s/%s", u.GetAPIVersion(), u.GetKind(), u.GetNamespace(), u.GetName())
+}
@@ -58,10 +58,29 @@ func (v *viperWrap) GetAllParameters() map[string]interface{} {
 }
 
 func (v *viperWrap) RemoveKey(key string) error {
-	// TODO viper does not support remove key
+	// viper does not support removing a key, rebuild the config without it.
+	settings := v.AllSettings()
+	if !removeNestedKey(settings, strings.Split(strings.ToLower(key), keyDelimiter(v.format))) {
+		return nil
+	}
+	cfg := newCfgViper(v.format)
+	if err := cfg.MergeConfigMap(settings); err != nil {
+		return err
+	}
+	v.Viper = cfg
 	return nil
 }
 
+func removeNestedKey(settings map[string]interface{}, path []string) bool {
+	if len(path) == 1 {
+		_, ok := settings[path[0]]
+		delete(settings, path[0])
+		return ok
+	}
+	sub, ok := settings[path[0]].(map[string]interface{})
+	return ok && removeNestedKey(sub, path[1:])
+}
+
 func (v *viperWrap) SubConfig(key string) ConfigObject {
 	return &viperWrap{
 		Viper:  v.Sub(key),
@@ -95,13 +114,16 @@ func (v viperWrap) Unmarshal(str string) error {
 	return v.ReadConfig(bytes.NewReader([]byte(str)))
 }
 
-func newCfgViper(cfgType appsv1beta1.CfgFileFormat) *oviper.Viper {
-	defaultKeySep := DelimiterDot
+func keyDelimiter(cfgType appsv1beta1.CfgFileFormat) string {
 	if cfgType == appsv1beta1.Properties || cfgType == appsv1beta1.Dotenv {
-		defaultKeySep = CfgDelimiterPlaceholder
+		return CfgDelimiterPlaceholder
 	}
+	return DelimiterDot
+}
+
+func newCfgViper(cfgType appsv1beta1.CfgFileFormat) *oviper.Viper {
 	// TODO config constraint support LoadOptions
-	v := oviper.NewWithOptions(oviper.KeyDelimiter(defaultKeySep), oviper.IniLoadOptions(ini.LoadOptions{
+	v := oviper.NewWithOptions(oviper.KeyDelimiter(keyDelimiter(cfgType)), oviper.IniLoadOptions(ini.LoadOptions{
 		SpaceBeforeInlineComment: true,
 		PreserveSurroundedQuote:  true,
 	}))
@@ -58,6 +58,12 @@ port=3306
 	assert.EqualValues(t, subConfigObj.Get("gtid_mode"), "ON")
 	assert.EqualValues(t, subConfigObj.Get("log_error"), "/data/mysql/log/mysqld.err")
 	assert.EqualValues(t, subConfigObj.Get("plugin-load"), "\"rpl_semi_sync_master=semisync_master.so;rpl_semi_sync_slave=semisync_slave.so\"")
+
+	// test remove
+	assert.Nil(t, iniConfigObj.RemoveKey("mysqld.gtid_mode"))
+	assert.Nil(t, iniConfigObj.Get("mysqld.gtid_mode"))
+	assert.EqualValues(t, iniConfigObj.Get("mysqld.port"), "3306")
+	assert.Nil(t, iniConfigObj.RemoveKey("mysqld.not_exist"))
 }
 
 func TestPropertiesFormat1(t *testing.T) {
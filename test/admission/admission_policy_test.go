@@ -0,0 +1,331 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admission
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+	"text/template"
+
+	celgo "github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/admission/plugin/cel"
+	celconfig "k8s.io/apiserver/pkg/apis/cel"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/cel/environment"
+	"sigs.k8s.io/yaml"
+)
+
+const admissionTemplate = "../../deploy/helm/templates/_admission.tpl"
+
+type validation struct {
+	Expression string `json:"expression"`
+	Message    string `json:"message"`
+}
+
+func (v *validation) GetExpression() string {
+	return v.Expression
+}
+
+func (v *validation) ReturnTypes() []*celgo.Type {
+	return []*celgo.Type{celgo.BoolType}
+}
+
+type policy struct {
+	Name        string       `json:"name"`
+	APIGroup    string       `json:"apiGroup"`
+	APIVersion  string       `json:"apiVersion"`
+	Resource    string       `json:"resource"`
+	Validations []validation `json:"validations"`
+}
+
+// renderPolicies renders the policies defined in the helm template, with the template functions it uses.
+func renderPolicies(t *testing.T) map[string]policy {
+	content, err := os.ReadFile(admissionTemplate)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", admissionTemplate, err)
+	}
+	funcs := template.FuncMap{
+		"dict": func(pairs ...string) map[string]string {
+			m := map[string]string{}
+			for i := 0; i+1 < len(pairs); i += 2 {
+				m[pairs[i]] = pairs[i+1]
+			}
+			return m
+		},
+		"fail": func(msg string) (string, error) {
+			return "", fmt.Errorf("%s", msg)
+		},
+	}
+	tpl, err := template.New("admission").Funcs(funcs).Parse(string(content))
+	if err != nil {
+		t.Fatalf("failed to parse %s: %v", admissionTemplate, err)
+	}
+	buf := &bytes.Buffer{}
+	if err = tpl.ExecuteTemplate(buf, "kubeblocks.admissionPolicies", nil); err != nil {
+		t.Fatalf("failed to render the admission policies: %v", err)
+	}
+	rendered := struct {
+		Policies []policy `json:"policies"`
+	}{}
+	if err = yaml.Unmarshal(buf.Bytes(), &rendered); err != nil {
+		t.Fatalf("failed to unmarshal the admission policies: %v", err)
+	}
+	policies := map[string]policy{}
+	for _, p := range rendered.Policies {
+		policies[p.Name] = p
+	}
+	return policies
+}
+
+func compile(t *testing.T, p policy) cel.Filter {
+	accessors := make([]cel.ExpressionAccessor, 0, len(p.Validations))
+	for i := range p.Validations {
+		accessors = append(accessors, &p.Validations[i])
+	}
+	compiler := cel.NewFilterCompiler(environment.MustBaseEnvSet(environment.DefaultCompatibilityVersion()))
+	filter := compiler.Compile(accessors, cel.OptionalVariableDeclarations{HasAuthorizer: true}, environment.StoredExpressions)
+	for _, err := range filter.CompilationErrors() {
+		t.Errorf("policy %s: %v", p.Name, err)
+	}
+	return filter
+}
+
+// evaluate returns the messages of the failed validations of the policy.
+func evaluate(t *testing.T, p policy, obj, oldObj *unstructured.Unstructured) []string {
+	filter := compile(t, p)
+	gvr := schema.GroupVersionResource{Group: p.APIGroup, Version: p.APIVersion, Resource: p.Resource}
+	gvk := obj.GroupVersionKind()
+	operation := admission.Create
+	if oldObj != nil {
+		operation = admission.Update
+	}
+	attr := admission.NewAttributesRecord(obj, oldObj, gvk, obj.GetNamespace(), obj.GetName(), gvr, "", operation,
+		nil, false, &user.DefaultInfo{Name: "alice"})
+	versionedAttr := &admission.VersionedAttributes{Attributes: attr, VersionedKind: gvk, VersionedObject: obj, VersionedOldObject: oldObj}
+	// only alice is allowed to approve the OpsRequests.
+	authz := authorizer.AuthorizerFunc(func(_ context.Context, a authorizer.Attributes) (authorizer.Decision, string, error) {
+		if a.GetVerb() == "approve" && a.GetUser().GetName() == "alice" {
+			return authorizer.DecisionAllow, "", nil
+		}
+		return authorizer.DecisionDeny, "", nil
+	})
+	request := cel.CreateAdmissionRequest(attr,
+		metav1.GroupVersionResource{Group: gvr.Group, Version: gvr.Version, Resource: gvr.Resource},
+		metav1.GroupVersionKind{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind})
+	results, _, err := filter.ForInput(context.Background(), versionedAttr, request,
+		cel.OptionalVariableBindings{Authorizer: authz}, nil, celconfig.RuntimeCELCostBudget)
+	if err != nil {
+		t.Fatalf("policy %s: failed to evaluate: %v", p.Name, err)
+	}
+	var messages []string
+	for _, result := range results {
+		if result.Error != nil {
+			t.Fatalf("policy %s: failed to evaluate %q: %v", p.Name, result.ExpressionAccessor.GetExpression(), result.Error)
+		}
+		if result.EvalResult != types.True {
+			messages = append(messages, result.ExpressionAccessor.(*validation).Message)
+		}
+	}
+	return messages
+}
+
+func newObject(t *testing.T, manifest string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal([]byte(manifest), &obj.Object); err != nil {
+		t.Fatalf("failed to unmarshal the object: %v", err)
+	}
+	return obj
+}
+
+func TestAdmissionPolicies(t *testing.T) {
+	policies := renderPolicies(t)
+	for _, name := range []string{"vcluster", "vopsrequest", "vinstanceset"} {
+		if _, ok := policies[name]; !ok {
+			t.Fatalf("policy %s is not rendered", name)
+		}
+	}
+
+	cases := []struct {
+		name     string
+		policy   string
+		object   string
+		old      string
+		messages []string
+	}{
+		{
+			name:   "valid cluster",
+			policy: "vcluster",
+			object: `
+apiVersion: apps.kubeblocks.io/v1alpha1
+kind: Cluster
+metadata: {name: mycluster, namespace: default}
+spec:
+  componentSpecs: [{name: mysql}]
+  shardingSpecs: [{name: shard}]
+`,
+		},
+		{
+			name:   "cluster without components",
+			policy: "vcluster",
+			object: `
+apiVersion: apps.kubeblocks.io/v1alpha1
+kind: Cluster
+metadata: {name: mycluster, namespace: default}
+spec: {terminationPolicy: Delete}
+`,
+			messages: []string{"either spec.clusterDefinitionRef, spec.componentSpecs or spec.shardingSpecs should be specified"},
+		},
+		{
+			name:   "cluster with duplicated and conflicted shardings",
+			policy: "vcluster",
+			object: `
+apiVersion: apps.kubeblocks.io/v1alpha1
+kind: Cluster
+metadata: {name: mycluster, namespace: default}
+spec:
+  componentSpecs: [{name: shard}]
+  shardingSpecs: [{name: shard}, {name: shard}]
+`,
+			messages: []string{"duplicated sharding spec name", "sharding spec name conflicts with component name"},
+		},
+		{
+			name:   "valid opsrequest",
+			policy: "vopsrequest",
+			object: `
+apiVersion: apps.kubeblocks.io/v1alpha1
+kind: OpsRequest
+metadata: {name: restart, namespace: default}
+spec:
+  clusterName: mycluster
+  type: Restart
+  restart: [{componentName: mysql}]
+`,
+		},
+		{
+			name:   "opsrequest without the spec of its type",
+			policy: "vopsrequest",
+			object: `
+apiVersion: apps.kubeblocks.io/v1alpha1
+kind: OpsRequest
+metadata: {name: hscale, namespace: default}
+spec:
+  type: HorizontalScaling
+`,
+			messages: []string{"spec.clusterName is required", "spec.horizontalScaling is required for HorizontalScaling OpsRequest"},
+		},
+		{
+			name:   "opsrequest approved by the requesting user",
+			policy: "vopsrequest",
+			object: `
+apiVersion: apps.kubeblocks.io/v1alpha1
+kind: OpsRequest
+metadata: {name: upgrade, namespace: default, annotations: {ops.kubeblocks.io/approved-by: alice}}
+spec:
+  clusterName: mycluster
+  type: Upgrade
+  upgrade: {clusterVersionRef: v1}
+`,
+		},
+		{
+			name:   "opsrequest approved on behalf of another user",
+			policy: "vopsrequest",
+			object: `
+apiVersion: apps.kubeblocks.io/v1alpha1
+kind: OpsRequest
+metadata: {name: upgrade, namespace: default, annotations: {ops.kubeblocks.io/approved-by: bob}}
+spec:
+  clusterName: mycluster
+  type: Upgrade
+  upgrade: {clusterVersionRef: v1}
+`,
+			messages: []string{"the ops.kubeblocks.io/approved-by annotation must be set to the name of the requesting user, who is allowed to approve the OpsRequest"},
+		},
+		{
+			name:   "opsrequest keeps the approval on update",
+			policy: "vopsrequest",
+			object: `
+apiVersion: apps.kubeblocks.io/v1alpha1
+kind: OpsRequest
+metadata: {name: upgrade, namespace: default, annotations: {ops.kubeblocks.io/approved-by: bob}}
+spec:
+  clusterName: mycluster
+  type: Upgrade
+  upgrade: {clusterVersionRef: v1}
+  cancel: true
+`,
+			old: `
+apiVersion: apps.kubeblocks.io/v1alpha1
+kind: OpsRequest
+metadata: {name: upgrade, namespace: default, annotations: {ops.kubeblocks.io/approved-by: bob}}
+spec:
+  clusterName: mycluster
+  type: Upgrade
+  upgrade: {clusterVersionRef: v1}
+`,
+		},
+		{
+			name:   "valid instanceset",
+			policy: "vinstanceset",
+			object: `
+apiVersion: workloads.kubeblocks.io/v1alpha1
+kind: InstanceSet
+metadata: {name: mycluster-mysql, namespace: default}
+spec:
+  roles: [{name: leader, isLeader: true}, {name: follower}]
+  instances: [{name: large}, {name: small}]
+`,
+		},
+		{
+			name:   "instanceset with duplicated roles and templates",
+			policy: "vinstanceset",
+			object: `
+apiVersion: workloads.kubeblocks.io/v1alpha1
+kind: InstanceSet
+metadata: {name: mycluster-mysql, namespace: default}
+spec:
+  roles: [{name: leader, isLeader: true}, {name: leader, isLeader: true}]
+  instances: [{name: large}, {name: large}]
+`,
+			messages: []string{"duplicated role name", "at most one role can be the leader", "duplicated instance template name"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var old *unstructured.Unstructured
+			if c.old != "" {
+				old = newObject(t, c.old)
+			}
+			messages := evaluate(t, policies[c.policy], newObject(t, c.object), old)
+			if !reflect.DeepEqual(messages, c.messages) {
+				t.Errorf("expected the failed validations %v, but got %v", c.messages, messages)
+			}
+		})
+	}
+}